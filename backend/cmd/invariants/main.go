@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/config"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/database"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// invariants checks the conversation data of one or every tenant for
+// corruption, see service.InvariantService, and prints the reports as JSON.
+// It reads the server's configuration. Events of repairs are staged in the
+// outbox and published by a running replica.
+//
+// Exit status: 0 when no violation is left, 1 when the check failed, 2 when
+// violations are left unrepaired.
+func main() {
+	tenant := flag.String("tenant", "", "check only this tenant ID")
+	repair := flag.Bool("repair", false, "repair the repairable violations")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		panic("failed to load config: " + err.Error())
+	}
+
+	log, err := logger.New(cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		panic("failed to create logger: " + err.Error())
+	}
+	defer log.Sync()
+
+	os.Exit(run(cfg, log, *tenant, *repair))
+}
+
+func run(cfg *config.Config, log *logger.Logger, tenant string, repair bool) int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := database.NewPoolWithRetry(&cfg.Database, log)
+	if err != nil {
+		log.Error("Failed to connect to database", zap.Error(err))
+		return 1
+	}
+	defer pool.Close()
+
+	repos := repository.NewRepositoryContainer(pool)
+	events := service.NewEventOutbox(repos, service.NewMultiPublisher(), service.DefaultOutboxConfig(), log)
+	invariants := service.NewInvariantService(repos, pool, events.StagedOnly(), service.NewAuditService(repos, log), log)
+
+	var reports []*domain.InvariantReport
+	if tenant != "" {
+		tenantID, err := uuid.Parse(tenant)
+		if err != nil {
+			log.Error("Invalid tenant ID", zap.String("tenant", tenant))
+			return 1
+		}
+		report, err := invariants.Check(ctx, tenantID, repair, nil)
+		if err != nil {
+			log.Error("Invariant check failed", zap.Error(err))
+			return 1
+		}
+		reports = append(reports, report)
+	} else {
+		reports, err = invariants.CheckAll(ctx, repair)
+		if err != nil {
+			log.Error("Invariant check failed", zap.Error(err))
+			return 1
+		}
+	}
+
+	out := make([]dto.InvariantReportResponse, len(reports))
+	left := 0
+	for i, report := range reports {
+		out[i] = dto.NewInvariantReportResponse(report)
+		left += len(report.Violations) - report.Repaired()
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if left > 0 {
+		return 2
+	}
+	return 0
+}
@@ -2,21 +2,35 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"os"
 	"os/signal"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/inbox-allocation-service/internal/api"
+	"github.com/inbox-allocation-service/internal/api/handler"
 	"github.com/inbox-allocation-service/internal/api/middleware"
 	"github.com/inbox-allocation-service/internal/config"
+	"github.com/inbox-allocation-service/internal/domain"
+	eventbus "github.com/inbox-allocation-service/internal/events"
+	"github.com/inbox-allocation-service/internal/pkg/auth"
+	"github.com/inbox-allocation-service/internal/pkg/breaker"
+	"github.com/inbox-allocation-service/internal/pkg/cache"
 	"github.com/inbox-allocation-service/internal/pkg/database"
+	"github.com/inbox-allocation-service/internal/pkg/encryption"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/outbound"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
+	"github.com/inbox-allocation-service/internal/push"
 	"github.com/inbox-allocation-service/internal/repository"
 	"github.com/inbox-allocation-service/internal/server"
 	"github.com/inbox-allocation-service/internal/service"
 	"github.com/inbox-allocation-service/internal/worker"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -43,6 +57,7 @@ func main() {
 	log.Info("Starting Inbox Allocation Service",
 		zap.String("version", Version),
 		zap.String("build_time", BuildTime),
+		zap.String("deployment_profile", cfg.Profile),
 	)
 
 	// Connect to database with retry
@@ -60,33 +75,461 @@ func main() {
 	repos := repository.NewRepositoryContainer(pool)
 	log.Info("Repositories initialized")
 
+	// Optional Redis cache for the reads on the allocation hot path
+	readCacheBackend := ""
+	if cfg.Cache.RedisAddr != "" {
+		readCacheBackend = "redis"
+		readCache := cache.NewRedis(cache.RedisConfig{
+			Addr:      cfg.Cache.RedisAddr,
+			Password:  cfg.Cache.RedisPassword,
+			DB:        cfg.Cache.RedisDB,
+			PoolSize:  cfg.Cache.RedisPoolSize,
+			Timeout:   cfg.Cache.RedisTimeout,
+			KeyPrefix: cfg.Cache.KeyPrefix,
+		})
+		defer readCache.Close()
+		if err := readCache.Ping(context.Background()); err != nil {
+			log.Warn("Redis cache unreachable, reads fall back to the database", zap.Error(err))
+		}
+		repos.UseCache(readCache, cfg.Cache.TTL)
+		log.Info("Read cache enabled",
+			zap.String("addr", cfg.Cache.RedisAddr),
+			zap.Duration("ttl", cfg.Cache.TTL))
+	}
+
+	// Candidate window of the allocation query, adapted to recent conflicts
+	repos.UseCandidateTuner(repository.NewCandidateTuner(repository.CandidateTunerConfig{
+		Enabled:    cfg.Candidates.Adaptive,
+		Min:        cfg.Candidates.Min,
+		Max:        cfg.Candidates.Max,
+		Percentile: cfg.Candidates.Percentile,
+		Samples:    cfg.Candidates.Samples,
+	}))
+
+	// Idempotency keys in Redis instead of Postgres, expired by Redis
+	switch cfg.Idempotency.Backend {
+	case "postgres":
+	case "redis":
+		addr := cfg.Idempotency.RedisAddr
+		if addr == "" {
+			addr = cfg.Cache.RedisAddr
+		}
+		if addr == "" {
+			log.Fatal("IDEMPOTENCY_BACKEND=redis requires IDEMPOTENCY_REDIS_ADDR or CACHE_REDIS_ADDR")
+		}
+		idempotencyRedis := cache.NewRedis(cache.RedisConfig{
+			Addr:      addr,
+			Password:  cfg.Cache.RedisPassword,
+			DB:        cfg.Cache.RedisDB,
+			PoolSize:  cfg.Cache.RedisPoolSize,
+			Timeout:   cfg.Cache.RedisTimeout,
+			KeyPrefix: cfg.Cache.KeyPrefix,
+		})
+		defer idempotencyRedis.Close()
+		if err := idempotencyRedis.Ping(context.Background()); err != nil {
+			log.Warn("Redis idempotency store unreachable", zap.Error(err))
+		}
+		repos.UseIdempotencyStore(repository.NewRedisIdempotencyRepository(idempotencyRedis))
+		log.Info("Idempotency keys stored in Redis", zap.String("addr", addr))
+	default:
+		log.Fatal("Invalid IDEMPOTENCY_BACKEND, expected postgres or redis", zap.String("backend", cfg.Idempotency.Backend))
+	}
+
 	// Initialize transaction manager
 	txMgr := database.NewTxManager(pool)
 
+	// Initialize webhook service
+	webhookConfig := service.DefaultWebhookConfig()
+	webhookConfig.Retry.MaxAttempts = cfg.Webhook.MaxAttempts
+	webhookConfig.RequestTimeout = cfg.Webhook.RequestTimeout
+	// Tenant-configured endpoints are called through a client that cannot
+	// reach loopback, private or link-local addresses
+	outboundClient := outbound.NewClient()
+	webhookService := service.NewWebhookService(repos, outboundClient, webhookConfig, log)
+
+	// Initialize event stream (SSE fan-out over LISTEN/NOTIFY)
+	realtimeKey := make([]byte, 32)
+	if cfg.Events.TokenSigningKey != "" {
+		realtimeKey, err = base64.StdEncoding.DecodeString(cfg.Events.TokenSigningKey)
+		if err != nil || len(realtimeKey) != 32 {
+			log.Fatal("REALTIME_TOKEN_SIGNING_KEY must be a base64-encoded 32-byte key")
+		}
+	} else {
+		if _, err := rand.Read(realtimeKey); err != nil {
+			log.Fatal("Failed to generate realtime token signing key", zap.Error(err))
+		}
+		log.Warn("No REALTIME_TOKEN_SIGNING_KEY: realtime tokens only work on the replica that issued them")
+	}
+	eventStreamService := service.NewEventStreamService(
+		repos,
+		pool,
+		domain.NewRealtimeTokenSigner(realtimeKey),
+		service.EventStreamConfig{BufferSize: cfg.Events.BufferSize},
+		log,
+	)
+
+	// Initialize QA sampling (resolved conversations feed the review queue)
+	qaService := service.NewQAService(repos, service.QAConfig{
+		SampleRate:   cfg.QA.SampleRate,
+		ClaimTimeout: cfg.QA.ClaimTimeout,
+	}, log)
+
+	// Materialized queue ranks for read paths, refreshed on conversation events
+	queueRankingService := service.NewQueueRankingService(repos, log)
+
+	// Presence WebSocket hub, fed operator status changes
+	presenceService := service.NewPresenceService(repos, pool, service.PresenceConfig{
+		Policy: domain.PresencePolicy{
+			HeartbeatInterval: cfg.Presence.HeartbeatInterval,
+			Timeout:           cfg.Presence.Timeout,
+		},
+	}, log)
+
+	// Push notifications to operators' mobile devices (FCM and APNs)
+	pushConfig := push.DefaultConfig()
+	pushConfig.FCMProjectID = cfg.Push.FCMProjectID
+	pushConfig.APNsKeyID = cfg.Push.APNsKeyID
+	pushConfig.APNsTeamID = cfg.Push.APNsTeamID
+	pushConfig.APNsTopic = cfg.Push.APNsTopic
+	pushConfig.APNsProduction = cfg.Push.APNsProduction
+	pushConfig.Timeout = cfg.Push.Timeout
+	if cfg.Push.FCMCredentialsFile != "" {
+		if pushConfig.FCMCredentials, err = os.ReadFile(cfg.Push.FCMCredentialsFile); err != nil {
+			log.Fatal("Failed to read PUSH_FCM_CREDENTIALS_FILE", zap.Error(err))
+		}
+	}
+	if cfg.Push.APNsKeyFile != "" {
+		if pushConfig.APNsKey, err = os.ReadFile(cfg.Push.APNsKeyFile); err != nil {
+			log.Fatal("Failed to read PUSH_APNS_KEY_FILE", zap.Error(err))
+		}
+	}
+	pushSender, err := push.NewSender(pushConfig)
+	if err != nil {
+		log.Fatal("Invalid push notification configuration", zap.Error(err))
+	}
+	pushService := service.NewPushNotificationService(repos, pushSender, service.PushConfig{
+		QueueSize: cfg.Push.QueueSize,
+		Workers:   cfg.Push.Workers,
+	}, log)
+
+	sinks := []domain.EventPublisher{webhookService, eventStreamService, qaService, queueRankingService, presenceService}
+	if pushService.Enabled() {
+		sinks = append(sinks, pushService)
+		log.Info("Push notifications enabled", zap.Strings("platforms", pushConfig.Platforms()))
+	}
+
+	// External event bus for downstream consumers such as analytics
+	busConfig := eventbus.DefaultConfig()
+	busConfig.Driver = cfg.EventBus.Driver
+	busConfig.Addrs = cfg.EventBus.Addrs
+	busConfig.Timeout = cfg.EventBus.Timeout
+	busConfig.Username = cfg.EventBus.Username
+	busConfig.Password = cfg.EventBus.Password
+	busConfig.Token = cfg.EventBus.Token
+	busConfig.Acks = cfg.EventBus.KafkaAcks
+	bus, err := eventbus.NewBus(busConfig)
+	if err != nil {
+		log.Fatal("Invalid event bus configuration", zap.Error(err))
+	}
+	if bus != nil {
+		defer bus.Close()
+		eventTypes := make([]domain.EventType, 0, len(cfg.EventBus.EventTypes))
+		for _, t := range cfg.EventBus.EventTypes {
+			eventTypes = append(eventTypes, domain.EventType(t))
+		}
+		sinks = append(sinks, service.NewEventBusPublisher(bus, service.EventBusConfig{
+			Topic:      cfg.EventBus.Topic,
+			EventTypes: eventTypes,
+		}))
+		log.Info("Event bus enabled",
+			zap.String("driver", cfg.EventBus.Driver),
+			zap.Strings("addrs", cfg.EventBus.Addrs),
+			zap.String("topic", cfg.EventBus.Topic))
+	}
+
+	// Lifecycle events go to webhooks, the event stream, QA sampling, queue
+	// ranking and the event bus, through the outbox so a crash after commit
+	// cannot lose them
+	outboxConfig := service.DefaultOutboxConfig()
+	outboxConfig.FlushGrace = cfg.Outbox.FlushGrace
+	outboxConfig.Retention = cfg.Outbox.Retention
+	events := service.NewEventOutbox(repos, service.NewMultiPublisher(sinks...), outboxConfig, log)
+
+	// Initialize audit log
+	auditService := service.NewAuditService(repos, log)
+
+	// Maintenance window for heavy jobs (backfills, full queue ranking,
+	// reconciliation)
+	maintenanceConfig := service.MaintenanceConfig{
+		Force: domain.MaintenanceOverrideMode(cfg.Maintenance.Force),
+	}
+	if cfg.Maintenance.Force != "" && !maintenanceConfig.Force.IsValid() {
+		log.Fatal("Invalid MAINTENANCE_FORCE, expected OPEN or CLOSED", zap.String("force", cfg.Maintenance.Force))
+	}
+	if cfg.Maintenance.Window != "" {
+		window, err := domain.ParseMaintenanceWindow(cfg.Maintenance.Window, cfg.Maintenance.Timezone)
+		if err != nil {
+			log.Fatal("Invalid MAINTENANCE_WINDOW", zap.Error(err))
+		}
+		maintenanceConfig.Window = window
+	}
+	maintenanceService := service.NewMaintenanceService(repos, auditService, maintenanceConfig, log)
+
+	// Initialize online schema-change backfills
+	backfillService := service.NewBackfillService(repos, pool, service.Backfills, service.BackfillConfig{
+		BatchSize:   cfg.Backfill.BatchSize,
+		LockTimeout: cfg.Backfill.LockTimeout,
+	}, log)
+
+	// Initialize conversation classification (tenant endpoints, best effort)
+	classificationService := service.NewClassificationService(repos, service.NewHTTPClassifier(outboundClient),
+		service.ClassifierConfig{Timeout: cfg.Classifier.Timeout}, auditService, log)
+
+	apiKeyService := service.NewAPIKeyService(repos, auditService, log)
+
+	// Journal of in-flight allocations, reconciled by the recovery worker
+	allocationJournal := service.NewAllocationJournal(repos, events, auditService, service.AllocationJournalConfig{
+		StaleAfter:    cfg.Allocation.StaleAfter,
+		Retention:     cfg.Allocation.Retention,
+		RecoveryBatch: service.DefaultAllocationJournalConfig().RecoveryBatch,
+	}, log)
+
+	// Anomaly detection over audit log and allocation journal counts
+	anomalyService := service.NewAnomalyService(repos, events, auditService, service.AnomalyDetectionConfig{
+		Window:   cfg.Anomaly.Window,
+		Baseline: cfg.Anomaly.Baseline,
+		Cooldown: cfg.Anomaly.Cooldown,
+	}, log)
+
+	operatorConfig := service.DefaultOperatorConfig()
+	operatorConfig.GracePeriod = cfg.Worker.GracePeriodDuration
+	operatorService := service.NewOperatorService(repos, txMgr, events, auditService, operatorConfig, log)
+
+	// Operator working-hours schedules, enforced by the shift-end worker
+	scheduleService := service.NewScheduleService(repos, operatorService, auditService, log)
+
+	// Per-inbox SLA policies, enforced by the SLA worker
+	slaService := service.NewSLAService(repos, queueRankingService, service.SLAConfig{
+		NearBreachRatio: cfg.SLA.NearBreachRatio,
+		BoostPriority:   decimal.NewFromFloat(cfg.SLA.BoostPriority),
+	}, log)
+
+	// Per-inbox category quotas, applied by allocation
+	categoryQuotaService := service.NewCategoryQuotaService(repos, pool, auditService, service.CategoryQuotaConfig{
+		Window:  cfg.Quotas.Window,
+		MaxWait: cfg.Quotas.MaxWait,
+	}, log)
+
+	// Health-weighted routing, adjusted by the operator health worker
+	healthPolicy := domain.DefaultAllocationHealthPolicy()
+	healthPolicy.ReturnRateThreshold = cfg.Health.ReturnRateThreshold
+	healthPolicy.MinAllocations = cfg.Health.MinAllocations
+	healthPolicy.MinWeight = cfg.Health.MinWeight
+	operatorHealthService := service.NewOperatorHealthService(repos, auditService, service.OperatorHealthConfig{
+		Window:       cfg.Health.Window,
+		PaceInterval: cfg.Health.PaceInterval,
+		Policy:       healthPolicy,
+	}, log)
+
+	// Conversation snoozes, ended by the snooze worker
+	snoozeService := service.NewSnoozeService(repos, pool, events, auditService, log)
+	transferService := service.NewTransferService(repos, pool, events, auditService, log)
+
+	// Operator vacations, drained by the vacation drain worker
+	vacationService := service.NewVacationService(repos, pool, events, auditService, log)
+
+	// Conversation due dates, flagged by the overdue worker
+	dueDateService := service.NewDueDateService(repos, pool, events, auditService, log)
+	stalenessService := service.NewStalenessService(repos, pool, events, log)
+	autoResolveService := service.NewAutoResolveService(repos, pool, events, auditService, log)
+
+	// Operator escalations to each inbox's escalation inbox
+	escalationService := service.NewEscalationService(repos, pool, events, auditService, log)
+
+	// Sandbox tenants ingest their demo conversations like real messages
+	conversationService := service.NewConversationService(repos, txMgr, classificationService, queueRankingService, events, auditService, log)
+	sandboxService := service.NewSandboxService(repos, pool, conversationService, auditService, log)
+
+	// Data invariant checks, run nightly by the invariant worker
+	invariantService := service.NewInvariantService(repos, pool, events, auditService, log)
+
+	// Reconciliation against the upstream system of record, run by the
+	// reconciliation worker when an upstream is configured
+	var conversationSource service.ConversationSource
+	if cfg.Reconciliation.UpstreamURL != "" {
+		conversationSource = service.NewHTTPConversationSource(cfg.Reconciliation.UpstreamURL, cfg.Reconciliation.UpstreamToken)
+	}
+	autoCorrect := make([]domain.DivergenceKind, 0, len(cfg.Reconciliation.AutoCorrect))
+	for _, kind := range cfg.Reconciliation.AutoCorrect {
+		if !domain.DivergenceKind(kind).IsValid() {
+			log.Fatal("Invalid RECONCILIATION_AUTO_CORRECT kind", zap.String("kind", kind))
+		}
+		autoCorrect = append(autoCorrect, domain.DivergenceKind(kind))
+	}
+	reconciliationService := service.NewReconciliationService(repos, pool, conversationSource, maintenanceService, service.ReconciliationConfig{
+		Lookback:     cfg.Reconciliation.Lookback,
+		BatchSize:    cfg.Reconciliation.BatchSize,
+		Timeout:      cfg.Reconciliation.Timeout,
+		AutoCorrect:  autoCorrect,
+		CorrectAfter: cfg.Reconciliation.CorrectAfter,
+	}, events, auditService, log)
+
+	// Signed, expiring links to conversation snapshots
+	shareLinkKey := make([]byte, 32)
+	if cfg.ShareLinks.SigningKey != "" {
+		shareLinkKey, err = base64.StdEncoding.DecodeString(cfg.ShareLinks.SigningKey)
+		if err != nil || len(shareLinkKey) != 32 {
+			log.Fatal("SHARE_LINK_SIGNING_KEY must be a base64-encoded 32-byte key")
+		}
+	} else {
+		if _, err := rand.Read(shareLinkKey); err != nil {
+			log.Fatal("Failed to generate share link signing key", zap.Error(err))
+		}
+		log.Warn("No SHARE_LINK_SIGNING_KEY: share links only work on this replica until it restarts")
+	}
+	shareLinkService := service.NewShareLinkService(repos, domain.NewShareTokenSigner(shareLinkKey),
+		service.ShareLinkConfig{BaseURL: cfg.ShareLinks.BaseURL}, auditService, log)
+
+	// Grace periods, processed by the grace period worker; /ready reports
+	// their backlog
+	gracePeriodService := service.NewGracePeriodService(repos, pool, events, auditService, domain.GracePipelinePolicy{
+		MaxOverdue: cfg.Worker.GracePeriodMaxOverdue,
+		MaxLag:     cfg.Worker.GracePeriodMaxLag,
+	}, log)
+
 	// Initialize services
 	services := &api.ServiceContainer{
-		Operator:     service.NewOperatorService(repos, txMgr, log),
+		Operator:     operatorService,
 		Inbox:        service.NewInboxService(repos, log),
 		Subscription: service.NewSubscriptionService(repos, log),
-		Tenant:       service.NewTenantService(repos, log),
-		Conversation: service.NewConversationService(repos, log),
-		Allocation:   service.NewAllocationService(repos, pool, log),
-		Lifecycle:    service.NewLifecycleService(repos, pool, log),
-		Label:        service.NewLabelService(repos, pool, log),
+		Tenant:       service.NewTenantService(repos, auditService, log),
+		Conversation: conversationService,
+		Allocation:   service.NewAllocationService(repos, pool, events, auditService, allocationJournal, categoryQuotaService, operatorHealthService, cfg.Allocation.AffinityWindow, log),
+		Lifecycle:    service.NewLifecycleService(repos, pool, events, auditService, gracePeriodService, log),
+		Label:        service.NewLabelService(repos, pool, events, auditService, log),
+		Webhook:      webhookService,
+		RoutingRule:  service.NewRoutingRuleService(repos, auditService, log),
+		EventStream:  eventStreamService,
+		Push:         pushService,
+		Presence:     presenceService,
+		Audit:        auditService,
+		Shadow:       service.NewShadowService(repos, auditService, log),
+		QA:           qaService,
+		Backfill:     backfillService,
+		Classifier:   classificationService,
+		APIKey:       apiKeyService,
+		Stats:        service.NewStatsService(repos, log),
+		QueueRanking: queueRankingService,
+		Anomaly:      anomalyService,
+		Schedule:     scheduleService,
+		InboxAdmin:   service.NewInboxAdminService(repos, auditService, log),
+		SLA:          slaService,
+		Snooze:       snoozeService,
+		Transfer:     transferService,
+		Vacation:     vacationService,
+		DueDate:      dueDateService,
+		Staleness:    stalenessService,
+		AutoResolve:  autoResolveService,
+		Escalation:   escalationService,
+		Invariants:   invariantService,
+		Reconcile:    reconciliationService,
+		Maintenance:  maintenanceService,
+		Quotas:       categoryQuotaService,
+		Checklist:    service.NewChecklistService(repos, auditService, log),
+		Health:       operatorHealthService,
+		WaitEstimate: service.NewWaitEstimateService(repos, service.WaitEstimateConfig{
+			Window:   cfg.Public.WaitEstimateWindow,
+			CacheTTL: cfg.Public.WaitEstimateCacheTTL,
+		}, log),
+		Experiment:  service.NewExperimentService(repos, auditService, log),
+		ShareLink:   shareLinkService,
+		Customer:    service.NewCustomerService(repos, auditService, log),
+		GracePeriod: gracePeriodService,
+		Sandbox:     sandboxService,
+		Scaling: service.NewScalingAuditService(service.ScalingAuditConfig{
+			CacheBackend:           readCacheBackend,
+			IdempotencyBackend:     cfg.Idempotency.Backend,
+			RealtimeKeyConfigured:  cfg.Events.TokenSigningKey != "",
+			ShareLinkKeyConfigured: cfg.ShareLinks.SigningKey != "",
+			PublicRateLimit:        cfg.Public.RateLimit,
+			WaitEstimateCacheTTL:   cfg.Public.WaitEstimateCacheTTL,
+			EventBusDriver:         cfg.EventBus.Driver,
+			PushPlatforms:          pushConfig.Platforms(),
+		}),
 	}
 	log.Info("Services initialized")
 
 	// Initialize idempotency service
+	degradationPolicies := make(map[service.EndpointClass]service.DegradationPolicy)
+	for class, policy := range cfg.Idempotency.DegradationOverrides {
+		degradationPolicies[service.EndpointClass(class)] = service.DegradationPolicy(policy)
+	}
+	var idempotencyKeyring *encryption.Keyring
+	if len(cfg.Idempotency.EncryptionKeys) > 0 {
+		idempotencyKeyring, err = encryption.NewKeyring(cfg.Idempotency.EncryptionKeys, cfg.Idempotency.EncryptionActiveKey)
+		if err != nil {
+			log.Fatal("Invalid idempotency encryption keys", zap.Error(err))
+		}
+		if idempotencyKeyring.ActiveKeyID() == "" {
+			log.Warn("No active idempotency encryption key: responses are stored in plaintext")
+		}
+	}
 	idempotencyService := service.NewIdempotencyService(
 		repos,
 		service.IdempotencyConfig{
 			TTL:             cfg.Idempotency.TTL,
 			CleanupInterval: cfg.Idempotency.CleanupInterval,
 			CleanupBatch:    100,
+			LockTimeout:     cfg.Idempotency.LockTimeout,
+			InFlightWait:    cfg.Idempotency.InFlightWait,
+			Degradation: service.DegradationConfig{
+				DefaultPolicy: service.DegradationPolicy(cfg.Idempotency.DegradationPolicy),
+				Policies:      degradationPolicies,
+				Breaker: breaker.Config{
+					FailureThreshold: cfg.Idempotency.BreakerThreshold,
+					OpenTimeout:      cfg.Idempotency.BreakerOpenTimeout,
+				},
+			},
+			Encryption: idempotencyKeyring,
 		},
 		log,
 	)
 
+	// Request authentication: JWTs, or trusted headers in dev mode
+	authConfig := middleware.AuthConfig{
+		DevMode:       cfg.Auth.DevMode,
+		APIKeys:       apiKeyService,
+		TenantClaim:   cfg.Auth.TenantClaim,
+		OperatorClaim: cfg.Auth.OperatorClaim,
+		RoleClaim:     cfg.Auth.RoleClaim,
+	}
+	if cfg.Auth.DevMode {
+		log.Warn("AUTH_DEV_MODE enabled: trusting X-Tenant-ID / X-Operator-ID headers without authentication")
+	} else {
+		authConfig.Verifier = auth.NewVerifier(auth.Config{
+			Issuer:             cfg.Auth.Issuer,
+			Audience:           cfg.Auth.Audience,
+			JWKSURL:            cfg.Auth.JWKSURL,
+			Leeway:             cfg.Auth.Leeway,
+			RefreshInterval:    cfg.Auth.JWKSRefresh,
+			MinRefreshInterval: cfg.Auth.JWKSMinRefresh,
+		})
+	}
+
+	// Worker scheduling; the manager is created ahead of the router, which
+	// reports the schedules, and the workers registered below
+	scheduleOverrides, err := worker.ParseScheduleOverrides(cfg.Worker.Schedules)
+	if err != nil {
+		log.Fatal("Invalid WORKER_SCHEDULES", zap.Error(err))
+	}
+	workerManagerConfig := worker.ManagerConfig{
+		JitterRatio:    cfg.Worker.JitterRatio,
+		CronJitter:     cfg.Worker.CronJitter,
+		AvoidAlignment: cfg.Worker.AvoidAlignment,
+		Overrides:      scheduleOverrides,
+	}
+	workerManager := worker.NewManager(workerManagerConfig)
+
 	// Create router with idempotency
 	router := api.NewRouter(api.RouterConfig{
 		Logger:             log,
@@ -97,13 +540,17 @@ func main() {
 		Version:            Version,
 		BuildTime:          BuildTime,
 		CORSConfig:         middleware.DefaultCORSConfig(),
+		Auth:               authConfig,
+		EventsHeartbeat:    cfg.Events.HeartbeatInterval,
+		ReadinessChecks:    []handler.ReadinessCheck{handler.NewGracePipelineCheck(gracePeriodService)},
+		Workers:            workerManager,
+		PublicRateLimiter: ratelimit.New(ratelimit.Config{
+			Rate:  cfg.Public.RateLimit,
+			Burst: cfg.Public.RateBurst,
+		}),
 	})
 
-	// Initialize workers
-	workerManager := worker.NewManager()
-
 	// Grace period worker
-	gracePeriodService := service.NewGracePeriodService(repos, pool, log)
 	gracePeriodWorker := worker.NewGracePeriodWorker(
 		gracePeriodService,
 		worker.GracePeriodWorkerConfig{
@@ -114,16 +561,183 @@ func main() {
 	)
 	workerManager.Register(gracePeriodWorker)
 
-	// Idempotency cleanup worker
-	idempotencyWorker := worker.NewIdempotencyWorker(
-		idempotencyService,
-		worker.IdempotencyWorkerConfig{
-			Interval: cfg.Idempotency.CleanupInterval,
+	// Idempotency cleanup worker; Redis expires keys itself
+	if cfg.Idempotency.Backend == "postgres" {
+		idempotencyWorker := worker.NewIdempotencyWorker(
+			idempotencyService,
+			worker.IdempotencyWorkerConfig{
+				Interval: cfg.Idempotency.CleanupInterval,
+			},
+			log,
+		)
+		workerManager.Register(idempotencyWorker)
+	}
+
+	// Queue ranking worker (full ranking at startup)
+	workerManager.Register(worker.NewQueueRankingWorker(
+		queueRankingService,
+		maintenanceService,
+		worker.QueueRankingWorkerConfig{
+			Interval:            cfg.QueueRanks.RefreshInterval,
+			FullRefreshInterval: cfg.QueueRanks.FullRefreshInterval,
+		},
+		log,
+	))
+
+	// Allocation recovery worker (first pass at startup)
+	workerManager.Register(worker.NewAllocationRecoveryWorker(
+		allocationJournal,
+		worker.AllocationRecoveryWorkerConfig{Interval: cfg.Allocation.RecoveryInterval},
+		log,
+	))
+
+	// Anomaly detection worker
+	workerManager.Register(worker.NewAnomalyWorker(
+		anomalyService,
+		worker.AnomalyWorkerConfig{Interval: cfg.Anomaly.CheckInterval},
+		log,
+	))
+
+	// Shift-end worker (sets operators OFFLINE when their schedule ends)
+	workerManager.Register(worker.NewShiftEndWorker(
+		scheduleService,
+		worker.ShiftEndWorkerConfig{Interval: cfg.Worker.ShiftEndInterval},
+		log,
+	))
+
+	// Presence worker (sets operators OFFLINE when their presence WebSocket times out)
+	workerManager.Register(worker.NewPresenceWorker(
+		presenceService,
+		operatorService,
+		worker.PresenceWorkerConfig{Interval: cfg.Presence.CheckInterval},
+		log,
+	))
+
+	// SLA worker (flags breaches, boosts conversations close to one)
+	workerManager.Register(worker.NewSLAWorker(
+		slaService,
+		worker.SLAWorkerConfig{Interval: cfg.SLA.CheckInterval},
+		log,
+	))
+
+	// Snooze worker (returns snoozed conversations to the queue or operator)
+	workerManager.Register(worker.NewSnoozeWorker(
+		snoozeService,
+		worker.SnoozeWorkerConfig{
+			Interval:  cfg.Worker.SnoozeInterval,
+			BatchSize: cfg.Worker.SnoozeBatchSize,
+		},
+		log,
+	))
+
+	// Vacation drain worker (moves conversations of operators on vacation)
+	workerManager.Register(worker.NewVacationDrainWorker(
+		vacationService,
+		worker.VacationDrainWorkerConfig{
+			Interval:  cfg.Worker.VacationDrainInterval,
+			BatchSize: worker.DefaultVacationDrainWorkerConfig().BatchSize,
+		},
+		log,
+	))
+
+	// Overdue worker (flags conversations past their due date)
+	workerManager.Register(worker.NewOverdueWorker(
+		dueDateService,
+		worker.OverdueWorkerConfig{
+			Interval:  cfg.Worker.OverdueCheckInterval,
+			BatchSize: worker.DefaultOverdueWorkerConfig().BatchSize,
+		},
+		log,
+	))
+
+	// Auto-resolve worker (resolves conversations idle past their inbox's threshold)
+	workerManager.Register(worker.NewAutoResolveWorker(
+		autoResolveService,
+		worker.AutoResolveWorkerConfig{
+			Interval:  cfg.Worker.AutoResolveInterval,
+			BatchSize: worker.DefaultAutoResolveWorkerConfig().BatchSize,
+		},
+		log,
+	))
+
+	// Stale allocation worker (flags allocations their operator stopped working on)
+	workerManager.Register(worker.NewStaleAllocationWorker(
+		stalenessService,
+		worker.StaleAllocationWorkerConfig{
+			Interval:  cfg.Worker.StaleAllocationInterval,
+			BatchSize: worker.DefaultStaleAllocationWorkerConfig().BatchSize,
+		},
+		log,
+	))
+
+	// Transfer expiry worker (ends transfers their recipient did not answer)
+	workerManager.Register(worker.NewTransferExpiryWorker(
+		transferService,
+		worker.TransferExpiryWorkerConfig{
+			Interval:  cfg.Worker.TransferExpiryInterval,
+			BatchSize: worker.DefaultTransferExpiryWorkerConfig().BatchSize,
+		},
+		log,
+	))
+
+	// Operator health worker (adjusts allocation weights from return rates)
+	workerManager.Register(worker.NewOperatorHealthWorker(
+		operatorHealthService,
+		worker.OperatorHealthWorkerConfig{Interval: cfg.Health.CheckInterval},
+		log,
+	))
+
+	// Webhook delivery worker
+	webhookWorker := worker.NewWebhookWorker(
+		webhookService,
+		worker.WebhookWorkerConfig{
+			Interval:  cfg.Webhook.WorkerInterval,
+			BatchSize: cfg.Webhook.BatchSize,
 		},
 		log,
 	)
-	workerManager.Register(idempotencyWorker)
+	workerManager.Register(webhookWorker)
+
+	// Event outbox worker (publishes events left pending after a crash or sink failure)
+	workerManager.Register(worker.NewOutboxWorker(
+		events,
+		worker.OutboxWorkerConfig{
+			Interval:  cfg.Outbox.WorkerInterval,
+			BatchSize: cfg.Outbox.BatchSize,
+		},
+		log,
+	))
+
+	// Schema backfill worker
+	workerManager.Register(worker.NewBackfillWorker(
+		backfillService,
+		maintenanceService,
+		worker.BackfillWorkerConfig{Interval: cfg.Backfill.Interval},
+		log,
+	))
 
+	// Invariant worker (nightly corruption check, optionally repairing)
+	workerManager.Register(worker.NewInvariantWorker(
+		invariantService,
+		worker.InvariantWorkerConfig{
+			Hour:   cfg.Worker.InvariantCheckHour,
+			Repair: cfg.Worker.InvariantAutoRepair,
+		},
+		log,
+	))
+
+	// Reconciliation worker (compares conversation states with upstream)
+	if conversationSource != nil {
+		workerManager.Register(worker.NewReconciliationWorker(
+			reconciliationService,
+			worker.ReconciliationWorkerConfig{Interval: cfg.Reconciliation.Interval},
+			log,
+		))
+	}
+
+	if err := workerManager.Validate(); err != nil {
+		log.Fatal("Invalid WORKER_SCHEDULES", zap.Error(err))
+	}
 	log.Info("Workers initialized")
 
 	// Parse server port
@@ -143,15 +757,94 @@ func main() {
 	}
 	srv := server.New(router, log, serverConfig)
 
+	// Internal listener for the messaging gateway: mutual TLS, machine
+	// endpoints only, its own rate limits
+	var internalSrv *server.Server
+	if cfg.Internal.Port != "" {
+		internalPort, err := strconv.Atoi(cfg.Internal.Port)
+		if err != nil {
+			log.Fatal("Invalid internal API port", zap.String("port", cfg.Internal.Port), zap.Error(err))
+		}
+		tlsConfig, err := server.MutualTLSConfig(cfg.Internal.CertFile, cfg.Internal.KeyFile, cfg.Internal.ClientCAFile)
+		if err != nil {
+			log.Fatal("Invalid internal API TLS configuration", zap.Error(err))
+		}
+		internalRouter := api.NewInternalRouter(api.InternalRouterConfig{
+			Logger:         log,
+			Services:       services,
+			AllowedClients: cfg.Internal.AllowedClients,
+			RateLimiter: ratelimit.New(ratelimit.Config{
+				Rate:  cfg.Internal.RateLimit,
+				Burst: cfg.Internal.RateBurst,
+			}),
+		})
+		internalConfig := serverConfig
+		internalConfig.Host = cfg.Internal.Host
+		internalConfig.Port = internalPort
+		internalConfig.TLS = tlsConfig
+		internalSrv = server.New(internalRouter, log.Named("internal"), internalConfig)
+	}
+
+	// The event stream and presence listeners only fan out notifications and
+	// run on every replica; the other workers only when the compatibility
+	// gate passes. WORKER_SCHEDULES does not apply to the compatibility
+	// heartbeat.
+	serveConfig := workerManagerConfig
+	serveConfig.Overrides = nil
+	serveManager := worker.NewManager(serveConfig)
+	serveManager.Register(worker.NewEventStreamWorker(eventStreamService, log))
+	serveManager.Register(worker.NewPresenceListenerWorker(presenceService, log))
+	if pushService.Enabled() {
+		serveManager.Register(worker.NewPushWorker(pushService, log))
+	}
+
+	compatConfig := service.DefaultCompatibilityConfig()
+	compatConfig.BinaryVersion = Version
+	compatConfig.InstanceTimeout = cfg.Worker.InstanceTimeout
+	compatibilityService := service.NewCompatibilityService(repos, compatConfig, log)
+
 	// Start workers
 	workerCtx, workerCancel := context.WithCancel(context.Background())
-	workerManager.StartAll(workerCtx)
+	stopWorkers := sync.OnceFunc(workerManager.StopAll)
+	report, err := compatibilityService.Check(workerCtx)
+	switch {
+	case err != nil:
+		log.Error("Compatibility check failed, workers disabled", zap.Error(err))
+	case !report.WorkersEnabled:
+		log.Error("Incompatible schema or worker protocol, workers disabled",
+			zap.Int64("schema_version", report.SchemaVersion),
+			zap.Bool("schema_dirty", report.SchemaDirty),
+			zap.Int64("min_schema_version", domain.MinSchemaVersion),
+			zap.Int64("max_schema_version", domain.MaxSchemaVersion),
+			zap.Int32("worker_protocol", domain.WorkerProtocolVersion),
+			zap.String("reason", report.Reason))
+	default:
+		log.Info("Compatibility check passed, starting workers",
+			zap.String("instance_id", compatConfig.InstanceID.String()),
+			zap.Int64("schema_version", report.SchemaVersion),
+			zap.Int32("worker_protocol", domain.WorkerProtocolVersion))
+		serveManager.Register(worker.NewCompatibilityWorker(
+			compatibilityService,
+			worker.CompatibilityWorkerConfig{Interval: cfg.Worker.CompatibilityInterval},
+			stopWorkers,
+			log,
+		))
+		workerManager.StartAll(workerCtx)
+	}
+	serveManager.StartAll(workerCtx)
 
 	// Register shutdown hooks
+	if internalSrv != nil {
+		srv.OnPreShutdown(func(ctx context.Context) error {
+			log.Info("stopping internal API server")
+			return internalSrv.Shutdown(ctx)
+		})
+	}
 	srv.OnPreShutdown(func(ctx context.Context) error {
 		log.Info("stopping workers")
 		workerCancel()
-		workerManager.StopAll()
+		stopWorkers()
+		serveManager.StopAll()
 		return nil
 	})
 
@@ -173,6 +866,13 @@ func main() {
 			log.Error("Server error", zap.Error(err))
 		}
 	}()
+	if internalSrv != nil {
+		go func() {
+			if err := internalSrv.Start(); err != nil {
+				log.Error("Internal API server error", zap.Error(err))
+			}
+		}()
+	}
 
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os/signal"
+	"syscall"
+
+	"github.com/inbox-allocation-service/internal/config"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/trafficgen"
+	"go.uber.org/zap"
+)
+
+// trafficgen continuously drives synthetic customers and operators against a
+// running non-production deployment. See TRAFFICGEN_* in .env.example.
+func main() {
+	cfg, err := config.LoadTrafficGen()
+	if err != nil {
+		panic("failed to load config: " + err.Error())
+	}
+
+	log, err := logger.New(cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		panic("failed to create logger: " + err.Error())
+	}
+	defer log.Sync()
+
+	client := trafficgen.NewClient(trafficgen.ClientConfig{
+		BaseURL:        cfg.BaseURL,
+		HTTPClient:     &http.Client{Timeout: cfg.Timeout},
+		TenantID:       cfg.TenantID,
+		APIKey:         cfg.APIKey,
+		ManagerID:      cfg.ManagerID,
+		OperatorTokens: cfg.OperatorTokens,
+	})
+	gen := trafficgen.NewGenerator(client, trafficgen.Config{
+		InboxIDs:               cfg.InboxIDs,
+		OperatorIDs:            cfg.OperatorIDs,
+		ConversationsPerMinute: cfg.ConversationsPerMinute,
+		FollowUpProbability:    cfg.FollowUpProbability,
+		CustomerPool:           cfg.CustomerPool,
+		OperatorTick:           cfg.OperatorTick,
+		OperatorCapacity:       cfg.OperatorCapacity,
+		HandleTime:             cfg.HandleTime,
+		DeallocateProbability:  cfg.DeallocateProbability,
+		BreakProbability:       cfg.BreakProbability,
+		BreakDuration:          cfg.BreakDuration,
+	}, log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	log.Info("Starting synthetic traffic generator",
+		zap.String("environment", cfg.Environment),
+		zap.String("base_url", cfg.BaseURL),
+		zap.String("tenant_id", cfg.TenantID.String()),
+		zap.Int("inboxes", len(cfg.InboxIDs)),
+		zap.Int("operators", len(cfg.OperatorIDs)),
+		zap.Float64("conversations_per_minute", cfg.ConversationsPerMinute))
+
+	gen.Run(ctx)
+
+	stats := gen.Stats()
+	log.Info("Synthetic traffic generator stopped",
+		zap.Int64("messages", stats.Messages.Load()),
+		zap.Int64("allocations", stats.Allocations.Load()),
+		zap.Int64("resolves", stats.Resolves.Load()),
+		zap.Int64("deallocates", stats.Deallocates.Load()),
+		zap.Int64("breaks", stats.Breaks.Load()),
+		zap.Int64("errors", stats.Errors.Load()))
+}
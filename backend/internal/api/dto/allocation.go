@@ -2,34 +2,100 @@ package dto
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 // ==================== Allocate Request ====================
 
-// AllocateRequest is intentionally empty - allocation is automatic
-// Operator ID and Tenant ID come from headers/context
+// MaxAllocateCount bounds the conversations of one batch allocate
+const MaxAllocateCount = 10
+
+// MaxAllocateLabels bounds the label filter of one allocate
+const MaxAllocateLabels = 20
+
+// AllocateRequest has no body - allocation is automatic
+// Operator ID and Tenant ID come from headers/context; the optional count
+// query parameter asks for a batch, the optional label_id parameters
+// (repeated or comma-separated) restrict it to conversations carrying one
+// of the labels
 type AllocateRequest struct {
-	// No body needed - allocation is based on operator context
+	Count    string
+	LabelIDs []string
 }
 
 func ParseAllocateRequest(r *http.Request) *AllocateRequest {
-	return &AllocateRequest{}
+	req := &AllocateRequest{Count: r.URL.Query().Get("count")}
+	for _, value := range r.URL.Query()["label_id"] {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				req.LabelIDs = append(req.LabelIDs, id)
+			}
+		}
+	}
+	return req
 }
 
 func (r *AllocateRequest) Validate() []string {
-	// No validation needed - operator context is validated by middleware
-	return nil
+	var errs []string
+	if r.Count != "" {
+		count, err := strconv.Atoi(r.Count)
+		if err != nil || count < 1 || count > MaxAllocateCount {
+			errs = append(errs, fmt.Sprintf("count must be between 1 and %d", MaxAllocateCount))
+		}
+	}
+	if len(r.LabelIDs) > MaxAllocateLabels {
+		errs = append(errs, fmt.Sprintf("at most %d label_id values are allowed", MaxAllocateLabels))
+	}
+	for _, id := range r.LabelIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			errs = append(errs, fmt.Sprintf("label_id %q is not a valid UUID", id))
+		}
+	}
+	return errs
+}
+
+// GetLabelIDs assumes Validate has passed; repeated labels are dropped
+func (r *AllocateRequest) GetLabelIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(r.LabelIDs))
+	var ids []uuid.UUID
+	for _, value := range r.LabelIDs {
+		id, err := uuid.Parse(value)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// IsBatch reports whether count was given; a batch is answered with a list
+// even for count=1
+func (r *AllocateRequest) IsBatch() bool {
+	return r.Count != ""
+}
+
+// GetCount assumes Validate has passed
+func (r *AllocateRequest) GetCount() int {
+	count, err := strconv.Atoi(r.Count)
+	if err != nil {
+		return 1
+	}
+	return count
 }
 
 // ==================== Claim Request ====================
 
 type ClaimRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
 }
 
 func ParseClaimRequest(r *http.Request) (*ClaimRequest, error) {
@@ -48,13 +114,7 @@ func ParseClaimRequest(r *http.Request) (*ClaimRequest, error) {
 }
 
 func (r *ClaimRequest) Validate() []string {
-	var errs []string
-
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
-	}
-
-	return errs
+	return validate.Struct(r)
 }
 
 // ==================== Allocation Response ====================
@@ -108,13 +168,32 @@ func NewAllocationResponse(c *domain.ConversationRef) AllocationResponse {
 	}
 }
 
+// BatchAllocationResponse lists the conversations of a batch allocate in
+// allocation order
+type BatchAllocationResponse struct {
+	Conversations []AllocationResponse `json:"conversations"`
+}
+
+func NewBatchAllocationResponse(convs []*domain.ConversationRef) BatchAllocationResponse {
+	resp := BatchAllocationResponse{Conversations: make([]AllocationResponse, len(convs))}
+	for i, c := range convs {
+		resp.Conversations[i] = NewAllocationResponse(c)
+	}
+	return resp
+}
+
 // ==================== Error Codes ====================
 
 const (
 	ErrCodeOperatorNotAvailable       = "OPERATOR_NOT_AVAILABLE"
+	ErrCodeOperatorInFocus            = "OPERATOR_IN_FOCUS"
+	ErrCodeOutsideSchedule            = "OUTSIDE_SCHEDULE"
 	ErrCodeNoSubscriptions            = "NO_SUBSCRIPTIONS"
 	ErrCodeNoConversationsAvailable   = "NO_CONVERSATIONS_AVAILABLE"
 	ErrCodeConversationNotQueued      = "CONVERSATION_NOT_QUEUED"
 	ErrCodeConversationAlreadyClaimed = "CONVERSATION_ALREADY_CLAIMED"
 	ErrCodeNotSubscribedToInbox       = "NOT_SUBSCRIBED_TO_INBOX"
+	ErrCodeAllocationInProgress       = "ALLOCATION_IN_PROGRESS"
+	ErrCodeIdempotencyKeyReused       = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeAllocationThrottled        = "ALLOCATION_THROTTLED"
 )
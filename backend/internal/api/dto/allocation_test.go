@@ -24,6 +24,73 @@ func TestParseAllocateRequest(t *testing.T) {
 	}
 }
 
+func TestAllocateRequest_Count(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantErr   bool
+		wantBatch bool
+		wantCount int
+	}{
+		{"no count", "", false, false, 1},
+		{"count", "?count=5", false, true, 5},
+		{"count of one", "?count=1", false, true, 1},
+		{"at limit", "?count=10", false, true, 10},
+		{"zero", "?count=0", true, true, 0},
+		{"over limit", "?count=11", true, true, 0},
+		{"not a number", "?count=many", true, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := dto.ParseAllocateRequest(httptest.NewRequest("POST", "/allocate"+tt.query, nil))
+			errs := parsed.Validate()
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("expected validation error")
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if parsed.IsBatch() != tt.wantBatch {
+				t.Errorf("expected IsBatch %v", tt.wantBatch)
+			}
+			if got := parsed.GetCount(); got != tt.wantCount {
+				t.Errorf("expected count %d, got %d", tt.wantCount, got)
+			}
+		})
+	}
+}
+
+func TestAllocateRequest_LabelIDs(t *testing.T) {
+	billing := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	refunds := uuid.MustParse("660fc2c9-1234-5678-9abc-def012345678")
+
+	parsed := dto.ParseAllocateRequest(httptest.NewRequest("POST",
+		"/allocate?label_id="+billing.String()+","+refunds.String()+"&label_id="+billing.String(), nil))
+	if errs := parsed.Validate(); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if parsed.IsBatch() {
+		t.Error("label_id alone should not ask for a batch")
+	}
+	got := parsed.GetLabelIDs()
+	if len(got) != 2 || got[0] != billing || got[1] != refunds {
+		t.Errorf("expected [%v %v], got %v", billing, refunds, got)
+	}
+
+	if got := dto.ParseAllocateRequest(httptest.NewRequest("POST", "/allocate", nil)).GetLabelIDs(); got != nil {
+		t.Errorf("expected no labels, got %v", got)
+	}
+
+	invalid := dto.ParseAllocateRequest(httptest.NewRequest("POST", "/allocate?label_id=billing", nil))
+	if errs := invalid.Validate(); len(errs) == 0 {
+		t.Error("expected validation error for a label_id that is not a UUID")
+	}
+}
+
 func TestClaimRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,145 @@
+package dto
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+const (
+	DefaultAnomalyLimit = 50
+	MaxAnomalyLimit     = 100
+	// DefaultAnomalyLookback applies when since is not given
+	DefaultAnomalyLookback = 24 * time.Hour
+)
+
+// ==================== List Anomalies Request ====================
+
+// ListAnomaliesRequest holds the raw query parameters of GET /api/v1/anomalies
+type ListAnomaliesRequest struct {
+	Since string
+	Limit string
+}
+
+func ParseListAnomaliesRequest(r *http.Request) *ListAnomaliesRequest {
+	q := r.URL.Query()
+	return &ListAnomaliesRequest{
+		Since: q.Get("since"),
+		Limit: q.Get("limit"),
+	}
+}
+
+func (r *ListAnomaliesRequest) Validate() []string {
+	var errs []string
+	if r.Since != "" {
+		if _, err := time.Parse(time.RFC3339, r.Since); err != nil {
+			errs = append(errs, "since must be an RFC 3339 timestamp")
+		}
+	}
+	if r.Limit != "" {
+		limit, err := strconv.Atoi(r.Limit)
+		if err != nil || limit < 1 || limit > MaxAnomalyLimit {
+			errs = append(errs, "limit must be between 1 and 100")
+		}
+	}
+	return errs
+}
+
+// GetSince assumes Validate has passed
+func (r *ListAnomaliesRequest) GetSince(now time.Time) time.Time {
+	since, err := time.Parse(time.RFC3339, r.Since)
+	if err != nil {
+		return now.Add(-DefaultAnomalyLookback)
+	}
+	return since
+}
+
+// GetLimit assumes Validate has passed
+func (r *ListAnomaliesRequest) GetLimit() int {
+	limit, err := strconv.Atoi(r.Limit)
+	if err != nil {
+		return DefaultAnomalyLimit
+	}
+	return limit
+}
+
+// ==================== Update Anomaly Settings Request ====================
+
+type UpdateAnomalySettingsRequest struct {
+	Sensitivity string `json:"sensitivity" validate:"required"`
+}
+
+func (r *UpdateAnomalySettingsRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if strings.TrimSpace(r.Sensitivity) != "" && !r.GetSensitivity().IsValid() {
+		errs = append(errs, "sensitivity must be one of OFF, LOW, MEDIUM, HIGH")
+	}
+	return errs
+}
+
+// GetSensitivity returns the sensitivity, case-insensitively
+func (r *UpdateAnomalySettingsRequest) GetSensitivity() domain.AnomalySensitivity {
+	return domain.AnomalySensitivity(strings.ToUpper(strings.TrimSpace(r.Sensitivity)))
+}
+
+// ==================== Anomaly Responses ====================
+
+type AnomalySettingsResponse struct {
+	Sensitivity string     `json:"sensitivity"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	// Null while the tenant uses the default sensitivity
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func NewAnomalySettingsResponse(s *domain.TenantAnomalySettings) AnomalySettingsResponse {
+	resp := AnomalySettingsResponse{
+		Sensitivity: string(s.Sensitivity),
+		UpdatedBy:   s.UpdatedBy,
+	}
+	if !s.UpdatedAt.IsZero() {
+		updatedAt := s.UpdatedAt
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+type AnomalyResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Kind          string     `json:"kind"`
+	OperatorID    *uuid.UUID `json:"operator_id"`
+	Observed      int        `json:"observed"`
+	Baseline      float64    `json:"baseline"`
+	Threshold     float64    `json:"threshold"`
+	WindowSeconds int        `json:"window_seconds"`
+	DetectedAt    time.Time  `json:"detected_at"`
+}
+
+func NewAnomalyResponse(a *domain.Anomaly) AnomalyResponse {
+	return AnomalyResponse{
+		ID:            a.ID,
+		Kind:          string(a.Kind),
+		OperatorID:    a.OperatorID,
+		Observed:      a.Observed,
+		Baseline:      a.Baseline,
+		Threshold:     a.Threshold,
+		WindowSeconds: int(a.Window.Seconds()),
+		DetectedAt:    a.DetectedAt,
+	}
+}
+
+type AnomalyListResponse struct {
+	Anomalies []AnomalyResponse `json:"anomalies"`
+}
+
+func NewAnomalyListResponse(anomalies []*domain.Anomaly) AnomalyListResponse {
+	resp := AnomalyListResponse{Anomalies: make([]AnomalyResponse, len(anomalies))}
+	for i, a := range anomalies {
+		resp.Anomalies[i] = NewAnomalyResponse(a)
+	}
+	return resp
+}
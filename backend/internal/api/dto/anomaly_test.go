@@ -0,0 +1,65 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAnomaliesRequest(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	req := dto.ParseListAnomaliesRequest(httptest.NewRequest("GET", "/api/v1/anomalies", nil))
+	assert.Empty(t, req.Validate())
+	assert.Equal(t, now.Add(-dto.DefaultAnomalyLookback), req.GetSince(now))
+	assert.Equal(t, dto.DefaultAnomalyLimit, req.GetLimit())
+
+	req = dto.ParseListAnomaliesRequest(httptest.NewRequest("GET", "/api/v1/anomalies?since=2025-03-10T08:00:00Z&limit=10", nil))
+	assert.Empty(t, req.Validate())
+	assert.Equal(t, time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), req.GetSince(now))
+	assert.Equal(t, 10, req.GetLimit())
+
+	for _, query := range []string{"since=yesterday", "limit=0", "limit=101", "limit=abc"} {
+		req := dto.ParseListAnomaliesRequest(httptest.NewRequest("GET", "/api/v1/anomalies?"+query, nil))
+		assert.NotEmpty(t, req.Validate(), query)
+	}
+}
+
+func TestUpdateAnomalySettingsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		sensitivity string
+		wantErr     bool
+	}{
+		{"HIGH", false},
+		{" low ", false},
+		{"off", false},
+		{"", true},
+		{"EXTREME", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sensitivity, func(t *testing.T) {
+			req := &dto.UpdateAnomalySettingsRequest{Sensitivity: tt.sensitivity}
+			errs := req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+
+	req := &dto.UpdateAnomalySettingsRequest{Sensitivity: " low "}
+	assert.Equal(t, domain.AnomalySensitivityLow, req.GetSensitivity())
+}
+
+func TestNewAnomalySettingsResponse_Default(t *testing.T) {
+	resp := dto.NewAnomalySettingsResponse(domain.DefaultTenantAnomalySettings([16]byte{1}))
+	assert.Equal(t, "MEDIUM", resp.Sensitivity)
+	assert.Nil(t, resp.UpdatedAt)
+	assert.Nil(t, resp.UpdatedBy)
+}
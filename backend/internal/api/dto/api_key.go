@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Create API Key Request ====================
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Role string `json:"role"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if !domain.OperatorRole(r.Role).IsValid() {
+		errs = append(errs, "role must be OPERATOR, MANAGER, or ADMIN")
+	}
+	return errs
+}
+
+// ==================== API Key Response ====================
+
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Role       string     `json:"role"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+func NewAPIKeyResponse(k *domain.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		KeyPrefix:  k.KeyPrefix,
+		Role:       string(k.Role),
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// CreatedAPIKeyResponse is the only response that includes the key itself
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeAPIKeyNotFound = "API_KEY_NOT_FOUND"
+)
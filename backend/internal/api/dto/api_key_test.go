@@ -0,0 +1,51 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestCreateAPIKeyRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      dto.CreateAPIKeyRequest
+		errCount int
+	}{
+		{
+			name:     "valid request",
+			req:      dto.CreateAPIKeyRequest{Name: "Messaging platform", Role: "MANAGER"},
+			errCount: 0,
+		},
+		{
+			name:     "missing name",
+			req:      dto.CreateAPIKeyRequest{Name: "  ", Role: "MANAGER"},
+			errCount: 1,
+		},
+		{
+			name:     "name too long",
+			req:      dto.CreateAPIKeyRequest{Name: strings.Repeat("k", 101), Role: "ADMIN"},
+			errCount: 1,
+		},
+		{
+			name:     "invalid role",
+			req:      dto.CreateAPIKeyRequest{Name: "Ingestion", Role: "manager"},
+			errCount: 1,
+		},
+		{
+			name:     "missing name and role",
+			req:      dto.CreateAPIKeyRequest{},
+			errCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
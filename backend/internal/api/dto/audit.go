@@ -0,0 +1,397 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== List Audit Log Request ====================
+
+// ListAuditLogRequest holds the raw query parameters of GET /api/v1/audit.
+// Values are kept as strings so Validate can report malformed input.
+type ListAuditLogRequest struct {
+	ActorID    string
+	EntityType string
+	EntityID   string
+	From       string
+	To         string
+	Cursor     string
+	PerPage    int
+}
+
+func ParseListAuditLogRequest(r *http.Request) *ListAuditLogRequest {
+	query := r.URL.Query()
+	return &ListAuditLogRequest{
+		ActorID:    query.Get("actor_id"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		Cursor:     query.Get("cursor"),
+		PerPage:    ParsePagination(r).PerPage,
+	}
+}
+
+func (r *ListAuditLogRequest) Validate() []string {
+	var errs []string
+
+	if r.ActorID != "" {
+		if _, err := uuid.Parse(r.ActorID); err != nil {
+			errs = append(errs, "actor_id must be a valid UUID")
+		}
+	}
+	if r.EntityType != "" && !domain.AuditEntityType(r.EntityType).IsValid() {
+		errs = append(errs, "entity_type must be conversation, label, operator, tenant, or api_key")
+	}
+	if r.EntityID != "" {
+		if _, err := uuid.Parse(r.EntityID); err != nil {
+			errs = append(errs, "entity_id must be a valid UUID")
+		}
+	}
+
+	from, fromErr := parseOptionalTime(r.From)
+	if fromErr != nil {
+		errs = append(errs, "from must be an RFC 3339 timestamp")
+	}
+	to, toErr := parseOptionalTime(r.To)
+	if toErr != nil {
+		errs = append(errs, "to must be an RFC 3339 timestamp")
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		errs = append(errs, "from must be before to")
+	}
+
+	if r.Cursor != "" {
+		if _, err := DecodeCursor(r.Cursor); err != nil {
+			errs = append(errs, "cursor is invalid")
+		}
+	}
+
+	return errs
+}
+
+// The accessors below assume Validate has passed
+
+func (r *ListAuditLogRequest) GetActorID() *uuid.UUID {
+	return parseOptionalUUID(r.ActorID)
+}
+
+func (r *ListAuditLogRequest) GetEntityType() *domain.AuditEntityType {
+	if r.EntityType == "" {
+		return nil
+	}
+	entityType := domain.AuditEntityType(r.EntityType)
+	return &entityType
+}
+
+func (r *ListAuditLogRequest) GetEntityID() *uuid.UUID {
+	return parseOptionalUUID(r.EntityID)
+}
+
+func (r *ListAuditLogRequest) GetFrom() *time.Time {
+	from, _ := parseOptionalTime(r.From)
+	return from
+}
+
+func (r *ListAuditLogRequest) GetTo() *time.Time {
+	to, _ := parseOptionalTime(r.To)
+	return to
+}
+
+func (r *ListAuditLogRequest) GetCursor() *Cursor {
+	if r.Cursor == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(r.Cursor)
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
+func parseOptionalUUID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ==================== Audit Entry Response ====================
+
+type AuditEntryResponse struct {
+	ID         uuid.UUID              `json:"id"`
+	ActorID    *uuid.UUID             `json:"actor_id"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   uuid.UUID              `json:"entity_id"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+func NewAuditEntryResponse(e *domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		Action:     string(e.Action),
+		EntityType: string(e.EntityType),
+		EntityID:   e.EntityID,
+		Before:     e.Before,
+		After:      e.After,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// ==================== List Response ====================
+
+type AuditLogListMeta struct {
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Count      int    `json:"count"`
+}
+
+type AuditLogListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Meta    AuditLogListMeta     `json:"meta"`
+}
+
+func NewAuditLogListResponse(entries []*domain.AuditEntry, perPage int) AuditLogListResponse {
+	items := make([]AuditEntryResponse, len(entries))
+	for i, e := range entries {
+		items[i] = NewAuditEntryResponse(e)
+	}
+
+	resp := AuditLogListResponse{
+		Entries: items,
+		Meta: AuditLogListMeta{
+			Count:   len(items),
+			HasMore: len(items) >= perPage,
+		},
+	}
+
+	if len(entries) > 0 && resp.Meta.HasMore {
+		last := entries[len(entries)-1]
+		resp.Meta.NextCursor = EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return resp
+}
+
+// ==================== Admin Activity ====================
+
+// ListAdminActivityRequest holds the raw query parameters of
+// GET /api/v1/admin/activity and its export. Action is a comma-separated
+// list of admin audit actions.
+type ListAdminActivityRequest struct {
+	ActorID string
+	Action  string
+	From    string
+	To      string
+	Cursor  string
+	PerPage int
+}
+
+func ParseListAdminActivityRequest(r *http.Request) *ListAdminActivityRequest {
+	query := r.URL.Query()
+	return &ListAdminActivityRequest{
+		ActorID: query.Get("actor_id"),
+		Action:  query.Get("action"),
+		From:    query.Get("from"),
+		To:      query.Get("to"),
+		Cursor:  query.Get("cursor"),
+		PerPage: ParsePagination(r).PerPage,
+	}
+}
+
+func (r *ListAdminActivityRequest) Validate() []string {
+	// The shared filters validate like the audit log's
+	errs := (&ListAuditLogRequest{ActorID: r.ActorID, From: r.From, To: r.To, Cursor: r.Cursor}).Validate()
+
+	for _, action := range splitAdminActions(r.Action) {
+		if !domain.AuditAction(action).IsAdmin() {
+			errs = append(errs, "action "+action+" is not an admin activity action")
+		}
+	}
+
+	return errs
+}
+
+// The accessors below assume Validate has passed
+
+func (r *ListAdminActivityRequest) GetActorID() *uuid.UUID {
+	return parseOptionalUUID(r.ActorID)
+}
+
+// GetActions returns the requested actions, nil for every admin action
+func (r *ListAdminActivityRequest) GetActions() []domain.AuditAction {
+	var actions []domain.AuditAction
+	for _, action := range splitAdminActions(r.Action) {
+		actions = append(actions, domain.AuditAction(action))
+	}
+	return actions
+}
+
+func (r *ListAdminActivityRequest) GetFrom() *time.Time {
+	from, _ := parseOptionalTime(r.From)
+	return from
+}
+
+func (r *ListAdminActivityRequest) GetTo() *time.Time {
+	to, _ := parseOptionalTime(r.To)
+	return to
+}
+
+func (r *ListAdminActivityRequest) GetCursor() *Cursor {
+	return (&ListAuditLogRequest{Cursor: r.Cursor}).GetCursor()
+}
+
+func splitAdminActions(value string) []string {
+	var actions []string
+	for _, action := range strings.Split(value, ",") {
+		if action = strings.TrimSpace(action); action != "" {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// AdminActivityCSVHeader is the first row of an admin activity export
+var AdminActivityCSVHeader = []string{"id", "created_at", "actor_id", "action", "entity_type", "entity_id", "before", "after"}
+
+// WriteAdminActivityCSV writes the entries as CSV, before and after states
+// as JSON objects (empty when absent)
+func WriteAdminActivityCSV(w io.Writer, entries []*domain.AuditEntry) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(AdminActivityCSVHeader); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		actorID := ""
+		if e.ActorID != nil {
+			actorID = e.ActorID.String()
+		}
+		before, err := auditStateJSON(e.Before)
+		if err != nil {
+			return err
+		}
+		after, err := auditStateJSON(e.After)
+		if err != nil {
+			return err
+		}
+		record := []string{
+			e.ID.String(),
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			actorID,
+			string(e.Action),
+			string(e.EntityType),
+			e.EntityID.String(),
+			before,
+			after,
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func auditStateJSON(state map[string]interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// ==================== Break-Glass Report ====================
+
+// BreakGlassAccessResponse is one conversation.break_glass_access entry,
+// flattened for compliance review
+type BreakGlassAccessResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	ActorID            *uuid.UUID `json:"actor_id"`
+	ConversationID     uuid.UUID  `json:"conversation_id"`
+	InboxID            *string    `json:"inbox_id"`
+	Restricted         bool       `json:"restricted"`
+	Reason             *string    `json:"reason"`
+	AssignedOperatorID *string    `json:"assigned_operator_id"`
+	AccessedAt         time.Time  `json:"accessed_at"`
+}
+
+func NewBreakGlassAccessResponse(e *domain.AuditEntry) BreakGlassAccessResponse {
+	restricted, _ := e.After["restricted"].(bool)
+	return BreakGlassAccessResponse{
+		ID:                 e.ID,
+		ActorID:            e.ActorID,
+		ConversationID:     e.EntityID,
+		InboxID:            auditStringField(e.After, "inbox_id"),
+		Restricted:         restricted,
+		Reason:             auditStringField(e.After, "reason"),
+		AssignedOperatorID: auditStringField(e.After, "assigned_operator_id"),
+		AccessedAt:         e.CreatedAt,
+	}
+}
+
+// auditStringField returns a string field of an audit state, nil when absent
+func auditStringField(state map[string]interface{}, key string) *string {
+	value, ok := state[key].(string)
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+type BreakGlassReportResponse struct {
+	Accesses []BreakGlassAccessResponse `json:"accesses"`
+	Meta     AuditLogListMeta           `json:"meta"`
+}
+
+func NewBreakGlassReportResponse(entries []*domain.AuditEntry, perPage int) BreakGlassReportResponse {
+	items := make([]BreakGlassAccessResponse, len(entries))
+	for i, e := range entries {
+		items[i] = NewBreakGlassAccessResponse(e)
+	}
+
+	resp := BreakGlassReportResponse{
+		Accesses: items,
+		Meta: AuditLogListMeta{
+			Count:   len(items),
+			HasMore: len(items) >= perPage,
+		},
+	}
+
+	if len(entries) > 0 && resp.Meta.HasMore {
+		last := entries[len(entries)-1]
+		resp.Meta.NextCursor = EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return resp
+}
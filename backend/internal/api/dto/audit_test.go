@@ -0,0 +1,203 @@
+package dto_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestListAuditLogRequest_Validate(t *testing.T) {
+	id := uuid.New().String()
+
+	tests := []struct {
+		name     string
+		req      dto.ListAuditLogRequest
+		errCount int
+	}{
+		{
+			name:     "no filters",
+			req:      dto.ListAuditLogRequest{},
+			errCount: 0,
+		},
+		{
+			name: "all filters",
+			req: dto.ListAuditLogRequest{
+				ActorID:    id,
+				EntityType: "conversation",
+				EntityID:   id,
+				From:       "2025-01-01T00:00:00Z",
+				To:         "2025-02-01T00:00:00Z",
+				Cursor:     dto.EncodeCursor(time.Now(), uuid.New()),
+			},
+			errCount: 0,
+		},
+		{
+			name:     "invalid actor_id",
+			req:      dto.ListAuditLogRequest{ActorID: "not-a-uuid"},
+			errCount: 1,
+		},
+		{
+			name:     "unknown entity_type",
+			req:      dto.ListAuditLogRequest{EntityType: "widget"},
+			errCount: 1,
+		},
+		{
+			name:     "invalid entity_id",
+			req:      dto.ListAuditLogRequest{EntityID: "123"},
+			errCount: 1,
+		},
+		{
+			name:     "invalid from",
+			req:      dto.ListAuditLogRequest{From: "yesterday"},
+			errCount: 1,
+		},
+		{
+			name:     "from after to",
+			req:      dto.ListAuditLogRequest{From: "2025-02-01T00:00:00Z", To: "2025-01-01T00:00:00Z"},
+			errCount: 1,
+		},
+		{
+			name:     "invalid cursor",
+			req:      dto.ListAuditLogRequest{Cursor: "%%%"},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestNewAuditLogListResponse_NextCursor(t *testing.T) {
+	actorID := uuid.New()
+	entries := []*domain.AuditEntry{
+		domain.NewAuditEntry(uuid.New(), &actorID, domain.AuditActionConversationResolve,
+			domain.AuditEntityConversation, uuid.New(), nil, nil),
+		domain.NewAuditEntry(uuid.New(), nil, domain.AuditActionLabelDelete,
+			domain.AuditEntityLabel, uuid.New(), nil, nil),
+	}
+
+	resp := dto.NewAuditLogListResponse(entries, 2)
+	if !resp.Meta.HasMore || resp.Meta.NextCursor == "" {
+		t.Fatalf("expected next cursor for a full page, got %+v", resp.Meta)
+	}
+
+	cursor, err := dto.DecodeCursor(resp.Meta.NextCursor)
+	if err != nil {
+		t.Fatalf("failed to decode cursor: %v", err)
+	}
+	if cursor.ID != entries[1].ID {
+		t.Errorf("cursor id = %v, want %v", cursor.ID, entries[1].ID)
+	}
+
+	resp = dto.NewAuditLogListResponse(entries, 50)
+	if resp.Meta.HasMore || resp.Meta.NextCursor != "" {
+		t.Errorf("expected no next cursor for a partial page, got %+v", resp.Meta)
+	}
+}
+
+func TestNewBreakGlassAccessResponse(t *testing.T) {
+	actorID := uuid.New()
+	inboxID := uuid.New().String()
+	entry := domain.NewAuditEntry(uuid.New(), &actorID, domain.AuditActionConversationBreakGlass,
+		domain.AuditEntityConversation, uuid.New(), nil, map[string]interface{}{
+			"reason":               "customer complaint",
+			"inbox_id":             inboxID,
+			"restricted":           true,
+			"state":                "ALLOCATED",
+			"assigned_operator_id": nil,
+		})
+
+	resp := dto.NewBreakGlassAccessResponse(entry)
+	if resp.ConversationID != entry.EntityID {
+		t.Errorf("conversation_id = %v, want %v", resp.ConversationID, entry.EntityID)
+	}
+	if resp.Reason == nil || *resp.Reason != "customer complaint" {
+		t.Errorf("reason = %v, want customer complaint", resp.Reason)
+	}
+	if resp.InboxID == nil || *resp.InboxID != inboxID {
+		t.Errorf("inbox_id = %v, want %v", resp.InboxID, inboxID)
+	}
+	if !resp.Restricted {
+		t.Error("expected restricted")
+	}
+	if resp.AssignedOperatorID != nil {
+		t.Errorf("assigned_operator_id = %v, want nil", *resp.AssignedOperatorID)
+	}
+}
+
+func TestListAdminActivityRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      dto.ListAdminActivityRequest
+		errCount int
+	}{
+		{"empty", dto.ListAdminActivityRequest{}, 0},
+		{"admin actions", dto.ListAdminActivityRequest{Action: "operator.role_change, routing_rule.update"}, 0},
+		{"operational action", dto.ListAdminActivityRequest{Action: "operator.role_change,conversation.allocate"}, 1},
+		{"unknown action", dto.ListAdminActivityRequest{Action: "tenant.rename"}, 1},
+		{"invalid actor", dto.ListAdminActivityRequest{ActorID: "nobody"}, 1},
+		{"inverted range", dto.ListAdminActivityRequest{From: "2025-02-01T00:00:00Z", To: "2025-01-01T00:00:00Z"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+
+	req := dto.ListAdminActivityRequest{Action: "operator.role_change,,routing_rule.update"}
+	actions := req.GetActions()
+	if len(actions) != 2 || actions[1] != domain.AuditActionRoutingRuleUpdate {
+		t.Errorf("GetActions() = %v", actions)
+	}
+	if (&dto.ListAdminActivityRequest{}).GetActions() != nil {
+		t.Error("expected no actions when action is omitted")
+	}
+}
+
+func TestWriteAdminActivityCSV(t *testing.T) {
+	actorID := uuid.New()
+	entries := []*domain.AuditEntry{
+		domain.NewAuditEntry(uuid.New(), &actorID, domain.AuditActionOperatorRoleChange,
+			domain.AuditEntityOperator, uuid.New(),
+			map[string]interface{}{"role": "OPERATOR"}, map[string]interface{}{"role": "MANAGER"}),
+		domain.NewAuditEntry(uuid.New(), nil, domain.AuditActionRoutingRuleDelete,
+			domain.AuditEntityRoutingRule, uuid.New(), map[string]interface{}{"name": "vip, urgent"}, nil),
+	}
+
+	var buf bytes.Buffer
+	if err := dto.WriteAdminActivityCSV(&buf, entries); err != nil {
+		t.Fatalf("WriteAdminActivityCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %d", len(records))
+	}
+	if records[1][2] != actorID.String() || records[1][3] != "operator.role_change" {
+		t.Errorf("unexpected first row %v", records[1])
+	}
+	if records[1][7] != `{"role":"MANAGER"}` {
+		t.Errorf("after = %q", records[1][7])
+	}
+	if records[2][2] != "" || records[2][6] != `{"name":"vip, urgent"}` || records[2][7] != "" {
+		t.Errorf("unexpected second row %v", records[2])
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Backfill Response ====================
+
+type BackfillResponse struct {
+	Name          string     `json:"name"`
+	TableName     string     `json:"table_name"`
+	Status        string     `json:"status"`
+	RowsProcessed int64      `json:"rows_processed"`
+	RowsTotal     int64      `json:"rows_total_estimate"`
+	Progress      float64    `json:"progress"`
+	BatchSize     int        `json:"batch_size"`
+	CursorID      *uuid.UUID `json:"cursor_id"`
+	LastError     *string    `json:"last_error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	StartedAt     *time.Time `json:"started_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+func NewBackfillResponse(b *domain.Backfill) BackfillResponse {
+	return BackfillResponse{
+		Name:          b.Name,
+		TableName:     b.TableName,
+		Status:        string(b.Status),
+		RowsProcessed: b.RowsProcessed,
+		RowsTotal:     b.RowsTotal,
+		Progress:      b.Progress(),
+		BatchSize:     b.BatchSize,
+		CursorID:      b.CursorID,
+		LastError:     b.LastError,
+		CreatedAt:     b.CreatedAt,
+		StartedAt:     b.StartedAt,
+		UpdatedAt:     b.UpdatedAt,
+		CompletedAt:   b.CompletedAt,
+	}
+}
+
+type BackfillListResponse struct {
+	Backfills []BackfillResponse `json:"backfills"`
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Category Quotas Request ====================
+
+// CategoryQuotasRequest replaces an inbox's category quotas; an empty list
+// removes them
+type CategoryQuotasRequest struct {
+	Quotas []CategoryQuotaItem `json:"quotas"`
+}
+
+type CategoryQuotaItem struct {
+	LabelID      string `json:"label_id"`
+	SharePercent int    `json:"share_percent"`
+}
+
+func (r *CategoryQuotasRequest) Validate() []string {
+	var errs []string
+	seen := make(map[string]bool, len(r.Quotas))
+	total := 0
+	for _, q := range r.Quotas {
+		if _, err := uuid.Parse(q.LabelID); err != nil {
+			errs = append(errs, "label_id must be a UUID")
+			continue
+		}
+		if seen[q.LabelID] {
+			errs = append(errs, "label_id "+q.LabelID+" is listed more than once")
+		}
+		seen[q.LabelID] = true
+		if q.SharePercent < 1 || q.SharePercent > 100 {
+			errs = append(errs, "share_percent must be between 1 and 100")
+		}
+		total += q.SharePercent
+	}
+	if total > 100 {
+		errs = append(errs, "share_percent values must add up to at most 100")
+	}
+	return errs
+}
+
+// GetLabelID assumes Validate has passed
+func (q CategoryQuotaItem) GetLabelID() uuid.UUID {
+	return uuid.MustParse(q.LabelID)
+}
+
+// ==================== Category Quotas Response ====================
+
+type CategoryQuotaResponse struct {
+	LabelID      uuid.UUID  `json:"label_id"`
+	SharePercent int        `json:"share_percent"`
+	UpdatedBy    *uuid.UUID `json:"updated_by"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	// Allocations is how many of TotalAllocations in the measurement window
+	// went to the category
+	Allocations          int     `json:"allocations"`
+	TotalAllocations     int     `json:"total_allocations"`
+	AchievedSharePercent float64 `json:"achieved_share_percent"`
+}
+
+type CategoryQuotasResponse struct {
+	InboxID uuid.UUID               `json:"inbox_id"`
+	Quotas  []CategoryQuotaResponse `json:"quotas"`
+}
+
+func NewCategoryQuotasResponse(inboxID uuid.UUID, statuses []*domain.CategoryQuotaStatus) CategoryQuotasResponse {
+	resp := CategoryQuotasResponse{InboxID: inboxID, Quotas: make([]CategoryQuotaResponse, len(statuses))}
+	for i, s := range statuses {
+		resp.Quotas[i] = CategoryQuotaResponse{
+			LabelID:              s.Quota.LabelID,
+			SharePercent:         s.Quota.SharePercent,
+			UpdatedBy:            s.Quota.UpdatedBy,
+			UpdatedAt:            s.Quota.UpdatedAt,
+			Allocations:          s.Allocations,
+			TotalAllocations:     s.Total,
+			AchievedSharePercent: s.AchievedSharePercent,
+		}
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeInvalidCategoryQuotas = "INVALID_CATEGORY_QUOTAS"
+)
@@ -0,0 +1,41 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryQuotasRequest_Validate(t *testing.T) {
+	complaints, billing := uuid.NewString(), uuid.NewString()
+
+	tests := []struct {
+		name    string
+		req     dto.CategoryQuotasRequest
+		wantErr bool
+	}{
+		{"empty removes quotas", dto.CategoryQuotasRequest{}, false},
+		{"single quota", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: complaints, SharePercent: 30}}}, false},
+		{"exactly 100", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: complaints, SharePercent: 60}, {LabelID: billing, SharePercent: 40}}}, false},
+		{"over 100", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: complaints, SharePercent: 60}, {LabelID: billing, SharePercent: 41}}}, true},
+		{"zero share", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: complaints, SharePercent: 0}}}, true},
+		{"invalid label", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: "complaints", SharePercent: 30}}}, true},
+		{"duplicate label", dto.CategoryQuotasRequest{Quotas: []dto.CategoryQuotaItem{{LabelID: complaints, SharePercent: 30}, {LabelID: complaints, SharePercent: 20}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+
+	item := dto.CategoryQuotaItem{LabelID: complaints, SharePercent: 30}
+	assert.Equal(t, complaints, item.GetLabelID().String())
+}
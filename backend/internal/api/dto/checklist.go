@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Checklist Template Request ====================
+
+// ChecklistTemplateRequest replaces an inbox's checklist template; no items,
+// no pinned note and strict false removes it
+type ChecklistTemplateRequest struct {
+	Items      []string `json:"items" validate:"max=20"`
+	PinnedNote *string  `json:"pinned_note" validate:"max=2000"`
+	Strict     bool     `json:"strict"`
+}
+
+func (r *ChecklistTemplateRequest) Validate() []string {
+	errs := validate.Struct(r)
+	seen := make(map[string]bool, len(r.Items))
+	for _, item := range r.Items {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "":
+			errs = append(errs, "items must not be blank")
+		case len([]rune(item)) > domain.MaxChecklistItemLength:
+			errs = append(errs, fmt.Sprintf("items must be at most %d characters", domain.MaxChecklistItemLength))
+		case seen[item]:
+			errs = append(errs, "item "+item+" is listed more than once")
+		}
+		seen[item] = true
+	}
+	return errs
+}
+
+// ==================== Checklist Item Request ====================
+
+type ChecklistItemRequest struct {
+	Completed *bool `json:"completed" validate:"required"`
+}
+
+func (r *ChecklistItemRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Checklist Responses ====================
+
+type ChecklistTemplateResponse struct {
+	InboxID    uuid.UUID  `json:"inbox_id"`
+	Items      []string   `json:"items"`
+	PinnedNote *string    `json:"pinned_note"`
+	Strict     bool       `json:"strict"`
+	UpdatedBy  *uuid.UUID `json:"updated_by"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func NewChecklistTemplateResponse(t *domain.ChecklistTemplate) ChecklistTemplateResponse {
+	items := t.Items
+	if items == nil {
+		items = []string{}
+	}
+	return ChecklistTemplateResponse{
+		InboxID:    t.InboxID,
+		Items:      items,
+		PinnedNote: t.PinnedNote,
+		Strict:     t.Strict,
+		UpdatedBy:  t.UpdatedBy,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+type ChecklistItemResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Position    int        `json:"position"`
+	Label       string     `json:"label"`
+	Completed   bool       `json:"completed"`
+	CompletedBy *uuid.UUID `json:"completed_by"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type PinnedNoteResponse struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ConversationChecklistResponse struct {
+	ConversationID uuid.UUID               `json:"conversation_id"`
+	Items          []ChecklistItemResponse `json:"items"`
+	PinnedNote     *PinnedNoteResponse     `json:"pinned_note"`
+	Complete       bool                    `json:"complete"`
+	Strict         bool                    `json:"strict"`
+}
+
+func NewConversationChecklistResponse(c *domain.ConversationChecklist) ConversationChecklistResponse {
+	resp := ConversationChecklistResponse{
+		ConversationID: c.ConversationID,
+		Items:          make([]ChecklistItemResponse, len(c.Items)),
+		Complete:       c.Complete(),
+		Strict:         c.Strict,
+	}
+	for i, item := range c.Items {
+		resp.Items[i] = ChecklistItemResponse{
+			ID:          item.ID,
+			Position:    item.Position,
+			Label:       item.Label,
+			Completed:   item.CompletedAt != nil,
+			CompletedBy: item.CompletedBy,
+			CompletedAt: item.CompletedAt,
+		}
+	}
+	if c.PinnedNote != nil {
+		resp.PinnedNote = &PinnedNoteResponse{Body: c.PinnedNote.Body, CreatedAt: c.PinnedNote.CreatedAt}
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeInvalidChecklistTemplate = "INVALID_CHECKLIST_TEMPLATE"
+	ErrCodeChecklistItemNotFound    = "CHECKLIST_ITEM_NOT_FOUND"
+	ErrCodeChecklistIncomplete      = "CHECKLIST_INCOMPLETE"
+)
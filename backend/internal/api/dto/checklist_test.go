@@ -0,0 +1,72 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklistTemplateRequest_Validate(t *testing.T) {
+	note := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		req     dto.ChecklistTemplateRequest
+		wantErr bool
+	}{
+		{"empty removes the template", dto.ChecklistTemplateRequest{}, false},
+		{"items and note", dto.ChecklistTemplateRequest{Items: []string{"Verify identity", "Confirm order number"}, PinnedNote: note("Check the order first"), Strict: true}, false},
+		{"blank item", dto.ChecklistTemplateRequest{Items: []string{" "}}, true},
+		{"duplicate item", dto.ChecklistTemplateRequest{Items: []string{"Verify identity", "Verify identity "}}, true},
+		{"too long item", dto.ChecklistTemplateRequest{Items: []string{strings.Repeat("x", domain.MaxChecklistItemLength+1)}}, true},
+		{"too many items", dto.ChecklistTemplateRequest{Items: make([]string, domain.MaxChecklistItems+1)}, true},
+		{"too long note", dto.ChecklistTemplateRequest{PinnedNote: note(strings.Repeat("x", domain.MaxPinnedNoteLength+1))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestChecklistItemRequest_Validate(t *testing.T) {
+	done := true
+	assert.Empty(t, (&dto.ChecklistItemRequest{Completed: &done}).Validate())
+	assert.NotEmpty(t, (&dto.ChecklistItemRequest{}).Validate())
+}
+
+func TestNewConversationChecklistResponse(t *testing.T) {
+	by, now := uuid.New(), time.Now()
+	checklist := &domain.ConversationChecklist{
+		ConversationID: uuid.New(),
+		Items: []*domain.ChecklistItem{
+			{ID: uuid.New(), Position: 1, Label: "Verify identity", CompletedBy: &by, CompletedAt: &now},
+			{ID: uuid.New(), Position: 2, Label: "Confirm order number"},
+		},
+		PinnedNote: &domain.ConversationNote{Body: "VIP customer", CreatedAt: now},
+		Strict:     true,
+	}
+
+	resp := dto.NewConversationChecklistResponse(checklist)
+	assert.False(t, resp.Complete)
+	assert.True(t, resp.Strict)
+	assert.True(t, resp.Items[0].Completed)
+	assert.False(t, resp.Items[1].Completed)
+	assert.Equal(t, "VIP customer", resp.PinnedNote.Body)
+
+	empty := dto.NewConversationChecklistResponse(&domain.ConversationChecklist{ConversationID: uuid.New()})
+	assert.True(t, empty.Complete)
+	assert.NotNil(t, empty.Items)
+	assert.Nil(t, empty.PinnedNote)
+}
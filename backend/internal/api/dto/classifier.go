@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Update Classifier Request ====================
+
+type UpdateClassifierRequest struct {
+	EndpointURL string `json:"endpoint_url" validate:"required"`
+	Enabled     *bool  `json:"enabled" validate:"required"`
+}
+
+func (r *UpdateClassifierRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if endpoint := strings.TrimSpace(r.EndpointURL); endpoint != "" {
+		errs = appendURLError(errs, "endpoint_url", endpoint)
+	}
+	return errs
+}
+
+// Endpoint returns the trimmed endpoint URL
+func (r *UpdateClassifierRequest) Endpoint() string {
+	return strings.TrimSpace(r.EndpointURL)
+}
+
+// ==================== Classifier Response ====================
+
+type ClassifierResponse struct {
+	EndpointURL string     `json:"endpoint_url"`
+	Enabled     bool       `json:"enabled"`
+	UpdatedBy   *uuid.UUID `json:"updated_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func NewClassifierResponse(c *domain.TenantClassifier) ClassifierResponse {
+	return ClassifierResponse{
+		EndpointURL: c.EndpointURL,
+		Enabled:     c.IsEnabled,
+		UpdatedBy:   c.UpdatedBy,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeClassifierNotConfigured = "CLASSIFIER_NOT_CONFIGURED"
+)
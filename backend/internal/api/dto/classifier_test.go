@@ -0,0 +1,52 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestUpdateClassifierRequest_Validate(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name     string
+		req      dto.UpdateClassifierRequest
+		errCount int
+	}{
+		{
+			name:     "valid request",
+			req:      dto.UpdateClassifierRequest{EndpointURL: "https://ml.example.com/classify", Enabled: &enabled},
+			errCount: 0,
+		},
+		{
+			name:     "missing endpoint",
+			req:      dto.UpdateClassifierRequest{Enabled: &enabled},
+			errCount: 1,
+		},
+		{
+			name:     "relative endpoint",
+			req:      dto.UpdateClassifierRequest{EndpointURL: "/classify", Enabled: &enabled},
+			errCount: 1,
+		},
+		{
+			name:     "private network endpoint",
+			req:      dto.UpdateClassifierRequest{EndpointURL: "http://192.168.1.20:9000/classify", Enabled: &enabled},
+			errCount: 1,
+		},
+		{
+			name:     "missing enabled",
+			req:      dto.UpdateClassifierRequest{EndpointURL: "https://ml.example.com/classify"},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
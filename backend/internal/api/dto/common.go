@@ -5,7 +5,6 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -69,22 +68,6 @@ func ParseJSON[T any](r *http.Request) (*T, error) {
 	return &req, nil
 }
 
-// ==================== String Validation ====================
-
-func ValidateRequired(value, fieldName string) error {
-	if strings.TrimSpace(value) == "" {
-		return errors.New(fieldName + " is required")
-	}
-	return nil
-}
-
-func ValidateMaxLength(value string, max int, fieldName string) error {
-	if len(value) > max {
-		return errors.New(fieldName + " exceeds maximum length")
-	}
-	return nil
-}
-
 // ==================== List Response ====================
 
 type ListMeta struct {
@@ -0,0 +1,278 @@
+package dto_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// enumContract ties a domain enum to the DTOs exposing it. The values come
+// from the domain source, so a value added there fails here until the
+// validators, the responses and the OpenAPI spec agree with it.
+type enumContract struct {
+	domainType string
+	isValid    func(string) bool
+	// validate runs the request validator accepting the value
+	validate func(string) []string
+	// render maps the domain value to its response field; nil when the
+	// handler writes it without a dto constructor
+	render func(string) string
+	// listsValues reports whether the validation message names every value
+	listsValues bool
+}
+
+var enumContracts = []enumContract{
+	{
+		domainType: "ConversationState",
+		isValid:    func(v string) bool { return domain.ConversationState(v).IsValid() },
+		validate: func(v string) []string {
+			req := dto.ListConversationsRequest{State: &v, Sort: dto.SortNewest, PerPage: 10}
+			return req.Validate()
+		},
+		render: func(v string) string {
+			return dto.NewConversationResponse(&domain.ConversationRef{State: domain.ConversationState(v)}).State
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "OperatorRole",
+		isValid:    func(v string) bool { return domain.OperatorRole(v).IsValid() },
+		validate: func(v string) []string {
+			errs := (&dto.CreateOperatorRequest{Role: v}).Validate()
+			errs = append(errs, (&dto.UpdateOperatorRequest{Role: v}).Validate()...)
+			return append(errs, (&dto.CreateAPIKeyRequest{Name: "key", Role: v}).Validate()...)
+		},
+		render: func(v string) string {
+			return dto.NewOperatorResponse(&domain.Operator{Role: domain.OperatorRole(v)}).Role
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "OperatorStatusType",
+		isValid:    func(v string) bool { return domain.OperatorStatusType(v).IsValid() },
+		validate: func(v string) []string {
+			return (&dto.UpdateStatusRequest{Status: v}).Validate()
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "VacationDrain",
+		isValid:    func(v string) bool { return domain.VacationDrain(v).IsValid() },
+		validate: func(v string) []string {
+			req := dto.StartVacationRequest{Drain: v}
+			if domain.VacationDrain(v) == domain.VacationDrainColleagues {
+				req.ColleagueIDs = []uuid.UUID{uuid.New()}
+			}
+			return req.Validate()
+		},
+		render: func(v string) string {
+			return dto.NewVacationResponse(&domain.OperatorVacation{Drain: domain.VacationDrain(v)}).Drain
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "AllocationEngine",
+		isValid:    func(v string) bool { return domain.AllocationEngine(v).IsValid() },
+		validate: func(v string) []string {
+			return (&dto.UpdateTenantSettingsRequest{AllocationEngine: &v}).Validate()
+		},
+		render: func(v string) string {
+			return dto.NewTenantResponse(&domain.Tenant{AllocationEngine: domain.AllocationEngine(v)}).AllocationEngine
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "DevicePlatform",
+		isValid:    func(v string) bool { return domain.DevicePlatform(v).IsValid() },
+		validate: func(v string) []string {
+			return (&dto.RegisterDeviceRequest{Platform: v, Token: "token"}).Validate()
+		},
+		render: func(v string) string {
+			return dto.NewDeviceResponse(&domain.OperatorDevice{Platform: domain.DevicePlatform(v)}).Platform
+		},
+		listsValues: true,
+	},
+	{
+		domainType: "EventType",
+		isValid:    func(v string) bool { return domain.EventType(v).IsValid() },
+		validate: func(v string) []string {
+			req := dto.CreateWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{v}}
+			return req.Validate()
+		},
+		render: func(v string) string {
+			req := dto.CreateWebhookRequest{EventTypes: []string{v}}
+			return string(req.ToEventTypes()[0])
+		},
+	},
+}
+
+func TestEnumContracts(t *testing.T) {
+	specEnums := openAPIEnums(t)
+
+	for _, c := range enumContracts {
+		t.Run(c.domainType, func(t *testing.T) {
+			values := domainEnumValues(t, c.domainType)
+			if len(values) == 0 {
+				t.Fatalf("no %s constants found in the domain package", c.domainType)
+			}
+
+			for _, v := range values {
+				if !c.isValid(v) {
+					t.Errorf("%s %q is declared but IsValid rejects it", c.domainType, v)
+				}
+				if errs := c.validate(v); len(errs) > 0 {
+					t.Errorf("request validation rejects %s %q: %v", c.domainType, v, errs)
+				}
+				if c.render != nil {
+					if got := c.render(v); got != v || !c.isValid(got) {
+						t.Errorf("response renders %s %q as %q", c.domainType, v, got)
+					}
+				}
+			}
+
+			errs := c.validate("NOT_A_VALUE")
+			if len(errs) == 0 {
+				t.Errorf("request validation accepts an unknown %s", c.domainType)
+			}
+			if c.listsValues {
+				message := strings.Join(errs, "; ")
+				for _, v := range values {
+					if !strings.Contains(message, v) {
+						t.Errorf("validation message %q does not list %q", message, v)
+					}
+				}
+			}
+
+			// An enum mostly of these values declares the type; stray
+			// shared values such as an audit action do not
+			matched := 0
+			for _, enum := range specEnums {
+				if 2*overlap(enum, values) <= len(enum) {
+					continue
+				}
+				matched++
+				if !sameSet(enum, values) {
+					t.Errorf("openapi enum %v does not match the domain values %v", enum, values)
+				}
+			}
+			if matched == 0 {
+				t.Errorf("openapi.yaml declares no enum for %s", c.domainType)
+			}
+		})
+	}
+}
+
+// domainEnumValues returns the string constants declared with the type in
+// the domain package
+func domainEnumValues(t *testing.T, typeName string) []string {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../../domain", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parse domain package: %v", err)
+	}
+
+	var values []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+					ident, ok := vs.Type.(*ast.Ident)
+					if !ok || ident.Name != typeName {
+						continue
+					}
+					for _, value := range vs.Values {
+						lit, ok := value.(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							continue
+						}
+						s, err := strconv.Unquote(lit.Value)
+						if err != nil {
+							t.Fatalf("unquote %s: %v", lit.Value, err)
+						}
+						values = append(values, s)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// openAPIEnums returns every enum of the spec, inline or as a block list.
+// The spec is read as text: the module carries no YAML parser of its own.
+func openAPIEnums(t *testing.T) [][]string {
+	t.Helper()
+	data, err := os.ReadFile("../../../api/openapi.yaml")
+	if err != nil {
+		t.Fatalf("read openapi.yaml: %v", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var enums [][]string
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "enum:") {
+			continue
+		}
+		rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "enum:"))
+		var enum []string
+		if strings.HasPrefix(rest, "[") {
+			for _, v := range strings.Split(strings.Trim(rest, "[]"), ",") {
+				enum = append(enum, strings.Trim(strings.TrimSpace(v), `'"`))
+			}
+		} else {
+			for i+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i+1]), "- ") {
+				i++
+				enum = append(enum, strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), "- ")), `'"`))
+			}
+		}
+		enums = append(enums, enum)
+	}
+	return enums
+}
+
+func overlap(a, b []string) int {
+	n := 0
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func sameSet(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, x := range a {
+		set[x] = true
+	}
+	if len(set) != len(b) {
+		return false
+	}
+	for _, y := range b {
+		if !set[y] {
+			return false
+		}
+	}
+	return true
+}
@@ -3,12 +3,16 @@ package dto
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+	"github.com/shopspring/decimal"
 )
 
 // ==================== Constants ====================
@@ -17,9 +21,13 @@ const (
 	SortNewest   = "newest"
 	SortOldest   = "oldest"
 	SortPriority = "priority"
+	// SortDue lists conversations with a due date, earliest due first
+	SortDue = "due"
 
 	MaxConversationsPerQuery = 100
 	DefaultPerPage           = 50
+
+	MaxBreakGlassReasonLength = 500
 )
 
 // ==================== Cursor ====================
@@ -27,6 +35,12 @@ const (
 type Cursor struct {
 	Timestamp time.Time `json:"ts"`
 	ID        uuid.UUID `json:"id"`
+	// Rank replaces Timestamp in full-text search results
+	Rank *float64 `json:"rank,omitempty"`
+	// AsOf is the time a workload is scored at. Urgency is the last score of
+	// a workload by urgency, whose Timestamp is then the creation time.
+	Urgency *float64   `json:"urgency,omitempty"`
+	AsOf    *time.Time `json:"as_of,omitempty"`
 }
 
 func EncodeCursor(ts time.Time, id uuid.UUID) string {
@@ -35,6 +49,13 @@ func EncodeCursor(ts time.Time, id uuid.UUID) string {
 	return base64.URLEncoding.EncodeToString(data)
 }
 
+// EncodeSearchCursor encodes the position after a full-text search hit
+func EncodeSearchCursor(rank float64, id uuid.UUID) string {
+	c := Cursor{ID: id, Rank: &rank}
+	data, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
 func DecodeCursor(encoded string) (*Cursor, error) {
 	data, err := base64.URLEncoding.DecodeString(encoded)
 	if err != nil {
@@ -55,6 +76,11 @@ type ListConversationsRequest struct {
 	InboxID    *uuid.UUID `json:"inbox_id,omitempty"`
 	OperatorID *uuid.UUID `json:"operator_id,omitempty"`
 	LabelID    *uuid.UUID `json:"label_id,omitempty"`
+	Category   *string    `json:"category,omitempty"`
+	Language   *string    `json:"language,omitempty"`
+	CustomerID *uuid.UUID `json:"customer_id,omitempty"`
+	// Overdue is "true" to list only open conversations past their due date
+	Overdue *string `json:"overdue,omitempty"`
 
 	// Sorting
 	Sort string `json:"sort"`
@@ -97,6 +123,33 @@ func ParseListConversationsRequest(r *http.Request) *ListConversationsRequest {
 		}
 	}
 
+	// Parse category filter
+	if category := r.URL.Query().Get("category"); category != "" {
+		category = strings.ToLower(strings.TrimSpace(category))
+		req.Category = &category
+	}
+
+	// Parse language filter
+	if language := r.URL.Query().Get("language"); language != "" {
+		if normalized, err := domain.NormalizeLanguage(language); err == nil {
+			language = normalized
+		}
+		req.Language = &language
+	}
+
+	// Parse customer_id filter
+	if customerIDStr := r.URL.Query().Get("customer_id"); customerIDStr != "" {
+		if id, err := uuid.Parse(customerIDStr); err == nil {
+			req.CustomerID = &id
+		}
+	}
+
+	// Parse overdue filter
+	if overdue := r.URL.Query().Get("overdue"); overdue != "" {
+		overdue = strings.ToLower(overdue)
+		req.Overdue = &overdue
+	}
+
 	// Normalize sort
 	if req.Sort == "" {
 		req.Sort = SortNewest
@@ -123,15 +176,39 @@ func (r *ListConversationsRequest) Validate() []string {
 		}
 	}
 
+	// Validate category
+	if r.Category != nil {
+		if _, err := domain.NormalizeCategory(*r.Category); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	// Validate language
+	if r.Language != nil {
+		if _, err := domain.NormalizeLanguage(*r.Language); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	// Validate overdue
+	if r.Overdue != nil && *r.Overdue != "true" && *r.Overdue != "false" {
+		errs = append(errs, "overdue must be true or false")
+	}
+
 	// Validate sort
 	sort := strings.ToLower(r.Sort)
-	if sort != SortNewest && sort != SortOldest && sort != SortPriority {
-		errs = append(errs, "sort must be newest, oldest, or priority")
+	if sort != SortNewest && sort != SortOldest && sort != SortPriority && sort != SortDue {
+		errs = append(errs, "sort must be newest, oldest, priority, or due")
 	}
 
 	return errs
 }
 
+// IsOverdue reports whether only overdue conversations are listed
+func (r *ListConversationsRequest) IsOverdue() bool {
+	return r.Overdue != nil && *r.Overdue == "true"
+}
+
 func (r *ListConversationsRequest) GetCursor() *Cursor {
 	if r.Cursor == "" {
 		return nil
@@ -143,34 +220,163 @@ func (r *ListConversationsRequest) GetCursor() *Cursor {
 	return cursor
 }
 
+// ==================== Get Request ====================
+
+// GetConversationRequest holds the query parameters of
+// GET /api/v1/conversations/{id}. Reason is the stated reason for break-glass
+// access, required by the service for conversations in restricted inboxes.
+type GetConversationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+func ParseGetConversationRequest(r *http.Request) *GetConversationRequest {
+	return &GetConversationRequest{
+		Reason: strings.TrimSpace(r.URL.Query().Get("reason")),
+	}
+}
+
+func (r *GetConversationRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
 // ==================== Search Request ====================
 
+// MinPhoneSearchDigits is the fewest digits a phone search takes; shorter
+// fragments match too much of a tenant's conversations to be useful
+const MinPhoneSearchDigits = 6
+
+// Bounds of the full-text query q
+const (
+	MinTextSearchLength = 2
+	MaxTextSearchLength = 200
+)
+
+// SearchConversationsRequest holds the parameters of GET /api/v1/search.
+// Phone may be any part of a number that starts or ends it, formatted or not.
+// Q searches external conversation IDs, customer names and notes; with both,
+// the phone narrows the text matches.
 type SearchConversationsRequest struct {
-	Phone string `json:"phone"`
+	Phone   string `json:"phone"`
+	Q       string `json:"q"`
+	Cursor  string `json:"cursor,omitempty"`
+	PerPage int    `json:"per_page"`
 }
 
 func ParseSearchRequest(r *http.Request) *SearchConversationsRequest {
+	perPage := ParsePagination(r).PerPage
+	if perPage > MaxConversationsPerQuery {
+		perPage = MaxConversationsPerQuery
+	}
+
 	return &SearchConversationsRequest{
-		Phone: r.URL.Query().Get("phone"),
+		Phone:   r.URL.Query().Get("phone"),
+		Q:       strings.TrimSpace(r.URL.Query().Get("q")),
+		Cursor:  r.URL.Query().Get("cursor"),
+		PerPage: perPage,
 	}
 }
 
 func (r *SearchConversationsRequest) Validate() []string {
 	var errs []string
-	if strings.TrimSpace(r.Phone) == "" {
-		errs = append(errs, "phone is required")
+	hasPhone := strings.TrimSpace(r.Phone) != ""
+	if !hasPhone && r.Q == "" {
+		errs = append(errs, "phone or q is required")
+	}
+	if hasPhone {
+		digits := r.PhoneDigits()
+		switch {
+		case len(digits) < MinPhoneSearchDigits:
+			errs = append(errs, fmt.Sprintf("phone must contain at least %d digits", MinPhoneSearchDigits))
+		case len(digits) > maxCustomerPhoneLength:
+			errs = append(errs, fmt.Sprintf("phone must contain at most %d digits", maxCustomerPhoneLength))
+		}
+	}
+	if r.Q != "" {
+		if n := utf8.RuneCountInString(r.Q); n < MinTextSearchLength || n > MaxTextSearchLength {
+			errs = append(errs, fmt.Sprintf("q must be between %d and %d characters", MinTextSearchLength, MaxTextSearchLength))
+		}
+	}
+	if r.Cursor != "" {
+		// A text search pages by rank, a phone search by time
+		cursor, err := DecodeCursor(r.Cursor)
+		if err != nil || (r.Q != "" && cursor.Rank == nil) {
+			errs = append(errs, "cursor is invalid")
+		}
 	}
 	return errs
 }
 
+// IsTextSearch reports whether the search has a full-text query
+func (r *SearchConversationsRequest) IsTextSearch() bool {
+	return r.Q != ""
+}
+
+// PhoneDigits returns the digits of the phone search, the form
+// conversation_refs.normalized_phone stores
+func (r *SearchConversationsRequest) PhoneDigits() string {
+	return strings.Map(func(c rune) rune {
+		if c >= '0' && c <= '9' {
+			return c
+		}
+		return -1
+	}, r.Phone)
+}
+
+// GetCursor assumes Validate has passed
+func (r *SearchConversationsRequest) GetCursor() *Cursor {
+	if r.Cursor == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(r.Cursor)
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
 // Normalize phone for search (remove spaces, ensure + prefix for international)
 func (r *SearchConversationsRequest) NormalizedPhone() string {
-	phone := strings.TrimSpace(r.Phone)
+	return normalizePhone(r.Phone)
+}
+
+func normalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
 	phone = strings.ReplaceAll(phone, " ", "")
 	phone = strings.ReplaceAll(phone, "-", "")
 	return phone
 }
 
+// ==================== Priority Override Request ====================
+
+// MaxPriorityOverride is the largest value priority_override can store
+const MaxPriorityOverride = 9999
+
+// PriorityOverrideRequest pins a conversation at priority_override; null
+// unpins it
+type PriorityOverrideRequest struct {
+	PriorityOverride *float64 `json:"priority_override" validate:"min=0,max=9999"`
+}
+
+func (r *PriorityOverrideRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// GetPriorityOverride returns the override, nil to unpin
+func (r *PriorityOverrideRequest) GetPriorityOverride() *decimal.Decimal {
+	if r.PriorityOverride == nil {
+		return nil
+	}
+	d := decimal.NewFromFloat(*r.PriorityOverride)
+	return &d
+}
+
+// ==================== Due Date Request ====================
+
+// DueDateRequest sets a conversation's due date to due_at; null clears it
+type DueDateRequest struct {
+	DueAt *time.Time `json:"due_at"`
+}
+
 // ==================== Conversation Response ====================
 
 type ConversationResponse struct {
@@ -184,10 +390,46 @@ type ConversationResponse struct {
 	LastMessageAt          time.Time      `json:"last_message_at"`
 	MessageCount           int            `json:"message_count"`
 	PriorityScore          float64        `json:"priority_score"`
+	PriorityOverride       *float64       `json:"priority_override"`
 	CreatedAt              time.Time      `json:"created_at"`
 	UpdatedAt              time.Time      `json:"updated_at"`
 	ResolvedAt             *time.Time     `json:"resolved_at"`
+	ReopenedCount          int            `json:"reopened_count"`
+	IsFirstContact         bool           `json:"is_first_contact"`
+	Category               *string        `json:"category"`
+	Language               *string        `json:"language"`
+	CustomerID             *uuid.UUID     `json:"customer_id"`
+	SLABreachedAt          *time.Time     `json:"sla_breached_at"`
+	SnoozedUntil           *time.Time     `json:"snoozed_until"`
+	SnoozeOperatorID       *uuid.UUID     `json:"snooze_operator_id"`
+	DueAt                  *time.Time     `json:"due_at"`
+	OverdueAt              *time.Time     `json:"overdue_at"`
 	Labels                 []LabelSummary `json:"labels,omitempty"`
+	// HandoverNote is the latest handover note addressed to the assignee,
+	// only on GET /conversations/{id}
+	HandoverNote *ConversationNoteResponse `json:"handover_note,omitempty"`
+	// Unread is whether the calling operator has customer messages to view,
+	// only in lists requested with an operator identity
+	Unread *bool `json:"unread,omitempty"`
+}
+
+type ConversationNoteResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	AuthorID  *uuid.UUID `json:"author_id"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewConversationNoteResponse(n *domain.ConversationNote) *ConversationNoteResponse {
+	if n == nil {
+		return nil
+	}
+	return &ConversationNoteResponse{
+		ID:        n.ID,
+		AuthorID:  n.AuthorID,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+	}
 }
 
 type LabelSummary struct {
@@ -198,6 +440,11 @@ type LabelSummary struct {
 
 func NewConversationResponse(c *domain.ConversationRef) ConversationResponse {
 	priorityScore, _ := c.PriorityScore.Float64()
+	var priorityOverride *float64
+	if c.PriorityOverride != nil {
+		f, _ := c.PriorityOverride.Float64()
+		priorityOverride = &f
+	}
 	return ConversationResponse{
 		ID:                     c.ID,
 		TenantID:               c.TenantID,
@@ -209,24 +456,40 @@ func NewConversationResponse(c *domain.ConversationRef) ConversationResponse {
 		LastMessageAt:          c.LastMessageAt,
 		MessageCount:           int(c.MessageCount),
 		PriorityScore:          priorityScore,
+		PriorityOverride:       priorityOverride,
 		CreatedAt:              c.CreatedAt,
 		UpdatedAt:              c.UpdatedAt,
 		ResolvedAt:             c.ResolvedAt,
+		ReopenedCount:          int(c.ReopenedCount),
+		IsFirstContact:         c.IsFirstContact,
+		Category:               c.Category,
+		Language:               c.Language,
+		CustomerID:             c.CustomerID,
+		SLABreachedAt:          c.SLABreachedAt,
+		SnoozedUntil:           c.SnoozedUntil,
+		SnoozeOperatorID:       c.SnoozeOperatorID,
+		DueAt:                  c.DueAt,
+		OverdueAt:              c.OverdueAt,
 		Labels:                 []LabelSummary{}, // Populated separately if needed
 	}
 }
 
 func NewConversationResponseWithLabels(c *domain.ConversationRef, labels []*domain.Label) ConversationResponse {
 	resp := NewConversationResponse(c)
-	resp.Labels = make([]LabelSummary, len(labels))
+	resp.Labels = newLabelSummaries(labels)
+	return resp
+}
+
+func newLabelSummaries(labels []*domain.Label) []LabelSummary {
+	summaries := make([]LabelSummary, len(labels))
 	for i, l := range labels {
-		resp.Labels[i] = LabelSummary{
+		summaries[i] = LabelSummary{
 			ID:    l.ID,
 			Name:  l.Name,
 			Color: l.Color,
 		}
 	}
-	return resp
+	return summaries
 }
 
 // ==================== List Response ====================
@@ -243,6 +506,13 @@ type ConversationListResponse struct {
 }
 
 func NewConversationListResponse(conversations []*domain.ConversationRef, perPage int) ConversationListResponse {
+	return NewSortedConversationListResponse(conversations, perPage, SortNewest)
+}
+
+// NewSortedConversationListResponse builds the page of a list in the given
+// sort order; the next cursor holds the due date for SortDue and the last
+// message time otherwise
+func NewSortedConversationListResponse(conversations []*domain.ConversationRef, perPage int, sort string) ConversationListResponse {
 	items := make([]ConversationResponse, len(conversations))
 	for i, c := range conversations {
 		items[i] = NewConversationResponse(c)
@@ -259,34 +529,112 @@ func NewConversationListResponse(conversations []*domain.ConversationRef, perPag
 	// Generate next cursor from last item
 	if len(conversations) > 0 && resp.Meta.HasMore {
 		last := conversations[len(conversations)-1]
-		resp.Meta.NextCursor = EncodeCursor(last.LastMessageAt, last.ID)
+		if sort == SortDue && last.DueAt != nil {
+			resp.Meta.NextCursor = EncodeCursor(*last.DueAt, last.ID)
+		} else {
+			resp.Meta.NextCursor = EncodeCursor(last.LastMessageAt, last.ID)
+		}
 	}
 
 	return resp
 }
 
+// SetUnread flags each conversation with its entry in flags, keyed by ID
+func (r *ConversationListResponse) SetUnread(flags map[uuid.UUID]bool) {
+	for i := range r.Conversations {
+		unread := flags[r.Conversations[i].ID]
+		r.Conversations[i].Unread = &unread
+	}
+}
+
+// SetLabels sets each conversation's labels to its entry in labels, keyed by ID
+func (r *ConversationListResponse) SetLabels(labels map[uuid.UUID][]*domain.Label) {
+	for i := range r.Conversations {
+		r.Conversations[i].Labels = newLabelSummaries(labels[r.Conversations[i].ID])
+	}
+}
+
+// ==================== Mark Read Response ====================
+
+type MarkReadResponse struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	LastReadAt     time.Time `json:"last_read_at"`
+}
+
+func NewMarkReadResponse(r *domain.ConversationRead) MarkReadResponse {
+	return MarkReadResponse{
+		ConversationID: r.ConversationID,
+		LastReadAt:     r.LastReadAt,
+	}
+}
+
 // ==================== Search Response ====================
 
 type SearchMeta struct {
-	Query string `json:"query"`
-	Count int    `json:"count"`
+	Query      string `json:"query"`
+	Count      int    `json:"count"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SearchHitResponse is a conversation found by search; Rank and
+// MatchedFields are only set by a full-text search
+type SearchHitResponse struct {
+	ConversationResponse
+	Rank          *float64 `json:"rank,omitempty"`
+	MatchedFields []string `json:"matched_fields,omitempty"`
 }
 
 type SearchConversationsResponse struct {
-	Conversations []ConversationResponse `json:"conversations"`
-	Meta          SearchMeta             `json:"meta"`
+	Conversations []SearchHitResponse `json:"conversations"`
+	Meta          SearchMeta          `json:"meta"`
 }
 
-func NewSearchResponse(conversations []*domain.ConversationRef, query string) SearchConversationsResponse {
-	items := make([]ConversationResponse, len(conversations))
-	for i, c := range conversations {
-		items[i] = NewConversationResponse(c)
+func NewSearchResponse(conversations []*domain.ConversationRef, query string, perPage int) SearchConversationsResponse {
+	list := NewConversationListResponse(conversations, perPage)
+	items := make([]SearchHitResponse, len(list.Conversations))
+	for i, c := range list.Conversations {
+		items[i] = SearchHitResponse{ConversationResponse: c}
 	}
 	return SearchConversationsResponse{
 		Conversations: items,
 		Meta: SearchMeta{
-			Query: query,
-			Count: len(items),
+			Query:      query,
+			Count:      list.Meta.Count,
+			HasMore:    list.Meta.HasMore,
+			NextCursor: list.Meta.NextCursor,
+		},
+	}
+}
+
+// NewTextSearchResponse builds the response of a full-text search; the next
+// cursor carries the last hit's rank
+func NewTextSearchResponse(hits []*domain.ConversationSearchHit, query string, perPage int) SearchConversationsResponse {
+	items := make([]SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		rank := hit.Rank
+		fields := make([]string, len(hit.MatchedFields))
+		for j, field := range hit.MatchedFields {
+			fields[j] = string(field)
+		}
+		items[i] = SearchHitResponse{
+			ConversationResponse: NewConversationResponse(hit.Conversation),
+			Rank:                 &rank,
+			MatchedFields:        fields,
+		}
+	}
+
+	resp := SearchConversationsResponse{
+		Conversations: items,
+		Meta: SearchMeta{
+			Query:   query,
+			Count:   len(items),
+			HasMore: len(items) >= perPage,
 		},
 	}
+	if len(hits) > 0 && resp.Meta.HasMore {
+		last := hits[len(hits)-1]
+		resp.Meta.NextCursor = EncodeSearchCursor(last.Rank, last.Conversation.ID)
+	}
+	return resp
 }
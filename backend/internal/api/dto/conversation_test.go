@@ -2,11 +2,14 @@ package dto_test
 
 import (
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
 )
 
 func TestEncodeCursor(t *testing.T) {
@@ -38,6 +41,31 @@ func TestDecodeCursor_Invalid(t *testing.T) {
 	}
 }
 
+func FuzzDecodeCursor(f *testing.F) {
+	seeds := testutil.NewFactory(1)
+	for i := 0; i < 8; i++ {
+		f.Add(dto.EncodeCursor(seeds.Time(), seeds.UUID()))
+	}
+	f.Add("")
+	f.Add("invalid-cursor")
+	f.Add("eyJ0cyI6MX0=") // {"ts":1}
+
+	f.Fuzz(func(t *testing.T, encoded string) {
+		cursor, err := dto.DecodeCursor(encoded)
+		if err != nil {
+			return
+		}
+		// Whatever decodes survives a round trip
+		again, err := dto.DecodeCursor(dto.EncodeCursor(cursor.Timestamp, cursor.ID))
+		if err != nil {
+			t.Fatalf("re-encoded cursor does not decode: %v", err)
+		}
+		if !again.Timestamp.Equal(cursor.Timestamp) || again.ID != cursor.ID {
+			t.Errorf("round trip changed the cursor: got %+v, want %+v", again, cursor)
+		}
+	})
+}
+
 func TestListConversationsRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -48,6 +76,7 @@ func TestListConversationsRequest_Validate(t *testing.T) {
 		{"valid newest", nil, "newest", false},
 		{"valid oldest", nil, "oldest", false},
 		{"valid priority", nil, "priority", false},
+		{"valid due", nil, "due", false},
 		{"valid QUEUED state", strPtr("QUEUED"), "newest", false},
 		{"valid ALLOCATED state", strPtr("ALLOCATED"), "newest", false},
 		{"valid RESOLVED state", strPtr("RESOLVED"), "newest", false},
@@ -81,6 +110,9 @@ func TestSearchConversationsRequest_Validate(t *testing.T) {
 		{"valid phone", "+1234567890", false},
 		{"empty phone", "", true},
 		{"whitespace only", "   ", true},
+		{"last digits", "001111", false},
+		{"too few digits", "+1-555", true},
+		{"too many digits", strings.Repeat("1", 21), true},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +129,88 @@ func TestSearchConversationsRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestSearchConversationsRequest_ValidateText(t *testing.T) {
+	textCursor := dto.EncodeSearchCursor(0.5, uuid.New())
+	timeCursor := dto.EncodeCursor(time.Now(), uuid.New())
+
+	tests := []struct {
+		name    string
+		req     dto.SearchConversationsRequest
+		wantErr bool
+	}{
+		{"query only", dto.SearchConversationsRequest{Q: "refund"}, false},
+		{"query and phone", dto.SearchConversationsRequest{Q: "refund", Phone: "0001111"}, false},
+		{"query too short", dto.SearchConversationsRequest{Q: "r"}, true},
+		{"query too long", dto.SearchConversationsRequest{Q: strings.Repeat("a", dto.MaxTextSearchLength+1)}, true},
+		{"query with invalid phone", dto.SearchConversationsRequest{Q: "refund", Phone: "555"}, true},
+		{"query with text cursor", dto.SearchConversationsRequest{Q: "refund", Cursor: textCursor}, false},
+		{"query with time cursor", dto.SearchConversationsRequest{Q: "refund", Cursor: timeCursor}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestParseSearchRequest_TrimsQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/search?q=++refund+order+", nil)
+	parsed := dto.ParseSearchRequest(req)
+	if parsed.Q != "refund order" || !parsed.IsTextSearch() {
+		t.Errorf("q: got %q", parsed.Q)
+	}
+}
+
+func TestNewTextSearchResponse_NextCursor(t *testing.T) {
+	hits := []*domain.ConversationSearchHit{
+		{Conversation: &domain.ConversationRef{ID: uuid.New()}, Rank: 0.9, MatchedFields: []domain.SearchField{domain.SearchFieldNotes}},
+		{Conversation: &domain.ConversationRef{ID: uuid.New()}, Rank: 0.25, MatchedFields: []domain.SearchField{domain.SearchFieldCustomerName}},
+	}
+
+	resp := dto.NewTextSearchResponse(hits, "refund", 2)
+	if len(resp.Conversations) != 2 || *resp.Conversations[0].Rank != 0.9 {
+		t.Fatalf("unexpected conversations: %+v", resp.Conversations)
+	}
+	if got := resp.Conversations[1].MatchedFields; len(got) != 1 || got[0] != "customer_name" {
+		t.Errorf("matched_fields: got %v", got)
+	}
+
+	cursor, err := dto.DecodeCursor(resp.Meta.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if cursor.Rank == nil || *cursor.Rank != 0.25 || cursor.ID != hits[1].Conversation.ID {
+		t.Errorf("cursor: got %+v", cursor)
+	}
+
+	if resp := dto.NewTextSearchResponse(hits, "refund", 10); resp.Meta.HasMore || resp.Meta.NextCursor != "" {
+		t.Errorf("expected no next cursor on the last page, got %+v", resp.Meta)
+	}
+}
+
+func TestGetConversationRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/conversations/x?reason=+customer+complaint+", nil)
+	parsed := dto.ParseGetConversationRequest(req)
+	if parsed.Reason != "customer complaint" {
+		t.Errorf("reason: got %q, want %q", parsed.Reason, "customer complaint")
+	}
+	if errs := parsed.Validate(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	parsed.Reason = strings.Repeat("a", dto.MaxBreakGlassReasonLength+1)
+	if errs := parsed.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error for an overlong reason, got %v", errs)
+	}
+}
+
 func TestSearchConversationsRequest_NormalizedPhone(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -117,6 +231,26 @@ func TestSearchConversationsRequest_NormalizedPhone(t *testing.T) {
 	}
 }
 
+func TestSearchConversationsRequest_PhoneDigits(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"+1 (555) 000-1111", "15550001111"},
+		{"  0001111 ", "0001111"},
+		{"+", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			req := &dto.SearchConversationsRequest{Phone: tt.input}
+			if got := req.PhoneDigits(); got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseListConversationsRequest(t *testing.T) {
 	req := httptest.NewRequest("GET", "/conversations?state=QUEUED&sort=priority&per_page=25", nil)
 	parsed := dto.ParseListConversationsRequest(req)
@@ -132,6 +266,62 @@ func TestParseListConversationsRequest(t *testing.T) {
 	}
 }
 
+func TestParseListConversationsRequest_Language(t *testing.T) {
+	req := httptest.NewRequest("GET", "/conversations?language=es-MX", nil)
+	parsed := dto.ParseListConversationsRequest(req)
+	if parsed.Language == nil || *parsed.Language != "es" {
+		t.Errorf("language: got %v, want es", parsed.Language)
+	}
+	if errs := parsed.Validate(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	req = httptest.NewRequest("GET", "/conversations?language=spanish", nil)
+	if errs := dto.ParseListConversationsRequest(req).Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error for an invalid language, got %v", errs)
+	}
+}
+
+func TestParseListConversationsRequest_Overdue(t *testing.T) {
+	req := httptest.NewRequest("GET", "/conversations?overdue=TRUE&sort=due", nil)
+	parsed := dto.ParseListConversationsRequest(req)
+	if !parsed.IsOverdue() {
+		t.Error("expected the overdue filter")
+	}
+	if errs := parsed.Validate(); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	req = httptest.NewRequest("GET", "/conversations?overdue=yes", nil)
+	if errs := dto.ParseListConversationsRequest(req).Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error for an invalid overdue, got %v", errs)
+	}
+	if dto.ParseListConversationsRequest(httptest.NewRequest("GET", "/conversations", nil)).IsOverdue() {
+		t.Error("overdue should be off by default")
+	}
+}
+
+func TestNewSortedConversationListResponse_DueCursor(t *testing.T) {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	conv := domain.NewConversationRef(uuid.New(), uuid.New(), "ext-1", "+1234567890")
+	conv.DueAt = &due
+
+	resp := dto.NewSortedConversationListResponse([]*domain.ConversationRef{conv}, 1, dto.SortDue)
+	cursor, err := dto.DecodeCursor(resp.Meta.NextCursor)
+	if err != nil {
+		t.Fatalf("next cursor does not decode: %v", err)
+	}
+	if !cursor.Timestamp.Equal(due) || cursor.ID != conv.ID {
+		t.Errorf("expected the due date cursor, got %+v", cursor)
+	}
+
+	resp = dto.NewConversationListResponse([]*domain.ConversationRef{conv}, 1)
+	cursor, _ = dto.DecodeCursor(resp.Meta.NextCursor)
+	if !cursor.Timestamp.Equal(conv.LastMessageAt) {
+		t.Errorf("expected the last message cursor, got %+v", cursor)
+	}
+}
+
 func TestParseListConversationsRequest_Defaults(t *testing.T) {
 	req := httptest.NewRequest("GET", "/conversations", nil)
 	parsed := dto.ParseListConversationsRequest(req)
@@ -160,3 +350,73 @@ func TestParseListConversationsRequest_MaxPerPage(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestPriorityOverrideRequest_Validate(t *testing.T) {
+	value := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name     string
+		override *float64
+		wantErr  bool
+	}{
+		{"pin", value(5), false},
+		{"pin at zero", value(0), false},
+		{"unpin", nil, false},
+		{"negative", value(-1), true},
+		{"too large", value(dto.MaxPriorityOverride + 1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.PriorityOverrideRequest{PriorityOverride: tt.override}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+
+	req := &dto.PriorityOverrideRequest{PriorityOverride: value(2.5)}
+	if got := req.GetPriorityOverride(); got == nil || got.String() != "2.5" {
+		t.Errorf("expected override 2.5, got %v", got)
+	}
+}
+
+func TestConversationListResponse_SetUnread(t *testing.T) {
+	tenantID, inboxID := uuid.New(), uuid.New()
+	read := testutil.NewTestConversation(tenantID, inboxID)
+	unread := testutil.NewTestConversation(tenantID, inboxID)
+
+	resp := dto.NewConversationListResponse([]*domain.ConversationRef{read, unread}, 50)
+	if resp.Conversations[0].Unread != nil {
+		t.Fatal("expected no unread flag before SetUnread")
+	}
+
+	resp.SetUnread(map[uuid.UUID]bool{unread.ID: true})
+	if got := resp.Conversations[0].Unread; got == nil || *got {
+		t.Errorf("expected read conversation flagged false, got %v", got)
+	}
+	if got := resp.Conversations[1].Unread; got == nil || !*got {
+		t.Errorf("expected unread conversation flagged true, got %v", got)
+	}
+}
+
+func TestConversationListResponse_SetLabels(t *testing.T) {
+	tenantID, inboxID := uuid.New(), uuid.New()
+	labeled := testutil.NewTestConversation(tenantID, inboxID)
+	unlabeled := testutil.NewTestConversation(tenantID, inboxID)
+	label := testutil.NewTestLabel(tenantID, inboxID)
+
+	resp := dto.NewConversationListResponse([]*domain.ConversationRef{labeled, unlabeled}, 50)
+	resp.SetLabels(map[uuid.UUID][]*domain.Label{labeled.ID: {label}})
+
+	if got := resp.Conversations[0].Labels; len(got) != 1 || got[0].ID != label.ID || got[0].Name != label.Name {
+		t.Errorf("expected the attached label, got %v", got)
+	}
+	if got := resp.Conversations[1].Labels; len(got) != 0 {
+		t.Errorf("expected no labels, got %v", got)
+	}
+}
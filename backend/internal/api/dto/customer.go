@@ -0,0 +1,233 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Search Customers Request ====================
+
+// SearchCustomersRequest holds the query parameters of GET /api/v1/customers
+type SearchCustomersRequest struct {
+	// Query matches the start of the phone number or part of the name
+	Query   string
+	Cursor  string
+	PerPage int
+}
+
+func ParseSearchCustomersRequest(r *http.Request) *SearchCustomersRequest {
+	query := r.URL.Query()
+	return &SearchCustomersRequest{
+		Query:   strings.TrimSpace(query.Get("q")),
+		Cursor:  query.Get("cursor"),
+		PerPage: ParsePagination(r).PerPage,
+	}
+}
+
+func (r *SearchCustomersRequest) Validate() []string {
+	var errs []string
+	if utf8.RuneCountInString(r.Query) > domain.MaxCustomerNameLength {
+		errs = append(errs, fmt.Sprintf("q must be at most %d characters", domain.MaxCustomerNameLength))
+	}
+	if r.Cursor != "" {
+		if _, err := DecodeCursor(r.Cursor); err != nil {
+			errs = append(errs, "cursor is invalid")
+		}
+	}
+	return errs
+}
+
+// GetCursor assumes Validate has passed
+func (r *SearchCustomersRequest) GetCursor() *Cursor {
+	if r.Cursor == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(r.Cursor)
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
+// ==================== Customer Conversations Request ====================
+
+// maxCustomerPhoneLength is the length of customers.phone_number
+const maxCustomerPhoneLength = 20
+
+// CustomerConversationsRequest holds the parameters of
+// GET /api/v1/customers/{phone}/conversations
+type CustomerConversationsRequest struct {
+	Phone   string
+	Cursor  string
+	PerPage int
+}
+
+// ParseCustomerConversationsRequest reads the phone number from the path,
+// where clients may send the leading + escaped as %2B
+func ParseCustomerConversationsRequest(r *http.Request) *CustomerConversationsRequest {
+	phone := chi.URLParam(r, "phone")
+	if unescaped, err := url.PathUnescape(phone); err == nil {
+		phone = unescaped
+	}
+
+	perPage := ParsePagination(r).PerPage
+	if perPage > MaxConversationsPerQuery {
+		perPage = MaxConversationsPerQuery
+	}
+
+	return &CustomerConversationsRequest{
+		Phone:   normalizePhone(phone),
+		Cursor:  r.URL.Query().Get("cursor"),
+		PerPage: perPage,
+	}
+}
+
+func (r *CustomerConversationsRequest) Validate() []string {
+	var errs []string
+	if r.Phone == "" {
+		errs = append(errs, "phone is required")
+	} else if len(r.Phone) > maxCustomerPhoneLength {
+		errs = append(errs, fmt.Sprintf("phone must be at most %d characters", maxCustomerPhoneLength))
+	}
+	if r.Cursor != "" {
+		if _, err := DecodeCursor(r.Cursor); err != nil {
+			errs = append(errs, "cursor is invalid")
+		}
+	}
+	return errs
+}
+
+// GetCursor assumes Validate has passed
+func (r *CustomerConversationsRequest) GetCursor() *Cursor {
+	if r.Cursor == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(r.Cursor)
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
+// ==================== Update Customer Request ====================
+
+type UpdateCustomerRequest struct {
+	// Name replaces the customer's name; empty clears it
+	Name *string `json:"name" validate:"max=255"`
+	// Metadata replaces the customer's metadata
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func (r *UpdateCustomerRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.Name == nil && r.Metadata == nil {
+		errs = append(errs, "name or metadata is required")
+	}
+	if r.Metadata != nil && domain.CustomerMetadataSize(r.Metadata) > domain.MaxCustomerMetadataSize {
+		errs = append(errs, fmt.Sprintf("metadata must be at most %d bytes of JSON", domain.MaxCustomerMetadataSize))
+	}
+	return errs
+}
+
+// GetName returns the trimmed name
+func (r *UpdateCustomerRequest) GetName() *string {
+	if r.Name == nil {
+		return nil
+	}
+	name := strings.TrimSpace(*r.Name)
+	return &name
+}
+
+// ==================== Customer Response ====================
+
+type CustomerResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	PhoneNumber string                 `json:"phone_number"`
+	Name        *string                `json:"name"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+func NewCustomerResponse(c *domain.Customer) CustomerResponse {
+	return CustomerResponse{
+		ID:          c.ID,
+		PhoneNumber: c.PhoneNumber,
+		Name:        c.Name,
+		Metadata:    c.Metadata,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+type CustomerListMeta struct {
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Count      int    `json:"count"`
+}
+
+type CustomerListResponse struct {
+	Customers []CustomerResponse `json:"customers"`
+	Meta      CustomerListMeta   `json:"meta"`
+}
+
+func NewCustomerListResponse(customers []*domain.Customer, perPage int) CustomerListResponse {
+	items := make([]CustomerResponse, len(customers))
+	for i, c := range customers {
+		items[i] = NewCustomerResponse(c)
+	}
+
+	resp := CustomerListResponse{
+		Customers: items,
+		Meta: CustomerListMeta{
+			Count:   len(items),
+			HasMore: len(items) >= perPage,
+		},
+	}
+
+	if len(customers) > 0 && resp.Meta.HasMore {
+		last := customers[len(customers)-1]
+		resp.Meta.NextCursor = EncodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return resp
+}
+
+// ==================== Customer Conversations Response ====================
+
+// CustomerSummary identifies the customer of a timeline; the profile itself
+// is served to managers by GET /api/v1/customers/{id}
+type CustomerSummary struct {
+	ID          uuid.UUID `json:"id"`
+	PhoneNumber string    `json:"phone_number"`
+}
+
+type CustomerConversationsResponse struct {
+	Customer CustomerSummary `json:"customer"`
+	ConversationListResponse
+}
+
+func NewCustomerConversationsResponse(customer *domain.Customer, conversations []*domain.ConversationRef, perPage int) CustomerConversationsResponse {
+	return CustomerConversationsResponse{
+		Customer: CustomerSummary{
+			ID:          customer.ID,
+			PhoneNumber: customer.PhoneNumber,
+		},
+		ConversationListResponse: NewConversationListResponse(conversations, perPage),
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeCustomerNotFound = "CUSTOMER_NOT_FOUND"
+)
@@ -0,0 +1,91 @@
+package dto_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestUpdateCustomerRequest_Validate(t *testing.T) {
+	name := "Ada"
+	long := strings.Repeat("a", domain.MaxCustomerNameLength+1)
+	tests := []struct {
+		name    string
+		req     dto.UpdateCustomerRequest
+		wantErr bool
+	}{
+		{"name", dto.UpdateCustomerRequest{Name: &name}, false},
+		{"metadata", dto.UpdateCustomerRequest{Metadata: map[string]interface{}{"tier": "gold"}}, false},
+		{"nothing to update", dto.UpdateCustomerRequest{}, true},
+		{"name too long", dto.UpdateCustomerRequest{Name: &long}, true},
+		{"metadata too large", dto.UpdateCustomerRequest{Metadata: map[string]interface{}{
+			"notes": strings.Repeat("x", domain.MaxCustomerMetadataSize),
+		}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr != (len(errs) > 0) {
+				t.Errorf("unexpected validation result: %v", errs)
+			}
+		})
+	}
+}
+
+func TestNewCustomerListResponse_NextCursor(t *testing.T) {
+	customers := []*domain.Customer{
+		domain.NewCustomer(uuid.New(), "+15550001111"),
+		domain.NewCustomer(uuid.New(), "+15550002222"),
+	}
+
+	resp := dto.NewCustomerListResponse(customers, 2)
+	if !resp.Meta.HasMore || resp.Meta.NextCursor == "" {
+		t.Fatalf("a full page should have a next cursor: %+v", resp.Meta)
+	}
+	cursor, err := dto.DecodeCursor(resp.Meta.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	last := customers[1]
+	if cursor.ID != last.ID || !cursor.Timestamp.Equal(last.CreatedAt) {
+		t.Errorf("cursor points at %v/%v, want the last customer", cursor.Timestamp, cursor.ID)
+	}
+
+	if resp := dto.NewCustomerListResponse(customers, 10); resp.Meta.HasMore || resp.Meta.NextCursor != "" {
+		t.Errorf("a short page has no next cursor: %+v", resp.Meta)
+	}
+}
+
+func TestParseCustomerConversationsRequest(t *testing.T) {
+	parse := func(phone, query string) *dto.CustomerConversationsRequest {
+		r := httptest.NewRequest("GET", "/api/v1/customers/x/conversations"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("phone", phone)
+		return dto.ParseCustomerConversationsRequest(r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx)))
+	}
+
+	req := parse("%2B1 555-000-1111", "?per_page=500")
+	if req.Phone != "+15550001111" {
+		t.Errorf("phone = %q, want it unescaped and normalized", req.Phone)
+	}
+	if req.PerPage != dto.MaxConversationsPerQuery {
+		t.Errorf("per_page = %d, want it capped", req.PerPage)
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		t.Errorf("unexpected validation errors: %v", errs)
+	}
+
+	if errs := parse("+1555000111122223333444", "").Validate(); len(errs) == 0 {
+		t.Error("an overlong phone number should fail validation")
+	}
+	if errs := parse("+15550001111", "?cursor=nope").Validate(); len(errs) == 0 {
+		t.Error("an invalid cursor should fail validation")
+	}
+}
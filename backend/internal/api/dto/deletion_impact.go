@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Delete Request ====================
+
+// DeleteRequest is the query of the deletes guarded by the deletion impact.
+// Force is "true" to delete despite the blockers.
+type DeleteRequest struct {
+	Force *string `json:"force,omitempty"`
+}
+
+func ParseDeleteRequest(r *http.Request) *DeleteRequest {
+	req := &DeleteRequest{}
+	if force := r.URL.Query().Get("force"); force != "" {
+		req.Force = &force
+	}
+	return req
+}
+
+func (r *DeleteRequest) Validate() []string {
+	if r.Force != nil && *r.Force != "true" && *r.Force != "false" {
+		return []string{"force must be true or false"}
+	}
+	return nil
+}
+
+// IsForced reports whether the blockers are overridden
+func (r *DeleteRequest) IsForced() bool {
+	return r.Force != nil && *r.Force == "true"
+}
+
+// ==================== Deletion Impact Response ====================
+
+// DeletionImpactResponse counts what deleting the resource would affect;
+// blockers lists what keeps it from being deleted without force=true
+type DeletionImpactResponse struct {
+	Resource          string    `json:"resource"`
+	ID                uuid.UUID `json:"id"`
+	Conversations     int64     `json:"conversations"`
+	OpenConversations int64     `json:"open_conversations"`
+	Subscriptions     int64     `json:"subscriptions"`
+	RoutingRules      int64     `json:"routing_rules"`
+	GracePeriods      int64     `json:"grace_periods"`
+	Blockers          []string  `json:"blockers"`
+}
+
+func NewDeletionImpactResponse(impact *domain.DeletionImpact) DeletionImpactResponse {
+	blockers := impact.Blockers()
+	if blockers == nil {
+		blockers = []string{}
+	}
+	return DeletionImpactResponse{
+		Resource:          string(impact.Resource),
+		ID:                impact.ID,
+		Conversations:     impact.Conversations,
+		OpenConversations: impact.OpenConversations,
+		Subscriptions:     impact.Subscriptions,
+		RoutingRules:      impact.RoutingRules,
+		GracePeriods:      impact.GracePeriods,
+		Blockers:          blockers,
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeDeletionBlocked = "DELETION_BLOCKED"
+)
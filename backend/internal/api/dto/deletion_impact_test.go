@@ -0,0 +1,56 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestDeleteRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantErrs   int
+		wantForced bool
+	}{
+		{"omitted", "/inboxes/1", 0, false},
+		{"forced", "/inboxes/1?force=true", 0, true},
+		{"not forced", "/inboxes/1?force=false", 0, false},
+		{"invalid", "/inboxes/1?force=yes", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.ParseDeleteRequest(httptest.NewRequest("DELETE", tt.url, nil))
+			if errs := req.Validate(); len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrs, errs)
+			}
+			if got := req.IsForced(); got != tt.wantForced {
+				t.Errorf("IsForced() = %v, want %v", got, tt.wantForced)
+			}
+		})
+	}
+}
+
+func TestNewDeletionImpactResponse(t *testing.T) {
+	id := uuid.New()
+	resp := dto.NewDeletionImpactResponse(&domain.DeletionImpact{
+		Resource:      domain.DeletionResourceInbox,
+		ID:            id,
+		Conversations: 4,
+		Subscriptions: 2,
+	})
+
+	if resp.Resource != "inbox" || resp.ID != id {
+		t.Errorf("got resource %q id %s", resp.Resource, resp.ID)
+	}
+	if resp.Conversations != 4 || resp.Subscriptions != 2 {
+		t.Errorf("got counts %+v", resp)
+	}
+	if resp.Blockers == nil || len(resp.Blockers) != 0 {
+		t.Errorf("Blockers = %v, want empty list", resp.Blockers)
+	}
+}
@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// MaxDeviceTokenLength bounds a push token; FCM and APNs tokens are well
+// below it
+const MaxDeviceTokenLength = 4096
+
+// ==================== Register Device Request ====================
+
+// RegisterDeviceRequest registers a device for push notifications of the
+// caller's assignments. event_types narrows the notified events; omitted or
+// empty means every push event.
+type RegisterDeviceRequest struct {
+	Platform   string   `json:"platform"`
+	Token      string   `json:"token" validate:"required,max=4096"`
+	EventTypes []string `json:"event_types"`
+}
+
+func (r *RegisterDeviceRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if !domain.DevicePlatform(r.Platform).IsValid() {
+		errs = append(errs, "platform must be FCM or APNS")
+	}
+	errs = append(errs, validatePushEventTypes(r.EventTypes)...)
+	return errs
+}
+
+func (r *RegisterDeviceRequest) GetPlatform() domain.DevicePlatform {
+	return domain.DevicePlatform(r.Platform)
+}
+
+func (r *RegisterDeviceRequest) ToEventTypes() []domain.EventType {
+	return toEventTypes(r.EventTypes)
+}
+
+// ==================== Update Device Preferences Request ====================
+
+// UpdateDevicePreferencesRequest replaces the notified events; an empty
+// list notifies every push event
+type UpdateDevicePreferencesRequest struct {
+	EventTypes []string `json:"event_types"`
+}
+
+func (r *UpdateDevicePreferencesRequest) Validate() []string {
+	return validatePushEventTypes(r.EventTypes)
+}
+
+func (r *UpdateDevicePreferencesRequest) ToEventTypes() []domain.EventType {
+	return toEventTypes(r.EventTypes)
+}
+
+func validatePushEventTypes(types []string) []string {
+	var errs []string
+	seen := make(map[string]bool, len(types))
+	for _, t := range types {
+		switch {
+		case !domain.IsPushEvent(domain.EventType(t)):
+			errs = append(errs, "unsupported push event type: "+t+
+				" (must be conversation.allocated or conversation.reassigned)")
+		case seen[t]:
+			errs = append(errs, "duplicate event type: "+t)
+		}
+		seen[t] = true
+	}
+	return errs
+}
+
+// ==================== Device Response ====================
+
+// DeviceResponse identifies the token by its last characters only; the
+// full token is never returned
+type DeviceResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Platform    string    `json:"platform"`
+	TokenSuffix string    `json:"token_suffix"`
+	EventTypes  []string  `json:"event_types"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// deviceTokenSuffixLength is the number of token characters shown
+const deviceTokenSuffixLength = 8
+
+func NewDeviceResponse(device *domain.OperatorDevice) DeviceResponse {
+	suffix := device.Token
+	if len(suffix) > deviceTokenSuffixLength {
+		suffix = suffix[len(suffix)-deviceTokenSuffixLength:]
+	}
+	eventTypes := make([]string, len(device.EventTypes))
+	for i, t := range device.EventTypes {
+		eventTypes[i] = string(t)
+	}
+	return DeviceResponse{
+		ID:          device.ID,
+		Platform:    string(device.Platform),
+		TokenSuffix: suffix,
+		EventTypes:  eventTypes,
+		CreatedAt:   device.CreatedAt,
+		UpdatedAt:   device.UpdatedAt,
+	}
+}
+
+type DeviceListResponse struct {
+	Devices []DeviceResponse `json:"devices"`
+}
+
+func NewDeviceListResponse(devices []*domain.OperatorDevice) DeviceListResponse {
+	resp := DeviceListResponse{Devices: make([]DeviceResponse, len(devices))}
+	for i, device := range devices {
+		resp.Devices[i] = NewDeviceResponse(device)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeDeviceNotFound = "DEVICE_NOT_FOUND"
+	ErrCodeTooManyDevices = "TOO_MANY_DEVICES"
+)
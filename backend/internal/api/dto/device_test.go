@@ -0,0 +1,53 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterDeviceRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.RegisterDeviceRequest{Platform: "FCM", Token: "token"}).Validate())
+	assert.Empty(t, (&dto.RegisterDeviceRequest{
+		Platform:   "APNS",
+		Token:      "token",
+		EventTypes: []string{"conversation.allocated", "conversation.reassigned"},
+	}).Validate())
+
+	assert.Len(t, (&dto.RegisterDeviceRequest{Platform: "WNS", Token: "token"}).Validate(), 1)
+	assert.Len(t, (&dto.RegisterDeviceRequest{Platform: "FCM"}).Validate(), 1)
+	assert.Len(t, (&dto.RegisterDeviceRequest{Platform: "FCM", Token: strings.Repeat("a", dto.MaxDeviceTokenLength+1)}).Validate(), 1)
+	assert.Len(t, (&dto.RegisterDeviceRequest{Platform: "FCM", Token: "token", EventTypes: []string{"conversation.resolved"}}).Validate(), 1)
+	assert.Len(t, (&dto.RegisterDeviceRequest{
+		Platform:   "FCM",
+		Token:      "token",
+		EventTypes: []string{"conversation.allocated", "conversation.allocated"},
+	}).Validate(), 1)
+}
+
+func TestUpdateDevicePreferencesRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.UpdateDevicePreferencesRequest{}).Validate())
+	assert.Empty(t, (&dto.UpdateDevicePreferencesRequest{EventTypes: []string{"conversation.reassigned"}}).Validate())
+	assert.Len(t, (&dto.UpdateDevicePreferencesRequest{EventTypes: []string{"nope"}}).Validate(), 1)
+}
+
+func TestNewDeviceResponse(t *testing.T) {
+	device := domain.NewOperatorDevice(uuid.New(), uuid.New(), domain.DevicePlatformFCM,
+		"fcm-registration-token-0123456789", []domain.EventType{domain.EventConversationAllocated})
+
+	resp := dto.NewDeviceResponse(device)
+	assert.Equal(t, "FCM", resp.Platform)
+	assert.Equal(t, "23456789", resp.TokenSuffix)
+	assert.Equal(t, []string{"conversation.allocated"}, resp.EventTypes)
+
+	device.Token = "short"
+	device.EventTypes = nil
+	resp = dto.NewDeviceResponse(device)
+	assert.Equal(t, "short", resp.TokenSuffix)
+	assert.Empty(t, resp.EventTypes)
+	assert.NotNil(t, resp.EventTypes)
+}
@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+	"github.com/shopspring/decimal"
+)
+
+// ==================== Create Experiment Request ====================
+
+type CreateExperimentRequest struct {
+	Name    string    `json:"name" validate:"required,max=100"`
+	InboxID uuid.UUID `json:"inbox_id" validate:"required"`
+	// Alpha and Beta are the weights of the treatment arm
+	Alpha float64 `json:"alpha" validate:"min=0,max=1"`
+	Beta  float64 `json:"beta" validate:"min=0,max=1"`
+	// TrafficPercent is the share of new conversations in the treatment arm
+	TrafficPercent int `json:"traffic_percent" validate:"min=1,max=99"`
+}
+
+func (r *CreateExperimentRequest) Validate() []string {
+	errs := validate.Struct(r)
+	sum := r.Alpha + r.Beta
+	if sum < 0.99 || sum > 1.01 {
+		errs = append(errs, "alpha + beta should equal 1.0")
+	}
+	return errs
+}
+
+func (r *CreateExperimentRequest) ToDecimal() (alpha, beta decimal.Decimal) {
+	return decimal.NewFromFloat(r.Alpha), decimal.NewFromFloat(r.Beta)
+}
+
+// ==================== Update Experiment Request ====================
+
+type UpdateExperimentRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (r *UpdateExperimentRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Experiment Response ====================
+
+type ExperimentResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	InboxID        uuid.UUID  `json:"inbox_id"`
+	Alpha          float64    `json:"alpha"`
+	Beta           float64    `json:"beta"`
+	TrafficPercent int        `json:"traffic_percent"`
+	Status         string     `json:"status"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	StartedAt      time.Time  `json:"started_at"`
+	StoppedAt      *time.Time `json:"stopped_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func NewExperimentResponse(e *domain.PriorityExperiment) ExperimentResponse {
+	return ExperimentResponse{
+		ID:             e.ID,
+		Name:           e.Name,
+		InboxID:        e.InboxID,
+		Alpha:          e.WeightAlpha.InexactFloat64(),
+		Beta:           e.WeightBeta.InexactFloat64(),
+		TrafficPercent: e.TrafficPercent,
+		Status:         string(e.Status),
+		CreatedBy:      e.CreatedBy,
+		StartedAt:      e.StartedAt,
+		StoppedAt:      e.StoppedAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+type ExperimentListResponse struct {
+	Experiments []ExperimentResponse `json:"experiments"`
+}
+
+func NewExperimentListResponse(experiments []*domain.PriorityExperiment) ExperimentListResponse {
+	items := make([]ExperimentResponse, len(experiments))
+	for i, e := range experiments {
+		items[i] = NewExperimentResponse(e)
+	}
+	return ExperimentListResponse{Experiments: items}
+}
+
+// ==================== Experiment Results Response ====================
+
+type ExperimentArmResponse struct {
+	Arm                  string  `json:"arm"`
+	Conversations        int     `json:"conversations"`
+	Allocated            int     `json:"allocated"`
+	Resolved             int     `json:"resolved"`
+	AvgWaitSeconds       float64 `json:"avg_wait_seconds"`
+	P50WaitSeconds       float64 `json:"p50_wait_seconds"`
+	P90WaitSeconds       float64 `json:"p90_wait_seconds"`
+	AvgResolutionSeconds float64 `json:"avg_resolution_seconds"`
+	P50ResolutionSeconds float64 `json:"p50_resolution_seconds"`
+	P90ResolutionSeconds float64 `json:"p90_resolution_seconds"`
+}
+
+type ExperimentResultsResponse struct {
+	Experiment ExperimentResponse      `json:"experiment"`
+	Arms       []ExperimentArmResponse `json:"arms"`
+	// WaitChange and ResolutionChange compare the treatment's averages with
+	// the control's (-0.2 is 20% shorter); null until both arms have data
+	WaitChange       *float64 `json:"wait_change"`
+	ResolutionChange *float64 `json:"resolution_change"`
+}
+
+func NewExperimentResultsResponse(r *domain.ExperimentResults) ExperimentResultsResponse {
+	arms := make([]ExperimentArmResponse, len(r.Arms))
+	for i, a := range r.Arms {
+		arms[i] = ExperimentArmResponse{
+			Arm:                  string(a.Arm),
+			Conversations:        a.Conversations,
+			Allocated:            a.Allocated,
+			Resolved:             a.Resolved,
+			AvgWaitSeconds:       a.AvgWait.Seconds(),
+			P50WaitSeconds:       a.P50Wait.Seconds(),
+			P90WaitSeconds:       a.P90Wait.Seconds(),
+			AvgResolutionSeconds: a.AvgResolution.Seconds(),
+			P50ResolutionSeconds: a.P50Resolution.Seconds(),
+			P90ResolutionSeconds: a.P90Resolution.Seconds(),
+		}
+	}
+	return ExperimentResultsResponse{
+		Experiment:       NewExperimentResponse(r.Experiment),
+		Arms:             arms,
+		WaitChange:       r.WaitChange(),
+		ResolutionChange: r.ResolutionChange(),
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeExperimentNotFound       = "EXPERIMENT_NOT_FOUND"
+	ErrCodeExperimentAlreadyRunning = "EXPERIMENT_ALREADY_RUNNING"
+	ErrCodeExperimentNotRunning     = "EXPERIMENT_NOT_RUNNING"
+)
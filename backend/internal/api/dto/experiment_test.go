@@ -0,0 +1,71 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestCreateExperimentRequest_Validate(t *testing.T) {
+	valid := func() dto.CreateExperimentRequest {
+		return dto.CreateExperimentRequest{Name: "recency first", InboxID: uuid.New(), Alpha: 0.3, Beta: 0.7, TrafficPercent: 50}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *dto.CreateExperimentRequest)
+		wantErr bool
+	}{
+		{"valid", func(r *dto.CreateExperimentRequest) {}, false},
+		{"missing name", func(r *dto.CreateExperimentRequest) { r.Name = "  " }, true},
+		{"long name", func(r *dto.CreateExperimentRequest) { r.Name = strings.Repeat("x", 101) }, true},
+		{"missing inbox", func(r *dto.CreateExperimentRequest) { r.InboxID = uuid.Nil }, true},
+		{"alpha > 1", func(r *dto.CreateExperimentRequest) { r.Alpha, r.Beta = 1.5, 0 }, true},
+		{"sum != 1", func(r *dto.CreateExperimentRequest) { r.Alpha, r.Beta = 0.3, 0.3 }, true},
+		{"traffic 0", func(r *dto.CreateExperimentRequest) { r.TrafficPercent = 0 }, true},
+		{"traffic 100", func(r *dto.CreateExperimentRequest) { r.TrafficPercent = 100 }, true},
+		{"traffic 1", func(r *dto.CreateExperimentRequest) { r.TrafficPercent = 1 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestNewExperimentResultsResponse(t *testing.T) {
+	exp := domain.NewPriorityExperiment(uuid.New(), uuid.New(), "recency first",
+		decimal.NewFromFloat(0.3), decimal.NewFromFloat(0.7), 50, nil)
+	results := domain.NewExperimentResults(exp, []*domain.ExperimentArmOutcome{
+		{Arm: domain.ExperimentArmControl, Conversations: 4, Allocated: 4, AvgWait: 100 * time.Second},
+		{Arm: domain.ExperimentArmTreatment, Conversations: 4, Allocated: 2, AvgWait: 50 * time.Second},
+	})
+
+	resp := dto.NewExperimentResultsResponse(results)
+	if len(resp.Arms) != 2 || resp.Arms[0].Arm != "CONTROL" || resp.Arms[1].AvgWaitSeconds != 50 {
+		t.Errorf("unexpected arms: %+v", resp.Arms)
+	}
+	if resp.WaitChange == nil || *resp.WaitChange != -0.5 {
+		t.Errorf("wait change: got %v, want -0.5", resp.WaitChange)
+	}
+	if resp.ResolutionChange != nil {
+		t.Errorf("resolution change without resolutions: got %v", *resp.ResolutionChange)
+	}
+	if resp.Experiment.Alpha != 0.3 || resp.Experiment.Status != "RUNNING" {
+		t.Errorf("unexpected experiment: %+v", resp.Experiment)
+	}
+}
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+const (
+	DefaultGracePeriodListLimit = 100
+	MaxGracePeriodListLimit     = 500
+	// MaxGracePeriodExtension bounds a single extension of a grace period
+	MaxGracePeriodExtension = 24 * time.Hour
+)
+
+// ==================== List Grace Periods Request ====================
+
+// ListGracePeriodsRequest holds the raw query parameters of
+// GET /api/v1/grace-periods
+type ListGracePeriodsRequest struct {
+	OperatorID     string
+	ConversationID string
+	Limit          string
+}
+
+func ParseListGracePeriodsRequest(r *http.Request) *ListGracePeriodsRequest {
+	query := r.URL.Query()
+	return &ListGracePeriodsRequest{
+		OperatorID:     query.Get("operator_id"),
+		ConversationID: query.Get("conversation_id"),
+		Limit:          query.Get("limit"),
+	}
+}
+
+func (r *ListGracePeriodsRequest) Validate() []string {
+	var errs []string
+	if r.OperatorID != "" {
+		if _, err := uuid.Parse(r.OperatorID); err != nil {
+			errs = append(errs, "operator_id must be a valid UUID")
+		}
+	}
+	if r.ConversationID != "" {
+		if _, err := uuid.Parse(r.ConversationID); err != nil {
+			errs = append(errs, "conversation_id must be a valid UUID")
+		}
+	}
+	if r.Limit != "" {
+		limit, err := strconv.Atoi(r.Limit)
+		if err != nil || limit < 1 || limit > MaxGracePeriodListLimit {
+			errs = append(errs, fmt.Sprintf("limit must be between 1 and %d", MaxGracePeriodListLimit))
+		}
+	}
+	return errs
+}
+
+// ToFilter assumes Validate has passed
+func (r *ListGracePeriodsRequest) ToFilter(tenantID uuid.UUID) domain.GracePeriodFilter {
+	limit, err := strconv.Atoi(r.Limit)
+	if err != nil {
+		limit = DefaultGracePeriodListLimit
+	}
+	return domain.GracePeriodFilter{
+		TenantID:       tenantID,
+		OperatorID:     parseOptionalUUID(r.OperatorID),
+		ConversationID: parseOptionalUUID(r.ConversationID),
+		Limit:          limit,
+	}
+}
+
+// ==================== Extend Grace Period Request ====================
+
+// ExtendGracePeriodRequest moves a grace period's expiry later
+type ExtendGracePeriodRequest struct {
+	ExtendBySeconds int `json:"extend_by_seconds" validate:"min=1,max=86400"`
+}
+
+func (r *ExtendGracePeriodRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+func (r *ExtendGracePeriodRequest) ExtendBy() time.Duration {
+	return time.Duration(r.ExtendBySeconds) * time.Second
+}
+
+// ==================== Grace Period Response ====================
+
+type GracePeriodResponse struct {
+	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	OperatorID     uuid.UUID `json:"operator_id"`
+	Reason         string    `json:"reason"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func NewGracePeriodResponse(gpa *domain.GracePeriodAssignment) GracePeriodResponse {
+	return GracePeriodResponse{
+		ID:             gpa.ID,
+		ConversationID: gpa.ConversationID,
+		OperatorID:     gpa.OperatorID,
+		Reason:         string(gpa.Reason),
+		ExpiresAt:      gpa.ExpiresAt,
+		CreatedAt:      gpa.CreatedAt,
+	}
+}
+
+type GracePeriodListResponse struct {
+	GracePeriods []GracePeriodResponse `json:"grace_periods"`
+}
+
+func NewGracePeriodListResponse(assignments []*domain.GracePeriodAssignment) GracePeriodListResponse {
+	resp := GracePeriodListResponse{GracePeriods: make([]GracePeriodResponse, len(assignments))}
+	for i, gpa := range assignments {
+		resp.GracePeriods[i] = NewGracePeriodResponse(gpa)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeGracePeriodNotFound = "GRACE_PERIOD_NOT_FOUND"
+	ErrCodeGracePeriodExpired  = "GRACE_PERIOD_EXPIRED"
+)
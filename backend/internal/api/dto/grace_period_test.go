@@ -0,0 +1,43 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGracePeriodsRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.ListGracePeriodsRequest{}).Validate())
+	assert.Empty(t, (&dto.ListGracePeriodsRequest{OperatorID: uuid.NewString(), Limit: "500"}).Validate())
+	assert.Len(t, (&dto.ListGracePeriodsRequest{OperatorID: "nope"}).Validate(), 1)
+	assert.Len(t, (&dto.ListGracePeriodsRequest{ConversationID: "nope"}).Validate(), 1)
+	assert.Len(t, (&dto.ListGracePeriodsRequest{Limit: "0"}).Validate(), 1)
+	assert.Len(t, (&dto.ListGracePeriodsRequest{Limit: "501"}).Validate(), 1)
+}
+
+func TestListGracePeriodsRequest_ToFilter(t *testing.T) {
+	tenantID := uuid.New()
+	operatorID := uuid.New()
+
+	filter := (&dto.ListGracePeriodsRequest{OperatorID: operatorID.String()}).ToFilter(tenantID)
+	assert.Equal(t, tenantID, filter.TenantID)
+	require.NotNil(t, filter.OperatorID)
+	assert.Equal(t, operatorID, *filter.OperatorID)
+	assert.Nil(t, filter.ConversationID)
+	assert.Equal(t, dto.DefaultGracePeriodListLimit, filter.Limit)
+
+	filter = (&dto.ListGracePeriodsRequest{Limit: "25"}).ToFilter(tenantID)
+	assert.Equal(t, 25, filter.Limit)
+}
+
+func TestExtendGracePeriodRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.ExtendGracePeriodRequest{ExtendBySeconds: 300}).Validate())
+	assert.Len(t, (&dto.ExtendGracePeriodRequest{}).Validate(), 1)
+	assert.Len(t, (&dto.ExtendGracePeriodRequest{ExtendBySeconds: 86401}).Validate(), 1)
+
+	assert.Equal(t, 5*time.Minute, (&dto.ExtendGracePeriodRequest{ExtendBySeconds: 300}).ExtendBy())
+}
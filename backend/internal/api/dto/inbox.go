@@ -1,72 +1,253 @@
 package dto
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 type CreateInboxRequest struct {
-	PhoneNumber string `json:"phone_number"`
-	DisplayName string `json:"display_name"`
+	PhoneNumber   string                `json:"phone_number" validate:"required,e164"`
+	DisplayName   string                `json:"display_name" validate:"required,max=255"`
+	IsRestricted  bool                  `json:"is_restricted"`
+	BusinessHours *BusinessHoursRequest `json:"business_hours,omitempty"`
 }
 
 func (r *CreateInboxRequest) Validate() []string {
-	var errs []string
-	if err := ValidateRequired(r.PhoneNumber, "phone_number"); err != nil {
-		errs = append(errs, err.Error())
-	}
-	if err := ValidateMaxLength(r.PhoneNumber, 20, "phone_number"); err != nil {
-		errs = append(errs, err.Error())
-	}
-	if err := ValidateRequired(r.DisplayName, "display_name"); err != nil {
-		errs = append(errs, err.Error())
-	}
-	if err := ValidateMaxLength(r.DisplayName, 255, "display_name"); err != nil {
-		errs = append(errs, err.Error())
+	errs := validate.Struct(r)
+	if r.BusinessHours != nil {
+		errs = append(errs, r.BusinessHours.Validate()...)
 	}
 	return errs
 }
 
 type UpdateInboxRequest struct {
-	PhoneNumber *string `json:"phone_number,omitempty"`
-	DisplayName *string `json:"display_name,omitempty"`
+	PhoneNumber  *string `json:"phone_number,omitempty" validate:"e164"`
+	DisplayName  *string `json:"display_name,omitempty" validate:"notblank,max=255"`
+	IsRestricted *bool   `json:"is_restricted,omitempty"`
+	// BusinessHours replaces the inbox's business hours when present; null
+	// clears them
+	BusinessHours OptionalBusinessHours `json:"business_hours"`
 }
 
 func (r *UpdateInboxRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.BusinessHours.Value != nil {
+		errs = append(errs, r.BusinessHours.Value.Validate()...)
+	}
+	return errs
+}
+
+// ==================== Business Hours ====================
+
+// BusinessHoursRequest is the weekly opening hours of an inbox, one entry
+// per open day. A close_time of "00:00" closes at midnight.
+type BusinessHoursRequest struct {
+	Timezone string                `json:"timezone"`
+	Days     []OpeningHoursRequest `json:"days"`
+}
+
+type OpeningHoursRequest struct {
+	DayOfWeek *int   `json:"day_of_week"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+func (r *BusinessHoursRequest) Validate() []string {
 	var errs []string
-	if r.PhoneNumber != nil {
-		if err := ValidateMaxLength(*r.PhoneNumber, 20, "phone_number"); err != nil {
-			errs = append(errs, err.Error())
-		}
+	if r.Timezone == "" {
+		errs = append(errs, "business_hours.timezone is required")
+	} else if _, err := time.LoadLocation(r.Timezone); err != nil {
+		errs = append(errs, "business_hours.timezone must be an IANA time zone name")
+	}
+	if len(r.Days) == 0 {
+		errs = append(errs, "business_hours.days must list at least one open day")
 	}
-	if r.DisplayName != nil {
-		if err := ValidateMaxLength(*r.DisplayName, 255, "display_name"); err != nil {
-			errs = append(errs, err.Error())
+
+	seen := make(map[int]bool, len(r.Days))
+	for i, day := range r.Days {
+		field := fmt.Sprintf("business_hours.days[%d]", i)
+		if day.DayOfWeek == nil {
+			errs = append(errs, field+".day_of_week is required")
+		} else if *day.DayOfWeek < 0 || *day.DayOfWeek > 6 {
+			errs = append(errs, field+".day_of_week must be between 0 (Sunday) and 6 (Saturday)")
+		} else if seen[*day.DayOfWeek] {
+			errs = append(errs, field+".day_of_week is listed twice")
+		} else {
+			seen[*day.DayOfWeek] = true
+		}
+
+		opens, openErr := parseTimeOfDay(day.OpenTime)
+		if openErr != nil {
+			errs = append(errs, field+".open_time must be HH:MM")
+		}
+		closes, closeErr := parseCloseTime(day.CloseTime)
+		if closeErr != nil {
+			errs = append(errs, field+".close_time must be HH:MM")
+		}
+		if openErr == nil && closeErr == nil && opens >= closes {
+			errs = append(errs, field+".open_time must be before close_time")
 		}
 	}
 	return errs
 }
 
+// ToDomain converts the validated request
+func (r *BusinessHoursRequest) ToDomain() *domain.BusinessHours {
+	hours := &domain.BusinessHours{
+		Timezone: r.Timezone,
+		Days:     make(map[time.Weekday]domain.OpeningHours, len(r.Days)),
+	}
+	for _, day := range r.Days {
+		opens, _ := parseTimeOfDay(day.OpenTime)
+		closes, _ := parseCloseTime(day.CloseTime)
+		hours.Days[time.Weekday(*day.DayOfWeek)] = domain.OpeningHours{Open: opens, Close: closes}
+	}
+	return hours
+}
+
+// parseCloseTime reads "00:00" as midnight at the end of the day
+func parseCloseTime(s string) (time.Duration, error) {
+	closes, err := parseTimeOfDay(s)
+	if err == nil && closes == 0 {
+		closes = 24 * time.Hour
+	}
+	return closes, err
+}
+
+// OptionalBusinessHours tells an omitted business_hours, which keeps the
+// inbox's, from null, which clears them
+type OptionalBusinessHours struct {
+	Set   bool
+	Value *BusinessHoursRequest
+}
+
+func (o *OptionalBusinessHours) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	o.Value = &BusinessHoursRequest{}
+	return json.Unmarshal(data, o.Value)
+}
+
+// ToDomain returns the requested business hours, nil when cleared
+func (o *OptionalBusinessHours) ToDomain() *domain.BusinessHours {
+	if o.Value == nil {
+		return nil
+	}
+	return o.Value.ToDomain()
+}
+
+type BusinessHoursResponse struct {
+	Timezone string                 `json:"timezone"`
+	Days     []OpeningHoursResponse `json:"days"`
+}
+
+type OpeningHoursResponse struct {
+	DayOfWeek int    `json:"day_of_week"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+// NewBusinessHoursResponse lists the open days in weekday order; nil when
+// the inbox has no business hours
+func NewBusinessHoursResponse(hours *domain.BusinessHours) *BusinessHoursResponse {
+	if hours == nil {
+		return nil
+	}
+	resp := &BusinessHoursResponse{Timezone: hours.Timezone, Days: []OpeningHoursResponse{}}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if opening, ok := hours.Days[day]; ok {
+			resp.Days = append(resp.Days, OpeningHoursResponse{
+				DayOfWeek: int(day),
+				OpenTime:  formatTimeOfDay(opening.Open),
+				// 24h formats as "00:00", midnight at the end of the day
+				CloseTime: formatTimeOfDay(opening.Close),
+			})
+		}
+	}
+	return resp
+}
+
 type InboxResponse struct {
-	ID          uuid.UUID `json:"id"`
-	TenantID    uuid.UUID `json:"tenant_id"`
-	PhoneNumber string    `json:"phone_number"`
-	DisplayName string    `json:"display_name"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uuid.UUID `json:"id"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+	PhoneNumber  string    `json:"phone_number"`
+	DisplayName  string    `json:"display_name"`
+	IsRestricted bool      `json:"is_restricted"`
+	// Inbox escalated conversations are moved to; null when escalation is disabled
+	EscalationInboxID *uuid.UUID `json:"escalation_inbox_id"`
+	// Idle time after which open conversations are resolved; null when
+	// auto-resolution is disabled
+	AutoResolveAfterSeconds *int `json:"auto_resolve_after_seconds"`
+	// Opening hours outside which delay does not raise priority; null when
+	// all time counts
+	BusinessHours *BusinessHoursResponse `json:"business_hours"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
 }
 
 func NewInboxResponse(inbox *domain.Inbox) InboxResponse {
+	var autoResolveAfter *int
+	if inbox.AutoResolveAfter != nil {
+		seconds := int(inbox.AutoResolveAfter.Seconds())
+		autoResolveAfter = &seconds
+	}
 	return InboxResponse{
-		ID:          inbox.ID,
-		TenantID:    inbox.TenantID,
-		PhoneNumber: inbox.PhoneNumber,
-		DisplayName: inbox.DisplayName,
-		CreatedAt:   inbox.CreatedAt,
-		UpdatedAt:   inbox.UpdatedAt,
+		ID:                      inbox.ID,
+		TenantID:                inbox.TenantID,
+		PhoneNumber:             inbox.PhoneNumber,
+		DisplayName:             inbox.DisplayName,
+		IsRestricted:            inbox.IsRestricted,
+		EscalationInboxID:       inbox.EscalationInboxID,
+		AutoResolveAfterSeconds: autoResolveAfter,
+		BusinessHours:           NewBusinessHoursResponse(inbox.BusinessHours),
+		CreatedAt:               inbox.CreatedAt,
+		UpdatedAt:               inbox.UpdatedAt,
+	}
+}
+
+// EscalationInboxRequest sets the inbox that conversations are escalated
+// to; null disables escalation
+type EscalationInboxRequest struct {
+	EscalationInboxID *uuid.UUID `json:"escalation_inbox_id"`
+}
+
+func (r *EscalationInboxRequest) Validate() []string {
+	var errs []string
+	if r.EscalationInboxID != nil && *r.EscalationInboxID == uuid.Nil {
+		errs = append(errs, "escalation_inbox_id must not be a nil UUID")
+	}
+	return errs
+}
+
+// MaxAutoResolveAfter bounds the idle time of an inbox's auto-resolution
+const MaxAutoResolveAfter = 90 * 24 * time.Hour
+
+// AutoResolveRequest sets how long open conversations of an inbox may go
+// without a customer message before they are resolved; null disables
+// auto-resolution
+type AutoResolveRequest struct {
+	AutoResolveAfterSeconds *int `json:"auto_resolve_after_seconds" validate:"min=1,max=7776000"`
+}
+
+func (r *AutoResolveRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// AutoResolveAfter returns the requested idle time, nil when disabled
+func (r *AutoResolveRequest) AutoResolveAfter() *time.Duration {
+	if r.AutoResolveAfterSeconds == nil {
+		return nil
 	}
+	d := time.Duration(*r.AutoResolveAfterSeconds) * time.Second
+	return &d
 }
 
 type InboxListResponse struct {
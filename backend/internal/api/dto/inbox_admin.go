@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Grant Inbox Admin Request ====================
+
+type GrantInboxAdminRequest struct {
+	OperatorID uuid.UUID `json:"operator_id" validate:"required"`
+}
+
+func (r *GrantInboxAdminRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Inbox Admin Response ====================
+
+type InboxAdminResponse struct {
+	OperatorID uuid.UUID  `json:"operator_id"`
+	InboxID    uuid.UUID  `json:"inbox_id"`
+	GrantedBy  *uuid.UUID `json:"granted_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewInboxAdminResponse(a *domain.InboxAdmin) InboxAdminResponse {
+	return InboxAdminResponse{
+		OperatorID: a.OperatorID,
+		InboxID:    a.InboxID,
+		GrantedBy:  a.GrantedBy,
+		CreatedAt:  a.CreatedAt,
+	}
+}
+
+type InboxAdminListResponse struct {
+	Admins []InboxAdminResponse `json:"admins"`
+}
+
+// ==================== Capabilities Response ====================
+
+type InboxCapabilitiesResponse struct {
+	InboxID      uuid.UUID           `json:"inbox_id"`
+	Capabilities []domain.Capability `json:"capabilities"`
+}
+
+type CapabilitiesResponse struct {
+	Role domain.OperatorRole `json:"role"`
+	// Capabilities apply to every inbox of the tenant
+	Capabilities []domain.Capability `json:"capabilities"`
+	// Inboxes lists capabilities held through inbox admin grants
+	Inboxes []InboxCapabilitiesResponse `json:"inboxes"`
+}
+
+func NewCapabilitiesResponse(c *domain.Capabilities) CapabilitiesResponse {
+	inboxes := make([]InboxCapabilitiesResponse, len(c.Inboxes))
+	for i, inbox := range c.Inboxes {
+		inboxes[i] = InboxCapabilitiesResponse{
+			InboxID:      inbox.InboxID,
+			Capabilities: inbox.Capabilities,
+		}
+	}
+	return CapabilitiesResponse{
+		Role:         c.Role,
+		Capabilities: c.Tenant,
+		Inboxes:      inboxes,
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeInboxAdminOperatorNotFound = "OPERATOR_NOT_FOUND"
+)
@@ -0,0 +1,45 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestGrantInboxAdminRequest_Validate(t *testing.T) {
+	req := dto.GrantInboxAdminRequest{}
+	if errs := req.Validate(); len(errs) != 1 {
+		t.Errorf("Validate() returned %d errors, want 1: %v", len(errs), errs)
+	}
+
+	req.OperatorID = uuid.New()
+	if errs := req.Validate(); len(errs) != 0 {
+		t.Errorf("Validate() returned errors for valid request: %v", errs)
+	}
+}
+
+func TestNewCapabilitiesResponse(t *testing.T) {
+	inboxID := uuid.New()
+	resp := dto.NewCapabilitiesResponse(&domain.Capabilities{
+		Role:   domain.OperatorRoleOperator,
+		Tenant: domain.OperatorRoleOperator.Capabilities(),
+		Inboxes: []domain.InboxCapabilities{
+			{InboxID: inboxID, Capabilities: domain.InboxAdminCapabilities},
+		},
+	})
+
+	if resp.Role != domain.OperatorRoleOperator {
+		t.Errorf("Role = %v, want OPERATOR", resp.Role)
+	}
+	if resp.Capabilities == nil || len(resp.Capabilities) != 0 {
+		t.Errorf("Capabilities = %v, want empty list", resp.Capabilities)
+	}
+	if len(resp.Inboxes) != 1 || resp.Inboxes[0].InboxID != inboxID {
+		t.Fatalf("Inboxes = %+v, want the granted inbox", resp.Inboxes)
+	}
+	if len(resp.Inboxes[0].Capabilities) != len(domain.InboxAdminCapabilities) {
+		t.Errorf("inbox capabilities = %v", resp.Inboxes[0].Capabilities)
+	}
+}
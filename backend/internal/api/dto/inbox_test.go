@@ -1,7 +1,9 @@
 package dto_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
 )
@@ -18,6 +20,8 @@ func TestCreateInboxRequest_Validate(t *testing.T) {
 		{"missing display", "+1234567890", "", 1},
 		{"both missing", "", "", 2},
 		{"phone too long", "123456789012345678901", "OK", 1},
+		{"phone not E.164", "555-0100", "Support", 1},
+		{"phone without country code", "0201234567", "Support", 1},
 		{"display too long", "+123", "Lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua Ut enim ad minim veniam quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur Excepteur sint occaecat cupidatat non proident sunt in culpa qui officia deserunt mollit anim id est laborum", 1},
 	}
 
@@ -68,3 +72,111 @@ func TestUpdateInboxRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoResolveRequest_Validate(t *testing.T) {
+	seconds := func(v int) *int { return &v }
+	maxSeconds := int(dto.MaxAutoResolveAfter.Seconds())
+
+	tests := []struct {
+		name     string
+		seconds  *int
+		wantErrs int
+	}{
+		{"disable", nil, 0},
+		{"one week", seconds(7 * 24 * 3600), 0},
+		{"at max", seconds(maxSeconds), 0},
+		{"zero", seconds(0), 1},
+		{"negative", seconds(-60), 1},
+		{"above max", seconds(maxSeconds + 1), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.AutoResolveRequest{AutoResolveAfterSeconds: tt.seconds}
+			errs := req.Validate()
+			if len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrs, errs)
+			}
+		})
+	}
+
+	req := dto.AutoResolveRequest{AutoResolveAfterSeconds: seconds(3600)}
+	if got := req.AutoResolveAfter(); got == nil || *got != time.Hour {
+		t.Errorf("AutoResolveAfter() = %v, want 1h", got)
+	}
+	if got := (&dto.AutoResolveRequest{}).AutoResolveAfter(); got != nil {
+		t.Errorf("AutoResolveAfter() = %v, want nil", got)
+	}
+}
+
+func TestBusinessHoursRequest_Validate(t *testing.T) {
+	day := func(d int, opens, closes string) dto.OpeningHoursRequest {
+		return dto.OpeningHoursRequest{DayOfWeek: &d, OpenTime: opens, CloseTime: closes}
+	}
+
+	tests := []struct {
+		name     string
+		req      dto.BusinessHoursRequest
+		wantErrs int
+	}{
+		{"valid", dto.BusinessHoursRequest{Timezone: "Europe/Berlin", Days: []dto.OpeningHoursRequest{day(1, "09:00", "17:00"), day(6, "10:00", "00:00")}}, 0},
+		{"missing timezone", dto.BusinessHoursRequest{Days: []dto.OpeningHoursRequest{day(1, "09:00", "17:00")}}, 1},
+		{"unknown timezone", dto.BusinessHoursRequest{Timezone: "Not/AZone", Days: []dto.OpeningHoursRequest{day(1, "09:00", "17:00")}}, 1},
+		{"no days", dto.BusinessHoursRequest{Timezone: "UTC"}, 1},
+		{"missing day", dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{{OpenTime: "09:00", CloseTime: "17:00"}}}, 1},
+		{"day out of range", dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{day(7, "09:00", "17:00")}}, 1},
+		{"day twice", dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{day(1, "09:00", "12:00"), day(1, "13:00", "17:00")}}, 1},
+		{"bad times", dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{day(1, "9am", "25:00")}}, 2},
+		{"closes before opening", dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{day(1, "17:00", "09:00")}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrs, errs)
+			}
+		})
+	}
+
+	saturday := dto.BusinessHoursRequest{Timezone: "UTC", Days: []dto.OpeningHoursRequest{day(6, "10:00", "00:00")}}
+	hours := saturday.ToDomain()
+	if got := hours.Days[time.Saturday]; got.Open != 10*time.Hour || got.Close != 24*time.Hour {
+		t.Errorf("Saturday = %+v, want 10:00 until midnight", got)
+	}
+	resp := dto.NewBusinessHoursResponse(hours)
+	if len(resp.Days) != 1 || resp.Days[0].OpenTime != "10:00" || resp.Days[0].CloseTime != "00:00" {
+		t.Errorf("response days = %+v", resp.Days)
+	}
+}
+
+func TestUpdateInboxRequest_BusinessHours(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantSet   bool
+		wantHours bool
+	}{
+		{"omitted keeps", `{"display_name": "Support"}`, false, false},
+		{"null clears", `{"business_hours": null}`, true, false},
+		{"object replaces", `{"business_hours": {"timezone": "UTC", "days": [{"day_of_week": 1, "open_time": "09:00", "close_time": "17:00"}]}}`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req dto.UpdateInboxRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if errs := req.Validate(); len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			if req.BusinessHours.Set != tt.wantSet {
+				t.Errorf("Set = %v, want %v", req.BusinessHours.Set, tt.wantSet)
+			}
+			if got := req.BusinessHours.ToDomain() != nil; got != tt.wantHours {
+				t.Errorf("has hours = %v, want %v", got, tt.wantHours)
+			}
+		})
+	}
+}
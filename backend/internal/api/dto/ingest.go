@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Ingest Message Request ====================
+
+// IngestMessageRequest is an inbound message event from the external messaging
+// platform. The inbox (by ID or by the phone number the customer wrote to) is
+// only used when the message starts a new conversation.
+type IngestMessageRequest struct {
+	ExternalConversationID string     `json:"external_conversation_id" validate:"required,max=255"`
+	CustomerPhoneNumber    string     `json:"customer_phone_number" validate:"required"`
+	InboxID                *uuid.UUID `json:"inbox_id,omitempty"`
+	InboxPhoneNumber       string     `json:"inbox_phone_number,omitempty"`
+	Timestamp              *time.Time `json:"timestamp,omitempty"`
+	// Text is only used for classification and is not stored
+	Text string `json:"text,omitempty" validate:"max=4096"`
+	// Language is the customer's language as detected by the gateway, an
+	// ISO 639 code (region subtags are dropped)
+	Language string `json:"language,omitempty"`
+	// Metadata is the platform's metadata for the message (campaign, source,
+	// tags); metadata routing rules look for keywords in its values, it is
+	// not stored
+	Metadata map[string]string `json:"metadata,omitempty" validate:"max=20"`
+}
+
+const (
+	// MaxIngestMetadataEntries bounds the metadata keys of an ingested message
+	MaxIngestMetadataEntries = 20
+	// MaxIngestMetadataValueLength bounds each metadata value
+	MaxIngestMetadataValueLength = 256
+)
+
+func (r *IngestMessageRequest) Validate() []string {
+	errs := validate.Struct(r)
+	// Phone numbers are checked once the gateway's formatting is removed
+	if phone := r.NormalizedCustomerPhone(); phone != "" && !validate.IsE164(phone) {
+		errs = append(errs, "customer_phone_number must be an E.164 phone number such as +14155550100")
+	}
+	if phone := r.NormalizedInboxPhone(); phone != "" && !validate.IsE164(phone) {
+		errs = append(errs, "inbox_phone_number must be an E.164 phone number such as +14155550100")
+	}
+	if r.Language != "" {
+		if _, err := domain.NormalizeLanguage(r.Language); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for key, value := range r.Metadata {
+		if utf8.RuneCountInString(value) > MaxIngestMetadataValueLength {
+			errs = append(errs, fmt.Sprintf("metadata.%s must be %d characters or less", key, MaxIngestMetadataValueLength))
+		}
+	}
+
+	hasInboxPhone := r.NormalizedInboxPhone() != ""
+	switch {
+	case r.InboxID == nil && !hasInboxPhone:
+		errs = append(errs, "inbox_id or inbox_phone_number is required")
+	case r.InboxID != nil && hasInboxPhone:
+		errs = append(errs, "only one of inbox_id and inbox_phone_number may be set")
+	case r.InboxID != nil && *r.InboxID == uuid.Nil:
+		errs = append(errs, "inbox_id must be a valid UUID")
+	}
+	return errs
+}
+
+func (r *IngestMessageRequest) NormalizedCustomerPhone() string {
+	return normalizePhone(r.CustomerPhoneNumber)
+}
+
+func (r *IngestMessageRequest) NormalizedInboxPhone() string {
+	return normalizePhone(r.InboxPhoneNumber)
+}
+
+// NormalizedLanguage returns the normalized language, nil when not sent
+func (r *IngestMessageRequest) NormalizedLanguage() *string {
+	if r.Language == "" {
+		return nil
+	}
+	language, err := domain.NormalizeLanguage(r.Language)
+	if err != nil {
+		return nil
+	}
+	return &language
+}
+
+// ReceivedAt returns the message timestamp, defaulting to now
+func (r *IngestMessageRequest) ReceivedAt() time.Time {
+	if r.Timestamp != nil {
+		return r.Timestamp.UTC()
+	}
+	return time.Now().UTC()
+}
+
+// ==================== Ingest Message Response ====================
+
+type IngestMessageResponse struct {
+	MessageReceivedResponse
+	Created  bool `json:"created"`
+	Reopened bool `json:"reopened"`
+}
+
+func NewIngestMessageResponse(conv *domain.ConversationRef, created, reopened bool, matchedRuleIDs []uuid.UUID, attached []*domain.Label) IngestMessageResponse {
+	return IngestMessageResponse{
+		MessageReceivedResponse: NewMessageReceivedResponse(conv, matchedRuleIDs, attached),
+		Created:                 created,
+		Reopened:                reopened,
+	}
+}
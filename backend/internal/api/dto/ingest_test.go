@@ -0,0 +1,146 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestIngestMessageRequest_Validate(t *testing.T) {
+	inboxID := uuid.New()
+	nilID := uuid.Nil
+
+	tests := []struct {
+		name     string
+		req      dto.IngestMessageRequest
+		errCount int
+	}{
+		{
+			name: "valid with inbox phone",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+1 555-0100",
+				InboxPhoneNumber:       "+15550199",
+			},
+			errCount: 0,
+		},
+		{
+			name: "valid with inbox id",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxID:                &inboxID,
+			},
+			errCount: 0,
+		},
+		{
+			name:     "missing everything",
+			req:      dto.IngestMessageRequest{},
+			errCount: 3,
+		},
+		{
+			name: "both inbox identifiers",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxID:                &inboxID,
+				InboxPhoneNumber:       "+15550199",
+			},
+			errCount: 1,
+		},
+		{
+			name: "nil inbox id",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxID:                &nilID,
+			},
+			errCount: 1,
+		},
+		{
+			name: "customer phone too long",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+1234567890123456789012",
+				InboxPhoneNumber:       "+15550199",
+			},
+			errCount: 1,
+		},
+		{
+			name: "customer phone not E.164",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "555-0100",
+				InboxPhoneNumber:       "+15550199",
+			},
+			errCount: 1,
+		},
+		{
+			name: "valid with language",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxPhoneNumber:       "+15550199",
+				Language:               "es-MX",
+			},
+			errCount: 0,
+		},
+		{
+			name: "invalid language",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxPhoneNumber:       "+15550199",
+				Language:               "spanish",
+			},
+			errCount: 1,
+		},
+		{
+			name: "metadata value too long",
+			req: dto.IngestMessageRequest{
+				ExternalConversationID: "ext-1",
+				CustomerPhoneNumber:    "+15550100",
+				InboxPhoneNumber:       "+15550199",
+				Metadata:               map[string]string{"campaign": "spring", "note": strings.Repeat("x", 257)},
+			},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestIngestMessageRequest_ReceivedAt(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	req := dto.IngestMessageRequest{Timestamp: &ts}
+	if got := req.ReceivedAt(); !got.Equal(ts) || got.Location() != time.UTC {
+		t.Errorf("ReceivedAt() = %v, want %v in UTC", got, ts)
+	}
+
+	before := time.Now().UTC()
+	if got := (&dto.IngestMessageRequest{}).ReceivedAt(); got.Before(before) {
+		t.Errorf("ReceivedAt() = %v, want now", got)
+	}
+}
+
+func TestIngestMessageRequest_NormalizedLanguage(t *testing.T) {
+	req := dto.IngestMessageRequest{Language: "pt_BR"}
+	if got := req.NormalizedLanguage(); got == nil || *got != "pt" {
+		t.Errorf("NormalizedLanguage() = %v, want pt", got)
+	}
+
+	req.Language = ""
+	if got := req.NormalizedLanguage(); got != nil {
+		t.Errorf("NormalizedLanguage() = %v, want nil", *got)
+	}
+}
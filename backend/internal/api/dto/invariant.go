@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Invariant Report Response ====================
+
+type InvariantViolationResponse struct {
+	Invariant              string     `json:"invariant"`
+	ConversationID         uuid.UUID  `json:"conversation_id"`
+	ExternalConversationID string     `json:"external_conversation_id"`
+	InboxID                uuid.UUID  `json:"inbox_id"`
+	State                  string     `json:"state"`
+	OperatorID             *uuid.UUID `json:"operator_id"`
+	Repairable             bool       `json:"repairable"`
+	Repaired               bool       `json:"repaired"`
+}
+
+type InvariantReportResponse struct {
+	TenantID   uuid.UUID                    `json:"tenant_id"`
+	CheckedAt  time.Time                    `json:"checked_at"`
+	Total      int                          `json:"total"`
+	Repaired   int                          `json:"repaired"`
+	Counts     map[string]int               `json:"counts"`
+	Violations []InvariantViolationResponse `json:"violations"`
+}
+
+func NewInvariantReportResponse(r *domain.InvariantReport) InvariantReportResponse {
+	counts := make(map[string]int, len(domain.InvariantKinds))
+	for kind, n := range r.Counts() {
+		counts[string(kind)] = n
+	}
+
+	violations := make([]InvariantViolationResponse, len(r.Violations))
+	for i, v := range r.Violations {
+		violations[i] = InvariantViolationResponse{
+			Invariant:              string(v.Kind),
+			ConversationID:         v.ConversationID,
+			ExternalConversationID: v.ExternalConversationID,
+			InboxID:                v.InboxID,
+			State:                  string(v.State),
+			OperatorID:             v.OperatorID,
+			Repairable:             v.Kind.Repairable(),
+			Repaired:               v.Repaired,
+		}
+	}
+
+	return InvariantReportResponse{
+		TenantID:   r.TenantID,
+		CheckedAt:  r.CheckedAt,
+		Total:      len(r.Violations),
+		Repaired:   r.Repaired(),
+		Counts:     counts,
+		Violations: violations,
+	}
+}
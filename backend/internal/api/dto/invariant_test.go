@@ -0,0 +1,43 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInvariantReportResponse(t *testing.T) {
+	operatorID := uuid.Must(uuid.NewV7())
+	report := domain.NewInvariantReport(uuid.Must(uuid.NewV7()))
+	report.Violations = append(report.Violations,
+		&domain.InvariantViolation{
+			Kind:       domain.InvariantOperatorNotSubscribed,
+			State:      domain.ConversationStateAllocated,
+			OperatorID: &operatorID,
+			Repaired:   true,
+		},
+		&domain.InvariantViolation{
+			Kind:  domain.InvariantDuplicateExternalID,
+			State: domain.ConversationStateQueued,
+		},
+	)
+
+	resp := dto.NewInvariantReportResponse(report)
+	assert.Equal(t, report.TenantID, resp.TenantID)
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, 1, resp.Repaired)
+	assert.Len(t, resp.Counts, len(domain.InvariantKinds))
+	assert.Equal(t, 1, resp.Counts["OPERATOR_NOT_SUBSCRIBED"])
+	assert.Equal(t, 0, resp.Counts["ALLOCATED_WITHOUT_OPERATOR"])
+
+	assert.Equal(t, "OPERATOR_NOT_SUBSCRIBED", resp.Violations[0].Invariant)
+	assert.Equal(t, "ALLOCATED", resp.Violations[0].State)
+	assert.Equal(t, &operatorID, resp.Violations[0].OperatorID)
+	assert.True(t, resp.Violations[0].Repairable)
+	assert.True(t, resp.Violations[0].Repaired)
+	assert.False(t, resp.Violations[1].Repairable)
+	assert.Nil(t, resp.Violations[1].OperatorID)
+}
@@ -4,18 +4,24 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 // ==================== Create Label Request ====================
 
 type CreateLabelRequest struct {
-	InboxID uuid.UUID `json:"inbox_id"`
-	Name    string    `json:"name"`
-	Color   *string   `json:"color"`
+	// Scope is INBOX (default) or TENANT, a label of every inbox of the
+	// tenant that takes no inbox_id
+	Scope   domain.LabelScope `json:"scope"`
+	InboxID uuid.UUID         `json:"inbox_id"`
+	Name    string            `json:"name" validate:"required,max=64"`
+	Color   *string           `json:"color" validate:"omitempty,hexcolor"`
+	// ParentLabelID nests the label below another label of the same scope
+	// and inbox
+	ParentLabelID *uuid.UUID `json:"parent_label_id"`
 }
 
 func ParseCreateLabelRequest(r *http.Request) (*CreateLabelRequest, error) {
@@ -29,23 +35,25 @@ func ParseCreateLabelRequest(r *http.Request) (*CreateLabelRequest, error) {
 	if err := json.Unmarshal(body, &req); err != nil {
 		return nil, err
 	}
+	if req.Scope == "" {
+		req.Scope = domain.LabelScopeInbox
+	}
 
 	return &req, nil
 }
 
 func (r *CreateLabelRequest) Validate() []string {
-	var errs []string
-	if r.InboxID == uuid.Nil {
+	errs := validate.Struct(r)
+	switch {
+	case r.Scope != "" && !r.Scope.IsValid():
+		errs = append(errs, "scope must be INBOX or TENANT")
+	case r.Scope == domain.LabelScopeTenant && r.InboxID != uuid.Nil:
+		errs = append(errs, "inbox_id must be omitted for a TENANT label")
+	case r.Scope != domain.LabelScopeTenant && r.InboxID == uuid.Nil:
 		errs = append(errs, "inbox_id is required")
 	}
-	name := strings.TrimSpace(r.Name)
-	if name == "" {
-		errs = append(errs, "name is required")
-	} else if len(name) > 64 {
-		errs = append(errs, "name must be 64 characters or less")
-	}
-	if r.Color != nil && len(*r.Color) > 32 {
-		errs = append(errs, "color must be 32 characters or less")
+	if r.ParentLabelID != nil && *r.ParentLabelID == uuid.Nil {
+		errs = append(errs, "parent_label_id must be a valid UUID")
 	}
 	return errs
 }
@@ -53,8 +61,28 @@ func (r *CreateLabelRequest) Validate() []string {
 // ==================== Update Label Request ====================
 
 type UpdateLabelRequest struct {
-	Name  *string `json:"name"`
-	Color *string `json:"color"`
+	Name  *string `json:"name" validate:"notblank,max=64"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+	// ParentLabelID moves the label below another label of the inbox, or to
+	// the top level when null
+	ParentLabelID OptionalUUID `json:"parent_label_id"`
+}
+
+// OptionalUUID tells an omitted ID, which keeps the current one, from null,
+// which clears it
+type OptionalUUID struct {
+	Set   bool
+	Value *uuid.UUID
+}
+
+func (o *OptionalUUID) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+	o.Value = &uuid.UUID{}
+	return json.Unmarshal(data, o.Value)
 }
 
 func ParseUpdateLabelRequest(r *http.Request) (*UpdateLabelRequest, error) {
@@ -73,21 +101,12 @@ func ParseUpdateLabelRequest(r *http.Request) (*UpdateLabelRequest, error) {
 }
 
 func (r *UpdateLabelRequest) Validate() []string {
-	var errs []string
-	if r.Name == nil && r.Color == nil {
-		errs = append(errs, "at least one field (name or color) must be provided")
-		return errs
-	}
-	if r.Name != nil {
-		name := strings.TrimSpace(*r.Name)
-		if name == "" {
-			errs = append(errs, "name cannot be empty")
-		} else if len(name) > 64 {
-			errs = append(errs, "name must be 64 characters or less")
-		}
+	if r.Name == nil && r.Color == nil && !r.ParentLabelID.Set {
+		return []string{"at least one field (name, color or parent_label_id) must be provided"}
 	}
-	if r.Color != nil && len(*r.Color) > 32 {
-		errs = append(errs, "color must be 32 characters or less")
+	errs := validate.Struct(r)
+	if r.ParentLabelID.Value != nil && *r.ParentLabelID.Value == uuid.Nil {
+		errs = append(errs, "parent_label_id must be a valid UUID")
 	}
 	return errs
 }
@@ -95,8 +114,8 @@ func (r *UpdateLabelRequest) Validate() []string {
 // ==================== Attach Label Request ====================
 
 type AttachLabelRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
-	LabelID        uuid.UUID `json:"label_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	LabelID        uuid.UUID `json:"label_id" validate:"required"`
 }
 
 func ParseAttachLabelRequest(r *http.Request) (*AttachLabelRequest, error) {
@@ -115,21 +134,14 @@ func ParseAttachLabelRequest(r *http.Request) (*AttachLabelRequest, error) {
 }
 
 func (r *AttachLabelRequest) Validate() []string {
-	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
-	}
-	if r.LabelID == uuid.Nil {
-		errs = append(errs, "label_id is required")
-	}
-	return errs
+	return validate.Struct(r)
 }
 
 // ==================== Detach Label Request ====================
 
 type DetachLabelRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
-	LabelID        uuid.UUID `json:"label_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	LabelID        uuid.UUID `json:"label_id" validate:"required"`
 }
 
 func ParseDetachLabelRequest(r *http.Request) (*DetachLabelRequest, error) {
@@ -148,37 +160,41 @@ func ParseDetachLabelRequest(r *http.Request) (*DetachLabelRequest, error) {
 }
 
 func (r *DetachLabelRequest) Validate() []string {
-	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
-	}
-	if r.LabelID == uuid.Nil {
-		errs = append(errs, "label_id is required")
-	}
-	return errs
+	return validate.Struct(r)
 }
 
 // ==================== Label Response ====================
 
 type LabelResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	TenantID  uuid.UUID  `json:"tenant_id"`
-	InboxID   uuid.UUID  `json:"inbox_id"`
-	Name      string     `json:"name"`
-	Color     *string    `json:"color"`
-	CreatedBy *uuid.UUID `json:"created_by"`
-	CreatedAt string     `json:"created_at"`
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	Scope    string    `json:"scope"`
+	// InboxID is null for a TENANT label
+	InboxID       *uuid.UUID `json:"inbox_id"`
+	Name          string     `json:"name"`
+	Color         *string    `json:"color"`
+	CreatedBy     *uuid.UUID `json:"created_by"`
+	CreatedAt     string     `json:"created_at"`
+	Version       int        `json:"version"`
+	ParentLabelID *uuid.UUID `json:"parent_label_id"`
 }
 
 func NewLabelResponse(l *domain.Label) LabelResponse {
+	var inboxID *uuid.UUID
+	if l.Scope != domain.LabelScopeTenant {
+		inboxID = &l.InboxID
+	}
 	return LabelResponse{
-		ID:        l.ID,
-		TenantID:  l.TenantID,
-		InboxID:   l.InboxID,
-		Name:      l.Name,
-		Color:     l.Color,
-		CreatedBy: l.CreatedBy,
-		CreatedAt: l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:            l.ID,
+		TenantID:      l.TenantID,
+		Scope:         string(l.Scope),
+		InboxID:       inboxID,
+		Name:          l.Name,
+		Color:         l.Color,
+		CreatedBy:     l.CreatedBy,
+		CreatedAt:     l.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Version:       l.Version,
+		ParentLabelID: l.ParentLabelID,
 	}
 }
 
@@ -190,6 +206,47 @@ func NewLabelListResponse(labels []*domain.Label) []LabelResponse {
 	return result
 }
 
+// LabelTreeResponse is a label with the labels nested below it
+type LabelTreeResponse struct {
+	LabelResponse
+	Children []LabelTreeResponse `json:"children"`
+}
+
+// NewLabelTreeResponse lists the top-level labels with their descendants
+func NewLabelTreeResponse(nodes []*domain.LabelNode) []LabelTreeResponse {
+	result := make([]LabelTreeResponse, len(nodes))
+	for i, node := range nodes {
+		result[i] = LabelTreeResponse{
+			LabelResponse: NewLabelResponse(node.Label),
+			Children:      NewLabelTreeResponse(node.Children),
+		}
+	}
+	return result
+}
+
+// LabelVersionResponse is one entry of a label's rename history
+type LabelVersionResponse struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Color     *string    `json:"color"`
+	ChangedBy *uuid.UUID `json:"changed_by"`
+	CreatedAt string     `json:"created_at"`
+}
+
+func NewLabelVersionListResponse(versions []*domain.LabelVersion) []LabelVersionResponse {
+	result := make([]LabelVersionResponse, len(versions))
+	for i, v := range versions {
+		result[i] = LabelVersionResponse{
+			Version:   v.Version,
+			Name:      v.Name,
+			Color:     v.Color,
+			ChangedBy: v.ChangedBy,
+			CreatedAt: v.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	return result
+}
+
 // ==================== Error Codes ====================
 
 const (
@@ -197,5 +254,6 @@ const (
 	ErrCodeLabelNameConflict     = "LABEL_NAME_CONFLICT"
 	ErrCodeLabelInboxMismatch    = "LABEL_INBOX_MISMATCH"
 	ErrCodeLabelPermissionDenied = "LABEL_PERMISSION_DENIED"
-	ErrCodeInboxNotFoundLabel    = "INBOX_NOT_FOUND"
+	ErrCodeLabelParentInvalid    = "LABEL_PARENT_INVALID"
+	ErrCodeLabelCycle            = "LABEL_CYCLE"
 )
@@ -3,11 +3,15 @@ package dto_test
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
 )
 
 func TestCreateLabelRequest_Validate(t *testing.T) {
@@ -15,6 +19,8 @@ func TestCreateLabelRequest_Validate(t *testing.T) {
 	color := "#FF0000"
 	longName := string(make([]byte, 65))
 	longColor := string(make([]byte, 33))
+	namedColor := "red"
+	shortColor := "#f00"
 
 	tests := []struct {
 		name     string
@@ -64,6 +70,36 @@ func TestCreateLabelRequest_Validate(t *testing.T) {
 			wantErr:  true,
 			errCount: 1,
 		},
+		{
+			name:     "color not hex",
+			req:      dto.CreateLabelRequest{InboxID: validID, Name: "Test", Color: &namedColor},
+			wantErr:  true,
+			errCount: 1,
+		},
+		{
+			name:     "short hex color",
+			req:      dto.CreateLabelRequest{InboxID: validID, Name: "Test", Color: &shortColor},
+			wantErr:  false,
+			errCount: 0,
+		},
+		{
+			name:     "valid tenant label",
+			req:      dto.CreateLabelRequest{Scope: domain.LabelScopeTenant, Name: "VIP"},
+			wantErr:  false,
+			errCount: 0,
+		},
+		{
+			name:     "tenant label with inbox_id",
+			req:      dto.CreateLabelRequest{Scope: domain.LabelScopeTenant, InboxID: validID, Name: "VIP"},
+			wantErr:  true,
+			errCount: 1,
+		},
+		{
+			name:     "unknown scope",
+			req:      dto.CreateLabelRequest{Scope: "GLOBAL", InboxID: validID, Name: "VIP"},
+			wantErr:  true,
+			errCount: 1,
+		},
 		{
 			name:     "multiple errors",
 			req:      dto.CreateLabelRequest{},
@@ -143,6 +179,18 @@ func TestUpdateLabelRequest_Validate(t *testing.T) {
 			wantErr:  true,
 			errCount: 1,
 		},
+		{
+			name:     "parent cleared only",
+			req:      dto.UpdateLabelRequest{ParentLabelID: dto.OptionalUUID{Set: true}},
+			wantErr:  false,
+			errCount: 0,
+		},
+		{
+			name:     "nil parent",
+			req:      dto.UpdateLabelRequest{ParentLabelID: dto.OptionalUUID{Set: true, Value: &uuid.Nil}},
+			wantErr:  true,
+			errCount: 1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -290,6 +338,38 @@ func TestParseCreateLabelRequest(t *testing.T) {
 	}
 }
 
+func TestParseUpdateLabelRequest_Parent(t *testing.T) {
+	parentID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+
+	tests := []struct {
+		name      string
+		body      string
+		wantSet   bool
+		wantValue *uuid.UUID
+	}{
+		{"omitted keeps the parent", `{"name": "Refunds"}`, false, nil},
+		{"null moves to the top level", `{"parent_label_id": null}`, true, nil},
+		{"id moves below the parent", `{"parent_label_id": "` + parentID.String() + `"}`, true, &parentID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/labels/x", strings.NewReader(tt.body))
+			parsed, err := dto.ParseUpdateLabelRequest(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if parsed.ParentLabelID.Set != tt.wantSet {
+				t.Errorf("set: expected %v, got %v", tt.wantSet, parsed.ParentLabelID.Set)
+			}
+			if (tt.wantValue == nil) != (parsed.ParentLabelID.Value == nil) ||
+				(tt.wantValue != nil && *parsed.ParentLabelID.Value != *tt.wantValue) {
+				t.Errorf("value: expected %v, got %v", tt.wantValue, parsed.ParentLabelID.Value)
+			}
+		})
+	}
+}
+
 func TestParseAttachLabelRequest(t *testing.T) {
 	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
 
@@ -311,3 +391,49 @@ func TestParseAttachLabelRequest(t *testing.T) {
 		t.Errorf("label_id: expected %v, got %v", validID, parsed.LabelID)
 	}
 }
+
+// fuzzRequest wraps a fuzzed body in a request for the Parse functions
+func fuzzRequest(body []byte) *http.Request {
+	return httptest.NewRequest("POST", "/", bytes.NewReader(body))
+}
+
+func FuzzLabelRequests(f *testing.F) {
+	seeds := testutil.NewFactory(1)
+	for i := 0; i < 4; i++ {
+		label := seeds.Label(seeds.UUID(), seeds.UUID())
+		body, _ := json.Marshal(map[string]interface{}{
+			"inbox_id":        label.InboxID,
+			"name":            label.Name,
+			"color":           label.Color,
+			"conversation_id": seeds.UUID(),
+			"label_id":        label.ID,
+		})
+		f.Add(body)
+	}
+	f.Add([]byte(`{"name": "   ", "inbox_id": "00000000-0000-0000-0000-000000000000"}`))
+	f.Add([]byte(`{"scope": "TENANT", "name": "VIP"}`))
+	f.Add([]byte(`{"color": 7}`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		if req, err := dto.ParseCreateLabelRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 {
+			name := strings.TrimSpace(req.Name)
+			if (req.Scope != domain.LabelScopeTenant) == (req.InboxID == uuid.Nil) || name == "" || len(name) > 64 {
+				t.Errorf("invalid create request accepted: %+v", req)
+			}
+		}
+		if req, err := dto.ParseUpdateLabelRequest(fuzzRequest(body)); err == nil {
+			req.Validate()
+		}
+		if req, err := dto.ParseAttachLabelRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 {
+			if req.ConversationID == uuid.Nil || req.LabelID == uuid.Nil {
+				t.Errorf("invalid attach request accepted: %+v", req)
+			}
+		}
+		if req, err := dto.ParseDetachLabelRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 {
+			if req.ConversationID == uuid.Nil || req.LabelID == uuid.Nil {
+				t.Errorf("invalid detach request accepted: %+v", req)
+			}
+		}
+	})
+}
@@ -2,17 +2,21 @@ package dto
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 // ==================== Resolve Request ====================
 
 type ResolveRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
 }
 
 func ParseResolveRequest(r *http.Request) (*ResolveRequest, error) {
@@ -31,17 +35,44 @@ func ParseResolveRequest(r *http.Request) (*ResolveRequest, error) {
 }
 
 func (r *ResolveRequest) Validate() []string {
-	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
+	return validate.Struct(r)
+}
+
+// ==================== Reopen Request ====================
+
+type ReopenRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+}
+
+func ParseReopenRequest(r *http.Request) (*ReopenRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
 	}
-	return errs
+	defer r.Body.Close()
+
+	var req ReopenRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *ReopenRequest) Validate() []string {
+	return validate.Struct(r)
 }
 
 // ==================== Deallocate Request ====================
 
+// MaxDeallocateGrace bounds the handover grace period of a deallocation
+const MaxDeallocateGrace = time.Hour
+
 type DeallocateRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	// GraceSeconds, when set, keeps the conversation with its operator for
+	// that long before it returns to the queue
+	GraceSeconds *int `json:"grace_seconds" validate:"min=1,max=3600"`
 }
 
 func ParseDeallocateRequest(r *http.Request) (*DeallocateRequest, error) {
@@ -60,18 +91,24 @@ func ParseDeallocateRequest(r *http.Request) (*DeallocateRequest, error) {
 }
 
 func (r *DeallocateRequest) Validate() []string {
-	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
+	return validate.Struct(r)
+}
+
+// Grace returns the requested grace period, zero for an immediate deallocation
+func (r *DeallocateRequest) Grace() time.Duration {
+	if r.GraceSeconds == nil {
+		return 0
 	}
-	return errs
+	return time.Duration(*r.GraceSeconds) * time.Second
 }
 
 // ==================== Reassign Request ====================
 
 type ReassignRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
-	OperatorID     uuid.UUID `json:"operator_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	OperatorID     uuid.UUID `json:"operator_id" validate:"required"`
+	// HandoverNote is optional context for the new operator
+	HandoverNote string `json:"handover_note" validate:"max=2000"`
 }
 
 func ParseReassignRequest(r *http.Request) (*ReassignRequest, error) {
@@ -90,21 +127,19 @@ func ParseReassignRequest(r *http.Request) (*ReassignRequest, error) {
 }
 
 func (r *ReassignRequest) Validate() []string {
-	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
-	}
-	if r.OperatorID == uuid.Nil {
-		errs = append(errs, "operator_id is required")
-	}
-	return errs
+	return validate.Struct(r)
+}
+
+// GetHandoverNote returns the trimmed note, empty for none
+func (r *ReassignRequest) GetHandoverNote() string {
+	return strings.TrimSpace(r.HandoverNote)
 }
 
 // ==================== Move Inbox Request ====================
 
 type MoveInboxRequest struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
-	InboxID        uuid.UUID `json:"inbox_id"`
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	InboxID        uuid.UUID `json:"inbox_id" validate:"required"`
 }
 
 func ParseMoveInboxRequest(r *http.Request) (*MoveInboxRequest, error) {
@@ -123,12 +158,214 @@ func ParseMoveInboxRequest(r *http.Request) (*MoveInboxRequest, error) {
 }
 
 func (r *MoveInboxRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Snooze Request ====================
+
+// MaxSnoozeDuration bounds how far ahead a conversation can be snoozed
+const MaxSnoozeDuration = 30 * 24 * time.Hour
+
+// SnoozeRequest parks an allocated conversation until a follow-up time; with
+// return_to_operator it goes back to the same operator instead of the queue
+type SnoozeRequest struct {
+	ConversationID   uuid.UUID  `json:"conversation_id" validate:"required"`
+	Until            *time.Time `json:"until"`
+	ReturnToOperator bool       `json:"return_to_operator"`
+}
+
+func ParseSnoozeRequest(r *http.Request) (*SnoozeRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var req SnoozeRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *SnoozeRequest) Validate() []string {
+	errs := validate.Struct(r)
+	now := time.Now()
+	switch {
+	case r.Until == nil:
+		errs = append(errs, "until is required")
+	case !r.Until.After(now):
+		errs = append(errs, "until must be in the future")
+	case r.Until.After(now.Add(MaxSnoozeDuration)):
+		errs = append(errs, "until must be at most 30 days ahead")
+	}
+	return errs
+}
+
+// ==================== Escalate Request ====================
+
+// EscalateRequest hands an allocated conversation to its inbox's escalation
+// inbox; the reason is recorded for managers
+type EscalateRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	Reason         string    `json:"reason" validate:"required,max=2000"`
+}
+
+func ParseEscalateRequest(r *http.Request) (*EscalateRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var req EscalateRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *EscalateRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// GetReason returns the trimmed reason
+func (r *EscalateRequest) GetReason() string {
+	return strings.TrimSpace(r.Reason)
+}
+
+// ==================== Bulk Resolve Request ====================
+
+// MaxBulkConversationIDs bounds the conversation IDs of one bulk request
+const MaxBulkConversationIDs = 500
+
+type BulkResolveRequest struct {
+	ConversationIDs []uuid.UUID `json:"conversation_ids"`
+}
+
+func ParseBulkResolveRequest(r *http.Request) (*BulkResolveRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var req BulkResolveRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *BulkResolveRequest) Validate() []string {
+	return validateBulkConversationIDs(r.ConversationIDs)
+}
+
+func validateBulkConversationIDs(ids []uuid.UUID) []string {
 	var errs []string
-	if r.ConversationID == uuid.Nil {
-		errs = append(errs, "conversation_id is required")
+	if len(ids) == 0 {
+		errs = append(errs, "conversation_ids is required")
+	}
+	if len(ids) > MaxBulkConversationIDs {
+		errs = append(errs, fmt.Sprintf("conversation_ids must not contain more than %d IDs", MaxBulkConversationIDs))
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil {
+			errs = append(errs, "conversation_ids must not contain nil UUIDs")
+			break
+		}
+		if _, dup := seen[id]; dup {
+			errs = append(errs, "conversation_ids must not contain duplicates")
+			break
+		}
+		seen[id] = struct{}{}
+	}
+	return errs
+}
+
+// ==================== Bulk Reassign Request ====================
+
+// BulkReassignRequest selects the conversations either by ID or as the
+// backlog of FromOperatorID
+type BulkReassignRequest struct {
+	ConversationIDs []uuid.UUID `json:"conversation_ids"`
+	FromOperatorID  *uuid.UUID  `json:"from_operator_id"`
+	OperatorID      uuid.UUID   `json:"operator_id" validate:"required"`
+}
+
+func ParseBulkReassignRequest(r *http.Request) (*BulkReassignRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
 	}
-	if r.InboxID == uuid.Nil {
-		errs = append(errs, "inbox_id is required")
+	defer r.Body.Close()
+
+	var req BulkReassignRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *BulkReassignRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.FromOperatorID == nil {
+		return append(errs, validateBulkConversationIDs(r.ConversationIDs)...)
+	}
+	if len(r.ConversationIDs) > 0 {
+		errs = append(errs, "only one of conversation_ids and from_operator_id may be set")
+	}
+	if *r.FromOperatorID == uuid.Nil {
+		errs = append(errs, "from_operator_id must not be a nil UUID")
+	} else if *r.FromOperatorID == r.OperatorID {
+		errs = append(errs, "from_operator_id must differ from operator_id")
+	}
+	return errs
+}
+
+// ==================== Bulk Move Inbox Request ====================
+
+// BulkMoveInboxRequest selects the conversations either by ID or as the open
+// conversations of FromInboxID
+type BulkMoveInboxRequest struct {
+	ConversationIDs []uuid.UUID `json:"conversation_ids"`
+	FromInboxID     *uuid.UUID  `json:"from_inbox_id"`
+	InboxID         uuid.UUID   `json:"inbox_id" validate:"required"`
+}
+
+func ParseBulkMoveInboxRequest(r *http.Request) (*BulkMoveInboxRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var req BulkMoveInboxRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *BulkMoveInboxRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.FromInboxID == nil {
+		return append(errs, validateBulkConversationIDs(r.ConversationIDs)...)
+	}
+	if len(r.ConversationIDs) > 0 {
+		errs = append(errs, "only one of conversation_ids and from_inbox_id may be set")
+	}
+	if *r.FromInboxID == uuid.Nil {
+		errs = append(errs, "from_inbox_id must not be a nil UUID")
+	} else if *r.FromInboxID == r.InboxID {
+		errs = append(errs, "from_inbox_id must differ from inbox_id")
 	}
 	return errs
 }
@@ -149,6 +386,9 @@ type LifecycleResponse struct {
 	CreatedAt              string     `json:"created_at"`
 	UpdatedAt              string     `json:"updated_at"`
 	ResolvedAt             *string    `json:"resolved_at"`
+	ReopenedCount          int        `json:"reopened_count"`
+	SnoozedUntil           *string    `json:"snoozed_until"`
+	SnoozeOperatorID       *uuid.UUID `json:"snooze_operator_id"`
 }
 
 func NewLifecycleResponse(c *domain.ConversationRef) LifecycleResponse {
@@ -160,6 +400,12 @@ func NewLifecycleResponse(c *domain.ConversationRef) LifecycleResponse {
 		resolvedAt = &t
 	}
 
+	var snoozedUntil *string
+	if c.SnoozedUntil != nil {
+		t := c.SnoozedUntil.Format("2006-01-02T15:04:05Z07:00")
+		snoozedUntil = &t
+	}
+
 	return LifecycleResponse{
 		ID:                     c.ID,
 		TenantID:               c.TenantID,
@@ -174,6 +420,9 @@ func NewLifecycleResponse(c *domain.ConversationRef) LifecycleResponse {
 		CreatedAt:              c.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:              c.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		ResolvedAt:             resolvedAt,
+		ReopenedCount:          int(c.ReopenedCount),
+		SnoozedUntil:           snoozedUntil,
+		SnoozeOperatorID:       c.SnoozeOperatorID,
 	}
 }
 
@@ -183,9 +432,12 @@ const (
 	ErrCodeConversationNotFound           = "CONVERSATION_NOT_FOUND"
 	ErrCodeConversationNotAllocated       = "CONVERSATION_NOT_ALLOCATED"
 	ErrCodeConversationAlreadyResolved    = "CONVERSATION_ALREADY_RESOLVED"
+	ErrCodeConversationNotResolved        = "CONVERSATION_NOT_RESOLVED"
 	ErrCodeInsufficientPermissions        = "INSUFFICIENT_PERMISSIONS"
 	ErrCodeOperatorNotFoundLifecycle      = "OPERATOR_NOT_FOUND"
 	ErrCodeOperatorNotSubscribedLifecycle = "OPERATOR_NOT_SUBSCRIBED"
 	ErrCodeInboxNotFound                  = "INBOX_NOT_FOUND"
 	ErrCodeInboxDifferentTenant           = "INBOX_DIFFERENT_TENANT"
+	ErrCodeEscalationNotConfigured        = "ESCALATION_NOT_CONFIGURED"
+	ErrCodeIntakeThrottled                = "INTAKE_THROTTLED"
 )
@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
 )
 
 func TestResolveRequest_Validate(t *testing.T) {
@@ -34,7 +38,7 @@ func TestResolveRequest_Validate(t *testing.T) {
 	}
 }
 
-func TestDeallocateRequest_Validate(t *testing.T) {
+func TestReopenRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name           string
 		conversationID uuid.UUID
@@ -46,7 +50,7 @@ func TestDeallocateRequest_Validate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			req := &dto.DeallocateRequest{ConversationID: tt.conversationID}
+			req := &dto.ReopenRequest{ConversationID: tt.conversationID}
 			errs := req.Validate()
 			if tt.wantErr && len(errs) == 0 {
 				t.Error("expected validation error")
@@ -58,6 +62,49 @@ func TestDeallocateRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestDeallocateRequest_Validate(t *testing.T) {
+	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	seconds := func(n int) *int { return &n }
+
+	tests := []struct {
+		name           string
+		conversationID uuid.UUID
+		graceSeconds   *int
+		wantErr        bool
+	}{
+		{"valid UUID", validID, nil, false},
+		{"nil UUID", uuid.Nil, nil, true},
+		{"with grace", validID, seconds(120), false},
+		{"longest grace", validID, seconds(3600), false},
+		{"zero grace", validID, seconds(0), true},
+		{"grace too long", validID, seconds(3601), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.DeallocateRequest{ConversationID: tt.conversationID, GraceSeconds: tt.graceSeconds}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestDeallocateRequest_Grace(t *testing.T) {
+	if grace := (&dto.DeallocateRequest{}).Grace(); grace != 0 {
+		t.Errorf("expected an immediate deallocation, got %v", grace)
+	}
+
+	seconds := 90
+	if grace := (&dto.DeallocateRequest{GraceSeconds: &seconds}).Grace(); grace != 90*time.Second {
+		t.Errorf("expected 90s, got %v", grace)
+	}
+}
+
 func TestReassignRequest_Validate(t *testing.T) {
 	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
 
@@ -94,6 +141,28 @@ func TestReassignRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestReassignRequest_HandoverNote(t *testing.T) {
+	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+
+	req := &dto.ReassignRequest{ConversationID: validID, OperatorID: validID, HandoverNote: "  refund approved \n"}
+	if errs := req.Validate(); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if got := req.GetHandoverNote(); got != "refund approved" {
+		t.Errorf("expected trimmed note, got %q", got)
+	}
+
+	// The limit counts characters, not bytes
+	req.HandoverNote = strings.Repeat("é", domain.MaxHandoverNoteLength)
+	if errs := req.Validate(); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	req.HandoverNote += "x"
+	if errs := req.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %d", len(errs))
+	}
+}
+
 func TestMoveInboxRequest_Validate(t *testing.T) {
 	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
 
@@ -130,6 +199,66 @@ func TestMoveInboxRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestSnoozeRequest_Validate(t *testing.T) {
+	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	at := func(d time.Duration) *time.Time {
+		t := time.Now().Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name           string
+		conversationID uuid.UUID
+		until          *time.Time
+		errCount       int
+	}{
+		{"valid", validID, at(time.Hour), 0},
+		{"nil conversation", uuid.Nil, at(time.Hour), 1},
+		{"missing until", validID, nil, 1},
+		{"until in the past", validID, at(-time.Minute), 1},
+		{"until too far ahead", validID, at(dto.MaxSnoozeDuration + time.Hour), 1},
+		{"nothing set", uuid.Nil, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.SnoozeRequest{ConversationID: tt.conversationID, Until: tt.until}
+			errs := req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %v", tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestEscalateRequest_Validate(t *testing.T) {
+	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+
+	tests := []struct {
+		name           string
+		conversationID uuid.UUID
+		reason         string
+		errCount       int
+	}{
+		{"valid", validID, "Customer asks for a refund above my limit", 0},
+		{"nil conversation", uuid.Nil, "Refund", 1},
+		{"missing reason", validID, "", 1},
+		{"blank reason", validID, "   ", 1},
+		{"reason too long", validID, strings.Repeat("a", domain.MaxEscalationReasonLength+1), 1},
+		{"nothing set", uuid.Nil, "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.EscalateRequest{ConversationID: tt.conversationID, Reason: tt.reason}
+			errs := req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %v", tt.errCount, errs)
+			}
+		})
+	}
+}
+
 func TestParseResolveRequest(t *testing.T) {
 	validID := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
 	body, _ := json.Marshal(map[string]interface{}{
@@ -167,3 +296,208 @@ func TestParseReassignRequest(t *testing.T) {
 		t.Errorf("operator_id: expected %v, got %v", validID, parsed.OperatorID)
 	}
 }
+
+func TestBulkResolveRequest_Validate(t *testing.T) {
+	idA := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	idB := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345679")
+
+	tooMany := make([]uuid.UUID, dto.MaxBulkConversationIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name    string
+		ids     []uuid.UUID
+		wantErr bool
+	}{
+		{"valid IDs", []uuid.UUID{idA, idB}, false},
+		{"empty", nil, true},
+		{"nil UUID", []uuid.UUID{idA, uuid.Nil}, true},
+		{"duplicate", []uuid.UUID{idA, idB, idA}, true},
+		{"too many", tooMany, true},
+		{"at limit", tooMany[:dto.MaxBulkConversationIDs], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.BulkResolveRequest{ConversationIDs: tt.ids}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestBulkReassignRequest_Validate(t *testing.T) {
+	idA := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	target := uuid.MustParse("660fc2c9-1234-5678-9abc-def012345678")
+	from := uuid.MustParse("770fc2c9-1234-5678-9abc-def012345678")
+	nilID := uuid.Nil
+
+	tests := []struct {
+		name    string
+		req     dto.BulkReassignRequest
+		wantErr bool
+	}{
+		{"by IDs", dto.BulkReassignRequest{ConversationIDs: []uuid.UUID{idA}, OperatorID: target}, false},
+		{"by operator backlog", dto.BulkReassignRequest{FromOperatorID: &from, OperatorID: target}, false},
+		{"missing target", dto.BulkReassignRequest{ConversationIDs: []uuid.UUID{idA}}, true},
+		{"no selection", dto.BulkReassignRequest{OperatorID: target}, true},
+		{"both selections", dto.BulkReassignRequest{ConversationIDs: []uuid.UUID{idA}, FromOperatorID: &from, OperatorID: target}, true},
+		{"nil from operator", dto.BulkReassignRequest{FromOperatorID: &nilID, OperatorID: target}, true},
+		{"from target", dto.BulkReassignRequest{FromOperatorID: &target, OperatorID: target}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestBulkMoveInboxRequest_Validate(t *testing.T) {
+	idA := uuid.MustParse("550fc2c9-1234-5678-9abc-def012345678")
+	target := uuid.MustParse("660fc2c9-1234-5678-9abc-def012345678")
+	from := uuid.MustParse("770fc2c9-1234-5678-9abc-def012345678")
+
+	tests := []struct {
+		name    string
+		req     dto.BulkMoveInboxRequest
+		wantErr bool
+	}{
+		{"by IDs", dto.BulkMoveInboxRequest{ConversationIDs: []uuid.UUID{idA}, InboxID: target}, false},
+		{"by inbox backlog", dto.BulkMoveInboxRequest{FromInboxID: &from, InboxID: target}, false},
+		{"missing target", dto.BulkMoveInboxRequest{FromInboxID: &from}, true},
+		{"duplicate IDs", dto.BulkMoveInboxRequest{ConversationIDs: []uuid.UUID{idA, idA}, InboxID: target}, true},
+		{"both selections", dto.BulkMoveInboxRequest{ConversationIDs: []uuid.UUID{idA}, FromInboxID: &from, InboxID: target}, true},
+		{"from target", dto.BulkMoveInboxRequest{FromInboxID: &target, InboxID: target}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func FuzzLifecycleRequests(f *testing.F) {
+	seeds := testutil.NewFactory(1)
+	for i := 0; i < 4; i++ {
+		body, _ := json.Marshal(map[string]interface{}{
+			"conversation_id":    seeds.UUID(),
+			"conversation_ids":   []uuid.UUID{seeds.UUID(), seeds.UUID()},
+			"operator_id":        seeds.UUID(),
+			"inbox_id":           seeds.UUID(),
+			"handover_note":      seeds.Name("note"),
+			"until":              time.Now().Add(time.Hour),
+			"return_to_operator": seeds.Bool(0.5),
+		})
+		f.Add(body)
+	}
+	f.Add([]byte(`{"conversation_ids": [], "from_operator_id": "00000000-0000-0000-0000-000000000000"}`))
+	f.Add([]byte(`{"until": "not-a-time"}`))
+	f.Add([]byte(`[]`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		single := map[string]func() (uuid.UUID, []string, error){
+			"resolve": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseResolveRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"reopen": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseReopenRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"deallocate": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseDeallocateRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"reassign": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseReassignRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"move": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseMoveInboxRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"snooze": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseSnoozeRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+			"escalate": func() (uuid.UUID, []string, error) {
+				req, err := dto.ParseEscalateRequest(fuzzRequest(body))
+				if err != nil {
+					return uuid.Nil, nil, err
+				}
+				return req.ConversationID, req.Validate(), nil
+			},
+		}
+		for name, parse := range single {
+			id, errs, err := parse()
+			if err == nil && len(errs) == 0 && id == uuid.Nil {
+				t.Errorf("%s: request without conversation_id accepted", name)
+			}
+		}
+
+		if req, err := dto.ParseBulkResolveRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 {
+			checkBulkIDs(t, req.ConversationIDs)
+		}
+		if req, err := dto.ParseBulkReassignRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 && req.FromOperatorID == nil {
+			checkBulkIDs(t, req.ConversationIDs)
+		}
+		if req, err := dto.ParseBulkMoveInboxRequest(fuzzRequest(body)); err == nil && len(req.Validate()) == 0 && req.FromInboxID == nil {
+			checkBulkIDs(t, req.ConversationIDs)
+		}
+	})
+}
+
+// checkBulkIDs fails unless the accepted IDs are non-empty, bounded, non-nil
+// and distinct
+func checkBulkIDs(t *testing.T, ids []uuid.UUID) {
+	t.Helper()
+	if len(ids) == 0 || len(ids) > dto.MaxBulkConversationIDs {
+		t.Errorf("accepted %d conversation IDs", len(ids))
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil || seen[id] {
+			t.Errorf("accepted nil or duplicate conversation ID %s", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,172 @@
+package dto
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+const (
+	// MinMaintenanceOverrideSeconds is the shortest manual override
+	MinMaintenanceOverrideSeconds = 60
+)
+
+// ==================== Set Maintenance Window Request ====================
+
+type SetMaintenanceWindowRequest struct {
+	// Window is "HH:MM-HH:MM"; an end not after the start runs past midnight
+	Window   string `json:"window" validate:"required"`
+	Timezone string `json:"timezone" validate:"required"`
+}
+
+func (r *SetMaintenanceWindowRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if len(errs) > 0 {
+		return errs
+	}
+	if _, err := r.GetWindow(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// GetWindow parses the window; Validate reports its error
+func (r *SetMaintenanceWindowRequest) GetWindow() (*domain.MaintenanceWindow, error) {
+	return domain.ParseMaintenanceWindow(strings.TrimSpace(r.Window), strings.TrimSpace(r.Timezone))
+}
+
+// ==================== Set Maintenance Override Request ====================
+
+type SetMaintenanceOverrideRequest struct {
+	Mode            string `json:"mode" validate:"required"`
+	DurationSeconds int    `json:"duration_seconds" validate:"min=60,max=604800"`
+	Reason          string `json:"reason" validate:"max=500"`
+}
+
+func (r *SetMaintenanceOverrideRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if strings.TrimSpace(r.Mode) != "" && !r.GetMode().IsValid() {
+		errs = append(errs, "mode must be one of OPEN, CLOSED")
+	}
+	return errs
+}
+
+// GetMode returns the mode, case-insensitively
+func (r *SetMaintenanceOverrideRequest) GetMode() domain.MaintenanceOverrideMode {
+	return domain.MaintenanceOverrideMode(strings.ToUpper(strings.TrimSpace(r.Mode)))
+}
+
+// GetOverride returns the override starting at now; Validate must have passed
+func (r *SetMaintenanceOverrideRequest) GetOverride(now time.Time) *domain.MaintenanceOverride {
+	return &domain.MaintenanceOverride{
+		Mode:   r.GetMode(),
+		Until:  now.Add(time.Duration(r.DurationSeconds) * time.Second),
+		Reason: strings.TrimSpace(r.Reason),
+	}
+}
+
+// ==================== Maintenance Responses ====================
+
+type MaintenanceWindowResponse struct {
+	Window   string `json:"window"`
+	Timezone string `json:"timezone"`
+}
+
+func newMaintenanceWindowResponse(w *domain.MaintenanceWindow) *MaintenanceWindowResponse {
+	if w == nil {
+		return nil
+	}
+	return &MaintenanceWindowResponse{Window: w.String(), Timezone: w.Timezone}
+}
+
+type MaintenanceOverrideResponse struct {
+	Mode string `json:"mode"`
+	// Null for the deployment's MAINTENANCE_FORCE, which has no expiry
+	Until  *time.Time `json:"until"`
+	Reason string     `json:"reason"`
+}
+
+func newMaintenanceOverrideResponse(o *domain.MaintenanceOverride) *MaintenanceOverrideResponse {
+	if o == nil {
+		return nil
+	}
+	resp := &MaintenanceOverrideResponse{Mode: string(o.Mode), Reason: o.Reason}
+	if !o.Until.IsZero() {
+		until := o.Until
+		resp.Until = &until
+	}
+	return resp
+}
+
+type MaintenanceStatusResponse struct {
+	Open bool `json:"open"`
+	// Null when no window is configured: heavy jobs run at any time
+	Window *MaintenanceWindowResponse `json:"window"`
+	// The override in effect, null when the window applies
+	Override   *MaintenanceOverrideResponse `json:"override"`
+	NextOpenAt *time.Time                   `json:"next_open_at"`
+}
+
+func NewMaintenanceStatusResponse(s domain.MaintenanceStatus) MaintenanceStatusResponse {
+	return MaintenanceStatusResponse{
+		Open:       s.Open,
+		Window:     newMaintenanceWindowResponse(s.Window),
+		Override:   newMaintenanceOverrideResponse(s.Override),
+		NextOpenAt: s.NextOpenAt,
+	}
+}
+
+type TenantMaintenanceResponse struct {
+	MaintenanceStatusResponse
+	// InheritsWindow is true while the tenant has no window of its own
+	InheritsWindow bool       `json:"inherits_window"`
+	UpdatedBy      *uuid.UUID `json:"updated_by"`
+	// Null while the tenant never configured maintenance
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type MaintenanceJobResponse struct {
+	Job   string `json:"job"`
+	Scope string `json:"scope"`
+	Open  bool   `json:"open"`
+}
+
+type MaintenanceReportResponse struct {
+	At         time.Time                 `json:"at"`
+	Deployment MaintenanceStatusResponse `json:"deployment"`
+	Tenant     TenantMaintenanceResponse `json:"tenant"`
+	Jobs       []MaintenanceJobResponse  `json:"jobs"`
+}
+
+func NewMaintenanceReportResponse(r *domain.MaintenanceReport) MaintenanceReportResponse {
+	tenant := TenantMaintenanceResponse{
+		MaintenanceStatusResponse: NewMaintenanceStatusResponse(r.Tenant),
+		InheritsWindow:            r.Settings.Window == nil,
+		UpdatedBy:                 r.Settings.UpdatedBy,
+	}
+	if !r.Settings.UpdatedAt.IsZero() {
+		updatedAt := r.Settings.UpdatedAt
+		tenant.UpdatedAt = &updatedAt
+	}
+
+	jobs := make([]MaintenanceJobResponse, 0, len(domain.MaintenanceJobs))
+	for job, scope := range domain.MaintenanceJobs {
+		jobs = append(jobs, MaintenanceJobResponse{
+			Job:   string(job),
+			Scope: string(scope),
+			Open:  r.JobOpen(job),
+		})
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Job < jobs[j].Job })
+
+	return MaintenanceReportResponse{
+		At:         r.At,
+		Deployment: NewMaintenanceStatusResponse(r.Deployment),
+		Tenant:     tenant,
+		Jobs:       jobs,
+	}
+}
@@ -0,0 +1,107 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestSetMaintenanceWindowRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   string
+		timezone string
+		wantErr  bool
+	}{
+		{"valid", "01:00-05:00", "UTC", false},
+		{"past midnight", "22:00-04:00", "Europe/Berlin", false},
+		{"missing window", "", "UTC", true},
+		{"missing timezone", "01:00-05:00", "", true},
+		{"malformed", "1am-5am", "UTC", true},
+		{"empty window", "03:00-03:00", "UTC", true},
+		{"unknown timezone", "01:00-05:00", "Mars/Olympus", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.SetMaintenanceWindowRequest{Window: tt.window, Timezone: tt.timezone}
+			errs := req.Validate()
+			if tt.wantErr != (len(errs) > 0) {
+				t.Fatalf("unexpected validation result: %v", errs)
+			}
+		})
+	}
+}
+
+func TestSetMaintenanceOverrideRequest_Validate(t *testing.T) {
+	maxSeconds := int(domain.MaxMaintenanceOverride.Seconds())
+	tests := []struct {
+		name    string
+		req     dto.SetMaintenanceOverrideRequest
+		wantErr bool
+	}{
+		{"open", dto.SetMaintenanceOverrideRequest{Mode: "OPEN", DurationSeconds: 3600}, false},
+		{"lowercase closed", dto.SetMaintenanceOverrideRequest{Mode: "closed", DurationSeconds: maxSeconds, Reason: "campaign"}, false},
+		{"missing mode", dto.SetMaintenanceOverrideRequest{DurationSeconds: 3600}, true},
+		{"unknown mode", dto.SetMaintenanceOverrideRequest{Mode: "PAUSED", DurationSeconds: 3600}, true},
+		{"too short", dto.SetMaintenanceOverrideRequest{Mode: "OPEN", DurationSeconds: 59}, true},
+		{"too long", dto.SetMaintenanceOverrideRequest{Mode: "OPEN", DurationSeconds: maxSeconds + 1}, true},
+		{"long reason", dto.SetMaintenanceOverrideRequest{Mode: "OPEN", DurationSeconds: 3600, Reason: strings.Repeat("x", 501)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr != (len(errs) > 0) {
+				t.Fatalf("unexpected validation result: %v", errs)
+			}
+		})
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	req := dto.SetMaintenanceOverrideRequest{Mode: " closed ", DurationSeconds: 3600, Reason: " incident "}
+	override := req.GetOverride(now)
+	if override.Mode != domain.MaintenanceOverrideClosed || !override.Until.Equal(now.Add(time.Hour)) || override.Reason != "incident" {
+		t.Errorf("unexpected override: %+v", override)
+	}
+}
+
+func TestNewMaintenanceReportResponse(t *testing.T) {
+	window := &domain.MaintenanceWindow{Start: time.Hour, End: 5 * time.Hour, Timezone: "UTC"}
+	report := &domain.MaintenanceReport{
+		Deployment: domain.MaintenanceStatus{Open: false, Window: window},
+		Tenant: domain.MaintenanceStatus{Open: true, Override: &domain.MaintenanceOverride{
+			Mode: domain.MaintenanceOverrideOpen, Until: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+		}},
+		Settings: domain.DefaultTenantMaintenanceSettings(uuid.New()),
+	}
+
+	resp := dto.NewMaintenanceReportResponse(report)
+	if resp.Deployment.Window == nil || resp.Deployment.Window.Window != "01:00-05:00" {
+		t.Errorf("deployment window: %+v", resp.Deployment.Window)
+	}
+	if !resp.Tenant.InheritsWindow || resp.Tenant.UpdatedAt != nil {
+		t.Errorf("tenant without settings: %+v", resp.Tenant)
+	}
+	if resp.Tenant.Override == nil || resp.Tenant.Override.Until == nil {
+		t.Errorf("tenant override: %+v", resp.Tenant.Override)
+	}
+
+	open := map[string]bool{}
+	for _, job := range resp.Jobs {
+		open[job.Job] = job.Open
+	}
+	want := map[string]bool{"backfill": false, "queue_ranking": false, "reconciliation": true}
+	if len(open) != len(want) {
+		t.Fatalf("jobs: got %v, want %v", open, want)
+	}
+	for job, wantOpen := range want {
+		if open[job] != wantOpen {
+			t.Errorf("job %s open: got %v, want %v", job, open[job], wantOpen)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package dto
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 // ==================== Status ====================
@@ -17,49 +19,130 @@ func (r *UpdateStatusRequest) Validate() []string {
 	var errs []string
 	status := domain.OperatorStatusType(r.Status)
 	if !status.IsValid() {
-		errs = append(errs, "status must be AVAILABLE or OFFLINE")
+		errs = append(errs, "status must be AVAILABLE, BUSY, AWAY or OFFLINE")
 	}
 	return errs
 }
 
+// StartFocusRequest starts focus mode for the given number of minutes
+type StartFocusRequest struct {
+	Minutes int `json:"minutes" validate:"min=1,max=240"`
+}
+
+func (r *StartFocusRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// Duration returns how long focus lasts
+func (r *StartFocusRequest) Duration() time.Duration {
+	return time.Duration(r.Minutes) * time.Minute
+}
+
 type OperatorStatusResponse struct {
 	OperatorID         uuid.UUID `json:"operator_id"`
 	Status             string    `json:"status"`
 	LastStatusChangeAt time.Time `json:"last_status_change_at"`
+	// FocusUntil and FocusRemainingSeconds are set while the operator is in focus
+	FocusUntil            *time.Time `json:"focus_until,omitempty"`
+	FocusRemainingSeconds int64      `json:"focus_remaining_seconds,omitempty"`
+}
+
+func NewOperatorStatusResponse(status *domain.OperatorStatus, now time.Time) OperatorStatusResponse {
+	resp := OperatorStatusResponse{
+		OperatorID:         status.OperatorID,
+		Status:             string(status.Status),
+		LastStatusChangeAt: status.LastStatusChangeAt,
+	}
+	if status.InFocus(now) {
+		resp.FocusUntil = status.FocusUntil
+		resp.FocusRemainingSeconds = int64(math.Ceil(status.FocusRemaining(now).Seconds()))
+	}
+	return resp
 }
 
 // ==================== CRUD ====================
 
 type CreateOperatorRequest struct {
-	Role string `json:"role"`
+	Role  string  `json:"role"`
+	Name  *string `json:"name" validate:"max=255"`
+	Email *string `json:"email" validate:"omitempty,max=255,email"`
+	// Languages the operator speaks (ISO 639 codes); empty takes conversations
+	// of every language
+	Languages []string `json:"languages"`
 }
 
 func (r *CreateOperatorRequest) Validate() []string {
-	var errs []string
+	errs := validate.Struct(r)
 	role := domain.OperatorRole(r.Role)
 	if !role.IsValid() {
 		errs = append(errs, "role must be OPERATOR, MANAGER, or ADMIN")
 	}
+	errs = append(errs, validateOperatorLanguages(r.Languages)...)
 	return errs
 }
 
+// NormalizedLanguages returns the normalized languages, nil when none were sent
+func (r *CreateOperatorRequest) NormalizedLanguages() []string {
+	return normalizeOperatorLanguages(r.Languages)
+}
+
+// UpdateOperatorRequest sets the role; an omitted name, email or languages is
+// left unchanged and an empty one is cleared
 type UpdateOperatorRequest struct {
-	Role string `json:"role"`
+	Role      string    `json:"role"`
+	Name      *string   `json:"name" validate:"max=255"`
+	Email     *string   `json:"email" validate:"omitempty,max=255,email"`
+	Languages *[]string `json:"languages"`
 }
 
 func (r *UpdateOperatorRequest) Validate() []string {
-	var errs []string
+	errs := validate.Struct(r)
 	role := domain.OperatorRole(r.Role)
 	if !role.IsValid() {
 		errs = append(errs, "role must be OPERATOR, MANAGER, or ADMIN")
 	}
+	if r.Languages != nil {
+		errs = append(errs, validateOperatorLanguages(*r.Languages)...)
+	}
 	return errs
 }
 
+// NormalizedLanguages returns the normalized languages, nil when omitted
+func (r *UpdateOperatorRequest) NormalizedLanguages() []string {
+	if r.Languages == nil {
+		return nil
+	}
+	if languages := normalizeOperatorLanguages(*r.Languages); languages != nil {
+		return languages
+	}
+	return []string{}
+}
+
+func validateOperatorLanguages(languages []string) []string {
+	if _, err := domain.NormalizeLanguages(languages); err != nil {
+		return []string{"languages: " + err.Error()}
+	}
+	return nil
+}
+
+func normalizeOperatorLanguages(raw []string) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	languages, err := domain.NormalizeLanguages(raw)
+	if err != nil {
+		return nil
+	}
+	return languages
+}
+
 type OperatorResponse struct {
 	ID        uuid.UUID `json:"id"`
 	TenantID  uuid.UUID `json:"tenant_id"`
 	Role      string    `json:"role"`
+	Name      *string   `json:"name"`
+	Email     *string   `json:"email"`
+	Languages []string  `json:"languages"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -69,6 +152,9 @@ func NewOperatorResponse(op *domain.Operator) OperatorResponse {
 		ID:        op.ID,
 		TenantID:  op.TenantID,
 		Role:      string(op.Role),
+		Name:      op.Name,
+		Email:     op.Email,
+		Languages: op.Languages,
 		CreatedAt: op.CreatedAt,
 		UpdatedAt: op.UpdatedAt,
 	}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Allocation Weight Override Request ====================
+
+// AllocationWeightOverrideRequest pins an operator's allocation weight
+type AllocationWeightOverrideRequest struct {
+	Weight *float64 `json:"weight" validate:"required"`
+}
+
+func (r *AllocationWeightOverrideRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.Weight != nil && !domain.ValidAllocationWeight(*r.Weight) {
+		errs = append(errs, "weight must be greater than 0 and at most 1")
+	}
+	return errs
+}
+
+// ==================== Operator Health Responses ====================
+
+type OperatorHealthResponse struct {
+	OperatorID uuid.UUID `json:"operator_id"`
+	// EffectiveWeight is what allocation applies: the override if set,
+	// otherwise the feedback-loop weight
+	EffectiveWeight float64    `json:"effective_weight"`
+	Weight          float64    `json:"weight"`
+	OverrideWeight  *float64   `json:"override_weight"`
+	OverrideBy      *uuid.UUID `json:"override_by"`
+	OverrideAt      *time.Time `json:"override_at"`
+	Allocations     int        `json:"allocations"`
+	Returns         int        `json:"returns"`
+	ReturnRate      float64    `json:"return_rate"`
+	EvaluatedAt     time.Time  `json:"evaluated_at"`
+}
+
+func NewOperatorHealthResponse(h *domain.OperatorAllocationHealth) OperatorHealthResponse {
+	return OperatorHealthResponse{
+		OperatorID:      h.OperatorID,
+		EffectiveWeight: h.EffectiveWeight(),
+		Weight:          h.Weight,
+		OverrideWeight:  h.OverrideWeight,
+		OverrideBy:      h.OverrideBy,
+		OverrideAt:      h.OverrideAt,
+		Allocations:     h.Allocations,
+		Returns:         h.Returns,
+		ReturnRate:      h.ReturnRate(),
+		EvaluatedAt:     h.EvaluatedAt,
+	}
+}
+
+type OperatorHealthListResponse struct {
+	Operators []OperatorHealthResponse `json:"operators"`
+}
+
+func NewOperatorHealthListResponse(health []*domain.OperatorAllocationHealth) OperatorHealthListResponse {
+	resp := OperatorHealthListResponse{Operators: make([]OperatorHealthResponse, len(health))}
+	for i, h := range health {
+		resp.Operators[i] = NewOperatorHealthResponse(h)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeHealthOperatorNotFound = "OPERATOR_NOT_FOUND"
+)
@@ -0,0 +1,48 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationWeightOverrideRequest_Validate(t *testing.T) {
+	weight := func(w float64) *float64 { return &w }
+
+	tests := []struct {
+		name    string
+		req     dto.AllocationWeightOverrideRequest
+		wantErr bool
+	}{
+		{"full weight", dto.AllocationWeightOverrideRequest{Weight: weight(1)}, false},
+		{"reduced", dto.AllocationWeightOverrideRequest{Weight: weight(0.3)}, false},
+		{"missing", dto.AllocationWeightOverrideRequest{}, true},
+		{"zero", dto.AllocationWeightOverrideRequest{Weight: weight(0)}, true},
+		{"above 1", dto.AllocationWeightOverrideRequest{Weight: weight(1.5)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestNewOperatorHealthResponse(t *testing.T) {
+	h := domain.NewOperatorAllocationHealth(uuid.New(), uuid.New())
+	h.Evaluate(domain.DefaultAllocationHealthPolicy(), 10, 6)
+
+	resp := dto.NewOperatorHealthResponse(h)
+	assert.Equal(t, 0.5, resp.Weight)
+	assert.Equal(t, 0.5, resp.EffectiveWeight)
+	assert.Equal(t, 0.6, resp.ReturnRate)
+	assert.Nil(t, resp.OverrideWeight)
+}
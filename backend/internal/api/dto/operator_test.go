@@ -1,9 +1,13 @@
 package dto_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
 )
 
 func TestUpdateStatusRequest_Validate(t *testing.T) {
@@ -13,6 +17,8 @@ func TestUpdateStatusRequest_Validate(t *testing.T) {
 		wantErr bool
 	}{
 		{"valid AVAILABLE", "AVAILABLE", false},
+		{"valid BUSY", "BUSY", false},
+		{"valid AWAY", "AWAY", false},
 		{"valid OFFLINE", "OFFLINE", false},
 		{"invalid status", "INVALID", true},
 		{"empty status", "", true},
@@ -32,6 +38,56 @@ func TestUpdateStatusRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestStartFocusRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes int
+		wantErr bool
+	}{
+		{"one minute", 1, false},
+		{"four hours", 240, false},
+		{"zero", 0, true},
+		{"negative", -5, true},
+		{"over four hours", 241, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.StartFocusRequest{Minutes: tt.minutes}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected validation errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestNewOperatorStatusResponse_Focus(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	status := domain.NewOperatorStatus(uuid.New())
+	status.SetStatus(domain.OperatorStatusAvailable)
+
+	resp := dto.NewOperatorStatusResponse(status, now)
+	if resp.FocusUntil != nil || resp.FocusRemainingSeconds != 0 {
+		t.Errorf("expected no focus, got %v / %d", resp.FocusUntil, resp.FocusRemainingSeconds)
+	}
+
+	status.StartFocus(now.Add(90 * time.Second))
+	resp = dto.NewOperatorStatusResponse(status, now.Add(500*time.Millisecond))
+	if resp.FocusUntil == nil || resp.FocusRemainingSeconds != 90 {
+		t.Errorf("expected 90s of focus remaining, got %v / %d", resp.FocusUntil, resp.FocusRemainingSeconds)
+	}
+
+	// Expired focus is not reported
+	resp = dto.NewOperatorStatusResponse(status, now.Add(2*time.Minute))
+	if resp.FocusUntil != nil || resp.FocusRemainingSeconds != 0 {
+		t.Errorf("expected focus to have ended, got %v / %d", resp.FocusUntil, resp.FocusRemainingSeconds)
+	}
+}
+
 func TestCreateOperatorRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -84,3 +140,58 @@ func TestUpdateOperatorRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestOperatorRequest_ValidateProfile(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		opName   *string
+		email    *string
+		errCount int
+	}{
+		{"no profile", nil, nil, 0},
+		{"name and email", strPtr("Maria"), strPtr("maria@example.com"), 0},
+		{"clear both", strPtr(""), strPtr(""), 0},
+		{"invalid email", nil, strPtr("maria"), 1},
+		{"display name in email", nil, strPtr("Maria <maria@example.com>"), 1},
+		{"name too long", strPtr(strings.Repeat("a", 256)), nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := dto.CreateOperatorRequest{Role: "OPERATOR", Name: tt.opName, Email: tt.email}
+			if errs := create.Validate(); len(errs) != tt.errCount {
+				t.Errorf("Create Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+			update := dto.UpdateOperatorRequest{Role: "OPERATOR", Name: tt.opName, Email: tt.email}
+			if errs := update.Validate(); len(errs) != tt.errCount {
+				t.Errorf("Update Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestOperatorRequest_Languages(t *testing.T) {
+	create := dto.CreateOperatorRequest{Role: "OPERATOR", Languages: []string{"es-MX", "EN", "es"}}
+	if errs := create.Validate(); len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if got := create.NormalizedLanguages(); len(got) != 2 || got[0] != "es" || got[1] != "en" {
+		t.Errorf("NormalizedLanguages() = %v, want [es en]", got)
+	}
+
+	create.Languages = []string{"spanish"}
+	if errs := create.Validate(); len(errs) != 1 {
+		t.Errorf("expected 1 error for an invalid language, got %v", errs)
+	}
+
+	update := dto.UpdateOperatorRequest{Role: "OPERATOR"}
+	if got := update.NormalizedLanguages(); got != nil {
+		t.Errorf("omitted languages should be nil, got %v", got)
+	}
+	update.Languages = &[]string{}
+	if got := update.NormalizedLanguages(); got == nil || len(got) != 0 {
+		t.Errorf("empty languages should clear, got %v", got)
+	}
+}
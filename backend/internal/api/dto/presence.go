@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// Presence WebSocket message types
+const (
+	PresenceMessageReady         = "presence.ready"
+	PresenceMessageStatusChanged = "presence.status_changed"
+)
+
+// ==================== Presence Messages ====================
+
+// PresenceReadyMessage is the first message on a presence connection
+type PresenceReadyMessage struct {
+	Type       string    `json:"type"`
+	SessionID  uuid.UUID `json:"session_id"`
+	OperatorID uuid.UUID `json:"operator_id"`
+	Status     string    `json:"status"`
+	// HeartbeatIntervalSeconds is how often the server pings
+	HeartbeatIntervalSeconds int `json:"heartbeat_interval_seconds"`
+	// TimeoutSeconds is how long the operator may go unseen before they are
+	// set OFFLINE
+	TimeoutSeconds int                        `json:"timeout_seconds"`
+	Teammates      []TeammatePresenceResponse `json:"teammates"`
+}
+
+type TeammatePresenceResponse struct {
+	OperatorID uuid.UUID `json:"operator_id"`
+	Status     string    `json:"status"`
+	Connected  bool      `json:"connected"`
+}
+
+func NewPresenceReadyMessage(sessionID uuid.UUID, status *domain.OperatorStatus, policy domain.PresencePolicy, teammates []domain.TeammatePresence) PresenceReadyMessage {
+	msg := PresenceReadyMessage{
+		Type:                     PresenceMessageReady,
+		SessionID:                sessionID,
+		OperatorID:               status.OperatorID,
+		Status:                   string(status.Status),
+		HeartbeatIntervalSeconds: int(policy.HeartbeatInterval.Seconds()),
+		TimeoutSeconds:           int(policy.Timeout.Seconds()),
+		Teammates:                make([]TeammatePresenceResponse, len(teammates)),
+	}
+	for i, t := range teammates {
+		msg.Teammates[i] = TeammatePresenceResponse{
+			OperatorID: t.OperatorID,
+			Status:     string(t.Status),
+			Connected:  t.Connected,
+		}
+	}
+	return msg
+}
+
+// PresenceStatusChangedMessage reports a teammate's status change
+type PresenceStatusChangedMessage struct {
+	Type           string    `json:"type"`
+	OperatorID     uuid.UUID `json:"operator_id"`
+	Status         string    `json:"status"`
+	PreviousStatus *string   `json:"previous_status"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+func NewPresenceStatusChangedMessage(change *domain.PresenceChange) PresenceStatusChangedMessage {
+	msg := PresenceStatusChangedMessage{
+		Type:       PresenceMessageStatusChanged,
+		OperatorID: change.OperatorID,
+		Status:     string(change.Status),
+		OccurredAt: change.OccurredAt,
+	}
+	if change.PreviousStatus != nil {
+		previous := string(*change.PreviousStatus)
+		msg.PreviousStatus = &previous
+	}
+	return msg
+}
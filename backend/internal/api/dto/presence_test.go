@@ -0,0 +1,55 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPresenceReadyMessage(t *testing.T) {
+	sessionID := uuid.New()
+	status := domain.NewOperatorStatus(uuid.New())
+	status.SetStatus(domain.OperatorStatusAvailable)
+	teammateID := uuid.New()
+	policy := domain.PresencePolicy{HeartbeatInterval: 15 * time.Second, Timeout: 45 * time.Second}
+
+	msg := dto.NewPresenceReadyMessage(sessionID, status, policy, []domain.TeammatePresence{
+		{OperatorID: teammateID, Status: domain.OperatorStatusOffline, Connected: true},
+	})
+
+	assert.Equal(t, dto.PresenceMessageReady, msg.Type)
+	assert.Equal(t, sessionID, msg.SessionID)
+	assert.Equal(t, "AVAILABLE", msg.Status)
+	assert.Equal(t, 15, msg.HeartbeatIntervalSeconds)
+	assert.Equal(t, 45, msg.TimeoutSeconds)
+	assert.Equal(t, []dto.TeammatePresenceResponse{
+		{OperatorID: teammateID, Status: "OFFLINE", Connected: true},
+	}, msg.Teammates)
+
+	empty := dto.NewPresenceReadyMessage(sessionID, status, policy, nil)
+	assert.NotNil(t, empty.Teammates, "teammates serialize as an empty list")
+}
+
+func TestNewPresenceStatusChangedMessage(t *testing.T) {
+	previous := domain.OperatorStatusAvailable
+	change := &domain.PresenceChange{
+		OperatorID:     uuid.New(),
+		Status:         domain.OperatorStatusOffline,
+		PreviousStatus: &previous,
+		OccurredAt:     time.Now().UTC(),
+	}
+
+	msg := dto.NewPresenceStatusChangedMessage(change)
+	assert.Equal(t, dto.PresenceMessageStatusChanged, msg.Type)
+	assert.Equal(t, "OFFLINE", msg.Status)
+	if assert.NotNil(t, msg.PreviousStatus) {
+		assert.Equal(t, "AVAILABLE", *msg.PreviousStatus)
+	}
+
+	change.PreviousStatus = nil
+	assert.Nil(t, dto.NewPresenceStatusChangedMessage(change).PreviousStatus)
+}
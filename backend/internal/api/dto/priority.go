@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+const (
+	DefaultPriorityComponentsLimit = 20
+	MaxPriorityComponentsLimit     = 100
+)
+
+// ==================== Priority Components Request ====================
+
+// PriorityComponentsRequest holds the raw query parameters of
+// GET /api/v1/conversations/{id}/priority/components
+type PriorityComponentsRequest struct {
+	Limit string
+}
+
+func ParsePriorityComponentsRequest(r *http.Request) *PriorityComponentsRequest {
+	return &PriorityComponentsRequest{Limit: r.URL.Query().Get("limit")}
+}
+
+func (r *PriorityComponentsRequest) Validate() []string {
+	var errs []string
+	if r.Limit != "" {
+		limit, err := strconv.Atoi(r.Limit)
+		if err != nil || limit < 1 || limit > MaxPriorityComponentsLimit {
+			errs = append(errs, "limit must be between 1 and 100")
+		}
+	}
+	return errs
+}
+
+// GetLimit assumes Validate has passed
+func (r *PriorityComponentsRequest) GetLimit() int {
+	limit, err := strconv.Atoi(r.Limit)
+	if err != nil {
+		return DefaultPriorityComponentsLimit
+	}
+	return limit
+}
+
+// ==================== Priority Components Response ====================
+
+// PriorityComponentsResponse is one recorded priority calculation;
+// priority_score = message_component + delay_component + rule_boost +
+// first_contact_boost
+type PriorityComponentsResponse struct {
+	ID                uuid.UUID `json:"id"`
+	MessageCount      int32     `json:"message_count"`
+	LastMessageAt     time.Time `json:"last_message_at"`
+	MessageFactor     float64   `json:"message_factor"`
+	DelayFactor       float64   `json:"delay_factor"`
+	WeightAlpha       float64   `json:"weight_alpha"`
+	WeightBeta        float64   `json:"weight_beta"`
+	MessageComponent  float64   `json:"message_component"`
+	DelayComponent    float64   `json:"delay_component"`
+	RuleBoost         float64   `json:"rule_boost"`
+	FirstContactBoost float64   `json:"first_contact_boost"`
+	PriorityScore     float64   `json:"priority_score"`
+	ComputedAt        time.Time `json:"computed_at"`
+}
+
+type PriorityComponentsListResponse struct {
+	ConversationID uuid.UUID                    `json:"conversation_id"`
+	Components     []PriorityComponentsResponse `json:"components"`
+}
+
+func NewPriorityComponentsListResponse(conversationID uuid.UUID, components []*domain.PriorityScoreComponents) PriorityComponentsListResponse {
+	resp := PriorityComponentsListResponse{
+		ConversationID: conversationID,
+		Components:     make([]PriorityComponentsResponse, len(components)),
+	}
+	for i, c := range components {
+		resp.Components[i] = PriorityComponentsResponse{
+			ID:                c.ID,
+			MessageCount:      c.MessageCount,
+			LastMessageAt:     c.LastMessageAt,
+			MessageFactor:     c.MessageFactor.InexactFloat64(),
+			DelayFactor:       c.DelayFactor.InexactFloat64(),
+			WeightAlpha:       c.WeightAlpha.InexactFloat64(),
+			WeightBeta:        c.WeightBeta.InexactFloat64(),
+			MessageComponent:  c.MessageComponent().InexactFloat64(),
+			DelayComponent:    c.DelayComponent().InexactFloat64(),
+			RuleBoost:         c.RuleBoost.InexactFloat64(),
+			FirstContactBoost: c.FirstContactBoost.InexactFloat64(),
+			PriorityScore:     c.PriorityScore.InexactFloat64(),
+			ComputedAt:        c.ComputedAt,
+		}
+	}
+	return resp
+}
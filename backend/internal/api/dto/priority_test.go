@@ -0,0 +1,37 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityComponentsRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.PriorityComponentsRequest{}).Validate())
+	assert.Len(t, (&dto.PriorityComponentsRequest{Limit: "0"}).Validate(), 1)
+	assert.Len(t, (&dto.PriorityComponentsRequest{Limit: "101"}).Validate(), 1)
+
+	assert.Equal(t, dto.DefaultPriorityComponentsLimit, (&dto.PriorityComponentsRequest{}).GetLimit())
+	assert.Equal(t, 5, (&dto.PriorityComponentsRequest{Limit: "5"}).GetLimit())
+}
+
+func TestNewPriorityComponentsListResponse(t *testing.T) {
+	now := time.Now().UTC()
+	conv := &domain.ConversationRef{ID: uuid.New(), MessageCount: 9, LastMessageAt: now.Add(-12 * time.Hour), IsFirstContact: true}
+	components := domain.NewPriorityScoreComponents(conv, decimal.NewFromFloat(0.6), decimal.NewFromFloat(0.4), decimal.NewFromFloat(0.1), decimal.NewFromFloat(0.05), nil, now)
+
+	resp := dto.NewPriorityComponentsListResponse(conv.ID, []*domain.PriorityScoreComponents{components})
+	require.Len(t, resp.Components, 1)
+	c := resp.Components[0]
+	assert.InDelta(t, 0.2, c.MessageComponent, 1e-9)
+	assert.InDelta(t, 0.2, c.DelayComponent, 1e-9)
+	assert.InDelta(t, 0.05, c.FirstContactBoost, 1e-9)
+	assert.InDelta(t, 0.55, c.PriorityScore, 1e-9)
+	assert.InDelta(t, c.MessageComponent+c.DelayComponent+c.RuleBoost+c.FirstContactBoost, c.PriorityScore, 1e-9)
+}
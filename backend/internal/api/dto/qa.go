@@ -0,0 +1,219 @@
+package dto
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+const (
+	// DefaultQAReportWindow is the report range when from is omitted
+	DefaultQAReportWindow = 30 * 24 * time.Hour
+)
+
+// ==================== Submit Review Request ====================
+
+type SubmitQAReviewRequest struct {
+	Scores  map[string]int `json:"scores" validate:"required"`
+	Comment *string        `json:"comment" validate:"max=2000"`
+}
+
+func (r *SubmitQAReviewRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if len(r.Scores) > 0 {
+		if err := domain.ValidateQAScores(r.Scores); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// ==================== Grant Reviewer Request ====================
+
+type GrantQAReviewerRequest struct {
+	OperatorID uuid.UUID `json:"operator_id" validate:"required"`
+}
+
+func (r *GrantQAReviewerRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Report Request ====================
+
+// QAReportRequest holds the raw query parameters of GET /api/v1/qa/reports/operators
+type QAReportRequest struct {
+	From string
+	To   string
+}
+
+func ParseQAReportRequest(r *http.Request) *QAReportRequest {
+	query := r.URL.Query()
+	return &QAReportRequest{
+		From: query.Get("from"),
+		To:   query.Get("to"),
+	}
+}
+
+func (r *QAReportRequest) Validate() []string {
+	var errs []string
+
+	from, fromErr := parseOptionalTime(r.From)
+	if fromErr != nil {
+		errs = append(errs, "from must be an RFC 3339 timestamp")
+	}
+	to, toErr := parseOptionalTime(r.To)
+	if toErr != nil {
+		errs = append(errs, "to must be an RFC 3339 timestamp")
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		errs = append(errs, "from must be before to")
+	}
+
+	return errs
+}
+
+// Range returns [from, to); to defaults to now and from to DefaultQAReportWindow
+// before to. Assumes Validate has passed.
+func (r *QAReportRequest) Range() (time.Time, time.Time) {
+	to := time.Now().UTC()
+	if parsed, _ := parseOptionalTime(r.To); parsed != nil {
+		to = *parsed
+	}
+	from := to.Add(-DefaultQAReportWindow)
+	if parsed, _ := parseOptionalTime(r.From); parsed != nil {
+		from = *parsed
+	}
+	return from, to
+}
+
+// ==================== Review Item Response ====================
+
+type QARubricCriterionResponse struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	MinScore    int    `json:"min_score"`
+	MaxScore    int    `json:"max_score"`
+}
+
+type QAReviewItemResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	ConversationID uuid.UUID      `json:"conversation_id"`
+	OperatorID     *uuid.UUID     `json:"operator_id"`
+	Status         string         `json:"status"`
+	ReviewerID     *uuid.UUID     `json:"reviewer_id"`
+	ClaimedUntil   *time.Time     `json:"claimed_until"`
+	Scores         map[string]int `json:"scores,omitempty"`
+	OverallScore   *float64       `json:"overall_score"`
+	Comment        *string        `json:"comment"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    *time.Time     `json:"completed_at"`
+}
+
+func NewQAReviewItemResponse(item *domain.QAReviewItem) QAReviewItemResponse {
+	resp := QAReviewItemResponse{
+		ID:             item.ID,
+		ConversationID: item.ConversationID,
+		OperatorID:     item.OperatorID,
+		Status:         string(item.Status),
+		ReviewerID:     item.ReviewerID,
+		ClaimedUntil:   item.ClaimedUntil,
+		Scores:         item.Scores,
+		Comment:        item.Comment,
+		CreatedAt:      item.CreatedAt,
+		CompletedAt:    item.CompletedAt,
+	}
+	if item.OverallScore != nil {
+		overall, _ := item.OverallScore.Float64()
+		resp.OverallScore = &overall
+	}
+	return resp
+}
+
+// QANextResponse is returned by POST /api/v1/qa/next together with the rubric to score against
+type QANextResponse struct {
+	Item   QAReviewItemResponse        `json:"item"`
+	Rubric []QARubricCriterionResponse `json:"rubric"`
+}
+
+func NewQANextResponse(item *domain.QAReviewItem) QANextResponse {
+	rubric := make([]QARubricCriterionResponse, len(domain.QARubric))
+	for i, criterion := range domain.QARubric {
+		rubric[i] = QARubricCriterionResponse{
+			Key:         criterion.Key,
+			Description: criterion.Description,
+			MinScore:    domain.QAMinScore,
+			MaxScore:    domain.QAMaxScore,
+		}
+	}
+	return QANextResponse{Item: NewQAReviewItemResponse(item), Rubric: rubric}
+}
+
+// ==================== Reviewer Response ====================
+
+type QAReviewerResponse struct {
+	OperatorID uuid.UUID  `json:"operator_id"`
+	GrantedBy  *uuid.UUID `json:"granted_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewQAReviewerResponse(r *domain.QAReviewer) QAReviewerResponse {
+	return QAReviewerResponse{
+		OperatorID: r.OperatorID,
+		GrantedBy:  r.GrantedBy,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+type QAReviewerListResponse struct {
+	Reviewers []QAReviewerResponse `json:"reviewers"`
+}
+
+// ==================== Report Response ====================
+
+type QAOperatorReportResponse struct {
+	OperatorID        uuid.UUID          `json:"operator_id"`
+	ReviewCount       int                `json:"review_count"`
+	AverageScore      float64            `json:"average_score"`
+	CriterionAverages map[string]float64 `json:"criterion_averages"`
+}
+
+type QAReportResponse struct {
+	From      time.Time                  `json:"from"`
+	To        time.Time                  `json:"to"`
+	Operators []QAOperatorReportResponse `json:"operators"`
+}
+
+func NewQAReportResponse(from, to time.Time, reports []*domain.QAOperatorReport) QAReportResponse {
+	resp := QAReportResponse{
+		From:      from,
+		To:        to,
+		Operators: make([]QAOperatorReportResponse, len(reports)),
+	}
+	for i, report := range reports {
+		average, _ := report.AverageScore.Float64()
+		criteria := make(map[string]float64, len(report.CriterionAverages))
+		for key, value := range report.CriterionAverages {
+			criteria[key], _ = value.Float64()
+		}
+		resp.Operators[i] = QAOperatorReportResponse{
+			OperatorID:        report.OperatorID,
+			ReviewCount:       report.ReviewCount,
+			AverageScore:      average,
+			CriterionAverages: criteria,
+		}
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeQANotReviewer      = "QA_NOT_REVIEWER"
+	ErrCodeQAQueueEmpty       = "QA_QUEUE_EMPTY"
+	ErrCodeQAItemNotFound     = "QA_ITEM_NOT_FOUND"
+	ErrCodeQAItemNotClaimed   = "QA_ITEM_NOT_CLAIMED"
+	ErrCodeQAOperatorNotFound = "OPERATOR_NOT_FOUND"
+)
@@ -0,0 +1,80 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubmitQAReviewRequest_Validate(t *testing.T) {
+	valid := map[string]int{"accuracy": 5, "resolution": 4, "tone": 3, "efficiency": 2}
+	longComment := strings.Repeat("x", 2001)
+
+	tests := []struct {
+		name     string
+		req      dto.SubmitQAReviewRequest
+		errCount int
+	}{
+		{
+			name:     "valid",
+			req:      dto.SubmitQAReviewRequest{Scores: valid},
+			errCount: 0,
+		},
+		{
+			name:     "missing scores",
+			req:      dto.SubmitQAReviewRequest{},
+			errCount: 1,
+		},
+		{
+			name:     "incomplete rubric",
+			req:      dto.SubmitQAReviewRequest{Scores: map[string]int{"accuracy": 5}},
+			errCount: 1,
+		},
+		{
+			name:     "score out of range",
+			req:      dto.SubmitQAReviewRequest{Scores: map[string]int{"accuracy": 6, "resolution": 4, "tone": 3, "efficiency": 2}},
+			errCount: 1,
+		},
+		{
+			name:     "comment too long",
+			req:      dto.SubmitQAReviewRequest{Scores: valid, Comment: &longComment},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestQAReportRequest_Range(t *testing.T) {
+	t.Run("defaults to trailing window", func(t *testing.T) {
+		req := dto.QAReportRequest{}
+		assert.Empty(t, req.Validate())
+
+		from, to := req.Range()
+		assert.Equal(t, dto.DefaultQAReportWindow, to.Sub(from))
+	})
+
+	t.Run("explicit range", func(t *testing.T) {
+		req := dto.QAReportRequest{From: "2025-01-01T00:00:00Z", To: "2025-02-01T00:00:00Z"}
+		assert.Empty(t, req.Validate())
+
+		from, to := req.Range()
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from.UTC())
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to.UTC())
+	})
+
+	t.Run("rejects inverted range", func(t *testing.T) {
+		req := dto.QAReportRequest{From: "2025-02-01T00:00:00Z", To: "2025-01-01T00:00:00Z"}
+		assert.Len(t, req.Validate(), 1)
+	})
+}
@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+const (
+	DefaultInboxQueueLimit = 20
+	MaxInboxQueueLimit     = 100
+)
+
+// ==================== Inbox Queue Request ====================
+
+// InboxQueueRequest holds the raw query parameters of
+// GET /api/v1/inboxes/{id}/queue
+type InboxQueueRequest struct {
+	Limit string
+}
+
+func ParseInboxQueueRequest(r *http.Request) *InboxQueueRequest {
+	return &InboxQueueRequest{Limit: r.URL.Query().Get("limit")}
+}
+
+func (r *InboxQueueRequest) Validate() []string {
+	var errs []string
+	if r.Limit != "" {
+		limit, err := strconv.Atoi(r.Limit)
+		if err != nil || limit < 1 || limit > MaxInboxQueueLimit {
+			errs = append(errs, "limit must be between 1 and 100")
+		}
+	}
+	return errs
+}
+
+// GetLimit assumes Validate has passed
+func (r *InboxQueueRequest) GetLimit() int {
+	limit, err := strconv.Atoi(r.Limit)
+	if err != nil {
+		return DefaultInboxQueueLimit
+	}
+	return limit
+}
+
+// ==================== Queue Responses ====================
+
+type QueuePositionResponse struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	InboxID        uuid.UUID `json:"inbox_id"`
+	// 1-based; 1 is allocated next
+	Position           int       `json:"position"`
+	ConversationsAhead int       `json:"conversations_ahead"`
+	QueueLength        int       `json:"queue_length"`
+	RefreshedAt        time.Time `json:"refreshed_at"`
+}
+
+func NewQueuePositionResponse(p *domain.QueuePosition) QueuePositionResponse {
+	return QueuePositionResponse{
+		ConversationID:     p.ConversationID,
+		InboxID:            p.InboxID,
+		Position:           p.Rank,
+		ConversationsAhead: p.ConversationsAhead(),
+		QueueLength:        p.QueueLength,
+		RefreshedAt:        p.RefreshedAt,
+	}
+}
+
+type QueueEntryResponse struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	Position       int       `json:"position"`
+	PriorityScore  float64   `json:"priority_score"`
+	LastMessageAt  time.Time `json:"last_message_at"`
+}
+
+type InboxQueueResponse struct {
+	InboxID       uuid.UUID            `json:"inbox_id"`
+	QueueLength   int                  `json:"queue_length"`
+	Conversations []QueueEntryResponse `json:"conversations"`
+	// When the ranks were computed; nil for an empty queue
+	RefreshedAt *time.Time `json:"refreshed_at"`
+}
+
+func NewInboxQueueResponse(inboxID uuid.UUID, ranks []*domain.QueueRank, length int) InboxQueueResponse {
+	resp := InboxQueueResponse{
+		InboxID:       inboxID,
+		QueueLength:   length,
+		Conversations: make([]QueueEntryResponse, len(ranks)),
+	}
+	for i, rank := range ranks {
+		score, _ := rank.PriorityScore.Float64()
+		resp.Conversations[i] = QueueEntryResponse{
+			ConversationID: rank.ConversationID,
+			Position:       rank.Rank,
+			PriorityScore:  score,
+			LastMessageAt:  rank.LastMessageAt,
+		}
+	}
+	if len(ranks) > 0 {
+		refreshedAt := ranks[0].RefreshedAt
+		resp.RefreshedAt = &refreshedAt
+	}
+	return resp
+}
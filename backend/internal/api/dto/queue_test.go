@@ -0,0 +1,48 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxQueueRequest_Validate(t *testing.T) {
+	assert.Empty(t, (&dto.InboxQueueRequest{}).Validate())
+	assert.Empty(t, (&dto.InboxQueueRequest{Limit: "100"}).Validate())
+	assert.Len(t, (&dto.InboxQueueRequest{Limit: "0"}).Validate(), 1)
+	assert.Len(t, (&dto.InboxQueueRequest{Limit: "101"}).Validate(), 1)
+	assert.Len(t, (&dto.InboxQueueRequest{Limit: "ten"}).Validate(), 1)
+
+	assert.Equal(t, dto.DefaultInboxQueueLimit, (&dto.InboxQueueRequest{}).GetLimit())
+	assert.Equal(t, 5, (&dto.InboxQueueRequest{Limit: "5"}).GetLimit())
+}
+
+func TestNewQueuePositionResponse(t *testing.T) {
+	position := &domain.QueuePosition{
+		QueueRank: domain.QueueRank{
+			ConversationID: uuid.New(),
+			InboxID:        uuid.New(),
+			Rank:           3,
+			PriorityScore:  decimal.NewFromFloat(0.5),
+			RefreshedAt:    time.Now().UTC(),
+		},
+		QueueLength: 7,
+	}
+
+	resp := dto.NewQueuePositionResponse(position)
+	assert.Equal(t, 3, resp.Position)
+	assert.Equal(t, 2, resp.ConversationsAhead)
+	assert.Equal(t, 7, resp.QueueLength)
+}
+
+func TestNewInboxQueueResponse_Empty(t *testing.T) {
+	resp := dto.NewInboxQueueResponse(uuid.New(), nil, 0)
+	assert.NotNil(t, resp.Conversations)
+	assert.Empty(t, resp.Conversations)
+	assert.Nil(t, resp.RefreshedAt)
+}
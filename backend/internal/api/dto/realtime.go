@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Realtime Token Request ====================
+
+type CreateRealtimeTokenRequest struct {
+	// InboxIDs narrows the token to some of the operator's subscribed
+	// inboxes; empty for all of them
+	InboxIDs []uuid.UUID `json:"inbox_ids"`
+	// EventTypes limits the stream to these event types; empty for all
+	EventTypes []string `json:"event_types"`
+	// ExpiresInSeconds defaults to domain.DefaultRealtimeTokenTTL
+	ExpiresInSeconds int `json:"expires_in_seconds" validate:"omitempty,min=30,max=900"`
+	// SubscriptionID refreshes an open stream with the new token
+	SubscriptionID *uuid.UUID `json:"subscription_id"`
+}
+
+func (r *CreateRealtimeTokenRequest) Validate() []string {
+	errs := validate.Struct(r)
+	for _, t := range r.EventTypes {
+		if !domain.EventType(t).IsStreamable() {
+			errs = append(errs, fmt.Sprintf("event_types: %q is not a streamed event type", t))
+		}
+	}
+	return errs
+}
+
+// TTL returns how long the token is valid
+func (r *CreateRealtimeTokenRequest) TTL() time.Duration {
+	if r.ExpiresInSeconds == 0 {
+		return domain.DefaultRealtimeTokenTTL
+	}
+	return time.Duration(r.ExpiresInSeconds) * time.Second
+}
+
+// Types returns the requested event types; Validate must have passed
+func (r *CreateRealtimeTokenRequest) Types() []domain.EventType {
+	types := make([]domain.EventType, len(r.EventTypes))
+	for i, t := range r.EventTypes {
+		types[i] = domain.EventType(t)
+	}
+	return types
+}
+
+// ==================== Realtime Token Response ====================
+
+type RealtimeTokenResponse struct {
+	Token      string      `json:"token"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	InboxIDs   []uuid.UUID `json:"inbox_ids"`
+	MentorIDs  []uuid.UUID `json:"mentor_ids"`
+	EventTypes []string    `json:"event_types"`
+}
+
+func NewRealtimeTokenResponse(grant *domain.RealtimeGrant, token string) RealtimeTokenResponse {
+	types := grant.EventTypes
+	if len(types) == 0 {
+		types = domain.StreamableEventTypes
+	}
+	eventTypes := make([]string, len(types))
+	for i, t := range types {
+		eventTypes[i] = string(t)
+	}
+
+	resp := RealtimeTokenResponse{
+		Token:      token,
+		ExpiresAt:  grant.ExpiresAt,
+		InboxIDs:   grant.InboxIDs,
+		MentorIDs:  grant.MentorIDs,
+		EventTypes: eventTypes,
+	}
+	if resp.InboxIDs == nil {
+		resp.InboxIDs = []uuid.UUID{}
+	}
+	if resp.MentorIDs == nil {
+		resp.MentorIDs = []uuid.UUID{}
+	}
+	return resp
+}
@@ -0,0 +1,50 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestCreateRealtimeTokenRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		seconds    int
+		eventTypes []string
+		wantErr    bool
+		wantTTL    time.Duration
+	}{
+		{"default expiry", 0, nil, false, domain.DefaultRealtimeTokenTTL},
+		{"one minute", 60, []string{"conversation.allocated"}, false, time.Minute},
+		{"too short", 29, nil, true, 0},
+		{"too long", int(domain.MaxRealtimeTokenTTL.Seconds()) + 1, nil, true, 0},
+		{"not streamed", 0, []string{"operator.status_changed"}, true, 0},
+		{"unknown type", 0, []string{"conversation.deleted"}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.CreateRealtimeTokenRequest{ExpiresInSeconds: tt.seconds, EventTypes: tt.eventTypes}
+			errs := req.Validate()
+			if tt.wantErr != (len(errs) > 0) {
+				t.Fatalf("unexpected validation result: %v", errs)
+			}
+			if !tt.wantErr && req.TTL() != tt.wantTTL {
+				t.Errorf("TTL: got %s, want %s", req.TTL(), tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestNewRealtimeTokenResponse_ListsEveryTypeWhenUnscoped(t *testing.T) {
+	resp := dto.NewRealtimeTokenResponse(&domain.RealtimeGrant{}, "token")
+
+	if len(resp.EventTypes) != len(domain.StreamableEventTypes) {
+		t.Errorf("got %v, want every streamable type", resp.EventTypes)
+	}
+	if resp.InboxIDs == nil || resp.MentorIDs == nil {
+		t.Error("scope lists must render as empty arrays")
+	}
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Reconciliation Report Request ====================
+
+// ReconciliationReportRequest holds the query parameters of
+// GET /api/v1/admin/reconciliation
+type ReconciliationReportRequest struct {
+	// Kind lists only the divergences of the kind; empty lists all
+	Kind string
+}
+
+func ParseReconciliationReportRequest(r *http.Request) *ReconciliationReportRequest {
+	return &ReconciliationReportRequest{
+		Kind: strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("kind"))),
+	}
+}
+
+func (r *ReconciliationReportRequest) Validate() []string {
+	var errs []string
+	if r.Kind != "" && !domain.DivergenceKind(r.Kind).IsValid() {
+		errs = append(errs, "kind must be one of RESOLVED_UPSTREAM_OPEN, OPEN_UPSTREAM_CLOSED, MISSING_UPSTREAM")
+	}
+	return errs
+}
+
+// ==================== Reconciliation Report Response ====================
+
+type ReconciliationDivergenceResponse struct {
+	Divergence             string     `json:"divergence"`
+	ConversationID         uuid.UUID  `json:"conversation_id"`
+	ExternalConversationID string     `json:"external_conversation_id"`
+	InboxID                uuid.UUID  `json:"inbox_id"`
+	LocalState             string     `json:"local_state"`
+	UpstreamStatus         string     `json:"upstream_status"`
+	FirstDetectedAt        time.Time  `json:"first_detected_at"`
+	LastCheckedAt          time.Time  `json:"last_checked_at"`
+	CorrectedAt            *time.Time `json:"corrected_at"`
+}
+
+type ReconciliationReportResponse struct {
+	TenantID    uuid.UUID                          `json:"tenant_id"`
+	GeneratedAt time.Time                          `json:"generated_at"`
+	Total       int                                `json:"total"`
+	Counts      map[string]int                     `json:"counts"`
+	Divergences []ReconciliationDivergenceResponse `json:"divergences"`
+}
+
+func NewReconciliationReportResponse(r *domain.ReconciliationReport) ReconciliationReportResponse {
+	total := 0
+	counts := make(map[string]int, len(r.Counts))
+	for kind, n := range r.Counts {
+		counts[string(kind)] = n
+		total += n
+	}
+
+	divergences := make([]ReconciliationDivergenceResponse, len(r.Divergences))
+	for i, d := range r.Divergences {
+		divergences[i] = ReconciliationDivergenceResponse{
+			Divergence:             string(d.Kind),
+			ConversationID:         d.ConversationID,
+			ExternalConversationID: d.ExternalConversationID,
+			InboxID:                d.InboxID,
+			LocalState:             string(d.LocalState),
+			UpstreamStatus:         string(d.UpstreamStatus),
+			FirstDetectedAt:        d.FirstDetectedAt,
+			LastCheckedAt:          d.LastCheckedAt,
+			CorrectedAt:            d.CorrectedAt,
+		}
+	}
+
+	return ReconciliationReportResponse{
+		TenantID:    r.TenantID,
+		GeneratedAt: r.GeneratedAt,
+		Total:       total,
+		Counts:      counts,
+		Divergences: divergences,
+	}
+}
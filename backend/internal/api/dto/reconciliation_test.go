@@ -0,0 +1,45 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationReportRequest_Validate(t *testing.T) {
+	req := dto.ParseReconciliationReportRequest(httptest.NewRequest("GET", "/?kind=resolved_upstream_open", nil))
+	assert.Equal(t, "RESOLVED_UPSTREAM_OPEN", req.Kind)
+	assert.Empty(t, req.Validate())
+
+	assert.Empty(t, (&dto.ReconciliationReportRequest{}).Validate(), "no kind lists all")
+	assert.NotEmpty(t, (&dto.ReconciliationReportRequest{Kind: "UNKNOWN"}).Validate())
+}
+
+func TestNewReconciliationReportResponse(t *testing.T) {
+	corrected := time.Now().UTC()
+	report := domain.NewReconciliationReport(uuid.Must(uuid.NewV7()))
+	report.Counts[domain.DivergenceResolvedUpstreamOpen] = 3
+	report.Counts[domain.DivergenceMissingUpstream] = 1
+	report.Divergences = append(report.Divergences, &domain.ReconciliationDivergence{
+		Kind:           domain.DivergenceResolvedUpstreamOpen,
+		LocalState:     domain.ConversationStateResolved,
+		UpstreamStatus: domain.UpstreamStatusOpen,
+		CorrectedAt:    &corrected,
+	})
+
+	resp := dto.NewReconciliationReportResponse(report)
+	assert.Equal(t, report.TenantID, resp.TenantID)
+	assert.Equal(t, 4, resp.Total, "the total counts every divergence, not only those listed")
+	assert.Len(t, resp.Counts, len(domain.DivergenceKinds))
+	assert.Equal(t, 0, resp.Counts["OPEN_UPSTREAM_CLOSED"])
+
+	assert.Equal(t, "RESOLVED_UPSTREAM_OPEN", resp.Divergences[0].Divergence)
+	assert.Equal(t, "RESOLVED", resp.Divergences[0].LocalState)
+	assert.Equal(t, "OPEN", resp.Divergences[0].UpstreamStatus)
+	assert.Equal(t, &corrected, resp.Divergences[0].CorrectedAt)
+}
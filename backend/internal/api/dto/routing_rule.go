@@ -0,0 +1,203 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+	"github.com/shopspring/decimal"
+)
+
+// ==================== Create Routing Rule Request ====================
+
+type RoutingRuleCondition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    int32  `json:"value"`
+	// Text is the compared category, language, phone number (prefix) or
+	// metadata keyword for text fields
+	Text *string `json:"text,omitempty"`
+}
+
+type RoutingRuleActions struct {
+	AttachLabel   *string  `json:"attach_label"`
+	PriorityBoost *float64 `json:"priority_boost"`
+}
+
+type CreateRoutingRuleRequest struct {
+	Name      string               `json:"name" validate:"required,max=100"`
+	InboxID   *uuid.UUID           `json:"inbox_id"`
+	Trigger   string               `json:"trigger"`
+	Condition RoutingRuleCondition `json:"condition"`
+	Actions   RoutingRuleActions   `json:"actions"`
+}
+
+func (r *CreateRoutingRuleRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if !domain.RoutingRuleTrigger(r.Trigger).IsValid() {
+		errs = append(errs, "trigger must be MESSAGE_RECEIVED or CONVERSATION_CREATED")
+	}
+	field := domain.RuleConditionField(r.Condition.Field)
+	operator := domain.RuleOperator(r.Condition.Operator)
+	if !field.IsValid() {
+		errs = append(errs, "condition.field must be message_count, category, language, customer_phone or metadata")
+	}
+	switch {
+	case !operator.IsValid():
+		errs = append(errs, "condition.operator must be one of gt, gte, lt, lte, eq, prefix, contains")
+	case field.IsValid() && !field.Allows(operator):
+		errs = append(errs, "condition.operator "+r.Condition.Operator+" cannot compare "+r.Condition.Field)
+	}
+	if field.IsValid() && field.IsText() {
+		if r.Condition.Text == nil {
+			errs = append(errs, "condition.text is required for "+r.Condition.Field)
+		} else if _, err := field.NormalizeText(*r.Condition.Text); err != nil {
+			errs = append(errs, "condition.text: "+err.Error())
+		}
+	}
+
+	hasLabel := r.Actions.AttachLabel != nil
+	if hasLabel {
+		label := strings.TrimSpace(*r.Actions.AttachLabel)
+		if label == "" {
+			errs = append(errs, "actions.attach_label cannot be empty")
+		} else if len(label) > 100 {
+			errs = append(errs, "actions.attach_label must be 100 characters or less")
+		}
+	}
+	hasBoost := r.Actions.PriorityBoost != nil && *r.Actions.PriorityBoost != 0
+	if hasBoost && (*r.Actions.PriorityBoost < -1 || *r.Actions.PriorityBoost > 1) {
+		errs = append(errs, "actions.priority_boost must be between -1 and 1")
+	}
+	if !hasLabel && !hasBoost {
+		errs = append(errs, "actions must set attach_label or a non-zero priority_boost")
+	}
+	return errs
+}
+
+// ConditionText returns the normalized condition text, or nil for numeric fields
+func (r *CreateRoutingRuleRequest) ConditionText() *string {
+	field := domain.RuleConditionField(r.Condition.Field)
+	if !field.IsText() || r.Condition.Text == nil {
+		return nil
+	}
+	text, err := field.NormalizeText(*r.Condition.Text)
+	if err != nil {
+		return nil
+	}
+	return &text
+}
+
+// LabelName returns the trimmed label name, or nil when no label action is set
+func (r *CreateRoutingRuleRequest) LabelName() *string {
+	if r.Actions.AttachLabel == nil {
+		return nil
+	}
+	name := strings.TrimSpace(*r.Actions.AttachLabel)
+	return &name
+}
+
+func (r *CreateRoutingRuleRequest) Boost() decimal.Decimal {
+	if r.Actions.PriorityBoost == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(*r.Actions.PriorityBoost)
+}
+
+// ==================== Update Routing Rule Request ====================
+
+type UpdateRoutingRuleRequest struct {
+	IsActive *bool `json:"is_active" validate:"required"`
+}
+
+func (r *UpdateRoutingRuleRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// ==================== Routing Rule Response ====================
+
+type RoutingRuleResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	Name      string               `json:"name"`
+	InboxID   *uuid.UUID           `json:"inbox_id"`
+	Trigger   string               `json:"trigger"`
+	Condition RoutingRuleCondition `json:"condition"`
+	Actions   RoutingRuleActions   `json:"actions"`
+	IsActive  bool                 `json:"is_active"`
+	CreatedBy *uuid.UUID           `json:"created_by"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+func NewRoutingRuleResponse(r *domain.RoutingRule) RoutingRuleResponse {
+	boost, _ := r.PriorityBoost.Float64()
+	return RoutingRuleResponse{
+		ID:      r.ID,
+		Name:    r.Name,
+		InboxID: r.InboxID,
+		Trigger: string(r.Trigger),
+		Condition: RoutingRuleCondition{
+			Field:    string(r.ConditionField),
+			Operator: string(r.ConditionOperator),
+			Value:    r.ConditionValue,
+			Text:     r.ConditionText,
+		},
+		Actions: RoutingRuleActions{
+			AttachLabel:   r.LabelName,
+			PriorityBoost: &boost,
+		},
+		IsActive:  r.IsActive,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+type RoutingRuleListResponse struct {
+	Rules []RoutingRuleResponse `json:"rules"`
+}
+
+// ==================== Message Received ====================
+
+// MaxMessageTextLength bounds the message text forwarded to the tenant classifier
+const MaxMessageTextLength = 4096
+
+type MessageReceivedRequest struct {
+	ReceivedAt *time.Time `json:"received_at"`
+	// Text is only used for classification and is not stored
+	Text string `json:"text,omitempty" validate:"max=4096"`
+}
+
+func (r *MessageReceivedRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+type MessageReceivedResponse struct {
+	Conversation   ConversationResponse `json:"conversation"`
+	MatchedRuleIDs []uuid.UUID          `json:"matched_rule_ids"`
+	AttachedLabels []LabelSummary       `json:"attached_labels"`
+}
+
+func NewMessageReceivedResponse(conv *domain.ConversationRef, matchedRuleIDs []uuid.UUID, attached []*domain.Label) MessageReceivedResponse {
+	resp := MessageReceivedResponse{
+		Conversation:   NewConversationResponse(conv),
+		MatchedRuleIDs: matchedRuleIDs,
+		AttachedLabels: make([]LabelSummary, len(attached)),
+	}
+	if resp.MatchedRuleIDs == nil {
+		resp.MatchedRuleIDs = []uuid.UUID{}
+	}
+	for i, l := range attached {
+		resp.AttachedLabels[i] = LabelSummary{ID: l.ID, Name: l.Name, Color: l.Color}
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeRoutingRuleNotFound  = "ROUTING_RULE_NOT_FOUND"
+	ErrCodeConversationResolved = "CONVERSATION_RESOLVED"
+)
@@ -0,0 +1,148 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestCreateRoutingRuleRequest_Validate(t *testing.T) {
+	label := "long-thread"
+	empty := "  "
+	boost := 0.2
+	tooBig := 1.5
+	billing := " Billing "
+	phonePrefix := "+34"
+	badCategory := "billing & payments"
+	spanish := "es-MX"
+
+	condition := dto.RoutingRuleCondition{Field: "message_count", Operator: "gt", Value: 10}
+
+	tests := []struct {
+		name     string
+		req      dto.CreateRoutingRuleRequest
+		errCount int
+	}{
+		{
+			name: "valid label and boost",
+			req: dto.CreateRoutingRuleRequest{Name: "Long threads", Trigger: "MESSAGE_RECEIVED", Condition: condition,
+				Actions: dto.RoutingRuleActions{AttachLabel: &label, PriorityBoost: &boost}},
+			errCount: 0,
+		},
+		{
+			name: "valid boost only",
+			req: dto.CreateRoutingRuleRequest{Name: "Boost", Trigger: "MESSAGE_RECEIVED", Condition: condition,
+				Actions: dto.RoutingRuleActions{PriorityBoost: &boost}},
+			errCount: 0,
+		},
+		{
+			name: "missing name",
+			req: dto.CreateRoutingRuleRequest{Trigger: "MESSAGE_RECEIVED", Condition: condition,
+				Actions: dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name: "unknown trigger and operator",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "ALLOCATED",
+				Condition: dto.RoutingRuleCondition{Field: "message_count", Operator: "between", Value: 1},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 2,
+		},
+		{
+			name: "unknown field",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "wait_time", Operator: "gt", Value: 1},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name: "valid category",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "category", Operator: "eq", Text: &billing},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 0,
+		},
+		{
+			name: "category without text",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "category", Operator: "eq"},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name: "category with invalid text and operator",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "category", Operator: "gt", Text: &badCategory},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 2,
+		},
+		{
+			name: "valid language",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "language", Operator: "eq", Text: &spanish},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 0,
+		},
+		{
+			name: "language with invalid text",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "language", Operator: "eq", Text: &billing},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name: "phone prefix on conversation created",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "CONVERSATION_CREATED",
+				Condition: dto.RoutingRuleCondition{Field: "customer_phone", Operator: "prefix", Text: &phonePrefix},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 0,
+		},
+		{
+			name: "metadata keyword",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "metadata", Operator: "contains", Text: &billing},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 0,
+		},
+		{
+			name: "operator not allowed for field",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "metadata", Operator: "prefix", Text: &billing},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name: "phone prefix with letters",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED",
+				Condition: dto.RoutingRuleCondition{Field: "customer_phone", Operator: "prefix", Text: &billing},
+				Actions:   dto.RoutingRuleActions{AttachLabel: &label}},
+			errCount: 1,
+		},
+		{
+			name:     "no actions",
+			req:      dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED", Condition: condition},
+			errCount: 1,
+		},
+		{
+			name: "empty label",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED", Condition: condition,
+				Actions: dto.RoutingRuleActions{AttachLabel: &empty, PriorityBoost: &boost}},
+			errCount: 1,
+		},
+		{
+			name: "boost out of range",
+			req: dto.CreateRoutingRuleRequest{Name: "x", Trigger: "MESSAGE_RECEIVED", Condition: condition,
+				Actions: dto.RoutingRuleActions{PriorityBoost: &tooBig}},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
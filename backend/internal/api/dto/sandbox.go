@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Sandbox Response ====================
+
+// SandboxResponse lists the demo data seeded into a sandbox tenant
+type SandboxResponse struct {
+	Sandbox       bool            `json:"sandbox"`
+	Inboxes       []InboxResponse `json:"inboxes"`
+	Conversations int             `json:"conversations"`
+}
+
+func NewSandboxResponse(inboxes []*domain.Inbox, conversations int) SandboxResponse {
+	resp := SandboxResponse{
+		Sandbox:       true,
+		Inboxes:       make([]InboxResponse, len(inboxes)),
+		Conversations: conversations,
+	}
+	for i, inbox := range inboxes {
+		resp.Inboxes[i] = NewInboxResponse(inbox)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeSandboxAlreadyEnabled = "SANDBOX_ALREADY_ENABLED"
+	ErrCodeTenantNotEmpty        = "TENANT_NOT_EMPTY"
+	ErrCodeTenantNotSandbox      = "TENANT_NOT_SANDBOX"
+)
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ScalingComponentResponse struct {
+	Name            string `json:"name"`
+	Backend         string `json:"backend"`
+	Scope           string `json:"scope"`
+	SafeForReplicas bool   `json:"safe_for_replicas"`
+	Note            string `json:"note"`
+}
+
+type ScalingReportResponse struct {
+	At         time.Time                  `json:"at"`
+	Safe       bool                       `json:"safe"`
+	Components []ScalingComponentResponse `json:"components"`
+}
+
+func NewScalingReportResponse(r *domain.ScalingReport) ScalingReportResponse {
+	components := make([]ScalingComponentResponse, len(r.Components))
+	for i, c := range r.Components {
+		components[i] = ScalingComponentResponse{
+			Name:            c.Name,
+			Backend:         c.Backend,
+			Scope:           string(c.Scope),
+			SafeForReplicas: c.SafeForReplicas,
+			Note:            c.Note,
+		}
+	}
+	return ScalingReportResponse{
+		At:         r.At,
+		Safe:       r.Safe(),
+		Components: components,
+	}
+}
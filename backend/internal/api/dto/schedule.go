@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// TimeOfDayLayout is the format of schedule start and end times
+const TimeOfDayLayout = "15:04"
+
+// ==================== Schedule Request ====================
+
+// ScheduleRequest is the body of creating or replacing a schedule window.
+// An end_time not after start_time runs past midnight; "00:00" ends at
+// midnight.
+type ScheduleRequest struct {
+	DayOfWeek *int   `json:"day_of_week" validate:"required,min=0,max=6"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Timezone  string `json:"timezone" validate:"required"`
+}
+
+func (r *ScheduleRequest) Validate() []string {
+	errs := validate.Struct(r)
+	start, startErr := parseTimeOfDay(r.StartTime)
+	if startErr != nil {
+		errs = append(errs, "start_time must be HH:MM")
+	}
+	end, endErr := parseTimeOfDay(r.EndTime)
+	if endErr != nil {
+		errs = append(errs, "end_time must be HH:MM")
+	}
+	if startErr == nil && endErr == nil && start == end {
+		errs = append(errs, "start_time and end_time must differ")
+	}
+
+	if r.Timezone != "" {
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			errs = append(errs, "timezone must be an IANA time zone name")
+		}
+	}
+	return errs
+}
+
+func (r *ScheduleRequest) GetDayOfWeek() time.Weekday {
+	return time.Weekday(*r.DayOfWeek)
+}
+
+func (r *ScheduleRequest) GetStart() time.Duration {
+	start, _ := parseTimeOfDay(r.StartTime)
+	return start
+}
+
+func (r *ScheduleRequest) GetEnd() time.Duration {
+	end, _ := parseTimeOfDay(r.EndTime)
+	return end
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse(TimeOfDayLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return time.Time{}.Add(d).Format(TimeOfDayLayout)
+}
+
+// ==================== Schedule Response ====================
+
+type OperatorScheduleResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	OperatorID uuid.UUID  `json:"operator_id"`
+	DayOfWeek  int        `json:"day_of_week"`
+	StartTime  string     `json:"start_time"`
+	EndTime    string     `json:"end_time"`
+	Timezone   string     `json:"timezone"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func NewOperatorScheduleResponse(s *domain.OperatorSchedule) OperatorScheduleResponse {
+	return OperatorScheduleResponse{
+		ID:         s.ID,
+		OperatorID: s.OperatorID,
+		DayOfWeek:  int(s.DayOfWeek),
+		StartTime:  formatTimeOfDay(s.Start),
+		EndTime:    formatTimeOfDay(s.End),
+		Timezone:   s.Timezone,
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+type OperatorScheduleListResponse struct {
+	Schedules []OperatorScheduleResponse `json:"schedules"`
+}
+
+func NewOperatorScheduleListResponse(schedules []*domain.OperatorSchedule) OperatorScheduleListResponse {
+	items := make([]OperatorScheduleResponse, len(schedules))
+	for i, s := range schedules {
+		items[i] = NewOperatorScheduleResponse(s)
+	}
+	return OperatorScheduleListResponse{Schedules: items}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeScheduleNotFound         = "SCHEDULE_NOT_FOUND"
+	ErrCodeScheduleOperatorNotFound = "OPERATOR_NOT_FOUND"
+)
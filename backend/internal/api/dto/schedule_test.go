@@ -0,0 +1,89 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestScheduleRequest_Validate(t *testing.T) {
+	day := func(d int) *int { return &d }
+
+	tests := []struct {
+		name     string
+		req      dto.ScheduleRequest
+		errCount int
+	}{
+		{
+			name:     "valid",
+			req:      dto.ScheduleRequest{DayOfWeek: day(1), StartTime: "09:00", EndTime: "17:30", Timezone: "Europe/Berlin"},
+			errCount: 0,
+		},
+		{
+			name:     "overnight",
+			req:      dto.ScheduleRequest{DayOfWeek: day(0), StartTime: "22:00", EndTime: "06:00", Timezone: "UTC"},
+			errCount: 0,
+		},
+		{
+			name:     "missing all",
+			req:      dto.ScheduleRequest{},
+			errCount: 4,
+		},
+		{
+			name:     "day out of range",
+			req:      dto.ScheduleRequest{DayOfWeek: day(7), StartTime: "09:00", EndTime: "17:00", Timezone: "UTC"},
+			errCount: 1,
+		},
+		{
+			name:     "bad time",
+			req:      dto.ScheduleRequest{DayOfWeek: day(1), StartTime: "9am", EndTime: "25:00", Timezone: "UTC"},
+			errCount: 2,
+		},
+		{
+			name:     "empty window",
+			req:      dto.ScheduleRequest{DayOfWeek: day(1), StartTime: "09:00", EndTime: "09:00", Timezone: "UTC"},
+			errCount: 1,
+		},
+		{
+			name:     "unknown timezone",
+			req:      dto.ScheduleRequest{DayOfWeek: day(1), StartTime: "09:00", EndTime: "17:00", Timezone: "Mars/Olympus"},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestScheduleRequest_Getters(t *testing.T) {
+	d := 5
+	req := dto.ScheduleRequest{DayOfWeek: &d, StartTime: "08:15", EndTime: "00:00", Timezone: "UTC"}
+
+	if got := req.GetDayOfWeek(); got != time.Friday {
+		t.Errorf("GetDayOfWeek() = %v, want Friday", got)
+	}
+	if got := req.GetStart(); got != 8*time.Hour+15*time.Minute {
+		t.Errorf("GetStart() = %v, want 8h15m", got)
+	}
+	if got := req.GetEnd(); got != 0 {
+		t.Errorf("GetEnd() = %v, want 0", got)
+	}
+}
+
+func TestNewOperatorScheduleResponse(t *testing.T) {
+	s := domain.NewOperatorSchedule(uuid.New(), uuid.New(), time.Tuesday, 9*time.Hour, 17*time.Hour+30*time.Minute, "UTC", nil)
+
+	resp := dto.NewOperatorScheduleResponse(s)
+	if resp.DayOfWeek != 2 || resp.StartTime != "09:00" || resp.EndTime != "17:30" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Create Shadow Request ====================
+
+type CreateShadowRequest struct {
+	MentorID  uuid.UUID `json:"mentor_id" validate:"required"`
+	TraineeID uuid.UUID `json:"trainee_id" validate:"required"`
+}
+
+func (r *CreateShadowRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.MentorID != uuid.Nil && r.MentorID == r.TraineeID {
+		errs = append(errs, "mentor_id and trainee_id must be different operators")
+	}
+	return errs
+}
+
+// ==================== Shadow Response ====================
+
+type ShadowResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	MentorID  uuid.UUID  `json:"mentor_id"`
+	TraineeID uuid.UUID  `json:"trainee_id"`
+	CreatedBy *uuid.UUID `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewShadowResponse(s *domain.OperatorShadow) ShadowResponse {
+	return ShadowResponse{
+		ID:        s.ID,
+		MentorID:  s.MentorID,
+		TraineeID: s.TraineeID,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type ShadowListResponse struct {
+	Shadows []ShadowResponse `json:"shadows"`
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeShadowNotFound         = "SHADOW_NOT_FOUND"
+	ErrCodeShadowAlreadyExists    = "SHADOW_ALREADY_EXISTS"
+	ErrCodeShadowSelf             = "SHADOW_SELF"
+	ErrCodeShadowOperatorNotFound = "OPERATOR_NOT_FOUND"
+)
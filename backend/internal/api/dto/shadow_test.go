@@ -0,0 +1,48 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestCreateShadowRequest_Validate(t *testing.T) {
+	mentorID := uuid.New()
+
+	tests := []struct {
+		name     string
+		req      dto.CreateShadowRequest
+		errCount int
+	}{
+		{
+			name:     "valid",
+			req:      dto.CreateShadowRequest{MentorID: mentorID, TraineeID: uuid.New()},
+			errCount: 0,
+		},
+		{
+			name:     "missing both",
+			req:      dto.CreateShadowRequest{},
+			errCount: 2,
+		},
+		{
+			name:     "missing trainee",
+			req:      dto.CreateShadowRequest{MentorID: mentorID},
+			errCount: 1,
+		},
+		{
+			name:     "same operator",
+			req:      dto.CreateShadowRequest{MentorID: mentorID, TraineeID: mentorID},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
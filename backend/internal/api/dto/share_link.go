@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Create Share Link Request ====================
+
+type CreateShareLinkRequest struct {
+	// ExpiresInSeconds defaults to domain.DefaultShareLinkTTL
+	ExpiresInSeconds int `json:"expires_in_seconds" validate:"omitempty,min=60,max=604800"`
+	// IncludePII adds the customer's phone number and external conversation
+	// ID to the snapshot (Admin only)
+	IncludePII bool `json:"include_pii"`
+}
+
+func (r *CreateShareLinkRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// TTL returns how long the link works
+func (r *CreateShareLinkRequest) TTL() time.Duration {
+	if r.ExpiresInSeconds == 0 {
+		return domain.DefaultShareLinkTTL
+	}
+	return time.Duration(r.ExpiresInSeconds) * time.Second
+}
+
+// ==================== Share Link Response ====================
+
+type ShareLinkResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	IncludePII     bool       `json:"include_pii"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	AccessCount    int32      `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewShareLinkResponse(l *domain.ShareLink) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:             l.ID,
+		ConversationID: l.ConversationID,
+		IncludePII:     l.IncludePII,
+		CreatedBy:      l.CreatedBy,
+		ExpiresAt:      l.ExpiresAt,
+		RevokedAt:      l.RevokedAt,
+		AccessCount:    l.AccessCount,
+		LastAccessedAt: l.LastAccessedAt,
+		CreatedAt:      l.CreatedAt,
+	}
+}
+
+// CreatedShareLinkResponse is the only response that includes the link's URL
+type CreatedShareLinkResponse struct {
+	ShareLinkResponse
+	URL string `json:"url"`
+}
+
+type ShareLinkListResponse struct {
+	ShareLinks []ShareLinkResponse `json:"share_links"`
+}
+
+func NewShareLinkListResponse(links []*domain.ShareLink) ShareLinkListResponse {
+	items := make([]ShareLinkResponse, len(links))
+	for i, l := range links {
+		items[i] = NewShareLinkResponse(l)
+	}
+	return ShareLinkListResponse{ShareLinks: items}
+}
+
+// ==================== Conversation Snapshot Response ====================
+
+// ConversationSnapshotResponse is what a share link shows. It carries no
+// internal IDs; the customer's identifiers are only present when the link
+// includes PII.
+type ConversationSnapshotResponse struct {
+	InboxName              string     `json:"inbox_name"`
+	State                  string     `json:"state"`
+	Category               *string    `json:"category"`
+	Language               *string    `json:"language"`
+	Labels                 []string   `json:"labels"`
+	MessageCount           int32      `json:"message_count"`
+	ReopenedCount          int32      `json:"reopened_count"`
+	CreatedAt              time.Time  `json:"created_at"`
+	LastMessageAt          time.Time  `json:"last_message_at"`
+	ResolvedAt             *time.Time `json:"resolved_at"`
+	CustomerPhoneNumber    string     `json:"customer_phone_number,omitempty"`
+	ExternalConversationID string     `json:"external_conversation_id,omitempty"`
+	IncludesPII            bool       `json:"includes_pii"`
+	ExpiresAt              time.Time  `json:"expires_at"`
+}
+
+func NewConversationSnapshotResponse(s *domain.ConversationSnapshot) ConversationSnapshotResponse {
+	conv := s.Conversation
+	return ConversationSnapshotResponse{
+		InboxName:              s.InboxName,
+		State:                  string(conv.State),
+		Category:               conv.Category,
+		Language:               conv.Language,
+		Labels:                 s.Labels,
+		MessageCount:           conv.MessageCount,
+		ReopenedCount:          conv.ReopenedCount,
+		CreatedAt:              conv.CreatedAt,
+		LastMessageAt:          conv.LastMessageAt,
+		ResolvedAt:             conv.ResolvedAt,
+		CustomerPhoneNumber:    conv.CustomerPhoneNumber,
+		ExternalConversationID: conv.ExternalConversationID,
+		IncludesPII:            s.IncludesPII,
+		ExpiresAt:              s.ExpiresAt,
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeShareLinkNotFound = "SHARE_LINK_NOT_FOUND"
+	ErrCodeShareLinkExpired  = "SHARE_LINK_EXPIRED"
+	ErrCodeShareLinkRevoked  = "SHARE_LINK_REVOKED"
+	ErrCodeShareNotAllowed   = "SHARE_NOT_ALLOWED"
+	ErrCodeSharePIIForbidden = "SHARE_PII_FORBIDDEN"
+)
@@ -0,0 +1,50 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestCreateShareLinkRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		wantErr bool
+		wantTTL time.Duration
+	}{
+		{"default expiry", 0, false, domain.DefaultShareLinkTTL},
+		{"one hour", 3600, false, time.Hour},
+		{"too short", 59, true, 0},
+		{"longer than a week", int(domain.MaxShareLinkTTL.Seconds()) + 1, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.CreateShareLinkRequest{ExpiresInSeconds: tt.seconds}
+			errs := req.Validate()
+			if tt.wantErr != (len(errs) > 0) {
+				t.Fatalf("unexpected validation result: %v", errs)
+			}
+			if !tt.wantErr && req.TTL() != tt.wantTTL {
+				t.Errorf("TTL: got %s, want %s", req.TTL(), tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestNewConversationSnapshotResponse_OmitsPII(t *testing.T) {
+	conv := domain.NewConversationRef(uuid.New(), uuid.New(), "ext-1", "+15550001111")
+	link := domain.NewShareLink(conv, time.Hour, false, nil)
+
+	resp := dto.NewConversationSnapshotResponse(domain.NewConversationSnapshot(conv, "Support", []string{"billing"}, link))
+	if resp.CustomerPhoneNumber != "" || resp.ExternalConversationID != "" || resp.IncludesPII {
+		t.Errorf("snapshot without PII exposes customer identifiers: %+v", resp)
+	}
+	if resp.InboxName != "Support" || len(resp.Labels) != 1 {
+		t.Errorf("unexpected snapshot: %+v", resp)
+	}
+}
@@ -0,0 +1,166 @@
+package dto
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+const (
+	// MaxSLATargetSeconds is 30 days
+	MaxSLATargetSeconds = 30 * 24 * 60 * 60
+
+	DefaultSLABreachLimit = 50
+	MaxSLABreachLimit     = 100
+	// DefaultSLABreachLookback applies when since is not given
+	DefaultSLABreachLookback = 7 * 24 * time.Hour
+)
+
+// ==================== SLA Policy Request ====================
+
+// SLAPolicyRequest replaces an inbox's SLA policy; an omitted target is not
+// tracked
+type SLAPolicyRequest struct {
+	FirstAssignmentSeconds *int `json:"first_assignment_seconds" validate:"min=1,max=2592000"`
+	ResolutionSeconds      *int `json:"resolution_seconds" validate:"min=1,max=2592000"`
+}
+
+func (r *SLAPolicyRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.FirstAssignmentSeconds == nil && r.ResolutionSeconds == nil {
+		errs = append(errs, "first_assignment_seconds or resolution_seconds is required")
+	}
+	return errs
+}
+
+func (r *SLAPolicyRequest) GetFirstAssignment() *time.Duration {
+	return secondsToDuration(r.FirstAssignmentSeconds)
+}
+
+func (r *SLAPolicyRequest) GetResolution() *time.Duration {
+	return secondsToDuration(r.ResolutionSeconds)
+}
+
+func secondsToDuration(seconds *int) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+	d := time.Duration(*seconds) * time.Second
+	return &d
+}
+
+// ==================== List SLA Breaches Request ====================
+
+// ListSLABreachesRequest holds the raw query parameters of GET /api/v1/sla/breaches
+type ListSLABreachesRequest struct {
+	InboxID string
+	Since   string
+	Limit   string
+}
+
+func ParseListSLABreachesRequest(r *http.Request) *ListSLABreachesRequest {
+	q := r.URL.Query()
+	return &ListSLABreachesRequest{
+		InboxID: q.Get("inbox_id"),
+		Since:   q.Get("since"),
+		Limit:   q.Get("limit"),
+	}
+}
+
+func (r *ListSLABreachesRequest) Validate() []string {
+	var errs []string
+	if r.InboxID != "" {
+		if _, err := uuid.Parse(r.InboxID); err != nil {
+			errs = append(errs, "inbox_id must be a UUID")
+		}
+	}
+	if r.Since != "" {
+		if _, err := time.Parse(time.RFC3339, r.Since); err != nil {
+			errs = append(errs, "since must be an RFC 3339 timestamp")
+		}
+	}
+	if r.Limit != "" {
+		limit, err := strconv.Atoi(r.Limit)
+		if err != nil || limit < 1 || limit > MaxSLABreachLimit {
+			errs = append(errs, "limit must be between 1 and 100")
+		}
+	}
+	return errs
+}
+
+// GetInboxID assumes Validate has passed
+func (r *ListSLABreachesRequest) GetInboxID() *uuid.UUID {
+	id, err := uuid.Parse(r.InboxID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// GetSince assumes Validate has passed
+func (r *ListSLABreachesRequest) GetSince(now time.Time) time.Time {
+	since, err := time.Parse(time.RFC3339, r.Since)
+	if err != nil {
+		return now.Add(-DefaultSLABreachLookback)
+	}
+	return since
+}
+
+// GetLimit assumes Validate has passed
+func (r *ListSLABreachesRequest) GetLimit() int {
+	limit, err := strconv.Atoi(r.Limit)
+	if err != nil {
+		return DefaultSLABreachLimit
+	}
+	return limit
+}
+
+// ==================== SLA Responses ====================
+
+type SLAPolicyResponse struct {
+	InboxID                uuid.UUID  `json:"inbox_id"`
+	FirstAssignmentSeconds *int       `json:"first_assignment_seconds"`
+	ResolutionSeconds      *int       `json:"resolution_seconds"`
+	UpdatedBy              *uuid.UUID `json:"updated_by"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+func NewSLAPolicyResponse(p *domain.InboxSLAPolicy) SLAPolicyResponse {
+	return SLAPolicyResponse{
+		InboxID:                p.InboxID,
+		FirstAssignmentSeconds: durationToSeconds(p.FirstAssignment),
+		ResolutionSeconds:      durationToSeconds(p.Resolution),
+		UpdatedBy:              p.UpdatedBy,
+		UpdatedAt:              p.UpdatedAt,
+	}
+}
+
+func durationToSeconds(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+	seconds := int(d.Seconds())
+	return &seconds
+}
+
+type SLABreachListResponse struct {
+	Breaches []ConversationResponse `json:"breaches"`
+}
+
+func NewSLABreachListResponse(conversations []*domain.ConversationRef) SLABreachListResponse {
+	resp := SLABreachListResponse{Breaches: make([]ConversationResponse, len(conversations))}
+	for i, c := range conversations {
+		resp.Breaches[i] = NewConversationResponse(c)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeSLAPolicyNotFound = "SLA_POLICY_NOT_FOUND"
+)
@@ -0,0 +1,64 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLAPolicyRequest_Validate(t *testing.T) {
+	seconds := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		req     dto.SLAPolicyRequest
+		wantErr bool
+	}{
+		{"first assignment only", dto.SLAPolicyRequest{FirstAssignmentSeconds: seconds(300)}, false},
+		{"both targets", dto.SLAPolicyRequest{FirstAssignmentSeconds: seconds(300), ResolutionSeconds: seconds(86400)}, false},
+		{"no target", dto.SLAPolicyRequest{}, true},
+		{"zero", dto.SLAPolicyRequest{ResolutionSeconds: seconds(0)}, true},
+		{"over 30 days", dto.SLAPolicyRequest{ResolutionSeconds: seconds(dto.MaxSLATargetSeconds + 1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+
+	req := dto.SLAPolicyRequest{FirstAssignmentSeconds: seconds(90)}
+	assert.Equal(t, 90*time.Second, *req.GetFirstAssignment())
+	assert.Nil(t, req.GetResolution())
+}
+
+func TestListSLABreachesRequest(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	req := dto.ParseListSLABreachesRequest(httptest.NewRequest("GET", "/api/v1/sla/breaches", nil))
+	assert.Empty(t, req.Validate())
+	assert.Nil(t, req.GetInboxID())
+	assert.Equal(t, now.Add(-dto.DefaultSLABreachLookback), req.GetSince(now))
+	assert.Equal(t, dto.DefaultSLABreachLimit, req.GetLimit())
+
+	inboxID := uuid.New()
+	req = dto.ParseListSLABreachesRequest(httptest.NewRequest("GET", "/api/v1/sla/breaches?inbox_id="+inboxID.String()+"&since=2025-03-10T08:00:00Z&limit=10", nil))
+	assert.Empty(t, req.Validate())
+	assert.Equal(t, &inboxID, req.GetInboxID())
+	assert.Equal(t, time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC), req.GetSince(now))
+	assert.Equal(t, 10, req.GetLimit())
+
+	for _, query := range []string{"inbox_id=abc", "since=yesterday", "limit=0", "limit=101"} {
+		req := dto.ParseListSLABreachesRequest(httptest.NewRequest("GET", "/api/v1/sla/breaches?"+query, nil))
+		assert.NotEmpty(t, req.Validate(), query)
+	}
+}
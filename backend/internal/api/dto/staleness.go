@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// MaxStalenessSeconds is 7 days
+const MaxStalenessSeconds = 7 * 24 * 60 * 60
+
+// ==================== Staleness Policy Request ====================
+
+// StalenessPolicyRequest replaces an inbox's staleness policy; without
+// deallocate_grace_seconds stale conversations are only flagged
+type StalenessPolicyRequest struct {
+	StaleAfterSeconds      int  `json:"stale_after_seconds" validate:"min=1,max=604800"`
+	DeallocateGraceSeconds *int `json:"deallocate_grace_seconds" validate:"min=1,max=604800"`
+}
+
+func (r *StalenessPolicyRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+func (r *StalenessPolicyRequest) GetStaleAfter() time.Duration {
+	return time.Duration(r.StaleAfterSeconds) * time.Second
+}
+
+func (r *StalenessPolicyRequest) GetDeallocateGrace() *time.Duration {
+	return secondsToDuration(r.DeallocateGraceSeconds)
+}
+
+// ==================== Activity Request ====================
+
+// RecordActivityRequest reports activity of the assigned operator on a
+// conversation
+type RecordActivityRequest struct {
+	Kind string `json:"kind"`
+}
+
+func (r *RecordActivityRequest) Validate() []string {
+	if !domain.ActivityKind(r.Kind).IsReportable() {
+		return []string{"kind must be MESSAGE, NOTE, ACK or READ"}
+	}
+	return nil
+}
+
+func (r *RecordActivityRequest) GetKind() domain.ActivityKind {
+	return domain.ActivityKind(r.Kind)
+}
+
+// ==================== Staleness Responses ====================
+
+type StalenessPolicyResponse struct {
+	InboxID                uuid.UUID  `json:"inbox_id"`
+	StaleAfterSeconds      int        `json:"stale_after_seconds"`
+	DeallocateGraceSeconds *int       `json:"deallocate_grace_seconds"`
+	UpdatedBy              *uuid.UUID `json:"updated_by"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+func NewStalenessPolicyResponse(p *domain.InboxStalenessPolicy) StalenessPolicyResponse {
+	return StalenessPolicyResponse{
+		InboxID:                p.InboxID,
+		StaleAfterSeconds:      int(p.StaleAfter.Seconds()),
+		DeallocateGraceSeconds: durationToSeconds(p.DeallocateGrace),
+		UpdatedBy:              p.UpdatedBy,
+		UpdatedAt:              p.UpdatedAt,
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeStalenessPolicyNotFound = "STALENESS_POLICY_NOT_FOUND"
+	ErrCodeActivityNotAssignee     = "ACTIVITY_NOT_ASSIGNEE"
+)
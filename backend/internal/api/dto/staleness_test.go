@@ -0,0 +1,53 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStalenessPolicyRequest_Validate(t *testing.T) {
+	seconds := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		req     dto.StalenessPolicyRequest
+		wantErr bool
+	}{
+		{"flag only", dto.StalenessPolicyRequest{StaleAfterSeconds: 900}, false},
+		{"with deallocation", dto.StalenessPolicyRequest{StaleAfterSeconds: 900, DeallocateGraceSeconds: seconds(300)}, false},
+		{"missing limit", dto.StalenessPolicyRequest{DeallocateGraceSeconds: seconds(300)}, true},
+		{"over 7 days", dto.StalenessPolicyRequest{StaleAfterSeconds: dto.MaxStalenessSeconds + 1}, true},
+		{"zero grace", dto.StalenessPolicyRequest{StaleAfterSeconds: 900, DeallocateGraceSeconds: seconds(0)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+
+	req := dto.StalenessPolicyRequest{StaleAfterSeconds: 900}
+	assert.Equal(t, 15*time.Minute, req.GetStaleAfter())
+	assert.Nil(t, req.GetDeallocateGrace())
+}
+
+func TestRecordActivityRequest_Validate(t *testing.T) {
+	for _, kind := range []string{"MESSAGE", "NOTE", "ACK", "READ"} {
+		req := dto.RecordActivityRequest{Kind: kind}
+		assert.Empty(t, req.Validate(), kind)
+		assert.Equal(t, domain.ActivityKind(kind), req.GetKind())
+	}
+	for _, kind := range []string{"", "ALLOCATED", "message"} {
+		req := dto.RecordActivityRequest{Kind: kind}
+		assert.NotEmpty(t, req.Validate(), kind)
+	}
+}
@@ -0,0 +1,365 @@
+package dto
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+const (
+	DefaultForecastHours = 8
+	MaxForecastHours     = 24
+
+	DefaultLabelUsageWindow = 30 * 24 * time.Hour
+	DefaultEscalationWindow = 30 * 24 * time.Hour
+)
+
+// ==================== Overview Response ====================
+
+type InboxOverviewResponse struct {
+	InboxID             uuid.UUID `json:"inbox_id"`
+	DisplayName         string    `json:"display_name"`
+	Queued              int       `json:"queued"`
+	Allocated           int       `json:"allocated"`
+	Resolved            int       `json:"resolved"`
+	Overdue             int       `json:"overdue"`
+	AllocationsLastHour int       `json:"allocations_last_hour"`
+	// Average over the resolution window; nil when nothing was resolved
+	AvgResolutionSeconds *float64 `json:"avg_resolution_seconds"`
+}
+
+type OverviewTotalsResponse struct {
+	Queued               int      `json:"queued"`
+	Allocated            int      `json:"allocated"`
+	Resolved             int      `json:"resolved"`
+	Overdue              int      `json:"overdue"`
+	ActiveOperators      int      `json:"active_operators"`
+	AllocationsLastHour  int      `json:"allocations_last_hour"`
+	AvgResolutionSeconds *float64 `json:"avg_resolution_seconds"`
+}
+
+type OverviewResponse struct {
+	GeneratedAt             time.Time               `json:"generated_at"`
+	ResolutionWindowSeconds int                     `json:"resolution_window_seconds"`
+	Totals                  OverviewTotalsResponse  `json:"totals"`
+	OperatorsByStatus       map[string]int          `json:"operators_by_status"`
+	Inboxes                 []InboxOverviewResponse `json:"inboxes"`
+}
+
+func NewOverviewResponse(o *domain.TenantOverview) OverviewResponse {
+	resp := OverviewResponse{
+		GeneratedAt:             o.GeneratedAt,
+		ResolutionWindowSeconds: int(o.ResolutionWindow.Seconds()),
+		Totals: OverviewTotalsResponse{
+			ActiveOperators:      o.ActiveOperators(),
+			AllocationsLastHour:  o.AllocationsLastHour,
+			AvgResolutionSeconds: avgResolutionSeconds(o.AvgResolution, o.RecentlyResolved),
+		},
+		OperatorsByStatus: make(map[string]int, len(o.OperatorsByStatus)),
+		Inboxes:           make([]InboxOverviewResponse, len(o.Inboxes)),
+	}
+	for status, count := range o.OperatorsByStatus {
+		resp.OperatorsByStatus[string(status)] = count
+	}
+	for i, inbox := range o.Inboxes {
+		resp.Inboxes[i] = InboxOverviewResponse{
+			InboxID:              inbox.InboxID,
+			DisplayName:          inbox.DisplayName,
+			Queued:               inbox.Queued,
+			Allocated:            inbox.Allocated,
+			Resolved:             inbox.Resolved,
+			Overdue:              inbox.Overdue,
+			AllocationsLastHour:  inbox.AllocationsLastHour,
+			AvgResolutionSeconds: avgResolutionSeconds(inbox.AvgResolution, inbox.RecentlyResolved),
+		}
+		resp.Totals.Queued += inbox.Queued
+		resp.Totals.Allocated += inbox.Allocated
+		resp.Totals.Resolved += inbox.Resolved
+		resp.Totals.Overdue += inbox.Overdue
+	}
+	return resp
+}
+
+func avgResolutionSeconds(avg time.Duration, resolved int) *float64 {
+	if resolved == 0 {
+		return nil
+	}
+	seconds := round2(avg.Seconds())
+	return &seconds
+}
+
+// ==================== Availability Forecast Request ====================
+
+// AvailabilityForecastRequest holds the raw query parameters of
+// GET /api/v1/stats/availability-forecast
+type AvailabilityForecastRequest struct {
+	InboxID string
+	Hours   string
+}
+
+func ParseAvailabilityForecastRequest(r *http.Request) *AvailabilityForecastRequest {
+	query := r.URL.Query()
+	return &AvailabilityForecastRequest{
+		InboxID: query.Get("inbox_id"),
+		Hours:   query.Get("hours"),
+	}
+}
+
+func (r *AvailabilityForecastRequest) Validate() []string {
+	var errs []string
+	if r.InboxID != "" {
+		if _, err := uuid.Parse(r.InboxID); err != nil {
+			errs = append(errs, "inbox_id must be a valid UUID")
+		}
+	}
+	if r.Hours != "" {
+		hours, err := strconv.Atoi(r.Hours)
+		if err != nil || hours < 1 || hours > MaxForecastHours {
+			errs = append(errs, "hours must be between 1 and 24")
+		}
+	}
+	return errs
+}
+
+// The accessors below assume Validate has passed
+
+func (r *AvailabilityForecastRequest) GetInboxID() *uuid.UUID {
+	return parseOptionalUUID(r.InboxID)
+}
+
+func (r *AvailabilityForecastRequest) GetHours() int {
+	hours, err := strconv.Atoi(r.Hours)
+	if err != nil {
+		return DefaultForecastHours
+	}
+	return hours
+}
+
+// ==================== Availability Forecast Response ====================
+
+type AvailabilityForecastHourResponse struct {
+	HourStart                  time.Time `json:"hour_start"`
+	ExpectedAvailableOperators float64   `json:"expected_available_operators"`
+	ProjectedInflow            float64   `json:"projected_inflow"`
+	// Projected conversations per available operator; nil when none are expected
+	InflowPerOperator *float64 `json:"inflow_per_operator"`
+}
+
+type AvailabilityForecastResponse struct {
+	InboxID      *uuid.UUID                         `json:"inbox_id"`
+	LookbackDays int                                `json:"lookback_days"`
+	GeneratedAt  time.Time                          `json:"generated_at"`
+	Forecast     []AvailabilityForecastHourResponse `json:"forecast"`
+}
+
+func NewAvailabilityForecastResponse(f *domain.AvailabilityForecast) AvailabilityForecastResponse {
+	hours := make([]AvailabilityForecastHourResponse, len(f.Hours))
+	for i, h := range f.Hours {
+		hours[i] = newAvailabilityForecastHourResponse(h)
+	}
+	return AvailabilityForecastResponse{
+		InboxID:      f.InboxID,
+		LookbackDays: f.LookbackDays,
+		GeneratedAt:  f.GeneratedAt,
+		Forecast:     hours,
+	}
+}
+
+func newAvailabilityForecastHourResponse(h domain.AvailabilityForecastHour) AvailabilityForecastHourResponse {
+	resp := AvailabilityForecastHourResponse{
+		HourStart:                  h.Start,
+		ExpectedAvailableOperators: round2(h.ExpectedAvailableOperators),
+		ProjectedInflow:            round2(h.ProjectedInflow),
+	}
+	if h.ExpectedAvailableOperators > 0 {
+		perOperator := round2(h.ProjectedInflow / h.ExpectedAvailableOperators)
+		resp.InflowPerOperator = &perOperator
+	}
+	return resp
+}
+
+// ==================== Label Usage Request ====================
+
+// LabelUsageRequest holds the raw query parameters of GET /api/v1/stats/labels
+type LabelUsageRequest struct {
+	InboxID string
+	From    string
+	To      string
+}
+
+func ParseLabelUsageRequest(r *http.Request) *LabelUsageRequest {
+	query := r.URL.Query()
+	return &LabelUsageRequest{
+		InboxID: query.Get("inbox_id"),
+		From:    query.Get("from"),
+		To:      query.Get("to"),
+	}
+}
+
+func (r *LabelUsageRequest) Validate() []string {
+	var errs []string
+
+	if r.InboxID == "" {
+		errs = append(errs, "inbox_id is required")
+	} else if _, err := uuid.Parse(r.InboxID); err != nil {
+		errs = append(errs, "inbox_id must be a valid UUID")
+	}
+
+	return append(errs, validateTimeRange(r.From, r.To)...)
+}
+
+// The accessors below assume Validate has passed
+
+func (r *LabelUsageRequest) GetInboxID() uuid.UUID {
+	return uuid.MustParse(r.InboxID)
+}
+
+// Range returns [from, to); to defaults to now and from to
+// DefaultLabelUsageWindow before to
+func (r *LabelUsageRequest) Range() (time.Time, time.Time) {
+	return timeRange(r.From, r.To, DefaultLabelUsageWindow)
+}
+
+// validateTimeRange checks the optional from and to query parameters
+func validateTimeRange(rawFrom, rawTo string) []string {
+	var errs []string
+	from, fromErr := parseOptionalTime(rawFrom)
+	if fromErr != nil {
+		errs = append(errs, "from must be an RFC 3339 timestamp")
+	}
+	to, toErr := parseOptionalTime(rawTo)
+	if toErr != nil {
+		errs = append(errs, "to must be an RFC 3339 timestamp")
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		errs = append(errs, "from must be before to")
+	}
+	return errs
+}
+
+// timeRange returns [from, to) of validated parameters; to defaults to now
+// and from to window before to
+func timeRange(rawFrom, rawTo string, window time.Duration) (time.Time, time.Time) {
+	to := time.Now().UTC()
+	if parsed, _ := parseOptionalTime(rawTo); parsed != nil {
+		to = *parsed
+	}
+	from := to.Add(-window)
+	if parsed, _ := parseOptionalTime(rawFrom); parsed != nil {
+		from = *parsed
+	}
+	return from, to
+}
+
+// ==================== Label Usage Response ====================
+
+// LabelUsageEntryResponse counts the attachments made under one label version
+type LabelUsageEntryResponse struct {
+	LabelID uuid.UUID `json:"label_id"`
+	Version int       `json:"version"`
+	// Name and color the label had when these attachments were made
+	Name        string  `json:"name"`
+	Color       *string `json:"color"`
+	Attachments int     `json:"attachments"`
+}
+
+type LabelUsageResponse struct {
+	InboxID uuid.UUID                 `json:"inbox_id"`
+	From    time.Time                 `json:"from"`
+	To      time.Time                 `json:"to"`
+	Labels  []LabelUsageEntryResponse `json:"labels"`
+}
+
+func NewLabelUsageResponse(report *domain.LabelUsageReport) LabelUsageResponse {
+	labels := make([]LabelUsageEntryResponse, len(report.Labels))
+	for i, l := range report.Labels {
+		labels[i] = LabelUsageEntryResponse{
+			LabelID:     l.LabelID,
+			Version:     l.Version,
+			Name:        l.Name,
+			Color:       l.Color,
+			Attachments: l.Attachments,
+		}
+	}
+	return LabelUsageResponse{
+		InboxID: report.InboxID,
+		From:    report.Since,
+		To:      report.Until,
+		Labels:  labels,
+	}
+}
+
+// ==================== Escalation Stats Request ====================
+
+// EscalationStatsRequest holds the raw query parameters of
+// GET /api/v1/stats/escalations
+type EscalationStatsRequest struct {
+	From string
+	To   string
+}
+
+func ParseEscalationStatsRequest(r *http.Request) *EscalationStatsRequest {
+	query := r.URL.Query()
+	return &EscalationStatsRequest{
+		From: query.Get("from"),
+		To:   query.Get("to"),
+	}
+}
+
+func (r *EscalationStatsRequest) Validate() []string {
+	return validateTimeRange(r.From, r.To)
+}
+
+// Range returns [from, to); to defaults to now and from to
+// DefaultEscalationWindow before to
+func (r *EscalationStatsRequest) Range() (time.Time, time.Time) {
+	return timeRange(r.From, r.To, DefaultEscalationWindow)
+}
+
+// ==================== Escalation Stats Response ====================
+
+type OperatorEscalationsResponse struct {
+	OperatorID  uuid.UUID `json:"operator_id"`
+	Escalations int       `json:"escalations"`
+}
+
+type InboxEscalationsResponse struct {
+	InboxID     uuid.UUID `json:"inbox_id"`
+	Escalations int       `json:"escalations"`
+}
+
+type EscalationStatsResponse struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Total int       `json:"total"`
+	// Operators the conversations were taken from, most escalations first
+	Operators []OperatorEscalationsResponse `json:"operators"`
+	// Inboxes the conversations left, most escalations first
+	Inboxes []InboxEscalationsResponse `json:"inboxes"`
+}
+
+func NewEscalationStatsResponse(report *domain.EscalationReport) EscalationStatsResponse {
+	operators := make([]OperatorEscalationsResponse, len(report.ByOperator))
+	for i, c := range report.ByOperator {
+		operators[i] = OperatorEscalationsResponse{OperatorID: c.ID, Escalations: c.Count}
+	}
+	inboxes := make([]InboxEscalationsResponse, len(report.ByInbox))
+	for i, c := range report.ByInbox {
+		inboxes[i] = InboxEscalationsResponse{InboxID: c.ID, Escalations: c.Count}
+	}
+	return EscalationStatsResponse{
+		From:      report.Since,
+		To:        report.Until,
+		Total:     report.Total,
+		Operators: operators,
+		Inboxes:   inboxes,
+	}
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
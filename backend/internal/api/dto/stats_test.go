@@ -0,0 +1,166 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestAvailabilityForecastRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      dto.AvailabilityForecastRequest
+		errCount int
+	}{
+		{
+			name:     "defaults",
+			req:      dto.AvailabilityForecastRequest{},
+			errCount: 0,
+		},
+		{
+			name:     "inbox and hours",
+			req:      dto.AvailabilityForecastRequest{InboxID: uuid.New().String(), Hours: "24"},
+			errCount: 0,
+		},
+		{
+			name:     "invalid inbox_id",
+			req:      dto.AvailabilityForecastRequest{InboxID: "inbox"},
+			errCount: 1,
+		},
+		{
+			name:     "zero hours",
+			req:      dto.AvailabilityForecastRequest{Hours: "0"},
+			errCount: 1,
+		},
+		{
+			name:     "too many hours",
+			req:      dto.AvailabilityForecastRequest{Hours: "25"},
+			errCount: 1,
+		},
+		{
+			name:     "non-numeric hours",
+			req:      dto.AvailabilityForecastRequest{Hours: "eight"},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.errCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestAvailabilityForecastRequest_GetHours(t *testing.T) {
+	if hours := (&dto.AvailabilityForecastRequest{}).GetHours(); hours != dto.DefaultForecastHours {
+		t.Errorf("expected default %d hours, got %d", dto.DefaultForecastHours, hours)
+	}
+	if hours := (&dto.AvailabilityForecastRequest{Hours: "3"}).GetHours(); hours != 3 {
+		t.Errorf("expected 3 hours, got %d", hours)
+	}
+}
+
+func TestLabelUsageRequest_Validate(t *testing.T) {
+	inboxID := uuid.New().String()
+	tests := []struct {
+		name     string
+		req      dto.LabelUsageRequest
+		errCount int
+	}{
+		{
+			name:     "inbox only",
+			req:      dto.LabelUsageRequest{InboxID: inboxID},
+			errCount: 0,
+		},
+		{
+			name:     "with range",
+			req:      dto.LabelUsageRequest{InboxID: inboxID, From: "2026-01-01T00:00:00Z", To: "2026-02-01T00:00:00Z"},
+			errCount: 0,
+		},
+		{
+			name:     "missing inbox_id",
+			req:      dto.LabelUsageRequest{},
+			errCount: 1,
+		},
+		{
+			name:     "invalid inbox_id",
+			req:      dto.LabelUsageRequest{InboxID: "inbox"},
+			errCount: 1,
+		},
+		{
+			name:     "invalid from",
+			req:      dto.LabelUsageRequest{InboxID: inboxID, From: "yesterday"},
+			errCount: 1,
+		},
+		{
+			name:     "from after to",
+			req:      dto.LabelUsageRequest{InboxID: inboxID, From: "2026-02-01T00:00:00Z", To: "2026-01-01T00:00:00Z"},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.errCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestLabelUsageRequest_Range(t *testing.T) {
+	req := &dto.LabelUsageRequest{InboxID: uuid.New().String(), To: "2026-02-01T00:00:00Z"}
+	from, to := req.Range()
+	if got := to.Sub(from); got != dto.DefaultLabelUsageWindow {
+		t.Errorf("expected default window %v, got %v", dto.DefaultLabelUsageWindow, got)
+	}
+}
+
+func TestEscalationStatsRequest(t *testing.T) {
+	if errs := (&dto.EscalationStatsRequest{}).Validate(); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := (&dto.EscalationStatsRequest{From: "2026-02-01T00:00:00Z", To: "2026-01-01T00:00:00Z"}).Validate(); len(errs) != 1 {
+		t.Errorf("expected one error for from after to, got %v", errs)
+	}
+
+	from, to := (&dto.EscalationStatsRequest{To: "2026-02-01T00:00:00Z"}).Range()
+	if got := to.Sub(from); got != dto.DefaultEscalationWindow {
+		t.Errorf("expected default window %v, got %v", dto.DefaultEscalationWindow, got)
+	}
+}
+
+func TestNewOverviewResponse(t *testing.T) {
+	inboxID := uuid.New()
+	overview := domain.NewTenantOverview(time.Now().UTC(), 24*time.Hour, []domain.InboxConversationStats{
+		{InboxID: inboxID, DisplayName: "Support", Queued: 2, Allocated: 1, Resolved: 5, RecentlyResolved: 2, AvgResolution: 90 * time.Second, Overdue: 1},
+		{InboxID: uuid.New(), Queued: 1, Overdue: 1},
+	}, map[uuid.UUID]int{inboxID: 3}, map[domain.OperatorStatusType]int{domain.OperatorStatusAvailable: 4})
+
+	resp := dto.NewOverviewResponse(overview)
+	if resp.Totals.Queued != 3 || resp.Totals.Allocated != 1 || resp.Totals.Resolved != 5 || resp.Totals.Overdue != 2 {
+		t.Errorf("unexpected totals %+v", resp.Totals)
+	}
+	if resp.Totals.ActiveOperators != 4 || resp.OperatorsByStatus["AVAILABLE"] != 4 {
+		t.Errorf("expected 4 active operators, got %+v", resp)
+	}
+	if resp.Totals.AllocationsLastHour != 3 || resp.Inboxes[0].AllocationsLastHour != 3 {
+		t.Errorf("expected 3 allocations, got %+v", resp)
+	}
+	if resp.Inboxes[0].AvgResolutionSeconds == nil || *resp.Inboxes[0].AvgResolutionSeconds != 90 {
+		t.Errorf("expected a 90s average, got %v", resp.Inboxes[0].AvgResolutionSeconds)
+	}
+	if resp.Inboxes[1].AvgResolutionSeconds != nil {
+		t.Error("expected no average for an inbox without resolutions")
+	}
+	if resp.ResolutionWindowSeconds != 86400 {
+		t.Errorf("expected a one day window, got %d", resp.ResolutionWindowSeconds)
+	}
+}
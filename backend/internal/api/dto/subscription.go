@@ -5,18 +5,15 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 )
 
 type SubscribeOperatorRequest struct {
-	OperatorID uuid.UUID `json:"operator_id"`
+	OperatorID uuid.UUID `json:"operator_id" validate:"required"`
 }
 
 func (r *SubscribeOperatorRequest) Validate() []string {
-	var errs []string
-	if r.OperatorID == uuid.Nil {
-		errs = append(errs, "operator_id is required")
-	}
-	return errs
+	return validate.Struct(r)
 }
 
 type SubscriptionResponse struct {
@@ -65,3 +62,9 @@ type OperatorInboxesResponse struct {
 	Inboxes    []InboxWithSubscription `json:"inboxes"`
 	Meta       ListMeta                `json:"meta"`
 }
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeSubscriptionConflict = "SUBSCRIPTION_CONFLICT"
+)
@@ -5,22 +5,20 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
 	"github.com/shopspring/decimal"
 )
 
 type UpdateTenantWeightsRequest struct {
-	Alpha float64 `json:"alpha"`
-	Beta  float64 `json:"beta"`
+	Alpha float64 `json:"alpha" validate:"min=0,max=1"`
+	Beta  float64 `json:"beta" validate:"min=0,max=1"`
+	// FirstContactBoost is added to the priority of first-contact
+	// conversations; omitted keeps the current boost, 0 disables it
+	FirstContactBoost *float64 `json:"first_contact_boost,omitempty" validate:"min=0,max=1"`
 }
 
 func (r *UpdateTenantWeightsRequest) Validate() []string {
-	var errs []string
-	if r.Alpha < 0 || r.Alpha > 1 {
-		errs = append(errs, "alpha must be between 0 and 1")
-	}
-	if r.Beta < 0 || r.Beta > 1 {
-		errs = append(errs, "beta must be between 0 and 1")
-	}
+	errs := validate.Struct(r)
 	sum := r.Alpha + r.Beta
 	if sum < 0.99 || sum > 1.01 {
 		errs = append(errs, "alpha + beta should equal 1.0")
@@ -32,23 +30,100 @@ func (r *UpdateTenantWeightsRequest) ToDecimal() (alpha, beta decimal.Decimal) {
 	return decimal.NewFromFloat(r.Alpha), decimal.NewFromFloat(r.Beta)
 }
 
+// GetFirstContactBoost returns the requested boost, nil when omitted
+func (r *UpdateTenantWeightsRequest) GetFirstContactBoost() *decimal.Decimal {
+	if r.FirstContactBoost == nil {
+		return nil
+	}
+	boost := decimal.NewFromFloat(*r.FirstContactBoost)
+	return &boost
+}
+
+// MaxTenantGracePeriod bounds a tenant's grace period
+const MaxTenantGracePeriod = 24 * time.Hour
+
+// MaxIntakeLimitPerHour bounds a tenant's per-phone intake limit
+const MaxIntakeLimitPerHour = 1000
+
+// UpdateTenantSettingsRequest replaces the tenant's settings; a null or
+// omitted grace_period_seconds uses the server default, a null or omitted
+// intake_limit_per_hour disables intake throttling, and a null or omitted
+// allocation_engine pins the tenant to the default engine
+type UpdateTenantSettingsRequest struct {
+	GracePeriodSeconds *int    `json:"grace_period_seconds" validate:"min=0,max=86400"`
+	IntakeLimitPerHour *int    `json:"intake_limit_per_hour" validate:"min=1,max=1000"`
+	AllocationEngine   *string `json:"allocation_engine"`
+}
+
+func (r *UpdateTenantSettingsRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.AllocationEngine != nil && !domain.AllocationEngine(*r.AllocationEngine).IsValid() {
+		errs = append(errs, "allocation_engine must be v1 or v2")
+	}
+	return errs
+}
+
+// GracePeriod returns the requested grace period, nil for the server default
+func (r *UpdateTenantSettingsRequest) GracePeriod() *time.Duration {
+	if r.GracePeriodSeconds == nil {
+		return nil
+	}
+	d := time.Duration(*r.GracePeriodSeconds) * time.Second
+	return &d
+}
+
+// ToDomain returns the requested settings
+func (r *UpdateTenantSettingsRequest) ToDomain() domain.TenantSettings {
+	return domain.TenantSettings{
+		GracePeriod:        r.GracePeriod(),
+		IntakeLimitPerHour: r.IntakeLimitPerHour,
+		AllocationEngine:   r.GetAllocationEngine(),
+	}
+}
+
+// GetAllocationEngine returns the requested engine, the default when omitted
+func (r *UpdateTenantSettingsRequest) GetAllocationEngine() domain.AllocationEngine {
+	if r.AllocationEngine == nil {
+		return domain.DefaultAllocationEngine
+	}
+	return domain.AllocationEngine(*r.AllocationEngine)
+}
+
 type TenantResponse struct {
 	ID                  uuid.UUID `json:"id"`
 	Name                string    `json:"name"`
 	PriorityWeightAlpha float64   `json:"priority_weight_alpha"`
 	PriorityWeightBeta  float64   `json:"priority_weight_beta"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	FirstContactBoost   float64   `json:"first_contact_boost"`
+	// GracePeriodSeconds is null when the server default applies
+	GracePeriodSeconds *int `json:"grace_period_seconds"`
+	// IntakeLimitPerHour is null when intake throttling is off
+	IntakeLimitPerHour *int   `json:"intake_limit_per_hour"`
+	AllocationEngine   string `json:"allocation_engine"`
+	// Sandbox marks a trial tenant holding demo data only
+	Sandbox   bool      `json:"sandbox"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 func NewTenantResponse(t *domain.Tenant) TenantResponse {
 	alpha, _ := t.PriorityWeightAlpha.Float64()
 	beta, _ := t.PriorityWeightBeta.Float64()
+	var gracePeriod *int
+	if t.GracePeriod != nil {
+		seconds := int(t.GracePeriod.Seconds())
+		gracePeriod = &seconds
+	}
 	return TenantResponse{
 		ID:                  t.ID,
 		Name:                t.Name,
 		PriorityWeightAlpha: alpha,
 		PriorityWeightBeta:  beta,
+		FirstContactBoost:   t.FirstContactBoost.InexactFloat64(),
+		GracePeriodSeconds:  gracePeriod,
+		IntakeLimitPerHour:  t.IntakeLimitPerHour,
+		AllocationEngine:    string(t.AllocationEngine),
+		Sandbox:             t.Sandbox,
 		CreatedAt:           t.CreatedAt,
 		UpdatedAt:           t.UpdatedAt,
 	}
@@ -2,8 +2,10 @@ package dto_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
 )
 
 func TestUpdateTenantWeightsRequest_Validate(t *testing.T) {
@@ -40,6 +42,35 @@ func TestUpdateTenantWeightsRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestUpdateTenantWeightsRequest_FirstContactBoost(t *testing.T) {
+	req := dto.UpdateTenantWeightsRequest{Alpha: 0.5, Beta: 0.5}
+	if errs := req.Validate(); len(errs) > 0 {
+		t.Errorf("omitted boost: unexpected errors: %v", errs)
+	}
+	if req.GetFirstContactBoost() != nil {
+		t.Error("omitted boost should keep the current one")
+	}
+
+	for _, boost := range []float64{0, 0.25, 1} {
+		b := boost
+		req.FirstContactBoost = &b
+		if errs := req.Validate(); len(errs) > 0 {
+			t.Errorf("boost %v: unexpected errors: %v", boost, errs)
+		}
+		if got := req.GetFirstContactBoost(); got == nil || got.InexactFloat64() != boost {
+			t.Errorf("boost %v: got %v", boost, got)
+		}
+	}
+
+	for _, boost := range []float64{-0.1, 1.5} {
+		b := boost
+		req.FirstContactBoost = &b
+		if errs := req.Validate(); len(errs) != 1 {
+			t.Errorf("boost %v: expected one validation error, got %v", boost, errs)
+		}
+	}
+}
+
 func TestUpdateTenantWeightsRequest_ToDecimal(t *testing.T) {
 	req := dto.UpdateTenantWeightsRequest{Alpha: 0.6, Beta: 0.4}
 	alpha, beta := req.ToDecimal()
@@ -54,3 +85,116 @@ func TestUpdateTenantWeightsRequest_ToDecimal(t *testing.T) {
 		t.Errorf("beta: got %v, want 0.4", betaFloat)
 	}
 }
+
+func TestUpdateTenantSettingsRequest_Validate(t *testing.T) {
+	seconds := func(s int) *int { return &s }
+	maxSeconds := int(dto.MaxTenantGracePeriod.Seconds())
+
+	tests := []struct {
+		name    string
+		seconds *int
+		wantErr bool
+	}{
+		{"server default", nil, false},
+		{"zero", seconds(0), false},
+		{"fifteen minutes", seconds(900), false},
+		{"maximum", seconds(maxSeconds), false},
+		{"negative", seconds(-1), true},
+		{"above maximum", seconds(maxSeconds + 1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.UpdateTenantSettingsRequest{GracePeriodSeconds: tt.seconds}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestUpdateTenantSettingsRequest_GracePeriod(t *testing.T) {
+	req := dto.UpdateTenantSettingsRequest{}
+	if req.GracePeriod() != nil {
+		t.Error("expected nil grace period for the server default")
+	}
+
+	seconds := 900
+	req.GracePeriodSeconds = &seconds
+	if got := req.GracePeriod(); got == nil || *got != 15*time.Minute {
+		t.Errorf("expected 15m, got %v", got)
+	}
+}
+
+func TestUpdateTenantSettingsRequest_IntakeLimit(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	for _, tt := range []struct {
+		name    string
+		limit   *int
+		wantErr bool
+	}{
+		{"disabled", nil, false},
+		{"one", limit(1), false},
+		{"maximum", limit(dto.MaxIntakeLimitPerHour), false},
+		{"zero", limit(0), true},
+		{"above maximum", limit(dto.MaxIntakeLimitPerHour + 1), true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.UpdateTenantSettingsRequest{IntakeLimitPerHour: tt.limit}
+			errs := req.Validate()
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+
+	req := dto.UpdateTenantSettingsRequest{IntakeLimitPerHour: limit(5)}
+	settings := req.ToDomain()
+	if settings.GracePeriod != nil {
+		t.Error("expected the server default grace period")
+	}
+	if settings.IntakeLimitPerHour == nil || *settings.IntakeLimitPerHour != 5 {
+		t.Errorf("expected intake limit 5, got %v", settings.IntakeLimitPerHour)
+	}
+}
+
+func TestUpdateTenantSettingsRequest_AllocationEngine(t *testing.T) {
+	engine := func(e string) *string { return &e }
+
+	for _, tt := range []struct {
+		name    string
+		engine  *string
+		want    domain.AllocationEngine
+		wantErr bool
+	}{
+		{"omitted", nil, domain.DefaultAllocationEngine, false},
+		{"v1", engine("v1"), domain.AllocationEngineV1, false},
+		{"v2", engine("v2"), domain.AllocationEngineV2, false},
+		{"unknown", engine("v3"), "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.UpdateTenantSettingsRequest{AllocationEngine: tt.engine}
+			errs := req.Validate()
+			if tt.wantErr {
+				if len(errs) == 0 {
+					t.Error("expected validation error")
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+			if got := req.ToDomain().AllocationEngine; got != tt.want {
+				t.Errorf("expected engine %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Transfer Request ====================
+
+// RequestTransferRequest proposes the caller's conversation to a colleague.
+// expires_in_seconds defaults to five minutes.
+type RequestTransferRequest struct {
+	ConversationID   uuid.UUID `json:"conversation_id" validate:"required"`
+	ToOperatorID     uuid.UUID `json:"to_operator_id" validate:"required"`
+	Note             *string   `json:"note" validate:"max=2000"`
+	ExpiresInSeconds *int      `json:"expires_in_seconds" validate:"min=1,max=3600"`
+}
+
+func (r *RequestTransferRequest) Validate() []string {
+	return validate.Struct(r)
+}
+
+// GetNote returns the trimmed note, nil when blank
+func (r *RequestTransferRequest) GetNote() *string {
+	if r.Note == nil {
+		return nil
+	}
+	note := strings.TrimSpace(*r.Note)
+	if note == "" {
+		return nil
+	}
+	return &note
+}
+
+func (r *RequestTransferRequest) GetTTL() time.Duration {
+	if r.ExpiresInSeconds == nil {
+		return domain.DefaultTransferTTL
+	}
+	return time.Duration(*r.ExpiresInSeconds) * time.Second
+}
+
+// ==================== Transfer Response ====================
+
+type TransferResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	FromOperatorID uuid.UUID  `json:"from_operator_id"`
+	ToOperatorID   uuid.UUID  `json:"to_operator_id"`
+	Note           *string    `json:"note"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RespondedAt    *time.Time `json:"responded_at"`
+}
+
+func NewTransferResponse(t *domain.ConversationTransfer) TransferResponse {
+	return TransferResponse{
+		ID:             t.ID,
+		ConversationID: t.ConversationID,
+		FromOperatorID: t.FromOperatorID,
+		ToOperatorID:   t.ToOperatorID,
+		Note:           t.Note,
+		Status:         string(t.Status),
+		ExpiresAt:      t.ExpiresAt,
+		CreatedAt:      t.CreatedAt,
+		RespondedAt:    t.RespondedAt,
+	}
+}
+
+type TransferListResponse struct {
+	Transfers []TransferResponse `json:"transfers"`
+}
+
+func NewTransferListResponse(transfers []*domain.ConversationTransfer) TransferListResponse {
+	resp := TransferListResponse{Transfers: make([]TransferResponse, len(transfers))}
+	for i, t := range transfers {
+		resp.Transfers[i] = NewTransferResponse(t)
+	}
+	return resp
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeTransferNotFound     = "TRANSFER_NOT_FOUND"
+	ErrCodeTransferNotAssignee  = "TRANSFER_NOT_ASSIGNEE"
+	ErrCodeTransferToSelf       = "TRANSFER_TO_SELF"
+	ErrCodeTransferPending      = "TRANSFER_ALREADY_PENDING"
+	ErrCodeTransferNotRecipient = "TRANSFER_NOT_RECIPIENT"
+	ErrCodeTransferNotPending   = "TRANSFER_NOT_PENDING"
+	ErrCodeTransferExpired      = "TRANSFER_EXPIRED"
+	ErrCodeTransferCancelled    = "TRANSFER_CANCELLED"
+)
@@ -0,0 +1,56 @@
+package dto_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestRequestTransferRequest_Validate(t *testing.T) {
+	conv, to := uuid.New(), uuid.New()
+	seconds := func(n int) *int { return &n }
+	note := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		req      dto.RequestTransferRequest
+		errCount int
+	}{
+		{"minimal", dto.RequestTransferRequest{ConversationID: conv, ToOperatorID: to}, 0},
+		{"with note and expiry", dto.RequestTransferRequest{ConversationID: conv, ToOperatorID: to, Note: note("billing question"), ExpiresInSeconds: seconds(600)}, 0},
+		{"missing ids", dto.RequestTransferRequest{}, 2},
+		{"note too long", dto.RequestTransferRequest{ConversationID: conv, ToOperatorID: to, Note: note(strings.Repeat("a", domain.MaxTransferNoteLength+1))}, 1},
+		{"zero expiry", dto.RequestTransferRequest{ConversationID: conv, ToOperatorID: to, ExpiresInSeconds: seconds(0)}, 1},
+		{"expiry over an hour", dto.RequestTransferRequest{ConversationID: conv, ToOperatorID: to, ExpiresInSeconds: seconds(3601)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.errCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestRequestTransferRequest_Defaults(t *testing.T) {
+	req := dto.RequestTransferRequest{}
+	if got := req.GetTTL(); got != domain.DefaultTransferTTL {
+		t.Errorf("expected default TTL %v, got %v", domain.DefaultTransferTTL, got)
+	}
+
+	seconds := 90
+	blank := "  "
+	req = dto.RequestTransferRequest{ExpiresInSeconds: &seconds, Note: &blank}
+	if got := req.GetTTL(); got != 90*time.Second {
+		t.Errorf("expected 90s, got %v", got)
+	}
+	if req.GetNote() != nil {
+		t.Errorf("expected blank note to be dropped")
+	}
+}
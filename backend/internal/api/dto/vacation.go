@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Vacation Request ====================
+
+// StartVacationRequest puts the operator on vacation. drain defaults to
+// NONE; colleague_ids are required with COLLEAGUES and rejected otherwise.
+// ramp_down_minutes spreads the drain, 0 drains at once.
+type StartVacationRequest struct {
+	Drain           string      `json:"drain"`
+	ColleagueIDs    []uuid.UUID `json:"colleague_ids" validate:"max=20"`
+	RampDownMinutes int         `json:"ramp_down_minutes" validate:"min=0,max=10080"`
+}
+
+func (r *StartVacationRequest) Validate() []string {
+	errs := validate.Struct(r)
+	drain := r.GetDrain()
+	if !drain.IsValid() {
+		errs = append(errs, "drain must be NONE, QUEUE or COLLEAGUES")
+	}
+	if drain == domain.VacationDrainColleagues && len(r.ColleagueIDs) == 0 {
+		errs = append(errs, "colleague_ids is required when drain is COLLEAGUES")
+	}
+	if drain != domain.VacationDrainColleagues && len(r.ColleagueIDs) > 0 {
+		errs = append(errs, "colleague_ids is only allowed when drain is COLLEAGUES")
+	}
+	seen := make(map[uuid.UUID]bool, len(r.ColleagueIDs))
+	for _, id := range r.ColleagueIDs {
+		if seen[id] {
+			errs = append(errs, "colleague_ids must not repeat")
+			break
+		}
+		seen[id] = true
+	}
+	return errs
+}
+
+func (r *StartVacationRequest) GetDrain() domain.VacationDrain {
+	if r.Drain == "" {
+		return domain.VacationDrainNone
+	}
+	return domain.VacationDrain(r.Drain)
+}
+
+func (r *StartVacationRequest) GetRampDown() time.Duration {
+	return time.Duration(r.RampDownMinutes) * time.Minute
+}
+
+// ==================== Vacation Response ====================
+
+type VacationResponse struct {
+	OperatorID   uuid.UUID   `json:"operator_id"`
+	StartedAt    time.Time   `json:"started_at"`
+	Drain        string      `json:"drain"`
+	ColleagueIDs []uuid.UUID `json:"colleague_ids"`
+	DrainUntil   time.Time   `json:"drain_until"`
+	// DrainedAt is set once no conversation was left to drain
+	DrainedAt *time.Time `json:"drained_at"`
+	Draining  bool       `json:"draining"`
+}
+
+func NewVacationResponse(v *domain.OperatorVacation) VacationResponse {
+	colleagueIDs := v.ColleagueIDs
+	if colleagueIDs == nil {
+		colleagueIDs = []uuid.UUID{}
+	}
+	return VacationResponse{
+		OperatorID:   v.OperatorID,
+		StartedAt:    v.StartedAt,
+		Drain:        string(v.Drain),
+		ColleagueIDs: colleagueIDs,
+		DrainUntil:   v.DrainUntil,
+		DrainedAt:    v.DrainedAt,
+		Draining:     v.Draining(),
+	}
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeVacationNotFound          = "VACATION_NOT_FOUND"
+	ErrCodeVacationColleagueNotFound = "VACATION_COLLEAGUE_NOT_FOUND"
+	ErrCodeOperatorOnVacation        = "OPERATOR_ON_VACATION"
+)
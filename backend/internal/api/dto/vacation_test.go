@@ -0,0 +1,57 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestStartVacationRequest_Validate(t *testing.T) {
+	colleague := uuid.New()
+
+	tests := []struct {
+		name     string
+		req      dto.StartVacationRequest
+		errCount int
+	}{
+		{"defaults to no drain", dto.StartVacationRequest{}, 0},
+		{"queue over a day", dto.StartVacationRequest{Drain: "QUEUE", RampDownMinutes: 24 * 60}, 0},
+		{"colleagues", dto.StartVacationRequest{Drain: "COLLEAGUES", ColleagueIDs: []uuid.UUID{colleague}}, 0},
+		{"colleagues without ids", dto.StartVacationRequest{Drain: "COLLEAGUES"}, 1},
+		{"ids without colleagues", dto.StartVacationRequest{Drain: "QUEUE", ColleagueIDs: []uuid.UUID{colleague}}, 1},
+		{"repeated colleague", dto.StartVacationRequest{Drain: "COLLEAGUES", ColleagueIDs: []uuid.UUID{colleague, colleague}}, 1},
+		{"negative ramp-down", dto.StartVacationRequest{Drain: "QUEUE", RampDownMinutes: -1}, 1},
+		{"ramp-down over a week", dto.StartVacationRequest{Drain: "QUEUE", RampDownMinutes: 7*24*60 + 1}, 1},
+		{"unknown drain", dto.StartVacationRequest{Drain: "SOMEWHERE"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.errCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestNewVacationResponse(t *testing.T) {
+	vacation, err := domain.NewOperatorVacation(uuid.New(), uuid.New(), domain.VacationDrainQueue, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("NewOperatorVacation: %v", err)
+	}
+
+	resp := dto.NewVacationResponse(vacation)
+	if resp.ColleagueIDs == nil {
+		t.Error("colleague_ids should render as an empty list")
+	}
+	if !resp.Draining || resp.DrainedAt != nil {
+		t.Errorf("expected a vacation still draining, got %+v", resp)
+	}
+	if got := resp.DrainUntil.Sub(resp.StartedAt); got != time.Hour {
+		t.Errorf("expected a one-hour ramp-down, got %v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package dto_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// tagBound ties a validate tag bound to the constant it spells out. Tags
+// can only hold literals, so a constant changed without its tag fails here.
+type tagBound struct {
+	request interface{}
+	field   string
+	rule    string
+	want    int
+}
+
+func seconds(d time.Duration) int { return int(d / time.Second) }
+
+var tagBounds = []tagBound{
+	{dto.AutoResolveRequest{}, "AutoResolveAfterSeconds", "max", seconds(dto.MaxAutoResolveAfter)},
+	{dto.ChecklistTemplateRequest{}, "Items", "max", domain.MaxChecklistItems},
+	{dto.ChecklistTemplateRequest{}, "PinnedNote", "max", domain.MaxPinnedNoteLength},
+	{dto.CreateRealtimeTokenRequest{}, "ExpiresInSeconds", "max", seconds(domain.MaxRealtimeTokenTTL)},
+	{dto.CreateShareLinkRequest{}, "ExpiresInSeconds", "max", seconds(domain.MaxShareLinkTTL)},
+	{dto.DeallocateRequest{}, "GraceSeconds", "max", seconds(dto.MaxDeallocateGrace)},
+	{dto.EscalateRequest{}, "Reason", "max", domain.MaxEscalationReasonLength},
+	{dto.ExtendGracePeriodRequest{}, "ExtendBySeconds", "max", seconds(dto.MaxGracePeriodExtension)},
+	{dto.GetConversationRequest{}, "Reason", "max", dto.MaxBreakGlassReasonLength},
+	{dto.IngestMessageRequest{}, "Metadata", "max", dto.MaxIngestMetadataEntries},
+	{dto.IngestMessageRequest{}, "Text", "max", dto.MaxMessageTextLength},
+	{dto.MessageReceivedRequest{}, "Text", "max", dto.MaxMessageTextLength},
+	{dto.PriorityOverrideRequest{}, "PriorityOverride", "max", dto.MaxPriorityOverride},
+	{dto.ReassignRequest{}, "HandoverNote", "max", domain.MaxHandoverNoteLength},
+	{dto.RegisterDeviceRequest{}, "Token", "max", dto.MaxDeviceTokenLength},
+	{dto.RequestTransferRequest{}, "ExpiresInSeconds", "max", seconds(domain.MaxTransferTTL)},
+	{dto.RequestTransferRequest{}, "Note", "max", domain.MaxTransferNoteLength},
+	{dto.SetMaintenanceOverrideRequest{}, "DurationSeconds", "min", dto.MinMaintenanceOverrideSeconds},
+	{dto.SetMaintenanceOverrideRequest{}, "DurationSeconds", "max", seconds(domain.MaxMaintenanceOverride)},
+	{dto.SLAPolicyRequest{}, "FirstAssignmentSeconds", "max", dto.MaxSLATargetSeconds},
+	{dto.SLAPolicyRequest{}, "ResolutionSeconds", "max", dto.MaxSLATargetSeconds},
+	{dto.StalenessPolicyRequest{}, "DeallocateGraceSeconds", "max", dto.MaxStalenessSeconds},
+	{dto.StalenessPolicyRequest{}, "StaleAfterSeconds", "max", dto.MaxStalenessSeconds},
+	{dto.StartFocusRequest{}, "Minutes", "max", int(domain.MaxFocusDuration / time.Minute)},
+	{dto.StartVacationRequest{}, "ColleagueIDs", "max", domain.MaxVacationColleagues},
+	{dto.StartVacationRequest{}, "RampDownMinutes", "max", int(domain.MaxVacationRampDown / time.Minute)},
+	{dto.UpdateCustomerRequest{}, "Name", "max", domain.MaxCustomerNameLength},
+	{dto.UpdateTenantSettingsRequest{}, "GracePeriodSeconds", "max", seconds(dto.MaxTenantGracePeriod)},
+	{dto.UpdateTenantSettingsRequest{}, "IntakeLimitPerHour", "max", dto.MaxIntakeLimitPerHour},
+}
+
+func TestValidateTagsMatchLimits(t *testing.T) {
+	for _, b := range tagBounds {
+		typ := reflect.TypeOf(b.request)
+		field, ok := typ.FieldByName(b.field)
+		if !ok {
+			t.Errorf("%s has no field %s", typ.Name(), b.field)
+			continue
+		}
+
+		got := ""
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if name, param, _ := strings.Cut(rule, "="); name == b.rule {
+				got = param
+			}
+		}
+		if got != strconv.Itoa(b.want) {
+			t.Errorf("%s.%s has %s=%q, want %d", typ.Name(), b.field, b.rule, got, b.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ==================== Wait Estimate Request ====================
+
+// WaitEstimateRequest holds the raw query parameters of
+// GET /api/v1/public/wait-estimate
+type WaitEstimateRequest struct {
+	InboxID string
+}
+
+func ParseWaitEstimateRequest(r *http.Request) *WaitEstimateRequest {
+	return &WaitEstimateRequest{InboxID: r.URL.Query().Get("inbox_id")}
+}
+
+func (r *WaitEstimateRequest) Validate() []string {
+	if r.InboxID == "" {
+		return []string{"inbox_id is required"}
+	}
+	if _, err := uuid.Parse(r.InboxID); err != nil {
+		return []string{"inbox_id must be a valid UUID"}
+	}
+	return nil
+}
+
+// GetInboxID assumes Validate has passed
+func (r *WaitEstimateRequest) GetInboxID() uuid.UUID {
+	id, _ := uuid.Parse(r.InboxID)
+	return id
+}
+
+// ==================== Wait Estimate Response ====================
+
+type WaitEstimateResponse struct {
+	InboxID uuid.UUID `json:"inbox_id"`
+	// Null when nothing was allocated recently
+	EstimatedWaitSeconds *int      `json:"estimated_wait_seconds"`
+	QueueDepth           int       `json:"queue_depth"`
+	AllocationsPerHour   float64   `json:"allocations_per_hour"`
+	ComputedAt           time.Time `json:"computed_at"`
+}
+
+func NewWaitEstimateResponse(e *domain.WaitEstimate) WaitEstimateResponse {
+	resp := WaitEstimateResponse{
+		InboxID:            e.InboxID,
+		QueueDepth:         e.QueueDepth,
+		AllocationsPerHour: round2(e.AllocationsPerHour),
+		ComputedAt:         e.ComputedAt,
+	}
+	if e.EstimatedWait != nil {
+		seconds := int(math.Round(e.EstimatedWait.Seconds()))
+		resp.EstimatedWaitSeconds = &seconds
+	}
+	return resp
+}
@@ -0,0 +1,45 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestWaitEstimateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      dto.WaitEstimateRequest
+		errCount int
+	}{
+		{"valid inbox_id", dto.WaitEstimateRequest{InboxID: uuid.New().String()}, 0},
+		{"missing inbox_id", dto.WaitEstimateRequest{}, 1},
+		{"invalid inbox_id", dto.WaitEstimateRequest{InboxID: "inbox"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("expected %d errors, got %d: %v", tt.errCount, len(errs), errs)
+			}
+		})
+	}
+}
+
+func TestNewWaitEstimateResponse(t *testing.T) {
+	now := time.Now().UTC()
+
+	resp := dto.NewWaitEstimateResponse(domain.NewWaitEstimate(uuid.New(), 2, 6, time.Hour, now))
+	if resp.EstimatedWaitSeconds == nil || *resp.EstimatedWaitSeconds != 1800 {
+		t.Errorf("expected 1800 seconds, got %v", resp.EstimatedWaitSeconds)
+	}
+
+	resp = dto.NewWaitEstimateResponse(domain.NewWaitEstimate(uuid.New(), 2, 0, time.Hour, now))
+	if resp.EstimatedWaitSeconds != nil {
+		t.Errorf("expected no estimate, got %d", *resp.EstimatedWaitSeconds)
+	}
+}
@@ -0,0 +1,239 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/outbound"
+	"github.com/inbox-allocation-service/internal/pkg/validate"
+)
+
+// ==================== Create Webhook Request ====================
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required"`
+	Secret     *string  `json:"secret" validate:"min=16,max=255"`
+	EventTypes []string `json:"event_types" validate:"required"`
+	// OperatorFields opts into operator profile fields on assignment events
+	OperatorFields []string `json:"operator_fields"`
+}
+
+func (r *CreateWebhookRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if strings.TrimSpace(r.URL) != "" {
+		errs = appendURLError(errs, "url", r.URL)
+	}
+	errs = append(errs, validateEventTypes(r.EventTypes)...)
+	errs = append(errs, validateOperatorFields(r.OperatorFields)...)
+	return errs
+}
+
+func (r *CreateWebhookRequest) ToEventTypes() []domain.EventType {
+	return toEventTypes(r.EventTypes)
+}
+
+func (r *CreateWebhookRequest) ToOperatorFields() []domain.WebhookOperatorField {
+	return toOperatorFields(r.OperatorFields)
+}
+
+// ==================== Update Webhook Request ====================
+
+type UpdateWebhookRequest struct {
+	URL            *string  `json:"url"`
+	Secret         *string  `json:"secret" validate:"min=16,max=255"`
+	EventTypes     []string `json:"event_types"`
+	OperatorFields []string `json:"operator_fields"`
+	IsActive       *bool    `json:"is_active"`
+}
+
+func (r *UpdateWebhookRequest) Validate() []string {
+	errs := validate.Struct(r)
+	if r.URL == nil && r.Secret == nil && r.EventTypes == nil && r.OperatorFields == nil && r.IsActive == nil {
+		errs = append(errs, "at least one field (url, secret, event_types, operator_fields or is_active) must be provided")
+		return errs
+	}
+	if r.URL != nil {
+		errs = appendURLError(errs, "url", *r.URL)
+	}
+	if r.EventTypes != nil && len(r.EventTypes) == 0 {
+		errs = append(errs, "event_types must contain at least one event type")
+	}
+	errs = append(errs, validateEventTypes(r.EventTypes)...)
+	errs = append(errs, validateOperatorFields(r.OperatorFields)...)
+	return errs
+}
+
+// ToEventTypes returns nil when event_types was omitted (leave unchanged)
+func (r *UpdateWebhookRequest) ToEventTypes() []domain.EventType {
+	if r.EventTypes == nil {
+		return nil
+	}
+	return toEventTypes(r.EventTypes)
+}
+
+// ToOperatorFields returns nil when operator_fields was omitted (leave
+// unchanged); an empty list turns enrichment off
+func (r *UpdateWebhookRequest) ToOperatorFields() []domain.WebhookOperatorField {
+	if r.OperatorFields == nil {
+		return nil
+	}
+	return toOperatorFields(r.OperatorFields)
+}
+
+// ==================== Validation Helpers ====================
+
+// appendURLError adds the reason raw cannot be called, if any. Hosts in the
+// service's own network are refused here when given as IP literals; names
+// that resolve there are refused by the outbound client when it dials.
+func appendURLError(errs []string, field, raw string) []string {
+	switch err := outbound.CheckURL(raw); {
+	case errors.Is(err, outbound.ErrBlockedAddress):
+		return append(errs, field+" must not point at a loopback, private or link-local address")
+	case err != nil:
+		return append(errs, field+" must be an absolute http or https URL")
+	}
+	return errs
+}
+
+func validateEventTypes(types []string) []string {
+	var errs []string
+	for _, t := range types {
+		if !domain.EventType(t).IsValid() {
+			errs = append(errs, "unsupported event type: "+t)
+		}
+	}
+	return errs
+}
+
+func toEventTypes(types []string) []domain.EventType {
+	result := make([]domain.EventType, len(types))
+	for i, t := range types {
+		result[i] = domain.EventType(t)
+	}
+	return result
+}
+
+func validateOperatorFields(fields []string) []string {
+	var errs []string
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		switch {
+		case !domain.WebhookOperatorField(f).IsValid():
+			errs = append(errs, "unsupported operator field: "+f+" (must be name or email)")
+		case seen[f]:
+			errs = append(errs, "duplicate operator field: "+f)
+		}
+		seen[f] = true
+	}
+	return errs
+}
+
+func toOperatorFields(fields []string) []domain.WebhookOperatorField {
+	result := make([]domain.WebhookOperatorField, len(fields))
+	for i, f := range fields {
+		result[i] = domain.WebhookOperatorField(f)
+	}
+	return result
+}
+
+// ==================== Webhook Response ====================
+
+type WebhookResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	URL            string     `json:"url"`
+	EventTypes     []string   `json:"event_types"`
+	OperatorFields []string   `json:"operator_fields"`
+	IsActive       bool       `json:"is_active"`
+	CreatedBy      *uuid.UUID `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func NewWebhookResponse(w *domain.Webhook) WebhookResponse {
+	eventTypes := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		eventTypes[i] = string(t)
+	}
+	operatorFields := make([]string, len(w.OperatorFields))
+	for i, f := range w.OperatorFields {
+		operatorFields[i] = string(f)
+	}
+	return WebhookResponse{
+		ID:             w.ID,
+		URL:            w.URL,
+		EventTypes:     eventTypes,
+		OperatorFields: operatorFields,
+		IsActive:       w.IsActive,
+		CreatedBy:      w.CreatedBy,
+		CreatedAt:      w.CreatedAt,
+		UpdatedAt:      w.UpdatedAt,
+	}
+}
+
+// WebhookCreatedResponse includes the signing secret, which is only returned on creation
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+func NewWebhookCreatedResponse(w *domain.Webhook) WebhookCreatedResponse {
+	return WebhookCreatedResponse{
+		WebhookResponse: NewWebhookResponse(w),
+		Secret:          w.Secret,
+	}
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Meta     ListMeta          `json:"meta"`
+}
+
+// ==================== Webhook Delivery Response ====================
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	AttemptCount   int             `json:"attempt_count"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func NewWebhookDeliveryResponse(d *domain.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Payload:        json.RawMessage(d.Payload),
+		Status:         string(d.Status),
+		AttemptCount:   d.AttemptCount,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+// ==================== Error Codes ====================
+
+const (
+	ErrCodeWebhookNotFound = "WEBHOOK_NOT_FOUND"
+)
@@ -0,0 +1,155 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+)
+
+func TestCreateWebhookRequest_Validate(t *testing.T) {
+	shortSecret := "short"
+	validSecret := "0123456789abcdef"
+
+	tests := []struct {
+		name     string
+		req      dto.CreateWebhookRequest
+		errCount int
+	}{
+		{
+			name:     "valid request",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com/hooks", EventTypes: []string{"conversation.allocated"}},
+			errCount: 0,
+		},
+		{
+			name:     "valid with secret",
+			req:      dto.CreateWebhookRequest{URL: "https://hooks.example.com/cb", Secret: &validSecret, EventTypes: []string{"operator.status_changed"}},
+			errCount: 0,
+		},
+		{
+			name:     "missing url",
+			req:      dto.CreateWebhookRequest{EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "relative url",
+			req:      dto.CreateWebhookRequest{URL: "/hooks", EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "unsupported scheme",
+			req:      dto.CreateWebhookRequest{URL: "ftp://example.com", EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "loopback url",
+			req:      dto.CreateWebhookRequest{URL: "http://localhost:9000/cb", EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "metadata endpoint",
+			req:      dto.CreateWebhookRequest{URL: "http://169.254.169.254/latest/meta-data/", EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "private network url",
+			req:      dto.CreateWebhookRequest{URL: "http://10.0.0.5:8080/hooks", EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "secret too short",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com", Secret: &shortSecret, EventTypes: []string{"conversation.resolved"}},
+			errCount: 1,
+		},
+		{
+			name:     "no event types",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com"},
+			errCount: 1,
+		},
+		{
+			name:     "unknown event type",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com", EventTypes: []string{"conversation.exploded"}},
+			errCount: 1,
+		},
+		{
+			name:     "valid operator fields",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com", EventTypes: []string{"conversation.allocated"}, OperatorFields: []string{"name", "email"}},
+			errCount: 0,
+		},
+		{
+			name:     "unknown operator field",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com", EventTypes: []string{"conversation.allocated"}, OperatorFields: []string{"phone"}},
+			errCount: 1,
+		},
+		{
+			name:     "duplicate operator field",
+			req:      dto.CreateWebhookRequest{URL: "https://example.com", EventTypes: []string{"conversation.allocated"}, OperatorFields: []string{"name", "name"}},
+			errCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhookRequest_Validate(t *testing.T) {
+	active := false
+	badURL := "not a url"
+
+	tests := []struct {
+		name     string
+		req      dto.UpdateWebhookRequest
+		errCount int
+	}{
+		{"empty request", dto.UpdateWebhookRequest{}, 1},
+		{"toggle active", dto.UpdateWebhookRequest{IsActive: &active}, 0},
+		{"invalid url", dto.UpdateWebhookRequest{URL: &badURL}, 1},
+		{"empty event types", dto.UpdateWebhookRequest{EventTypes: []string{}}, 1},
+		{"valid event types", dto.UpdateWebhookRequest{EventTypes: []string{"conversation.reassigned"}}, 0},
+		{"clear operator fields", dto.UpdateWebhookRequest{OperatorFields: []string{}}, 0},
+		{"unknown operator field", dto.UpdateWebhookRequest{OperatorFields: []string{"avatar"}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.req.Validate()
+			if len(errs) != tt.errCount {
+				t.Errorf("Validate() returned %d errors, want %d: %v", len(errs), tt.errCount, errs)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhookRequest_ToEventTypes(t *testing.T) {
+	req := dto.UpdateWebhookRequest{}
+	if req.ToEventTypes() != nil {
+		t.Error("Expected nil event types when omitted")
+	}
+
+	req.EventTypes = []string{"conversation.resolved"}
+	if got := req.ToEventTypes(); len(got) != 1 || got[0] != "conversation.resolved" {
+		t.Errorf("Unexpected event types: %v", got)
+	}
+}
+
+func TestUpdateWebhookRequest_ToOperatorFields(t *testing.T) {
+	req := dto.UpdateWebhookRequest{}
+	if req.ToOperatorFields() != nil {
+		t.Error("Expected nil operator fields when omitted")
+	}
+
+	req.OperatorFields = []string{}
+	if got := req.ToOperatorFields(); got == nil || len(got) != 0 {
+		t.Errorf("Expected empty operator fields, got %v", got)
+	}
+
+	req.OperatorFields = []string{"email"}
+	if got := req.ToOperatorFields(); len(got) != 1 || got[0] != "email" {
+		t.Errorf("Unexpected operator fields: %v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type WorkerScheduleResponse struct {
+	Name            string     `json:"name"`
+	Schedule        string     `json:"schedule"`
+	IntervalSeconds *float64   `json:"interval_seconds"`
+	Cron            *string    `json:"cron"`
+	JitterSeconds   float64    `json:"jitter_seconds"`
+	PhaseSeconds    float64    `json:"phase_seconds"`
+	Running         bool       `json:"running"`
+	NextRunAt       *time.Time `json:"next_run_at"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+}
+
+type WorkerScheduleListResponse struct {
+	Workers []WorkerScheduleResponse `json:"workers"`
+}
+
+func NewWorkerScheduleListResponse(schedules []domain.WorkerSchedule) WorkerScheduleListResponse {
+	resp := WorkerScheduleListResponse{Workers: make([]WorkerScheduleResponse, len(schedules))}
+	for i, s := range schedules {
+		w := WorkerScheduleResponse{
+			Name:          s.Name,
+			Schedule:      s.Schedule,
+			JitterSeconds: s.Jitter.Seconds(),
+			PhaseSeconds:  s.Phase.Seconds(),
+			Running:       s.Running,
+			NextRunAt:     s.NextRunAt,
+			LastRunAt:     s.LastRunAt,
+		}
+		if s.Interval > 0 {
+			seconds := s.Interval.Seconds()
+			w.IntervalSeconds = &seconds
+		}
+		if s.Cron != "" {
+			cron := s.Cron
+			w.Cron = &cron
+		}
+		resp.Workers[i] = w
+	}
+	return resp
+}
@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// SortUrgency lists a workload most urgent first, see
+// domain.ConversationUrgency
+const SortUrgency = "urgency"
+
+// ==================== List Workload Request ====================
+
+// ListWorkloadRequest holds the parameters of GET
+// /api/v1/operator/conversations, the caller's allocated conversations.
+// Sort is urgency (default) or newest.
+type ListWorkloadRequest struct {
+	Sort    string `json:"sort"`
+	Cursor  string `json:"cursor,omitempty"`
+	PerPage int    `json:"per_page"`
+}
+
+func ParseListWorkloadRequest(r *http.Request) *ListWorkloadRequest {
+	req := &ListWorkloadRequest{
+		Sort:    strings.ToLower(r.URL.Query().Get("sort")),
+		Cursor:  r.URL.Query().Get("cursor"),
+		PerPage: ParsePagination(r).PerPage,
+	}
+	if req.Sort == "" {
+		req.Sort = SortUrgency
+	}
+	if req.PerPage > MaxConversationsPerQuery {
+		req.PerPage = MaxConversationsPerQuery
+	}
+	return req
+}
+
+func (r *ListWorkloadRequest) Validate() []string {
+	var errs []string
+	if r.Sort != SortUrgency && r.Sort != SortNewest {
+		errs = append(errs, "sort must be urgency or newest")
+	}
+	if r.Cursor != "" {
+		// A cursor holds the scoring time, and the last score by urgency
+		cursor, err := DecodeCursor(r.Cursor)
+		if err != nil || cursor.AsOf == nil || (r.Sort == SortUrgency && cursor.Urgency == nil) {
+			errs = append(errs, "cursor is invalid")
+		}
+	}
+	return errs
+}
+
+// GetCursor assumes Validate has passed
+func (r *ListWorkloadRequest) GetCursor() *Cursor {
+	if r.Cursor == "" {
+		return nil
+	}
+	cursor, err := DecodeCursor(r.Cursor)
+	if err != nil {
+		return nil
+	}
+	return cursor
+}
+
+// AsOf is the time the list is scored at: that of the cursor, now for the
+// first page
+func (r *ListWorkloadRequest) AsOf(now time.Time) time.Time {
+	if cursor := r.GetCursor(); cursor != nil && cursor.AsOf != nil {
+		return *cursor.AsOf
+	}
+	return now
+}
+
+// ==================== Workload Response ====================
+
+// UrgencyResponse is the score and its components, each between 0 and 1
+type UrgencyResponse struct {
+	Score  float64 `json:"score"`
+	SLA    float64 `json:"sla"`
+	Unread bool    `json:"unread"`
+	Age    float64 `json:"age"`
+}
+
+type WorkloadItemResponse struct {
+	ConversationResponse
+	Urgency UrgencyResponse `json:"urgency"`
+}
+
+type WorkloadMeta struct {
+	Sort       string    `json:"sort"`
+	AsOf       time.Time `json:"as_of"`
+	Count      int       `json:"count"`
+	HasMore    bool      `json:"has_more"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type WorkloadResponse struct {
+	Conversations []WorkloadItemResponse `json:"conversations"`
+	Meta          WorkloadMeta           `json:"meta"`
+}
+
+func NewWorkloadResponse(items []*domain.ConversationUrgency, sort string, asOf time.Time, perPage int) WorkloadResponse {
+	resp := WorkloadResponse{
+		Conversations: make([]WorkloadItemResponse, len(items)),
+		Meta: WorkloadMeta{
+			Sort:    sort,
+			AsOf:    asOf,
+			Count:   len(items),
+			HasMore: len(items) >= perPage,
+		},
+	}
+	for i, item := range items {
+		conv := NewConversationResponse(item.Conversation)
+		unread := item.Unread
+		conv.Unread = &unread
+		resp.Conversations[i] = WorkloadItemResponse{
+			ConversationResponse: conv,
+			Urgency: UrgencyResponse{
+				Score:  item.Score,
+				SLA:    item.SLA,
+				Unread: item.Unread,
+				Age:    item.Age,
+			},
+		}
+	}
+	if len(items) > 0 && resp.Meta.HasMore {
+		resp.Meta.NextCursor = encodeWorkloadCursor(items[len(items)-1], sort, asOf)
+	}
+	return resp
+}
+
+func encodeWorkloadCursor(last *domain.ConversationUrgency, sort string, asOf time.Time) string {
+	c := Cursor{Timestamp: last.Conversation.LastMessageAt, ID: last.Conversation.ID, AsOf: &asOf}
+	if sort == SortUrgency {
+		score := last.Score
+		c.Timestamp = last.Conversation.CreatedAt
+		c.Urgency = &score
+	}
+	data, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,82 @@
+package dto_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func workloadItem(score float64, createdAt time.Time) *domain.ConversationUrgency {
+	conv := domain.NewConversationRef(uuid.New(), uuid.New(), uuid.NewString(), "+15550100")
+	conv.CreatedAt = createdAt
+	conv.LastMessageAt = createdAt.Add(time.Minute)
+	return &domain.ConversationUrgency{Conversation: conv, Score: score, SLA: 0.5, Unread: true, Age: 0.25}
+}
+
+func TestListWorkloadRequest_Validate(t *testing.T) {
+	asOf := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	page := dto.NewWorkloadResponse([]*domain.ConversationUrgency{workloadItem(0.7, asOf)}, dto.SortUrgency, asOf, 1)
+	newestPage := dto.NewWorkloadResponse([]*domain.ConversationUrgency{workloadItem(0.7, asOf)}, dto.SortNewest, asOf, 1)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantSort string
+		wantErrs int
+	}{
+		{"defaults to urgency", "", dto.SortUrgency, 0},
+		{"newest", "?sort=NEWEST", dto.SortNewest, 0},
+		{"unsupported sort", "?sort=priority", "priority", 1},
+		{"urgency cursor", "?cursor=" + page.Meta.NextCursor, dto.SortUrgency, 0},
+		{"newest cursor by urgency", "?cursor=" + newestPage.Meta.NextCursor, dto.SortUrgency, 1},
+		{"newest cursor", "?sort=newest&cursor=" + newestPage.Meta.NextCursor, dto.SortNewest, 0},
+		{"time cursor without as_of", "?sort=newest&cursor=" + dto.EncodeCursor(asOf, uuid.New()), dto.SortNewest, 1},
+		{"garbage cursor", "?cursor=not-base64", dto.SortUrgency, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := dto.ParseListWorkloadRequest(httptest.NewRequest("GET", "/api/v1/operator/conversations"+tt.query, nil))
+			if req.Sort != tt.wantSort {
+				t.Errorf("Sort = %q, want %q", req.Sort, tt.wantSort)
+			}
+			if errs := req.Validate(); len(errs) != tt.wantErrs {
+				t.Errorf("got %d errors, want %d: %v", len(errs), tt.wantErrs, errs)
+			}
+		})
+	}
+}
+
+func TestNewWorkloadResponse_CursorKeepsScoringTime(t *testing.T) {
+	asOf := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	items := []*domain.ConversationUrgency{workloadItem(0.9, asOf.Add(-time.Hour)), workloadItem(0.4, asOf.Add(-2*time.Hour))}
+
+	resp := dto.NewWorkloadResponse(items, dto.SortUrgency, asOf, 2)
+	if !resp.Meta.HasMore || resp.Meta.NextCursor == "" {
+		t.Fatalf("expected a next cursor, got %+v", resp.Meta)
+	}
+	if got := resp.Conversations[0]; got.Urgency.Score != 0.9 || got.Unread == nil || !*got.Unread {
+		t.Errorf("first item = %+v", got)
+	}
+
+	next := dto.ParseListWorkloadRequest(httptest.NewRequest("GET", "/?cursor="+resp.Meta.NextCursor, nil))
+	cursor := next.GetCursor()
+	if cursor == nil || cursor.Urgency == nil || *cursor.Urgency != 0.4 {
+		t.Fatalf("cursor = %+v, want the last score", cursor)
+	}
+	if !cursor.Timestamp.Equal(items[1].Conversation.CreatedAt) || cursor.ID != items[1].Conversation.ID {
+		t.Errorf("cursor = %+v, want the last creation time and ID", cursor)
+	}
+	if got := next.AsOf(time.Now()); !got.Equal(asOf) {
+		t.Errorf("AsOf() = %s, want %s", got, asOf)
+	}
+
+	last := dto.NewWorkloadResponse(items[:1], dto.SortUrgency, asOf, 2)
+	if last.Meta.HasMore || last.Meta.NextCursor != "" {
+		t.Errorf("last page meta = %+v", last.Meta)
+	}
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
 	"github.com/inbox-allocation-service/internal/api/middleware"
@@ -35,25 +37,62 @@ func (h *AllocationHandler) Allocate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse request (no body needed)
+	// Parse request (no body needed, optional count and label_id query parameters)
 	req := dto.ParseAllocateRequest(r)
 	if errs := req.Validate(); len(errs) > 0 {
 		response.ValidationError(w, "Validation failed", errs...)
 		return
 	}
 
+	filter := service.AllocationFilter{LabelIDs: req.GetLabelIDs()}
+	if req.IsBatch() {
+		convs, err := h.service.AllocateFiltered(ctx, tenantID, operatorID, req.GetCount(), filter)
+		if err != nil {
+			h.handleAllocationError(w, err)
+			return
+		}
+		response.OK(w, dto.NewBatchAllocationResponse(convs))
+		return
+	}
+
 	// Execute allocation
-	conv, err := h.service.Allocate(ctx, tenantID, operatorID)
+	convs, err := h.service.AllocateFiltered(ctx, tenantID, operatorID, 1, filter)
 	if err != nil {
 		h.handleAllocationError(w, err)
 		return
 	}
+	conv := convs[0]
 
 	// Build response
 	resp := dto.NewAllocationResponse(conv)
 	response.OK(w, resp)
 }
 
+// Preview handles GET /api/v1/allocate/preview
+func (h *AllocationHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	conv, err := h.service.Preview(ctx, tenantID, operatorID)
+	if err != nil {
+		h.handleAllocationError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewConversationResponse(conv))
+}
+
 // Claim handles POST /api/v1/claim
 func (h *AllocationHandler) Claim(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -97,26 +136,60 @@ func (h *AllocationHandler) Claim(w http.ResponseWriter, r *http.Request) {
 // ==================== Error Handling ====================
 
 func (h *AllocationHandler) handleAllocationError(w http.ResponseWriter, err error) {
+	if handleJournalError(w, err) {
+		return
+	}
+	var throttled *service.AllocationThrottledError
+	if errors.As(err, &throttled) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		response.Error(w, http.StatusTooManyRequests, dto.ErrCodeAllocationThrottled,
+			"Allocations to this operator are paced; retry later")
+		return
+	}
 	switch {
 	case errors.Is(err, service.ErrOperatorNotAvailable):
 		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorNotAvailable,
 			"Operator must be AVAILABLE to allocate conversations")
+	case errors.Is(err, service.ErrOperatorInFocus):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorInFocus,
+			"Operator is in focus mode; end focus to allocate conversations")
+	case errors.Is(err, service.ErrOperatorOnVacation):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorOnVacation,
+			"Operator is on vacation; end the vacation to allocate conversations")
+	case errors.Is(err, service.ErrOutsideSchedule):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOutsideSchedule,
+			"Operator is outside their scheduled working hours")
 	case errors.Is(err, service.ErrNoSubscriptions):
 		response.Error(w, http.StatusBadRequest, dto.ErrCodeNoSubscriptions,
 			"Operator has no inbox subscriptions")
 	case errors.Is(err, service.ErrNoConversationsAvailable):
 		response.Error(w, http.StatusNotFound, dto.ErrCodeNoConversationsAvailable,
 			"No conversations available for allocation")
+	case errors.Is(err, service.ErrLabelNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeLabelNotFound,
+			"Label not found")
 	default:
-		response.InternalError(w, "Failed to allocate conversation")
+		handleServiceError(w, err, "Failed to allocate conversation")
 	}
 }
 
 func (h *AllocationHandler) handleClaimError(w http.ResponseWriter, err error) {
+	if handleJournalError(w, err) {
+		return
+	}
 	switch {
 	case errors.Is(err, service.ErrOperatorNotAvailable):
 		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorNotAvailable,
 			"Operator must be AVAILABLE to claim conversations")
+	case errors.Is(err, service.ErrOperatorInFocus):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorInFocus,
+			"Operator is in focus mode; end focus to claim conversations")
+	case errors.Is(err, service.ErrOperatorOnVacation):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorOnVacation,
+			"Operator is on vacation; end the vacation to claim conversations")
+	case errors.Is(err, service.ErrOutsideSchedule):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOutsideSchedule,
+			"Operator is outside their scheduled working hours")
 	case errors.Is(err, service.ErrConversationNotQueued):
 		response.Error(w, http.StatusConflict, dto.ErrCodeConversationNotQueued,
 			"Conversation is not available for claim")
@@ -127,8 +200,26 @@ func (h *AllocationHandler) handleClaimError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusForbidden, dto.ErrCodeNotSubscribedToInbox,
 			"You are not subscribed to this conversation's inbox")
 	case errors.Is(err, domain.ErrNotFound):
-		response.NotFound(w, "Conversation not found")
+		response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound,
+			"Conversation not found")
+	default:
+		handleServiceError(w, err, "Failed to claim conversation")
+	}
+}
+
+// handleJournalError handles errors from replaying an idempotency key against
+// the allocation journal
+func handleJournalError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrAllocationInProgress):
+		w.Header().Set("Retry-After", "1")
+		response.Error(w, http.StatusConflict, dto.ErrCodeAllocationInProgress,
+			"An allocation with this idempotency key is still in progress")
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		response.Error(w, http.StatusUnprocessableEntity, dto.ErrCodeIdempotencyKeyReused,
+			"Idempotency key was already used for a different allocation")
 	default:
-		response.InternalError(w, "Failed to claim conversation")
+		return false
 	}
+	return true
 }
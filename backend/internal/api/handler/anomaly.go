@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type AnomalyHandler struct {
+	service *service.AnomalyService
+}
+
+func NewAnomalyHandler(svc *service.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{service: svc}
+}
+
+// List handles GET /api/v1/anomalies?since=&limit=
+func (h *AnomalyHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListAnomaliesRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	anomalies, err := h.service.List(r.Context(), tenantID, req.GetSince(time.Now().UTC()), req.GetLimit())
+	if err != nil {
+		handleServiceError(w, err, "Failed to list anomalies")
+		return
+	}
+
+	response.OK(w, dto.NewAnomalyListResponse(anomalies))
+}
+
+// GetSettings handles GET /api/v1/tenant/anomaly-settings
+func (h *AnomalyHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get anomaly settings")
+		return
+	}
+
+	response.OK(w, dto.NewAnomalySettingsResponse(settings))
+}
+
+// UpdateSettings handles PUT /api/v1/tenant/anomaly-settings
+func (h *AnomalyHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateAnomalySettingsRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), tenantID, req.GetSensitivity(), optionalOperatorID(r))
+	if err != nil {
+		handleServiceError(w, err, "Failed to update anomaly settings")
+		return
+	}
+
+	response.OK(w, dto.NewAnomalySettingsResponse(settings))
+}
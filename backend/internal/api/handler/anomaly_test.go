@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// recordingDB finds no rows and keeps the arguments of the last Exec
+type recordingDB struct {
+	execArgs []interface{}
+}
+
+func (db *recordingDB) Exec(_ context.Context, _ string, args ...interface{}) (pgconn.CommandTag, error) {
+	db.execArgs = args
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *recordingDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (db *recordingDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return noRow{}
+}
+
+type noRow struct{}
+
+func (noRow) Scan(...interface{}) error { return pgx.ErrNoRows }
+
+func TestAnomalyUpdateSettings_APIKeyCallerHasNoOperator(t *testing.T) {
+	db := &recordingDB{}
+	repos := &repository.RepositoryContainer{
+		AnomalySettings: repository.NewTenantAnomalySettingsRepository(repository.New(db)),
+	}
+	h := NewAnomalyHandler(service.NewAnomalyService(repos, nil, nil, service.AnomalyDetectionConfig{}, logger.NewNop()))
+
+	// What the auth middleware leaves for an ADMIN API key: a tenant and a
+	// role, but no operator
+	ctx := context.WithValue(context.Background(), middleware.TenantIDKey, uuid.New())
+	ctx = context.WithValue(ctx, middleware.OperatorRoleKey, domain.OperatorRoleAdmin)
+	ctx = context.WithValue(ctx, middleware.APIKeyIDKey, uuid.New())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tenant/anomaly-settings", strings.NewReader(`{"sensitivity":"HIGH"}`)).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	h.UpdateSettings(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	if len(db.execArgs) < 3 {
+		t.Fatalf("upsert args = %v, want updated_by among them", db.execArgs)
+	}
+	if updatedBy := db.execArgs[2].(pgtype.UUID); updatedBy.Valid {
+		t.Errorf("updated_by = %v, want NULL for an API key caller", updatedBy)
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type APIKeyHandler struct {
+	service *service.APIKeyService
+}
+
+func NewAPIKeyHandler(svc *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: svc}
+}
+
+// List handles GET /api/v1/api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	keys, err := h.service.ListKeys(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list API keys")
+		return
+	}
+
+	items := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		items[i] = dto.NewAPIKeyResponse(key)
+	}
+
+	response.OK(w, dto.APIKeyListResponse{APIKeys: items})
+}
+
+// Create handles POST /api/v1/api-keys
+// The key is only returned in this response
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateAPIKeyRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	key, raw, err := h.service.CreateKey(r.Context(), tenantID, strings.TrimSpace(req.Name),
+		domain.OperatorRole(req.Role), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.CreatedAPIKeyResponse{APIKeyResponse: dto.NewAPIKeyResponse(key), Key: raw})
+}
+
+// Revoke handles DELETE /api/v1/api-keys/{id}
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid API key ID")
+		return
+	}
+
+	if _, err := h.service.RevokeKey(r.Context(), tenantID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// optionalOperatorID returns the calling operator, or nil for API key callers
+func optionalOperatorID(r *http.Request) *uuid.UUID {
+	if operatorID, ok := middleware.GetOperatorUUID(r.Context()); ok {
+		return &operatorID
+	}
+	return nil
+}
+
+// ==================== Error Handling ====================
+
+func (h *APIKeyHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrAPIKeyNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeAPIKeyNotFound,
+			"API key not found")
+	default:
+		handleServiceError(w, err, "Failed to process API key operation")
+	}
+}
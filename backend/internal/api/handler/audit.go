@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(svc *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: svc}
+}
+
+// List handles GET /api/v1/audit?actor_id=&entity_type=&entity_id=&from=&to=&cursor=&per_page=
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListAuditLogRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	entries, err := h.service.List(r.Context(), service.ListAuditLogParams{
+		TenantID:   tenantID,
+		ActorID:    req.GetActorID(),
+		EntityType: req.GetEntityType(),
+		EntityID:   req.GetEntityID(),
+		From:       req.GetFrom(),
+		To:         req.GetTo(),
+		Cursor:     req.GetCursor(),
+		PerPage:    req.PerPage,
+	})
+	if err != nil {
+		handleServiceError(w, err, "Failed to list audit log")
+		return
+	}
+
+	response.OK(w, dto.NewAuditLogListResponse(entries, req.PerPage))
+}
+
+// BreakGlassReport handles GET /api/v1/audit/break-glass?actor_id=&entity_id=&from=&to=&cursor=&per_page=
+// Lists managers and admins opening conversations not assigned to them,
+// newest first; entity_id filters by conversation
+func (h *AuditHandler) BreakGlassReport(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListAuditLogRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	action := domain.AuditActionConversationBreakGlass
+	entityType := domain.AuditEntityConversation
+	entries, err := h.service.List(r.Context(), service.ListAuditLogParams{
+		TenantID:   tenantID,
+		ActorID:    req.GetActorID(),
+		EntityType: &entityType,
+		EntityID:   req.GetEntityID(),
+		Action:     &action,
+		From:       req.GetFrom(),
+		To:         req.GetTo(),
+		Cursor:     req.GetCursor(),
+		PerPage:    req.PerPage,
+	})
+	if err != nil {
+		handleServiceError(w, err, "Failed to list break-glass access")
+		return
+	}
+
+	response.OK(w, dto.NewBreakGlassReportResponse(entries, req.PerPage))
+}
+
+// AdminActivity handles GET /api/v1/admin/activity?actor_id=&action=&from=&to=&cursor=&per_page=
+// Lists configuration changes made by admins, newest first; action takes a
+// comma-separated list of admin actions
+func (h *AuditHandler) AdminActivity(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListAdminActivityRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	entries, err := h.service.ListAdminActivity(r.Context(), service.ListAdminActivityParams{
+		TenantID: tenantID,
+		ActorID:  req.GetActorID(),
+		Actions:  req.GetActions(),
+		From:     req.GetFrom(),
+		To:       req.GetTo(),
+		Cursor:   req.GetCursor(),
+		PerPage:  req.PerPage,
+	})
+	if err != nil {
+		handleServiceError(w, err, "Failed to list admin activity")
+		return
+	}
+
+	response.OK(w, dto.NewAuditLogListResponse(entries, req.PerPage))
+}
+
+// ExportAdminActivity handles GET /api/v1/admin/activity/export?actor_id=&action=&from=&to=
+// Downloads the matching admin activity as CSV, newest first. X-Export-Truncated
+// is set when older entries beyond the export limit were left out.
+func (h *AuditHandler) ExportAdminActivity(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListAdminActivityRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	entries, truncated, err := h.service.ExportAdminActivity(r.Context(), service.ListAdminActivityParams{
+		TenantID: tenantID,
+		ActorID:  req.GetActorID(),
+		Actions:  req.GetActions(),
+		From:     req.GetFrom(),
+		To:       req.GetTo(),
+	})
+	if err != nil {
+		handleServiceError(w, err, "Failed to export admin activity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="admin-activity.csv"`)
+	w.Header().Set("X-Export-Truncated", strconv.FormatBool(truncated))
+	w.WriteHeader(http.StatusOK)
+	// Headers are sent, so a write error can only end the download early
+	_ = dto.WriteAdminActivityCSV(w, entries)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type AutoResolveHandler struct {
+	service *service.AutoResolveService
+}
+
+func NewAutoResolveHandler(svc *service.AutoResolveService) *AutoResolveHandler {
+	return &AutoResolveHandler{service: svc}
+}
+
+// SetAutoResolve handles PUT /api/v1/inboxes/{id}/auto-resolve
+func (h *AutoResolveHandler) SetAutoResolve(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.AutoResolveRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	inbox, err := h.service.SetAutoResolveAfter(r.Context(), tenantID, inboxID, req.AutoResolveAfter(), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewInboxResponse(inbox))
+}
+
+// ==================== Error Handling ====================
+
+func (h *AutoResolveHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrAutoResolveInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	default:
+		handleServiceError(w, err, "Failed to update inbox auto-resolution")
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type BackfillHandler struct {
+	service *service.BackfillService
+}
+
+func NewBackfillHandler(svc *service.BackfillService) *BackfillHandler {
+	return &BackfillHandler{service: svc}
+}
+
+// List handles GET /api/v1/admin/backfills
+// Reports the progress of online schema-change backfills
+func (h *BackfillHandler) List(w http.ResponseWriter, r *http.Request) {
+	backfills, err := h.service.List(r.Context())
+	if err != nil {
+		handleServiceError(w, err, "Failed to list backfills")
+		return
+	}
+
+	items := make([]dto.BackfillResponse, len(backfills))
+	for i, b := range backfills {
+		items[i] = dto.NewBackfillResponse(b)
+	}
+
+	response.OK(w, dto.BackfillListResponse{Backfills: items})
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type CategoryQuotaHandler struct {
+	service *service.CategoryQuotaService
+}
+
+func NewCategoryQuotaHandler(svc *service.CategoryQuotaService) *CategoryQuotaHandler {
+	return &CategoryQuotaHandler{service: svc}
+}
+
+// GetQuotas handles GET /api/v1/inboxes/{id}/category-quotas
+func (h *CategoryQuotaHandler) GetQuotas(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	statuses, err := h.service.GetQuotas(r.Context(), tenantID, inboxID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewCategoryQuotasResponse(inboxID, statuses))
+}
+
+// UpdateQuotas handles PUT /api/v1/inboxes/{id}/category-quotas
+func (h *CategoryQuotaHandler) UpdateQuotas(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CategoryQuotasRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	shares := make([]service.CategoryShare, len(req.Quotas))
+	for i, q := range req.Quotas {
+		shares[i] = service.CategoryShare{LabelID: q.GetLabelID(), SharePercent: q.SharePercent}
+	}
+
+	statuses, err := h.service.SetQuotas(r.Context(), tenantID, inboxID, shares, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewCategoryQuotasResponse(inboxID, statuses))
+}
+
+// ==================== Error Handling ====================
+
+func (h *CategoryQuotaHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrCategoryQuotaInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrCategoryQuotaLabelInvalid):
+		response.Error(w, http.StatusUnprocessableEntity, dto.ErrCodeInvalidCategoryQuotas,
+			"Every label must belong to the inbox")
+	case errors.Is(err, domain.ErrInvalidCategoryQuotas):
+		response.Error(w, http.StatusUnprocessableEntity, dto.ErrCodeInvalidCategoryQuotas,
+			err.Error())
+	default:
+		handleServiceError(w, err, "Failed to process category quota operation")
+	}
+}
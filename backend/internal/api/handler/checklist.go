@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ChecklistHandler struct {
+	service *service.ChecklistService
+}
+
+func NewChecklistHandler(svc *service.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{service: svc}
+}
+
+// GetTemplate handles GET /api/v1/inboxes/{id}/checklist-template
+func (h *ChecklistHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	template, err := h.service.GetTemplate(r.Context(), tenantID, inboxID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewChecklistTemplateResponse(template))
+}
+
+// UpdateTemplate handles PUT /api/v1/inboxes/{id}/checklist-template
+func (h *ChecklistHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.ChecklistTemplateRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	template, err := h.service.SetTemplate(r.Context(), tenantID, inboxID, req.Items, req.PinnedNote, req.Strict, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewChecklistTemplateResponse(template))
+}
+
+// GetChecklist handles GET /api/v1/conversations/{id}/checklist
+func (h *ChecklistHandler) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	checklist, err := h.service.GetChecklist(r.Context(), tenantID, conversationID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewConversationChecklistResponse(checklist))
+}
+
+// UpdateItem handles PUT /api/v1/conversations/{id}/checklist/{item_id}
+func (h *ChecklistHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	itemID, err := dto.ParseUUIDParam(r, "item_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid checklist item ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.ChecklistItemRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	checklist, err := h.service.SetItemCompleted(ctx, tenantID, operatorID, conversationID, itemID, *req.Completed, role)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewConversationChecklistResponse(checklist))
+}
+
+// ==================== Error Handling ====================
+
+func (h *ChecklistHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrChecklistInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrChecklistItemNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeChecklistItemNotFound,
+			"Checklist item not found")
+	case errors.Is(err, domain.ErrInvalidChecklistTemplate):
+		response.Error(w, http.StatusUnprocessableEntity, dto.ErrCodeInvalidChecklistTemplate,
+			err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound,
+			"Conversation not found")
+	case errors.Is(err, service.ErrConversationAlreadyResolved):
+		response.Error(w, http.StatusConflict, dto.ErrCodeConversationAlreadyResolved,
+			"Conversation is already resolved")
+	case errors.Is(err, service.ErrInsufficientPermissions):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeInsufficientPermissions,
+			"You don't have permission for this operation")
+	default:
+		handleServiceError(w, err, "Failed to process checklist operation")
+	}
+}
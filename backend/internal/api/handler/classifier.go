@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ClassifierHandler struct {
+	service *service.ClassificationService
+}
+
+func NewClassifierHandler(svc *service.ClassificationService) *ClassifierHandler {
+	return &ClassifierHandler{service: svc}
+}
+
+// Get handles GET /api/v1/tenant/classifier
+func (h *ClassifierHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	cfg, err := h.service.GetConfig(r.Context(), tenantID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewClassifierResponse(cfg))
+}
+
+// Update handles PUT /api/v1/tenant/classifier
+// Sets the classification endpoint and enables or disables it for the tenant
+func (h *ClassifierHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateClassifierRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	cfg, err := h.service.Configure(r.Context(), tenantID, req.Endpoint(), *req.Enabled, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewClassifierResponse(cfg))
+}
+
+// ==================== Error Handling ====================
+
+func (h *ClassifierHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrClassifierNotConfigured):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeClassifierNotConfigured,
+			"No classifier configured for this tenant")
+	default:
+		handleServiceError(w, err, "Failed to process classifier operation")
+	}
+}
@@ -0,0 +1,480 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// errorCase is an error a mapping handles, named as the handler source
+// refers to it
+type errorCase struct {
+	name string
+	err  error
+}
+
+// errorMapping is a function translating service errors into responses.
+// TestErrorContracts checks the table against the handler source, so a new
+// case in a mapping fails until it is listed here.
+type errorMapping struct {
+	fn     string
+	handle func(w http.ResponseWriter, err error)
+	cases  []errorCase
+}
+
+var errorMappings = []errorMapping{
+	{"handleJournalError", func(w http.ResponseWriter, err error) {
+		if !handleJournalError(w, err) {
+			response.InternalError(w, "unhandled")
+		}
+	}, []errorCase{
+		{"service.ErrAllocationInProgress", service.ErrAllocationInProgress},
+		{"service.ErrIdempotencyKeyReused", service.ErrIdempotencyKeyReused},
+	}},
+	{"handleDeletionError", func(w http.ResponseWriter, err error) {
+		if !handleDeletionError(w, err) {
+			response.InternalError(w, "unhandled")
+		}
+	}, []errorCase{
+		{"service.DeletionBlockedError", &service.DeletionBlockedError{Impact: &domain.DeletionImpact{
+			Resource: domain.DeletionResourceLabel, RoutingRules: 1,
+		}}},
+	}},
+	{"handleAllocationError", (&AllocationHandler{}).handleAllocationError, []errorCase{
+		{"service.AllocationThrottledError", &service.AllocationThrottledError{RetryAfter: time.Second}},
+		{"service.ErrOperatorNotAvailable", service.ErrOperatorNotAvailable},
+		{"service.ErrOperatorInFocus", service.ErrOperatorInFocus},
+		{"service.ErrOperatorOnVacation", service.ErrOperatorOnVacation},
+		{"service.ErrOutsideSchedule", service.ErrOutsideSchedule},
+		{"service.ErrNoSubscriptions", service.ErrNoSubscriptions},
+		{"service.ErrNoConversationsAvailable", service.ErrNoConversationsAvailable},
+		{"service.ErrLabelNotFound", service.ErrLabelNotFound},
+	}},
+	{"handleClaimError", (&AllocationHandler{}).handleClaimError, []errorCase{
+		{"service.ErrOperatorNotAvailable", service.ErrOperatorNotAvailable},
+		{"service.ErrOperatorInFocus", service.ErrOperatorInFocus},
+		{"service.ErrOperatorOnVacation", service.ErrOperatorOnVacation},
+		{"service.ErrOutsideSchedule", service.ErrOutsideSchedule},
+		{"service.ErrConversationNotQueued", service.ErrConversationNotQueued},
+		{"service.ErrConversationAlreadyClaimed", service.ErrConversationAlreadyClaimed},
+		{"service.ErrNotSubscribedToInbox", service.ErrNotSubscribedToInbox},
+		{"domain.ErrNotFound", domain.ErrNotFound},
+	}},
+	{"handleFocusError", (&OperatorHandler{}).handleFocusError, []errorCase{
+		{"service.ErrFocusRequiresAvailable", service.ErrFocusRequiresAvailable},
+	}},
+	{"APIKeyHandler.handleError", (&APIKeyHandler{}).handleError, []errorCase{
+		{"service.ErrAPIKeyNotFound", service.ErrAPIKeyNotFound},
+	}},
+	{"AutoResolveHandler.handleError", (&AutoResolveHandler{}).handleError, []errorCase{
+		{"service.ErrAutoResolveInboxNotFound", service.ErrAutoResolveInboxNotFound},
+	}},
+	{"CategoryQuotaHandler.handleError", (&CategoryQuotaHandler{}).handleError, []errorCase{
+		{"service.ErrCategoryQuotaInboxNotFound", service.ErrCategoryQuotaInboxNotFound},
+		{"service.ErrCategoryQuotaLabelInvalid", service.ErrCategoryQuotaLabelInvalid},
+		{"domain.ErrInvalidCategoryQuotas", domain.ErrInvalidCategoryQuotas},
+	}},
+	{"ChecklistHandler.handleError", (&ChecklistHandler{}).handleError, []errorCase{
+		{"service.ErrChecklistInboxNotFound", service.ErrChecklistInboxNotFound},
+		{"service.ErrChecklistItemNotFound", service.ErrChecklistItemNotFound},
+		{"domain.ErrInvalidChecklistTemplate", domain.ErrInvalidChecklistTemplate},
+		{"domain.ErrNotFound", domain.ErrNotFound},
+		{"service.ErrConversationAlreadyResolved", service.ErrConversationAlreadyResolved},
+		{"service.ErrInsufficientPermissions", service.ErrInsufficientPermissions},
+	}},
+	{"ClassifierHandler.handleError", (&ClassifierHandler{}).handleError, []errorCase{
+		{"service.ErrClassifierNotConfigured", service.ErrClassifierNotConfigured},
+	}},
+	{"CustomerHandler.handleError", (&CustomerHandler{}).handleError, []errorCase{
+		{"service.ErrCustomerNotFound", service.ErrCustomerNotFound},
+	}},
+	{"DeviceHandler.handleError", (&DeviceHandler{}).handleError, []errorCase{
+		{"service.ErrDeviceNotFound", service.ErrDeviceNotFound},
+		{"service.ErrTooManyDevices", service.ErrTooManyDevices},
+	}},
+	{"EscalationHandler.handleError", (&EscalationHandler{}).handleError, []errorCase{
+		{"service.ErrEscalationInboxNotFound", service.ErrEscalationInboxNotFound},
+		{"service.ErrEscalationInboxInvalid", service.ErrEscalationInboxInvalid},
+		{"service.ErrTargetInboxNotFound", service.ErrTargetInboxNotFound},
+		{"service.ErrTargetInboxDifferentTenant", service.ErrTargetInboxDifferentTenant},
+	}},
+	{"ExperimentHandler.handleError", (&ExperimentHandler{}).handleError, []errorCase{
+		{"service.ErrExperimentNotFound", service.ErrExperimentNotFound},
+		{"service.ErrExperimentInboxNotFound", service.ErrExperimentInboxNotFound},
+		{"service.ErrExperimentAlreadyRunning", service.ErrExperimentAlreadyRunning},
+		{"domain.ErrExperimentNotRunning", domain.ErrExperimentNotRunning},
+	}},
+	{"GracePeriodHandler.handleError", (&GracePeriodHandler{}).handleError, []errorCase{
+		{"service.ErrGracePeriodNotFound", service.ErrGracePeriodNotFound},
+		{"service.ErrGracePeriodExpired", service.ErrGracePeriodExpired},
+	}},
+	{"InboxAdminHandler.handleError", (&InboxAdminHandler{}).handleError, []errorCase{
+		{"service.ErrInboxAdminInboxNotFound", service.ErrInboxAdminInboxNotFound},
+		{"service.ErrInboxAdminOperatorNotFound", service.ErrInboxAdminOperatorNotFound},
+	}},
+	{"LabelHandler.handleError", (&LabelHandler{}).handleError, []errorCase{
+		{"service.ErrLabelNotFound", service.ErrLabelNotFound},
+		{"domain.ErrNotFound", domain.ErrNotFound},
+		{"service.ErrLabelNameConflict", service.ErrLabelNameConflict},
+		{"service.ErrLabelInboxMismatch", service.ErrLabelInboxMismatch},
+		{"service.ErrLabelPermissionDenied", service.ErrLabelPermissionDenied},
+		{"service.ErrLabelParentInvalid", service.ErrLabelParentInvalid},
+		{"service.ErrLabelCycle", service.ErrLabelCycle},
+	}},
+	{"serviceError", func(w http.ResponseWriter, err error) {
+		handleServiceError(w, err, "unhandled")
+	}, []errorCase{
+		{"domain.ErrAlreadyExists", domain.ErrAlreadyExists},
+		{"domain.ErrConcurrentModification", domain.ErrConcurrentModification},
+		{"domain.ErrInvalidStateTransition", domain.ErrInvalidStateTransition},
+		{"domain.ErrConversationLocked", domain.ErrConversationLocked},
+		{"domain.ErrLockAcquisitionFailed", domain.ErrLockAcquisitionFailed},
+		{"domain.ErrLockTimeout", domain.ErrLockTimeout},
+	}},
+	{"lifecycleError", func(w http.ResponseWriter, err error) {
+		(&LifecycleHandler{}).handleError(w, err, "update")
+	}, []errorCase{
+		{"domain.ErrNotFound", domain.ErrNotFound},
+		{"service.ErrConversationNotAllocated", service.ErrConversationNotAllocated},
+		{"service.ErrConversationAlreadyResolved", service.ErrConversationAlreadyResolved},
+		{"service.ErrConversationNotResolved", service.ErrConversationNotResolved},
+		{"service.ErrInsufficientPermissions", service.ErrInsufficientPermissions},
+		{"service.ErrChecklistIncomplete", service.ErrChecklistIncomplete},
+		{"service.ErrTargetOperatorNotFound", service.ErrTargetOperatorNotFound},
+		{"service.ErrTargetOperatorNotSubscribed", service.ErrTargetOperatorNotSubscribed},
+		{"service.ErrTargetInboxNotFound", service.ErrTargetInboxNotFound},
+		{"service.ErrTargetInboxDifferentTenant", service.ErrTargetInboxDifferentTenant},
+		{"service.ErrEscalationNotConfigured", service.ErrEscalationNotConfigured},
+	}},
+	{"OperatorHealthHandler.handleError", (&OperatorHealthHandler{}).handleError, []errorCase{
+		{"service.ErrHealthOperatorNotFound", service.ErrHealthOperatorNotFound},
+		{"domain.ErrInvalidAllocationWeight", domain.ErrInvalidAllocationWeight},
+	}},
+	{"QAHandler.handleError", (&QAHandler{}).handleError, []errorCase{
+		{"service.ErrQANotReviewer", service.ErrQANotReviewer},
+		{"service.ErrQAQueueEmpty", service.ErrQAQueueEmpty},
+		{"service.ErrQAItemNotFound", service.ErrQAItemNotFound},
+		{"service.ErrQAItemNotClaimed", service.ErrQAItemNotClaimed},
+		{"service.ErrQAReviewerOperatorNotFound", service.ErrQAReviewerOperatorNotFound},
+	}},
+	{"RoutingRuleHandler.handleError", (&RoutingRuleHandler{}).handleError, []errorCase{
+		{"service.ErrRoutingRuleNotFound", service.ErrRoutingRuleNotFound},
+		{"service.ErrRoutingRuleInboxNotFound", service.ErrRoutingRuleInboxNotFound},
+		{"service.ErrRoutingRulePermissionDenied", service.ErrRoutingRulePermissionDenied},
+	}},
+	{"ScheduleHandler.handleError", (&ScheduleHandler{}).handleError, []errorCase{
+		{"service.ErrScheduleNotFound", service.ErrScheduleNotFound},
+		{"service.ErrScheduleOperatorNotFound", service.ErrScheduleOperatorNotFound},
+	}},
+	{"VacationHandler.handleError", (&VacationHandler{}).handleError, []errorCase{
+		{"service.ErrVacationNotFound", service.ErrVacationNotFound},
+		{"service.ErrVacationColleagueNotFound", service.ErrVacationColleagueNotFound},
+		{"domain.ErrInvalidVacation", domain.ErrInvalidVacation},
+	}},
+	{"ShadowHandler.handleError", (&ShadowHandler{}).handleError, []errorCase{
+		{"service.ErrShadowNotFound", service.ErrShadowNotFound},
+		{"service.ErrShadowOperatorNotFound", service.ErrShadowOperatorNotFound},
+		{"service.ErrShadowAlreadyExists", service.ErrShadowAlreadyExists},
+		{"service.ErrShadowSelf", service.ErrShadowSelf},
+	}},
+	{"ShareLinkHandler.handleError", (&ShareLinkHandler{}).handleError, []errorCase{
+		{"service.ErrShareLinkNotFound", service.ErrShareLinkNotFound},
+		{"service.ErrShareLinkExpired", service.ErrShareLinkExpired},
+		{"service.ErrShareLinkRevoked", service.ErrShareLinkRevoked},
+		{"service.ErrShareConversationNotFound", service.ErrShareConversationNotFound},
+		{"service.ErrShareRestrictedInbox", service.ErrShareRestrictedInbox},
+		{"service.ErrSharePIIForbidden", service.ErrSharePIIForbidden},
+	}},
+	{"SandboxHandler.handleError", (&SandboxHandler{}).handleError, []errorCase{
+		{"service.ErrSandboxAlreadyEnabled", service.ErrSandboxAlreadyEnabled},
+		{"service.ErrTenantNotEmpty", service.ErrTenantNotEmpty},
+		{"service.ErrTenantNotSandbox", service.ErrTenantNotSandbox},
+	}},
+	{"SLAHandler.handleError", (&SLAHandler{}).handleError, []errorCase{
+		{"service.ErrSLAPolicyNotFound", service.ErrSLAPolicyNotFound},
+		{"service.ErrSLAInboxNotFound", service.ErrSLAInboxNotFound},
+	}},
+	{"StalenessHandler.handleError", (&StalenessHandler{}).handleError, []errorCase{
+		{"service.ErrStalenessPolicyNotFound", service.ErrStalenessPolicyNotFound},
+		{"service.ErrStalenessInboxNotFound", service.ErrStalenessInboxNotFound},
+		{"service.ErrActivityNotAssignee", service.ErrActivityNotAssignee},
+		{"domain.ErrNotFound", domain.ErrNotFound},
+	}},
+	{"SubscriptionHandler.handleError", func(w http.ResponseWriter, err error) {
+		(&SubscriptionHandler{}).handleError(w, err, "unhandled")
+	}, []errorCase{
+		{"service.ErrSubscriptionConflict", service.ErrSubscriptionConflict},
+		{"service.ErrSubscriptionPermissionDenied", service.ErrSubscriptionPermissionDenied},
+	}},
+	{"TransferHandler.handleError", func(w http.ResponseWriter, err error) {
+		(&TransferHandler{}).handleError(w, err, "unhandled")
+	}, []errorCase{
+		{"service.ErrTransferNotFound", service.ErrTransferNotFound},
+		{"service.ErrTransferNotAssignee", service.ErrTransferNotAssignee},
+		{"service.ErrTransferToSelf", service.ErrTransferToSelf},
+		{"service.ErrTransferPending", service.ErrTransferPending},
+		{"service.ErrTransferNotRecipient", service.ErrTransferNotRecipient},
+		{"service.ErrTransferNotPending", service.ErrTransferNotPending},
+		{"service.ErrTransferExpired", service.ErrTransferExpired},
+		{"service.ErrTransferCancelled", service.ErrTransferCancelled},
+	}},
+	{"WebhookHandler.handleError", (&WebhookHandler{}).handleError, []errorCase{
+		{"service.ErrWebhookNotFound", service.ErrWebhookNotFound},
+	}},
+}
+
+type mappedError struct {
+	status int
+	code   response.ErrorCode
+	fn     string
+}
+
+func TestErrorContracts(t *testing.T) {
+	byError := make(map[string]mappedError)
+	byCode := make(map[response.ErrorCode]mappedError)
+	emitted := make(map[response.ErrorCode]bool)
+
+	for _, m := range errorMappings {
+		for _, c := range m.cases {
+			// Services wrap their errors; the mapping must look through that
+			status, code := recordError(t, m.handle, fmt.Errorf("%s: %w", m.fn, c.err))
+			if status == http.StatusInternalServerError {
+				t.Errorf("%s does not handle %s", m.fn, c.name)
+				continue
+			}
+			emitted[code] = true
+			got := mappedError{status, code, m.fn}
+
+			// An error means the same thing wherever it is handled
+			if prev, ok := byError[c.name]; ok && (prev.code != code || prev.status != status) {
+				t.Errorf("%s maps to %d %s in %s but %d %s in %s",
+					c.name, status, code, m.fn, prev.status, prev.code, prev.fn)
+			}
+			byError[c.name] = got
+
+			// A code is always answered with the same status
+			if prev, ok := byCode[code]; ok && prev.status != status {
+				t.Errorf("%s is %d in %s but %d in %s", code, status, m.fn, prev.status, prev.fn)
+			}
+			byCode[code] = got
+		}
+	}
+
+	source := parseHandlerSource(t)
+	codes := dtoErrorCodes(t)
+	for _, m := range errorMappings {
+		listed := make([]string, len(m.cases))
+		for i, c := range m.cases {
+			listed[i] = c.name
+		}
+		sort.Strings(listed)
+		handled := source.handled[m.fn]
+		if strings.Join(listed, ",") != strings.Join(handled, ",") {
+			t.Errorf("%s handles %v but the contract lists %v", m.fn, handled, listed)
+		}
+		for _, code := range source.mappedCodes[m.fn] {
+			if !emitted[response.ErrorCode(codes[code])] {
+				t.Errorf("%s references dto.%s but no listed error produces it", m.fn, code)
+			}
+		}
+	}
+	for fn := range source.handled {
+		if !hasMapping(fn) {
+			t.Errorf("error mapping %s is missing from the contract", fn)
+		}
+	}
+
+	// Every dto error code is answered somewhere: a code nobody returns is
+	// drift waiting to be documented
+	for name := range codes {
+		if !source.referencedCodes[name] {
+			t.Errorf("dto.%s is never returned by a handler", name)
+		}
+	}
+}
+
+func recordError(t *testing.T, handle func(http.ResponseWriter, error), err error) (int, response.ErrorCode) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handle(rr, err)
+	if ct := rr.Header().Get("Content-Type"); ct != response.ProblemContentType {
+		t.Errorf("error response has content type %q, want %q", ct, response.ProblemContentType)
+	}
+	var body response.Problem
+	if decodeErr := json.NewDecoder(rr.Body).Decode(&body); decodeErr != nil {
+		t.Fatalf("decode error response: %v", decodeErr)
+	}
+	if body.Status != rr.Code || body.Type != response.ProblemType(body.Code) {
+		t.Errorf("problem %+v does not match its status %d and code", body, rr.Code)
+	}
+	return rr.Code, body.Code
+}
+
+func hasMapping(fn string) bool {
+	for _, m := range errorMappings {
+		if m.fn == fn {
+			return true
+		}
+	}
+	return false
+}
+
+type handlerSource struct {
+	// handled lists the errors each mapping function tests for, sorted
+	handled map[string][]string
+	// mappedCodes lists the dto codes each mapping function returns
+	mappedCodes map[string][]string
+	// referencedCodes holds every dto code referenced by a handler
+	referencedCodes map[string]bool
+}
+
+// parseHandlerSource reads the error mappings out of the handler source:
+// functions named handle*Error, lifecycleError and serviceError
+func parseHandlerSource(t *testing.T) handlerSource {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parse handler package: %v", err)
+	}
+
+	src := handlerSource{
+		handled:         make(map[string][]string),
+		mappedCodes:     make(map[string][]string),
+		referencedCodes: make(map[string]bool),
+	}
+	for _, file := range pkgs["handler"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok && isPackage(sel.X, "dto") && strings.HasPrefix(sel.Sel.Name, "ErrCode") {
+				src.referencedCodes[sel.Sel.Name] = true
+			}
+			return true
+		})
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !isErrorMapping(fn.Name.Name) {
+				continue
+			}
+			name := fn.Name.Name
+			if fn.Recv != nil && name == "handleError" {
+				name = receiverName(fn) + "." + name
+			}
+			if fn.Name.Name == "handleError" && receiverName(fn) == "LifecycleHandler" {
+				continue // delegates to lifecycleError
+			}
+
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.CallExpr:
+					sel, ok := n.Fun.(*ast.SelectorExpr)
+					if !ok || !isPackage(sel.X, "errors") || len(n.Args) != 2 {
+						return true
+					}
+					switch sel.Sel.Name {
+					case "Is":
+						src.handled[name] = append(src.handled[name], exprName(n.Args[1]))
+					case "As":
+						if unary, ok := n.Args[1].(*ast.UnaryExpr); ok {
+							if ident, ok := unary.X.(*ast.Ident); ok && ident.Obj != nil {
+								if vs, ok := ident.Obj.Decl.(*ast.ValueSpec); ok {
+									if star, ok := vs.Type.(*ast.StarExpr); ok {
+										src.handled[name] = append(src.handled[name], exprName(star.X))
+									}
+								}
+							}
+						}
+					}
+				case *ast.SelectorExpr:
+					if isPackage(n.X, "dto") && strings.HasPrefix(n.Sel.Name, "ErrCode") {
+						src.mappedCodes[name] = append(src.mappedCodes[name], n.Sel.Name)
+					}
+				}
+				return true
+			})
+			sort.Strings(src.handled[name])
+		}
+	}
+	return src
+}
+
+// dtoErrorCodes returns the ErrCode constants of the dto package by name
+func dtoErrorCodes(t *testing.T) map[string]string {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../dto", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parse dto package: %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, file := range pkgs["dto"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, ident := range vs.Names {
+					if !strings.HasPrefix(ident.Name, "ErrCode") || i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.BasicLit); ok {
+						value, _ := strconv.Unquote(lit.Value)
+						codes[ident.Name] = value
+					}
+				}
+			}
+		}
+	}
+	return codes
+}
+
+func isErrorMapping(name string) bool {
+	return name == "lifecycleError" || name == "serviceError" ||
+		(strings.HasPrefix(name, "handle") && strings.HasSuffix(name, "Error"))
+}
+
+func isPackage(expr ast.Expr, pkg string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func exprName(expr ast.Expr) string {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			return ident.Name + "." + sel.Sel.Name
+		}
+	}
+	return fmt.Sprintf("%T", expr)
+}
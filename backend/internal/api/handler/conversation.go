@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
 	"github.com/inbox-allocation-service/internal/api/middleware"
@@ -28,7 +32,7 @@ func (h *ConversationHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	operatorID, _ := middleware.GetOperatorUUID(ctx)
+	operatorID, hasOperator := middleware.GetOperatorUUID(ctx)
 	role, _ := middleware.GetOperatorRole(ctx)
 
 	// Parse request
@@ -46,6 +50,7 @@ func (h *ConversationHandler) List(w http.ResponseWriter, r *http.Request) {
 		OperatorID: operatorID,
 		Role:       role,
 		Sort:       req.Sort,
+		Overdue:    req.IsOverdue(),
 		Cursor:     req.GetCursor(),
 		PerPage:    req.PerPage,
 	}
@@ -64,20 +69,87 @@ func (h *ConversationHandler) List(w http.ResponseWriter, r *http.Request) {
 	if req.LabelID != nil {
 		params.LabelID = req.LabelID
 	}
+	if req.Category != nil {
+		params.Category = req.Category
+	}
+	if req.Language != nil {
+		params.Language = req.Language
+	}
+	if req.CustomerID != nil {
+		params.CustomerID = req.CustomerID
+	}
 
 	// Execute
 	conversations, err := h.service.List(ctx, params)
 	if err != nil {
-		response.InternalError(w, "Failed to list conversations")
+		handleServiceError(w, err, "Failed to list conversations")
 		return
 	}
 
 	// Build response
-	resp := dto.NewConversationListResponse(conversations, req.PerPage)
+	resp := dto.NewSortedConversationListResponse(conversations, req.PerPage, req.Sort)
+
+	// Labels of the whole page in one query
+	labels, err := h.service.LabelsFor(ctx, conversations)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list conversations")
+		return
+	}
+	resp.SetLabels(labels)
+
+	// Unread flags are per operator; API key callers get none
+	if hasOperator {
+		flags, err := h.service.UnreadFlags(ctx, operatorID, conversations)
+		if err != nil {
+			handleServiceError(w, err, "Failed to list conversations")
+			return
+		}
+		resp.SetUnread(flags)
+	}
+
 	response.OK(w, resp)
 }
 
-// GetByID handles GET /api/v1/conversations/{id}
+// ListWorkload handles GET /api/v1/operator/conversations
+func (h *ConversationHandler) ListWorkload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	req := dto.ParseListWorkloadRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	asOf := req.AsOf(time.Now().UTC())
+	items, err := h.service.ListWorkload(ctx, service.ListWorkloadParams{
+		TenantID:   tenantID,
+		OperatorID: operatorID,
+		Sort:       req.Sort,
+		AsOf:       asOf,
+		Cursor:     req.GetCursor(),
+		PerPage:    req.PerPage,
+	})
+	if err != nil {
+		handleServiceError(w, err, "Failed to list conversations")
+		return
+	}
+
+	response.OK(w, dto.NewWorkloadResponse(items, req.Sort, asOf, req.PerPage))
+}
+
+// GetByID handles GET /api/v1/conversations/{id}?reason=
 func (h *ConversationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -104,7 +176,7 @@ func (h *ConversationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Conversation not found")
 			return
 		}
-		response.InternalError(w, "Failed to get conversation")
+		handleServiceError(w, err, "Failed to get conversation")
 		return
 	}
 
@@ -114,15 +186,141 @@ func (h *ConversationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get labels
-	labels, _ := h.service.GetLabels(ctx, conversationID)
+	// Record break-glass access by managers and admins
+	req := dto.ParseGetConversationRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+	if err := h.service.RecordBreakGlassAccess(ctx, optionalOperatorID(r), role, conv, req.Reason); err != nil {
+		if errors.Is(err, service.ErrBreakGlassReasonRequired) {
+			response.ValidationError(w, "Validation failed", "reason is required for conversations in restricted inboxes")
+			return
+		}
+		handleServiceError(w, err, "Failed to record conversation access")
+		return
+	}
+
+	labels, err := h.service.GetLabels(ctx, conversationID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get conversation labels")
+		return
+	}
+
+	note, err := h.service.GetHandoverNote(ctx, conv)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get handover note")
+		return
+	}
 
 	// Build response
 	resp := dto.NewConversationResponseWithLabels(conv, labels)
+	resp.HandoverNote = dto.NewConversationNoteResponse(note)
+	response.OK(w, resp)
+}
+
+// MarkRead handles POST /api/v1/conversations/{id}/mark-read
+func (h *ConversationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	conv, err := h.service.GetByID(ctx, tenantID, conversationID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			response.NotFound(w, "Conversation not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get conversation")
+		return
+	}
+
+	if !h.service.CanAccess(ctx, operatorID, role, conv) {
+		response.NotFound(w, "Conversation not found")
+		return
+	}
+
+	read, err := h.service.MarkRead(ctx, operatorID, conv)
+	if err != nil {
+		handleServiceError(w, err, "Failed to mark conversation read")
+		return
+	}
+
+	response.OK(w, dto.NewMarkReadResponse(read))
+}
+
+// CustomerConversations handles GET /api/v1/customers/{phone}/conversations
+// Returns the customer's conversations across inboxes, most recent first
+func (h *ConversationHandler) CustomerConversations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, hasOperator := middleware.GetOperatorUUID(ctx)
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	req := dto.ParseCustomerConversationsRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	customer, conversations, err := h.service.CustomerTimeline(ctx, service.CustomerTimelineParams{
+		TenantID:    tenantID,
+		PhoneNumber: req.Phone,
+		OperatorID:  operatorID,
+		Role:        role,
+		Cursor:      req.GetCursor(),
+		PerPage:     req.PerPage,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrCustomerNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeCustomerNotFound, "Customer not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to list customer conversations")
+		return
+	}
+
+	resp := dto.NewCustomerConversationsResponse(customer, conversations, req.PerPage)
+
+	// Unread flags are per operator; API key callers get none
+	if hasOperator {
+		flags, err := h.service.UnreadFlags(ctx, operatorID, conversations)
+		if err != nil {
+			handleServiceError(w, err, "Failed to list customer conversations")
+			return
+		}
+		resp.SetUnread(flags)
+	}
+
 	response.OK(w, resp)
 }
 
 // Search handles GET /api/v1/search
+// Finds conversations by the start or the last digits of the customer's phone
+// number, or by full-text search over external IDs, customer names and notes
 func (h *ConversationHandler) Search(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -144,15 +342,229 @@ func (h *ConversationHandler) Search(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	digits := req.PhoneDigits()
+	if req.IsTextSearch() {
+		hits, err := h.service.SearchText(ctx, service.SearchTextParams{
+			TenantID:    tenantID,
+			Query:       req.Q,
+			PhoneDigits: digits,
+			OperatorID:  operatorID,
+			Role:        role,
+			Cursor:      req.GetCursor(),
+			PerPage:     req.PerPage,
+		})
+		if err != nil {
+			handleServiceError(w, err, "Failed to search conversations")
+			return
+		}
+		response.OK(w, dto.NewTextSearchResponse(hits, req.Q, req.PerPage))
+		return
+	}
+
 	// Execute search
-	phone := req.NormalizedPhone()
-	conversations, err := h.service.SearchByPhone(ctx, tenantID, phone, operatorID, role)
+	conversations, err := h.service.SearchByPhone(ctx, service.SearchByPhoneParams{
+		TenantID:    tenantID,
+		PhoneDigits: digits,
+		OperatorID:  operatorID,
+		Role:        role,
+		Cursor:      req.GetCursor(),
+		PerPage:     req.PerPage,
+	})
 	if err != nil {
-		response.InternalError(w, "Failed to search conversations")
+		handleServiceError(w, err, "Failed to search conversations")
 		return
 	}
 
 	// Build response
-	resp := dto.NewSearchResponse(conversations, phone)
+	resp := dto.NewSearchResponse(conversations, digits, req.PerPage)
+	response.OK(w, resp)
+}
+
+// RecordMessage handles POST /api/v1/conversations/{id}/messages
+// Records an inbound message and applies MESSAGE_RECEIVED routing rules
+func (h *ConversationHandler) RecordMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.MessageReceivedRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	receivedAt := time.Now().UTC()
+	if req.ReceivedAt != nil {
+		receivedAt = req.ReceivedAt.UTC()
+	}
+
+	result, err := h.service.RecordMessageReceived(ctx, tenantID, conversationID, receivedAt, req.Text)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound, "Conversation not found")
+		case errors.Is(err, service.ErrMessageOnResolvedConversation):
+			response.Error(w, http.StatusConflict, dto.ErrCodeConversationResolved, "Conversation is resolved")
+		default:
+			handleServiceError(w, err, "Failed to record message")
+		}
+		return
+	}
+
+	response.OK(w, dto.NewMessageReceivedResponse(result.Conversation, result.Rules.MatchedRuleIDs, result.Rules.AttachedLabels))
+}
+
+// SetPriority handles POST /api/v1/conversations/{id}/priority
+// Pins the conversation ahead of the computed queue order, or unpins it
+func (h *ConversationHandler) SetPriority(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.PriorityOverrideRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	conv, err := h.service.SetPriorityOverride(ctx, tenantID, conversationID, req.GetPriorityOverride(), optionalOperatorID(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound, "Conversation not found")
+		case errors.Is(err, service.ErrPriorityOverrideOnResolved):
+			response.Error(w, http.StatusConflict, dto.ErrCodeConversationResolved, "Conversation is resolved")
+		default:
+			handleServiceError(w, err, "Failed to set conversation priority")
+		}
+		return
+	}
+
+	response.OK(w, dto.NewConversationResponse(conv))
+}
+
+// PriorityComponents handles GET /api/v1/conversations/{id}/priority/components
+// Lists the recorded priority calculations of the conversation, newest first
+func (h *ConversationHandler) PriorityComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req := dto.ParsePriorityComponentsRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	components, err := h.service.ListPriorityComponents(ctx, tenantID, conversationID, req.GetLimit())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound, "Conversation not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get priority components")
+		return
+	}
+
+	response.OK(w, dto.NewPriorityComponentsListResponse(conversationID, components))
+}
+
+// Ingest handles POST /api/v1/ingest/messages
+// Upserts the conversation for an external message event and records the message;
+// resolved conversations are re-queued
+func (h *ConversationHandler) Ingest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.IngestMessageRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	result, err := h.service.IngestMessage(ctx, service.IngestMessageParams{
+		TenantID:               tenantID,
+		InboxID:                req.InboxID,
+		InboxPhoneNumber:       req.NormalizedInboxPhone(),
+		ExternalConversationID: req.ExternalConversationID,
+		CustomerPhoneNumber:    req.NormalizedCustomerPhone(),
+		ReceivedAt:             req.ReceivedAt(),
+		Text:                   req.Text,
+		Language:               req.NormalizedLanguage(),
+		Metadata:               req.Metadata,
+	})
+	if err != nil {
+		var throttled *service.IntakeThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			response.Error(w, http.StatusTooManyRequests, dto.ErrCodeIntakeThrottled,
+				"This phone number started too many conversations in the inbox; retry later")
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrIngestInboxNotFound):
+			response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound, "Inbox not found")
+		default:
+			handleServiceError(w, err, "Failed to ingest message")
+		}
+		return
+	}
+
+	resp := dto.NewIngestMessageResponse(result.Conversation, result.Created, result.Reopened,
+		result.Rules.MatchedRuleIDs, result.Rules.AttachedLabels)
+	if result.Created {
+		response.Created(w, resp)
+		return
+	}
 	response.OK(w, resp)
 }
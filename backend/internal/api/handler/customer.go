@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type CustomerHandler struct {
+	service *service.CustomerService
+}
+
+func NewCustomerHandler(svc *service.CustomerService) *CustomerHandler {
+	return &CustomerHandler{service: svc}
+}
+
+// Search handles GET /api/v1/customers?q=&cursor=&per_page=
+func (h *CustomerHandler) Search(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	req := dto.ParseSearchCustomersRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	filter := domain.CustomerFilter{
+		TenantID: tenantID,
+		Query:    req.Query,
+		Limit:    req.PerPage,
+	}
+	if cursor := req.GetCursor(); cursor != nil {
+		filter.CursorTimestamp = &cursor.Timestamp
+		filter.CursorID = &cursor.ID
+	}
+
+	customers, err := h.service.Search(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewCustomerListResponse(customers, req.PerPage))
+}
+
+// GetByID handles GET /api/v1/customers/{id}
+func (h *CustomerHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid customer ID")
+		return
+	}
+
+	customer, err := h.service.Get(r.Context(), tenantID, id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewCustomerResponse(customer))
+}
+
+// Update handles PUT /api/v1/customers/{id}
+func (h *CustomerHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid customer ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateCustomerRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	customer, err := h.service.Update(r.Context(), service.UpdateCustomerParams{
+		TenantID:   tenantID,
+		CustomerID: id,
+		Name:       req.GetName(),
+		Metadata:   req.Metadata,
+		ActorID:    optionalOperatorID(r),
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewCustomerResponse(customer))
+}
+
+// ==================== Error Handling ====================
+
+func (h *CustomerHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrCustomerNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeCustomerNotFound,
+			"Customer not found")
+	default:
+		handleServiceError(w, err, "Failed to process customer operation")
+	}
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// handleDeletionError handles a delete refused by the deletion guard
+func handleDeletionError(w http.ResponseWriter, err error) bool {
+	var blocked *service.DeletionBlockedError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+	response.Error(w, http.StatusConflict, dto.ErrCodeDeletionBlocked,
+		"Deletion would affect "+strings.Join(blocked.Impact.Blockers(), ", ")+"; retry with force=true to delete anyway")
+	return true
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type DeviceHandler struct {
+	service *service.PushNotificationService
+}
+
+func NewDeviceHandler(svc *service.PushNotificationService) *DeviceHandler {
+	return &DeviceHandler{service: svc}
+}
+
+// List handles GET /api/v1/operator/devices
+func (h *DeviceHandler) List(w http.ResponseWriter, r *http.Request) {
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	devices, err := h.service.ListDevices(r.Context(), operatorID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list devices")
+		return
+	}
+
+	response.OK(w, dto.NewDeviceListResponse(devices))
+}
+
+// Register handles POST /api/v1/operator/devices
+// Registering a known token updates its device instead of adding one
+func (h *DeviceHandler) Register(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	req, err := dto.ParseJSON[dto.RegisterDeviceRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	device, err := h.service.RegisterDevice(r.Context(), tenantID, operatorID, req.GetPlatform(), req.Token, req.ToEventTypes())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewDeviceResponse(device))
+}
+
+// UpdatePreferences handles PUT /api/v1/operator/devices/{id}
+func (h *DeviceHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid device ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateDevicePreferencesRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	device, err := h.service.UpdateDevicePreferences(r.Context(), operatorID, id, req.ToEventTypes())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewDeviceResponse(device))
+}
+
+// Delete handles DELETE /api/v1/operator/devices/{id}
+func (h *DeviceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid device ID")
+		return
+	}
+
+	if err := h.service.DeleteDevice(r.Context(), operatorID, id); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *DeviceHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrDeviceNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeDeviceNotFound,
+			"Device not found")
+	case errors.Is(err, service.ErrTooManyDevices):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTooManyDevices,
+			"Operator has too many registered devices")
+	default:
+		handleServiceError(w, err, "Failed to process device operation")
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type DueDateHandler struct {
+	service *service.DueDateService
+}
+
+func NewDueDateHandler(svc *service.DueDateService) *DueDateHandler {
+	return &DueDateHandler{service: svc}
+}
+
+// SetDueDate handles POST /api/v1/conversations/{id}/due-date
+// Sets or clears (due_at null) the conversation's due date
+func (h *DueDateHandler) SetDueDate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.DueDateRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	conv, err := h.service.SetDueDate(ctx, tenantID, conversationID, req.DueAt, optionalOperatorID(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound, "Conversation not found")
+		case errors.Is(err, service.ErrDueDateOnResolved):
+			response.Error(w, http.StatusConflict, dto.ErrCodeConversationResolved, "Conversation is resolved")
+		default:
+			handleServiceError(w, err, "Failed to set conversation due date")
+		}
+		return
+	}
+
+	response.OK(w, dto.NewConversationResponse(conv))
+}
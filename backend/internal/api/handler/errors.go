@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// handleServiceError answers an error no resource-specific mapping handled:
+// the storage-level errors any service can return get their own code, and
+// everything else is a 500 with fallback as the detail. Handlers call it
+// from their default case so no error escapes the shared mapping.
+func handleServiceError(w http.ResponseWriter, err error, fallback string) {
+	status, code, message := serviceError(err, fallback)
+	response.Error(w, status, code, message)
+}
+
+// serviceError maps an error any service can return to its HTTP status,
+// error code and message
+func serviceError(err error, fallback string) (int, response.ErrorCode, string) {
+	switch {
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return http.StatusConflict, response.ErrCodeConflict,
+			"Resource already exists"
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return http.StatusConflict, response.ErrCodeConflict,
+			"Resource was modified concurrently, retry the request"
+	case errors.Is(err, domain.ErrInvalidStateTransition):
+		return http.StatusConflict, response.ErrCodeInvalidState,
+			"Invalid state transition"
+	case errors.Is(err, domain.ErrConversationLocked),
+		errors.Is(err, domain.ErrLockAcquisitionFailed),
+		errors.Is(err, domain.ErrLockTimeout):
+		return http.StatusConflict, response.ErrCodeConversationLocked,
+			"Conversation is being modified by another request, retry the request"
+	default:
+		return http.StatusInternalServerError, response.ErrCodeInternal, fallback
+	}
+}
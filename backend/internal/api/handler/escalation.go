@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type EscalationHandler struct {
+	service *service.EscalationService
+}
+
+func NewEscalationHandler(svc *service.EscalationService) *EscalationHandler {
+	return &EscalationHandler{service: svc}
+}
+
+// Escalate handles POST /api/v1/escalate
+func (h *EscalationHandler) Escalate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	req, err := dto.ParseEscalateRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	conv, err := h.service.Escalate(ctx, tenantID, operatorID, req.ConversationID, req.GetReason(), role)
+	if err != nil {
+		status, code, message := lifecycleError(err, "escalate")
+		response.Error(w, status, code, message)
+		return
+	}
+
+	response.OK(w, dto.NewLifecycleResponse(conv))
+}
+
+// SetEscalationInbox handles PUT /api/v1/inboxes/{id}/escalation
+func (h *EscalationHandler) SetEscalationInbox(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.EscalationInboxRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	inbox, err := h.service.SetEscalationInbox(r.Context(), tenantID, inboxID, req.EscalationInboxID, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewInboxResponse(inbox))
+}
+
+// ==================== Error Handling ====================
+
+func (h *EscalationHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrEscalationInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrEscalationInboxInvalid):
+		response.Error(w, http.StatusBadRequest, response.ErrCodeValidation,
+			"escalation_inbox_id must differ from the inbox")
+	case errors.Is(err, service.ErrTargetInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Escalation inbox not found")
+	case errors.Is(err, service.ErrTargetInboxDifferentTenant):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeInboxDifferentTenant,
+			"Escalation inbox belongs to a different tenant")
+	default:
+		handleServiceError(w, err, "Failed to update escalation inbox")
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type EventsHandler struct {
+	service   *service.EventStreamService
+	heartbeat time.Duration
+}
+
+func NewEventsHandler(svc *service.EventStreamService, heartbeat time.Duration) *EventsHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &EventsHandler{service: svc, heartbeat: heartbeat}
+}
+
+// Stream handles GET /api/v1/events
+// Streams conversation events for the caller's subscribed inboxes as Server-Sent Events.
+// Events are not persisted: clients should refetch state after reconnecting.
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+	operatorID, _ := middleware.GetOperatorUUID(ctx)
+
+	sub, err := h.service.Subscribe(ctx, tenantID, operatorID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to subscribe to events")
+		return
+	}
+	h.serve(w, r, sub)
+}
+
+// StreamWithToken handles GET /realtime/events?token=
+// Public: the realtime token is the only credential, so browser clients can
+// connect without setting headers. The stream ends with a stream.expired
+// event when the token expires, unless it is refreshed first through
+// POST /api/v1/realtime/token with the stream's subscription_id.
+func (h *EventsHandler) StreamWithToken(w http.ResponseWriter, r *http.Request) {
+	sub, err := h.service.SubscribeWithToken(r.URL.Query().Get("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRealtimeTokenExpired):
+			response.Unauthorized(w, "Realtime token has expired")
+		case errors.Is(err, domain.ErrRealtimeTokenInvalid):
+			response.Unauthorized(w, "Invalid realtime token")
+		default:
+			handleServiceError(w, err, "Failed to subscribe to events")
+		}
+		return
+	}
+	h.serve(w, r, sub)
+}
+
+// serve writes the subscription's events until the client disconnects or
+// its grant expires. The first event, stream.ready, carries the
+// subscription_id a token refresh applies to.
+func (h *EventsHandler) serve(w http.ResponseWriter, r *http.Request, sub *service.EventSubscription) {
+	defer sub.Close()
+	ctx := r.Context()
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		response.InternalError(w, "Streaming not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 3000\n\n")
+	expiresAt := sub.ExpiresAt()
+	writeStreamEvent(w, "stream.ready", sub, expiresAt)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	// Header-authenticated streams do not expire
+	var expired <-chan time.Time
+	var expiry *time.Timer
+	if !expiresAt.IsZero() {
+		expiry = time.NewTimer(time.Until(expiresAt))
+		defer expiry.Stop()
+		expired = expiry.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			data, err := json.Marshal(service.NewEventEnvelope(event))
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		case expiresAt = <-sub.Refreshed():
+			if expiry == nil {
+				expiry = time.NewTimer(time.Until(expiresAt))
+				defer expiry.Stop()
+				expired = expiry.C
+			} else {
+				if !expiry.Stop() {
+					select {
+					case <-expiry.C:
+					default:
+					}
+				}
+				expiry.Reset(time.Until(expiresAt))
+			}
+			writeStreamEvent(w, "stream.refreshed", sub, expiresAt)
+		case <-expired:
+			writeStreamEvent(w, "stream.expired", sub, expiresAt)
+			_ = rc.Flush()
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// streamStatus is the data of the stream.* lifecycle events
+type streamStatus struct {
+	SubscriptionID string     `json:"subscription_id"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+func writeStreamEvent(w http.ResponseWriter, name string, sub *service.EventSubscription, expiresAt time.Time) {
+	status := streamStatus{SubscriptionID: sub.ID.String()}
+	if !expiresAt.IsZero() {
+		status.ExpiresAt = &expiresAt
+	}
+	data, _ := json.Marshal(status)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+}
+
+// IssueToken handles POST /api/v1/realtime/token
+// Signs a short-lived token scoped to the operator's tenant, inbox visibility
+// and the requested event types. With subscription_id the token also
+// refreshes that open stream, which continues without reconnecting.
+func (h *EventsHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	req, err := dto.ParseJSON[dto.CreateRealtimeTokenRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	grant, token, err := h.service.IssueToken(r.Context(), service.IssueRealtimeTokenParams{
+		TenantID:       tenantID,
+		OperatorID:     operatorID,
+		InboxIDs:       req.InboxIDs,
+		EventTypes:     req.Types(),
+		TTL:            req.TTL(),
+		SubscriptionID: req.SubscriptionID,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrRealtimeInboxNotVisible) {
+			response.Error(w, http.StatusForbidden, dto.ErrCodeNotSubscribedToInbox,
+				"Operator is not subscribed to the inbox")
+			return
+		}
+		handleServiceError(w, err, "Failed to issue realtime token")
+		return
+	}
+
+	// Tokens are credentials
+	w.Header().Set("Cache-Control", "no-store")
+	response.Created(w, dto.NewRealtimeTokenResponse(grant, token))
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ExperimentHandler struct {
+	service *service.ExperimentService
+}
+
+func NewExperimentHandler(svc *service.ExperimentService) *ExperimentHandler {
+	return &ExperimentHandler{service: svc}
+}
+
+// List handles GET /api/v1/tenant/experiments
+func (h *ExperimentHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	experiments, err := h.service.List(r.Context(), tenantID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewExperimentListResponse(experiments))
+}
+
+// Create handles POST /api/v1/tenant/experiments
+func (h *ExperimentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateExperimentRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	alpha, beta := req.ToDecimal()
+	experiment, err := h.service.Create(r.Context(), service.CreateExperimentParams{
+		TenantID:       tenantID,
+		InboxID:        req.InboxID,
+		Name:           strings.TrimSpace(req.Name),
+		WeightAlpha:    alpha,
+		WeightBeta:     beta,
+		TrafficPercent: req.TrafficPercent,
+		CreatedBy:      optionalOperatorID(r),
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewExperimentResponse(experiment))
+}
+
+// Get handles GET /api/v1/tenant/experiments/{id}
+func (h *ExperimentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid experiment ID")
+		return
+	}
+
+	experiment, err := h.service.Get(r.Context(), tenantID, id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewExperimentResponse(experiment))
+}
+
+// Update handles PUT /api/v1/tenant/experiments/{id}
+func (h *ExperimentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid experiment ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateExperimentRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	experiment, err := h.service.Rename(r.Context(), tenantID, id, strings.TrimSpace(req.Name), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewExperimentResponse(experiment))
+}
+
+// Stop handles POST /api/v1/tenant/experiments/{id}/stop
+func (h *ExperimentHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid experiment ID")
+		return
+	}
+
+	experiment, err := h.service.Stop(r.Context(), tenantID, id, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewExperimentResponse(experiment))
+}
+
+// Delete handles DELETE /api/v1/tenant/experiments/{id}
+func (h *ExperimentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid experiment ID")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), tenantID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// Results handles GET /api/v1/tenant/experiments/{id}/results
+func (h *ExperimentHandler) Results(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid experiment ID")
+		return
+	}
+
+	results, err := h.service.Results(r.Context(), tenantID, id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewExperimentResultsResponse(results))
+}
+
+// ==================== Error Handling ====================
+
+func (h *ExperimentHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrExperimentNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeExperimentNotFound,
+			"Experiment not found")
+	case errors.Is(err, service.ErrExperimentInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrExperimentAlreadyRunning):
+		response.Error(w, http.StatusConflict, dto.ErrCodeExperimentAlreadyRunning,
+			"An experiment is already running in the inbox")
+	case errors.Is(err, domain.ErrExperimentNotRunning):
+		response.Error(w, http.StatusConflict, dto.ErrCodeExperimentNotRunning,
+			"Experiment is not running")
+	default:
+		handleServiceError(w, err, "Failed to process experiment operation")
+	}
+}
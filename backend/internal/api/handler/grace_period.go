@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type GracePeriodHandler struct {
+	service *service.GracePeriodService
+}
+
+func NewGracePeriodHandler(svc *service.GracePeriodService) *GracePeriodHandler {
+	return &GracePeriodHandler{service: svc}
+}
+
+// List handles GET /api/v1/grace-periods
+// Optionally filtered by operator_id and conversation_id
+func (h *GracePeriodHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListGracePeriodsRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	assignments, err := h.service.List(r.Context(), req.ToFilter(tenantID))
+	if err != nil {
+		handleServiceError(w, err, "Failed to list grace periods")
+		return
+	}
+
+	response.OK(w, dto.NewGracePeriodListResponse(assignments))
+}
+
+// Cancel handles DELETE /api/v1/grace-periods/{id}
+// The conversation stays assigned to the operator
+func (h *GracePeriodHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid grace period ID")
+		return
+	}
+
+	if err := h.service.Cancel(r.Context(), tenantID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// Extend handles POST /api/v1/grace-periods/{id}/extend
+func (h *GracePeriodHandler) Extend(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid grace period ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.ExtendGracePeriodRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	gpa, err := h.service.Extend(r.Context(), tenantID, id, req.ExtendBy(), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewGracePeriodResponse(gpa))
+}
+
+// ==================== Error Handling ====================
+
+func (h *GracePeriodHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrGracePeriodNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeGracePeriodNotFound,
+			"Grace period not found")
+	case errors.Is(err, service.ErrGracePeriodExpired):
+		response.Error(w, http.StatusConflict, dto.ErrCodeGracePeriodExpired,
+			"Grace period has already expired")
+	default:
+		handleServiceError(w, err, "Failed to process grace period operation")
+	}
+}
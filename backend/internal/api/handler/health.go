@@ -4,25 +4,45 @@ import (
 	"context"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Check statuses
+const (
+	CheckHealthy   = "healthy"
+	CheckDegraded  = "degraded"
+	CheckUnhealthy = "unhealthy"
+)
+
+// ReadinessCheck is a component /ready reports on besides the database.
+// An unhealthy check makes the replica not ready; a degraded one is reported
+// while the replica stays ready, for shared pipelines that taking replicas
+// out of the load balancer would not help.
+type ReadinessCheck interface {
+	Name() string
+	Check(ctx context.Context) Check
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
 	pool      *pgxpool.Pool
 	version   string
 	buildTime string
+	checks    []ReadinessCheck
 }
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler(pool *pgxpool.Pool, version, buildTime string) *HealthHandler {
+func NewHealthHandler(pool *pgxpool.Pool, version, buildTime string, checks ...ReadinessCheck) *HealthHandler {
 	return &HealthHandler{
 		pool:      pool,
 		version:   version,
 		buildTime: buildTime,
+		checks:    checks,
 	}
 }
 
@@ -35,14 +55,18 @@ type HealthResponse struct {
 
 // Check represents an individual health check
 type Check struct {
-	Status  string `json:"status"`
-	Message string `json:"message,omitempty"`
+	Status  string                 `json:"status"`
+	Message string                 `json:"message,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
-// ReadyResponse represents the readiness response
+// ReadyResponse represents the readiness response. Status is the worst
+// status of the checks; Ready is false only when one is unhealthy.
 type ReadyResponse struct {
-	Ready     bool      `json:"ready"`
-	Timestamp time.Time `json:"timestamp"`
+	Ready     bool             `json:"ready"`
+	Status    string           `json:"status"`
+	Checks    map[string]Check `json:"checks"`
+	Timestamp time.Time        `json:"timestamp"`
 }
 
 // VersionResponse represents the version endpoint response
@@ -60,13 +84,13 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	checks := make(map[string]Check)
-	overallStatus := "healthy"
+	overallStatus := CheckHealthy
 
 	// Check database connectivity
 	dbCheck := h.checkDatabase(ctx)
 	checks["database"] = dbCheck
-	if dbCheck.Status != "healthy" {
-		overallStatus = "unhealthy"
+	if dbCheck.Status != CheckHealthy {
+		overallStatus = CheckUnhealthy
 	}
 
 	healthResponse := HealthResponse{
@@ -76,7 +100,7 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	}
 
 	status := http.StatusOK
-	if overallStatus != "healthy" {
+	if overallStatus != CheckHealthy {
 		status = http.StatusServiceUnavailable
 	}
 
@@ -88,15 +112,10 @@ func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 3*time.Second)
 	defer cancel()
 
-	ready := h.isReady(ctx)
-
-	readyResponse := ReadyResponse{
-		Ready:     ready,
-		Timestamp: time.Now().UTC(),
-	}
+	readyResponse := h.readiness(ctx)
 
 	status := http.StatusOK
-	if !ready {
+	if !readyResponse.Ready {
 		status = http.StatusServiceUnavailable
 	}
 
@@ -120,7 +139,7 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) Check {
 	err := h.pool.Ping(ctx)
 	if err != nil {
 		return Check{
-			Status:  "unhealthy",
+			Status:  CheckUnhealthy,
 			Message: "Database connection failed",
 		}
 	}
@@ -129,22 +148,86 @@ func (h *HealthHandler) checkDatabase(ctx context.Context) Check {
 	stats := h.pool.Stat()
 	if stats.TotalConns() == 0 {
 		return Check{
-			Status:  "unhealthy",
+			Status:  CheckUnhealthy,
 			Message: "No database connections available",
 		}
 	}
 
 	return Check{
-		Status:  "healthy",
+		Status:  CheckHealthy,
 		Message: "Connected",
 	}
 }
 
-func (h *HealthHandler) isReady(ctx context.Context) bool {
-	// Check database
+func (h *HealthHandler) readiness(ctx context.Context) ReadyResponse {
+	checks := make(map[string]Check, len(h.checks)+1)
+
+	// The database is required; the other checks are only consulted with it up
 	if err := h.pool.Ping(ctx); err != nil {
-		return false
+		checks["database"] = Check{Status: CheckUnhealthy, Message: "Database connection failed"}
+	} else {
+		checks["database"] = Check{Status: CheckHealthy}
+		for _, c := range h.checks {
+			checks[c.Name()] = c.Check(ctx)
+		}
+	}
+
+	return newReadyResponse(checks)
+}
+
+func newReadyResponse(checks map[string]Check) ReadyResponse {
+	status := CheckHealthy
+	for _, c := range checks {
+		switch c.Status {
+		case CheckUnhealthy:
+			status = CheckUnhealthy
+		case CheckDegraded:
+			if status == CheckHealthy {
+				status = CheckDegraded
+			}
+		}
 	}
 
-	return true
+	return ReadyResponse{
+		Ready:     status != CheckUnhealthy,
+		Status:    status,
+		Checks:    checks,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// ==================== Grace Period Pipeline ====================
+
+type gracePipelineCheck struct {
+	service *service.GracePeriodService
+}
+
+// NewGracePipelineCheck reports the grace period pipeline degraded when the
+// backlog of expired grace periods exceeds its policy
+func NewGracePipelineCheck(svc *service.GracePeriodService) ReadinessCheck {
+	return &gracePipelineCheck{service: svc}
+}
+
+func (c *gracePipelineCheck) Name() string {
+	return "grace_period_pipeline"
+}
+
+func (c *gracePipelineCheck) Check(ctx context.Context) Check {
+	health, err := c.service.PipelineHealth(ctx)
+	if err != nil {
+		return Check{Status: CheckDegraded, Message: "Failed to read grace period backlog"}
+	}
+
+	check := Check{
+		Status: CheckHealthy,
+		Details: map[string]interface{}{
+			"overdue":     health.Overdue,
+			"lag_seconds": int64(health.Lag.Seconds()),
+		},
+	}
+	if health.Degraded() {
+		check.Status = CheckDegraded
+		check.Message = strings.Join(health.Degradations, "; ")
+	}
+	return check
 }
@@ -37,7 +37,7 @@ func (h *InboxHandler) ListForOperator(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		response.InternalError(w, "Failed to list inboxes")
+		handleServiceError(w, err, "Failed to list inboxes")
 		return
 	}
 
@@ -72,13 +72,18 @@ func (h *InboxHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	inbox, err := h.service.Create(r.Context(), tenantID, req.PhoneNumber, req.DisplayName)
+	var businessHours *domain.BusinessHours
+	if req.BusinessHours != nil {
+		businessHours = req.BusinessHours.ToDomain()
+	}
+
+	inbox, err := h.service.Create(r.Context(), tenantID, req.PhoneNumber, req.DisplayName, req.IsRestricted, businessHours)
 	if err != nil {
 		if err == domain.ErrAlreadyExists {
 			response.Conflict(w, response.ErrCodeConflict, "Phone number already exists")
 			return
 		}
-		response.InternalError(w, "Failed to create inbox")
+		handleServiceError(w, err, "Failed to create inbox")
 		return
 	}
 
@@ -99,7 +104,7 @@ func (h *InboxHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Inbox not found")
 			return
 		}
-		response.InternalError(w, "Failed to get inbox")
+		handleServiceError(w, err, "Failed to get inbox")
 		return
 	}
 
@@ -137,7 +142,7 @@ func (h *InboxHandler) Update(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Inbox not found")
 			return
 		}
-		response.InternalError(w, "Failed to get inbox")
+		handleServiceError(w, err, "Failed to get inbox")
 		return
 	}
 
@@ -147,13 +152,14 @@ func (h *InboxHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	inbox, err := h.service.Update(r.Context(), id, req.PhoneNumber, req.DisplayName)
+	inbox, err := h.service.Update(r.Context(), id, req.PhoneNumber, req.DisplayName, req.IsRestricted,
+		req.BusinessHours.ToDomain(), req.BusinessHours.Set)
 	if err != nil {
 		if err == domain.ErrAlreadyExists {
 			response.Conflict(w, response.ErrCodeConflict, "Phone number already exists")
 			return
 		}
-		response.InternalError(w, "Failed to update inbox")
+		handleServiceError(w, err, "Failed to update inbox")
 		return
 	}
 
@@ -174,7 +180,7 @@ func (h *InboxHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Inbox not found")
 			return
 		}
-		response.InternalError(w, "Failed to get inbox")
+		handleServiceError(w, err, "Failed to get inbox")
 		return
 	}
 
@@ -184,10 +190,52 @@ func (h *InboxHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
-		response.InternalError(w, "Failed to delete inbox")
+	req := dto.ParseDeleteRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, req.IsForced()); err != nil {
+		if handleDeletionError(w, err) {
+			return
+		}
+		handleServiceError(w, err, "Failed to delete inbox")
 		return
 	}
 
 	response.NoContent(w)
 }
+
+// DeletionImpact handles GET /api/v1/inboxes/{id}/deletion-impact
+func (h *InboxHandler) DeletionImpact(w http.ResponseWriter, r *http.Request) {
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	inbox, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			response.NotFound(w, "Inbox not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get inbox")
+		return
+	}
+
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	if inbox.TenantID != tenantID {
+		response.NotFound(w, "Inbox not found")
+		return
+	}
+
+	impact, err := h.service.DeletionImpact(r.Context(), id)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get deletion impact")
+		return
+	}
+
+	response.OK(w, dto.NewDeletionImpactResponse(impact))
+}
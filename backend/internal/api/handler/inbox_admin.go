@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type InboxAdminHandler struct {
+	service *service.InboxAdminService
+}
+
+func NewInboxAdminHandler(svc *service.InboxAdminService) *InboxAdminHandler {
+	return &InboxAdminHandler{service: svc}
+}
+
+// Capabilities handles GET /api/v1/operator/capabilities
+func (h *InboxAdminHandler) Capabilities(w http.ResponseWriter, r *http.Request) {
+	role, _ := middleware.GetOperatorRole(r.Context())
+
+	caps, err := h.service.GetCapabilities(r.Context(), optionalOperatorID(r), role)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get capabilities")
+		return
+	}
+
+	response.OK(w, dto.NewCapabilitiesResponse(caps))
+}
+
+// List handles GET /api/v1/inboxes/{inbox_id}/admins
+func (h *InboxAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "inbox_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	admins, err := h.service.ListInboxAdmins(r.Context(), tenantID, inboxID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	items := make([]dto.InboxAdminResponse, len(admins))
+	for i, admin := range admins {
+		items[i] = dto.NewInboxAdminResponse(admin)
+	}
+
+	response.OK(w, dto.InboxAdminListResponse{Admins: items})
+}
+
+// Grant handles POST /api/v1/inboxes/{inbox_id}/admins
+func (h *InboxAdminHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "inbox_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.GrantInboxAdminRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	admin, err := h.service.GrantInboxAdmin(r.Context(), tenantID, inboxID, req.OperatorID, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewInboxAdminResponse(admin))
+}
+
+// Revoke handles DELETE /api/v1/inboxes/{inbox_id}/admins/{operator_id}
+func (h *InboxAdminHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "inbox_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	operatorID, err := dto.ParseUUIDParam(r, "operator_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	if err := h.service.RevokeInboxAdmin(r.Context(), tenantID, inboxID, operatorID, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *InboxAdminHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInboxAdminInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrInboxAdminOperatorNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxAdminOperatorNotFound,
+			"Operator not found")
+	default:
+		handleServiceError(w, err, "Failed to process inbox admin operation")
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type InvariantHandler struct {
+	service *service.InvariantService
+}
+
+func NewInvariantHandler(svc *service.InvariantService) *InvariantHandler {
+	return &InvariantHandler{service: svc}
+}
+
+// Check handles GET /api/v1/admin/invariants
+// Reports the tenant's conversations breaking a data invariant
+func (h *InvariantHandler) Check(w http.ResponseWriter, r *http.Request) {
+	h.check(w, r, false)
+}
+
+// Repair handles POST /api/v1/admin/invariants/repair
+// Repairs the repairable violations and reports all of them
+func (h *InvariantHandler) Repair(w http.ResponseWriter, r *http.Request) {
+	h.check(w, r, true)
+}
+
+func (h *InvariantHandler) check(w http.ResponseWriter, r *http.Request, repair bool) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	report, err := h.service.Check(r.Context(), tenantID, repair, optionalOperatorID(r))
+	if err != nil {
+		handleServiceError(w, err, "Failed to check invariants")
+		return
+	}
+
+	response.OK(w, dto.NewInvariantReportResponse(report))
+}
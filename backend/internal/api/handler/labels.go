@@ -52,7 +52,7 @@ func (h *LabelHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Execute
-	label, err := h.service.CreateLabel(ctx, tenantID, operatorID, req.InboxID, role, req.Name, req.Color)
+	label, err := h.service.CreateLabel(ctx, tenantID, operatorID, req.InboxID, role, req.Scope, req.Name, req.Color, req.ParentLabelID)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -61,7 +61,8 @@ func (h *LabelHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.Created(w, dto.NewLabelResponse(label))
 }
 
-// List handles GET /api/v1/labels?inbox_id=
+// List handles GET /api/v1/labels?inbox_id=&nested= and, for the tenant
+// labels only, GET /api/v1/labels?scope=TENANT
 func (h *LabelHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -79,27 +80,59 @@ func (h *LabelHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	role, _ := middleware.GetOperatorRole(ctx)
 
+	var labels []*domain.Label
+	switch scope := domain.LabelScope(r.URL.Query().Get("scope")); scope {
+	case domain.LabelScopeTenant:
+		var err error
+		labels, err = h.service.ListTenantLabels(ctx, tenantID)
+		if err != nil {
+			h.handleError(w, err)
+			return
+		}
+	case "", domain.LabelScopeInbox:
+		var ok bool
+		labels, ok = h.listInbox(w, r, tenantID, operatorID, role)
+		if !ok {
+			return
+		}
+	default:
+		response.Error(w, http.StatusBadRequest, "INVALID_QUERY", "scope must be INBOX or TENANT")
+		return
+	}
+
+	// nested=true returns the top-level labels with their descendants
+	if r.URL.Query().Get("nested") == "true" {
+		response.OK(w, dto.NewLabelTreeResponse(domain.NestLabels(labels)))
+		return
+	}
+	response.OK(w, dto.NewLabelListResponse(labels))
+}
+
+// listInbox lists the labels of the inbox_id query parameter, including the
+// tenant labels; false once the error response is written
+func (h *LabelHandler) listInbox(w http.ResponseWriter, r *http.Request, tenantID, operatorID uuid.UUID, role domain.OperatorRole) ([]*domain.Label, bool) {
+	ctx := r.Context()
+
 	// Parse inbox_id from query
 	inboxIDStr := r.URL.Query().Get("inbox_id")
 	if inboxIDStr == "" {
 		response.Error(w, http.StatusBadRequest, "INVALID_QUERY", "inbox_id query parameter is required")
-		return
+		return nil, false
 	}
 
 	inboxID, err := uuid.Parse(inboxIDStr)
 	if err != nil {
 		response.Error(w, http.StatusBadRequest, "INVALID_QUERY", "inbox_id must be a valid UUID")
-		return
+		return nil, false
 	}
 
 	// Execute
 	labels, err := h.service.ListLabelsByInbox(ctx, tenantID, operatorID, inboxID, role)
 	if err != nil {
 		h.handleError(w, err)
-		return
+		return nil, false
 	}
-
-	response.OK(w, dto.NewLabelListResponse(labels))
+	return labels, true
 }
 
 // Update handles PUT /api/v1/labels/{id}
@@ -141,7 +174,8 @@ func (h *LabelHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Execute
-	label, err := h.service.UpdateLabel(ctx, tenantID, operatorID, labelID, role, req.Name, req.Color)
+	label, err := h.service.UpdateLabel(ctx, tenantID, operatorID, labelID, role, req.Name, req.Color,
+		req.ParentLabelID.Value, req.ParentLabelID.Set)
 	if err != nil {
 		h.handleError(w, err)
 		return
@@ -176,8 +210,17 @@ func (h *LabelHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req := dto.ParseDeleteRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
 	// Execute
-	if err := h.service.DeleteLabel(ctx, tenantID, operatorID, labelID, role); err != nil {
+	if err := h.service.DeleteLabel(ctx, tenantID, operatorID, labelID, role, req.IsForced()); err != nil {
+		if handleDeletionError(w, err) {
+			return
+		}
 		h.handleError(w, err)
 		return
 	}
@@ -185,6 +228,74 @@ func (h *LabelHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w)
 }
 
+// DeletionImpact handles GET /api/v1/labels/{id}/deletion-impact
+func (h *LabelHandler) DeletionImpact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	labelID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_PATH", "id must be a valid UUID")
+		return
+	}
+
+	impact, err := h.service.LabelDeletionImpact(ctx, tenantID, operatorID, labelID, role)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewDeletionImpactResponse(impact))
+}
+
+// Versions handles GET /api/v1/labels/{id}/versions
+func (h *LabelHandler) Versions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	// Parse label ID from path
+	labelID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_PATH", "id must be a valid UUID")
+		return
+	}
+
+	// Execute
+	versions, err := h.service.ListLabelVersions(ctx, tenantID, operatorID, labelID, role)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewLabelVersionListResponse(versions))
+}
+
 // Attach handles POST /api/v1/labels/attach
 func (h *LabelHandler) Attach(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -282,7 +393,13 @@ func (h *LabelHandler) handleError(w http.ResponseWriter, err error) {
 	case errors.Is(err, service.ErrLabelPermissionDenied):
 		response.Error(w, http.StatusForbidden, dto.ErrCodeLabelPermissionDenied,
 			"You don't have permission for this operation")
+	case errors.Is(err, service.ErrLabelParentInvalid):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeLabelParentInvalid,
+			"Parent label must be a label of the same inbox")
+	case errors.Is(err, service.ErrLabelCycle):
+		response.Error(w, http.StatusConflict, dto.ErrCodeLabelCycle,
+			"A label cannot be nested below itself or its descendants")
 	default:
-		response.InternalError(w, "Failed to process label operation")
+		handleServiceError(w, err, "Failed to process label operation")
 	}
 }
@@ -59,7 +59,48 @@ func (h *LifecycleHandler) Resolve(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, dto.NewLifecycleResponse(conv))
 }
 
+// Reopen handles POST /api/v1/reopen
+func (h *LifecycleHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	// Parse request
+	req, err := dto.ParseReopenRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	// Execute
+	conv, err := h.service.Reopen(ctx, tenantID, operatorID, req.ConversationID, role)
+	if err != nil {
+		h.handleError(w, err, "reopen")
+		return
+	}
+
+	response.OK(w, dto.NewLifecycleResponse(conv))
+}
+
 // Deallocate handles POST /api/v1/deallocate
+// With grace_seconds it answers 202 with the grace period instead
 func (h *LifecycleHandler) Deallocate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -89,6 +130,17 @@ func (h *LifecycleHandler) Deallocate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// With a grace period the conversation stays allocated until it expires
+	if grace := req.Grace(); grace > 0 {
+		gpa, err := h.service.DeallocateWithGrace(ctx, tenantID, operatorID, req.ConversationID, grace, role)
+		if err != nil {
+			h.handleError(w, err, "deallocate")
+			return
+		}
+		response.JSON(w, http.StatusAccepted, dto.NewGracePeriodResponse(gpa))
+		return
+	}
+
 	// Execute
 	conv, err := h.service.Deallocate(ctx, tenantID, operatorID, req.ConversationID, role)
 	if err != nil {
@@ -130,7 +182,7 @@ func (h *LifecycleHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Execute
-	conv, err := h.service.Reassign(ctx, tenantID, operatorID, req.ConversationID, req.OperatorID, role)
+	conv, err := h.service.Reassign(ctx, tenantID, operatorID, req.ConversationID, req.OperatorID, req.GetHandoverNote(), role)
 	if err != nil {
 		h.handleError(w, err, "reassign")
 		return
@@ -179,35 +231,202 @@ func (h *LifecycleHandler) MoveInbox(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, dto.NewLifecycleResponse(conv))
 }
 
+// BulkResolve handles POST /api/v1/conversations/bulk/resolve
+func (h *LifecycleHandler) BulkResolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	// Parse request
+	req, err := dto.ParseBulkResolveRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	// Execute
+	results, err := h.service.BulkResolve(ctx, tenantID, operatorID, req.ConversationIDs, role)
+	if err != nil {
+		h.handleError(w, err, "resolve")
+		return
+	}
+
+	response.Multi(w, bulkResponse(results, "resolve"))
+}
+
+// BulkReassign handles POST /api/v1/conversations/bulk/reassign
+func (h *LifecycleHandler) BulkReassign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	// Parse request
+	req, err := dto.ParseBulkReassignRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	conversationIDs := req.ConversationIDs
+	if req.FromOperatorID != nil {
+		if conversationIDs, err = h.service.OperatorBacklog(ctx, tenantID, *req.FromOperatorID); err != nil {
+			h.handleError(w, err, "reassign")
+			return
+		}
+	}
+
+	// Execute
+	results, err := h.service.BulkReassign(ctx, tenantID, operatorID, conversationIDs, req.OperatorID, role)
+	if err != nil {
+		h.handleError(w, err, "reassign")
+		return
+	}
+
+	response.Multi(w, bulkResponse(results, "reassign"))
+}
+
+// BulkMoveInbox handles POST /api/v1/conversations/bulk/move_inbox
+func (h *LifecycleHandler) BulkMoveInbox(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	// Parse request
+	req, err := dto.ParseBulkMoveInboxRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	conversationIDs := req.ConversationIDs
+	if req.FromInboxID != nil {
+		if conversationIDs, err = h.service.InboxBacklog(ctx, tenantID, *req.FromInboxID); err != nil {
+			h.handleError(w, err, "move_inbox")
+			return
+		}
+	}
+
+	// Execute
+	results, err := h.service.BulkMoveInbox(ctx, tenantID, operatorID, conversationIDs, req.InboxID, role)
+	if err != nil {
+		h.handleError(w, err, "move_inbox")
+		return
+	}
+
+	response.Multi(w, bulkResponse(results, "move_inbox"))
+}
+
+// bulkResponse reports each conversation's outcome with the status and
+// error code the single-conversation endpoint would have returned
+func bulkResponse(results []service.BulkResult, operation string) *response.MultiStatus {
+	resp := response.NewMultiStatus(len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			status, code, message := lifecycleError(result.Err, operation)
+			resp.AddFailure(result.ConversationID.String(), status, code, message)
+			continue
+		}
+		resp.AddSuccess(result.ConversationID.String(), http.StatusOK, dto.NewLifecycleResponse(result.Conversation))
+	}
+	return resp
+}
+
 // ==================== Error Handling ====================
 
 func (h *LifecycleHandler) handleError(w http.ResponseWriter, err error, operation string) {
+	status, code, message := lifecycleError(err, operation)
+	response.Error(w, status, code, message)
+}
+
+// lifecycleError maps a lifecycle service error to its HTTP status, error
+// code and message
+func lifecycleError(err error, operation string) (int, response.ErrorCode, string) {
 	switch {
 	case errors.Is(err, domain.ErrNotFound):
-		response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound,
-			"Conversation not found")
+		return http.StatusNotFound, dto.ErrCodeConversationNotFound,
+			"Conversation not found"
 	case errors.Is(err, service.ErrConversationNotAllocated):
-		response.Error(w, http.StatusConflict, dto.ErrCodeConversationNotAllocated,
-			"Conversation is not in ALLOCATED state")
+		return http.StatusConflict, dto.ErrCodeConversationNotAllocated,
+			"Conversation is not in ALLOCATED state"
 	case errors.Is(err, service.ErrConversationAlreadyResolved):
-		response.Error(w, http.StatusConflict, dto.ErrCodeConversationAlreadyResolved,
-			"Conversation is already resolved")
+		return http.StatusConflict, dto.ErrCodeConversationAlreadyResolved,
+			"Conversation is already resolved"
+	case errors.Is(err, service.ErrConversationNotResolved):
+		return http.StatusConflict, dto.ErrCodeConversationNotResolved,
+			"Conversation is not in RESOLVED state"
 	case errors.Is(err, service.ErrInsufficientPermissions):
-		response.Error(w, http.StatusForbidden, dto.ErrCodeInsufficientPermissions,
-			"You don't have permission for this operation")
+		return http.StatusForbidden, dto.ErrCodeInsufficientPermissions,
+			"You don't have permission for this operation"
+	case errors.Is(err, service.ErrChecklistIncomplete):
+		return http.StatusConflict, dto.ErrCodeChecklistIncomplete,
+			"Every checklist item must be ticked before the conversation is resolved"
 	case errors.Is(err, service.ErrTargetOperatorNotFound):
-		response.Error(w, http.StatusNotFound, dto.ErrCodeOperatorNotFoundLifecycle,
-			"Target operator not found")
+		return http.StatusNotFound, dto.ErrCodeOperatorNotFoundLifecycle,
+			"Target operator not found"
 	case errors.Is(err, service.ErrTargetOperatorNotSubscribed):
-		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorNotSubscribedLifecycle,
-			"Target operator is not subscribed to the inbox")
+		return http.StatusBadRequest, dto.ErrCodeOperatorNotSubscribedLifecycle,
+			"Target operator is not subscribed to the inbox"
 	case errors.Is(err, service.ErrTargetInboxNotFound):
-		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
-			"Target inbox not found")
+		return http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Target inbox not found"
 	case errors.Is(err, service.ErrTargetInboxDifferentTenant):
-		response.Error(w, http.StatusBadRequest, dto.ErrCodeInboxDifferentTenant,
-			"Target inbox belongs to a different tenant")
+		return http.StatusBadRequest, dto.ErrCodeInboxDifferentTenant,
+			"Target inbox belongs to a different tenant"
+	case errors.Is(err, service.ErrEscalationNotConfigured):
+		return http.StatusConflict, dto.ErrCodeEscalationNotConfigured,
+			"The conversation's inbox has no escalation inbox"
 	default:
-		response.InternalError(w, "Failed to "+operation+" conversation")
+		return serviceError(err, "Failed to "+operation+" conversation")
 	}
 }
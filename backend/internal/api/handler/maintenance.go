@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type MaintenanceHandler struct {
+	service *service.MaintenanceService
+}
+
+func NewMaintenanceHandler(svc *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: svc}
+}
+
+// Report handles GET /api/v1/admin/maintenance
+// Reports whether heavy background jobs may run now, for the deployment and
+// the tenant, and when they may next
+func (h *MaintenanceHandler) Report(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	report, err := h.service.Report(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to report maintenance status")
+		return
+	}
+
+	response.OK(w, dto.NewMaintenanceReportResponse(report))
+}
+
+// SetWindow handles PUT /api/v1/admin/maintenance/window
+func (h *MaintenanceHandler) SetWindow(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.SetMaintenanceWindowRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+	window, _ := req.GetWindow()
+
+	if _, err := h.service.SetWindow(r.Context(), tenantID, window, optionalOperatorID(r)); err != nil {
+		handleServiceError(w, err, "Failed to set maintenance window")
+		return
+	}
+
+	h.Report(w, r)
+}
+
+// ClearWindow handles DELETE /api/v1/admin/maintenance/window
+// The tenant goes back to the deployment's window
+func (h *MaintenanceHandler) ClearWindow(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	if _, err := h.service.SetWindow(r.Context(), tenantID, nil, optionalOperatorID(r)); err != nil {
+		handleServiceError(w, err, "Failed to clear maintenance window")
+		return
+	}
+
+	h.Report(w, r)
+}
+
+// SetOverride handles PUT /api/v1/admin/maintenance/override
+// Holds the tenant's window open or closed for duration_seconds
+func (h *MaintenanceHandler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.SetMaintenanceOverrideRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	override := req.GetOverride(time.Now().UTC())
+	if _, err := h.service.SetOverride(r.Context(), tenantID, override, optionalOperatorID(r)); err != nil {
+		handleServiceError(w, err, "Failed to set maintenance override")
+		return
+	}
+
+	h.Report(w, r)
+}
+
+// ClearOverride handles DELETE /api/v1/admin/maintenance/override
+func (h *MaintenanceHandler) ClearOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	if _, err := h.service.SetOverride(r.Context(), tenantID, nil, optionalOperatorID(r)); err != nil {
+		handleServiceError(w, err, "Failed to clear maintenance override")
+		return
+	}
+
+	h.Report(w, r)
+}
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
 	"github.com/inbox-allocation-service/internal/api/middleware"
@@ -32,15 +35,11 @@ func (h *OperatorHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Operator status not found")
 			return
 		}
-		response.InternalError(w, "Failed to get status")
+		handleServiceError(w, err, "Failed to get status")
 		return
 	}
 
-	response.OK(w, dto.OperatorStatusResponse{
-		OperatorID:         status.OperatorID,
-		Status:             string(status.Status),
-		LastStatusChangeAt: status.LastStatusChangeAt,
-	})
+	response.OK(w, dto.NewOperatorStatusResponse(status, time.Now()))
 }
 
 // UpdateStatus handles PUT /api/v1/operator/status
@@ -64,15 +63,72 @@ func (h *OperatorHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 
 	status, err := h.service.UpdateStatus(r.Context(), operatorID, domain.OperatorStatusType(req.Status))
 	if err != nil {
-		response.InternalError(w, "Failed to update status")
+		handleServiceError(w, err, "Failed to update status")
 		return
 	}
 
-	response.OK(w, dto.OperatorStatusResponse{
-		OperatorID:         status.OperatorID,
-		Status:             string(status.Status),
-		LastStatusChangeAt: status.LastStatusChangeAt,
-	})
+	response.OK(w, dto.NewOperatorStatusResponse(status, time.Now()))
+}
+
+// StartFocus handles PUT /api/v1/operator/focus
+// The AVAILABLE operator receives no new conversations for the given minutes
+// while keeping the ones they have; focus ends on its own.
+func (h *OperatorHandler) StartFocus(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.GetOperatorUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.StartFocusRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	status, err := h.service.StartFocus(r.Context(), operatorID, req.Duration())
+	if err != nil {
+		h.handleFocusError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorStatusResponse(status, time.Now()))
+}
+
+// EndFocus handles DELETE /api/v1/operator/focus
+func (h *OperatorHandler) EndFocus(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := middleware.GetOperatorUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	status, err := h.service.EndFocus(r.Context(), operatorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			response.NotFound(w, "Operator status not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to end focus")
+		return
+	}
+
+	response.OK(w, dto.NewOperatorStatusResponse(status, time.Now()))
+}
+
+func (h *OperatorHandler) handleFocusError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrFocusRequiresAvailable):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeOperatorNotAvailable,
+			"Operator must be AVAILABLE to start focus mode")
+	default:
+		handleServiceError(w, err, "Failed to start focus")
+	}
 }
 
 // Create handles POST /api/v1/operators
@@ -94,9 +150,11 @@ func (h *OperatorHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	operator, err := h.service.Create(r.Context(), tenantID, domain.OperatorRole(req.Role))
+	callerID, _ := middleware.GetOperatorUUID(r.Context())
+
+	operator, err := h.service.Create(r.Context(), tenantID, domain.OperatorRole(req.Role), req.Name, req.Email, req.NormalizedLanguages(), &callerID)
 	if err != nil {
-		response.InternalError(w, "Failed to create operator")
+		handleServiceError(w, err, "Failed to create operator")
 		return
 	}
 
@@ -117,7 +175,7 @@ func (h *OperatorHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Operator not found")
 			return
 		}
-		response.InternalError(w, "Failed to get operator")
+		handleServiceError(w, err, "Failed to get operator")
 		return
 	}
 
@@ -131,6 +189,7 @@ func (h *OperatorHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 }
 
 // List handles GET /api/v1/operators
+// ?language= only returns operators who speak the language
 func (h *OperatorHandler) List(w http.ResponseWriter, r *http.Request) {
 	tenantID, ok := middleware.GetTenantUUID(r.Context())
 	if !ok {
@@ -138,15 +197,28 @@ func (h *OperatorHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var language string
+	if raw := r.URL.Query().Get("language"); raw != "" {
+		normalized, err := domain.NormalizeLanguage(raw)
+		if err != nil {
+			response.ValidationError(w, "Validation failed", err.Error())
+			return
+		}
+		language = normalized
+	}
+
 	operators, err := h.service.ListByTenant(r.Context(), tenantID)
 	if err != nil {
-		response.InternalError(w, "Failed to list operators")
+		handleServiceError(w, err, "Failed to list operators")
 		return
 	}
 
-	items := make([]dto.OperatorResponse, len(operators))
-	for i, op := range operators {
-		items[i] = dto.NewOperatorResponse(op)
+	items := make([]dto.OperatorResponse, 0, len(operators))
+	for _, op := range operators {
+		if language != "" && !slices.Contains(op.Languages, language) {
+			continue
+		}
+		items = append(items, dto.NewOperatorResponse(op))
 	}
 
 	pagination := dto.ParsePagination(r)
@@ -182,7 +254,7 @@ func (h *OperatorHandler) Update(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Operator not found")
 			return
 		}
-		response.InternalError(w, "Failed to get operator")
+		handleServiceError(w, err, "Failed to get operator")
 		return
 	}
 
@@ -192,9 +264,11 @@ func (h *OperatorHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updated, err := h.service.Update(r.Context(), id, domain.OperatorRole(req.Role))
+	callerID, _ := middleware.GetOperatorUUID(r.Context())
+
+	updated, err := h.service.Update(r.Context(), id, domain.OperatorRole(req.Role), req.Name, req.Email, req.NormalizedLanguages(), &callerID)
 	if err != nil {
-		response.InternalError(w, "Failed to update operator")
+		handleServiceError(w, err, "Failed to update operator")
 		return
 	}
 
@@ -215,7 +289,7 @@ func (h *OperatorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Operator not found")
 			return
 		}
-		response.InternalError(w, "Failed to get operator")
+		handleServiceError(w, err, "Failed to get operator")
 		return
 	}
 
@@ -225,10 +299,54 @@ func (h *OperatorHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
-		response.InternalError(w, "Failed to delete operator")
+	req := dto.ParseDeleteRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	callerID, _ := middleware.GetOperatorUUID(r.Context())
+
+	if err := h.service.Delete(r.Context(), id, &callerID, req.IsForced()); err != nil {
+		if handleDeletionError(w, err) {
+			return
+		}
+		handleServiceError(w, err, "Failed to delete operator")
 		return
 	}
 
 	response.NoContent(w)
 }
+
+// DeletionImpact handles GET /api/v1/operators/{id}/deletion-impact
+func (h *OperatorHandler) DeletionImpact(w http.ResponseWriter, r *http.Request) {
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	operator, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			response.NotFound(w, "Operator not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get operator")
+		return
+	}
+
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	if operator.TenantID != tenantID {
+		response.NotFound(w, "Operator not found")
+		return
+	}
+
+	impact, err := h.service.DeletionImpact(r.Context(), id)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get deletion impact")
+		return
+	}
+
+	response.OK(w, dto.NewDeletionImpactResponse(impact))
+}
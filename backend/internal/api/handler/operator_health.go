@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type OperatorHealthHandler struct {
+	service *service.OperatorHealthService
+}
+
+func NewOperatorHealthHandler(svc *service.OperatorHealthService) *OperatorHealthHandler {
+	return &OperatorHealthHandler{service: svc}
+}
+
+// List handles GET /api/v1/operator-health
+func (h *OperatorHealthHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	health, err := h.service.List(r.Context(), tenantID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorHealthListResponse(health))
+}
+
+// SetOverride handles PUT /api/v1/operator-health/{operator_id}/override
+func (h *OperatorHealthHandler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, err := dto.ParseUUIDParam(r, "operator_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.AllocationWeightOverrideRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	health, err := h.service.SetOverride(r.Context(), tenantID, operatorID, req.Weight, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorHealthResponse(health))
+}
+
+// ClearOverride handles DELETE /api/v1/operator-health/{operator_id}/override
+func (h *OperatorHealthHandler) ClearOverride(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, err := dto.ParseUUIDParam(r, "operator_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	health, err := h.service.SetOverride(r.Context(), tenantID, operatorID, nil, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorHealthResponse(health))
+}
+
+// ==================== Error Handling ====================
+
+func (h *OperatorHealthHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrHealthOperatorNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeHealthOperatorNotFound,
+			"Operator not found")
+	case errors.Is(err, domain.ErrInvalidAllocationWeight):
+		response.BadRequest(w, err.Error())
+	default:
+		handleServiceError(w, err, "Failed to process operator health operation")
+	}
+}
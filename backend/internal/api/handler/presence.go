@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/pkg/websocket"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type PresenceHandler struct {
+	presence  *service.PresenceService
+	operators *service.OperatorService
+}
+
+func NewPresenceHandler(presence *service.PresenceService, operators *service.OperatorService) *PresenceHandler {
+	return &PresenceHandler{presence: presence, operators: operators}
+}
+
+// Connect handles GET /api/v1/ws
+// Opens the caller's presence WebSocket. Connecting sets an OFFLINE operator
+// AVAILABLE; the server pings every heartbeat interval and the operator is
+// set OFFLINE, starting grace periods, once no connection of theirs has been
+// seen for the presence timeout. Teammates' status changes are pushed as
+// presence.status_changed messages. Messages from the client are ignored.
+func (h *PresenceHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+	operatorID, _ := middleware.GetOperatorUUID(ctx)
+
+	if !websocket.IsUpgradeRequest(r) {
+		response.BadRequest(w, "WebSocket upgrade required")
+		return
+	}
+
+	session, err := h.presence.Connect(ctx, tenantID, operatorID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to connect presence")
+		return
+	}
+	defer session.Close()
+
+	status, err := h.operators.ResumePresence(ctx, operatorID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to update status")
+		return
+	}
+	teammates, err := h.presence.Teammates(ctx, session)
+	if err != nil {
+		handleServiceError(w, err, "Failed to get teammates")
+		return
+	}
+
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		handleServiceError(w, err, "WebSocket not supported")
+		return
+	}
+	policy := h.presence.Policy()
+	h.serve(r, conn, session, dto.NewPresenceReadyMessage(session.ID, status, policy, teammates))
+}
+
+// serve pings and pushes teammates' status changes until the client
+// disconnects or stops answering within the presence timeout
+func (h *PresenceHandler) serve(r *http.Request, conn *websocket.Conn, session *service.PresenceSession, ready dto.PresenceReadyMessage) {
+	ctx := r.Context()
+	policy := h.presence.Policy()
+
+	// Every frame from the client counts as a sign of life
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if err := conn.SetReadDeadline(time.Now().Add(policy.Timeout)); err != nil {
+				return
+			}
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(v interface{}) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(policy.HeartbeatInterval))
+		return conn.WriteJSON(v) == nil
+	}
+
+	closeCode := websocket.CloseNormal
+	defer func() {
+		conn.Close(closeCode)
+		<-gone
+	}()
+
+	if !write(ready) {
+		return
+	}
+
+	heartbeat := time.NewTicker(policy.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-gone:
+			return
+		case change, open := <-session.Changes():
+			if !open {
+				return
+			}
+			if !write(dto.NewPresenceStatusChangedMessage(change)) {
+				return
+			}
+		case <-heartbeat.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(policy.HeartbeatInterval))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+			// A failed refresh is retried on the next heartbeat
+			restored, err := h.presence.Touch(ctx, session.TenantID, session.OperatorID)
+			if err == nil && restored {
+				// The presence timed out meanwhile and the operator was set
+				// OFFLINE; the connection is alive, so they are back
+				if _, err := h.operators.ResumePresence(ctx, session.OperatorID); err != nil {
+					closeCode = websocket.CloseInternalError
+					return
+				}
+			}
+		}
+	}
+}
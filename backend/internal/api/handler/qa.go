@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type QAHandler struct {
+	service *service.QAService
+}
+
+func NewQAHandler(svc *service.QAService) *QAHandler {
+	return &QAHandler{service: svc}
+}
+
+// Next handles POST /api/v1/qa/next
+// Returns the reviewer's current item or leases the next one from the queue
+func (h *QAHandler) Next(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	item, err := h.service.NextItem(ctx, tenantID, operatorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewQANextResponse(item))
+}
+
+// SubmitReview handles POST /api/v1/qa/items/{id}/review
+func (h *QAHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, _ := middleware.GetTenantUUID(ctx)
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid review item ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.SubmitQAReviewRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	item, err := h.service.SubmitReview(ctx, tenantID, operatorID, id, req.Scores, req.Comment)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewQAReviewItemResponse(item))
+}
+
+// OperatorReport handles GET /api/v1/qa/reports/operators?from=&to=
+func (h *QAHandler) OperatorReport(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseQAReportRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	from, to := req.Range()
+	reports, err := h.service.OperatorReports(r.Context(), tenantID, from, to)
+	if err != nil {
+		handleServiceError(w, err, "Failed to build QA report")
+		return
+	}
+
+	response.OK(w, dto.NewQAReportResponse(from, to, reports))
+}
+
+// ListReviewers handles GET /api/v1/qa/reviewers
+func (h *QAHandler) ListReviewers(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	reviewers, err := h.service.ListReviewers(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list QA reviewers")
+		return
+	}
+
+	items := make([]dto.QAReviewerResponse, len(reviewers))
+	for i, reviewer := range reviewers {
+		items[i] = dto.NewQAReviewerResponse(reviewer)
+	}
+
+	response.OK(w, dto.QAReviewerListResponse{Reviewers: items})
+}
+
+// GrantReviewer handles POST /api/v1/qa/reviewers
+func (h *QAHandler) GrantReviewer(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.GrantQAReviewerRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	callerID, _ := middleware.GetOperatorUUID(r.Context())
+
+	reviewer, err := h.service.GrantReviewer(r.Context(), tenantID, req.OperatorID, &callerID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewQAReviewerResponse(reviewer))
+}
+
+// RevokeReviewer handles DELETE /api/v1/qa/reviewers/{operator_id}
+func (h *QAHandler) RevokeReviewer(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	operatorID, err := dto.ParseUUIDParam(r, "operator_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	if err := h.service.RevokeReviewer(r.Context(), tenantID, operatorID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *QAHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrQANotReviewer):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeQANotReviewer,
+			"QA reviewer permission required")
+	case errors.Is(err, service.ErrQAQueueEmpty):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeQAQueueEmpty,
+			"No conversations awaiting review")
+	case errors.Is(err, service.ErrQAItemNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeQAItemNotFound,
+			"Review item not found")
+	case errors.Is(err, service.ErrQAItemNotClaimed):
+		response.Error(w, http.StatusConflict, dto.ErrCodeQAItemNotClaimed,
+			"Review item is not claimed by this reviewer")
+	case errors.Is(err, service.ErrQAReviewerOperatorNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeQAOperatorNotFound,
+			"Operator not found")
+	default:
+		handleServiceError(w, err, "Failed to process QA operation")
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// QueueHandler serves reads of the materialized queue ranks
+type QueueHandler struct {
+	service       *service.QueueRankingService
+	conversations *service.ConversationService
+}
+
+func NewQueueHandler(svc *service.QueueRankingService, conversations *service.ConversationService) *QueueHandler {
+	return &QueueHandler{service: svc, conversations: conversations}
+}
+
+// QueuePosition handles GET /api/v1/conversations/{id}/queue-position
+func (h *QueueHandler) QueuePosition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, _ := middleware.GetOperatorUUID(ctx)
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	conv, err := h.conversations.GetByID(ctx, tenantID, conversationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.NotFound(w, "Conversation not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get conversation")
+		return
+	}
+	if !h.conversations.CanAccess(ctx, operatorID, role, conv) {
+		response.NotFound(w, "Conversation not found")
+		return
+	}
+
+	position, err := h.service.QueuePosition(ctx, conv)
+	if err != nil {
+		if errors.Is(err, service.ErrConversationNotQueued) {
+			response.Error(w, http.StatusConflict, dto.ErrCodeConversationNotQueued,
+				"Conversation is not in the queue")
+			return
+		}
+		handleServiceError(w, err, "Failed to get queue position")
+		return
+	}
+
+	response.OK(w, dto.NewQueuePositionResponse(position))
+}
+
+// InboxQueue handles GET /api/v1/inboxes/{id}/queue?limit=
+func (h *QueueHandler) InboxQueue(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req := dto.ParseInboxQueueRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	ranks, length, err := h.service.InboxQueue(r.Context(), tenantID, inboxID, req.GetLimit())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound, "Inbox not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to get inbox queue")
+		return
+	}
+
+	response.OK(w, dto.NewInboxQueueResponse(inboxID, ranks, length))
+}
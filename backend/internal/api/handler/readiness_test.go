@@ -0,0 +1,25 @@
+package handler
+
+import "testing"
+
+func TestNewReadyResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		wantReady  bool
+		wantStatus string
+	}{
+		{"all healthy", map[string]Check{"database": {Status: CheckHealthy}, "grace_period_pipeline": {Status: CheckHealthy}}, true, CheckHealthy},
+		{"degraded stays ready", map[string]Check{"database": {Status: CheckHealthy}, "grace_period_pipeline": {Status: CheckDegraded}}, true, CheckDegraded},
+		{"unhealthy wins", map[string]Check{"database": {Status: CheckUnhealthy}, "grace_period_pipeline": {Status: CheckDegraded}}, false, CheckUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := newReadyResponse(tt.checks)
+			if resp.Ready != tt.wantReady || resp.Status != tt.wantStatus {
+				t.Errorf("got ready=%v status=%s, want ready=%v status=%s", resp.Ready, resp.Status, tt.wantReady, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ReconciliationHandler struct {
+	service *service.ReconciliationService
+}
+
+func NewReconciliationHandler(svc *service.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{service: svc}
+}
+
+// Report handles GET /api/v1/admin/reconciliation?kind=
+// Reports the tenant's conversations diverging from the upstream system of
+// record
+func (h *ReconciliationHandler) Report(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseReconciliationReportRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	report, err := h.service.Report(r.Context(), tenantID, domain.DivergenceKind(req.Kind))
+	if err != nil {
+		handleServiceError(w, err, "Failed to report reconciliation divergences")
+		return
+	}
+
+	response.OK(w, dto.NewReconciliationReportResponse(report))
+}
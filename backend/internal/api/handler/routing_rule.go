@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type RoutingRuleHandler struct {
+	service *service.RoutingRuleService
+}
+
+func NewRoutingRuleHandler(svc *service.RoutingRuleService) *RoutingRuleHandler {
+	return &RoutingRuleHandler{service: svc}
+}
+
+// List handles GET /api/v1/routing-rules
+func (h *RoutingRuleHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(r.Context())
+	rules, err := h.service.ListRules(r.Context(), tenantID, optionalOperatorID(r), role)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	items := make([]dto.RoutingRuleResponse, len(rules))
+	for i, rule := range rules {
+		items[i] = dto.NewRoutingRuleResponse(rule)
+	}
+
+	response.OK(w, dto.RoutingRuleListResponse{Rules: items})
+}
+
+// Create handles POST /api/v1/routing-rules
+func (h *RoutingRuleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateRoutingRuleRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(r.Context())
+
+	rule, err := h.service.CreateRule(r.Context(), service.CreateRoutingRuleParams{
+		TenantID:      tenantID,
+		InboxID:       req.InboxID,
+		Name:          req.Name,
+		Trigger:       domain.RoutingRuleTrigger(req.Trigger),
+		Field:         domain.RuleConditionField(req.Condition.Field),
+		Operator:      domain.RuleOperator(req.Condition.Operator),
+		Value:         req.Condition.Value,
+		Text:          req.ConditionText(),
+		LabelName:     req.LabelName(),
+		PriorityBoost: req.Boost(),
+		CreatedBy:     optionalOperatorID(r),
+		Role:          role,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewRoutingRuleResponse(rule))
+}
+
+// Update handles PUT /api/v1/routing-rules/{id}
+func (h *RoutingRuleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid routing rule ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateRoutingRuleRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(r.Context())
+	rule, err := h.service.SetRuleActive(r.Context(), tenantID, id, *req.IsActive, optionalOperatorID(r), role)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewRoutingRuleResponse(rule))
+}
+
+// Delete handles DELETE /api/v1/routing-rules/{id}
+func (h *RoutingRuleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid routing rule ID")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(r.Context())
+	if err := h.service.DeleteRule(r.Context(), tenantID, id, optionalOperatorID(r), role); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *RoutingRuleHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrRoutingRuleNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeRoutingRuleNotFound,
+			"Routing rule not found")
+	case errors.Is(err, service.ErrRoutingRuleInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrRoutingRulePermissionDenied):
+		response.Forbidden(w, "Admin or inbox admin access required")
+	default:
+		handleServiceError(w, err, "Failed to process routing rule operation")
+	}
+}
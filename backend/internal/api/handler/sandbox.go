@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type SandboxHandler struct {
+	service *service.SandboxService
+}
+
+func NewSandboxHandler(svc *service.SandboxService) *SandboxHandler {
+	return &SandboxHandler{service: svc}
+}
+
+// Enable handles POST /api/v1/tenant/sandbox
+func (h *SandboxHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	seed, err := h.service.Enable(r.Context(), tenantID, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewSandboxResponse(seed.Inboxes, seed.Conversations))
+}
+
+// Reset handles POST /api/v1/tenant/sandbox/reset
+func (h *SandboxHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	seed, err := h.service.Reset(r.Context(), tenantID, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewSandboxResponse(seed.Inboxes, seed.Conversations))
+}
+
+// ==================== Error Handling ====================
+
+func (h *SandboxHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrSandboxAlreadyEnabled):
+		response.Error(w, http.StatusConflict, dto.ErrCodeSandboxAlreadyEnabled,
+			"Tenant is already a sandbox")
+	case errors.Is(err, service.ErrTenantNotEmpty):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTenantNotEmpty,
+			"Only a tenant without inboxes can become a sandbox")
+	case errors.Is(err, service.ErrTenantNotSandbox):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTenantNotSandbox,
+			"Only sandbox tenants can be reset")
+	default:
+		handleServiceError(w, err, "Failed to process sandbox operation")
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ScalingHandler struct {
+	service *service.ScalingAuditService
+}
+
+func NewScalingHandler(svc *service.ScalingAuditService) *ScalingHandler {
+	return &ScalingHandler{service: svc}
+}
+
+// Report handles GET /api/v1/admin/scaling
+// Reports the state this replica keeps to itself and whether the deployment
+// is safe to run on several replicas without sticky sessions
+func (h *ScalingHandler) Report(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, dto.NewScalingReportResponse(h.service.Report()))
+}
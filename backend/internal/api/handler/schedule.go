@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ScheduleHandler struct {
+	service *service.ScheduleService
+}
+
+func NewScheduleHandler(svc *service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: svc}
+}
+
+// GetOwn handles GET /api/v1/operator/schedule
+func (h *ScheduleHandler) GetOwn(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	schedules, err := h.service.ListSchedules(r.Context(), tenantID, operatorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorScheduleListResponse(schedules))
+}
+
+// List handles GET /api/v1/operators/{id}/schedules
+func (h *ScheduleHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	operatorID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	schedules, err := h.service.ListSchedules(r.Context(), tenantID, operatorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorScheduleListResponse(schedules))
+}
+
+// Create handles POST /api/v1/operators/{id}/schedules
+func (h *ScheduleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.ScheduleRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(r.Context(), tenantID, operatorID, scheduleWindow(req), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewOperatorScheduleResponse(schedule))
+}
+
+// Update handles PUT /api/v1/operators/{id}/schedules/{schedule_id}
+func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, id, ok := parseScheduleParams(w, r)
+	if !ok {
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.ScheduleRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(r.Context(), tenantID, operatorID, id, scheduleWindow(req), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewOperatorScheduleResponse(schedule))
+}
+
+// Delete handles DELETE /api/v1/operators/{id}/schedules/{schedule_id}
+func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	operatorID, id, ok := parseScheduleParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(r.Context(), tenantID, operatorID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func parseScheduleParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	operatorID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid operator ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := dto.ParseUUIDParam(r, "schedule_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid schedule ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return operatorID, id, true
+}
+
+func scheduleWindow(req *dto.ScheduleRequest) service.ScheduleWindow {
+	return service.ScheduleWindow{
+		DayOfWeek: req.GetDayOfWeek(),
+		Start:     req.GetStart(),
+		End:       req.GetEnd(),
+		Timezone:  req.Timezone,
+	}
+}
+
+// ==================== Error Handling ====================
+
+func (h *ScheduleHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrScheduleNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeScheduleNotFound,
+			"Schedule not found")
+	case errors.Is(err, service.ErrScheduleOperatorNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeScheduleOperatorNotFound,
+			"Operator not found")
+	default:
+		handleServiceError(w, err, "Failed to process schedule operation")
+	}
+}
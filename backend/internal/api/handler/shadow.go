@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ShadowHandler struct {
+	service *service.ShadowService
+}
+
+func NewShadowHandler(svc *service.ShadowService) *ShadowHandler {
+	return &ShadowHandler{service: svc}
+}
+
+// List handles GET /api/v1/shadows
+func (h *ShadowHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	shadows, err := h.service.ListShadows(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list shadows")
+		return
+	}
+
+	items := make([]dto.ShadowResponse, len(shadows))
+	for i, shadow := range shadows {
+		items[i] = dto.NewShadowResponse(shadow)
+	}
+
+	response.OK(w, dto.ShadowListResponse{Shadows: items})
+}
+
+// Create handles POST /api/v1/shadows
+func (h *ShadowHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateShadowRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	shadow, err := h.service.CreateShadow(r.Context(), tenantID, req.MentorID, req.TraineeID, optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewShadowResponse(shadow))
+}
+
+// Delete handles DELETE /api/v1/shadows/{id}
+func (h *ShadowHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid shadow ID")
+		return
+	}
+
+	if err := h.service.DeleteShadow(r.Context(), tenantID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *ShadowHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrShadowNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeShadowNotFound,
+			"Shadow relationship not found")
+	case errors.Is(err, service.ErrShadowOperatorNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeShadowOperatorNotFound,
+			"Mentor or trainee operator not found")
+	case errors.Is(err, service.ErrShadowAlreadyExists):
+		response.Error(w, http.StatusConflict, dto.ErrCodeShadowAlreadyExists,
+			"Trainee is already shadowing this mentor")
+	case errors.Is(err, service.ErrShadowSelf):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeShadowSelf,
+			"Operator cannot shadow themselves")
+	default:
+		handleServiceError(w, err, "Failed to process shadow operation")
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type ShareLinkHandler struct {
+	service *service.ShareLinkService
+}
+
+func NewShareLinkHandler(svc *service.ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{service: svc}
+}
+
+// Create handles POST /api/v1/conversations/{id}/share
+// The link's URL is only returned in this response
+func (h *ShareLinkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	role, _ := middleware.GetOperatorRole(r.Context())
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateShareLinkRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	link, url, err := h.service.Create(r.Context(), service.CreateShareLinkParams{
+		TenantID:       tenantID,
+		ConversationID: conversationID,
+		TTL:            req.TTL(),
+		IncludePII:     req.IncludePII,
+		CreatedBy:      optionalOperatorID(r),
+		Role:           role,
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.CreatedShareLinkResponse{ShareLinkResponse: dto.NewShareLinkResponse(link), URL: url})
+}
+
+// List handles GET /api/v1/conversations/{id}/shares
+func (h *ShareLinkHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	links, err := h.service.List(r.Context(), tenantID, conversationID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewShareLinkListResponse(links))
+}
+
+// Revoke handles DELETE /api/v1/conversations/{id}/shares/{share_id}
+func (h *ShareLinkHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+	id, err := dto.ParseUUIDParam(r, "share_id")
+	if err != nil {
+		response.BadRequest(w, "Invalid share link ID")
+		return
+	}
+
+	if _, err := h.service.Revoke(r.Context(), tenantID, conversationID, id, optionalOperatorID(r)); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// Open handles GET /share/{token}
+// Public: the signed token is the only credential
+func (h *ShareLinkHandler) Open(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.Open(r.Context(), chi.URLParam(r, "token"), service.ShareAccess{
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.UserAgent(),
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Snapshots stay out of shared caches and browser history caches
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	response.OK(w, dto.NewConversationSnapshotResponse(snapshot))
+}
+
+// ==================== Error Handling ====================
+
+func (h *ShareLinkHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrShareLinkNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeShareLinkNotFound,
+			"Share link not found")
+	case errors.Is(err, service.ErrShareLinkExpired):
+		response.Error(w, http.StatusGone, dto.ErrCodeShareLinkExpired,
+			"Share link has expired")
+	case errors.Is(err, service.ErrShareLinkRevoked):
+		response.Error(w, http.StatusGone, dto.ErrCodeShareLinkRevoked,
+			"Share link has been revoked")
+	case errors.Is(err, service.ErrShareConversationNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound,
+			"Conversation not found")
+	case errors.Is(err, service.ErrShareRestrictedInbox):
+		response.Error(w, http.StatusConflict, dto.ErrCodeShareNotAllowed,
+			"Conversations in restricted inboxes cannot be shared")
+	case errors.Is(err, service.ErrSharePIIForbidden):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeSharePIIForbidden,
+			"Only admins can share customer identifiers")
+	default:
+		handleServiceError(w, err, "Failed to process share link operation")
+	}
+}
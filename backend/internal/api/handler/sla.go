@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type SLAHandler struct {
+	service *service.SLAService
+}
+
+func NewSLAHandler(svc *service.SLAService) *SLAHandler {
+	return &SLAHandler{service: svc}
+}
+
+// GetPolicy handles GET /api/v1/inboxes/{id}/sla
+func (h *SLAHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	policy, err := h.service.GetPolicy(r.Context(), tenantID, inboxID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewSLAPolicyResponse(policy))
+}
+
+// UpdatePolicy handles PUT /api/v1/inboxes/{id}/sla
+func (h *SLAHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.SLAPolicyRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	policy, err := h.service.SetPolicy(r.Context(), tenantID, inboxID,
+		req.GetFirstAssignment(), req.GetResolution(), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewSLAPolicyResponse(policy))
+}
+
+// DeletePolicy handles DELETE /api/v1/inboxes/{id}/sla
+func (h *SLAHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	if err := h.service.DeletePolicy(r.Context(), tenantID, inboxID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ListBreaches handles GET /api/v1/sla/breaches?inbox_id=&since=&limit=
+func (h *SLAHandler) ListBreaches(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseListSLABreachesRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	breaches, err := h.service.ListBreaches(r.Context(), tenantID, req.GetInboxID(),
+		req.GetSince(time.Now().UTC()), req.GetLimit())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewSLABreachListResponse(breaches))
+}
+
+// ==================== Error Handling ====================
+
+func (h *SLAHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrSLAPolicyNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeSLAPolicyNotFound,
+			"SLA policy not found")
+	case errors.Is(err, service.ErrSLAInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	default:
+		handleServiceError(w, err, "Failed to process SLA operation")
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type SnoozeHandler struct {
+	service *service.SnoozeService
+}
+
+func NewSnoozeHandler(svc *service.SnoozeService) *SnoozeHandler {
+	return &SnoozeHandler{service: svc}
+}
+
+// Snooze handles POST /api/v1/snooze
+func (h *SnoozeHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	role, _ := middleware.GetOperatorRole(ctx)
+
+	req, err := dto.ParseSnoozeRequest(r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	conv, err := h.service.Snooze(ctx, tenantID, operatorID, req.ConversationID, *req.Until, req.ReturnToOperator, role)
+	if err != nil {
+		status, code, message := lifecycleError(err, "snooze")
+		response.Error(w, status, code, message)
+		return
+	}
+
+	response.OK(w, dto.NewLifecycleResponse(conv))
+}
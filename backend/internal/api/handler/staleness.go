@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type StalenessHandler struct {
+	service *service.StalenessService
+}
+
+func NewStalenessHandler(svc *service.StalenessService) *StalenessHandler {
+	return &StalenessHandler{service: svc}
+}
+
+// GetPolicy handles GET /api/v1/inboxes/{id}/staleness
+func (h *StalenessHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	policy, err := h.service.GetPolicy(r.Context(), tenantID, inboxID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewStalenessPolicyResponse(policy))
+}
+
+// UpdatePolicy handles PUT /api/v1/inboxes/{id}/staleness
+func (h *StalenessHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.StalenessPolicyRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	policy, err := h.service.SetPolicy(r.Context(), tenantID, inboxID,
+		req.GetStaleAfter(), req.GetDeallocateGrace(), optionalOperatorID(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewStalenessPolicyResponse(policy))
+}
+
+// DeletePolicy handles DELETE /api/v1/inboxes/{id}/staleness
+func (h *StalenessHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	inboxID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid inbox ID")
+		return
+	}
+
+	if err := h.service.DeletePolicy(r.Context(), tenantID, inboxID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// RecordActivity handles POST /api/v1/conversations/{id}/activity
+func (h *StalenessHandler) RecordActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	conversationID, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid conversation ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.RecordActivityRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	if err := h.service.RecordActivity(ctx, tenantID, conversationID, operatorID, req.GetKind()); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ==================== Error Handling ====================
+
+func (h *StalenessHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrStalenessPolicyNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeStalenessPolicyNotFound,
+			"Staleness policy not found")
+	case errors.Is(err, service.ErrStalenessInboxNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound,
+			"Inbox not found")
+	case errors.Is(err, service.ErrActivityNotAssignee):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeActivityNotAssignee,
+			"Only the assigned operator can report activity on the conversation")
+	case errors.Is(err, domain.ErrNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeConversationNotFound,
+			"Conversation not found")
+	default:
+		handleServiceError(w, err, "Failed to process staleness operation")
+	}
+}
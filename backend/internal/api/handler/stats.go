@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type StatsHandler struct {
+	service *service.StatsService
+}
+
+func NewStatsHandler(svc *service.StatsService) *StatsHandler {
+	return &StatsHandler{service: svc}
+}
+
+// Overview handles GET /api/v1/stats/overview
+func (h *StatsHandler) Overview(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	overview, err := h.service.Overview(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to compute overview")
+		return
+	}
+
+	response.OK(w, dto.NewOverviewResponse(overview))
+}
+
+// AvailabilityForecast handles GET /api/v1/stats/availability-forecast?inbox_id=&hours=
+func (h *StatsHandler) AvailabilityForecast(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseAvailabilityForecastRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	forecast, err := h.service.AvailabilityForecast(r.Context(), tenantID, req.GetInboxID(), req.GetHours())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound, "Inbox not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to compute availability forecast")
+		return
+	}
+
+	response.OK(w, dto.NewAvailabilityForecastResponse(forecast))
+}
+
+// LabelUsage handles GET /api/v1/stats/labels?inbox_id=&from=&to=
+func (h *StatsHandler) LabelUsage(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseLabelUsageRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	from, to := req.Range()
+	report, err := h.service.LabelUsage(r.Context(), tenantID, req.GetInboxID(), from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound, "Inbox not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to compute label usage")
+		return
+	}
+
+	response.OK(w, dto.NewLabelUsageResponse(report))
+}
+
+// Escalations handles GET /api/v1/stats/escalations?from=&to=
+func (h *StatsHandler) Escalations(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseEscalationStatsRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	from, to := req.Range()
+	report, err := h.service.Escalations(r.Context(), tenantID, from, to)
+	if err != nil {
+		handleServiceError(w, err, "Failed to compute escalation statistics")
+		return
+	}
+
+	response.OK(w, dto.NewEscalationStatsResponse(report))
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/inbox-allocation-service/internal/api/dto"
@@ -54,12 +55,18 @@ func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	sub, err := h.subSvc.Subscribe(r.Context(), req.OperatorID, inboxID)
+	role, _ := middleware.GetOperatorRole(r.Context())
+	sub, created, err := h.subSvc.Subscribe(r.Context(), optionalOperatorID(r), role, req.OperatorID, inboxID)
 	if err != nil {
-		response.InternalError(w, "Failed to subscribe")
+		h.handleError(w, err, "Failed to subscribe")
 		return
 	}
 
+	// Subscribing again returns the existing subscription
+	if !created {
+		response.OK(w, dto.NewSubscriptionResponse(sub))
+		return
+	}
 	response.Created(w, dto.NewSubscriptionResponse(sub))
 }
 
@@ -77,8 +84,9 @@ func (h *SubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := h.subSvc.Unsubscribe(r.Context(), operatorID, inboxID); err != nil {
-		response.InternalError(w, "Failed to unsubscribe")
+	role, _ := middleware.GetOperatorRole(r.Context())
+	if err := h.subSvc.Unsubscribe(r.Context(), optionalOperatorID(r), role, operatorID, inboxID); err != nil {
+		h.handleError(w, err, "Failed to unsubscribe")
 		return
 	}
 
@@ -93,9 +101,10 @@ func (h *SubscriptionHandler) ListOperators(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	subs, err := h.subSvc.GetOperatorsByInbox(r.Context(), inboxID)
+	role, _ := middleware.GetOperatorRole(r.Context())
+	subs, err := h.subSvc.GetOperatorsByInbox(r.Context(), optionalOperatorID(r), role, inboxID)
 	if err != nil {
-		response.InternalError(w, "Failed to list operators")
+		h.handleError(w, err, "Failed to list operators")
 		return
 	}
 
@@ -121,7 +130,7 @@ func (h *SubscriptionHandler) ListInboxes(w http.ResponseWriter, r *http.Request
 
 	subs, err := h.subSvc.GetInboxesByOperator(r.Context(), operatorID)
 	if err != nil {
-		response.InternalError(w, "Failed to list inboxes")
+		handleServiceError(w, err, "Failed to list inboxes")
 		return
 	}
 
@@ -136,3 +145,18 @@ func (h *SubscriptionHandler) ListInboxes(w http.ResponseWriter, r *http.Request
 		Meta:          dto.NewListMeta(pagination.Page, pagination.PerPage, len(items)),
 	})
 }
+
+// ==================== Error Handling ====================
+
+func (h *SubscriptionHandler) handleError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, service.ErrSubscriptionPermissionDenied) {
+		response.Forbidden(w, "Manager, Admin or inbox admin access required")
+		return
+	}
+	if errors.Is(err, service.ErrSubscriptionConflict) {
+		response.Error(w, http.StatusConflict, dto.ErrCodeSubscriptionConflict,
+			"Subscription changed concurrently, retry the request")
+		return
+	}
+	handleServiceError(w, err, message)
+}
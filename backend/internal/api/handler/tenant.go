@@ -32,7 +32,7 @@ func (h *TenantHandler) Get(w http.ResponseWriter, r *http.Request) {
 			response.NotFound(w, "Tenant not found")
 			return
 		}
-		response.InternalError(w, "Failed to get tenant")
+		handleServiceError(w, err, "Failed to get tenant")
 		return
 	}
 
@@ -58,16 +58,47 @@ func (h *TenantHandler) UpdateWeights(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	operatorID, _ := middleware.GetOperatorUUID(r.Context())
 	alpha, beta := req.ToDecimal()
 
-	tenant, err := h.service.UpdateWeights(r.Context(), tenantID, alpha, beta, &operatorID)
+	tenant, err := h.service.UpdateWeights(r.Context(), tenantID, alpha, beta, req.GetFirstContactBoost(), optionalOperatorID(r))
 	if err != nil {
 		if err == domain.ErrNotFound {
 			response.NotFound(w, "Tenant not found")
 			return
 		}
-		response.InternalError(w, "Failed to update weights")
+		handleServiceError(w, err, "Failed to update weights")
+		return
+	}
+
+	response.OK(w, dto.NewTenantResponse(tenant))
+}
+
+// UpdateSettings handles PUT /api/v1/tenant/settings
+func (h *TenantHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateTenantSettingsRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	tenant, err := h.service.UpdateSettings(r.Context(), tenantID, req.ToDomain(), optionalOperatorID(r))
+	if err != nil {
+		if err == domain.ErrNotFound {
+			response.NotFound(w, "Tenant not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to update settings")
 		return
 	}
 
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type TransferHandler struct {
+	service *service.TransferService
+}
+
+func NewTransferHandler(svc *service.TransferService) *TransferHandler {
+	return &TransferHandler{service: svc}
+}
+
+// Request handles POST /api/v1/transfers
+func (h *TransferHandler) Request(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.RequestTransferRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	transfer, err := h.service.Request(ctx, service.RequestTransferParams{
+		TenantID:       tenantID,
+		ConversationID: req.ConversationID,
+		FromOperatorID: operatorID,
+		ToOperatorID:   req.ToOperatorID,
+		Note:           req.GetNote(),
+		TTL:            req.GetTTL(),
+	})
+	if err != nil {
+		h.handleError(w, err, "request")
+		return
+	}
+
+	response.Created(w, dto.NewTransferResponse(transfer))
+}
+
+// List handles GET /api/v1/transfers
+// Pending transfers the caller proposed or received
+func (h *TransferHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	transfers, err := h.service.ListPending(ctx, tenantID, operatorID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list transfers")
+		return
+	}
+
+	response.OK(w, dto.NewTransferListResponse(transfers))
+}
+
+// Accept handles POST /api/v1/transfers/{id}/accept
+func (h *TransferHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.answer(w, r, "accept", h.service.Accept)
+}
+
+// Decline handles POST /api/v1/transfers/{id}/decline
+func (h *TransferHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.answer(w, r, "decline", h.service.Decline)
+}
+
+func (h *TransferHandler) answer(w http.ResponseWriter, r *http.Request, operation string, fn func(ctx context.Context, tenantID, callerID, transferID uuid.UUID) (*domain.ConversationTransfer, error)) {
+	ctx := r.Context()
+
+	tenantID, ok := middleware.GetTenantUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	operatorID, ok := middleware.GetOperatorUUID(ctx)
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeOperatorRequired, "X-Operator-ID required")
+		return
+	}
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid transfer ID")
+		return
+	}
+
+	transfer, err := fn(ctx, tenantID, operatorID, id)
+	if err != nil {
+		h.handleError(w, err, operation)
+		return
+	}
+
+	response.OK(w, dto.NewTransferResponse(transfer))
+}
+
+// ==================== Error Handling ====================
+
+func (h *TransferHandler) handleError(w http.ResponseWriter, err error, operation string) {
+	switch {
+	case errors.Is(err, service.ErrTransferNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeTransferNotFound,
+			"Transfer not found")
+	case errors.Is(err, service.ErrTransferNotAssignee):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeTransferNotAssignee,
+			"Only the assigned operator can transfer the conversation")
+	case errors.Is(err, service.ErrTransferToSelf):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeTransferToSelf,
+			"Cannot transfer a conversation to its assignee")
+	case errors.Is(err, service.ErrTransferPending):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTransferPending,
+			"Conversation already has a pending transfer")
+	case errors.Is(err, service.ErrTransferNotRecipient):
+		response.Error(w, http.StatusForbidden, dto.ErrCodeTransferNotRecipient,
+			"Only the recipient can answer the transfer")
+	case errors.Is(err, service.ErrTransferNotPending):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTransferNotPending,
+			"Transfer has already been answered")
+	case errors.Is(err, service.ErrTransferExpired):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTransferExpired,
+			"Transfer expired")
+	case errors.Is(err, service.ErrTransferCancelled):
+		response.Error(w, http.StatusConflict, dto.ErrCodeTransferCancelled,
+			"Conversation is no longer assigned to the proposing operator")
+	default:
+		status, code, message := lifecycleError(err, operation+" transfer of")
+		response.Error(w, status, code, message)
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type VacationHandler struct {
+	service *service.VacationService
+}
+
+func NewVacationHandler(svc *service.VacationService) *VacationHandler {
+	return &VacationHandler{service: svc}
+}
+
+// Get handles GET /api/v1/operator/vacation
+func (h *VacationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	vacation, err := h.service.Get(r.Context(), operatorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewVacationResponse(vacation))
+}
+
+// Start handles PUT /api/v1/operator/vacation
+// New allocations to the caller stop at once. Their ALLOCATED conversations
+// are optionally drained to the queue or to colleagues over the ramp-down.
+func (h *VacationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	req, err := dto.ParseJSON[dto.StartVacationRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	vacation, err := h.service.Start(r.Context(), tenantID, operatorID, req.GetDrain(), req.ColleagueIDs, req.GetRampDown())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewVacationResponse(vacation))
+}
+
+// End handles DELETE /api/v1/operator/vacation
+func (h *VacationHandler) End(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+	operatorID, _ := middleware.GetOperatorUUID(r.Context())
+
+	if err := h.service.End(r.Context(), tenantID, operatorID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+func (h *VacationHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrVacationNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeVacationNotFound,
+			"Operator is not on vacation")
+	case errors.Is(err, service.ErrVacationColleagueNotFound):
+		response.Error(w, http.StatusBadRequest, dto.ErrCodeVacationColleagueNotFound,
+			"Colleague not found")
+	case errors.Is(err, domain.ErrInvalidVacation):
+		response.ValidationError(w, "Invalid vacation")
+	default:
+		handleServiceError(w, err, "Failed to update vacation")
+	}
+}
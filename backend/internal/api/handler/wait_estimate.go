@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type WaitEstimateHandler struct {
+	service *service.WaitEstimateService
+}
+
+func NewWaitEstimateHandler(svc *service.WaitEstimateService) *WaitEstimateHandler {
+	return &WaitEstimateHandler{service: svc}
+}
+
+// Get handles GET /api/v1/public/wait-estimate?inbox_id=
+func (h *WaitEstimateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req := dto.ParseWaitEstimateRequest(r)
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	estimate, err := h.service.Estimate(r.Context(), tenantID, req.GetInboxID())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeInboxNotFound, "Inbox not found")
+			return
+		}
+		handleServiceError(w, err, "Failed to estimate wait time")
+		return
+	}
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.service.CacheTTL().Seconds())))
+	response.OK(w, dto.NewWaitEstimateResponse(estimate))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+const (
+	defaultDeliveryListLimit = 50
+	maxDeliveryListLimit     = 200
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(svc *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: svc}
+}
+
+// List handles GET /api/v1/webhooks
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	webhooks, err := h.service.ListWebhooks(r.Context(), tenantID)
+	if err != nil {
+		handleServiceError(w, err, "Failed to list webhooks")
+		return
+	}
+
+	items := make([]dto.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		items[i] = dto.NewWebhookResponse(webhook)
+	}
+
+	pagination := dto.ParsePagination(r)
+	response.OK(w, dto.WebhookListResponse{
+		Webhooks: items,
+		Meta:     dto.NewListMeta(pagination.Page, pagination.PerPage, len(items)),
+	})
+}
+
+// Create handles POST /api/v1/webhooks
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := middleware.GetTenantUUID(r.Context())
+	if !ok {
+		response.Error(w, http.StatusBadRequest, response.ErrCodeTenantRequired, "X-Tenant-ID required")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.CreateWebhookRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(r.Context(), tenantID, optionalOperatorID(r), req.URL, req.Secret, req.ToEventTypes(), req.ToOperatorFields())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Created(w, dto.NewWebhookCreatedResponse(webhook))
+}
+
+// GetByID handles GET /api/v1/webhooks/{id}
+func (h *WebhookHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid webhook ID")
+		return
+	}
+
+	webhook, err := h.service.GetWebhook(r.Context(), tenantID, id)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewWebhookResponse(webhook))
+}
+
+// Update handles PUT /api/v1/webhooks/{id}
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid webhook ID")
+		return
+	}
+
+	req, err := dto.ParseJSON[dto.UpdateWebhookRequest](r)
+	if err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		response.ValidationError(w, "Validation failed", errs...)
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(r.Context(), tenantID, id, req.URL, req.Secret, req.ToEventTypes(), req.ToOperatorFields(), req.IsActive)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.OK(w, dto.NewWebhookResponse(webhook))
+}
+
+// Delete handles DELETE /api/v1/webhooks/{id}
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), tenantID, id); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/{id}/deliveries?limit=
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	tenantID, _ := middleware.GetTenantUUID(r.Context())
+
+	id, err := dto.ParseUUIDParam(r, "id")
+	if err != nil {
+		response.BadRequest(w, "Invalid webhook ID")
+		return
+	}
+
+	limit := defaultDeliveryListLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			response.Error(w, http.StatusBadRequest, "INVALID_QUERY", "limit must be a positive integer")
+			return
+		}
+		limit = min(parsed, maxDeliveryListLimit)
+	}
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), tenantID, id, limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	items := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = dto.NewWebhookDeliveryResponse(delivery)
+	}
+
+	response.OK(w, dto.WebhookDeliveryListResponse{Deliveries: items})
+}
+
+// ==================== Error Handling ====================
+
+func (h *WebhookHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		response.Error(w, http.StatusNotFound, dto.ErrCodeWebhookNotFound,
+			"Webhook not found")
+	default:
+		handleServiceError(w, err, "Failed to process webhook operation")
+	}
+}
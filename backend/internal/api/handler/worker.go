@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// WorkerScheduler reports when the background workers of this replica run
+type WorkerScheduler interface {
+	Schedules() []domain.WorkerSchedule
+}
+
+type WorkerHandler struct {
+	scheduler WorkerScheduler
+}
+
+func NewWorkerHandler(scheduler WorkerScheduler) *WorkerHandler {
+	return &WorkerHandler{scheduler: scheduler}
+}
+
+// List handles GET /api/v1/admin/workers
+// Reports the schedule, jitter and phase of each worker of the replica
+// serving the request, and when it runs next
+func (h *WorkerHandler) List(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, dto.NewWorkerScheduleListResponse(h.scheduler.Schedules()))
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/inbox-allocation-service/internal/api/handler"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
+)
+
+// InternalRouterConfig holds dependencies for the internal router
+type InternalRouterConfig struct {
+	Logger   *logger.Logger
+	Services *ServiceContainer
+	// AllowedClients lists the client certificate common names accepted;
+	// empty accepts any certificate the listener verified
+	AllowedClients []string
+	// RateLimiter limits requests per client certificate and tenant
+	RateLimiter *ratelimit.Limiter
+}
+
+// NewInternalRouter creates the router of the internal listener, which the
+// messaging gateway calls over mutual TLS. It exposes only the machine
+// endpoints (ingestion, received messages and reconciliation) and never the
+// operator-facing API or its authentication.
+func NewInternalRouter(cfg InternalRouterConfig) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Recovery(cfg.Logger))
+	r.Use(middleware.Logger(cfg.Logger))
+
+	r.Route("/internal/v1", func(r chi.Router) {
+		r.Use(middleware.ClientCertAuth(cfg.AllowedClients))
+		r.Use(middleware.RequireTenant)
+		if cfg.RateLimiter != nil {
+			r.Use(middleware.RateLimit(cfg.RateLimiter))
+		}
+
+		conversationHandler := handler.NewConversationHandler(cfg.Services.Conversation)
+		reconciliationHandler := handler.NewReconciliationHandler(cfg.Services.Reconcile)
+
+		r.Post("/ingest/messages", conversationHandler.Ingest)
+		r.Post("/conversations/{id}/messages", conversationHandler.RecordMessage)
+		r.Get("/reconciliation", reconciliationHandler.Report)
+	})
+
+	return r
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/auth"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+const (
+	// APIKeyHeader carries a tenant API key for service-to-service calls
+	APIKeyHeader = "X-API-Key"
+
+	APIKeyIDKey ContextKey = "api_key_id"
+)
+
+// TokenVerifier validates a bearer token and returns its claims
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (auth.Claims, error)
+}
+
+// APIKeyAuthenticator resolves X-API-Key values to tenant API keys
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*domain.APIKey, error)
+}
+
+// AuthConfig configures request authentication
+type AuthConfig struct {
+	// DevMode trusts the X-Tenant-ID / X-Operator-ID headers without a token.
+	// For local development only.
+	DevMode  bool
+	Verifier TokenVerifier
+	// APIKeys enables X-API-Key authentication when set
+	APIKeys APIKeyAuthenticator
+
+	// Claim names carrying the tenant, operator and role
+	TenantClaim   string
+	OperatorClaim string
+	RoleClaim     string
+}
+
+// Authenticate establishes the caller's tenant, operator and role.
+// A request carrying X-API-Key is authenticated by that key alone: the key's
+// tenant and role, no operator. Otherwise, outside dev mode, a valid
+// "Authorization: Bearer <JWT>" is required and the identity comes only from
+// its claims; X-Tenant-ID / X-Operator-ID are ignored. The tenant claim is
+// required, operator and role are optional (the role is otherwise loaded from
+// the operator record by OperatorLoader).
+func Authenticate(cfg AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var identity http.Handler
+		if cfg.DevMode {
+			identity = TenantContext(next)
+		} else {
+			identity = bearerAuth(cfg, next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if raw := r.Header.Get(APIKeyHeader); raw != "" && cfg.APIKeys != nil {
+				apiKeyAuth(cfg.APIKeys, next, w, r, raw)
+				return
+			}
+			identity.ServeHTTP(w, r)
+		})
+	}
+}
+
+func apiKeyAuth(keys APIKeyAuthenticator, next http.Handler, w http.ResponseWriter, r *http.Request, raw string) {
+	key, err := keys.Authenticate(r.Context(), raw)
+	if err != nil {
+		if errors.Is(err, service.ErrAPIKeyInvalid) {
+			response.Unauthorized(w, "Invalid or revoked API key")
+			return
+		}
+		response.InternalError(w, "Failed to authenticate API key")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), TenantIDKey, key.TenantID)
+	ctx = context.WithValue(ctx, OperatorRoleKey, key.Role)
+	ctx = context.WithValue(ctx, APIKeyIDKey, key.ID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func bearerAuth(cfg AuthConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			response.Unauthorized(w, "Bearer token required")
+			return
+		}
+
+		claims, err := cfg.Verifier.Verify(r.Context(), token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			response.Unauthorized(w, "Invalid token: "+auth.Describe(err))
+			return
+		}
+
+		tenantID, err := uuid.Parse(claims.String(cfg.TenantClaim))
+		if err != nil {
+			response.Unauthorized(w, "Token has no valid "+cfg.TenantClaim+" claim")
+			return
+		}
+		ctx := context.WithValue(r.Context(), TenantIDKey, tenantID)
+
+		if raw := claims.String(cfg.OperatorClaim); raw != "" {
+			operatorID, err := uuid.Parse(raw)
+			if err != nil {
+				response.Unauthorized(w, "Token has an invalid "+cfg.OperatorClaim+" claim")
+				return
+			}
+			ctx = context.WithValue(ctx, OperatorIDKey, operatorID)
+		}
+
+		if raw := claims.String(cfg.RoleClaim); raw != "" {
+			role := domain.OperatorRole(strings.ToUpper(raw))
+			if !role.IsValid() {
+				response.Unauthorized(w, "Token has an invalid "+cfg.RoleClaim+" claim")
+				return
+			}
+			ctx = context.WithValue(ctx, OperatorRoleKey, role)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetAPIKeyID returns the API key that authenticated the request, if any
+func GetAPIKeyID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(APIKeyIDKey).(uuid.UUID)
+	return id, ok
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
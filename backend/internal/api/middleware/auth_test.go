@@ -0,0 +1,183 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/auth"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+type fakeVerifier struct {
+	tokens map[string]auth.Claims
+}
+
+func (f fakeVerifier) Verify(ctx context.Context, token string) (auth.Claims, error) {
+	claims, ok := f.tokens[token]
+	if !ok {
+		return nil, auth.ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func newAuthConfig(tokens map[string]auth.Claims) middleware.AuthConfig {
+	return middleware.AuthConfig{
+		Verifier:      fakeVerifier{tokens: tokens},
+		TenantClaim:   "tenant_id",
+		OperatorClaim: "operator_id",
+		RoleClaim:     "role",
+	}
+}
+
+func TestAuthenticate_ExtractsClaims(t *testing.T) {
+	tenantID, operatorID := uuid.New(), uuid.New()
+	cfg := newAuthConfig(map[string]auth.Claims{
+		"good": {"tenant_id": tenantID.String(), "operator_id": operatorID.String(), "role": "manager"},
+	})
+
+	handler := middleware.Authenticate(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, _ := middleware.GetTenantUUID(r.Context()); id != tenantID {
+			t.Errorf("expected tenant %s, got %s", tenantID, id)
+		}
+		if id, _ := middleware.GetOperatorUUID(r.Context()); id != operatorID {
+			t.Errorf("expected operator %s, got %s", operatorID, id)
+		}
+		if role, _ := middleware.GetOperatorRole(r.Context()); role != domain.OperatorRoleManager {
+			t.Errorf("expected role MANAGER, got %s", role)
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer good")
+	req.Header.Set("X-Tenant-ID", uuid.New().String())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestAuthenticate_Rejects(t *testing.T) {
+	cfg := newAuthConfig(map[string]auth.Claims{
+		"no-tenant":    {"operator_id": uuid.New().String()},
+		"bad-operator": {"tenant_id": uuid.New().String(), "operator_id": "alice"},
+		"bad-role":     {"tenant_id": uuid.New().String(), "role": "OWNER"},
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "missing token"},
+		{name: "wrong scheme", authorization: "Basic dXNlcjpwYXNz"},
+		{name: "invalid token", authorization: "Bearer expired"},
+		{name: "missing tenant claim", authorization: "Bearer no-tenant"},
+		{name: "invalid operator claim", authorization: "Bearer bad-operator"},
+		{name: "invalid role claim", authorization: "Bearer bad-role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := middleware.Authenticate(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("handler should not be called")
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("X-Tenant-ID", uuid.New().String())
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("expected 401, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_DevModeTrustsHeaders(t *testing.T) {
+	tenantID := uuid.New()
+
+	handler := middleware.Authenticate(middleware.AuthConfig{DevMode: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, _ := middleware.GetTenantUUID(r.Context()); id != tenantID {
+			t.Errorf("expected tenant %s, got %s", tenantID, id)
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Tenant-ID", tenantID.String())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rr.Code)
+	}
+}
+
+type fakeAPIKeys struct {
+	keys map[string]*domain.APIKey
+}
+
+func (f fakeAPIKeys) Authenticate(ctx context.Context, raw string) (*domain.APIKey, error) {
+	key, ok := f.keys[raw]
+	if !ok {
+		return nil, service.ErrAPIKeyInvalid
+	}
+	return key, nil
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	key := &domain.APIKey{ID: uuid.New(), TenantID: uuid.New(), Role: domain.OperatorRoleManager}
+	cfg := newAuthConfig(nil)
+	cfg.APIKeys = fakeAPIKeys{keys: map[string]*domain.APIKey{"iak_good": key}}
+
+	t.Run("valid key", func(t *testing.T) {
+		handler := middleware.Authenticate(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, _ := middleware.GetTenantUUID(r.Context()); id != key.TenantID {
+				t.Errorf("expected tenant %s, got %s", key.TenantID, id)
+			}
+			if role, _ := middleware.GetOperatorRole(r.Context()); role != domain.OperatorRoleManager {
+				t.Errorf("expected role MANAGER, got %s", role)
+			}
+			if id, _ := middleware.GetAPIKeyID(r.Context()); id != key.ID {
+				t.Errorf("expected api key %s, got %s", key.ID, id)
+			}
+			if _, ok := middleware.GetOperatorUUID(r.Context()); ok {
+				t.Error("expected no operator for an API key")
+			}
+		}))
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(middleware.APIKeyHeader, "iak_good")
+		req.Header.Set("X-Tenant-ID", uuid.New().String())
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d", rr.Code)
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		handler := middleware.Authenticate(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler should not be called")
+		}))
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(middleware.APIKeyHeader, "iak_revoked")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/response"
+)
+
+// ClientCertKey is the context key for the common name of the verified TLS
+// client certificate
+const ClientCertKey ContextKey = "client_cert"
+
+// ClientCertAuth authenticates machine callers of the internal listener by
+// their TLS client certificate, which the listener has already verified
+// against the client CA. allowed lists the accepted common names; empty
+// accepts any verified certificate. The client acts for the tenant in
+// X-Tenant-ID; no operator or role is established.
+func ClientCertAuth(allowed []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+				response.Unauthorized(w, "Client certificate required")
+				return
+			}
+			name := r.TLS.VerifiedChains[0][0].Subject.CommonName
+			if len(allowed) > 0 && !slices.Contains(allowed, name) {
+				response.Forbidden(w, "Client certificate not allowed")
+				return
+			}
+			ctx := context.WithValue(r.Context(), ClientCertKey, name)
+
+			if raw := r.Header.Get(TenantIDHeader); raw != "" {
+				tenantID, err := uuid.Parse(raw)
+				if err != nil {
+					response.BadRequest(w, "Invalid tenant ID format")
+					return
+				}
+				ctx = context.WithValue(ctx, TenantIDKey, tenantID)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetClientCert returns the common name of the client certificate that
+// authenticated the request, if any
+func GetClientCert(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(ClientCertKey).(string)
+	return name, ok
+}
@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
+)
+
+func requestWithClientCert(name string, tenantID uuid.UUID) *http.Request {
+	req := httptest.NewRequest("POST", "/internal/v1/ingest/messages", nil)
+	req.Header.Set(middleware.TenantIDHeader, tenantID.String())
+	req.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: name}}}},
+	}
+	return req
+}
+
+func TestClientCertAuth(t *testing.T) {
+	tenantID := uuid.New()
+	handler := middleware.ClientCertAuth([]string{"messaging-gateway"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, _ := middleware.GetClientCert(r.Context()); name != "messaging-gateway" {
+			t.Errorf("expected client messaging-gateway, got %q", name)
+		}
+		if id, _ := middleware.GetTenantUUID(r.Context()); id != tenantID {
+			t.Errorf("expected tenant %s, got %s", tenantID, id)
+		}
+		if _, ok := middleware.GetOperatorUUID(r.Context()); ok {
+			t.Error("expected no operator")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithClientCert("messaging-gateway", tenantID))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 for an allowed client, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithClientCert("reporting", tenantID))
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a client not allowed, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest("POST", "/internal/v1/ingest/messages", nil)
+	req.Header.Set(middleware.TenantIDHeader, tenantID.String())
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a client certificate, got %d", rr.Code)
+	}
+
+	req = requestWithClientCert("messaging-gateway", tenantID)
+	req.Header.Set(middleware.TenantIDHeader, "not-a-uuid")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid tenant ID, got %d", rr.Code)
+	}
+}
+
+func TestRateLimit_PerClientCertAndTenant(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Config{Rate: 1, Burst: 1})
+	handler := middleware.ClientCertAuth(nil)(middleware.RateLimit(limiter)(okHandler()))
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithClientCert("gateway", tenantA))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected first request allowed, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithClientCert("gateway", tenantA))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 for the same client and tenant, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithClientCert("gateway", tenantB))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected another tenant to have its own limit, got %d", rr.Code)
+	}
+}
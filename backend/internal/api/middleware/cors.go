@@ -20,8 +20,8 @@ func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Tenant-ID", "X-Operator-ID", "X-Request-ID"},
-		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Tenant-ID", "X-Operator-ID", "X-API-Key", "X-Request-ID"},
+		ExposedHeaders:   []string{"X-Request-ID", SandboxHeader},
 		AllowCredentials: false,
 		MaxAge:           86400, // 24 hours
 	}
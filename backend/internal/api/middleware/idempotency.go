@@ -2,9 +2,12 @@ package middleware
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"net/http"
 
+	"github.com/inbox-allocation-service/internal/api/response"
 	"github.com/inbox-allocation-service/internal/service"
 )
 
@@ -13,6 +16,8 @@ const (
 	IdempotencyKeyHeader = "X-Idempotency-Key"
 	// IdempotencyReplayHeader indicates a replayed response
 	IdempotencyReplayHeader = "X-Idempotency-Replay"
+	// IdempotencyDegradedHeader marks a response processed without idempotency protection
+	IdempotencyDegradedHeader = "X-Idempotency-Degraded"
 )
 
 // responseRecorder captures the response for caching
@@ -40,8 +45,9 @@ func (r *responseRecorder) Write(b []byte) (int, error) {
 	return r.ResponseWriter.Write(b)
 }
 
-// Idempotency creates middleware for idempotency key handling
-func Idempotency(svc *service.IdempotencyService) func(http.Handler) http.Handler {
+// Idempotency creates middleware for idempotency key handling.
+// class selects the degradation policy applied when idempotency storage is unavailable.
+func Idempotency(svc *service.IdempotencyService, class service.EndpointClass) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Only apply to mutation methods
@@ -75,11 +81,31 @@ func Idempotency(svc *service.IdempotencyService) func(http.Handler) http.Handle
 				r.Body = io.NopCloser(bytes.NewBuffer(requestBody))
 			}
 
-			// Check if key exists
-			cached, err := svc.CheckKey(r.Context(), tenantID, key, requestBody)
+			// Claim the key, so that of concurrent requests with it only one
+			// executes and the others replay its response
+			claim, cached, err := svc.Claim(r.Context(), tenantID, key, r.URL.Path, r.Method, requestBody)
 			if err != nil {
 				if err == service.ErrRequestHashMismatch {
-					http.Error(w, "Idempotency key reused with different request", http.StatusUnprocessableEntity)
+					response.Error(w, http.StatusUnprocessableEntity, response.ErrCodeIdempotencyKeyReused,
+						"Idempotency key reused with different request")
+					return
+				}
+				if err == service.ErrIdempotencyKeyInProgress {
+					w.Header().Set("Retry-After", "1")
+					response.Error(w, http.StatusConflict, response.ErrCodeIdempotencyKeyInProgress,
+						"A request with this idempotency key is still in progress, retry later")
+					return
+				}
+				if errors.Is(err, service.ErrIdempotencyUnavailable) {
+					if !svc.Degrade(class, r.URL.Path, err) {
+						w.Header().Set("Retry-After", "5")
+						response.Error(w, http.StatusServiceUnavailable, response.ErrCodeIdempotencyUnavailable,
+							"Idempotency storage is unavailable, retry later")
+						return
+					}
+					// Fail-open: process without storing the result
+					w.Header().Set(IdempotencyDegradedHeader, "true")
+					next.ServeHTTP(w, r.WithContext(service.WithIdempotencyKey(r.Context(), key)))
 					return
 				}
 				// Log error but proceed with request
@@ -87,36 +113,48 @@ func Idempotency(svc *service.IdempotencyService) func(http.Handler) http.Handle
 				return
 			}
 
-			// If cached response exists, return it
+			// If cached response exists, return it. Only 2xx and 4xx are
+			// stored, and every 4xx is a problem
 			if cached != nil {
-				w.Header().Set("Content-Type", "application/json; charset=utf-8")
 				w.Header().Set(IdempotencyReplayHeader, "true")
+				if cached.Status >= 400 {
+					response.ReplayProblem(w, cached.Status, cached.Body)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
 				w.WriteHeader(cached.Status)
 				w.Write(cached.Body)
 				return
 			}
 
-			// No cached response, execute request and capture result
+			// The claim is settled even when the client goes away or the
+			// handler panics, so that a retry does not wait for the lock
+			// timeout
+			settleCtx := context.WithoutCancel(r.Context())
+			settled := false
+			defer func() {
+				if !settled {
+					svc.Release(settleCtx, claim)
+				}
+			}()
+
+			// Execute the request and capture the result.
+			// The key also goes to the service, which journals it with the
+			// allocation so a retry after a crash can be recovered.
+			r = r.WithContext(service.WithIdempotencyKey(r.Context(), key))
 			recorder := newResponseRecorder(w)
 			next.ServeHTTP(recorder, r)
 
 			// Store result (only for successful responses or specific errors)
-			// Store for 2xx and 4xx (not 5xx which might be transient)
-			if recorder.status < 500 {
-				err := svc.StoreResult(
-					r.Context(),
-					tenantID,
-					key,
-					r.URL.Path,
-					r.Method,
-					requestBody,
-					recorder.status,
-					recorder.body.Bytes(),
-				)
-				if err != nil {
-					// Log error but don't fail the request
-					// The response was already sent
-				}
+			// Store for 2xx and 4xx (not 5xx which might be transient, nor
+			// responses asking the client to retry, such as an allocation
+			// still in progress under this key); otherwise the claim is
+			// released so that a retry executes again
+			if recorder.status < 500 && recorder.Header().Get("Retry-After") == "" {
+				err := svc.Complete(settleCtx, claim, recorder.status, recorder.body.Bytes())
+				// The response was already sent; a claim that could not be
+				// completed is released
+				settled = err == nil || errors.Is(err, service.ErrIdempotencyClaimLost)
 			}
 		})
 	}
@@ -0,0 +1,68 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+	"github.com/inbox-allocation-service/internal/testutil"
+)
+
+func TestIdempotency_ReplaysProblem(t *testing.T) {
+	repos := &repository.RepositoryContainer{Idempotency: testutil.NewMockIdempotencyRepository()}
+	svc := service.NewIdempotencyService(repos, service.DefaultIdempotencyConfig(), logger.NewNop())
+
+	calls := 0
+	handler := middleware.Idempotency(svc, service.EndpointClassLifecycle)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		response.ValidationError(w, "Validation failed", "reason is required")
+	}))
+	tenantID := uuid.New()
+
+	send := func(correlationID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/conversations/resolve", strings.NewReader(`{}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		req.Header.Set(middleware.HeaderXCorrelationID, correlationID)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.TenantIDKey, tenantID))
+		rr := httptest.NewRecorder()
+		middleware.RequestID(handler).ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := send("first-request")
+	replay := send("retry-request")
+
+	if calls != 1 {
+		t.Fatalf("Expected the handler to run once, ran %d times", calls)
+	}
+	if replay.Code != http.StatusBadRequest {
+		t.Errorf("Expected replayed status 400, got %d", replay.Code)
+	}
+	if replay.Header().Get(middleware.IdempotencyReplayHeader) != "true" {
+		t.Error("Expected replay header on the second response")
+	}
+	if got := replay.Header().Get("Content-Type"); got != first.Header().Get("Content-Type") || got != response.ProblemContentType {
+		t.Errorf("Expected replayed Content-Type %s, got %s", response.ProblemContentType, got)
+	}
+
+	var problem response.Problem
+	if err := json.Unmarshal(replay.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Replayed body is not a problem: %v", err)
+	}
+	if problem.Code != response.ErrCodeValidation || len(problem.Errors) != 1 {
+		t.Errorf("Expected the stored problem, got %+v", problem)
+	}
+	if problem.CorrelationID != "retry-request" {
+		t.Errorf("Expected the retry's correlation ID, got %q", problem.CorrelationID)
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
@@ -18,8 +19,8 @@ func Logger(log *logger.Logger) func(http.Handler) http.Handler {
 			// Create request-scoped logger with context fields
 			reqLogger := log.WithContext(r.Context()).WithFields(
 				zap.String("method", r.Method),
-				zap.String("path", r.URL.Path),
-				zap.String("query", r.URL.RawQuery),
+				zap.String("path", redactPath(r.URL.Path)),
+				zap.String("query", redactQuery(r.URL.RawQuery)),
 				zap.String("remote_addr", r.RemoteAddr),
 				zap.String("user_agent", r.UserAgent()),
 				zap.Int64("content_length", r.ContentLength),
@@ -60,6 +61,47 @@ func Logger(log *logger.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// redactedQueryParams are credentials some routes take in the query string,
+// such as the realtime token on GET /realtime/events
+var redactedQueryParams = map[string]bool{
+	"token": true,
+}
+
+// redactQuery replaces the values of credential parameters in a raw query,
+// leaving the rest of it as sent
+func redactQuery(raw string) string {
+	if raw == "" {
+		return raw
+	}
+	params := strings.Split(raw, "&")
+	for i, param := range params {
+		key, _, hasValue := strings.Cut(param, "=")
+		if hasValue && redactedQueryParams[key] {
+			params[i] = key + "=REDACTED"
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// redactedPathPrefixes precede a credential path segment, such as the share
+// link token on GET /share/{token}
+var redactedPathPrefixes = []string{"/share/"}
+
+// redactPath replaces the credential segment of paths that carry one
+func redactPath(path string) string {
+	for _, prefix := range redactedPathPrefixes {
+		rest, ok := strings.CutPrefix(path, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		if _, tail, hasTail := strings.Cut(rest, "/"); hasTail {
+			return prefix + "REDACTED/" + tail
+		}
+		return prefix + "REDACTED"
+	}
+	return path
+}
+
 // LoggerWithSampling returns a logger that samples high-volume requests
 func LoggerWithSampling(log *logger.Logger, sampleRate int) func(http.Handler) http.Handler {
 	counter := 0
@@ -79,7 +121,7 @@ func LoggerWithSampling(log *logger.Logger, sampleRate int) func(http.Handler) h
 			if shouldLog || ww.Status() >= 400 {
 				reqLogger.Info("request completed",
 					zap.String("method", r.Method),
-					zap.String("path", r.URL.Path),
+					zap.String("path", redactPath(r.URL.Path)),
 					zap.Int("status", ww.Status()),
 					zap.Duration("duration", time.Since(start)),
 				)
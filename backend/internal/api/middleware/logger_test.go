@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+)
+
+func TestLogger_RedactsTokenQueryParam(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := middleware.Logger(&logger.Logger{Logger: zap.New(core)})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	const token = "eyJ0ZW5hbnQiOiJ0In0.c2lnbmF0dXJl"
+	req := httptest.NewRequest("GET", "/realtime/events?inbox_id=42&token="+token, nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.All()
+	if len(entries) == 0 {
+		t.Fatal("Expected request log entries")
+	}
+	for _, entry := range entries {
+		query, _ := entry.ContextMap()["query"].(string)
+		if strings.Contains(query, token) {
+			t.Errorf("%q logged the token in query %q", entry.Message, query)
+		}
+		if query != "inbox_id=42&token=REDACTED" {
+			t.Errorf("%q logged query %q, want inbox_id=42&token=REDACTED", entry.Message, query)
+		}
+	}
+}
+
+func TestLogger_RedactsShareToken(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := middleware.Logger(&logger.Logger{Logger: zap.New(core)})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	const token = "c2hhcmUtbGluay1wYXlsb2Fk.c2lnbmF0dXJl"
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/share/"+token, nil))
+
+	entries := logs.All()
+	if len(entries) == 0 {
+		t.Fatal("Expected request log entries")
+	}
+	for _, entry := range entries {
+		if path, _ := entry.ContextMap()["path"].(string); path != "/share/REDACTED" {
+			t.Errorf("%q logged path %q, want /share/REDACTED", entry.Message, path)
+		}
+	}
+}
+
+func TestRecovery_RedactsShareToken(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := middleware.Recovery(&logger.Logger{Logger: zap.New(core)})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	const token = "c2hhcmUtbGluay1wYXlsb2Fk.c2lnbmF0dXJl"
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/share/"+token, nil))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one panic log entry, got %d", len(entries))
+	}
+	if path, _ := entries[0].ContextMap()["path"].(string); strings.Contains(path, token) {
+		t.Errorf("Panic log contains the share token in path %q", path)
+	}
+}
@@ -11,11 +11,17 @@ import (
 
 const OperatorRoleKey ContextKey = "operator_role"
 
-// OperatorLoader loads operator role into context
+// OperatorLoader loads operator role into context. A role already set from
+// token claims (see Authenticate) takes precedence over the stored role.
 func OperatorLoader(repos *repository.RepositoryContainer) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
+			if _, ok := GetOperatorRole(ctx); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			operatorID, ok := GetOperatorUUID(ctx)
 			if !ok {
 				next.ServeHTTP(w, r)
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
+)
+
+// RequireAPIKey ensures the request was authenticated with an API key
+func RequireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetAPIKeyID(r.Context()); !ok {
+			response.Unauthorized(w, "API key required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimit limits requests per API key, per client certificate and tenant
+// on the internal listener, or per tenant otherwise. Rejected requests get
+// 429 with a Retry-After header.
+func RateLimit(limiter *ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := "tenant:" + GetTenantID(r.Context())
+			if apiKeyID, ok := GetAPIKeyID(r.Context()); ok {
+				key = "api_key:" + apiKeyID.String()
+			} else if client, ok := GetClientCert(r.Context()); ok {
+				key = "client:" + client + ":" + GetTenantID(r.Context())
+			}
+
+			if ok, retryAfter := limiter.Allow(key); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				response.TooManyRequests(w, "Rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
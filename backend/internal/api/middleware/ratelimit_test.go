@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func requestWithAPIKey(apiKeyID uuid.UUID) *http.Request {
+	req := httptest.NewRequest("GET", "/", nil)
+	ctx := context.WithValue(req.Context(), middleware.TenantIDKey, uuid.New())
+	ctx = context.WithValue(ctx, middleware.APIKeyIDKey, apiKeyID)
+	return req.WithContext(ctx)
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	handler := middleware.RequireAPIKey(okHandler())
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithAPIKey(uuid.New()))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 with an API key, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.TenantIDKey, uuid.New()))
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without an API key, got %d", rr.Code)
+	}
+}
+
+func TestRateLimit_PerAPIKey(t *testing.T) {
+	handler := middleware.RateLimit(ratelimit.New(ratelimit.Config{Rate: 1, Burst: 1}))(okHandler())
+	limited, other := uuid.New(), uuid.New()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithAPIKey(limited))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithAPIKey(limited))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", rr.Code)
+	}
+	if rr.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected Retry-After 1, got %q", rr.Header().Get("Retry-After"))
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithAPIKey(other))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 for another API key, got %d", rr.Code)
+	}
+}
@@ -23,7 +23,7 @@ func Recovery(log *logger.Logger) func(http.Handler) http.Handler {
 						zap.Any("error", err),
 						zap.String("stack", string(stack)),
 						zap.String("method", r.Method),
-						zap.String("path", r.URL.Path),
+						zap.String("path", redactPath(r.URL.Path)),
 					)
 
 					response.InternalError(w, "An unexpected error occurred")
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/response"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
 )
 
@@ -11,7 +12,7 @@ const (
 	// HeaderXRequestID is the header name for request ID
 	HeaderXRequestID = "X-Request-ID"
 	// HeaderXCorrelationID is the header name for correlation ID
-	HeaderXCorrelationID = "X-Correlation-ID"
+	HeaderXCorrelationID = response.HeaderCorrelationID
 )
 
 // RequestID middleware generates or extracts request/correlation IDs
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/inbox-allocation-service/internal/repository"
+)
+
+// SandboxHeader watermarks responses to sandbox tenants, whose data is demo
+// data only
+const SandboxHeader = "X-Sandbox"
+
+// SandboxWatermark sets SandboxHeader on every response to a sandbox tenant.
+// The tenant is read through the tenant cache; when it cannot be read the
+// response is left unmarked.
+func SandboxWatermark(repos *repository.RepositoryContainer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tenantID, ok := GetTenantUUID(r.Context()); ok {
+				if tenant, err := repos.Tenants.GetByID(r.Context(), tenantID); err == nil && tenant.Sandbox {
+					w.Header().Set(SandboxHeader, "true")
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,9 +1,6 @@
 package response
 
-import (
-	"net/http"
-	"time"
-)
+import "net/http"
 
 // ErrorCode represents application-specific error codes
 type ErrorCode string
@@ -27,34 +24,21 @@ const (
 	ErrCodeConversationLocked ErrorCode = "CONVERSATION_LOCKED"
 	ErrCodeTenantRequired     ErrorCode = "TENANT_REQUIRED"
 	ErrCodeOperatorRequired   ErrorCode = "OPERATOR_REQUIRED"
-)
-
-// ErrorResponse is the standard error response format
-type ErrorResponse struct {
-	Success   bool      `json:"success"`
-	Error     ErrorBody `json:"error"`
-	Timestamp time.Time `json:"timestamp"`
-}
 
-// ErrorBody contains error details
-type ErrorBody struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
-	Details []string  `json:"details,omitempty"`
-}
+	// Infrastructure errors
+	ErrCodeIdempotencyUnavailable ErrorCode = "IDEMPOTENCY_UNAVAILABLE"
+	// A request with the same idempotency key is still being processed
+	ErrCodeIdempotencyKeyInProgress ErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	// An idempotency key was reused with a different request
+	ErrCodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+)
 
-// Error sends an error response
+// Error sends an error response as an RFC 7807 problem. details, if any,
+// become the problem's errors list.
 func Error(w http.ResponseWriter, status int, code ErrorCode, message string, details ...string) {
-	response := ErrorResponse{
-		Success: false,
-		Error: ErrorBody{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-		Timestamp: time.Now().UTC(),
-	}
-	writeJSON(w, status, response)
+	problem := NewProblem(status, code, message, details...)
+	problem.CorrelationID = w.Header().Get(HeaderCorrelationID)
+	writeProblem(w, problem)
 }
 
 // BadRequest sends a 400 Bad Request error
@@ -0,0 +1,67 @@
+package response
+
+import "net/http"
+
+// MultiStatus is the body of a batch request whose items succeed or fail
+// independently. Results are in request order, each with the HTTP status and
+// error code the single-item endpoint would have returned, and the resource
+// on success. It is sent as 200 when every item succeeded and 207 Multi-Status
+// otherwise.
+type MultiStatus struct {
+	Results   []ItemResult `json:"results"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+}
+
+// ItemResult is the outcome of one item of a batch request
+type ItemResult struct {
+	// Index is the item's position in the request
+	Index int `json:"index"`
+	// ID identifies the item's resource, empty when it has none yet
+	ID       string      `json:"id,omitempty"`
+	Status   int         `json:"status"`
+	Success  bool        `json:"success"`
+	Resource interface{} `json:"resource,omitempty"`
+	Error    *Problem    `json:"error,omitempty"`
+}
+
+// NewMultiStatus returns an empty result list for size items
+func NewMultiStatus(size int) *MultiStatus {
+	return &MultiStatus{Results: make([]ItemResult, 0, size)}
+}
+
+// AddSuccess appends the result of an item the operation succeeded on
+func (m *MultiStatus) AddSuccess(id string, status int, resource interface{}) {
+	m.Results = append(m.Results, ItemResult{
+		Index:    len(m.Results),
+		ID:       id,
+		Status:   status,
+		Success:  true,
+		Resource: resource,
+	})
+	m.Succeeded++
+}
+
+// AddFailure appends the result of an item the operation skipped
+func (m *MultiStatus) AddFailure(id string, status int, code ErrorCode, message string) {
+	m.Results = append(m.Results, ItemResult{
+		Index:  len(m.Results),
+		ID:     id,
+		Status: status,
+		Error:  NewProblem(status, code, message),
+	})
+	m.Failed++
+}
+
+// Status is 200 when every item succeeded, 207 Multi-Status otherwise
+func (m *MultiStatus) Status() int {
+	if m.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
+
+// Multi sends the results of a batch request with their overall status
+func Multi(w http.ResponseWriter, m *MultiStatus) {
+	JSON(w, m.Status(), m)
+}
@@ -0,0 +1,57 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/response"
+)
+
+func TestMultiStatus(t *testing.T) {
+	resp := response.NewMultiStatus(2)
+	resp.AddSuccess("a", http.StatusOK, map[string]string{"state": "RESOLVED"})
+	if resp.Status() != http.StatusOK {
+		t.Errorf("all succeeded: status %d, want 200", resp.Status())
+	}
+
+	resp.AddFailure("b", http.StatusNotFound, response.ErrCodeNotFound, "Conversation not found")
+	if resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Fatalf("expected 1 succeeded and 1 failed, got %d and %d", resp.Succeeded, resp.Failed)
+	}
+	if resp.Status() != http.StatusMultiStatus {
+		t.Errorf("partial failure: status %d, want 207", resp.Status())
+	}
+
+	first, second := resp.Results[0], resp.Results[1]
+	if first.Index != 0 || !first.Success || first.Status != http.StatusOK || first.Resource == nil || first.Error != nil {
+		t.Errorf("unexpected success result: %+v", first)
+	}
+	if second.Index != 1 || second.Success || second.ID != "b" || second.Status != http.StatusNotFound ||
+		second.Error == nil || second.Error.Code != response.ErrCodeNotFound {
+		t.Errorf("unexpected failure result: %+v", second)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	resp := response.NewMultiStatus(1)
+	resp.AddFailure("a", http.StatusConflict, response.ErrCodeConflict, "Conflict")
+
+	rec := httptest.NewRecorder()
+	response.Multi(rec, resp)
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("status %d, want 207", rec.Code)
+	}
+
+	var body struct {
+		Success bool                 `json:"success"`
+		Data    response.MultiStatus `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !body.Success || body.Data.Failed != 1 || body.Data.Results[0].Error.Code != response.ErrCodeConflict {
+		t.Errorf("unexpected body: %s", rec.Body.String())
+	}
+}
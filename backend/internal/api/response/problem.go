@@ -0,0 +1,73 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// HeaderCorrelationID is the response header the RequestID middleware sets;
+// error responses echo it in the problem so a client can quote it
+const HeaderCorrelationID = "X-Correlation-ID"
+
+// problemTypePrefix namespaces the problem type URIs derived from error codes
+const problemTypePrefix = "urn:inbox-allocation:problem:"
+
+// Problem is the body of every error response, an RFC 7807 problem details
+// object extended with the application error code and the request's
+// correlation ID
+type Problem struct {
+	// Type identifies the kind of problem, derived from Code
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	// Code is the machine-readable application error code
+	Code ErrorCode `json:"code"`
+	// Errors lists individual failures, e.g. one per invalid field
+	Errors        []string `json:"errors,omitempty"`
+	CorrelationID string   `json:"correlation_id,omitempty"`
+}
+
+// NewProblem returns the problem for an error with the given status and code
+func NewProblem(status int, code ErrorCode, detail string, errs ...string) *Problem {
+	return &Problem{
+		Type:   ProblemType(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: errs,
+	}
+}
+
+// ProblemType returns the type URI of problems with the given code, e.g.
+// urn:inbox-allocation:problem:validation-error for VALIDATION_ERROR
+func ProblemType(code ErrorCode) string {
+	return problemTypePrefix + strings.ToLower(strings.ReplaceAll(string(code), "_", "-"))
+}
+
+// ReplayProblem writes a stored error response again, e.g. for an
+// idempotent retry. The correlation ID is the current request's; a body
+// that is not a problem is written unchanged.
+func ReplayProblem(w http.ResponseWriter, status int, body []byte) {
+	var problem Problem
+	if err := json.Unmarshal(body, &problem); err == nil && problem.Code != "" {
+		problem.CorrelationID = w.Header().Get(HeaderCorrelationID)
+		if replayed, err := json.Marshal(&problem); err == nil {
+			body = append(replayed, '\n')
+		}
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func writeProblem(w http.ResponseWriter, p *Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
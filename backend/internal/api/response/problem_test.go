@@ -0,0 +1,60 @@
+package response_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/api/response"
+)
+
+func TestError_WritesProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(response.HeaderCorrelationID, "req-123")
+	response.ValidationError(rec, "Invalid request", "name is required")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != response.ProblemContentType {
+		t.Errorf("content type %q, want %q", ct, response.ProblemContentType)
+	}
+
+	var problem response.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := response.Problem{
+		Type:          "urn:inbox-allocation:problem:validation-error",
+		Title:         "Bad Request",
+		Status:        http.StatusBadRequest,
+		Detail:        "Invalid request",
+		Code:          response.ErrCodeValidation,
+		Errors:        []string{"name is required"},
+		CorrelationID: "req-123",
+	}
+	if problem.Type != want.Type || problem.Title != want.Title || problem.Status != want.Status ||
+		problem.Detail != want.Detail || problem.Code != want.Code || problem.CorrelationID != want.CorrelationID ||
+		len(problem.Errors) != 1 || problem.Errors[0] != want.Errors[0] {
+		t.Errorf("problem %+v, want %+v", problem, want)
+	}
+}
+
+func TestError_OmitsEmptyMembers(t *testing.T) {
+	rec := httptest.NewRecorder()
+	response.NotFound(rec, "Inbox not found")
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, member := range []string{"errors", "correlation_id"} {
+		if _, ok := body[member]; ok {
+			t.Errorf("unexpected %s in %s", member, rec.Body.String())
+		}
+	}
+	if body["code"] != string(response.ErrCodeNotFound) {
+		t.Errorf("code %v, want %s", body["code"], response.ErrCodeNotFound)
+	}
+}
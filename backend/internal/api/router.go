@@ -1,11 +1,15 @@
 package api
 
 import (
+	"time"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/inbox-allocation-service/internal/api/handler"
 	"github.com/inbox-allocation-service/internal/api/handlers"
 	"github.com/inbox-allocation-service/internal/api/middleware"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/pkg/ratelimit"
 	"github.com/inbox-allocation-service/internal/repository"
 	"github.com/inbox-allocation-service/internal/service"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,6 +25,14 @@ type RouterConfig struct {
 	Version            string
 	BuildTime          string
 	CORSConfig         middleware.CORSConfig
+	Auth               middleware.AuthConfig
+	EventsHeartbeat    time.Duration
+	// ReadinessChecks are reported by /ready besides the database
+	ReadinessChecks []handler.ReadinessCheck
+	// PublicRateLimiter limits the customer-facing /public endpoints per API key
+	PublicRateLimiter *ratelimit.Limiter
+	// Workers reports the worker schedules of this replica
+	Workers handler.WorkerScheduler
 }
 
 // ServiceContainer holds all service instances
@@ -33,6 +45,43 @@ type ServiceContainer struct {
 	Allocation   *service.AllocationService
 	Lifecycle    *service.LifecycleService
 	Label        *service.LabelService
+	Webhook      *service.WebhookService
+	RoutingRule  *service.RoutingRuleService
+	EventStream  *service.EventStreamService
+	Push         *service.PushNotificationService
+	Presence     *service.PresenceService
+	Audit        *service.AuditService
+	Shadow       *service.ShadowService
+	QA           *service.QAService
+	Backfill     *service.BackfillService
+	Classifier   *service.ClassificationService
+	APIKey       *service.APIKeyService
+	Stats        *service.StatsService
+	QueueRanking *service.QueueRankingService
+	Anomaly      *service.AnomalyService
+	Schedule     *service.ScheduleService
+	InboxAdmin   *service.InboxAdminService
+	SLA          *service.SLAService
+	Snooze       *service.SnoozeService
+	Vacation     *service.VacationService
+	DueDate      *service.DueDateService
+	Staleness    *service.StalenessService
+	AutoResolve  *service.AutoResolveService
+	Escalation   *service.EscalationService
+	Transfer     *service.TransferService
+	Invariants   *service.InvariantService
+	Reconcile    *service.ReconciliationService
+	Maintenance  *service.MaintenanceService
+	Quotas       *service.CategoryQuotaService
+	Checklist    *service.ChecklistService
+	Health       *service.OperatorHealthService
+	WaitEstimate *service.WaitEstimateService
+	Experiment   *service.ExperimentService
+	ShareLink    *service.ShareLinkService
+	Customer     *service.CustomerService
+	Scaling      *service.ScalingAuditService
+	GracePeriod  *service.GracePeriodService
+	Sandbox      *service.SandboxService
 }
 
 // NewRouter creates and configures the Chi router
@@ -44,24 +93,34 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 	r.Use(middleware.CORS(cfg.CORSConfig)) // 2. CORS early
 	r.Use(middleware.Recovery(cfg.Logger)) // 3. Recovery before logging
 	r.Use(middleware.Logger(cfg.Logger))   // 4. Logging
-	r.Use(middleware.TenantContext)        // 5. Tenant context extraction
 
 	// Health check handlers (no tenant required)
-	healthHandler := handler.NewHealthHandler(cfg.Pool, cfg.Version, cfg.BuildTime)
+	healthHandler := handler.NewHealthHandler(cfg.Pool, cfg.Version, cfg.BuildTime, cfg.ReadinessChecks...)
 	r.Get("/health", healthHandler.Health)
 	r.Get("/ready", healthHandler.Ready)
 	r.Get("/version", healthHandler.Version)
+	r.Handle("/metrics", metrics.Handler())
 
 	// Documentation routes (no tenant required)
 	docsHandler := handlers.NewDocsHandler()
 	r.Get("/docs", docsHandler.ServeSwaggerUI)
 	r.Get("/api/openapi.yaml", docsHandler.ServeOpenAPISpec)
 
+	// Conversation snapshots shared by link (the signed token is the credential)
+	shareLinkHandler := handler.NewShareLinkHandler(cfg.Services.ShareLink)
+	r.Get("/share/{token}", shareLinkHandler.Open)
+
+	// Server-Sent Events for browser clients (the realtime token is the credential)
+	eventsHandler := handler.NewEventsHandler(cfg.Services.EventStream, cfg.EventsHeartbeat)
+	r.Get("/realtime/events", eventsHandler.StreamWithToken)
+
 	// API v1 routes (tenant required)
 	r.Route("/api/v1", func(r chi.Router) {
-		// Apply tenant requirement and operator loader to all API routes
+		// Authenticate, then apply tenant requirement and operator loader to all API routes
+		r.Use(middleware.Authenticate(cfg.Auth))
 		r.Use(middleware.RequireTenant)
 		r.Use(middleware.OperatorLoader(cfg.Repos))
+		r.Use(middleware.SandboxWatermark(cfg.Repos))
 
 		// Initialize handlers
 		operatorHandler := handler.NewOperatorHandler(cfg.Services.Operator)
@@ -72,12 +131,40 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			cfg.Services.Inbox,
 		)
 		tenantHandler := handler.NewTenantHandler(cfg.Services.Tenant)
+		sandboxHandler := handler.NewSandboxHandler(cfg.Services.Sandbox)
+		classifierHandler := handler.NewClassifierHandler(cfg.Services.Classifier)
+		queueHandler := handler.NewQueueHandler(cfg.Services.QueueRanking, cfg.Services.Conversation)
+		anomalyHandler := handler.NewAnomalyHandler(cfg.Services.Anomaly)
+		experimentHandler := handler.NewExperimentHandler(cfg.Services.Experiment)
+		scheduleHandler := handler.NewScheduleHandler(cfg.Services.Schedule)
+		inboxAdminHandler := handler.NewInboxAdminHandler(cfg.Services.InboxAdmin)
+		slaHandler := handler.NewSLAHandler(cfg.Services.SLA)
+		stalenessHandler := handler.NewStalenessHandler(cfg.Services.Staleness)
+		categoryQuotaHandler := handler.NewCategoryQuotaHandler(cfg.Services.Quotas)
+		checklistHandler := handler.NewChecklistHandler(cfg.Services.Checklist)
+		escalationHandler := handler.NewEscalationHandler(cfg.Services.Escalation)
+		autoResolveHandler := handler.NewAutoResolveHandler(cfg.Services.AutoResolve)
+		vacationHandler := handler.NewVacationHandler(cfg.Services.Vacation)
+		deviceHandler := handler.NewDeviceHandler(cfg.Services.Push)
+		conversationHandler := handler.NewConversationHandler(cfg.Services.Conversation)
 
 		// 4.1 Operator Status (any operator)
 		r.Route("/operator", func(r chi.Router) {
 			r.Use(middleware.RequireOperator)
 			r.Get("/status", operatorHandler.GetStatus)
 			r.Put("/status", operatorHandler.UpdateStatus)
+			r.Put("/focus", operatorHandler.StartFocus)
+			r.Delete("/focus", operatorHandler.EndFocus)
+			r.Get("/vacation", vacationHandler.Get)
+			r.Put("/vacation", vacationHandler.Start)
+			r.Delete("/vacation", vacationHandler.End)
+			r.Get("/schedule", scheduleHandler.GetOwn)
+			r.Get("/capabilities", inboxAdminHandler.Capabilities)
+			r.Get("/devices", deviceHandler.List)
+			r.Post("/devices", deviceHandler.Register)
+			r.Put("/devices/{id}", deviceHandler.UpdatePreferences)
+			r.Delete("/devices/{id}", deviceHandler.Delete)
+			r.Get("/conversations", conversationHandler.ListWorkload)
 		})
 
 		// 4.2 & 4.4 Inboxes
@@ -95,15 +182,36 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 				r.Get("/", inboxHandler.GetByID)
 				r.Put("/", inboxHandler.Update)
 				r.Delete("/", inboxHandler.Delete)
+				r.Get("/deletion-impact", inboxHandler.DeletionImpact)
+				r.Get("/queue", queueHandler.InboxQueue)
+				r.Get("/sla", slaHandler.GetPolicy)
+				r.Put("/sla", slaHandler.UpdatePolicy)
+				r.Delete("/sla", slaHandler.DeletePolicy)
+				r.Get("/staleness", stalenessHandler.GetPolicy)
+				r.Put("/staleness", stalenessHandler.UpdatePolicy)
+				r.Delete("/staleness", stalenessHandler.DeletePolicy)
+				r.Get("/category-quotas", categoryQuotaHandler.GetQuotas)
+				r.Put("/category-quotas", categoryQuotaHandler.UpdateQuotas)
+				r.Get("/checklist-template", checklistHandler.GetTemplate)
+				r.Put("/checklist-template", checklistHandler.UpdateTemplate)
+				r.Put("/escalation", escalationHandler.SetEscalationInbox)
+				r.Put("/auto-resolve", autoResolveHandler.SetAutoResolve)
 			})
 
-			// 4.5 Subscriptions for inbox
+			// 4.5 Subscriptions for inbox (Manager+ or inbox admin, checked by the service)
 			r.Route("/{inbox_id}/operators", func(r chi.Router) {
-				r.Use(middleware.RequireManager)
 				r.Get("/", subscriptionHandler.ListOperators)
 				r.Post("/", subscriptionHandler.Subscribe)
 				r.Delete("/{operator_id}", subscriptionHandler.Unsubscribe)
 			})
+
+			// Inbox-scoped admin grants (Admin only)
+			r.Route("/{inbox_id}/admins", func(r chi.Router) {
+				r.Use(middleware.RequireAdmin)
+				r.Get("/", inboxAdminHandler.List)
+				r.Post("/", inboxAdminHandler.Grant)
+				r.Delete("/{operator_id}", inboxAdminHandler.Revoke)
+			})
 		})
 
 		// 4.3 Operators CRUD (Admin only)
@@ -115,6 +223,15 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 				r.Get("/", operatorHandler.GetByID)
 				r.Put("/", operatorHandler.Update)
 				r.Delete("/", operatorHandler.Delete)
+				r.Get("/deletion-impact", operatorHandler.DeletionImpact)
+
+				// Working-hours schedule
+				r.Route("/schedules", func(r chi.Router) {
+					r.Get("/", scheduleHandler.List)
+					r.Post("/", scheduleHandler.Create)
+					r.Put("/{schedule_id}", scheduleHandler.Update)
+					r.Delete("/{schedule_id}", scheduleHandler.Delete)
+				})
 			})
 			// Subscriptions for operator
 			r.Get("/{operator_id}/inboxes", subscriptionHandler.ListInboxes)
@@ -125,41 +242,130 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Use(middleware.RequireAdmin)
 			r.Get("/", tenantHandler.Get)
 			r.Put("/weights", tenantHandler.UpdateWeights)
+			r.Put("/settings", tenantHandler.UpdateSettings)
+			r.Get("/classifier", classifierHandler.Get)
+			r.Put("/classifier", classifierHandler.Update)
+			r.Get("/anomaly-settings", anomalyHandler.GetSettings)
+			r.Put("/anomaly-settings", anomalyHandler.UpdateSettings)
+
+			// Sandbox mode for trial tenants: enable seeds demo data, reset
+			// replaces it with fresh demo data
+			r.Post("/sandbox", sandboxHandler.Enable)
+			r.Post("/sandbox/reset", sandboxHandler.Reset)
+
+			// Priority weight experiments
+			r.Route("/experiments", func(r chi.Router) {
+				r.Get("/", experimentHandler.List)
+				r.Post("/", experimentHandler.Create)
+				r.Get("/{id}", experimentHandler.Get)
+				r.Put("/{id}", experimentHandler.Update)
+				r.Delete("/{id}", experimentHandler.Delete)
+				r.Post("/{id}/stop", experimentHandler.Stop)
+				r.Get("/{id}/results", experimentHandler.Results)
+			})
 		})
 
 		// 5.1 & 5.2 Conversations (any operator with access)
-		conversationHandler := handler.NewConversationHandler(cfg.Services.Conversation)
+		lifecycleHandler := handler.NewLifecycleHandler(cfg.Services.Lifecycle)
+		snoozeHandler := handler.NewSnoozeHandler(cfg.Services.Snooze)
+		dueDateHandler := handler.NewDueDateHandler(cfg.Services.DueDate)
 		r.Route("/conversations", func(r chi.Router) {
 			r.Get("/", conversationHandler.List)
 			r.Get("/{id}", conversationHandler.GetByID)
+			r.Post("/{id}/mark-read", conversationHandler.MarkRead)
+			r.With(middleware.RequireOperator).Post("/{id}/activity", stalenessHandler.RecordActivity)
+			r.Get("/{id}/queue-position", queueHandler.QueuePosition)
+			r.Get("/{id}/checklist", checklistHandler.GetChecklist)
+			r.Put("/{id}/checklist/{item_id}", checklistHandler.UpdateItem)
+			r.With(middleware.RequireManager).Post("/{id}/messages", conversationHandler.RecordMessage)
+			r.With(middleware.RequireManager).Post("/{id}/priority", conversationHandler.SetPriority)
+			r.With(middleware.RequireManager).Get("/{id}/priority/components", conversationHandler.PriorityComponents)
+			r.With(middleware.RequireManager).Post("/{id}/due-date", dueDateHandler.SetDueDate)
+
+			// Share links to conversation snapshots (Manager+)
+			r.With(middleware.RequireManager).Post("/{id}/share", shareLinkHandler.Create)
+			r.With(middleware.RequireManager).Get("/{id}/shares", shareLinkHandler.List)
+			r.With(middleware.RequireManager).Delete("/{id}/shares/{share_id}", shareLinkHandler.Revoke)
+
+			// Bulk lifecycle operations (Manager+)
+			r.Route("/bulk", func(r chi.Router) {
+				r.Use(middleware.RequireManager)
+				if cfg.IdempotencyService != nil {
+					r.Use(middleware.Idempotency(cfg.IdempotencyService, service.EndpointClassLifecycle))
+				}
+				r.Post("/resolve", lifecycleHandler.BulkResolve)
+				r.Post("/reassign", lifecycleHandler.BulkReassign)
+				r.Post("/move_inbox", lifecycleHandler.BulkMoveInbox)
+			})
+		})
+
+		// Conversation transfers between operators; the recipient accepts or
+		// declines (any operator)
+		transferHandler := handler.NewTransferHandler(cfg.Services.Transfer)
+		r.Route("/transfers", func(r chi.Router) {
+			r.Use(middleware.RequireOperator)
+			r.Get("/", transferHandler.List)
+			r.Post("/", transferHandler.Request)
+			r.Post("/{id}/accept", transferHandler.Accept)
+			r.Post("/{id}/decline", transferHandler.Decline)
 		})
 
 		// Search endpoint
 		r.Get("/search", conversationHandler.Search)
 
+		// Inbound message events from the external messaging platform
+		r.With(middleware.RequireManager).Post("/ingest/messages", conversationHandler.Ingest)
+
+		// Server-Sent Events stream of conversation updates (any operator)
+		r.With(middleware.RequireOperator).Get("/events", eventsHandler.Stream)
+		// Short-lived tokens for /realtime/events (any operator)
+		r.With(middleware.RequireOperator).Post("/realtime/token", eventsHandler.IssueToken)
+		// Presence WebSocket: connected operators are AVAILABLE and see teammates' status (any operator)
+		presenceHandler := handler.NewPresenceHandler(cfg.Services.Presence, cfg.Services.Operator)
+		r.With(middleware.RequireOperator).Get("/ws", presenceHandler.Connect)
+
+		// Customer-facing endpoints for chat widgets (API keys only, rate limited)
+		waitEstimateHandler := handler.NewWaitEstimateHandler(cfg.Services.WaitEstimate)
+		r.Route("/public", func(r chi.Router) {
+			r.Use(middleware.RequireAPIKey)
+			if cfg.PublicRateLimiter != nil {
+				r.Use(middleware.RateLimit(cfg.PublicRateLimiter))
+			}
+			r.Get("/wait-estimate", waitEstimateHandler.Get)
+		})
+
 		// 6.1 & 6.2 Allocation & Claim with Idempotency
 		allocationHandler := handler.NewAllocationHandler(cfg.Services.Allocation)
-		lifecycleHandler := handler.NewLifecycleHandler(cfg.Services.Lifecycle)
+		r.Get("/allocate/preview", allocationHandler.Preview)
 
 		if cfg.IdempotencyService != nil {
 			// Apply idempotency middleware to critical mutation endpoints
 			r.Group(func(r chi.Router) {
-				r.Use(middleware.Idempotency(cfg.IdempotencyService))
+				r.Use(middleware.Idempotency(cfg.IdempotencyService, service.EndpointClassAllocation))
 				r.Post("/allocate", allocationHandler.Allocate)
 				r.Post("/claim", allocationHandler.Claim)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.Idempotency(cfg.IdempotencyService, service.EndpointClassLifecycle))
 				r.Post("/resolve", lifecycleHandler.Resolve)
+				r.Post("/reopen", lifecycleHandler.Reopen)
 				r.Post("/deallocate", lifecycleHandler.Deallocate)
 				r.Post("/reassign", lifecycleHandler.Reassign)
 				r.Post("/move_inbox", lifecycleHandler.MoveInbox)
+				r.Post("/snooze", snoozeHandler.Snooze)
+				r.Post("/escalate", escalationHandler.Escalate)
 			})
 		} else {
 			// Without idempotency (fallback)
 			r.Post("/allocate", allocationHandler.Allocate)
 			r.Post("/claim", allocationHandler.Claim)
 			r.Post("/resolve", lifecycleHandler.Resolve)
+			r.Post("/reopen", lifecycleHandler.Reopen)
 			r.Post("/deallocate", lifecycleHandler.Deallocate)
 			r.Post("/reassign", lifecycleHandler.Reassign)
 			r.Post("/move_inbox", lifecycleHandler.MoveInbox)
+			r.Post("/snooze", snoozeHandler.Snooze)
+			r.Post("/escalate", escalationHandler.Escalate)
 		}
 
 		// 8.1-8.2 Label Management
@@ -169,10 +375,144 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Get("/", labelHandler.List)
 			r.Put("/{id}", labelHandler.Update)
 			r.Delete("/{id}", labelHandler.Delete)
+			r.Get("/{id}/versions", labelHandler.Versions)
+			r.Get("/{id}/deletion-impact", labelHandler.DeletionImpact)
 
 			r.Post("/attach", labelHandler.Attach)
 			r.Post("/detach", labelHandler.Detach)
 		})
+
+		// Customer profiles (Manager+); the conversation timeline is filtered
+		// by the caller's access like the conversation list
+		customerHandler := handler.NewCustomerHandler(cfg.Services.Customer)
+		r.Route("/customers", func(r chi.Router) {
+			r.Get("/{phone}/conversations", conversationHandler.CustomerConversations)
+			r.With(middleware.RequireManager).Get("/", customerHandler.Search)
+			r.With(middleware.RequireManager).Get("/{id}", customerHandler.GetByID)
+			r.With(middleware.RequireManager).Put("/{id}", customerHandler.Update)
+		})
+
+		// Grace periods of operators who went offline or away (Manager+)
+		gracePeriodHandler := handler.NewGracePeriodHandler(cfg.Services.GracePeriod)
+		r.Route("/grace-periods", func(r chi.Router) {
+			r.Use(middleware.RequireManager)
+			r.Get("/", gracePeriodHandler.List)
+			r.Delete("/{id}", gracePeriodHandler.Cancel)
+			r.Post("/{id}/extend", gracePeriodHandler.Extend)
+		})
+
+		// Webhooks (Admin only)
+		webhookHandler := handler.NewWebhookHandler(cfg.Services.Webhook)
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/", webhookHandler.List)
+			r.Post("/", webhookHandler.Create)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", webhookHandler.GetByID)
+				r.Put("/", webhookHandler.Update)
+				r.Delete("/", webhookHandler.Delete)
+				r.Get("/deliveries", webhookHandler.ListDeliveries)
+			})
+		})
+
+		// API keys for service-to-service calls (Admin only)
+		apiKeyHandler := handler.NewAPIKeyHandler(cfg.Services.APIKey)
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/", apiKeyHandler.List)
+			r.Post("/", apiKeyHandler.Create)
+			r.Delete("/{id}", apiKeyHandler.Revoke)
+		})
+
+		// Routing Rules (Admin, or inbox admin for rules of their inbox; checked by the service)
+		routingRuleHandler := handler.NewRoutingRuleHandler(cfg.Services.RoutingRule)
+		r.Route("/routing-rules", func(r chi.Router) {
+			r.Get("/", routingRuleHandler.List)
+			r.Post("/", routingRuleHandler.Create)
+			r.Put("/{id}", routingRuleHandler.Update)
+			r.Delete("/{id}", routingRuleHandler.Delete)
+		})
+
+		// Audit log (Admin only)
+		auditHandler := handler.NewAuditHandler(cfg.Services.Audit)
+		r.Route("/audit", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/", auditHandler.List)
+			r.Get("/break-glass", auditHandler.BreakGlassReport)
+		})
+
+		// Anomalies flagged by the anomaly worker (Manager+)
+		r.With(middleware.RequireManager).Get("/anomalies", anomalyHandler.List)
+
+		// SLA breach report (Manager+)
+		r.With(middleware.RequireManager).Get("/sla/breaches", slaHandler.ListBreaches)
+
+		// Health-weighted routing: allocation weights and overrides (Manager+)
+		operatorHealthHandler := handler.NewOperatorHealthHandler(cfg.Services.Health)
+		r.Route("/operator-health", func(r chi.Router) {
+			r.Use(middleware.RequireManager)
+			r.Get("/", operatorHealthHandler.List)
+			r.Put("/{operator_id}/override", operatorHealthHandler.SetOverride)
+			r.Delete("/{operator_id}/override", operatorHealthHandler.ClearOverride)
+		})
+
+		// Dashboard and staffing statistics (Manager+)
+		statsHandler := handler.NewStatsHandler(cfg.Services.Stats)
+		r.Route("/stats", func(r chi.Router) {
+			r.Use(middleware.RequireManager)
+			r.Get("/overview", statsHandler.Overview)
+			r.Get("/availability-forecast", statsHandler.AvailabilityForecast)
+			r.Get("/labels", statsHandler.LabelUsage)
+			r.Get("/escalations", statsHandler.Escalations)
+		})
+
+		// Mentor/trainee shadowing (Admin only)
+		shadowHandler := handler.NewShadowHandler(cfg.Services.Shadow)
+		r.Route("/shadows", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/", shadowHandler.List)
+			r.Post("/", shadowHandler.Create)
+			r.Delete("/{id}", shadowHandler.Delete)
+		})
+
+		// QA review queue (reviewer permission checked by the service)
+		qaHandler := handler.NewQAHandler(cfg.Services.QA)
+		r.Route("/qa", func(r chi.Router) {
+			r.Use(middleware.RequireOperator)
+			r.Post("/next", qaHandler.Next)
+			r.Post("/items/{id}/review", qaHandler.SubmitReview)
+			r.With(middleware.RequireManager).Get("/reports/operators", qaHandler.OperatorReport)
+			r.Route("/reviewers", func(r chi.Router) {
+				r.Use(middleware.RequireAdmin)
+				r.Get("/", qaHandler.ListReviewers)
+				r.Post("/", qaHandler.GrantReviewer)
+				r.Delete("/{operator_id}", qaHandler.RevokeReviewer)
+			})
+		})
+
+		// Operational endpoints (Admin only)
+		backfillHandler := handler.NewBackfillHandler(cfg.Services.Backfill)
+		invariantHandler := handler.NewInvariantHandler(cfg.Services.Invariants)
+		reconciliationHandler := handler.NewReconciliationHandler(cfg.Services.Reconcile)
+		maintenanceHandler := handler.NewMaintenanceHandler(cfg.Services.Maintenance)
+		scalingHandler := handler.NewScalingHandler(cfg.Services.Scaling)
+		workerHandler := handler.NewWorkerHandler(cfg.Workers)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/activity", auditHandler.AdminActivity)
+			r.Get("/activity/export", auditHandler.ExportAdminActivity)
+			r.Get("/backfills", backfillHandler.List)
+			r.Get("/invariants", invariantHandler.Check)
+			r.Post("/invariants/repair", invariantHandler.Repair)
+			r.Get("/reconciliation", reconciliationHandler.Report)
+			r.Get("/maintenance", maintenanceHandler.Report)
+			r.Put("/maintenance/window", maintenanceHandler.SetWindow)
+			r.Delete("/maintenance/window", maintenanceHandler.ClearWindow)
+			r.Put("/maintenance/override", maintenanceHandler.SetOverride)
+			r.Delete("/maintenance/override", maintenanceHandler.ClearOverride)
+			r.Get("/scaling", scalingHandler.Report)
+			r.Get("/workers", workerHandler.List)
+		})
 	})
 
 	return r
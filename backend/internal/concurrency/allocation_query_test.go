@@ -0,0 +1,180 @@
+//go:build integration
+
+package concurrency
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedQueues inserts perInbox queued conversations into each of n inboxes,
+// a few of them pinned, plus as many resolved ones, and returns the inbox IDs
+func seedQueues(tb testing.TB, ctx context.Context, pool *pgxpool.Pool, repos *repository.RepositoryContainer, tenantID uuid.UUID, n, perInbox int) []uuid.UUID {
+	tb.Helper()
+
+	inboxIDs := make([]uuid.UUID, n)
+	for i := range inboxIDs {
+		inbox := testutil.NewTestInbox(tenantID)
+		require.NoError(tb, repos.Inboxes.Create(ctx, inbox))
+		inboxIDs[i] = inbox.ID
+
+		_, err := pool.Exec(ctx, `
+			INSERT INTO conversation_refs (
+				id, tenant_id, inbox_id, external_conversation_id, customer_phone_number,
+				state, last_message_at, priority_score, priority_override
+			)
+			SELECT gen_random_uuid(), $1, $2, $2::text || '-' || g, '+1555' || g,
+			       CASE WHEN g % 2 = 0 THEN 'QUEUED' ELSE 'RESOLVED' END,
+			       NOW() - g * INTERVAL '1 second',
+			       random(),
+			       CASE WHEN g % 997 = 0 THEN 1 END
+			FROM generate_series(1, $3::int) AS g`,
+			tenantID, inbox.ID, 2*perInbox)
+		require.NoError(tb, err)
+	}
+
+	_, err := pool.Exec(ctx, `ANALYZE conversation_refs`)
+	require.NoError(tb, err)
+	return inboxIDs
+}
+
+// TestAllocationQuery_MatchesFullScan checks that the index-driven candidate
+// query allocates in the same order as a scan of every queued conversation,
+// and still finds conversations once the candidates are all locked
+func TestAllocationQuery_MatchesFullScan(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping concurrency test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+	pc.CleanTables(ctx)
+
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	tenant := testutil.NewTestTenant()
+	require.NoError(t, repos.Tenants.Create(ctx, tenant))
+	inboxIDs := seedQueues(t, ctx, pc.Pool, repos, tenant.ID, 3, 2000)
+
+	pgIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgIDs[i] = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	for _, limit := range []int{1, 5, 20} {
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		got, err := repos.WithTx(tx).ConversationRefs.GetNextForAllocation(ctx, tenant.ID, inboxIDs, nil, limit)
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback(ctx))
+
+		tx, err = pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		want, err := repos.WithTx(tx).Queries().GetNextConversationsForAllocationFullScan(ctx, repository.GetNextConversationsForAllocationFullScanParams{
+			TenantID: pgtype.UUID{Bytes: tenant.ID, Valid: true},
+			Column2:  pgIDs,
+			Limit:    int32(limit),
+		})
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback(ctx))
+
+		require.Len(t, got, limit)
+		for i := range want {
+			assert.Equal(t, uuid.UUID(want[i].ID.Bytes), got[i].ID, "limit %d, position %d", limit, i)
+		}
+		// Pinned conversations come first
+		assert.NotNil(t, got[0].PriorityOverride)
+	}
+
+	// Another allocator holds more than the candidates of every inbox
+	holder, err := pc.Pool.Begin(ctx)
+	require.NoError(t, err)
+	defer holder.Rollback(ctx)
+	held, err := repos.WithTx(holder).ConversationRefs.GetNextForAllocation(ctx, tenant.ID, inboxIDs, nil, 3*40)
+	require.NoError(t, err)
+	require.Len(t, held, 3*40)
+
+	tx, err := pc.Pool.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx)
+	next, err := repos.WithTx(tx).ConversationRefs.GetNextForAllocation(ctx, tenant.ID, inboxIDs, nil, 5)
+	require.NoError(t, err)
+	require.Len(t, next, 5)
+	heldIDs := make(map[uuid.UUID]bool, len(held))
+	for _, conv := range held {
+		heldIDs[conv.ID] = true
+	}
+	for _, conv := range next {
+		assert.False(t, heldIDs[conv.ID], "locked conversation %s allocated twice", conv.ID)
+		assert.Equal(t, domain.ConversationStateQueued, conv.State)
+	}
+}
+
+// BenchmarkAllocationQuery compares the latency of the allocation query with
+// a scan of every queued conversation on deep queues and reports the p99:
+//
+//	go test -tags integration -run '^$' -bench AllocationQuery ./internal/concurrency
+func BenchmarkAllocationQuery(b *testing.B) {
+	pc := testutil.NewPostgresContainer(b)
+	ctx := context.Background()
+	pc.CleanTables(ctx)
+
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	tenant := testutil.NewTestTenant()
+	require.NoError(b, repos.Tenants.Create(ctx, tenant))
+	inboxIDs := seedQueues(b, ctx, pc.Pool, repos, tenant.ID, 5, 20000)
+
+	pgIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgIDs[i] = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	queries := map[string]func(repos *repository.RepositoryContainer) error{
+		"candidates": func(repos *repository.RepositoryContainer) error {
+			_, err := repos.ConversationRefs.GetNextForAllocation(ctx, tenant.ID, inboxIDs, nil, 1)
+			return err
+		},
+		"full_scan": func(repos *repository.RepositoryContainer) error {
+			_, err := repos.Queries().GetNextConversationsForAllocationFullScan(ctx, repository.GetNextConversationsForAllocationFullScanParams{
+				TenantID: pgtype.UUID{Bytes: tenant.ID, Valid: true},
+				Column2:  pgIDs,
+				Limit:    1,
+			})
+			return err
+		},
+	}
+
+	for _, name := range []string{"candidates", "full_scan"} {
+		query := queries[name]
+		b.Run(name, func(b *testing.B) {
+			durations := make([]time.Duration, 0, b.N)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				tx, err := pc.Pool.Begin(ctx)
+				if err != nil {
+					b.Fatal(err)
+				}
+				start := time.Now()
+				if err := query(repos.WithTx(tx)); err != nil {
+					b.Fatal(err)
+				}
+				durations = append(durations, time.Since(start))
+				tx.Rollback(ctx)
+			}
+			b.StopTimer()
+
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			b.ReportMetric(float64(durations[len(durations)*99/100].Microseconds()), "p99-µs")
+		})
+	}
+}
@@ -72,6 +72,7 @@ func TestConcurrentAllocation(t *testing.T) {
 					ctx,
 					tenant.ID,
 					[]uuid.UUID{inbox.ID},
+					nil,
 					1,
 				)
 
@@ -152,6 +153,7 @@ func TestConcurrentAllocation(t *testing.T) {
 					ctx,
 					tenant.ID,
 					[]uuid.UUID{inbox.ID},
+					nil,
 					1,
 				)
 
@@ -0,0 +1,222 @@
+//go:build integration
+
+package concurrency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultiAllocationIsolation runs allocations, batch allocations,
+// resolves, deallocations and grace periods of several operators against
+// the same queues through the services' transactions, then requires the
+// invariant check to find nothing.
+func TestMultiAllocationIsolation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping concurrency test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+	pc.CleanTables(ctx)
+
+	log := logger.NewNop()
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	journal := service.NewAllocationJournal(repos, nil, nil, service.DefaultAllocationJournalConfig(), log)
+	quotas := service.NewCategoryQuotaService(repos, pc.Pool, nil, service.DefaultCategoryQuotaConfig(), log)
+	health := service.NewOperatorHealthService(repos, nil, service.DefaultOperatorHealthConfig(), log)
+	allocation := service.NewAllocationService(repos, pc.Pool, nil, nil, journal, quotas, health, 0, log)
+	lifecycle := service.NewLifecycleService(repos, pc.Pool, nil, nil, nil, log)
+	invariants := service.NewInvariantService(repos, pc.Pool, nil, nil, log)
+
+	tenant := testutil.NewTestTenant()
+	require.NoError(t, repos.Tenants.Create(ctx, tenant))
+	inboxes := []*domain.Inbox{testutil.NewTestInbox(tenant.ID), testutil.NewTestInbox(tenant.ID)}
+	for _, inbox := range inboxes {
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		for i := 0; i < 100; i++ {
+			require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, inbox.ID)))
+		}
+	}
+
+	numOperators := 8
+	operators := make([]*domain.Operator, numOperators)
+	for i := range operators {
+		op := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, op))
+		for _, inbox := range inboxes {
+			require.NoError(t, repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(op.ID, inbox.ID)))
+		}
+		require.NoError(t, repos.OperatorStatus.Create(ctx, testutil.NewTestOperatorStatus(op.ID, domain.OperatorStatusAvailable)))
+		operators[i] = op
+	}
+
+	var wg sync.WaitGroup
+	var allocated, resolved, deallocated, unexpected int32
+	for i, op := range operators {
+		wg.Add(1)
+		go func(i int, operator *domain.Operator) {
+			defer wg.Done()
+			for round := 0; round < 10; round++ {
+				convs, err := allocation.AllocateBatch(ctx, tenant.ID, operator.ID, 1+round%3)
+				if err != nil {
+					if !errors.Is(err, service.ErrNoConversationsAvailable) {
+						atomic.AddInt32(&unexpected, 1)
+					}
+					continue
+				}
+				atomic.AddInt32(&allocated, int32(len(convs)))
+
+				for j, conv := range convs {
+					switch (i + round + j) % 3 {
+					case 0:
+						if _, err := lifecycle.Resolve(ctx, tenant.ID, operator.ID, conv.ID, operator.Role); err == nil {
+							atomic.AddInt32(&resolved, 1)
+						}
+					case 1:
+						if _, err := lifecycle.Deallocate(ctx, tenant.ID, operator.ID, conv.ID, domain.OperatorRoleManager); err == nil {
+							atomic.AddInt32(&deallocated, 1)
+						}
+					default:
+						// Left allocated, with a grace period racing the others
+						gpa := testutil.NewTestGracePeriod(conv.ID, operator.ID, time.Now().Add(time.Hour))
+						_ = repos.GracePeriodAssignments.Create(ctx, gpa)
+					}
+				}
+			}
+		}(i, op)
+	}
+	wg.Wait()
+
+	t.Logf("Allocated: %d, resolved: %d, deallocated: %d", allocated, resolved, deallocated)
+	assert.Zero(t, unexpected, "allocation should only fail for lack of conversations")
+	assert.Positive(t, allocated)
+
+	report, err := invariants.Check(ctx, tenant.ID, false, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Violations, "concurrent lifecycle changes should preserve every invariant")
+
+	// Every allocated conversation is held by exactly one operator
+	seen := make(map[uuid.UUID]uuid.UUID)
+	for _, op := range operators {
+		convs, err := repos.ConversationRefs.GetByOperatorID(ctx, tenant.ID, op.ID, nil)
+		require.NoError(t, err)
+		for _, conv := range convs {
+			if conv.State != domain.ConversationStateAllocated {
+				continue
+			}
+			if other, ok := seen[conv.ID]; ok {
+				t.Errorf("conversation %s held by %s and %s", conv.ID, other, op.ID)
+			}
+			seen[conv.ID] = op.ID
+		}
+	}
+}
+
+// TestInvariantRepair seeds each kind of corruption and checks that the
+// invariant check reports all of it and repairs the repairable kinds.
+func TestInvariantRepair(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping concurrency test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+	pc.CleanTables(ctx)
+
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	invariants := service.NewInvariantService(repos, pc.Pool, nil, nil, logger.NewNop())
+
+	tenant := testutil.NewTestTenant()
+	require.NoError(t, repos.Tenants.Create(ctx, tenant))
+	inbox := testutil.NewTestInbox(tenant.ID)
+	require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+	operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+	require.NoError(t, repos.Operators.Create(ctx, operator))
+	require.NoError(t, repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID)))
+	stranger := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+	require.NoError(t, repos.Operators.Create(ctx, stranger))
+
+	// ALLOCATED without operator
+	orphan := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	require.NoError(t, repos.ConversationRefs.Create(ctx, orphan))
+	_, err := pc.Pool.Exec(ctx, `UPDATE conversation_refs SET state = 'ALLOCATED' WHERE id = $1`, orphan.ID)
+	require.NoError(t, err)
+
+	// Assigned to an operator not subscribed to the inbox
+	unsubscribed := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	require.NoError(t, repos.ConversationRefs.Create(ctx, unsubscribed))
+	require.NoError(t, unsubscribed.Allocate(stranger.ID))
+	require.NoError(t, repos.ConversationRefs.Update(ctx, unsubscribed))
+
+	// RESOLVED with a pending grace period
+	resolved := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	require.NoError(t, repos.ConversationRefs.Create(ctx, resolved))
+	require.NoError(t, resolved.Allocate(operator.ID))
+	require.NoError(t, resolved.Resolve())
+	require.NoError(t, repos.ConversationRefs.Update(ctx, resolved))
+	require.NoError(t, repos.GracePeriodAssignments.Create(ctx,
+		testutil.NewTestGracePeriod(resolved.ID, operator.ID, time.Now().Add(time.Hour))))
+
+	// Duplicate external ID, possible only without the unique constraint
+	_, err = pc.Pool.Exec(ctx, `ALTER TABLE conversation_refs DROP CONSTRAINT conversation_refs_tenant_id_external_conversation_id_key`)
+	require.NoError(t, err)
+	first := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	require.NoError(t, repos.ConversationRefs.Create(ctx, first))
+	second := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	second.ExternalConversationID = first.ExternalConversationID
+	require.NoError(t, repos.ConversationRefs.Create(ctx, second))
+
+	// A healthy allocation is left alone
+	healthy := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	require.NoError(t, repos.ConversationRefs.Create(ctx, healthy))
+	require.NoError(t, healthy.Allocate(operator.ID))
+	require.NoError(t, repos.ConversationRefs.Update(ctx, healthy))
+
+	report, err := invariants.Check(ctx, tenant.ID, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.InvariantKind]int{
+		domain.InvariantAllocatedWithoutOperator: 1,
+		domain.InvariantOperatorNotSubscribed:    1,
+		domain.InvariantResolvedWithGracePeriod:  1,
+		domain.InvariantDuplicateExternalID:      2,
+	}, report.Counts())
+	assert.Zero(t, report.Repaired())
+
+	report, err = invariants.Check(ctx, tenant.ID, true, &operator.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Repaired())
+
+	for _, id := range []uuid.UUID{orphan.ID, unsubscribed.ID} {
+		conv, err := repos.ConversationRefs.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStateQueued, conv.State)
+		assert.Nil(t, conv.AssignedOperatorID)
+	}
+	_, err = repos.GracePeriodAssignments.GetByConversationID(ctx, resolved.ID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	conv, err := repos.ConversationRefs.GetByID(ctx, healthy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConversationStateAllocated, conv.State)
+
+	// Only the duplicates, which need a person, are left
+	report, err = invariants.Check(ctx, tenant.ID, true, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Violations, 2)
+	for _, v := range report.Violations {
+		assert.Equal(t, domain.InvariantDuplicateExternalID, v.Kind)
+		assert.False(t, v.Repaired)
+	}
+}
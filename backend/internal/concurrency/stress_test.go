@@ -79,6 +79,7 @@ func TestHighLoadAllocation(t *testing.T) {
 						ctx,
 						tenant.ID,
 						[]uuid.UUID{inbox.ID},
+						nil,
 						1,
 					)
 
@@ -178,6 +179,7 @@ func TestConcurrentMixedOperations(t *testing.T) {
 						ctx,
 						tenant.ID,
 						[]uuid.UUID{inbox.ID},
+						nil,
 						1,
 					)
 					if err == nil && len(convs) > 0 {
@@ -0,0 +1,75 @@
+//go:build integration
+
+package concurrency
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConcurrentSubscribe subscribes the same operator to the same inbox
+// from many goroutines: exactly one creates the subscription and every
+// other gets it back instead of an error.
+func TestConcurrentSubscribe(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping concurrency test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+	pc.CleanTables(ctx)
+
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	subscriptions := service.NewSubscriptionService(repos, logger.NewNop())
+
+	tenant := testutil.NewTestTenant()
+	require.NoError(t, repos.Tenants.Create(ctx, tenant))
+	inbox := testutil.NewTestInbox(tenant.ID)
+	require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+	operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+	require.NoError(t, repos.Operators.Create(ctx, operator))
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+		ids     = make(map[uuid.UUID]bool)
+		errs    []error
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub, isNew, err := subscriptions.Subscribe(ctx, nil, domain.OperatorRoleAdmin, operator.ID, inbox.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			if isNew {
+				created++
+			}
+			ids[sub.ID] = true
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, created)
+	assert.Len(t, ids, 1)
+
+	subs, err := repos.Subscriptions.GetByInboxID(ctx, inbox.ID)
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+}
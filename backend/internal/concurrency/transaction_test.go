@@ -0,0 +1,99 @@
+//go:build integration
+
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/inbox-allocation-service/internal/service"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLifecycleLocksLiveInTransaction checks that the services take their row
+// locks in their own transaction: a lifecycle change waits for another
+// transaction holding the conversation and then sees its committed state.
+func TestLifecycleLocksLiveInTransaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping concurrency test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+	pc.CleanTables(ctx)
+
+	log := logger.NewNop()
+	repos := repository.NewRepositoryContainer(pc.Pool)
+	journal := service.NewAllocationJournal(repos, nil, nil, service.DefaultAllocationJournalConfig(), log)
+	quotas := service.NewCategoryQuotaService(repos, pc.Pool, nil, service.DefaultCategoryQuotaConfig(), log)
+	health := service.NewOperatorHealthService(repos, nil, service.DefaultOperatorHealthConfig(), log)
+	allocation := service.NewAllocationService(repos, pc.Pool, nil, nil, journal, quotas, health, 0, log)
+	lifecycle := service.NewLifecycleService(repos, pc.Pool, nil, nil, nil, log)
+
+	tenant := testutil.NewTestTenant()
+	require.NoError(t, repos.Tenants.Create(ctx, tenant))
+	inbox := testutil.NewTestInbox(tenant.ID)
+	require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+	operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+	require.NoError(t, repos.Operators.Create(ctx, operator))
+	require.NoError(t, repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID)))
+	require.NoError(t, repos.OperatorStatus.Create(ctx, testutil.NewTestOperatorStatus(operator.ID, domain.OperatorStatusAvailable)))
+
+	t.Run("resolve waits for the lock holder", func(t *testing.T) {
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+		require.NoError(t, conv.Allocate(operator.ID))
+		require.NoError(t, repos.ConversationRefs.Update(ctx, conv))
+
+		holder, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer holder.Rollback(ctx)
+		held, err := repos.WithTx(holder).ConversationRefs.LockForUpdate(ctx, conv.ID)
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := lifecycle.Resolve(ctx, tenant.ID, operator.ID, conv.ID, operator.Role)
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			t.Fatalf("resolve did not wait for the lock: %v", err)
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		// The holder requeues the conversation; resolve must see it
+		require.NoError(t, held.Deallocate())
+		require.NoError(t, repos.WithTx(holder).ConversationRefs.Update(ctx, held))
+		require.NoError(t, holder.Commit(ctx))
+
+		assert.ErrorIs(t, <-done, service.ErrConversationNotAllocated)
+		stored, err := repos.ConversationRefs.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStateQueued, stored.State)
+	})
+
+	t.Run("claim fails fast while another transaction holds the row", func(t *testing.T) {
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		holder, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		_, err = repos.WithTx(holder).ConversationRefs.LockForUpdate(ctx, conv.ID)
+		require.NoError(t, err)
+
+		_, err = allocation.Claim(ctx, tenant.ID, operator.ID, conv.ID)
+		assert.ErrorIs(t, err, service.ErrConversationAlreadyClaimed)
+		require.NoError(t, holder.Rollback(ctx))
+
+		claimed, err := allocation.Claim(ctx, tenant.ID, operator.ID, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStateAllocated, claimed.State)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -41,21 +42,354 @@ type LogConfig struct {
 type WorkerConfig struct {
 	GracePeriodInterval  time.Duration
 	GracePeriodBatchSize int
+	// GracePeriodMaxOverdue and GracePeriodMaxLag bound the backlog of
+	// expired grace periods past which /ready reports the pipeline degraded
+	GracePeriodMaxOverdue int64
+	GracePeriodMaxLag     time.Duration
+	// GracePeriodDuration is how long an operator who goes OFFLINE keeps
+	// their conversations, for tenants without their own grace period
+	GracePeriodDuration time.Duration
+	// ShiftEndInterval is how often operators whose schedule ended are set OFFLINE
+	ShiftEndInterval time.Duration
+	// SnoozeInterval is how often due snoozes are ended
+	SnoozeInterval  time.Duration
+	SnoozeBatchSize int
+	// VacationDrainInterval is how often conversations of operators on
+	// vacation are drained
+	VacationDrainInterval time.Duration
+	// OverdueCheckInterval is how often conversations past their due date
+	// are flagged overdue
+	OverdueCheckInterval time.Duration
+	// AutoResolveInterval is how often conversations idle past their inbox's
+	// auto-resolve threshold are resolved
+	AutoResolveInterval time.Duration
+	// StaleAllocationInterval is how often allocations without operator
+	// activity past their inbox's staleness limit are flagged
+	StaleAllocationInterval time.Duration
+	// TransferExpiryInterval is how often unanswered transfers past their
+	// expiry are ended
+	TransferExpiryInterval time.Duration
+	// CompatibilityInterval is how often a replica running workers repeats the
+	// compatibility check; it is also its heartbeat
+	CompatibilityInterval time.Duration
+	// InstanceTimeout is how long after its last heartbeat a replica stops
+	// counting as live for the compatibility check
+	InstanceTimeout time.Duration
+	// InvariantCheckHour is the hour of day (UTC) of the nightly invariant
+	// check; InvariantAutoRepair lets it repair what it finds
+	InvariantCheckHour  int
+	InvariantAutoRepair bool
+	// JitterRatio delays each run of an interval worker at random by up to
+	// this fraction of its interval, CronJitter each run of a cron worker;
+	// AvoidAlignment spreads the first runs of workers sharing an interval
+	JitterRatio    float64
+	CronJitter     time.Duration
+	AvoidAlignment bool
+	// Schedules overrides the schedules and jitter of workers by name, as
+	// parsed by worker.ParseScheduleOverrides
+	Schedules string
 }
 
 // IdempotencyConfig holds idempotency configuration
 type IdempotencyConfig struct {
 	TTL             time.Duration
 	CleanupInterval time.Duration
+
+	// Storage: postgres, or redis (expires keys itself, no cleanup)
+	Backend   string
+	RedisAddr string // empty: the read cache's CACHE_REDIS_ADDR
+
+	// Concurrent requests with the same key
+	LockTimeout  time.Duration // claim of a key by a request that stopped is taken over after it
+	InFlightWait time.Duration // wait of a duplicate for the in-flight response before 409
+
+	// Behavior while idempotency storage is unavailable
+	DegradationPolicy    string            // fail_open or fail_closed
+	DegradationOverrides map[string]string // endpoint class -> policy
+	BreakerThreshold     int
+	BreakerOpenTimeout   time.Duration
+
+	// Response body encryption at rest
+	EncryptionKeys      map[string]string // key ID -> base64 32-byte key
+	EncryptionActiveKey string            // empty: store plaintext, decrypt only
+}
+
+// AllocationJournalConfig holds allocation intent journal configuration
+type AllocationJournalConfig struct {
+	RecoveryInterval time.Duration
+	StaleAfter       time.Duration
+	Retention        time.Duration
+	// AffinityWindow is how long after resolving a customer's conversation an
+	// operator is offered the customer's next ones first; zero disables it
+	AffinityWindow time.Duration
+}
+
+// AllocationCandidateConfig holds the adaptive sizing of the allocation
+// query's candidate window
+type AllocationCandidateConfig struct {
+	// Adaptive sizes the window from recent conflicts; false keeps the
+	// constant window
+	Adaptive   bool
+	Min        int
+	Max        int
+	Percentile float64
+	// Samples is how many recent allocations the percentile is taken over
+	Samples int
+}
+
+// QueueRankingConfig holds materialized queue rank configuration
+type QueueRankingConfig struct {
+	RefreshInterval     time.Duration
+	FullRefreshInterval time.Duration
+}
+
+// AnomalyConfig holds anomaly detection configuration
+type AnomalyConfig struct {
+	CheckInterval time.Duration
+	Window        time.Duration
+	Baseline      time.Duration
+	Cooldown      time.Duration
+}
+
+// SLAConfig holds SLA tracking configuration
+type SLAConfig struct {
+	CheckInterval   time.Duration
+	NearBreachRatio float64
+	BoostPriority   float64
+}
+
+// CategoryQuotaConfig holds per-inbox category quota configuration
+type CategoryQuotaConfig struct {
+	Window  time.Duration
+	MaxWait time.Duration
+}
+
+// OperatorHealthConfig holds health-weighted routing configuration
+type OperatorHealthConfig struct {
+	CheckInterval       time.Duration
+	Window              time.Duration
+	PaceInterval        time.Duration
+	ReturnRateThreshold float64
+	MinAllocations      int
+	MinWeight           float64
+}
+
+// WebhookConfig holds webhook delivery configuration
+type WebhookConfig struct {
+	WorkerInterval time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	RequestTimeout time.Duration
+}
+
+// OutboxConfig holds event outbox configuration
+type OutboxConfig struct {
+	WorkerInterval time.Duration
+	BatchSize      int
+	FlushGrace     time.Duration
+	Retention      time.Duration
+}
+
+// EventBusConfig holds external event bus (Kafka or NATS) configuration
+type EventBusConfig struct {
+	Driver     string
+	Addrs      []string
+	Topic      string
+	EventTypes []string
+	Timeout    time.Duration
+	Username   string
+	Password   string
+	Token      string
+	KafkaAcks  int
+}
+
+// PushConfig holds push notification (FCM and APNs) configuration; a
+// platform without credentials is disabled
+type PushConfig struct {
+	// FCMCredentialsFile is the path of a service account JSON key
+	FCMCredentialsFile string
+	FCMProjectID       string
+	// APNsKeyFile is the path of the .p8 token signing key
+	APNsKeyFile    string
+	APNsKeyID      string
+	APNsTeamID     string
+	APNsTopic      string
+	APNsProduction bool
+	Timeout        time.Duration
+	QueueSize      int
+	Workers        int
+}
+
+// EventsConfig holds Server-Sent Events stream configuration
+type EventsConfig struct {
+	HeartbeatInterval time.Duration
+	BufferSize        int
+	// TokenSigningKey is the base64-encoded 32-byte key realtime tokens are
+	// signed with; empty uses a random key, so tokens only work on the
+	// replica that issued them
+	TokenSigningKey string
+}
+
+// PresenceConfig holds presence WebSocket configuration
+type PresenceConfig struct {
+	// HeartbeatInterval is how often connected clients are pinged
+	HeartbeatInterval time.Duration
+	// Timeout is how long an operator may go unseen before they are set OFFLINE
+	Timeout time.Duration
+	// CheckInterval is how often timed-out presence is looked for
+	CheckInterval time.Duration
+}
+
+// QAConfig holds conversation quality sampling configuration
+type QAConfig struct {
+	SampleRate   float64
+	ClaimTimeout time.Duration
+}
+
+// BackfillConfig holds online schema-change backfill configuration
+type BackfillConfig struct {
+	Interval    time.Duration
+	BatchSize   int
+	LockTimeout time.Duration
+}
+
+// ClassifierConfig holds conversation classification configuration
+type ClassifierConfig struct {
+	Timeout time.Duration
+}
+
+// CacheConfig holds the optional Redis read cache configuration
+type CacheConfig struct {
+	// RedisAddr enables the cache; empty reads everything from Postgres
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	RedisPoolSize int
+	RedisTimeout  time.Duration
+	KeyPrefix     string
+	TTL           time.Duration
+}
+
+// PublicAPIConfig holds configuration for the customer-facing endpoints
+// under /api/v1/public
+type PublicAPIConfig struct {
+	// RateLimit is the sustained requests per second allowed per API key
+	RateLimit float64
+	RateBurst int
+	// WaitEstimateWindow is how far back allocation throughput is measured
+	WaitEstimateWindow   time.Duration
+	WaitEstimateCacheTTL time.Duration
+}
+
+// InternalAPIConfig holds configuration for the internal listener serving
+// the messaging gateway over mutual TLS
+type InternalAPIConfig struct {
+	// Port enables the internal listener; empty disables it
+	Port string
+	Host string
+	// CertFile and KeyFile hold the listener's certificate; clients must
+	// present a certificate signed by a CA in ClientCAFile
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// AllowedClients lists the client certificate common names accepted;
+	// empty accepts any certificate signed by the client CA
+	AllowedClients []string
+	// RateLimit is the sustained requests per second allowed per client
+	// certificate and tenant
+	RateLimit float64
+	RateBurst int
+}
+
+// ShareLinkConfig holds configuration for conversation share links
+type ShareLinkConfig struct {
+	// SigningKey is the base64-encoded 32-byte key tokens are signed with;
+	// empty uses a random key, so links stop working at restart
+	SigningKey string
+	// BaseURL is the public address share link URLs start with
+	BaseURL string
+}
+
+// ReconciliationConfig holds configuration for reconciliation against the
+// upstream conversation source
+type ReconciliationConfig struct {
+	// UpstreamURL is the status endpoint of the system of record; empty
+	// disables reconciliation
+	UpstreamURL   string
+	UpstreamToken string
+	Interval      time.Duration
+	// Lookback bounds the conversations compared to those updated within it,
+	// plus those already diverging
+	Lookback  time.Duration
+	BatchSize int
+	// Timeout bounds one upstream request
+	Timeout time.Duration
+	// AutoCorrect lists the divergence kinds corrected without a person, once
+	// they have persisted for CorrectAfter
+	AutoCorrect  []string
+	CorrectAfter time.Duration
+}
+
+// MaintenanceConfig holds the deployment's maintenance window for heavy
+// background jobs
+type MaintenanceConfig struct {
+	// Window is "HH:MM-HH:MM" in Timezone; empty runs heavy jobs at any time
+	Window   string
+	Timezone string
+	// Force is OPEN or CLOSED to override the window until changed; empty
+	// follows the window
+	Force string
+}
+
+// AuthConfig holds API authentication configuration
+type AuthConfig struct {
+	// DevMode trusts X-Tenant-ID / X-Operator-ID headers instead of JWTs
+	DevMode bool
+
+	Issuer         string
+	Audience       string
+	JWKSURL        string
+	Leeway         time.Duration
+	JWKSRefresh    time.Duration
+	JWKSMinRefresh time.Duration
+	TenantClaim    string
+	OperatorClaim  string
+	RoleClaim      string
 }
 
 // Config holds all application configuration
 type Config struct {
-	Server      ServerConfig
-	Database    DatabaseConfig
-	Log         LogConfig
-	Worker      WorkerConfig
-	Idempotency IdempotencyConfig
+	// Profile is the deployment profile the defaults below came from
+	Profile string
+
+	Server         ServerConfig
+	Database       DatabaseConfig
+	Log            LogConfig
+	Worker         WorkerConfig
+	Idempotency    IdempotencyConfig
+	Allocation     AllocationJournalConfig
+	Candidates     AllocationCandidateConfig
+	QueueRanks     QueueRankingConfig
+	Anomaly        AnomalyConfig
+	SLA            SLAConfig
+	Quotas         CategoryQuotaConfig
+	Health         OperatorHealthConfig
+	Webhook        WebhookConfig
+	Outbox         OutboxConfig
+	EventBus       EventBusConfig
+	Push           PushConfig
+	Events         EventsConfig
+	Presence       PresenceConfig
+	QA             QAConfig
+	Backfill       BackfillConfig
+	Classifier     ClassifierConfig
+	Cache          CacheConfig
+	Public         PublicAPIConfig
+	Internal       InternalAPIConfig
+	ShareLinks     ShareLinkConfig
+	Reconciliation ReconciliationConfig
+	Maintenance    MaintenanceConfig
+	Auth           AuthConfig
 }
 
 // Load reads configuration from environment variables
@@ -63,7 +397,13 @@ func Load() (*Config, error) {
 	// Try to load .env file (ignore error if not present)
 	_ = godotenv.Load()
 
+	profile, err := LookupProfile(getEnv("DEPLOYMENT_PROFILE", DefaultProfile))
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
+		Profile: profile.Name,
 		Server: ServerConfig{
 			Port:            getEnv("SERVER_PORT", "8080"),
 			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
@@ -79,20 +419,206 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", "allocation_pass"),
 			DBName:   getEnv("DB_NAME", "allocation_db"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
-			MaxConns: getEnvAsInt("DB_MAX_CONNS", 25),
-			MinConns: getEnvAsInt("DB_MIN_CONNS", 5),
+			MaxConns: getEnvAsInt("DB_MAX_CONNS", profile.DBMaxConns),
+			MinConns: getEnvAsInt("DB_MIN_CONNS", profile.DBMinConns),
 		},
 		Log: LogConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
 		Worker: WorkerConfig{
-			GracePeriodInterval:  getEnvAsDuration("GRACE_PERIOD_INTERVAL", 30*time.Second),
-			GracePeriodBatchSize: getEnvAsInt("GRACE_PERIOD_BATCH_SIZE", 100),
+			GracePeriodInterval:     getEnvAsDuration("GRACE_PERIOD_INTERVAL", profile.GracePeriodInterval),
+			GracePeriodBatchSize:    getEnvAsInt("GRACE_PERIOD_BATCH_SIZE", profile.GracePeriodBatchSize),
+			GracePeriodMaxOverdue:   int64(getEnvAsInt("GRACE_PERIOD_MAX_OVERDUE", 500)),
+			GracePeriodMaxLag:       getEnvAsDuration("GRACE_PERIOD_MAX_LAG", 5*time.Minute),
+			GracePeriodDuration:     getEnvAsDuration("GRACE_PERIOD_DURATION", 5*time.Minute),
+			ShiftEndInterval:        getEnvAsDuration("SHIFT_END_CHECK_INTERVAL", 1*time.Minute),
+			SnoozeInterval:          getEnvAsDuration("SNOOZE_CHECK_INTERVAL", profile.SnoozeInterval),
+			SnoozeBatchSize:         getEnvAsInt("SNOOZE_BATCH_SIZE", profile.SnoozeBatchSize),
+			VacationDrainInterval:   getEnvAsDuration("VACATION_DRAIN_INTERVAL", 1*time.Minute),
+			OverdueCheckInterval:    getEnvAsDuration("OVERDUE_CHECK_INTERVAL", 1*time.Minute),
+			AutoResolveInterval:     getEnvAsDuration("AUTO_RESOLVE_INTERVAL", 1*time.Minute),
+			StaleAllocationInterval: getEnvAsDuration("STALE_ALLOCATION_INTERVAL", 1*time.Minute),
+			TransferExpiryInterval:  getEnvAsDuration("TRANSFER_EXPIRY_INTERVAL", 15*time.Second),
+			CompatibilityInterval:   getEnvAsDuration("COMPAT_CHECK_INTERVAL", 15*time.Second),
+			InstanceTimeout:         getEnvAsDuration("COMPAT_INSTANCE_TIMEOUT", 1*time.Minute),
+			InvariantCheckHour:      getEnvAsInt("INVARIANT_CHECK_HOUR", 3),
+			InvariantAutoRepair:     getEnvAsBool("INVARIANT_AUTO_REPAIR", false),
+			JitterRatio:             getEnvAsFloat("WORKER_JITTER_RATIO", 0.1),
+			CronJitter:              getEnvAsDuration("WORKER_CRON_JITTER", 1*time.Minute),
+			AvoidAlignment:          getEnvAsBool("WORKER_ALIGNMENT_AVOIDANCE", true),
+			Schedules:               getEnv("WORKER_SCHEDULES", ""),
 		},
 		Idempotency: IdempotencyConfig{
 			TTL:             getEnvAsDuration("IDEMPOTENCY_TTL", 24*time.Hour),
 			CleanupInterval: getEnvAsDuration("IDEMPOTENCY_CLEANUP_INTERVAL", 1*time.Hour),
+
+			Backend:   getEnv("IDEMPOTENCY_BACKEND", "postgres"),
+			RedisAddr: getEnv("IDEMPOTENCY_REDIS_ADDR", ""),
+
+			LockTimeout:  getEnvAsDuration("IDEMPOTENCY_LOCK_TIMEOUT", 1*time.Minute),
+			InFlightWait: getEnvAsDuration("IDEMPOTENCY_IN_FLIGHT_WAIT", 5*time.Second),
+
+			DegradationPolicy:    getEnv("IDEMPOTENCY_DEGRADATION_POLICY", "fail_open"),
+			DegradationOverrides: getEnvAsMap("IDEMPOTENCY_DEGRADATION_OVERRIDES"),
+			BreakerThreshold:     getEnvAsInt("IDEMPOTENCY_BREAKER_THRESHOLD", 5),
+			BreakerOpenTimeout:   getEnvAsDuration("IDEMPOTENCY_BREAKER_OPEN_TIMEOUT", 30*time.Second),
+
+			EncryptionKeys:      getEnvAsMap("IDEMPOTENCY_ENCRYPTION_KEYS"),
+			EncryptionActiveKey: getEnv("IDEMPOTENCY_ENCRYPTION_ACTIVE_KEY", ""),
+		},
+		Allocation: AllocationJournalConfig{
+			RecoveryInterval: getEnvAsDuration("ALLOCATION_RECOVERY_INTERVAL", profile.AllocationRecoveryInterval),
+			StaleAfter:       getEnvAsDuration("ALLOCATION_INTENT_STALE_AFTER", 1*time.Minute),
+			Retention:        getEnvAsDuration("ALLOCATION_INTENT_RETENTION", 24*time.Hour),
+			AffinityWindow:   getEnvAsDuration("ALLOCATION_AFFINITY_WINDOW", 72*time.Hour),
+		},
+		Candidates: AllocationCandidateConfig{
+			Adaptive:   getEnvAsBool("ALLOCATION_CANDIDATES_ADAPTIVE", true),
+			Min:        getEnvAsInt("ALLOCATION_CANDIDATES_MIN", 4),
+			Max:        getEnvAsInt("ALLOCATION_CANDIDATES_MAX", 100),
+			Percentile: getEnvAsFloat("ALLOCATION_CANDIDATES_PERCENTILE", 95),
+			Samples:    getEnvAsInt("ALLOCATION_CANDIDATES_SAMPLES", 200),
+		},
+		QueueRanks: QueueRankingConfig{
+			RefreshInterval:     getEnvAsDuration("QUEUE_RANK_REFRESH_INTERVAL", profile.QueueRankRefresh),
+			FullRefreshInterval: getEnvAsDuration("QUEUE_RANK_FULL_REFRESH_INTERVAL", profile.QueueRankFullRefresh),
+		},
+		Anomaly: AnomalyConfig{
+			CheckInterval: getEnvAsDuration("ANOMALY_CHECK_INTERVAL", 1*time.Minute),
+			Window:        getEnvAsDuration("ANOMALY_WINDOW", 15*time.Minute),
+			Baseline:      getEnvAsDuration("ANOMALY_BASELINE", 24*time.Hour),
+			Cooldown:      getEnvAsDuration("ANOMALY_COOLDOWN", 1*time.Hour),
+		},
+		SLA: SLAConfig{
+			CheckInterval:   getEnvAsDuration("SLA_CHECK_INTERVAL", 1*time.Minute),
+			NearBreachRatio: getEnvAsFloat("SLA_NEAR_BREACH_RATIO", 0.8),
+			BoostPriority:   getEnvAsFloat("SLA_BOOST_PRIORITY", 1.0),
+		},
+		Quotas: CategoryQuotaConfig{
+			Window:  getEnvAsDuration("CATEGORY_QUOTA_WINDOW", 1*time.Hour),
+			MaxWait: getEnvAsDuration("CATEGORY_QUOTA_MAX_WAIT", 10*time.Minute),
+		},
+		Health: OperatorHealthConfig{
+			CheckInterval:       getEnvAsDuration("OPERATOR_HEALTH_CHECK_INTERVAL", profile.OperatorHealthInterval),
+			Window:              getEnvAsDuration("OPERATOR_HEALTH_WINDOW", 1*time.Hour),
+			PaceInterval:        getEnvAsDuration("OPERATOR_HEALTH_PACE_INTERVAL", 1*time.Minute),
+			ReturnRateThreshold: getEnvAsFloat("OPERATOR_HEALTH_RETURN_RATE", 0.5),
+			MinAllocations:      getEnvAsInt("OPERATOR_HEALTH_MIN_ALLOCATIONS", 5),
+			MinWeight:           getEnvAsFloat("OPERATOR_HEALTH_MIN_WEIGHT", 0.25),
+		},
+		Webhook: WebhookConfig{
+			WorkerInterval: getEnvAsDuration("WEBHOOK_WORKER_INTERVAL", profile.WebhookWorkerInterval),
+			BatchSize:      getEnvAsInt("WEBHOOK_BATCH_SIZE", profile.WebhookBatchSize),
+			MaxAttempts:    getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			RequestTimeout: getEnvAsDuration("WEBHOOK_REQUEST_TIMEOUT", 10*time.Second),
+		},
+		Outbox: OutboxConfig{
+			WorkerInterval: getEnvAsDuration("OUTBOX_WORKER_INTERVAL", profile.OutboxWorkerInterval),
+			BatchSize:      getEnvAsInt("OUTBOX_BATCH_SIZE", profile.OutboxBatchSize),
+			FlushGrace:     getEnvAsDuration("OUTBOX_FLUSH_GRACE", 30*time.Second),
+			Retention:      getEnvAsDuration("OUTBOX_RETENTION", 24*time.Hour),
+		},
+		EventBus: EventBusConfig{
+			Driver:     getEnv("EVENT_BUS_DRIVER", "none"),
+			Addrs:      getEnvAsList("EVENT_BUS_ADDRS", nil),
+			Topic:      getEnv("EVENT_BUS_TOPIC", "inbox-allocation.events"),
+			EventTypes: getEnvAsList("EVENT_BUS_EVENT_TYPES", []string{"conversation.created", "conversation.allocated", "conversation.resolved", "operator.status_changed"}),
+			Timeout:    getEnvAsDuration("EVENT_BUS_TIMEOUT", 5*time.Second),
+			Username:   getEnv("EVENT_BUS_USERNAME", ""),
+			Password:   getEnv("EVENT_BUS_PASSWORD", ""),
+			Token:      getEnv("EVENT_BUS_TOKEN", ""),
+			KafkaAcks:  getEnvAsInt("EVENT_BUS_KAFKA_ACKS", -1),
+		},
+		Push: PushConfig{
+			FCMCredentialsFile: getEnv("PUSH_FCM_CREDENTIALS_FILE", ""),
+			FCMProjectID:       getEnv("PUSH_FCM_PROJECT_ID", ""),
+			APNsKeyFile:        getEnv("PUSH_APNS_KEY_FILE", ""),
+			APNsKeyID:          getEnv("PUSH_APNS_KEY_ID", ""),
+			APNsTeamID:         getEnv("PUSH_APNS_TEAM_ID", ""),
+			APNsTopic:          getEnv("PUSH_APNS_TOPIC", ""),
+			APNsProduction:     getEnvAsBool("PUSH_APNS_PRODUCTION", false),
+			Timeout:            getEnvAsDuration("PUSH_TIMEOUT", 10*time.Second),
+			QueueSize:          getEnvAsInt("PUSH_QUEUE_SIZE", 1024),
+			Workers:            getEnvAsInt("PUSH_WORKERS", 4),
+		},
+		Events: EventsConfig{
+			HeartbeatInterval: getEnvAsDuration("EVENTS_HEARTBEAT_INTERVAL", 15*time.Second),
+			BufferSize:        getEnvAsInt("EVENTS_BUFFER_SIZE", 64),
+			TokenSigningKey:   getEnv("REALTIME_TOKEN_SIGNING_KEY", ""),
+		},
+		Presence: PresenceConfig{
+			HeartbeatInterval: getEnvAsDuration("PRESENCE_HEARTBEAT_INTERVAL", 15*time.Second),
+			Timeout:           getEnvAsDuration("PRESENCE_TIMEOUT", 45*time.Second),
+			CheckInterval:     getEnvAsDuration("PRESENCE_CHECK_INTERVAL", 15*time.Second),
+		},
+		QA: QAConfig{
+			SampleRate:   getEnvAsFloat("QA_SAMPLE_RATE", 0.05),
+			ClaimTimeout: getEnvAsDuration("QA_CLAIM_TIMEOUT", 30*time.Minute),
+		},
+		Backfill: BackfillConfig{
+			Interval:    getEnvAsDuration("BACKFILL_INTERVAL", profile.BackfillInterval),
+			BatchSize:   getEnvAsInt("BACKFILL_BATCH_SIZE", profile.BackfillBatchSize),
+			LockTimeout: getEnvAsDuration("BACKFILL_LOCK_TIMEOUT", 5*time.Second),
+		},
+		Classifier: ClassifierConfig{
+			Timeout: getEnvAsDuration("CLASSIFIER_TIMEOUT", 2*time.Second),
+		},
+		Cache: CacheConfig{
+			RedisAddr:     getEnv("CACHE_REDIS_ADDR", ""),
+			RedisPassword: getEnv("CACHE_REDIS_PASSWORD", ""),
+			RedisDB:       getEnvAsInt("CACHE_REDIS_DB", 0),
+			RedisPoolSize: getEnvAsInt("CACHE_REDIS_POOL_SIZE", profile.RedisPoolSize),
+			RedisTimeout:  getEnvAsDuration("CACHE_REDIS_TIMEOUT", 200*time.Millisecond),
+			KeyPrefix:     getEnv("CACHE_KEY_PREFIX", "inbox:"),
+			TTL:           getEnvAsDuration("CACHE_TTL", profile.CacheTTL),
+		},
+		Public: PublicAPIConfig{
+			RateLimit:            getEnvAsFloat("PUBLIC_RATE_LIMIT", profile.PublicRateLimit),
+			RateBurst:            getEnvAsInt("PUBLIC_RATE_BURST", profile.PublicRateBurst),
+			WaitEstimateWindow:   getEnvAsDuration("WAIT_ESTIMATE_WINDOW", 1*time.Hour),
+			WaitEstimateCacheTTL: getEnvAsDuration("WAIT_ESTIMATE_CACHE_TTL", profile.WaitEstimateCacheTTL),
+		},
+		Internal: InternalAPIConfig{
+			Port:           getEnv("INTERNAL_API_PORT", ""),
+			Host:           getEnv("INTERNAL_API_HOST", "0.0.0.0"),
+			CertFile:       getEnv("INTERNAL_TLS_CERT_FILE", ""),
+			KeyFile:        getEnv("INTERNAL_TLS_KEY_FILE", ""),
+			ClientCAFile:   getEnv("INTERNAL_TLS_CLIENT_CA_FILE", ""),
+			AllowedClients: getEnvAsList("INTERNAL_ALLOWED_CLIENTS", nil),
+			RateLimit:      getEnvAsFloat("INTERNAL_RATE_LIMIT", 200),
+			RateBurst:      getEnvAsInt("INTERNAL_RATE_BURST", 400),
+		},
+		ShareLinks: ShareLinkConfig{
+			SigningKey: getEnv("SHARE_LINK_SIGNING_KEY", ""),
+			BaseURL:    getEnv("SHARE_LINK_BASE_URL", ""),
+		},
+		Reconciliation: ReconciliationConfig{
+			UpstreamURL:   getEnv("RECONCILIATION_UPSTREAM_URL", ""),
+			UpstreamToken: getEnv("RECONCILIATION_UPSTREAM_TOKEN", ""),
+			Interval:      getEnvAsDuration("RECONCILIATION_INTERVAL", 10*time.Minute),
+			Lookback:      getEnvAsDuration("RECONCILIATION_LOOKBACK", 24*time.Hour),
+			BatchSize:     getEnvAsInt("RECONCILIATION_BATCH_SIZE", 100),
+			Timeout:       getEnvAsDuration("RECONCILIATION_TIMEOUT", 10*time.Second),
+			AutoCorrect:   getEnvAsList("RECONCILIATION_AUTO_CORRECT", nil),
+			CorrectAfter:  getEnvAsDuration("RECONCILIATION_CORRECT_AFTER", 15*time.Minute),
+		},
+		Maintenance: MaintenanceConfig{
+			Window:   getEnv("MAINTENANCE_WINDOW", ""),
+			Timezone: getEnv("MAINTENANCE_TIMEZONE", "UTC"),
+			Force:    getEnv("MAINTENANCE_FORCE", ""),
+		},
+		Auth: AuthConfig{
+			DevMode:        getEnvAsBool("AUTH_DEV_MODE", false),
+			Issuer:         getEnv("AUTH_ISSUER", ""),
+			Audience:       getEnv("AUTH_AUDIENCE", ""),
+			JWKSURL:        getEnv("AUTH_JWKS_URL", ""),
+			Leeway:         getEnvAsDuration("AUTH_LEEWAY", 30*time.Second),
+			JWKSRefresh:    getEnvAsDuration("AUTH_JWKS_REFRESH", 1*time.Hour),
+			JWKSMinRefresh: getEnvAsDuration("AUTH_JWKS_MIN_REFRESH", 1*time.Minute),
+			TenantClaim:    getEnv("AUTH_TENANT_CLAIM", "tenant_id"),
+			OperatorClaim:  getEnv("AUTH_OPERATOR_CLAIM", "operator_id"),
+			RoleClaim:      getEnv("AUTH_ROLE_CLAIM", "role"),
 		},
 	}
 
@@ -106,6 +632,15 @@ func Load() (*Config, error) {
 	if cfg.Database.DBName == "" {
 		return nil, fmt.Errorf("DB_NAME is required")
 	}
+	if !cfg.Auth.DevMode && cfg.Auth.Issuer == "" && cfg.Auth.JWKSURL == "" {
+		return nil, fmt.Errorf("AUTH_ISSUER or AUTH_JWKS_URL is required unless AUTH_DEV_MODE is enabled")
+	}
+	if cfg.Internal.Port != "" && (cfg.Internal.CertFile == "" || cfg.Internal.KeyFile == "" || cfg.Internal.ClientCAFile == "") {
+		return nil, fmt.Errorf("INTERNAL_TLS_CERT_FILE, INTERNAL_TLS_KEY_FILE and INTERNAL_TLS_CLIENT_CA_FILE are required when INTERNAL_API_PORT is set")
+	}
+	if cfg.Presence.Timeout <= cfg.Presence.HeartbeatInterval {
+		return nil, fmt.Errorf("PRESENCE_TIMEOUT must be longer than PRESENCE_HEARTBEAT_INTERVAL")
+	}
 
 	return cfg, nil
 }
@@ -128,6 +663,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsBool retrieves an environment variable as bool or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsFloat retrieves an environment variable as float64 or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getEnvAsDuration retrieves an environment variable as duration or returns a default value
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
@@ -137,3 +692,32 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getEnvAsList parses a comma-separated list or returns a default value;
+// blank entries are skipped
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// getEnvAsMap parses "key=value,key=value" pairs; malformed pairs are skipped
+func getEnvAsMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Deployment profiles, selected with DEPLOYMENT_PROFILE
+const (
+	ProfileSmall  = "small"
+	ProfileMedium = "medium"
+	ProfileLarge  = "large"
+
+	// DefaultProfile matches the defaults the service had before profiles
+	DefaultProfile = ProfileMedium
+)
+
+// Profile is a preset of coherent defaults for a deployment size: pool
+// sizes, worker intervals, rate limits, cache TTLs and batch sizes. The
+// environment variable of each setting still overrides its profile value.
+type Profile struct {
+	Name string
+
+	// Pool sizes
+	DBMaxConns    int
+	DBMinConns    int
+	RedisPoolSize int
+
+	// Worker intervals
+	GracePeriodInterval        time.Duration
+	SnoozeInterval             time.Duration
+	QueueRankRefresh           time.Duration
+	QueueRankFullRefresh       time.Duration
+	WebhookWorkerInterval      time.Duration
+	OutboxWorkerInterval       time.Duration
+	BackfillInterval           time.Duration
+	OperatorHealthInterval     time.Duration
+	AllocationRecoveryInterval time.Duration
+
+	// Rate limits
+	PublicRateLimit float64
+	PublicRateBurst int
+
+	// Cache TTLs
+	CacheTTL             time.Duration
+	WaitEstimateCacheTTL time.Duration
+
+	// Batch sizes
+	GracePeriodBatchSize int
+	SnoozeBatchSize      int
+	WebhookBatchSize     int
+	OutboxBatchSize      int
+	BackfillBatchSize    int
+}
+
+var profiles = map[string]Profile{
+	// A single replica on a small database: few connections, relaxed
+	// polling and small batches
+	ProfileSmall: {
+		Name:                       ProfileSmall,
+		DBMaxConns:                 10,
+		DBMinConns:                 2,
+		RedisPoolSize:              5,
+		GracePeriodInterval:        1 * time.Minute,
+		SnoozeInterval:             1 * time.Minute,
+		QueueRankRefresh:           5 * time.Second,
+		QueueRankFullRefresh:       15 * time.Minute,
+		WebhookWorkerInterval:      30 * time.Second,
+		OutboxWorkerInterval:       10 * time.Second,
+		BackfillInterval:           2 * time.Second,
+		OperatorHealthInterval:     5 * time.Minute,
+		AllocationRecoveryInterval: 2 * time.Minute,
+		PublicRateLimit:            2,
+		PublicRateBurst:            10,
+		CacheTTL:                   15 * time.Second,
+		WaitEstimateCacheTTL:       1 * time.Minute,
+		GracePeriodBatchSize:       50,
+		SnoozeBatchSize:            50,
+		WebhookBatchSize:           20,
+		OutboxBatchSize:            50,
+		BackfillBatchSize:          500,
+	},
+	ProfileMedium: {
+		Name:                       ProfileMedium,
+		DBMaxConns:                 25,
+		DBMinConns:                 5,
+		RedisPoolSize:              10,
+		GracePeriodInterval:        30 * time.Second,
+		SnoozeInterval:             30 * time.Second,
+		QueueRankRefresh:           2 * time.Second,
+		QueueRankFullRefresh:       5 * time.Minute,
+		WebhookWorkerInterval:      10 * time.Second,
+		OutboxWorkerInterval:       5 * time.Second,
+		BackfillInterval:           1 * time.Second,
+		OperatorHealthInterval:     1 * time.Minute,
+		AllocationRecoveryInterval: 1 * time.Minute,
+		PublicRateLimit:            5,
+		PublicRateBurst:            20,
+		CacheTTL:                   30 * time.Second,
+		WaitEstimateCacheTTL:       30 * time.Second,
+		GracePeriodBatchSize:       100,
+		SnoozeBatchSize:            100,
+		WebhookBatchSize:           50,
+		OutboxBatchSize:            100,
+		BackfillBatchSize:          1000,
+	},
+	// Several replicas on a large database: bigger pools, tight polling so
+	// backlogs drain quickly, and large batches
+	ProfileLarge: {
+		Name:                       ProfileLarge,
+		DBMaxConns:                 100,
+		DBMinConns:                 20,
+		RedisPoolSize:              50,
+		GracePeriodInterval:        10 * time.Second,
+		SnoozeInterval:             10 * time.Second,
+		QueueRankRefresh:           1 * time.Second,
+		QueueRankFullRefresh:       2 * time.Minute,
+		WebhookWorkerInterval:      2 * time.Second,
+		OutboxWorkerInterval:       1 * time.Second,
+		BackfillInterval:           500 * time.Millisecond,
+		OperatorHealthInterval:     30 * time.Second,
+		AllocationRecoveryInterval: 30 * time.Second,
+		PublicRateLimit:            20,
+		PublicRateBurst:            100,
+		CacheTTL:                   1 * time.Minute,
+		WaitEstimateCacheTTL:       15 * time.Second,
+		GracePeriodBatchSize:       500,
+		SnoozeBatchSize:            500,
+		WebhookBatchSize:           200,
+		OutboxBatchSize:            500,
+		BackfillBatchSize:          5000,
+	},
+}
+
+// ProfileNames returns the names of the deployment profiles, sorted
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupProfile returns the named deployment profile; the name is case
+// insensitive
+func LookupProfile(name string) (Profile, error) {
+	profile, ok := profiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Profile{}, fmt.Errorf("DEPLOYMENT_PROFILE must be one of %s, got %q",
+			strings.Join(ProfileNames(), ", "), name)
+	}
+	return profile, nil
+}
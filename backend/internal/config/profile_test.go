@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupProfile(t *testing.T) {
+	for _, name := range []string{"small", "medium", "large", " Large "} {
+		profile, err := LookupProfile(name)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, profile.Name)
+	}
+
+	_, err := LookupProfile("huge")
+	assert.ErrorContains(t, err, "large, medium, small")
+}
+
+func TestProfilesScaleWithSize(t *testing.T) {
+	small, medium, large := profiles[ProfileSmall], profiles[ProfileMedium], profiles[ProfileLarge]
+
+	assert.Less(t, small.DBMaxConns, medium.DBMaxConns)
+	assert.Less(t, medium.DBMaxConns, large.DBMaxConns)
+	assert.Greater(t, small.OutboxWorkerInterval, medium.OutboxWorkerInterval)
+	assert.Greater(t, medium.OutboxWorkerInterval, large.OutboxWorkerInterval)
+	assert.Less(t, small.OutboxBatchSize, large.OutboxBatchSize)
+	for _, p := range profiles {
+		assert.LessOrEqual(t, p.DBMinConns, p.DBMaxConns, p.Name)
+	}
+}
+
+func TestLoad_Profile(t *testing.T) {
+	t.Setenv("AUTH_DEV_MODE", "true")
+
+	t.Run("defaults to medium", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, ProfileMedium, cfg.Profile)
+		assert.Equal(t, 25, cfg.Database.MaxConns)
+		assert.Equal(t, 30*time.Second, cfg.Worker.GracePeriodInterval)
+	})
+
+	t.Run("profile sets defaults", func(t *testing.T) {
+		t.Setenv("DEPLOYMENT_PROFILE", "large")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, ProfileLarge, cfg.Profile)
+		assert.Equal(t, 100, cfg.Database.MaxConns)
+		assert.Equal(t, 500, cfg.Outbox.BatchSize)
+		assert.Equal(t, float64(20), cfg.Public.RateLimit)
+	})
+
+	t.Run("variables override the profile", func(t *testing.T) {
+		t.Setenv("DEPLOYMENT_PROFILE", "small")
+		t.Setenv("DB_MAX_CONNS", "40")
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, 40, cfg.Database.MaxConns)
+		assert.Equal(t, 2, cfg.Database.MinConns)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		t.Setenv("DEPLOYMENT_PROFILE", "xl")
+		_, err := Load()
+		assert.ErrorContains(t, err, "DEPLOYMENT_PROFILE")
+	})
+}
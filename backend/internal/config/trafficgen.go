@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+// TrafficGenConfig holds configuration for the synthetic traffic generator
+// (cmd/trafficgen), which drives a running service over its HTTP API
+type TrafficGenConfig struct {
+	// Environment names the target deployment; the generator refuses to run
+	// against production
+	Environment string
+	BaseURL     string
+	Timeout     time.Duration
+	// Duration stops the generator after it elapses; zero runs until signalled
+	Duration time.Duration
+	Log      LogConfig
+
+	TenantID    uuid.UUID
+	InboxIDs    []uuid.UUID
+	OperatorIDs []uuid.UUID
+
+	// Ingest authenticates with APIKey when set, otherwise as ManagerID via
+	// the dev-mode headers
+	APIKey    string
+	ManagerID *uuid.UUID
+	// OperatorTokens maps operator ID to bearer token for targets that are not
+	// in AUTH_DEV_MODE; operators without one use the dev-mode headers
+	OperatorTokens map[string]string
+
+	// Customer behavior
+	ConversationsPerMinute float64
+	FollowUpProbability    float64
+	CustomerPool           int
+
+	// Operator behavior
+	OperatorTick          time.Duration
+	OperatorCapacity      int
+	HandleTime            time.Duration
+	DeallocateProbability float64
+	BreakProbability      float64
+	BreakDuration         time.Duration
+}
+
+// LoadTrafficGen reads the traffic generator configuration from TRAFFICGEN_*
+// environment variables
+func LoadTrafficGen() (*TrafficGenConfig, error) {
+	_ = godotenv.Load()
+
+	cfg := &TrafficGenConfig{
+		Environment: getEnv("TRAFFICGEN_ENVIRONMENT", ""),
+		BaseURL:     strings.TrimRight(getEnv("TRAFFICGEN_BASE_URL", "http://localhost:8080"), "/"),
+		Timeout:     getEnvAsDuration("TRAFFICGEN_TIMEOUT", 10*time.Second),
+		Duration:    getEnvAsDuration("TRAFFICGEN_DURATION", 0),
+		Log: LogConfig{
+			Level:  getEnv("LOG_LEVEL", "info"),
+			Format: getEnv("LOG_FORMAT", "json"),
+		},
+
+		APIKey:         getEnv("TRAFFICGEN_API_KEY", ""),
+		OperatorTokens: getEnvAsMap("TRAFFICGEN_OPERATOR_TOKENS"),
+
+		ConversationsPerMinute: getEnvAsFloat("TRAFFICGEN_CONVERSATIONS_PER_MINUTE", 30),
+		FollowUpProbability:    getEnvAsFloat("TRAFFICGEN_FOLLOW_UP_PROBABILITY", 0.3),
+		CustomerPool:           getEnvAsInt("TRAFFICGEN_CUSTOMER_POOL", 500),
+
+		OperatorTick:          getEnvAsDuration("TRAFFICGEN_OPERATOR_TICK", 5*time.Second),
+		OperatorCapacity:      getEnvAsInt("TRAFFICGEN_OPERATOR_CAPACITY", 3),
+		HandleTime:            getEnvAsDuration("TRAFFICGEN_HANDLE_TIME", 3*time.Minute),
+		DeallocateProbability: getEnvAsFloat("TRAFFICGEN_DEALLOCATE_PROBABILITY", 0.05),
+		BreakProbability:      getEnvAsFloat("TRAFFICGEN_BREAK_PROBABILITY", 0.005),
+		BreakDuration:         getEnvAsDuration("TRAFFICGEN_BREAK_DURATION", 5*time.Minute),
+	}
+
+	switch strings.ToLower(cfg.Environment) {
+	case "":
+		return nil, fmt.Errorf("TRAFFICGEN_ENVIRONMENT is required")
+	case "production", "prod":
+		return nil, fmt.Errorf("the traffic generator must not run against production")
+	}
+
+	tenantID, err := uuid.Parse(getEnv("TRAFFICGEN_TENANT_ID", ""))
+	if err != nil {
+		return nil, fmt.Errorf("TRAFFICGEN_TENANT_ID must be a UUID")
+	}
+	cfg.TenantID = tenantID
+
+	if cfg.InboxIDs, err = getEnvAsUUIDs("TRAFFICGEN_INBOX_IDS"); err != nil {
+		return nil, err
+	}
+	if len(cfg.InboxIDs) == 0 {
+		return nil, fmt.Errorf("TRAFFICGEN_INBOX_IDS is required")
+	}
+	if cfg.OperatorIDs, err = getEnvAsUUIDs("TRAFFICGEN_OPERATOR_IDS"); err != nil {
+		return nil, err
+	}
+
+	if raw := getEnv("TRAFFICGEN_MANAGER_ID", ""); raw != "" {
+		managerID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("TRAFFICGEN_MANAGER_ID must be a UUID")
+		}
+		cfg.ManagerID = &managerID
+	}
+	if cfg.APIKey == "" && cfg.ManagerID == nil {
+		return nil, fmt.Errorf("TRAFFICGEN_API_KEY or TRAFFICGEN_MANAGER_ID is required")
+	}
+
+	if cfg.ConversationsPerMinute <= 0 {
+		return nil, fmt.Errorf("TRAFFICGEN_CONVERSATIONS_PER_MINUTE must be positive")
+	}
+	if cfg.CustomerPool < 1 {
+		return nil, fmt.Errorf("TRAFFICGEN_CUSTOMER_POOL must be at least 1")
+	}
+	if cfg.OperatorTick <= 0 || cfg.HandleTime <= 0 {
+		return nil, fmt.Errorf("TRAFFICGEN_OPERATOR_TICK and TRAFFICGEN_HANDLE_TIME must be positive")
+	}
+	if cfg.OperatorCapacity < 1 {
+		return nil, fmt.Errorf("TRAFFICGEN_OPERATOR_CAPACITY must be at least 1")
+	}
+
+	return cfg, nil
+}
+
+// getEnvAsUUIDs parses a comma-separated list of UUIDs; empty entries are skipped
+func getEnvAsUUIDs(key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, raw := range strings.Split(getEnv(key, ""), ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid UUID %q", key, raw)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
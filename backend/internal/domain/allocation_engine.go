@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// AllocationEngine is the version of the automatic allocation logic a
+// tenant is pinned to, so that a change of allocation behavior can be
+// rolled out to selected tenants before the rest
+type AllocationEngine string
+
+const (
+	// AllocationEngineV1 allocates by manual priority override, then
+	// priority score, then the longest-waiting last message
+	AllocationEngineV1 AllocationEngine = "v1"
+	// AllocationEngineV2 allocates conversations that breached an SLA target
+	// or their due date first, earliest breach first, then as v1. Manual
+	// priority overrides and category quotas still come first.
+	AllocationEngineV2 AllocationEngine = "v2"
+)
+
+// DefaultAllocationEngine is the engine of tenants that were not pinned
+const DefaultAllocationEngine = AllocationEngineV1
+
+func (e AllocationEngine) IsValid() bool {
+	switch e {
+	case AllocationEngineV1, AllocationEngineV2:
+		return true
+	}
+	return false
+}
+
+// BreachedAt returns when the conversation first missed an SLA target or
+// its due date, whichever came first; nil when it missed neither
+func (c *ConversationRef) BreachedAt() *time.Time {
+	switch {
+	case c.SLABreachedAt == nil:
+		return c.OverdueAt
+	case c.OverdueAt == nil || c.SLABreachedAt.Before(*c.OverdueAt):
+		return c.SLABreachedAt
+	}
+	return c.OverdueAt
+}
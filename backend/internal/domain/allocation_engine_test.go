@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationEngine_IsValid(t *testing.T) {
+	assert.True(t, AllocationEngineV1.IsValid())
+	assert.True(t, AllocationEngineV2.IsValid())
+	assert.False(t, AllocationEngine("v3").IsValid())
+	assert.False(t, AllocationEngine("").IsValid())
+}
+
+func TestTenant_ApplySettings_AllocationEngine(t *testing.T) {
+	tenant := NewTenant("Test", decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.5))
+	assert.Equal(t, DefaultAllocationEngine, tenant.AllocationEngine)
+
+	tenant.ApplySettings(TenantSettings{AllocationEngine: AllocationEngineV2})
+	assert.Equal(t, AllocationEngineV2, tenant.Settings().AllocationEngine)
+
+	tenant.ApplySettings(TenantSettings{})
+	assert.Equal(t, DefaultAllocationEngine, tenant.AllocationEngine)
+}
+
+func TestConversationRef_BreachedAt(t *testing.T) {
+	earlier := time.Now().UTC().Add(-time.Hour)
+	later := earlier.Add(30 * time.Minute)
+	conv := NewConversationRef(uuid.New(), uuid.New(), "ext", "+15550000000")
+
+	assert.Nil(t, conv.BreachedAt())
+
+	conv.OverdueAt = &later
+	assert.Equal(t, &later, conv.BreachedAt())
+
+	conv.SLABreachedAt = &earlier
+	assert.Equal(t, &earlier, conv.BreachedAt())
+
+	conv.OverdueAt = nil
+	assert.Equal(t, &earlier, conv.BreachedAt())
+}
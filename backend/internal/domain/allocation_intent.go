@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== AllocationIntent ====================
+
+type AllocationIntentKind string
+
+const (
+	AllocationIntentAllocate AllocationIntentKind = "allocate"
+	AllocationIntentClaim    AllocationIntentKind = "claim"
+)
+
+type AllocationIntentStatus string
+
+const (
+	AllocationIntentPending   AllocationIntentStatus = "PENDING"
+	AllocationIntentCommitted AllocationIntentStatus = "COMMITTED"
+	AllocationIntentAborted   AllocationIntentStatus = "ABORTED"
+)
+
+// AllocationIntent journals an allocate or claim attempt. It is written
+// before the allocation critical section and resolved after it, so attempts
+// cut short by a crash stay PENDING until recovery reconciles them.
+type AllocationIntent struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	OperatorID     uuid.UUID
+	Kind           AllocationIntentKind
+	IdempotencyKey *string
+	// Claimed conversation, or the one selected by allocate
+	ConversationID *uuid.UUID
+	Status         AllocationIntentStatus
+	// Resolved by recovery rather than by the request
+	Recovered  bool
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+func NewAllocationIntent(tenantID, operatorID uuid.UUID, kind AllocationIntentKind, idempotencyKey *string, conversationID *uuid.UUID) *AllocationIntent {
+	return &AllocationIntent{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       tenantID,
+		OperatorID:     operatorID,
+		Kind:           kind,
+		IdempotencyKey: idempotencyKey,
+		ConversationID: conversationID,
+		Status:         AllocationIntentPending,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+func (i *AllocationIntent) IsPending() bool {
+	return i.Status == AllocationIntentPending
+}
+
+// IsStale reports whether a pending intent has outlived any live request
+func (i *AllocationIntent) IsStale(now time.Time, staleAfter time.Duration) bool {
+	return i.IsPending() && now.Sub(i.CreatedAt) >= staleAfter
+}
+
+// Matches reports whether a retry is the same request as the journaled one
+func (i *AllocationIntent) Matches(operatorID uuid.UUID, kind AllocationIntentKind, conversationID *uuid.UUID) bool {
+	if i.OperatorID != operatorID || i.Kind != kind {
+		return false
+	}
+	if kind == AllocationIntentClaim {
+		return conversationID != nil && i.ConversationID != nil && *i.ConversationID == *conversationID
+	}
+	return true
+}
+
+// Resolve records the outcome of the attempt
+func (i *AllocationIntent) Resolve(status AllocationIntentStatus, recovered bool) {
+	now := time.Now().UTC()
+	i.Status = status
+	i.Recovered = recovered
+	i.ResolvedAt = &now
+}
+
+// WasApplied reports whether conv shows the effect of this attempt: it is
+// allocated to the intent's operator and was last updated after the intent
+// was written
+func (i *AllocationIntent) WasApplied(conv *ConversationRef) bool {
+	return conv.State == ConversationStateAllocated &&
+		conv.AssignedOperatorID != nil &&
+		*conv.AssignedOperatorID == i.OperatorID &&
+		!conv.UpdatedAt.Before(i.CreatedAt)
+}
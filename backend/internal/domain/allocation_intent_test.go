@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationIntent_Matches(t *testing.T) {
+	operatorID := uuid.New()
+	conversationID := uuid.New()
+
+	allocate := NewAllocationIntent(uuid.New(), operatorID, AllocationIntentAllocate, nil, nil)
+	assert.True(t, allocate.Matches(operatorID, AllocationIntentAllocate, nil))
+	assert.False(t, allocate.Matches(uuid.New(), AllocationIntentAllocate, nil))
+	assert.False(t, allocate.Matches(operatorID, AllocationIntentClaim, &conversationID))
+
+	claim := NewAllocationIntent(uuid.New(), operatorID, AllocationIntentClaim, nil, &conversationID)
+	assert.True(t, claim.Matches(operatorID, AllocationIntentClaim, &conversationID))
+	other := uuid.New()
+	assert.False(t, claim.Matches(operatorID, AllocationIntentClaim, &other))
+}
+
+func TestAllocationIntent_IsStale(t *testing.T) {
+	intent := NewAllocationIntent(uuid.New(), uuid.New(), AllocationIntentAllocate, nil, nil)
+	now := intent.CreatedAt
+
+	assert.False(t, intent.IsStale(now.Add(30*time.Second), time.Minute))
+	assert.True(t, intent.IsStale(now.Add(time.Minute), time.Minute))
+
+	intent.Resolve(AllocationIntentAborted, false)
+	assert.False(t, intent.IsStale(now.Add(time.Hour), time.Minute))
+	assert.NotNil(t, intent.ResolvedAt)
+}
+
+func TestAllocationIntent_WasApplied(t *testing.T) {
+	operatorID := uuid.New()
+	intent := NewAllocationIntent(uuid.New(), operatorID, AllocationIntentAllocate, nil, nil)
+
+	conv := &ConversationRef{
+		State:              ConversationStateAllocated,
+		AssignedOperatorID: &operatorID,
+		UpdatedAt:          intent.CreatedAt.Add(time.Millisecond),
+	}
+	assert.True(t, intent.WasApplied(conv))
+
+	// Assigned before the intent was written
+	conv.UpdatedAt = intent.CreatedAt.Add(-time.Second)
+	assert.False(t, intent.WasApplied(conv))
+
+	conv.UpdatedAt = intent.CreatedAt
+	other := uuid.New()
+	conv.AssignedOperatorID = &other
+	assert.False(t, intent.WasApplied(conv))
+
+	conv.State = ConversationStateQueued
+	conv.AssignedOperatorID = nil
+	assert.False(t, intent.WasApplied(conv))
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FastResolveWithin is how soon after its assignment a resolution counts as
+// suspiciously fast
+const FastResolveWithin = 10 * time.Second
+
+// ==================== AnomalyKind ====================
+
+type AnomalyKind string
+
+const (
+	// AnomalyDeallocationSpike: far more deallocations than usual in the tenant
+	AnomalyDeallocationSpike AnomalyKind = "DEALLOCATION_SPIKE"
+	// AnomalyFastResolves: an operator repeatedly resolving conversations
+	// within FastResolveWithin of receiving them
+	AnomalyFastResolves AnomalyKind = "FAST_RESOLVES"
+	// AnomalyAllocationFailureSurge: far more allocate/claim attempts that
+	// assigned nothing than usual in the tenant
+	AnomalyAllocationFailureSurge AnomalyKind = "ALLOCATION_FAILURE_SURGE"
+)
+
+func (k AnomalyKind) IsValid() bool {
+	switch k {
+	case AnomalyDeallocationSpike, AnomalyFastResolves, AnomalyAllocationFailureSurge:
+		return true
+	}
+	return false
+}
+
+// ==================== AnomalySensitivity ====================
+
+type AnomalySensitivity string
+
+const (
+	AnomalySensitivityOff    AnomalySensitivity = "OFF"
+	AnomalySensitivityLow    AnomalySensitivity = "LOW"
+	AnomalySensitivityMedium AnomalySensitivity = "MEDIUM"
+	AnomalySensitivityHigh   AnomalySensitivity = "HIGH"
+)
+
+// DefaultAnomalySensitivity applies to tenants without anomaly settings
+const DefaultAnomalySensitivity = AnomalySensitivityMedium
+
+func (s AnomalySensitivity) IsValid() bool {
+	switch s {
+	case AnomalySensitivityOff, AnomalySensitivityLow, AnomalySensitivityMedium, AnomalySensitivityHigh:
+		return true
+	}
+	return false
+}
+
+// AnomalyThresholds decide when counts in a detection window are flagged
+type AnomalyThresholds struct {
+	// SpikeFactor is how many times the baseline average per window a count
+	// must reach to be a spike
+	SpikeFactor float64
+	// MinSpikeCount keeps small absolute counts from being flagged when the
+	// baseline is near zero
+	MinSpikeCount int
+	// FastResolves is how many fast resolutions per window flag an operator
+	FastResolves int
+}
+
+// Thresholds returns the thresholds of the sensitivity; false for OFF
+func (s AnomalySensitivity) Thresholds() (AnomalyThresholds, bool) {
+	switch s {
+	case AnomalySensitivityLow:
+		return AnomalyThresholds{SpikeFactor: 5, MinSpikeCount: 20, FastResolves: 10}, true
+	case AnomalySensitivityMedium:
+		return AnomalyThresholds{SpikeFactor: 3, MinSpikeCount: 10, FastResolves: 5}, true
+	case AnomalySensitivityHigh:
+		return AnomalyThresholds{SpikeFactor: 2, MinSpikeCount: 5, FastResolves: 3}, true
+	}
+	return AnomalyThresholds{}, false
+}
+
+// SpikeThreshold returns the count at or above which a window is a spike
+// against baselinePerWindow
+func (t AnomalyThresholds) SpikeThreshold(baselinePerWindow float64) float64 {
+	threshold := t.SpikeFactor * baselinePerWindow
+	if threshold < float64(t.MinSpikeCount) {
+		return float64(t.MinSpikeCount)
+	}
+	return threshold
+}
+
+// ==================== TenantAnomalySettings ====================
+
+// TenantAnomalySettings is the anomaly detection configuration of a tenant
+type TenantAnomalySettings struct {
+	TenantID    uuid.UUID
+	Sensitivity AnomalySensitivity
+	UpdatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewTenantAnomalySettings(tenantID uuid.UUID, sensitivity AnomalySensitivity, updatedBy *uuid.UUID) *TenantAnomalySettings {
+	now := time.Now().UTC()
+	return &TenantAnomalySettings{
+		TenantID:    tenantID,
+		Sensitivity: sensitivity,
+		UpdatedBy:   updatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// DefaultTenantAnomalySettings returns the settings of a tenant that never
+// configured anomaly detection
+func DefaultTenantAnomalySettings(tenantID uuid.UUID) *TenantAnomalySettings {
+	return &TenantAnomalySettings{TenantID: tenantID, Sensitivity: DefaultAnomalySensitivity}
+}
+
+// ==================== Anomaly ====================
+
+// Anomaly is an unusual allocation pattern flagged for managers
+type Anomaly struct {
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Kind     AnomalyKind
+	// OperatorID is nil for tenant-wide anomalies
+	OperatorID *uuid.UUID
+	// Observed is the count in the detection window
+	Observed int
+	// Baseline is the average count per window over the baseline period;
+	// 0 for per-operator anomalies
+	Baseline   float64
+	Threshold  float64
+	Window     time.Duration
+	DetectedAt time.Time
+}
+
+func NewAnomaly(tenantID uuid.UUID, kind AnomalyKind, operatorID *uuid.UUID, observed int, baseline, threshold float64, window time.Duration) *Anomaly {
+	return &Anomaly{
+		ID:         uuid.Must(uuid.NewV7()),
+		TenantID:   tenantID,
+		Kind:       kind,
+		OperatorID: operatorID,
+		Observed:   observed,
+		Baseline:   baseline,
+		Threshold:  threshold,
+		Window:     window,
+		DetectedAt: time.Now().UTC(),
+	}
+}
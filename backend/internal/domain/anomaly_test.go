@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnomalySensitivity_Thresholds(t *testing.T) {
+	_, ok := AnomalySensitivityOff.Thresholds()
+	assert.False(t, ok)
+
+	low, ok := AnomalySensitivityLow.Thresholds()
+	assert.True(t, ok)
+	medium, _ := AnomalySensitivityMedium.Thresholds()
+	high, _ := AnomalySensitivityHigh.Thresholds()
+
+	// Higher sensitivity flags smaller deviations
+	assert.Greater(t, low.SpikeFactor, medium.SpikeFactor)
+	assert.Greater(t, medium.SpikeFactor, high.SpikeFactor)
+	assert.Greater(t, low.MinSpikeCount, medium.MinSpikeCount)
+	assert.Greater(t, medium.MinSpikeCount, high.MinSpikeCount)
+	assert.Greater(t, low.FastResolves, medium.FastResolves)
+	assert.Greater(t, medium.FastResolves, high.FastResolves)
+
+	assert.True(t, DefaultAnomalySensitivity.IsValid())
+	assert.False(t, AnomalySensitivity("EXTREME").IsValid())
+}
+
+func TestAnomalyThresholds_SpikeThreshold(t *testing.T) {
+	thresholds := AnomalyThresholds{SpikeFactor: 3, MinSpikeCount: 10}
+
+	// Quiet baseline: the minimum count applies
+	assert.Equal(t, 10.0, thresholds.SpikeThreshold(0))
+	assert.Equal(t, 10.0, thresholds.SpikeThreshold(2))
+	// Busy baseline: a multiple of the usual count
+	assert.Equal(t, 15.0, thresholds.SpikeThreshold(5))
+}
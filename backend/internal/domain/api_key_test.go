@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, raw, err := NewAPIKey(uuid.New(), "ingest", OperatorRoleManager, nil)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(raw, APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(raw, key.KeyPrefix))
+	assert.Equal(t, HashAPIKey(raw), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, raw)
+	assert.False(t, key.IsRevoked())
+
+	_, other, err := NewAPIKey(key.TenantID, "ingest", OperatorRoleManager, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, raw, other)
+}
+
+func TestAPIKey_NeedsTouch(t *testing.T) {
+	now := time.Now().UTC()
+	key := &APIKey{}
+	assert.True(t, key.NeedsTouch(now))
+
+	recent := now.Add(-10 * time.Second)
+	key.LastUsedAt = &recent
+	assert.False(t, key.NeedsTouch(now))
+
+	stale := now.Add(-APIKeyTouchInterval)
+	key.LastUsedAt = &stale
+	assert.True(t, key.NeedsTouch(now))
+}
+
+func TestAPIKey_Revoke(t *testing.T) {
+	key := &APIKey{}
+	key.Revoke()
+	assert.True(t, key.IsRevoked())
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== AuditAction ====================
+
+type AuditAction string
+
+const (
+	AuditActionConversationAllocate     AuditAction = "conversation.allocate"
+	AuditActionConversationClaim        AuditAction = "conversation.claim"
+	AuditActionConversationResolve      AuditAction = "conversation.resolve"
+	AuditActionConversationDeallocate   AuditAction = "conversation.deallocate"
+	AuditActionConversationReassign     AuditAction = "conversation.reassign"
+	AuditActionConversationTransfer     AuditAction = "conversation.transfer"
+	AuditActionConversationMoveInbox    AuditAction = "conversation.move_inbox"
+	AuditActionConversationReopen       AuditAction = "conversation.reopen"
+	AuditActionConversationBreakGlass   AuditAction = "conversation.break_glass_access"
+	AuditActionConversationSnooze       AuditAction = "conversation.snooze"
+	AuditActionConversationPriority     AuditAction = "conversation.priority_override"
+	AuditActionConversationEscalate     AuditAction = "conversation.escalate"
+	AuditActionConversationRepair       AuditAction = "conversation.invariant_repair"
+	AuditActionConversationReconcile    AuditAction = "conversation.reconcile"
+	AuditActionConversationShare        AuditAction = "conversation.share"
+	AuditActionConversationShareRevoke  AuditAction = "conversation.share_revoke"
+	AuditActionConversationShareAccess  AuditAction = "conversation.share_access"
+	AuditActionLabelCreate              AuditAction = "label.create"
+	AuditActionLabelUpdate              AuditAction = "label.update"
+	AuditActionLabelDelete              AuditAction = "label.delete"
+	AuditActionLabelAttach              AuditAction = "label.attach"
+	AuditActionLabelDetach              AuditAction = "label.detach"
+	AuditActionOperatorCreate           AuditAction = "operator.create"
+	AuditActionOperatorRoleChange       AuditAction = "operator.role_change"
+	AuditActionOperatorProfileChange    AuditAction = "operator.profile_change"
+	AuditActionOperatorDelete           AuditAction = "operator.delete"
+	AuditActionOperatorStatusChange     AuditAction = "operator.status_change"
+	AuditActionOperatorScheduleChange   AuditAction = "operator.schedule_change"
+	AuditActionOperatorFocusChange      AuditAction = "operator.focus_change"
+	AuditActionOperatorVacationStart    AuditAction = "operator.vacation_start"
+	AuditActionOperatorVacationEnd      AuditAction = "operator.vacation_end"
+	AuditActionOperatorShadowStart      AuditAction = "operator.shadow_start"
+	AuditActionOperatorShadowEnd        AuditAction = "operator.shadow_end"
+	AuditActionOperatorInboxAdminGrant  AuditAction = "operator.inbox_admin_grant"
+	AuditActionOperatorInboxAdminRevoke AuditAction = "operator.inbox_admin_revoke"
+	AuditActionOperatorAllocationWeight AuditAction = "operator.allocation_weight_override"
+	AuditActionTenantWeightsChange      AuditAction = "tenant.weights_change"
+	AuditActionTenantClassifierChange   AuditAction = "tenant.classifier_change"
+	AuditActionTenantAnomalySettings    AuditAction = "tenant.anomaly_settings_change"
+	AuditActionTenantMaintenanceChange  AuditAction = "tenant.maintenance_change"
+	AuditActionAPIKeyCreate             AuditAction = "api_key.create"
+	AuditActionAPIKeyRevoke             AuditAction = "api_key.revoke"
+	AuditActionAnomalyDetected          AuditAction = "anomaly.detected"
+	AuditActionInboxCategoryQuotas      AuditAction = "inbox.category_quotas_change"
+	AuditActionInboxChecklistTemplate   AuditAction = "inbox.checklist_template_change"
+	AuditActionInboxEscalationChange    AuditAction = "inbox.escalation_change"
+	AuditActionConversationChecklist    AuditAction = "conversation.checklist_item"
+	AuditActionExperimentCreate         AuditAction = "experiment.create"
+	AuditActionExperimentUpdate         AuditAction = "experiment.update"
+	AuditActionExperimentStop           AuditAction = "experiment.stop"
+	AuditActionExperimentDelete         AuditAction = "experiment.delete"
+	AuditActionCustomerUpdate           AuditAction = "customer.update"
+	AuditActionRoutingRuleCreate        AuditAction = "routing_rule.create"
+	AuditActionRoutingRuleUpdate        AuditAction = "routing_rule.update"
+	AuditActionRoutingRuleDelete        AuditAction = "routing_rule.delete"
+	AuditActionConversationDueDate      AuditAction = "conversation.due_date_change"
+	AuditActionInboxAutoResolveChange   AuditAction = "inbox.auto_resolve_change"
+	AuditActionTenantSettingsChange     AuditAction = "tenant.settings_change"
+	AuditActionGracePeriodCancel        AuditAction = "conversation.grace_period_cancel"
+	AuditActionGracePeriodExtend        AuditAction = "conversation.grace_period_extend"
+	AuditActionTenantSandboxEnable      AuditAction = "tenant.sandbox_enable"
+	AuditActionTenantSandboxReset       AuditAction = "tenant.sandbox_reset"
+)
+
+// AdminAuditActions are the configuration changes shown in the admin
+// activity feed: tenant settings, roles and grants, routing rules, weights,
+// API keys, inbox settings and experiments
+var AdminAuditActions = []AuditAction{
+	AuditActionOperatorCreate,
+	AuditActionOperatorRoleChange,
+	AuditActionOperatorDelete,
+	AuditActionOperatorInboxAdminGrant,
+	AuditActionOperatorInboxAdminRevoke,
+	AuditActionOperatorAllocationWeight,
+	AuditActionTenantWeightsChange,
+	AuditActionTenantClassifierChange,
+	AuditActionTenantAnomalySettings,
+	AuditActionTenantMaintenanceChange,
+	AuditActionTenantSettingsChange,
+	AuditActionTenantSandboxEnable,
+	AuditActionTenantSandboxReset,
+	AuditActionAPIKeyCreate,
+	AuditActionAPIKeyRevoke,
+	AuditActionInboxCategoryQuotas,
+	AuditActionInboxChecklistTemplate,
+	AuditActionInboxEscalationChange,
+	AuditActionInboxAutoResolveChange,
+	AuditActionExperimentCreate,
+	AuditActionExperimentUpdate,
+	AuditActionExperimentStop,
+	AuditActionExperimentDelete,
+	AuditActionRoutingRuleCreate,
+	AuditActionRoutingRuleUpdate,
+	AuditActionRoutingRuleDelete,
+}
+
+// IsAdmin reports whether the action belongs to the admin activity feed
+func (a AuditAction) IsAdmin() bool {
+	for _, action := range AdminAuditActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (a AuditAction) String() string {
+	return string(a)
+}
+
+// ==================== AuditEntityType ====================
+
+type AuditEntityType string
+
+const (
+	AuditEntityConversation AuditEntityType = "conversation"
+	AuditEntityLabel        AuditEntityType = "label"
+	AuditEntityOperator     AuditEntityType = "operator"
+	AuditEntityTenant       AuditEntityType = "tenant"
+	AuditEntityAPIKey       AuditEntityType = "api_key"
+	AuditEntityAnomaly      AuditEntityType = "anomaly"
+	AuditEntityInbox        AuditEntityType = "inbox"
+	AuditEntityExperiment   AuditEntityType = "experiment"
+	AuditEntityCustomer     AuditEntityType = "customer"
+	AuditEntityRoutingRule  AuditEntityType = "routing_rule"
+)
+
+func (t AuditEntityType) IsValid() bool {
+	switch t {
+	case AuditEntityConversation, AuditEntityLabel, AuditEntityOperator, AuditEntityTenant, AuditEntityAPIKey,
+		AuditEntityAnomaly, AuditEntityInbox, AuditEntityExperiment, AuditEntityCustomer, AuditEntityRoutingRule:
+		return true
+	}
+	return false
+}
+
+func (t AuditEntityType) String() string {
+	return string(t)
+}
+
+// ==================== AuditEntry ====================
+
+// AuditEntry records a single mutating operation.
+// Before and After hold the fields relevant to the action; either may be nil
+// when the entity was created or deleted.
+type AuditEntry struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	ActorID    *uuid.UUID
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   uuid.UUID
+	Before     map[string]interface{}
+	After      map[string]interface{}
+	CreatedAt  time.Time
+}
+
+func NewAuditEntry(
+	tenantID uuid.UUID,
+	actorID *uuid.UUID,
+	action AuditAction,
+	entityType AuditEntityType,
+	entityID uuid.UUID,
+	before, after map[string]interface{},
+) *AuditEntry {
+	return &AuditEntry{
+		ID:         uuid.Must(uuid.NewV7()),
+		TenantID:   tenantID,
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     before,
+		After:      after,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
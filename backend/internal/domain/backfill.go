@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== BackfillStatus ====================
+
+type BackfillStatus string
+
+const (
+	BackfillStatusPending   BackfillStatus = "PENDING"
+	BackfillStatusRunning   BackfillStatus = "RUNNING"
+	BackfillStatusCompleted BackfillStatus = "COMPLETED"
+)
+
+func (s BackfillStatus) IsValid() bool {
+	switch s {
+	case BackfillStatusPending, BackfillStatusRunning, BackfillStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (s BackfillStatus) String() string {
+	return string(s)
+}
+
+// ==================== Backfill ====================
+
+// Backfill tracks a batched data backfill of an online schema change.
+// Rows are processed in id order; CursorID is the last processed id.
+type Backfill struct {
+	Name          string
+	TableName     string
+	Status        BackfillStatus
+	CursorID      *uuid.UUID
+	RowsProcessed int64
+	// RowsTotal is the planner estimate at registration, used for progress only
+	RowsTotal   int64
+	BatchSize   int
+	LastError   *string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+func NewBackfill(name, tableName string, batchSize int, rowsTotal int64) *Backfill {
+	now := time.Now().UTC()
+	return &Backfill{
+		Name:      name,
+		TableName: tableName,
+		Status:    BackfillStatusPending,
+		RowsTotal: rowsTotal,
+		BatchSize: batchSize,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// RecordBatch advances the cursor past a processed batch. A short batch means
+// the table is exhausted and completes the backfill.
+func (b *Backfill) RecordBatch(cursor uuid.UUID, processed int) {
+	now := time.Now().UTC()
+	if b.StartedAt == nil {
+		b.StartedAt = &now
+	}
+	b.Status = BackfillStatusRunning
+	if processed > 0 {
+		b.CursorID = &cursor
+		b.RowsProcessed += int64(processed)
+	}
+	b.LastError = nil
+	b.UpdatedAt = now
+
+	if processed < b.BatchSize {
+		b.Status = BackfillStatusCompleted
+		b.CompletedAt = &now
+	}
+}
+
+// RecordFailure keeps the cursor so the failed batch is retried on the next run
+func (b *Backfill) RecordFailure(err error) {
+	msg := err.Error()
+	b.LastError = &msg
+	b.UpdatedAt = time.Now().UTC()
+}
+
+// Progress returns the completed fraction in [0, 1]. The row total is an
+// estimate, so a running backfill never reports more than 99%.
+func (b *Backfill) Progress() float64 {
+	if b.Status == BackfillStatusCompleted {
+		return 1
+	}
+	if b.RowsTotal <= 0 {
+		return 0
+	}
+	progress := float64(b.RowsProcessed) / float64(b.RowsTotal)
+	if progress > 0.99 {
+		return 0.99
+	}
+	return progress
+}
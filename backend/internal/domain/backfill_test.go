@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfill_RecordBatch(t *testing.T) {
+	b := NewBackfill("conversation_refs_example", "conversation_refs", 100, 250)
+	assert.Equal(t, BackfillStatusPending, b.Status)
+	assert.Zero(t, b.Progress())
+
+	first := uuid.Must(uuid.NewV7())
+	b.RecordBatch(first, 100)
+	require.NotNil(t, b.StartedAt)
+	assert.Equal(t, BackfillStatusRunning, b.Status)
+	assert.Equal(t, first, *b.CursorID)
+	assert.Equal(t, int64(100), b.RowsProcessed)
+	assert.InDelta(t, 0.4, b.Progress(), 0.001)
+
+	b.RecordFailure(errors.New("lock timeout"))
+	require.NotNil(t, b.LastError)
+	assert.Equal(t, first, *b.CursorID)
+
+	second := uuid.Must(uuid.NewV7())
+	b.RecordBatch(second, 100)
+	assert.Nil(t, b.LastError)
+	assert.Equal(t, BackfillStatusRunning, b.Status)
+
+	// Empty short batch completes without moving the cursor
+	b.RecordBatch(uuid.Nil, 0)
+	assert.Equal(t, BackfillStatusCompleted, b.Status)
+	assert.Equal(t, second, *b.CursorID)
+	assert.Equal(t, int64(200), b.RowsProcessed)
+	assert.NotNil(t, b.CompletedAt)
+	assert.Equal(t, 1.0, b.Progress())
+}
+
+func TestBackfill_ProgressCapsEstimate(t *testing.T) {
+	b := NewBackfill("example", "conversation_refs", 10, 5)
+	b.RecordBatch(uuid.Must(uuid.NewV7()), 10)
+	assert.Equal(t, 0.99, b.Progress())
+}
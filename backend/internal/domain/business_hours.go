@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// ==================== BusinessHours ====================
+
+// BusinessHours is the weekly opening hours of an inbox in Timezone. Time
+// outside them does not count towards a conversation's delay, so queued
+// conversations do not gain priority overnight while nobody answers them.
+type BusinessHours struct {
+	// Timezone is an IANA time zone name
+	Timezone string
+	// Days holds the hours of each open weekday; a day without hours is
+	// closed
+	Days map[time.Weekday]OpeningHours
+}
+
+// OpeningHours is the open period of one day, as offsets from midnight.
+// Close may be 24h for a day open until midnight.
+type OpeningHours struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// Elapsed returns how much of the time between from and to falls within the
+// business hours. Business hours whose time zone cannot be loaded count all
+// of it.
+func (b *BusinessHours) Elapsed(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil {
+		return to.Sub(from)
+	}
+
+	var elapsed time.Duration
+	local := from.In(loc)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		hours, ok := b.Days[day.Weekday()]
+		if !ok {
+			continue
+		}
+		opens, closes := atTimeOfDay(day, hours.Open), atTimeOfDay(day, hours.Close)
+		if opens.Before(from) {
+			opens = from
+		}
+		if closes.After(to) {
+			closes = to
+		}
+		if closes.After(opens) {
+			elapsed += closes.Sub(opens)
+		}
+	}
+	return elapsed
+}
+
+// atTimeOfDay returns the wall clock time offset from the day's midnight,
+// so days with a daylight saving change keep their opening hours
+func atTimeOfDay(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(),
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, day.Location())
+}
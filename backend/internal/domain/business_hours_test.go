@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func weekdays(opens, closes time.Duration) map[time.Weekday]OpeningHours {
+	days := make(map[time.Weekday]OpeningHours)
+	for day := time.Monday; day <= time.Friday; day++ {
+		days[day] = OpeningHours{Open: opens, Close: closes}
+	}
+	return days
+}
+
+func TestBusinessHours_Elapsed(t *testing.T) {
+	hours := &BusinessHours{Timezone: "UTC", Days: weekdays(9*time.Hour, 17*time.Hour)}
+	// 2024-01-01 is a Monday
+	at := func(day, hour, min int) time.Time { return time.Date(2024, 1, day, hour, min, 0, 0, time.UTC) }
+
+	assert.Equal(t, 2*time.Hour, hours.Elapsed(at(1, 10, 0), at(1, 12, 0)), "within the day")
+	assert.Equal(t, time.Hour, hours.Elapsed(at(1, 8, 0), at(1, 10, 0)), "before opening")
+	assert.Zero(t, hours.Elapsed(at(1, 18, 0), at(2, 8, 0)), "overnight")
+	assert.Equal(t, 2*time.Hour, hours.Elapsed(at(1, 16, 0), at(2, 10, 0)), "across the night")
+	assert.Equal(t, time.Hour, hours.Elapsed(at(5, 16, 0), at(8, 9, 0)), "across the weekend")
+	assert.Zero(t, hours.Elapsed(at(6, 10, 0), at(7, 10, 0)), "closed days")
+	assert.Zero(t, hours.Elapsed(at(1, 12, 0), at(1, 10, 0)), "to before from")
+
+	allDay := &BusinessHours{Timezone: "UTC", Days: map[time.Weekday]OpeningHours{time.Monday: {Open: 0, Close: 24 * time.Hour}}}
+	assert.Equal(t, 24*time.Hour, allDay.Elapsed(at(1, 0, 0), at(3, 0, 0)), "open until midnight")
+}
+
+func TestBusinessHours_ElapsedTimezone(t *testing.T) {
+	hours := &BusinessHours{Timezone: "America/New_York", Days: weekdays(9*time.Hour, 17*time.Hour)}
+	// 08:00-10:00 in New York (EST, UTC-5)
+	assert.Equal(t, time.Hour, hours.Elapsed(
+		time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)))
+
+	// Opening hours keep their wall clock time on the daylight saving change
+	// (2024-03-10, a Sunday)
+	sunday := &BusinessHours{Timezone: "America/New_York", Days: map[time.Weekday]OpeningHours{time.Sunday: {Open: 9 * time.Hour, Close: 17 * time.Hour}}}
+	assert.Equal(t, 8*time.Hour, sunday.Elapsed(
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)))
+
+	invalid := &BusinessHours{Timezone: "Not/AZone", Days: weekdays(9*time.Hour, 17*time.Hour)}
+	assert.Equal(t, 24*time.Hour, invalid.Elapsed(
+		time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)), "counts all time")
+}
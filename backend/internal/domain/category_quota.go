@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCategoryQuotas is returned for a quota set that cannot be met
+var ErrInvalidCategoryQuotas = errors.New("invalid category quotas")
+
+// ==================== CategoryQuota ====================
+
+// CategoryQuota reserves a minimum share of an inbox's allocations for
+// conversations carrying a label
+type CategoryQuota struct {
+	InboxID      uuid.UUID
+	LabelID      uuid.UUID
+	TenantID     uuid.UUID
+	SharePercent int
+	UpdatedBy    *uuid.UUID
+	UpdatedAt    time.Time
+}
+
+func NewCategoryQuota(tenantID, inboxID, labelID uuid.UUID, sharePercent int, updatedBy *uuid.UUID) *CategoryQuota {
+	return &CategoryQuota{
+		InboxID:      inboxID,
+		LabelID:      labelID,
+		TenantID:     tenantID,
+		SharePercent: sharePercent,
+		UpdatedBy:    updatedBy,
+		UpdatedAt:    time.Now().UTC(),
+	}
+}
+
+// ValidateCategoryQuotas checks that an inbox's quotas name each label once,
+// with shares of 1-100% that add up to at most 100%
+func ValidateCategoryQuotas(quotas []*CategoryQuota) error {
+	seen := make(map[uuid.UUID]bool, len(quotas))
+	total := 0
+	for _, q := range quotas {
+		if q.SharePercent < 1 || q.SharePercent > 100 {
+			return fmt.Errorf("%w: share_percent must be between 1 and 100", ErrInvalidCategoryQuotas)
+		}
+		if seen[q.LabelID] {
+			return fmt.Errorf("%w: label %s is listed twice", ErrInvalidCategoryQuotas, q.LabelID)
+		}
+		seen[q.LabelID] = true
+		total += q.SharePercent
+	}
+	if total > 100 {
+		return fmt.Errorf("%w: shares add up to %d%%", ErrInvalidCategoryQuotas, total)
+	}
+	return nil
+}
+
+// PreferredCategory returns the label of the quota furthest behind its
+// share, given how many of the inbox's recent allocations went to each label
+// and in total. It weighs the next allocation in, so a 30% share is
+// preferred for roughly three allocations out of ten. Nil when every quota
+// is met.
+func PreferredCategory(quotas []*CategoryQuota, allocated map[uuid.UUID]int, total int) *uuid.UUID {
+	var preferred *uuid.UUID
+	best := 0.0
+	for _, q := range quotas {
+		deficit := float64(q.SharePercent)/100*float64(total+1) - float64(allocated[q.LabelID])
+		if deficit > best {
+			best = deficit
+			label := q.LabelID
+			preferred = &label
+		}
+	}
+	return preferred
+}
+
+// AchievedSharePercent is the percentage of total allocations that went to a
+// category, zero without allocations
+func AchievedSharePercent(allocated, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(allocated) * 100 / float64(total)
+}
+
+// ==================== CategoryQuotaStatus ====================
+
+// CategoryQuotaStatus compares a quota with the share its category received
+// over the measurement window
+type CategoryQuotaStatus struct {
+	Quota *CategoryQuota
+	// Allocations is how many of the inbox's Total allocations went to the category
+	Allocations          int
+	Total                int
+	AchievedSharePercent float64
+}
+
+// ==================== AllocationPreference ====================
+
+// AllocationPreference is how the next allocation honors category quotas:
+// conversations created before StarvedBefore go first so nothing starves,
+// then those carrying one of LabelIDs, the categories behind their share
+type AllocationPreference struct {
+	LabelIDs      []uuid.UUID
+	StarvedBefore time.Time
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCategoryQuotas(t *testing.T) {
+	tenantID, inboxID := uuid.New(), uuid.New()
+	complaints, billing := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		quotas  []*CategoryQuota
+		wantErr bool
+	}{
+		{name: "empty", quotas: nil},
+		{
+			name: "valid",
+			quotas: []*CategoryQuota{
+				NewCategoryQuota(tenantID, inboxID, complaints, 30, nil),
+				NewCategoryQuota(tenantID, inboxID, billing, 70, nil),
+			},
+		},
+		{
+			name:    "zero share",
+			quotas:  []*CategoryQuota{NewCategoryQuota(tenantID, inboxID, complaints, 0, nil)},
+			wantErr: true,
+		},
+		{
+			name: "duplicate label",
+			quotas: []*CategoryQuota{
+				NewCategoryQuota(tenantID, inboxID, complaints, 10, nil),
+				NewCategoryQuota(tenantID, inboxID, complaints, 20, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "over 100%",
+			quotas: []*CategoryQuota{
+				NewCategoryQuota(tenantID, inboxID, complaints, 60, nil),
+				NewCategoryQuota(tenantID, inboxID, billing, 50, nil),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCategoryQuotas(tt.quotas)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCategoryQuotas)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPreferredCategory(t *testing.T) {
+	complaints := uuid.New()
+	quotas := []*CategoryQuota{NewCategoryQuota(uuid.New(), uuid.New(), complaints, 30, nil)}
+
+	t.Run("behind its share", func(t *testing.T) {
+		preferred := PreferredCategory(quotas, map[uuid.UUID]int{complaints: 2}, 10)
+		require.NotNil(t, preferred)
+		assert.Equal(t, complaints, *preferred)
+	})
+
+	t.Run("share met", func(t *testing.T) {
+		assert.Nil(t, PreferredCategory(quotas, map[uuid.UUID]int{complaints: 4}, 10))
+	})
+
+	t.Run("converges to the share", func(t *testing.T) {
+		allocated := map[uuid.UUID]int{}
+		for total := 0; total < 100; total++ {
+			if PreferredCategory(quotas, allocated, total) != nil {
+				allocated[complaints]++
+			}
+		}
+		assert.InDelta(t, 30, allocated[complaints], 1)
+	})
+
+	t.Run("furthest behind wins", func(t *testing.T) {
+		billing := uuid.New()
+		both := append(quotas, NewCategoryQuota(uuid.New(), uuid.New(), billing, 50, nil))
+		preferred := PreferredCategory(both, map[uuid.UUID]int{complaints: 3, billing: 1}, 10)
+		require.NotNil(t, preferred)
+		assert.Equal(t, billing, *preferred)
+	})
+}
+
+func TestAchievedSharePercent(t *testing.T) {
+	assert.Equal(t, 0.0, AchievedSharePercent(0, 0))
+	assert.Equal(t, 25.0, AchievedSharePercent(1, 4))
+}
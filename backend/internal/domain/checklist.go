@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits of an inbox checklist template
+const (
+	MaxChecklistItems      = 20
+	MaxChecklistItemLength = 200
+	MaxPinnedNoteLength    = MaxHandoverNoteLength
+)
+
+// ErrInvalidChecklistTemplate is returned for a template with too many,
+// empty, too long or repeated items, or a too long pinned note
+var ErrInvalidChecklistTemplate = errors.New("invalid checklist template")
+
+// ==================== ChecklistTemplate ====================
+
+// ChecklistTemplate is an inbox's standard checklist, such as "verify
+// identity" and "confirm order number", and the note pinned to every
+// conversation of the inbox. Both are instantiated on a conversation when it
+// is first allocated.
+type ChecklistTemplate struct {
+	InboxID    uuid.UUID
+	TenantID   uuid.UUID
+	Items      []string
+	PinnedNote *string
+	// Strict refuses to resolve conversations with unticked items
+	Strict    bool
+	UpdatedBy *uuid.UUID
+	UpdatedAt time.Time
+}
+
+func NewChecklistTemplate(tenantID, inboxID uuid.UUID, items []string, pinnedNote *string, strict bool, updatedBy *uuid.UUID) *ChecklistTemplate {
+	trimmed := make([]string, len(items))
+	for i, item := range items {
+		trimmed[i] = strings.TrimSpace(item)
+	}
+	if pinnedNote != nil {
+		note := strings.TrimSpace(*pinnedNote)
+		pinnedNote = &note
+		if note == "" {
+			pinnedNote = nil
+		}
+	}
+	return &ChecklistTemplate{
+		InboxID:    inboxID,
+		TenantID:   tenantID,
+		Items:      trimmed,
+		PinnedNote: pinnedNote,
+		Strict:     strict,
+		UpdatedBy:  updatedBy,
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+// Validate checks the template's limits
+func (t *ChecklistTemplate) Validate() error {
+	if len(t.Items) > MaxChecklistItems {
+		return ErrInvalidChecklistTemplate
+	}
+	seen := make(map[string]bool, len(t.Items))
+	for _, item := range t.Items {
+		if item == "" || len([]rune(item)) > MaxChecklistItemLength || seen[item] {
+			return ErrInvalidChecklistTemplate
+		}
+		seen[item] = true
+	}
+	if t.PinnedNote != nil && len([]rune(*t.PinnedNote)) > MaxPinnedNoteLength {
+		return ErrInvalidChecklistTemplate
+	}
+	return nil
+}
+
+// IsEmpty reports whether the template adds nothing to conversations
+func (t *ChecklistTemplate) IsEmpty() bool {
+	return len(t.Items) == 0 && t.PinnedNote == nil && !t.Strict
+}
+
+// ==================== ChecklistItem ====================
+
+// ChecklistItem is one item of a conversation's checklist, copied from the
+// inbox template when the conversation was first allocated
+type ChecklistItem struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	Position       int
+	Label          string
+	CompletedBy    *uuid.UUID
+	CompletedAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// SetCompleted ticks or unticks the item; ticking a ticked item keeps who
+// ticked it first
+func (i *ChecklistItem) SetCompleted(completed bool, by uuid.UUID) {
+	if !completed {
+		i.CompletedBy, i.CompletedAt = nil, nil
+		return
+	}
+	if i.CompletedAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	i.CompletedBy, i.CompletedAt = &by, &now
+}
+
+// ConversationChecklist is a conversation's checklist and pinned note
+type ConversationChecklist struct {
+	ConversationID uuid.UUID
+	Items          []*ChecklistItem
+	PinnedNote     *ConversationNote
+	// Strict is whether the inbox template refuses to resolve the
+	// conversation while items are unticked
+	Strict bool
+}
+
+// Complete reports whether every item is ticked
+func (c *ConversationChecklist) Complete() bool {
+	for _, item := range c.Items {
+		if item.CompletedAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ChecklistStatus counts a conversation's checklist items
+type ChecklistStatus struct {
+	Total      int
+	Incomplete int
+}
+
+// Complete reports whether every item is ticked; a conversation without a
+// checklist is complete
+func (s ChecklistStatus) Complete() bool {
+	return s.Incomplete == 0
+}
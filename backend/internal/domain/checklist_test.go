@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklistTemplate_Validate(t *testing.T) {
+	tenantID, inboxID := uuid.New(), uuid.New()
+	note := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		items   []string
+		note    *string
+		wantErr bool
+	}{
+		{"valid", []string{"Verify identity", "Confirm order number"}, note("Be polite"), false},
+		{"empty template", nil, nil, false},
+		{"blank item", []string{"Verify identity", "  "}, nil, true},
+		{"repeated item", []string{"Verify identity", " Verify identity"}, nil, true},
+		{"too long item", []string{strings.Repeat("x", MaxChecklistItemLength+1)}, nil, true},
+		{"too many items", make([]string, MaxChecklistItems+1), nil, true},
+		{"too long note", nil, note(strings.Repeat("x", MaxPinnedNoteLength+1)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewChecklistTemplate(tenantID, inboxID, tt.items, tt.note, false, nil).Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidChecklistTemplate)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChecklistTemplate_BlankNoteIsDropped(t *testing.T) {
+	blank := "   "
+	template := NewChecklistTemplate(uuid.New(), uuid.New(), nil, &blank, false, nil)
+	assert.Nil(t, template.PinnedNote)
+	assert.True(t, template.IsEmpty())
+}
+
+func TestChecklistItem_SetCompleted(t *testing.T) {
+	item := &ChecklistItem{Label: "Verify identity"}
+	first, second := uuid.New(), uuid.New()
+
+	item.SetCompleted(true, first)
+	assert.Equal(t, &first, item.CompletedBy)
+	completedAt := item.CompletedAt
+
+	item.SetCompleted(true, second)
+	assert.Equal(t, &first, item.CompletedBy)
+	assert.Equal(t, completedAt, item.CompletedAt)
+
+	item.SetCompleted(false, second)
+	assert.Nil(t, item.CompletedBy)
+	assert.Nil(t, item.CompletedAt)
+}
+
+func TestChecklistStatus_Complete(t *testing.T) {
+	assert.True(t, ChecklistStatus{}.Complete())
+	assert.True(t, ChecklistStatus{Total: 2}.Complete())
+	assert.False(t, ChecklistStatus{Total: 2, Incomplete: 1}.Complete())
+}
+
+func TestConversationChecklist_Complete(t *testing.T) {
+	now := time.Now()
+	checklist := &ConversationChecklist{Items: []*ChecklistItem{{CompletedAt: &now}, {}}}
+	assert.False(t, checklist.Complete())
+
+	checklist.Items[1].CompletedAt = &now
+	assert.True(t, checklist.Complete())
+	assert.True(t, (&ConversationChecklist{}).Complete())
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidCategory = errors.New("category must be 1-50 characters of a-z, 0-9, '_', '-' or '.'")
+)
+
+// MaxCategoryLength matches conversation_refs.category
+const MaxCategoryLength = 50
+
+// NormalizeCategory lowercases and trims a classifier category and checks it
+// is a short identifier that routing rules and reports can match exactly
+func NormalizeCategory(raw string) (string, error) {
+	category := strings.ToLower(strings.TrimSpace(raw))
+	if category == "" || len(category) > MaxCategoryLength {
+		return "", ErrInvalidCategory
+	}
+	for _, r := range category {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+		default:
+			return "", ErrInvalidCategory
+		}
+	}
+	return category, nil
+}
+
+// ==================== TenantClassifier ====================
+
+// TenantClassifier is the intent classification endpoint of a tenant. While
+// enabled, messages are classified on ingestion and message updates.
+type TenantClassifier struct {
+	TenantID    uuid.UUID
+	EndpointURL string
+	IsEnabled   bool
+	UpdatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewTenantClassifier(tenantID uuid.UUID, endpointURL string, enabled bool, updatedBy *uuid.UUID) *TenantClassifier {
+	now := time.Now().UTC()
+	return &TenantClassifier{
+		TenantID:    tenantID,
+		EndpointURL: endpointURL,
+		IsEnabled:   enabled,
+		UpdatedBy:   updatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"lowercased and trimmed", "  Billing ", "billing", false},
+		{"separators allowed", "order.refund_request-v2", "order.refund_request-v2", false},
+		{"empty", "   ", "", true},
+		{"spaces inside", "billing question", "", true},
+		{"non ascii", "facturación", "", true},
+		{"too long", "a123456789b123456789c123456789d123456789e123456789f", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeCategory(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCategory)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema and worker protocol expectations of this binary. Raise
+// MaxSchemaVersion with every migration the binary understands, and
+// MinSchemaVersion once the code relies on a migration. Bump
+// WorkerProtocolVersion when workers change how they process shared rows
+// (grace periods, deliveries, intents) so that replicas on the previous
+// protocol stop their workers during a rolling upgrade.
+const (
+	MinSchemaVersion      int64 = 78
+	MaxSchemaVersion      int64 = 78
+	WorkerProtocolVersion int32 = 2
+)
+
+// ==================== SchemaVersion ====================
+
+// SchemaVersion is the migration state of the database
+type SchemaVersion struct {
+	Version int64
+	// Dirty is set while a migration failed half-way
+	Dirty bool
+}
+
+// ==================== WorkerInstance ====================
+
+// WorkerInstance is a running replica as recorded for its peers
+type WorkerInstance struct {
+	InstanceID       uuid.UUID
+	BinaryVersion    string
+	WorkerProtocol   int32
+	MinSchemaVersion int64
+	MaxSchemaVersion int64
+	WorkersEnabled   bool
+	StartedAt        time.Time
+	HeartbeatAt      time.Time
+}
+
+// NewWorkerInstance records this binary's expectations
+func NewWorkerInstance(instanceID uuid.UUID, binaryVersion string, workersEnabled bool, now time.Time) *WorkerInstance {
+	return &WorkerInstance{
+		InstanceID:       instanceID,
+		BinaryVersion:    binaryVersion,
+		WorkerProtocol:   WorkerProtocolVersion,
+		MinSchemaVersion: MinSchemaVersion,
+		MaxSchemaVersion: MaxSchemaVersion,
+		WorkersEnabled:   workersEnabled,
+		StartedAt:        now,
+		HeartbeatAt:      now,
+	}
+}
+
+// CheckWorkerCompatibility decides whether this binary may run workers
+// against the schema, given the newest worker protocol among the other live
+// replicas running workers (0 for none). It returns an empty reason when it
+// may.
+func CheckWorkerCompatibility(schema SchemaVersion, newestPeerProtocol int32) string {
+	switch {
+	case schema.Dirty:
+		return fmt.Sprintf("schema version %d is dirty", schema.Version)
+	case schema.Version < MinSchemaVersion:
+		return fmt.Sprintf("schema version %d is older than the supported minimum %d", schema.Version, MinSchemaVersion)
+	case schema.Version > MaxSchemaVersion:
+		return fmt.Sprintf("schema version %d is newer than the supported maximum %d", schema.Version, MaxSchemaVersion)
+	case newestPeerProtocol > WorkerProtocolVersion:
+		return fmt.Sprintf("a live replica runs worker protocol %d, newer than %d", newestPeerProtocol, WorkerProtocolVersion)
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWorkerCompatibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     SchemaVersion
+		peer       int32
+		compatible bool
+	}{
+		{"supported schema", SchemaVersion{Version: MaxSchemaVersion}, 0, true},
+		{"peer on the same protocol", SchemaVersion{Version: MinSchemaVersion}, WorkerProtocolVersion, true},
+		{"peer on an older protocol", SchemaVersion{Version: MaxSchemaVersion}, WorkerProtocolVersion - 1, true},
+		{"dirty schema", SchemaVersion{Version: MaxSchemaVersion, Dirty: true}, 0, false},
+		{"schema too old", SchemaVersion{Version: MinSchemaVersion - 1}, 0, false},
+		{"schema too new", SchemaVersion{Version: MaxSchemaVersion + 1}, 0, false},
+		{"peer on a newer protocol", SchemaVersion{Version: MaxSchemaVersion}, WorkerProtocolVersion + 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := CheckWorkerCompatibility(tt.schema, tt.peer)
+			assert.Equal(t, tt.compatible, reason == "", reason)
+		})
+	}
+}
+
+// Every migration has to be accepted by the binary that ships it
+func TestMaxSchemaVersion_MatchesMigrations(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	require.NoError(t, err)
+
+	pattern := regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+	var latest int64
+	for _, entry := range entries {
+		m := pattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		require.NoError(t, err)
+		if version > latest {
+			latest = version
+		}
+	}
+
+	assert.Equal(t, latest, MaxSchemaVersion, "raise MaxSchemaVersion with the new migration")
+	assert.LessOrEqual(t, MinSchemaVersion, MaxSchemaVersion)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxHandoverNoteLength caps the handover note of a reassignment, in characters
+const MaxHandoverNoteLength = 2000
+
+// ==================== ConversationNoteKind ====================
+
+type ConversationNoteKind string
+
+const (
+	// ConversationNoteHandover passes context from the manager reassigning a
+	// conversation to its new assignee
+	ConversationNoteHandover ConversationNoteKind = "HANDOVER"
+	// ConversationNotePinned is the pinned note of the inbox's checklist
+	// template, copied on the conversation's first allocation
+	ConversationNotePinned ConversationNoteKind = "PINNED"
+)
+
+func (k ConversationNoteKind) IsValid() bool {
+	return k == ConversationNoteHandover || k == ConversationNotePinned
+}
+
+// ==================== ConversationNote ====================
+
+// ConversationNote is an internal note on a conversation; customers never
+// see it
+type ConversationNote struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	Kind           ConversationNoteKind
+	AuthorID       *uuid.UUID
+	// RecipientID is the operator the note is addressed to
+	RecipientID *uuid.UUID
+	Body        string
+	CreatedAt   time.Time
+}
+
+// NewHandoverNote addresses a reassignment note to the new assignee
+func NewHandoverNote(conv *ConversationRef, authorID, recipientID uuid.UUID, body string) *ConversationNote {
+	return &ConversationNote{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       conv.TenantID,
+		ConversationID: conv.ID,
+		Kind:           ConversationNoteHandover,
+		AuthorID:       &authorID,
+		RecipientID:    &recipientID,
+		Body:           body,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
+)
+
+// conversationOp is one state machine operation; it reports whether the
+// operation applies so the test can skip what services never attempt
+type conversationOp struct {
+	name  string
+	apply func(f *testutil.Factory, c *domain.ConversationRef) (bool, error)
+}
+
+var conversationOps = []conversationOp{
+	{"allocate", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		// Allocation queries skip snoozed conversations
+		if c.IsSnoozed() {
+			return false, nil
+		}
+		return true, c.Allocate(f.UUID())
+	}},
+	{"deallocate", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		return true, c.Deallocate()
+	}},
+	{"resolve", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		return true, c.Resolve()
+	}},
+	{"reopen", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		return true, c.Reopen()
+	}},
+	{"snooze", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		return true, c.Snooze(f.Time().Add(time.Hour), f.Bool(0.5))
+	}},
+	{"end snooze", func(f *testutil.Factory, c *domain.ConversationRef) (bool, error) {
+		if !c.IsSnoozed() {
+			return false, nil
+		}
+		c.EndSnooze()
+		return true, nil
+	}},
+}
+
+// TestConversationRef_StateMachineProperties applies random operation
+// sequences to random conversations and checks the invariants after each
+// step. A failure logs its seed: rerun with TEST_SEED to replay it.
+func TestConversationRef_StateMachineProperties(t *testing.T) {
+	f := testutil.SeededFactory(t)
+
+	for run := 0; run < 200; run++ {
+		conv := f.Conversation(f.UUID(), f.UUID())
+		var history []string
+
+		for step := 0; step < 30; step++ {
+			op := conversationOps[f.Rand().Intn(len(conversationOps))]
+			before := *conv
+			applied, err := op.apply(f, conv)
+			if !applied {
+				continue
+			}
+			history = append(history, op.name)
+
+			if err != nil {
+				if err != domain.ErrInvalidStateTransition {
+					t.Fatalf("run %d %v: unexpected error %v", run, history, err)
+				}
+				if conv.State != before.State || conv.ReopenedCount != before.ReopenedCount ||
+					!uuidPtrEqual(conv.AssignedOperatorID, before.AssignedOperatorID) {
+					t.Fatalf("run %d %v: rejected operation changed the conversation", run, history)
+				}
+				continue
+			}
+
+			if before.State != conv.State && !before.State.CanTransitionTo(conv.State) {
+				t.Fatalf("run %d %v: illegal transition %s -> %s", run, history, before.State, conv.State)
+			}
+			checkConversationInvariants(t, conv, history)
+			if conv.ReopenedCount < before.ReopenedCount {
+				t.Fatalf("run %d %v: reopened count decreased", run, history)
+			}
+		}
+	}
+}
+
+func checkConversationInvariants(t *testing.T, c *domain.ConversationRef, history []string) {
+	t.Helper()
+	if !c.State.IsValid() {
+		t.Fatalf("%v: invalid state %q", history, c.State)
+	}
+	if (c.State == domain.ConversationStateAllocated) != (c.AssignedOperatorID != nil) && c.State != domain.ConversationStateResolved {
+		t.Fatalf("%v: state %s with assigned operator %v", history, c.State, c.AssignedOperatorID)
+	}
+	if (c.State == domain.ConversationStateResolved) != (c.ResolvedAt != nil) {
+		t.Fatalf("%v: state %s with resolved_at %v", history, c.State, c.ResolvedAt)
+	}
+	if c.IsSnoozed() && c.State != domain.ConversationStateQueued {
+		t.Fatalf("%v: snoozed conversation in state %s", history, c.State)
+	}
+	if c.SnoozeOperatorID != nil && !c.IsSnoozed() {
+		t.Fatalf("%v: snooze operator without a snooze", history)
+	}
+}
+
+func uuidPtrEqual(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
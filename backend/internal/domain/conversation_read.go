@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationRead is the last time an operator viewed a conversation
+type ConversationRead struct {
+	OperatorID     uuid.UUID
+	ConversationID uuid.UUID
+	TenantID       uuid.UUID
+	LastReadAt     time.Time
+}
+
+func NewConversationRead(conv *ConversationRef, operatorID uuid.UUID) *ConversationRead {
+	return &ConversationRead{
+		OperatorID:     operatorID,
+		ConversationID: conv.ID,
+		TenantID:       conv.TenantID,
+		LastReadAt:     time.Now().UTC(),
+	}
+}
+
+// IsUnread reports whether conv has customer messages the operator has not
+// seen: any message when never read, else one after lastReadAt
+func IsUnread(conv *ConversationRef, lastReadAt *time.Time) bool {
+	if conv.MessageCount == 0 {
+		return false
+	}
+	if lastReadAt == nil {
+		return true
+	}
+	return conv.LastMessageAt.After(*lastReadAt)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestIsUnread(t *testing.T) {
+	now := time.Now().UTC()
+	before, after := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		messageCount int32
+		lastReadAt   *time.Time
+		want         bool
+	}{
+		{"no messages, never read", 0, nil, false},
+		{"messages, never read", 3, nil, true},
+		{"message after last read", 3, &before, true},
+		{"read after last message", 3, &after, false},
+		{"read at last message", 3, &now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv := &ConversationRef{ID: uuid.New(), MessageCount: tt.messageCount, LastMessageAt: now}
+			if got := IsUnread(conv, tt.lastReadAt); got != tt.want {
+				t.Errorf("IsUnread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
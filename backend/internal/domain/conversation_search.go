@@ -0,0 +1,29 @@
+package domain
+
+// ==================== Conversation Search ====================
+
+// SearchField names what a full-text search matched in a conversation
+type SearchField string
+
+const (
+	SearchFieldExternalConversationID SearchField = "external_conversation_id"
+	SearchFieldCustomerName           SearchField = "customer_name"
+	SearchFieldNotes                  SearchField = "notes"
+)
+
+// Weights of a match in each field; a conversation's rank is the sum of the
+// weighted ts_rank of every field that matched, so an external ID match
+// outranks the same words in a note
+const (
+	SearchWeightExternalConversationID = 1.0
+	SearchWeightCustomerName           = 0.8
+	SearchWeightNotes                  = 0.5
+)
+
+// ConversationSearchHit is a conversation found by full-text search
+type ConversationSearchHit struct {
+	Conversation *ConversationRef
+	Rank         float64
+	// MatchedFields lists the fields the query matched, sorted
+	MatchedFields []SearchField
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxCustomerNameLength caps a customer's name, in characters
+	MaxCustomerNameLength = 255
+	// MaxCustomerMetadataSize caps a customer's metadata, in bytes of JSON
+	MaxCustomerMetadataSize = 16 * 1024
+)
+
+// ==================== Customer ====================
+
+// Customer is the profile of the person behind a phone number. Ingestion
+// creates it on first contact and links every conversation from the number
+// to it, so repeated contacts can be grouped.
+type Customer struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	PhoneNumber string
+	Name        *string
+	// Metadata is free-form data about the customer, kept by the tenant
+	Metadata  map[string]interface{}
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCustomer(tenantID uuid.UUID, phoneNumber string) *Customer {
+	now := time.Now().UTC()
+	return &Customer{
+		ID:          uuid.Must(uuid.NewV7()),
+		TenantID:    tenantID,
+		PhoneNumber: phoneNumber,
+		Metadata:    map[string]interface{}{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// SetProfile updates the customer's name and metadata; nil leaves a field
+// unchanged and an empty name clears it. Returns true if anything changed.
+func (c *Customer) SetProfile(name *string, metadata map[string]interface{}) bool {
+	changed := setOptionalString(&c.Name, name)
+	if metadata != nil && !reflect.DeepEqual(c.Metadata, metadata) {
+		c.Metadata = metadata
+		changed = true
+	}
+	if changed {
+		c.UpdatedAt = time.Now().UTC()
+	}
+	return changed
+}
+
+// CustomerMetadataSize returns the size of metadata once stored
+func CustomerMetadataSize(metadata map[string]interface{}) int {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// ==================== Operator Affinity ====================
+
+// CustomerOperatorAffinity is the operator who last resolved a conversation
+// of the customer. Allocation offers that operator the customer's queued
+// conversations before the rest of the queue, while the affinity is recent.
+type CustomerOperatorAffinity struct {
+	CustomerID uuid.UUID
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+	HandledAt  time.Time
+}
+
+// AffinityOnResolve returns the affinity the resolution of conv records;
+// nil for a conversation without a customer or an assigned operator
+func AffinityOnResolve(conv *ConversationRef) *CustomerOperatorAffinity {
+	if conv.CustomerID == nil || conv.AssignedOperatorID == nil {
+		return nil
+	}
+	handledAt := time.Now().UTC()
+	if conv.ResolvedAt != nil {
+		handledAt = *conv.ResolvedAt
+	}
+	return &CustomerOperatorAffinity{
+		CustomerID: *conv.CustomerID,
+		TenantID:   conv.TenantID,
+		OperatorID: *conv.AssignedOperatorID,
+		HandledAt:  handledAt,
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomer_SetProfile(t *testing.T) {
+	c := NewCustomer(uuid.New(), "+15550001111")
+	name := "Ada"
+	empty := ""
+
+	assert.True(t, c.SetProfile(&name, nil))
+	assert.Equal(t, "Ada", *c.Name)
+	assert.False(t, c.SetProfile(&name, nil), "same name")
+
+	assert.True(t, c.SetProfile(nil, map[string]interface{}{"tier": "gold"}))
+	assert.False(t, c.SetProfile(nil, map[string]interface{}{"tier": "gold"}), "same metadata")
+	assert.Equal(t, "Ada", *c.Name, "nil leaves the name alone")
+
+	assert.True(t, c.SetProfile(&empty, nil))
+	assert.Nil(t, c.Name)
+	assert.Equal(t, map[string]interface{}{"tier": "gold"}, c.Metadata, "nil leaves the metadata alone")
+}
+
+func TestCustomerMetadataSize(t *testing.T) {
+	assert.Equal(t, len(`{}`), CustomerMetadataSize(map[string]interface{}{}))
+	assert.Equal(t, len(`{"a":1}`), CustomerMetadataSize(map[string]interface{}{"a": 1}))
+}
+
+func TestAffinityOnResolve(t *testing.T) {
+	customerID, operatorID := uuid.New(), uuid.New()
+	resolvedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	conv := &ConversationRef{TenantID: uuid.New(), CustomerID: &customerID, AssignedOperatorID: &operatorID, ResolvedAt: &resolvedAt}
+
+	affinity := AffinityOnResolve(conv)
+	require.NotNil(t, affinity)
+	assert.Equal(t, customerID, affinity.CustomerID)
+	assert.Equal(t, conv.TenantID, affinity.TenantID)
+	assert.Equal(t, operatorID, affinity.OperatorID)
+	assert.Equal(t, resolvedAt, affinity.HandledAt)
+
+	assert.Nil(t, AffinityOnResolve(&ConversationRef{AssignedOperatorID: &operatorID}), "no customer")
+	assert.Nil(t, AffinityOnResolve(&ConversationRef{CustomerID: &customerID}), "no operator")
+}
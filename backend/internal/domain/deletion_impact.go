@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeletionResource is a kind of resource whose deletion is guarded
+type DeletionResource string
+
+const (
+	DeletionResourceInbox    DeletionResource = "inbox"
+	DeletionResourceLabel    DeletionResource = "label"
+	DeletionResourceOperator DeletionResource = "operator"
+)
+
+// DeletionImpact counts what deleting a resource would affect. Conversations
+// are the resource's dependent conversations: those of an inbox (deleted
+// with it), those carrying a label (which lose it) or those assigned to an
+// operator (left without an operator). Counts that do not apply to the
+// resource are zero.
+type DeletionImpact struct {
+	Resource DeletionResource
+	ID       uuid.UUID
+	// Conversations counts every dependent conversation, OpenConversations
+	// the QUEUED or ALLOCATED ones among them
+	Conversations     int64
+	OpenConversations int64
+	Subscriptions     int64
+	// RoutingRules are the rules of an inbox (deleted with it), or the rules
+	// attaching a label by name (which re-create it)
+	RoutingRules int64
+	GracePeriods int64
+}
+
+// Blockers lists what makes the deletion lose active work, for which the
+// deletion guard refuses it unless forced; empty when the resource can be
+// deleted safely
+func (d *DeletionImpact) Blockers() []string {
+	var blockers []string
+	switch d.Resource {
+	case DeletionResourceInbox, DeletionResourceOperator:
+		if d.OpenConversations > 0 {
+			blockers = append(blockers, countOf(d.OpenConversations, "open conversation"))
+		}
+		if d.GracePeriods > 0 {
+			blockers = append(blockers, countOf(d.GracePeriods, "grace period"))
+		}
+	case DeletionResourceLabel:
+		if d.RoutingRules > 0 {
+			blockers = append(blockers, countOf(d.RoutingRules, "routing rule"))
+		}
+	}
+	return blockers
+}
+
+func countOf(n int64, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
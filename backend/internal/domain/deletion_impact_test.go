@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionImpact_Blockers(t *testing.T) {
+	tests := []struct {
+		name   string
+		impact DeletionImpact
+		want   []string
+	}{
+		{
+			"inbox with only resolved conversations",
+			DeletionImpact{Resource: DeletionResourceInbox, Conversations: 12, Subscriptions: 3, RoutingRules: 1},
+			nil,
+		},
+		{
+			"inbox with open conversations and a grace period",
+			DeletionImpact{Resource: DeletionResourceInbox, Conversations: 12, OpenConversations: 2, GracePeriods: 1},
+			[]string{"2 open conversations", "1 grace period"},
+		},
+		{
+			"operator with an allocated conversation",
+			DeletionImpact{Resource: DeletionResourceOperator, OpenConversations: 1, Subscriptions: 4},
+			[]string{"1 open conversation"},
+		},
+		{
+			"label on open conversations",
+			DeletionImpact{Resource: DeletionResourceLabel, Conversations: 5, OpenConversations: 5},
+			nil,
+		},
+		{
+			"label attached by routing rules",
+			DeletionImpact{Resource: DeletionResourceLabel, RoutingRules: 2},
+			[]string{"2 routing rules"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.impact.ID = uuid.New()
+			assert.Equal(t, tt.want, tt.impact.Blockers())
+		})
+	}
+}
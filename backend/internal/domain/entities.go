@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,46 @@ type Tenant struct {
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 	UpdatedBy           *uuid.UUID
+	// FirstContactBoost is added to the priority of first-contact
+	// conversations; zero disables it
+	FirstContactBoost decimal.Decimal
+	// GracePeriod is how long an offline operator keeps their conversations;
+	// nil uses the server default
+	GracePeriod *time.Duration
+	// IntakeLimitPerHour is the most conversations a customer phone number
+	// may start per inbox within an hour; nil disables the limit
+	IntakeLimitPerHour *int
+	// AllocationEngine is the allocation logic the tenant is pinned to
+	AllocationEngine AllocationEngine
+	// Sandbox marks a trial tenant holding demo data only; see sandbox.go
+	Sandbox bool
+}
+
+// TenantSettings are the tenant's operational settings, replaced together
+type TenantSettings struct {
+	GracePeriod        *time.Duration
+	IntakeLimitPerHour *int
+	// AllocationEngine is empty for DefaultAllocationEngine
+	AllocationEngine AllocationEngine
+}
+
+// Settings returns the tenant's operational settings
+func (t *Tenant) Settings() TenantSettings {
+	return TenantSettings{
+		GracePeriod:        t.GracePeriod,
+		IntakeLimitPerHour: t.IntakeLimitPerHour,
+		AllocationEngine:   t.AllocationEngine,
+	}
+}
+
+// ApplySettings replaces the tenant's operational settings
+func (t *Tenant) ApplySettings(settings TenantSettings) {
+	t.GracePeriod = settings.GracePeriod
+	t.IntakeLimitPerHour = settings.IntakeLimitPerHour
+	t.AllocationEngine = settings.AllocationEngine
+	if t.AllocationEngine == "" {
+		t.AllocationEngine = DefaultAllocationEngine
+	}
 }
 
 func NewTenant(name string, alpha, beta decimal.Decimal) *Tenant {
@@ -28,6 +69,7 @@ func NewTenant(name string, alpha, beta decimal.Decimal) *Tenant {
 		PriorityWeightBeta:  beta,
 		CreatedAt:           now,
 		UpdatedAt:           now,
+		AllocationEngine:    DefaultAllocationEngine,
 	}
 }
 
@@ -38,8 +80,21 @@ type Inbox struct {
 	TenantID    uuid.UUID
 	PhoneNumber string
 	DisplayName string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// IsRestricted requires managers and admins to state a reason when
+	// opening its conversations without being assigned to them
+	IsRestricted bool
+	// EscalationInboxID is where operators escalate the inbox's
+	// conversations to; nil disables escalation
+	EscalationInboxID *uuid.UUID
+	// AutoResolveAfter is how long open conversations may go without a
+	// customer message before the auto-resolve worker resolves them; nil
+	// disables auto-resolution
+	AutoResolveAfter *time.Duration
+	// BusinessHours limits the delay of its conversations' priority to time
+	// the inbox is open; nil counts all time
+	BusinessHours *BusinessHours
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func NewInbox(tenantID uuid.UUID, phoneNumber, displayName string) *Inbox {
@@ -57,9 +112,16 @@ func NewInbox(tenantID uuid.UUID, phoneNumber, displayName string) *Inbox {
 // ==================== Operator ====================
 
 type Operator struct {
-	ID        uuid.UUID
-	TenantID  uuid.UUID
-	Role      OperatorRole
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	Role     OperatorRole
+	// Name and Email are the optional profile shown to customers; webhooks
+	// opt into them through WebhookOperatorField
+	Name  *string
+	Email *string
+	// Languages the operator handles conversations in, normalized; empty
+	// takes conversations in any language
+	Languages []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -70,11 +132,51 @@ func NewOperator(tenantID uuid.UUID, role OperatorRole) *Operator {
 		ID:        uuid.Must(uuid.NewV7()),
 		TenantID:  tenantID,
 		Role:      role,
+		Languages: []string{},
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
+// SetProfile applies a profile change: a nil field is left unchanged and an
+// empty one is cleared. Reports whether anything changed.
+func (o *Operator) SetProfile(name, email *string) bool {
+	changed := setOptionalString(&o.Name, name)
+	if setOptionalString(&o.Email, email) {
+		changed = true
+	}
+	return changed
+}
+
+// SetLanguages replaces the operator's languages, which must be normalized.
+// Reports whether they changed.
+func (o *Operator) SetLanguages(languages []string) bool {
+	if slices.Equal(o.Languages, languages) {
+		return false
+	}
+	o.Languages = languages
+	return true
+}
+
+func setOptionalString(field **string, value *string) bool {
+	if value == nil {
+		return false
+	}
+	if *value == "" {
+		if *field == nil {
+			return false
+		}
+		*field = nil
+		return true
+	}
+	if *field != nil && **field == *value {
+		return false
+	}
+	v := *value
+	*field = &v
+	return true
+}
+
 // ==================== OperatorInboxSubscription ====================
 
 type OperatorInboxSubscription struct {
@@ -93,13 +195,43 @@ func NewOperatorInboxSubscription(operatorID, inboxID uuid.UUID) *OperatorInboxS
 	}
 }
 
+// ==================== OperatorShadow ====================
+
+// OperatorShadow attaches a trainee to a mentor. The trainee may read the
+// mentor's allocated conversations but never mutate them.
+type OperatorShadow struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	MentorID  uuid.UUID
+	TraineeID uuid.UUID
+	CreatedBy *uuid.UUID
+	CreatedAt time.Time
+}
+
+func NewOperatorShadow(tenantID, mentorID, traineeID uuid.UUID, createdBy *uuid.UUID) *OperatorShadow {
+	return &OperatorShadow{
+		ID:        uuid.Must(uuid.NewV7()),
+		TenantID:  tenantID,
+		MentorID:  mentorID,
+		TraineeID: traineeID,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
 // ==================== OperatorStatus ====================
 
+// MaxFocusDuration is the longest focus an operator can ask for
+const MaxFocusDuration = 4 * time.Hour
+
 type OperatorStatus struct {
 	ID                 uuid.UUID
 	OperatorID         uuid.UUID
 	Status             OperatorStatusType
 	LastStatusChangeAt time.Time
+	// FocusUntil keeps an AVAILABLE operator from receiving new conversations
+	// until then; focus ends on its own once it has passed
+	FocusUntil *time.Time
 }
 
 func NewOperatorStatus(operatorID uuid.UUID) *OperatorStatus {
@@ -111,9 +243,41 @@ func NewOperatorStatus(operatorID uuid.UUID) *OperatorStatus {
 	}
 }
 
+// SetStatus changes the status, which ends any focus
 func (os *OperatorStatus) SetStatus(status OperatorStatusType) {
 	os.Status = status
 	os.LastStatusChangeAt = time.Now().UTC()
+	os.FocusUntil = nil
+}
+
+// StartFocus stops new allocations to the operator until until
+func (os *OperatorStatus) StartFocus(until time.Time) {
+	until = until.UTC()
+	os.FocusUntil = &until
+}
+
+// EndFocus ends focus early
+func (os *OperatorStatus) EndFocus() {
+	os.FocusUntil = nil
+}
+
+// InFocus reports whether the operator is in focus at now
+func (os *OperatorStatus) InFocus(now time.Time) bool {
+	return os.FocusUntil != nil && now.Before(*os.FocusUntil)
+}
+
+// FocusRemaining returns how long focus lasts from now, zero when not in focus
+func (os *OperatorStatus) FocusRemaining(now time.Time) time.Duration {
+	if !os.InFocus(now) {
+		return 0
+	}
+	return os.FocusUntil.Sub(now)
+}
+
+// AcceptsAllocations reports whether conversations may be allocated to or
+// claimed by the operator at now
+func (os *OperatorStatus) AcceptsAllocations(now time.Time) bool {
+	return os.Status.AcceptsAllocations() && !os.InFocus(now)
 }
 
 // ==================== ConversationRef ====================
@@ -132,6 +296,37 @@ type ConversationRef struct {
 	CreatedAt              time.Time
 	UpdatedAt              time.Time
 	ResolvedAt             *time.Time
+	ReopenedCount          int32
+	// Category is the intent assigned by the tenant classifier, nil until classified
+	Category *string
+	// SLABreachedAt is set by the SLA worker when the conversation first
+	// misses a target of its inbox's SLA policy
+	SLABreachedAt *time.Time
+	// SnoozedUntil keeps a QUEUED conversation out of allocation until then;
+	// SnoozeOperatorID is who it returns to, nil for the queue
+	SnoozedUntil     *time.Time
+	SnoozeOperatorID *uuid.UUID
+	// PriorityOverride pins the conversation: allocation orders by it before
+	// PriorityScore, and conversations without one come after all pinned ones
+	PriorityOverride *decimal.Decimal
+	// IsFirstContact is set at ingestion when the tenant had no earlier
+	// conversation with the customer's phone number
+	IsFirstContact bool
+	// Version is incremented by every update; updating from a stale read
+	// fails with ErrConcurrentModification
+	Version int32
+	// Language is the customer's primary language (see NormalizeLanguage),
+	// set by the messaging gateway or the tenant classifier; nil until known
+	Language *string
+	// CustomerID is the customer profile of CustomerPhoneNumber, linked at
+	// ingestion; nil for conversations not linked yet
+	CustomerID *uuid.UUID
+	// DueAt is when the conversation should be resolved by, set by a manager
+	// or at creation from the inbox's SLA resolution target; nil for none
+	DueAt *time.Time
+	// OverdueAt is set by the overdue worker once DueAt passed with the
+	// conversation still open
+	OverdueAt *time.Time
 }
 
 func NewConversationRef(
@@ -151,6 +346,7 @@ func NewConversationRef(
 		PriorityScore:          decimal.Zero,
 		CreatedAt:              now,
 		UpdatedAt:              now,
+		Version:                1,
 	}
 }
 
@@ -167,7 +363,7 @@ func (c *ConversationRef) Allocate(operatorID uuid.UUID) error {
 
 // Deallocate returns conversation to queue
 func (c *ConversationRef) Deallocate() error {
-	if !c.State.CanTransitionTo(ConversationStateQueued) {
+	if c.State != ConversationStateAllocated || !c.State.CanTransitionTo(ConversationStateQueued) {
 		return ErrInvalidStateTransition
 	}
 	c.State = ConversationStateQueued
@@ -176,6 +372,19 @@ func (c *ConversationRef) Deallocate() error {
 	return nil
 }
 
+// Escalate takes an allocated conversation from its operator and queues it
+// in the escalation inbox
+func (c *ConversationRef) Escalate(toInboxID uuid.UUID) error {
+	if c.State != ConversationStateAllocated || !c.State.CanTransitionTo(ConversationStateQueued) {
+		return ErrInvalidStateTransition
+	}
+	c.InboxID = toInboxID
+	c.State = ConversationStateQueued
+	c.AssignedOperatorID = nil
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
 // Resolve marks conversation as resolved
 func (c *ConversationRef) Resolve() error {
 	if !c.State.CanTransitionTo(ConversationStateResolved) {
@@ -188,16 +397,97 @@ func (c *ConversationRef) Resolve() error {
 	return nil
 }
 
+// Reopen returns a resolved conversation to the queue when the customer writes again
+func (c *ConversationRef) Reopen() error {
+	if c.State != ConversationStateResolved || !c.State.CanTransitionTo(ConversationStateQueued) {
+		return ErrInvalidStateTransition
+	}
+	c.State = ConversationStateQueued
+	c.AssignedOperatorID = nil
+	c.ResolvedAt = nil
+	c.ReopenedCount++
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Snooze parks an allocated conversation until the given time: it leaves the
+// operator and waits in the queue without being allocated. With
+// returnToOperator the snooze worker gives it back to the same operator.
+func (c *ConversationRef) Snooze(until time.Time, returnToOperator bool) error {
+	if c.State != ConversationStateAllocated || !c.State.CanTransitionTo(ConversationStateQueued) {
+		return ErrInvalidStateTransition
+	}
+	c.SnoozedUntil = &until
+	c.SnoozeOperatorID = nil
+	if returnToOperator {
+		c.SnoozeOperatorID = c.AssignedOperatorID
+	}
+	c.State = ConversationStateQueued
+	c.AssignedOperatorID = nil
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// IsSnoozed reports whether the conversation is parked
+func (c *ConversationRef) IsSnoozed() bool {
+	return c.SnoozedUntil != nil
+}
+
+// EndSnooze returns a snoozed conversation to the queue
+func (c *ConversationRef) EndSnooze() {
+	c.SnoozedUntil = nil
+	c.SnoozeOperatorID = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// SetDueAt sets or, with nil, clears the due date. A new due date is
+// flagged overdue again once it passes.
+func (c *ConversationRef) SetDueAt(dueAt *time.Time) {
+	c.DueAt = dueAt
+	c.OverdueAt = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// IsOverdue reports whether the conversation is open past its due date
+func (c *ConversationRef) IsOverdue(now time.Time) bool {
+	return c.DueAt != nil && c.State != ConversationStateResolved && !now.Before(*c.DueAt)
+}
+
+// IsAllocatedTo reports whether the conversation is ALLOCATED to the operator
+func (c *ConversationRef) IsAllocatedTo(operatorID uuid.UUID) bool {
+	return c.State == ConversationStateAllocated && c.AssignedOperatorID != nil && *c.AssignedOperatorID == operatorID
+}
+
 // ==================== Label ====================
 
+// LabelScope is what a label applies to: one inbox, or every inbox of the
+// tenant
+type LabelScope string
+
+const (
+	LabelScopeInbox  LabelScope = "INBOX"
+	LabelScopeTenant LabelScope = "TENANT"
+)
+
+func (s LabelScope) IsValid() bool {
+	return s == LabelScopeInbox || s == LabelScopeTenant
+}
+
 type Label struct {
-	ID        uuid.UUID
-	TenantID  uuid.UUID
+	ID       uuid.UUID
+	TenantID uuid.UUID
+	// InboxID is uuid.Nil for a tenant-scoped label
 	InboxID   uuid.UUID
+	Scope     LabelScope
 	Name      string
 	Color     *string
 	CreatedBy *uuid.UUID
 	CreatedAt time.Time
+	// Version starts at 1 and increases with every rename or recolor
+	Version int
+	// ParentLabelID nests the label below another of its scope and inbox;
+	// nil for a top-level label
+	ParentLabelID *uuid.UUID
 }
 
 func NewLabel(tenantID, inboxID uuid.UUID, name string, color *string, createdBy *uuid.UUID) *Label {
@@ -205,11 +495,40 @@ func NewLabel(tenantID, inboxID uuid.UUID, name string, color *string, createdBy
 		ID:        uuid.Must(uuid.NewV7()),
 		TenantID:  tenantID,
 		InboxID:   inboxID,
+		Scope:     LabelScopeInbox,
 		Name:      name,
 		Color:     color,
 		CreatedBy: createdBy,
 		CreatedAt: time.Now().UTC(),
+		Version:   1,
+	}
+}
+
+// NewTenantLabel returns a label of every inbox of the tenant
+func NewTenantLabel(tenantID uuid.UUID, name string, color *string, createdBy *uuid.UUID) *Label {
+	label := NewLabel(tenantID, uuid.Nil, name, color, createdBy)
+	label.Scope = LabelScopeTenant
+	return label
+}
+
+// AppliesTo reports whether the label can be attached to conversations of
+// the inbox
+func (l *Label) AppliesTo(inboxID uuid.UUID) bool {
+	return l.Scope == LabelScopeTenant || l.InboxID == inboxID
+}
+
+// Change sets a new name and color and starts a new version when either
+// differs from the current one; it reports whether the label changed
+func (l *Label) Change(name string, color *string) bool {
+	sameColor := (l.Color == nil && color == nil) ||
+		(l.Color != nil && color != nil && *l.Color == *color)
+	if name == l.Name && sameColor {
+		return false
 	}
+	l.Name = name
+	l.Color = color
+	l.Version++
+	return true
 }
 
 // ==================== ConversationLabel ====================
@@ -218,14 +537,18 @@ type ConversationLabel struct {
 	ID             uuid.UUID
 	ConversationID uuid.UUID
 	LabelID        uuid.UUID
-	CreatedAt      time.Time
+	// LabelVersion is the label version at attach time, so reports keep the
+	// name the label had then
+	LabelVersion int
+	CreatedAt    time.Time
 }
 
-func NewConversationLabel(conversationID, labelID uuid.UUID) *ConversationLabel {
+func NewConversationLabel(conversationID uuid.UUID, label *Label) *ConversationLabel {
 	return &ConversationLabel{
 		ID:             uuid.Must(uuid.NewV7()),
 		ConversationID: conversationID,
-		LabelID:        labelID,
+		LabelID:        label.ID,
+		LabelVersion:   label.Version,
 		CreatedAt:      time.Now().UTC(),
 	}
 }
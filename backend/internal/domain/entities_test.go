@@ -81,6 +81,22 @@ func TestNewOperator(t *testing.T) {
 	}
 }
 
+func TestOperator_SetProfile(t *testing.T) {
+	name, email, empty := "Maria", "maria@example.com", ""
+	operator := NewOperator(uuid.Must(uuid.NewV7()), OperatorRoleOperator)
+
+	assert.True(t, operator.SetProfile(&name, &email))
+	require.NotNil(t, operator.Name)
+	assert.Equal(t, "Maria", *operator.Name)
+	require.NotNil(t, operator.Email)
+
+	assert.False(t, operator.SetProfile(&name, nil), "same value is not a change")
+
+	assert.True(t, operator.SetProfile(nil, &empty))
+	assert.Nil(t, operator.Email)
+	assert.NotNil(t, operator.Name, "nil leaves the field unchanged")
+}
+
 // ==================== OperatorInboxSubscription Tests ====================
 
 func TestNewOperatorInboxSubscription(t *testing.T) {
@@ -125,6 +141,36 @@ func TestOperatorStatus_SetStatus(t *testing.T) {
 	assert.True(t, status.LastStatusChangeAt.After(originalChangedAt))
 }
 
+func TestOperatorStatus_Focus(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	status := NewOperatorStatus(uuid.Must(uuid.NewV7()))
+	status.SetStatus(OperatorStatusAvailable)
+
+	assert.False(t, status.InFocus(now))
+	assert.True(t, status.AcceptsAllocations(now))
+	assert.Zero(t, status.FocusRemaining(now))
+
+	status.StartFocus(now.Add(30 * time.Minute))
+	assert.True(t, status.InFocus(now))
+	assert.False(t, status.AcceptsAllocations(now))
+	assert.Equal(t, 30*time.Minute, status.FocusRemaining(now))
+
+	// Focus ends on its own
+	later := now.Add(30 * time.Minute)
+	assert.False(t, status.InFocus(later))
+	assert.True(t, status.AcceptsAllocations(later))
+	assert.Zero(t, status.FocusRemaining(later))
+
+	status.EndFocus()
+	assert.Nil(t, status.FocusUntil)
+
+	// Changing the status ends focus
+	status.StartFocus(now.Add(time.Hour))
+	status.SetStatus(OperatorStatusBusy)
+	assert.Nil(t, status.FocusUntil)
+	assert.False(t, status.AcceptsAllocations(now))
+}
+
 // ==================== ConversationRef Tests ====================
 
 func TestNewConversationRef(t *testing.T) {
@@ -173,6 +219,11 @@ func TestConversationRef_Deallocate(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, ConversationStateQueued, conv.State)
 	assert.Nil(t, conv.AssignedOperatorID)
+
+	// A resolved conversation is reopened, not deallocated
+	conv.Allocate(operatorID)
+	conv.Resolve()
+	assert.ErrorIs(t, conv.Deallocate(), ErrInvalidStateTransition)
 }
 
 func TestConversationRef_Resolve(t *testing.T) {
@@ -189,6 +240,100 @@ func TestConversationRef_Resolve(t *testing.T) {
 	assert.NotNil(t, conv.ResolvedAt)
 }
 
+func TestConversationRef_Reopen(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+	inboxID := uuid.Must(uuid.NewV7())
+	operatorID := uuid.Must(uuid.NewV7())
+
+	conv := NewConversationRef(tenantID, inboxID, "ext-1", "+1234567890")
+	assert.ErrorIs(t, conv.Reopen(), ErrInvalidStateTransition)
+
+	conv.Allocate(operatorID)
+	conv.Resolve()
+
+	err := conv.Reopen()
+	require.NoError(t, err)
+	assert.Equal(t, ConversationStateQueued, conv.State)
+	assert.Nil(t, conv.AssignedOperatorID)
+	assert.Nil(t, conv.ResolvedAt)
+	assert.Equal(t, int32(1), conv.ReopenedCount)
+}
+
+func TestConversationRef_Snooze(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+	inboxID := uuid.Must(uuid.NewV7())
+	operatorID := uuid.Must(uuid.NewV7())
+	until := time.Now().Add(time.Hour)
+
+	conv := NewConversationRef(tenantID, inboxID, "ext-1", "+1234567890")
+	assert.ErrorIs(t, conv.Snooze(until, false), ErrInvalidStateTransition)
+
+	conv.Allocate(operatorID)
+	require.NoError(t, conv.Snooze(until, true))
+	assert.Equal(t, ConversationStateQueued, conv.State)
+	assert.Nil(t, conv.AssignedOperatorID)
+	assert.True(t, conv.IsSnoozed())
+	assert.Equal(t, operatorID, *conv.SnoozeOperatorID)
+
+	conv.EndSnooze()
+	assert.False(t, conv.IsSnoozed())
+	assert.Nil(t, conv.SnoozeOperatorID)
+
+	// Without return to operator it rejoins the queue
+	conv.Allocate(operatorID)
+	require.NoError(t, conv.Snooze(until, false))
+	assert.Nil(t, conv.SnoozeOperatorID)
+}
+
+func TestConversationRef_Escalate(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+	inboxID := uuid.Must(uuid.NewV7())
+	escalationInboxID := uuid.Must(uuid.NewV7())
+	operatorID := uuid.Must(uuid.NewV7())
+
+	conv := NewConversationRef(tenantID, inboxID, "ext-1", "+1234567890")
+	assert.ErrorIs(t, conv.Escalate(escalationInboxID), ErrInvalidStateTransition)
+
+	conv.Allocate(operatorID)
+	require.NoError(t, conv.Escalate(escalationInboxID))
+	assert.Equal(t, ConversationStateQueued, conv.State)
+	assert.Equal(t, escalationInboxID, conv.InboxID)
+	assert.Nil(t, conv.AssignedOperatorID)
+
+	escalation := NewConversationEscalation(conv, &operatorID, operatorID, inboxID, "Refund above my limit")
+	assert.Equal(t, inboxID, escalation.FromInboxID)
+	assert.Equal(t, escalationInboxID, escalation.ToInboxID)
+	assert.Equal(t, operatorID, *escalation.OperatorID)
+}
+
+func TestConversationRef_DueDate(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+	inboxID := uuid.Must(uuid.NewV7())
+	now := time.Now()
+
+	conv := NewConversationRef(tenantID, inboxID, "ext-1", "+1234567890")
+	assert.False(t, conv.IsOverdue(now), "no due date")
+
+	due := now.Add(-time.Minute)
+	conv.OverdueAt = &now
+	conv.SetDueAt(&due)
+	assert.Nil(t, conv.OverdueAt, "a new due date re-arms the overdue flag")
+	assert.True(t, conv.IsOverdue(now))
+	assert.False(t, conv.IsOverdue(due.Add(-time.Second)))
+
+	conv.Allocate(uuid.Must(uuid.NewV7()))
+	require.NoError(t, conv.Resolve())
+	assert.False(t, conv.IsOverdue(now), "resolved conversations are never overdue")
+
+	conv.SetDueAt(nil)
+	assert.Nil(t, conv.DueAt)
+
+	resolution := 4 * time.Hour
+	policy := &InboxSLAPolicy{Resolution: &resolution}
+	assert.Equal(t, now.Add(resolution), *policy.DueAt(now))
+	assert.Nil(t, (&InboxSLAPolicy{}).DueAt(now), "no resolution target, no due date")
+}
+
 // ==================== Label Tests ====================
 
 func TestNewLabel(t *testing.T) {
@@ -205,20 +350,89 @@ func TestNewLabel(t *testing.T) {
 	assert.Equal(t, "important", label.Name)
 	assert.Equal(t, color, *label.Color)
 	assert.False(t, label.CreatedAt.IsZero())
+	assert.Equal(t, 1, label.Version)
+}
+
+func TestNewTenantLabel(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+	inboxID := uuid.Must(uuid.NewV7())
+
+	label := NewTenantLabel(tenantID, "vip", nil, nil)
+
+	assert.Equal(t, LabelScopeTenant, label.Scope)
+	assert.Equal(t, uuid.Nil, label.InboxID)
+	assert.True(t, label.AppliesTo(inboxID))
+	assert.True(t, label.AppliesTo(uuid.Must(uuid.NewV7())))
+
+	inboxLabel := NewLabel(tenantID, inboxID, "vip", nil, nil)
+	assert.Equal(t, LabelScopeInbox, inboxLabel.Scope)
+	assert.True(t, inboxLabel.AppliesTo(inboxID))
+	assert.False(t, inboxLabel.AppliesTo(uuid.Must(uuid.NewV7())))
+}
+
+func TestLabel_Change(t *testing.T) {
+	red, blue := "#FF0000", "#0000FF"
+	label := NewLabel(uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), "vip", &red, nil)
+
+	assert.False(t, label.Change("vip", &red), "same name and color")
+	assert.Equal(t, 1, label.Version)
+
+	assert.True(t, label.Change("priority", &red))
+	assert.Equal(t, "priority", label.Name)
+	assert.Equal(t, 2, label.Version)
+
+	assert.True(t, label.Change("priority", &blue))
+	assert.Equal(t, blue, *label.Color)
+	assert.Equal(t, 3, label.Version)
+
+	assert.True(t, label.Change("priority", nil), "clearing the color")
+	assert.Nil(t, label.Color)
+	assert.Equal(t, 4, label.Version)
+}
+
+func TestNestLabels(t *testing.T) {
+	tenantID, inboxID := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	billing := NewLabel(tenantID, inboxID, "billing", nil, nil)
+	invoices := NewLabel(tenantID, inboxID, "invoices", nil, nil)
+	invoices.ParentLabelID = &billing.ID
+	refunds := NewLabel(tenantID, inboxID, "refunds", nil, nil)
+	refunds.ParentLabelID = &billing.ID
+	late := NewLabel(tenantID, inboxID, "late", nil, nil)
+	late.ParentLabelID = &refunds.ID
+	orphan := NewLabel(tenantID, inboxID, "orphan", nil, nil)
+	missing := uuid.Must(uuid.NewV7())
+	orphan.ParentLabelID = &missing
+
+	roots := NestLabels([]*Label{billing, invoices, late, orphan, refunds})
+	require.Len(t, roots, 2)
+	assert.Equal(t, billing.ID, roots[0].Label.ID)
+	assert.Equal(t, orphan.ID, roots[1].Label.ID, "labels with an unknown parent are roots")
+	require.Len(t, roots[0].Children, 2)
+	assert.Equal(t, invoices.ID, roots[0].Children[0].Label.ID)
+	assert.Empty(t, roots[0].Children[0].Children)
+	require.Len(t, roots[0].Children[1].Children, 1)
+	assert.Equal(t, late.ID, roots[0].Children[1].Children[0].Label.ID)
+
+	assert.True(t, billing.SameParent(nil))
+	assert.True(t, refunds.SameParent(&billing.ID))
+	assert.False(t, refunds.SameParent(nil))
+	assert.False(t, refunds.SameParent(&invoices.ID))
 }
 
 // ==================== ConversationLabel Tests ====================
 
 func TestNewConversationLabel(t *testing.T) {
 	conversationID := uuid.Must(uuid.NewV7())
-	labelID := uuid.Must(uuid.NewV7())
+	label := NewLabel(uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), "vip", nil, nil)
+	label.Change("priority", nil)
 
-	cl := NewConversationLabel(conversationID, labelID)
+	cl := NewConversationLabel(conversationID, label)
 
 	require.NotNil(t, cl)
 	assert.NotEqual(t, uuid.Nil, cl.ID)
 	assert.Equal(t, conversationID, cl.ConversationID)
-	assert.Equal(t, labelID, cl.LabelID)
+	assert.Equal(t, label.ID, cl.LabelID)
+	assert.Equal(t, 2, cl.LabelVersion)
 	assert.False(t, cl.CreatedAt.IsZero())
 }
 
@@ -303,6 +517,35 @@ func TestNewIdempotencyKey(t *testing.T) {
 	assert.True(t, ik.ExpiresAt.After(ik.CreatedAt))
 }
 
+func TestNewIdempotencyClaim(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+
+	ik := NewIdempotencyClaim("key", tenantID, "/api/v1/allocate", "POST", nil, time.Minute, 24*time.Hour)
+
+	assert.Equal(t, IdempotencyStatusPending, ik.Status)
+	assert.True(t, ik.IsPending())
+	require.NotNil(t, ik.LockedUntil)
+	assert.Equal(t, ik.CreatedAt.Add(time.Minute), *ik.LockedUntil)
+	assert.Equal(t, ik.CreatedAt.Add(24*time.Hour), ik.ExpiresAt)
+	assert.Nil(t, ik.ResponseBody)
+	assert.False(t, ik.IsStale())
+
+	completed := NewIdempotencyKey("key", tenantID, "/api", "POST", nil, 200, []byte("{}"), time.Hour)
+	assert.Equal(t, IdempotencyStatusCompleted, completed.Status)
+	assert.False(t, completed.IsPending())
+}
+
+func TestIdempotencyKey_IsStale(t *testing.T) {
+	tenantID := uuid.Must(uuid.NewV7())
+
+	stale := NewIdempotencyClaim("key", tenantID, "/api", "POST", nil, -time.Second, time.Hour)
+	assert.True(t, stale.IsStale())
+
+	completed := NewIdempotencyKey("key", tenantID, "/api", "POST", nil, 200, []byte("{}"), time.Hour)
+	completed.LockedUntil = stale.LockedUntil
+	assert.False(t, completed.IsStale(), "a completed key is never taken over before it expires")
+}
+
 func TestIdempotencyKey_IsExpired(t *testing.T) {
 	tenantID := uuid.Must(uuid.NewV7())
 
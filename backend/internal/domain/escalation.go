@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxEscalationReasonLength caps the reason of an escalation, in characters
+const MaxEscalationReasonLength = 2000
+
+// ==================== ConversationEscalation ====================
+
+// ConversationEscalation records an operator handing a conversation they
+// can't handle over to their inbox's escalation inbox
+type ConversationEscalation struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	// OperatorID is the operator the conversation was assigned to
+	OperatorID *uuid.UUID
+	// EscalatedBy is the assignee, or a manager escalating on their behalf
+	EscalatedBy *uuid.UUID
+	FromInboxID uuid.UUID
+	ToInboxID   uuid.UUID
+	Reason      string
+	CreatedAt   time.Time
+}
+
+// NewConversationEscalation records the escalation of conv from fromInboxID
+// to the inbox it has just been moved to
+func NewConversationEscalation(conv *ConversationRef, operatorID *uuid.UUID, escalatedBy, fromInboxID uuid.UUID, reason string) *ConversationEscalation {
+	return &ConversationEscalation{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       conv.TenantID,
+		ConversationID: conv.ID,
+		OperatorID:     operatorID,
+		EscalatedBy:    &escalatedBy,
+		FromInboxID:    fromInboxID,
+		ToInboxID:      conv.InboxID,
+		Reason:         reason,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// ==================== EscalationReport ====================
+
+// EscalationCount is the number of escalations of one operator or inbox
+type EscalationCount struct {
+	ID    uuid.UUID
+	Count int
+}
+
+// EscalationReport counts the escalations in [Since, Until) per operator
+// they were taken from and per inbox they left
+type EscalationReport struct {
+	Since      time.Time
+	Until      time.Time
+	Total      int
+	ByOperator []EscalationCount
+	ByInbox    []EscalationCount
+}
+
+// NewEscalationReport sorts the counts, most escalations first. Every
+// escalation has an inbox, so the total is the sum of the inbox counts.
+func NewEscalationReport(since, until time.Time, byOperator, byInbox []EscalationCount) *EscalationReport {
+	sortEscalationCounts(byOperator)
+	sortEscalationCounts(byInbox)
+
+	total := 0
+	for _, c := range byInbox {
+		total += c.Count
+	}
+	return &EscalationReport{
+		Since:      since,
+		Until:      until,
+		Total:      total,
+		ByOperator: byOperator,
+		ByInbox:    byInbox,
+	}
+}
+
+func sortEscalationCounts(counts []EscalationCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].ID.String() < counts[j].ID.String()
+	})
+}
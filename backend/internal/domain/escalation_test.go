@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEscalationReport(t *testing.T) {
+	until := time.Now().UTC()
+	since := until.Add(-24 * time.Hour)
+	op1, op2 := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	inbox1, inbox2 := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+
+	report := NewEscalationReport(since, until,
+		[]EscalationCount{{ID: op1, Count: 1}, {ID: op2, Count: 4}},
+		[]EscalationCount{{ID: inbox1, Count: 2}, {ID: inbox2, Count: 3}},
+	)
+
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, []EscalationCount{{ID: op2, Count: 4}, {ID: op1, Count: 1}}, report.ByOperator)
+	assert.Equal(t, []EscalationCount{{ID: inbox2, Count: 3}, {ID: inbox1, Count: 2}}, report.ByInbox)
+}
+
+func TestNewEscalationReport_Empty(t *testing.T) {
+	report := NewEscalationReport(time.Time{}, time.Now(), []EscalationCount{}, []EscalationCount{})
+	assert.Zero(t, report.Total)
+	assert.Empty(t, report.ByOperator)
+	assert.Empty(t, report.ByInbox)
+}
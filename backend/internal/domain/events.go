@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== EventType ====================
+
+type EventType string
+
+const (
+	EventConversationCreated     EventType = "conversation.created"
+	EventConversationAllocated   EventType = "conversation.allocated"
+	EventConversationResolved    EventType = "conversation.resolved"
+	EventConversationDeallocated EventType = "conversation.deallocated"
+	EventConversationReassigned  EventType = "conversation.reassigned"
+	EventConversationReopened    EventType = "conversation.reopened"
+	EventConversationSnoozed     EventType = "conversation.snoozed"
+	EventConversationUnsnoozed   EventType = "conversation.unsnoozed"
+	EventConversationEscalated   EventType = "conversation.escalated"
+	EventConversationLabeled     EventType = "conversation.label_attached"
+	EventConversationUnlabeled   EventType = "conversation.label_detached"
+	EventConversationOverdue     EventType = "conversation.overdue"
+	// EventConversationStale flags an allocation without operator activity
+	// for its inbox's staleness limit
+	EventConversationStale EventType = "conversation.stale"
+	// A transfer proposed by the assignee, and its ends other than
+	// acceptance; an accepted transfer emits conversation.reassigned
+	EventConversationTransferRequested EventType = "conversation.transfer_requested"
+	EventConversationTransferDeclined  EventType = "conversation.transfer_declined"
+	EventConversationTransferExpired   EventType = "conversation.transfer_expired"
+	EventOperatorStatusChanged         EventType = "operator.status_changed"
+	EventAnomalyDetected               EventType = "anomaly.detected"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventConversationCreated, EventConversationAllocated, EventConversationResolved, EventConversationDeallocated,
+		EventConversationReassigned, EventConversationReopened, EventConversationSnoozed,
+		EventConversationUnsnoozed, EventConversationEscalated, EventConversationLabeled,
+		EventConversationUnlabeled, EventConversationOverdue, EventConversationStale, EventConversationTransferRequested,
+		EventConversationTransferDeclined, EventConversationTransferExpired, EventOperatorStatusChanged, EventAnomalyDetected:
+		return true
+	}
+	return false
+}
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+// IsAssignment reports whether the event assigns a conversation to the
+// operator named by assigned_operator_id
+func (t EventType) IsAssignment() bool {
+	return t == EventConversationAllocated || t == EventConversationReassigned
+}
+
+// IsLabelChange reports whether the event only attaches or detaches a label,
+// leaving the conversation's state and queue position unchanged
+func (t EventType) IsLabelChange() bool {
+	return t == EventConversationLabeled || t == EventConversationUnlabeled
+}
+
+// ==================== Event ====================
+
+// Event is a domain event emitted after a state change has been committed
+type Event struct {
+	ID         uuid.UUID
+	Type       EventType
+	TenantID   uuid.UUID
+	OccurredAt time.Time
+	Data       map[string]interface{}
+}
+
+func NewEvent(tenantID uuid.UUID, eventType EventType, data map[string]interface{}) *Event {
+	return &Event{
+		ID:         uuid.Must(uuid.NewV7()),
+		Type:       eventType,
+		TenantID:   tenantID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// EventPublisher receives domain events from services
+type EventPublisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrExperimentNotRunning is returned when stopping a stopped experiment
+	ErrExperimentNotRunning = errors.New("experiment is not running")
+)
+
+// ==================== ExperimentStatus ====================
+
+type ExperimentStatus string
+
+const (
+	ExperimentStatusRunning ExperimentStatus = "RUNNING"
+	ExperimentStatusStopped ExperimentStatus = "STOPPED"
+)
+
+func (s ExperimentStatus) IsValid() bool {
+	switch s {
+	case ExperimentStatusRunning, ExperimentStatusStopped:
+		return true
+	}
+	return false
+}
+
+func (s ExperimentStatus) String() string {
+	return string(s)
+}
+
+// ==================== ExperimentArm ====================
+
+// ExperimentArm is the group of an enrolled conversation: CONTROL keeps the
+// tenant weights, TREATMENT uses the experiment's
+type ExperimentArm string
+
+const (
+	ExperimentArmControl   ExperimentArm = "CONTROL"
+	ExperimentArmTreatment ExperimentArm = "TREATMENT"
+)
+
+// ExperimentArms lists the arms in report order
+var ExperimentArms = []ExperimentArm{ExperimentArmControl, ExperimentArmTreatment}
+
+func (a ExperimentArm) IsValid() bool {
+	switch a {
+	case ExperimentArmControl, ExperimentArmTreatment:
+		return true
+	}
+	return false
+}
+
+func (a ExperimentArm) String() string {
+	return string(a)
+}
+
+// ==================== PriorityExperiment ====================
+
+// PriorityExperiment A/B tests alternative priority weights in one inbox.
+// While it runs, TrafficPercent of the conversations created in the inbox
+// after StartedAt are prioritized with WeightAlpha/WeightBeta instead of the
+// tenant weights. Weights apply when a priority is calculated, so starting or
+// stopping an experiment takes effect without a restart or a recalculation.
+type PriorityExperiment struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	InboxID        uuid.UUID
+	Name           string
+	WeightAlpha    decimal.Decimal
+	WeightBeta     decimal.Decimal
+	TrafficPercent int
+	Status         ExperimentStatus
+	CreatedBy      *uuid.UUID
+	StartedAt      time.Time
+	StoppedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewPriorityExperiment creates a running experiment
+func NewPriorityExperiment(tenantID, inboxID uuid.UUID, name string, alpha, beta decimal.Decimal, trafficPercent int, createdBy *uuid.UUID) *PriorityExperiment {
+	now := time.Now().UTC()
+	return &PriorityExperiment{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       tenantID,
+		InboxID:        inboxID,
+		Name:           name,
+		WeightAlpha:    alpha,
+		WeightBeta:     beta,
+		TrafficPercent: trafficPercent,
+		Status:         ExperimentStatusRunning,
+		CreatedBy:      createdBy,
+		StartedAt:      now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (e *PriorityExperiment) IsRunning() bool {
+	return e.Status == ExperimentStatusRunning
+}
+
+// Enrolls reports whether the conversation takes part in the experiment:
+// it runs, and the conversation was created in its inbox after it started.
+// Conversations already waiting at the start would mix both formulas.
+func (e *PriorityExperiment) Enrolls(conv *ConversationRef) bool {
+	return e.IsRunning() && conv.InboxID == e.InboxID && !conv.CreatedAt.Before(e.StartedAt)
+}
+
+// Arm returns the conversation's arm. The split hashes the experiment and
+// conversation IDs, so it is stable across replicas and recalculations and
+// independent between experiments.
+func (e *PriorityExperiment) Arm(conversationID uuid.UUID) ExperimentArm {
+	h := fnv.New32a()
+	h.Write(e.ID[:])
+	h.Write(conversationID[:])
+	if int(h.Sum32()%100) < e.TrafficPercent {
+		return ExperimentArmTreatment
+	}
+	return ExperimentArmControl
+}
+
+// Stop ends the experiment; its conversations return to the tenant weights
+// at their next priority calculation
+func (e *PriorityExperiment) Stop() error {
+	if !e.IsRunning() {
+		return ErrExperimentNotRunning
+	}
+	now := time.Now().UTC()
+	e.Status = ExperimentStatusStopped
+	e.StoppedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// ==================== Experiment Results ====================
+
+// ExperimentArmOutcome summarizes the outcomes of one arm. Wait is from
+// creation to first allocation, resolution from creation to resolution; the
+// averages and percentiles cover only the conversations that got there.
+type ExperimentArmOutcome struct {
+	Arm           ExperimentArm
+	Conversations int
+	Allocated     int
+	Resolved      int
+	AvgWait       time.Duration
+	P50Wait       time.Duration
+	P90Wait       time.Duration
+	AvgResolution time.Duration
+	P50Resolution time.Duration
+	P90Resolution time.Duration
+}
+
+// ExperimentResults are the outcomes of an experiment per arm, in
+// ExperimentArms order
+type ExperimentResults struct {
+	Experiment *PriorityExperiment
+	Arms       []*ExperimentArmOutcome
+}
+
+// NewExperimentResults fills in empty outcomes for arms without
+// conversations and orders them as ExperimentArms
+func NewExperimentResults(experiment *PriorityExperiment, outcomes []*ExperimentArmOutcome) *ExperimentResults {
+	byArm := make(map[ExperimentArm]*ExperimentArmOutcome, len(outcomes))
+	for _, o := range outcomes {
+		byArm[o.Arm] = o
+	}
+	results := &ExperimentResults{Experiment: experiment}
+	for _, arm := range ExperimentArms {
+		o, ok := byArm[arm]
+		if !ok {
+			o = &ExperimentArmOutcome{Arm: arm}
+		}
+		results.Arms = append(results.Arms, o)
+	}
+	return results
+}
+
+// WaitChange is the relative change of the treatment's average wait against
+// the control's (-0.2 is 20% shorter), nil while either arm has no
+// allocations
+func (r *ExperimentResults) WaitChange() *float64 {
+	return relativeChange(r.Arms[0].AvgWait, r.Arms[1].AvgWait, r.Arms[0].Allocated, r.Arms[1].Allocated)
+}
+
+// ResolutionChange is WaitChange for the average resolution time
+func (r *ExperimentResults) ResolutionChange() *float64 {
+	return relativeChange(r.Arms[0].AvgResolution, r.Arms[1].AvgResolution, r.Arms[0].Resolved, r.Arms[1].Resolved)
+}
+
+func relativeChange(control, treatment time.Duration, controlN, treatmentN int) *float64 {
+	if controlN == 0 || treatmentN == 0 || control <= 0 {
+		return nil
+	}
+	change := float64(treatment-control) / float64(control)
+	return &change
+}
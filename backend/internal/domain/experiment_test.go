@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExperiment(trafficPercent int) *PriorityExperiment {
+	return NewPriorityExperiment(uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), "recency first",
+		decimal.NewFromFloat(0.3), decimal.NewFromFloat(0.7), trafficPercent, nil)
+}
+
+func TestPriorityExperiment_Arm(t *testing.T) {
+	exp := newTestExperiment(30)
+
+	treatment := 0
+	for i := 0; i < 10000; i++ {
+		id := uuid.Must(uuid.NewV7())
+		arm := exp.Arm(id)
+		assert.Equal(t, arm, exp.Arm(id), "arm must be stable")
+		if arm == ExperimentArmTreatment {
+			treatment++
+		}
+	}
+	assert.InDelta(t, 3000, treatment, 300)
+}
+
+func TestPriorityExperiment_Enrolls(t *testing.T) {
+	exp := newTestExperiment(50)
+
+	conv := &ConversationRef{ID: uuid.Must(uuid.NewV7()), InboxID: exp.InboxID, CreatedAt: exp.StartedAt.Add(time.Second)}
+	assert.True(t, exp.Enrolls(conv))
+
+	older := &ConversationRef{ID: uuid.Must(uuid.NewV7()), InboxID: exp.InboxID, CreatedAt: exp.StartedAt.Add(-time.Second)}
+	assert.False(t, exp.Enrolls(older), "conversations created before the start are not enrolled")
+
+	elsewhere := &ConversationRef{ID: uuid.Must(uuid.NewV7()), InboxID: uuid.Must(uuid.NewV7()), CreatedAt: conv.CreatedAt}
+	assert.False(t, exp.Enrolls(elsewhere))
+
+	require.NoError(t, exp.Stop())
+	assert.False(t, exp.Enrolls(conv), "stopped experiments enroll nothing")
+}
+
+func TestPriorityExperiment_Stop(t *testing.T) {
+	exp := newTestExperiment(50)
+	require.NoError(t, exp.Stop())
+	assert.Equal(t, ExperimentStatusStopped, exp.Status)
+	assert.NotNil(t, exp.StoppedAt)
+	assert.ErrorIs(t, exp.Stop(), ErrExperimentNotRunning)
+}
+
+func TestNewExperimentResults(t *testing.T) {
+	exp := newTestExperiment(50)
+
+	results := NewExperimentResults(exp, []*ExperimentArmOutcome{
+		{Arm: ExperimentArmTreatment, Conversations: 10, Allocated: 8, AvgWait: 80 * time.Second},
+	})
+	require.Len(t, results.Arms, 2)
+	assert.Equal(t, ExperimentArmControl, results.Arms[0].Arm)
+	assert.Zero(t, results.Arms[0].Conversations)
+	assert.Nil(t, results.WaitChange(), "no change without control allocations")
+
+	results = NewExperimentResults(exp, []*ExperimentArmOutcome{
+		{Arm: ExperimentArmTreatment, Conversations: 10, Allocated: 8, AvgWait: 80 * time.Second},
+		{Arm: ExperimentArmControl, Conversations: 10, Allocated: 9, AvgWait: 100 * time.Second},
+	})
+	assert.Equal(t, ExperimentArmControl, results.Arms[0].Arm)
+	require.NotNil(t, results.WaitChange())
+	assert.InDelta(t, -0.2, *results.WaitChange(), 1e-9)
+	assert.Nil(t, results.ResolutionChange())
+}
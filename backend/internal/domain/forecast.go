@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== Availability Forecast ====================
+
+// OperatorStatusChange is one entry of an operator's status history
+type OperatorStatusChange struct {
+	OperatorID uuid.UUID
+	// Previous is nil for an operator's first recorded status
+	Previous *OperatorStatusType
+	Status   OperatorStatusType
+	At       time.Time
+}
+
+// OperatorStatusChangeFromAudit reads a status change from an
+// operator.status_change audit entry
+func OperatorStatusChangeFromAudit(entry *AuditEntry) (OperatorStatusChange, bool) {
+	if entry.Action != AuditActionOperatorStatusChange {
+		return OperatorStatusChange{}, false
+	}
+	status, ok := entry.After["status"].(string)
+	if !ok {
+		return OperatorStatusChange{}, false
+	}
+
+	change := OperatorStatusChange{
+		OperatorID: entry.EntityID,
+		Status:     OperatorStatusType(status),
+		At:         entry.CreatedAt,
+	}
+	if previous, ok := entry.Before["status"].(string); ok {
+		previousStatus := OperatorStatusType(previous)
+		change.Previous = &previousStatus
+	}
+	return change, true
+}
+
+// AvailabilityForecastHour is the forecast for one clock hour
+type AvailabilityForecastHour struct {
+	Start time.Time
+	// Operators expected to be AVAILABLE, in operator-hours
+	ExpectedAvailableOperators float64
+	// Conversations expected to arrive
+	ProjectedInflow float64
+}
+
+// AvailabilityForecast is the staffing forecast for the next hours
+type AvailabilityForecast struct {
+	// InboxID is nil for a tenant-wide forecast
+	InboxID      *uuid.UUID
+	LookbackDays int
+	GeneratedAt  time.Time
+	Hours        []AvailabilityForecastHour
+}
+
+// AvailabilityForecastInput is the history a forecast is computed from
+type AvailabilityForecastInput struct {
+	// Start is the beginning of the first forecast hour (truncated to the hour)
+	Start        time.Time
+	Hours        int
+	LookbackDays int
+	// Current status of each operator counted in the forecast
+	CurrentStatus map[uuid.UUID]OperatorStatusType
+	// Status changes since Start minus LookbackDays, oldest first
+	Changes []OperatorStatusChange
+	// Conversations created per hour, keyed by the UTC hour start
+	InflowByHour map[time.Time]int
+}
+
+// ForecastAvailability projects each of the next Hours hours from the same
+// clock hour on each of the previous LookbackDays days: availability is the
+// average time operators spent AVAILABLE in that hour, inflow the average
+// number of conversations created.
+func ForecastAvailability(in AvailabilityForecastInput) []AvailabilityForecastHour {
+	timelines := make(map[uuid.UUID][]OperatorStatusChange, len(in.CurrentStatus))
+	for _, change := range in.Changes {
+		if _, ok := in.CurrentStatus[change.OperatorID]; ok {
+			timelines[change.OperatorID] = append(timelines[change.OperatorID], change)
+		}
+	}
+	for id := range timelines {
+		changes := timelines[id]
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].At.Before(changes[j].At) })
+	}
+
+	forecast := make([]AvailabilityForecastHour, in.Hours)
+	for h := 0; h < in.Hours; h++ {
+		start := in.Start.Add(time.Duration(h) * time.Hour)
+
+		var available, inflow float64
+		for d := 1; d <= in.LookbackDays; d++ {
+			from := start.Add(-time.Duration(d) * 24 * time.Hour)
+			to := from.Add(time.Hour)
+			for id, current := range in.CurrentStatus {
+				available += availableFraction(timelines[id], current, from, to)
+			}
+			inflow += float64(in.InflowByHour[from.UTC()])
+		}
+
+		forecast[h] = AvailabilityForecastHour{Start: start}
+		if in.LookbackDays > 0 {
+			forecast[h].ExpectedAvailableOperators = available / float64(in.LookbackDays)
+			forecast[h].ProjectedInflow = inflow / float64(in.LookbackDays)
+		}
+	}
+	return forecast
+}
+
+// availableFraction returns the share of [from, to) the operator spent
+// AVAILABLE. Before the first recorded change the operator had that change's
+// previous status (OFFLINE if none); without changes, the current status.
+func availableFraction(changes []OperatorStatusChange, current OperatorStatusType, from, to time.Time) float64 {
+	status := current
+	if len(changes) > 0 {
+		status = OperatorStatusOffline
+		if changes[0].Previous != nil {
+			status = *changes[0].Previous
+		}
+	}
+
+	var availableFor time.Duration
+	cursor := from
+	for _, change := range changes {
+		if !change.At.After(from) {
+			status = change.Status
+			continue
+		}
+		if !change.At.Before(to) {
+			break
+		}
+		if status == OperatorStatusAvailable {
+			availableFor += change.At.Sub(cursor)
+		}
+		status = change.Status
+		cursor = change.At
+	}
+	if status == OperatorStatusAvailable {
+		availableFor += to.Sub(cursor)
+	}
+
+	return availableFor.Seconds() / to.Sub(from).Seconds()
+}
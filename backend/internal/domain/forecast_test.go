@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statusPtr(s OperatorStatusType) *OperatorStatusType {
+	return &s
+}
+
+func TestForecastAvailability(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	alwaysOn, morning, unrecorded := uuid.New(), uuid.New(), uuid.New()
+
+	// morning was AVAILABLE 09:00-09:30 each of the last two days
+	var changes []OperatorStatusChange
+	for d := 2; d >= 1; d-- {
+		day := start.Add(-time.Duration(d) * 24 * time.Hour)
+		changes = append(changes,
+			OperatorStatusChange{OperatorID: morning, Previous: statusPtr(OperatorStatusOffline), Status: OperatorStatusAvailable, At: day},
+			OperatorStatusChange{OperatorID: morning, Previous: statusPtr(OperatorStatusAvailable), Status: OperatorStatusOffline, At: day.Add(30 * time.Minute)},
+		)
+	}
+	// Changes of operators outside the forecast are ignored
+	changes = append(changes, OperatorStatusChange{OperatorID: uuid.New(), Status: OperatorStatusAvailable, At: start.Add(-48 * time.Hour)})
+
+	forecast := ForecastAvailability(AvailabilityForecastInput{
+		Start:        start,
+		Hours:        2,
+		LookbackDays: 2,
+		CurrentStatus: map[uuid.UUID]OperatorStatusType{
+			alwaysOn:   OperatorStatusAvailable,
+			morning:    OperatorStatusOffline,
+			unrecorded: OperatorStatusOffline,
+		},
+		Changes: changes,
+		InflowByHour: map[time.Time]int{
+			start.Add(-24 * time.Hour):           6,
+			start.Add(-48 * time.Hour):           2,
+			start.Add(-24*time.Hour + time.Hour): 3,
+			start.Add(-72 * time.Hour):           100, // outside the lookback
+		},
+	})
+	require.Len(t, forecast, 2)
+
+	assert.Equal(t, start, forecast[0].Start)
+	assert.InDelta(t, 1.5, forecast[0].ExpectedAvailableOperators, 0.001)
+	assert.InDelta(t, 4, forecast[0].ProjectedInflow, 0.001)
+
+	assert.Equal(t, start.Add(time.Hour), forecast[1].Start)
+	assert.InDelta(t, 1, forecast[1].ExpectedAvailableOperators, 0.001)
+	assert.InDelta(t, 1.5, forecast[1].ProjectedInflow, 0.001)
+}
+
+func TestOperatorStatusChangeFromAudit(t *testing.T) {
+	operatorID := uuid.New()
+	entry := NewAuditEntry(uuid.New(), &operatorID, AuditActionOperatorStatusChange, AuditEntityOperator, operatorID,
+		map[string]interface{}{"status": "OFFLINE"}, map[string]interface{}{"status": "AVAILABLE"})
+
+	change, ok := OperatorStatusChangeFromAudit(entry)
+	require.True(t, ok)
+	assert.Equal(t, operatorID, change.OperatorID)
+	assert.Equal(t, OperatorStatusAvailable, change.Status)
+	require.NotNil(t, change.Previous)
+	assert.Equal(t, OperatorStatusOffline, *change.Previous)
+
+	first := NewAuditEntry(uuid.New(), &operatorID, AuditActionOperatorStatusChange, AuditEntityOperator, operatorID,
+		nil, map[string]interface{}{"status": "AVAILABLE"})
+	change, ok = OperatorStatusChangeFromAudit(first)
+	require.True(t, ok)
+	assert.Nil(t, change.Previous)
+
+	other := NewAuditEntry(uuid.New(), &operatorID, AuditActionOperatorCreate, AuditEntityOperator, operatorID,
+		nil, map[string]interface{}{"role": "OPERATOR"})
+	_, ok = OperatorStatusChangeFromAudit(other)
+	assert.False(t, ok)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ==================== GracePeriodBacklog ====================
+
+// GracePeriodBacklog is the grace periods that have expired and are still
+// waiting for the grace period worker to return their conversations
+type GracePeriodBacklog struct {
+	Overdue         int64
+	OldestExpiresAt *time.Time
+}
+
+// Lag returns how long the oldest overdue grace period has been waiting,
+// zero without a backlog
+func (b GracePeriodBacklog) Lag(now time.Time) time.Duration {
+	if b.Overdue == 0 || b.OldestExpiresAt == nil || now.Before(*b.OldestExpiresAt) {
+		return 0
+	}
+	return now.Sub(*b.OldestExpiresAt)
+}
+
+// ==================== GracePipelinePolicy ====================
+
+// GracePipelinePolicy holds the backlog limits past which the grace period
+// pipeline is degraded: conversations are staying assigned to operators
+// who went offline
+type GracePipelinePolicy struct {
+	// MaxOverdue is the most overdue grace periods that count as healthy
+	MaxOverdue int64
+	// MaxLag is the longest the oldest one may wait; it should be a few
+	// worker intervals
+	MaxLag time.Duration
+}
+
+// DefaultGracePipelinePolicy returns sensible defaults
+func DefaultGracePipelinePolicy() GracePipelinePolicy {
+	return GracePipelinePolicy{
+		MaxOverdue: 500,
+		MaxLag:     5 * time.Minute,
+	}
+}
+
+// Degradations returns why the backlog exceeds the policy, none when healthy
+func (p GracePipelinePolicy) Degradations(b GracePeriodBacklog, now time.Time) []string {
+	var reasons []string
+	if p.MaxOverdue > 0 && b.Overdue > p.MaxOverdue {
+		reasons = append(reasons, fmt.Sprintf("%d overdue grace periods (limit %d)", b.Overdue, p.MaxOverdue))
+	}
+	if lag := b.Lag(now); p.MaxLag > 0 && lag > p.MaxLag {
+		reasons = append(reasons, fmt.Sprintf("oldest grace period overdue for %s (limit %s)",
+			lag.Truncate(time.Second), p.MaxLag))
+	}
+	return reasons
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGracePeriodBacklog_Lag(t *testing.T) {
+	now := time.Now().UTC()
+	expired := now.Add(-90 * time.Second)
+
+	assert.Zero(t, GracePeriodBacklog{}.Lag(now))
+	assert.Equal(t, 90*time.Second, GracePeriodBacklog{Overdue: 3, OldestExpiresAt: &expired}.Lag(now))
+}
+
+func TestGracePipelinePolicy_Degradations(t *testing.T) {
+	p := GracePipelinePolicy{MaxOverdue: 10, MaxLag: time.Minute}
+	now := time.Now().UTC()
+	recent := now.Add(-30 * time.Second)
+	stale := now.Add(-2 * time.Minute)
+
+	tests := []struct {
+		name    string
+		backlog GracePeriodBacklog
+		want    int
+	}{
+		{"no backlog", GracePeriodBacklog{}, 0},
+		{"small recent backlog", GracePeriodBacklog{Overdue: 5, OldestExpiresAt: &recent}, 0},
+		{"too many overdue", GracePeriodBacklog{Overdue: 11, OldestExpiresAt: &recent}, 1},
+		{"oldest waiting too long", GracePeriodBacklog{Overdue: 1, OldestExpiresAt: &stale}, 1},
+		{"both", GracePeriodBacklog{Overdue: 50, OldestExpiresAt: &stale}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, p.Degradations(tt.backlog, now), tt.want)
+		})
+	}
+}
@@ -6,6 +6,16 @@ import (
 	"github.com/google/uuid"
 )
 
+// IdempotencyStatus is the state of an idempotency key
+type IdempotencyStatus string
+
+const (
+	// IdempotencyStatusPending marks a key claimed by a request still running
+	IdempotencyStatusPending IdempotencyStatus = "PENDING"
+	// IdempotencyStatusCompleted marks a key with a stored response
+	IdempotencyStatusCompleted IdempotencyStatus = "COMPLETED"
+)
+
 // IdempotencyKey represents a stored idempotency key with its response
 type IdempotencyKey struct {
 	ID             uuid.UUID
@@ -15,9 +25,17 @@ type IdempotencyKey struct {
 	Method         string
 	RequestHash    *string
 	ResponseStatus int
-	ResponseBody   []byte
-	CreatedAt      time.Time
-	ExpiresAt      time.Time
+	// ResponseBody is the plaintext response; nil when it is stored in
+	// ResponseCiphertext, sealed with the key EncryptionKeyID
+	ResponseBody       []byte
+	ResponseCiphertext []byte
+	EncryptionKeyID    *string
+	Status             IdempotencyStatus
+	// LockedUntil is when a PENDING claim may be taken over by another
+	// request; nil once COMPLETED
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
 }
 
 // NewIdempotencyKey creates a new idempotency key record
@@ -40,15 +58,51 @@ func NewIdempotencyKey(
 		RequestHash:    requestHash,
 		ResponseStatus: responseStatus,
 		ResponseBody:   responseBody,
+		Status:         IdempotencyStatusCompleted,
 		CreatedAt:      now,
 		ExpiresAt:      now.Add(ttl),
 	}
 }
 
+// NewIdempotencyClaim creates the PENDING claim of a key by a request about
+// to run. Another request may take it over after lockTimeout.
+func NewIdempotencyClaim(
+	key string,
+	tenantID uuid.UUID,
+	endpoint, method string,
+	requestHash *string,
+	lockTimeout, ttl time.Duration,
+) *IdempotencyKey {
+	now := time.Now().UTC()
+	lockedUntil := now.Add(lockTimeout)
+	return &IdempotencyKey{
+		ID:          uuid.Must(uuid.NewV7()),
+		Key:         key,
+		TenantID:    tenantID,
+		Endpoint:    endpoint,
+		Method:      method,
+		RequestHash: requestHash,
+		Status:      IdempotencyStatusPending,
+		LockedUntil: &lockedUntil,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
 // IsExpired checks if the idempotency key has expired
 func (ik *IdempotencyKey) IsExpired() bool {
 	return time.Now().UTC().After(ik.ExpiresAt)
 }
 
+// IsPending reports whether a request still holds the key
+func (ik *IdempotencyKey) IsPending() bool {
+	return ik.Status == IdempotencyStatusPending
+}
+
+// IsStale reports whether the key is a PENDING claim that may be taken over
+func (ik *IdempotencyKey) IsStale() bool {
+	return ik.IsPending() && ik.LockedUntil != nil && time.Now().UTC().After(*ik.LockedUntil)
+}
+
 // DefaultIdempotencyTTL is the default time-to-live for idempotency keys
 const DefaultIdempotencyTTL = 24 * time.Hour
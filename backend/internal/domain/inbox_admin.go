@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== InboxAdmin ====================
+
+// InboxAdmin delegates admin permissions on a single inbox to an operator:
+// managing its labels, subscriptions and routing rules, whatever the
+// operator's role
+type InboxAdmin struct {
+	OperatorID uuid.UUID
+	InboxID    uuid.UUID
+	TenantID   uuid.UUID
+	GrantedBy  *uuid.UUID
+	CreatedAt  time.Time
+}
+
+func NewInboxAdmin(tenantID, operatorID, inboxID uuid.UUID, grantedBy *uuid.UUID) *InboxAdmin {
+	return &InboxAdmin{
+		OperatorID: operatorID,
+		InboxID:    inboxID,
+		TenantID:   tenantID,
+		GrantedBy:  grantedBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// ==================== Capability ====================
+
+// Capability names a permission reported by the capabilities endpoint
+type Capability string
+
+const (
+	CapabilityDeallocate          Capability = "conversations.deallocate"
+	CapabilityReassign            Capability = "conversations.reassign"
+	CapabilityMoveInbox           Capability = "conversations.move_inbox"
+	CapabilityManageLabels        Capability = "labels.manage"
+	CapabilityManageSubscriptions Capability = "subscriptions.manage"
+	CapabilityManageRoutingRules  Capability = "routing_rules.manage"
+)
+
+// InboxAdminCapabilities are the capabilities an inbox admin holds on their inboxes
+var InboxAdminCapabilities = []Capability{
+	CapabilityManageLabels,
+	CapabilityManageSubscriptions,
+	CapabilityManageRoutingRules,
+}
+
+// Capabilities returns the tenant-wide capabilities of the role
+func (r OperatorRole) Capabilities() []Capability {
+	caps := []Capability{}
+	if r.CanDeallocate() {
+		caps = append(caps, CapabilityDeallocate)
+	}
+	if r.CanReassign() {
+		caps = append(caps, CapabilityReassign)
+	}
+	if r.CanMoveInbox() {
+		caps = append(caps, CapabilityMoveInbox)
+	}
+	if r.CanManageLabels() {
+		caps = append(caps, CapabilityManageLabels)
+	}
+	if r.CanManageSubscriptions() {
+		caps = append(caps, CapabilityManageSubscriptions)
+	}
+	if r.CanManageRoutingRules() {
+		caps = append(caps, CapabilityManageRoutingRules)
+	}
+	return caps
+}
+
+// InboxCapabilities lists capabilities held on a single inbox
+type InboxCapabilities struct {
+	InboxID      uuid.UUID
+	Capabilities []Capability
+}
+
+// Capabilities describes what an operator or API key may do
+type Capabilities struct {
+	Role OperatorRole
+	// Tenant holds the capabilities that apply to every inbox
+	Tenant []Capability
+	// Inboxes holds capabilities from inbox admin grants
+	Inboxes []InboxCapabilities
+}
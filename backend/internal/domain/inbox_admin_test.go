@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorRole_Capabilities(t *testing.T) {
+	assert.Empty(t, OperatorRoleOperator.Capabilities())
+	assert.ElementsMatch(t, []Capability{
+		CapabilityDeallocate, CapabilityReassign, CapabilityMoveInbox,
+		CapabilityManageLabels, CapabilityManageSubscriptions,
+	}, OperatorRoleManager.Capabilities())
+	assert.Contains(t, OperatorRoleAdmin.Capabilities(), CapabilityManageRoutingRules)
+	assert.NotContains(t, OperatorRoleManager.Capabilities(), CapabilityManageRoutingRules)
+}
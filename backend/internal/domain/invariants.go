@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxInvariantViolations caps the violations of one kind a check reports per
+// tenant; the next check picks up the rest
+const MaxInvariantViolations = 1000
+
+// ==================== InvariantKind ====================
+
+// InvariantKind names an invariant of the conversation data that concurrent
+// allocations and lifecycle changes must preserve
+type InvariantKind string
+
+const (
+	// InvariantAllocatedWithoutOperator: an ALLOCATED conversation has no
+	// assigned operator
+	InvariantAllocatedWithoutOperator InvariantKind = "ALLOCATED_WITHOUT_OPERATOR"
+	// InvariantOperatorNotSubscribed: an ALLOCATED conversation is assigned
+	// to an operator not subscribed to its inbox
+	InvariantOperatorNotSubscribed InvariantKind = "OPERATOR_NOT_SUBSCRIBED"
+	// InvariantResolvedWithGracePeriod: a RESOLVED conversation still has a
+	// pending grace period
+	InvariantResolvedWithGracePeriod InvariantKind = "RESOLVED_WITH_GRACE_PERIOD"
+	// InvariantDuplicateExternalID: more than one unresolved conversation of
+	// a tenant has the same external conversation ID
+	InvariantDuplicateExternalID InvariantKind = "DUPLICATE_EXTERNAL_ID"
+)
+
+// InvariantKinds lists every checked invariant in check order
+var InvariantKinds = []InvariantKind{
+	InvariantAllocatedWithoutOperator,
+	InvariantOperatorNotSubscribed,
+	InvariantResolvedWithGracePeriod,
+	InvariantDuplicateExternalID,
+}
+
+func (k InvariantKind) IsValid() bool {
+	for _, kind := range InvariantKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Repairable reports whether the check can repair a violation on its own.
+// Allocations without a valid operator go back to the queue and stale grace
+// periods are deleted; which of several duplicate conversations is the real
+// one takes a person to decide.
+func (k InvariantKind) Repairable() bool {
+	switch k {
+	case InvariantAllocatedWithoutOperator, InvariantOperatorNotSubscribed, InvariantResolvedWithGracePeriod:
+		return true
+	default:
+		return false
+	}
+}
+
+// ==================== InvariantViolation ====================
+
+// InvariantViolation is one conversation breaking an invariant
+type InvariantViolation struct {
+	Kind                   InvariantKind
+	ConversationID         uuid.UUID
+	ExternalConversationID string
+	InboxID                uuid.UUID
+	State                  ConversationState
+	OperatorID             *uuid.UUID
+	// Repaired is set once the check has repaired the violation
+	Repaired bool
+}
+
+// ==================== InvariantReport ====================
+
+// InvariantReport is the result of checking the invariants of one tenant
+type InvariantReport struct {
+	TenantID   uuid.UUID
+	CheckedAt  time.Time
+	Violations []*InvariantViolation
+}
+
+func NewInvariantReport(tenantID uuid.UUID) *InvariantReport {
+	return &InvariantReport{
+		TenantID:   tenantID,
+		CheckedAt:  time.Now().UTC(),
+		Violations: []*InvariantViolation{},
+	}
+}
+
+// Counts returns the number of violations per kind, with every kind present
+func (r *InvariantReport) Counts() map[InvariantKind]int {
+	counts := make(map[InvariantKind]int, len(InvariantKinds))
+	for _, kind := range InvariantKinds {
+		counts[kind] = 0
+	}
+	for _, v := range r.Violations {
+		counts[v.Kind]++
+	}
+	return counts
+}
+
+// Repaired returns the number of repaired violations
+func (r *InvariantReport) Repaired() int {
+	n := 0
+	for _, v := range r.Violations {
+		if v.Repaired {
+			n++
+		}
+	}
+	return n
+}
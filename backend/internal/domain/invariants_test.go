@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvariantKind_Repairable(t *testing.T) {
+	assert.True(t, InvariantAllocatedWithoutOperator.Repairable())
+	assert.True(t, InvariantOperatorNotSubscribed.Repairable())
+	assert.True(t, InvariantResolvedWithGracePeriod.Repairable())
+	assert.False(t, InvariantDuplicateExternalID.Repairable())
+
+	for _, kind := range InvariantKinds {
+		assert.True(t, kind.IsValid(), kind)
+	}
+	assert.False(t, InvariantKind("UNKNOWN").IsValid())
+}
+
+func TestInvariantReport_Counts(t *testing.T) {
+	report := NewInvariantReport(uuid.Must(uuid.NewV7()))
+	assert.Empty(t, report.Violations)
+	assert.Zero(t, report.Repaired())
+
+	report.Violations = append(report.Violations,
+		&InvariantViolation{Kind: InvariantAllocatedWithoutOperator, Repaired: true},
+		&InvariantViolation{Kind: InvariantDuplicateExternalID},
+		&InvariantViolation{Kind: InvariantDuplicateExternalID},
+	)
+
+	counts := report.Counts()
+	assert.Len(t, counts, len(InvariantKinds))
+	assert.Equal(t, 1, counts[InvariantAllocatedWithoutOperator])
+	assert.Equal(t, 0, counts[InvariantOperatorNotSubscribed])
+	assert.Equal(t, 0, counts[InvariantResolvedWithGracePeriod])
+	assert.Equal(t, 2, counts[InvariantDuplicateExternalID])
+	assert.Equal(t, 1, report.Repaired())
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "github.com/google/uuid"
+
+// ==================== Label Hierarchy ====================
+
+// LabelNode is a label with the labels nested directly below it
+type LabelNode struct {
+	Label    *Label
+	Children []*LabelNode
+}
+
+// NestLabels arranges the labels of an inbox into trees. Siblings keep the
+// order they have in labels; a label whose parent is not among labels is
+// a root.
+func NestLabels(labels []*Label) []*LabelNode {
+	nodes := make(map[uuid.UUID]*LabelNode, len(labels))
+	for _, label := range labels {
+		nodes[label.ID] = &LabelNode{Label: label, Children: []*LabelNode{}}
+	}
+
+	roots := []*LabelNode{}
+	for _, label := range labels {
+		node := nodes[label.ID]
+		if label.ParentLabelID != nil {
+			if parent, ok := nodes[*label.ParentLabelID]; ok && parent != node {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// SameParent reports whether the label's parent is parentID
+func (l *Label) SameParent(parentID *uuid.UUID) bool {
+	if l.ParentLabelID == nil || parentID == nil {
+		return l.ParentLabelID == nil && parentID == nil
+	}
+	return *l.ParentLabelID == *parentID
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== Label Version ====================
+
+// LabelVersion is the name and color a label had from one rename or recolor
+// to the next. Conversation labels record the version they were attached
+// under, so renaming a label does not rewrite historical reports.
+type LabelVersion struct {
+	LabelID   uuid.UUID
+	Version   int
+	Name      string
+	Color     *string
+	ChangedBy *uuid.UUID
+	CreatedAt time.Time
+}
+
+// NewLabelVersion snapshots the label's current name and color
+func NewLabelVersion(label *Label, changedBy *uuid.UUID) *LabelVersion {
+	return &LabelVersion{
+		LabelID:   label.ID,
+		Version:   label.Version,
+		Name:      label.Name,
+		Color:     label.Color,
+		ChangedBy: changedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// ==================== Label Usage Report ====================
+
+// LabelAttachmentCount counts attachments of one label version
+type LabelAttachmentCount struct {
+	LabelID     uuid.UUID
+	Version     int
+	Name        string
+	Color       *string
+	Attachments int
+}
+
+// LabelUsageReport counts the labels attached to an inbox's conversations in
+// [Since, Until), by the name each label had when it was attached
+type LabelUsageReport struct {
+	InboxID uuid.UUID
+	Since   time.Time
+	Until   time.Time
+	Labels  []LabelAttachmentCount
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLanguage  = errors.New("language must be an ISO 639 code of 2-3 letters, optionally with a region (es, pt-BR)")
+	ErrTooManyLanguages = errors.New("an operator can have at most 20 languages")
+)
+
+// MaxOperatorLanguages bounds the languages an operator can be routed
+const MaxOperatorLanguages = 20
+
+// NormalizeLanguage reduces a language tag (es, es-MX, pt_BR) to its
+// lowercased primary subtag. Conversations and operators are matched on the
+// primary language only, so es-MX conversations go to operators speaking es.
+func NormalizeLanguage(raw string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(raw))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if len(tag) < 2 || len(tag) > 3 {
+		return "", ErrInvalidLanguage
+	}
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return "", ErrInvalidLanguage
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeLanguages normalizes an operator's languages, dropping duplicates
+func NormalizeLanguages(raw []string) ([]string, error) {
+	languages := make([]string, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		language, err := NormalizeLanguage(r)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+	}
+	if len(languages) > MaxOperatorLanguages {
+		return nil, ErrTooManyLanguages
+	}
+	return languages, nil
+}
+
+// SpeaksLanguage reports whether automatic allocation may give an operator
+// speaking languages a conversation in language. Operators without languages
+// take every conversation, and conversations of unknown language go to every
+// operator.
+func SpeaksLanguage(languages []string, language *string) bool {
+	if language == nil || len(languages) == 0 {
+		return true
+	}
+	for _, l := range languages {
+		if l == *language {
+			return true
+		}
+	}
+	return false
+}
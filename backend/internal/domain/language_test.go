@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"es", "es", false},
+		{" ES-mx ", "es", false},
+		{"pt_BR", "pt", false},
+		{"fil", "fil", false},
+		{"e", "", true},
+		{"spanish", "", true},
+		{"e1", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeLanguage(tt.raw)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, ErrInvalidLanguage, tt.raw)
+			continue
+		}
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.want, got, tt.raw)
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	languages, err := NormalizeLanguages([]string{"es-MX", "en", "es"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"es", "en"}, languages)
+
+	_, err = NormalizeLanguages([]string{"en", "english"})
+	assert.ErrorIs(t, err, ErrInvalidLanguage)
+}
+
+func TestSpeaksLanguage(t *testing.T) {
+	spanish, german := "es", "de"
+
+	assert.True(t, SpeaksLanguage(nil, &german), "operators without languages take any conversation")
+	assert.True(t, SpeaksLanguage([]string{"en", "es"}, nil), "unknown language goes to anyone")
+	assert.True(t, SpeaksLanguage([]string{"en", "es"}, &spanish))
+	assert.False(t, SpeaksLanguage([]string{"en", "es"}, &german))
+}
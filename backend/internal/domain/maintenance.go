@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidMaintenanceWindow is returned for a window that cannot be parsed
+var ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+// MaxMaintenanceOverride is the longest a manual override may last
+const MaxMaintenanceOverride = 7 * 24 * time.Hour
+
+// ==================== MaintenanceJob ====================
+
+// MaintenanceJob names a heavy background job that only runs inside the
+// maintenance window
+type MaintenanceJob string
+
+const (
+	// MaintenanceJobBackfill: online schema-change backfills (deployment)
+	MaintenanceJobBackfill MaintenanceJob = "backfill"
+	// MaintenanceJobQueueRanking: periodic full re-ranking of every inbox
+	// queue (deployment); stale inboxes are still re-ranked outside it
+	MaintenanceJobQueueRanking MaintenanceJob = "queue_ranking"
+	// MaintenanceJobReconciliation: comparison with the upstream system of
+	// record (per tenant)
+	MaintenanceJobReconciliation MaintenanceJob = "reconciliation"
+)
+
+// MaintenanceScope is whose window gates a job
+type MaintenanceScope string
+
+const (
+	MaintenanceScopeDeployment MaintenanceScope = "DEPLOYMENT"
+	MaintenanceScopeTenant     MaintenanceScope = "TENANT"
+)
+
+// MaintenanceJobs lists every gated job with the window that gates it
+var MaintenanceJobs = map[MaintenanceJob]MaintenanceScope{
+	MaintenanceJobBackfill:       MaintenanceScopeDeployment,
+	MaintenanceJobQueueRanking:   MaintenanceScopeDeployment,
+	MaintenanceJobReconciliation: MaintenanceScopeTenant,
+}
+
+// ==================== MaintenanceWindow ====================
+
+// MaintenanceWindow is a daily low-traffic window. Start and End are times
+// of day in Timezone, as offsets from midnight; a window whose End is not
+// after its Start runs past midnight into the next day.
+type MaintenanceWindow struct {
+	Start time.Duration
+	End   time.Duration
+	// Timezone is an IANA time zone name
+	Timezone string
+}
+
+// ParseMaintenanceWindow parses "HH:MM-HH:MM" in the time zone
+func ParseMaintenanceWindow(window, timezone string) (*MaintenanceWindow, error) {
+	startText, endText, ok := strings.Cut(window, "-")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not HH:MM-HH:MM", ErrInvalidMaintenanceWindow, window)
+	}
+	start, err := parseTimeOfDay(strings.TrimSpace(startText))
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(strings.TrimSpace(endText))
+	if err != nil {
+		return nil, err
+	}
+	w := &MaintenanceWindow{Start: start, End: end, Timezone: timezone}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(text string) (time.Duration, error) {
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not HH:MM", ErrInvalidMaintenanceWindow, text)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w *MaintenanceWindow) Validate() error {
+	if w.Start < 0 || w.Start >= 24*time.Hour || w.End < 0 || w.End >= 24*time.Hour {
+		return fmt.Errorf("%w: times must be within a day", ErrInvalidMaintenanceWindow)
+	}
+	if w.Start == w.End {
+		return fmt.Errorf("%w: start and end must differ", ErrInvalidMaintenanceWindow)
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidMaintenanceWindow, w.Timezone)
+	}
+	return nil
+}
+
+// String formats the window as ParseMaintenanceWindow reads it
+func (w *MaintenanceWindow) String() string {
+	return formatTimeOfDay(w.Start) + "-" + formatTimeOfDay(w.End)
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Contains reports whether t falls within the window. A window whose time
+// zone cannot be loaded contains nothing.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+	offset := sinceMidnight(t.In(loc))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	// Runs past midnight
+	return offset >= w.Start || offset < w.End
+}
+
+// NextStart returns the first time after now the window opens
+func (w *MaintenanceWindow) NextStart(now time.Time) time.Time {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return time.Time{}
+	}
+	local := now.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	next := midnight.Add(w.Start)
+	if !next.After(now) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc).Add(w.Start)
+	}
+	return next.UTC()
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	hour, min, sec := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second
+}
+
+// ==================== MaintenanceOverride ====================
+
+// MaintenanceOverrideMode forces the window open or closed
+type MaintenanceOverrideMode string
+
+const (
+	// MaintenanceOverrideOpen runs heavy jobs now, e.g. to finish a backfill
+	MaintenanceOverrideOpen MaintenanceOverrideMode = "OPEN"
+	// MaintenanceOverrideClosed holds heavy jobs, e.g. during an incident
+	MaintenanceOverrideClosed MaintenanceOverrideMode = "CLOSED"
+)
+
+func (m MaintenanceOverrideMode) IsValid() bool {
+	return m == MaintenanceOverrideOpen || m == MaintenanceOverrideClosed
+}
+
+// MaintenanceOverride replaces the window until it expires
+type MaintenanceOverride struct {
+	Mode MaintenanceOverrideMode
+	// Until is zero for the deployment's MAINTENANCE_FORCE, which lasts
+	// until the configuration changes
+	Until  time.Time
+	Reason string
+}
+
+func (o *MaintenanceOverride) ActiveAt(now time.Time) bool {
+	return o != nil && (o.Until.IsZero() || now.Before(o.Until))
+}
+
+// ==================== TenantMaintenanceSettings ====================
+
+// TenantMaintenanceSettings is a tenant's own maintenance configuration.
+// Without a window the deployment's applies.
+type TenantMaintenanceSettings struct {
+	TenantID  uuid.UUID
+	Window    *MaintenanceWindow
+	Override  *MaintenanceOverride
+	UpdatedBy *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultTenantMaintenanceSettings returns the settings of a tenant that
+// never configured maintenance: the deployment's window, no override
+func DefaultTenantMaintenanceSettings(tenantID uuid.UUID) *TenantMaintenanceSettings {
+	return &TenantMaintenanceSettings{TenantID: tenantID}
+}
+
+// ==================== MaintenanceStatus ====================
+
+// MaintenanceStatus is whether heavy jobs may run now, and why
+type MaintenanceStatus struct {
+	Open bool
+	// Window is nil when none is configured: heavy jobs always run
+	Window   *MaintenanceWindow
+	Override *MaintenanceOverride
+	// NextOpenAt is when heavy jobs may run again; nil when open or held
+	// closed until further notice
+	NextOpenAt *time.Time
+}
+
+// EvaluateMaintenance decides whether heavy jobs may run at now. An active
+// override wins; otherwise jobs run inside the window, or any time when
+// there is no window.
+func EvaluateMaintenance(window *MaintenanceWindow, override *MaintenanceOverride, now time.Time) MaintenanceStatus {
+	status := MaintenanceStatus{Window: window}
+	if override.ActiveAt(now) {
+		status.Override = override
+		status.Open = override.Mode == MaintenanceOverrideOpen
+		if !status.Open && !override.Until.IsZero() {
+			next := override.Until
+			if window != nil && !window.Contains(next) {
+				next = window.NextStart(next)
+			}
+			status.NextOpenAt = &next
+		}
+		return status
+	}
+
+	status.Open = window == nil || window.Contains(now)
+	if !status.Open {
+		next := window.NextStart(now)
+		status.NextOpenAt = &next
+	}
+	return status
+}
+
+// ==================== MaintenanceReport ====================
+
+// MaintenanceReport is the maintenance status seen by a tenant's admins:
+// the deployment's window gates deployment-wide jobs, the tenant's gates
+// the tenant's own
+type MaintenanceReport struct {
+	At         time.Time
+	Deployment MaintenanceStatus
+	Tenant     MaintenanceStatus
+	Settings   *TenantMaintenanceSettings
+}
+
+// JobOpen reports whether the job may run at the report's time
+func (r *MaintenanceReport) JobOpen(job MaintenanceJob) bool {
+	if MaintenanceJobs[job] == MaintenanceScopeTenant {
+		return r.Tenant.Open
+	}
+	return r.Deployment.Open
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	w, err := ParseMaintenanceWindow("22:30-04:00", "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, 22*time.Hour+30*time.Minute, w.Start)
+	assert.Equal(t, 4*time.Hour, w.End)
+	assert.Equal(t, "Europe/Berlin", w.Timezone)
+	assert.Equal(t, "22:30-04:00", w.String())
+
+	for _, tc := range []struct{ window, timezone string }{
+		{"22:30", "UTC"},
+		{"25:00-04:00", "UTC"},
+		{"01:00-1am", "UTC"},
+		{"01:00-01:00", "UTC"},
+		{"01:00-05:00", "Not/AZone"},
+	} {
+		_, err := ParseMaintenanceWindow(tc.window, tc.timezone)
+		assert.True(t, errors.Is(err, ErrInvalidMaintenanceWindow), "%s %s", tc.window, tc.timezone)
+	}
+}
+
+func TestMaintenanceWindow_Contains(t *testing.T) {
+	day := &MaintenanceWindow{Start: 1 * time.Hour, End: 5 * time.Hour, Timezone: "UTC"}
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)))
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 4, 59, 0, 0, time.UTC)))
+	assert.False(t, day.Contains(time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)), "end is exclusive")
+
+	night := &MaintenanceWindow{Start: 22 * time.Hour, End: 4 * time.Hour, Timezone: "UTC"}
+	assert.True(t, night.Contains(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, night.Contains(time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)), "past midnight")
+	assert.False(t, night.Contains(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+
+	// 01:00-05:00 in New York (EST, UTC-5)
+	ny := &MaintenanceWindow{Start: 1 * time.Hour, End: 5 * time.Hour, Timezone: "America/New_York"}
+	assert.True(t, ny.Contains(time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)))
+	assert.False(t, ny.Contains(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)))
+
+	invalid := &MaintenanceWindow{Start: 0, End: 23 * time.Hour, Timezone: "Not/AZone"}
+	assert.False(t, invalid.Contains(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestMaintenanceWindow_NextStart(t *testing.T) {
+	w := &MaintenanceWindow{Start: 1 * time.Hour, End: 5 * time.Hour, Timezone: "UTC"}
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), w.NextStart(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), w.NextStart(time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), w.NextStart(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)), "strictly after")
+
+	ny := &MaintenanceWindow{Start: 1 * time.Hour, End: 5 * time.Hour, Timezone: "America/New_York"}
+	assert.Equal(t, time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC), ny.NextStart(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestEvaluateMaintenance(t *testing.T) {
+	window := &MaintenanceWindow{Start: 1 * time.Hour, End: 5 * time.Hour, Timezone: "UTC"}
+	inside := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	outside := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no window is always open", func(t *testing.T) {
+		status := EvaluateMaintenance(nil, nil, outside)
+		assert.True(t, status.Open)
+		assert.Nil(t, status.NextOpenAt)
+	})
+
+	t.Run("follows the window", func(t *testing.T) {
+		assert.True(t, EvaluateMaintenance(window, nil, inside).Open)
+
+		status := EvaluateMaintenance(window, nil, outside)
+		assert.False(t, status.Open)
+		require.NotNil(t, status.NextOpenAt)
+		assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), *status.NextOpenAt)
+	})
+
+	t.Run("an active override wins", func(t *testing.T) {
+		open := &MaintenanceOverride{Mode: MaintenanceOverrideOpen, Until: outside.Add(time.Hour)}
+		status := EvaluateMaintenance(window, open, outside)
+		assert.True(t, status.Open)
+		assert.Equal(t, open, status.Override)
+
+		closed := &MaintenanceOverride{Mode: MaintenanceOverrideClosed, Until: inside.Add(time.Hour)}
+		status = EvaluateMaintenance(window, closed, inside)
+		assert.False(t, status.Open)
+		require.NotNil(t, status.NextOpenAt)
+		assert.Equal(t, closed.Until, *status.NextOpenAt, "window still open when the override ends")
+
+		lateClosed := &MaintenanceOverride{Mode: MaintenanceOverrideClosed, Until: inside.Add(6 * time.Hour)}
+		status = EvaluateMaintenance(window, lateClosed, inside)
+		require.NotNil(t, status.NextOpenAt)
+		assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), *status.NextOpenAt, "next window after the override")
+	})
+
+	t.Run("an expired override is ignored", func(t *testing.T) {
+		expired := &MaintenanceOverride{Mode: MaintenanceOverrideOpen, Until: outside.Add(-time.Minute)}
+		status := EvaluateMaintenance(window, expired, outside)
+		assert.False(t, status.Open)
+		assert.Nil(t, status.Override)
+	})
+
+	t.Run("a forced override never expires", func(t *testing.T) {
+		forced := &MaintenanceOverride{Mode: MaintenanceOverrideClosed}
+		status := EvaluateMaintenance(nil, forced, inside.AddDate(1, 0, 0))
+		assert.False(t, status.Open)
+		assert.Nil(t, status.NextOpenAt)
+	})
+}
+
+func TestMaintenanceReport_JobOpen(t *testing.T) {
+	report := &MaintenanceReport{
+		Deployment: MaintenanceStatus{Open: false},
+		Tenant:     MaintenanceStatus{Open: true},
+	}
+	assert.False(t, report.JobOpen(MaintenanceJobBackfill))
+	assert.False(t, report.JobOpen(MaintenanceJobQueueRanking))
+	assert.True(t, report.JobOpen(MaintenanceJobReconciliation))
+}
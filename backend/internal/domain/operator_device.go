@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDevicesPerOperator bounds the devices registered for push notifications
+// per operator
+const MaxDevicesPerOperator = 10
+
+// ==================== DevicePlatform ====================
+
+// DevicePlatform is the push service delivering to a device
+type DevicePlatform string
+
+const (
+	DevicePlatformFCM  DevicePlatform = "FCM"
+	DevicePlatformAPNs DevicePlatform = "APNS"
+)
+
+func (p DevicePlatform) IsValid() bool {
+	switch p {
+	case DevicePlatformFCM, DevicePlatformAPNs:
+		return true
+	}
+	return false
+}
+
+// IsPushEvent reports whether devices can be notified of the event type:
+// the assignments of a conversation to the device's operator
+func IsPushEvent(t EventType) bool {
+	return t.IsAssignment()
+}
+
+// ==================== OperatorDevice ====================
+
+// OperatorDevice is a mobile device registered for push notifications of
+// the operator's assignments. A push token belongs to one device, so
+// registering it again moves it to the registering operator.
+type OperatorDevice struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+	Platform   DevicePlatform
+	Token      string
+	// EventTypes are the push events the device is notified of; empty
+	// means every push event
+	EventTypes []EventType
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewOperatorDevice(tenantID, operatorID uuid.UUID, platform DevicePlatform, token string, eventTypes []EventType) *OperatorDevice {
+	now := time.Now().UTC()
+	return &OperatorDevice{
+		ID:         uuid.Must(uuid.NewV7()),
+		TenantID:   tenantID,
+		OperatorID: operatorID,
+		Platform:   platform,
+		Token:      token,
+		EventTypes: eventTypes,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Wants reports whether the device is notified of the event type
+func (d *OperatorDevice) Wants(t EventType) bool {
+	if !IsPushEvent(t) {
+		return false
+	}
+	if len(d.EventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range d.EventTypes {
+		if wanted == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorDevice_Wants(t *testing.T) {
+	all := NewOperatorDevice(uuid.New(), uuid.New(), DevicePlatformFCM, "token", nil)
+	assert.True(t, all.Wants(EventConversationAllocated))
+	assert.True(t, all.Wants(EventConversationReassigned))
+	assert.False(t, all.Wants(EventConversationResolved), "only assignments are pushed")
+
+	reassignOnly := NewOperatorDevice(uuid.New(), uuid.New(), DevicePlatformAPNs, "token",
+		[]EventType{EventConversationReassigned})
+	assert.False(t, reassignOnly.Wants(EventConversationAllocated))
+	assert.True(t, reassignOnly.Wants(EventConversationReassigned))
+}
+
+func TestDevicePlatform_IsValid(t *testing.T) {
+	assert.True(t, DevicePlatformFCM.IsValid())
+	assert.True(t, DevicePlatformAPNs.IsValid())
+	assert.False(t, DevicePlatform("WEB").IsValid())
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAllocationWeight is returned for a weight outside (0, 1]
+var ErrInvalidAllocationWeight = errors.New("allocation weight must be greater than 0 and at most 1")
+
+// ==================== AllocationHealthPolicy ====================
+
+// AllocationHealthPolicy is the feedback loop that adjusts an operator's
+// allocation weight from their return rate over an evaluation window
+type AllocationHealthPolicy struct {
+	// ReturnRateThreshold is the returns per allocation at or above which
+	// the rate is abnormal
+	ReturnRateThreshold float64
+	// MinAllocations keeps operators with few allocations from being judged
+	MinAllocations int
+	// Decay multiplies the weight after each abnormal window
+	Decay float64
+	// Recovery is added back to the weight after each normal window
+	Recovery float64
+	// MinWeight is the lowest weight the loop sets
+	MinWeight float64
+}
+
+// DefaultAllocationHealthPolicy returns sensible defaults
+func DefaultAllocationHealthPolicy() AllocationHealthPolicy {
+	return AllocationHealthPolicy{
+		ReturnRateThreshold: 0.5,
+		MinAllocations:      5,
+		Decay:               0.5,
+		Recovery:            0.25,
+		MinWeight:           0.25,
+	}
+}
+
+// Abnormal reports whether the return rate over a window is abnormally high
+func (p AllocationHealthPolicy) Abnormal(allocations, returns int) bool {
+	if allocations < p.MinAllocations {
+		return false
+	}
+	return float64(returns)/float64(allocations) >= p.ReturnRateThreshold
+}
+
+// Adjust returns the weight after a window with the given outcomes
+func (p AllocationHealthPolicy) Adjust(weight float64, allocations, returns int) float64 {
+	if p.Abnormal(allocations, returns) {
+		weight *= p.Decay
+		if weight < p.MinWeight {
+			weight = p.MinWeight
+		}
+		return weight
+	}
+	weight += p.Recovery
+	if weight > 1 {
+		weight = 1
+	}
+	return weight
+}
+
+// ValidAllocationWeight reports whether w is a usable weight
+func ValidAllocationWeight(w float64) bool {
+	return w > 0 && w <= 1
+}
+
+// AllocationPace returns how long an operator with weight w waits between
+// automatic allocations: none at 1, one interval at 0.5, three at 0.25
+func AllocationPace(w float64, interval time.Duration) time.Duration {
+	if w >= 1 || w <= 0 {
+		return 0
+	}
+	return time.Duration(float64(interval) * (1/w - 1))
+}
+
+// ==================== OperatorAllocationHealth ====================
+
+// OperatorAllocationHealth is an operator's allocation weight with the
+// outcomes it was last evaluated from
+type OperatorAllocationHealth struct {
+	OperatorID  uuid.UUID
+	TenantID    uuid.UUID
+	Weight      float64
+	Allocations int
+	Returns     int
+	EvaluatedAt time.Time
+	// OverrideWeight is pinned by a manager and replaces Weight
+	OverrideWeight *float64
+	OverrideBy     *uuid.UUID
+	OverrideAt     *time.Time
+}
+
+// NewOperatorAllocationHealth returns the health of an operator never evaluated
+func NewOperatorAllocationHealth(tenantID, operatorID uuid.UUID) *OperatorAllocationHealth {
+	return &OperatorAllocationHealth{
+		OperatorID:  operatorID,
+		TenantID:    tenantID,
+		Weight:      1,
+		EvaluatedAt: time.Now().UTC(),
+	}
+}
+
+// EffectiveWeight is the weight allocation applies
+func (h *OperatorAllocationHealth) EffectiveWeight() float64 {
+	if h.OverrideWeight != nil {
+		return *h.OverrideWeight
+	}
+	return h.Weight
+}
+
+// ReturnRate is returns per allocation in the last evaluation window
+func (h *OperatorAllocationHealth) ReturnRate() float64 {
+	if h.Allocations == 0 {
+		return 0
+	}
+	return float64(h.Returns) / float64(h.Allocations)
+}
+
+// Evaluate records a window's outcomes and adjusts Weight by the policy
+func (h *OperatorAllocationHealth) Evaluate(p AllocationHealthPolicy, allocations, returns int) {
+	h.Weight = p.Adjust(h.Weight, allocations, returns)
+	h.Allocations = allocations
+	h.Returns = returns
+	h.EvaluatedAt = time.Now().UTC()
+}
+
+// Override pins the weight; nil clears the override
+func (h *OperatorAllocationHealth) Override(weight *float64, by *uuid.UUID) error {
+	if weight == nil {
+		h.OverrideWeight, h.OverrideBy, h.OverrideAt = nil, nil, nil
+		return nil
+	}
+	if !ValidAllocationWeight(*weight) {
+		return ErrInvalidAllocationWeight
+	}
+	now := time.Now().UTC()
+	h.OverrideWeight, h.OverrideBy, h.OverrideAt = weight, by, &now
+	return nil
+}
+
+// OperatorAllocationOutcome counts an operator's allocations and returns in
+// a window
+type OperatorAllocationOutcome struct {
+	Allocations int
+	Returns     int
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationHealthPolicy_Adjust(t *testing.T) {
+	p := DefaultAllocationHealthPolicy()
+
+	tests := []struct {
+		name        string
+		weight      float64
+		allocations int
+		returns     int
+		want        float64
+	}{
+		{"healthy stays at 1", 1, 20, 2, 1},
+		{"abnormal rate halves", 1, 10, 5, 0.5},
+		{"decay stops at the floor", 0.25, 10, 9, 0.25},
+		{"too few allocations to judge", 1, 4, 4, 1},
+		{"recovers after a normal window", 0.25, 10, 1, 0.5},
+		{"recovery is capped at 1", 0.9, 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, p.Adjust(tt.weight, tt.allocations, tt.returns), 1e-9)
+		})
+	}
+}
+
+func TestAllocationPace(t *testing.T) {
+	assert.Zero(t, AllocationPace(1, time.Minute))
+	assert.Equal(t, time.Minute, AllocationPace(0.5, time.Minute))
+	assert.Equal(t, 3*time.Minute, AllocationPace(0.25, time.Minute))
+}
+
+func TestOperatorAllocationHealth_Override(t *testing.T) {
+	h := NewOperatorAllocationHealth(uuid.New(), uuid.New())
+	h.Evaluate(DefaultAllocationHealthPolicy(), 10, 8)
+	assert.Equal(t, 0.5, h.EffectiveWeight())
+	assert.Equal(t, 0.8, h.ReturnRate())
+
+	manager := uuid.New()
+	full := 1.0
+	require.NoError(t, h.Override(&full, &manager))
+	assert.Equal(t, 1.0, h.EffectiveWeight())
+	assert.Equal(t, &manager, h.OverrideBy)
+
+	zero := 0.0
+	assert.ErrorIs(t, h.Override(&zero, &manager), ErrInvalidAllocationWeight)
+
+	require.NoError(t, h.Override(nil, nil))
+	assert.Equal(t, 0.5, h.EffectiveWeight())
+	assert.Nil(t, h.OverrideAt)
+}
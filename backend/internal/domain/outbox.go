@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+)
+
+// ==================== OutboxEntry ====================
+
+// OutboxEntry is a domain event staged in the transaction of the state change
+// that produced it, kept until every sink has accepted it
+type OutboxEntry struct {
+	Event         *Event
+	AttemptCount  int
+	NextAttemptAt time.Time
+	LastError     *string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// NewOutboxEntry stages an event. The worker leaves it alone until
+// availableAt, giving the producer the first chance to publish it.
+func NewOutboxEntry(event *Event, availableAt time.Time) *OutboxEntry {
+	return &OutboxEntry{
+		Event:         event,
+		NextAttemptAt: availableAt,
+		CreatedAt:     time.Now().UTC(),
+	}
+}
+
+// MarkPublished records that every sink accepted the event
+func (e *OutboxEntry) MarkPublished() {
+	now := time.Now().UTC()
+	e.AttemptCount++
+	e.LastError = nil
+	e.PublishedAt = &now
+}
+
+// MarkFailed records a failed attempt and reschedules the entry. Entries are
+// never given up on: publishing is at least once.
+func (e *OutboxEntry) MarkFailed(errMsg string, nextAttemptAt time.Time) {
+	e.AttemptCount++
+	e.LastError = &errMsg
+	e.NextAttemptAt = nextAttemptAt
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxEntry_Attempts(t *testing.T) {
+	event := NewEvent(uuid.New(), EventConversationResolved, map[string]interface{}{"conversation_id": "c1"})
+	availableAt := time.Now().UTC().Add(30 * time.Second)
+	entry := NewOutboxEntry(event, availableAt)
+
+	assert.Equal(t, availableAt, entry.NextAttemptAt)
+	assert.Nil(t, entry.PublishedAt)
+
+	retryAt := availableAt.Add(time.Minute)
+	entry.MarkFailed("sink unavailable", retryAt)
+	assert.Equal(t, 1, entry.AttemptCount)
+	assert.Equal(t, retryAt, entry.NextAttemptAt)
+	require.NotNil(t, entry.LastError)
+	assert.Equal(t, "sink unavailable", *entry.LastError)
+	assert.Nil(t, entry.PublishedAt)
+
+	entry.MarkPublished()
+	assert.Equal(t, 2, entry.AttemptCount)
+	assert.Nil(t, entry.LastError)
+	assert.NotNil(t, entry.PublishedAt)
+}
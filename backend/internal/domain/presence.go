@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OperatorPresence records that an operator holds a presence WebSocket on
+// some replica. The row lives while heartbeats keep it fresh.
+type OperatorPresence struct {
+	OperatorID uuid.UUID
+	TenantID   uuid.UUID
+	// ConnectedAt is when the operator connected after last timing out
+	ConnectedAt time.Time
+	LastSeenAt  time.Time
+}
+
+// PresenceChange is an operator's status change, addressed to the teammates
+// subscribed to one of their inboxes
+type PresenceChange struct {
+	TenantID       uuid.UUID           `json:"tenant_id"`
+	OperatorID     uuid.UUID           `json:"operator_id"`
+	InboxIDs       []uuid.UUID         `json:"inbox_ids"`
+	Status         OperatorStatusType  `json:"status"`
+	PreviousStatus *OperatorStatusType `json:"previous_status"`
+	OccurredAt     time.Time           `json:"occurred_at"`
+}
+
+// TeammatePresence is a teammate's status when an operator connects
+type TeammatePresence struct {
+	OperatorID uuid.UUID
+	Status     OperatorStatusType
+	// Connected reports whether the teammate holds a presence connection
+	Connected bool
+}
+
+// ==================== PresencePolicy ====================
+
+// PresencePolicy decides when a connected operator counts as gone
+type PresencePolicy struct {
+	// HeartbeatInterval is how often the server pings the client and
+	// refreshes the operator's presence
+	HeartbeatInterval time.Duration
+	// Timeout is how long an operator may go unseen before they are set
+	// OFFLINE. It spans several heartbeats, so one lost ping does not end
+	// the presence.
+	Timeout time.Duration
+}
+
+// DefaultPresencePolicy returns sensible defaults
+func DefaultPresencePolicy() PresencePolicy {
+	return PresencePolicy{
+		HeartbeatInterval: 15 * time.Second,
+		Timeout:           45 * time.Second,
+	}
+}
+
+// StaleBefore returns the last-seen time before which presence has timed out
+func (p PresencePolicy) StaleBefore(now time.Time) time.Time {
+	return now.Add(-p.Timeout)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresencePolicy_StaleBefore(t *testing.T) {
+	policy := PresencePolicy{HeartbeatInterval: 10 * time.Second, Timeout: 30 * time.Second}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now.Add(-30*time.Second), policy.StaleBefore(now))
+}
+
+func TestDefaultPresencePolicy_TimeoutSpansHeartbeats(t *testing.T) {
+	policy := DefaultPresencePolicy()
+
+	assert.GreaterOrEqual(t, policy.Timeout, 2*policy.HeartbeatInterval)
+}
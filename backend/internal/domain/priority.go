@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ==================== PriorityScoreComponents ====================
+
+// PriorityScoreComponents records one priority calculation of a conversation:
+// the inputs, the normalized factors and weights, and the resulting score
+//
+//	score = alpha × message_factor + beta × delay_factor + rule_boost + first_contact_boost
+type PriorityScoreComponents struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	TenantID       uuid.UUID
+	MessageCount   int32
+	LastMessageAt  time.Time
+	// MessageFactor is min(log10(message_count + 1) / 3, 1)
+	MessageFactor decimal.Decimal
+	// DelayFactor is min(hours since last message / 24, 1), counting only
+	// the inbox's business hours when it has them
+	DelayFactor decimal.Decimal
+	WeightAlpha decimal.Decimal
+	WeightBeta  decimal.Decimal
+	// RuleBoost is added by matching routing rules
+	RuleBoost decimal.Decimal
+	// FirstContactBoost is the tenant's boost when the conversation is a
+	// first contact, zero otherwise
+	FirstContactBoost decimal.Decimal
+	PriorityScore     decimal.Decimal
+	ComputedAt        time.Time
+}
+
+// NewPriorityScoreComponents calculates the conversation's priority at now
+// with the tenant weights and a routing rule boost. firstContactBoost only
+// applies when the conversation is a first contact. With the inbox's
+// business hours, only time the inbox is open counts towards the delay.
+func NewPriorityScoreComponents(conv *ConversationRef, alpha, beta, boost, firstContactBoost decimal.Decimal, hours *BusinessHours, now time.Time) *PriorityScoreComponents {
+	if !conv.IsFirstContact {
+		firstContactBoost = decimal.Zero
+	}
+
+	delay := now.Sub(conv.LastMessageAt)
+	if hours != nil {
+		delay = hours.Elapsed(conv.LastMessageAt, now)
+	}
+
+	messageFactor := decimal.NewFromFloat(math.Min(math.Log10(float64(conv.MessageCount+1))/3.0, 1.0))
+	delayFactor := decimal.NewFromFloat(math.Min(delay.Hours()/24.0, 1.0))
+
+	c := &PriorityScoreComponents{
+		ID:                uuid.Must(uuid.NewV7()),
+		ConversationID:    conv.ID,
+		TenantID:          conv.TenantID,
+		MessageCount:      conv.MessageCount,
+		LastMessageAt:     conv.LastMessageAt,
+		MessageFactor:     messageFactor,
+		DelayFactor:       delayFactor,
+		WeightAlpha:       alpha,
+		WeightBeta:        beta,
+		RuleBoost:         boost,
+		FirstContactBoost: firstContactBoost,
+		ComputedAt:        now,
+	}
+	c.PriorityScore = c.MessageComponent().Add(c.DelayComponent()).Add(boost).Add(firstContactBoost)
+	return c
+}
+
+// MessageComponent is the weighted message factor
+func (c *PriorityScoreComponents) MessageComponent() decimal.Decimal {
+	return c.WeightAlpha.Mul(c.MessageFactor)
+}
+
+// DelayComponent is the weighted delay factor
+func (c *PriorityScoreComponents) DelayComponent() decimal.Decimal {
+	return c.WeightBeta.Mul(c.DelayFactor)
+}
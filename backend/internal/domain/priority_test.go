@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPriorityScoreComponents(t *testing.T) {
+	now := time.Now().UTC()
+	conv := &ConversationRef{
+		ID:            uuid.New(),
+		TenantID:      uuid.New(),
+		MessageCount:  99,
+		LastMessageAt: now.Add(-6 * time.Hour),
+	}
+	half := decimal.NewFromFloat(0.5)
+
+	c := NewPriorityScoreComponents(conv, half, half, decimal.NewFromFloat(0.2), decimal.Zero, nil, now)
+	assert.Equal(t, conv.ID, c.ConversationID)
+	assert.InDelta(t, 2.0/3.0, c.MessageFactor.InexactFloat64(), 1e-9)
+	assert.InDelta(t, 0.25, c.DelayFactor.InexactFloat64(), 1e-9)
+	assert.True(t, c.PriorityScore.Equal(c.MessageComponent().Add(c.DelayComponent()).Add(c.RuleBoost)))
+
+	// Both factors are capped at 1
+	conv.MessageCount = 100000
+	conv.LastMessageAt = now.Add(-72 * time.Hour)
+	c = NewPriorityScoreComponents(conv, half, half, decimal.Zero, decimal.Zero, nil, now)
+	assert.True(t, c.MessageFactor.Equal(decimal.NewFromInt(1)))
+	assert.True(t, c.DelayFactor.Equal(decimal.NewFromInt(1)))
+	assert.True(t, c.PriorityScore.Equal(decimal.NewFromInt(1)))
+}
+
+func TestNewPriorityScoreComponents_FirstContactBoost(t *testing.T) {
+	now := time.Now().UTC()
+	conv := &ConversationRef{ID: uuid.New(), MessageCount: 0, LastMessageAt: now}
+	half := decimal.NewFromFloat(0.5)
+	boost := decimal.NewFromFloat(0.3)
+
+	// Returning customers are not boosted
+	c := NewPriorityScoreComponents(conv, half, half, decimal.Zero, boost, nil, now)
+	assert.True(t, c.FirstContactBoost.IsZero())
+	assert.True(t, c.PriorityScore.IsZero())
+
+	conv.IsFirstContact = true
+	c = NewPriorityScoreComponents(conv, half, half, decimal.NewFromFloat(0.1), boost, nil, now)
+	assert.True(t, c.FirstContactBoost.Equal(boost))
+	assert.True(t, c.PriorityScore.Equal(decimal.NewFromFloat(0.4)))
+}
+
+func TestNewPriorityScoreComponents_BusinessHours(t *testing.T) {
+	// Friday 16:00 to Monday 10:00, open weekdays 09:00-17:00: two business hours
+	now := time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC)
+	conv := &ConversationRef{ID: uuid.New(), LastMessageAt: time.Date(2024, 1, 5, 16, 0, 0, 0, time.UTC)}
+	half := decimal.NewFromFloat(0.5)
+	hours := &BusinessHours{Timezone: "UTC", Days: weekdays(9*time.Hour, 17*time.Hour)}
+
+	c := NewPriorityScoreComponents(conv, half, half, decimal.Zero, decimal.Zero, hours, now)
+	assert.InDelta(t, 2.0/24.0, c.DelayFactor.InexactFloat64(), 1e-9)
+
+	c = NewPriorityScoreComponents(conv, half, half, decimal.Zero, decimal.Zero, nil, now)
+	assert.True(t, c.DelayFactor.Equal(decimal.NewFromInt(1)), "all time counts without business hours")
+}
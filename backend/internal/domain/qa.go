@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidQAScores = errors.New("scores do not match the QA rubric")
+)
+
+// ==================== QAReviewStatus ====================
+
+type QAReviewStatus string
+
+const (
+	QAReviewStatusPending   QAReviewStatus = "PENDING"
+	QAReviewStatusInReview  QAReviewStatus = "IN_REVIEW"
+	QAReviewStatusCompleted QAReviewStatus = "COMPLETED"
+)
+
+func (s QAReviewStatus) IsValid() bool {
+	switch s {
+	case QAReviewStatusPending, QAReviewStatusInReview, QAReviewStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (s QAReviewStatus) String() string {
+	return string(s)
+}
+
+// ==================== Rubric ====================
+
+// Every rubric criterion is scored on this inclusive scale
+const (
+	QAMinScore = 1
+	QAMaxScore = 5
+)
+
+type QARubricCriterion struct {
+	Key         string
+	Description string
+}
+
+// QARubric is the fixed set of criteria a review must score
+var QARubric = []QARubricCriterion{
+	{Key: "accuracy", Description: "Information given to the customer was correct"},
+	{Key: "resolution", Description: "The customer's issue was resolved"},
+	{Key: "tone", Description: "Communication was polite and professional"},
+	{Key: "efficiency", Description: "The conversation was handled without unnecessary delay"},
+}
+
+// ValidateQAScores checks that scores covers exactly the rubric criteria,
+// each within [QAMinScore, QAMaxScore]
+func ValidateQAScores(scores map[string]int) error {
+	if len(scores) != len(QARubric) {
+		return fmt.Errorf("%w: expected %d criteria, got %d", ErrInvalidQAScores, len(QARubric), len(scores))
+	}
+	for _, criterion := range QARubric {
+		score, ok := scores[criterion.Key]
+		if !ok {
+			return fmt.Errorf("%w: missing criterion %q", ErrInvalidQAScores, criterion.Key)
+		}
+		if score < QAMinScore || score > QAMaxScore {
+			return fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidQAScores, criterion.Key, QAMinScore, QAMaxScore)
+		}
+	}
+	return nil
+}
+
+// ==================== QAReviewer ====================
+
+// QAReviewer grants an operator the permission to review sampled conversations
+type QAReviewer struct {
+	OperatorID uuid.UUID
+	TenantID   uuid.UUID
+	GrantedBy  *uuid.UUID
+	CreatedAt  time.Time
+}
+
+func NewQAReviewer(tenantID, operatorID uuid.UUID, grantedBy *uuid.UUID) *QAReviewer {
+	return &QAReviewer{
+		OperatorID: operatorID,
+		TenantID:   tenantID,
+		GrantedBy:  grantedBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// ==================== QAReviewItem ====================
+
+// QAReviewItem is a sampled resolved conversation in the review queue.
+// OperatorID is the operator who handled the conversation when it was resolved.
+type QAReviewItem struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	OperatorID     *uuid.UUID
+	Status         QAReviewStatus
+	ReviewerID     *uuid.UUID
+	ClaimedUntil   *time.Time
+	Scores         map[string]int
+	OverallScore   *decimal.Decimal
+	Comment        *string
+	CreatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+func NewQAReviewItem(tenantID, conversationID uuid.UUID, operatorID *uuid.UUID) *QAReviewItem {
+	return &QAReviewItem{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       tenantID,
+		ConversationID: conversationID,
+		OperatorID:     operatorID,
+		Status:         QAReviewStatusPending,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// Complete records the review. The overall score is the mean of the criteria.
+func (i *QAReviewItem) Complete(scores map[string]int, comment *string) error {
+	if i.Status != QAReviewStatusInReview {
+		return ErrInvalidStateTransition
+	}
+	if err := ValidateQAScores(scores); err != nil {
+		return err
+	}
+
+	total := 0
+	for _, score := range scores {
+		total += score
+	}
+	overall := decimal.NewFromInt(int64(total)).Div(decimal.NewFromInt(int64(len(scores)))).Round(2)
+
+	now := time.Now().UTC()
+	i.Status = QAReviewStatusCompleted
+	i.Scores = scores
+	i.OverallScore = &overall
+	i.Comment = comment
+	i.ClaimedUntil = nil
+	i.CompletedAt = &now
+	return nil
+}
+
+// ==================== QAOperatorReport ====================
+
+// QAOperatorReport aggregates completed reviews of one operator
+type QAOperatorReport struct {
+	OperatorID        uuid.UUID
+	ReviewCount       int
+	AverageScore      decimal.Decimal
+	CriterionAverages map[string]decimal.Decimal
+}
+
+// BuildQAOperatorReports aggregates completed reviews per operator, ordered by
+// average score (highest first). Items without an operator are skipped.
+func BuildQAOperatorReports(items []*QAReviewItem) []*QAOperatorReport {
+	type totals struct {
+		count     int
+		overall   decimal.Decimal
+		criterion map[string]int
+	}
+
+	byOperator := make(map[uuid.UUID]*totals)
+	for _, item := range items {
+		if item.Status != QAReviewStatusCompleted || item.OperatorID == nil || item.OverallScore == nil {
+			continue
+		}
+		t, ok := byOperator[*item.OperatorID]
+		if !ok {
+			t = &totals{overall: decimal.Zero, criterion: make(map[string]int)}
+			byOperator[*item.OperatorID] = t
+		}
+		t.count++
+		t.overall = t.overall.Add(*item.OverallScore)
+		for key, score := range item.Scores {
+			t.criterion[key] += score
+		}
+	}
+
+	reports := make([]*QAOperatorReport, 0, len(byOperator))
+	for operatorID, t := range byOperator {
+		count := decimal.NewFromInt(int64(t.count))
+		report := &QAOperatorReport{
+			OperatorID:        operatorID,
+			ReviewCount:       t.count,
+			AverageScore:      t.overall.Div(count).Round(2),
+			CriterionAverages: make(map[string]decimal.Decimal, len(t.criterion)),
+		}
+		for key, sum := range t.criterion {
+			report.CriterionAverages[key] = decimal.NewFromInt(int64(sum)).Div(count).Round(2)
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(a, b int) bool {
+		if !reports[a].AverageScore.Equal(reports[b].AverageScore) {
+			return reports[a].AverageScore.GreaterThan(reports[b].AverageScore)
+		}
+		return reports[a].OperatorID.String() < reports[b].OperatorID.String()
+	})
+	return reports
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fullQAScores(score int) map[string]int {
+	scores := make(map[string]int, len(QARubric))
+	for _, criterion := range QARubric {
+		scores[criterion.Key] = score
+	}
+	return scores
+}
+
+func TestValidateQAScores(t *testing.T) {
+	assert.NoError(t, ValidateQAScores(fullQAScores(QAMaxScore)))
+
+	missing := fullQAScores(3)
+	delete(missing, "tone")
+	assert.ErrorIs(t, ValidateQAScores(missing), ErrInvalidQAScores)
+
+	unknown := fullQAScores(3)
+	delete(unknown, "tone")
+	unknown["speed"] = 3
+	assert.ErrorIs(t, ValidateQAScores(unknown), ErrInvalidQAScores)
+
+	assert.ErrorIs(t, ValidateQAScores(fullQAScores(QAMaxScore+1)), ErrInvalidQAScores)
+	assert.ErrorIs(t, ValidateQAScores(fullQAScores(QAMinScore-1)), ErrInvalidQAScores)
+}
+
+func TestQAReviewItem_Complete(t *testing.T) {
+	item := NewQAReviewItem(uuid.New(), uuid.New(), nil)
+
+	// Must be claimed first
+	assert.ErrorIs(t, item.Complete(fullQAScores(4), nil), ErrInvalidStateTransition)
+
+	item.Status = QAReviewStatusInReview
+	scores := map[string]int{"accuracy": 5, "resolution": 4, "tone": 4, "efficiency": 2}
+	require.NoError(t, item.Complete(scores, nil))
+
+	assert.Equal(t, QAReviewStatusCompleted, item.Status)
+	require.NotNil(t, item.OverallScore)
+	assert.True(t, decimal.RequireFromString("3.75").Equal(*item.OverallScore))
+	assert.NotNil(t, item.CompletedAt)
+}
+
+func TestBuildQAOperatorReports(t *testing.T) {
+	good := uuid.New()
+	weak := uuid.New()
+
+	completed := func(operatorID *uuid.UUID, score int) *QAReviewItem {
+		item := NewQAReviewItem(uuid.New(), uuid.New(), operatorID)
+		item.Status = QAReviewStatusInReview
+		require.NoError(t, item.Complete(fullQAScores(score), nil))
+		return item
+	}
+
+	items := []*QAReviewItem{
+		completed(&weak, 2),
+		completed(&good, 5),
+		completed(&good, 4),
+		completed(nil, 1),
+		NewQAReviewItem(uuid.New(), uuid.New(), &weak), // still pending
+	}
+
+	reports := BuildQAOperatorReports(items)
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, good, reports[0].OperatorID)
+	assert.Equal(t, 2, reports[0].ReviewCount)
+	assert.True(t, decimal.RequireFromString("4.5").Equal(reports[0].AverageScore))
+	assert.True(t, decimal.RequireFromString("4.5").Equal(reports[0].CriterionAverages["tone"]))
+
+	assert.Equal(t, weak, reports[1].OperatorID)
+	assert.Equal(t, 1, reports[1].ReviewCount)
+}
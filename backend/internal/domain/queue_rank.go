@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ==================== QueueRank ====================
+
+// QueueRank is the materialized position of a QUEUED conversation in its
+// inbox, in allocation order. It lags the conversation by up to one refresh.
+type QueueRank struct {
+	ConversationID uuid.UUID
+	TenantID       uuid.UUID
+	InboxID        uuid.UUID
+	// 1-based; 1 is allocated next
+	Rank          int
+	PriorityScore decimal.Decimal
+	LastMessageAt time.Time
+	RefreshedAt   time.Time
+}
+
+// QueuePosition is a conversation's rank together with its queue's length
+type QueuePosition struct {
+	QueueRank
+	QueueLength int
+}
+
+// ConversationsAhead returns how many conversations are allocated before this one
+func (p *QueuePosition) ConversationsAhead() int {
+	return p.Rank - 1
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealtimeTokenSigner(t *testing.T) {
+	grant := &RealtimeGrant{
+		TenantID:   uuid.New(),
+		OperatorID: uuid.New(),
+		InboxIDs:   []uuid.UUID{uuid.New()},
+		EventTypes: []EventType{EventConversationAllocated},
+		ExpiresAt:  time.Now().UTC().Add(time.Minute).Truncate(time.Second),
+	}
+	signer := NewRealtimeTokenSigner([]byte("0123456789abcdef0123456789abcdef"))
+
+	token, err := signer.Sign(grant)
+	require.NoError(t, err)
+	verified, err := signer.Verify(token, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, grant.TenantID, verified.TenantID)
+	assert.Equal(t, grant.OperatorID, verified.OperatorID)
+	assert.Equal(t, grant.InboxIDs, verified.InboxIDs)
+	assert.Equal(t, grant.EventTypes, verified.EventTypes)
+	assert.True(t, grant.ExpiresAt.Equal(verified.ExpiresAt))
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := signer.Verify(token, grant.ExpiresAt)
+		assert.ErrorIs(t, err, ErrRealtimeTokenExpired)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		other := NewRealtimeTokenSigner([]byte("fedcba9876543210fedcba9876543210"))
+		_, err := other.Verify(token, time.Now())
+		assert.ErrorIs(t, err, ErrRealtimeTokenInvalid)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		payload, mac, _ := strings.Cut(token, ".")
+		tampered := []byte(payload)
+		tampered[len(tampered)-2] ^= 1
+		_, err := signer.Verify(string(tampered)+"."+mac, time.Now())
+		assert.ErrorIs(t, err, ErrRealtimeTokenInvalid)
+	})
+
+	t.Run("without expiry", func(t *testing.T) {
+		token, err := signer.Sign(&RealtimeGrant{TenantID: grant.TenantID})
+		require.NoError(t, err)
+		_, err = signer.Verify(token, time.Now())
+		assert.ErrorIs(t, err, ErrRealtimeTokenInvalid, "only streams authenticated otherwise may not expire")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "!!!.???"} {
+			_, err := signer.Verify(token, time.Now())
+			assert.ErrorIs(t, err, ErrRealtimeTokenInvalid, token)
+		}
+	})
+}
+
+func TestRealtimeGrant_AllowsEventType(t *testing.T) {
+	all := &RealtimeGrant{}
+	assert.True(t, all.AllowsEventType(EventConversationResolved))
+	assert.False(t, all.AllowsEventType(EventOperatorStatusChanged), "only conversation events are streamed")
+
+	scoped := &RealtimeGrant{EventTypes: []EventType{EventConversationAllocated}}
+	assert.True(t, scoped.AllowsEventType(EventConversationAllocated))
+	assert.False(t, scoped.AllowsEventType(EventConversationResolved))
+}
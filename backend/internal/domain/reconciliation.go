@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxReconciliationDivergences caps the divergences a report lists; the
+// counts cover all of them
+const MaxReconciliationDivergences = 1000
+
+// ==================== UpstreamStatus ====================
+
+// UpstreamStatus is a conversation's status in the upstream system of record
+type UpstreamStatus string
+
+const (
+	UpstreamStatusOpen   UpstreamStatus = "OPEN"
+	UpstreamStatusClosed UpstreamStatus = "CLOSED"
+	// UpstreamStatusMissing: upstream does not know the conversation
+	UpstreamStatusMissing UpstreamStatus = "MISSING"
+)
+
+// ==================== DivergenceKind ====================
+
+// DivergenceKind names a way a conversation's state can disagree with the
+// upstream system of record
+type DivergenceKind string
+
+const (
+	// DivergenceResolvedUpstreamOpen: the conversation is RESOLVED here but
+	// still open upstream
+	DivergenceResolvedUpstreamOpen DivergenceKind = "RESOLVED_UPSTREAM_OPEN"
+	// DivergenceOpenUpstreamClosed: the conversation is QUEUED or ALLOCATED
+	// here but closed upstream
+	DivergenceOpenUpstreamClosed DivergenceKind = "OPEN_UPSTREAM_CLOSED"
+	// DivergenceMissingUpstream: upstream does not know the conversation
+	DivergenceMissingUpstream DivergenceKind = "MISSING_UPSTREAM"
+)
+
+// DivergenceKinds lists every detected divergence
+var DivergenceKinds = []DivergenceKind{
+	DivergenceResolvedUpstreamOpen,
+	DivergenceOpenUpstreamClosed,
+	DivergenceMissingUpstream,
+}
+
+func (k DivergenceKind) IsValid() bool {
+	for _, kind := range DivergenceKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Correctable reports whether reconciliation can bring a conversation in
+// the given state in line with upstream: a resolved conversation open
+// upstream is reopened, an allocated one closed upstream is resolved. A
+// queued conversation cannot be resolved without an operator, and a
+// conversation upstream does not know takes a person to look at.
+func (k DivergenceKind) Correctable(state ConversationState) bool {
+	switch k {
+	case DivergenceResolvedUpstreamOpen:
+		return state == ConversationStateResolved
+	case DivergenceOpenUpstreamClosed:
+		return state == ConversationStateAllocated
+	default:
+		return false
+	}
+}
+
+// DetectDivergence compares a conversation's state with its upstream status
+// and returns the divergence, if any
+func DetectDivergence(state ConversationState, upstream UpstreamStatus) (DivergenceKind, bool) {
+	switch {
+	case upstream == UpstreamStatusMissing:
+		return DivergenceMissingUpstream, true
+	case state == ConversationStateResolved && upstream == UpstreamStatusOpen:
+		return DivergenceResolvedUpstreamOpen, true
+	case state != ConversationStateResolved && upstream == UpstreamStatusClosed:
+		return DivergenceOpenUpstreamClosed, true
+	default:
+		return "", false
+	}
+}
+
+// ==================== ReconciliationCandidate ====================
+
+// ReconciliationCandidate is a conversation to compare with upstream
+type ReconciliationCandidate struct {
+	ConversationID         uuid.UUID
+	ExternalConversationID string
+	State                  ConversationState
+}
+
+// ==================== ReconciliationDivergence ====================
+
+// ReconciliationDivergence is a conversation disagreeing with upstream, as
+// last seen by reconciliation
+type ReconciliationDivergence struct {
+	ConversationID         uuid.UUID
+	TenantID               uuid.UUID
+	ExternalConversationID string
+	InboxID                uuid.UUID
+	Kind                   DivergenceKind
+	LocalState             ConversationState
+	UpstreamStatus         UpstreamStatus
+	// FirstDetectedAt is kept while the divergence persists with the same kind
+	FirstDetectedAt time.Time
+	LastCheckedAt   time.Time
+	// CorrectedAt is set once reconciliation has corrected the conversation
+	CorrectedAt *time.Time
+}
+
+// DueForCorrection reports whether auto-correction may correct the
+// divergence at now: it has persisted for at least settle and has not been
+// corrected yet
+func (d *ReconciliationDivergence) DueForCorrection(now time.Time, settle time.Duration) bool {
+	return d.CorrectedAt == nil &&
+		d.Kind.Correctable(d.LocalState) &&
+		!d.FirstDetectedAt.Add(settle).After(now)
+}
+
+// ==================== ReconciliationReport ====================
+
+// ReconciliationReport lists a tenant's open divergences
+type ReconciliationReport struct {
+	TenantID    uuid.UUID
+	GeneratedAt time.Time
+	// Counts has every kind, also when Divergences is capped or filtered
+	Counts      map[DivergenceKind]int
+	Divergences []*ReconciliationDivergence
+}
+
+func NewReconciliationReport(tenantID uuid.UUID) *ReconciliationReport {
+	counts := make(map[DivergenceKind]int, len(DivergenceKinds))
+	for _, kind := range DivergenceKinds {
+		counts[kind] = 0
+	}
+	return &ReconciliationReport{
+		TenantID:    tenantID,
+		GeneratedAt: time.Now().UTC(),
+		Counts:      counts,
+		Divergences: []*ReconciliationDivergence{},
+	}
+}
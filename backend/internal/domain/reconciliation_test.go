@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectDivergence(t *testing.T) {
+	tests := []struct {
+		state    ConversationState
+		upstream UpstreamStatus
+		want     DivergenceKind
+	}{
+		{ConversationStateResolved, UpstreamStatusOpen, DivergenceResolvedUpstreamOpen},
+		{ConversationStateResolved, UpstreamStatusClosed, ""},
+		{ConversationStateQueued, UpstreamStatusClosed, DivergenceOpenUpstreamClosed},
+		{ConversationStateAllocated, UpstreamStatusClosed, DivergenceOpenUpstreamClosed},
+		{ConversationStateAllocated, UpstreamStatusOpen, ""},
+		{ConversationStateQueued, UpstreamStatusMissing, DivergenceMissingUpstream},
+		{ConversationStateResolved, UpstreamStatusMissing, DivergenceMissingUpstream},
+	}
+
+	for _, tt := range tests {
+		kind, diverges := DetectDivergence(tt.state, tt.upstream)
+		assert.Equal(t, tt.want, kind, "%s/%s", tt.state, tt.upstream)
+		assert.Equal(t, tt.want != "", diverges, "%s/%s", tt.state, tt.upstream)
+	}
+}
+
+func TestDivergenceKind_Correctable(t *testing.T) {
+	assert.True(t, DivergenceResolvedUpstreamOpen.Correctable(ConversationStateResolved))
+	assert.True(t, DivergenceOpenUpstreamClosed.Correctable(ConversationStateAllocated))
+	assert.False(t, DivergenceOpenUpstreamClosed.Correctable(ConversationStateQueued), "queued conversations cannot be resolved")
+	assert.False(t, DivergenceMissingUpstream.Correctable(ConversationStateQueued))
+
+	for _, kind := range DivergenceKinds {
+		assert.True(t, kind.IsValid(), kind)
+	}
+	assert.False(t, DivergenceKind("UNKNOWN").IsValid())
+}
+
+func TestReconciliationDivergence_DueForCorrection(t *testing.T) {
+	now := time.Now().UTC()
+	d := &ReconciliationDivergence{
+		Kind:            DivergenceResolvedUpstreamOpen,
+		LocalState:      ConversationStateResolved,
+		FirstDetectedAt: now.Add(-10 * time.Minute),
+	}
+
+	assert.True(t, d.DueForCorrection(now, 10*time.Minute))
+	assert.False(t, d.DueForCorrection(now, 15*time.Minute), "not settled yet")
+
+	corrected := now.Add(-time.Minute)
+	d.CorrectedAt = &corrected
+	assert.False(t, d.DueForCorrection(now, 0), "already corrected")
+
+	d.CorrectedAt = nil
+	d.Kind = DivergenceMissingUpstream
+	assert.False(t, d.DueForCorrection(now, 0), "not correctable")
+}
@@ -2,8 +2,10 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // ==================== TenantRepository ====================
@@ -14,6 +16,7 @@ type TenantRepository interface {
 	GetByName(ctx context.Context, name string) (*Tenant, error)
 	Update(ctx context.Context, tenant *Tenant) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context) ([]*Tenant, error)
 }
 
 // ==================== InboxRepository ====================
@@ -25,6 +28,9 @@ type InboxRepository interface {
 	GetByPhoneNumber(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (*Inbox, error)
 	Update(ctx context.Context, inbox *Inbox) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByTenantID deletes every inbox of the tenant; their
+	// conversations, labels and subscriptions go with them
+	DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) error
 }
 
 // ==================== OperatorRepository ====================
@@ -42,6 +48,8 @@ type OperatorRepository interface {
 
 type OperatorInboxSubscriptionRepository interface {
 	Create(ctx context.Context, subscription *OperatorInboxSubscription) error
+	// Insert unless the operator is already subscribed to the inbox; false if they were
+	CreateIfNotExists(ctx context.Context, subscription *OperatorInboxSubscription) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*OperatorInboxSubscription, error)
 	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*OperatorInboxSubscription, error)
 	GetByInboxID(ctx context.Context, inboxID uuid.UUID) ([]*OperatorInboxSubscription, error)
@@ -54,6 +62,54 @@ type OperatorInboxSubscriptionRepository interface {
 	IsSubscribed(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error)
 }
 
+// ==================== OperatorShadowRepository ====================
+
+type OperatorShadowRepository interface {
+	Create(ctx context.Context, shadow *OperatorShadow) error
+	GetByID(ctx context.Context, id uuid.UUID) (*OperatorShadow, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*OperatorShadow, error)
+	// Returns the operators the trainee is shadowing
+	GetMentorIDs(ctx context.Context, traineeID uuid.UUID) ([]uuid.UUID, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ==================== OperatorPresenceRepository ====================
+
+type OperatorPresenceRepository interface {
+	// Refreshes the operator's presence; true when they were not present
+	Touch(ctx context.Context, presence *OperatorPresence) (bool, error)
+	// Deletes and returns presence last seen before the cutoff
+	ClaimStale(ctx context.Context, cutoff time.Time) ([]*OperatorPresence, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*OperatorPresence, error)
+}
+
+// ==================== OperatorVacationRepository ====================
+
+type OperatorVacationRepository interface {
+	// Starts the vacation or replaces the drain plan of the running one,
+	// returning it as stored
+	Upsert(ctx context.Context, vacation *OperatorVacation) (*OperatorVacation, error)
+	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*OperatorVacation, error)
+	// Locks vacations still draining using FOR UPDATE SKIP LOCKED
+	GetAndLockDraining(ctx context.Context, limit int) ([]*OperatorVacation, error)
+	// Writes DrainedAt and NextColleague
+	UpdateDrain(ctx context.Context, vacation *OperatorVacation) error
+	Delete(ctx context.Context, operatorID uuid.UUID) error
+}
+
+// ==================== OperatorScheduleRepository ====================
+
+type OperatorScheduleRepository interface {
+	Create(ctx context.Context, schedule *OperatorSchedule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*OperatorSchedule, error)
+	// Returns the operator's windows ordered by day and start time
+	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*OperatorSchedule, error)
+	// Returns the windows of every AVAILABLE operator that has a schedule
+	GetOfAvailableOperators(ctx context.Context) ([]*OperatorSchedule, error)
+	Update(ctx context.Context, schedule *OperatorSchedule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
 // ==================== OperatorStatusRepository ====================
 
 type OperatorStatusRepository interface {
@@ -61,6 +117,20 @@ type OperatorStatusRepository interface {
 	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*OperatorStatus, error)
 	Update(ctx context.Context, status *OperatorStatus) error
 	GetAvailableOperators(ctx context.Context, tenantID uuid.UUID) ([]*OperatorStatus, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*OperatorStatus, error)
+	// Counts the tenant's operators per status
+	CountByStatus(ctx context.Context, tenantID uuid.UUID) (map[OperatorStatusType]int, error)
+}
+
+// ==================== OperatorAllocationHealthRepository ====================
+
+type OperatorAllocationHealthRepository interface {
+	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*OperatorAllocationHealth, error)
+	ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*OperatorAllocationHealth, error)
+	// SaveWeight and SaveOverride each write only their own columns, so the
+	// health worker and a manager never overwrite each other
+	SaveWeight(ctx context.Context, health *OperatorAllocationHealth) error
+	SaveOverride(ctx context.Context, health *OperatorAllocationHealth) error
 }
 
 // ==================== ConversationRefRepository ====================
@@ -77,21 +147,98 @@ type ConversationFilter struct {
 
 type ConversationRefRepository interface {
 	Create(ctx context.Context, conv *ConversationRef) error
+	// Insert unless the external conversation ID is already tracked; false if it was
+	CreateIfNotExists(ctx context.Context, conv *ConversationRef) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*ConversationRef, error)
 	GetByExternalID(ctx context.Context, tenantID uuid.UUID, externalID string) (*ConversationRef, error)
 	GetByFilter(ctx context.Context, filter ConversationFilter) ([]*ConversationRef, error)
 	SearchByPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) ([]*ConversationRef, error)
+	// Whether the tenant has any conversation with the phone number (first-contact detection)
+	HasConversationWithPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (bool, error)
+	// CustomerIntakeSince counts the conversations the phone number started in
+	// the inbox since the given time, and returns when the oldest started
+	CustomerIntakeSince(ctx context.Context, tenantID, inboxID uuid.UUID, phoneNumber string, since time.Time) (int64, *time.Time, error)
 	Update(ctx context.Context, conv *ConversationRef) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Allocation-specific methods (with locking)
 	// Returns the next available conversation for allocation using FOR UPDATE SKIP LOCKED
-	GetNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, limit int) ([]*ConversationRef, error)
+	GetNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, limit int) ([]*ConversationRef, error)
+	GetNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time, limit int) ([]*ConversationRef, error)
+	// Peek the conversation the allocation queries would return first,
+	// without locking it; ErrNotFound when none is queued
+	PeekNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string) (*ConversationRef, error)
+	PeekNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time) (*ConversationRef, error)
+	// The same for the v2 engine (AllocationEngineV2); a nil starvedBefore
+	// and no preferred labels leave category quotas out
+	GetNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time, limit int) ([]*ConversationRef, error)
+	PeekNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time) (*ConversationRef, error)
+	// Conversations carrying one of the labels, in the order of the v1
+	// engine or, with breachFirst, of the v2 engine, using FOR UPDATE SKIP LOCKED
+	GetNextForAllocationByLabels(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, labelIDs []uuid.UUID, breachFirst bool, limit int) ([]*ConversationRef, error)
+	// Queued conversations of the customers the operator resolved last since
+	// the given time, in allocation order, using FOR UPDATE SKIP LOCKED
+	GetNextAffineForAllocation(ctx context.Context, tenantID, operatorID uuid.UUID, inboxIDs []uuid.UUID, languages []string, since time.Time, limit int) ([]*ConversationRef, error)
 	// Lock a specific conversation for claim
 	LockForClaim(ctx context.Context, id uuid.UUID) (*ConversationRef, error)
+	// Lock a specific conversation for in-place updates regardless of state
+	LockForUpdate(ctx context.Context, id uuid.UUID) (*ConversationRef, error)
+	// Lock a conversation by its external ID for in-place updates
+	LockByExternalID(ctx context.Context, tenantID uuid.UUID, externalID string) (*ConversationRef, error)
 
 	// Bulk operations
 	GetByOperatorID(ctx context.Context, tenantID, operatorID uuid.UUID, state *ConversationState) ([]*ConversationRef, error)
+	// IDs of the inbox's QUEUED and ALLOCATED conversations, oldest first
+	GetOpenIDsByInbox(ctx context.Context, tenantID, inboxID uuid.UUID, limit int) ([]uuid.UUID, error)
+
+	// Conversations created per hour since the given time, keyed by the UTC
+	// hour start; inboxID nil counts the whole tenant
+	CountCreatedByHour(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time) (map[time.Time]int, error)
+	// Conversations of the inbox waiting for allocation, snoozed ones excluded
+	CountQueuedByInbox(ctx context.Context, inboxID uuid.UUID) (int, error)
+	// Conversations of every inbox of the tenant by state, with the average
+	// resolution time of those resolved since resolvedSince and the open ones
+	// past their due date at now
+	CountByInboxAndState(ctx context.Context, tenantID uuid.UUID, resolvedSince, now time.Time) ([]InboxConversationStats, error)
+
+	// SLA tracking
+	// Sets sla_breached_at on open conversations past a target of their inbox's policy
+	MarkSLABreaches(ctx context.Context, now time.Time) ([]*SLABreach, error)
+	// Raises QUEUED conversations past the ratio of a target to at least
+	// priority; returns the inboxes touched
+	BoostNearSLABreach(ctx context.Context, now time.Time, ratio float64, priority decimal.Decimal) ([]uuid.UUID, error)
+	// Conversations breached since the given time, newest breach first;
+	// inboxID nil lists the whole tenant
+	ListSLABreaches(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time, limit int) ([]*ConversationRef, error)
+
+	// Priority override
+	// Writes PriorityOverride, which Update leaves alone
+	SetPriorityOverride(ctx context.Context, conv *ConversationRef) error
+
+	// Snooze
+	// Writes SnoozedUntil and SnoozeOperatorID, which Update leaves alone
+	SetSnooze(ctx context.Context, conv *ConversationRef) error
+	// Locks QUEUED conversations whose snooze ended using FOR UPDATE SKIP LOCKED
+	GetAndLockEndedSnoozes(ctx context.Context, now time.Time, limit int) ([]*ConversationRef, error)
+
+	// Due dates
+	// Writes DueAt and clears OverdueAt, which Update leaves alone
+	SetDueAt(ctx context.Context, conv *ConversationRef) error
+	// Writes OverdueAt, which Update leaves alone
+	SetOverdue(ctx context.Context, conv *ConversationRef) error
+	// Locks open conversations past their due date not flagged overdue yet
+	// using FOR UPDATE SKIP LOCKED, earliest due first
+	GetAndLockOverdue(ctx context.Context, now time.Time, limit int) ([]*ConversationRef, error)
+
+	// Auto-resolution
+	// Locks open, unsnoozed conversations idle past their inbox's
+	// AutoResolveAfter using FOR UPDATE SKIP LOCKED, stalest first
+	GetAndLockStale(ctx context.Context, now time.Time, limit int) ([]*ConversationRef, error)
+
+	// Vacation drain
+	// Locks the operator's ALLOCATED conversations, most urgent first, using
+	// FOR UPDATE SKIP LOCKED
+	GetAndLockAllocatedByOperator(ctx context.Context, operatorID uuid.UUID) ([]*ConversationRef, error)
 }
 
 // ==================== LabelRepository ====================
@@ -99,10 +246,19 @@ type ConversationRefRepository interface {
 type LabelRepository interface {
 	Create(ctx context.Context, label *Label) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Label, error)
+	// GetByInboxID includes the tenant-scoped labels, which apply to every inbox
 	GetByInboxID(ctx context.Context, tenantID, inboxID uuid.UUID) ([]*Label, error)
 	GetByName(ctx context.Context, inboxID uuid.UUID, name string) (*Label, error)
+	GetTenantScoped(ctx context.Context, tenantID uuid.UUID) ([]*Label, error)
+	GetTenantScopedByName(ctx context.Context, tenantID uuid.UUID, name string) (*Label, error)
+	GetOrCreateByName(ctx context.Context, tenantID, inboxID uuid.UUID, name string) (*Label, error)
+	LockForUpdate(ctx context.Context, id uuid.UUID) (*Label, error)
 	Update(ctx context.Context, label *Label) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	CreateVersion(ctx context.Context, version *LabelVersion) error
+	ListVersions(ctx context.Context, labelID uuid.UUID) ([]*LabelVersion, error)
+	// GetDescendantIDs returns the labels and every label nested below them
+	GetDescendantIDs(ctx context.Context, labelIDs []uuid.UUID) ([]uuid.UUID, error)
 }
 
 // ==================== ConversationLabelRepository ====================
@@ -110,10 +266,16 @@ type LabelRepository interface {
 type ConversationLabelRepository interface {
 	Create(ctx context.Context, cl *ConversationLabel) error
 	GetByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*ConversationLabel, error)
+	// GetLabels returns the labels attached to the conversation
+	GetLabels(ctx context.Context, conversationID uuid.UUID) ([]*Label, error)
+	// GetLabelsForConversations returns the labels attached to each of the
+	// conversations in one query
+	GetLabelsForConversations(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID][]*Label, error)
 	GetByLabelID(ctx context.Context, labelID uuid.UUID) ([]*ConversationLabel, error)
 	Delete(ctx context.Context, conversationID, labelID uuid.UUID) error
 	DeleteAllForConversation(ctx context.Context, conversationID uuid.UUID) error
 	Exists(ctx context.Context, conversationID, labelID uuid.UUID) (bool, error)
+	CountByVersion(ctx context.Context, tenantID, inboxID uuid.UUID, since, until time.Time) ([]LabelAttachmentCount, error)
 }
 
 // ==================== GracePeriodAssignmentRepository ====================
@@ -129,24 +291,532 @@ type GracePeriodAssignmentRepository interface {
 
 	// For worker: get and lock expired assignments
 	GetAndLockExpired(ctx context.Context, limit int) ([]*GracePeriodAssignment, error)
+
+	// GetBacklog counts the expired grace periods not yet processed
+	GetBacklog(ctx context.Context) (*GracePeriodBacklog, error)
+
+	// GetByID returns the tenant's grace period, ErrNotFound for another tenant's
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*GracePeriodAssignment, error)
+	List(ctx context.Context, filter GracePeriodFilter) ([]*GracePeriodAssignment, error)
+	// SetExpiry moves the expiry of a grace period that has not expired yet,
+	// and returns ErrNotFound once it has
+	SetExpiry(ctx context.Context, id uuid.UUID, expiresAt time.Time) error
+}
+
+// GracePeriodFilter selects the grace periods of a tenant
+type GracePeriodFilter struct {
+	TenantID       uuid.UUID
+	OperatorID     *uuid.UUID
+	ConversationID *uuid.UUID
+	Limit          int
 }
 
 // ==================== IdempotencyRepository ====================
 
-// IdempotencyRepository handles idempotency key storage
+// IdempotencyRepository handles idempotency key storage. Postgres is the
+// default; stores that expire keys natively (Redis) have nothing to clean up
+// or re-encrypt.
 type IdempotencyRepository interface {
 	// Create stores a new idempotency key
 	Create(ctx context.Context, ik *IdempotencyKey) error
 
+	// Claim stores the PENDING claim ik of its key unless an unexpired key
+	// holds it: a COMPLETED one, or a claim before its LockedUntil. An
+	// expired key or stale claim is taken over. Returns whether ik holds the
+	// key.
+	Claim(ctx context.Context, ik *IdempotencyKey) (bool, error)
+
+	// Complete stores the response of the PENDING claim ik and marks it
+	// COMPLETED; ErrNotFound when the claim was taken over
+	Complete(ctx context.Context, ik *IdempotencyKey) error
+
 	// GetByKey retrieves an idempotency key by tenant and key
 	GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*IdempotencyKey, error)
 
-	// Delete removes an idempotency key
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Release removes the PENDING claim ik; nothing once it was taken over
+	// or completed
+	Release(ctx context.Context, ik *IdempotencyKey) error
 
 	// DeleteExpired removes all expired idempotency keys
 	DeleteExpired(ctx context.Context) (int64, error)
 
 	// GetExpiredForCleanup gets expired keys with lock for distributed cleanup
 	GetExpiredForCleanup(ctx context.Context, limit int) ([]*IdempotencyKey, error)
+
+	// GetToReencrypt gets unexpired keys whose response is not stored under
+	// encryptionKeyID (nil: not in plaintext), after the cursor in id order
+	GetToReencrypt(ctx context.Context, encryptionKeyID *string, after uuid.UUID, limit int) ([]*IdempotencyKey, error)
+
+	// UpdateResponse replaces the stored response body and its encryption
+	UpdateResponse(ctx context.Context, ik *IdempotencyKey) error
+}
+
+// ==================== WebhookRepository ====================
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*Webhook, error)
+	// Returns active webhooks of the tenant subscribed to the event type
+	GetActiveForEvent(ctx context.Context, tenantID uuid.UUID, eventType EventType) ([]*Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ==================== WebhookDeliveryRepository ====================
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	GetByWebhookID(ctx context.Context, webhookID uuid.UUID, limit int) ([]*WebhookDelivery, error)
+	UpdateAttempt(ctx context.Context, delivery *WebhookDelivery) error
+
+	// For worker: lease due deliveries so concurrent workers skip them
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*WebhookDelivery, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, entry *OutboxEntry) error
+	MarkPublished(ctx context.Context, eventID uuid.UUID, at time.Time) error
+	UpdateAttempt(ctx context.Context, entry *OutboxEntry) error
+	CountPending(ctx context.Context) (int64, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// For worker: lease due entries so concurrent workers skip them
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*OutboxEntry, error)
+}
+
+// ==================== RoutingRuleRepository ====================
+
+type RoutingRuleRepository interface {
+	Create(ctx context.Context, rule *RoutingRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*RoutingRule, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*RoutingRule, error)
+	// Returns active tenant-wide rules plus rules scoped to the inbox
+	GetActiveForTrigger(ctx context.Context, tenantID, inboxID uuid.UUID, trigger RoutingRuleTrigger) ([]*RoutingRule, error)
+	Update(ctx context.Context, rule *RoutingRule) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ==================== AuditLogRepository ====================
+
+type AuditLogFilter struct {
+	TenantID   uuid.UUID
+	ActorID    *uuid.UUID
+	EntityType *AuditEntityType
+	EntityID   *uuid.UUID
+	Action     *AuditAction
+	Actions    []AuditAction // matches any of the actions; ignored when empty
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+
+	// Keyset pagination: entries strictly older than (CursorTimestamp, CursorID)
+	CursorTimestamp *time.Time
+	CursorID        *uuid.UUID
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *AuditEntry) error
+	// Returns matching entries, newest first
+	List(ctx context.Context, filter AuditLogFilter) ([]*AuditEntry, error)
+	// Returns the tenant's entries for one action since the given time, oldest first
+	ListByAction(ctx context.Context, tenantID uuid.UUID, action AuditAction, since time.Time) ([]*AuditEntry, error)
+	// Counts the tenant's entries for one action in [from, to)
+	CountByAction(ctx context.Context, tenantID uuid.UUID, action AuditAction, from, to time.Time) (int, error)
+	// Counts, per operator, resolutions since the given time that the assigned
+	// operator made within the given duration of the latest assignment
+	CountFastResolves(ctx context.Context, tenantID uuid.UUID, since time.Time, within time.Duration) (map[uuid.UUID]int, error)
+	// Counts allocations and claims of the inbox's conversations since the given time
+	CountInboxAllocations(ctx context.Context, tenantID, inboxID uuid.UUID, since time.Time) (int, error)
+	CountInboxAllocationsByLabel(ctx context.Context, tenantID, inboxID uuid.UUID, labelIDs []uuid.UUID, since time.Time) (map[uuid.UUID]int, error)
+	// Counts allocations and claims per inbox since the given time
+	CountAllocationsByInbox(ctx context.Context, tenantID uuid.UUID, since time.Time) (map[uuid.UUID]int, error)
+	// Counts, per operator, conversations assigned to them and deallocations
+	// of conversations they held since the given time
+	CountOperatorOutcomes(ctx context.Context, tenantID uuid.UUID, since time.Time) (map[uuid.UUID]OperatorAllocationOutcome, error)
+	LastAllocationAt(ctx context.Context, tenantID, operatorID uuid.UUID) (time.Time, error)
+}
+
+// ==================== InboxAdminRepository ====================
+
+type InboxAdminRepository interface {
+	Create(ctx context.Context, grant *InboxAdmin) error
+	Exists(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error)
+	GetByInboxID(ctx context.Context, inboxID uuid.UUID) ([]*InboxAdmin, error)
+	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*InboxAdmin, error)
+	Delete(ctx context.Context, operatorID, inboxID uuid.UUID) error
+}
+
+// ==================== InboxStalenessPolicyRepository ====================
+
+type InboxStalenessPolicyRepository interface {
+	Get(ctx context.Context, inboxID uuid.UUID) (*InboxStalenessPolicy, error)
+	Upsert(ctx context.Context, policy *InboxStalenessPolicy) error
+	Delete(ctx context.Context, inboxID uuid.UUID) error
+}
+
+// ==================== ConversationActivityRepository ====================
+
+type ConversationActivityRepository interface {
+	Get(ctx context.Context, conversationID uuid.UUID) (*ConversationActivity, error)
+	// Record upserts the activity, clearing the stale flag
+	Record(ctx context.Context, activity *ConversationActivity) error
+	// StartClocks records an ALLOCATED activity at now for the allocated
+	// conversations of inboxes with a staleness policy that have none, or
+	// one of another operator
+	StartClocks(ctx context.Context, now time.Time) (int64, error)
+	// Prune deletes the activity of conversations no longer allocated
+	Prune(ctx context.Context) (int64, error)
+	// GetAndLockStale returns the activities of allocated conversations idle
+	// past their inbox's limit and not flagged yet, using FOR UPDATE SKIP
+	// LOCKED, idlest first
+	GetAndLockStale(ctx context.Context, now time.Time, limit int) ([]*ConversationActivity, error)
+	MarkStale(ctx context.Context, conversationID uuid.UUID, at time.Time) error
+	// CountStale counts the flagged conversations still allocated
+	CountStale(ctx context.Context) (int64, error)
+}
+
+// ==================== InboxSLAPolicyRepository ====================
+
+type InboxSLAPolicyRepository interface {
+	Get(ctx context.Context, inboxID uuid.UUID) (*InboxSLAPolicy, error)
+	// Upsert creates or replaces the inbox's policy
+	Upsert(ctx context.Context, policy *InboxSLAPolicy) error
+	Delete(ctx context.Context, inboxID uuid.UUID) error
+}
+
+// ==================== CategoryQuotaRepository ====================
+
+type CategoryQuotaRepository interface {
+	ListByInbox(ctx context.Context, inboxID uuid.UUID) ([]*CategoryQuota, error)
+	ListByInboxIDs(ctx context.Context, inboxIDs []uuid.UUID) ([]*CategoryQuota, error)
+	Create(ctx context.Context, quota *CategoryQuota) error
+	DeleteByInbox(ctx context.Context, inboxID uuid.UUID) error
+}
+
+// ==================== ChecklistRepository ====================
+
+type ChecklistRepository interface {
+	GetTemplate(ctx context.Context, inboxID uuid.UUID) (*ChecklistTemplate, error)
+	// Creates or replaces the inbox's template
+	SaveTemplate(ctx context.Context, template *ChecklistTemplate) error
+	DeleteTemplate(ctx context.Context, inboxID uuid.UUID) error
+	// Copies their inbox templates onto the conversations that have no
+	// checklist or pinned note yet; returns the items added
+	Instantiate(ctx context.Context, conversationIDs []uuid.UUID) (int64, error)
+	ListItems(ctx context.Context, conversationID uuid.UUID) ([]*ChecklistItem, error)
+	GetItem(ctx context.Context, id uuid.UUID) (*ChecklistItem, error)
+	UpdateItemCompletion(ctx context.Context, item *ChecklistItem) error
+	GetStatus(ctx context.Context, conversationID uuid.UUID) (ChecklistStatus, error)
+}
+
+// ==================== QAReviewerRepository ====================
+
+type QAReviewerRepository interface {
+	Create(ctx context.Context, reviewer *QAReviewer) error
+	Exists(ctx context.Context, operatorID uuid.UUID) (bool, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*QAReviewer, error)
+	Delete(ctx context.Context, operatorID uuid.UUID) error
+}
+
+// ==================== QAReviewItemRepository ====================
+
+type QAReviewItemRepository interface {
+	Create(ctx context.Context, item *QAReviewItem) error
+	GetByID(ctx context.Context, id uuid.UUID) (*QAReviewItem, error)
+	// Returns the item the reviewer currently holds an unexpired lease on
+	GetActiveForReviewer(ctx context.Context, reviewerID uuid.UUID) (*QAReviewItem, error)
+	// Leases the oldest open item to the reviewer (FOR UPDATE SKIP LOCKED)
+	ClaimNext(ctx context.Context, tenantID, reviewerID uuid.UUID, claimedUntil time.Time) (*QAReviewItem, error)
+	// Persists a completed review; returns false if the reviewer no longer holds the item
+	Complete(ctx context.Context, item *QAReviewItem) (bool, error)
+	// Returns items completed in [from, to)
+	GetCompleted(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*QAReviewItem, error)
+}
+
+// ==================== BackfillRepository ====================
+
+type BackfillRepository interface {
+	// Register inserts the backfill unless it is already tracked
+	Register(ctx context.Context, b *Backfill) error
+	List(ctx context.Context) ([]*Backfill, error)
+	// LockPending locks an unfinished backfill, skipping it if another
+	// instance holds the lock (returns ErrNotFound)
+	LockPending(ctx context.Context, name string) (*Backfill, error)
+	UpdateProgress(ctx context.Context, b *Backfill) error
+}
+
+// ==================== TenantClassifierRepository ====================
+
+type TenantClassifierRepository interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*TenantClassifier, error)
+	// Upsert creates or replaces the tenant's classifier configuration
+	Upsert(ctx context.Context, c *TenantClassifier) error
+}
+
+// ==================== TenantAnomalySettingsRepository ====================
+
+type TenantAnomalySettingsRepository interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*TenantAnomalySettings, error)
+	// Upsert creates or replaces the tenant's anomaly settings
+	Upsert(ctx context.Context, settings *TenantAnomalySettings) error
+	// ListSensitivities returns every tenant's sensitivity, the default for
+	// tenants without settings
+	ListSensitivities(ctx context.Context) (map[uuid.UUID]AnomalySensitivity, error)
+}
+
+// ==================== TenantMaintenanceSettingsRepository ====================
+
+type TenantMaintenanceSettingsRepository interface {
+	Get(ctx context.Context, tenantID uuid.UUID) (*TenantMaintenanceSettings, error)
+	// Upsert creates or replaces the tenant's settings
+	Upsert(ctx context.Context, settings *TenantMaintenanceSettings) error
+}
+
+// ==================== AnomalyRepository ====================
+
+type AnomalyRepository interface {
+	Create(ctx context.Context, anomaly *Anomaly) error
+	// ExistsSince reports whether an anomaly of the kind was flagged for the
+	// operator (tenant-wide when nil) after the given time
+	ExistsSince(ctx context.Context, tenantID uuid.UUID, kind AnomalyKind, operatorID *uuid.UUID, since time.Time) (bool, error)
+	// Returns the tenant's anomalies detected since the given time, newest first
+	List(ctx context.Context, tenantID uuid.UUID, since time.Time, limit int) ([]*Anomaly, error)
+}
+
+// ==================== APIKeyRepository ====================
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*APIKey, error)
+	Revoke(ctx context.Context, key *APIKey) error
+	// Touch records the time of the last authenticated request
+	Touch(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
+
+// ==================== OperatorDeviceRepository ====================
+
+type OperatorDeviceRepository interface {
+	// Upsert registers the device, or moves an already registered token to
+	// the device's operator, and returns the stored device
+	Upsert(ctx context.Context, device *OperatorDevice) (*OperatorDevice, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*OperatorDevice, error)
+	GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*OperatorDevice, error)
+	CountByOperatorID(ctx context.Context, operatorID uuid.UUID) (int, error)
+	UpdateEventTypes(ctx context.Context, device *OperatorDevice) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByToken removes a device whose token the push service rejected
+	DeleteByToken(ctx context.Context, token string) error
+}
+
+// ==================== DeletionImpactRepository ====================
+
+// DeletionImpactRepository counts the dependents of a resource with
+// aggregate queries
+type DeletionImpactRepository interface {
+	ForInbox(ctx context.Context, inboxID uuid.UUID) (*DeletionImpact, error)
+	// ForLabel returns ErrNotFound for an unknown label
+	ForLabel(ctx context.Context, labelID uuid.UUID) (*DeletionImpact, error)
+	ForOperator(ctx context.Context, operatorID uuid.UUID) (*DeletionImpact, error)
+}
+
+// ==================== AllocationIntentRepository ====================
+
+type AllocationIntentRepository interface {
+	// Returns ErrAlreadyExists if the idempotency key is already journaled
+	Create(ctx context.Context, intent *AllocationIntent) error
+	GetByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, key string) (*AllocationIntent, error)
+	SetConversation(ctx context.Context, id, conversationID uuid.UUID) error
+	// Resolves a PENDING intent; false if it was already resolved
+	Resolve(ctx context.Context, intent *AllocationIntent) (bool, error)
+	// Takes over the ABORTED intent with the same idempotency key, setting
+	// intent.ID; ErrNotFound if there is none
+	Restart(ctx context.Context, intent *AllocationIntent) error
+	// Returns PENDING intents created before the given time, oldest first
+	GetPendingBefore(ctx context.Context, before time.Time, limit int) ([]*AllocationIntent, error)
+	DeleteResolvedBefore(ctx context.Context, before time.Time) (int64, error)
+	// Counts the tenant's ABORTED intents created in [from, to)
+	CountAborted(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (int, error)
+}
+
+// ==================== ConversationNoteRepository ====================
+
+type ConversationNoteRepository interface {
+	Create(ctx context.Context, note *ConversationNote) error
+	// Most recent note of the kind
+	GetLatest(ctx context.Context, conversationID uuid.UUID, kind ConversationNoteKind) (*ConversationNote, error)
+	// Most recent note of the kind addressed to the operator
+	GetLatestForRecipient(ctx context.Context, conversationID uuid.UUID, kind ConversationNoteKind, recipientID uuid.UUID) (*ConversationNote, error)
+}
+
+// ==================== ConversationEscalationRepository ====================
+
+type ConversationEscalationRepository interface {
+	Create(ctx context.Context, escalation *ConversationEscalation) error
+	// Counts the tenant's escalations in [from, to) per operator the
+	// conversations were taken from
+	CountByOperator(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]EscalationCount, error)
+	// Counts the tenant's escalations in [from, to) per inbox they left
+	CountByInbox(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]EscalationCount, error)
+}
+
+// ==================== PriorityScoreComponentsRepository ====================
+
+type PriorityScoreComponentsRepository interface {
+	Create(ctx context.Context, components *PriorityScoreComponents) error
+	// Newest calculation first
+	ListByConversation(ctx context.Context, conversationID uuid.UUID, limit int) ([]*PriorityScoreComponents, error)
+}
+
+// ==================== QueueRankRepository ====================
+
+type QueueRankRepository interface {
+	// RefreshInbox re-ranks the inbox's QUEUED conversations and returns how
+	// many are ranked
+	RefreshInbox(ctx context.Context, inboxID uuid.UUID) (int64, error)
+	GetByConversationID(ctx context.Context, conversationID uuid.UUID) (*QueueRank, error)
+	CountByInbox(ctx context.Context, inboxID uuid.UUID) (int, error)
+	ListByInbox(ctx context.Context, inboxID uuid.UUID, limit int) ([]*QueueRank, error)
+	// GetInboxIDsToRank returns inboxes that have queued conversations or ranks
+	GetInboxIDsToRank(ctx context.Context) ([]uuid.UUID, error)
+}
+
+// ==================== WorkerInstanceRepository ====================
+
+type WorkerInstanceRepository interface {
+	GetSchemaVersion(ctx context.Context) (*SchemaVersion, error)
+	Upsert(ctx context.Context, instance *WorkerInstance) error
+	Delete(ctx context.Context, instanceID uuid.UUID) error
+	// DeleteStale removes instances whose last heartbeat is before cutoff
+	DeleteStale(ctx context.Context, cutoff time.Time) (int64, error)
+	// NewestLiveProtocol returns the newest worker protocol among the other
+	// instances running workers with a heartbeat since cutoff, 0 for none
+	NewestLiveProtocol(ctx context.Context, instanceID uuid.UUID, cutoff time.Time) (int32, error)
+}
+
+// ==================== InvariantRepository ====================
+
+// InvariantRepository finds the tenant's conversations breaking an
+// invariant, at most limit per kind, ordered by conversation ID (duplicates
+// grouped by external ID)
+type InvariantRepository interface {
+	FindAllocatedWithoutOperator(ctx context.Context, tenantID uuid.UUID, limit int) ([]*InvariantViolation, error)
+	FindOperatorNotSubscribed(ctx context.Context, tenantID uuid.UUID, limit int) ([]*InvariantViolation, error)
+	FindResolvedWithGracePeriod(ctx context.Context, tenantID uuid.UUID, limit int) ([]*InvariantViolation, error)
+	FindDuplicateExternalIDs(ctx context.Context, tenantID uuid.UUID, limit int) ([]*InvariantViolation, error)
+}
+
+// ==================== ReconciliationRepository ====================
+
+type ReconciliationRepository interface {
+	// ListCandidates returns the tenant's conversations after afterID, by ID,
+	// updated since updatedSince or already diverging
+	ListCandidates(ctx context.Context, tenantID, afterID uuid.UUID, updatedSince time.Time, limit int) ([]*ReconciliationCandidate, error)
+	// Upsert records a divergence and returns it as stored, with the
+	// FirstDetectedAt and CorrectedAt of an earlier detection of the same kind
+	Upsert(ctx context.Context, divergence *ReconciliationDivergence) (*ReconciliationDivergence, error)
+	// DeleteByConversationIDs forgets the divergences of the conversations
+	DeleteByConversationIDs(ctx context.Context, conversationIDs []uuid.UUID) error
+	MarkCorrected(ctx context.Context, conversationID uuid.UUID, correctedAt time.Time) error
+	// List returns the tenant's divergences of the kind (empty for all),
+	// oldest first
+	List(ctx context.Context, tenantID uuid.UUID, kind DivergenceKind, limit int) ([]*ReconciliationDivergence, error)
+	// Count returns the number of the tenant's divergences per kind
+	Count(ctx context.Context, tenantID uuid.UUID) (map[DivergenceKind]int, error)
+}
+
+// ==================== PriorityExperimentRepository ====================
+
+type PriorityExperimentRepository interface {
+	Create(ctx context.Context, experiment *PriorityExperiment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*PriorityExperiment, error)
+	// GetByTenantID returns the tenant's experiments, newest first
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*PriorityExperiment, error)
+	// GetRunningByInbox returns ErrNotFound when no experiment runs in the inbox
+	GetRunningByInbox(ctx context.Context, inboxID uuid.UUID) (*PriorityExperiment, error)
+	GetRunningByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*PriorityExperiment, error)
+	Update(ctx context.Context, experiment *PriorityExperiment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Assign records the conversation's arm unless already recorded
+	Assign(ctx context.Context, experimentID, conversationID uuid.UUID, arm ExperimentArm) error
+	// MarkAllocated stamps the first allocation of the enrolled conversations
+	// among conversationIDs
+	MarkAllocated(ctx context.Context, conversationIDs []uuid.UUID, at time.Time) error
+	GetOutcomes(ctx context.Context, experimentID uuid.UUID) ([]*ExperimentArmOutcome, error)
+}
+
+// ==================== ConversationReadRepository ====================
+
+type ConversationReadRepository interface {
+	// MarkRead records the read; an earlier LastReadAt never overwrites a later one
+	MarkRead(ctx context.Context, read *ConversationRead) error
+	// GetLastReadAt returns the operator's last read of each conversation
+	// among conversationIDs; conversations never read are absent
+	GetLastReadAt(ctx context.Context, operatorID uuid.UUID, conversationIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
+}
+
+// ==================== ShareLinkRepository ====================
+
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *ShareLink) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ShareLink, error)
+	// GetByConversationID returns the conversation's links, newest first
+	GetByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*ShareLink, error)
+	Revoke(ctx context.Context, link *ShareLink) error
+	RecordAccess(ctx context.Context, id uuid.UUID, accessedAt time.Time) error
+}
+
+// ==================== ConversationTransferRepository ====================
+
+type ConversationTransferRepository interface {
+	// Create fails with ErrAlreadyExists while the conversation has a
+	// pending transfer
+	Create(ctx context.Context, transfer *ConversationTransfer) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ConversationTransfer, error)
+	// LockForUpdate locks the transfer until the transaction ends
+	LockForUpdate(ctx context.Context, id uuid.UUID) (*ConversationTransfer, error)
+	// ListPending returns the pending transfers the operator proposed or
+	// received, oldest first
+	ListPending(ctx context.Context, tenantID, operatorID uuid.UUID) ([]*ConversationTransfer, error)
+	// UpdateStatus writes Status and RespondedAt
+	UpdateStatus(ctx context.Context, transfer *ConversationTransfer) error
+	// ExpireDue marks up to limit pending transfers past their expiry at now
+	// EXPIRED and returns them, using FOR UPDATE SKIP LOCKED
+	ExpireDue(ctx context.Context, now time.Time, limit int) ([]*ConversationTransfer, error)
+}
+
+// ==================== CustomerRepository ====================
+
+// CustomerFilter selects customers for Search
+type CustomerFilter struct {
+	TenantID uuid.UUID
+	// Query matches the start of the phone number or part of the name;
+	// empty matches all customers
+	Query string
+	Limit int
+
+	// Keyset pagination: customers strictly older than (CursorTimestamp, CursorID)
+	CursorTimestamp *time.Time
+	CursorID        *uuid.UUID
+}
+
+type CustomerRepository interface {
+	// GetOrCreate returns the tenant's customer with the phone number,
+	// creating it on first contact
+	GetOrCreate(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (*Customer, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Customer, error)
+	GetByPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (*Customer, error)
+	Update(ctx context.Context, customer *Customer) error
+	// Search returns customers matching the filter, newest first
+	Search(ctx context.Context, filter CustomerFilter) ([]*Customer, error)
+	DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) error
+}
+
+type CustomerOperatorAffinityRepository interface {
+	// Record makes the operator the customer's affinity unless a later
+	// resolution is already recorded
+	Record(ctx context.Context, affinity *CustomerOperatorAffinity) error
 }
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidPhonePrefix = errors.New("phone prefix must be 1-20 digits, optionally starting with '+'")
+	ErrInvalidRuleKeyword = errors.New("keyword must be 1-50 characters")
+)
+
+// MaxRuleKeywordLength bounds the keyword of a metadata condition
+const MaxRuleKeywordLength = 50
+
+// ==================== RoutingRuleTrigger ====================
+
+type RoutingRuleTrigger string
+
+const (
+	RoutingRuleTriggerMessageReceived RoutingRuleTrigger = "MESSAGE_RECEIVED"
+	// RoutingRuleTriggerConversationCreated fires once, for the ingested
+	// message that starts a conversation, after the MESSAGE_RECEIVED rules
+	RoutingRuleTriggerConversationCreated RoutingRuleTrigger = "CONVERSATION_CREATED"
+)
+
+func (t RoutingRuleTrigger) IsValid() bool {
+	switch t {
+	case RoutingRuleTriggerMessageReceived, RoutingRuleTriggerConversationCreated:
+		return true
+	}
+	return false
+}
+
+func (t RoutingRuleTrigger) String() string {
+	return string(t)
+}
+
+// ==================== RuleConditionField ====================
+
+// RuleConditionField names the conversation attribute a rule compares
+type RuleConditionField string
+
+const (
+	RuleFieldMessageCount RuleConditionField = "message_count"
+	RuleFieldCategory     RuleConditionField = "category"
+	RuleFieldLanguage     RuleConditionField = "language"
+	// RuleFieldCustomerPhone compares the customer's phone number, eq or prefix
+	RuleFieldCustomerPhone RuleConditionField = "customer_phone"
+	// RuleFieldMetadata looks for a keyword (contains) in the values of the
+	// external metadata sent with an ingested message
+	RuleFieldMetadata RuleConditionField = "metadata"
+)
+
+func (f RuleConditionField) IsValid() bool {
+	switch f {
+	case RuleFieldMessageCount, RuleFieldCategory, RuleFieldLanguage, RuleFieldCustomerPhone, RuleFieldMetadata:
+		return true
+	}
+	return false
+}
+
+// IsText reports whether the field is compared against ConditionText
+// instead of ConditionValue
+func (f RuleConditionField) IsText() bool {
+	return f != RuleFieldMessageCount
+}
+
+// Allows reports whether the operator can compare the field: the numeric
+// operators message_count, eq category and language, eq or prefix
+// customer_phone and contains metadata
+func (f RuleConditionField) Allows(o RuleOperator) bool {
+	switch f {
+	case RuleFieldMessageCount:
+		return o.IsNumeric()
+	case RuleFieldCategory, RuleFieldLanguage:
+		return o == RuleOperatorEqual
+	case RuleFieldCustomerPhone:
+		return o == RuleOperatorEqual || o == RuleOperatorPrefix
+	case RuleFieldMetadata:
+		return o == RuleOperatorContains
+	}
+	return false
+}
+
+// NormalizeText normalizes the comparison value of a text field the way the
+// conversation attribute it is compared with is normalized
+func (f RuleConditionField) NormalizeText(raw string) (string, error) {
+	switch f {
+	case RuleFieldLanguage:
+		return NormalizeLanguage(raw)
+	case RuleFieldCustomerPhone:
+		return normalizePhonePrefix(raw)
+	case RuleFieldMetadata:
+		keyword := strings.ToLower(strings.TrimSpace(raw))
+		if keyword == "" || len(keyword) > MaxRuleKeywordLength {
+			return "", ErrInvalidRuleKeyword
+		}
+		return keyword, nil
+	}
+	return NormalizeCategory(raw)
+}
+
+// normalizePhonePrefix drops the spaces and dashes ingestion drops from
+// phone numbers and checks what is left is a phone number or its start
+func normalizePhonePrefix(raw string) (string, error) {
+	prefix := strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(raw))
+	digits := strings.TrimPrefix(prefix, "+")
+	if digits == "" || len(prefix) > 20 {
+		return "", ErrInvalidPhonePrefix
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhonePrefix
+		}
+	}
+	return prefix, nil
+}
+
+func (f RuleConditionField) String() string {
+	return string(f)
+}
+
+// ==================== RuleOperator ====================
+
+type RuleOperator string
+
+const (
+	RuleOperatorGreaterThan        RuleOperator = "gt"
+	RuleOperatorGreaterThanOrEqual RuleOperator = "gte"
+	RuleOperatorLessThan           RuleOperator = "lt"
+	RuleOperatorLessThanOrEqual    RuleOperator = "lte"
+	RuleOperatorEqual              RuleOperator = "eq"
+	RuleOperatorPrefix             RuleOperator = "prefix"
+	RuleOperatorContains           RuleOperator = "contains"
+)
+
+func (o RuleOperator) IsValid() bool {
+	return o.IsNumeric() || o == RuleOperatorPrefix || o == RuleOperatorContains
+}
+
+// IsNumeric reports whether the operator compares numbers (eq also compares text)
+func (o RuleOperator) IsNumeric() bool {
+	switch o {
+	case RuleOperatorGreaterThan, RuleOperatorGreaterThanOrEqual,
+		RuleOperatorLessThan, RuleOperatorLessThanOrEqual, RuleOperatorEqual:
+		return true
+	}
+	return false
+}
+
+func (o RuleOperator) String() string {
+	return string(o)
+}
+
+// Compare evaluates "actual <operator> expected"
+func (o RuleOperator) Compare(actual, expected int64) bool {
+	switch o {
+	case RuleOperatorGreaterThan:
+		return actual > expected
+	case RuleOperatorGreaterThanOrEqual:
+		return actual >= expected
+	case RuleOperatorLessThan:
+		return actual < expected
+	case RuleOperatorLessThanOrEqual:
+		return actual <= expected
+	case RuleOperatorEqual:
+		return actual == expected
+	}
+	return false
+}
+
+// ==================== RoutingRule ====================
+
+// RoutingRule applies actions (label, priority boost) to conversations that
+// match its condition when the trigger fires
+type RoutingRule struct {
+	ID                uuid.UUID
+	TenantID          uuid.UUID
+	InboxID           *uuid.UUID // nil applies to every inbox of the tenant
+	Name              string
+	Trigger           RoutingRuleTrigger
+	ConditionField    RuleConditionField
+	ConditionOperator RuleOperator
+	ConditionValue    int32
+	ConditionText     *string // compared for text fields (category, language, customer_phone, metadata)
+	LabelName         *string
+	PriorityBoost     decimal.Decimal
+	IsActive          bool
+	CreatedBy         *uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewRoutingRule(
+	tenantID uuid.UUID,
+	inboxID *uuid.UUID,
+	name string,
+	trigger RoutingRuleTrigger,
+	field RuleConditionField,
+	operator RuleOperator,
+	value int32,
+	labelName *string,
+	priorityBoost decimal.Decimal,
+	createdBy *uuid.UUID,
+) *RoutingRule {
+	now := time.Now().UTC()
+	return &RoutingRule{
+		ID:                uuid.Must(uuid.NewV7()),
+		TenantID:          tenantID,
+		InboxID:           inboxID,
+		Name:              name,
+		Trigger:           trigger,
+		ConditionField:    field,
+		ConditionOperator: operator,
+		ConditionValue:    value,
+		LabelName:         labelName,
+		PriorityBoost:     priorityBoost,
+		IsActive:          true,
+		CreatedBy:         createdBy,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+// Matches reports whether the rule condition holds for the conversation;
+// metadata conditions never hold without a message, see MatchesMessage
+func (r *RoutingRule) Matches(conv *ConversationRef) bool {
+	return r.MatchesMessage(conv, nil)
+}
+
+// MatchesMessage reports whether the rule condition holds for the
+// conversation and the external metadata of the message that triggered it
+func (r *RoutingRule) MatchesMessage(conv *ConversationRef, metadata map[string]string) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.InboxID != nil && *r.InboxID != conv.InboxID {
+		return false
+	}
+
+	switch r.ConditionField {
+	case RuleFieldMessageCount:
+		return r.ConditionOperator.Compare(int64(conv.MessageCount), int64(r.ConditionValue))
+	case RuleFieldCategory:
+		return r.ConditionOperator == RuleOperatorEqual &&
+			r.ConditionText != nil && conv.Category != nil && *conv.Category == *r.ConditionText
+	case RuleFieldLanguage:
+		return r.ConditionOperator == RuleOperatorEqual &&
+			r.ConditionText != nil && conv.Language != nil && *conv.Language == *r.ConditionText
+	case RuleFieldCustomerPhone:
+		if r.ConditionText == nil {
+			return false
+		}
+		if r.ConditionOperator == RuleOperatorPrefix {
+			return strings.HasPrefix(conv.CustomerPhoneNumber, *r.ConditionText)
+		}
+		return r.ConditionOperator == RuleOperatorEqual && conv.CustomerPhoneNumber == *r.ConditionText
+	case RuleFieldMetadata:
+		if r.ConditionOperator != RuleOperatorContains || r.ConditionText == nil {
+			return false
+		}
+		for _, value := range metadata {
+			if strings.Contains(strings.ToLower(value), *r.ConditionText) {
+				return true
+			}
+		}
+	}
+	return false
+}
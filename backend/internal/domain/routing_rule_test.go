@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleOperator_Compare(t *testing.T) {
+	tests := []struct {
+		operator RuleOperator
+		actual   int64
+		expected int64
+		want     bool
+	}{
+		{RuleOperatorGreaterThan, 11, 10, true},
+		{RuleOperatorGreaterThan, 10, 10, false},
+		{RuleOperatorGreaterThanOrEqual, 10, 10, true},
+		{RuleOperatorLessThan, 9, 10, true},
+		{RuleOperatorLessThanOrEqual, 11, 10, false},
+		{RuleOperatorEqual, 10, 10, true},
+		{RuleOperator("between"), 10, 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operator), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.operator.Compare(tt.actual, tt.expected))
+		})
+	}
+}
+
+func TestRoutingRule_Matches(t *testing.T) {
+	tenantID := uuid.New()
+	inboxID := uuid.New()
+	label := "long-thread"
+
+	conv := NewConversationRef(tenantID, inboxID, "ext-1", "+1234567890")
+	conv.MessageCount = 11
+
+	t.Run("tenant-wide rule matches", func(t *testing.T) {
+		rule := NewRoutingRule(tenantID, nil, "long threads", RoutingRuleTriggerMessageReceived,
+			RuleFieldMessageCount, RuleOperatorGreaterThan, 10, &label, decimal.NewFromFloat(0.2), nil)
+		assert.True(t, rule.Matches(conv))
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		rule := NewRoutingRule(tenantID, nil, "very long threads", RoutingRuleTriggerMessageReceived,
+			RuleFieldMessageCount, RuleOperatorGreaterThan, 20, &label, decimal.Zero, nil)
+		assert.False(t, rule.Matches(conv))
+	})
+
+	t.Run("other inbox", func(t *testing.T) {
+		otherInbox := uuid.New()
+		rule := NewRoutingRule(tenantID, &otherInbox, "long threads", RoutingRuleTriggerMessageReceived,
+			RuleFieldMessageCount, RuleOperatorGreaterThan, 10, &label, decimal.Zero, nil)
+		assert.False(t, rule.Matches(conv))
+	})
+
+	t.Run("inactive rule", func(t *testing.T) {
+		rule := NewRoutingRule(tenantID, &inboxID, "long threads", RoutingRuleTriggerMessageReceived,
+			RuleFieldMessageCount, RuleOperatorGreaterThan, 10, &label, decimal.Zero, nil)
+		rule.IsActive = false
+		assert.False(t, rule.Matches(conv))
+	})
+
+	t.Run("category rule", func(t *testing.T) {
+		billing := "billing"
+		rule := NewRoutingRule(tenantID, nil, "billing", RoutingRuleTriggerMessageReceived,
+			RuleFieldCategory, RuleOperatorEqual, 0, &label, decimal.Zero, nil)
+		rule.ConditionText = &billing
+
+		assert.False(t, rule.Matches(conv), "unclassified conversation")
+
+		conv.Category = &billing
+		assert.True(t, rule.Matches(conv))
+
+		other := "sales"
+		conv.Category = &other
+		assert.False(t, rule.Matches(conv))
+		conv.Category = nil
+	})
+
+	t.Run("language rule", func(t *testing.T) {
+		spanish := "es"
+		rule := NewRoutingRule(tenantID, nil, "spanish", RoutingRuleTriggerMessageReceived,
+			RuleFieldLanguage, RuleOperatorEqual, 0, &label, decimal.Zero, nil)
+		rule.ConditionText = &spanish
+
+		assert.False(t, rule.Matches(conv), "language unknown")
+
+		conv.Language = &spanish
+		assert.True(t, rule.Matches(conv))
+		conv.Language = nil
+	})
+
+	t.Run("customer phone prefix rule", func(t *testing.T) {
+		prefix := "+123"
+		rule := NewRoutingRule(tenantID, nil, "country", RoutingRuleTriggerConversationCreated,
+			RuleFieldCustomerPhone, RuleOperatorPrefix, 0, &label, decimal.Zero, nil)
+		rule.ConditionText = &prefix
+		assert.True(t, rule.Matches(conv))
+
+		other := "+44"
+		rule.ConditionText = &other
+		assert.False(t, rule.Matches(conv))
+	})
+
+	t.Run("metadata keyword rule", func(t *testing.T) {
+		keyword := "black-friday"
+		rule := NewRoutingRule(tenantID, nil, "campaign", RoutingRuleTriggerMessageReceived,
+			RuleFieldMetadata, RuleOperatorContains, 0, &label, decimal.Zero, nil)
+		rule.ConditionText = &keyword
+
+		assert.False(t, rule.Matches(conv), "no message metadata")
+		assert.True(t, rule.MatchesMessage(conv, map[string]string{"campaign": "Black-Friday-2026"}))
+		assert.False(t, rule.MatchesMessage(conv, map[string]string{"campaign": "spring"}))
+	})
+}
+
+func TestRuleConditionField_Allows(t *testing.T) {
+	assert.True(t, RuleFieldMessageCount.Allows(RuleOperatorGreaterThan))
+	assert.False(t, RuleFieldMessageCount.Allows(RuleOperatorContains))
+	assert.True(t, RuleFieldCategory.Allows(RuleOperatorEqual))
+	assert.False(t, RuleFieldCategory.Allows(RuleOperatorPrefix))
+	assert.True(t, RuleFieldCustomerPhone.Allows(RuleOperatorPrefix))
+	assert.False(t, RuleFieldCustomerPhone.Allows(RuleOperatorContains))
+	assert.True(t, RuleFieldMetadata.Allows(RuleOperatorContains))
+	assert.False(t, RuleFieldMetadata.Allows(RuleOperatorEqual))
+}
+
+func TestRuleConditionField_NormalizeText(t *testing.T) {
+	prefix, err := RuleFieldCustomerPhone.NormalizeText(" +1 234-5 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "+12345", prefix)
+
+	_, err = RuleFieldCustomerPhone.NormalizeText("+")
+	assert.ErrorIs(t, err, ErrInvalidPhonePrefix)
+	_, err = RuleFieldCustomerPhone.NormalizeText("+1abc")
+	assert.ErrorIs(t, err, ErrInvalidPhonePrefix)
+
+	keyword, err := RuleFieldMetadata.NormalizeText("  VIP Customer ")
+	assert.NoError(t, err)
+	assert.Equal(t, "vip customer", keyword)
+
+	_, err = RuleFieldMetadata.NormalizeText("   ")
+	assert.ErrorIs(t, err, ErrInvalidRuleKeyword)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Sandbox tenants let prospects trial the product without real data. Their
+// inboxes and conversations are the demo data below, re-created when the
+// tenant is reset; their webhooks are never sent.
+
+// SandboxExternalIDPrefix prefixes the external IDs of demo conversations
+const SandboxExternalIDPrefix = "sandbox-"
+
+// SandboxDemoInbox is an inbox seeded into sandbox tenants
+type SandboxDemoInbox struct {
+	PhoneNumber string
+	DisplayName string
+}
+
+// SandboxDemoConversation is a conversation seeded into sandbox tenants
+type SandboxDemoConversation struct {
+	// Inbox indexes SandboxDemoInboxes
+	Inbox               int
+	CustomerPhoneNumber string
+	Text                string
+	// Age is how long ago the customer's message was received
+	Age time.Duration
+}
+
+// Phone numbers are from the 555-01xx range reserved for fiction
+var SandboxDemoInboxes = []SandboxDemoInbox{
+	{PhoneNumber: "+12025550100", DisplayName: "Support (demo)"},
+	{PhoneNumber: "+12025550101", DisplayName: "Sales (demo)"},
+}
+
+var SandboxDemoConversations = []SandboxDemoConversation{
+	{0, "+12025550110", "Hi, my order #1042 hasn't arrived yet", 3 * time.Hour},
+	{0, "+12025550111", "I was charged twice this month", 2 * time.Hour},
+	{0, "+12025550112", "How do I reset my password?", 95 * time.Minute},
+	{0, "+12025550113", "The app crashes when I open settings", time.Hour},
+	{0, "+12025550114", "Can I change my delivery address?", 40 * time.Minute},
+	{0, "+12025550110", "Any update on my order?", 25 * time.Minute},
+	{0, "+12025550115", "I'd like to cancel my subscription", 10 * time.Minute},
+	{1, "+12025550120", "Do you offer discounts for teams?", 150 * time.Minute},
+	{1, "+12025550121", "Can I get a demo next week?", 80 * time.Minute},
+	{1, "+12025550122", "What's the difference between the plans?", 45 * time.Minute},
+	{1, "+12025550123", "Do you ship to Canada?", 15 * time.Minute},
+	{1, "+12025550124", "Is there a yearly plan?", 5 * time.Minute},
+}
+
+// ExternalID is the external conversation ID of the i-th demo conversation
+func (c SandboxDemoConversation) ExternalID(i int) string {
+	return fmt.Sprintf("%s%03d", SandboxExternalIDPrefix, i+1)
+}
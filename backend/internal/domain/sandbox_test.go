@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxDemoData(t *testing.T) {
+	seen := make(map[string]bool)
+	for i, conv := range SandboxDemoConversations {
+		assert.Less(t, conv.Inbox, len(SandboxDemoInboxes), "conversation %d names an unknown inbox", i)
+		id := conv.ExternalID(i)
+		assert.False(t, seen[id], "duplicate external ID %s", id)
+		seen[id] = true
+	}
+	assert.Equal(t, "sandbox-001", SandboxDemoConversations[0].ExternalID(0))
+}
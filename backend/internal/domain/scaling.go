@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// ScalingScope is where a component keeps its state
+type ScalingScope string
+
+const (
+	// ScalingScopeShared: state lives in a backend every replica reaches
+	ScalingScopeShared ScalingScope = "SHARED"
+	// ScalingScopeNodeLocal: state lives in the memory of each replica
+	ScalingScopeNodeLocal ScalingScope = "NODE_LOCAL"
+)
+
+// ScalingComponent describes where one piece of runtime state lives, and
+// whether running several replicas behind a load balancer without sticky
+// sessions keeps it correct
+type ScalingComponent struct {
+	Name    string
+	Backend string
+	Scope   ScalingScope
+	// SafeForReplicas is false when requests served by different replicas
+	// can disagree, e.g. a token issued by one replica rejected by another
+	SafeForReplicas bool
+	Note            string
+}
+
+// ScalingReport lists the node-local and shared state of a replica
+type ScalingReport struct {
+	At         time.Time
+	Components []ScalingComponent
+}
+
+// Safe reports whether every component is safe to run on several replicas
+func (r *ScalingReport) Safe() bool {
+	for _, c := range r.Components {
+		if !c.SafeForReplicas {
+			return false
+		}
+	}
+	return true
+}
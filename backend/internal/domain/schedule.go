@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== OperatorSchedule ====================
+
+// OperatorSchedule is one weekly recurring window an operator works in.
+// Start and End are times of day in Timezone, as offsets from midnight; a
+// window whose End is not after its Start runs past midnight into the next
+// day. An operator without windows is not restricted.
+type OperatorSchedule struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+	// DayOfWeek is the day the window starts on
+	DayOfWeek time.Weekday
+	Start     time.Duration
+	End       time.Duration
+	// Timezone is an IANA time zone name
+	Timezone  string
+	CreatedBy *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewOperatorSchedule(tenantID, operatorID uuid.UUID, day time.Weekday, start, end time.Duration, timezone string, createdBy *uuid.UUID) *OperatorSchedule {
+	now := time.Now().UTC()
+	return &OperatorSchedule{
+		ID:         uuid.Must(uuid.NewV7()),
+		TenantID:   tenantID,
+		OperatorID: operatorID,
+		DayOfWeek:  day,
+		Start:      start,
+		End:        end,
+		Timezone:   timezone,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Contains reports whether t falls within the window. A window whose time
+// zone cannot be loaded contains nothing.
+func (s *OperatorSchedule) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+	local := t.In(loc)
+	hour, min, sec := local.Clock()
+	offset := time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second
+	day := local.Weekday()
+
+	if s.Start < s.End {
+		return day == s.DayOfWeek && offset >= s.Start && offset < s.End
+	}
+	// Runs past midnight
+	next := (s.DayOfWeek + 1) % 7
+	return (day == s.DayOfWeek && offset >= s.Start) || (day == next && offset < s.End)
+}
+
+// InSchedule reports whether t falls within any of the windows; true when
+// there are none
+func InSchedule(windows []*OperatorSchedule, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
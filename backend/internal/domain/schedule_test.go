@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorSchedule_Contains(t *testing.T) {
+	// 2024-01-01 is a Monday
+	day := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 9*time.Hour, 17*time.Hour, "UTC", nil)
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)))
+	assert.True(t, day.Contains(time.Date(2024, 1, 1, 16, 59, 0, 0, time.UTC)))
+	assert.False(t, day.Contains(time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)), "end is exclusive")
+	assert.False(t, day.Contains(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)), "other day")
+
+	night := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 22*time.Hour, 6*time.Hour, "UTC", nil)
+	assert.True(t, night.Contains(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, night.Contains(time.Date(2024, 1, 2, 5, 0, 0, 0, time.UTC)), "past midnight")
+	assert.False(t, night.Contains(time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)), "before start on start day")
+	assert.False(t, night.Contains(time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC)))
+
+	toMidnight := NewOperatorSchedule(uuid.New(), uuid.New(), time.Saturday, 18*time.Hour, 0, "UTC", nil)
+	assert.True(t, toMidnight.Contains(time.Date(2024, 1, 6, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, toMidnight.Contains(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestOperatorSchedule_ContainsTimezone(t *testing.T) {
+	s := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 9*time.Hour, 17*time.Hour, "America/New_York", nil)
+	// 09:30 in New York (EST, UTC-5)
+	assert.True(t, s.Contains(time.Date(2024, 1, 1, 14, 30, 0, 0, time.UTC)))
+	assert.False(t, s.Contains(time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)))
+
+	invalid := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 0, 23*time.Hour, "Not/AZone", nil)
+	assert.False(t, invalid.Contains(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestInSchedule(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, InSchedule(nil, at), "no schedule is unrestricted")
+
+	morning := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 8*time.Hour, 11*time.Hour, "UTC", nil)
+	afternoon := NewOperatorSchedule(uuid.New(), uuid.New(), time.Monday, 11*time.Hour, 15*time.Hour, "UTC", nil)
+	assert.False(t, InSchedule([]*OperatorSchedule{morning}, at))
+	assert.True(t, InSchedule([]*OperatorSchedule{morning, afternoon}, at))
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareTokenSigner(t *testing.T) {
+	conv := NewConversationRef(uuid.New(), uuid.New(), "ext-1", "+15550001111")
+	link := NewShareLink(conv, time.Hour, false, nil)
+	signer := NewShareTokenSigner([]byte("0123456789abcdef0123456789abcdef"))
+
+	token := signer.Sign(link)
+	id, expiresAt, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, link.ID, id)
+	assert.True(t, link.ExpiresAt.Equal(expiresAt))
+
+	t.Run("other key", func(t *testing.T) {
+		other := NewShareTokenSigner([]byte("fedcba9876543210fedcba9876543210"))
+		_, _, err := other.Verify(token)
+		assert.ErrorIs(t, err, ErrShareTokenInvalid)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		payload, mac, _ := strings.Cut(token, ".")
+		tampered := []byte(payload)
+		tampered[len(tampered)-1] ^= 1
+		_, _, err := signer.Verify(string(tampered) + "." + mac)
+		assert.ErrorIs(t, err, ErrShareTokenInvalid)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "!!!.???"} {
+			_, _, err := signer.Verify(token)
+			assert.ErrorIs(t, err, ErrShareTokenInvalid, token)
+		}
+	})
+}
+
+func TestShareLink_IsExpired(t *testing.T) {
+	conv := NewConversationRef(uuid.New(), uuid.New(), "ext-1", "+15550001111")
+	link := NewShareLink(conv, time.Hour, false, nil)
+
+	assert.False(t, link.IsExpired(time.Now()))
+	assert.True(t, link.IsExpired(time.Now().Add(2*time.Hour)))
+}
+
+func TestNewConversationSnapshot(t *testing.T) {
+	conv := NewConversationRef(uuid.New(), uuid.New(), "ext-1", "+15550001111")
+
+	sanitized := NewConversationSnapshot(conv, "Support", nil, NewShareLink(conv, time.Hour, false, nil))
+	assert.Empty(t, sanitized.Conversation.CustomerPhoneNumber)
+	assert.Empty(t, sanitized.Conversation.ExternalConversationID)
+	assert.Equal(t, "+15550001111", conv.CustomerPhoneNumber, "the conversation itself is unchanged")
+
+	withPII := NewConversationSnapshot(conv, "Support", nil, NewShareLink(conv, time.Hour, true, nil))
+	assert.Equal(t, "+15550001111", withPII.Conversation.CustomerPhoneNumber)
+	assert.True(t, withPII.IncludesPII)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== InboxSLAPolicy ====================
+
+// InboxSLAPolicy holds the service level targets of an inbox. Both are
+// measured from conversation creation; a nil target is not tracked.
+type InboxSLAPolicy struct {
+	InboxID  uuid.UUID
+	TenantID uuid.UUID
+	// FirstAssignment is how long a conversation may stay QUEUED
+	FirstAssignment *time.Duration
+	// Resolution is how long a conversation may stay unresolved
+	Resolution *time.Duration
+	UpdatedBy  *uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewInboxSLAPolicy(tenantID, inboxID uuid.UUID, firstAssignment, resolution *time.Duration, updatedBy *uuid.UUID) *InboxSLAPolicy {
+	now := time.Now().UTC()
+	return &InboxSLAPolicy{
+		InboxID:         inboxID,
+		TenantID:        tenantID,
+		FirstAssignment: firstAssignment,
+		Resolution:      resolution,
+		UpdatedBy:       updatedBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// DueAt is the due date of a conversation created at createdAt: the end of
+// the resolution target, nil when the policy has none
+func (p *InboxSLAPolicy) DueAt(createdAt time.Time) *time.Time {
+	if p.Resolution == nil {
+		return nil
+	}
+	due := createdAt.Add(*p.Resolution)
+	return &due
+}
+
+// ==================== SLABreach ====================
+
+// SLABreach is a conversation flagged by the SLA worker
+type SLABreach struct {
+	ConversationID uuid.UUID
+	TenantID       uuid.UUID
+	InboxID        uuid.UUID
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== InboxStalenessPolicy ====================
+
+// InboxStalenessPolicy limits how long an ALLOCATED conversation of the
+// inbox may go without activity of its operator before it is flagged stale
+type InboxStalenessPolicy struct {
+	InboxID    uuid.UUID
+	TenantID   uuid.UUID
+	StaleAfter time.Duration
+	// DeallocateGrace is the grace period a stale conversation gets before
+	// it returns to the queue; nil only flags it
+	DeallocateGrace *time.Duration
+	UpdatedBy       *uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func NewInboxStalenessPolicy(tenantID, inboxID uuid.UUID, staleAfter time.Duration, deallocateGrace *time.Duration, updatedBy *uuid.UUID) *InboxStalenessPolicy {
+	now := time.Now().UTC()
+	return &InboxStalenessPolicy{
+		InboxID:         inboxID,
+		TenantID:        tenantID,
+		StaleAfter:      staleAfter,
+		DeallocateGrace: deallocateGrace,
+		UpdatedBy:       updatedBy,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// ==================== ActivityKind ====================
+
+// ActivityKind is the operator action that kept an allocation active
+type ActivityKind string
+
+const (
+	// ActivityKindAllocated starts the clock of an allocation the stale
+	// allocation worker has not seen before
+	ActivityKindAllocated ActivityKind = "ALLOCATED"
+	ActivityKindMessage   ActivityKind = "MESSAGE"
+	ActivityKindNote      ActivityKind = "NOTE"
+	ActivityKindAck       ActivityKind = "ACK"
+	ActivityKindRead      ActivityKind = "READ"
+)
+
+// IsReportable reports whether clients may report activity of this kind;
+// ALLOCATED is only recorded by the worker
+func (k ActivityKind) IsReportable() bool {
+	switch k {
+	case ActivityKindMessage, ActivityKindNote, ActivityKindAck, ActivityKindRead:
+		return true
+	}
+	return false
+}
+
+// ==================== ConversationActivity ====================
+
+// ConversationActivity is the last activity of the assigned operator on an
+// ALLOCATED conversation
+type ConversationActivity struct {
+	ConversationID uuid.UUID
+	OperatorID     uuid.UUID
+	LastActivityAt time.Time
+	LastKind       ActivityKind
+	// StaleAt is when the conversation was flagged stale, nil while active
+	StaleAt *time.Time
+}
+
+func NewConversationActivity(conversationID, operatorID uuid.UUID, kind ActivityKind, at time.Time) *ConversationActivity {
+	return &ConversationActivity{
+		ConversationID: conversationID,
+		OperatorID:     operatorID,
+		LastActivityAt: at,
+		LastKind:       kind,
+	}
+}
+
+// IdleFor is how long the operator has been inactive at now
+func (a *ConversationActivity) IdleFor(now time.Time) time.Duration {
+	return now.Sub(a.LastActivityAt)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInboxStalenessPolicy(t *testing.T) {
+	tenantID, inboxID := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	grace := 5 * time.Minute
+
+	policy := NewInboxStalenessPolicy(tenantID, inboxID, 15*time.Minute, &grace, nil)
+	assert.Equal(t, inboxID, policy.InboxID)
+	assert.Equal(t, 15*time.Minute, policy.StaleAfter)
+	assert.Equal(t, &grace, policy.DeallocateGrace)
+	assert.Equal(t, policy.CreatedAt, policy.UpdatedAt)
+}
+
+func TestActivityKind_IsReportable(t *testing.T) {
+	for _, kind := range []ActivityKind{ActivityKindMessage, ActivityKindNote, ActivityKindAck, ActivityKindRead} {
+		assert.True(t, kind.IsReportable(), kind)
+	}
+	assert.False(t, ActivityKindAllocated.IsReportable(), "only the worker starts clocks")
+	assert.False(t, ActivityKind("TYPING").IsReportable())
+	assert.False(t, ActivityKind("").IsReportable())
+}
+
+func TestConversationActivity_IdleFor(t *testing.T) {
+	at := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	activity := NewConversationActivity(uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), ActivityKindAck, at)
+
+	assert.Equal(t, ActivityKindAck, activity.LastKind)
+	assert.Nil(t, activity.StaleAt)
+	assert.Equal(t, 20*time.Minute, activity.IdleFor(at.Add(20*time.Minute)))
+}
+
+func TestConversationRef_IsAllocatedTo(t *testing.T) {
+	operatorID, other := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	conv := NewConversationRef(uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7()), "ext-1", "+1234567890")
+	assert.False(t, conv.IsAllocatedTo(operatorID), "queued")
+
+	require.NoError(t, conv.Allocate(operatorID))
+	assert.True(t, conv.IsAllocatedTo(operatorID))
+	assert.False(t, conv.IsAllocatedTo(other))
+
+	require.NoError(t, conv.Resolve())
+	assert.False(t, conv.IsAllocatedTo(operatorID), "resolved conversations keep their operator")
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== Tenant Overview ====================
+
+// InboxConversationStats counts an inbox's conversations by state
+type InboxConversationStats struct {
+	InboxID     uuid.UUID
+	DisplayName string
+	Queued      int
+	Allocated   int
+	Resolved    int
+	// RecentlyResolved counts the conversations resolved within the
+	// resolution window; AvgResolution averages their time from creation
+	// to resolution, zero when there are none
+	RecentlyResolved int
+	AvgResolution    time.Duration
+	// Overdue counts the open conversations past their due date
+	Overdue int
+}
+
+// InboxOverview is one inbox's row of the tenant overview
+type InboxOverview struct {
+	InboxConversationStats
+	AllocationsLastHour int
+}
+
+// TenantOverview is the dashboard summary of a tenant
+type TenantOverview struct {
+	GeneratedAt time.Time
+	// ResolutionWindow is how far back resolutions are averaged
+	ResolutionWindow time.Duration
+	Inboxes          []InboxOverview
+	// OperatorsByStatus counts operators with a recorded status
+	OperatorsByStatus   map[OperatorStatusType]int
+	AllocationsLastHour int
+	RecentlyResolved    int
+	// AvgResolution averages every inbox's recent resolutions
+	AvgResolution time.Duration
+}
+
+// NewTenantOverview combines the per-inbox counts into the overview;
+// allocationsByInbox holds the allocations of the last hour per inbox
+func NewTenantOverview(
+	now time.Time,
+	resolutionWindow time.Duration,
+	stats []InboxConversationStats,
+	allocationsByInbox map[uuid.UUID]int,
+	operatorsByStatus map[OperatorStatusType]int,
+) *TenantOverview {
+	overview := &TenantOverview{
+		GeneratedAt:       now,
+		ResolutionWindow:  resolutionWindow,
+		Inboxes:           make([]InboxOverview, len(stats)),
+		OperatorsByStatus: operatorsByStatus,
+	}
+
+	var resolutionTotal time.Duration
+	for i, s := range stats {
+		overview.Inboxes[i] = InboxOverview{
+			InboxConversationStats: s,
+			AllocationsLastHour:    allocationsByInbox[s.InboxID],
+		}
+		overview.AllocationsLastHour += allocationsByInbox[s.InboxID]
+		overview.RecentlyResolved += s.RecentlyResolved
+		resolutionTotal += s.AvgResolution * time.Duration(s.RecentlyResolved)
+	}
+	if overview.RecentlyResolved > 0 {
+		overview.AvgResolution = resolutionTotal / time.Duration(overview.RecentlyResolved)
+	}
+	return overview
+}
+
+// ActiveOperators counts the operators currently AVAILABLE
+func (o *TenantOverview) ActiveOperators() int {
+	return o.OperatorsByStatus[OperatorStatusAvailable]
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTenantOverview(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	busy, quiet := uuid.New(), uuid.New()
+
+	overview := NewTenantOverview(now, 24*time.Hour, []InboxConversationStats{
+		{InboxID: busy, Queued: 4, Allocated: 2, Resolved: 10, RecentlyResolved: 3, AvgResolution: 10 * time.Minute},
+		{InboxID: quiet, Resolved: 1, RecentlyResolved: 1, AvgResolution: 30 * time.Minute},
+	}, map[uuid.UUID]int{busy: 5, uuid.New(): 7}, map[OperatorStatusType]int{
+		OperatorStatusAvailable: 3,
+		OperatorStatusOffline:   2,
+	})
+
+	require.Len(t, overview.Inboxes, 2)
+	assert.Equal(t, 5, overview.Inboxes[0].AllocationsLastHour)
+	assert.Equal(t, 0, overview.Inboxes[1].AllocationsLastHour)
+	assert.Equal(t, 5, overview.AllocationsLastHour, "allocations of unknown inboxes are ignored")
+	assert.Equal(t, 4, overview.RecentlyResolved)
+	assert.Equal(t, 15*time.Minute, overview.AvgResolution, "weighted by resolutions")
+	assert.Equal(t, 3, overview.ActiveOperators())
+
+	empty := NewTenantOverview(now, 24*time.Hour, nil, nil, nil)
+	assert.Empty(t, empty.Inboxes)
+	assert.Zero(t, empty.AvgResolution)
+	assert.Zero(t, empty.ActiveOperators())
+}
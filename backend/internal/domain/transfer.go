@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTransferTTL is how long the recipient has to answer a transfer
+	// when no expiry is requested
+	DefaultTransferTTL = 5 * time.Minute
+	// MaxTransferTTL is the longest a transfer may wait for an answer
+	MaxTransferTTL = time.Hour
+	// MaxTransferNoteLength caps the note of a transfer, in characters
+	MaxTransferNoteLength = 2000
+)
+
+// ==================== TransferStatus ====================
+
+type TransferStatus string
+
+const (
+	TransferStatusPending  TransferStatus = "PENDING"
+	TransferStatusAccepted TransferStatus = "ACCEPTED"
+	TransferStatusDeclined TransferStatus = "DECLINED"
+	TransferStatusExpired  TransferStatus = "EXPIRED"
+	// TransferStatusCancelled ends a transfer whose conversation left the
+	// proposing operator before the recipient answered
+	TransferStatusCancelled TransferStatus = "CANCELLED"
+)
+
+func (s TransferStatus) IsValid() bool {
+	switch s {
+	case TransferStatusPending, TransferStatusAccepted, TransferStatusDeclined, TransferStatusExpired, TransferStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s TransferStatus) String() string {
+	return string(s)
+}
+
+// CanTransitionTo validates status transitions: a pending transfer ends
+// exactly once
+func (s TransferStatus) CanTransitionTo(target TransferStatus) bool {
+	return s == TransferStatusPending && target.IsValid() && target != TransferStatusPending
+}
+
+// ==================== ConversationTransfer ====================
+
+// ConversationTransfer is an operator's proposal to hand their conversation
+// to a colleague, who accepts or declines it before ExpiresAt. Unlike a
+// reassignment, the conversation only moves once the recipient accepts.
+type ConversationTransfer struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	FromOperatorID uuid.UUID
+	ToOperatorID   uuid.UUID
+	// Note is handed to the recipient as a HANDOVER note on acceptance
+	Note        *string
+	Status      TransferStatus
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+	RespondedAt *time.Time
+}
+
+func NewConversationTransfer(conv *ConversationRef, fromOperatorID, toOperatorID uuid.UUID, note *string, ttl time.Duration) *ConversationTransfer {
+	now := time.Now().UTC()
+	return &ConversationTransfer{
+		ID:             uuid.Must(uuid.NewV7()),
+		TenantID:       conv.TenantID,
+		ConversationID: conv.ID,
+		FromOperatorID: fromOperatorID,
+		ToOperatorID:   toOperatorID,
+		Note:           note,
+		Status:         TransferStatusPending,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}
+}
+
+func (t *ConversationTransfer) IsPending() bool {
+	return t.Status == TransferStatusPending
+}
+
+func (t *ConversationTransfer) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// End moves a pending transfer to status; ErrInvalidStateTransition once it
+// has ended
+func (t *ConversationTransfer) End(status TransferStatus, now time.Time) error {
+	if !t.Status.CanTransitionTo(status) {
+		return ErrInvalidStateTransition
+	}
+	t.Status = status
+	t.RespondedAt = &now
+	return nil
+}
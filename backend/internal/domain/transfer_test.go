@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferStatus_CanTransitionTo(t *testing.T) {
+	for _, target := range []TransferStatus{TransferStatusAccepted, TransferStatusDeclined, TransferStatusExpired, TransferStatusCancelled} {
+		assert.True(t, TransferStatusPending.CanTransitionTo(target), target)
+		assert.False(t, target.CanTransitionTo(TransferStatusPending), target)
+		assert.False(t, target.CanTransitionTo(TransferStatusAccepted), target)
+	}
+	assert.False(t, TransferStatusPending.CanTransitionTo(TransferStatusPending))
+	assert.False(t, TransferStatusPending.CanTransitionTo("UNKNOWN"))
+}
+
+func TestConversationTransfer_End(t *testing.T) {
+	conv := &ConversationRef{ID: uuid.New(), TenantID: uuid.New()}
+	transfer := NewConversationTransfer(conv, uuid.New(), uuid.New(), nil, DefaultTransferTTL)
+	require.True(t, transfer.IsPending())
+	assert.Equal(t, conv.ID, transfer.ConversationID)
+	assert.False(t, transfer.IsExpired(transfer.CreatedAt))
+	assert.True(t, transfer.IsExpired(transfer.CreatedAt.Add(DefaultTransferTTL)))
+
+	now := time.Now().UTC()
+	require.NoError(t, transfer.End(TransferStatusDeclined, now))
+	assert.Equal(t, TransferStatusDeclined, transfer.Status)
+	assert.Equal(t, &now, transfer.RespondedAt)
+
+	assert.ErrorIs(t, transfer.End(TransferStatusAccepted, now), ErrInvalidStateTransition, "answered once")
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// ==================== Urgency ====================
+
+// Weights of the urgency components. Each component is between 0 and 1, and
+// so is the score, their weighted sum:
+//   - SLA: the elapsed fraction of the inbox's resolution target, 0 without one
+//   - unread: 1 while the assignee has not read the latest message, as in
+//     IsUnread
+//   - age: the conversation's age as a fraction of UrgencyAgeHorizon
+const (
+	UrgencyWeightSLA    = 0.5
+	UrgencyWeightUnread = 0.3
+	UrgencyWeightAge    = 0.2
+)
+
+// UrgencyAgeHorizon is the age at which the age component saturates
+const UrgencyAgeHorizon = 24 * time.Hour
+
+// ConversationUrgency is a conversation of an operator's workload with its
+// urgency. A list by urgency scores every page as of the time of its first
+// page, so the order holds across pages while the conversations do not
+// change; it is most urgent first, then oldest first, then by ID.
+type ConversationUrgency struct {
+	Conversation *ConversationRef
+	Score        float64
+	SLA          float64
+	Unread       bool
+	Age          float64
+}
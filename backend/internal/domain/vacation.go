@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidVacation = errors.New("invalid vacation")
+
+// MaxVacationRampDown is the longest period a vacation drain may be spread over
+const MaxVacationRampDown = 7 * 24 * time.Hour
+
+// MaxVacationColleagues caps the colleagues a vacation drain hands over to
+const MaxVacationColleagues = 20
+
+// ==================== VacationDrain ====================
+
+// VacationDrain is what happens to the ALLOCATED conversations of an
+// operator going on vacation
+type VacationDrain string
+
+const (
+	// VacationDrainNone leaves them with the operator
+	VacationDrainNone VacationDrain = "NONE"
+	// VacationDrainQueue returns them to the queue
+	VacationDrainQueue VacationDrain = "QUEUE"
+	// VacationDrainColleagues hands them round-robin to the named colleagues
+	// subscribed to their inbox, and to the queue when none is
+	VacationDrainColleagues VacationDrain = "COLLEAGUES"
+)
+
+func (d VacationDrain) IsValid() bool {
+	switch d {
+	case VacationDrainNone, VacationDrainQueue, VacationDrainColleagues:
+		return true
+	}
+	return false
+}
+
+// ==================== OperatorVacation ====================
+
+// OperatorVacation keeps new conversations from an operator whatever their
+// status until it ends. Their ALLOCATED conversations are drained as Drain
+// says, spread evenly until DrainUntil.
+type OperatorVacation struct {
+	OperatorID   uuid.UUID
+	TenantID     uuid.UUID
+	StartedAt    time.Time
+	Drain        VacationDrain
+	ColleagueIDs []uuid.UUID
+	// DrainUntil is the end of the ramp-down; StartedAt when draining at once
+	DrainUntil time.Time
+	// DrainedAt is set once no conversation was left to drain
+	DrainedAt *time.Time
+	// NextColleague is the round-robin position in ColleagueIDs
+	NextColleague int
+}
+
+// NewOperatorVacation starts a vacation now, draining over rampDown
+func NewOperatorVacation(tenantID, operatorID uuid.UUID, drain VacationDrain, colleagueIDs []uuid.UUID, rampDown time.Duration) (*OperatorVacation, error) {
+	if !drain.IsValid() || rampDown < 0 || rampDown > MaxVacationRampDown {
+		return nil, ErrInvalidVacation
+	}
+	if (drain == VacationDrainColleagues) != (len(colleagueIDs) > 0) || len(colleagueIDs) > MaxVacationColleagues {
+		return nil, ErrInvalidVacation
+	}
+	for _, id := range colleagueIDs {
+		if id == operatorID {
+			return nil, ErrInvalidVacation
+		}
+	}
+
+	now := time.Now().UTC()
+	return &OperatorVacation{
+		OperatorID:   operatorID,
+		TenantID:     tenantID,
+		StartedAt:    now,
+		Drain:        drain,
+		ColleagueIDs: colleagueIDs,
+		DrainUntil:   now.Add(rampDown),
+	}, nil
+}
+
+// Draining reports whether conversations are still to be drained
+func (v *OperatorVacation) Draining() bool {
+	return v.Drain != VacationDrainNone && v.DrainedAt == nil
+}
+
+// DrainQuota returns how many of the remaining conversations to drain at now
+// when the drain runs every interval, so that the rest are spread evenly
+// over what is left of the ramp-down. Everything goes in the last interval.
+func (v *OperatorVacation) DrainQuota(remaining int, now time.Time, interval time.Duration) int {
+	if remaining <= 0 {
+		return 0
+	}
+	left := v.DrainUntil.Sub(now)
+	if interval <= 0 || left <= interval {
+		return remaining
+	}
+	runs := int((left + interval - 1) / interval)
+	return (remaining + runs - 1) / runs
+}
+
+// NextColleagueFor returns the next colleague in round-robin order accepted
+// by eligible, advancing past them; false when none is
+func (v *OperatorVacation) NextColleagueFor(eligible func(uuid.UUID) bool) (uuid.UUID, bool) {
+	for range v.ColleagueIDs {
+		id := v.ColleagueIDs[v.NextColleague%len(v.ColleagueIDs)]
+		v.NextColleague = (v.NextColleague + 1) % len(v.ColleagueIDs)
+		if eligible(id) {
+			return id, true
+		}
+	}
+	return uuid.Nil, false
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOperatorVacation(t *testing.T) {
+	operatorID := uuid.New()
+	colleague := uuid.New()
+
+	vacation, err := NewOperatorVacation(uuid.New(), operatorID, VacationDrainColleagues, []uuid.UUID{colleague}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, vacation.DrainUntil.Sub(vacation.StartedAt))
+	assert.True(t, vacation.Draining())
+
+	none, err := NewOperatorVacation(uuid.New(), operatorID, VacationDrainNone, nil, 0)
+	require.NoError(t, err)
+	assert.False(t, none.Draining())
+
+	invalid := []struct {
+		name       string
+		drain      VacationDrain
+		colleagues []uuid.UUID
+		rampDown   time.Duration
+	}{
+		{"unknown drain", VacationDrain("ELSEWHERE"), nil, 0},
+		{"colleagues missing", VacationDrainColleagues, nil, 0},
+		{"colleagues with queue", VacationDrainQueue, []uuid.UUID{colleague}, 0},
+		{"self as colleague", VacationDrainColleagues, []uuid.UUID{operatorID}, 0},
+		{"negative ramp-down", VacationDrainQueue, nil, -time.Minute},
+		{"ramp-down too long", VacationDrainQueue, nil, MaxVacationRampDown + time.Minute},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOperatorVacation(uuid.New(), operatorID, tt.drain, tt.colleagues, tt.rampDown)
+			assert.ErrorIs(t, err, ErrInvalidVacation)
+		})
+	}
+}
+
+func TestOperatorVacation_DrainQuota(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	vacation := &OperatorVacation{Drain: VacationDrainQueue, DrainUntil: now.Add(10 * time.Minute)}
+
+	// Ten runs left: one conversation each, rounded up
+	assert.Equal(t, 1, vacation.DrainQuota(10, now, time.Minute))
+	assert.Equal(t, 2, vacation.DrainQuota(11, now, time.Minute))
+	assert.Equal(t, 0, vacation.DrainQuota(0, now, time.Minute))
+
+	// The last run and any after the ramp-down take everything
+	assert.Equal(t, 7, vacation.DrainQuota(7, now.Add(9*time.Minute+30*time.Second), time.Minute))
+	assert.Equal(t, 7, vacation.DrainQuota(7, now.Add(time.Hour), time.Minute))
+
+	immediate := &OperatorVacation{Drain: VacationDrainQueue, DrainUntil: now}
+	assert.Equal(t, 5, immediate.DrainQuota(5, now, time.Minute))
+}
+
+func TestOperatorVacation_NextColleagueFor(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	vacation := &OperatorVacation{Drain: VacationDrainColleagues, ColleagueIDs: []uuid.UUID{a, b, c}}
+	all := func(uuid.UUID) bool { return true }
+
+	for _, want := range []uuid.UUID{a, b, c, a} {
+		got, ok := vacation.NextColleagueFor(all)
+		require.True(t, ok)
+		assert.Equal(t, want, got)
+	}
+
+	// Ineligible colleagues are skipped without losing the rotation
+	got, ok := vacation.NextColleagueFor(func(id uuid.UUID) bool { return id == c })
+	require.True(t, ok)
+	assert.Equal(t, c, got)
+	got, _ = vacation.NextColleagueFor(all)
+	assert.Equal(t, a, got)
+
+	_, ok = vacation.NextColleagueFor(func(uuid.UUID) bool { return false })
+	assert.False(t, ok)
+}
@@ -33,7 +33,7 @@ func (s ConversationState) CanTransitionTo(target ConversationState) bool {
 	transitions := map[ConversationState][]ConversationState{
 		ConversationStateQueued:    {ConversationStateAllocated},
 		ConversationStateAllocated: {ConversationStateQueued, ConversationStateResolved},
-		ConversationStateResolved:  {}, // Terminal state
+		ConversationStateResolved:  {ConversationStateQueued}, // Reopen
 	}
 	for _, allowed := range transitions[s] {
 		if allowed == target {
@@ -82,23 +82,55 @@ func (r OperatorRole) CanMoveInbox() bool {
 	return r == OperatorRoleManager || r == OperatorRoleAdmin
 }
 
+// The following apply to every inbox of the tenant; inbox admins hold them
+// for their inboxes only (see InboxAdmin)
+
+func (r OperatorRole) CanManageLabels() bool {
+	return r == OperatorRoleManager || r == OperatorRoleAdmin
+}
+
+func (r OperatorRole) CanManageSubscriptions() bool {
+	return r == OperatorRoleManager || r == OperatorRoleAdmin
+}
+
+func (r OperatorRole) CanManageRoutingRules() bool {
+	return r == OperatorRoleAdmin
+}
+
 // ==================== OperatorStatusType ====================
 
 type OperatorStatusType string
 
 const (
 	OperatorStatusAvailable OperatorStatusType = "AVAILABLE"
-	OperatorStatusOffline   OperatorStatusType = "OFFLINE"
+	// OperatorStatusBusy keeps the operator's conversations but takes no new ones
+	OperatorStatusBusy OperatorStatusType = "BUSY"
+	// OperatorStatusAway is a short break: conversations get a shorter grace period
+	OperatorStatusAway    OperatorStatusType = "AWAY"
+	OperatorStatusOffline OperatorStatusType = "OFFLINE"
 )
 
 func (s OperatorStatusType) IsValid() bool {
 	switch s {
-	case OperatorStatusAvailable, OperatorStatusOffline:
+	case OperatorStatusAvailable, OperatorStatusBusy, OperatorStatusAway, OperatorStatusOffline:
 		return true
 	}
 	return false
 }
 
+// AcceptsAllocations reports whether conversations may be allocated to or
+// claimed by an operator in this status
+func (s OperatorStatusType) AcceptsAllocations() bool {
+	return s == OperatorStatusAvailable
+}
+
+// HoldsConversations reports whether an operator in this status keeps their
+// conversations. Leaving these statuses starts grace periods; returning to
+// them cancels the grace periods.
+func (s OperatorStatusType) HoldsConversations() bool {
+	return s == OperatorStatusAvailable || s == OperatorStatusBusy
+}
+
 func (s OperatorStatusType) String() string {
 	return string(s)
 }
@@ -109,12 +141,16 @@ type GracePeriodReason string
 
 const (
 	GracePeriodReasonOffline GracePeriodReason = "OFFLINE"
+	GracePeriodReasonAway    GracePeriodReason = "AWAY"
 	GracePeriodReasonManual  GracePeriodReason = "MANUAL"
+	// GracePeriodReasonStale returns an allocation without operator
+	// activity to the queue, see InboxStalenessPolicy
+	GracePeriodReasonStale GracePeriodReason = "STALE"
 )
 
 func (r GracePeriodReason) IsValid() bool {
 	switch r {
-	case GracePeriodReasonOffline, GracePeriodReasonManual:
+	case GracePeriodReasonOffline, GracePeriodReasonAway, GracePeriodReasonManual, GracePeriodReasonStale:
 		return true
 	}
 	return false
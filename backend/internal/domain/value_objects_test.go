@@ -13,7 +13,7 @@ func TestConversationState_CanTransitionTo(t *testing.T) {
 		{"QUEUED to RESOLVED", ConversationStateQueued, ConversationStateResolved, false},
 		{"ALLOCATED to QUEUED", ConversationStateAllocated, ConversationStateQueued, true},
 		{"ALLOCATED to RESOLVED", ConversationStateAllocated, ConversationStateResolved, true},
-		{"RESOLVED to QUEUED", ConversationStateResolved, ConversationStateQueued, false},
+		{"RESOLVED to QUEUED", ConversationStateResolved, ConversationStateQueued, true},
 		{"RESOLVED to ALLOCATED", ConversationStateResolved, ConversationStateAllocated, false},
 	}
 
@@ -103,6 +103,8 @@ func TestOperatorStatusType_IsValid(t *testing.T) {
 		want   bool
 	}{
 		{"AVAILABLE is valid", OperatorStatusAvailable, true},
+		{"BUSY is valid", OperatorStatusBusy, true},
+		{"AWAY is valid", OperatorStatusAway, true},
 		{"OFFLINE is valid", OperatorStatusOffline, true},
 		{"INVALID is not valid", OperatorStatusType("INVALID"), false},
 	}
@@ -116,6 +118,30 @@ func TestOperatorStatusType_IsValid(t *testing.T) {
 	}
 }
 
+func TestOperatorStatusType_Semantics(t *testing.T) {
+	tests := []struct {
+		status             OperatorStatusType
+		acceptsAllocations bool
+		holdsConversations bool
+	}{
+		{OperatorStatusAvailable, true, true},
+		{OperatorStatusBusy, false, true},
+		{OperatorStatusAway, false, false},
+		{OperatorStatusOffline, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.AcceptsAllocations(); got != tt.acceptsAllocations {
+				t.Errorf("AcceptsAllocations() = %v, want %v", got, tt.acceptsAllocations)
+			}
+			if got := tt.status.HoldsConversations(); got != tt.holdsConversations {
+				t.Errorf("HoldsConversations() = %v, want %v", got, tt.holdsConversations)
+			}
+		})
+	}
+}
+
 func TestGracePeriodReason_IsValid(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -123,7 +149,9 @@ func TestGracePeriodReason_IsValid(t *testing.T) {
 		want   bool
 	}{
 		{"OFFLINE is valid", GracePeriodReasonOffline, true},
+		{"AWAY is valid", GracePeriodReasonAway, true},
 		{"MANUAL is valid", GracePeriodReasonManual, true},
+		{"STALE is valid", GracePeriodReasonStale, true},
 		{"INVALID is not valid", GracePeriodReason("INVALID"), false},
 	}
 
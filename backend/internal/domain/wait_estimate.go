@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== Wait Estimate ====================
+
+// WaitEstimate is the expected wait of a new conversation in an inbox
+type WaitEstimate struct {
+	InboxID uuid.UUID
+	// QueueDepth is the number of conversations waiting for allocation
+	QueueDepth int
+	// AllocationsPerHour is the recent rate at which the inbox's
+	// conversations were allocated or claimed
+	AllocationsPerHour float64
+	// EstimatedWait is nil when nothing was allocated recently, so no
+	// estimate can be made
+	EstimatedWait *time.Duration
+	ComputedAt    time.Time
+}
+
+// NewWaitEstimate estimates the wait of a conversation joining the back of
+// the queue: the time the recent allocation rate needs to drain the queue
+// and take the new conversation. allocations is the count over window.
+func NewWaitEstimate(inboxID uuid.UUID, queueDepth, allocations int, window time.Duration, now time.Time) *WaitEstimate {
+	estimate := &WaitEstimate{
+		InboxID:    inboxID,
+		QueueDepth: queueDepth,
+		ComputedAt: now,
+	}
+	if window <= 0 || allocations <= 0 {
+		return estimate
+	}
+
+	estimate.AllocationsPerHour = float64(allocations) / window.Hours()
+	wait := time.Duration(float64(queueDepth+1) / float64(allocations) * float64(window)).Round(time.Second)
+	estimate.EstimatedWait = &wait
+	return estimate
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWaitEstimate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("drains the queue at the recent rate", func(t *testing.T) {
+		// 12 allocations an hour: one every 5 minutes, 3 ahead of the new one
+		estimate := NewWaitEstimate(uuid.New(), 3, 12, time.Hour, now)
+		require.NotNil(t, estimate.EstimatedWait)
+		assert.Equal(t, 20*time.Minute, *estimate.EstimatedWait)
+		assert.Equal(t, 12.0, estimate.AllocationsPerHour)
+	})
+
+	t.Run("empty queue waits for the next allocation", func(t *testing.T) {
+		estimate := NewWaitEstimate(uuid.New(), 0, 30, 15*time.Minute, now)
+		require.NotNil(t, estimate.EstimatedWait)
+		assert.Equal(t, 30*time.Second, *estimate.EstimatedWait)
+		assert.Equal(t, 120.0, estimate.AllocationsPerHour)
+	})
+
+	t.Run("no recent allocations gives no estimate", func(t *testing.T) {
+		estimate := NewWaitEstimate(uuid.New(), 5, 0, time.Hour, now)
+		assert.Nil(t, estimate.EstimatedWait)
+		assert.Equal(t, 5, estimate.QueueDepth)
+		assert.Zero(t, estimate.AllocationsPerHour)
+	})
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ==================== WebhookDeliveryStatus ====================
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered  WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "DEAD_LETTER"
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryDeadLetter:
+		return true
+	}
+	return false
+}
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// ==================== WebhookOperatorField ====================
+
+// WebhookOperatorField is an operator profile field a webhook opts into
+// receiving with assignment events
+type WebhookOperatorField string
+
+const (
+	WebhookOperatorFieldName  WebhookOperatorField = "name"
+	WebhookOperatorFieldEmail WebhookOperatorField = "email"
+)
+
+func (f WebhookOperatorField) IsValid() bool {
+	switch f {
+	case WebhookOperatorFieldName, WebhookOperatorFieldEmail:
+		return true
+	}
+	return false
+}
+
+// ==================== Webhook ====================
+
+type Webhook struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	// OperatorFields are the operator profile fields added to the payload of
+	// assignment events; empty leaves the payload unenriched
+	OperatorFields []WebhookOperatorField
+	IsActive       bool
+	CreatedBy      *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewWebhook(tenantID uuid.UUID, url, secret string, eventTypes []EventType, createdBy *uuid.UUID) *Webhook {
+	now := time.Now().UTC()
+	return &Webhook{
+		ID:         uuid.Must(uuid.NewV7()),
+		TenantID:   tenantID,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   true,
+		CreatedBy:  createdBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Subscribes reports whether the webhook wants to receive the given event type
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// OperatorPayload returns the "operator" object of an assignment event's
+// payload: the operator's ID and the profile fields the webhook opted into.
+// An opted-in field the operator has not set is null.
+func (w *Webhook) OperatorPayload(operator *Operator) map[string]interface{} {
+	payload := map[string]interface{}{"id": operator.ID.String()}
+	for _, field := range w.OperatorFields {
+		var value *string
+		switch field {
+		case WebhookOperatorFieldName:
+			value = operator.Name
+		case WebhookOperatorFieldEmail:
+			value = operator.Email
+		default:
+			continue
+		}
+		if value != nil {
+			payload[string(field)] = *value
+		} else {
+			payload[string(field)] = nil
+		}
+	}
+	return payload
+}
+
+// ==================== WebhookDelivery ====================
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	TenantID       uuid.UUID
+	EventID        uuid.UUID
+	EventType      EventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	AttemptCount   int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+func NewWebhookDelivery(webhookID, tenantID, eventID uuid.UUID, eventType EventType, payload []byte) *WebhookDelivery {
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		ID:            uuid.Must(uuid.NewV7()),
+		WebhookID:     webhookID,
+		TenantID:      tenantID,
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// MarkDelivered records a successful delivery attempt
+func (d *WebhookDelivery) MarkDelivered(statusCode int) {
+	now := time.Now().UTC()
+	d.AttemptCount++
+	d.Status = WebhookDeliveryDelivered
+	d.LastAttemptAt = &now
+	d.LastStatusCode = &statusCode
+	d.LastError = nil
+	d.DeliveredAt = &now
+}
+
+// MarkFailed records a failed attempt. The delivery is rescheduled at nextAttemptAt,
+// or moved to DEAD_LETTER once maxAttempts has been reached.
+func (d *WebhookDelivery) MarkFailed(statusCode *int, errMsg string, maxAttempts int, nextAttemptAt time.Time) {
+	now := time.Now().UTC()
+	d.AttemptCount++
+	d.LastAttemptAt = &now
+	d.LastStatusCode = statusCode
+	d.LastError = &errMsg
+	if d.AttemptCount >= maxAttempts {
+		d.Status = WebhookDeliveryDeadLetter
+		return
+	}
+	d.NextAttemptAt = nextAttemptAt
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Subscribes(t *testing.T) {
+	webhook := NewWebhook(uuid.New(), "https://example.com/hook", "secret-secret-secret",
+		[]EventType{EventConversationAllocated, EventConversationResolved}, nil)
+
+	assert.True(t, webhook.IsActive)
+	assert.True(t, webhook.Subscribes(EventConversationAllocated))
+	assert.True(t, webhook.Subscribes(EventConversationResolved))
+	assert.False(t, webhook.Subscribes(EventOperatorStatusChanged))
+}
+
+func TestWebhook_OperatorPayload(t *testing.T) {
+	name := "Maria"
+	operator := NewOperator(uuid.New(), OperatorRoleOperator)
+	operator.Name = &name
+
+	webhook := NewWebhook(uuid.New(), "https://example.com/hook", "secret-secret-secret",
+		[]EventType{EventConversationAllocated}, nil)
+	assert.Equal(t, map[string]interface{}{"id": operator.ID.String()}, webhook.OperatorPayload(operator))
+
+	webhook.OperatorFields = []WebhookOperatorField{WebhookOperatorFieldName, WebhookOperatorFieldEmail}
+	assert.Equal(t, map[string]interface{}{
+		"id":    operator.ID.String(),
+		"name":  "Maria",
+		"email": nil,
+	}, webhook.OperatorPayload(operator))
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	d := NewWebhookDelivery(uuid.New(), uuid.New(), uuid.New(), EventConversationResolved, []byte(`{}`))
+
+	require.NotNil(t, d)
+	assert.Equal(t, WebhookDeliveryPending, d.Status)
+	assert.Equal(t, 0, d.AttemptCount)
+	assert.False(t, d.NextAttemptAt.After(time.Now().UTC()))
+	assert.Nil(t, d.DeliveredAt)
+}
+
+func TestWebhookDelivery_MarkDelivered(t *testing.T) {
+	d := NewWebhookDelivery(uuid.New(), uuid.New(), uuid.New(), EventConversationResolved, []byte(`{}`))
+
+	d.MarkDelivered(204)
+
+	assert.Equal(t, WebhookDeliveryDelivered, d.Status)
+	assert.Equal(t, 1, d.AttemptCount)
+	require.NotNil(t, d.LastStatusCode)
+	assert.Equal(t, 204, *d.LastStatusCode)
+	assert.NotNil(t, d.DeliveredAt)
+	assert.Nil(t, d.LastError)
+}
+
+func TestWebhookDelivery_MarkFailed(t *testing.T) {
+	t.Run("reschedules while attempts remain", func(t *testing.T) {
+		d := NewWebhookDelivery(uuid.New(), uuid.New(), uuid.New(), EventConversationResolved, []byte(`{}`))
+		next := time.Now().UTC().Add(time.Minute)
+		status := 503
+
+		d.MarkFailed(&status, "endpoint responded with status 503", 3, next)
+
+		assert.Equal(t, WebhookDeliveryPending, d.Status)
+		assert.Equal(t, 1, d.AttemptCount)
+		assert.Equal(t, next, d.NextAttemptAt)
+		require.NotNil(t, d.LastError)
+	})
+
+	t.Run("moves to dead letter at max attempts", func(t *testing.T) {
+		d := NewWebhookDelivery(uuid.New(), uuid.New(), uuid.New(), EventConversationResolved, []byte(`{}`))
+
+		for i := 0; i < 3; i++ {
+			d.MarkFailed(nil, "connection refused", 3, time.Now().UTC())
+		}
+
+		assert.Equal(t, WebhookDeliveryDeadLetter, d.Status)
+		assert.Equal(t, 3, d.AttemptCount)
+	})
+}
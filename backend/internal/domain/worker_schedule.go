@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// WorkerSchedule describes when a background worker of this replica runs
+type WorkerSchedule struct {
+	Name string
+	// Schedule is "every <interval>" or "cron <expression>"; empty for
+	// workers that wait for notifications instead of running on a schedule
+	Schedule string
+	Interval time.Duration
+	Cron     string
+	// Jitter is the most each run is delayed at random
+	Jitter time.Duration
+	// Phase delays the first run, spreading workers sharing an interval
+	Phase   time.Duration
+	Running bool
+	// NextRunAt is nil while the worker is stopped; LastRunAt before its
+	// first run
+	NextRunAt *time.Time
+	LastRunAt *time.Time
+}
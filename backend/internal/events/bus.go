@@ -0,0 +1,90 @@
+// Package events publishes domain events to an external message bus, so
+// downstream systems such as analytics can consume allocation activity
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Drivers selectable in Config
+const (
+	DriverNone  = "none"
+	DriverKafka = "kafka"
+	DriverNATS  = "nats"
+)
+
+// Message is one event on the bus
+type Message struct {
+	// Topic is the Kafka topic, or the NATS subject prefix
+	Topic string
+	// Type is the event type; a Kafka record header and the last token of
+	// the NATS subject
+	Type string
+	// Key orders messages: Kafka sends equal keys to the same partition
+	Key   string
+	Value []byte
+}
+
+// Bus publishes messages to an external broker. Implementations must be safe
+// for concurrent use.
+type Bus interface {
+	// Publish returns once the broker has accepted the message
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// Config selects and configures the bus
+type Config struct {
+	// Driver is DriverNone, DriverKafka or DriverNATS
+	Driver string
+	// Addrs are the Kafka bootstrap brokers, or the NATS server, as host:port
+	Addrs []string
+	// ClientID identifies the service to the broker
+	ClientID string
+	// Timeout bounds dialing and each request unless the context ends sooner
+	Timeout time.Duration
+	// Username, Password and Token authenticate to NATS
+	Username string
+	Password string
+	Token    string
+	// Acks is the Kafka acknowledgement level: 1 (leader) or -1 (all replicas)
+	Acks int
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		Driver:   DriverNone,
+		ClientID: "inbox-allocation-service",
+		Timeout:  5 * time.Second,
+		Acks:     -1,
+	}
+}
+
+// NewBus creates the configured bus; nil for DriverNone. No connection is
+// made until the first message.
+func NewBus(cfg Config) (Bus, error) {
+	switch cfg.Driver {
+	case "", DriverNone:
+		return nil, nil
+	case DriverKafka, DriverNATS:
+	default:
+		return nil, fmt.Errorf("events: unknown driver %q", cfg.Driver)
+	}
+	if len(cfg.Addrs) == 0 {
+		return nil, fmt.Errorf("events: %s needs at least one address", cfg.Driver)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultConfig().Timeout
+	}
+
+	if cfg.Driver == DriverKafka {
+		if cfg.Acks != 1 && cfg.Acks != -1 {
+			return nil, fmt.Errorf("events: kafka acks must be 1 or -1, got %d", cfg.Acks)
+		}
+		return NewKafka(cfg), nil
+	}
+	return NewNATS(cfg), nil
+}
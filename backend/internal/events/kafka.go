@@ -0,0 +1,567 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kafka protocol API keys and versions spoken by the producer
+const (
+	kafkaAPIProduce  int16 = 0
+	kafkaAPIMetadata int16 = 3
+
+	kafkaProduceVersion  int16 = 3
+	kafkaMetadataVersion int16 = 1
+
+	// kafkaMaxResponse bounds the response size read from a broker
+	kafkaMaxResponse = 64 << 20
+)
+
+// KafkaError is an error code returned by a broker
+type KafkaError int16
+
+// Error codes after which the cached partition leaders are stale
+const (
+	kafkaUnknownTopicOrPartition KafkaError = 3
+	kafkaLeaderNotAvailable      KafkaError = 5
+	kafkaNotLeaderOrFollower     KafkaError = 6
+)
+
+func (e KafkaError) Error() string {
+	switch e {
+	case kafkaUnknownTopicOrPartition:
+		return "kafka: unknown topic or partition"
+	case kafkaLeaderNotAvailable:
+		return "kafka: leader not available"
+	case kafkaNotLeaderOrFollower:
+		return "kafka: not leader or follower"
+	}
+	return "kafka: error code " + strconv.Itoa(int(e))
+}
+
+func (e KafkaError) staleMetadata() bool {
+	return e == kafkaUnknownTopicOrPartition || e == kafkaLeaderNotAvailable || e == kafkaNotLeaderOrFollower
+}
+
+var (
+	errKafkaMalformed   = errors.New("kafka: malformed response")
+	errKafkaNoBroker    = errors.New("kafka: no bootstrap broker reachable")
+	errKafkaNoPartition = errors.New("kafka: topic has no partitions")
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// Kafka is a Bus backed by a Kafka cluster. It speaks just enough of the
+// Kafka protocol to look up partition leaders (Metadata v1) and produce one
+// uncompressed record at a time (Produce v3, record batch v2), without
+// transactions or idempotence. Keyed messages go to the partition the Java
+// client's default partitioner would pick, so consumers see the events of a
+// conversation in order. Leaders are cached per topic and looked up again
+// after an error; the caller retries.
+type Kafka struct {
+	config Config
+
+	correlation atomic.Int32
+	roundRobin  atomic.Uint32
+
+	mu      sync.Mutex
+	brokers map[int32]string
+	topics  map[string][]int32
+	conns   map[string]*kafkaConn
+}
+
+// kafkaConn carries one request at a time
+type kafkaConn struct {
+	mu sync.Mutex
+	net.Conn
+	rd *bufio.Reader
+}
+
+// NewKafka creates a Kafka bus bootstrapping from cfg.Addrs
+func NewKafka(cfg Config) *Kafka {
+	return &Kafka{
+		config:  cfg,
+		brokers: make(map[int32]string),
+		topics:  make(map[string][]int32),
+		conns:   make(map[string]*kafkaConn),
+	}
+}
+
+func (k *Kafka) Publish(ctx context.Context, msg Message) error {
+	leaders, err := k.leaders(ctx, msg.Topic)
+	if err != nil {
+		return err
+	}
+
+	var partition int32
+	if msg.Key != "" {
+		partition = int32(uint32(murmur2([]byte(msg.Key))&0x7fffffff) % uint32(len(leaders)))
+	} else {
+		partition = int32(k.roundRobin.Add(1) % uint32(len(leaders)))
+	}
+
+	k.mu.Lock()
+	addr, ok := k.brokers[leaders[partition]]
+	k.mu.Unlock()
+	if !ok {
+		k.forget(msg.Topic)
+		return kafkaLeaderNotAvailable
+	}
+
+	err = k.produce(ctx, addr, msg, partition)
+	var kafkaErr KafkaError
+	if errors.As(err, &kafkaErr) && kafkaErr.staleMetadata() {
+		k.forget(msg.Topic)
+	}
+	return err
+}
+
+// Close closes every broker connection
+func (k *Kafka) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for addr, c := range k.conns {
+		c.Close()
+		delete(k.conns, addr)
+	}
+	return nil
+}
+
+// ==================== Produce ====================
+
+func (k *Kafka) produce(ctx context.Context, addr string, msg Message, partition int32) error {
+	batch := encodeRecordBatch(msg, time.Now())
+
+	var body kafkaEncoder
+	body.nullableString(nil) // transactional_id
+	body.int16(int16(k.config.Acks))
+	body.int32(int32(k.config.Timeout.Milliseconds()))
+	body.int32(1) // topics
+	body.string(msg.Topic)
+	body.int32(1) // partitions
+	body.int32(partition)
+	body.bytes(batch)
+
+	resp, err := k.roundTrip(ctx, addr, kafkaAPIProduce, kafkaProduceVersion, body)
+	if err != nil {
+		return err
+	}
+
+	d := kafkaDecoder{buf: resp}
+	for topics := d.int32(); topics > 0 && d.err == nil; topics-- {
+		d.string()
+		for partitions := d.int32(); partitions > 0 && d.err == nil; partitions-- {
+			d.int32()
+			code := d.int16()
+			d.int64() // base_offset
+			d.int64() // log_append_time
+			if d.err == nil && code != 0 {
+				return KafkaError(code)
+			}
+		}
+	}
+	return d.err
+}
+
+// encodeRecordBatch encodes msg as a v2 record batch of one record
+func encodeRecordBatch(msg Message, now time.Time) []byte {
+	var record kafkaEncoder
+	record.buf = append(record.buf, 0)              // attributes
+	record.buf = binary.AppendVarint(record.buf, 0) // timestamp delta
+	record.buf = binary.AppendVarint(record.buf, 0) // offset delta
+	if msg.Key == "" {
+		record.buf = binary.AppendVarint(record.buf, -1)
+	} else {
+		record.varintBytes([]byte(msg.Key))
+	}
+	record.varintBytes(msg.Value)
+	if msg.Type == "" {
+		record.buf = binary.AppendVarint(record.buf, 0)
+	} else {
+		record.buf = binary.AppendVarint(record.buf, 1)
+		record.varintBytes([]byte("event_type"))
+		record.varintBytes([]byte(msg.Type))
+	}
+
+	// Everything from attributes on is covered by the CRC
+	var tail kafkaEncoder
+	ms := now.UnixMilli()
+	tail.int16(0) // attributes: no compression, create time
+	tail.int32(0) // last offset delta
+	tail.int64(ms)
+	tail.int64(ms)
+	tail.int64(-1) // producer id
+	tail.int16(-1) // producer epoch
+	tail.int32(-1) // base sequence
+	tail.int32(1)  // records
+	tail.buf = binary.AppendVarint(tail.buf, int64(len(record.buf)))
+	tail.buf = append(tail.buf, record.buf...)
+
+	var batch kafkaEncoder
+	batch.int64(0) // base offset
+	batch.int32(int32(4 + 1 + 4 + len(tail.buf)))
+	batch.int32(-1)                  // partition leader epoch
+	batch.buf = append(batch.buf, 2) // magic
+	batch.buf = binary.BigEndian.AppendUint32(batch.buf, crc32.Checksum(tail.buf, crc32c))
+	batch.buf = append(batch.buf, tail.buf...)
+	return batch.buf
+}
+
+// ==================== Metadata ====================
+
+// leaders returns the leader broker of each partition of the topic
+func (k *Kafka) leaders(ctx context.Context, topic string) ([]int32, error) {
+	k.mu.Lock()
+	leaders, ok := k.topics[topic]
+	k.mu.Unlock()
+	if ok {
+		return leaders, nil
+	}
+
+	var body kafkaEncoder
+	body.int32(1)
+	body.string(topic)
+
+	var (
+		resp []byte
+		err  = errKafkaNoBroker
+	)
+	for _, addr := range k.config.Addrs {
+		resp, err = k.roundTrip(ctx, addr, kafkaAPIMetadata, kafkaMetadataVersion, body)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	brokers, leaders, err := decodeMetadata(resp, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	for id, addr := range brokers {
+		k.brokers[id] = addr
+	}
+	k.topics[topic] = leaders
+	k.mu.Unlock()
+	return leaders, nil
+}
+
+// decodeMetadata returns the broker addresses by node ID and the leader of
+// each partition of the topic, indexed by partition
+func decodeMetadata(resp []byte, topic string) (map[int32]string, []int32, error) {
+	d := kafkaDecoder{buf: resp}
+
+	brokers := make(map[int32]string)
+	for n := d.int32(); n > 0 && d.err == nil; n-- {
+		id := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.nullableString() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	d.int32() // controller id
+
+	var leaders []int32
+	for n := d.int32(); n > 0 && d.err == nil; n-- {
+		code := d.int16()
+		name := d.string()
+		d.int8() // is_internal
+		partitions := d.int32()
+		if partitions < 0 || int(partitions) > len(d.buf) {
+			return nil, nil, errKafkaMalformed
+		}
+		byIndex := make([]int32, partitions)
+		for i := int32(0); i < partitions && d.err == nil; i++ {
+			d.int16() // partition error code
+			index := d.int32()
+			leader := d.int32()
+			d.int32Array() // replicas
+			d.int32Array() // isr
+			if index >= 0 && index < partitions {
+				byIndex[index] = leader
+			}
+		}
+		if name != topic {
+			continue
+		}
+		if code != 0 {
+			return nil, nil, KafkaError(code)
+		}
+		leaders = byIndex
+	}
+	if d.err != nil {
+		return nil, nil, d.err
+	}
+	if len(leaders) == 0 {
+		return nil, nil, errKafkaNoPartition
+	}
+	return brokers, leaders, nil
+}
+
+// forget drops the topic's cached leaders so the next message looks them up
+func (k *Kafka) forget(topic string) {
+	k.mu.Lock()
+	delete(k.topics, topic)
+	k.mu.Unlock()
+}
+
+// ==================== Connections ====================
+
+// roundTrip sends one request to the broker and returns the response body.
+// A connection that saw an error is closed and dialed again next time.
+func (k *Kafka) roundTrip(ctx context.Context, addr string, apiKey, version int16, body kafkaEncoder) ([]byte, error) {
+	c, err := k.conn(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp, err := c.roundTrip(ctx, k.config.Timeout, k.header(apiKey, version), body.buf)
+	if err != nil {
+		k.mu.Lock()
+		if k.conns[addr] == c {
+			delete(k.conns, addr)
+		}
+		k.mu.Unlock()
+		c.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// header encodes a v1 request header with a fresh correlation ID
+func (k *Kafka) header(apiKey, version int16) kafkaEncoder {
+	var h kafkaEncoder
+	h.int16(apiKey)
+	h.int16(version)
+	h.int32(k.correlation.Add(1))
+	h.string(k.config.ClientID)
+	return h
+}
+
+func (k *Kafka) conn(ctx context.Context, addr string) (*kafkaConn, error) {
+	k.mu.Lock()
+	c, ok := k.conns[addr]
+	k.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	dialer := net.Dialer{Timeout: k.config.Timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c = &kafkaConn{Conn: nc, rd: bufio.NewReader(nc)}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if existing, ok := k.conns[addr]; ok {
+		// Another publisher dialed first
+		nc.Close()
+		return existing, nil
+	}
+	k.conns[addr] = c
+	return c, nil
+}
+
+func (c *kafkaConn) roundTrip(ctx context.Context, timeout time.Duration, header kafkaEncoder, body []byte) ([]byte, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	req := make([]byte, 0, 4+len(header.buf)+len(body))
+	req = binary.BigEndian.AppendUint32(req, uint32(len(header.buf)+len(body)))
+	req = append(req, header.buf...)
+	req = append(req, body...)
+	if _, err := c.Write(req); err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(c.rd, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < 4 || n > kafkaMaxResponse {
+		return nil, errKafkaMalformed
+	}
+	resp := make([]byte, n)
+	if _, err := io.ReadFull(c.rd, resp); err != nil {
+		return nil, err
+	}
+
+	// Responses come in request order; the correlation ID must match ours
+	want := binary.BigEndian.Uint32(header.buf[4:8])
+	if got := binary.BigEndian.Uint32(resp[:4]); got != want {
+		return nil, fmt.Errorf("%w: correlation id %d, want %d", errKafkaMalformed, got, want)
+	}
+	return resp[4:], nil
+}
+
+// ==================== Encoding ====================
+
+type kafkaEncoder struct {
+	buf []byte
+}
+
+func (e *kafkaEncoder) int16(v int16) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v))
+}
+
+func (e *kafkaEncoder) int32(v int32) {
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v))
+}
+
+func (e *kafkaEncoder) int64(v int64) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v))
+}
+
+func (e *kafkaEncoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *kafkaEncoder) nullableString(s *string) {
+	if s == nil {
+		e.int16(-1)
+		return
+	}
+	e.string(*s)
+}
+
+func (e *kafkaEncoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *kafkaEncoder) varintBytes(b []byte) {
+	e.buf = binary.AppendVarint(e.buf, int64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// kafkaDecoder reads big-endian fields; after the first short read every
+// field is zero and err is set
+type kafkaDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *kafkaDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.buf) < n {
+		d.err = errKafkaMalformed
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) string() string {
+	return string(d.next(int(d.int16())))
+}
+
+func (d *kafkaDecoder) nullableString() *string {
+	n := d.int16()
+	if n < 0 {
+		return nil
+	}
+	s := string(d.next(int(n)))
+	return &s
+}
+
+func (d *kafkaDecoder) int32Array() {
+	n := d.int32()
+	if n > 0 {
+		d.next(4 * int(n))
+	}
+}
+
+// murmur2 is the hash of the Java client's default partitioner
+func murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	length := len(data)
+	h := seed ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length & 3 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
@@ -0,0 +1,310 @@
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecord is a record the fake broker accepted
+type fakeRecord struct {
+	Topic     string
+	Partition int32
+	Key       string
+	Value     string
+	Headers   map[string]string
+}
+
+// fakeKafka is a single broker leading every partition of every topic. It
+// answers Metadata v1 and Produce v3 and fails the next produce with
+// produceErr when set.
+type fakeKafka struct {
+	ln         net.Listener
+	partitions int32
+
+	mu         sync.Mutex
+	metadata   int
+	produceErr KafkaError
+	records    []fakeRecord
+	badCRC     int
+}
+
+func newFakeKafka(t *testing.T, partitions int32) *fakeKafka {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeKafka{ln: ln, partitions: partitions}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+	return f
+}
+
+func (f *fakeKafka) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeKafka) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		d := kafkaDecoder{buf: req}
+		apiKey := d.int16()
+		d.int16() // version
+		correlation := d.int32()
+		d.string() // client id
+
+		var resp kafkaEncoder
+		resp.int32(correlation)
+		switch apiKey {
+		case kafkaAPIMetadata:
+			f.writeMetadata(&resp, &d)
+		case kafkaAPIProduce:
+			f.writeProduce(&resp, &d)
+		default:
+			return
+		}
+
+		out := binary.BigEndian.AppendUint32(nil, uint32(len(resp.buf)))
+		if _, err := conn.Write(append(out, resp.buf...)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeKafka) writeMetadata(resp *kafkaEncoder, d *kafkaDecoder) {
+	d.int32()
+	topic := d.string()
+
+	f.mu.Lock()
+	f.metadata++
+	f.mu.Unlock()
+
+	host, port, _ := net.SplitHostPort(f.ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	resp.int32(1) // brokers
+	resp.int32(1)
+	resp.string(host)
+	resp.int32(int32(portNum))
+	resp.nullableString(nil)
+	resp.int32(1) // controller
+	resp.int32(1) // topics
+	resp.int16(0)
+	resp.string(topic)
+	resp.buf = append(resp.buf, 0)
+	resp.int32(f.partitions)
+	for i := int32(0); i < f.partitions; i++ {
+		resp.int16(0)
+		resp.int32(i)
+		resp.int32(1) // leader
+		resp.int32(1)
+		resp.int32(1)
+		resp.int32(1)
+		resp.int32(1)
+	}
+}
+
+func (f *fakeKafka) writeProduce(resp *kafkaEncoder, d *kafkaDecoder) {
+	d.nullableString() // transactional id
+	d.int16()          // acks
+	d.int32()          // timeout
+	d.int32()          // topics
+	topic := d.string()
+	d.int32() // partitions
+	partition := d.int32()
+	batch := d.next(int(d.int32()))
+
+	f.mu.Lock()
+	code := f.produceErr
+	f.produceErr = 0
+	if code == 0 {
+		if record, ok := decodeFakeBatch(batch); ok {
+			record.Topic, record.Partition = topic, partition
+			f.records = append(f.records, record)
+		} else {
+			f.badCRC++
+			code = 2 // CORRUPT_MESSAGE
+		}
+	}
+	f.mu.Unlock()
+
+	resp.int32(1)
+	resp.string(topic)
+	resp.int32(1)
+	resp.int32(partition)
+	resp.int16(int16(code))
+	resp.int64(0)
+	resp.int64(-1)
+	resp.int32(0) // throttle time
+}
+
+// decodeFakeBatch checks the batch CRC and decodes its single record
+func decodeFakeBatch(batch []byte) (fakeRecord, bool) {
+	if len(batch) < 21 || batch[16] != 2 {
+		return fakeRecord{}, false
+	}
+	if crc32.Checksum(batch[21:], crc32c) != binary.BigEndian.Uint32(batch[17:21]) {
+		return fakeRecord{}, false
+	}
+
+	// Skip to the record: attributes through the record count are 40 bytes
+	buf := batch[21+40:]
+	varint := func() int64 {
+		v, n := binary.Varint(buf)
+		buf = buf[n:]
+		return v
+	}
+	bytes := func() string {
+		n := varint()
+		if n < 0 {
+			return ""
+		}
+		s := string(buf[:n])
+		buf = buf[n:]
+		return s
+	}
+
+	varint() // length
+	buf = buf[1:]
+	varint() // timestamp delta
+	varint() // offset delta
+	record := fakeRecord{Key: bytes(), Value: bytes(), Headers: make(map[string]string)}
+	for n := varint(); n > 0; n-- {
+		k := bytes()
+		record.Headers[k] = bytes()
+	}
+	return record, true
+}
+
+func newTestKafka(srv *fakeKafka) *Kafka {
+	cfg := DefaultConfig()
+	cfg.Addrs = []string{srv.ln.Addr().String()}
+	return NewKafka(cfg)
+}
+
+func TestKafka_Publish(t *testing.T) {
+	srv := newFakeKafka(t, 3)
+	bus := newTestKafka(srv)
+	defer bus.Close()
+	ctx := context.Background()
+
+	msg := Message{Topic: "allocation", Type: "conversation.allocated", Key: "conv-1", Value: []byte(`{"id":1}`)}
+	require.NoError(t, bus.Publish(ctx, msg))
+	require.NoError(t, bus.Publish(ctx, msg))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Len(t, srv.records, 2)
+	assert.Zero(t, srv.badCRC)
+	assert.Equal(t, 1, srv.metadata, "leaders are cached")
+
+	want := int32(uint32(murmur2([]byte("conv-1"))&0x7fffffff) % 3)
+	for _, r := range srv.records {
+		assert.Equal(t, fakeRecord{
+			Topic:     "allocation",
+			Partition: want,
+			Key:       "conv-1",
+			Value:     `{"id":1}`,
+			Headers:   map[string]string{"event_type": "conversation.allocated"},
+		}, r)
+	}
+}
+
+func TestKafka_StaleLeaderRefreshesMetadata(t *testing.T) {
+	srv := newFakeKafka(t, 1)
+	bus := newTestKafka(srv)
+	defer bus.Close()
+	ctx := context.Background()
+	msg := Message{Topic: "allocation", Type: "conversation.resolved", Key: "conv-1", Value: []byte("{}")}
+
+	require.NoError(t, bus.Publish(ctx, msg))
+
+	srv.mu.Lock()
+	srv.produceErr = kafkaNotLeaderOrFollower
+	srv.mu.Unlock()
+
+	var kafkaErr KafkaError
+	require.ErrorAs(t, bus.Publish(ctx, msg), &kafkaErr)
+	assert.Equal(t, kafkaNotLeaderOrFollower, kafkaErr)
+
+	require.NoError(t, bus.Publish(ctx, msg))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, 2, srv.metadata)
+	assert.Len(t, srv.records, 2)
+}
+
+func TestKafka_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := DefaultConfig()
+	cfg.Addrs = []string{addr}
+	cfg.Timeout = 100 * time.Millisecond
+	err = NewKafka(cfg).Publish(context.Background(), Message{Topic: "t", Type: "e"})
+	assert.Error(t, err)
+}
+
+func TestMurmur2(t *testing.T) {
+	// Vectors from the Java client's partitioner tests
+	tests := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, murmur2([]byte(input)), input)
+	}
+}
+
+func TestNewBus(t *testing.T) {
+	bus, err := NewBus(DefaultConfig())
+	require.NoError(t, err)
+	assert.Nil(t, bus)
+
+	cfg := DefaultConfig()
+	cfg.Driver = DriverKafka
+	_, err = NewBus(cfg)
+	assert.Error(t, err, "addresses are required")
+
+	cfg.Addrs = []string{"localhost:9092"}
+	cfg.Acks = 0
+	_, err = NewBus(cfg)
+	assert.Error(t, err, "fire-and-forget acks are rejected")
+
+	cfg.Driver = "rabbitmq"
+	_, err = NewBus(cfg)
+	assert.Error(t, err)
+
+	cfg.Driver = DriverNATS
+	bus, err = NewBus(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &NATS{}, bus)
+}
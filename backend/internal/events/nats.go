@@ -0,0 +1,193 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSError is an -ERR message from the server
+type NATSError string
+
+func (e NATSError) Error() string {
+	return "nats: " + string(e)
+}
+
+var errUnexpectedNATSReply = errors.New("nats: unexpected reply")
+
+// NATS is a Bus backed by a NATS server. It speaks just enough of the core
+// protocol to CONNECT and PUB, and follows every PUB with a PING so Publish
+// returns only once the server has processed the message. Messages go to the
+// subject "<topic>.<type>". One connection is dialed lazily and redialed
+// after an error.
+type NATS struct {
+	config Config
+
+	mu   sync.Mutex
+	conn *natsConn
+}
+
+type natsConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+// NewNATS creates a NATS bus publishing to the first of cfg.Addrs
+func NewNATS(cfg Config) *NATS {
+	return &NATS{config: cfg}
+}
+
+func (n *NATS) Publish(ctx context.Context, msg Message) error {
+	subject := msg.Topic + "." + msg.Type
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	c, err := n.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, len(subject)+len(msg.Value)+32)
+	buf = append(buf, "PUB "...)
+	buf = append(buf, subject...)
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(len(msg.Value)), 10)
+	buf = append(buf, "\r\n"...)
+	buf = append(buf, msg.Value...)
+	buf = append(buf, "\r\nPING\r\n"...)
+
+	if err := c.flush(ctx, n.config.Timeout, buf); err != nil {
+		// The connection may be half-written: start over on the next message
+		n.conn.Close()
+		n.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
+
+// connect returns the open connection, dialing and handshaking a new one
+// if there is none
+func (n *NATS) connect(ctx context.Context) (*natsConn, error) {
+	if n.conn != nil {
+		return n.conn, nil
+	}
+
+	addr := strings.TrimPrefix(n.config.Addrs[0], "nats://")
+	dialer := net.Dialer{Timeout: n.config.Timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &natsConn{Conn: nc, rd: bufio.NewReader(nc)}
+
+	if err := c.handshake(ctx, n.config); err != nil {
+		c.Close()
+		return nil, err
+	}
+	n.conn = c
+	return c, nil
+}
+
+// handshake reads the server's INFO and sends CONNECT
+func (c *natsConn) handshake(ctx context.Context, cfg Config) error {
+	if err := c.setDeadline(ctx, cfg.Timeout); err != nil {
+		return err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return errUnexpectedNATSReply
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"lang":     "go",
+		"name":     cfg.ClientID,
+	}
+	if cfg.Username != "" {
+		options["user"] = cfg.Username
+		options["pass"] = cfg.Password
+	}
+	if cfg.Token != "" {
+		options["auth_token"] = cfg.Token
+	}
+	body, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 0, len(body)+16)
+	buf = append(buf, "CONNECT "...)
+	buf = append(buf, body...)
+	buf = append(buf, "\r\nPING\r\n"...)
+	return c.flush(ctx, cfg.Timeout, buf)
+}
+
+// flush writes buf, which must end with a PING, and waits for the PONG.
+// Server PINGs are answered; an -ERR fails the flush.
+func (c *natsConn) flush(ctx context.Context, timeout time.Duration, buf []byte) error {
+	if err := c.setDeadline(ctx, timeout); err != nil {
+		return err
+	}
+	if _, err := c.Write(buf); err != nil {
+		return err
+	}
+
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := c.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return NATSError(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		default:
+			return errUnexpectedNATSReply
+		}
+	}
+}
+
+func (c *natsConn) setDeadline(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	return c.SetDeadline(deadline)
+}
+
+func (c *natsConn) readLine() (string, error) {
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,147 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATS accepts CONNECT, PUB and PING and records what it received
+type fakeNATS struct {
+	ln    net.Listener
+	token string
+
+	mu        sync.Mutex
+	connects  int
+	options   map[string]interface{}
+	published map[string]string
+}
+
+func newFakeNATS(t *testing.T, token string) *fakeNATS {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeNATS{ln: ln, token: token, published: make(map[string]string)}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+	return f
+}
+
+func (f *fakeNATS) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeNATS) handle(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	if _, err := io.WriteString(conn, `INFO {"server_id":"fake","max_payload":1048576}`+"\r\n"); err != nil {
+		return
+	}
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		op, args, _ := strings.Cut(line, " ")
+
+		var reply string
+		switch op {
+		case "CONNECT":
+			var options map[string]interface{}
+			_ = json.Unmarshal([]byte(args), &options)
+			f.mu.Lock()
+			f.connects++
+			f.options = options
+			f.mu.Unlock()
+			if f.token != "" && options["auth_token"] != f.token {
+				io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PUB":
+			fields := strings.Fields(args)
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(rd, payload); err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.published[fields[0]] = string(payload[:n])
+			f.mu.Unlock()
+		case "PING":
+			reply = "PONG\r\n"
+		default:
+			reply = "-ERR 'Unknown Protocol Operation'\r\n"
+		}
+		if reply != "" {
+			if _, err := io.WriteString(conn, reply); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestNATS_Publish(t *testing.T) {
+	srv := newFakeNATS(t, "")
+	cfg := DefaultConfig()
+	cfg.Addrs = []string{"nats://" + srv.ln.Addr().String()}
+	bus := NewNATS(cfg)
+	defer bus.Close()
+	ctx := context.Background()
+
+	require.NoError(t, bus.Publish(ctx, Message{Topic: "allocation", Type: "conversation.allocated", Value: []byte(`{"id":1}`)}))
+	require.NoError(t, bus.Publish(ctx, Message{Topic: "allocation", Type: "conversation.resolved", Value: []byte(`{"id":2}`)}))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, map[string]string{
+		"allocation.conversation.allocated": `{"id":1}`,
+		"allocation.conversation.resolved":  `{"id":2}`,
+	}, srv.published)
+	assert.Equal(t, 1, srv.connects, "one connection for both messages")
+	assert.Equal(t, "inbox-allocation-service", srv.options["name"])
+}
+
+func TestNATS_Auth(t *testing.T) {
+	srv := newFakeNATS(t, "secret")
+	ctx := context.Background()
+	msg := Message{Topic: "allocation", Type: "conversation.created", Value: []byte("{}")}
+
+	cfg := DefaultConfig()
+	cfg.Addrs = []string{srv.ln.Addr().String()}
+	cfg.Token = "secret"
+	require.NoError(t, NewNATS(cfg).Publish(ctx, msg))
+
+	cfg.Token = "wrong"
+	var natsErr NATSError
+	require.ErrorAs(t, NewNATS(cfg).Publish(ctx, msg), &natsErr)
+	assert.Equal(t, NATSError("Authorization Violation"), natsErr)
+}
+
+func TestNATS_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := DefaultConfig()
+	cfg.Addrs = []string{addr}
+	cfg.Timeout = 100 * time.Millisecond
+	err = NewNATS(cfg).Publish(context.Background(), Message{Topic: "t", Type: "e"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrKeyNotFound is returned when no key in the JWKS matches the token's kid
+var ErrKeyNotFound = errors.New("signing key not found")
+
+// maxJWKSBytes bounds the JWKS and discovery documents read from the issuer
+const maxJWKSBytes = 1 << 20
+
+// KeySet caches the issuer's JSON Web Key Set. Keys are refetched after
+// RefreshInterval, or early when a token names an unknown kid (key rotation),
+// at most once per MinRefreshInterval. Safe for concurrent use.
+type KeySet struct {
+	jwksURL string
+	issuer  string
+	config  Config
+	client  *http.Client
+	now     func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewKeySet creates a key set for cfg. Without JWKSURL the URL is discovered
+// from the issuer's /.well-known/openid-configuration on first use.
+func NewKeySet(cfg Config) *KeySet {
+	return &KeySet{
+		jwksURL: cfg.JWKSURL,
+		issuer:  cfg.Issuer,
+		config:  cfg,
+		client:  &http.Client{Timeout: cfg.FetchTimeout},
+		now:     time.Now,
+	}
+}
+
+// Key returns the public key for kid. An empty kid matches the only key of a
+// single-key set.
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	stale := k.keys == nil || now.Sub(k.fetchedAt) >= k.config.RefreshInterval
+	if key, ok := k.lookup(kid); ok && !stale {
+		return key, nil
+	}
+
+	if k.keys == nil || now.Sub(k.attemptedAt) >= k.config.MinRefreshInterval {
+		k.attemptedAt = now
+		if err := k.refresh(ctx); err != nil && k.keys == nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+func (k *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+func (k *KeySet) refresh(ctx context.Context) error {
+	if k.jwksURL == "" {
+		url, err := k.discover(ctx)
+		if err != nil {
+			return err
+		}
+		k.jwksURL = url
+	}
+
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := k.getJSON(ctx, k.jwksURL, &doc); err != nil {
+		return fmt.Errorf("fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Unsupported key types are skipped, not fatal
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	k.keys = keys
+	k.fetchedAt = k.now()
+	return nil
+}
+
+func (k *KeySet) discover(ctx context.Context) (string, error) {
+	if k.issuer == "" {
+		return "", errors.New("jwks url or issuer is required")
+	}
+	var doc struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	url := strings.TrimSuffix(k.issuer, "/") + "/.well-known/openid-configuration"
+	if err := k.getJSON(ctx, url, &doc); err != nil {
+		return "", fmt.Errorf("oidc discovery: %w", err)
+	}
+	if doc.JWKSURI == "" {
+		return "", errors.New("oidc discovery: jwks_uri missing")
+	}
+	return doc.JWKSURI, nil
+}
+
+func (k *KeySet) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(out)
+}
+
+// ==================== JSON Web Keys ====================
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (j jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("rsa exponent out of range")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("ec point not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
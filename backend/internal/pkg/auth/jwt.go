@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken       = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature     = errors.New("invalid token signature")
+	ErrTokenExpired         = errors.New("token expired")
+	ErrTokenNotYetValid     = errors.New("token not yet valid")
+	ErrInvalidIssuer        = errors.New("invalid token issuer")
+	ErrInvalidAudience      = errors.New("invalid token audience")
+)
+
+// Config holds JWT verification configuration
+type Config struct {
+	// Issuer is compared with the iss claim and used for OIDC discovery
+	Issuer string
+	// Audience, when set, must be listed in the aud claim
+	Audience string
+	// JWKSURL overrides the jwks_uri discovered from the issuer
+	JWKSURL string
+	// Leeway tolerates clock skew when checking exp and nbf
+	Leeway time.Duration
+	// RefreshInterval is how long fetched keys are used before refetching
+	RefreshInterval time.Duration
+	// MinRefreshInterval rate-limits refetches triggered by unknown key ids
+	MinRefreshInterval time.Duration
+	// FetchTimeout bounds a single JWKS or discovery request
+	FetchTimeout time.Duration
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		Leeway:             30 * time.Second,
+		RefreshInterval:    1 * time.Hour,
+		MinRefreshInterval: 1 * time.Minute,
+		FetchTimeout:       5 * time.Second,
+	}
+}
+
+// KeyProvider resolves the public key for a token's kid header
+type KeyProvider interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+// Claims are the verified claims of a token
+type Claims map[string]interface{}
+
+// String returns a string claim, or "" when absent or not a string
+func (c Claims) String(name string) string {
+	s, _ := c[name].(string)
+	return s
+}
+
+// Verifier validates signed JWTs (RS256/384/512, ES256/384/512) against the
+// issuer's keys
+type Verifier struct {
+	config Config
+	keys   KeyProvider
+	now    func() time.Time
+}
+
+// NewVerifier creates a verifier that fetches keys from the issuer's JWKS
+func NewVerifier(cfg Config) *Verifier {
+	cfg = withDefaults(cfg)
+	return NewVerifierWithKeys(cfg, NewKeySet(cfg))
+}
+
+// NewVerifierWithKeys creates a verifier using keys from the given provider
+func NewVerifierWithKeys(cfg Config, keys KeyProvider) *Verifier {
+	return &Verifier{config: withDefaults(cfg), keys: keys, now: time.Now}
+}
+
+func withDefaults(cfg Config) Config {
+	defaults := DefaultConfig()
+	if cfg.Leeway < 0 {
+		cfg.Leeway = 0
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaults.RefreshInterval
+	}
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = defaults.MinRefreshInterval
+	}
+	if cfg.FetchTimeout <= 0 {
+		cfg.FetchTimeout = defaults.FetchTimeout
+	}
+	return cfg
+}
+
+// Verify checks the token's signature, expiry, issuer and audience and
+// returns its claims. exp is required.
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	key, err := v.keys.Key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *Verifier) validateClaims(claims Claims) error {
+	now := v.now()
+
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return ErrMalformedToken
+	}
+	if now.After(exp.Add(v.config.Leeway)) {
+		return ErrTokenExpired
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(v.config.Leeway).Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+
+	if v.config.Issuer != "" && claims.String("iss") != v.config.Issuer {
+		return ErrInvalidIssuer
+	}
+	if v.config.Audience != "" && !hasAudience(claims["aud"], v.config.Audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+// ==================== Signatures ====================
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+	digest := hashInput(hash, signingInput)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return ErrUnsupportedAlgorithm
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, signature); err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return ErrUnsupportedAlgorithm
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+}
+
+func hashInput(hash crypto.Hash, input string) []byte {
+	switch hash {
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(input))
+		return sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(input))
+		return sum[:]
+	default:
+		sum := sha256.Sum256([]byte(input))
+		return sum[:]
+	}
+}
+
+// ==================== Helpers ====================
+
+func decodeSegment(segment string, out interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+func numericDate(v interface{}) (time.Time, bool) {
+	f, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+func hasAudience(aud interface{}, want string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == want
+	case []interface{}:
+		for _, item := range a {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Describe returns a short reason for a verification failure, safe to return
+// to clients
+func Describe(err error) string {
+	for _, known := range []error{ErrTokenExpired, ErrTokenNotYetValid, ErrInvalidIssuer, ErrInvalidAudience} {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	return "invalid token"
+}
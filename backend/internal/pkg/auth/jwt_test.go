@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	input := encodeSegments(t, map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}, claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	input := encodeSegments(t, map[string]string{"alg": "ES256", "typ": "JWT", "kid": kid}, claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func encodeSegments(t *testing.T, header map[string]string, claims map[string]interface{}) string {
+	t.Helper()
+	h, err := json.Marshal(header)
+	require.NoError(t, err)
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func validClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":       "https://idp.example.com",
+		"aud":       []string{"inbox-api"},
+		"exp":       now.Add(time.Hour).Unix(),
+		"tenant_id": "tenant",
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{
+			rsaJWK("rsa-1", &rsaKey.PublicKey),
+			{
+				"kty": "EC",
+				"kid": "ec-1",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+		}})
+	}))
+	defer server.Close()
+
+	verifier := NewVerifier(Config{Issuer: "https://idp.example.com", Audience: "inbox-api", JWKSURL: server.URL})
+
+	t.Run("valid RS256 token", func(t *testing.T) {
+		claims, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", validClaims(now)))
+		require.NoError(t, err)
+		assert.Equal(t, "tenant", claims.String("tenant_id"))
+	})
+
+	t.Run("valid ES256 token", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, signES256(t, ecKey, "ec-1", validClaims(now)))
+		assert.NoError(t, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		token := signRS256(t, rsaKey, "rsa-1", validClaims(now))
+		other := signRS256(t, rsaKey, "rsa-1", map[string]interface{}{"exp": now.Add(time.Hour).Unix(), "tenant_id": "other"})
+		forged := other[:strings.LastIndex(other, ".")] + token[strings.LastIndex(token, "."):]
+		_, err := verifier.Verify(ctx, forged)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("algorithm does not match key type", func(t *testing.T) {
+		token := signES256(t, ecKey, "rsa-1", validClaims(now))
+		_, err := verifier.Verify(ctx, token)
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token := encodeSegments(t, map[string]string{"alg": "none", "kid": "rsa-1"}, validClaims(now)) + "."
+		_, err := verifier.Verify(ctx, token)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		claims := validClaims(now)
+		claims["exp"] = now.Add(-time.Hour).Unix()
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", claims))
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("missing exp", func(t *testing.T) {
+		claims := validClaims(now)
+		delete(claims, "exp")
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", claims))
+		assert.ErrorIs(t, err, ErrMalformedToken)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := validClaims(now)
+		claims["iss"] = "https://evil.example.com"
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", claims))
+		assert.ErrorIs(t, err, ErrInvalidIssuer)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		claims := validClaims(now)
+		claims["aud"] = "other-api"
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-1", claims))
+		assert.ErrorIs(t, err, ErrInvalidAudience)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, signRS256(t, rsaKey, "rsa-2", validClaims(now)))
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, "not-a-token")
+		assert.ErrorIs(t, err, ErrMalformedToken)
+	})
+}
+
+func TestKeySet_RotationAndDiscovery(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": server.URL, "jwks_uri": server.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		keys := []map[string]string{rsaJWK("old", &oldKey.PublicKey)}
+		if rotated.Load() {
+			keys = append(keys, rsaJWK("new", &newKey.PublicKey))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+
+	keys := NewKeySet(withDefaults(Config{Issuer: server.URL}))
+	clock := now
+	keys.now = func() time.Time { return clock }
+	verifier := NewVerifierWithKeys(Config{Issuer: server.URL}, keys)
+
+	claims := validClaims(now)
+	claims["iss"] = server.URL
+
+	_, err = verifier.Verify(ctx, signRS256(t, oldKey, "old", claims))
+	require.NoError(t, err)
+
+	rotated.Store(true)
+	_, err = verifier.Verify(ctx, signRS256(t, newKey, "new", claims))
+	assert.ErrorIs(t, err, ErrKeyNotFound, "refetch is rate limited")
+
+	clock = clock.Add(2 * time.Minute)
+	_, err = verifier.Verify(ctx, signRS256(t, newKey, "new", claims))
+	assert.NoError(t, err, "unknown kid triggers a refetch")
+	assert.Equal(t, int32(2), fetches.Load())
+}
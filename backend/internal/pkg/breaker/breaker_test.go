@@ -0,0 +1,69 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBreaker(threshold int, timeout time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := New(Config{FailureThreshold: threshold, OpenTimeout: timeout})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	b.RecordFailure()
+	b.RecordFailure()
+	assert.Equal(t, StateClosed, b.State())
+	assert.True(t, b.Allow())
+
+	b.RecordFailure()
+	assert.Equal(t, StateOpen, b.State())
+	assert.False(t, b.Allow())
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	b.RecordFailure()
+	b.RecordSuccess()
+	b.RecordFailure()
+
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenAdmitsSingleProbe(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+
+	b.RecordFailure()
+	assert.False(t, b.Allow())
+
+	*now = now.Add(time.Minute)
+	assert.True(t, b.Allow())
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.False(t, b.Allow(), "only one probe while half-open")
+
+	b.RecordSuccess()
+	assert.Equal(t, StateClosed, b.State())
+	assert.True(t, b.Allow())
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	b, now := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		b.RecordFailure()
+	}
+
+	*now = now.Add(time.Minute)
+	assert.True(t, b.Allow())
+
+	b.RecordFailure()
+	assert.Equal(t, StateOpen, b.State())
+	assert.False(t, b.Allow())
+}
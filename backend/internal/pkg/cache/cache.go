@@ -0,0 +1,17 @@
+// Package cache provides a key-value cache for hot database reads
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores opaque values with a time to live. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the value of key; found is false on a miss
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
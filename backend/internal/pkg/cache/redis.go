@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RedisConfig configures the Redis cache
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+	// PoolSize is the number of idle connections kept for reuse
+	PoolSize int
+	// Timeout bounds dialing and each command unless the context ends sooner
+	Timeout time.Duration
+	// KeyPrefix namespaces every key, so instances can share a Redis
+	KeyPrefix string
+}
+
+// DefaultRedisConfig returns sensible defaults
+func DefaultRedisConfig() RedisConfig {
+	return RedisConfig{
+		Addr:      "localhost:6379",
+		PoolSize:  10,
+		Timeout:   200 * time.Millisecond,
+		KeyPrefix: "inbox:",
+	}
+}
+
+// RedisError is an error reply from the server
+type RedisError string
+
+func (e RedisError) Error() string {
+	return "redis: " + string(e)
+}
+
+var errUnexpectedReply = errors.New("redis: unexpected reply")
+
+// Redis is a Cache backed by a Redis server. It speaks just enough of RESP2
+// for GET, SET PX, DEL and EVAL over a small pool of connections, dialed
+// lazily.
+type Redis struct {
+	config RedisConfig
+	idle   chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+// NewRedis creates a Redis cache; no connection is made until first use
+func NewRedis(cfg RedisConfig) *Redis {
+	if cfg.PoolSize < 1 {
+		cfg.PoolSize = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultRedisConfig().Timeout
+	}
+	return &Redis{config: cfg, idle: make(chan *redisConn, cfg.PoolSize)}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", r.config.KeyPrefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, errUnexpectedReply
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := r.do(ctx, "SET", r.config.KeyPrefix+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]string, 0, len(keys)+1)
+	args = append(args, "DEL")
+	for _, key := range keys {
+		args = append(args, r.config.KeyPrefix+key)
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// SetNX stores value only if key does not exist; returns whether it did
+func (r *Redis) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	reply, err := r.do(ctx, "SET", r.config.KeyPrefix+key, string(value), "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// Eval runs a Lua script atomically on keys. The script must return an
+// integer, a status or a single string.
+func (r *Redis) Eval(ctx context.Context, script string, keys []string, args ...string) (interface{}, error) {
+	cmd := make([]string, 0, 3+len(keys)+len(args))
+	cmd = append(cmd, "EVAL", script, strconv.Itoa(len(keys)))
+	for _, key := range keys {
+		cmd = append(cmd, r.config.KeyPrefix+key)
+	}
+	cmd = append(cmd, args...)
+	return r.do(ctx, cmd...)
+}
+
+// Ping checks that the server is reachable
+func (r *Redis) Ping(ctx context.Context) error {
+	_, err := r.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections
+func (r *Redis) Close() error {
+	for {
+		select {
+		case c := <-r.idle:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends one command and reads its reply. A connection that saw an I/O or
+// protocol error is discarded; one that got an error reply is reused.
+func (r *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+	c, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.roundTrip(ctx, r.config.Timeout, args)
+	var redisErr RedisError
+	if err != nil && !errors.As(err, &redisErr) {
+		c.Close()
+		return nil, err
+	}
+
+	select {
+	case r.idle <- c:
+	default:
+		c.Close()
+	}
+	return reply, err
+}
+
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-r.idle:
+		return c, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: r.config.Timeout}
+	nc, err := dialer.DialContext(ctx, "tcp", r.config.Addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &redisConn{Conn: nc, rd: bufio.NewReader(nc)}
+
+	if r.config.Password != "" {
+		if _, err := c.roundTrip(ctx, r.config.Timeout, []string{"AUTH", r.config.Password}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if r.config.DB != 0 {
+		if _, err := c.roundTrip(ctx, r.config.Timeout, []string{"SELECT", strconv.Itoa(r.config.DB)}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *redisConn) roundTrip(ctx context.Context, timeout time.Duration, args []string) (interface{}, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	if _, err := c.Write(buf); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply. A
+// null bulk string is returned as nil.
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errUnexpectedReply
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, RedisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, errUnexpectedReply
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.rd, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	}
+	return nil, fmt.Errorf("%w: %q", errUnexpectedReply, kind)
+}
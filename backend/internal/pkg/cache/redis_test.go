@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves GET, SET (with NX), DEL, AUTH and PING from a map,
+// ignoring TTLs. EVAL records its arguments and replies 1.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+	evals    [][]string
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &fakeRedis{ln: ln, password: password, data: make(map[string]string)}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args[0])
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authed = args[1] == f.password
+			reply = "+OK\r\n"
+			if !authed {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required\r\n"
+		case args[0] == "PING":
+			reply = "+PONG\r\n"
+		case args[0] == "GET":
+			if v, ok := f.data[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case args[0] == "SET":
+			_, exists := f.data[args[1]]
+			if exists && len(args) > 3 && args[3] == "NX" {
+				reply = "$-1\r\n"
+				break
+			}
+			f.data[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case args[0] == "EVAL":
+			f.evals = append(f.evals, args[1:])
+			reply = ":1\r\n"
+		case args[0] == "DEL":
+			n := 0
+			for _, key := range args[1:] {
+				if _, ok := f.data[key]; ok {
+					delete(f.data, key)
+					n++
+				}
+			}
+			reply = fmt.Sprintf(":%d\r\n", n)
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(line[1 : len(line)-2])
+	args := make([]string, n)
+	for i := range args {
+		if _, err := rd.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := rd.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg[:len(arg)-2]
+	}
+	return args, nil
+}
+
+func TestRedis_GetSetDelete(t *testing.T) {
+	srv := newFakeRedis(t, "")
+	c := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), PoolSize: 2, KeyPrefix: "test:"})
+	defer c.Close()
+	ctx := context.Background()
+
+	_, found, err := c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, c.Set(ctx, "k", []byte("value"), time.Minute))
+	value, found, err := c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "value", string(value))
+
+	srv.mu.Lock()
+	_, prefixed := srv.data["test:k"]
+	srv.mu.Unlock()
+	assert.True(t, prefixed)
+
+	require.NoError(t, c.Delete(ctx, "k", "missing"))
+	_, found, err = c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestRedis_SetNX(t *testing.T) {
+	srv := newFakeRedis(t, "")
+	c := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), KeyPrefix: "test:"})
+	defer c.Close()
+	ctx := context.Background()
+
+	stored, err := c.SetNX(ctx, "k", []byte("first"), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, stored)
+
+	stored, err = c.SetNX(ctx, "k", []byte("second"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	value, _, err := c.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(value))
+}
+
+func TestRedis_Eval(t *testing.T) {
+	srv := newFakeRedis(t, "")
+	c := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), KeyPrefix: "test:"})
+	defer c.Close()
+
+	reply, err := c.Eval(context.Background(), "return 1", []string{"a", "b"}, "x")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reply)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	require.Len(t, srv.evals, 1)
+	assert.Equal(t, []string{"return 1", "2", "test:a", "test:b", "x"}, srv.evals[0], "keys are prefixed, arguments are not")
+}
+
+func TestRedis_Auth(t *testing.T) {
+	srv := newFakeRedis(t, "secret")
+	ctx := context.Background()
+
+	c := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), Password: "secret"})
+	require.NoError(t, c.Ping(ctx))
+
+	wrong := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), Password: "wrong"})
+	var redisErr RedisError
+	assert.ErrorAs(t, wrong.Ping(ctx), &redisErr)
+}
+
+func TestRedis_ReusesConnections(t *testing.T) {
+	srv := newFakeRedis(t, "secret")
+	c := NewRedis(RedisConfig{Addr: srv.ln.Addr().String(), Password: "secret", PoolSize: 1})
+	defer c.Close()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.Ping(ctx))
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, []string{"AUTH", "PING", "PING", "PING"}, srv.commands, "one connection, authenticated once")
+}
+
+func TestRedis_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	c := NewRedis(RedisConfig{Addr: addr, Timeout: 100 * time.Millisecond})
+	_, _, err = c.Get(context.Background(), "k")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/retry"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Notify sends a payload on a PostgreSQL NOTIFY channel.
+// Payloads are limited to 8000 bytes by PostgreSQL.
+func Notify(ctx context.Context, pool *pgxpool.Pool, channel, payload string) error {
+	_, err := pool.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload)
+	return err
+}
+
+// NotificationHandler is invoked for every payload received on a channel
+type NotificationHandler func(payload string)
+
+// Listen holds a dedicated connection that LISTENs on channel and passes every
+// notification to handler until ctx is cancelled. Lost connections are
+// re-established with exponential backoff; notifications sent while
+// disconnected are lost.
+func Listen(ctx context.Context, pool *pgxpool.Pool, channel string, handler NotificationHandler, log *logger.Logger) {
+	backoff := retry.Config{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		BackoffFactor:  2.0,
+		Jitter:         0.1,
+	}
+
+	attempt := 0
+	for {
+		connected := false
+		err := listenOnce(ctx, pool, channel, handler, func() { connected = true })
+		if ctx.Err() != nil {
+			return
+		}
+
+		// A connection that reached LISTEN resets the backoff schedule
+		if connected {
+			attempt = 0
+		}
+		attempt++
+
+		// Cap the exponent; MaxBackoff is reached long before this
+		wait := backoff.Backoff(min(attempt, 16))
+		log.Warn("LISTEN connection lost, reconnecting",
+			zap.String("channel", channel),
+			zap.Int("attempt", attempt),
+			zap.Duration("next_retry_in", wait),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, pool *pgxpool.Pool, channel string, handler NotificationHandler, onListening func()) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// The connection is in LISTEN state; don't hand it back to the pool
+	defer conn.Hijack().Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+	onListening()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handler(notification.Payload)
+	}
+}
@@ -0,0 +1,170 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Helpers for changing large tables (conversation_refs) without blocking
+// writers. See migrations/README.md for when to use which.
+
+// DB is satisfied by *pgxpool.Pool, *pgx.Conn and pgx.Tx
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// ==================== Lock Timeout ====================
+
+// SetLockTimeout bounds how long DDL in tx waits for its table lock, so a
+// blocked ALTER fails fast instead of queueing every writer behind it.
+func SetLockTimeout(ctx context.Context, tx pgx.Tx, timeout time.Duration) error {
+	_, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL lock_timeout = '%dms'", timeout.Milliseconds()))
+	return err
+}
+
+// ==================== Concurrent Indexes ====================
+
+// IndexSpec describes an index built with CREATE INDEX CONCURRENTLY
+type IndexSpec struct {
+	Name    string
+	Table   string
+	Columns []string
+	Unique  bool
+	// Where is an optional partial index predicate
+	Where string
+}
+
+// SQL returns the CREATE INDEX CONCURRENTLY statement for the spec
+func (s IndexSpec) SQL() string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if s.Unique {
+		b.WriteString("UNIQUE ")
+	}
+	b.WriteString("INDEX CONCURRENTLY IF NOT EXISTS ")
+	b.WriteString(pgx.Identifier{s.Name}.Sanitize())
+	b.WriteString(" ON ")
+	b.WriteString(pgx.Identifier{s.Table}.Sanitize())
+	b.WriteString(" (")
+	b.WriteString(strings.Join(s.Columns, ", "))
+	b.WriteString(")")
+	if s.Where != "" {
+		b.WriteString(" WHERE ")
+		b.WriteString(s.Where)
+	}
+	return b.String()
+}
+
+// CreateIndexConcurrently builds the index without blocking writes. A failed
+// concurrent build leaves an INVALID index behind that IF NOT EXISTS would
+// silently keep, so it is dropped and rebuilt. Must not run inside a transaction.
+func CreateIndexConcurrently(ctx context.Context, db DB, spec IndexSpec) error {
+	var valid bool
+	err := db.QueryRow(ctx,
+		`SELECT i.indisvalid FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid WHERE c.relname = $1`,
+		spec.Name).Scan(&valid)
+	switch {
+	case err == nil && valid:
+		return nil
+	case err == nil:
+		if _, err := db.Exec(ctx, "DROP INDEX CONCURRENTLY IF EXISTS "+pgx.Identifier{spec.Name}.Sanitize()); err != nil {
+			return fmt.Errorf("drop invalid index %s: %w", spec.Name, err)
+		}
+	case err != pgx.ErrNoRows:
+		return fmt.Errorf("inspect index %s: %w", spec.Name, err)
+	}
+
+	if _, err := db.Exec(ctx, spec.SQL()); err != nil {
+		return fmt.Errorf("create index %s: %w", spec.Name, err)
+	}
+	return nil
+}
+
+// ==================== NOT VALID Constraints ====================
+
+// AddConstraintNotValid adds a CHECK or FOREIGN KEY constraint without scanning
+// existing rows; only new writes are checked. Follow up with ValidateConstraint.
+// definition is the constraint body, e.g. "CHECK (message_count >= 0)".
+func AddConstraintNotValid(ctx context.Context, db DB, table, name, definition string) error {
+	var exists bool
+	if err := db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = $1 AND conrelid = $2::regclass)`,
+		name, table).Scan(&exists); err != nil {
+		return fmt.Errorf("inspect constraint %s: %w", name, err)
+	}
+	if exists {
+		return nil
+	}
+
+	stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s NOT VALID",
+		pgx.Identifier{table}.Sanitize(), pgx.Identifier{name}.Sanitize(), definition)
+	if _, err := db.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf("add constraint %s: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateConstraint scans existing rows for a NOT VALID constraint. It only
+// takes a SHARE UPDATE EXCLUSIVE lock, so reads and writes continue.
+func ValidateConstraint(ctx context.Context, db DB, table, name string) error {
+	stmt := fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s",
+		pgx.Identifier{table}.Sanitize(), pgx.Identifier{name}.Sanitize())
+	if _, err := db.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf("validate constraint %s: %w", name, err)
+	}
+	return nil
+}
+
+// ==================== Batched Backfills ====================
+
+// EstimateRowCount returns the planner's row estimate for table, which is
+// free to read where COUNT(*) on a large table is not. Zero before the first ANALYZE.
+func EstimateRowCount(ctx context.Context, db DB, table string) (int64, error) {
+	var estimate float32
+	if err := db.QueryRow(ctx,
+		`SELECT reltuples FROM pg_class WHERE oid = $1::regclass`, table).Scan(&estimate); err != nil {
+		return 0, err
+	}
+	if estimate < 0 {
+		return 0, nil
+	}
+	return int64(estimate), nil
+}
+
+// RunBackfillBatch executes one batch of a keyset-paginated backfill.
+// stmt receives the cursor ($1, uuid.Nil on the first batch) and the batch
+// size ($2), must only touch rows with id > $1 in id order, and must
+// RETURNING id of every row it processed. Returns the new cursor and the
+// number of rows processed; fewer than size rows means the backfill is done.
+func RunBackfillBatch(ctx context.Context, db DB, stmt string, after uuid.UUID, size int) (uuid.UUID, int, error) {
+	rows, err := db.Query(ctx, stmt, after, size)
+	if err != nil {
+		return after, 0, err
+	}
+	defer rows.Close()
+
+	cursor, count := after, 0
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return after, 0, err
+		}
+		if bytes.Compare(id[:], cursor[:]) > 0 {
+			cursor = id
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return after, 0, err
+	}
+	return cursor, count, nil
+}
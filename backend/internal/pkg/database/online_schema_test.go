@@ -0,0 +1,36 @@
+package database
+
+import "testing"
+
+func TestIndexSpec_SQL(t *testing.T) {
+	tests := []struct {
+		name string
+		spec IndexSpec
+		want string
+	}{
+		{
+			name: "plain",
+			spec: IndexSpec{Name: "idx_conversations_created", Table: "conversation_refs", Columns: []string{"created_at"}},
+			want: `CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_conversations_created" ON "conversation_refs" (created_at)`,
+		},
+		{
+			name: "unique partial",
+			spec: IndexSpec{
+				Name:    "idx_conversations_open_external",
+				Table:   "conversation_refs",
+				Columns: []string{"tenant_id", "external_conversation_id"},
+				Unique:  true,
+				Where:   "state <> 'RESOLVED'",
+			},
+			want: `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "idx_conversations_open_external" ON "conversation_refs" (tenant_id, external_conversation_id) WHERE state <> 'RESOLVED'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.SQL(); got != tt.want {
+				t.Errorf("SQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUnknownKey is returned when data was sealed with a key the keyring
+	// does not hold
+	ErrUnknownKey = errors.New("unknown encryption key")
+	// ErrNoActiveKey is returned when sealing with a keyring that only decrypts
+	ErrNoActiveKey = errors.New("no active encryption key")
+	// ErrDecrypt is returned when ciphertext fails authentication
+	ErrDecrypt = errors.New("decryption failed")
+)
+
+// Keyring seals data with AES-256-GCM under per-tenant keys derived from
+// master keys. Every sealed value records the ID of its master key, so keys
+// can be rotated: add the new key, make it active, and keep the old one until
+// nothing sealed with it remains.
+type Keyring struct {
+	keys   map[string][]byte
+	active string
+}
+
+// NewKeyring builds a keyring from base64-encoded 32-byte master keys by ID.
+// active names the key new data is sealed with; empty keeps the keyring
+// decrypt-only.
+func NewKeyring(keys map[string]string, active string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string][]byte, len(keys)), active: active}
+	for id, encoded := range keys {
+		if len(id) > 64 {
+			return nil, fmt.Errorf("encryption key ID %q is longer than 64 characters", id)
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", id, err)
+		}
+		if len(raw) != 32 {
+			return nil, fmt.Errorf("encryption key %q must be 32 bytes, got %d", id, len(raw))
+		}
+		k.keys[id] = raw
+	}
+	if active != "" {
+		if _, ok := k.keys[active]; !ok {
+			return nil, fmt.Errorf("active encryption key %q is not configured", active)
+		}
+	}
+	return k, nil
+}
+
+// ActiveKeyID returns the ID of the key new data is sealed with, empty when
+// the keyring only decrypts
+func (k *Keyring) ActiveKeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.active
+}
+
+// Seal encrypts plaintext for the tenant with the active key and returns the
+// key ID with the ciphertext (nonce prepended)
+func (k *Keyring) Seal(tenantID uuid.UUID, plaintext []byte) (string, []byte, error) {
+	if k.ActiveKeyID() == "" {
+		return "", nil, ErrNoActiveKey
+	}
+	aead, err := k.aead(k.active, tenantID)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", nil, err
+	}
+	return k.active, aead.Seal(nonce, nonce, plaintext, tenantID[:]), nil
+}
+
+// Open decrypts ciphertext sealed for the tenant with the given key
+func (k *Keyring) Open(keyID string, tenantID uuid.UUID, ciphertext []byte) ([]byte, error) {
+	aead, err := k.aead(keyID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, tenantID[:])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// aead returns the cipher of the tenant's key derived from a master key. The
+// tenant ID is also the additional data, so a value cannot be replayed under
+// another tenant.
+func (k *Keyring) aead(keyID string, tenantID uuid.UUID) (cipher.AEAD, error) {
+	if k == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	master, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte("tenant:"))
+	mac.Write(tenantID[:])
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
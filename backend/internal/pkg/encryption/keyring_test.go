@@ -0,0 +1,78 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	k, err := NewKeyring(map[string]string{"k1": testKey(1)}, "k1")
+	require.NoError(t, err)
+
+	tenantID := uuid.New()
+	keyID, ciphertext, err := k.Seal(tenantID, []byte(`{"id":"abc"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "k1", keyID)
+	assert.NotContains(t, string(ciphertext), "abc")
+
+	plaintext, err := k.Open(keyID, tenantID, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"abc"}`, string(plaintext))
+
+	_, err = k.Open(keyID, uuid.New(), ciphertext)
+	assert.ErrorIs(t, err, ErrDecrypt, "sealed for another tenant")
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := NewKeyring(map[string]string{"k1": testKey(1)}, "k1")
+	require.NoError(t, err)
+	tenantID := uuid.New()
+	keyID, ciphertext, err := old.Seal(tenantID, []byte("body"))
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring(map[string]string{"k1": testKey(1), "k2": testKey(2)}, "k2")
+	require.NoError(t, err)
+	plaintext, err := rotated.Open(keyID, tenantID, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "body", string(plaintext))
+
+	keyID, _, err = rotated.Seal(tenantID, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", keyID)
+
+	retired, err := NewKeyring(map[string]string{"k2": testKey(2)}, "k2")
+	require.NoError(t, err)
+	_, err = retired.Open("k1", tenantID, ciphertext)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_DecryptOnly(t *testing.T) {
+	k, err := NewKeyring(map[string]string{"k1": testKey(1)}, "")
+	require.NoError(t, err)
+
+	_, _, err = k.Seal(uuid.New(), []byte("body"))
+	assert.ErrorIs(t, err, ErrNoActiveKey)
+
+	var disabled *Keyring
+	assert.Equal(t, "", disabled.ActiveKeyID())
+}
+
+func TestNewKeyring_Invalid(t *testing.T) {
+	_, err := NewKeyring(map[string]string{"k1": "not base64!"}, "k1")
+	assert.Error(t, err)
+
+	_, err = NewKeyring(map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))}, "k1")
+	assert.Error(t, err)
+
+	_, err = NewKeyring(map[string]string{"k1": testKey(1)}, "k2")
+	assert.Error(t, err, "active key must be configured")
+}
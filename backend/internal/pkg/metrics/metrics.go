@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// registerMu guards get-or-create registration; expvar panics on duplicate names
+var registerMu sync.Mutex
+
+// Counter is a monotonically increasing value published through expvar
+type Counter struct {
+	v *expvar.Int
+}
+
+// NewCounter returns the counter registered under name, creating it on first use.
+// Registering the same name twice returns the same counter.
+func NewCounter(name string) *Counter {
+	return &Counter{v: publishInt(name)}
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	c.v.Add(1)
+}
+
+// Add adds delta to the counter
+func (c *Counter) Add(delta int64) {
+	c.v.Add(delta)
+}
+
+// Value returns the current count
+func (c *Counter) Value() int64 {
+	return c.v.Value()
+}
+
+// Gauge is a value that can go up and down, published through expvar
+type Gauge struct {
+	v *expvar.Int
+}
+
+// NewGauge returns the gauge registered under name, creating it on first use
+func NewGauge(name string) *Gauge {
+	return &Gauge{v: publishInt(name)}
+}
+
+// Set replaces the gauge value
+func (g *Gauge) Set(value int64) {
+	g.v.Set(value)
+}
+
+// Value returns the current value
+func (g *Gauge) Value() int64 {
+	return g.v.Value()
+}
+
+// GaugeMap is a set of gauges published through expvar as one map keyed by,
+// for example, an inbox ID
+type GaugeMap struct {
+	m *expvar.Map
+}
+
+// NewGaugeMap returns the gauge map registered under name, creating it on first use
+func NewGaugeMap(name string) *GaugeMap {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		m = expvar.NewMap(name)
+	}
+	return &GaugeMap{m: m}
+}
+
+// Set replaces the value of the gauge under key
+func (g *GaugeMap) Set(key string, value int64) {
+	registerMu.Lock()
+	v := mapInt(g.m, key)
+	registerMu.Unlock()
+	v.Set(value)
+}
+
+// Value returns the value of the gauge under key, zero when it was never set
+func (g *GaugeMap) Value(key string) int64 {
+	if v, ok := g.m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+// CounterMap is a set of counters published through expvar as one map keyed
+// by, for example, an engine version
+type CounterMap struct {
+	m *expvar.Map
+}
+
+// NewCounterMap returns the counter map registered under name, creating it on first use
+func NewCounterMap(name string) *CounterMap {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		m = expvar.NewMap(name)
+	}
+	return &CounterMap{m: m}
+}
+
+// Inc adds one to the counter under key
+func (c *CounterMap) Inc(key string) {
+	c.Add(key, 1)
+}
+
+// Add adds delta to the counter under key
+func (c *CounterMap) Add(key string, delta int64) {
+	registerMu.Lock()
+	v := mapInt(c.m, key)
+	registerMu.Unlock()
+	v.Add(delta)
+}
+
+// Value returns the count under key, zero when it was never incremented
+func (c *CounterMap) Value(key string) int64 {
+	if v, ok := c.m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+// Histogram counts observations into cumulative buckets, published through
+// expvar as a map: {"le_<bound>": n, ..., "le_inf": n, "count": n, "sum": n}
+type Histogram struct {
+	bounds  []int64
+	buckets []*expvar.Int
+	inf     *expvar.Int
+	count   *expvar.Int
+	sum     *expvar.Int
+}
+
+// NewHistogram returns the histogram registered under name, creating it on
+// first use. bounds are the inclusive upper bounds of the buckets, ascending.
+func NewHistogram(name string, bounds []int64) *Histogram {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	m, ok := expvar.Get(name).(*expvar.Map)
+	if !ok {
+		m = expvar.NewMap(name)
+	}
+
+	h := &Histogram{
+		bounds:  bounds,
+		buckets: make([]*expvar.Int, len(bounds)),
+		inf:     mapInt(m, "le_inf"),
+		count:   mapInt(m, "count"),
+		sum:     mapInt(m, "sum"),
+	}
+	for i, bound := range bounds {
+		h.buckets[i] = mapInt(m, "le_"+strconv.FormatInt(bound, 10))
+	}
+	return h
+}
+
+// Observe records one value
+func (h *Histogram) Observe(value int64) {
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.buckets[i].Add(1)
+		}
+	}
+	h.inf.Add(1)
+	h.count.Add(1)
+	h.sum.Add(value)
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() int64 {
+	return h.count.Value()
+}
+
+// Sum returns the sum of all observed values
+func (h *Histogram) Sum() int64 {
+	return h.sum.Value()
+}
+
+// Handler serves all published metrics as JSON
+func Handler() http.Handler {
+	return expvar.Handler()
+}
+
+func publishInt(name string) *expvar.Int {
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	if existing, ok := expvar.Get(name).(*expvar.Int); ok {
+		return existing
+	}
+	return expvar.NewInt(name)
+}
+
+// mapInt returns the counter stored under key, creating it on first use.
+// Callers hold registerMu.
+func mapInt(m *expvar.Map, key string) *expvar.Int {
+	if existing, ok := m.Get(key).(*expvar.Int); ok {
+		return existing
+	}
+	v := new(expvar.Int)
+	m.Set(key, v)
+	return v
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCounter_SameNameSharesValue(t *testing.T) {
+	a := NewCounter("test_shared_counter")
+	b := NewCounter("test_shared_counter")
+
+	a.Inc()
+	b.Add(2)
+
+	assert.Equal(t, int64(3), a.Value())
+	assert.Equal(t, int64(3), b.Value())
+}
+
+func TestGauge_Set(t *testing.T) {
+	g := NewGauge("test_gauge")
+
+	g.Set(7)
+	g.Set(4)
+
+	assert.Equal(t, int64(4), g.Value())
+}
+
+func TestGaugeMap_Set(t *testing.T) {
+	g := NewGaugeMap("test_gauge_map")
+
+	g.Set("a", 7)
+	g.Set("a", 4)
+	g.Set("b", 1)
+
+	assert.Equal(t, int64(4), g.Value("a"))
+	assert.Equal(t, int64(1), NewGaugeMap("test_gauge_map").Value("b"))
+	assert.Zero(t, g.Value("missing"))
+}
+
+func TestCounterMap_Add(t *testing.T) {
+	c := NewCounterMap("test_counter_map")
+
+	c.Inc("v1")
+	c.Add("v1", 2)
+	c.Inc("v2")
+
+	assert.Equal(t, int64(3), c.Value("v1"))
+	assert.Equal(t, int64(1), NewCounterMap("test_counter_map").Value("v2"))
+	assert.Zero(t, c.Value("missing"))
+}
+
+func TestHistogram_Observe(t *testing.T) {
+	h := NewHistogram("test_histogram", []int64{10, 100})
+
+	h.Observe(5)
+	h.Observe(50)
+	h.Observe(500)
+
+	assert.Equal(t, int64(3), h.Count())
+	assert.Equal(t, int64(555), h.Sum())
+
+	published := expvar.Get("test_histogram").(*expvar.Map)
+	assert.Equal(t, "1", published.Get("le_10").String())
+	assert.Equal(t, "2", published.Get("le_100").String())
+	assert.Equal(t, "3", published.Get("le_inf").String())
+
+	// Same name shares the buckets
+	NewHistogram("test_histogram", []int64{10, 100}).Observe(1)
+	assert.Equal(t, int64(4), h.Count())
+	assert.Equal(t, "2", published.Get("le_10").String())
+}
@@ -0,0 +1,91 @@
+package outbound
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.10", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"0.0.0.0", true},
+		{"0.1.2.3", true},
+		{"::", true},
+		{"224.0.0.1", true},
+		{"100.100.100.200", true},
+		{"::ffff:127.0.0.1", true},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.blocked, IsBlockedIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://hooks.example.com/inbox", nil},
+		{"http://93.184.216.34:8080/cb", nil},
+		{"/hooks", ErrInvalidURL},
+		{"ftp://example.com", ErrInvalidURL},
+		{"http://localhost:9000/cb", ErrBlockedAddress},
+		{"http://receiver.localhost/cb", ErrBlockedAddress},
+		{"http://127.0.0.1:9000/cb", ErrBlockedAddress},
+		{"http://[::1]/cb", ErrBlockedAddress},
+		{"http://10.0.0.5/cb", ErrBlockedAddress},
+		{"http://169.254.169.254/latest/meta-data/", ErrBlockedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if tt.want == nil {
+				assert.NoError(t, CheckURL(tt.url))
+				return
+			}
+			assert.ErrorIs(t, CheckURL(tt.url), tt.want)
+		})
+	}
+}
+
+func TestNewClient_RefusesBlockedAddresses(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// httptest listens on loopback, so the dial itself is refused
+	_, err := NewClient().Get(server.URL)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBlockedAddress), "got %v", err)
+	assert.False(t, called)
+}
+
+func TestNewClient_DoesNotFollowRedirects(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://hooks.example.com/moved", nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, NewClient().CheckRedirect(req, nil), http.ErrUseLastResponse)
+}
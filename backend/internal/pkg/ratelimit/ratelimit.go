@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Config defines rate limiter behavior
+type Config struct {
+	// Rate is the sustained number of requests allowed per second for one key
+	Rate float64
+	// Burst is the number of requests one key may make at once
+	Burst int
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		Rate:  5,
+		Burst: 20,
+	}
+}
+
+// pruneInterval bounds how often buckets that have refilled are dropped
+const pruneInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter is an in-memory token bucket limiter with one bucket per key. It
+// is safe for concurrent use. Limits apply per process, so a deployment of n
+// replicas allows up to n times the configured rate.
+type Limiter struct {
+	config Config
+	now    func() time.Time
+
+	mu         sync.Mutex
+	buckets    map[string]*bucket
+	lastPruned time.Time
+}
+
+// New creates a limiter
+func New(cfg Config) *Limiter {
+	if cfg.Burst < 1 {
+		cfg.Burst = 1
+	}
+	return &Limiter{config: cfg, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes a token from the key's bucket. When none is left it returns
+// false and how long until the next token.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.config.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	if l.config.Rate <= 0 {
+		return false, pruneInterval
+	}
+	wait := time.Duration(math.Ceil((1 - b.tokens) / l.config.Rate * float64(time.Second)))
+	return false, wait
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) {
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(l.config.Burst), b.tokens+elapsed*l.config.Rate)
+		b.updated = now
+	}
+}
+
+// prune drops full buckets, which behave exactly like missing ones
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < pruneInterval {
+		return
+	}
+	l.lastPruned = now
+	for key, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= float64(l.config.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
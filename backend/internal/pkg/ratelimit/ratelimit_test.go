@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLimiter(rate float64, burst int) (*Limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := New(Config{Rate: rate, Burst: burst})
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_AllowsBurstThenLimits(t *testing.T) {
+	l, _ := newTestLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("key")
+		assert.True(t, ok)
+	}
+	ok, retryAfter := l.Allow("key")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+}
+
+func TestLimiter_Refills(t *testing.T) {
+	l, now := newTestLimiter(2, 1)
+
+	ok, _ := l.Allow("key")
+	assert.True(t, ok)
+	ok, retryAfter := l.Allow("key")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	*now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("key")
+	assert.True(t, ok)
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(1, 1)
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("b")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestLimiter_PrunesRefilledBuckets(t *testing.T) {
+	l, now := newTestLimiter(1, 1)
+
+	l.Allow("a")
+	*now = now.Add(pruneInterval)
+	l.Allow("b")
+
+	assert.Len(t, l.buckets, 1, "a refilled and was dropped")
+}
@@ -103,6 +103,17 @@ func DoWithResult[T any](ctx context.Context, cfg Config, fn func() (T, error))
 	return result, err
 }
 
+// Backoff returns the wait before the given retry attempt (1-based), following the
+// same exponential schedule as Do. Useful when retries are persisted and scheduled
+// rather than slept in-process.
+func (c Config) Backoff(attempt int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff = time.Duration(float64(backoff) * c.BackoffFactor)
+	}
+	return calculateBackoff(backoff, c.MaxBackoff, c.Jitter)
+}
+
 // isRetryable checks if error should trigger a retry
 func isRetryable(err error, retryableErrors []error) bool {
 	// If no specific errors defined, retry all
@@ -126,8 +137,8 @@ func calculateBackoff(backoff, maxBackoff time.Duration, jitter float64) time.Du
 		backoff = backoff + time.Duration(jitterAmount)
 	}
 
-	// Cap at max
-	if backoff > maxBackoff {
+	// Cap at max (zero means uncapped)
+	if maxBackoff > 0 && backoff > maxBackoff {
 		backoff = maxBackoff
 	}
 
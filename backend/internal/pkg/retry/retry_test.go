@@ -107,3 +107,18 @@ func TestDoWithResult_RetryUntilSuccess(t *testing.T) {
 	assert.Equal(t, 42, result)
 	assert.Equal(t, 3, calls)
 }
+
+func TestConfig_Backoff(t *testing.T) {
+	cfg := Config{InitialBackoff: 1 * time.Second, MaxBackoff: 5 * time.Second, BackoffFactor: 2.0}
+
+	assert.Equal(t, 1*time.Second, cfg.Backoff(1))
+	assert.Equal(t, 2*time.Second, cfg.Backoff(2))
+	assert.Equal(t, 4*time.Second, cfg.Backoff(3))
+	assert.Equal(t, 5*time.Second, cfg.Backoff(4)) // capped
+}
+
+func TestConfig_Backoff_Uncapped(t *testing.T) {
+	cfg := Config{InitialBackoff: 1 * time.Second, BackoffFactor: 2.0}
+
+	assert.Equal(t, 8*time.Second, cfg.Backoff(4))
+}
@@ -0,0 +1,347 @@
+// Package validate checks request structs against rules declared in their
+// `validate` struct tags, so that every request reports invalid fields the
+// same way.
+//
+// A tag is a comma-separated list of rules:
+//
+//	Name  string  `json:"name" validate:"required,max=64"`
+//	Color *string `json:"color" validate:"hexcolor"`
+//
+// Rules:
+//
+//	required   the field is set: not nil for pointers, not blank for strings,
+//	           not empty for slices and maps, not the zero value otherwise
+//	notblank   a string, when set, is not blank
+//	min=N      strings have at least N characters, slices and maps at least
+//	           N items, numbers are at least N
+//	max=N      the upper bound, measured as min; with both bounds the
+//	           message names the range
+//	oneof=A B  the string is one of the space-separated values
+//	hexcolor   the string is a #RGB or #RRGGBB color
+//	e164       the string is an E.164 phone number, such as +14155550100
+//	email      the string is a bare email address, such as ana@example.com
+//	omitempty  skips the other rules when the value is the zero value
+//
+// A nil pointer passes every rule but required; otherwise the rules apply to
+// the value it points to. Fields are named by their json tag in messages,
+// and each invalid field reports only its first failed rule. Untagged
+// embedded structs are validated as if their fields were declared inline.
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	e164Pattern     = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+)
+
+// IsHexColor reports whether s is a #RGB or #RRGGBB color
+func IsHexColor(s string) bool {
+	return hexColorPattern.MatchString(s)
+}
+
+// IsE164 reports whether s is an E.164 phone number: a + and up to 15
+// digits, the first not zero
+func IsE164(s string) bool {
+	return e164Pattern.MatchString(s)
+}
+
+// Struct validates the struct v points to and returns one message per
+// invalid field, in field order. It panics on a malformed tag, which is a
+// programming error.
+func Struct(v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: %T is not a struct", v))
+	}
+
+	var errs []string
+	for _, f := range fieldsOf(rv.Type()) {
+		if msg := f.check(rv.FieldByIndex(f.index)); msg != "" {
+			errs = append(errs, f.name+" "+msg)
+		}
+	}
+	return errs
+}
+
+// field is a tagged struct field and its parsed rules
+type field struct {
+	index     []int
+	name      string
+	rules     []rule
+	required  bool
+	omitempty bool
+}
+
+type rule struct {
+	name  string
+	param string
+	check func(v reflect.Value, param string) string
+}
+
+var checks = map[string]func(v reflect.Value, param string) string{
+	"notblank": checkNotBlank,
+	"min":      checkMin,
+	"max":      checkMax,
+	"oneof":    checkOneOf,
+	"hexcolor": checkHexColor,
+	"e164":     checkE164,
+	"email":    checkEmail,
+}
+
+// fields caches the parsed fields of each struct type
+var fields sync.Map
+
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fields.Load(t); ok {
+		return cached.([]field)
+	}
+	parsed := parseFields(t, nil)
+	fields.Store(t, parsed)
+	return parsed
+}
+
+func parseFields(t reflect.Type, index []int) []field {
+	var out []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+		tag, tagged := sf.Tag.Lookup("validate")
+		if !tagged {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				out = append(out, parseFields(sf.Type, fieldIndex)...)
+			}
+			continue
+		}
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		f := field{index: fieldIndex, name: jsonName(sf)}
+		for _, spec := range strings.Split(tag, ",") {
+			name, param, _ := strings.Cut(spec, "=")
+			switch name {
+			case "required":
+				f.required = true
+				continue
+			case "omitempty":
+				f.omitempty = true
+				continue
+			}
+			check, ok := checks[name]
+			if !ok {
+				panic(fmt.Sprintf("validate: unknown rule %q on %s.%s", name, t.Name(), sf.Name))
+			}
+			if (name == "min" || name == "max") && !isNumber(param) {
+				panic(fmt.Sprintf("validate: %s needs a number on %s.%s", name, t.Name(), sf.Name))
+			}
+			f.rules = append(f.rules, rule{name: name, param: param, check: check})
+		}
+		f.rules = mergeBounds(f.rules)
+		out = append(out, f)
+	}
+	return out
+}
+
+// mergeBounds replaces a min and a max rule with one between rule, so that
+// a value out of range reports the whole range
+func mergeBounds(rules []rule) []rule {
+	minAt, maxAt := -1, -1
+	for i, r := range rules {
+		switch r.name {
+		case "min":
+			minAt = i
+		case "max":
+			maxAt = i
+		}
+	}
+	if minAt < 0 || maxAt < 0 {
+		return rules
+	}
+	between := rule{name: "between", param: rules[minAt].param + " " + rules[maxAt].param, check: checkBetween}
+	merged := make([]rule, 0, len(rules)-1)
+	for i, r := range rules {
+		switch i {
+		case minAt:
+			merged = append(merged, between)
+		case maxAt:
+		default:
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+func (f field) check(v reflect.Value) string {
+	pointer := v.Kind() == reflect.Pointer
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if f.required {
+				return "is required"
+			}
+			return ""
+		}
+		v = v.Elem()
+	}
+	if isEmpty(v) {
+		if f.required && !pointer {
+			return "is required"
+		}
+		if f.omitempty {
+			return ""
+		}
+	}
+	for _, r := range f.rules {
+		if msg := r.check(v, r.param); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// isEmpty reports whether v is unset: a blank string, an empty slice or
+// map, or the zero value
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func checkNotBlank(v reflect.Value, _ string) string {
+	if v.Kind() == reflect.String && strings.TrimSpace(v.String()) == "" {
+		return "cannot be empty"
+	}
+	return ""
+}
+
+func checkMin(v reflect.Value, param string) string {
+	n, _ := strconv.ParseFloat(param, 64)
+	switch v.Kind() {
+	case reflect.String:
+		if float64(utf8.RuneCountInString(v.String())) < n {
+			return "must be at least " + param + " characters"
+		}
+	case reflect.Slice, reflect.Map:
+		if float64(v.Len()) < n {
+			return "must have at least " + param + " items"
+		}
+	default:
+		if number, ok := numberOf(v); ok && number < n {
+			return "must be at least " + param
+		}
+	}
+	return ""
+}
+
+func checkMax(v reflect.Value, param string) string {
+	n, _ := strconv.ParseFloat(param, 64)
+	switch v.Kind() {
+	case reflect.String:
+		if float64(utf8.RuneCountInString(v.String())) > n {
+			return "must be " + param + " characters or less"
+		}
+	case reflect.Slice, reflect.Map:
+		if float64(v.Len()) > n {
+			return "must have " + param + " items or less"
+		}
+	default:
+		if number, ok := numberOf(v); ok && number > n {
+			return "must be at most " + param
+		}
+	}
+	return ""
+}
+
+func checkBetween(v reflect.Value, param string) string {
+	lower, upper, _ := strings.Cut(param, " ")
+	if checkMin(v, lower) == "" && checkMax(v, upper) == "" {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return "must be between " + lower + " and " + upper + " characters"
+	case reflect.Slice, reflect.Map:
+		return "must have between " + lower + " and " + upper + " items"
+	default:
+		return "must be between " + lower + " and " + upper
+	}
+}
+
+func checkOneOf(v reflect.Value, param string) string {
+	allowed := strings.Fields(param)
+	for _, value := range allowed {
+		if v.String() == value {
+			return ""
+		}
+	}
+	return "must be one of " + strings.Join(allowed, ", ")
+}
+
+func checkHexColor(v reflect.Value, _ string) string {
+	if !IsHexColor(v.String()) {
+		return "must be a hex color such as #FF8800"
+	}
+	return ""
+}
+
+func checkE164(v reflect.Value, _ string) string {
+	if !IsE164(v.String()) {
+		return "must be an E.164 phone number such as +14155550100"
+	}
+	return ""
+}
+
+func checkEmail(v reflect.Value, _ string) string {
+	addr, err := mail.ParseAddress(v.String())
+	if err != nil || addr.Address != strings.TrimSpace(v.String()) {
+		return "must be a valid email address"
+	}
+	return ""
+}
+
+func numberOf(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
+// jsonName is the name a field has in request bodies
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
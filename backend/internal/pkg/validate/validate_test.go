@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type embedded struct {
+	Cursor string `json:"cursor" validate:"max=8"`
+}
+
+type request struct {
+	embedded
+	Name     string     `json:"name" validate:"required,max=5"`
+	Nickname *string    `json:"nickname" validate:"notblank,max=5"`
+	Color    *string    `json:"color" validate:"hexcolor"`
+	Phone    string     `json:"phone,omitempty" validate:"omitempty,e164"`
+	Scope    string     `json:"scope" validate:"omitempty,oneof=INBOX TENANT"`
+	Count    int        `json:"count" validate:"min=1,max=10"`
+	IDs      []string   `json:"ids" validate:"max=2"`
+	InboxID  uuid.UUID  `json:"inbox_id" validate:"required"`
+	ParentID *uuid.UUID `json:"parent_id" validate:"required"`
+	Day      *int       `json:"day" validate:"required,min=0,max=6"`
+	Code     string     `json:"code" validate:"omitempty,min=2"`
+	Email    *string    `json:"email" validate:"omitempty,max=20,email"`
+	Weight   float64    `json:"weight" validate:"max=1"`
+	Ignored  string     `json:"ignored"`
+}
+
+func validRequest() request {
+	parent := uuid.New()
+	sunday := 0
+	return request{Name: "ok", Count: 1, InboxID: uuid.New(), ParentID: &parent, Day: &sunday}
+}
+
+func TestStruct(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		modify func(r *request)
+		want   []string
+	}{
+		{"valid", func(r *request) {}, nil},
+		{"valid optional fields", func(r *request) {
+			r.Nickname, r.Color, r.Phone, r.Scope, r.Email = str("nick"), str("#fa0"), "+14155550100", "TENANT", str("ana@example.com")
+		}, nil},
+		{"required", func(r *request) {
+			r.Name, r.InboxID, r.ParentID, r.Day = " ", uuid.Nil, nil, nil
+		}, []string{"name is required", "inbox_id is required", "parent_id is required", "day is required"}},
+		{"string length", func(r *request) { r.Name = "toolong" }, []string{"name must be 5 characters or less"}},
+		{"embedded", func(r *request) { r.Cursor = "123456789" }, []string{"cursor must be 8 characters or less"}},
+		{"first failed rule only", func(r *request) { r.Nickname = str("   ") }, []string{"nickname cannot be empty"}},
+		{"hex color", func(r *request) { r.Color = str("red") }, []string{"color must be a hex color such as #FF8800"}},
+		{"empty color", func(r *request) { r.Color = str("") }, []string{"color must be a hex color such as #FF8800"}},
+		{"e164", func(r *request) { r.Phone = "0155501" }, []string{"phone must be an E.164 phone number such as +14155550100"}},
+		{"email", func(r *request) { r.Email = str("Ana <a@b.io>") }, []string{"email must be a valid email address"}},
+		{"email checked after length", func(r *request) { r.Email = str("a-very-long-address@example.com") }, []string{"email must be 20 characters or less"}},
+		{"oneof", func(r *request) { r.Scope = "GLOBAL" }, []string{"scope must be one of INBOX, TENANT"}},
+		{"number range", func(r *request) { r.Count = 0 }, []string{"count must be between 1 and 10"}},
+		{"number upper bound", func(r *request) { r.Count = 11 }, []string{"count must be between 1 and 10"}},
+		{"string lower bound", func(r *request) { r.Code = "a" }, []string{"code must be at least 2 characters"}},
+		{"float bound", func(r *request) { r.Weight = 1.5 }, []string{"weight must be at most 1"}},
+		{"items", func(r *request) { r.IDs = []string{"a", "b", "c"} }, []string{"ids must have 2 items or less"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := validRequest()
+			tt.modify(&r)
+			assert.Equal(t, tt.want, Struct(&r))
+		})
+	}
+}
+
+func TestStruct_MalformedTag(t *testing.T) {
+	type unknownRule struct {
+		Name string `validate:"uppercase"`
+	}
+	type badBound struct {
+		Name string `validate:"max=ten"`
+	}
+
+	assert.Panics(t, func() { Struct(&unknownRule{}) })
+	assert.Panics(t, func() { Struct(&badBound{}) })
+	assert.Panics(t, func() { Struct("not a struct") })
+}
+
+func TestIsE164(t *testing.T) {
+	for s, want := range map[string]bool{
+		"+14155550100":      true,
+		"+442071838750":     true,
+		"+12":               true,
+		"14155550100":       false,
+		"+04155550100":      false,
+		"+1 415 555 0100":   false,
+		"+1234567890123456": false,
+		"+1":                false,
+	} {
+		assert.Equal(t, want, IsE164(s), s)
+	}
+}
+
+func TestIsHexColor(t *testing.T) {
+	for s, want := range map[string]bool{
+		"#FF8800":   true,
+		"#ff8800":   true,
+		"#F80":      true,
+		"FF8800":    false,
+		"#FF88":     false,
+		"#GG8800":   false,
+		"#FF880000": false,
+	} {
+		assert.Equal(t, want, IsHexColor(s), s)
+	}
+}
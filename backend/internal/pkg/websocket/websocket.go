@@ -0,0 +1,322 @@
+// Package websocket implements the server side of the WebSocket protocol
+// (RFC 6455): the opening handshake, text and binary messages, and the
+// close, ping and pong control frames. Extensions and subprotocols are not
+// negotiated.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// acceptGUID is appended to the client's key to compute Sec-WebSocket-Accept
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// DefaultMaxMessageSize bounds a message read from the client
+const DefaultMaxMessageSize = 64 << 10
+
+var (
+	// ErrNotWebSocket is returned by Upgrade for a request that is not a
+	// WebSocket opening handshake
+	ErrNotWebSocket = errors.New("websocket: not a websocket handshake")
+	// ErrClosed is returned by ReadMessage once the client closed the connection
+	ErrClosed = errors.New("websocket: connection closed")
+	// ErrMessageTooLarge is returned for a message over the size limit
+	ErrMessageTooLarge = errors.New("websocket: message too large")
+	// ErrProtocol is returned for a frame that breaks RFC 6455
+	ErrProtocol = errors.New("websocket: protocol error")
+)
+
+// MessageType is a frame opcode
+type MessageType int
+
+const (
+	continuationFrame MessageType = 0
+	TextMessage       MessageType = 1
+	BinaryMessage     MessageType = 2
+	CloseMessage      MessageType = 8
+	PingMessage       MessageType = 9
+	PongMessage       MessageType = 10
+)
+
+func (t MessageType) isControl() bool {
+	return t >= CloseMessage
+}
+
+// Close status codes
+const (
+	CloseNormal         = 1000
+	CloseGoingAway      = 1001
+	CloseProtocolError  = 1002
+	CloseMessageTooBig  = 1009
+	CloseInternalError  = 1011
+	closeNoStatus       = 1005
+	maxControlFrameSize = 125
+)
+
+// Conn is a server-side WebSocket connection. One goroutine may read while
+// others write: writes are serialized.
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	// MaxMessageSize bounds a message read from the client
+	MaxMessageSize int64
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// Upgrade completes the opening handshake and takes over the connection.
+// Nothing is written to w when the request is not a handshake, so the
+// caller can answer it.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if !IsUpgradeRequest(r) {
+		return nil, ErrNotWebSocket
+	}
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("websocket: hijack: %w", err)
+	}
+	// The server's read and write timeouts do not apply to the connection
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n"
+	if _, err := rw.WriteString(handshake); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &Conn{conn: conn, br: rw.Reader, MaxMessageSize: DefaultMaxMessageSize}, nil
+}
+
+// IsUpgradeRequest reports whether r is a WebSocket opening handshake, so a
+// handler can check before doing work it would have to undo
+func IsUpgradeRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		headerContainsToken(r.Header, "Connection", "upgrade") &&
+		headerContainsToken(r.Header, "Upgrade", "websocket") &&
+		r.Header.Get("Sec-WebSocket-Version") == "13" &&
+		r.Header.Get("Sec-WebSocket-Key") != ""
+}
+
+// AcceptKey returns the Sec-WebSocket-Accept value answering the client's
+// Sec-WebSocket-Key
+func AcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func headerContainsToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ==================== Reading ====================
+
+// ReadMessage returns the next message from the client. Pings are answered
+// before they are returned, and pongs are returned too, so the caller can
+// treat any frame as a sign of life. A close frame is answered and
+// reported as ErrClosed.
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	var (
+		messageType MessageType
+		message     []byte
+	)
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch {
+		case opcode == CloseMessage:
+			code := closeNoStatus
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			_ = c.writeClose(code)
+			c.conn.Close()
+			return 0, nil, ErrClosed
+		case opcode == PingMessage:
+			if err := c.WriteMessage(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			return PingMessage, payload, nil
+		case opcode == PongMessage:
+			return PongMessage, payload, nil
+		case opcode == continuationFrame:
+			if messageType == 0 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+		default:
+			if messageType != 0 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+			messageType = opcode
+		}
+
+		if int64(len(message)+len(payload)) > c.MaxMessageSize {
+			return 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooLarge)
+		}
+		message = append(message, payload...)
+		if fin {
+			return messageType, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (fin bool, opcode MessageType, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	opcode = MessageType(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	// No extensions are negotiated, so the reserved bits must be clear, and
+	// every client frame is masked
+	if header[0]&0x70 != 0 || !masked {
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+	switch opcode {
+	case continuationFrame, TextMessage, BinaryMessage, CloseMessage, PingMessage, PongMessage:
+	default:
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+	if opcode.isControl() && (length > maxControlFrameSize || !fin) {
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+	if length < 0 || length > c.MaxMessageSize {
+		return false, 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooLarge)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// fail closes the connection with the status code and returns err
+func (c *Conn) fail(code int, err error) error {
+	_ = c.writeClose(code)
+	c.conn.Close()
+	return err
+}
+
+// SetReadDeadline bounds the wait for the next frame; zero waits forever
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// ==================== Writing ====================
+
+// WriteMessage sends a single-frame message. Server frames are not masked.
+func (c *Conn) WriteMessage(messageType MessageType, data []byte) error {
+	if messageType.isControl() && len(data) > maxControlFrameSize {
+		return ErrProtocol
+	}
+
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | byte(messageType)
+	switch n := len(data); {
+	case n <= 125:
+		header[1] = byte(n)
+	case n <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err := c.conn.Write(append(header, data...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WriteJSON sends v as a text message
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(TextMessage, data)
+}
+
+// SetWriteDeadline bounds the following writes; zero waits forever
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+func (c *Conn) writeClose(code int) error {
+	return c.WriteMessage(CloseMessage, binary.BigEndian.AppendUint16(nil, uint16(code)))
+}
+
+// Close sends a close frame with the status code and closes the connection.
+// Safe to call more than once.
+func (c *Conn) Close(code int) error {
+	var err error
+	c.closeOnce.Do(func() {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+		_ = c.writeClose(code)
+		err = c.conn.Close()
+	})
+	return err
+}
@@ -0,0 +1,172 @@
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClient speaks the client side of the protocol over a raw connection
+type testClient struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+func dial(t *testing.T, server *httptest.Server) *testClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	_, err = io.WriteString(conn, "GET / HTTP/1.1\r\n"+
+		"Host: example.com\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n"+
+		"Sec-WebSocket-Version: 13\r\n\r\n")
+	require.NoError(t, err)
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+	return &testClient{conn: conn, br: br}
+}
+
+func (c *testClient) writeFrame(t *testing.T, first byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{first, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func (c *testClient) readFrame(t *testing.T) (MessageType, []byte) {
+	t.Helper()
+	var header [2]byte
+	_, err := io.ReadFull(c.br, header[:])
+	require.NoError(t, err)
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		_, err := io.ReadFull(c.br, ext[:])
+		require.NoError(t, err)
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	_, err = io.ReadFull(c.br, payload)
+	require.NoError(t, err)
+	return MessageType(header[0] & 0x0f), payload
+}
+
+// echoServer echoes every data message and reports the control frames it read
+func echoServer(t *testing.T, control chan<- MessageType) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer conn.Close(CloseNormal)
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if messageType.isControl() {
+				control <- messageType
+				continue
+			}
+			if err := conn.WriteMessage(messageType, data); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpgrade_RejectsPlainRequests(t *testing.T) {
+	server := echoServer(t, make(chan MessageType, 1))
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestIsUpgradeRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.False(t, IsUpgradeRequest(r))
+
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	assert.True(t, IsUpgradeRequest(r))
+
+	r.Header.Set("Sec-WebSocket-Version", "8")
+	assert.False(t, IsUpgradeRequest(r))
+}
+
+func TestConn_EchoesMessages(t *testing.T) {
+	client := dial(t, echoServer(t, make(chan MessageType, 1)))
+
+	client.writeFrame(t, 0x81, []byte("hello"))
+	messageType, payload := client.readFrame(t)
+	assert.Equal(t, TextMessage, messageType)
+	assert.Equal(t, "hello", string(payload))
+
+	// A fragmented message is reassembled
+	client.writeFrame(t, 0x01, []byte("hel"))
+	client.writeFrame(t, 0x80, []byte("lo again"))
+	messageType, payload = client.readFrame(t)
+	assert.Equal(t, TextMessage, messageType)
+	assert.Equal(t, "hello again", string(payload))
+}
+
+func TestConn_AnswersPings(t *testing.T) {
+	control := make(chan MessageType, 2)
+	client := dial(t, echoServer(t, control))
+
+	client.writeFrame(t, 0x89, []byte("beat"))
+	messageType, payload := client.readFrame(t)
+	assert.Equal(t, PongMessage, messageType)
+	assert.Equal(t, "beat", string(payload))
+	assert.Equal(t, PingMessage, <-control)
+
+	client.writeFrame(t, 0x8a, nil)
+	assert.Equal(t, PongMessage, <-control)
+}
+
+func TestConn_AnswersClose(t *testing.T) {
+	client := dial(t, echoServer(t, make(chan MessageType, 1)))
+
+	client.writeFrame(t, 0x88, binary.BigEndian.AppendUint16(nil, CloseGoingAway))
+	messageType, payload := client.readFrame(t)
+	assert.Equal(t, CloseMessage, messageType)
+	assert.Equal(t, CloseGoingAway, int(binary.BigEndian.Uint16(payload)))
+}
+
+func TestConn_RejectsUnmaskedFrames(t *testing.T) {
+	client := dial(t, echoServer(t, make(chan MessageType, 1)))
+
+	_, err := client.conn.Write([]byte{0x81, 0x02, 'h', 'i'})
+	require.NoError(t, err)
+	messageType, payload := client.readFrame(t)
+	assert.Equal(t, CloseMessage, messageType)
+	assert.Equal(t, CloseProtocolError, int(binary.BigEndian.Uint16(payload)))
+}
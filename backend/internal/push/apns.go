@@ -0,0 +1,160 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	apnsProductionEndpoint = "https://api.push.apple.com"
+	apnsSandboxEndpoint    = "https://api.sandbox.push.apple.com"
+	// apnsTokenLifetime renews the provider token well within the hour APNs
+	// accepts it for, and no more often than the 20 minutes it allows
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNs sends through the Apple Push Notification service with token-based
+// authentication: each request carries a provider JWT signed (ES256) with
+// the team's .p8 key. APNs only speaks HTTP/2, which net/http negotiates
+// over TLS.
+type APNs struct {
+	endpoint string
+	topic    string
+	keyID    string
+	teamID   string
+	key      crypto.Signer
+	client   *http.Client
+	now      func() time.Time
+
+	mu       sync.Mutex
+	jwt      string
+	issuedAt time.Time
+}
+
+// NewAPNs creates an APNs sender from a PEM .p8 signing key
+func NewAPNs(key []byte, keyID, teamID, topic string, production bool, timeout time.Duration) (*APNs, error) {
+	signer, err := parsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("push: invalid APNs key: %w", err)
+	}
+	if _, ok := signer.(*ecdsa.PrivateKey); !ok {
+		return nil, errors.New("push: APNs key must be an EC P-256 key")
+	}
+
+	endpoint := apnsSandboxEndpoint
+	if production {
+		endpoint = apnsProductionEndpoint
+	}
+
+	return &APNs{
+		endpoint: endpoint,
+		topic:    topic,
+		keyID:    keyID,
+		teamID:   teamID,
+		key:      signer,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+	}, nil
+}
+
+type apnsAlert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type apnsAps struct {
+	Alert apnsAlert `json:"alert"`
+	Sound string    `json:"sound"`
+}
+
+func (a *APNs) Send(ctx context.Context, token string, n Notification) error {
+	jwt, err := a.token()
+	if err != nil {
+		return err
+	}
+
+	// Custom data sits next to aps at the top level of the payload
+	payload := make(map[string]interface{}, len(n.Data)+1)
+	for k, v := range n.Data {
+		payload[k] = v
+	}
+	payload["aps"] = apnsAps{Alert: apnsAlert{Title: n.Title, Body: n.Body}, Sound: "default"}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		a.endpoint+"/3/device/"+url.PathEscape(token), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "bearer "+jwt)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if n.CollapseKey != "" {
+		req.Header.Set("apns-collapse-id", n.CollapseKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var errResp struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal(respBody, &errResp)
+
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		errResp.Reason == "BadDeviceToken", errResp.Reason == "Unregistered",
+		errResp.Reason == "DeviceTokenNotForTopic":
+		return ErrInvalidToken
+	case errResp.Reason == "ExpiredProviderToken":
+		a.mu.Lock()
+		a.jwt = ""
+		a.mu.Unlock()
+	}
+	return &StatusError{StatusCode: resp.StatusCode, Reason: errResp.Reason}
+}
+
+// token returns the provider token, signing a new one when it is due
+func (a *APNs) token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.jwt != "" && now.Sub(a.issuedAt) < apnsTokenLifetime {
+		return a.jwt, nil
+	}
+
+	jwt, err := signJWT(a.key, a.keyID, map[string]interface{}{
+		"iss": a.teamID,
+		"iat": now.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	a.jwt = jwt
+	a.issuedAt = now
+	return jwt, nil
+}
@@ -0,0 +1,228 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fcmScope           = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmDefaultTokenURL = "https://oauth2.googleapis.com/token"
+	fcmEndpoint        = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	// fcmTokenMargin renews the access token this long before it expires
+	fcmTokenMargin = time.Minute
+)
+
+// FCM sends through the Firebase Cloud Messaging HTTP v1 API. It
+// authenticates as a service account: a JWT signed with the account's key is
+// exchanged for an OAuth access token, which is reused until shortly before
+// it expires.
+type FCM struct {
+	endpoint string
+	tokenURL string
+	email    string
+	keyID    string
+	key      crypto.Signer
+	client   *http.Client
+	now      func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// serviceAccount holds the fields used from a service account JSON key
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// NewFCM creates an FCM sender from a service account JSON key; projectID
+// overrides the key's project
+func NewFCM(credentials []byte, projectID string, timeout time.Duration) (*FCM, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, fmt.Errorf("push: invalid FCM credentials: %w", err)
+	}
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+	if projectID == "" || account.ClientEmail == "" {
+		return nil, errors.New("push: FCM credentials need a project_id and a client_email")
+	}
+	key, err := parsePrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("push: invalid FCM private key: %w", err)
+	}
+	tokenURL := account.TokenURI
+	if tokenURL == "" {
+		tokenURL = fcmDefaultTokenURL
+	}
+
+	return &FCM{
+		endpoint: fmt.Sprintf(fcmEndpoint, url.PathEscape(projectID)),
+		tokenURL: tokenURL,
+		email:    account.ClientEmail,
+		keyID:    account.PrivateKeyID,
+		key:      key,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+	}, nil
+}
+
+type fcmMessage struct {
+	Message fcmMessageBody `json:"message"`
+}
+
+type fcmMessageBody struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      fcmAndroidConfig  `json:"android"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroidConfig struct {
+	CollapseKey string `json:"collapse_key,omitempty"`
+	Priority    string `json:"priority"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+func (f *FCM) Send(ctx context.Context, token string, n Notification) error {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(fcmMessage{Message: fcmMessageBody{
+		Token:        token,
+		Notification: fcmNotification{Title: n.Title, Body: n.Body},
+		Data:         n.Data,
+		Android:      fcmAndroidConfig{CollapseKey: n.CollapseKey, Priority: "HIGH"},
+	}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	var errResp fcmErrorResponse
+	_ = json.Unmarshal(respBody, &errResp)
+	reason := errResp.Error.Status
+	for _, detail := range errResp.Error.Details {
+		if detail.ErrorCode != "" {
+			reason = detail.ErrorCode
+		}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || reason == "UNREGISTERED":
+		return ErrInvalidToken
+	case resp.StatusCode == http.StatusUnauthorized:
+		// Fetch a new access token for the next notification
+		f.mu.Lock()
+		f.accessToken = ""
+		f.mu.Unlock()
+	}
+	return &StatusError{StatusCode: resp.StatusCode, Reason: reason}
+}
+
+// token returns a valid access token, exchanging a new service account
+// assertion when the cached one is about to expire
+func (f *FCM) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	if f.accessToken != "" && now.Add(fcmTokenMargin).Before(f.expiresAt) {
+		return f.accessToken, nil
+	}
+
+	assertion, err := signJWT(f.key, f.keyID, map[string]interface{}{
+		"iss":   f.email,
+		"scope": fcmScope,
+		"aud":   f.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("push: FCM token exchange responded with status %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.AccessToken == "" {
+		return "", errors.New("push: FCM token exchange returned no access token")
+	}
+
+	f.accessToken = tokenResp.AccessToken
+	f.expiresAt = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
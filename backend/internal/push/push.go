@@ -0,0 +1,151 @@
+// Package push delivers notifications to mobile devices through Firebase
+// Cloud Messaging (Android) and the Apple Push Notification service (iOS)
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Platforms a device token belongs to
+const (
+	PlatformFCM  = "FCM"
+	PlatformAPNs = "APNS"
+)
+
+var (
+	// ErrInvalidToken is returned when the push service rejects the device
+	// token for good, e.g. because the app was uninstalled; the device
+	// should be forgotten
+	ErrInvalidToken = errors.New("push: device token is no longer valid")
+	// ErrPlatformNotConfigured is returned for a device of a platform
+	// without credentials
+	ErrPlatformNotConfigured = errors.New("push: platform not configured")
+)
+
+// Notification is one push message
+type Notification struct {
+	Title string
+	Body  string
+	// Data is handed to the app alongside the alert
+	Data map[string]string
+	// CollapseKey lets the push service replace an undelivered notification
+	// with a newer one of the same key
+	CollapseKey string
+}
+
+// Sender delivers notifications to devices. Implementations must be safe for
+// concurrent use.
+type Sender interface {
+	// Send returns once the push service has accepted the notification
+	Send(ctx context.Context, platform, token string, n Notification) error
+}
+
+// Config holds the credentials of each platform; a platform without them is
+// disabled
+type Config struct {
+	// FCMCredentials is the JSON key of a service account allowed to send
+	// through FCM; FCMProjectID overrides its project_id
+	FCMCredentials []byte
+	FCMProjectID   string
+	// APNsKey is the PEM (.p8) token signing key identified by APNsKeyID,
+	// issued to APNsTeamID
+	APNsKey    []byte
+	APNsKeyID  string
+	APNsTeamID string
+	// APNsTopic is the app's bundle ID
+	APNsTopic string
+	// APNsProduction selects the production gateway instead of the sandbox
+	APNsProduction bool
+	// Timeout bounds each request unless the context ends sooner
+	Timeout time.Duration
+}
+
+// DefaultConfig returns sensible defaults
+func DefaultConfig() Config {
+	return Config{
+		Timeout: 10 * time.Second,
+	}
+}
+
+// Platforms returns the platforms with credentials
+func (c Config) Platforms() []string {
+	var platforms []string
+	if len(c.FCMCredentials) > 0 {
+		platforms = append(platforms, PlatformFCM)
+	}
+	if len(c.APNsKey) > 0 {
+		platforms = append(platforms, PlatformAPNs)
+	}
+	return platforms
+}
+
+// NewSender creates a sender for the configured platforms; nil when none is
+// configured. No connection is made until the first notification.
+func NewSender(cfg Config) (Sender, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultConfig().Timeout
+	}
+
+	var r router
+	if len(cfg.FCMCredentials) > 0 {
+		fcm, err := NewFCM(cfg.FCMCredentials, cfg.FCMProjectID, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.fcm = fcm
+	}
+	if len(cfg.APNsKey) > 0 {
+		if cfg.APNsKeyID == "" || cfg.APNsTeamID == "" || cfg.APNsTopic == "" {
+			return nil, errors.New("push: APNs needs a key id, a team id and a topic")
+		}
+		apns, err := NewAPNs(cfg.APNsKey, cfg.APNsKeyID, cfg.APNsTeamID, cfg.APNsTopic, cfg.APNsProduction, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		r.apns = apns
+	}
+
+	if r.fcm == nil && r.apns == nil {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// router sends each notification through its device's platform
+type router struct {
+	fcm  *FCM
+	apns *APNs
+}
+
+func (r *router) Send(ctx context.Context, platform, token string, n Notification) error {
+	switch platform {
+	case PlatformFCM:
+		if r.fcm != nil {
+			return r.fcm.Send(ctx, token, n)
+		}
+	case PlatformAPNs:
+		if r.apns != nil {
+			return r.apns.Send(ctx, token, n)
+		}
+	default:
+		return fmt.Errorf("push: unknown platform %q", platform)
+	}
+	return ErrPlatformNotConfigured
+}
+
+// StatusError is a push service response other than success or an invalid
+// token
+type StatusError struct {
+	StatusCode int
+	Reason     string
+}
+
+func (e *StatusError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("push: service responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("push: service responded with status %d: %s", e.StatusCode, e.Reason)
+}
@@ -0,0 +1,186 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pkcs8PEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// jwtParts decodes a compact JWT into its header, claims and signature
+func jwtParts(t *testing.T, token string) (map[string]interface{}, map[string]interface{}, []byte) {
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	var header, claims map[string]interface{}
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &header))
+	raw, err = base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(raw, &claims))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	return header, claims, signature
+}
+
+func TestFCM_Send(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var exchanges atomic.Int32
+	var message fcmMessage
+	var tokenURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			exchanges.Add(1)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+			header, claims, _ := jwtParts(t, r.PostForm.Get("assertion"))
+			assert.Equal(t, "RS256", header["alg"])
+			assert.Equal(t, "key-1", header["kid"])
+			assert.Equal(t, "sender@example.iam.gserviceaccount.com", claims["iss"])
+			assert.Equal(t, fcmScope, claims["scope"])
+			assert.Equal(t, tokenURL, claims["aud"])
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "ya29.token", "expires_in": 3600})
+		case "/v1/projects/demo-project/messages:send":
+			assert.Equal(t, "Bearer ya29.token", r.Header.Get("Authorization"))
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &message))
+			if message.Message.Token == "gone" {
+				w.WriteHeader(http.StatusNotFound)
+				io.WriteString(w, `{"error":{"code":404,"status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`)
+				return
+			}
+			io.WriteString(w, `{"name":"projects/demo-project/messages/1"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	tokenURL = server.URL + "/token"
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "demo-project",
+		"private_key_id": "key-1",
+		"private_key":    string(pkcs8PEM(t, key)),
+		"client_email":   "sender@example.iam.gserviceaccount.com",
+		"token_uri":      tokenURL,
+	})
+	require.NoError(t, err)
+
+	fcm, err := NewFCM(credentials, "", time.Second)
+	require.NoError(t, err)
+	fcm.endpoint = server.URL + "/v1/projects/demo-project/messages:send"
+
+	ctx := context.Background()
+	n := Notification{Title: "New conversation", Body: "Assigned to you", Data: map[string]string{"conversation_id": "c1"}, CollapseKey: "c1"}
+	require.NoError(t, fcm.Send(ctx, "device-token", n))
+	assert.Equal(t, "device-token", message.Message.Token)
+	assert.Equal(t, "New conversation", message.Message.Notification.Title)
+	assert.Equal(t, "c1", message.Message.Data["conversation_id"])
+	assert.Equal(t, "c1", message.Message.Android.CollapseKey)
+
+	// The access token is reused
+	require.NoError(t, fcm.Send(ctx, "device-token", n))
+	assert.Equal(t, int32(1), exchanges.Load())
+
+	assert.ErrorIs(t, fcm.Send(ctx, "gone", n), ErrInvalidToken)
+}
+
+func TestAPNs_Send(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var payload map[string]interface{}
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &payload))
+		switch r.URL.Path {
+		case "/3/device/gone":
+			w.WriteHeader(http.StatusGone)
+			io.WriteString(w, `{"reason":"Unregistered"}`)
+		case "/3/device/throttled":
+			w.WriteHeader(http.StatusTooManyRequests)
+			io.WriteString(w, `{"reason":"TooManyRequests"}`)
+		}
+	}))
+	defer server.Close()
+
+	apns, err := NewAPNs(pkcs8PEM(t, key), "KEY123", "TEAM456", "com.example.inbox", false, time.Second)
+	require.NoError(t, err)
+	apns.endpoint = server.URL
+
+	ctx := context.Background()
+	n := Notification{Title: "New conversation", Body: "Assigned to you", Data: map[string]string{"conversation_id": "c1"}, CollapseKey: "c1"}
+	require.NoError(t, apns.Send(ctx, "device-token", n))
+
+	assert.Equal(t, "com.example.inbox", headers.Get("apns-topic"))
+	assert.Equal(t, "alert", headers.Get("apns-push-type"))
+	assert.Equal(t, "c1", headers.Get("apns-collapse-id"))
+	assert.Equal(t, "c1", payload["conversation_id"])
+	aps := payload["aps"].(map[string]interface{})
+	assert.Equal(t, "New conversation", aps["alert"].(map[string]interface{})["title"])
+
+	// The provider token is an ES256 JWT of the team, signed with the key
+	jwt := strings.TrimPrefix(headers.Get("Authorization"), "bearer ")
+	header, claims, signature := jwtParts(t, jwt)
+	assert.Equal(t, "ES256", header["alg"])
+	assert.Equal(t, "KEY123", header["kid"])
+	assert.Equal(t, "TEAM456", claims["iss"])
+	require.Len(t, signature, 64)
+	digest := sha256.Sum256([]byte(jwt[:strings.LastIndex(jwt, ".")]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.True(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s))
+
+	assert.ErrorIs(t, apns.Send(ctx, "gone", n), ErrInvalidToken)
+
+	var statusErr *StatusError
+	require.ErrorAs(t, apns.Send(ctx, "throttled", n), &statusErr)
+	assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+	assert.Equal(t, "TooManyRequests", statusErr.Reason)
+}
+
+func TestNewSender(t *testing.T) {
+	sender, err := NewSender(DefaultConfig())
+	require.NoError(t, err)
+	assert.Nil(t, sender, "no platform configured")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewSender(Config{APNsKey: pkcs8PEM(t, key)})
+	assert.Error(t, err, "APNs needs a key id, team id and topic")
+
+	sender, err = NewSender(Config{APNsKey: pkcs8PEM(t, key), APNsKeyID: "KEY123", APNsTeamID: "TEAM456", APNsTopic: "com.example.inbox"})
+	require.NoError(t, err)
+	require.NotNil(t, sender)
+	assert.ErrorIs(t, sender.Send(context.Background(), PlatformFCM, "token", Notification{}), ErrPlatformNotConfigured)
+	assert.Error(t, sender.Send(context.Background(), "WEB", "token", Notification{}))
+}
@@ -1,47 +1,198 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/cache"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // RepositoryContainer holds all repository instances
 type RepositoryContainer struct {
-	pool                   *pgxpool.Pool
-	queries                *Queries
+	pool    *pgxpool.Pool
+	queries *Queries
+	// tx and invalidations are set on containers returned by WithTx
+	tx            pgx.Tx
+	invalidations *txInvalidations
+
 	Tenants                *TenantRepositoryImpl
+	TenantClassifiers      *TenantClassifierRepositoryImpl
 	Inboxes                *InboxRepositoryImpl
+	InboxAdmins            *InboxAdminRepositoryImpl
+	InboxSLAPolicies       *InboxSLAPolicyRepositoryImpl
+	InboxStalenessPolicies *InboxStalenessPolicyRepositoryImpl
+	CategoryQuotas         *CategoryQuotaRepositoryImpl
+	Checklists             *ChecklistRepositoryImpl
 	Operators              *OperatorRepositoryImpl
 	Subscriptions          *SubscriptionRepositoryImpl
+	OperatorShadows        *OperatorShadowRepositoryImpl
+	OperatorSchedules      *OperatorScheduleRepositoryImpl
 	OperatorStatus         *OperatorStatusRepositoryImpl
+	OperatorPresence       *OperatorPresenceRepositoryImpl
+	OperatorVacations      *OperatorVacationRepositoryImpl
+	OperatorDevices        *OperatorDeviceRepositoryImpl
+	OperatorHealth         *OperatorAllocationHealthRepositoryImpl
 	ConversationRefs       *ConversationRefRepositoryImpl
+	PriorityComponents     *PriorityScoreComponentRepositoryImpl
+	ConversationNotes      *ConversationNoteRepositoryImpl
+	ConversationReads      *ConversationReadRepositoryImpl
+	ConversationActivity   *ConversationActivityRepositoryImpl
+	ShareLinks             *ShareLinkRepositoryImpl
+	Customers              *CustomerRepositoryImpl
+	CustomerAffinities     *CustomerOperatorAffinityRepositoryImpl
+	Escalations            *ConversationEscalationRepositoryImpl
+	Transfers              *ConversationTransferRepositoryImpl
 	Labels                 *LabelRepositoryImpl
 	ConversationLabels     *ConversationLabelRepositoryImpl
 	GracePeriodAssignments *GracePeriodRepositoryImpl
-	Idempotency            *IdempotencyRepositoryImpl
+	DeletionImpacts        *DeletionImpactRepositoryImpl
+	Idempotency            domain.IdempotencyRepository
+	Webhooks               *WebhookRepositoryImpl
+	WebhookDeliveries      *WebhookDeliveryRepositoryImpl
+	Outbox                 *OutboxRepositoryImpl
+	RoutingRules           *RoutingRuleRepositoryImpl
+	Experiments            *PriorityExperimentRepositoryImpl
+	AuditLogs              *AuditLogRepositoryImpl
+	QAReviewers            *QAReviewerRepositoryImpl
+	QAReviewItems          *QAReviewItemRepositoryImpl
+	Backfills              *BackfillRepositoryImpl
+	APIKeys                *APIKeyRepositoryImpl
+	AllocationIntents      *AllocationIntentRepositoryImpl
+	QueueRanks             *QueueRankRepositoryImpl
+	AnomalySettings        *TenantAnomalySettingsRepositoryImpl
+	MaintenanceSettings    *TenantMaintenanceSettingsRepositoryImpl
+	Anomalies              *AnomalyRepositoryImpl
+	WorkerInstances        *WorkerInstanceRepositoryImpl
+	Invariants             *InvariantRepositoryImpl
+	Reconciliation         *ReconciliationRepositoryImpl
 }
 
 // NewRepositoryContainer creates all repository instances
 func NewRepositoryContainer(pool *pgxpool.Pool) *RepositoryContainer {
-	queries := New(pool)
+	return newRepositoryContainer(pool, New(pool), pool)
+}
 
+// newRepositoryContainer creates the repositories on queries; db runs the
+// queries built at runtime
+func newRepositoryContainer(pool *pgxpool.Pool, queries *Queries, db DBTX) *RepositoryContainer {
 	return &RepositoryContainer{
 		pool:                   pool,
 		queries:                queries,
 		Tenants:                NewTenantRepository(queries),
+		TenantClassifiers:      NewTenantClassifierRepository(queries),
 		Inboxes:                NewInboxRepository(queries),
+		InboxAdmins:            NewInboxAdminRepository(queries),
+		InboxSLAPolicies:       NewInboxSLAPolicyRepository(queries),
+		InboxStalenessPolicies: NewInboxStalenessPolicyRepository(queries),
+		CategoryQuotas:         NewCategoryQuotaRepository(queries),
+		Checklists:             NewChecklistRepository(queries),
 		Operators:              NewOperatorRepository(queries),
 		Subscriptions:          NewSubscriptionRepository(queries),
+		OperatorShadows:        NewOperatorShadowRepository(queries),
+		OperatorSchedules:      NewOperatorScheduleRepository(queries),
 		OperatorStatus:         NewOperatorStatusRepository(queries),
-		ConversationRefs:       NewConversationRefRepository(queries, pool),
+		OperatorPresence:       NewOperatorPresenceRepository(queries),
+		OperatorVacations:      NewOperatorVacationRepository(queries),
+		OperatorDevices:        NewOperatorDeviceRepository(queries),
+		OperatorHealth:         NewOperatorAllocationHealthRepository(queries),
+		ConversationRefs:       NewConversationRefRepository(queries, db),
+		PriorityComponents:     NewPriorityScoreComponentRepository(queries),
+		ConversationNotes:      NewConversationNoteRepository(queries),
+		ConversationReads:      NewConversationReadRepository(queries),
+		ConversationActivity:   NewConversationActivityRepository(queries),
+		ShareLinks:             NewShareLinkRepository(queries),
+		Customers:              NewCustomerRepository(queries),
+		CustomerAffinities:     NewCustomerOperatorAffinityRepository(queries),
+		Escalations:            NewConversationEscalationRepository(queries),
+		Transfers:              NewConversationTransferRepository(queries),
 		Labels:                 NewLabelRepository(queries),
 		ConversationLabels:     NewConversationLabelRepository(queries),
 		GracePeriodAssignments: NewGracePeriodRepository(queries, pool),
+		DeletionImpacts:        NewDeletionImpactRepository(queries),
 		Idempotency:            NewIdempotencyRepository(queries),
+		Webhooks:               NewWebhookRepository(queries),
+		WebhookDeliveries:      NewWebhookDeliveryRepository(queries),
+		Outbox:                 NewOutboxRepository(queries),
+		RoutingRules:           NewRoutingRuleRepository(queries),
+		Experiments:            NewPriorityExperimentRepository(queries),
+		AuditLogs:              NewAuditLogRepository(queries, db),
+		QAReviewers:            NewQAReviewerRepository(queries),
+		QAReviewItems:          NewQAReviewItemRepository(queries),
+		Backfills:              NewBackfillRepository(queries),
+		APIKeys:                NewAPIKeyRepository(queries),
+		AllocationIntents:      NewAllocationIntentRepository(queries),
+		QueueRanks:             NewQueueRankRepository(queries, pool),
+		AnomalySettings:        NewTenantAnomalySettingsRepository(queries),
+		MaintenanceSettings:    NewTenantMaintenanceSettingsRepository(queries),
+		Anomalies:              NewAnomalyRepository(queries),
+		WorkerInstances:        NewWorkerInstanceRepository(queries),
+		Invariants:             NewInvariantRepository(queries),
+		Reconciliation:         NewReconciliationRepository(queries),
+	}
+}
+
+// UseCache serves operator statuses, subscribed inbox IDs and tenants from c,
+// the reads made on every allocation. Their writes through this container
+// invalidate the entries; ttl bounds how stale an entry can get otherwise.
+func (rc *RepositoryContainer) UseCache(c cache.Cache, ttl time.Duration) {
+	reads := &readCache{cache: c, ttl: ttl}
+	rc.OperatorStatus.cache = reads
+	rc.Subscriptions.cache = reads
+	rc.Tenants.cache = reads
+}
+
+// UseCandidateTuner lets tuner size the candidate window of the allocation
+// query, see CandidateTuner
+func (rc *RepositoryContainer) UseCandidateTuner(tuner *CandidateTuner) {
+	rc.ConversationRefs.candidates = tuner
+}
+
+// UseIdempotencyStore keeps idempotency keys in store instead of Postgres.
+// Repositories bound to a transaction keep using Postgres; idempotency keys
+// are never written in one.
+func (rc *RepositoryContainer) UseIdempotencyStore(store domain.IdempotencyRepository) {
+	rc.Idempotency = store
+}
+
+// WithTx returns the repositories bound to a transaction, so that the rows
+// they lock and write belong to tx. Cached reads go to the transaction
+// instead, and the cache entries their writes make stale are dropped by
+// Commit. QueueRanks keeps running its own transactions on the pool.
+func (rc *RepositoryContainer) WithTx(tx pgx.Tx) *RepositoryContainer {
+	txRepos := newRepositoryContainer(rc.pool, rc.queries.WithTx(tx), tx)
+	txRepos.tx = tx
+	if reads := rc.Tenants.cache; reads != nil {
+		pending := &txInvalidations{cache: reads.cache}
+		txRepos.OperatorStatus.cache = rc.OperatorStatus.cache.forTx(pending)
+		txRepos.Subscriptions.cache = rc.Subscriptions.cache.forTx(pending)
+		txRepos.Tenants.cache = reads.forTx(pending)
+		txRepos.invalidations = pending
+	}
+	txRepos.ConversationRefs.candidates = rc.ConversationRefs.candidates
+	return txRepos
+}
+
+// Commit commits the transaction the repositories are bound to, then drops
+// the cache entries their writes made stale. Committing the transaction
+// directly leaves those entries until the cache TTL expires them.
+func (rc *RepositoryContainer) Commit(ctx context.Context) error {
+	if rc.tx == nil {
+		return errors.New("repository: Commit outside a transaction")
+	}
+	if err := rc.tx.Commit(ctx); err != nil {
+		return err
+	}
+	if rc.invalidations != nil {
+		rc.invalidations.flush(ctx)
 	}
+	return nil
 }
 
-// WithTx returns queries bound to a transaction
-func (rc *RepositoryContainer) WithTx(tx pgx.Tx) *Queries {
-	return rc.queries.WithTx(tx)
+// Queries returns the queries the repositories run on
+func (rc *RepositoryContainer) Queries() *Queries {
+	return rc.queries
 }
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AllocationIntentRepositoryImpl struct {
+	q *Queries
+}
+
+func NewAllocationIntentRepository(q *Queries) *AllocationIntentRepositoryImpl {
+	return &AllocationIntentRepositoryImpl{q: q}
+}
+
+func (r *AllocationIntentRepositoryImpl) Create(ctx context.Context, intent *domain.AllocationIntent) error {
+	err := r.q.CreateAllocationIntent(ctx, CreateAllocationIntentParams{
+		ID:             uuidToPgtype(intent.ID),
+		TenantID:       uuidToPgtype(intent.TenantID),
+		OperatorID:     uuidToPgtype(intent.OperatorID),
+		Kind:           string(intent.Kind),
+		IdempotencyKey: stringPtrToPgtype(intent.IdempotencyKey),
+		ConversationID: uuidPtrToPgtype(intent.ConversationID),
+		Status:         string(intent.Status),
+		Recovered:      intent.Recovered,
+		CreatedAt:      timeToPgtype(intent.CreatedAt),
+		ResolvedAt:     timePtrToPgtype(intent.ResolvedAt),
+	})
+	return mapError(err)
+}
+
+func (r *AllocationIntentRepositoryImpl) GetByIdempotencyKey(ctx context.Context, tenantID uuid.UUID, key string) (*domain.AllocationIntent, error) {
+	row, err := r.q.GetAllocationIntentByIdempotencyKey(ctx, GetAllocationIntentByIdempotencyKeyParams{
+		TenantID:       uuidToPgtype(tenantID),
+		IdempotencyKey: pgtype.Text{String: key, Valid: true},
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *AllocationIntentRepositoryImpl) SetConversation(ctx context.Context, id, conversationID uuid.UUID) error {
+	err := r.q.SetAllocationIntentConversation(ctx, SetAllocationIntentConversationParams{
+		ID:             uuidToPgtype(id),
+		ConversationID: uuidToPgtype(conversationID),
+	})
+	return mapError(err)
+}
+
+func (r *AllocationIntentRepositoryImpl) Resolve(ctx context.Context, intent *domain.AllocationIntent) (bool, error) {
+	rows, err := r.q.ResolveAllocationIntent(ctx, ResolveAllocationIntentParams{
+		ID:         uuidToPgtype(intent.ID),
+		Status:     string(intent.Status),
+		Recovered:  intent.Recovered,
+		ResolvedAt: timePtrToPgtype(intent.ResolvedAt),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return rows > 0, nil
+}
+
+func (r *AllocationIntentRepositoryImpl) Restart(ctx context.Context, intent *domain.AllocationIntent) error {
+	id, err := r.q.RestartAllocationIntent(ctx, RestartAllocationIntentParams{
+		TenantID:       uuidToPgtype(intent.TenantID),
+		IdempotencyKey: stringPtrToPgtype(intent.IdempotencyKey),
+		OperatorID:     uuidToPgtype(intent.OperatorID),
+		Kind:           string(intent.Kind),
+		ConversationID: uuidPtrToPgtype(intent.ConversationID),
+		CreatedAt:      timeToPgtype(intent.CreatedAt),
+	})
+	if err != nil {
+		return mapError(err)
+	}
+	intent.ID = pgtypeToUUID(id)
+	return nil
+}
+
+func (r *AllocationIntentRepositoryImpl) GetPendingBefore(ctx context.Context, before time.Time, limit int) ([]*domain.AllocationIntent, error) {
+	rows, err := r.q.GetPendingAllocationIntents(ctx, GetPendingAllocationIntentsParams{
+		CreatedAt: timeToPgtype(before),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make([]*domain.AllocationIntent, len(rows))
+	for i, row := range rows {
+		result[i] = r.toDomain(row)
+	}
+	return result, nil
+}
+
+func (r *AllocationIntentRepositoryImpl) DeleteResolvedBefore(ctx context.Context, before time.Time) (int64, error) {
+	rows, err := r.q.DeleteResolvedAllocationIntents(ctx, timeToPgtype(before))
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return rows, nil
+}
+
+func (r *AllocationIntentRepositoryImpl) CountAborted(ctx context.Context, tenantID uuid.UUID, from, to time.Time) (int, error) {
+	count, err := r.q.CountAbortedAllocationIntents(ctx, CountAbortedAllocationIntentsParams{
+		TenantID:    uuidToPgtype(tenantID),
+		CreatedAt:   timeToPgtype(from),
+		CreatedAt_2: timeToPgtype(to),
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+func (r *AllocationIntentRepositoryImpl) toDomain(row AllocationIntent) *domain.AllocationIntent {
+	return &domain.AllocationIntent{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		OperatorID:     pgtypeToUUID(row.OperatorID),
+		Kind:           domain.AllocationIntentKind(row.Kind),
+		IdempotencyKey: pgtypeToStringPtr(row.IdempotencyKey),
+		ConversationID: pgtypeToUUIDPtr(row.ConversationID),
+		Status:         domain.AllocationIntentStatus(row.Status),
+		Recovered:      row.Recovered,
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+		ResolvedAt:     pgtypeToTimePtr(row.ResolvedAt),
+	}
+}
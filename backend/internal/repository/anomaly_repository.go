@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type AnomalyRepositoryImpl struct {
+	q *Queries
+}
+
+func NewAnomalyRepository(q *Queries) *AnomalyRepositoryImpl {
+	return &AnomalyRepositoryImpl{q: q}
+}
+
+func (r *AnomalyRepositoryImpl) Create(ctx context.Context, a *domain.Anomaly) error {
+	err := r.q.CreateAnomaly(ctx, CreateAnomalyParams{
+		ID:            uuidToPgtype(a.ID),
+		TenantID:      uuidToPgtype(a.TenantID),
+		Kind:          string(a.Kind),
+		OperatorID:    uuidPtrToPgtype(a.OperatorID),
+		Observed:      int32(a.Observed),
+		Baseline:      a.Baseline,
+		Threshold:     a.Threshold,
+		WindowSeconds: int32(a.Window.Seconds()),
+		DetectedAt:    timeToPgtype(a.DetectedAt),
+	})
+	return mapError(err)
+}
+
+func (r *AnomalyRepositoryImpl) ExistsSince(ctx context.Context, tenantID uuid.UUID, kind domain.AnomalyKind, operatorID *uuid.UUID, since time.Time) (bool, error) {
+	exists, err := r.q.ExistsRecentAnomaly(ctx, ExistsRecentAnomalyParams{
+		TenantID:   uuidToPgtype(tenantID),
+		Kind:       string(kind),
+		OperatorID: uuidPtrToPgtype(operatorID),
+		DetectedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return exists, nil
+}
+
+func (r *AnomalyRepositoryImpl) List(ctx context.Context, tenantID uuid.UUID, since time.Time, limit int) ([]*domain.Anomaly, error) {
+	rows, err := r.q.ListAnomalies(ctx, ListAnomaliesParams{
+		TenantID:   uuidToPgtype(tenantID),
+		DetectedAt: timeToPgtype(since),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	anomalies := make([]*domain.Anomaly, len(rows))
+	for i, row := range rows {
+		anomalies[i] = r.toDomain(row)
+	}
+	return anomalies, nil
+}
+
+func (r *AnomalyRepositoryImpl) toDomain(row Anomaly) *domain.Anomaly {
+	return &domain.Anomaly{
+		ID:         pgtypeToUUID(row.ID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		Kind:       domain.AnomalyKind(row.Kind),
+		OperatorID: pgtypeToUUIDPtr(row.OperatorID),
+		Observed:   int(row.Observed),
+		Baseline:   row.Baseline,
+		Threshold:  row.Threshold,
+		Window:     time.Duration(row.WindowSeconds) * time.Second,
+		DetectedAt: pgtypeToTime(row.DetectedAt),
+	}
+}
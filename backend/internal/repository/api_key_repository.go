@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type APIKeyRepositoryImpl struct {
+	q *Queries
+}
+
+func NewAPIKeyRepository(q *Queries) *APIKeyRepositoryImpl {
+	return &APIKeyRepositoryImpl{q: q}
+}
+
+func (r *APIKeyRepositoryImpl) Create(ctx context.Context, key *domain.APIKey) error {
+	err := r.q.CreateApiKey(ctx, CreateApiKeyParams{
+		ID:        uuidToPgtype(key.ID),
+		TenantID:  uuidToPgtype(key.TenantID),
+		Name:      key.Name,
+		KeyPrefix: key.KeyPrefix,
+		KeyHash:   key.KeyHash,
+		Role:      operatorRoleToPgtype(key.Role),
+		CreatedBy: uuidPtrToPgtype(key.CreatedBy),
+		CreatedAt: timeToPgtype(key.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *APIKeyRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	row, err := r.q.GetApiKeyByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	row, err := r.q.GetApiKeyByHash(ctx, keyHash)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *APIKeyRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.APIKey, error) {
+	rows, err := r.q.GetApiKeysByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	keys := make([]*domain.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = r.toDomain(row)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepositoryImpl) Revoke(ctx context.Context, key *domain.APIKey) error {
+	return mapError(r.q.RevokeApiKey(ctx, RevokeApiKeyParams{
+		ID:        uuidToPgtype(key.ID),
+		RevokedAt: timePtrToPgtype(key.RevokedAt),
+	}))
+}
+
+func (r *APIKeyRepositoryImpl) Touch(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	return mapError(r.q.TouchApiKey(ctx, TouchApiKeyParams{
+		ID:         uuidToPgtype(id),
+		LastUsedAt: timeToPgtype(usedAt),
+	}))
+}
+
+func (r *APIKeyRepositoryImpl) toDomain(row ApiKey) *domain.APIKey {
+	return &domain.APIKey{
+		ID:         pgtypeToUUID(row.ID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		Name:       row.Name,
+		KeyPrefix:  row.KeyPrefix,
+		KeyHash:    row.KeyHash,
+		Role:       pgtypeToOperatorRole(row.Role),
+		CreatedBy:  pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt:  pgtypeToTime(row.CreatedAt),
+		LastUsedAt: pgtypeToTimePtr(row.LastUsedAt),
+		RevokedAt:  pgtypeToTimePtr(row.RevokedAt),
+	}
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultAuditLogLimit = 50
+	maxAuditLogLimit     = 100
+)
+
+type AuditLogRepositoryImpl struct {
+	q  *Queries
+	db DBTX
+}
+
+func NewAuditLogRepository(q *Queries, db DBTX) *AuditLogRepositoryImpl {
+	return &AuditLogRepositoryImpl{q: q, db: db}
+}
+
+func (r *AuditLogRepositoryImpl) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	before, err := marshalSnapshot(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := marshalSnapshot(entry.After)
+	if err != nil {
+		return err
+	}
+
+	return r.q.CreateAuditLogEntry(ctx, CreateAuditLogEntryParams{
+		ID:          uuidToPgtype(entry.ID),
+		TenantID:    uuidToPgtype(entry.TenantID),
+		ActorID:     uuidPtrToPgtype(entry.ActorID),
+		Action:      string(entry.Action),
+		EntityType:  string(entry.EntityType),
+		EntityID:    uuidToPgtype(entry.EntityID),
+		BeforeState: before,
+		AfterState:  after,
+		CreatedAt:   timeToPgtype(entry.CreatedAt),
+	})
+}
+
+// List returns audit entries matching the filter, newest first
+func (r *AuditLogRepositoryImpl) List(ctx context.Context, filter domain.AuditLogFilter) ([]*domain.AuditEntry, error) {
+	// Build dynamic query
+	query := `
+		SELECT
+			id, tenant_id, actor_id, action, entity_type, entity_id,
+			before_state, after_state, created_at
+		FROM audit_log
+		WHERE tenant_id = $1
+	`
+	args := []interface{}{filter.TenantID}
+	argIndex := 2
+
+	if filter.ActorID != nil {
+		query += fmt.Sprintf(` AND actor_id = $%d`, argIndex)
+		args = append(args, *filter.ActorID)
+		argIndex++
+	}
+
+	if filter.EntityType != nil {
+		query += fmt.Sprintf(` AND entity_type = $%d`, argIndex)
+		args = append(args, string(*filter.EntityType))
+		argIndex++
+	}
+
+	if filter.EntityID != nil {
+		query += fmt.Sprintf(` AND entity_id = $%d`, argIndex)
+		args = append(args, *filter.EntityID)
+		argIndex++
+	}
+
+	if filter.Action != nil {
+		query += fmt.Sprintf(` AND action = $%d`, argIndex)
+		args = append(args, string(*filter.Action))
+		argIndex++
+	}
+
+	if len(filter.Actions) > 0 {
+		actions := make([]string, len(filter.Actions))
+		for i, action := range filter.Actions {
+			actions[i] = string(action)
+		}
+		query += fmt.Sprintf(` AND action = ANY($%d)`, argIndex)
+		args = append(args, actions)
+		argIndex++
+	}
+
+	if filter.From != nil {
+		query += fmt.Sprintf(` AND created_at >= $%d`, argIndex)
+		args = append(args, *filter.From)
+		argIndex++
+	}
+
+	if filter.To != nil {
+		query += fmt.Sprintf(` AND created_at < $%d`, argIndex)
+		args = append(args, *filter.To)
+		argIndex++
+	}
+
+	// Cursor pagination
+	if filter.CursorTimestamp != nil && filter.CursorID != nil {
+		query += fmt.Sprintf(` AND (created_at, id) < ($%d, $%d)`, argIndex, argIndex+1)
+		args = append(args, *filter.CursorTimestamp, *filter.CursorID)
+		argIndex += 2
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLogLimit
+	}
+	if limit > maxAuditLogLimit {
+		limit = maxAuditLogLimit
+	}
+
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, argIndex)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.AuditEntry{}
+	for rows.Next() {
+		var row AuditLog
+		err := rows.Scan(
+			&row.ID, &row.TenantID, &row.ActorID, &row.Action, &row.EntityType, &row.EntityID,
+			&row.BeforeState, &row.AfterState, &row.CreatedAt,
+		)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		entry, err := r.toDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, mapError(err)
+	}
+
+	return entries, nil
+}
+
+// ListByAction returns the tenant's entries for one action since the given time, oldest first
+func (r *AuditLogRepositoryImpl) ListByAction(ctx context.Context, tenantID uuid.UUID, action domain.AuditAction, since time.Time) ([]*domain.AuditEntry, error) {
+	rows, err := r.q.ListAuditLogByAction(ctx, ListAuditLogByActionParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Action:    string(action),
+		CreatedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := r.toDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *AuditLogRepositoryImpl) CountByAction(ctx context.Context, tenantID uuid.UUID, action domain.AuditAction, from, to time.Time) (int, error) {
+	count, err := r.q.CountAuditLogByAction(ctx, CountAuditLogByActionParams{
+		TenantID:    uuidToPgtype(tenantID),
+		Action:      string(action),
+		CreatedAt:   timeToPgtype(from),
+		CreatedAt_2: timeToPgtype(to),
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+func (r *AuditLogRepositoryImpl) CountFastResolves(ctx context.Context, tenantID uuid.UUID, since time.Time, within time.Duration) (map[uuid.UUID]int, error) {
+	rows, err := r.q.CountFastResolvesByActor(ctx, CountFastResolvesByActorParams{
+		TenantID:  uuidToPgtype(tenantID),
+		CreatedAt: timeToPgtype(since),
+		Column3:   within.Seconds(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[pgtypeToUUID(row.ActorID)] = int(row.Resolves)
+	}
+	return counts, nil
+}
+
+func (r *AuditLogRepositoryImpl) CountInboxAllocations(ctx context.Context, tenantID, inboxID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.q.CountInboxAllocations(ctx, CountInboxAllocationsParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   inboxID.String(),
+		CreatedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+// CountInboxAllocationsByLabel counts the allocations and claims out of the
+// inbox since the given time of conversations carrying each of the labels;
+// labels without any are absent
+func (r *AuditLogRepositoryImpl) CountInboxAllocationsByLabel(ctx context.Context, tenantID, inboxID uuid.UUID, labelIDs []uuid.UUID, since time.Time) (map[uuid.UUID]int, error) {
+	ids := make([]pgtype.UUID, len(labelIDs))
+	for i, id := range labelIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	rows, err := r.q.CountInboxAllocationsByLabel(ctx, CountInboxAllocationsByLabelParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   inboxID.String(),
+		CreatedAt: timeToPgtype(since),
+		Column4:   ids,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[pgtypeToUUID(row.LabelID)] = int(row.Allocations)
+	}
+	return counts, nil
+}
+
+// CountAllocationsByInbox counts the allocations and claims per inbox since
+// the given time; inboxes without any are absent
+func (r *AuditLogRepositoryImpl) CountAllocationsByInbox(ctx context.Context, tenantID uuid.UUID, since time.Time) (map[uuid.UUID]int, error) {
+	rows, err := r.q.CountAllocationsByInbox(ctx, CountAllocationsByInboxParams{
+		TenantID:  uuidToPgtype(tenantID),
+		CreatedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[pgtypeToUUID(row.InboxID)] = int(row.Allocations)
+	}
+	return counts, nil
+}
+
+func (r *AuditLogRepositoryImpl) toDomain(row AuditLog) (*domain.AuditEntry, error) {
+	before, err := unmarshalSnapshot(row.BeforeState)
+	if err != nil {
+		return nil, err
+	}
+	after, err := unmarshalSnapshot(row.AfterState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuditEntry{
+		ID:         pgtypeToUUID(row.ID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		ActorID:    pgtypeToUUIDPtr(row.ActorID),
+		Action:     domain.AuditAction(row.Action),
+		EntityType: domain.AuditEntityType(row.EntityType),
+		EntityID:   pgtypeToUUID(row.EntityID),
+		Before:     before,
+		After:      after,
+		CreatedAt:  pgtypeToTime(row.CreatedAt),
+	}, nil
+}
+
+// marshalSnapshot encodes a snapshot as JSONB; nil maps are stored as NULL
+func marshalSnapshot(snapshot map[string]interface{}) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
+
+func unmarshalSnapshot(data []byte) (map[string]interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CountOperatorOutcomes counts, per operator, the conversations allocated,
+// claimed or reassigned to them since the given time, and how many of the
+// conversations they held were deallocated
+func (r *AuditLogRepositoryImpl) CountOperatorOutcomes(ctx context.Context, tenantID uuid.UUID, since time.Time) (map[uuid.UUID]domain.OperatorAllocationOutcome, error) {
+	rows, err := r.q.CountOperatorAllocationOutcomes(ctx, CountOperatorAllocationOutcomesParams{
+		TenantID:  uuidToPgtype(tenantID),
+		CreatedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	outcomes := make(map[uuid.UUID]domain.OperatorAllocationOutcome, len(rows))
+	for _, row := range rows {
+		outcomes[pgtypeToUUID(row.OperatorID)] = domain.OperatorAllocationOutcome{
+			Allocations: int(row.Allocations),
+			Returns:     int(row.Returns),
+		}
+	}
+	return outcomes, nil
+}
+
+// LastAllocationAt returns when the operator was last allocated a
+// conversation automatically; domain.ErrNotFound if never
+func (r *AuditLogRepositoryImpl) LastAllocationAt(ctx context.Context, tenantID, operatorID uuid.UUID) (time.Time, error) {
+	at, err := r.q.GetLastAllocationByActor(ctx, GetLastAllocationByActorParams{
+		TenantID: uuidToPgtype(tenantID),
+		ActorID:  uuidToPgtype(operatorID),
+	})
+	if err != nil {
+		return time.Time{}, mapError(err)
+	}
+	return pgtypeToTime(at), nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type BackfillRepositoryImpl struct {
+	q *Queries
+}
+
+func NewBackfillRepository(q *Queries) *BackfillRepositoryImpl {
+	return &BackfillRepositoryImpl{q: q}
+}
+
+func (r *BackfillRepositoryImpl) Register(ctx context.Context, b *domain.Backfill) error {
+	err := r.q.CreateSchemaBackfill(ctx, CreateSchemaBackfillParams{
+		Name:      b.Name,
+		TableName: b.TableName,
+		Status:    backfillStatusToPgtype(b.Status),
+		RowsTotal: b.RowsTotal,
+		BatchSize: int32(b.BatchSize),
+		CreatedAt: timeToPgtype(b.CreatedAt),
+		UpdatedAt: timeToPgtype(b.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *BackfillRepositoryImpl) List(ctx context.Context) ([]*domain.Backfill, error) {
+	rows, err := r.q.GetSchemaBackfills(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make([]*domain.Backfill, len(rows))
+	for i, row := range rows {
+		result[i] = r.toDomain(row)
+	}
+	return result, nil
+}
+
+func (r *BackfillRepositoryImpl) LockPending(ctx context.Context, name string) (*domain.Backfill, error) {
+	row, err := r.q.LockPendingSchemaBackfill(ctx, name)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *BackfillRepositoryImpl) UpdateProgress(ctx context.Context, b *domain.Backfill) error {
+	err := r.q.UpdateSchemaBackfillProgress(ctx, UpdateSchemaBackfillProgressParams{
+		Name:          b.Name,
+		Status:        backfillStatusToPgtype(b.Status),
+		CursorID:      uuidPtrToPgtype(b.CursorID),
+		RowsProcessed: b.RowsProcessed,
+		LastError:     stringPtrToPgtype(b.LastError),
+		StartedAt:     timePtrToPgtype(b.StartedAt),
+		UpdatedAt:     timeToPgtype(b.UpdatedAt),
+		CompletedAt:   timePtrToPgtype(b.CompletedAt),
+	})
+	return mapError(err)
+}
+
+func (r *BackfillRepositoryImpl) toDomain(row SchemaBackfill) *domain.Backfill {
+	return &domain.Backfill{
+		Name:          row.Name,
+		TableName:     row.TableName,
+		Status:        pgtypeToBackfillStatus(row.Status),
+		CursorID:      pgtypeToUUIDPtr(row.CursorID),
+		RowsProcessed: row.RowsProcessed,
+		RowsTotal:     row.RowsTotal,
+		BatchSize:     int(row.BatchSize),
+		LastError:     pgtypeToStringPtr(row.LastError),
+		CreatedAt:     pgtypeToTime(row.CreatedAt),
+		StartedAt:     pgtypeToTimePtr(row.StartedAt),
+		UpdatedAt:     pgtypeToTime(row.UpdatedAt),
+		CompletedAt:   pgtypeToTimePtr(row.CompletedAt),
+	}
+}
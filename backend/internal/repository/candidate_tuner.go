@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+)
+
+var (
+	allocationCandidateWindow    = metrics.NewGauge("allocation_candidate_window")
+	allocationCandidateConflicts = metrics.NewCounter("allocation_candidate_conflicts_total")
+)
+
+// CandidateTunerConfig bounds the adaptive candidate window of the
+// allocation query
+type CandidateTunerConfig struct {
+	// Enabled adapts the window; disabled keeps the constant
+	// allocationCandidateSlack
+	Enabled  bool
+	Min, Max int
+	// Percentile of the recent samples the window covers
+	Percentile float64
+	// Samples is how many recent allocations are remembered
+	Samples int
+}
+
+// DefaultCandidateTunerConfig returns the default bounds
+func DefaultCandidateTunerConfig() CandidateTunerConfig {
+	return CandidateTunerConfig{
+		Enabled:    true,
+		Min:        4,
+		Max:        100,
+		Percentile: 95,
+		Samples:    200,
+	}
+}
+
+// CandidateTuner sizes the candidates per inbox the allocation query reads
+// beyond its limit. Every allocation leaves a sample: zero when the window
+// sufficed or the queues were simply short, otherwise twice the rows that
+// concurrent allocators at least held in the window (a conflict, which costs
+// a search over every queued conversation). The window is the configured
+// percentile of the recent samples, so it stays small while allocators
+// rarely meet and widens once conflicts pass the percentile's share.
+// A nil tuner keeps the constant window.
+type CandidateTuner struct {
+	config CandidateTunerConfig
+
+	mu      sync.Mutex
+	samples []int
+	next    int
+	window  int
+}
+
+// NewCandidateTuner returns a tuner starting at the smallest window
+func NewCandidateTuner(config CandidateTunerConfig) *CandidateTuner {
+	defaults := DefaultCandidateTunerConfig()
+	if config.Min <= 0 {
+		config.Min = defaults.Min
+	}
+	if config.Max < config.Min {
+		config.Max = config.Min
+	}
+	if config.Percentile <= 0 || config.Percentile > 100 {
+		config.Percentile = defaults.Percentile
+	}
+	if config.Samples <= 0 {
+		config.Samples = defaults.Samples
+	}
+
+	t := &CandidateTuner{
+		config:  config,
+		samples: make([]int, 0, config.Samples),
+		window:  config.Min,
+	}
+	if !config.Enabled {
+		t.window = allocationCandidateSlack
+	}
+	allocationCandidateWindow.Set(int64(t.window))
+	return t
+}
+
+// Window returns the candidates per inbox to read beyond the limit
+func (t *CandidateTuner) Window() int {
+	if t == nil {
+		return allocationCandidateSlack
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.window
+}
+
+// Observe records an allocation of limit conversations that read window
+// extra candidates per inbox and locked got of them. full is what the search
+// over every queued conversation then returned, -1 when it did not run.
+func (t *CandidateTuner) Observe(window, limit, got, full int) {
+	sample := 0
+	if got < limit && full > got {
+		allocationCandidateConflicts.Inc()
+		// At least window+limit-got of the candidates were held by others;
+		// the bound is loose across inboxes
+		sample = 2 * (window + limit - got)
+	}
+	if t == nil || !t.config.Enabled {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.samples) < t.config.Samples {
+		t.samples = append(t.samples, sample)
+	} else {
+		t.samples[t.next] = sample
+	}
+	t.next = (t.next + 1) % t.config.Samples
+
+	t.window = min(max(percentile(t.samples, t.config.Percentile), t.config.Min), t.config.Max)
+	allocationCandidateWindow.Set(int64(t.window))
+}
+
+// percentile returns the nearest-rank percentile p of samples
+func percentile(samples []int, p float64) int {
+	sorted := append([]int(nil), samples...)
+	sort.Ints(sorted)
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidateTuner_AdaptsToConflicts(t *testing.T) {
+	tuner := NewCandidateTuner(CandidateTunerConfig{Enabled: true, Min: 4, Max: 40, Percentile: 90, Samples: 10})
+	assert.Equal(t, 4, tuner.Window(), "starts at the smallest window")
+
+	// Quiet: the window sufficed, or the queue was short
+	tuner.Observe(4, 1, 1, -1)
+	tuner.Observe(4, 5, 2, 2)
+	assert.Equal(t, 4, tuner.Window())
+
+	// One conflict in ten samples is within the 90th percentile
+	for i := 0; i < 8; i++ {
+		tuner.Observe(4, 1, 1, -1)
+	}
+	tuner.Observe(4, 2, 0, 2)
+	assert.Equal(t, 4, tuner.Window())
+
+	// A second one widens the window to twice the rows held by others
+	tuner.Observe(4, 2, 1, 2)
+	assert.Equal(t, 10, tuner.Window())
+
+	// Bounded by Max under heavy contention
+	for i := 0; i < 10; i++ {
+		tuner.Observe(tuner.Window(), 10, 0, 10)
+	}
+	assert.Equal(t, 40, tuner.Window())
+
+	// Conflicts age out of the samples once allocators stop meeting
+	for i := 0; i < 10; i++ {
+		tuner.Observe(tuner.Window(), 1, 1, -1)
+	}
+	assert.Equal(t, 4, tuner.Window())
+}
+
+func TestCandidateTuner_Disabled(t *testing.T) {
+	tuner := NewCandidateTuner(CandidateTunerConfig{Enabled: false, Min: 4, Max: 40})
+	for i := 0; i < 10; i++ {
+		tuner.Observe(tuner.Window(), 10, 0, 10)
+	}
+	assert.Equal(t, allocationCandidateSlack, tuner.Window())
+
+	var none *CandidateTuner
+	none.Observe(allocationCandidateSlack, 1, 0, 1)
+	assert.Equal(t, allocationCandidateSlack, none.Window())
+}
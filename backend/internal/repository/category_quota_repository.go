@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CategoryQuotaRepositoryImpl struct {
+	q *Queries
+}
+
+func NewCategoryQuotaRepository(q *Queries) *CategoryQuotaRepositoryImpl {
+	return &CategoryQuotaRepositoryImpl{q: q}
+}
+
+// ListByInbox returns the inbox's quotas, largest share first
+func (r *CategoryQuotaRepositoryImpl) ListByInbox(ctx context.Context, inboxID uuid.UUID) ([]*domain.CategoryQuota, error) {
+	rows, err := r.q.ListInboxCategoryQuotas(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// ListByInboxIDs returns the quotas of all the inboxes, grouped by inbox
+func (r *CategoryQuotaRepositoryImpl) ListByInboxIDs(ctx context.Context, inboxIDs []uuid.UUID) ([]*domain.CategoryQuota, error) {
+	ids := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	rows, err := r.q.ListCategoryQuotasByInboxIDs(ctx, ids)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *CategoryQuotaRepositoryImpl) Create(ctx context.Context, quota *domain.CategoryQuota) error {
+	return mapError(r.q.CreateInboxCategoryQuota(ctx, CreateInboxCategoryQuotaParams{
+		InboxID:      uuidToPgtype(quota.InboxID),
+		LabelID:      uuidToPgtype(quota.LabelID),
+		TenantID:     uuidToPgtype(quota.TenantID),
+		SharePercent: int32(quota.SharePercent),
+		UpdatedBy:    uuidPtrToPgtype(quota.UpdatedBy),
+		UpdatedAt:    timeToPgtype(quota.UpdatedAt),
+	}))
+}
+
+func (r *CategoryQuotaRepositoryImpl) DeleteByInbox(ctx context.Context, inboxID uuid.UUID) error {
+	return mapError(r.q.DeleteInboxCategoryQuotas(ctx, uuidToPgtype(inboxID)))
+}
+
+func (r *CategoryQuotaRepositoryImpl) toDomainSlice(rows []InboxCategoryQuota) []*domain.CategoryQuota {
+	quotas := make([]*domain.CategoryQuota, len(rows))
+	for i, row := range rows {
+		quotas[i] = &domain.CategoryQuota{
+			InboxID:      pgtypeToUUID(row.InboxID),
+			LabelID:      pgtypeToUUID(row.LabelID),
+			TenantID:     pgtypeToUUID(row.TenantID),
+			SharePercent: int(row.SharePercent),
+			UpdatedBy:    pgtypeToUUIDPtr(row.UpdatedBy),
+			UpdatedAt:    pgtypeToTime(row.UpdatedAt),
+		}
+	}
+	return quotas
+}
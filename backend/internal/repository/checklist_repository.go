@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ChecklistRepositoryImpl struct {
+	q *Queries
+}
+
+func NewChecklistRepository(q *Queries) *ChecklistRepositoryImpl {
+	return &ChecklistRepositoryImpl{q: q}
+}
+
+// ==================== Templates ====================
+
+func (r *ChecklistRepositoryImpl) GetTemplate(ctx context.Context, inboxID uuid.UUID) (*domain.ChecklistTemplate, error) {
+	row, err := r.q.GetInboxChecklistTemplate(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.ChecklistTemplate{
+		InboxID:    pgtypeToUUID(row.InboxID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		Items:      row.Items,
+		PinnedNote: pgtypeToStringPtr(row.PinnedNote),
+		Strict:     row.Strict,
+		UpdatedBy:  pgtypeToUUIDPtr(row.UpdatedBy),
+		UpdatedAt:  pgtypeToTime(row.UpdatedAt),
+	}, nil
+}
+
+func (r *ChecklistRepositoryImpl) SaveTemplate(ctx context.Context, template *domain.ChecklistTemplate) error {
+	items := template.Items
+	if items == nil {
+		items = []string{}
+	}
+	return mapError(r.q.UpsertInboxChecklistTemplate(ctx, UpsertInboxChecklistTemplateParams{
+		InboxID:    uuidToPgtype(template.InboxID),
+		TenantID:   uuidToPgtype(template.TenantID),
+		Items:      items,
+		PinnedNote: stringPtrToPgtype(template.PinnedNote),
+		Strict:     template.Strict,
+		UpdatedBy:  uuidPtrToPgtype(template.UpdatedBy),
+		UpdatedAt:  timeToPgtype(template.UpdatedAt),
+	}))
+}
+
+func (r *ChecklistRepositoryImpl) DeleteTemplate(ctx context.Context, inboxID uuid.UUID) error {
+	return mapError(r.q.DeleteInboxChecklistTemplate(ctx, uuidToPgtype(inboxID)))
+}
+
+// Instantiate copies their inbox templates onto the conversations that have
+// no checklist items or pinned note yet and returns how many items it added
+func (r *ChecklistRepositoryImpl) Instantiate(ctx context.Context, conversationIDs []uuid.UUID) (int64, error) {
+	ids := make([]pgtype.UUID, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	n, err := r.q.InstantiateConversationChecklists(ctx, ids)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	if _, err := r.q.InstantiatePinnedNotes(ctx, ids); err != nil {
+		return 0, mapError(err)
+	}
+	return n, nil
+}
+
+// ==================== Items ====================
+
+func (r *ChecklistRepositoryImpl) ListItems(ctx context.Context, conversationID uuid.UUID) ([]*domain.ChecklistItem, error) {
+	rows, err := r.q.ListConversationChecklistItems(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	items := make([]*domain.ChecklistItem, len(rows))
+	for i, row := range rows {
+		items[i] = r.toDomain(row)
+	}
+	return items, nil
+}
+
+func (r *ChecklistRepositoryImpl) GetItem(ctx context.Context, id uuid.UUID) (*domain.ChecklistItem, error) {
+	row, err := r.q.GetConversationChecklistItem(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ChecklistRepositoryImpl) UpdateItemCompletion(ctx context.Context, item *domain.ChecklistItem) error {
+	return mapError(r.q.UpdateConversationChecklistItemCompletion(ctx, UpdateConversationChecklistItemCompletionParams{
+		ID:          uuidToPgtype(item.ID),
+		CompletedBy: uuidPtrToPgtype(item.CompletedBy),
+		CompletedAt: timePtrToPgtype(item.CompletedAt),
+	}))
+}
+
+func (r *ChecklistRepositoryImpl) GetStatus(ctx context.Context, conversationID uuid.UUID) (domain.ChecklistStatus, error) {
+	row, err := r.q.GetConversationChecklistStatus(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return domain.ChecklistStatus{}, mapError(err)
+	}
+	return domain.ChecklistStatus{Total: int(row.Total), Incomplete: int(row.Incomplete)}, nil
+}
+
+func (r *ChecklistRepositoryImpl) toDomain(row ConversationChecklistItem) *domain.ChecklistItem {
+	return &domain.ChecklistItem{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		Position:       int(row.Position),
+		Label:          row.Label,
+		CompletedBy:    pgtypeToUUIDPtr(row.CompletedBy),
+		CompletedAt:    pgtypeToTimePtr(row.CompletedAt),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+	}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ConversationActivityRepositoryImpl struct {
+	q *Queries
+}
+
+func NewConversationActivityRepository(q *Queries) *ConversationActivityRepositoryImpl {
+	return &ConversationActivityRepositoryImpl{q: q}
+}
+
+func (r *ConversationActivityRepositoryImpl) Get(ctx context.Context, conversationID uuid.UUID) (*domain.ConversationActivity, error) {
+	row, err := r.q.GetConversationActivity(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ConversationActivityRepositoryImpl) Record(ctx context.Context, activity *domain.ConversationActivity) error {
+	err := r.q.RecordConversationActivity(ctx, RecordConversationActivityParams{
+		ConversationID:   uuidToPgtype(activity.ConversationID),
+		OperatorID:       uuidToPgtype(activity.OperatorID),
+		LastActivityAt:   timeToPgtype(activity.LastActivityAt),
+		LastActivityKind: string(activity.LastKind),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationActivityRepositoryImpl) StartClocks(ctx context.Context, now time.Time) (int64, error) {
+	n, err := r.q.StartConversationActivityClocks(ctx, timeToPgtype(now))
+	return n, mapError(err)
+}
+
+func (r *ConversationActivityRepositoryImpl) Prune(ctx context.Context) (int64, error) {
+	n, err := r.q.PruneConversationActivity(ctx)
+	return n, mapError(err)
+}
+
+func (r *ConversationActivityRepositoryImpl) GetAndLockStale(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationActivity, error) {
+	rows, err := r.q.GetAndLockStaleConversationActivity(ctx, GetAndLockStaleConversationActivityParams{
+		LastActivityAt: timeToPgtype(now),
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	activities := make([]*domain.ConversationActivity, len(rows))
+	for i, row := range rows {
+		activities[i] = r.toDomain(row)
+	}
+	return activities, nil
+}
+
+func (r *ConversationActivityRepositoryImpl) MarkStale(ctx context.Context, conversationID uuid.UUID, at time.Time) error {
+	err := r.q.MarkConversationActivityStale(ctx, MarkConversationActivityStaleParams{
+		ConversationID: uuidToPgtype(conversationID),
+		StaleAt:        timeToPgtype(at),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationActivityRepositoryImpl) CountStale(ctx context.Context) (int64, error) {
+	n, err := r.q.CountStaleConversationActivity(ctx)
+	return n, mapError(err)
+}
+
+func (r *ConversationActivityRepositoryImpl) toDomain(row ConversationActivity) *domain.ConversationActivity {
+	return &domain.ConversationActivity{
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		OperatorID:     pgtypeToUUID(row.OperatorID),
+		LastActivityAt: pgtypeToTime(row.LastActivityAt),
+		LastKind:       domain.ActivityKind(row.LastActivityKind),
+		StaleAt:        pgtypeToTimePtr(row.StaleAt),
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ConversationEscalationRepositoryImpl struct {
+	q *Queries
+}
+
+func NewConversationEscalationRepository(q *Queries) *ConversationEscalationRepositoryImpl {
+	return &ConversationEscalationRepositoryImpl{q: q}
+}
+
+func (r *ConversationEscalationRepositoryImpl) Create(ctx context.Context, e *domain.ConversationEscalation) error {
+	err := r.q.CreateConversationEscalation(ctx, CreateConversationEscalationParams{
+		ID:             uuidToPgtype(e.ID),
+		TenantID:       uuidToPgtype(e.TenantID),
+		ConversationID: uuidToPgtype(e.ConversationID),
+		OperatorID:     uuidPtrToPgtype(e.OperatorID),
+		EscalatedBy:    uuidPtrToPgtype(e.EscalatedBy),
+		FromInboxID:    uuidToPgtype(e.FromInboxID),
+		ToInboxID:      uuidToPgtype(e.ToInboxID),
+		Reason:         e.Reason,
+		CreatedAt:      timeToPgtype(e.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationEscalationRepositoryImpl) CountByOperator(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]domain.EscalationCount, error) {
+	rows, err := r.q.CountEscalationsByOperator(ctx, CountEscalationsByOperatorParams{
+		TenantID:    uuidToPgtype(tenantID),
+		CreatedAt:   timeToPgtype(from),
+		CreatedAt_2: timeToPgtype(to),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make([]domain.EscalationCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.EscalationCount{ID: pgtypeToUUID(row.OperatorID), Count: int(row.Escalations)}
+	}
+	return counts, nil
+}
+
+func (r *ConversationEscalationRepositoryImpl) CountByInbox(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]domain.EscalationCount, error) {
+	rows, err := r.q.CountEscalationsByInbox(ctx, CountEscalationsByInboxParams{
+		TenantID:    uuidToPgtype(tenantID),
+		CreatedAt:   timeToPgtype(from),
+		CreatedAt_2: timeToPgtype(to),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make([]domain.EscalationCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.EscalationCount{ID: pgtypeToUUID(row.FromInboxID), Count: int(row.Escalations)}
+	}
+	return counts, nil
+}
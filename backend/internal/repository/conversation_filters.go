@@ -16,17 +16,48 @@ type ConversationFilters struct {
 	State      *domain.ConversationState
 	InboxID    *uuid.UUID
 	OperatorID *uuid.UUID
-	LabelID    *uuid.UUID
+	// LabelIDs matches conversations carrying any of the labels
+	LabelIDs   []uuid.UUID
+	Category   *string
+	Language   *string
+	CustomerID *uuid.UUID
+	// PhoneDigits matches conversations whose phone number starts or ends
+	// with these digits
+	PhoneDigits string
+	// OverdueAsOf matches open conversations past their due date at this time
+	OverdueAsOf *time.Time
+
+	// TextQuery is the full-text query of SearchText
+	TextQuery string
+	// NoteVisibleTo limits the notes SearchText matches to the pinned notes
+	// and the handover notes the operator wrote or received; nil matches
+	// every note
+	NoteVisibleTo *uuid.UUID
+
+	// UrgencyAsOf is the time ListWithUrgency scores at; the reads of
+	// UrgencyReaderID decide whether a conversation is unread
+	UrgencyAsOf     *time.Time
+	UrgencyReaderID uuid.UUID
 
 	// Access control - if set, only return conversations in these inboxes
 	AllowedInboxIDs []uuid.UUID
+	// Access control - conversations allocated to these operators are also
+	// returned (shadowed mentors); combined with AllowedInboxIDs using OR
+	ShadowedOperatorIDs []uuid.UUID
 
-	// Sorting: "newest", "oldest", "priority"
+	// Sorting: "newest", "oldest", "priority", "due" (conversations with a
+	// due date, earliest first; CursorTimestamp is then the due date), and
+	// "urgency" in ListWithUrgency
 	SortOrder string
 
 	// Cursor pagination
 	CursorTimestamp *time.Time
 	CursorID        *uuid.UUID
+	// CursorRank replaces CursorTimestamp in SearchText
+	CursorRank *float64
+	// CursorUrgency is the last score of ListWithUrgency by "urgency";
+	// CursorTimestamp is then the creation time
+	CursorUrgency *float64
 
 	// Limit
 	Limit int
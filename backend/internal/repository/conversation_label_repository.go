@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type ConversationLabelRepositoryImpl struct {
@@ -21,6 +23,7 @@ func (r *ConversationLabelRepositoryImpl) Create(ctx context.Context, cl *domain
 		ConversationID: uuidToPgtype(cl.ConversationID),
 		LabelID:        uuidToPgtype(cl.LabelID),
 		CreatedAt:      timeToPgtype(cl.CreatedAt),
+		LabelVersion:   pgtype.Int4{Int32: int32(cl.LabelVersion), Valid: true},
 	})
 }
 
@@ -37,6 +40,56 @@ func (r *ConversationLabelRepositoryImpl) GetByConversationID(ctx context.Contex
 	return labels, nil
 }
 
+// GetLabels returns the labels attached to the conversation, by name
+func (r *ConversationLabelRepositoryImpl) GetLabels(ctx context.Context, conversationID uuid.UUID) ([]*domain.Label, error) {
+	rows, err := r.q.GetLabelsByConversationID(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	labels := make([]*domain.Label, len(rows))
+	for i, row := range rows {
+		labels[i] = labelToDomain(row)
+	}
+	return labels, nil
+}
+
+// GetLabelsForConversations returns the labels attached to each of the
+// conversations, by name, in one query. Conversations without labels are
+// left out of the map.
+func (r *ConversationLabelRepositoryImpl) GetLabelsForConversations(ctx context.Context, conversationIDs []uuid.UUID) (map[uuid.UUID][]*domain.Label, error) {
+	labels := make(map[uuid.UUID][]*domain.Label)
+	if len(conversationIDs) == 0 {
+		return labels, nil
+	}
+
+	ids := make([]pgtype.UUID, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	rows, err := r.q.GetLabelsForConversations(ctx, ids)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	for _, row := range rows {
+		conversationID := pgtypeToUUID(row.ConversationID)
+		labels[conversationID] = append(labels[conversationID], labelToDomain(Label{
+			ID:            row.ID,
+			TenantID:      row.TenantID,
+			InboxID:       row.InboxID,
+			Name:          row.Name,
+			Color:         row.Color,
+			CreatedBy:     row.CreatedBy,
+			CreatedAt:     row.CreatedAt,
+			Version:       row.Version,
+			ParentLabelID: row.ParentLabelID,
+			Scope:         row.Scope,
+		}))
+	}
+	return labels, nil
+}
+
 func (r *ConversationLabelRepositoryImpl) GetByLabelID(ctx context.Context, labelID uuid.UUID) ([]*domain.ConversationLabel, error) {
 	rows, err := r.q.GetConversationLabelsByLabelID(ctx, uuidToPgtype(labelID))
 	if err != nil {
@@ -72,11 +125,44 @@ func (r *ConversationLabelRepositoryImpl) Exists(ctx context.Context, conversati
 	return exists, nil
 }
 
+// CountByVersion counts the inbox's label attachments made in [since, until)
+// that are still in place, per label version
+func (r *ConversationLabelRepositoryImpl) CountByVersion(ctx context.Context, tenantID, inboxID uuid.UUID, since, until time.Time) ([]domain.LabelAttachmentCount, error) {
+	rows, err := r.q.CountLabelAttachmentsByVersion(ctx, CountLabelAttachmentsByVersionParams{
+		TenantID:    uuidToPgtype(tenantID),
+		InboxID:     uuidToPgtype(inboxID),
+		CreatedAt:   timeToPgtype(since),
+		CreatedAt_2: timeToPgtype(until),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make([]domain.LabelAttachmentCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.LabelAttachmentCount{
+			LabelID:     pgtypeToUUID(row.LabelID),
+			Version:     int(row.Version),
+			Name:        row.Name,
+			Color:       pgtypeToStringPtr(row.Color),
+			Attachments: int(row.Attachments),
+		}
+	}
+	return counts, nil
+}
+
 func (r *ConversationLabelRepositoryImpl) toDomain(row ConversationLabel) *domain.ConversationLabel {
+	// Attachments older than label versioning have no version: they were made
+	// under the first one
+	version := 1
+	if row.LabelVersion.Valid {
+		version = int(row.LabelVersion.Int32)
+	}
 	return &domain.ConversationLabel{
 		ID:             pgtypeToUUID(row.ID),
 		ConversationID: pgtypeToUUID(row.ConversationID),
 		LabelID:        pgtypeToUUID(row.LabelID),
+		LabelVersion:   version,
 		CreatedAt:      pgtypeToTime(row.CreatedAt),
 	}
 }
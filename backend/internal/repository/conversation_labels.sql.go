@@ -30,9 +30,71 @@ func (q *Queries) CheckConversationLabelExists(ctx context.Context, arg CheckCon
 	return exists, err
 }
 
+const countLabelAttachmentsByVersion = `-- name: CountLabelAttachmentsByVersion :many
+SELECT cl.label_id, lv.version, lv.name, lv.color, COUNT(*) AS attachments
+FROM conversation_labels cl
+JOIN labels l ON l.id = cl.label_id
+JOIN label_versions lv ON lv.label_id = cl.label_id AND lv.version = COALESCE(cl.label_version, 1)
+JOIN conversation_refs c ON c.id = cl.conversation_id
+WHERE l.tenant_id = $1
+  AND (l.inbox_id = $2 OR (l.scope = 'TENANT' AND c.inbox_id = $2))
+  AND cl.created_at >= $3 AND cl.created_at < $4
+GROUP BY cl.label_id, lv.version, lv.name, lv.color
+ORDER BY lv.name, lv.version
+`
+
+type CountLabelAttachmentsByVersionParams struct {
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	InboxID     pgtype.UUID        `json:"inbox_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CreatedAt_2 pgtype.Timestamptz `json:"created_at_2"`
+}
+
+type CountLabelAttachmentsByVersionRow struct {
+	LabelID     pgtype.UUID `json:"label_id"`
+	Version     int32       `json:"version"`
+	Name        string      `json:"name"`
+	Color       pgtype.Text `json:"color"`
+	Attachments int64       `json:"attachments"`
+}
+
+// Attachments made in [$3, $4) that are still in place, grouped by the label
+// version they were attached under so renamed labels keep their old names
+// Tenant-scoped labels count for the inbox of the conversation
+func (q *Queries) CountLabelAttachmentsByVersion(ctx context.Context, arg CountLabelAttachmentsByVersionParams) ([]CountLabelAttachmentsByVersionRow, error) {
+	rows, err := q.db.Query(ctx, countLabelAttachmentsByVersion,
+		arg.TenantID,
+		arg.InboxID,
+		arg.CreatedAt,
+		arg.CreatedAt_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountLabelAttachmentsByVersionRow{}
+	for rows.Next() {
+		var i CountLabelAttachmentsByVersionRow
+		if err := rows.Scan(
+			&i.LabelID,
+			&i.Version,
+			&i.Name,
+			&i.Color,
+			&i.Attachments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createConversationLabel = `-- name: CreateConversationLabel :exec
-INSERT INTO conversation_labels (id, conversation_id, label_id, created_at)
-VALUES ($1, $2, $3, $4)
+INSERT INTO conversation_labels (id, conversation_id, label_id, created_at, label_version)
+VALUES ($1, $2, $3, $4, $5)
 `
 
 type CreateConversationLabelParams struct {
@@ -40,6 +102,7 @@ type CreateConversationLabelParams struct {
 	ConversationID pgtype.UUID        `json:"conversation_id"`
 	LabelID        pgtype.UUID        `json:"label_id"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	LabelVersion   pgtype.Int4        `json:"label_version"`
 }
 
 func (q *Queries) CreateConversationLabel(ctx context.Context, arg CreateConversationLabelParams) error {
@@ -48,6 +111,7 @@ func (q *Queries) CreateConversationLabel(ctx context.Context, arg CreateConvers
 		arg.ConversationID,
 		arg.LabelID,
 		arg.CreatedAt,
+		arg.LabelVersion,
 	)
 	return err
 }
@@ -76,7 +140,7 @@ func (q *Queries) DeleteConversationLabel(ctx context.Context, arg DeleteConvers
 }
 
 const getConversationLabelsByConversationID = `-- name: GetConversationLabelsByConversationID :many
-SELECT id, conversation_id, label_id, created_at FROM conversation_labels WHERE conversation_id = $1
+SELECT id, conversation_id, label_id, created_at, label_version FROM conversation_labels WHERE conversation_id = $1
 `
 
 func (q *Queries) GetConversationLabelsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]ConversationLabel, error) {
@@ -93,6 +157,7 @@ func (q *Queries) GetConversationLabelsByConversationID(ctx context.Context, con
 			&i.ConversationID,
 			&i.LabelID,
 			&i.CreatedAt,
+			&i.LabelVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -105,7 +170,7 @@ func (q *Queries) GetConversationLabelsByConversationID(ctx context.Context, con
 }
 
 const getConversationLabelsByLabelID = `-- name: GetConversationLabelsByLabelID :many
-SELECT id, conversation_id, label_id, created_at FROM conversation_labels WHERE label_id = $1
+SELECT id, conversation_id, label_id, created_at, label_version FROM conversation_labels WHERE label_id = $1
 `
 
 func (q *Queries) GetConversationLabelsByLabelID(ctx context.Context, labelID pgtype.UUID) ([]ConversationLabel, error) {
@@ -122,6 +187,102 @@ func (q *Queries) GetConversationLabelsByLabelID(ctx context.Context, labelID pg
 			&i.ConversationID,
 			&i.LabelID,
 			&i.CreatedAt,
+			&i.LabelVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLabelsByConversationID = `-- name: GetLabelsByConversationID :many
+SELECT l.id, l.tenant_id, l.inbox_id, l.name, l.color, l.created_by, l.created_at, l.version, l.parent_label_id, l.scope FROM labels l
+JOIN conversation_labels cl ON cl.label_id = l.id
+WHERE cl.conversation_id = $1
+ORDER BY l.name
+`
+
+// Labels attached to the conversation, by name
+func (q *Queries) GetLabelsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]Label, error) {
+	rows, err := q.db.Query(ctx, getLabelsByConversationID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Label{}
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.Name,
+			&i.Color,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Version,
+			&i.ParentLabelID,
+			&i.Scope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLabelsForConversations = `-- name: GetLabelsForConversations :many
+SELECT cl.conversation_id, l.id, l.tenant_id, l.inbox_id, l.name, l.color, l.created_by, l.created_at, l.version, l.parent_label_id, l.scope
+FROM conversation_labels cl
+JOIN labels l ON l.id = cl.label_id
+WHERE cl.conversation_id = ANY($1::uuid[])
+ORDER BY cl.conversation_id, l.name
+`
+
+type GetLabelsForConversationsRow struct {
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	ID             pgtype.UUID        `json:"id"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	InboxID        pgtype.UUID        `json:"inbox_id"`
+	Name           string             `json:"name"`
+	Color          pgtype.Text        `json:"color"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	Version        int32              `json:"version"`
+	ParentLabelID  pgtype.UUID        `json:"parent_label_id"`
+	Scope          string             `json:"scope"`
+}
+
+// Labels attached to each of the conversations, by name; one query for a
+// whole list page
+func (q *Queries) GetLabelsForConversations(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetLabelsForConversationsRow, error) {
+	rows, err := q.db.Query(ctx, getLabelsForConversations, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLabelsForConversationsRow{}
+	for rows.Next() {
+		var i GetLabelsForConversationsRow
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.Name,
+			&i.Color,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Version,
+			&i.ParentLabelID,
+			&i.Scope,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ConversationNoteRepositoryImpl struct {
+	q *Queries
+}
+
+func NewConversationNoteRepository(q *Queries) *ConversationNoteRepositoryImpl {
+	return &ConversationNoteRepositoryImpl{q: q}
+}
+
+func (r *ConversationNoteRepositoryImpl) Create(ctx context.Context, note *domain.ConversationNote) error {
+	err := r.q.CreateConversationNote(ctx, CreateConversationNoteParams{
+		ID:             uuidToPgtype(note.ID),
+		TenantID:       uuidToPgtype(note.TenantID),
+		ConversationID: uuidToPgtype(note.ConversationID),
+		Kind:           string(note.Kind),
+		AuthorID:       uuidPtrToPgtype(note.AuthorID),
+		RecipientID:    uuidPtrToPgtype(note.RecipientID),
+		Body:           note.Body,
+		CreatedAt:      timeToPgtype(note.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationNoteRepositoryImpl) GetLatest(ctx context.Context, conversationID uuid.UUID, kind domain.ConversationNoteKind) (*domain.ConversationNote, error) {
+	row, err := r.q.GetLatestConversationNote(ctx, GetLatestConversationNoteParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Kind:           string(kind),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ConversationNoteRepositoryImpl) GetLatestForRecipient(ctx context.Context, conversationID uuid.UUID, kind domain.ConversationNoteKind, recipientID uuid.UUID) (*domain.ConversationNote, error) {
+	row, err := r.q.GetLatestConversationNoteForRecipient(ctx, GetLatestConversationNoteForRecipientParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Kind:           string(kind),
+		RecipientID:    uuidToPgtype(recipientID),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ConversationNoteRepositoryImpl) toDomain(row ConversationNote) *domain.ConversationNote {
+	return &domain.ConversationNote{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		Kind:           domain.ConversationNoteKind(row.Kind),
+		AuthorID:       pgtypeToUUIDPtr(row.AuthorID),
+		RecipientID:    pgtypeToUUIDPtr(row.RecipientID),
+		Body:           row.Body,
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ConversationReadRepositoryImpl struct {
+	q *Queries
+}
+
+func NewConversationReadRepository(q *Queries) *ConversationReadRepositoryImpl {
+	return &ConversationReadRepositoryImpl{q: q}
+}
+
+func (r *ConversationReadRepositoryImpl) MarkRead(ctx context.Context, read *domain.ConversationRead) error {
+	err := r.q.UpsertConversationRead(ctx, UpsertConversationReadParams{
+		OperatorID:     uuidToPgtype(read.OperatorID),
+		ConversationID: uuidToPgtype(read.ConversationID),
+		TenantID:       uuidToPgtype(read.TenantID),
+		LastReadAt:     timeToPgtype(read.LastReadAt),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationReadRepositoryImpl) GetLastReadAt(ctx context.Context, operatorID uuid.UUID, conversationIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	ids := make([]pgtype.UUID, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	rows, err := r.q.GetConversationReadsByOperator(ctx, GetConversationReadsByOperatorParams{
+		OperatorID: uuidToPgtype(operatorID),
+		Column2:    ids,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make(map[uuid.UUID]time.Time, len(rows))
+	for _, row := range rows {
+		result[pgtypeToUUID(row.ConversationID)] = pgtypeToTime(row.LastReadAt)
+	}
+	return result, nil
+}
@@ -3,20 +3,24 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 type ConversationRefRepositoryImpl struct {
-	q    *Queries
-	pool *pgxpool.Pool
+	q  *Queries
+	db DBTX
+	// candidates sizes the candidate window of GetNextForAllocation; nil
+	// keeps allocationCandidateSlack
+	candidates *CandidateTuner
 }
 
-func NewConversationRefRepository(q *Queries, pool *pgxpool.Pool) *ConversationRefRepositoryImpl {
-	return &ConversationRefRepositoryImpl{q: q, pool: pool}
+func NewConversationRefRepository(q *Queries, db DBTX) *ConversationRefRepositoryImpl {
+	return &ConversationRefRepositoryImpl{q: q, db: db}
 }
 
 func (r *ConversationRefRepositoryImpl) Create(ctx context.Context, conv *domain.ConversationRef) error {
@@ -34,9 +38,39 @@ func (r *ConversationRefRepositoryImpl) Create(ctx context.Context, conv *domain
 		CreatedAt:              timeToPgtype(conv.CreatedAt),
 		UpdatedAt:              timeToPgtype(conv.UpdatedAt),
 		ResolvedAt:             timePtrToPgtype(conv.ResolvedAt),
+		IsFirstContact:         conv.IsFirstContact,
+		CustomerID:             uuidPtrToPgtype(conv.CustomerID),
+		DueAt:                  timePtrToPgtype(conv.DueAt),
 	})
 }
 
+// CreateIfNotExists inserts conv unless the tenant already tracks its external
+// conversation ID. Returns false when the row already existed.
+func (r *ConversationRefRepositoryImpl) CreateIfNotExists(ctx context.Context, conv *domain.ConversationRef) (bool, error) {
+	rows, err := r.q.CreateConversationRefIfNotExists(ctx, CreateConversationRefIfNotExistsParams{
+		ID:                     uuidToPgtype(conv.ID),
+		TenantID:               uuidToPgtype(conv.TenantID),
+		InboxID:                uuidToPgtype(conv.InboxID),
+		ExternalConversationID: conv.ExternalConversationID,
+		CustomerPhoneNumber:    conv.CustomerPhoneNumber,
+		State:                  conversationStateToPgtype(conv.State),
+		AssignedOperatorID:     uuidPtrToPgtype(conv.AssignedOperatorID),
+		LastMessageAt:          timeToPgtype(conv.LastMessageAt),
+		MessageCount:           conv.MessageCount,
+		PriorityScore:          decimalToPgtype(conv.PriorityScore),
+		CreatedAt:              timeToPgtype(conv.CreatedAt),
+		UpdatedAt:              timeToPgtype(conv.UpdatedAt),
+		ResolvedAt:             timePtrToPgtype(conv.ResolvedAt),
+		IsFirstContact:         conv.IsFirstContact,
+		CustomerID:             uuidPtrToPgtype(conv.CustomerID),
+		DueAt:                  timePtrToPgtype(conv.DueAt),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return rows > 0, nil
+}
+
 func (r *ConversationRefRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.ConversationRef, error) {
 	row, err := r.q.GetConversationRefByID(ctx, uuidToPgtype(id))
 	if err != nil {
@@ -108,8 +142,39 @@ func (r *ConversationRefRepositoryImpl) SearchByPhone(ctx context.Context, tenan
 	return r.toDomainSlice(rows), nil
 }
 
+// HasConversationWithPhone reports whether the tenant already has a
+// conversation with the customer phone number, resolved ones included
+func (r *ConversationRefRepositoryImpl) HasConversationWithPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (bool, error) {
+	seen, err := r.q.CheckCustomerPhoneSeen(ctx, CheckCustomerPhoneSeenParams{
+		TenantID:            uuidToPgtype(tenantID),
+		CustomerPhoneNumber: phoneNumber,
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return seen, nil
+}
+
+// CustomerIntakeSince counts the conversations the phone number started in
+// the inbox since the given time, and returns when the oldest of them started
+func (r *ConversationRefRepositoryImpl) CustomerIntakeSince(ctx context.Context, tenantID, inboxID uuid.UUID, phoneNumber string, since time.Time) (int64, *time.Time, error) {
+	row, err := r.q.CountCustomerIntakeSince(ctx, CountCustomerIntakeSinceParams{
+		TenantID:            uuidToPgtype(tenantID),
+		InboxID:             uuidToPgtype(inboxID),
+		CustomerPhoneNumber: phoneNumber,
+		CreatedAt:           timeToPgtype(since),
+	})
+	if err != nil {
+		return 0, nil, mapError(err)
+	}
+	return row.Conversations, pgtypeToTimePtr(row.OldestCreatedAt), nil
+}
+
+// Update writes conv if it still has the version it was read at, and
+// returns domain.ErrConcurrentModification otherwise; conv.Version is then
+// left alone so the caller can reload and retry
 func (r *ConversationRefRepositoryImpl) Update(ctx context.Context, conv *domain.ConversationRef) error {
-	return r.q.UpdateConversationRef(ctx, UpdateConversationRefParams{
+	rows, err := r.q.UpdateConversationRef(ctx, UpdateConversationRefParams{
 		ID:                 uuidToPgtype(conv.ID),
 		InboxID:            uuidToPgtype(conv.InboxID),
 		State:              conversationStateToPgtype(conv.State),
@@ -119,25 +184,241 @@ func (r *ConversationRefRepositoryImpl) Update(ctx context.Context, conv *domain
 		PriorityScore:      decimalToPgtype(conv.PriorityScore),
 		UpdatedAt:          timeToPgtype(conv.UpdatedAt),
 		ResolvedAt:         timePtrToPgtype(conv.ResolvedAt),
+		ReopenedCount:      conv.ReopenedCount,
+		Category:           stringPtrToPgtype(conv.Category),
+		Language:           stringPtrToPgtype(conv.Language),
+		Column13:           uuidPtrToPgtype(conv.CustomerID),
+		Version:            conv.Version,
 	})
+	if err != nil {
+		return mapError(err)
+	}
+	if rows == 0 {
+		return domain.ErrConcurrentModification
+	}
+	conv.Version++
+	return nil
 }
 
 func (r *ConversationRefRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.q.DeleteConversationRef(ctx, uuidToPgtype(id))
 }
 
+// allocationCandidateSlack is how many candidates per inbox the allocation
+// query reads beyond the limit, to absorb those locked by concurrent
+// allocators, unless a CandidateTuner adapts it
+const allocationCandidateSlack = 16
+
 // GetNextForAllocation - CRITICAL: Uses FOR UPDATE SKIP LOCKED
-func (r *ConversationRefRepositoryImpl) GetNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, limit int) ([]*domain.ConversationRef, error) {
+// Reads a few candidates per inbox off idx_conversations_queue; when
+// concurrent allocators hold too many of them, or the queues are short, it
+// repeats the search over every queued conversation. Rows locked by the
+// first query are ours, so the second returns them again in order. How many
+// candidates are read is up to the CandidateTuner, which learns from the
+// outcome.
+// Conversations in a language outside languages are skipped (see
+// domain.SpeaksLanguage).
+func (r *ConversationRefRepositoryImpl) GetNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, limit int) ([]*domain.ConversationRef, error) {
 	// Convert []uuid.UUID to []pgtype.UUID
 	pgtypeIDs := make([]pgtype.UUID, len(inboxIDs))
 	for i, id := range inboxIDs {
 		pgtypeIDs[i] = uuidToPgtype(id)
 	}
 
+	window := r.candidates.Window()
 	rows, err := r.q.GetNextConversationsForAllocation(ctx, GetNextConversationsForAllocationParams{
 		TenantID: uuidToPgtype(tenantID),
 		Column2:  pgtypeIDs,
 		Limit:    int32(limit),
+		Limit_2:  int32(limit + window),
+		Column5:  languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if got := len(rows); got < limit {
+		rows, err = r.q.GetNextConversationsForAllocationFullScan(ctx, GetNextConversationsForAllocationFullScanParams{
+			TenantID: uuidToPgtype(tenantID),
+			Column2:  pgtypeIDs,
+			Limit:    int32(limit),
+			Column4:  languagesToPgtype(languages),
+		})
+		if err != nil {
+			return nil, mapError(err)
+		}
+		r.candidates.Observe(window, limit, got, len(rows))
+	} else {
+		r.candidates.Observe(window, limit, got, -1)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// GetNextForAllocationWithQuotas is GetNextForAllocation for inboxes with
+// category quotas: conversations created before starvedBefore come first,
+// then those carrying one of preferredLabelIDs
+func (r *ConversationRefRepositoryImpl) GetNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time, limit int) ([]*domain.ConversationRef, error) {
+	pgtypeInboxIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeInboxIDs[i] = uuidToPgtype(id)
+	}
+	pgtypeLabelIDs := make([]pgtype.UUID, len(preferredLabelIDs))
+	for i, id := range preferredLabelIDs {
+		pgtypeLabelIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.q.GetNextConversationsForAllocationWithQuotas(ctx, GetNextConversationsForAllocationWithQuotasParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   pgtypeInboxIDs,
+		Column3:   pgtypeLabelIDs,
+		CreatedAt: timeToPgtype(starvedBefore),
+		Limit:     int32(limit),
+		Column6:   languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// PeekNextForAllocation returns the conversation GetNextForAllocation would
+// return first, without locking it; ErrNotFound when none is queued
+func (r *ConversationRefRepositoryImpl) PeekNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string) (*domain.ConversationRef, error) {
+	pgtypeIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeIDs[i] = uuidToPgtype(id)
+	}
+
+	row, err := r.q.PeekNextConversationForAllocation(ctx, PeekNextConversationForAllocationParams{
+		TenantID: uuidToPgtype(tenantID),
+		Column2:  pgtypeIDs,
+		Column3:  languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// PeekNextForAllocationWithQuotas is PeekNextForAllocation in the order of
+// GetNextForAllocationWithQuotas
+func (r *ConversationRefRepositoryImpl) PeekNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time) (*domain.ConversationRef, error) {
+	pgtypeInboxIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeInboxIDs[i] = uuidToPgtype(id)
+	}
+	pgtypeLabelIDs := make([]pgtype.UUID, len(preferredLabelIDs))
+	for i, id := range preferredLabelIDs {
+		pgtypeLabelIDs[i] = uuidToPgtype(id)
+	}
+
+	row, err := r.q.PeekNextConversationForAllocationWithQuotas(ctx, PeekNextConversationForAllocationWithQuotasParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   pgtypeInboxIDs,
+		Column3:   pgtypeLabelIDs,
+		CreatedAt: timeToPgtype(starvedBefore),
+		Column5:   languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// GetNextForAllocationBreachFirst locks up to limit conversations in the
+// allocation order of the v2 engine; a nil starvedBefore and no preferred
+// labels leave category quotas out
+func (r *ConversationRefRepositoryImpl) GetNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time, limit int) ([]*domain.ConversationRef, error) {
+	pgtypeInboxIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeInboxIDs[i] = uuidToPgtype(id)
+	}
+	pgtypeLabelIDs := make([]pgtype.UUID, len(preferredLabelIDs))
+	for i, id := range preferredLabelIDs {
+		pgtypeLabelIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.q.GetNextConversationsForAllocationBreachFirst(ctx, GetNextConversationsForAllocationBreachFirstParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   pgtypeInboxIDs,
+		Column3:   pgtypeLabelIDs,
+		CreatedAt: timePtrToPgtype(starvedBefore),
+		Limit:     int32(limit),
+		Column6:   languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// GetNextForAllocationByLabels locks up to limit conversations carrying one
+// of labelIDs, in the allocation order of the v1 engine or, with
+// breachFirst, of the v2 engine. Category quotas do not apply.
+func (r *ConversationRefRepositoryImpl) GetNextForAllocationByLabels(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, labelIDs []uuid.UUID, breachFirst bool, limit int) ([]*domain.ConversationRef, error) {
+	pgtypeInboxIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeInboxIDs[i] = uuidToPgtype(id)
+	}
+	pgtypeLabelIDs := make([]pgtype.UUID, len(labelIDs))
+	for i, id := range labelIDs {
+		pgtypeLabelIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.q.GetNextConversationsForAllocationByLabels(ctx, GetNextConversationsForAllocationByLabelsParams{
+		TenantID: uuidToPgtype(tenantID),
+		Column2:  pgtypeInboxIDs,
+		Column3:  pgtypeLabelIDs,
+		Column4:  breachFirst,
+		Limit:    int32(limit),
+		Column6:  languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// PeekNextForAllocationBreachFirst returns the conversation
+// GetNextForAllocationBreachFirst would return first, without locking it
+func (r *ConversationRefRepositoryImpl) PeekNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time) (*domain.ConversationRef, error) {
+	pgtypeInboxIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeInboxIDs[i] = uuidToPgtype(id)
+	}
+	pgtypeLabelIDs := make([]pgtype.UUID, len(preferredLabelIDs))
+	for i, id := range preferredLabelIDs {
+		pgtypeLabelIDs[i] = uuidToPgtype(id)
+	}
+
+	row, err := r.q.PeekNextConversationForAllocationBreachFirst(ctx, PeekNextConversationForAllocationBreachFirstParams{
+		TenantID:  uuidToPgtype(tenantID),
+		Column2:   pgtypeInboxIDs,
+		Column3:   pgtypeLabelIDs,
+		CreatedAt: timePtrToPgtype(starvedBefore),
+		Column5:   languagesToPgtype(languages),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// GetNextAffineForAllocation locks up to limit queued conversations of the
+// customers the operator resolved last since the given time, in the order of
+// GetNextForAllocation
+func (r *ConversationRefRepositoryImpl) GetNextAffineForAllocation(ctx context.Context, tenantID, operatorID uuid.UUID, inboxIDs []uuid.UUID, languages []string, since time.Time, limit int) ([]*domain.ConversationRef, error) {
+	pgtypeIDs := make([]pgtype.UUID, len(inboxIDs))
+	for i, id := range inboxIDs {
+		pgtypeIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.q.GetNextAffineConversationsForAllocation(ctx, GetNextAffineConversationsForAllocationParams{
+		TenantID:   uuidToPgtype(tenantID),
+		Column2:    pgtypeIDs,
+		OperatorID: uuidToPgtype(operatorID),
+		HandledAt:  timeToPgtype(since),
+		Limit:      int32(limit),
+		Column6:    languagesToPgtype(languages),
 	})
 	if err != nil {
 		return nil, mapError(err)
@@ -154,6 +435,27 @@ func (r *ConversationRefRepositoryImpl) LockForClaim(ctx context.Context, id uui
 	return r.toDomain(row), nil
 }
 
+// LockForUpdate uses FOR UPDATE (waits for concurrent writers)
+func (r *ConversationRefRepositoryImpl) LockForUpdate(ctx context.Context, id uuid.UUID) (*domain.ConversationRef, error) {
+	row, err := r.q.LockConversationRefForUpdate(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// LockByExternalID uses FOR UPDATE on the tenant's external conversation ID
+func (r *ConversationRefRepositoryImpl) LockByExternalID(ctx context.Context, tenantID uuid.UUID, externalID string) (*domain.ConversationRef, error) {
+	row, err := r.q.LockConversationRefByExternalID(ctx, LockConversationRefByExternalIDParams{
+		TenantID:               uuidToPgtype(tenantID),
+		ExternalConversationID: externalID,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
 func (r *ConversationRefRepositoryImpl) GetByOperatorID(ctx context.Context, tenantID, operatorID uuid.UUID, state *domain.ConversationState) ([]*domain.ConversationRef, error) {
 	if state != nil {
 		rows, err := r.q.GetConversationsByOperatorAndState(ctx, GetConversationsByOperatorAndStateParams{
@@ -177,6 +479,234 @@ func (r *ConversationRefRepositoryImpl) GetByOperatorID(ctx context.Context, ten
 	return r.toDomainSlice(rows), nil
 }
 
+func (r *ConversationRefRepositoryImpl) GetOpenIDsByInbox(ctx context.Context, tenantID, inboxID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	rows, err := r.q.GetOpenConversationIDsByInbox(ctx, GetOpenConversationIDsByInboxParams{
+		TenantID: uuidToPgtype(tenantID),
+		InboxID:  uuidToPgtype(inboxID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+func (r *ConversationRefRepositoryImpl) CountCreatedByHour(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time) (map[time.Time]int, error) {
+	counts := make(map[time.Time]int)
+
+	if inboxID != nil {
+		rows, err := r.q.CountInboxConversationsCreatedByHour(ctx, CountInboxConversationsCreatedByHourParams{
+			TenantID:  uuidToPgtype(tenantID),
+			InboxID:   uuidToPgtype(*inboxID),
+			CreatedAt: timeToPgtype(since),
+		})
+		if err != nil {
+			return nil, mapError(err)
+		}
+		for _, row := range rows {
+			counts[time.Unix(row.HourStart, 0).UTC()] = int(row.Conversations)
+		}
+		return counts, nil
+	}
+
+	rows, err := r.q.CountConversationsCreatedByHour(ctx, CountConversationsCreatedByHourParams{
+		TenantID:  uuidToPgtype(tenantID),
+		CreatedAt: timeToPgtype(since),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	for _, row := range rows {
+		counts[time.Unix(row.HourStart, 0).UTC()] = int(row.Conversations)
+	}
+	return counts, nil
+}
+
+func (r *ConversationRefRepositoryImpl) CountQueuedByInbox(ctx context.Context, inboxID uuid.UUID) (int, error) {
+	count, err := r.q.CountQueuedConversationsByInbox(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+// CountByInboxAndState counts the conversations of every inbox of the tenant
+// by state, inboxes without conversations included
+func (r *ConversationRefRepositoryImpl) CountByInboxAndState(ctx context.Context, tenantID uuid.UUID, resolvedSince, now time.Time) ([]domain.InboxConversationStats, error) {
+	rows, err := r.q.CountInboxConversationsByState(ctx, CountInboxConversationsByStateParams{
+		TenantID:   uuidToPgtype(tenantID),
+		ResolvedAt: timeToPgtype(resolvedSince),
+		DueAt:      timeToPgtype(now),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	stats := make([]domain.InboxConversationStats, len(rows))
+	for i, row := range rows {
+		stats[i] = domain.InboxConversationStats{
+			InboxID:          pgtypeToUUID(row.InboxID),
+			DisplayName:      row.DisplayName,
+			Queued:           int(row.Queued),
+			Allocated:        int(row.Allocated),
+			Resolved:         int(row.Resolved),
+			RecentlyResolved: int(row.RecentlyResolved),
+			Overdue:          int(row.Overdue),
+			AvgResolution:    time.Duration(row.AvgResolutionSeconds * float64(time.Second)),
+		}
+	}
+	return stats, nil
+}
+
+func (r *ConversationRefRepositoryImpl) MarkSLABreaches(ctx context.Context, now time.Time) ([]*domain.SLABreach, error) {
+	rows, err := r.q.MarkSLABreaches(ctx, timeToPgtype(now))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	breaches := make([]*domain.SLABreach, len(rows))
+	for i, row := range rows {
+		breaches[i] = &domain.SLABreach{
+			ConversationID: pgtypeToUUID(row.ID),
+			TenantID:       pgtypeToUUID(row.TenantID),
+			InboxID:        pgtypeToUUID(row.InboxID),
+		}
+	}
+	return breaches, nil
+}
+
+func (r *ConversationRefRepositoryImpl) BoostNearSLABreach(ctx context.Context, now time.Time, ratio float64, priority decimal.Decimal) ([]uuid.UUID, error) {
+	rows, err := r.q.BoostNearSLABreach(ctx, BoostNearSLABreachParams{
+		UpdatedAt:     timeToPgtype(now),
+		PriorityScore: decimalToPgtype(priority),
+		Column3:       ratio,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var inboxIDs []uuid.UUID
+	for _, row := range rows {
+		id := pgtypeToUUID(row)
+		if !seen[id] {
+			seen[id] = true
+			inboxIDs = append(inboxIDs, id)
+		}
+	}
+	return inboxIDs, nil
+}
+
+func (r *ConversationRefRepositoryImpl) ListSLABreaches(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time, limit int) ([]*domain.ConversationRef, error) {
+	if inboxID != nil {
+		rows, err := r.q.ListInboxSLABreaches(ctx, ListInboxSLABreachesParams{
+			TenantID:      uuidToPgtype(tenantID),
+			InboxID:       uuidToPgtype(*inboxID),
+			SlaBreachedAt: timeToPgtype(since),
+			Limit:         int32(limit),
+		})
+		if err != nil {
+			return nil, mapError(err)
+		}
+		return r.toDomainSlice(rows), nil
+	}
+
+	rows, err := r.q.ListSLABreaches(ctx, ListSLABreachesParams{
+		TenantID:      uuidToPgtype(tenantID),
+		SlaBreachedAt: timeToPgtype(since),
+		Limit:         int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// SetPriorityOverride writes the conversation's manual priority; Update leaves it alone
+func (r *ConversationRefRepositoryImpl) SetPriorityOverride(ctx context.Context, conv *domain.ConversationRef) error {
+	return r.q.SetConversationRefPriorityOverride(ctx, SetConversationRefPriorityOverrideParams{
+		ID:               uuidToPgtype(conv.ID),
+		PriorityOverride: decimalPtrToPgtype(conv.PriorityOverride),
+		UpdatedAt:        timeToPgtype(conv.UpdatedAt),
+	})
+}
+
+// SetSnooze writes the conversation's snooze fields; Update leaves them alone
+func (r *ConversationRefRepositoryImpl) SetSnooze(ctx context.Context, conv *domain.ConversationRef) error {
+	return r.q.SetConversationRefSnooze(ctx, SetConversationRefSnoozeParams{
+		ID:               uuidToPgtype(conv.ID),
+		SnoozedUntil:     timePtrToPgtype(conv.SnoozedUntil),
+		SnoozeOperatorID: uuidPtrToPgtype(conv.SnoozeOperatorID),
+		UpdatedAt:        timeToPgtype(conv.UpdatedAt),
+	})
+}
+
+// SetDueAt writes the conversation's due date and clears its overdue flag;
+// Update leaves both alone
+func (r *ConversationRefRepositoryImpl) SetDueAt(ctx context.Context, conv *domain.ConversationRef) error {
+	return r.q.SetConversationRefDueAt(ctx, SetConversationRefDueAtParams{
+		ID:        uuidToPgtype(conv.ID),
+		DueAt:     timePtrToPgtype(conv.DueAt),
+		UpdatedAt: timeToPgtype(conv.UpdatedAt),
+	})
+}
+
+// SetOverdue writes the conversation's overdue flag; Update leaves it alone
+func (r *ConversationRefRepositoryImpl) SetOverdue(ctx context.Context, conv *domain.ConversationRef) error {
+	return r.q.SetConversationRefOverdue(ctx, SetConversationRefOverdueParams{
+		ID:        uuidToPgtype(conv.ID),
+		OverdueAt: timePtrToPgtype(conv.OverdueAt),
+	})
+}
+
+// GetAndLockOverdue - Uses FOR UPDATE SKIP LOCKED
+func (r *ConversationRefRepositoryImpl) GetAndLockOverdue(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	rows, err := r.q.GetAndLockOverdueConversations(ctx, GetAndLockOverdueConversationsParams{
+		DueAt: timeToPgtype(now),
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// GetAndLockStale - Uses FOR UPDATE SKIP LOCKED
+func (r *ConversationRefRepositoryImpl) GetAndLockStale(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	rows, err := r.q.GetAndLockStaleConversations(ctx, GetAndLockStaleConversationsParams{
+		Column1: timeToPgtype(now),
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// GetAndLockEndedSnoozes - Uses FOR UPDATE SKIP LOCKED
+func (r *ConversationRefRepositoryImpl) GetAndLockEndedSnoozes(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	rows, err := r.q.GetAndLockEndedSnoozes(ctx, GetAndLockEndedSnoozesParams{
+		SnoozedUntil: timeToPgtype(now),
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+// GetAndLockAllocatedByOperator - Uses FOR UPDATE SKIP LOCKED
+func (r *ConversationRefRepositoryImpl) GetAndLockAllocatedByOperator(ctx context.Context, operatorID uuid.UUID) ([]*domain.ConversationRef, error) {
+	rows, err := r.q.GetAndLockAllocatedByOperator(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
 func (r *ConversationRefRepositoryImpl) toDomain(row ConversationRef) *domain.ConversationRef {
 	return &domain.ConversationRef{
 		ID:                     pgtypeToUUID(row.ID),
@@ -192,6 +722,18 @@ func (r *ConversationRefRepositoryImpl) toDomain(row ConversationRef) *domain.Co
 		CreatedAt:              pgtypeToTime(row.CreatedAt),
 		UpdatedAt:              pgtypeToTime(row.UpdatedAt),
 		ResolvedAt:             pgtypeToTimePtr(row.ResolvedAt),
+		ReopenedCount:          row.ReopenedCount,
+		Category:               pgtypeToStringPtr(row.Category),
+		SLABreachedAt:          pgtypeToTimePtr(row.SlaBreachedAt),
+		SnoozedUntil:           pgtypeToTimePtr(row.SnoozedUntil),
+		SnoozeOperatorID:       pgtypeToUUIDPtr(row.SnoozeOperatorID),
+		PriorityOverride:       pgtypeToDecimalPtr(row.PriorityOverride),
+		IsFirstContact:         row.IsFirstContact,
+		Version:                row.Version,
+		Language:               pgtypeToStringPtr(row.Language),
+		CustomerID:             pgtypeToUUIDPtr(row.CustomerID),
+		DueAt:                  pgtypeToTimePtr(row.DueAt),
+		OverdueAt:              pgtypeToTimePtr(row.OverdueAt),
 	}
 }
 
@@ -211,51 +753,26 @@ func (r *ConversationRefRepositoryImpl) ListWithFilters(ctx context.Context, fil
 			id, tenant_id, inbox_id, external_conversation_id,
 			customer_phone_number, state, assigned_operator_id,
 			last_message_at, message_count, priority_score,
-			created_at, updated_at, resolved_at
+			created_at, updated_at, resolved_at, reopened_count, category,
+			sla_breached_at, snoozed_until, snooze_operator_id, priority_override,
+			is_first_contact, version, language, customer_id, normalized_phone,
+			due_at, overdue_at
 		FROM conversation_refs
 		WHERE tenant_id = $1
 	`
 	args := []interface{}{filters.TenantID}
-	argIndex := 2
+	query, args = appendConversationFilters(query, args, filters)
+	argIndex := len(args) + 1
 
-	// State filter
-	if filters.State != nil {
-		query += fmt.Sprintf(` AND state = $%d`, argIndex)
-		args = append(args, string(*filters.State))
-		argIndex++
-	}
-
-	// Inbox filter
-	if filters.InboxID != nil {
-		query += fmt.Sprintf(` AND inbox_id = $%d`, argIndex)
-		args = append(args, *filters.InboxID)
-		argIndex++
-	}
-
-	// Operator filter
-	if filters.OperatorID != nil {
-		query += fmt.Sprintf(` AND assigned_operator_id = $%d`, argIndex)
-		args = append(args, *filters.OperatorID)
-		argIndex++
-	}
-
-	// Allowed inboxes filter (for operators)
-	if len(filters.AllowedInboxIDs) > 0 {
-		query += fmt.Sprintf(` AND inbox_id = ANY($%d)`, argIndex)
-		args = append(args, filters.AllowedInboxIDs)
-		argIndex++
-	}
-
-	// Label filter (join)
-	if filters.LabelID != nil {
-		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM conversation_labels cl WHERE cl.conversation_id = id AND cl.label_id = $%d)`, argIndex)
-		args = append(args, *filters.LabelID)
-		argIndex++
+	if filters.SortOrder == "due" {
+		query += ` AND due_at IS NOT NULL`
 	}
 
 	// Cursor pagination
 	if filters.HasCursor() {
 		switch filters.SortOrder {
+		case "due":
+			query += fmt.Sprintf(` AND (due_at, id) > ($%d, $%d)`, argIndex, argIndex+1)
 		case "oldest":
 			query += fmt.Sprintf(` AND (last_message_at, id) > ($%d, $%d)`, argIndex, argIndex+1)
 		case "priority":
@@ -269,6 +786,8 @@ func (r *ConversationRefRepositoryImpl) ListWithFilters(ctx context.Context, fil
 
 	// Sorting
 	switch filters.SortOrder {
+	case "due":
+		query += ` ORDER BY due_at ASC, id ASC`
 	case "oldest":
 		query += ` ORDER BY last_message_at ASC, id ASC`
 	case "priority":
@@ -281,7 +800,7 @@ func (r *ConversationRefRepositoryImpl) ListWithFilters(ctx context.Context, fil
 	query += fmt.Sprintf(` LIMIT $%d`, argIndex)
 	args = append(args, filters.GetLimit())
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, mapError(err)
 	}
@@ -290,13 +809,7 @@ func (r *ConversationRefRepositoryImpl) ListWithFilters(ctx context.Context, fil
 	var conversations []*domain.ConversationRef
 	for rows.Next() {
 		var row ConversationRef
-		err := rows.Scan(
-			&row.ID, &row.TenantID, &row.InboxID, &row.ExternalConversationID,
-			&row.CustomerPhoneNumber, &row.State, &row.AssignedOperatorID,
-			&row.LastMessageAt, &row.MessageCount, &row.PriorityScore,
-			&row.CreatedAt, &row.UpdatedAt, &row.ResolvedAt,
-		)
-		if err != nil {
+		if err := rows.Scan(conversationRefScanTargets(&row)...); err != nil {
 			return nil, mapError(err)
 		}
 		conversations = append(conversations, r.toDomain(row))
@@ -305,6 +818,296 @@ func (r *ConversationRefRepositoryImpl) ListWithFilters(ctx context.Context, fil
 	return conversations, nil
 }
 
+// ListWithUrgency returns the conversations matching the filters with their
+// urgency as of filters.UrgencyAsOf, sorted by "urgency" (most urgent, then
+// oldest, then lowest ID first) or "newest". The score is computed here so
+// that it orders the pages; see domain.ConversationUrgency.
+func (r *ConversationRefRepositoryImpl) ListWithUrgency(ctx context.Context, filters ConversationFilters) ([]*domain.ConversationUrgency, error) {
+	asOf := time.Now().UTC()
+	if filters.UrgencyAsOf != nil {
+		asOf = *filters.UrgencyAsOf
+	}
+	args := []interface{}{
+		filters.TenantID, asOf, filters.UrgencyReaderID,
+		domain.UrgencyWeightSLA, domain.UrgencyWeightUnread, domain.UrgencyWeightAge,
+		domain.UrgencyAgeHorizon.Seconds(),
+	}
+
+	query := `
+		SELECT
+			id, tenant_id, inbox_id, external_conversation_id,
+			customer_phone_number, state, assigned_operator_id,
+			last_message_at, message_count, priority_score,
+			created_at, updated_at, resolved_at, reopened_count, category,
+			sla_breached_at, snoozed_until, snooze_operator_id, priority_override,
+			is_first_contact, version, language, customer_id, normalized_phone,
+			due_at, overdue_at, urgency_score, urgency_sla, urgency_unread, urgency_age
+		FROM (
+			SELECT c.*,
+				($4::float8 * u.sla + $5::float8 * u.unread::int + $6::float8 * u.age)::float8 AS urgency_score,
+				u.sla AS urgency_sla, u.unread AS urgency_unread, u.age AS urgency_age
+			FROM conversation_refs c
+			LEFT JOIN inbox_sla_policies p ON p.inbox_id = c.inbox_id
+			LEFT JOIN conversation_reads cr ON cr.conversation_id = c.id AND cr.operator_id = $3
+			CROSS JOIN LATERAL (
+				SELECT
+					CASE WHEN p.resolution_seconds IS NULL THEN 0
+					ELSE LEAST(GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - c.created_at)) / p.resolution_seconds, 0), 1)
+					END::float8 AS sla,
+					(c.message_count > 0 AND (cr.last_read_at IS NULL OR cr.last_read_at < c.last_message_at)) AS unread,
+					LEAST(GREATEST(EXTRACT(EPOCH FROM ($2::timestamptz - c.created_at)) / $7::float8, 0), 1)::float8 AS age
+			) u
+			WHERE c.tenant_id = $1
+		) AS conversation_refs
+		WHERE tenant_id = $1
+	`
+	query, args = appendConversationFilters(query, args, filters)
+	argIndex := len(args) + 1
+
+	// Cursor pagination; urgency descends while the tie-breaks ascend
+	if filters.HasCursor() {
+		if filters.SortOrder == "urgency" && filters.CursorUrgency != nil {
+			query += fmt.Sprintf(` AND (urgency_score < $%d OR (urgency_score = $%d AND (created_at, id) > ($%d, $%d)))`,
+				argIndex, argIndex, argIndex+1, argIndex+2)
+			args = append(args, *filters.CursorUrgency, *filters.CursorTimestamp, *filters.CursorID)
+			argIndex += 3
+		} else {
+			query += fmt.Sprintf(` AND (last_message_at, id) < ($%d, $%d)`, argIndex, argIndex+1)
+			args = append(args, *filters.CursorTimestamp, *filters.CursorID)
+			argIndex += 2
+		}
+	}
+
+	if filters.SortOrder == "urgency" {
+		query += ` ORDER BY urgency_score DESC, created_at ASC, id ASC`
+	} else {
+		query += ` ORDER BY last_message_at DESC, id DESC`
+	}
+	query += fmt.Sprintf(` LIMIT $%d`, argIndex)
+	args = append(args, filters.GetLimit())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var items []*domain.ConversationUrgency
+	for rows.Next() {
+		var row ConversationRef
+		item := &domain.ConversationUrgency{}
+		if err := rows.Scan(append(conversationRefScanTargets(&row), &item.Score, &item.SLA, &item.Unread, &item.Age)...); err != nil {
+			return nil, mapError(err)
+		}
+		item.Conversation = r.toDomain(row)
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// SearchText returns the conversations whose external ID, customer name or
+// notes match filters.TextQuery, best match first. The optional filters of
+// ListWithFilters narrow the matches; SortOrder is ignored. Each field is
+// searched through its GIN index (idx_conversations_external_id_fts,
+// idx_customers_name_fts, idx_conversation_notes_fts).
+func (r *ConversationRefRepositoryImpl) SearchText(ctx context.Context, filters ConversationFilters) ([]*domain.ConversationSearchHit, error) {
+	args := []interface{}{
+		filters.TenantID, filters.TextQuery,
+		domain.SearchWeightExternalConversationID, domain.SearchWeightCustomerName, domain.SearchWeightNotes,
+	}
+
+	noteVisibility := ""
+	if filters.NoteVisibleTo != nil {
+		noteVisibility = ` AND (n.kind = 'PINNED' OR n.author_id = $6 OR n.recipient_id = $6)`
+		args = append(args, *filters.NoteVisibleTo)
+	}
+
+	query := `
+		WITH search AS (
+			SELECT plainto_tsquery('simple', $2) AS query
+		),
+		hits AS (
+			SELECT c.id AS conversation_id,
+				$3::float8 * ts_rank(to_tsvector('simple', c.external_conversation_id), s.query) AS rank,
+				'external_conversation_id'::text AS field
+			FROM conversation_refs c CROSS JOIN search s
+			WHERE c.tenant_id = $1 AND to_tsvector('simple', c.external_conversation_id) @@ s.query
+			UNION ALL
+			SELECT c.id,
+				$4::float8 * ts_rank(to_tsvector('simple', coalesce(cu.name, '')), s.query),
+				'customer_name'::text
+			FROM customers cu
+			JOIN conversation_refs c ON c.customer_id = cu.id AND c.tenant_id = cu.tenant_id
+			CROSS JOIN search s
+			WHERE cu.tenant_id = $1 AND to_tsvector('simple', coalesce(cu.name, '')) @@ s.query
+			UNION ALL
+			SELECT n.conversation_id,
+				$5::float8 * ts_rank(to_tsvector('simple', n.body), s.query),
+				'notes'::text
+			FROM conversation_notes n CROSS JOIN search s
+			WHERE n.tenant_id = $1 AND to_tsvector('simple', n.body) @@ s.query` + noteVisibility + `
+		),
+		ranked AS (
+			SELECT conversation_id, SUM(rank)::float8 AS search_rank,
+				array_agg(DISTINCT field ORDER BY field) AS matched_fields
+			FROM hits
+			GROUP BY conversation_id
+		)
+		SELECT
+			id, tenant_id, inbox_id, external_conversation_id,
+			customer_phone_number, state, assigned_operator_id,
+			last_message_at, message_count, priority_score,
+			created_at, updated_at, resolved_at, reopened_count, category,
+			sla_breached_at, snoozed_until, snooze_operator_id, priority_override,
+			is_first_contact, version, language, customer_id, normalized_phone,
+			due_at, overdue_at, search_rank, matched_fields
+		FROM (
+			SELECT c.*, r.search_rank, r.matched_fields
+			FROM conversation_refs c
+			JOIN ranked r ON r.conversation_id = c.id
+		) AS conversation_refs
+		WHERE tenant_id = $1
+	`
+	query, args = appendConversationFilters(query, args, filters)
+	argIndex := len(args) + 1
+
+	// Cursor pagination
+	if filters.CursorRank != nil && filters.CursorID != nil {
+		query += fmt.Sprintf(` AND (search_rank, id) < ($%d, $%d)`, argIndex, argIndex+1)
+		args = append(args, *filters.CursorRank, *filters.CursorID)
+		argIndex += 2
+	}
+
+	query += ` ORDER BY search_rank DESC, id DESC`
+	query += fmt.Sprintf(` LIMIT $%d`, argIndex)
+	args = append(args, filters.GetLimit())
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var hits []*domain.ConversationSearchHit
+	for rows.Next() {
+		var row ConversationRef
+		var rank float64
+		var fields []string
+		if err := rows.Scan(append(conversationRefScanTargets(&row), &rank, &fields)...); err != nil {
+			return nil, mapError(err)
+		}
+		hit := &domain.ConversationSearchHit{
+			Conversation:  r.toDomain(row),
+			Rank:          rank,
+			MatchedFields: make([]domain.SearchField, len(fields)),
+		}
+		for i, field := range fields {
+			hit.MatchedFields[i] = domain.SearchField(field)
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
+
+// appendConversationFilters appends the optional filters of ListWithFilters
+// and SearchText to a query selecting from conversation_refs
+func appendConversationFilters(query string, args []interface{}, filters ConversationFilters) (string, []interface{}) {
+	argIndex := len(args) + 1
+
+	// State filter
+	if filters.State != nil {
+		query += fmt.Sprintf(` AND state = $%d`, argIndex)
+		args = append(args, string(*filters.State))
+		argIndex++
+	}
+
+	// Inbox filter
+	if filters.InboxID != nil {
+		query += fmt.Sprintf(` AND inbox_id = $%d`, argIndex)
+		args = append(args, *filters.InboxID)
+		argIndex++
+	}
+
+	// Operator filter
+	if filters.OperatorID != nil {
+		query += fmt.Sprintf(` AND assigned_operator_id = $%d`, argIndex)
+		args = append(args, *filters.OperatorID)
+		argIndex++
+	}
+
+	// Allowed inboxes filter (for operators), widened by shadowed mentors
+	if len(filters.ShadowedOperatorIDs) > 0 {
+		query += fmt.Sprintf(` AND (inbox_id = ANY($%d) OR (state = 'ALLOCATED' AND assigned_operator_id = ANY($%d)))`, argIndex, argIndex+1)
+		args = append(args, filters.AllowedInboxIDs, filters.ShadowedOperatorIDs)
+		argIndex += 2
+	} else if len(filters.AllowedInboxIDs) > 0 {
+		query += fmt.Sprintf(` AND inbox_id = ANY($%d)`, argIndex)
+		args = append(args, filters.AllowedInboxIDs)
+		argIndex++
+	}
+
+	// Category filter
+	if filters.Category != nil {
+		query += fmt.Sprintf(` AND category = $%d`, argIndex)
+		args = append(args, *filters.Category)
+		argIndex++
+	}
+
+	// Language filter
+	if filters.Language != nil {
+		query += fmt.Sprintf(` AND language = $%d`, argIndex)
+		args = append(args, *filters.Language)
+		argIndex++
+	}
+
+	// Customer filter
+	if filters.CustomerID != nil {
+		query += fmt.Sprintf(` AND customer_id = $%d`, argIndex)
+		args = append(args, *filters.CustomerID)
+		argIndex++
+	}
+
+	// Phone filter: the digits start or end the customer's phone number,
+	// served by idx_conversations_phone_prefix and idx_conversations_phone_suffix
+	if filters.PhoneDigits != "" {
+		query += fmt.Sprintf(` AND (normalized_phone LIKE $%d::text || '%%' OR reverse(normalized_phone) LIKE reverse($%d::text) || '%%')`, argIndex, argIndex)
+		args = append(args, filters.PhoneDigits)
+		argIndex++
+	}
+
+	// Overdue filter, served by idx_conversations_inbox_due
+	if filters.OverdueAsOf != nil {
+		query += fmt.Sprintf(` AND due_at <= $%d AND state <> 'RESOLVED'`, argIndex)
+		args = append(args, *filters.OverdueAsOf)
+		argIndex++
+	}
+
+	// Label filter (join)
+	if len(filters.LabelIDs) > 0 {
+		query += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM conversation_labels cl WHERE cl.conversation_id = conversation_refs.id AND cl.label_id = ANY($%d::uuid[]))`, argIndex)
+		args = append(args, filters.LabelIDs)
+		argIndex++
+	}
+
+	return query, args
+}
+
+// conversationRefScanTargets returns the scan targets of the columns the
+// dynamic conversation queries select, in order
+func conversationRefScanTargets(row *ConversationRef) []interface{} {
+	return []interface{}{
+		&row.ID, &row.TenantID, &row.InboxID, &row.ExternalConversationID,
+		&row.CustomerPhoneNumber, &row.State, &row.AssignedOperatorID,
+		&row.LastMessageAt, &row.MessageCount, &row.PriorityScore,
+		&row.CreatedAt, &row.UpdatedAt, &row.ResolvedAt, &row.ReopenedCount,
+		&row.Category, &row.SlaBreachedAt, &row.SnoozedUntil, &row.SnoozeOperatorID,
+		&row.PriorityOverride, &row.IsFirstContact, &row.Version, &row.Language,
+		&row.CustomerID, &row.NormalizedPhone, &row.DueAt, &row.OverdueAt,
+	}
+}
+
 // GetByPhone returns conversations by customer phone number
 func (r *ConversationRefRepositoryImpl) GetByPhone(ctx context.Context, tenantID uuid.UUID, phone string) ([]*domain.ConversationRef, error) {
 	rows, err := r.q.SearchConversationsByPhone(ctx, SearchConversationsByPhoneParams{
@@ -11,12 +11,268 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const boostNearSLABreach = `-- name: BoostNearSLABreach :many
+UPDATE conversation_refs c
+SET priority_score = $2,
+    updated_at = $1,
+    version = c.version + 1
+FROM inbox_sla_policies p
+WHERE p.inbox_id = c.inbox_id
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND c.priority_score < $2
+  AND (
+      c.created_at + make_interval(secs => p.first_assignment_seconds * $3::float8) <= $1
+      OR c.created_at + make_interval(secs => p.resolution_seconds * $3::float8) <= $1
+  )
+RETURNING c.inbox_id
+`
+
+type BoostNearSLABreachParams struct {
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	PriorityScore pgtype.Numeric     `json:"priority_score"`
+	Column3       float64            `json:"column_3"`
+}
+
+// Raise queued conversations that used up the $3 fraction of an SLA target
+// to priority $2; returns their inboxes
+func (q *Queries) BoostNearSLABreach(ctx context.Context, arg BoostNearSLABreachParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, boostNearSLABreach, arg.UpdatedAt, arg.PriorityScore, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var inbox_id pgtype.UUID
+		if err := rows.Scan(&inbox_id); err != nil {
+			return nil, err
+		}
+		items = append(items, inbox_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const checkCustomerPhoneSeen = `-- name: CheckCustomerPhoneSeen :one
+SELECT EXISTS(
+    SELECT 1 FROM conversation_refs
+    WHERE tenant_id = $1 AND customer_phone_number = $2
+) AS exists
+`
+
+type CheckCustomerPhoneSeenParams struct {
+	TenantID            pgtype.UUID `json:"tenant_id"`
+	CustomerPhoneNumber string      `json:"customer_phone_number"`
+}
+
+// Whether the tenant already has a conversation with the phone number;
+// served by idx_conversations_phone
+func (q *Queries) CheckCustomerPhoneSeen(ctx context.Context, arg CheckCustomerPhoneSeenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, checkCustomerPhoneSeen, arg.TenantID, arg.CustomerPhoneNumber)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const countConversationsCreatedByHour = `-- name: CountConversationsCreatedByHour :many
+SELECT (floor(extract(epoch FROM created_at) / 3600) * 3600)::bigint AS hour_start,
+       COUNT(*) AS conversations
+FROM conversation_refs
+WHERE tenant_id = $1 AND created_at >= $2
+GROUP BY hour_start
+ORDER BY hour_start
+`
+
+type CountConversationsCreatedByHourParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type CountConversationsCreatedByHourRow struct {
+	HourStart     int64 `json:"hour_start"`
+	Conversations int64 `json:"conversations"`
+}
+
+// Conversations created per hour (as Unix time of the hour start)
+func (q *Queries) CountConversationsCreatedByHour(ctx context.Context, arg CountConversationsCreatedByHourParams) ([]CountConversationsCreatedByHourRow, error) {
+	rows, err := q.db.Query(ctx, countConversationsCreatedByHour, arg.TenantID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountConversationsCreatedByHourRow{}
+	for rows.Next() {
+		var i CountConversationsCreatedByHourRow
+		if err := rows.Scan(&i.HourStart, &i.Conversations); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countCustomerIntakeSince = `-- name: CountCustomerIntakeSince :one
+SELECT COUNT(*) AS conversations, MIN(created_at)::timestamptz AS oldest_created_at
+FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND customer_phone_number = $3 AND created_at >= $4
+`
+
+type CountCustomerIntakeSinceParams struct {
+	TenantID            pgtype.UUID        `json:"tenant_id"`
+	InboxID             pgtype.UUID        `json:"inbox_id"`
+	CustomerPhoneNumber string             `json:"customer_phone_number"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+}
+
+type CountCustomerIntakeSinceRow struct {
+	Conversations   int64              `json:"conversations"`
+	OldestCreatedAt pgtype.Timestamptz `json:"oldest_created_at"`
+}
+
+// Conversations the phone number started in the inbox since the given time,
+// and when the oldest of them started; served by idx_conversations_phone
+func (q *Queries) CountCustomerIntakeSince(ctx context.Context, arg CountCustomerIntakeSinceParams) (CountCustomerIntakeSinceRow, error) {
+	row := q.db.QueryRow(ctx, countCustomerIntakeSince,
+		arg.TenantID,
+		arg.InboxID,
+		arg.CustomerPhoneNumber,
+		arg.CreatedAt,
+	)
+	var i CountCustomerIntakeSinceRow
+	err := row.Scan(&i.Conversations, &i.OldestCreatedAt)
+	return i, err
+}
+
+const countInboxConversationsByState = `-- name: CountInboxConversationsByState :many
+SELECT i.id AS inbox_id, i.display_name,
+       COUNT(c.id) FILTER (WHERE c.state = 'QUEUED') AS queued,
+       COUNT(c.id) FILTER (WHERE c.state = 'ALLOCATED') AS allocated,
+       COUNT(c.id) FILTER (WHERE c.state = 'RESOLVED') AS resolved,
+       COUNT(c.id) FILTER (WHERE c.state = 'RESOLVED' AND c.resolved_at >= $2) AS recently_resolved,
+       COUNT(c.id) FILTER (WHERE c.state <> 'RESOLVED' AND c.due_at <= $3) AS overdue,
+       COALESCE(AVG(EXTRACT(EPOCH FROM c.resolved_at - c.created_at))
+           FILTER (WHERE c.state = 'RESOLVED' AND c.resolved_at >= $2), 0)::float8 AS avg_resolution_seconds
+FROM inboxes i
+LEFT JOIN conversation_refs c ON c.inbox_id = i.id
+WHERE i.tenant_id = $1
+GROUP BY i.id, i.display_name
+ORDER BY i.display_name, i.id
+`
+
+type CountInboxConversationsByStateParams struct {
+	TenantID   pgtype.UUID        `json:"tenant_id"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+	DueAt      pgtype.Timestamptz `json:"due_at"`
+}
+
+type CountInboxConversationsByStateRow struct {
+	InboxID              pgtype.UUID `json:"inbox_id"`
+	DisplayName          string      `json:"display_name"`
+	Queued               int64       `json:"queued"`
+	Allocated            int64       `json:"allocated"`
+	Resolved             int64       `json:"resolved"`
+	RecentlyResolved     int64       `json:"recently_resolved"`
+	Overdue              int64       `json:"overdue"`
+	AvgResolutionSeconds float64     `json:"avg_resolution_seconds"`
+}
+
+// Conversations of each inbox of the tenant by state, those open past their
+// due date at $3, and the average time from creation to resolution of those
+// resolved since $2
+func (q *Queries) CountInboxConversationsByState(ctx context.Context, arg CountInboxConversationsByStateParams) ([]CountInboxConversationsByStateRow, error) {
+	rows, err := q.db.Query(ctx, countInboxConversationsByState, arg.TenantID, arg.ResolvedAt, arg.DueAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountInboxConversationsByStateRow{}
+	for rows.Next() {
+		var i CountInboxConversationsByStateRow
+		if err := rows.Scan(
+			&i.InboxID,
+			&i.DisplayName,
+			&i.Queued,
+			&i.Allocated,
+			&i.Resolved,
+			&i.RecentlyResolved,
+			&i.Overdue,
+			&i.AvgResolutionSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countInboxConversationsCreatedByHour = `-- name: CountInboxConversationsCreatedByHour :many
+SELECT (floor(extract(epoch FROM created_at) / 3600) * 3600)::bigint AS hour_start,
+       COUNT(*) AS conversations
+FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND created_at >= $3
+GROUP BY hour_start
+ORDER BY hour_start
+`
+
+type CountInboxConversationsCreatedByHourParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	InboxID   pgtype.UUID        `json:"inbox_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type CountInboxConversationsCreatedByHourRow struct {
+	HourStart     int64 `json:"hour_start"`
+	Conversations int64 `json:"conversations"`
+}
+
+func (q *Queries) CountInboxConversationsCreatedByHour(ctx context.Context, arg CountInboxConversationsCreatedByHourParams) ([]CountInboxConversationsCreatedByHourRow, error) {
+	rows, err := q.db.Query(ctx, countInboxConversationsCreatedByHour, arg.TenantID, arg.InboxID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountInboxConversationsCreatedByHourRow{}
+	for rows.Next() {
+		var i CountInboxConversationsCreatedByHourRow
+		if err := rows.Scan(&i.HourStart, &i.Conversations); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countQueuedConversationsByInbox = `-- name: CountQueuedConversationsByInbox :one
+SELECT COUNT(*) FROM conversation_refs
+WHERE inbox_id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
+`
+
+// Conversations waiting for allocation in the inbox, excluding snoozed ones
+func (q *Queries) CountQueuedConversationsByInbox(ctx context.Context, inboxID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countQueuedConversationsByInbox, inboxID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createConversationRef = `-- name: CreateConversationRef :exec
 INSERT INTO conversation_refs (
     id, tenant_id, inbox_id, external_conversation_id, customer_phone_number,
     state, assigned_operator_id, last_message_at, message_count, priority_score,
-    created_at, updated_at, resolved_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    created_at, updated_at, resolved_at, is_first_contact, customer_id, normalized_phone, due_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, regexp_replace($5, '[^0-9]', '', 'g'), $16)
 `
 
 type CreateConversationRefParams struct {
@@ -33,6 +289,9 @@ type CreateConversationRefParams struct {
 	CreatedAt              pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
 	ResolvedAt             pgtype.Timestamptz `json:"resolved_at"`
+	IsFirstContact         bool               `json:"is_first_contact"`
+	CustomerID             pgtype.UUID        `json:"customer_id"`
+	DueAt                  pgtype.Timestamptz `json:"due_at"`
 }
 
 func (q *Queries) CreateConversationRef(ctx context.Context, arg CreateConversationRefParams) error {
@@ -50,10 +309,67 @@ func (q *Queries) CreateConversationRef(ctx context.Context, arg CreateConversat
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.ResolvedAt,
+		arg.IsFirstContact,
+		arg.CustomerID,
+		arg.DueAt,
 	)
 	return err
 }
 
+const createConversationRefIfNotExists = `-- name: CreateConversationRefIfNotExists :execrows
+INSERT INTO conversation_refs (
+    id, tenant_id, inbox_id, external_conversation_id, customer_phone_number,
+    state, assigned_operator_id, last_message_at, message_count, priority_score,
+    created_at, updated_at, resolved_at, is_first_contact, customer_id, normalized_phone, due_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, regexp_replace($5, '[^0-9]', '', 'g'), $16)
+ON CONFLICT (tenant_id, external_conversation_id) DO NOTHING
+`
+
+type CreateConversationRefIfNotExistsParams struct {
+	ID                     pgtype.UUID        `json:"id"`
+	TenantID               pgtype.UUID        `json:"tenant_id"`
+	InboxID                pgtype.UUID        `json:"inbox_id"`
+	ExternalConversationID string             `json:"external_conversation_id"`
+	CustomerPhoneNumber    string             `json:"customer_phone_number"`
+	State                  ConversationState  `json:"state"`
+	AssignedOperatorID     pgtype.UUID        `json:"assigned_operator_id"`
+	LastMessageAt          pgtype.Timestamptz `json:"last_message_at"`
+	MessageCount           int32              `json:"message_count"`
+	PriorityScore          pgtype.Numeric     `json:"priority_score"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	ResolvedAt             pgtype.Timestamptz `json:"resolved_at"`
+	IsFirstContact         bool               `json:"is_first_contact"`
+	CustomerID             pgtype.UUID        `json:"customer_id"`
+	DueAt                  pgtype.Timestamptz `json:"due_at"`
+}
+
+// Insert unless the external conversation is already tracked (ingestion upsert)
+func (q *Queries) CreateConversationRefIfNotExists(ctx context.Context, arg CreateConversationRefIfNotExistsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createConversationRefIfNotExists,
+		arg.ID,
+		arg.TenantID,
+		arg.InboxID,
+		arg.ExternalConversationID,
+		arg.CustomerPhoneNumber,
+		arg.State,
+		arg.AssignedOperatorID,
+		arg.LastMessageAt,
+		arg.MessageCount,
+		arg.PriorityScore,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.ResolvedAt,
+		arg.IsFirstContact,
+		arg.CustomerID,
+		arg.DueAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteConversationRef = `-- name: DeleteConversationRef :exec
 DELETE FROM conversation_refs WHERE id = $1
 `
@@ -63,77 +379,78 @@ func (q *Queries) DeleteConversationRef(ctx context.Context, id pgtype.UUID) err
 	return err
 }
 
-const getConversationRefByExternalID = `-- name: GetConversationRefByExternalID :one
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs 
-WHERE tenant_id = $1 AND external_conversation_id = $2
-`
-
-type GetConversationRefByExternalIDParams struct {
-	TenantID               pgtype.UUID `json:"tenant_id"`
-	ExternalConversationID string      `json:"external_conversation_id"`
-}
-
-func (q *Queries) GetConversationRefByExternalID(ctx context.Context, arg GetConversationRefByExternalIDParams) (ConversationRef, error) {
-	row := q.db.QueryRow(ctx, getConversationRefByExternalID, arg.TenantID, arg.ExternalConversationID)
-	var i ConversationRef
-	err := row.Scan(
-		&i.ID,
-		&i.TenantID,
-		&i.InboxID,
-		&i.ExternalConversationID,
-		&i.CustomerPhoneNumber,
-		&i.State,
-		&i.AssignedOperatorID,
-		&i.LastMessageAt,
-		&i.MessageCount,
-		&i.PriorityScore,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.ResolvedAt,
-	)
-	return i, err
-}
-
-const getConversationRefByID = `-- name: GetConversationRefByID :one
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs WHERE id = $1
+const getAndLockAllocatedByOperator = `-- name: GetAndLockAllocatedByOperator :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE assigned_operator_id = $1 AND state = 'ALLOCATED'
+ORDER BY priority_score DESC, created_at ASC
+FOR UPDATE SKIP LOCKED
 `
 
-func (q *Queries) GetConversationRefByID(ctx context.Context, id pgtype.UUID) (ConversationRef, error) {
-	row := q.db.QueryRow(ctx, getConversationRefByID, id)
-	var i ConversationRef
-	err := row.Scan(
-		&i.ID,
-		&i.TenantID,
-		&i.InboxID,
-		&i.ExternalConversationID,
-		&i.CustomerPhoneNumber,
-		&i.State,
-		&i.AssignedOperatorID,
-		&i.LastMessageAt,
-		&i.MessageCount,
-		&i.PriorityScore,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.ResolvedAt,
-	)
-	return i, err
+// The operator's allocated conversations, most urgent first, locked for the
+// vacation drain worker
+func (q *Queries) GetAndLockAllocatedByOperator(ctx context.Context, assignedOperatorID pgtype.UUID) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getAndLockAllocatedByOperator, assignedOperatorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getConversationsByInbox = `-- name: GetConversationsByInbox :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 AND inbox_id = $2
-ORDER BY created_at DESC
-LIMIT $3
+const getAndLockEndedSnoozes = `-- name: GetAndLockEndedSnoozes :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE snoozed_until <= $1 AND state = 'QUEUED'
+ORDER BY snoozed_until ASC
+LIMIT $2
+FOR UPDATE SKIP LOCKED
 `
 
-type GetConversationsByInboxParams struct {
-	TenantID pgtype.UUID `json:"tenant_id"`
-	InboxID  pgtype.UUID `json:"inbox_id"`
-	Limit    int32       `json:"limit"`
+type GetAndLockEndedSnoozesParams struct {
+	SnoozedUntil pgtype.Timestamptz `json:"snoozed_until"`
+	Limit        int32              `json:"limit"`
 }
 
-func (q *Queries) GetConversationsByInbox(ctx context.Context, arg GetConversationsByInboxParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getConversationsByInbox, arg.TenantID, arg.InboxID, arg.Limit)
+// Snoozes that ended, locked for the snooze worker
+func (q *Queries) GetAndLockEndedSnoozes(ctx context.Context, arg GetAndLockEndedSnoozesParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getAndLockEndedSnoozes, arg.SnoozedUntil, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +472,19 @@ func (q *Queries) GetConversationsByInbox(ctx context.Context, arg GetConversati
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -166,22 +496,23 @@ func (q *Queries) GetConversationsByInbox(ctx context.Context, arg GetConversati
 	return items, nil
 }
 
-const getConversationsByOperatorAndState = `-- name: GetConversationsByOperatorAndState :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 
-  AND assigned_operator_id = $2 
-  AND state = $3
-ORDER BY created_at DESC
+const getAndLockOverdueConversations = `-- name: GetAndLockOverdueConversations :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE due_at <= $1 AND overdue_at IS NULL AND state <> 'RESOLVED'
+ORDER BY due_at ASC
+LIMIT $2
+FOR UPDATE SKIP LOCKED
 `
 
-type GetConversationsByOperatorAndStateParams struct {
-	TenantID           pgtype.UUID       `json:"tenant_id"`
-	AssignedOperatorID pgtype.UUID       `json:"assigned_operator_id"`
-	State              ConversationState `json:"state"`
+type GetAndLockOverdueConversationsParams struct {
+	DueAt pgtype.Timestamptz `json:"due_at"`
+	Limit int32              `json:"limit"`
 }
 
-func (q *Queries) GetConversationsByOperatorAndState(ctx context.Context, arg GetConversationsByOperatorAndStateParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getConversationsByOperatorAndState, arg.TenantID, arg.AssignedOperatorID, arg.State)
+// Open conversations past their due date not flagged yet, locked for the
+// overdue worker
+func (q *Queries) GetAndLockOverdueConversations(ctx context.Context, arg GetAndLockOverdueConversationsParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getAndLockOverdueConversations, arg.DueAt, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +534,19 @@ func (q *Queries) GetConversationsByOperatorAndState(ctx context.Context, arg Ge
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -214,9 +558,279 @@ func (q *Queries) GetConversationsByOperatorAndState(ctx context.Context, arg Ge
 	return items, nil
 }
 
-const getConversationsByOperatorID = `-- name: GetConversationsByOperatorID :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 AND assigned_operator_id = $2
+const getAndLockStaleConversations = `-- name: GetAndLockStaleConversations :many
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+JOIN inboxes i ON i.id = c.inbox_id
+WHERE i.auto_resolve_after_seconds IS NOT NULL
+  AND c.state <> 'RESOLVED'
+  AND c.snoozed_until IS NULL
+  AND c.last_message_at + make_interval(secs => i.auto_resolve_after_seconds) <= $1::timestamptz
+ORDER BY c.last_message_at ASC
+LIMIT $2
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetAndLockStaleConversationsParams struct {
+	Column1 pgtype.Timestamptz `json:"column_1"`
+	Limit   int32              `json:"limit"`
+}
+
+// Open conversations idle past their inbox's auto_resolve_after_seconds,
+// stalest first, locked for the auto-resolve worker; snoozed conversations
+// wait for their follow-up
+func (q *Queries) GetAndLockStaleConversations(ctx context.Context, arg GetAndLockStaleConversationsParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getAndLockStaleConversations, arg.Column1, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConversationRefByExternalID = `-- name: GetConversationRefByExternalID :one
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs 
+WHERE tenant_id = $1 AND external_conversation_id = $2
+`
+
+type GetConversationRefByExternalIDParams struct {
+	TenantID               pgtype.UUID `json:"tenant_id"`
+	ExternalConversationID string      `json:"external_conversation_id"`
+}
+
+func (q *Queries) GetConversationRefByExternalID(ctx context.Context, arg GetConversationRefByExternalIDParams) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, getConversationRefByExternalID, arg.TenantID, arg.ExternalConversationID)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const getConversationRefByID = `-- name: GetConversationRefByID :one
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs WHERE id = $1
+`
+
+func (q *Queries) GetConversationRefByID(ctx context.Context, id pgtype.UUID) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, getConversationRefByID, id)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const getConversationsByInbox = `-- name: GetConversationsByInbox :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type GetConversationsByInboxParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	InboxID  pgtype.UUID `json:"inbox_id"`
+	Limit    int32       `json:"limit"`
+}
+
+func (q *Queries) GetConversationsByInbox(ctx context.Context, arg GetConversationsByInboxParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getConversationsByInbox, arg.TenantID, arg.InboxID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConversationsByOperatorAndState = `-- name: GetConversationsByOperatorAndState :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 
+  AND assigned_operator_id = $2 
+  AND state = $3
+ORDER BY created_at DESC
+`
+
+type GetConversationsByOperatorAndStateParams struct {
+	TenantID           pgtype.UUID       `json:"tenant_id"`
+	AssignedOperatorID pgtype.UUID       `json:"assigned_operator_id"`
+	State              ConversationState `json:"state"`
+}
+
+func (q *Queries) GetConversationsByOperatorAndState(ctx context.Context, arg GetConversationsByOperatorAndStateParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getConversationsByOperatorAndState, arg.TenantID, arg.AssignedOperatorID, arg.State)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConversationsByOperatorID = `-- name: GetConversationsByOperatorID :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND assigned_operator_id = $2
 ORDER BY created_at DESC
 `
 
@@ -225,8 +839,573 @@ type GetConversationsByOperatorIDParams struct {
 	AssignedOperatorID pgtype.UUID `json:"assigned_operator_id"`
 }
 
-func (q *Queries) GetConversationsByOperatorID(ctx context.Context, arg GetConversationsByOperatorIDParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getConversationsByOperatorID, arg.TenantID, arg.AssignedOperatorID)
+func (q *Queries) GetConversationsByOperatorID(ctx context.Context, arg GetConversationsByOperatorIDParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getConversationsByOperatorID, arg.TenantID, arg.AssignedOperatorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConversationsByTenantAndState = `-- name: GetConversationsByTenantAndState :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND state = $2
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type GetConversationsByTenantAndStateParams struct {
+	TenantID pgtype.UUID       `json:"tenant_id"`
+	State    ConversationState `json:"state"`
+	Limit    int32             `json:"limit"`
+}
+
+func (q *Queries) GetConversationsByTenantAndState(ctx context.Context, arg GetConversationsByTenantAndStateParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getConversationsByTenantAndState, arg.TenantID, arg.State, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextAffineConversationsForAllocation = `-- name: GetNextAffineConversationsForAllocation :many
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+JOIN customer_operator_affinity a ON a.customer_id = c.customer_id
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND a.operator_id = $3
+  AND a.handled_at >= $4
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetNextAffineConversationsForAllocationParams struct {
+	TenantID   pgtype.UUID        `json:"tenant_id"`
+	Column2    []pgtype.UUID      `json:"column_2"`
+	OperatorID pgtype.UUID        `json:"operator_id"`
+	HandledAt  pgtype.Timestamptz `json:"handled_at"`
+	Limit      int32              `json:"limit"`
+	Column6    []string           `json:"column_6"`
+}
+
+// Queued conversations of the customers the operator $3 resolved last, since
+// $4, in allocation order; offered to the operator before the rest of the queue
+func (q *Queries) GetNextAffineConversationsForAllocation(ctx context.Context, arg GetNextAffineConversationsForAllocationParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextAffineConversationsForAllocation,
+		arg.TenantID,
+		arg.Column2,
+		arg.OperatorID,
+		arg.HandledAt,
+		arg.Limit,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextConversationsForAllocation = `-- name: GetNextConversationsForAllocation :many
+WITH candidates AS (
+    SELECT top.id
+    FROM unnest($2::uuid[]) AS inbox(id)
+    CROSS JOIN LATERAL (
+        SELECT q.id FROM conversation_refs q
+        WHERE q.tenant_id = $1
+          AND q.inbox_id = inbox.id
+          AND q.state = 'QUEUED'
+          AND q.snoozed_until IS NULL
+          AND (q.language IS NULL OR cardinality($5::text[]) = 0 OR q.language = ANY($5::text[]))
+        ORDER BY q.priority_score DESC, q.last_message_at ASC
+        LIMIT $4
+    ) top
+    UNION
+    SELECT p.id FROM conversation_refs p
+    WHERE p.tenant_id = $1
+      AND p.inbox_id = ANY($2::uuid[])
+      AND p.state = 'QUEUED'
+      AND p.priority_override IS NOT NULL
+      AND p.snoozed_until IS NULL
+      AND (p.language IS NULL OR cardinality($5::text[]) = 0 OR p.language = ANY($5::text[]))
+)
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+JOIN candidates ON candidates.id = c.id
+WHERE c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $3
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetNextConversationsForAllocationParams struct {
+	TenantID pgtype.UUID   `json:"tenant_id"`
+	Column2  []pgtype.UUID `json:"column_2"`
+	Limit    int32         `json:"limit"`
+	Limit_2  int32         `json:"limit_2"`
+	Column5  []string      `json:"column_5"`
+}
+
+// CRITICAL: Allocation query with FOR UPDATE SKIP LOCKED
+// Candidates are the top $4 of each inbox in idx_conversations_queue order
+// plus the pinned ones (idx_conversations_pinned), so the scan stops after a
+// few index entries per inbox instead of sorting every queued conversation.
+// Candidates locked by concurrent allocators are skipped; when fewer than $3
+// are left the caller falls back to GetNextConversationsForAllocationFullScan.
+func (q *Queries) GetNextConversationsForAllocation(ctx context.Context, arg GetNextConversationsForAllocationParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextConversationsForAllocation,
+		arg.TenantID,
+		arg.Column2,
+		arg.Limit,
+		arg.Limit_2,
+		arg.Column5,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextConversationsForAllocationBreachFirst = `-- name: GetNextConversationsForAllocationBreachFirst :many
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST,
+         LEAST(c.sla_breached_at, c.overdue_at) ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetNextConversationsForAllocationBreachFirstParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	Column2   []pgtype.UUID      `json:"column_2"`
+	Column3   []pgtype.UUID      `json:"column_3"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Limit     int32              `json:"limit"`
+	Column6   []string           `json:"column_6"`
+}
+
+// Allocation order of the v2 engine: conversations that breached an SLA
+// target or their due date come first, earliest breach first, then the usual
+// order. Category quotas apply as in GetNextConversationsForAllocationWithQuotas;
+// an empty $3 and a NULL $4 leave them out.
+func (q *Queries) GetNextConversationsForAllocationBreachFirst(ctx context.Context, arg GetNextConversationsForAllocationBreachFirstParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextConversationsForAllocationBreachFirst,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextConversationsForAllocationByLabels = `-- name: GetNextConversationsForAllocationByLabels :many
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+  AND EXISTS (
+      SELECT 1 FROM conversation_labels cl
+      WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+  )
+ORDER BY c.priority_override DESC NULLS LAST,
+         CASE WHEN $4::boolean THEN LEAST(c.sla_breached_at, c.overdue_at) END ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetNextConversationsForAllocationByLabelsParams struct {
+	TenantID pgtype.UUID   `json:"tenant_id"`
+	Column2  []pgtype.UUID `json:"column_2"`
+	Column3  []pgtype.UUID `json:"column_3"`
+	Column4  bool          `json:"column_4"`
+	Limit    int32         `json:"limit"`
+	Column6  []string      `json:"column_6"`
+}
+
+// Allocation order restricted to conversations carrying one of the labels
+// $3, for operators pulling e.g. only billing conversations; $4 takes
+// breached conversations first as the v2 engine does
+func (q *Queries) GetNextConversationsForAllocationByLabels(ctx context.Context, arg GetNextConversationsForAllocationByLabelsParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextConversationsForAllocationByLabels,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextConversationsForAllocationFullScan = `-- name: GetNextConversationsForAllocationFullScan :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1
+  AND inbox_id = ANY($2::uuid[])
+  AND state = 'QUEUED'
+  AND snoozed_until IS NULL
+  AND (language IS NULL OR cardinality($4::text[]) = 0 OR language = ANY($4::text[]))
+ORDER BY priority_override DESC NULLS LAST, priority_score DESC, last_message_at ASC
+LIMIT $3
+FOR UPDATE SKIP LOCKED
+`
+
+type GetNextConversationsForAllocationFullScanParams struct {
+	TenantID pgtype.UUID   `json:"tenant_id"`
+	Column2  []pgtype.UUID `json:"column_2"`
+	Limit    int32         `json:"limit"`
+	Column4  []string      `json:"column_4"`
+}
+
+// Allocation order over every queued conversation of the inboxes; the
+// fallback of GetNextConversationsForAllocation under contention
+func (q *Queries) GetNextConversationsForAllocationFullScan(ctx context.Context, arg GetNextConversationsForAllocationFullScanParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextConversationsForAllocationFullScan,
+		arg.TenantID,
+		arg.Column2,
+		arg.Limit,
+		arg.Column4,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationRef{}
+	for rows.Next() {
+		var i ConversationRef
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.ExternalConversationID,
+			&i.CustomerPhoneNumber,
+			&i.State,
+			&i.AssignedOperatorID,
+			&i.LastMessageAt,
+			&i.MessageCount,
+			&i.PriorityScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextConversationsForAllocationWithQuotas = `-- name: GetNextConversationsForAllocationWithQuotas :many
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED
+`
+
+type GetNextConversationsForAllocationWithQuotasParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	Column2   []pgtype.UUID      `json:"column_2"`
+	Column3   []pgtype.UUID      `json:"column_3"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Limit     int32              `json:"limit"`
+	Column6   []string           `json:"column_6"`
+}
+
+// Allocation order for inboxes with category quotas: conversations created
+// before $4 come first so nothing starves, then those carrying one of the
+// preferred category labels $3, then the usual order
+func (q *Queries) GetNextConversationsForAllocationWithQuotas(ctx context.Context, arg GetNextConversationsForAllocationWithQuotasParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getNextConversationsForAllocationWithQuotas,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.Column6,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -248,6 +1427,19 @@ func (q *Queries) GetConversationsByOperatorID(ctx context.Context, arg GetConve
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -259,21 +1451,54 @@ func (q *Queries) GetConversationsByOperatorID(ctx context.Context, arg GetConve
 	return items, nil
 }
 
-const getConversationsByTenantAndState = `-- name: GetConversationsByTenantAndState :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 AND state = $2
-ORDER BY created_at DESC
+const getOpenConversationIDsByInbox = `-- name: GetOpenConversationIDsByInbox :many
+SELECT id FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND state <> 'RESOLVED'
+ORDER BY created_at ASC
 LIMIT $3
 `
 
-type GetConversationsByTenantAndStateParams struct {
-	TenantID pgtype.UUID       `json:"tenant_id"`
-	State    ConversationState `json:"state"`
-	Limit    int32             `json:"limit"`
+type GetOpenConversationIDsByInboxParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	InboxID  pgtype.UUID `json:"inbox_id"`
+	Limit    int32       `json:"limit"`
 }
 
-func (q *Queries) GetConversationsByTenantAndState(ctx context.Context, arg GetConversationsByTenantAndStateParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getConversationsByTenantAndState, arg.TenantID, arg.State, arg.Limit)
+// Oldest first, for moving an inbox's backlog in batches
+func (q *Queries) GetOpenConversationIDsByInbox(ctx context.Context, arg GetOpenConversationIDsByInboxParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getOpenConversationIDsByInbox, arg.TenantID, arg.InboxID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQueuedConversationsByTenant = `-- name: GetQueuedConversationsByTenant :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
+ORDER BY priority_override DESC NULLS LAST, priority_score DESC, last_message_at ASC
+LIMIT $2
+`
+
+type GetQueuedConversationsByTenantParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Limit    int32       `json:"limit"`
+}
+
+func (q *Queries) GetQueuedConversationsByTenant(ctx context.Context, arg GetQueuedConversationsByTenantParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, getQueuedConversationsByTenant, arg.TenantID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +1520,19 @@ func (q *Queries) GetConversationsByTenantAndState(ctx context.Context, arg GetC
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -306,25 +1544,27 @@ func (q *Queries) GetConversationsByTenantAndState(ctx context.Context, arg GetC
 	return items, nil
 }
 
-const getNextConversationsForAllocation = `-- name: GetNextConversationsForAllocation :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 
-  AND inbox_id = ANY($2::uuid[])
-  AND state = 'QUEUED'
-ORDER BY priority_score DESC, last_message_at ASC
-LIMIT $3
-FOR UPDATE SKIP LOCKED
+const listInboxSLABreaches = `-- name: ListInboxSLABreaches :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND sla_breached_at >= $3
+ORDER BY sla_breached_at DESC, id DESC
+LIMIT $4
 `
 
-type GetNextConversationsForAllocationParams struct {
-	TenantID pgtype.UUID   `json:"tenant_id"`
-	Column2  []pgtype.UUID `json:"column_2"`
-	Limit    int32         `json:"limit"`
+type ListInboxSLABreachesParams struct {
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	InboxID       pgtype.UUID        `json:"inbox_id"`
+	SlaBreachedAt pgtype.Timestamptz `json:"sla_breached_at"`
+	Limit         int32              `json:"limit"`
 }
 
-// CRITICAL: Allocation query with FOR UPDATE SKIP LOCKED
-func (q *Queries) GetNextConversationsForAllocation(ctx context.Context, arg GetNextConversationsForAllocationParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getNextConversationsForAllocation, arg.TenantID, arg.Column2, arg.Limit)
+func (q *Queries) ListInboxSLABreaches(ctx context.Context, arg ListInboxSLABreachesParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, listInboxSLABreaches,
+		arg.TenantID,
+		arg.InboxID,
+		arg.SlaBreachedAt,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -346,6 +1586,19 @@ func (q *Queries) GetNextConversationsForAllocation(ctx context.Context, arg Get
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -357,20 +1610,21 @@ func (q *Queries) GetNextConversationsForAllocation(ctx context.Context, arg Get
 	return items, nil
 }
 
-const getQueuedConversationsByTenant = `-- name: GetQueuedConversationsByTenant :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE tenant_id = $1 AND state = 'QUEUED'
-ORDER BY priority_score DESC, last_message_at ASC
-LIMIT $2
+const listSLABreaches = `-- name: ListSLABreaches :many
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND sla_breached_at >= $2
+ORDER BY sla_breached_at DESC, id DESC
+LIMIT $3
 `
 
-type GetQueuedConversationsByTenantParams struct {
-	TenantID pgtype.UUID `json:"tenant_id"`
-	Limit    int32       `json:"limit"`
+type ListSLABreachesParams struct {
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	SlaBreachedAt pgtype.Timestamptz `json:"sla_breached_at"`
+	Limit         int32              `json:"limit"`
 }
 
-func (q *Queries) GetQueuedConversationsByTenant(ctx context.Context, arg GetQueuedConversationsByTenantParams) ([]ConversationRef, error) {
-	rows, err := q.db.Query(ctx, getQueuedConversationsByTenant, arg.TenantID, arg.Limit)
+func (q *Queries) ListSLABreaches(ctx context.Context, arg ListSLABreachesParams) ([]ConversationRef, error) {
+	rows, err := q.db.Query(ctx, listSLABreaches, arg.TenantID, arg.SlaBreachedAt, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -392,6 +1646,19 @@ func (q *Queries) GetQueuedConversationsByTenant(ctx context.Context, arg GetQue
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -404,8 +1671,8 @@ func (q *Queries) GetQueuedConversationsByTenant(ctx context.Context, arg GetQue
 }
 
 const lockConversationForClaim = `-- name: LockConversationForClaim :one
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
-WHERE id = $1 AND state = 'QUEUED'
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
 FOR UPDATE NOWAIT
 `
 
@@ -427,12 +1694,361 @@ func (q *Queries) LockConversationForClaim(ctx context.Context, id pgtype.UUID)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const lockConversationRefByExternalID = `-- name: LockConversationRefByExternalID :one
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE tenant_id = $1 AND external_conversation_id = $2
+FOR UPDATE
+`
+
+type LockConversationRefByExternalIDParams struct {
+	TenantID               pgtype.UUID `json:"tenant_id"`
+	ExternalConversationID string      `json:"external_conversation_id"`
+}
+
+// Lock conversation row by external ID for in-place updates (ingestion)
+func (q *Queries) LockConversationRefByExternalID(ctx context.Context, arg LockConversationRefByExternalIDParams) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, lockConversationRefByExternalID, arg.TenantID, arg.ExternalConversationID)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const lockConversationRefForUpdate = `-- name: LockConversationRefForUpdate :one
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
+WHERE id = $1
+FOR UPDATE
+`
+
+// Lock conversation row for in-place updates (message received)
+func (q *Queries) LockConversationRefForUpdate(ctx context.Context, id pgtype.UUID) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, lockConversationRefForUpdate, id)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const markSLABreaches = `-- name: MarkSLABreaches :many
+UPDATE conversation_refs c
+SET sla_breached_at = $1
+FROM inbox_sla_policies p
+WHERE p.inbox_id = c.inbox_id
+  AND c.sla_breached_at IS NULL
+  AND c.state <> 'RESOLVED'
+  AND (
+      (c.state = 'QUEUED' AND c.snoozed_until IS NULL
+       AND c.created_at + make_interval(secs => p.first_assignment_seconds) <= $1)
+      OR c.created_at + make_interval(secs => p.resolution_seconds) <= $1
+  )
+RETURNING c.id, c.tenant_id, c.inbox_id
+`
+
+type MarkSLABreachesRow struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	InboxID  pgtype.UUID `json:"inbox_id"`
+}
+
+// Flag open conversations past a target of their inbox's SLA policy. Snoozed
+// conversations were already assigned once and only count for resolution.
+func (q *Queries) MarkSLABreaches(ctx context.Context, slaBreachedAt pgtype.Timestamptz) ([]MarkSLABreachesRow, error) {
+	rows, err := q.db.Query(ctx, markSLABreaches, slaBreachedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MarkSLABreachesRow{}
+	for rows.Next() {
+		var i MarkSLABreachesRow
+		if err := rows.Scan(&i.ID, &i.TenantID, &i.InboxID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const peekNextConversationForAllocation = `-- name: PeekNextConversationForAllocation :one
+WITH candidates AS (
+    SELECT top.id
+    FROM unnest($2::uuid[]) AS inbox(id)
+    CROSS JOIN LATERAL (
+        SELECT q.id FROM conversation_refs q
+        WHERE q.tenant_id = $1
+          AND q.inbox_id = inbox.id
+          AND q.state = 'QUEUED'
+          AND q.snoozed_until IS NULL
+          AND (q.language IS NULL OR cardinality($3::text[]) = 0 OR q.language = ANY($3::text[]))
+        ORDER BY q.priority_score DESC, q.last_message_at ASC
+        LIMIT 1
+    ) top
+    UNION
+    SELECT p.id FROM conversation_refs p
+    WHERE p.tenant_id = $1
+      AND p.inbox_id = ANY($2::uuid[])
+      AND p.state = 'QUEUED'
+      AND p.priority_override IS NOT NULL
+      AND p.snoozed_until IS NULL
+      AND (p.language IS NULL OR cardinality($3::text[]) = 0 OR p.language = ANY($3::text[]))
+)
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+JOIN candidates ON candidates.id = c.id
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT 1
+`
+
+type PeekNextConversationForAllocationParams struct {
+	TenantID pgtype.UUID   `json:"tenant_id"`
+	Column2  []pgtype.UUID `json:"column_2"`
+	Column3  []string      `json:"column_3"`
+}
+
+// Head of the allocation order of GetNextConversationsForAllocation, without
+// locking, for previews; may return a conversation being allocated
+// concurrently
+func (q *Queries) PeekNextConversationForAllocation(ctx context.Context, arg PeekNextConversationForAllocationParams) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, peekNextConversationForAllocation, arg.TenantID, arg.Column2, arg.Column3)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const peekNextConversationForAllocationBreachFirst = `-- name: PeekNextConversationForAllocationBreachFirst :one
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($5::text[]) = 0 OR c.language = ANY($5::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST,
+         LEAST(c.sla_breached_at, c.overdue_at) ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT 1
+`
+
+type PeekNextConversationForAllocationBreachFirstParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	Column2   []pgtype.UUID      `json:"column_2"`
+	Column3   []pgtype.UUID      `json:"column_3"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Column5   []string           `json:"column_5"`
+}
+
+// Head of the allocation order of GetNextConversationsForAllocationBreachFirst,
+// without locking, for previews
+func (q *Queries) PeekNextConversationForAllocationBreachFirst(ctx context.Context, arg PeekNextConversationForAllocationBreachFirstParams) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, peekNextConversationForAllocationBreachFirst,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.CreatedAt,
+		arg.Column5,
+	)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
+	)
+	return i, err
+}
+
+const peekNextConversationForAllocationWithQuotas = `-- name: PeekNextConversationForAllocationWithQuotas :one
+SELECT c.id, c.tenant_id, c.inbox_id, c.external_conversation_id, c.customer_phone_number, c.state, c.assigned_operator_id, c.last_message_at, c.message_count, c.priority_score, c.created_at, c.updated_at, c.resolved_at, c.reopened_count, c.category, c.sla_breached_at, c.snoozed_until, c.snooze_operator_id, c.priority_override, c.is_first_contact, c.version, c.language, c.customer_id, c.normalized_phone, c.due_at, c.overdue_at FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($5::text[]) = 0 OR c.language = ANY($5::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT 1
+`
+
+type PeekNextConversationForAllocationWithQuotasParams struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	Column2   []pgtype.UUID      `json:"column_2"`
+	Column3   []pgtype.UUID      `json:"column_3"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Column5   []string           `json:"column_5"`
+}
+
+// Head of the allocation order of GetNextConversationsForAllocationWithQuotas,
+// without locking, for previews
+func (q *Queries) PeekNextConversationForAllocationWithQuotas(ctx context.Context, arg PeekNextConversationForAllocationWithQuotasParams) (ConversationRef, error) {
+	row := q.db.QueryRow(ctx, peekNextConversationForAllocationWithQuotas,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.CreatedAt,
+		arg.Column5,
+	)
+	var i ConversationRef
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.ExternalConversationID,
+		&i.CustomerPhoneNumber,
+		&i.State,
+		&i.AssignedOperatorID,
+		&i.LastMessageAt,
+		&i.MessageCount,
+		&i.PriorityScore,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ResolvedAt,
+		&i.ReopenedCount,
+		&i.Category,
+		&i.SlaBreachedAt,
+		&i.SnoozedUntil,
+		&i.SnoozeOperatorID,
+		&i.PriorityOverride,
+		&i.IsFirstContact,
+		&i.Version,
+		&i.Language,
+		&i.CustomerID,
+		&i.NormalizedPhone,
+		&i.DueAt,
+		&i.OverdueAt,
 	)
 	return i, err
 }
 
 const searchConversationsByPhone = `-- name: SearchConversationsByPhone :many
-SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at FROM conversation_refs
+SELECT id, tenant_id, inbox_id, external_conversation_id, customer_phone_number, state, assigned_operator_id, last_message_at, message_count, priority_score, created_at, updated_at, resolved_at, reopened_count, category, sla_breached_at, snoozed_until, snooze_operator_id, priority_override, is_first_contact, version, language, customer_id, normalized_phone, due_at, overdue_at FROM conversation_refs
 WHERE tenant_id = $1 AND customer_phone_number = $2
 ORDER BY created_at DESC
 `
@@ -465,6 +2081,19 @@ func (q *Queries) SearchConversationsByPhone(ctx context.Context, arg SearchConv
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ResolvedAt,
+			&i.ReopenedCount,
+			&i.Category,
+			&i.SlaBreachedAt,
+			&i.SnoozedUntil,
+			&i.SnoozeOperatorID,
+			&i.PriorityOverride,
+			&i.IsFirstContact,
+			&i.Version,
+			&i.Language,
+			&i.CustomerID,
+			&i.NormalizedPhone,
+			&i.DueAt,
+			&i.OverdueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -476,7 +2105,91 @@ func (q *Queries) SearchConversationsByPhone(ctx context.Context, arg SearchConv
 	return items, nil
 }
 
-const updateConversationRef = `-- name: UpdateConversationRef :exec
+const setConversationRefDueAt = `-- name: SetConversationRefDueAt :exec
+UPDATE conversation_refs
+SET due_at = $2,
+    overdue_at = NULL,
+    updated_at = $3
+WHERE id = $1
+`
+
+type SetConversationRefDueAtParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	DueAt     pgtype.Timestamptz `json:"due_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Set or clear the due date, which re-arms the overdue flag;
+// UpdateConversationRef leaves both alone
+func (q *Queries) SetConversationRefDueAt(ctx context.Context, arg SetConversationRefDueAtParams) error {
+	_, err := q.db.Exec(ctx, setConversationRefDueAt, arg.ID, arg.DueAt, arg.UpdatedAt)
+	return err
+}
+
+const setConversationRefOverdue = `-- name: SetConversationRefOverdue :exec
+UPDATE conversation_refs
+SET overdue_at = $2
+WHERE id = $1
+`
+
+type SetConversationRefOverdueParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	OverdueAt pgtype.Timestamptz `json:"overdue_at"`
+}
+
+// Flag the conversation overdue; UpdateConversationRef leaves it alone
+func (q *Queries) SetConversationRefOverdue(ctx context.Context, arg SetConversationRefOverdueParams) error {
+	_, err := q.db.Exec(ctx, setConversationRefOverdue, arg.ID, arg.OverdueAt)
+	return err
+}
+
+const setConversationRefPriorityOverride = `-- name: SetConversationRefPriorityOverride :exec
+UPDATE conversation_refs
+SET priority_override = $2,
+    updated_at = $3
+WHERE id = $1
+`
+
+type SetConversationRefPriorityOverrideParams struct {
+	ID               pgtype.UUID        `json:"id"`
+	PriorityOverride pgtype.Numeric     `json:"priority_override"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Set or clear the manual priority; UpdateConversationRef leaves it alone
+func (q *Queries) SetConversationRefPriorityOverride(ctx context.Context, arg SetConversationRefPriorityOverrideParams) error {
+	_, err := q.db.Exec(ctx, setConversationRefPriorityOverride, arg.ID, arg.PriorityOverride, arg.UpdatedAt)
+	return err
+}
+
+const setConversationRefSnooze = `-- name: SetConversationRefSnooze :exec
+UPDATE conversation_refs
+SET snoozed_until = $2,
+    snooze_operator_id = $3,
+    updated_at = $4
+WHERE id = $1
+`
+
+type SetConversationRefSnoozeParams struct {
+	ID               pgtype.UUID        `json:"id"`
+	SnoozedUntil     pgtype.Timestamptz `json:"snoozed_until"`
+	SnoozeOperatorID pgtype.UUID        `json:"snooze_operator_id"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Set or clear the snooze; state and assignment are changed through
+// UpdateConversationRef, which leaves these columns alone
+func (q *Queries) SetConversationRefSnooze(ctx context.Context, arg SetConversationRefSnoozeParams) error {
+	_, err := q.db.Exec(ctx, setConversationRefSnooze,
+		arg.ID,
+		arg.SnoozedUntil,
+		arg.SnoozeOperatorID,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const updateConversationRef = `-- name: UpdateConversationRef :execrows
 UPDATE conversation_refs
 SET inbox_id = $2,
     state = $3,
@@ -485,8 +2198,13 @@ SET inbox_id = $2,
     message_count = $6,
     priority_score = $7,
     updated_at = $8,
-    resolved_at = $9
-WHERE id = $1
+    resolved_at = $9,
+    reopened_count = $10,
+    category = $11,
+    language = $12,
+    customer_id = COALESCE($13::uuid, customer_id),
+    version = version + 1
+WHERE id = $1 AND version = $14
 `
 
 type UpdateConversationRefParams struct {
@@ -499,10 +2217,17 @@ type UpdateConversationRefParams struct {
 	PriorityScore      pgtype.Numeric     `json:"priority_score"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 	ResolvedAt         pgtype.Timestamptz `json:"resolved_at"`
+	ReopenedCount      int32              `json:"reopened_count"`
+	Category           pgtype.Text        `json:"category"`
+	Language           pgtype.Text        `json:"language"`
+	Column13           pgtype.UUID        `json:"column_13"`
+	Version            int32              `json:"version"`
 }
 
-func (q *Queries) UpdateConversationRef(ctx context.Context, arg UpdateConversationRefParams) error {
-	_, err := q.db.Exec(ctx, updateConversationRef,
+// Compare-and-swap on version: no row is updated when another writer
+// changed the conversation since it was read
+func (q *Queries) UpdateConversationRef(ctx context.Context, arg UpdateConversationRefParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateConversationRef,
 		arg.ID,
 		arg.InboxID,
 		arg.State,
@@ -512,8 +2237,16 @@ func (q *Queries) UpdateConversationRef(ctx context.Context, arg UpdateConversat
 		arg.PriorityScore,
 		arg.UpdatedAt,
 		arg.ResolvedAt,
+		arg.ReopenedCount,
+		arg.Category,
+		arg.Language,
+		arg.Column13,
+		arg.Version,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updateConversationState = `-- name: UpdateConversationState :exec
@@ -521,7 +2254,8 @@ UPDATE conversation_refs
 SET state = $2,
     assigned_operator_id = $3,
     updated_at = $4,
-    resolved_at = $5
+    resolved_at = $5,
+    version = version + 1
 WHERE id = $1
 `
 
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ConversationTransferRepositoryImpl struct {
+	q *Queries
+}
+
+func NewConversationTransferRepository(q *Queries) *ConversationTransferRepositoryImpl {
+	return &ConversationTransferRepositoryImpl{q: q}
+}
+
+func (r *ConversationTransferRepositoryImpl) Create(ctx context.Context, transfer *domain.ConversationTransfer) error {
+	err := r.q.CreateConversationTransfer(ctx, CreateConversationTransferParams{
+		ID:             uuidToPgtype(transfer.ID),
+		TenantID:       uuidToPgtype(transfer.TenantID),
+		ConversationID: uuidToPgtype(transfer.ConversationID),
+		FromOperatorID: uuidToPgtype(transfer.FromOperatorID),
+		ToOperatorID:   uuidToPgtype(transfer.ToOperatorID),
+		Note:           stringPtrToPgtype(transfer.Note),
+		Status:         string(transfer.Status),
+		ExpiresAt:      timeToPgtype(transfer.ExpiresAt),
+		CreatedAt:      timeToPgtype(transfer.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *ConversationTransferRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.ConversationTransfer, error) {
+	row, err := r.q.GetConversationTransferByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ConversationTransferRepositoryImpl) LockForUpdate(ctx context.Context, id uuid.UUID) (*domain.ConversationTransfer, error) {
+	row, err := r.q.LockConversationTransfer(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ConversationTransferRepositoryImpl) ListPending(ctx context.Context, tenantID, operatorID uuid.UUID) ([]*domain.ConversationTransfer, error) {
+	rows, err := r.q.ListPendingConversationTransfersByOperator(ctx, ListPendingConversationTransfersByOperatorParams{
+		TenantID:     uuidToPgtype(tenantID),
+		ToOperatorID: uuidToPgtype(operatorID),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *ConversationTransferRepositoryImpl) UpdateStatus(ctx context.Context, transfer *domain.ConversationTransfer) error {
+	return mapError(r.q.UpdateConversationTransferStatus(ctx, UpdateConversationTransferStatusParams{
+		ID:          uuidToPgtype(transfer.ID),
+		Status:      string(transfer.Status),
+		RespondedAt: timePtrToPgtype(transfer.RespondedAt),
+	}))
+}
+
+func (r *ConversationTransferRepositoryImpl) ExpireDue(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationTransfer, error) {
+	rows, err := r.q.ExpireConversationTransfers(ctx, ExpireConversationTransfersParams{
+		RespondedAt: timeToPgtype(now),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *ConversationTransferRepositoryImpl) toDomain(row ConversationTransfer) *domain.ConversationTransfer {
+	return &domain.ConversationTransfer{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		FromOperatorID: pgtypeToUUID(row.FromOperatorID),
+		ToOperatorID:   pgtypeToUUID(row.ToOperatorID),
+		Note:           pgtypeToStringPtr(row.Note),
+		Status:         domain.TransferStatus(row.Status),
+		ExpiresAt:      pgtypeToTime(row.ExpiresAt),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+		RespondedAt:    pgtypeToTimePtr(row.RespondedAt),
+	}
+}
+
+func (r *ConversationTransferRepositoryImpl) toDomainSlice(rows []ConversationTransfer) []*domain.ConversationTransfer {
+	transfers := make([]*domain.ConversationTransfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = r.toDomain(row)
+	}
+	return transfers
+}
@@ -120,6 +120,21 @@ func pgtypeToDecimal(n pgtype.Numeric) decimal.Decimal {
 	return d.Shift(n.Exp)
 }
 
+func decimalPtrToPgtype(d *decimal.Decimal) pgtype.Numeric {
+	if d == nil {
+		return pgtype.Numeric{}
+	}
+	return decimalToPgtype(*d)
+}
+
+func pgtypeToDecimalPtr(n pgtype.Numeric) *decimal.Decimal {
+	if !n.Valid {
+		return nil
+	}
+	d := pgtypeToDecimal(n)
+	return &d
+}
+
 // ==================== String Converters ====================
 
 func stringPtrToPgtype(s *string) pgtype.Text {
@@ -136,6 +151,67 @@ func pgtypeToStringPtr(t pgtype.Text) *string {
 	return &t.String
 }
 
+// ==================== Integer Converters ====================
+
+func intPtrToPgtype(i *int) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{Valid: false}
+	}
+	return pgtype.Int4{Int32: int32(*i), Valid: true}
+}
+
+func pgtypeToIntPtr(i pgtype.Int4) *int {
+	if !i.Valid {
+		return nil
+	}
+	v := int(i.Int32)
+	return &v
+}
+
+func floatPtrToPgtype(f *float64) pgtype.Float8 {
+	if f == nil {
+		return pgtype.Float8{Valid: false}
+	}
+	return pgtype.Float8{Float64: *f, Valid: true}
+}
+
+func pgtypeToFloatPtr(f pgtype.Float8) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	v := f.Float64
+	return &v
+}
+
+// ==================== Duration Converters ====================
+
+// durationPtrToSeconds converts a duration to whole seconds, NULL when nil
+func durationPtrToSeconds(d *time.Duration) pgtype.Int4 {
+	if d == nil {
+		return pgtype.Int4{Valid: false}
+	}
+	return pgtype.Int4{Int32: int32(*d / time.Second), Valid: true}
+}
+
+func secondsToDurationPtr(s pgtype.Int4) *time.Duration {
+	if !s.Valid {
+		return nil
+	}
+	d := time.Duration(s.Int32) * time.Second
+	return &d
+}
+
+// ==================== Time of Day Converters ====================
+
+// timeOfDayToPgtype converts an offset from midnight to a TIME value
+func timeOfDayToPgtype(d time.Duration) pgtype.Time {
+	return pgtype.Time{Microseconds: d.Microseconds(), Valid: true}
+}
+
+func pgtypeToTimeOfDay(t pgtype.Time) time.Duration {
+	return time.Duration(t.Microseconds) * time.Microsecond
+}
+
 // ==================== Domain Value Object Converters ====================
 
 func conversationStateToPgtype(s domain.ConversationState) ConversationState {
@@ -169,3 +245,76 @@ func gracePeriodReasonToPgtype(r domain.GracePeriodReason) GracePeriodReason {
 func pgtypeToGracePeriodReason(r GracePeriodReason) domain.GracePeriodReason {
 	return domain.GracePeriodReason(r)
 }
+
+func webhookDeliveryStatusToPgtype(s domain.WebhookDeliveryStatus) WebhookDeliveryStatus {
+	return WebhookDeliveryStatus(s)
+}
+
+func pgtypeToWebhookDeliveryStatus(s WebhookDeliveryStatus) domain.WebhookDeliveryStatus {
+	return domain.WebhookDeliveryStatus(s)
+}
+
+func routingRuleTriggerToPgtype(t domain.RoutingRuleTrigger) RoutingRuleTrigger {
+	return RoutingRuleTrigger(t)
+}
+
+func pgtypeToRoutingRuleTrigger(t RoutingRuleTrigger) domain.RoutingRuleTrigger {
+	return domain.RoutingRuleTrigger(t)
+}
+
+func qaReviewStatusToPgtype(s domain.QAReviewStatus) QaReviewStatus {
+	return QaReviewStatus(s)
+}
+
+func pgtypeToQAReviewStatus(s QaReviewStatus) domain.QAReviewStatus {
+	return domain.QAReviewStatus(s)
+}
+
+func backfillStatusToPgtype(s domain.BackfillStatus) BackfillStatus {
+	return BackfillStatus(s)
+}
+
+func pgtypeToBackfillStatus(s BackfillStatus) domain.BackfillStatus {
+	return domain.BackfillStatus(s)
+}
+
+func eventTypesToStrings(types []domain.EventType) []string {
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result
+}
+
+func stringsToEventTypes(values []string) []domain.EventType {
+	result := make([]domain.EventType, len(values))
+	for i, v := range values {
+		result[i] = domain.EventType(v)
+	}
+	return result
+}
+
+func webhookOperatorFieldsToStrings(fields []domain.WebhookOperatorField) []string {
+	result := make([]string, len(fields))
+	for i, f := range fields {
+		result[i] = string(f)
+	}
+	return result
+}
+
+func stringsToWebhookOperatorFields(values []string) []domain.WebhookOperatorField {
+	result := make([]domain.WebhookOperatorField, len(values))
+	for i, v := range values {
+		result[i] = domain.WebhookOperatorField(v)
+	}
+	return result
+}
+
+// languagesToPgtype never returns nil: a NULL array would make the language
+// conditions of the allocation queries exclude every conversation with a language
+func languagesToPgtype(languages []string) []string {
+	if languages == nil {
+		return []string{}
+	}
+	return languages
+}
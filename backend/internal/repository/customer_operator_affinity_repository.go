@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type CustomerOperatorAffinityRepositoryImpl struct {
+	q *Queries
+}
+
+func NewCustomerOperatorAffinityRepository(q *Queries) *CustomerOperatorAffinityRepositoryImpl {
+	return &CustomerOperatorAffinityRepositoryImpl{q: q}
+}
+
+func (r *CustomerOperatorAffinityRepositoryImpl) Record(ctx context.Context, affinity *domain.CustomerOperatorAffinity) error {
+	err := r.q.UpsertCustomerOperatorAffinity(ctx, UpsertCustomerOperatorAffinityParams{
+		CustomerID: uuidToPgtype(affinity.CustomerID),
+		TenantID:   uuidToPgtype(affinity.TenantID),
+		OperatorID: uuidToPgtype(affinity.OperatorID),
+		HandledAt:  timeToPgtype(affinity.HandledAt),
+	})
+	return mapError(err)
+}
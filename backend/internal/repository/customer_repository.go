@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+const (
+	defaultCustomerSearchLimit = 50
+	maxCustomerSearchLimit     = 100
+)
+
+// likeEscaper escapes the LIKE wildcards of a search query, so they match
+// literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type CustomerRepositoryImpl struct {
+	q *Queries
+}
+
+func NewCustomerRepository(q *Queries) *CustomerRepositoryImpl {
+	return &CustomerRepositoryImpl{q: q}
+}
+
+func (r *CustomerRepositoryImpl) GetOrCreate(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (*domain.Customer, error) {
+	customer := domain.NewCustomer(tenantID, phoneNumber)
+	row, err := r.q.UpsertCustomer(ctx, UpsertCustomerParams{
+		ID:          uuidToPgtype(customer.ID),
+		TenantID:    uuidToPgtype(customer.TenantID),
+		PhoneNumber: customer.PhoneNumber,
+		CreatedAt:   timeToPgtype(customer.CreatedAt),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *CustomerRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
+	row, err := r.q.GetCustomerByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *CustomerRepositoryImpl) GetByPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (*domain.Customer, error) {
+	row, err := r.q.GetCustomerByPhone(ctx, GetCustomerByPhoneParams{
+		TenantID:    uuidToPgtype(tenantID),
+		PhoneNumber: phoneNumber,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *CustomerRepositoryImpl) Update(ctx context.Context, customer *domain.Customer) error {
+	metadata, err := marshalCustomerMetadata(customer.Metadata)
+	if err != nil {
+		return err
+	}
+	return mapError(r.q.UpdateCustomer(ctx, UpdateCustomerParams{
+		ID:        uuidToPgtype(customer.ID),
+		Name:      stringPtrToPgtype(customer.Name),
+		Metadata:  metadata,
+		UpdatedAt: timeToPgtype(customer.UpdatedAt),
+	}))
+}
+
+func (r *CustomerRepositoryImpl) Search(ctx context.Context, filter domain.CustomerFilter) ([]*domain.Customer, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCustomerSearchLimit
+	}
+	if limit > maxCustomerSearchLimit {
+		limit = maxCustomerSearchLimit
+	}
+
+	params := SearchCustomersParams{
+		TenantID: uuidToPgtype(filter.TenantID),
+		Column2:  likeEscaper.Replace(filter.Query),
+		Limit:    int32(limit),
+	}
+	if filter.CursorTimestamp != nil && filter.CursorID != nil {
+		params.Column3 = timeToPgtype(*filter.CursorTimestamp)
+		params.Column4 = uuidToPgtype(*filter.CursorID)
+	}
+
+	rows, err := r.q.SearchCustomers(ctx, params)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	customers := make([]*domain.Customer, 0, len(rows))
+	for _, row := range rows {
+		customer, err := r.toDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, customer)
+	}
+	return customers, nil
+}
+
+// DeleteByTenantID deletes every customer profile of the tenant
+func (r *CustomerRepositoryImpl) DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) error {
+	return r.q.DeleteCustomersByTenantID(ctx, uuidToPgtype(tenantID))
+}
+
+func (r *CustomerRepositoryImpl) toDomain(row Customer) (*domain.Customer, error) {
+	metadata := map[string]interface{}{}
+	if len(row.Metadata) > 0 {
+		if err := json.Unmarshal(row.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.Customer{
+		ID:          pgtypeToUUID(row.ID),
+		TenantID:    pgtypeToUUID(row.TenantID),
+		PhoneNumber: row.PhoneNumber,
+		Name:        pgtypeToStringPtr(row.Name),
+		Metadata:    metadata,
+		CreatedAt:   pgtypeToTime(row.CreatedAt),
+		UpdatedAt:   pgtypeToTime(row.UpdatedAt),
+	}, nil
+}
+
+// marshalCustomerMetadata stores nil metadata as an empty object, as the
+// column is NOT NULL
+func marshalCustomerMetadata(metadata map[string]interface{}) ([]byte, error) {
+	if metadata == nil {
+		return []byte(`{}`), nil
+	}
+	return json.Marshal(metadata)
+}
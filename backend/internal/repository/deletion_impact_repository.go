@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type DeletionImpactRepositoryImpl struct {
+	q *Queries
+}
+
+func NewDeletionImpactRepository(q *Queries) *DeletionImpactRepositoryImpl {
+	return &DeletionImpactRepositoryImpl{q: q}
+}
+
+func (r *DeletionImpactRepositoryImpl) ForInbox(ctx context.Context, inboxID uuid.UUID) (*domain.DeletionImpact, error) {
+	row, err := r.q.GetInboxDeletionImpact(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.DeletionImpact{
+		Resource:          domain.DeletionResourceInbox,
+		ID:                inboxID,
+		Conversations:     row.Conversations,
+		OpenConversations: row.OpenConversations,
+		Subscriptions:     row.Subscriptions,
+		RoutingRules:      row.RoutingRules,
+		GracePeriods:      row.GracePeriods,
+	}, nil
+}
+
+func (r *DeletionImpactRepositoryImpl) ForLabel(ctx context.Context, labelID uuid.UUID) (*domain.DeletionImpact, error) {
+	row, err := r.q.GetLabelDeletionImpact(ctx, uuidToPgtype(labelID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.DeletionImpact{
+		Resource:          domain.DeletionResourceLabel,
+		ID:                labelID,
+		Conversations:     row.Conversations,
+		OpenConversations: row.OpenConversations,
+		RoutingRules:      row.RoutingRules,
+	}, nil
+}
+
+func (r *DeletionImpactRepositoryImpl) ForOperator(ctx context.Context, operatorID uuid.UUID) (*domain.DeletionImpact, error) {
+	row, err := r.q.GetOperatorDeletionImpact(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.DeletionImpact{
+		Resource:          domain.DeletionResourceOperator,
+		ID:                operatorID,
+		Conversations:     row.Conversations,
+		OpenConversations: row.OpenConversations,
+		Subscriptions:     row.Subscriptions,
+		GracePeriods:      row.GracePeriods,
+	}, nil
+}
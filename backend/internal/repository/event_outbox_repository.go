@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OutboxRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOutboxRepository(q *Queries) *OutboxRepositoryImpl {
+	return &OutboxRepositoryImpl{q: q}
+}
+
+// Create stages an entry; an event already in the outbox is left unchanged
+func (r *OutboxRepositoryImpl) Create(ctx context.Context, entry *domain.OutboxEntry) error {
+	data, err := json.Marshal(entry.Event.Data)
+	if err != nil {
+		return err
+	}
+	return r.q.CreateOutboxEntry(ctx, CreateOutboxEntryParams{
+		ID:            uuidToPgtype(entry.Event.ID),
+		TenantID:      uuidToPgtype(entry.Event.TenantID),
+		EventType:     string(entry.Event.Type),
+		Data:          data,
+		OccurredAt:    timeToPgtype(entry.Event.OccurredAt),
+		NextAttemptAt: timeToPgtype(entry.NextAttemptAt),
+		CreatedAt:     timeToPgtype(entry.CreatedAt),
+	})
+}
+
+// ClaimDue leases up to limit due entries until leaseUntil (FOR UPDATE SKIP LOCKED)
+func (r *OutboxRepositoryImpl) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.OutboxEntry, error) {
+	rows, err := r.q.ClaimDueOutboxEntries(ctx, ClaimDueOutboxEntriesParams{
+		Limit:         int32(limit),
+		NextAttemptAt: timeToPgtype(leaseUntil),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	entries := make([]*domain.OutboxEntry, len(rows))
+	for i, row := range rows {
+		if entries[i], err = r.toDomain(row); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// MarkPublished marks the event's entry published; a no-op for events that
+// were never staged
+func (r *OutboxRepositoryImpl) MarkPublished(ctx context.Context, eventID uuid.UUID, at time.Time) error {
+	return r.q.MarkOutboxEntryPublished(ctx, MarkOutboxEntryPublishedParams{
+		ID:          uuidToPgtype(eventID),
+		PublishedAt: timeToPgtype(at),
+	})
+}
+
+func (r *OutboxRepositoryImpl) UpdateAttempt(ctx context.Context, entry *domain.OutboxEntry) error {
+	return r.q.UpdateOutboxEntryAttempt(ctx, UpdateOutboxEntryAttemptParams{
+		ID:            uuidToPgtype(entry.Event.ID),
+		AttemptCount:  int32(entry.AttemptCount),
+		NextAttemptAt: timeToPgtype(entry.NextAttemptAt),
+		LastError:     stringPtrToPgtype(entry.LastError),
+		PublishedAt:   timePtrToPgtype(entry.PublishedAt),
+	})
+}
+
+func (r *OutboxRepositoryImpl) CountPending(ctx context.Context) (int64, error) {
+	return r.q.CountPendingOutboxEntries(ctx)
+}
+
+// DeletePublishedBefore purges entries published before the given time
+func (r *OutboxRepositoryImpl) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return r.q.DeletePublishedOutboxEntries(ctx, timeToPgtype(before))
+}
+
+func (r *OutboxRepositoryImpl) toDomain(row EventOutbox) (*domain.OutboxEntry, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(row.Data, &data); err != nil {
+		return nil, err
+	}
+	return &domain.OutboxEntry{
+		Event: &domain.Event{
+			ID:         pgtypeToUUID(row.ID),
+			Type:       domain.EventType(row.EventType),
+			TenantID:   pgtypeToUUID(row.TenantID),
+			OccurredAt: pgtypeToTime(row.OccurredAt),
+			Data:       data,
+		},
+		AttemptCount:  int(row.AttemptCount),
+		NextAttemptAt: pgtypeToTime(row.NextAttemptAt),
+		LastError:     pgtypeToStringPtr(row.LastError),
+		PublishedAt:   pgtypeToTimePtr(row.PublishedAt),
+		CreatedAt:     pgtypeToTime(row.CreatedAt),
+	}, nil
+}
@@ -152,6 +152,31 @@ func (q *Queries) GetGracePeriodByConversationID(ctx context.Context, conversati
 	return i, err
 }
 
+const getGracePeriodByID = `-- name: GetGracePeriodByID :one
+SELECT g.id, g.conversation_id, g.operator_id, g.expires_at, g.reason, g.created_at FROM grace_period_assignments g
+JOIN conversation_refs c ON c.id = g.conversation_id
+WHERE g.id = $1 AND c.tenant_id = $2
+`
+
+type GetGracePeriodByIDParams struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+}
+
+func (q *Queries) GetGracePeriodByID(ctx context.Context, arg GetGracePeriodByIDParams) (GracePeriodAssignment, error) {
+	row := q.db.QueryRow(ctx, getGracePeriodByID, arg.ID, arg.TenantID)
+	var i GracePeriodAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.OperatorID,
+		&i.ExpiresAt,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getGracePeriodsByOperatorID = `-- name: GetGracePeriodsByOperatorID :many
 SELECT id, conversation_id, operator_id, expires_at, reason, created_at FROM grace_period_assignments WHERE operator_id = $1
 `
@@ -182,3 +207,93 @@ func (q *Queries) GetGracePeriodsByOperatorID(ctx context.Context, operatorID pg
 	}
 	return items, nil
 }
+
+const getOverdueGracePeriodStats = `-- name: GetOverdueGracePeriodStats :one
+SELECT COUNT(*)::bigint AS overdue_count,
+       MIN(expires_at)::timestamptz AS oldest_expires_at
+FROM grace_period_assignments
+WHERE expires_at <= NOW()
+`
+
+type GetOverdueGracePeriodStatsRow struct {
+	OverdueCount    int64              `json:"overdue_count"`
+	OldestExpiresAt pgtype.Timestamptz `json:"oldest_expires_at"`
+}
+
+// Backlog of the grace period worker, for pipeline health
+func (q *Queries) GetOverdueGracePeriodStats(ctx context.Context) (GetOverdueGracePeriodStatsRow, error) {
+	row := q.db.QueryRow(ctx, getOverdueGracePeriodStats)
+	var i GetOverdueGracePeriodStatsRow
+	err := row.Scan(&i.OverdueCount, &i.OldestExpiresAt)
+	return i, err
+}
+
+const listGracePeriods = `-- name: ListGracePeriods :many
+SELECT g.id, g.conversation_id, g.operator_id, g.expires_at, g.reason, g.created_at FROM grace_period_assignments g
+JOIN conversation_refs c ON c.id = g.conversation_id
+WHERE c.tenant_id = $1
+  AND ($2::uuid IS NULL OR g.operator_id = $2::uuid)
+  AND ($3::uuid IS NULL OR g.conversation_id = $3::uuid)
+ORDER BY g.expires_at, g.id
+LIMIT $4
+`
+
+type ListGracePeriodsParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Column2  pgtype.UUID `json:"column_2"`
+	Column3  pgtype.UUID `json:"column_3"`
+	Limit    int32       `json:"limit"`
+}
+
+// Grace periods of the tenant, optionally of one operator ($2) or
+// conversation ($3), soonest to expire first
+func (q *Queries) ListGracePeriods(ctx context.Context, arg ListGracePeriodsParams) ([]GracePeriodAssignment, error) {
+	rows, err := q.db.Query(ctx, listGracePeriods,
+		arg.TenantID,
+		arg.Column2,
+		arg.Column3,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GracePeriodAssignment{}
+	for rows.Next() {
+		var i GracePeriodAssignment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.OperatorID,
+			&i.ExpiresAt,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setGracePeriodExpiry = `-- name: SetGracePeriodExpiry :execrows
+UPDATE grace_period_assignments SET expires_at = $2
+WHERE id = $1 AND expires_at > NOW()
+`
+
+type SetGracePeriodExpiryParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Moves the expiry of a grace period the worker has not picked up yet
+func (q *Queries) SetGracePeriodExpiry(ctx context.Context, arg SetGracePeriodExpiryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setGracePeriodExpiry, arg.ID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -2,12 +2,18 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	defaultGracePeriodListLimit = 100
+	maxGracePeriodListLimit     = 500
+)
+
 type GracePeriodRepositoryImpl struct {
 	q    *Queries
 	pool *pgxpool.Pool
@@ -88,6 +94,73 @@ func (r *GracePeriodRepositoryImpl) GetAndLockExpired(ctx context.Context, limit
 	return assignments, nil
 }
 
+func (r *GracePeriodRepositoryImpl) GetBacklog(ctx context.Context) (*domain.GracePeriodBacklog, error) {
+	row, err := r.q.GetOverdueGracePeriodStats(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.GracePeriodBacklog{
+		Overdue:         row.OverdueCount,
+		OldestExpiresAt: pgtypeToTimePtr(row.OldestExpiresAt),
+	}, nil
+}
+
+// GetByID returns the grace period if its conversation belongs to the tenant
+func (r *GracePeriodRepositoryImpl) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.GracePeriodAssignment, error) {
+	row, err := r.q.GetGracePeriodByID(ctx, GetGracePeriodByIDParams{
+		ID:       uuidToPgtype(id),
+		TenantID: uuidToPgtype(tenantID),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// List returns the tenant's grace periods matching the filter, soonest to
+// expire first
+func (r *GracePeriodRepositoryImpl) List(ctx context.Context, filter domain.GracePeriodFilter) ([]*domain.GracePeriodAssignment, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultGracePeriodListLimit
+	}
+	if limit > maxGracePeriodListLimit {
+		limit = maxGracePeriodListLimit
+	}
+
+	rows, err := r.q.ListGracePeriods(ctx, ListGracePeriodsParams{
+		TenantID: uuidToPgtype(filter.TenantID),
+		Column2:  uuidPtrToPgtype(filter.OperatorID),
+		Column3:  uuidPtrToPgtype(filter.ConversationID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	assignments := make([]*domain.GracePeriodAssignment, len(rows))
+	for i, row := range rows {
+		assignments[i] = r.toDomain(row)
+	}
+	return assignments, nil
+}
+
+// SetExpiry moves the expiry of a grace period that has not expired yet.
+// Once expired the worker may be processing it, so ErrNotFound is returned.
+func (r *GracePeriodRepositoryImpl) SetExpiry(ctx context.Context, id uuid.UUID, expiresAt time.Time) error {
+	rows, err := r.q.SetGracePeriodExpiry(ctx, SetGracePeriodExpiryParams{
+		ID:        uuidToPgtype(id),
+		ExpiresAt: timeToPgtype(expiresAt),
+	})
+	if err != nil {
+		return mapError(err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 func (r *GracePeriodRepositoryImpl) toDomain(row GracePeriodAssignment) *domain.GracePeriodAssignment {
 	return &domain.GracePeriodAssignment{
 		ID:             pgtypeToUUID(row.ID),
@@ -11,6 +11,98 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :execrows
+INSERT INTO idempotency_keys (
+    id, key, tenant_id, endpoint, method, request_hash,
+    response_status, status, locked_until, created_at, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, 0, 'PENDING', $7, $8, $9)
+ON CONFLICT (tenant_id, key) DO UPDATE
+SET id = EXCLUDED.id,
+    endpoint = EXCLUDED.endpoint,
+    method = EXCLUDED.method,
+    request_hash = EXCLUDED.request_hash,
+    response_status = 0,
+    response_body = NULL,
+    response_ciphertext = NULL,
+    encryption_key_id = NULL,
+    status = 'PENDING',
+    locked_until = EXCLUDED.locked_until,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < NOW()
+   OR (idempotency_keys.status = 'PENDING' AND idempotency_keys.locked_until < NOW())
+`
+
+type ClaimIdempotencyKeyParams struct {
+	ID          pgtype.UUID        `json:"id"`
+	Key         string             `json:"key"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	Endpoint    string             `json:"endpoint"`
+	Method      string             `json:"method"`
+	RequestHash pgtype.Text        `json:"request_hash"`
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Inserts the PENDING claim of a key, or takes over an expired key or a
+// claim past its locked_until; no row is affected while the key is held
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, claimIdempotencyKey,
+		arg.ID,
+		arg.Key,
+		arg.TenantID,
+		arg.Endpoint,
+		arg.Method,
+		arg.RequestHash,
+		arg.LockedUntil,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET status = 'COMPLETED',
+    locked_until = NULL,
+    response_status = $2,
+    response_body = $3,
+    response_ciphertext = $4,
+    encryption_key_id = $5,
+    expires_at = $6
+WHERE id = $1 AND status = 'PENDING'
+`
+
+type CompleteIdempotencyKeyParams struct {
+	ID                 pgtype.UUID        `json:"id"`
+	ResponseStatus     int32              `json:"response_status"`
+	ResponseBody       []byte             `json:"response_body"`
+	ResponseCiphertext []byte             `json:"response_ciphertext"`
+	EncryptionKeyID    pgtype.Text        `json:"encryption_key_id"`
+	ExpiresAt          pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Stores the response of a PENDING claim; no row is affected once the claim
+// was taken over
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, completeIdempotencyKey,
+		arg.ID,
+		arg.ResponseStatus,
+		arg.ResponseBody,
+		arg.ResponseCiphertext,
+		arg.EncryptionKeyID,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countIdempotencyKeys = `-- name: CountIdempotencyKeys :one
 SELECT COUNT(*) FROM idempotency_keys WHERE tenant_id = $1
 `
@@ -25,21 +117,24 @@ func (q *Queries) CountIdempotencyKeys(ctx context.Context, tenantID pgtype.UUID
 const createIdempotencyKey = `-- name: CreateIdempotencyKey :exec
 INSERT INTO idempotency_keys (
     id, key, tenant_id, endpoint, method, request_hash,
-    response_status, response_body, created_at, expires_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    response_status, response_body, response_ciphertext, encryption_key_id,
+    created_at, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 `
 
 type CreateIdempotencyKeyParams struct {
-	ID             pgtype.UUID        `json:"id"`
-	Key            string             `json:"key"`
-	TenantID       pgtype.UUID        `json:"tenant_id"`
-	Endpoint       string             `json:"endpoint"`
-	Method         string             `json:"method"`
-	RequestHash    pgtype.Text        `json:"request_hash"`
-	ResponseStatus int32              `json:"response_status"`
-	ResponseBody   []byte             `json:"response_body"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	ID                 pgtype.UUID        `json:"id"`
+	Key                string             `json:"key"`
+	TenantID           pgtype.UUID        `json:"tenant_id"`
+	Endpoint           string             `json:"endpoint"`
+	Method             string             `json:"method"`
+	RequestHash        pgtype.Text        `json:"request_hash"`
+	ResponseStatus     int32              `json:"response_status"`
+	ResponseBody       []byte             `json:"response_body"`
+	ResponseCiphertext []byte             `json:"response_ciphertext"`
+	EncryptionKeyID    pgtype.Text        `json:"encryption_key_id"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt          pgtype.Timestamptz `json:"expires_at"`
 }
 
 func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error {
@@ -52,6 +147,8 @@ func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotenc
 		arg.RequestHash,
 		arg.ResponseStatus,
 		arg.ResponseBody,
+		arg.ResponseCiphertext,
+		arg.EncryptionKeyID,
 		arg.CreatedAt,
 		arg.ExpiresAt,
 	)
@@ -81,7 +178,7 @@ func (q *Queries) DeleteIdempotencyKey(ctx context.Context, id pgtype.UUID) erro
 }
 
 const getExpiredIdempotencyKeysForCleanup = `-- name: GetExpiredIdempotencyKeysForCleanup :many
-SELECT id, key, tenant_id, endpoint, method, request_hash, response_status, response_body, created_at, expires_at FROM idempotency_keys
+SELECT id, key, tenant_id, endpoint, method, request_hash, response_status, response_body, created_at, expires_at, response_ciphertext, encryption_key_id, status, locked_until FROM idempotency_keys
 WHERE expires_at < NOW()
 ORDER BY expires_at ASC
 LIMIT $1
@@ -108,6 +205,10 @@ func (q *Queries) GetExpiredIdempotencyKeysForCleanup(ctx context.Context, limit
 			&i.ResponseBody,
 			&i.CreatedAt,
 			&i.ExpiresAt,
+			&i.ResponseCiphertext,
+			&i.EncryptionKeyID,
+			&i.Status,
+			&i.LockedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -120,7 +221,7 @@ func (q *Queries) GetExpiredIdempotencyKeysForCleanup(ctx context.Context, limit
 }
 
 const getIdempotencyKey = `-- name: GetIdempotencyKey :one
-SELECT id, key, tenant_id, endpoint, method, request_hash, response_status, response_body, created_at, expires_at FROM idempotency_keys
+SELECT id, key, tenant_id, endpoint, method, request_hash, response_status, response_body, created_at, expires_at, response_ciphertext, encryption_key_id, status, locked_until FROM idempotency_keys
 WHERE tenant_id = $1 AND key = $2
 `
 
@@ -143,6 +244,95 @@ func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyPa
 		&i.ResponseBody,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.ResponseCiphertext,
+		&i.EncryptionKeyID,
+		&i.Status,
+		&i.LockedUntil,
 	)
 	return i, err
 }
+
+const getIdempotencyKeysToReencrypt = `-- name: GetIdempotencyKeysToReencrypt :many
+SELECT id, key, tenant_id, endpoint, method, request_hash, response_status, response_body, created_at, expires_at, response_ciphertext, encryption_key_id, status, locked_until FROM idempotency_keys
+WHERE expires_at >= NOW()
+  AND status = 'COMPLETED'
+  AND encryption_key_id IS DISTINCT FROM $1
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type GetIdempotencyKeysToReencryptParams struct {
+	EncryptionKeyID pgtype.Text `json:"encryption_key_id"`
+	ID              pgtype.UUID `json:"id"`
+	Limit           int32       `json:"limit"`
+}
+
+// Unexpired completed keys whose response is not stored under the given key
+// (NULL: stored in plaintext), after the cursor in id order
+func (q *Queries) GetIdempotencyKeysToReencrypt(ctx context.Context, arg GetIdempotencyKeysToReencryptParams) ([]IdempotencyKey, error) {
+	rows, err := q.db.Query(ctx, getIdempotencyKeysToReencrypt, arg.EncryptionKeyID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []IdempotencyKey{}
+	for rows.Next() {
+		var i IdempotencyKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.TenantID,
+			&i.Endpoint,
+			&i.Method,
+			&i.RequestHash,
+			&i.ResponseStatus,
+			&i.ResponseBody,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.ResponseCiphertext,
+			&i.EncryptionKeyID,
+			&i.Status,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE id = $1 AND status = 'PENDING'
+`
+
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, releaseIdempotencyKey, id)
+	return err
+}
+
+const updateIdempotencyKeyResponse = `-- name: UpdateIdempotencyKeyResponse :exec
+UPDATE idempotency_keys
+SET response_body = $2, response_ciphertext = $3, encryption_key_id = $4
+WHERE id = $1
+`
+
+type UpdateIdempotencyKeyResponseParams struct {
+	ID                 pgtype.UUID `json:"id"`
+	ResponseBody       []byte      `json:"response_body"`
+	ResponseCiphertext []byte      `json:"response_ciphertext"`
+	EncryptionKeyID    pgtype.Text `json:"encryption_key_id"`
+}
+
+func (q *Queries) UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) error {
+	_, err := q.db.Exec(ctx, updateIdempotencyKeyResponse,
+		arg.ID,
+		arg.ResponseBody,
+		arg.ResponseCiphertext,
+		arg.EncryptionKeyID,
+	)
+	return err
+}
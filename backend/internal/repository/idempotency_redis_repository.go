@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// IdempotencyRedis is the part of the Redis client the idempotency store uses
+type IdempotencyRedis interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Eval(ctx context.Context, script string, keys []string, args ...string) (interface{}, error)
+}
+
+// RedisIdempotencyRepositoryImpl keeps idempotency keys in Redis, which
+// expires them natively: a PENDING claim when its lock times out, so the
+// next claim takes it over, and a completed key after its TTL. Nothing is
+// left to clean up, and responses are not re-encrypted after a key rotation;
+// they expire within the TTL instead.
+type RedisIdempotencyRepositoryImpl struct {
+	redis IdempotencyRedis
+}
+
+func NewRedisIdempotencyRepository(redis IdempotencyRedis) *RedisIdempotencyRepositoryImpl {
+	return &RedisIdempotencyRepositoryImpl{redis: redis}
+}
+
+// redisReplaceIdempotencyKey stores ARGV[3] in KEYS[1] for ARGV[4] ms if it
+// still holds the record ARGV[1] with status ARGV[2]
+const redisReplaceIdempotencyKey = `
+local held = redis.call('GET', KEYS[1])
+if not held then return 0 end
+local ik = cjson.decode(held)
+if ik.id ~= ARGV[1] or ik.status ~= ARGV[2] then return 0 end
+redis.call('SET', KEYS[1], ARGV[3], 'PX', ARGV[4])
+return 1`
+
+// redisReleaseIdempotencyKey deletes KEYS[1] if it still holds the PENDING
+// record ARGV[1]
+const redisReleaseIdempotencyKey = `
+local held = redis.call('GET', KEYS[1])
+if not held then return 0 end
+local ik = cjson.decode(held)
+if ik.id ~= ARGV[1] or ik.status ~= 'PENDING' then return 0 end
+return redis.call('DEL', KEYS[1])`
+
+// redisIdempotencyRecord is the stored form of a key; the tenant and key
+// are in the Redis key
+type redisIdempotencyRecord struct {
+	ID                 uuid.UUID                `json:"id"`
+	Endpoint           string                   `json:"endpoint"`
+	Method             string                   `json:"method"`
+	RequestHash        *string                  `json:"request_hash,omitempty"`
+	ResponseStatus     int                      `json:"response_status"`
+	ResponseBody       []byte                   `json:"response_body,omitempty"`
+	ResponseCiphertext []byte                   `json:"response_ciphertext,omitempty"`
+	EncryptionKeyID    *string                  `json:"encryption_key_id,omitempty"`
+	Status             domain.IdempotencyStatus `json:"status"`
+	LockedUntil        *time.Time               `json:"locked_until,omitempty"`
+	CreatedAt          time.Time                `json:"created_at"`
+	ExpiresAt          time.Time                `json:"expires_at"`
+}
+
+func redisIdempotencyKey(tenantID uuid.UUID, key string) string {
+	return "idempotency:" + tenantID.String() + ":" + key
+}
+
+// redisTTL is the time to live of a record until t; Redis rejects a zero
+// or negative one
+func redisTTL(t time.Time) time.Duration {
+	if ttl := time.Until(t); ttl >= time.Millisecond {
+		return ttl
+	}
+	return time.Millisecond
+}
+
+func (r *RedisIdempotencyRepositoryImpl) Create(ctx context.Context, ik *domain.IdempotencyKey) error {
+	stored, err := r.setNX(ctx, ik, ik.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return domain.ErrAlreadyExists
+	}
+	return nil
+}
+
+// Claim stores the claim until its lock times out; an expired key or a
+// timed-out claim is already gone
+func (r *RedisIdempotencyRepositoryImpl) Claim(ctx context.Context, ik *domain.IdempotencyKey) (bool, error) {
+	until := ik.ExpiresAt
+	if ik.LockedUntil != nil && ik.LockedUntil.Before(until) {
+		until = *ik.LockedUntil
+	}
+	return r.setNX(ctx, ik, until)
+}
+
+func (r *RedisIdempotencyRepositoryImpl) Complete(ctx context.Context, ik *domain.IdempotencyKey) error {
+	completed := *ik
+	completed.Status, completed.LockedUntil = domain.IdempotencyStatusCompleted, nil
+	replaced, err := r.replace(ctx, &completed, domain.IdempotencyStatusPending)
+	if err != nil {
+		return err
+	}
+	if !replaced {
+		return domain.ErrNotFound
+	}
+	ik.Status, ik.LockedUntil = completed.Status, nil
+	return nil
+}
+
+func (r *RedisIdempotencyRepositoryImpl) GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
+	data, found, err := r.redis.Get(ctx, redisIdempotencyKey(tenantID, key))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrNotFound
+	}
+	var rec redisIdempotencyRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return &domain.IdempotencyKey{
+		ID:                 rec.ID,
+		Key:                key,
+		TenantID:           tenantID,
+		Endpoint:           rec.Endpoint,
+		Method:             rec.Method,
+		RequestHash:        rec.RequestHash,
+		ResponseStatus:     rec.ResponseStatus,
+		ResponseBody:       rec.ResponseBody,
+		ResponseCiphertext: rec.ResponseCiphertext,
+		EncryptionKeyID:    rec.EncryptionKeyID,
+		Status:             rec.Status,
+		LockedUntil:        rec.LockedUntil,
+		CreatedAt:          rec.CreatedAt,
+		ExpiresAt:          rec.ExpiresAt,
+	}, nil
+}
+
+func (r *RedisIdempotencyRepositoryImpl) Release(ctx context.Context, ik *domain.IdempotencyKey) error {
+	_, err := r.redis.Eval(ctx, redisReleaseIdempotencyKey,
+		[]string{redisIdempotencyKey(ik.TenantID, ik.Key)}, ik.ID.String())
+	return err
+}
+
+// DeleteExpired has nothing to do: Redis expires keys itself
+func (r *RedisIdempotencyRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// GetExpiredForCleanup finds nothing: Redis expires keys itself
+func (r *RedisIdempotencyRepositoryImpl) GetExpiredForCleanup(ctx context.Context, limit int) ([]*domain.IdempotencyKey, error) {
+	return nil, nil
+}
+
+// GetToReencrypt finds nothing: responses expire within the TTL instead
+func (r *RedisIdempotencyRepositoryImpl) GetToReencrypt(ctx context.Context, encryptionKeyID *string, after uuid.UUID, limit int) ([]*domain.IdempotencyKey, error) {
+	return nil, nil
+}
+
+func (r *RedisIdempotencyRepositoryImpl) UpdateResponse(ctx context.Context, ik *domain.IdempotencyKey) error {
+	_, err := r.replace(ctx, ik, domain.IdempotencyStatusCompleted)
+	return err
+}
+
+func (r *RedisIdempotencyRepositoryImpl) setNX(ctx context.Context, ik *domain.IdempotencyKey, until time.Time) (bool, error) {
+	data, err := json.Marshal(newRedisIdempotencyRecord(ik))
+	if err != nil {
+		return false, err
+	}
+	return r.redis.SetNX(ctx, redisIdempotencyKey(ik.TenantID, ik.Key), data, redisTTL(until))
+}
+
+// replace stores ik over the record of the same ID if it has status
+func (r *RedisIdempotencyRepositoryImpl) replace(ctx context.Context, ik *domain.IdempotencyKey, status domain.IdempotencyStatus) (bool, error) {
+	data, err := json.Marshal(newRedisIdempotencyRecord(ik))
+	if err != nil {
+		return false, err
+	}
+	ttl := redisTTL(ik.ExpiresAt).Milliseconds()
+	reply, err := r.redis.Eval(ctx, redisReplaceIdempotencyKey,
+		[]string{redisIdempotencyKey(ik.TenantID, ik.Key)},
+		ik.ID.String(), string(status), string(data), strconv.FormatInt(ttl, 10))
+	if err != nil {
+		return false, err
+	}
+	n, _ := reply.(int64)
+	return n == 1, nil
+}
+
+func newRedisIdempotencyRecord(ik *domain.IdempotencyKey) redisIdempotencyRecord {
+	return redisIdempotencyRecord{
+		ID:                 ik.ID,
+		Endpoint:           ik.Endpoint,
+		Method:             ik.Method,
+		RequestHash:        ik.RequestHash,
+		ResponseStatus:     ik.ResponseStatus,
+		ResponseBody:       ik.ResponseBody,
+		ResponseCiphertext: ik.ResponseCiphertext,
+		EncryptionKeyID:    ik.EncryptionKeyID,
+		Status:             ik.Status,
+		LockedUntil:        ik.LockedUntil,
+		CreatedAt:          ik.CreatedAt,
+		ExpiresAt:          ik.ExpiresAt,
+	}
+}
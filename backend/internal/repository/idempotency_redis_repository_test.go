@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIdempotencyRedis runs the store's scripts in Go, ignoring TTLs
+type fakeIdempotencyRedis struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	ttls map[string]time.Duration
+}
+
+func newFakeIdempotencyRedis() *fakeIdempotencyRedis {
+	return &fakeIdempotencyRedis{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeIdempotencyRedis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.data[key]
+	return v, ok, nil
+}
+
+func (f *fakeIdempotencyRedis) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.data[key]; ok {
+		return false, nil
+	}
+	f.data[key], f.ttls[key] = value, ttl
+	return true, nil
+}
+
+func (f *fakeIdempotencyRedis) Eval(ctx context.Context, script string, keys []string, args ...string) (interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var held struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	data, ok := f.data[keys[0]]
+	if !ok {
+		return int64(0), nil
+	}
+	if err := json.Unmarshal(data, &held); err != nil {
+		return nil, err
+	}
+
+	switch script {
+	case redisReplaceIdempotencyKey:
+		if held.ID != args[0] || held.Status != args[1] {
+			return int64(0), nil
+		}
+		f.data[keys[0]] = []byte(args[2])
+		return int64(1), nil
+	case redisReleaseIdempotencyKey:
+		if held.ID != args[0] || held.Status != "PENDING" {
+			return int64(0), nil
+		}
+		delete(f.data, keys[0])
+		return int64(1), nil
+	}
+	return nil, errors.New("unknown script")
+}
+
+func TestRedisIdempotencyRepository_ClaimCompleteRelease(t *testing.T) {
+	ctx := context.Background()
+	redis := newFakeIdempotencyRedis()
+	repo := NewRedisIdempotencyRepository(redis)
+	tenantID := uuid.New()
+	hash := "abc"
+
+	first := domain.NewIdempotencyClaim("key", tenantID, "/api/v1/allocate", "POST", &hash, time.Minute, time.Hour)
+	claimed, err := repo.Claim(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.LessOrEqual(t, redis.ttls["idempotency:"+tenantID.String()+":key"], time.Minute, "a claim lives until its lock times out")
+
+	second := domain.NewIdempotencyClaim("key", tenantID, "/api/v1/allocate", "POST", &hash, time.Minute, time.Hour)
+	claimed, err = repo.Claim(ctx, second)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	held, err := repo.GetByKey(ctx, tenantID, "key")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, held.ID)
+	assert.Equal(t, "key", held.Key)
+	assert.Equal(t, tenantID, held.TenantID)
+	assert.True(t, held.IsPending())
+	require.NotNil(t, held.RequestHash)
+	assert.Equal(t, hash, *held.RequestHash)
+
+	// Only the holder completes the claim, once
+	assert.ErrorIs(t, repo.Complete(ctx, second), domain.ErrNotFound)
+	first.ResponseStatus, first.ResponseBody = 201, []byte(`{"ok":true}`)
+	require.NoError(t, repo.Complete(ctx, first))
+	assert.Equal(t, domain.IdempotencyStatusCompleted, first.Status)
+	assert.ErrorIs(t, repo.Complete(ctx, first), domain.ErrNotFound)
+
+	completed, err := repo.GetByKey(ctx, tenantID, "key")
+	require.NoError(t, err)
+	assert.False(t, completed.IsPending())
+	assert.Nil(t, completed.LockedUntil)
+	assert.Equal(t, 201, completed.ResponseStatus)
+	assert.Equal(t, []byte(`{"ok":true}`), completed.ResponseBody)
+
+	// A completed key is not released
+	require.NoError(t, repo.Release(ctx, first))
+	_, err = repo.GetByKey(ctx, tenantID, "key")
+	require.NoError(t, err)
+
+	other := domain.NewIdempotencyClaim("other", tenantID, "/api/v1/allocate", "POST", nil, time.Minute, time.Hour)
+	claimed, err = repo.Claim(ctx, other)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	require.NoError(t, repo.Release(ctx, other))
+	_, err = repo.GetByKey(ctx, tenantID, "other")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRedisIdempotencyRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRedisIdempotencyRepository(newFakeIdempotencyRedis())
+	tenantID := uuid.New()
+
+	ik := domain.NewIdempotencyKey("key", tenantID, "/api", "POST", nil, 200, []byte(`{}`), time.Hour)
+	require.NoError(t, repo.Create(ctx, ik))
+	assert.ErrorIs(t, repo.Create(ctx, ik), domain.ErrAlreadyExists)
+
+	count, err := repo.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count, "Redis expires keys itself")
+}
@@ -16,18 +16,57 @@ func NewIdempotencyRepository(q *Queries) *IdempotencyRepositoryImpl {
 }
 
 func (r *IdempotencyRepositoryImpl) Create(ctx context.Context, ik *domain.IdempotencyKey) error {
-	return r.q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
-		ID:             uuidToPgtype(ik.ID),
-		Key:            ik.Key,
-		TenantID:       uuidToPgtype(ik.TenantID),
-		Endpoint:       ik.Endpoint,
-		Method:         ik.Method,
-		RequestHash:    stringPtrToPgtype(ik.RequestHash),
-		ResponseStatus: int32(ik.ResponseStatus),
-		ResponseBody:   ik.ResponseBody,
-		CreatedAt:      timeToPgtype(ik.CreatedAt),
-		ExpiresAt:      timeToPgtype(ik.ExpiresAt),
+	err := r.q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		ID:                 uuidToPgtype(ik.ID),
+		Key:                ik.Key,
+		TenantID:           uuidToPgtype(ik.TenantID),
+		Endpoint:           ik.Endpoint,
+		Method:             ik.Method,
+		RequestHash:        stringPtrToPgtype(ik.RequestHash),
+		ResponseStatus:     int32(ik.ResponseStatus),
+		ResponseBody:       ik.ResponseBody,
+		ResponseCiphertext: ik.ResponseCiphertext,
+		EncryptionKeyID:    stringPtrToPgtype(ik.EncryptionKeyID),
+		CreatedAt:          timeToPgtype(ik.CreatedAt),
+		ExpiresAt:          timeToPgtype(ik.ExpiresAt),
 	})
+	return mapError(err)
+}
+
+func (r *IdempotencyRepositoryImpl) Claim(ctx context.Context, ik *domain.IdempotencyKey) (bool, error) {
+	n, err := r.q.ClaimIdempotencyKey(ctx, ClaimIdempotencyKeyParams{
+		ID:          uuidToPgtype(ik.ID),
+		Key:         ik.Key,
+		TenantID:    uuidToPgtype(ik.TenantID),
+		Endpoint:    ik.Endpoint,
+		Method:      ik.Method,
+		RequestHash: stringPtrToPgtype(ik.RequestHash),
+		LockedUntil: timePtrToPgtype(ik.LockedUntil),
+		CreatedAt:   timeToPgtype(ik.CreatedAt),
+		ExpiresAt:   timeToPgtype(ik.ExpiresAt),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return n > 0, nil
+}
+
+func (r *IdempotencyRepositoryImpl) Complete(ctx context.Context, ik *domain.IdempotencyKey) error {
+	n, err := r.q.CompleteIdempotencyKey(ctx, CompleteIdempotencyKeyParams{
+		ID:                 uuidToPgtype(ik.ID),
+		ResponseStatus:     int32(ik.ResponseStatus),
+		ResponseBody:       ik.ResponseBody,
+		ResponseCiphertext: ik.ResponseCiphertext,
+		EncryptionKeyID:    stringPtrToPgtype(ik.EncryptionKeyID),
+		ExpiresAt:          timeToPgtype(ik.ExpiresAt),
+	})
+	if err != nil {
+		return mapError(err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
 }
 
 func (r *IdempotencyRepositoryImpl) GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
@@ -45,6 +84,10 @@ func (r *IdempotencyRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) er
 	return r.q.DeleteIdempotencyKey(ctx, uuidToPgtype(id))
 }
 
+func (r *IdempotencyRepositoryImpl) Release(ctx context.Context, ik *domain.IdempotencyKey) error {
+	return r.q.ReleaseIdempotencyKey(ctx, uuidToPgtype(ik.ID))
+}
+
 func (r *IdempotencyRepositoryImpl) DeleteExpired(ctx context.Context) (int64, error) {
 	return r.q.DeleteExpiredIdempotencyKeys(ctx)
 }
@@ -62,17 +105,47 @@ func (r *IdempotencyRepositoryImpl) GetExpiredForCleanup(ctx context.Context, li
 	return keys, nil
 }
 
+func (r *IdempotencyRepositoryImpl) GetToReencrypt(ctx context.Context, encryptionKeyID *string, after uuid.UUID, limit int) ([]*domain.IdempotencyKey, error) {
+	rows, err := r.q.GetIdempotencyKeysToReencrypt(ctx, GetIdempotencyKeysToReencryptParams{
+		EncryptionKeyID: stringPtrToPgtype(encryptionKeyID),
+		ID:              uuidToPgtype(after),
+		Limit:           int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	keys := make([]*domain.IdempotencyKey, len(rows))
+	for i, row := range rows {
+		keys[i] = r.toDomain(row)
+	}
+	return keys, nil
+}
+
+func (r *IdempotencyRepositoryImpl) UpdateResponse(ctx context.Context, ik *domain.IdempotencyKey) error {
+	return r.q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+		ID:                 uuidToPgtype(ik.ID),
+		ResponseBody:       ik.ResponseBody,
+		ResponseCiphertext: ik.ResponseCiphertext,
+		EncryptionKeyID:    stringPtrToPgtype(ik.EncryptionKeyID),
+	})
+}
+
 func (r *IdempotencyRepositoryImpl) toDomain(row IdempotencyKey) *domain.IdempotencyKey {
 	return &domain.IdempotencyKey{
-		ID:             pgtypeToUUID(row.ID),
-		Key:            row.Key,
-		TenantID:       pgtypeToUUID(row.TenantID),
-		Endpoint:       row.Endpoint,
-		Method:         row.Method,
-		RequestHash:    pgtypeToStringPtr(row.RequestHash),
-		ResponseStatus: int(row.ResponseStatus),
-		ResponseBody:   row.ResponseBody,
-		CreatedAt:      pgtypeToTime(row.CreatedAt),
-		ExpiresAt:      pgtypeToTime(row.ExpiresAt),
+		ID:                 pgtypeToUUID(row.ID),
+		Key:                row.Key,
+		TenantID:           pgtypeToUUID(row.TenantID),
+		Endpoint:           row.Endpoint,
+		Method:             row.Method,
+		RequestHash:        pgtypeToStringPtr(row.RequestHash),
+		ResponseStatus:     int(row.ResponseStatus),
+		ResponseBody:       row.ResponseBody,
+		ResponseCiphertext: row.ResponseCiphertext,
+		EncryptionKeyID:    pgtypeToStringPtr(row.EncryptionKeyID),
+		Status:             domain.IdempotencyStatus(row.Status),
+		LockedUntil:        pgtypeToTimePtr(row.LockedUntil),
+		CreatedAt:          pgtypeToTime(row.CreatedAt),
+		ExpiresAt:          pgtypeToTime(row.ExpiresAt),
 	}
 }
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type InboxAdminRepositoryImpl struct {
+	q *Queries
+}
+
+func NewInboxAdminRepository(q *Queries) *InboxAdminRepositoryImpl {
+	return &InboxAdminRepositoryImpl{q: q}
+}
+
+func (r *InboxAdminRepositoryImpl) Create(ctx context.Context, grant *domain.InboxAdmin) error {
+	err := r.q.CreateInboxAdmin(ctx, CreateInboxAdminParams{
+		OperatorID: uuidToPgtype(grant.OperatorID),
+		InboxID:    uuidToPgtype(grant.InboxID),
+		TenantID:   uuidToPgtype(grant.TenantID),
+		GrantedBy:  uuidPtrToPgtype(grant.GrantedBy),
+		CreatedAt:  timeToPgtype(grant.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *InboxAdminRepositoryImpl) Exists(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error) {
+	exists, err := r.q.CheckInboxAdminExists(ctx, CheckInboxAdminExistsParams{
+		OperatorID: uuidToPgtype(operatorID),
+		InboxID:    uuidToPgtype(inboxID),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return exists, nil
+}
+
+func (r *InboxAdminRepositoryImpl) GetByInboxID(ctx context.Context, inboxID uuid.UUID) ([]*domain.InboxAdmin, error) {
+	rows, err := r.q.GetInboxAdminsByInboxID(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *InboxAdminRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*domain.InboxAdmin, error) {
+	rows, err := r.q.GetInboxAdminsByOperatorID(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *InboxAdminRepositoryImpl) Delete(ctx context.Context, operatorID, inboxID uuid.UUID) error {
+	return r.q.DeleteInboxAdmin(ctx, DeleteInboxAdminParams{
+		OperatorID: uuidToPgtype(operatorID),
+		InboxID:    uuidToPgtype(inboxID),
+	})
+}
+
+func (r *InboxAdminRepositoryImpl) toDomainSlice(rows []InboxAdmin) []*domain.InboxAdmin {
+	grants := make([]*domain.InboxAdmin, len(rows))
+	for i, row := range rows {
+		grants[i] = &domain.InboxAdmin{
+			OperatorID: pgtypeToUUID(row.OperatorID),
+			InboxID:    pgtypeToUUID(row.InboxID),
+			TenantID:   pgtypeToUUID(row.TenantID),
+			GrantedBy:  pgtypeToUUIDPtr(row.GrantedBy),
+			CreatedAt:  pgtypeToTime(row.CreatedAt),
+		}
+	}
+	return grants
+}
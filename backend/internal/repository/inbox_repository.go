@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
@@ -16,13 +18,19 @@ func NewInboxRepository(q *Queries) *InboxRepositoryImpl {
 }
 
 func (r *InboxRepositoryImpl) Create(ctx context.Context, inbox *domain.Inbox) error {
+	businessHours, err := marshalBusinessHours(inbox.BusinessHours)
+	if err != nil {
+		return err
+	}
 	return r.q.CreateInbox(ctx, CreateInboxParams{
-		ID:          uuidToPgtype(inbox.ID),
-		TenantID:    uuidToPgtype(inbox.TenantID),
-		PhoneNumber: inbox.PhoneNumber,
-		DisplayName: inbox.DisplayName,
-		CreatedAt:   timeToPgtype(inbox.CreatedAt),
-		UpdatedAt:   timeToPgtype(inbox.UpdatedAt),
+		ID:            uuidToPgtype(inbox.ID),
+		TenantID:      uuidToPgtype(inbox.TenantID),
+		PhoneNumber:   inbox.PhoneNumber,
+		DisplayName:   inbox.DisplayName,
+		IsRestricted:  inbox.IsRestricted,
+		CreatedAt:     timeToPgtype(inbox.CreatedAt),
+		UpdatedAt:     timeToPgtype(inbox.UpdatedAt),
+		BusinessHours: businessHours,
 	})
 }
 
@@ -31,7 +39,7 @@ func (r *InboxRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domai
 	if err != nil {
 		return nil, mapError(err)
 	}
-	return r.toDomain(row), nil
+	return r.toDomain(row)
 }
 
 func (r *InboxRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.Inbox, error) {
@@ -42,7 +50,11 @@ func (r *InboxRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.U
 
 	inboxes := make([]*domain.Inbox, len(rows))
 	for i, row := range rows {
-		inboxes[i] = r.toDomain(row)
+		inbox, err := r.toDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		inboxes[i] = inbox
 	}
 	return inboxes, nil
 }
@@ -55,15 +67,23 @@ func (r *InboxRepositoryImpl) GetByPhoneNumber(ctx context.Context, tenantID uui
 	if err != nil {
 		return nil, mapError(err)
 	}
-	return r.toDomain(row), nil
+	return r.toDomain(row)
 }
 
 func (r *InboxRepositoryImpl) Update(ctx context.Context, inbox *domain.Inbox) error {
+	businessHours, err := marshalBusinessHours(inbox.BusinessHours)
+	if err != nil {
+		return err
+	}
 	return r.q.UpdateInbox(ctx, UpdateInboxParams{
-		ID:          uuidToPgtype(inbox.ID),
-		PhoneNumber: inbox.PhoneNumber,
-		DisplayName: inbox.DisplayName,
-		UpdatedAt:   timeToPgtype(inbox.UpdatedAt),
+		ID:                      uuidToPgtype(inbox.ID),
+		PhoneNumber:             inbox.PhoneNumber,
+		DisplayName:             inbox.DisplayName,
+		IsRestricted:            inbox.IsRestricted,
+		UpdatedAt:               timeToPgtype(inbox.UpdatedAt),
+		EscalationInboxID:       uuidPtrToPgtype(inbox.EscalationInboxID),
+		AutoResolveAfterSeconds: durationPtrToSeconds(inbox.AutoResolveAfter),
+		BusinessHours:           businessHours,
 	})
 }
 
@@ -71,13 +91,79 @@ func (r *InboxRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.q.DeleteInbox(ctx, uuidToPgtype(id))
 }
 
-func (r *InboxRepositoryImpl) toDomain(row Inbox) *domain.Inbox {
+// DeleteByTenantID deletes every inbox of the tenant; their conversations,
+// labels and subscriptions go with them
+func (r *InboxRepositoryImpl) DeleteByTenantID(ctx context.Context, tenantID uuid.UUID) error {
+	return r.q.DeleteInboxesByTenantID(ctx, uuidToPgtype(tenantID))
+}
+
+func (r *InboxRepositoryImpl) toDomain(row Inbox) (*domain.Inbox, error) {
+	businessHours, err := unmarshalBusinessHours(row.BusinessHours)
+	if err != nil {
+		return nil, err
+	}
 	return &domain.Inbox{
-		ID:          pgtypeToUUID(row.ID),
-		TenantID:    pgtypeToUUID(row.TenantID),
-		PhoneNumber: row.PhoneNumber,
-		DisplayName: row.DisplayName,
-		CreatedAt:   pgtypeToTime(row.CreatedAt),
-		UpdatedAt:   pgtypeToTime(row.UpdatedAt),
+		ID:                pgtypeToUUID(row.ID),
+		TenantID:          pgtypeToUUID(row.TenantID),
+		PhoneNumber:       row.PhoneNumber,
+		DisplayName:       row.DisplayName,
+		IsRestricted:      row.IsRestricted,
+		EscalationInboxID: pgtypeToUUIDPtr(row.EscalationInboxID),
+		AutoResolveAfter:  secondsToDurationPtr(row.AutoResolveAfterSeconds),
+		BusinessHours:     businessHours,
+		CreatedAt:         pgtypeToTime(row.CreatedAt),
+		UpdatedAt:         pgtypeToTime(row.UpdatedAt),
+	}, nil
+}
+
+// storedBusinessHours is the JSON of inboxes.business_hours
+type storedBusinessHours struct {
+	Timezone string               `json:"timezone"`
+	Days     []storedOpeningHours `json:"days"`
+}
+
+type storedOpeningHours struct {
+	DayOfWeek    int   `json:"day_of_week"`
+	OpenSeconds  int64 `json:"open_seconds"`
+	CloseSeconds int64 `json:"close_seconds"`
+}
+
+// marshalBusinessHours stores nil business hours as NULL, with the days in
+// weekday order
+func marshalBusinessHours(hours *domain.BusinessHours) ([]byte, error) {
+	if hours == nil {
+		return nil, nil
+	}
+	stored := storedBusinessHours{Timezone: hours.Timezone, Days: []storedOpeningHours{}}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if opening, ok := hours.Days[day]; ok {
+			stored.Days = append(stored.Days, storedOpeningHours{
+				DayOfWeek:    int(day),
+				OpenSeconds:  int64(opening.Open / time.Second),
+				CloseSeconds: int64(opening.Close / time.Second),
+			})
+		}
+	}
+	return json.Marshal(stored)
+}
+
+func unmarshalBusinessHours(data []byte) (*domain.BusinessHours, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var stored storedBusinessHours
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	hours := &domain.BusinessHours{
+		Timezone: stored.Timezone,
+		Days:     make(map[time.Weekday]domain.OpeningHours, len(stored.Days)),
+	}
+	for _, d := range stored.Days {
+		hours.Days[time.Weekday(d.DayOfWeek)] = domain.OpeningHours{
+			Open:  time.Duration(d.OpenSeconds) * time.Second,
+			Close: time.Duration(d.CloseSeconds) * time.Second,
+		}
 	}
+	return hours, nil
 }
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type InboxSLAPolicyRepositoryImpl struct {
+	q *Queries
+}
+
+func NewInboxSLAPolicyRepository(q *Queries) *InboxSLAPolicyRepositoryImpl {
+	return &InboxSLAPolicyRepositoryImpl{q: q}
+}
+
+func (r *InboxSLAPolicyRepositoryImpl) Get(ctx context.Context, inboxID uuid.UUID) (*domain.InboxSLAPolicy, error) {
+	row, err := r.q.GetInboxSLAPolicy(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *InboxSLAPolicyRepositoryImpl) Upsert(ctx context.Context, policy *domain.InboxSLAPolicy) error {
+	err := r.q.UpsertInboxSLAPolicy(ctx, UpsertInboxSLAPolicyParams{
+		InboxID:                uuidToPgtype(policy.InboxID),
+		TenantID:               uuidToPgtype(policy.TenantID),
+		FirstAssignmentSeconds: durationPtrToSeconds(policy.FirstAssignment),
+		ResolutionSeconds:      durationPtrToSeconds(policy.Resolution),
+		UpdatedBy:              uuidPtrToPgtype(policy.UpdatedBy),
+		CreatedAt:              timeToPgtype(policy.CreatedAt),
+		UpdatedAt:              timeToPgtype(policy.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *InboxSLAPolicyRepositoryImpl) Delete(ctx context.Context, inboxID uuid.UUID) error {
+	return mapError(r.q.DeleteInboxSLAPolicy(ctx, uuidToPgtype(inboxID)))
+}
+
+func (r *InboxSLAPolicyRepositoryImpl) toDomain(row InboxSlaPolicy) *domain.InboxSLAPolicy {
+	return &domain.InboxSLAPolicy{
+		InboxID:         pgtypeToUUID(row.InboxID),
+		TenantID:        pgtypeToUUID(row.TenantID),
+		FirstAssignment: secondsToDurationPtr(row.FirstAssignmentSeconds),
+		Resolution:      secondsToDurationPtr(row.ResolutionSeconds),
+		UpdatedBy:       pgtypeToUUIDPtr(row.UpdatedBy),
+		CreatedAt:       pgtypeToTime(row.CreatedAt),
+		UpdatedAt:       pgtypeToTime(row.UpdatedAt),
+	}
+}
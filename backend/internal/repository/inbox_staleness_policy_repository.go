@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type InboxStalenessPolicyRepositoryImpl struct {
+	q *Queries
+}
+
+func NewInboxStalenessPolicyRepository(q *Queries) *InboxStalenessPolicyRepositoryImpl {
+	return &InboxStalenessPolicyRepositoryImpl{q: q}
+}
+
+func (r *InboxStalenessPolicyRepositoryImpl) Get(ctx context.Context, inboxID uuid.UUID) (*domain.InboxStalenessPolicy, error) {
+	row, err := r.q.GetInboxStalenessPolicy(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *InboxStalenessPolicyRepositoryImpl) Upsert(ctx context.Context, policy *domain.InboxStalenessPolicy) error {
+	err := r.q.UpsertInboxStalenessPolicy(ctx, UpsertInboxStalenessPolicyParams{
+		InboxID:                uuidToPgtype(policy.InboxID),
+		TenantID:               uuidToPgtype(policy.TenantID),
+		StaleAfterSeconds:      int32(policy.StaleAfter / time.Second),
+		DeallocateGraceSeconds: durationPtrToSeconds(policy.DeallocateGrace),
+		UpdatedBy:              uuidPtrToPgtype(policy.UpdatedBy),
+		CreatedAt:              timeToPgtype(policy.CreatedAt),
+		UpdatedAt:              timeToPgtype(policy.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *InboxStalenessPolicyRepositoryImpl) Delete(ctx context.Context, inboxID uuid.UUID) error {
+	return mapError(r.q.DeleteInboxStalenessPolicy(ctx, uuidToPgtype(inboxID)))
+}
+
+func (r *InboxStalenessPolicyRepositoryImpl) toDomain(row InboxStalenessPolicy) *domain.InboxStalenessPolicy {
+	return &domain.InboxStalenessPolicy{
+		InboxID:         pgtypeToUUID(row.InboxID),
+		TenantID:        pgtypeToUUID(row.TenantID),
+		StaleAfter:      time.Duration(row.StaleAfterSeconds) * time.Second,
+		DeallocateGrace: secondsToDurationPtr(row.DeallocateGraceSeconds),
+		UpdatedBy:       pgtypeToUUIDPtr(row.UpdatedBy),
+		CreatedAt:       pgtypeToTime(row.CreatedAt),
+		UpdatedAt:       pgtypeToTime(row.UpdatedAt),
+	}
+}
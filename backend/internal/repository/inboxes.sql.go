@@ -12,17 +12,19 @@ import (
 )
 
 const createInbox = `-- name: CreateInbox :exec
-INSERT INTO inboxes (id, tenant_id, phone_number, display_name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO inboxes (id, tenant_id, phone_number, display_name, is_restricted, created_at, updated_at, business_hours)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateInboxParams struct {
-	ID          pgtype.UUID        `json:"id"`
-	TenantID    pgtype.UUID        `json:"tenant_id"`
-	PhoneNumber string             `json:"phone_number"`
-	DisplayName string             `json:"display_name"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ID            pgtype.UUID        `json:"id"`
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	PhoneNumber   string             `json:"phone_number"`
+	DisplayName   string             `json:"display_name"`
+	IsRestricted  bool               `json:"is_restricted"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	BusinessHours []byte             `json:"business_hours"`
 }
 
 func (q *Queries) CreateInbox(ctx context.Context, arg CreateInboxParams) error {
@@ -31,8 +33,10 @@ func (q *Queries) CreateInbox(ctx context.Context, arg CreateInboxParams) error
 		arg.TenantID,
 		arg.PhoneNumber,
 		arg.DisplayName,
+		arg.IsRestricted,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.BusinessHours,
 	)
 	return err
 }
@@ -46,8 +50,19 @@ func (q *Queries) DeleteInbox(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deleteInboxesByTenantID = `-- name: DeleteInboxesByTenantID :exec
+DELETE FROM inboxes WHERE tenant_id = $1
+`
+
+// Deletes every inbox of the tenant with its conversations, labels and
+// subscriptions
+func (q *Queries) DeleteInboxesByTenantID(ctx context.Context, tenantID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteInboxesByTenantID, tenantID)
+	return err
+}
+
 const getInboxByID = `-- name: GetInboxByID :one
-SELECT id, tenant_id, phone_number, display_name, created_at, updated_at FROM inboxes WHERE id = $1
+SELECT id, tenant_id, phone_number, display_name, created_at, updated_at, is_restricted, escalation_inbox_id, auto_resolve_after_seconds, business_hours FROM inboxes WHERE id = $1
 `
 
 func (q *Queries) GetInboxByID(ctx context.Context, id pgtype.UUID) (Inbox, error) {
@@ -60,12 +75,16 @@ func (q *Queries) GetInboxByID(ctx context.Context, id pgtype.UUID) (Inbox, erro
 		&i.DisplayName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsRestricted,
+		&i.EscalationInboxID,
+		&i.AutoResolveAfterSeconds,
+		&i.BusinessHours,
 	)
 	return i, err
 }
 
 const getInboxByPhoneNumber = `-- name: GetInboxByPhoneNumber :one
-SELECT id, tenant_id, phone_number, display_name, created_at, updated_at FROM inboxes WHERE tenant_id = $1 AND phone_number = $2
+SELECT id, tenant_id, phone_number, display_name, created_at, updated_at, is_restricted, escalation_inbox_id, auto_resolve_after_seconds, business_hours FROM inboxes WHERE tenant_id = $1 AND phone_number = $2
 `
 
 type GetInboxByPhoneNumberParams struct {
@@ -83,12 +102,16 @@ func (q *Queries) GetInboxByPhoneNumber(ctx context.Context, arg GetInboxByPhone
 		&i.DisplayName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsRestricted,
+		&i.EscalationInboxID,
+		&i.AutoResolveAfterSeconds,
+		&i.BusinessHours,
 	)
 	return i, err
 }
 
 const getInboxesByTenantID = `-- name: GetInboxesByTenantID :many
-SELECT id, tenant_id, phone_number, display_name, created_at, updated_at FROM inboxes WHERE tenant_id = $1 ORDER BY created_at DESC
+SELECT id, tenant_id, phone_number, display_name, created_at, updated_at, is_restricted, escalation_inbox_id, auto_resolve_after_seconds, business_hours FROM inboxes WHERE tenant_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) GetInboxesByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]Inbox, error) {
@@ -107,6 +130,10 @@ func (q *Queries) GetInboxesByTenantID(ctx context.Context, tenantID pgtype.UUID
 			&i.DisplayName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsRestricted,
+			&i.EscalationInboxID,
+			&i.AutoResolveAfterSeconds,
+			&i.BusinessHours,
 		); err != nil {
 			return nil, err
 		}
@@ -122,15 +149,23 @@ const updateInbox = `-- name: UpdateInbox :exec
 UPDATE inboxes
 SET phone_number = $2,
     display_name = $3,
-    updated_at = $4
+    is_restricted = $4,
+    updated_at = $5,
+    escalation_inbox_id = $6,
+    auto_resolve_after_seconds = $7,
+    business_hours = $8
 WHERE id = $1
 `
 
 type UpdateInboxParams struct {
-	ID          pgtype.UUID        `json:"id"`
-	PhoneNumber string             `json:"phone_number"`
-	DisplayName string             `json:"display_name"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ID                      pgtype.UUID        `json:"id"`
+	PhoneNumber             string             `json:"phone_number"`
+	DisplayName             string             `json:"display_name"`
+	IsRestricted            bool               `json:"is_restricted"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+	EscalationInboxID       pgtype.UUID        `json:"escalation_inbox_id"`
+	AutoResolveAfterSeconds pgtype.Int4        `json:"auto_resolve_after_seconds"`
+	BusinessHours           []byte             `json:"business_hours"`
 }
 
 func (q *Queries) UpdateInbox(ctx context.Context, arg UpdateInboxParams) error {
@@ -138,7 +173,11 @@ func (q *Queries) UpdateInbox(ctx context.Context, arg UpdateInboxParams) error
 		arg.ID,
 		arg.PhoneNumber,
 		arg.DisplayName,
+		arg.IsRestricted,
 		arg.UpdatedAt,
+		arg.EscalationInboxID,
+		arg.AutoResolveAfterSeconds,
+		arg.BusinessHours,
 	)
 	return err
 }
@@ -3,12 +3,15 @@
 package repository
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -113,7 +116,7 @@ func TestConversationRepository_Integration(t *testing.T) {
 		}
 
 		// Get next for allocation (uses FOR UPDATE SKIP LOCKED)
-		convs, err := repo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, 3)
+		convs, err := repo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, 3)
 		require.NoError(t, err)
 		assert.Len(t, convs, 3)
 
@@ -122,6 +125,412 @@ func TestConversationRepository_Integration(t *testing.T) {
 			assert.Equal(t, domain.ConversationStateQueued, conv.State)
 		}
 	})
+
+	t.Run("pinned conversations are allocated first", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+
+		urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		urgent.PriorityScore = decimal.NewFromInt(5)
+		require.NoError(t, repo.Create(ctx, urgent))
+
+		pinned := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, pinned))
+		override := decimal.NewFromFloat(0.5)
+		pinned.PriorityOverride = &override
+		require.NoError(t, repo.SetPriorityOverride(ctx, pinned))
+
+		snoozed := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		snoozed.PriorityScore = decimal.NewFromInt(10)
+		require.NoError(t, repo.Create(ctx, snoozed))
+		until := time.Now().Add(time.Hour)
+		snoozed.SnoozedUntil = &until
+		require.NoError(t, repo.SetSnooze(ctx, snoozed))
+
+		convs, err := repo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, convs, 2, "snoozed conversations are not allocated")
+		assert.Equal(t, pinned.ID, convs[0].ID)
+		require.NotNil(t, convs[0].PriorityOverride)
+		assert.True(t, convs[0].PriorityOverride.Equal(override))
+		assert.Equal(t, urgent.ID, convs[1].ID)
+	})
+
+	t.Run("allocation skips conversations in other languages", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, NewTenantRepository(queries).Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+
+		spanish := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		spanish.PriorityScore = decimal.NewFromInt(5)
+		require.NoError(t, repo.Create(ctx, spanish))
+		es := "es"
+		spanish.Language = &es
+		require.NoError(t, repo.Update(ctx, spanish))
+
+		unknown := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, unknown))
+
+		convs, err := repo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, []string{"en"}, 10)
+		require.NoError(t, err)
+		require.Len(t, convs, 1)
+		assert.Equal(t, unknown.ID, convs[0].ID)
+
+		next, err := repo.PeekNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, []string{"en", "es"})
+		require.NoError(t, err)
+		assert.Equal(t, spanish.ID, next.ID)
+		require.NotNil(t, next.Language)
+		assert.Equal(t, "es", *next.Language)
+
+		convs, err = repo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, convs, 2, "operators without languages take every conversation")
+	})
+
+	t.Run("peek next for allocation without locking", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, NewTenantRepository(queries).Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+
+		_, err := repo.PeekNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		low := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, low))
+		urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		urgent.PriorityScore = decimal.NewFromInt(5)
+		require.NoError(t, repo.Create(ctx, urgent))
+
+		// An allocator holding the head of the queue does not hide it
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		locked, err := NewConversationRefRepository(queries.WithTx(tx), tx).GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, locked, 1)
+		assert.Equal(t, urgent.ID, locked[0].ID)
+
+		next, err := repo.PeekNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, urgent.ID, next.ID)
+		assert.Equal(t, domain.ConversationStateQueued, next.State)
+
+		next, err = repo.PeekNextForAllocationWithQuotas(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, nil, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, urgent.ID, next.ID)
+	})
+
+	t.Run("record priority score components", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+		components := NewPriorityScoreComponentRepository(queries)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, NewTenantRepository(queries).Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, conv))
+
+		now := time.Now().UTC()
+		first := domain.NewPriorityScoreComponents(conv, tenant.PriorityWeightAlpha, tenant.PriorityWeightBeta, decimal.Zero, decimal.Zero, nil, now.Add(-time.Minute))
+		second := domain.NewPriorityScoreComponents(conv, tenant.PriorityWeightAlpha, tenant.PriorityWeightBeta, decimal.NewFromFloat(0.2), decimal.Zero, nil, now)
+		require.NoError(t, components.Create(ctx, first))
+		require.NoError(t, components.Create(ctx, second))
+
+		history, err := components.ListByConversation(ctx, conv.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, second.ID, history[0].ID, "newest first")
+		assert.True(t, history[0].RuleBoost.Equal(decimal.NewFromFloat(0.2)))
+		assert.True(t, history[0].PriorityScore.Equal(second.PriorityScore.Round(6)))
+	})
+
+	t.Run("latest handover note for the assignee", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+		notes := NewConversationNoteRepository(queries)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, NewTenantRepository(queries).Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+		operators := NewOperatorRepository(queries)
+		manager := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleManager)
+		require.NoError(t, operators.Create(ctx, manager))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, operators.Create(ctx, operator))
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, conv))
+
+		_, err := notes.GetLatestForRecipient(ctx, conv.ID, domain.ConversationNoteHandover, operator.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		first := domain.NewHandoverNote(conv, manager.ID, operator.ID, "first")
+		require.NoError(t, notes.Create(ctx, first))
+		second := domain.NewHandoverNote(conv, manager.ID, operator.ID, "second")
+		second.CreatedAt = first.CreatedAt.Add(time.Second)
+		require.NoError(t, notes.Create(ctx, second))
+
+		latest, err := notes.GetLatestForRecipient(ctx, conv.ID, domain.ConversationNoteHandover, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "second", latest.Body)
+		assert.Equal(t, manager.ID, *latest.AuthorID)
+
+		_, err = notes.GetLatestForRecipient(ctx, conv.ID, domain.ConversationNoteHandover, manager.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("create if not exists by external id", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		created, err := repo.CreateIfNotExists(ctx, conv)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		// Same external ID is not inserted twice
+		duplicate := domain.NewConversationRef(tenant.ID, inbox.ID, conv.ExternalConversationID, conv.CustomerPhoneNumber)
+		created, err = repo.CreateIfNotExists(ctx, duplicate)
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		locked, err := repo.LockByExternalID(ctx, tenant.ID, conv.ExternalConversationID)
+		require.NoError(t, err)
+		assert.Equal(t, conv.ID, locked.ID)
+
+		_, err = repo.LockByExternalID(ctx, tenant.ID, "missing")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("first contact detection and boost", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+		tenants := NewTenantRepository(queries)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, tenants.Create(ctx, tenant))
+		tenant.FirstContactBoost = decimal.NewFromFloat(0.25)
+		require.NoError(t, tenants.Update(ctx, tenant))
+		stored, err := tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.FirstContactBoost.Equal(decimal.NewFromFloat(0.25)))
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		seen, err := repo.HasConversationWithPhone(ctx, tenant.ID, conv.CustomerPhoneNumber)
+		require.NoError(t, err)
+		assert.False(t, seen)
+
+		conv.IsFirstContact = true
+		_, err = repo.CreateIfNotExists(ctx, conv)
+		require.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.True(t, got.IsFirstContact)
+
+		seen, err = repo.HasConversationWithPhone(ctx, tenant.ID, conv.CustomerPhoneNumber)
+		require.NoError(t, err)
+		assert.True(t, seen)
+		seen, err = repo.HasConversationWithPhone(ctx, uuid.New(), conv.CustomerPhoneNumber)
+		require.NoError(t, err)
+		assert.False(t, seen, "other tenants' customers do not count")
+	})
+
+	t.Run("reopen persists reopened count", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repo.Create(ctx, conv))
+
+		conv.Allocate(operator.ID)
+		require.NoError(t, conv.Resolve())
+		require.NoError(t, conv.Reopen())
+		require.NoError(t, repo.Update(ctx, conv))
+
+		found, err := repo.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStateQueued, found.State)
+		assert.Equal(t, int32(1), found.ReopenedCount)
+		assert.Nil(t, found.ResolvedAt)
+	})
+
+	t.Run("count created by hour", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+		other := testutil.NewTestInbox(tenant.ID)
+		other.PhoneNumber = "+1234567891"
+		inboxRepo.Create(ctx, other)
+
+		hour := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+		for i, inboxID := range []uuid.UUID{inbox.ID, inbox.ID, other.ID} {
+			conv := testutil.NewTestConversation(tenant.ID, inboxID)
+			conv.CreatedAt = hour.Add(time.Duration(i+1) * time.Minute)
+			require.NoError(t, repo.Create(ctx, conv))
+		}
+
+		counts, err := repo.CountCreatedByHour(ctx, tenant.ID, nil, hour.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, map[time.Time]int{hour: 3}, counts)
+
+		counts, err = repo.CountCreatedByHour(ctx, tenant.ID, &inbox.ID, hour.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, map[time.Time]int{hour: 2}, counts)
+	})
+
+	t.Run("open conversation IDs by inbox", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		base := time.Now().UTC().Add(-time.Hour)
+		var ids []uuid.UUID
+		for i, state := range []domain.ConversationState{
+			domain.ConversationStateAllocated,
+			domain.ConversationStateResolved,
+			domain.ConversationStateQueued,
+			domain.ConversationStateQueued,
+		} {
+			var operatorID *uuid.UUID
+			if state != domain.ConversationStateQueued {
+				operatorID = &operator.ID
+			}
+			conv := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, state, operatorID)
+			conv.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			require.NoError(t, repo.Create(ctx, conv))
+			ids = append(ids, conv.ID)
+		}
+
+		// Oldest first, resolved conversations excluded, bounded by limit
+		found, err := repo.GetOpenIDsByInbox(ctx, tenant.ID, inbox.ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ids[0], ids[2]}, found)
+	})
+}
+
+func TestQueueRankRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("refresh ranks inbox in allocation order", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewQueueRankRepository(queries, pc.Pool)
+		conversations := NewConversationRefRepository(queries, pc.Pool)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+
+		low := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		low.PriorityScore = decimal.NewFromFloat(0.2)
+		high := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		high.PriorityScore = decimal.NewFromFloat(0.8)
+		for _, conv := range []*domain.ConversationRef{low, high} {
+			require.NoError(t, conversations.Create(ctx, conv))
+		}
+
+		ranked, err := repo.RefreshInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), ranked)
+
+		ranks, err := repo.ListByInbox(ctx, inbox.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, ranks, 2)
+		assert.Equal(t, high.ID, ranks[0].ConversationID)
+		assert.Equal(t, 1, ranks[0].Rank)
+		assert.Equal(t, low.ID, ranks[1].ConversationID)
+
+		// Allocated conversations leave the ranking on the next refresh
+		high.State = domain.ConversationStateAllocated
+		require.NoError(t, conversations.Update(ctx, high))
+		_, err = repo.RefreshInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+
+		rank, err := repo.GetByConversationID(ctx, low.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rank.Rank)
+
+		_, err = repo.GetByConversationID(ctx, high.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		count, err := repo.CountByInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
 }
 
 func TestIdempotencyRepository_Integration(t *testing.T) {
@@ -233,62 +642,233 @@ func TestIdempotencyRepository_Integration(t *testing.T) {
 		_, err = repo.GetByKey(ctx, tenant.ID, "expired-key")
 		assert.ErrorIs(t, err, domain.ErrNotFound)
 	})
-}
-
-func TestOperatorStatusRepository_Integration(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
-
-	pc := testutil.NewPostgresContainer(t)
-	ctx := testutil.TestContext(t)
-
-	queries := New(pc.Pool)
 
-	t.Run("create and update operator status", func(t *testing.T) {
+	t.Run("claim and complete idempotency key", func(t *testing.T) {
 		pc.CleanTables(ctx)
-		repo := NewOperatorStatusRepository(queries)
+		repo := NewIdempotencyRepository(queries)
 
-		// Setup
 		tenantRepo := NewTenantRepository(queries)
 		tenant := testutil.NewTestTenant()
 		tenantRepo.Create(ctx, tenant)
 
-		operatorRepo := NewOperatorRepository(queries)
-		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
-		operatorRepo.Create(ctx, operator)
+		first := domain.NewIdempotencyClaim("claimed-key", tenant.ID, "/api", "POST", nil, time.Minute, time.Hour)
+		claimed, err := repo.Claim(ctx, first)
+		require.NoError(t, err)
+		assert.True(t, claimed)
 
-		// Create status
-		status := testutil.NewTestOperatorStatus(operator.ID, domain.OperatorStatusOffline)
-		err := repo.Create(ctx, status)
+		// A concurrent duplicate finds the claim
+		second := domain.NewIdempotencyClaim("claimed-key", tenant.ID, "/api", "POST", nil, time.Minute, time.Hour)
+		claimed, err = repo.Claim(ctx, second)
 		require.NoError(t, err)
+		assert.False(t, claimed)
 
-		// Verify created
-		retrieved, err := repo.GetByOperatorID(ctx, operator.ID)
+		held, err := repo.GetByKey(ctx, tenant.ID, "claimed-key")
 		require.NoError(t, err)
-		assert.Equal(t, domain.OperatorStatusOffline, retrieved.Status)
+		assert.Equal(t, first.ID, held.ID)
+		assert.True(t, held.IsPending())
 
-		// Update status
-		status.SetStatus(domain.OperatorStatusAvailable)
-		err = repo.Update(ctx, status)
+		first.ResponseStatus, first.ResponseBody = 201, []byte(`{"ok":true}`)
+		require.NoError(t, repo.Complete(ctx, first))
+		assert.ErrorIs(t, repo.Complete(ctx, first), domain.ErrNotFound, "completed once")
+
+		completed, err := repo.GetByKey(ctx, tenant.ID, "claimed-key")
 		require.NoError(t, err)
+		assert.Equal(t, domain.IdempotencyStatusCompleted, completed.Status)
+		assert.Nil(t, completed.LockedUntil)
+		assert.Equal(t, 201, completed.ResponseStatus)
 
-		// Verify updated
-		retrieved, err = repo.GetByOperatorID(ctx, operator.ID)
+		claimed, err = repo.Claim(ctx, second)
 		require.NoError(t, err)
-		assert.Equal(t, domain.OperatorStatusAvailable, retrieved.Status)
+		assert.False(t, claimed, "a completed key is replayed, not claimed")
 	})
-}
-
-func TestGracePeriodRepository_Integration(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test in short mode")
-	}
 
-	pc := testutil.NewPostgresContainer(t)
-	ctx := testutil.TestContext(t)
+	t.Run("stale claim is taken over", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewIdempotencyRepository(queries)
 
-	queries := New(pc.Pool)
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		crashed := domain.NewIdempotencyClaim("stale-key", tenant.ID, "/api", "POST", nil, -time.Second, time.Hour)
+		claimed, err := repo.Claim(ctx, crashed)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		retry := domain.NewIdempotencyClaim("stale-key", tenant.ID, "/api", "POST", nil, time.Minute, time.Hour)
+		claimed, err = repo.Claim(ctx, retry)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+
+		crashed.ResponseStatus, crashed.ResponseBody = 200, []byte(`{}`)
+		assert.ErrorIs(t, repo.Complete(ctx, crashed), domain.ErrNotFound, "the taken-over claim cannot complete")
+
+		held, err := repo.GetByKey(ctx, tenant.ID, "stale-key")
+		require.NoError(t, err)
+		assert.Equal(t, retry.ID, held.ID)
+	})
+
+	t.Run("find and rewrite keys to re-encrypt", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewIdempotencyRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		plain := domain.NewIdempotencyKey("plain", tenant.ID, "/api", "POST", nil, 200, []byte(`{"a":1}`), time.Hour)
+		require.NoError(t, repo.Create(ctx, plain))
+
+		oldKey, newKey := "k1", "k2"
+		sealed := domain.NewIdempotencyKey("sealed", tenant.ID, "/api", "POST", nil, 200, nil, time.Hour)
+		sealed.ResponseCiphertext, sealed.EncryptionKeyID = []byte("ciphertext"), &oldKey
+		require.NoError(t, repo.Create(ctx, sealed))
+
+		pending, err := repo.GetToReencrypt(ctx, &newKey, uuid.Nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, pending, 2)
+
+		pending, err = repo.GetToReencrypt(ctx, nil, uuid.Nil, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, sealed.ID, pending[0].ID)
+		assert.Equal(t, []byte("ciphertext"), pending[0].ResponseCiphertext)
+
+		plain.ResponseBody, plain.ResponseCiphertext, plain.EncryptionKeyID = nil, []byte("other"), &newKey
+		require.NoError(t, repo.UpdateResponse(ctx, plain))
+
+		pending, err = repo.GetToReencrypt(ctx, &newKey, uuid.Nil, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, sealed.ID, pending[0].ID)
+
+		pending, err = repo.GetToReencrypt(ctx, &newKey, sealed.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending, "cursor skips keys already visited")
+	})
+}
+
+func TestOperatorStatusRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("create and update operator status", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewOperatorStatusRepository(queries)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		// Create status
+		status := testutil.NewTestOperatorStatus(operator.ID, domain.OperatorStatusOffline)
+		err := repo.Create(ctx, status)
+		require.NoError(t, err)
+
+		// Verify created
+		retrieved, err := repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperatorStatusOffline, retrieved.Status)
+
+		// Update status
+		status.SetStatus(domain.OperatorStatusAvailable)
+		err = repo.Update(ctx, status)
+		require.NoError(t, err)
+
+		// Verify updated
+		retrieved, err = repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperatorStatusAvailable, retrieved.Status)
+
+		for _, next := range []domain.OperatorStatusType{domain.OperatorStatusBusy, domain.OperatorStatusAway} {
+			status.SetStatus(next)
+			require.NoError(t, repo.Update(ctx, status))
+
+			retrieved, err = repo.GetByOperatorID(ctx, operator.ID)
+			require.NoError(t, err)
+			assert.Equal(t, next, retrieved.Status)
+		}
+
+		// Focus is stored and cleared by a status change
+		status.SetStatus(domain.OperatorStatusAvailable)
+		status.StartFocus(time.Now().Add(30 * time.Minute))
+		require.NoError(t, repo.Update(ctx, status))
+
+		retrieved, err = repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved.FocusUntil)
+		assert.WithinDuration(t, *status.FocusUntil, *retrieved.FocusUntil, time.Millisecond)
+		assert.False(t, retrieved.AcceptsAllocations(time.Now()))
+
+		status.SetStatus(domain.OperatorStatusBusy)
+		require.NoError(t, repo.Update(ctx, status))
+
+		retrieved, err = repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Nil(t, retrieved.FocusUntil)
+	})
+}
+
+func TestOperatorRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("persist and clear operator profile", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewOperatorRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		name, email, empty := "Maria", "maria@example.com", ""
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operator.SetProfile(&name, &email)
+		require.NoError(t, repo.Create(ctx, operator))
+
+		retrieved, err := repo.GetByID(ctx, operator.ID)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved.Name)
+		assert.Equal(t, name, *retrieved.Name)
+		require.NotNil(t, retrieved.Email)
+		assert.Equal(t, email, *retrieved.Email)
+
+		retrieved.SetProfile(nil, &empty)
+		require.NoError(t, repo.Update(ctx, retrieved))
+
+		retrieved, err = repo.GetByID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, retrieved.Name)
+		assert.Nil(t, retrieved.Email)
+	})
+}
+
+func TestGracePeriodRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
 
 	t.Run("create and get grace period", func(t *testing.T) {
 		pc.CleanTables(ctx)
@@ -356,6 +936,13 @@ func TestGracePeriodRepository_Integration(t *testing.T) {
 		)
 		repo.Create(ctx, gpa)
 
+		// Counted in the backlog until processed
+		backlog, err := repo.GetBacklog(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), backlog.Overdue)
+		require.NotNil(t, backlog.OldestExpiresAt)
+		assert.WithinDuration(t, gpa.ExpiresAt, *backlog.OldestExpiresAt, time.Second)
+
 		// Get and lock expired
 		expired, err := repo.GetAndLockExpired(ctx, 10)
 		require.NoError(t, err)
@@ -368,5 +955,3198 @@ func TestGracePeriodRepository_Integration(t *testing.T) {
 		// Verify deleted
 		remaining, _ := repo.GetByOperatorID(ctx, operator.ID)
 		assert.Len(t, remaining, 0)
+
+		backlog, err = repo.GetBacklog(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, backlog.Overdue)
+		assert.Nil(t, backlog.OldestExpiresAt)
+	})
+
+	t.Run("list, get and extend within the tenant", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewGracePeriodRepository(queries, pc.Pool)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+		otherTenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, otherTenant)
+
+		inboxRepo := NewInboxRepository(queries)
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inboxRepo.Create(ctx, inbox)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		convRepo := NewConversationRefRepository(queries, pc.Pool)
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		convRepo.Create(ctx, conv)
+		expiredConv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		convRepo.Create(ctx, expiredConv)
+
+		gpa := testutil.NewTestGracePeriod(conv.ID, operator.ID, time.Now().UTC().Add(5*time.Minute))
+		require.NoError(t, repo.Create(ctx, gpa))
+		expired := testutil.NewTestGracePeriod(expiredConv.ID, operator.ID, time.Now().UTC().Add(-time.Minute))
+		require.NoError(t, repo.Create(ctx, expired))
+
+		// List is ordered soonest to expire first and filters by conversation
+		all, err := repo.List(ctx, domain.GracePeriodFilter{TenantID: tenant.ID, OperatorID: &operator.ID})
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, expired.ID, all[0].ID)
+
+		byConv, err := repo.List(ctx, domain.GracePeriodFilter{TenantID: tenant.ID, ConversationID: &conv.ID})
+		require.NoError(t, err)
+		require.Len(t, byConv, 1)
+		assert.Equal(t, gpa.ID, byConv[0].ID)
+
+		none, err := repo.List(ctx, domain.GracePeriodFilter{TenantID: otherTenant.ID})
+		require.NoError(t, err)
+		assert.Empty(t, none)
+
+		// GetByID is tenant scoped
+		_, err = repo.GetByID(ctx, otherTenant.ID, gpa.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		stored, err := repo.GetByID(ctx, tenant.ID, gpa.ID)
+		require.NoError(t, err)
+		assert.Equal(t, conv.ID, stored.ConversationID)
+
+		// Only pending grace periods can be extended
+		newExpiry := gpa.ExpiresAt.Add(10 * time.Minute)
+		require.NoError(t, repo.SetExpiry(ctx, gpa.ID, newExpiry))
+		stored, err = repo.GetByID(ctx, tenant.ID, gpa.ID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, newExpiry, stored.ExpiresAt, time.Second)
+
+		err = repo.SetExpiry(ctx, expired.ID, time.Now().UTC().Add(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestAllocationIntentRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("resolve only once and restart aborted intent", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAllocationIntentRepository(queries)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		key := "alloc-key"
+		intent := domain.NewAllocationIntent(tenant.ID, operator.ID, domain.AllocationIntentAllocate, &key, nil)
+		require.NoError(t, repo.Create(ctx, intent))
+
+		// Same key again - rejected
+		dup := domain.NewAllocationIntent(tenant.ID, operator.ID, domain.AllocationIntentAllocate, &key, nil)
+		assert.ErrorIs(t, repo.Create(ctx, dup), domain.ErrAlreadyExists)
+
+		// Pending intents are found by recovery
+		pending, err := repo.GetPendingBefore(ctx, time.Now().UTC().Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, intent.ID, pending[0].ID)
+
+		// Restart only applies to aborted intents
+		assert.ErrorIs(t, repo.Restart(ctx, dup), domain.ErrNotFound)
+
+		intent.Resolve(domain.AllocationIntentAborted, false)
+		won, err := repo.Resolve(ctx, intent)
+		require.NoError(t, err)
+		assert.True(t, won)
+
+		// A second resolution loses
+		intent.Resolve(domain.AllocationIntentCommitted, true)
+		won, err = repo.Resolve(ctx, intent)
+		require.NoError(t, err)
+		assert.False(t, won)
+
+		require.NoError(t, repo.Restart(ctx, dup))
+		assert.Equal(t, intent.ID, dup.ID)
+
+		retrieved, err := repo.GetByIdempotencyKey(ctx, tenant.ID, key)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AllocationIntentPending, retrieved.Status)
+		assert.Nil(t, retrieved.ResolvedAt)
+	})
+
+	t.Run("delete resolved intents", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAllocationIntentRepository(queries)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		resolved := domain.NewAllocationIntent(tenant.ID, operator.ID, domain.AllocationIntentAllocate, nil, nil)
+		require.NoError(t, repo.Create(ctx, resolved))
+		resolved.Resolve(domain.AllocationIntentCommitted, false)
+		_, err := repo.Resolve(ctx, resolved)
+		require.NoError(t, err)
+
+		pending := domain.NewAllocationIntent(tenant.ID, operator.ID, domain.AllocationIntentAllocate, nil, nil)
+		require.NoError(t, repo.Create(ctx, pending))
+
+		count, err := repo.DeleteResolvedBefore(ctx, time.Now().UTC().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestAnomalyDetection_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("count deallocations and fast resolves", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAuditLogRepository(queries, pc.Pool)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+		manager := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleManager)
+		operatorRepo.Create(ctx, manager)
+
+		now := time.Now().UTC()
+		entry := func(action domain.AuditAction, actorID, conversationID uuid.UUID, at time.Time) {
+			e := domain.NewAuditEntry(tenant.ID, &actorID, action, domain.AuditEntityConversation, conversationID,
+				nil, map[string]interface{}{"assigned_operator_id": operator.ID.String()})
+			e.CreatedAt = at
+			require.NoError(t, repo.Create(ctx, e))
+		}
+
+		// Resolved 5s after allocation by the assigned operator: fast
+		fast := uuid.New()
+		entry(domain.AuditActionConversationAllocate, operator.ID, fast, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationResolve, operator.ID, fast, now.Add(-time.Minute+5*time.Second))
+
+		// Resolved a minute after allocation: not fast
+		slow := uuid.New()
+		entry(domain.AuditActionConversationClaim, operator.ID, slow, now.Add(-2*time.Minute))
+		entry(domain.AuditActionConversationResolve, operator.ID, slow, now.Add(-time.Minute))
+
+		// Resolved quickly by a manager, not the assignee: not counted
+		other := uuid.New()
+		entry(domain.AuditActionConversationAllocate, operator.ID, other, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationResolve, manager.ID, other, now.Add(-time.Minute+time.Second))
+
+		counts, err := repo.CountFastResolves(ctx, tenant.ID, now.Add(-time.Hour), domain.FastResolveWithin)
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int{operator.ID: 1}, counts)
+
+		entry(domain.AuditActionConversationDeallocate, manager.ID, uuid.New(), now.Add(-time.Minute))
+		entry(domain.AuditActionConversationDeallocate, manager.ID, uuid.New(), now.Add(-2*time.Hour))
+
+		count, err := repo.CountByAction(ctx, tenant.ID, domain.AuditActionConversationDeallocate, now.Add(-time.Hour), now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("count aborted intents", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAllocationIntentRepository(queries)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		for _, status := range []domain.AllocationIntentStatus{domain.AllocationIntentAborted, domain.AllocationIntentAborted, domain.AllocationIntentCommitted} {
+			intent := domain.NewAllocationIntent(tenant.ID, operator.ID, domain.AllocationIntentAllocate, nil, nil)
+			require.NoError(t, repo.Create(ctx, intent))
+			intent.Resolve(status, false)
+			_, err := repo.Resolve(ctx, intent)
+			require.NoError(t, err)
+		}
+
+		now := time.Now().UTC()
+		count, err := repo.CountAborted(ctx, tenant.ID, now.Add(-time.Minute), now.Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("anomalies and settings", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAnomalyRepository(queries)
+		settingsRepo := NewTenantAnomalySettingsRepository(queries)
+
+		// Setup
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+		quiet := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, quiet)
+
+		operatorRepo := NewOperatorRepository(queries)
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		operatorRepo.Create(ctx, operator)
+
+		// Unconfigured tenants get the default sensitivity
+		require.NoError(t, settingsRepo.Upsert(ctx, domain.NewTenantAnomalySettings(quiet.ID, domain.AnomalySensitivityOff, nil)))
+		sensitivities, err := settingsRepo.ListSensitivities(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]domain.AnomalySensitivity{
+			tenant.ID: domain.DefaultAnomalySensitivity,
+			quiet.ID:  domain.AnomalySensitivityOff,
+		}, sensitivities)
+
+		anomaly := domain.NewAnomaly(tenant.ID, domain.AnomalyFastResolves, &operator.ID, 6, 0, 5, 15*time.Minute)
+		require.NoError(t, repo.Create(ctx, anomaly))
+
+		since := anomaly.DetectedAt.Add(-time.Minute)
+		exists, err := repo.ExistsSince(ctx, tenant.ID, domain.AnomalyFastResolves, &operator.ID, since)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		// Tenant-wide anomalies of the kind are tracked separately
+		exists, err = repo.ExistsSince(ctx, tenant.ID, domain.AnomalyFastResolves, nil, since)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		listed, err := repo.List(ctx, tenant.ID, since, 10)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, anomaly.ID, listed[0].ID)
+		assert.Equal(t, 15*time.Minute, listed[0].Window)
+		assert.Equal(t, &operator.ID, listed[0].OperatorID)
+	})
+}
+
+func TestBreakGlassAccess_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("persist inbox restriction", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewInboxRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inbox.IsRestricted = true
+		require.NoError(t, repo.Create(ctx, inbox))
+
+		retrieved, err := repo.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.True(t, retrieved.IsRestricted)
+
+		retrieved.IsRestricted = false
+		require.NoError(t, repo.Update(ctx, retrieved))
+
+		retrieved, err = repo.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.False(t, retrieved.IsRestricted)
+	})
+
+	t.Run("list audit log by action", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewAuditLogRepository(queries, pc.Pool)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		managerID := uuid.New()
+		conversationID := uuid.New()
+		access := domain.NewAuditEntry(tenant.ID, &managerID, domain.AuditActionConversationBreakGlass,
+			domain.AuditEntityConversation, conversationID, nil, map[string]interface{}{"reason": "customer complaint"})
+		require.NoError(t, repo.Create(ctx, access))
+		require.NoError(t, repo.Create(ctx, domain.NewAuditEntry(tenant.ID, &managerID, domain.AuditActionConversationReassign,
+			domain.AuditEntityConversation, conversationID, nil, nil)))
+
+		action := domain.AuditActionConversationBreakGlass
+		entries, err := repo.List(ctx, domain.AuditLogFilter{TenantID: tenant.ID, Action: &action, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, access.ID, entries[0].ID)
+		assert.Equal(t, "customer complaint", entries[0].After["reason"])
+	})
+}
+
+func TestOperatorScheduleRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("persist schedule windows", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewOperatorScheduleRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, NewOperatorRepository(queries).Create(ctx, operator))
+
+		night := domain.NewOperatorSchedule(tenant.ID, operator.ID, time.Monday, 22*time.Hour, 6*time.Hour+30*time.Minute, "Europe/Berlin", nil)
+		require.NoError(t, repo.Create(ctx, night))
+		day := domain.NewOperatorSchedule(tenant.ID, operator.ID, time.Monday, 9*time.Hour, 17*time.Hour, "Europe/Berlin", nil)
+		require.NoError(t, repo.Create(ctx, day))
+
+		schedules, err := repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+		assert.Equal(t, day.ID, schedules[0].ID, "ordered by start time")
+		assert.Equal(t, 6*time.Hour+30*time.Minute, schedules[1].End)
+		assert.Equal(t, time.Monday, schedules[1].DayOfWeek)
+
+		night.DayOfWeek = time.Friday
+		require.NoError(t, repo.Update(ctx, night))
+		retrieved, err := repo.GetByID(ctx, night.ID)
+		require.NoError(t, err)
+		assert.Equal(t, time.Friday, retrieved.DayOfWeek)
+
+		require.NoError(t, repo.Delete(ctx, night.ID))
+		_, err = repo.GetByID(ctx, night.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("list schedules of available operators", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewOperatorScheduleRepository(queries)
+		statusRepo := NewOperatorStatusRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		available := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		offline := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		for _, op := range []*domain.Operator{available, offline} {
+			require.NoError(t, NewOperatorRepository(queries).Create(ctx, op))
+			require.NoError(t, repo.Create(ctx, domain.NewOperatorSchedule(tenant.ID, op.ID, time.Monday, 9*time.Hour, 17*time.Hour, "UTC", nil)))
+		}
+		require.NoError(t, statusRepo.Create(ctx, testutil.NewTestOperatorStatus(available.ID, domain.OperatorStatusAvailable)))
+		require.NoError(t, statusRepo.Create(ctx, testutil.NewTestOperatorStatus(offline.ID, domain.OperatorStatusOffline)))
+
+		schedules, err := repo.GetOfAvailableOperators(ctx)
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		assert.Equal(t, available.ID, schedules[0].OperatorID)
+	})
+}
+
+func TestInboxAdminRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("grant and revoke inbox admin", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		repo := NewInboxAdminRepository(queries)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+		other := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, other))
+
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, NewOperatorRepository(queries).Create(ctx, operator))
+
+		require.NoError(t, repo.Create(ctx, domain.NewInboxAdmin(tenant.ID, operator.ID, inbox.ID, nil)))
+		err := repo.Create(ctx, domain.NewInboxAdmin(tenant.ID, operator.ID, inbox.ID, nil))
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		exists, err := repo.Exists(ctx, operator.ID, inbox.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = repo.Exists(ctx, operator.ID, other.ID)
+		require.NoError(t, err)
+		assert.False(t, exists, "grant is scoped to one inbox")
+
+		grants, err := repo.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, inbox.ID, grants[0].InboxID)
+
+		grants, err = repo.GetByInboxID(ctx, inbox.ID)
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+
+		require.NoError(t, repo.Delete(ctx, operator.ID, inbox.ID))
+		exists, err = repo.Exists(ctx, operator.ID, inbox.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestSLATracking_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	queries := New(pc.Pool)
+
+	t.Run("flag breaches and boost near-breach conversations", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		policies := NewInboxSLAPolicyRepository(queries)
+		convRepo := NewConversationRefRepository(queries, pc.Pool)
+
+		tenantRepo := NewTenantRepository(queries)
+		tenant := testutil.NewTestTenant()
+		tenantRepo.Create(ctx, tenant)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, inbox))
+		untracked := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, NewInboxRepository(queries).Create(ctx, untracked))
+
+		firstAssignment := 10 * time.Minute
+		require.NoError(t, policies.Upsert(ctx, domain.NewInboxSLAPolicy(tenant.ID, inbox.ID, &firstAssignment, nil, nil)))
+		policy, err := policies.Get(ctx, inbox.ID)
+		require.NoError(t, err)
+		require.NotNil(t, policy.FirstAssignment)
+		assert.Equal(t, firstAssignment, *policy.FirstAssignment)
+		assert.Nil(t, policy.Resolution)
+
+		now := time.Now().UTC()
+		newConversation := func(inboxID uuid.UUID, age time.Duration) *domain.ConversationRef {
+			conv := testutil.NewTestConversation(tenant.ID, inboxID)
+			conv.CreatedAt = now.Add(-age)
+			require.NoError(t, convRepo.Create(ctx, conv))
+			return conv
+		}
+		late := newConversation(inbox.ID, 15*time.Minute)
+		nearBreach := newConversation(inbox.ID, 9*time.Minute)
+		fresh := newConversation(inbox.ID, time.Minute)
+		elsewhere := newConversation(untracked.ID, time.Hour)
+
+		breaches, err := convRepo.MarkSLABreaches(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, breaches, 1)
+		assert.Equal(t, late.ID, breaches[0].ConversationID)
+
+		breaches, err = convRepo.MarkSLABreaches(ctx, now)
+		require.NoError(t, err)
+		assert.Empty(t, breaches, "breaches are flagged once")
+
+		inboxIDs, err := convRepo.BoostNearSLABreach(ctx, now, 0.8, decimal.NewFromInt(1))
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{inbox.ID}, inboxIDs)
+
+		for _, conv := range []*domain.ConversationRef{late, nearBreach} {
+			got, err := convRepo.GetByID(ctx, conv.ID)
+			require.NoError(t, err)
+			assert.True(t, got.PriorityScore.Equal(decimal.NewFromInt(1)))
+		}
+		for _, conv := range []*domain.ConversationRef{fresh, elsewhere} {
+			got, err := convRepo.GetByID(ctx, conv.ID)
+			require.NoError(t, err)
+			assert.True(t, got.PriorityScore.IsZero())
+			assert.Nil(t, got.SLABreachedAt)
+		}
+
+		listed, err := convRepo.ListSLABreaches(ctx, tenant.ID, nil, now.Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, late.ID, listed[0].ID)
+		assert.NotNil(t, listed[0].SLABreachedAt)
+
+		listed, err = convRepo.ListSLABreaches(ctx, tenant.ID, &untracked.ID, now.Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+
+		require.NoError(t, policies.Delete(ctx, inbox.ID))
+		_, err = policies.Get(ctx, inbox.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+// mapCache is an in-process cache.Cache that ignores TTLs
+type mapCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (c *mapCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.data[key]
+	return v, ok, nil
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *mapCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.data, key)
+	}
+	return nil
+}
+
+func TestReadCache_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("writes invalidate cached reads", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		c := &mapCache{data: make(map[string][]byte)}
+		repos := NewRepositoryContainer(pc.Pool)
+		repos.UseCache(c, time.Minute)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		require.NoError(t, repos.OperatorStatus.Create(ctx, testutil.NewTestOperatorStatus(operator.ID, domain.OperatorStatusOffline)))
+
+		// Populate the cache
+		_, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		ids, err := repos.Subscriptions.GetSubscribedInboxIDs(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+		status, err := repos.OperatorStatus.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperatorStatusOffline, status.Status)
+		assert.Len(t, c.data, 3)
+
+		tenant.PriorityWeightAlpha = decimal.NewFromFloat(0.9)
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		require.NoError(t, repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID)))
+		status.SetStatus(domain.OperatorStatusAvailable)
+		require.NoError(t, repos.OperatorStatus.Update(ctx, status))
+		assert.Empty(t, c.data)
+
+		got, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.True(t, got.PriorityWeightAlpha.Equal(decimal.NewFromFloat(0.9)))
+		ids, err = repos.Subscriptions.GetSubscribedInboxIDs(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{inbox.ID}, ids)
+		status, err = repos.OperatorStatus.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperatorStatusAvailable, status.Status)
+
+		// Served from the cache
+		cached, err := repos.OperatorStatus.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, status.ID, cached.ID)
+		assert.Len(t, c.data, 3)
+	})
+
+	t.Run("transaction writes invalidate once committed", func(t *testing.T) {
+		pc.CleanTables(ctx)
+		c := &mapCache{data: make(map[string][]byte)}
+		repos := NewRepositoryContainer(pc.Pool)
+		repos.UseCache(c, time.Minute)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		txRepos := repos.WithTx(tx)
+
+		tenant.Sandbox = true
+		require.NoError(t, txRepos.Tenants.Update(ctx, tenant))
+
+		// A read racing the transaction caches the committed row
+		got, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.False(t, got.Sandbox)
+		assert.Len(t, c.data, 1)
+
+		require.NoError(t, txRepos.Commit(ctx))
+		assert.Empty(t, c.data)
+
+		got, err = repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.True(t, got.Sandbox)
+	})
+}
+
+func TestWorkerInstanceRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("newest live protocol ignores self, disabled and stale replicas", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+		now := time.Now().UTC()
+
+		_, err := pc.Pool.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, domain.MaxSchemaVersion)
+		require.NoError(t, err)
+		schema, err := repos.WorkerInstances.GetSchemaVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MaxSchemaVersion, schema.Version)
+		assert.False(t, schema.Dirty)
+
+		self := domain.NewWorkerInstance(uuid.New(), "v2", true, now)
+		self.WorkerProtocol = domain.WorkerProtocolVersion + 5
+		require.NoError(t, repos.WorkerInstances.Upsert(ctx, self))
+		disabled := domain.NewWorkerInstance(uuid.New(), "v3", false, now)
+		disabled.WorkerProtocol = domain.WorkerProtocolVersion + 3
+		require.NoError(t, repos.WorkerInstances.Upsert(ctx, disabled))
+		stale := domain.NewWorkerInstance(uuid.New(), "v4", true, now.Add(-time.Hour))
+		stale.WorkerProtocol = domain.WorkerProtocolVersion + 2
+		require.NoError(t, repos.WorkerInstances.Upsert(ctx, stale))
+		peer := domain.NewWorkerInstance(uuid.New(), "v1", true, now)
+		require.NoError(t, repos.WorkerInstances.Upsert(ctx, peer))
+
+		cutoff := now.Add(-time.Minute)
+		protocol, err := repos.WorkerInstances.NewestLiveProtocol(ctx, self.InstanceID, cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, domain.WorkerProtocolVersion, protocol)
+
+		removed, err := repos.WorkerInstances.DeleteStale(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+
+		require.NoError(t, repos.WorkerInstances.Delete(ctx, peer.InstanceID))
+		protocol, err = repos.WorkerInstances.NewestLiveProtocol(ctx, self.InstanceID, cutoff)
+		require.NoError(t, err)
+		assert.Zero(t, protocol)
+	})
+}
+
+func TestWaitEstimateInputs_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("queue depth and recent inbox allocations", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		other := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, other))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		queued := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, queued))
+		snoozed := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, snoozed))
+		require.NoError(t, snoozed.Allocate(operator.ID))
+		require.NoError(t, snoozed.Snooze(time.Now().UTC().Add(time.Hour), false))
+		require.NoError(t, repos.ConversationRefs.Update(ctx, snoozed))
+		require.NoError(t, repos.ConversationRefs.SetSnooze(ctx, snoozed))
+		require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, other.ID)))
+
+		depth, err := repos.ConversationRefs.CountQueuedByInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, depth)
+
+		now := time.Now().UTC()
+		entry := func(action domain.AuditAction, inboxID uuid.UUID, at time.Time) {
+			e := domain.NewAuditEntry(tenant.ID, &operator.ID, action, domain.AuditEntityConversation, uuid.New(),
+				nil, map[string]interface{}{"inbox_id": inboxID.String()})
+			e.CreatedAt = at
+			require.NoError(t, repos.AuditLogs.Create(ctx, e))
+		}
+		entry(domain.AuditActionConversationAllocate, inbox.ID, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationClaim, inbox.ID, now.Add(-2*time.Minute))
+		entry(domain.AuditActionConversationResolve, inbox.ID, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationAllocate, other.ID, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationAllocate, inbox.ID, now.Add(-2*time.Hour))
+
+		count, err := repos.AuditLogs.CountInboxAllocations(ctx, tenant.ID, inbox.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
+
+func TestLabelVersions_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("attachments keep the name of the version they were made under", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		label := domain.NewLabel(tenant.ID, inbox.ID, "vip", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, label))
+
+		before := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, before))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(before.ID, label)))
+
+		require.True(t, label.Change("priority", nil))
+		require.NoError(t, repos.Labels.Update(ctx, label))
+		require.NoError(t, repos.Labels.CreateVersion(ctx, domain.NewLabelVersion(label, nil)))
+
+		after := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, after))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(after.ID, label)))
+
+		versions, err := repos.Labels.ListVersions(ctx, label.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, "vip", versions[0].Name)
+		assert.Equal(t, "priority", versions[1].Name)
+
+		now := time.Now().UTC()
+		counts, err := repos.ConversationLabels.CountByVersion(ctx, tenant.ID, inbox.ID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, counts, 2)
+		assert.Equal(t, "priority", counts[0].Name)
+		assert.Equal(t, 2, counts[0].Version)
+		assert.Equal(t, 1, counts[0].Attachments)
+		assert.Equal(t, "vip", counts[1].Name)
+		assert.Equal(t, 1, counts[1].Version)
+		assert.Equal(t, 1, counts[1].Attachments)
+	})
+
+	t.Run("renaming to a taken name is a conflict", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		require.NoError(t, repos.Labels.Create(ctx, domain.NewLabel(tenant.ID, inbox.ID, "billing", nil, nil)))
+		label := domain.NewLabel(tenant.ID, inbox.ID, "sales", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, label))
+
+		label.Change("billing", nil)
+		assert.ErrorIs(t, repos.Labels.Update(ctx, label), domain.ErrAlreadyExists)
+	})
+}
+
+func TestCategoryQuotas_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("quotas are replaced per inbox", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		complaints := domain.NewLabel(tenant.ID, inbox.ID, "complaints", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, complaints))
+
+		require.NoError(t, repos.CategoryQuotas.Create(ctx, domain.NewCategoryQuota(tenant.ID, inbox.ID, complaints.ID, 30, nil)))
+		err := repos.CategoryQuotas.Create(ctx, domain.NewCategoryQuota(tenant.ID, inbox.ID, complaints.ID, 40, nil))
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		quotas, err := repos.CategoryQuotas.ListByInboxIDs(ctx, []uuid.UUID{inbox.ID, uuid.New()})
+		require.NoError(t, err)
+		require.Len(t, quotas, 1)
+		assert.Equal(t, 30, quotas[0].SharePercent)
+
+		require.NoError(t, repos.CategoryQuotas.DeleteByInbox(ctx, inbox.ID))
+		quotas, err = repos.CategoryQuotas.ListByInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Empty(t, quotas)
+	})
+
+	t.Run("starving conversations go first, then preferred categories", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		complaints := domain.NewLabel(tenant.ID, inbox.ID, "complaints", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, complaints))
+
+		now := time.Now().UTC()
+
+		urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		urgent.PriorityScore = decimal.NewFromInt(5)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, urgent))
+
+		complaint := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		complaint.PriorityScore = decimal.NewFromInt(1)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, complaint))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(complaint.ID, complaints)))
+
+		starving := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		starving.PriorityScore = decimal.NewFromFloat(0.1)
+		starving.CreatedAt = now.Add(-time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, starving))
+
+		convs, err := repos.ConversationRefs.GetNextForAllocationWithQuotas(ctx, tenant.ID,
+			[]uuid.UUID{inbox.ID}, nil, []uuid.UUID{complaints.ID}, now.Add(-10*time.Minute), 3)
+		require.NoError(t, err)
+		require.Len(t, convs, 3)
+		assert.Equal(t, starving.ID, convs[0].ID)
+		assert.Equal(t, complaint.ID, convs[1].ID)
+		assert.Equal(t, urgent.ID, convs[2].ID)
+	})
+
+	t.Run("allocations are counted per label", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		complaints := domain.NewLabel(tenant.ID, inbox.ID, "complaints", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, complaints))
+
+		labeled := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, labeled))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(labeled.ID, complaints)))
+		unlabeled := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, unlabeled))
+
+		for _, conv := range []*domain.ConversationRef{labeled, unlabeled} {
+			require.NoError(t, repos.AuditLogs.Create(ctx, domain.NewAuditEntry(tenant.ID, nil,
+				domain.AuditActionConversationAllocate, domain.AuditEntityConversation, conv.ID,
+				nil, map[string]interface{}{"inbox_id": inbox.ID.String()})))
+		}
+
+		since := time.Now().UTC().Add(-time.Hour)
+		total, err := repos.AuditLogs.CountInboxAllocations(ctx, tenant.ID, inbox.ID, since)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		byLabel, err := repos.AuditLogs.CountInboxAllocationsByLabel(ctx, tenant.ID, inbox.ID, []uuid.UUID{complaints.ID}, since)
+		require.NoError(t, err)
+		assert.Equal(t, 1, byLabel[complaints.ID])
+	})
+}
+
+func TestOperatorAllocationHealth_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("weight and override are saved independently", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		manager := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleManager)
+		require.NoError(t, repos.Operators.Create(ctx, manager))
+
+		_, err := repos.OperatorHealth.GetByOperatorID(ctx, operator.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		health := domain.NewOperatorAllocationHealth(tenant.ID, operator.ID)
+		pinned := 0.75
+		require.NoError(t, health.Override(&pinned, &manager.ID))
+		require.NoError(t, repos.OperatorHealth.SaveOverride(ctx, health))
+
+		// The feedback loop does not clear the manager's override
+		evaluated := domain.NewOperatorAllocationHealth(tenant.ID, operator.ID)
+		evaluated.Evaluate(domain.DefaultAllocationHealthPolicy(), 10, 6)
+		require.NoError(t, repos.OperatorHealth.SaveWeight(ctx, evaluated))
+
+		got, err := repos.OperatorHealth.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, got.Weight)
+		assert.Equal(t, 6, got.Returns)
+		require.NotNil(t, got.OverrideWeight)
+		assert.Equal(t, 0.75, *got.OverrideWeight)
+		assert.Equal(t, 0.75, got.EffectiveWeight())
+
+		listed, err := repos.OperatorHealth.ListByTenant(ctx, tenant.ID)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, operator.ID, listed[0].OperatorID)
+	})
+
+	t.Run("outcomes are counted per assigned operator", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		now := time.Now().UTC()
+		assigned := map[string]interface{}{"assigned_operator_id": operator.ID.String()}
+		entry := func(action domain.AuditAction, before, after map[string]interface{}, at time.Time) {
+			e := domain.NewAuditEntry(tenant.ID, &operator.ID, action, domain.AuditEntityConversation, uuid.New(),
+				before, after)
+			e.CreatedAt = at
+			require.NoError(t, repos.AuditLogs.Create(ctx, e))
+		}
+
+		_, err := repos.AuditLogs.LastAllocationAt(ctx, tenant.ID, operator.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		entry(domain.AuditActionConversationAllocate, nil, assigned, now.Add(-2*time.Minute))
+		entry(domain.AuditActionConversationClaim, nil, assigned, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationDeallocate, assigned, nil, now.Add(-30*time.Second))
+		// Outside the window
+		entry(domain.AuditActionConversationAllocate, nil, assigned, now.Add(-2*time.Hour))
+
+		outcomes, err := repos.AuditLogs.CountOperatorOutcomes(ctx, tenant.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperatorAllocationOutcome{Allocations: 2, Returns: 1}, outcomes[operator.ID])
+
+		last, err := repos.AuditLogs.LastAllocationAt(ctx, tenant.ID, operator.ID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, now.Add(-2*time.Minute), last, time.Second)
+	})
+}
+
+func TestEventOutbox_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("staged entries are claimed once due", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		now := time.Now().UTC()
+		due := domain.NewEvent(tenant.ID, domain.EventConversationResolved, map[string]interface{}{"conversation_id": "c1"})
+		require.NoError(t, repos.Outbox.Create(ctx, domain.NewOutboxEntry(due, now.Add(-time.Second))))
+		// Still in the producer's grace period
+		flushing := domain.NewEvent(tenant.ID, domain.EventConversationAllocated, nil)
+		require.NoError(t, repos.Outbox.Create(ctx, domain.NewOutboxEntry(flushing, now.Add(time.Minute))))
+		// Staging the same event twice keeps one entry
+		require.NoError(t, repos.Outbox.Create(ctx, domain.NewOutboxEntry(due, now.Add(-time.Second))))
+
+		claimed, err := repos.Outbox.ClaimDue(ctx, 10, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, due.ID, claimed[0].Event.ID)
+		assert.Equal(t, "c1", claimed[0].Event.Data["conversation_id"])
+
+		// Leased: a second worker finds nothing
+		again, err := repos.Outbox.ClaimDue(ctx, 10, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, again)
+
+		claimed[0].MarkPublished()
+		require.NoError(t, repos.Outbox.UpdateAttempt(ctx, claimed[0]))
+		require.NoError(t, repos.Outbox.MarkPublished(ctx, flushing.ID, now))
+
+		pending, err := repos.Outbox.CountPending(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, pending)
+
+		purged, err := repos.Outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+	})
+}
+
+func TestConversationChecklists_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("templates are instantiated once per conversation", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		note := "Loyalty customers get free shipping"
+		template := domain.NewChecklistTemplate(tenant.ID, inbox.ID, []string{"Verify identity", "Confirm order number"}, &note, true, nil)
+		require.NoError(t, repos.Checklists.SaveTemplate(ctx, template))
+
+		saved, err := repos.Checklists.GetTemplate(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, template.Items, saved.Items)
+		assert.True(t, saved.Strict)
+
+		added, err := repos.Checklists.Instantiate(ctx, []uuid.UUID{conv.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), added)
+
+		// Template changes do not reach conversations with a checklist
+		template.Items = append(template.Items, "Offer a callback")
+		require.NoError(t, repos.Checklists.SaveTemplate(ctx, template))
+		added, err = repos.Checklists.Instantiate(ctx, []uuid.UUID{conv.ID})
+		require.NoError(t, err)
+		assert.Zero(t, added)
+
+		items, err := repos.Checklists.ListItems(ctx, conv.ID)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "Verify identity", items[0].Label)
+		assert.Equal(t, 1, items[0].Position)
+
+		pinned, err := repos.ConversationNotes.GetLatest(ctx, conv.ID, domain.ConversationNotePinned)
+		require.NoError(t, err)
+		assert.Equal(t, note, pinned.Body)
+
+		status, err := repos.Checklists.GetStatus(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ChecklistStatus{Total: 2, Incomplete: 2}, status)
+
+		for _, item := range items {
+			item.SetCompleted(true, operator.ID)
+			require.NoError(t, repos.Checklists.UpdateItemCompletion(ctx, item))
+		}
+		status, err = repos.Checklists.GetStatus(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.True(t, status.Complete())
+
+		ticked, err := repos.Checklists.GetItem(ctx, items[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, &operator.ID, ticked.CompletedBy)
+
+		require.NoError(t, repos.Checklists.DeleteTemplate(ctx, inbox.ID))
+		_, err = repos.Checklists.GetTemplate(ctx, inbox.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestTenantOverview_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("grouped counts per inbox and status", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		empty := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, empty))
+		available := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, available))
+		offline := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, offline))
+
+		status := domain.NewOperatorStatus(available.ID)
+		status.SetStatus(domain.OperatorStatusAvailable)
+		require.NoError(t, repos.OperatorStatus.Create(ctx, status))
+		require.NoError(t, repos.OperatorStatus.Create(ctx, domain.NewOperatorStatus(offline.ID)))
+
+		now := time.Now().UTC()
+		require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, inbox.ID)))
+		pastDue := now.Add(-time.Minute)
+		allocated := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		allocated.DueAt = &pastDue
+		require.NoError(t, repos.ConversationRefs.Create(ctx, allocated))
+		require.NoError(t, allocated.Allocate(available.ID))
+		require.NoError(t, repos.ConversationRefs.Update(ctx, allocated))
+		resolved := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		resolved.CreatedAt = now.Add(-10 * time.Minute)
+		resolved.DueAt = &pastDue
+		require.NoError(t, repos.ConversationRefs.Create(ctx, resolved))
+		require.NoError(t, resolved.Allocate(available.ID))
+		require.NoError(t, resolved.Resolve())
+		require.NoError(t, repos.ConversationRefs.Update(ctx, resolved))
+
+		stats, err := repos.ConversationRefs.CountByInboxAndState(ctx, tenant.ID, now.Add(-time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, stats, 2, "inboxes without conversations are included")
+		byInbox := make(map[uuid.UUID]domain.InboxConversationStats)
+		for _, s := range stats {
+			byInbox[s.InboxID] = s
+		}
+		got := byInbox[inbox.ID]
+		assert.Equal(t, inbox.DisplayName, got.DisplayName)
+		assert.Equal(t, 1, got.Queued)
+		assert.Equal(t, 1, got.Allocated)
+		assert.Equal(t, 1, got.Resolved)
+		assert.Equal(t, 1, got.RecentlyResolved)
+		assert.Equal(t, 1, got.Overdue, "resolved conversations are never overdue")
+		assert.InDelta(t, (10 * time.Minute).Seconds(), got.AvgResolution.Seconds(), 5)
+		assert.Equal(t, domain.InboxConversationStats{InboxID: empty.ID, DisplayName: empty.DisplayName}, byInbox[empty.ID])
+
+		entry := func(action domain.AuditAction, inboxID uuid.UUID, at time.Time) {
+			e := domain.NewAuditEntry(tenant.ID, &available.ID, action, domain.AuditEntityConversation, uuid.New(),
+				nil, map[string]interface{}{"inbox_id": inboxID.String()})
+			e.CreatedAt = at
+			require.NoError(t, repos.AuditLogs.Create(ctx, e))
+		}
+		entry(domain.AuditActionConversationAllocate, inbox.ID, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationClaim, inbox.ID, now.Add(-2*time.Minute))
+		entry(domain.AuditActionConversationResolve, inbox.ID, now.Add(-time.Minute))
+		entry(domain.AuditActionConversationAllocate, inbox.ID, now.Add(-2*time.Hour))
+
+		allocations, err := repos.AuditLogs.CountAllocationsByInbox(ctx, tenant.ID, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int{inbox.ID: 2}, allocations)
+
+		operators, err := repos.OperatorStatus.CountByStatus(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.OperatorStatusType]int{
+			domain.OperatorStatusAvailable: 1,
+			domain.OperatorStatusOffline:   1,
+		}, operators)
+	})
+}
+
+func TestConversationEscalations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("escalation inbox and counts per operator and inbox", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		managers := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, managers))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		inbox.EscalationInboxID = &managers.ID
+		require.NoError(t, repos.Inboxes.Update(ctx, inbox))
+		saved, err := repos.Inboxes.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &managers.ID, saved.EscalationInboxID)
+
+		now := time.Now().UTC()
+		for i := 0; i < 2; i++ {
+			conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+			require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+			require.NoError(t, conv.Allocate(operator.ID))
+			require.NoError(t, conv.Escalate(managers.ID))
+			require.NoError(t, repos.ConversationRefs.Update(ctx, conv))
+			require.NoError(t, repos.Escalations.Create(ctx,
+				domain.NewConversationEscalation(conv, &operator.ID, operator.ID, inbox.ID, "Refund above my limit")))
+
+			moved, err := repos.ConversationRefs.GetByID(ctx, conv.ID)
+			require.NoError(t, err)
+			assert.Equal(t, managers.ID, moved.InboxID)
+			assert.Equal(t, domain.ConversationStateQueued, moved.State)
+		}
+
+		byOperator, err := repos.Escalations.CountByOperator(ctx, tenant.ID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EscalationCount{{ID: operator.ID, Count: 2}}, byOperator)
+
+		byInbox, err := repos.Escalations.CountByInbox(ctx, tenant.ID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EscalationCount{{ID: inbox.ID, Count: 2}}, byInbox)
+
+		byInbox, err = repos.Escalations.CountByInbox(ctx, tenant.ID, now.Add(time.Hour), now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, byInbox)
+
+		// Deleting the escalation inbox disables escalation
+		require.NoError(t, repos.Inboxes.Delete(ctx, managers.ID))
+		saved, err = repos.Inboxes.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Nil(t, saved.EscalationInboxID)
+	})
+}
+
+func TestPriorityExperiments_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("one running experiment per inbox and outcomes per arm", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		experiment := domain.NewPriorityExperiment(tenant.ID, inbox.ID, "recency first",
+			decimal.NewFromFloat(0.3), decimal.NewFromFloat(0.7), 50, nil)
+		require.NoError(t, repos.Experiments.Create(ctx, experiment))
+
+		second := domain.NewPriorityExperiment(tenant.ID, inbox.ID, "volume first",
+			decimal.NewFromFloat(0.7), decimal.NewFromFloat(0.3), 50, nil)
+		assert.ErrorIs(t, repos.Experiments.Create(ctx, second), domain.ErrAlreadyExists)
+
+		running, err := repos.Experiments.GetRunningByInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, experiment.ID, running.ID)
+		assert.True(t, running.WeightBeta.Equal(decimal.NewFromFloat(0.7)))
+
+		now := time.Now().UTC()
+		waits := map[domain.ExperimentArm]time.Duration{
+			domain.ExperimentArmControl:   10 * time.Minute,
+			domain.ExperimentArmTreatment: 4 * time.Minute,
+		}
+		for arm, wait := range waits {
+			allocated := testutil.NewTestConversation(tenant.ID, inbox.ID)
+			allocated.CreatedAt = now.Add(-wait)
+			require.NoError(t, repos.ConversationRefs.Create(ctx, allocated))
+			require.NoError(t, repos.Experiments.Assign(ctx, experiment.ID, allocated.ID, arm))
+			// Recorded once
+			require.NoError(t, repos.Experiments.Assign(ctx, experiment.ID, allocated.ID, arm))
+
+			waiting := testutil.NewTestConversation(tenant.ID, inbox.ID)
+			require.NoError(t, repos.ConversationRefs.Create(ctx, waiting))
+			require.NoError(t, repos.Experiments.Assign(ctx, experiment.ID, waiting.ID, arm))
+
+			require.NoError(t, repos.Experiments.MarkAllocated(ctx, []uuid.UUID{allocated.ID}, now))
+			// Later allocations keep the first
+			require.NoError(t, repos.Experiments.MarkAllocated(ctx, []uuid.UUID{allocated.ID}, now.Add(time.Hour)))
+		}
+
+		outcomes, err := repos.Experiments.GetOutcomes(ctx, experiment.ID)
+		require.NoError(t, err)
+		require.Len(t, outcomes, 2)
+		for _, outcome := range outcomes {
+			assert.Equal(t, 2, outcome.Conversations)
+			assert.Equal(t, 1, outcome.Allocated)
+			assert.Zero(t, outcome.Resolved)
+			assert.InDelta(t, waits[outcome.Arm].Seconds(), outcome.AvgWait.Seconds(), 1)
+			assert.InDelta(t, waits[outcome.Arm].Seconds(), outcome.P90Wait.Seconds(), 1)
+		}
+
+		require.NoError(t, experiment.Stop())
+		require.NoError(t, repos.Experiments.Update(ctx, experiment))
+		_, err = repos.Experiments.GetRunningByInbox(ctx, inbox.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		require.NoError(t, repos.Experiments.Create(ctx, second))
+
+		list, err := repos.Experiments.GetByTenantID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+
+		require.NoError(t, repos.Experiments.Delete(ctx, experiment.ID))
+		outcomes, err = repos.Experiments.GetOutcomes(ctx, experiment.ID)
+		require.NoError(t, err)
+		assert.Empty(t, outcomes)
+	})
+}
+
+func TestConversationReads_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("last read per operator never moves back", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		other := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, other))
+
+		read := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, read))
+		unread := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, unread))
+
+		mark := domain.NewConversationRead(read, operator.ID)
+		require.NoError(t, repos.ConversationReads.MarkRead(ctx, mark))
+		stale := *mark
+		stale.LastReadAt = mark.LastReadAt.Add(-time.Hour)
+		require.NoError(t, repos.ConversationReads.MarkRead(ctx, &stale))
+
+		reads, err := repos.ConversationReads.GetLastReadAt(ctx, operator.ID, []uuid.UUID{read.ID, unread.ID})
+		require.NoError(t, err)
+		require.Len(t, reads, 1)
+		assert.WithinDuration(t, mark.LastReadAt, reads[read.ID], time.Millisecond)
+
+		reads, err = repos.ConversationReads.GetLastReadAt(ctx, other.ID, []uuid.UUID{read.ID, unread.ID})
+		require.NoError(t, err)
+		assert.Empty(t, reads)
+	})
+}
+
+func TestConversationRefVersion_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("update from a stale read fails", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		first, err := repos.ConversationRefs.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		second, err := repos.ConversationRefs.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), first.Version)
+
+		require.NoError(t, first.Allocate(operator.ID))
+		require.NoError(t, repos.ConversationRefs.Update(ctx, first))
+		assert.Equal(t, int32(2), first.Version)
+
+		// The stale copy would put the conversation back in the queue
+		second.PriorityScore = decimal.NewFromInt(1)
+		assert.ErrorIs(t, repos.ConversationRefs.Update(ctx, second), domain.ErrConcurrentModification)
+		assert.Equal(t, int32(1), second.Version)
+
+		stored, err := repos.ConversationRefs.GetByID(ctx, conv.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ConversationStateAllocated, stored.State)
+		assert.Equal(t, int32(2), stored.Version)
+
+		// Successive updates of the same copy keep its version current
+		require.NoError(t, first.Resolve())
+		require.NoError(t, repos.ConversationRefs.Update(ctx, first))
+		assert.Equal(t, int32(3), first.Version)
+	})
+}
+
+func TestConversationShareLinks_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("accesses are counted until revoked", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		link := domain.NewShareLink(conv, time.Hour, false, nil)
+		require.NoError(t, repos.ShareLinks.Create(ctx, link))
+
+		accessedAt := time.Now().UTC()
+		require.NoError(t, repos.ShareLinks.RecordAccess(ctx, link.ID, accessedAt))
+		require.NoError(t, repos.ShareLinks.RecordAccess(ctx, link.ID, accessedAt))
+
+		got, err := repos.ShareLinks.GetByID(ctx, link.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), got.AccessCount)
+		require.NotNil(t, got.LastAccessedAt)
+		assert.WithinDuration(t, accessedAt, *got.LastAccessedAt, time.Millisecond)
+		assert.True(t, link.ExpiresAt.Equal(got.ExpiresAt))
+
+		got.Revoke()
+		require.NoError(t, repos.ShareLinks.Revoke(ctx, got))
+
+		links, err := repos.ShareLinks.GetByConversationID(ctx, conv.ID)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.True(t, links[0].IsRevoked())
+	})
+}
+
+func TestCustomers_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("one customer per phone number, searchable by phone and name", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		first, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550001111")
+		require.NoError(t, err)
+		again, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550001111")
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, again.ID)
+		other, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550002222")
+		require.NoError(t, err)
+		byPhoneNumber, err := repos.Customers.GetByPhone(ctx, tenant.ID, "+15550002222")
+		require.NoError(t, err)
+		assert.Equal(t, other.ID, byPhoneNumber.ID)
+		_, err = repos.Customers.GetByPhone(ctx, tenant.ID, "+15550009999")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		name := "Ada 100%"
+		first.SetProfile(&name, map[string]interface{}{"tier": "gold"})
+		require.NoError(t, repos.Customers.Update(ctx, first))
+		stored, err := repos.Customers.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, name, *stored.Name)
+		assert.Equal(t, "gold", stored.Metadata["tier"])
+
+		byPhone, err := repos.Customers.Search(ctx, domain.CustomerFilter{TenantID: tenant.ID, Query: "+1555000222"})
+		require.NoError(t, err)
+		require.Len(t, byPhone, 1)
+		assert.Equal(t, other.ID, byPhone[0].ID)
+
+		byName, err := repos.Customers.Search(ctx, domain.CustomerFilter{TenantID: tenant.ID, Query: "ada 100%"})
+		require.NoError(t, err)
+		require.Len(t, byName, 1)
+		assert.Equal(t, first.ID, byName[0].ID)
+
+		// Wildcards match literally
+		wildcard, err := repos.Customers.Search(ctx, domain.CustomerFilter{TenantID: tenant.ID, Query: "%"})
+		require.NoError(t, err)
+		assert.Len(t, wildcard, 1)
+
+		page, err := repos.Customers.Search(ctx, domain.CustomerFilter{TenantID: tenant.ID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		rest, err := repos.Customers.Search(ctx, domain.CustomerFilter{
+			TenantID:        tenant.ID,
+			CursorTimestamp: &page[0].CreatedAt,
+			CursorID:        &page[0].ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		assert.NotEqual(t, page[0].ID, rest[0].ID)
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		conv.CustomerID = &first.ID
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+		linked, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{TenantID: tenant.ID, CustomerID: &first.ID})
+		require.NoError(t, err)
+		require.Len(t, linked, 1)
+		assert.Equal(t, first.ID, *linked[0].CustomerID)
+	})
+}
+
+func TestReconciliation_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("divergences keep their first detection until the kind changes", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		candidates, err := repos.Reconciliation.ListCandidates(ctx, tenant.ID, uuid.Nil, time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, conv.ExternalConversationID, candidates[0].ExternalConversationID)
+
+		// Already diverging conversations are candidates outside the lookback
+		stale, err := repos.Reconciliation.ListCandidates(ctx, tenant.ID, uuid.Nil, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, stale)
+
+		detected := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		divergence := &domain.ReconciliationDivergence{
+			ConversationID:  conv.ID,
+			TenantID:        tenant.ID,
+			Kind:            domain.DivergenceOpenUpstreamClosed,
+			LocalState:      domain.ConversationStateQueued,
+			UpstreamStatus:  domain.UpstreamStatusClosed,
+			FirstDetectedAt: detected,
+		}
+		_, err = repos.Reconciliation.Upsert(ctx, divergence)
+		require.NoError(t, err)
+
+		stale, err = repos.Reconciliation.ListCandidates(ctx, tenant.ID, uuid.Nil, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, stale, 1)
+
+		again := *divergence
+		again.FirstDetectedAt = time.Now().UTC()
+		stored, err := repos.Reconciliation.Upsert(ctx, &again)
+		require.NoError(t, err)
+		assert.True(t, detected.Equal(stored.FirstDetectedAt), "same kind keeps the first detection")
+
+		require.NoError(t, repos.Reconciliation.MarkCorrected(ctx, conv.ID, time.Now().UTC()))
+		changed := again
+		changed.Kind = domain.DivergenceMissingUpstream
+		changed.UpstreamStatus = domain.UpstreamStatusMissing
+		stored, err = repos.Reconciliation.Upsert(ctx, &changed)
+		require.NoError(t, err)
+		assert.True(t, again.FirstDetectedAt.Truncate(time.Microsecond).Equal(stored.FirstDetectedAt), "a new kind restarts")
+		assert.Nil(t, stored.CorrectedAt)
+
+		listed, err := repos.Reconciliation.List(ctx, tenant.ID, domain.DivergenceMissingUpstream, 10)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, conv.ExternalConversationID, listed[0].ExternalConversationID)
+		assert.Equal(t, inbox.ID, listed[0].InboxID)
+
+		counts, err := repos.Reconciliation.Count(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, map[domain.DivergenceKind]int{domain.DivergenceMissingUpstream: 1}, counts)
+
+		require.NoError(t, repos.Reconciliation.DeleteByConversationIDs(ctx, []uuid.UUID{conv.ID}))
+		listed, err = repos.Reconciliation.List(ctx, tenant.ID, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, listed)
+	})
+}
+
+func TestConversationPhoneSearch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("matches the start or the end of the phone digits", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		target := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		target.CustomerPhoneNumber = "+15550001111"
+		require.NoError(t, repos.ConversationRefs.Create(ctx, target))
+		other := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		other.CustomerPhoneNumber = "+442079460000"
+		require.NoError(t, repos.ConversationRefs.Create(ctx, other))
+
+		for _, digits := range []string{"15550001111", "155500", "0001111"} {
+			found, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{TenantID: tenant.ID, PhoneDigits: digits})
+			require.NoError(t, err)
+			require.Len(t, found, 1, digits)
+			assert.Equal(t, target.ID, found[0].ID, digits)
+		}
+
+		// Digits in the middle of the number do not match
+		found, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{TenantID: tenant.ID, PhoneDigits: "555000"})
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestConversationTextSearch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("ranks external ID, customer name and note matches", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		author := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, author))
+		recipient := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, recipient))
+		bystander := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, bystander))
+
+		byID := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		byID.ExternalConversationID = "refund-4411"
+		require.NoError(t, repos.ConversationRefs.Create(ctx, byID))
+
+		customer, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550003333")
+		require.NoError(t, err)
+		name := "Refund Smith"
+		customer.SetProfile(&name, nil)
+		require.NoError(t, repos.Customers.Update(ctx, customer))
+		byName := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		byName.CustomerID = &customer.ID
+		require.NoError(t, repos.ConversationRefs.Create(ctx, byName))
+
+		byNote := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, byNote))
+		require.NoError(t, repos.ConversationNotes.Create(ctx,
+			domain.NewHandoverNote(byNote, author.ID, recipient.ID, "Customer asked for a refund")))
+
+		other := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, other))
+		otherInbox := testutil.NewTestInbox(other.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, otherInbox))
+		foreign := testutil.NewTestConversation(other.ID, otherInbox.ID)
+		foreign.ExternalConversationID = "refund-9999"
+		require.NoError(t, repos.ConversationRefs.Create(ctx, foreign))
+
+		hits, err := repos.ConversationRefs.SearchText(ctx, ConversationFilters{TenantID: tenant.ID, TextQuery: "refund"})
+		require.NoError(t, err)
+		require.Len(t, hits, 3)
+		assert.Equal(t, byID.ID, hits[0].Conversation.ID)
+		assert.Equal(t, []domain.SearchField{domain.SearchFieldExternalConversationID}, hits[0].MatchedFields)
+		assert.Equal(t, byName.ID, hits[1].Conversation.ID)
+		assert.Equal(t, []domain.SearchField{domain.SearchFieldCustomerName}, hits[1].MatchedFields)
+		assert.Equal(t, byNote.ID, hits[2].Conversation.ID)
+		assert.Equal(t, []domain.SearchField{domain.SearchFieldNotes}, hits[2].MatchedFields)
+		assert.Greater(t, hits[0].Rank, hits[1].Rank)
+		assert.Greater(t, hits[1].Rank, hits[2].Rank)
+
+		// Pages continue after the cursor's rank
+		page, err := repos.ConversationRefs.SearchText(ctx, ConversationFilters{
+			TenantID:   tenant.ID,
+			TextQuery:  "refund",
+			CursorRank: &hits[0].Rank,
+			CursorID:   &hits[0].Conversation.ID,
+			Limit:      1,
+		})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, byName.ID, page[0].Conversation.ID)
+
+		// A handover note only matches for its author and recipient
+		for operatorID, want := range map[uuid.UUID]int{author.ID: 3, recipient.ID: 3, bystander.ID: 2} {
+			operatorID := operatorID
+			hits, err := repos.ConversationRefs.SearchText(ctx, ConversationFilters{
+				TenantID:      tenant.ID,
+				TextQuery:     "refund",
+				NoteVisibleTo: &operatorID,
+			})
+			require.NoError(t, err)
+			assert.Len(t, hits, want)
+		}
+	})
+}
+
+func TestTenantMaintenanceSettings_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("window and override round-trip and clear", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		_, err := repos.MaintenanceSettings.Get(ctx, tenant.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		settings := domain.DefaultTenantMaintenanceSettings(tenant.ID)
+		settings.Window = &domain.MaintenanceWindow{Start: 22 * time.Hour, End: 4 * time.Hour, Timezone: "Europe/Berlin"}
+		settings.Override = &domain.MaintenanceOverride{
+			Mode:   domain.MaintenanceOverrideClosed,
+			Until:  time.Now().UTC().Add(time.Hour).Truncate(time.Microsecond),
+			Reason: "campaign",
+		}
+		settings.CreatedAt = time.Now().UTC()
+		settings.UpdatedAt = settings.CreatedAt
+		require.NoError(t, repos.MaintenanceSettings.Upsert(ctx, settings))
+
+		got, err := repos.MaintenanceSettings.Get(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, settings.Window, got.Window)
+		require.NotNil(t, got.Override)
+		assert.Equal(t, domain.MaintenanceOverrideClosed, got.Override.Mode)
+		assert.True(t, settings.Override.Until.Equal(got.Override.Until))
+		assert.Equal(t, "campaign", got.Override.Reason)
+
+		got.Window = nil
+		got.Override = nil
+		require.NoError(t, repos.MaintenanceSettings.Upsert(ctx, got))
+
+		cleared, err := repos.MaintenanceSettings.Get(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Nil(t, cleared.Window)
+		assert.Nil(t, cleared.Override)
+	})
+}
+
+func TestSubscriptionCreateIfNotExists_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("duplicates are reported, not inserted", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		first := testutil.NewTestSubscription(operator.ID, inbox.ID)
+		created, err := repos.Subscriptions.CreateIfNotExists(ctx, first)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		created, err = repos.Subscriptions.CreateIfNotExists(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID))
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		// A plain insert of the duplicate maps the unique violation
+		err = repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID))
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		subs, err := repos.Subscriptions.GetByOperatorID(ctx, operator.ID)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, first.ID, subs[0].ID)
+	})
+}
+
+func TestOperatorPresence_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("touch keeps connected_at and stale rows are claimed once", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		gone := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, gone))
+		live := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, live))
+
+		base := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+		touch := func(operatorID uuid.UUID, at time.Time) bool {
+			inserted, err := repos.OperatorPresence.Touch(ctx, &domain.OperatorPresence{
+				OperatorID: operatorID, TenantID: tenant.ID, ConnectedAt: at, LastSeenAt: at,
+			})
+			require.NoError(t, err)
+			return inserted
+		}
+
+		assert.True(t, touch(gone.ID, base))
+		assert.True(t, touch(live.ID, base))
+		assert.False(t, touch(live.ID, base.Add(time.Minute)))
+
+		present, err := repos.OperatorPresence.GetByTenantID(ctx, tenant.ID)
+		require.NoError(t, err)
+		require.Len(t, present, 2)
+		for _, p := range present {
+			assert.True(t, p.ConnectedAt.Equal(base))
+		}
+
+		stale, err := repos.OperatorPresence.ClaimStale(ctx, base.Add(30*time.Second))
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		assert.Equal(t, gone.ID, stale[0].OperatorID)
+
+		stale, err = repos.OperatorPresence.ClaimStale(ctx, base.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, stale)
+
+		// A heartbeat after the timeout finds the row gone
+		assert.True(t, touch(gone.ID, base.Add(2*time.Minute)))
+	})
+}
+
+func TestOperatorVacations_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("upsert, drain progress and delete", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		away := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, away))
+		colleague := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, colleague))
+
+		_, err := repos.OperatorVacations.GetByOperatorID(ctx, away.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		vacation, err := domain.NewOperatorVacation(tenant.ID, away.ID, domain.VacationDrainColleagues, []uuid.UUID{colleague.ID}, time.Hour)
+		require.NoError(t, err)
+		stored, err := repos.OperatorVacations.Upsert(ctx, vacation)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{colleague.ID}, stored.ColleagueIDs)
+		assert.True(t, stored.Draining())
+
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		txRepos := NewRepositoryContainer(pc.Pool).WithTx(tx)
+		draining, err := txRepos.OperatorVacations.GetAndLockDraining(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, draining, 1)
+
+		// A second worker skips the locked vacation
+		others, err := repos.OperatorVacations.GetAndLockDraining(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, others)
+
+		drainedAt := time.Now().UTC()
+		draining[0].DrainedAt = &drainedAt
+		draining[0].NextColleague = 1
+		require.NoError(t, txRepos.OperatorVacations.UpdateDrain(ctx, draining[0]))
+		require.NoError(t, tx.Commit(ctx))
+
+		got, err := repos.OperatorVacations.GetByOperatorID(ctx, away.ID)
+		require.NoError(t, err)
+		assert.False(t, got.Draining())
+		assert.Equal(t, 1, got.NextColleague)
+
+		draining, err = repos.OperatorVacations.GetAndLockDraining(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, draining)
+
+		require.NoError(t, repos.OperatorVacations.Delete(ctx, away.ID))
+		assert.ErrorIs(t, repos.OperatorVacations.Delete(ctx, away.ID), domain.ErrNotFound)
+	})
+
+	t.Run("allocated conversations are locked by priority", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		op := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, op))
+
+		low := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &op.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, low))
+		urgent := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &op.ID)
+		urgent.PriorityScore = decimal.NewFromInt(5)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, urgent))
+		queued := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, queued))
+
+		held, err := repos.ConversationRefs.GetAndLockAllocatedByOperator(ctx, op.ID)
+		require.NoError(t, err)
+		require.Len(t, held, 2)
+		assert.Equal(t, urgent.ID, held[0].ID)
+		assert.Equal(t, low.ID, held[1].ID)
+	})
+}
+
+func TestAuditLogActionsFilter_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("list matches any of the actions", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		for _, action := range []domain.AuditAction{
+			domain.AuditActionOperatorRoleChange,
+			domain.AuditActionConversationAllocate,
+			domain.AuditActionRoutingRuleCreate,
+		} {
+			require.NoError(t, repos.AuditLogs.Create(ctx, domain.NewAuditEntry(tenant.ID, nil,
+				action, domain.AuditEntityOperator, uuid.New(), nil, nil)))
+		}
+
+		entries, err := repos.AuditLogs.List(ctx, domain.AuditLogFilter{
+			TenantID: tenant.ID,
+			Actions:  domain.AdminAuditActions,
+		})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, domain.AuditActionRoutingRuleCreate, entries[0].Action)
+		assert.Equal(t, domain.AuditActionOperatorRoleChange, entries[1].Action)
+	})
+}
+
+func TestConversationDueDates_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("overdue conversations are locked, flagged and listed", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		now := time.Now().UTC()
+		early, late, future := now.Add(-2*time.Hour), now.Add(-time.Hour), now.Add(time.Hour)
+
+		overdueLate := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		overdueLate.DueAt = &late
+		require.NoError(t, repos.ConversationRefs.Create(ctx, overdueLate))
+		overdueEarly := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, overdueEarly))
+		overdueEarly.SetDueAt(&early)
+		require.NoError(t, repos.ConversationRefs.SetDueAt(ctx, overdueEarly))
+		notDue := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		notDue.DueAt = &future
+		require.NoError(t, repos.ConversationRefs.Create(ctx, notDue))
+		require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, inbox.ID)))
+
+		locked, err := repos.ConversationRefs.GetAndLockOverdue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, locked, 2)
+		assert.Equal(t, overdueEarly.ID, locked[0].ID, "earliest due first")
+		assert.Equal(t, overdueLate.ID, locked[1].ID)
+
+		locked[0].OverdueAt = &now
+		require.NoError(t, repos.ConversationRefs.SetOverdue(ctx, locked[0]))
+		locked, err = repos.ConversationRefs.GetAndLockOverdue(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, locked, 1, "flagged conversations are not locked again")
+		assert.Equal(t, overdueLate.ID, locked[0].ID)
+
+		got, err := repos.ConversationRefs.GetByID(ctx, overdueEarly.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.DueAt)
+		assert.WithinDuration(t, early, *got.DueAt, time.Millisecond)
+		assert.NotNil(t, got.OverdueAt)
+
+		listed, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{
+			TenantID:    tenant.ID,
+			OverdueAsOf: &now,
+			SortOrder:   "due",
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, overdueEarly.ID, listed[0].ID)
+
+		due := listed[0].DueAt
+		page, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{
+			TenantID:        tenant.ID,
+			SortOrder:       "due",
+			CursorTimestamp: due,
+			CursorID:        &listed[0].ID,
+			Limit:           10,
+		})
+		require.NoError(t, err)
+		require.Len(t, page, 2, "conversations without a due date are left out")
+		assert.Equal(t, overdueLate.ID, page[0].ID)
+		assert.Equal(t, notDue.ID, page[1].ID)
+	})
+}
+
+func TestConversationAutoResolve_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("stale open conversations of auto-resolving inboxes are locked", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		manual := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, manual))
+
+		after := 24 * time.Hour
+		inbox.AutoResolveAfter = &after
+		require.NoError(t, repos.Inboxes.Update(ctx, inbox))
+		saved, err := repos.Inboxes.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &after, saved.AutoResolveAfter)
+
+		now := time.Now().UTC()
+		stalest := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		stalest.LastMessageAt = now.Add(-72 * time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, stalest))
+		stale := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		stale.LastMessageAt = now.Add(-48 * time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, stale))
+		recent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		recent.LastMessageAt = now.Add(-time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, recent))
+		snoozed := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		snoozed.LastMessageAt = now.Add(-72 * time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, snoozed))
+		until := now.Add(time.Hour)
+		snoozed.SnoozedUntil = &until
+		require.NoError(t, repos.ConversationRefs.SetSnooze(ctx, snoozed))
+		other := testutil.NewTestConversation(tenant.ID, manual.ID)
+		other.LastMessageAt = now.Add(-72 * time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, other))
+
+		locked, err := repos.ConversationRefs.GetAndLockStale(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, locked, 2)
+		assert.Equal(t, stalest.ID, locked[0].ID, "stalest first")
+		assert.Equal(t, stale.ID, locked[1].ID)
+
+		locked[0].State = domain.ConversationStateResolved
+		locked[0].ResolvedAt = &now
+		locked[0].UpdatedAt = now
+		require.NoError(t, repos.ConversationRefs.Update(ctx, locked[0]))
+		locked, err = repos.ConversationRefs.GetAndLockStale(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, locked, 1, "resolved conversations are not locked again")
+		assert.Equal(t, stale.ID, locked[0].ID)
+
+		inbox.AutoResolveAfter = nil
+		require.NoError(t, repos.Inboxes.Update(ctx, inbox))
+		locked, err = repos.ConversationRefs.GetAndLockStale(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Empty(t, locked, "disabling auto-resolution stops it")
+	})
+}
+
+func TestInboxBusinessHours_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("business hours are stored, replaced and cleared", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		hours := &domain.BusinessHours{
+			Timezone: "Europe/Berlin",
+			Days: map[time.Weekday]domain.OpeningHours{
+				time.Monday:   {Open: 9 * time.Hour, Close: 17 * time.Hour},
+				time.Saturday: {Open: 10 * time.Hour, Close: 24 * time.Hour},
+			},
+		}
+		inbox := testutil.NewTestInbox(tenant.ID)
+		inbox.BusinessHours = hours
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		require.NoError(t, repos.Inboxes.Create(ctx, testutil.NewTestInbox(tenant.ID)))
+
+		saved, err := repos.Inboxes.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, hours, saved.BusinessHours)
+
+		saved.BusinessHours = &domain.BusinessHours{
+			Timezone: "UTC",
+			Days:     map[time.Weekday]domain.OpeningHours{time.Friday: {Open: 8 * time.Hour, Close: 12 * time.Hour}},
+		}
+		require.NoError(t, repos.Inboxes.Update(ctx, saved))
+		inboxes, err := repos.Inboxes.GetByTenantID(ctx, tenant.ID)
+		require.NoError(t, err)
+		require.Len(t, inboxes, 2)
+		for _, got := range inboxes {
+			if got.ID == inbox.ID {
+				assert.Equal(t, saved.BusinessHours, got.BusinessHours)
+			} else {
+				assert.Nil(t, got.BusinessHours)
+			}
+		}
+
+		saved.BusinessHours = nil
+		require.NoError(t, repos.Inboxes.Update(ctx, saved))
+		cleared, err := repos.Inboxes.GetByID(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Nil(t, cleared.BusinessHours)
+	})
+}
+
+func TestTenantGracePeriod_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("grace period round-trips and clears", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		stored, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.GracePeriod, "new tenants use the server default")
+
+		gracePeriod := 15 * time.Minute
+		tenant.GracePeriod = &gracePeriod
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		stored, err = repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.GracePeriod)
+		assert.Equal(t, 15*time.Minute, *stored.GracePeriod)
+
+		tenant.GracePeriod = nil
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		stored, err = repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.GracePeriod)
+	})
+}
+
+func TestTenantAllocationEngine_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("engine round-trips and v2 takes breached conversations first", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		stored, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AllocationEngineV1, stored.AllocationEngine, "new tenants use v1")
+
+		tenant.AllocationEngine = domain.AllocationEngineV2
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		stored, err = repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.AllocationEngineV2, stored.AllocationEngine)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		urgent.PriorityScore = decimal.NewFromInt(90)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, urgent))
+		breached := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		breached.PriorityScore = decimal.NewFromInt(10)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, breached))
+		_, err = pc.Pool.Exec(ctx, `UPDATE conversation_refs SET sla_breached_at = NOW() WHERE id = $1`, breached.ID)
+		require.NoError(t, err)
+
+		head, err := repos.ConversationRefs.PeekNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, urgent.ID, head.ID)
+
+		head, err = repos.ConversationRefs.PeekNextForAllocationBreachFirst(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, breached.ID, head.ID)
+
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		convs, err := repos.WithTx(tx).ConversationRefs.GetNextForAllocationBreachFirst(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, nil, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, breached.ID, convs[0].ID)
+		assert.Equal(t, urgent.ID, convs[1].ID)
+	})
+}
+
+func TestCustomerIntake_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("counts conversations the phone started in the inbox", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		limit := 2
+		tenant.IntakeLimitPerHour = &limit
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		stored, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.IntakeLimitPerHour)
+		assert.Equal(t, 2, *stored.IntakeLimitPerHour)
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		other := testutil.NewTestInbox(tenant.ID)
+		other.PhoneNumber = "+1234567891"
+		require.NoError(t, repos.Inboxes.Create(ctx, other))
+
+		now := time.Now().UTC()
+		old := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		old.CreatedAt = now.Add(-2 * time.Hour)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, old))
+		recent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		recent.CustomerPhoneNumber = old.CustomerPhoneNumber
+		recent.CreatedAt = now.Add(-10 * time.Minute)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, recent))
+		elsewhere := testutil.NewTestConversation(tenant.ID, other.ID)
+		elsewhere.CustomerPhoneNumber = old.CustomerPhoneNumber
+		require.NoError(t, repos.ConversationRefs.Create(ctx, elsewhere))
+
+		started, oldest, err := repos.ConversationRefs.CustomerIntakeSince(ctx, tenant.ID, inbox.ID, old.CustomerPhoneNumber, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), started)
+		require.NotNil(t, oldest)
+		assert.WithinDuration(t, recent.CreatedAt, *oldest, time.Millisecond)
+
+		started, oldest, err = repos.ConversationRefs.CustomerIntakeSince(ctx, tenant.ID, inbox.ID, "+15550000000", now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, started)
+		assert.Nil(t, oldest)
+	})
+}
+
+func TestOperatorDeviceRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("registering a token again moves the device", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		first := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, first))
+		second := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, second))
+
+		device, err := repos.OperatorDevices.Upsert(ctx, domain.NewOperatorDevice(tenant.ID, first.ID,
+			domain.DevicePlatformFCM, "token-1", []domain.EventType{domain.EventConversationAllocated}))
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EventType{domain.EventConversationAllocated}, device.EventTypes)
+
+		moved, err := repos.OperatorDevices.Upsert(ctx, domain.NewOperatorDevice(tenant.ID, second.ID,
+			domain.DevicePlatformFCM, "token-1", nil))
+		require.NoError(t, err)
+		assert.Equal(t, device.ID, moved.ID)
+		assert.Equal(t, second.ID, moved.OperatorID)
+		assert.Empty(t, moved.EventTypes)
+
+		count, err := repos.OperatorDevices.CountByOperatorID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		devices, err := repos.OperatorDevices.GetByOperatorID(ctx, second.ID)
+		require.NoError(t, err)
+		require.Len(t, devices, 1)
+
+		moved.EventTypes = []domain.EventType{domain.EventConversationReassigned}
+		require.NoError(t, repos.OperatorDevices.UpdateEventTypes(ctx, moved))
+		stored, err := repos.OperatorDevices.GetByID(ctx, moved.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EventType{domain.EventConversationReassigned}, stored.EventTypes)
+
+		require.NoError(t, repos.OperatorDevices.DeleteByToken(ctx, "token-1"))
+		_, err = repos.OperatorDevices.GetByID(ctx, moved.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestCustomerOperatorAffinity_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("queued conversations of recently handled customers", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		other := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, other))
+
+		returning, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550001111")
+		require.NoError(t, err)
+		stale, err := repos.Customers.GetOrCreate(ctx, tenant.ID, "+15550002222")
+		require.NoError(t, err)
+
+		now := time.Now().UTC()
+		require.NoError(t, repos.CustomerAffinities.Record(ctx, &domain.CustomerOperatorAffinity{
+			CustomerID: returning.ID, TenantID: tenant.ID, OperatorID: operator.ID, HandledAt: now.Add(-time.Hour),
+		}))
+		// An older resolution processed late keeps the newer affinity
+		require.NoError(t, repos.CustomerAffinities.Record(ctx, &domain.CustomerOperatorAffinity{
+			CustomerID: returning.ID, TenantID: tenant.ID, OperatorID: other.ID, HandledAt: now.Add(-2 * time.Hour),
+		}))
+		require.NoError(t, repos.CustomerAffinities.Record(ctx, &domain.CustomerOperatorAffinity{
+			CustomerID: stale.ID, TenantID: tenant.ID, OperatorID: operator.ID, HandledAt: now.Add(-96 * time.Hour),
+		}))
+
+		affine := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		affine.CustomerID = &returning.ID
+		require.NoError(t, repos.ConversationRefs.Create(ctx, affine))
+		expired := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		expired.CustomerID = &stale.ID
+		require.NoError(t, repos.ConversationRefs.Create(ctx, expired))
+		require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, inbox.ID)))
+
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+		txConvs := repos.WithTx(tx).ConversationRefs
+
+		convs, err := txConvs.GetNextAffineForAllocation(ctx, tenant.ID, operator.ID, []uuid.UUID{inbox.ID}, nil, now.Add(-72*time.Hour), 5)
+		require.NoError(t, err)
+		require.Len(t, convs, 1)
+		assert.Equal(t, affine.ID, convs[0].ID)
+
+		convs, err = txConvs.GetNextAffineForAllocation(ctx, tenant.ID, other.ID, []uuid.UUID{inbox.ID}, nil, now.Add(-72*time.Hour), 5)
+		require.NoError(t, err)
+		assert.Empty(t, convs, "the later resolution holds the affinity")
+	})
+}
+
+func TestDeletionImpactRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("counts dependents of inboxes, operators and labels", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+		require.NoError(t, repos.Subscriptions.Create(ctx, testutil.NewTestSubscription(operator.ID, inbox.ID)))
+
+		allocated := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &operator.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, allocated))
+		resolved := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateResolved, &operator.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, resolved))
+		require.NoError(t, repos.GracePeriodAssignments.Create(ctx,
+			testutil.NewTestGracePeriod(allocated.ID, operator.ID, time.Now().UTC().Add(time.Minute))))
+
+		label := testutil.NewTestLabel(tenant.ID, inbox.ID)
+		require.NoError(t, repos.Labels.Create(ctx, label))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(resolved.ID, label)))
+		require.NoError(t, repos.RoutingRules.Create(ctx, domain.NewRoutingRule(tenant.ID, nil, "tag long threads",
+			domain.RoutingRuleTriggerMessageReceived, domain.RuleFieldMessageCount, domain.RuleOperatorGreaterThan, 10,
+			&label.Name, decimal.Zero, nil)))
+
+		impact, err := repos.DeletionImpacts.ForInbox(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), impact.Conversations)
+		assert.Equal(t, int64(1), impact.OpenConversations)
+		assert.Equal(t, int64(1), impact.Subscriptions)
+		assert.Zero(t, impact.RoutingRules)
+		assert.Equal(t, int64(1), impact.GracePeriods)
+
+		impact, err = repos.DeletionImpacts.ForOperator(ctx, operator.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), impact.Conversations)
+		assert.Equal(t, int64(1), impact.OpenConversations)
+		assert.Equal(t, int64(1), impact.Subscriptions)
+		assert.Equal(t, int64(1), impact.GracePeriods)
+
+		impact, err = repos.DeletionImpacts.ForLabel(ctx, label.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), impact.Conversations)
+		assert.Zero(t, impact.OpenConversations)
+		assert.Equal(t, int64(1), impact.RoutingRules)
+		assert.Equal(t, []string{"1 routing rule"}, impact.Blockers())
+
+		_, err = repos.DeletionImpacts.ForLabel(ctx, uuid.New())
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestConversationUrgency_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("workload is paged by urgency as of one time", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		resolution := 4 * time.Hour
+		require.NoError(t, repos.InboxSLAPolicies.Upsert(ctx, domain.NewInboxSLAPolicy(tenant.ID, inbox.ID, nil, &resolution, nil)))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		asOf := time.Now().UTC().Truncate(time.Second)
+		allocated := func(age time.Duration, messages int32) *domain.ConversationRef {
+			conv := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &operator.ID)
+			conv.CreatedAt = asOf.Add(-age)
+			conv.LastMessageAt = asOf.Add(-age / 2)
+			conv.MessageCount = messages
+			require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+			return conv
+		}
+		breached := allocated(6*time.Hour, 3) // SLA 1, age 0.25, unread
+		read := allocated(2*time.Hour, 3)     // SLA 0.5, age ~0.08, read
+		unread := allocated(2*time.Hour, 3)   // as read but unread
+		twin := allocated(2*time.Hour, 3)     // ties with unread
+		require.NoError(t, repos.ConversationReads.MarkRead(ctx, domain.NewConversationRead(read, operator.ID)))
+		other := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, other))
+		notMine := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &other.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, notMine))
+
+		state := domain.ConversationStateAllocated
+		filters := ConversationFilters{
+			TenantID:        tenant.ID,
+			State:           &state,
+			OperatorID:      &operator.ID,
+			UrgencyAsOf:     &asOf,
+			UrgencyReaderID: operator.ID,
+			SortOrder:       "urgency",
+			Limit:           2,
+		}
+		first, err := repos.ConversationRefs.ListWithUrgency(ctx, filters)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.Equal(t, breached.ID, first[0].Conversation.ID)
+		assert.InDelta(t, 1.0, first[0].SLA, 1e-9)
+		assert.InDelta(t, 0.25, first[0].Age, 1e-9)
+		assert.True(t, first[0].Unread)
+		assert.InDelta(t, domain.UrgencyWeightSLA+domain.UrgencyWeightUnread+0.25*domain.UrgencyWeightAge, first[0].Score, 1e-9)
+
+		// unread and twin tie on score and age, so the lower ID goes first
+		lower, higher := unread, twin
+		if twin.ID.String() < unread.ID.String() {
+			lower, higher = twin, unread
+		}
+		assert.Equal(t, lower.ID, first[1].Conversation.ID)
+
+		last := first[1]
+		filters.CursorUrgency = &last.Score
+		filters.CursorTimestamp = &last.Conversation.CreatedAt
+		filters.CursorID = &last.Conversation.ID
+		second, err := repos.ConversationRefs.ListWithUrgency(ctx, filters)
+		require.NoError(t, err)
+		require.Len(t, second, 2)
+		assert.Equal(t, higher.ID, second[0].Conversation.ID)
+		assert.Equal(t, read.ID, second[1].Conversation.ID)
+		assert.False(t, second[1].Unread)
+	})
+}
+
+func TestConversationTransferRepository_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("one pending transfer per conversation, expired by the worker query", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		from := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, from))
+		to := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, to))
+
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+
+		note := "Invoice question"
+		transfer := domain.NewConversationTransfer(conv, from.ID, to.ID, &note, time.Minute)
+		require.NoError(t, repos.Transfers.Create(ctx, transfer))
+
+		err := repos.Transfers.Create(ctx, domain.NewConversationTransfer(conv, from.ID, to.ID, nil, time.Minute))
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		pending, err := repos.Transfers.ListPending(ctx, tenant.ID, to.ID)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, transfer.ID, pending[0].ID)
+		assert.Equal(t, &note, pending[0].Note)
+
+		expired, err := repos.Transfers.ExpireDue(ctx, time.Now().UTC(), 10)
+		require.NoError(t, err)
+		assert.Empty(t, expired, "the transfer has not expired yet")
+
+		expired, err = repos.Transfers.ExpireDue(ctx, transfer.ExpiresAt.Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, domain.TransferStatusExpired, expired[0].Status)
+		assert.NotNil(t, expired[0].RespondedAt)
+
+		pending, err = repos.Transfers.ListPending(ctx, tenant.ID, from.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		// Ended transfers no longer block a new one
+		require.NoError(t, repos.Transfers.Create(ctx, domain.NewConversationTransfer(conv, from.ID, to.ID, nil, time.Minute)))
+	})
+}
+
+func TestTenantSandbox_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("sandbox flag round-trips and reset deletes only the tenant's data", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		stored, err := repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.Sandbox, "new tenants are not sandboxes")
+
+		tenant.Sandbox = true
+		require.NoError(t, repos.Tenants.Update(ctx, tenant))
+		stored, err = repos.Tenants.GetByID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.Sandbox)
+
+		other := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, other))
+
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		conv := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+		_, err = repos.Customers.GetOrCreate(ctx, tenant.ID, "+12025550110")
+		require.NoError(t, err)
+
+		otherInbox := testutil.NewTestInbox(other.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, otherInbox))
+		otherCustomer, err := repos.Customers.GetOrCreate(ctx, other.ID, "+12025550110")
+		require.NoError(t, err)
+
+		require.NoError(t, repos.Inboxes.DeleteByTenantID(ctx, tenant.ID))
+		require.NoError(t, repos.Customers.DeleteByTenantID(ctx, tenant.ID))
+
+		inboxes, err := repos.Inboxes.GetByTenantID(ctx, tenant.ID)
+		require.NoError(t, err)
+		assert.Empty(t, inboxes)
+		_, err = repos.ConversationRefs.GetByID(ctx, conv.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound, "conversations go with their inbox")
+		_, err = repos.Customers.GetByPhone(ctx, tenant.ID, "+12025550110")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+
+		otherInboxes, err := repos.Inboxes.GetByTenantID(ctx, other.ID)
+		require.NoError(t, err)
+		require.Len(t, otherInboxes, 1)
+		kept, err := repos.Customers.GetByPhone(ctx, other.ID, "+12025550110")
+		require.NoError(t, err)
+		assert.Equal(t, otherCustomer.ID, kept.ID)
+	})
+}
+
+func TestAllocationByLabels_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("only labeled conversations are locked, in engine order", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		billing := domain.NewLabel(tenant.ID, inbox.ID, "billing", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, billing))
+		refunds := domain.NewLabel(tenant.ID, inbox.ID, "refunds", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, refunds))
+
+		unlabeled := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		unlabeled.PriorityScore = decimal.NewFromInt(100)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, unlabeled))
+
+		urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		urgent.PriorityScore = decimal.NewFromInt(50)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, urgent))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(urgent.ID, billing)))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(urgent.ID, refunds)))
+
+		breached := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		breached.PriorityScore = decimal.NewFromInt(1)
+		breachedAt := time.Now().UTC().Add(-time.Minute)
+		breached.SLABreachedAt = &breachedAt
+		require.NoError(t, repos.ConversationRefs.Create(ctx, breached))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(breached.ID, refunds)))
+
+		labelIDs := []uuid.UUID{billing.ID, refunds.ID}
+
+		tx, err := pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		convs, err := repos.WithTx(tx).ConversationRefs.GetNextForAllocationByLabels(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, labelIDs, false, 5)
+		require.NoError(t, err)
+		require.Len(t, convs, 2, "a conversation with both labels is returned once")
+		assert.Equal(t, urgent.ID, convs[0].ID)
+		assert.Equal(t, breached.ID, convs[1].ID)
+		require.NoError(t, tx.Rollback(ctx))
+
+		tx, err = pc.Pool.Begin(ctx)
+		require.NoError(t, err)
+		convs, err = repos.WithTx(tx).ConversationRefs.GetNextForAllocationByLabels(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, labelIDs, true, 5)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, breached.ID, convs[0].ID, "breached conversations go first with breachFirst")
+		require.NoError(t, tx.Rollback(ctx))
+
+		convs, err = repos.ConversationRefs.GetNextForAllocationByLabels(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, []uuid.UUID{uuid.New()}, false, 5)
+		require.NoError(t, err)
+		assert.Empty(t, convs)
+	})
+}
+
+func TestLabelHierarchy_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("a parent label matches conversations tagged with any descendant", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		billing := domain.NewLabel(tenant.ID, inbox.ID, "billing", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, billing))
+		refunds := domain.NewLabel(tenant.ID, inbox.ID, "refunds", nil, nil)
+		refunds.ParentLabelID = &billing.ID
+		require.NoError(t, repos.Labels.Create(ctx, refunds))
+		late := domain.NewLabel(tenant.ID, inbox.ID, "late refunds", nil, nil)
+		late.ParentLabelID = &refunds.ID
+		require.NoError(t, repos.Labels.Create(ctx, late))
+		shipping := domain.NewLabel(tenant.ID, inbox.ID, "shipping", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, shipping))
+
+		stored, err := repos.Labels.GetByID(ctx, late.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.ParentLabelID)
+		assert.Equal(t, refunds.ID, *stored.ParentLabelID)
+
+		ids, err := repos.Labels.GetDescendantIDs(ctx, []uuid.UUID{billing.ID})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{billing.ID, refunds.ID, late.ID}, ids)
+
+		tagged := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, tagged))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(tagged.ID, late)))
+		other := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, other))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(other.ID, shipping)))
+
+		convs, err := repos.ConversationRefs.ListWithFilters(ctx, ConversationFilters{TenantID: tenant.ID, LabelIDs: ids})
+		require.NoError(t, err)
+		require.Len(t, convs, 1)
+		assert.Equal(t, tagged.ID, convs[0].ID)
+
+		// Deleting a label makes its children top-level labels
+		require.NoError(t, repos.Labels.Delete(ctx, refunds.ID))
+		stored, err = repos.Labels.GetByID(ctx, late.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.ParentLabelID)
+
+		stored.ParentLabelID = &shipping.ID
+		require.NoError(t, repos.Labels.Update(ctx, stored))
+		ids, err = repos.Labels.GetDescendantIDs(ctx, []uuid.UUID{shipping.ID})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{shipping.ID, late.ID}, ids)
+	})
+}
+
+func TestTenantLabels_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("a tenant label is listed and counted in every inbox", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		sales := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, sales))
+		support := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, support))
+
+		vip := domain.NewTenantLabel(tenant.ID, "vip", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, vip))
+		// Inbox labels may share the name of a tenant label
+		require.NoError(t, repos.Labels.Create(ctx, domain.NewLabel(tenant.ID, sales.ID, "vip", nil, nil)))
+
+		found, err := repos.Labels.GetTenantScopedByName(ctx, tenant.ID, "vip")
+		require.NoError(t, err)
+		assert.Equal(t, vip.ID, found.ID)
+		assert.Equal(t, domain.LabelScopeTenant, found.Scope)
+		assert.Equal(t, uuid.Nil, found.InboxID)
+
+		labels, err := repos.Labels.GetByInboxID(ctx, tenant.ID, support.ID)
+		require.NoError(t, err)
+		require.Len(t, labels, 1)
+		assert.Equal(t, vip.ID, labels[0].ID)
+
+		conv := testutil.NewTestConversation(tenant.ID, support.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, conv))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(conv.ID, vip)))
+
+		now := time.Now().UTC()
+		counts, err := repos.ConversationLabels.CountByVersion(ctx, tenant.ID, support.ID, now.Add(-time.Hour), now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, counts, 1)
+		assert.Equal(t, 1, counts[0].Attachments)
+	})
+
+	t.Run("tenant label names are unique per tenant", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+
+		require.NoError(t, repos.Labels.Create(ctx, domain.NewTenantLabel(tenant.ID, "vip", nil, nil)))
+		assert.Error(t, repos.Labels.Create(ctx, domain.NewTenantLabel(tenant.ID, "vip", nil, nil)))
+	})
+}
+
+func TestConversationStaleness_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("clocks start, idle allocations are locked and activity clears the flag", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+		untracked := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, untracked))
+		operator := testutil.NewTestOperator(tenant.ID, domain.OperatorRoleOperator)
+		require.NoError(t, repos.Operators.Create(ctx, operator))
+
+		require.NoError(t, repos.InboxStalenessPolicies.Upsert(ctx,
+			domain.NewInboxStalenessPolicy(tenant.ID, inbox.ID, 15*time.Minute, nil, nil)))
+		policy, err := repos.InboxStalenessPolicies.Get(ctx, inbox.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, policy.StaleAfter)
+		assert.Nil(t, policy.DeallocateGrace)
+
+		allocated := testutil.NewTestConversationWithState(tenant.ID, inbox.ID, domain.ConversationStateAllocated, &operator.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, allocated))
+		require.NoError(t, repos.ConversationRefs.Create(ctx, testutil.NewTestConversation(tenant.ID, inbox.ID)))
+		elsewhere := testutil.NewTestConversationWithState(tenant.ID, untracked.ID, domain.ConversationStateAllocated, &operator.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, elsewhere))
+
+		now := time.Now().UTC()
+		started, err := repos.ConversationActivity.StartClocks(ctx, now.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), started, "only allocations in inboxes with a policy")
+		started, err = repos.ConversationActivity.StartClocks(ctx, now)
+		require.NoError(t, err)
+		assert.Zero(t, started, "running clocks are kept")
+
+		stale, err := repos.ConversationActivity.GetAndLockStale(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		assert.Equal(t, allocated.ID, stale[0].ConversationID)
+		assert.Equal(t, domain.ActivityKindAllocated, stale[0].LastKind)
+
+		require.NoError(t, repos.ConversationActivity.MarkStale(ctx, allocated.ID, now))
+		stale, err = repos.ConversationActivity.GetAndLockStale(ctx, now, 10)
+		require.NoError(t, err)
+		assert.Empty(t, stale, "flagged allocations are not locked again")
+		count, err := repos.ConversationActivity.CountStale(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		require.NoError(t, repos.ConversationActivity.Record(ctx,
+			domain.NewConversationActivity(allocated.ID, operator.ID, domain.ActivityKindMessage, now)))
+		activity, err := repos.ConversationActivity.Get(ctx, allocated.ID)
+		require.NoError(t, err)
+		assert.Nil(t, activity.StaleAt, "activity clears the flag")
+		assert.Equal(t, domain.ActivityKindMessage, activity.LastKind)
+		assert.WithinDuration(t, now, activity.LastActivityAt, time.Millisecond)
+
+		require.NoError(t, allocated.Deallocate())
+		require.NoError(t, repos.ConversationRefs.Update(ctx, allocated))
+		pruned, err := repos.ConversationActivity.Prune(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), pruned)
+		_, err = repos.ConversationActivity.Get(ctx, allocated.ID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestConversationLabelLoading_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	pc := testutil.NewPostgresContainer(t)
+	ctx := testutil.TestContext(t)
+
+	t.Cleanup(func() {
+		pc.CleanTables(ctx)
+	})
+
+	t.Run("labels load per conversation and for a whole page", func(t *testing.T) {
+		repos := NewRepositoryContainer(pc.Pool)
+
+		tenant := testutil.NewTestTenant()
+		require.NoError(t, repos.Tenants.Create(ctx, tenant))
+		inbox := testutil.NewTestInbox(tenant.ID)
+		require.NoError(t, repos.Inboxes.Create(ctx, inbox))
+
+		vip := domain.NewLabel(tenant.ID, inbox.ID, "vip", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, vip))
+		billing := domain.NewTenantLabel(tenant.ID, "billing", nil, nil)
+		require.NoError(t, repos.Labels.Create(ctx, billing))
+
+		both := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, both))
+		one := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, one))
+		none := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		require.NoError(t, repos.ConversationRefs.Create(ctx, none))
+
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(both.ID, vip)))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(both.ID, billing)))
+		require.NoError(t, repos.ConversationLabels.Create(ctx, domain.NewConversationLabel(one.ID, vip)))
+
+		labels, err := repos.ConversationLabels.GetLabels(ctx, both.ID)
+		require.NoError(t, err)
+		require.Len(t, labels, 2)
+		assert.Equal(t, "billing", labels[0].Name, "by name, tenant labels included")
+		assert.Equal(t, domain.LabelScopeTenant, labels[0].Scope)
+		assert.Equal(t, "vip", labels[1].Name)
+
+		byConversation, err := repos.ConversationLabels.GetLabelsForConversations(ctx, []uuid.UUID{both.ID, one.ID, none.ID})
+		require.NoError(t, err)
+		assert.Len(t, byConversation[both.ID], 2)
+		require.Len(t, byConversation[one.ID], 1)
+		assert.Equal(t, vip.ID, byConversation[one.ID][0].ID)
+		assert.NotContains(t, byConversation, none.ID)
+
+		empty, err := repos.ConversationLabels.GetLabelsForConversations(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, empty)
 	})
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type InvariantRepositoryImpl struct {
+	q *Queries
+}
+
+func NewInvariantRepository(q *Queries) *InvariantRepositoryImpl {
+	return &InvariantRepositoryImpl{q: q}
+}
+
+func (r *InvariantRepositoryImpl) FindAllocatedWithoutOperator(ctx context.Context, tenantID uuid.UUID, limit int) ([]*domain.InvariantViolation, error) {
+	rows, err := r.q.FindAllocatedWithoutOperator(ctx, FindAllocatedWithoutOperatorParams{
+		TenantID: uuidToPgtype(tenantID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	violations := make([]*domain.InvariantViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = toInvariantViolation(domain.InvariantAllocatedWithoutOperator,
+			row.ID, row.ExternalConversationID, row.InboxID, row.State, row.AssignedOperatorID)
+	}
+	return violations, nil
+}
+
+func (r *InvariantRepositoryImpl) FindOperatorNotSubscribed(ctx context.Context, tenantID uuid.UUID, limit int) ([]*domain.InvariantViolation, error) {
+	rows, err := r.q.FindOperatorNotSubscribed(ctx, FindOperatorNotSubscribedParams{
+		TenantID: uuidToPgtype(tenantID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	violations := make([]*domain.InvariantViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = toInvariantViolation(domain.InvariantOperatorNotSubscribed,
+			row.ID, row.ExternalConversationID, row.InboxID, row.State, row.AssignedOperatorID)
+	}
+	return violations, nil
+}
+
+func (r *InvariantRepositoryImpl) FindResolvedWithGracePeriod(ctx context.Context, tenantID uuid.UUID, limit int) ([]*domain.InvariantViolation, error) {
+	rows, err := r.q.FindResolvedWithGracePeriod(ctx, FindResolvedWithGracePeriodParams{
+		TenantID: uuidToPgtype(tenantID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	violations := make([]*domain.InvariantViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = toInvariantViolation(domain.InvariantResolvedWithGracePeriod,
+			row.ID, row.ExternalConversationID, row.InboxID, row.State, row.OperatorID)
+	}
+	return violations, nil
+}
+
+func (r *InvariantRepositoryImpl) FindDuplicateExternalIDs(ctx context.Context, tenantID uuid.UUID, limit int) ([]*domain.InvariantViolation, error) {
+	rows, err := r.q.FindDuplicateExternalIDs(ctx, FindDuplicateExternalIDsParams{
+		TenantID: uuidToPgtype(tenantID),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	violations := make([]*domain.InvariantViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = toInvariantViolation(domain.InvariantDuplicateExternalID,
+			row.ID, row.ExternalConversationID, row.InboxID, row.State, row.AssignedOperatorID)
+	}
+	return violations, nil
+}
+
+func toInvariantViolation(kind domain.InvariantKind, id pgtype.UUID, externalID string, inboxID pgtype.UUID, state ConversationState, operatorID pgtype.UUID) *domain.InvariantViolation {
+	return &domain.InvariantViolation{
+		Kind:                   kind,
+		ConversationID:         pgtypeToUUID(id),
+		ExternalConversationID: externalID,
+		InboxID:                pgtypeToUUID(inboxID),
+		State:                  domain.ConversationState(state),
+		OperatorID:             pgtypeToUUIDPtr(operatorID),
+	}
+}
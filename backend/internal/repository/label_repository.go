@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type LabelRepositoryImpl struct {
@@ -16,14 +17,21 @@ func NewLabelRepository(q *Queries) *LabelRepositoryImpl {
 }
 
 func (r *LabelRepositoryImpl) Create(ctx context.Context, label *domain.Label) error {
+	// Tenant-scoped labels have no inbox
+	inboxID := uuidToPgtype(label.InboxID)
+	if label.Scope == domain.LabelScopeTenant {
+		inboxID = pgtype.UUID{}
+	}
 	return r.q.CreateLabel(ctx, CreateLabelParams{
-		ID:        uuidToPgtype(label.ID),
-		TenantID:  uuidToPgtype(label.TenantID),
-		InboxID:   uuidToPgtype(label.InboxID),
-		Name:      label.Name,
-		Color:     stringPtrToPgtype(label.Color),
-		CreatedBy: uuidPtrToPgtype(label.CreatedBy),
-		CreatedAt: timeToPgtype(label.CreatedAt),
+		ID:            uuidToPgtype(label.ID),
+		TenantID:      uuidToPgtype(label.TenantID),
+		InboxID:       inboxID,
+		Name:          label.Name,
+		Color:         stringPtrToPgtype(label.Color),
+		CreatedBy:     uuidPtrToPgtype(label.CreatedBy),
+		CreatedAt:     timeToPgtype(label.CreatedAt),
+		ParentLabelID: uuidPtrToPgtype(label.ParentLabelID),
+		Scope:         string(label.Scope),
 	})
 }
 
@@ -35,6 +43,8 @@ func (r *LabelRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domai
 	return r.toDomain(row), nil
 }
 
+// GetByInboxID returns the labels of the inbox, including the tenant-scoped
+// labels that apply to it
 func (r *LabelRepositoryImpl) GetByInboxID(ctx context.Context, tenantID, inboxID uuid.UUID) ([]*domain.Label, error) {
 	rows, err := r.q.GetLabelsByInboxID(ctx, GetLabelsByInboxIDParams{
 		TenantID: uuidToPgtype(tenantID),
@@ -62,26 +72,139 @@ func (r *LabelRepositoryImpl) GetByName(ctx context.Context, inboxID uuid.UUID,
 	return r.toDomain(row), nil
 }
 
-func (r *LabelRepositoryImpl) Update(ctx context.Context, label *domain.Label) error {
-	return r.q.UpdateLabel(ctx, UpdateLabelParams{
-		ID:    uuidToPgtype(label.ID),
-		Name:  label.Name,
-		Color: stringPtrToPgtype(label.Color),
+// GetTenantScoped returns the tenant's tenant-scoped labels
+func (r *LabelRepositoryImpl) GetTenantScoped(ctx context.Context, tenantID uuid.UUID) ([]*domain.Label, error) {
+	rows, err := r.q.GetTenantLabels(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	labels := make([]*domain.Label, len(rows))
+	for i, row := range rows {
+		labels[i] = r.toDomain(row)
+	}
+	return labels, nil
+}
+
+// GetTenantScopedByName returns the tenant-scoped label with the given name
+func (r *LabelRepositoryImpl) GetTenantScopedByName(ctx context.Context, tenantID uuid.UUID, name string) (*domain.Label, error) {
+	row, err := r.q.GetTenantLabelByName(ctx, GetTenantLabelByNameParams{
+		TenantID: uuidToPgtype(tenantID),
+		Name:     name,
 	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// GetOrCreateByName returns the inbox label with the given name, creating it
+// (without color or creator) when it does not exist yet
+func (r *LabelRepositoryImpl) GetOrCreateByName(ctx context.Context, tenantID, inboxID uuid.UUID, name string) (*domain.Label, error) {
+	label := domain.NewLabel(tenantID, inboxID, name, nil, nil)
+	if err := r.q.CreateLabelIfNotExists(ctx, CreateLabelIfNotExistsParams{
+		ID:        uuidToPgtype(label.ID),
+		TenantID:  uuidToPgtype(label.TenantID),
+		InboxID:   uuidToPgtype(label.InboxID),
+		Name:      label.Name,
+		Color:     stringPtrToPgtype(label.Color),
+		CreatedBy: uuidPtrToPgtype(label.CreatedBy),
+		CreatedAt: timeToPgtype(label.CreatedAt),
+	}); err != nil {
+		return nil, mapError(err)
+	}
+	return r.GetByName(ctx, inboxID, name)
+}
+
+// LockForUpdate loads a label and locks its row until the transaction ends
+func (r *LabelRepositoryImpl) LockForUpdate(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
+	row, err := r.q.GetLabelByIDForUpdate(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *LabelRepositoryImpl) Update(ctx context.Context, label *domain.Label) error {
+	return mapError(r.q.UpdateLabel(ctx, UpdateLabelParams{
+		ID:            uuidToPgtype(label.ID),
+		Name:          label.Name,
+		Color:         stringPtrToPgtype(label.Color),
+		Version:       int32(label.Version),
+		ParentLabelID: uuidPtrToPgtype(label.ParentLabelID),
+	}))
+}
+
+// GetDescendantIDs returns the labels and every label nested below them
+func (r *LabelRepositoryImpl) GetDescendantIDs(ctx context.Context, labelIDs []uuid.UUID) ([]uuid.UUID, error) {
+	pgtypeIDs := make([]pgtype.UUID, len(labelIDs))
+	for i, id := range labelIDs {
+		pgtypeIDs[i] = uuidToPgtype(id)
+	}
+
+	rows, err := r.q.GetLabelDescendantIDs(ctx, pgtypeIDs)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
 }
 
 func (r *LabelRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.q.DeleteLabel(ctx, uuidToPgtype(id))
 }
 
+func (r *LabelRepositoryImpl) CreateVersion(ctx context.Context, version *domain.LabelVersion) error {
+	return mapError(r.q.CreateLabelVersion(ctx, CreateLabelVersionParams{
+		LabelID:   uuidToPgtype(version.LabelID),
+		Version:   int32(version.Version),
+		Name:      version.Name,
+		Color:     stringPtrToPgtype(version.Color),
+		ChangedBy: uuidPtrToPgtype(version.ChangedBy),
+		CreatedAt: timeToPgtype(version.CreatedAt),
+	}))
+}
+
+// ListVersions returns every version of a label, oldest first
+func (r *LabelRepositoryImpl) ListVersions(ctx context.Context, labelID uuid.UUID) ([]*domain.LabelVersion, error) {
+	rows, err := r.q.ListLabelVersions(ctx, uuidToPgtype(labelID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	versions := make([]*domain.LabelVersion, len(rows))
+	for i, row := range rows {
+		versions[i] = &domain.LabelVersion{
+			LabelID:   pgtypeToUUID(row.LabelID),
+			Version:   int(row.Version),
+			Name:      row.Name,
+			Color:     pgtypeToStringPtr(row.Color),
+			ChangedBy: pgtypeToUUIDPtr(row.ChangedBy),
+			CreatedAt: pgtypeToTime(row.CreatedAt),
+		}
+	}
+	return versions, nil
+}
+
 func (r *LabelRepositoryImpl) toDomain(row Label) *domain.Label {
+	return labelToDomain(row)
+}
+
+func labelToDomain(row Label) *domain.Label {
 	return &domain.Label{
-		ID:        pgtypeToUUID(row.ID),
-		TenantID:  pgtypeToUUID(row.TenantID),
-		InboxID:   pgtypeToUUID(row.InboxID),
-		Name:      row.Name,
-		Color:     pgtypeToStringPtr(row.Color),
-		CreatedBy: pgtypeToUUIDPtr(row.CreatedBy),
-		CreatedAt: pgtypeToTime(row.CreatedAt),
+		ID:            pgtypeToUUID(row.ID),
+		TenantID:      pgtypeToUUID(row.TenantID),
+		InboxID:       pgtypeToUUID(row.InboxID),
+		Name:          row.Name,
+		Color:         pgtypeToStringPtr(row.Color),
+		CreatedBy:     pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt:     pgtypeToTime(row.CreatedAt),
+		Version:       int(row.Version),
+		ParentLabelID: pgtypeToUUIDPtr(row.ParentLabelID),
+		Scope:         domain.LabelScope(row.Scope),
 	}
 }
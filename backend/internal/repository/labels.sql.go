@@ -12,11 +12,55 @@ import (
 )
 
 const createLabel = `-- name: CreateLabel :exec
-INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+WITH created AS (
+    INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at, parent_label_id, scope)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    RETURNING id, name, color, created_by, created_at
+)
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+SELECT id, 1, name, color, created_by, created_at FROM created
 `
 
 type CreateLabelParams struct {
+	ID            pgtype.UUID        `json:"id"`
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	InboxID       pgtype.UUID        `json:"inbox_id"`
+	Name          string             `json:"name"`
+	Color         pgtype.Text        `json:"color"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	ParentLabelID pgtype.UUID        `json:"parent_label_id"`
+	Scope         string             `json:"scope"`
+}
+
+// Creates the label together with its first version
+func (q *Queries) CreateLabel(ctx context.Context, arg CreateLabelParams) error {
+	_, err := q.db.Exec(ctx, createLabel,
+		arg.ID,
+		arg.TenantID,
+		arg.InboxID,
+		arg.Name,
+		arg.Color,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.ParentLabelID,
+		arg.Scope,
+	)
+	return err
+}
+
+const createLabelIfNotExists = `-- name: CreateLabelIfNotExists :exec
+WITH created AS (
+    INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (inbox_id, name) WHERE scope = 'INBOX' DO NOTHING
+    RETURNING id, name, color, created_by, created_at
+)
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+SELECT id, 1, name, color, created_by, created_at FROM created
+`
+
+type CreateLabelIfNotExistsParams struct {
 	ID        pgtype.UUID        `json:"id"`
 	TenantID  pgtype.UUID        `json:"tenant_id"`
 	InboxID   pgtype.UUID        `json:"inbox_id"`
@@ -26,8 +70,9 @@ type CreateLabelParams struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
-func (q *Queries) CreateLabel(ctx context.Context, arg CreateLabelParams) error {
-	_, err := q.db.Exec(ctx, createLabel,
+// Used by routing rules: concurrent creators of the same label must not fail
+func (q *Queries) CreateLabelIfNotExists(ctx context.Context, arg CreateLabelIfNotExistsParams) error {
+	_, err := q.db.Exec(ctx, createLabelIfNotExists,
 		arg.ID,
 		arg.TenantID,
 		arg.InboxID,
@@ -39,6 +84,32 @@ func (q *Queries) CreateLabel(ctx context.Context, arg CreateLabelParams) error
 	return err
 }
 
+const createLabelVersion = `-- name: CreateLabelVersion :exec
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateLabelVersionParams struct {
+	LabelID   pgtype.UUID        `json:"label_id"`
+	Version   int32              `json:"version"`
+	Name      string             `json:"name"`
+	Color     pgtype.Text        `json:"color"`
+	ChangedBy pgtype.UUID        `json:"changed_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CreateLabelVersion(ctx context.Context, arg CreateLabelVersionParams) error {
+	_, err := q.db.Exec(ctx, createLabelVersion,
+		arg.LabelID,
+		arg.Version,
+		arg.Name,
+		arg.Color,
+		arg.ChangedBy,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const deleteLabel = `-- name: DeleteLabel :exec
 DELETE FROM labels WHERE id = $1
 `
@@ -49,7 +120,7 @@ func (q *Queries) DeleteLabel(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getLabelByID = `-- name: GetLabelByID :one
-SELECT id, tenant_id, inbox_id, name, color, created_by, created_at FROM labels WHERE id = $1
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE id = $1
 `
 
 func (q *Queries) GetLabelByID(ctx context.Context, id pgtype.UUID) (Label, error) {
@@ -63,12 +134,38 @@ func (q *Queries) GetLabelByID(ctx context.Context, id pgtype.UUID) (Label, erro
 		&i.Color,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Version,
+		&i.ParentLabelID,
+		&i.Scope,
+	)
+	return i, err
+}
+
+const getLabelByIDForUpdate = `-- name: GetLabelByIDForUpdate :one
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE id = $1 FOR UPDATE
+`
+
+// Serializes concurrent renames of the same label
+func (q *Queries) GetLabelByIDForUpdate(ctx context.Context, id pgtype.UUID) (Label, error) {
+	row := q.db.QueryRow(ctx, getLabelByIDForUpdate, id)
+	var i Label
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.Name,
+		&i.Color,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Version,
+		&i.ParentLabelID,
+		&i.Scope,
 	)
 	return i, err
 }
 
 const getLabelByName = `-- name: GetLabelByName :one
-SELECT id, tenant_id, inbox_id, name, color, created_by, created_at FROM labels WHERE inbox_id = $1 AND name = $2
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE inbox_id = $1 AND name = $2
 `
 
 type GetLabelByNameParams struct {
@@ -87,12 +184,45 @@ func (q *Queries) GetLabelByName(ctx context.Context, arg GetLabelByNameParams)
 		&i.Color,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.Version,
+		&i.ParentLabelID,
+		&i.Scope,
 	)
 	return i, err
 }
 
+const getLabelDescendantIDs = `-- name: GetLabelDescendantIDs :many
+WITH RECURSIVE tree AS (
+    SELECT l.id FROM labels l WHERE l.id = ANY($1::uuid[])
+    UNION
+    SELECT child.id FROM labels child JOIN tree ON child.parent_label_id = tree.id
+)
+SELECT id FROM tree
+`
+
+// Returns the labels and every label below them; UNION stops at cycles
+func (q *Queries) GetLabelDescendantIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getLabelDescendantIDs, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLabelsByInboxID = `-- name: GetLabelsByInboxID :many
-SELECT id, tenant_id, inbox_id, name, color, created_by, created_at FROM labels WHERE tenant_id = $1 AND inbox_id = $2 ORDER BY name
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE tenant_id = $1 AND (inbox_id = $2 OR scope = 'TENANT') ORDER BY name
 `
 
 type GetLabelsByInboxIDParams struct {
@@ -100,6 +230,7 @@ type GetLabelsByInboxIDParams struct {
 	InboxID  pgtype.UUID `json:"inbox_id"`
 }
 
+// The inbox's labels and the tenant-scoped labels, which apply to every inbox
 func (q *Queries) GetLabelsByInboxID(ctx context.Context, arg GetLabelsByInboxIDParams) ([]Label, error) {
 	rows, err := q.db.Query(ctx, getLabelsByInboxID, arg.TenantID, arg.InboxID)
 	if err != nil {
@@ -117,6 +248,102 @@ func (q *Queries) GetLabelsByInboxID(ctx context.Context, arg GetLabelsByInboxID
 			&i.Color,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.Version,
+			&i.ParentLabelID,
+			&i.Scope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTenantLabelByName = `-- name: GetTenantLabelByName :one
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE tenant_id = $1 AND scope = 'TENANT' AND name = $2
+`
+
+type GetTenantLabelByNameParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Name     string      `json:"name"`
+}
+
+func (q *Queries) GetTenantLabelByName(ctx context.Context, arg GetTenantLabelByNameParams) (Label, error) {
+	row := q.db.QueryRow(ctx, getTenantLabelByName, arg.TenantID, arg.Name)
+	var i Label
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.InboxID,
+		&i.Name,
+		&i.Color,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Version,
+		&i.ParentLabelID,
+		&i.Scope,
+	)
+	return i, err
+}
+
+const getTenantLabels = `-- name: GetTenantLabels :many
+SELECT id, tenant_id, inbox_id, name, color, created_by, created_at, version, parent_label_id, scope FROM labels WHERE tenant_id = $1 AND scope = 'TENANT' ORDER BY name
+`
+
+func (q *Queries) GetTenantLabels(ctx context.Context, tenantID pgtype.UUID) ([]Label, error) {
+	rows, err := q.db.Query(ctx, getTenantLabels, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Label{}
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.InboxID,
+			&i.Name,
+			&i.Color,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Version,
+			&i.ParentLabelID,
+			&i.Scope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabelVersions = `-- name: ListLabelVersions :many
+SELECT label_id, version, name, color, changed_by, created_at FROM label_versions WHERE label_id = $1 ORDER BY version
+`
+
+func (q *Queries) ListLabelVersions(ctx context.Context, labelID pgtype.UUID) ([]LabelVersion, error) {
+	rows, err := q.db.Query(ctx, listLabelVersions, labelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LabelVersion{}
+	for rows.Next() {
+		var i LabelVersion
+		if err := rows.Scan(
+			&i.LabelID,
+			&i.Version,
+			&i.Name,
+			&i.Color,
+			&i.ChangedBy,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -131,17 +358,27 @@ func (q *Queries) GetLabelsByInboxID(ctx context.Context, arg GetLabelsByInboxID
 const updateLabel = `-- name: UpdateLabel :exec
 UPDATE labels
 SET name = $2,
-    color = $3
+    color = $3,
+    version = $4,
+    parent_label_id = $5
 WHERE id = $1
 `
 
 type UpdateLabelParams struct {
-	ID    pgtype.UUID `json:"id"`
-	Name  string      `json:"name"`
-	Color pgtype.Text `json:"color"`
+	ID            pgtype.UUID `json:"id"`
+	Name          string      `json:"name"`
+	Color         pgtype.Text `json:"color"`
+	Version       int32       `json:"version"`
+	ParentLabelID pgtype.UUID `json:"parent_label_id"`
 }
 
 func (q *Queries) UpdateLabel(ctx context.Context, arg UpdateLabelParams) error {
-	_, err := q.db.Exec(ctx, updateLabel, arg.ID, arg.Name, arg.Color)
+	_, err := q.db.Exec(ctx, updateLabel,
+		arg.ID,
+		arg.Name,
+		arg.Color,
+		arg.Version,
+		arg.ParentLabelID,
+	)
 	return err
 }
@@ -11,6 +11,49 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BackfillStatus string
+
+const (
+	BackfillStatusPENDING   BackfillStatus = "PENDING"
+	BackfillStatusRUNNING   BackfillStatus = "RUNNING"
+	BackfillStatusCOMPLETED BackfillStatus = "COMPLETED"
+)
+
+func (e *BackfillStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BackfillStatus(s)
+	case string:
+		*e = BackfillStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BackfillStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBackfillStatus struct {
+	BackfillStatus BackfillStatus `json:"backfill_status"`
+	Valid          bool           `json:"valid"` // Valid is true if BackfillStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBackfillStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BackfillStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BackfillStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBackfillStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BackfillStatus), nil
+}
+
 type ConversationState string
 
 const (
@@ -59,6 +102,8 @@ type GracePeriodReason string
 const (
 	GracePeriodReasonOFFLINE GracePeriodReason = "OFFLINE"
 	GracePeriodReasonMANUAL  GracePeriodReason = "MANUAL"
+	GracePeriodReasonAWAY    GracePeriodReason = "AWAY"
+	GracePeriodReasonSTALE   GracePeriodReason = "STALE"
 )
 
 func (e *GracePeriodReason) Scan(src interface{}) error {
@@ -144,6 +189,8 @@ type OperatorStatusType string
 const (
 	OperatorStatusTypeAVAILABLE OperatorStatusType = "AVAILABLE"
 	OperatorStatusTypeOFFLINE   OperatorStatusType = "OFFLINE"
+	OperatorStatusTypeBUSY      OperatorStatusType = "BUSY"
+	OperatorStatusTypeAWAY      OperatorStatusType = "AWAY"
 )
 
 func (e *OperatorStatusType) Scan(src interface{}) error {
@@ -181,11 +228,259 @@ func (ns NullOperatorStatusType) Value() (driver.Value, error) {
 	return string(ns.OperatorStatusType), nil
 }
 
+type QaReviewStatus string
+
+const (
+	QaReviewStatusPENDING   QaReviewStatus = "PENDING"
+	QaReviewStatusINREVIEW  QaReviewStatus = "IN_REVIEW"
+	QaReviewStatusCOMPLETED QaReviewStatus = "COMPLETED"
+)
+
+func (e *QaReviewStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QaReviewStatus(s)
+	case string:
+		*e = QaReviewStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QaReviewStatus: %T", src)
+	}
+	return nil
+}
+
+type NullQaReviewStatus struct {
+	QaReviewStatus QaReviewStatus `json:"qa_review_status"`
+	Valid          bool           `json:"valid"` // Valid is true if QaReviewStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQaReviewStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.QaReviewStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QaReviewStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQaReviewStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QaReviewStatus), nil
+}
+
+type RoutingRuleTrigger string
+
+const (
+	RoutingRuleTriggerMESSAGERECEIVED     RoutingRuleTrigger = "MESSAGE_RECEIVED"
+	RoutingRuleTriggerCONVERSATIONCREATED RoutingRuleTrigger = "CONVERSATION_CREATED"
+)
+
+func (e *RoutingRuleTrigger) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RoutingRuleTrigger(s)
+	case string:
+		*e = RoutingRuleTrigger(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RoutingRuleTrigger: %T", src)
+	}
+	return nil
+}
+
+type NullRoutingRuleTrigger struct {
+	RoutingRuleTrigger RoutingRuleTrigger `json:"routing_rule_trigger"`
+	Valid              bool               `json:"valid"` // Valid is true if RoutingRuleTrigger is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRoutingRuleTrigger) Scan(value interface{}) error {
+	if value == nil {
+		ns.RoutingRuleTrigger, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RoutingRuleTrigger.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRoutingRuleTrigger) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RoutingRuleTrigger), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPENDING    WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDELIVERED  WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusDEADLETTER WebhookDeliveryStatus = "DEAD_LETTER"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus `json:"webhook_delivery_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+// Journal of in-flight allocations for crash recovery and retry deduplication
+type AllocationIntent struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	OperatorID     pgtype.UUID `json:"operator_id"`
+	Kind           string      `json:"kind"`
+	IdempotencyKey pgtype.Text `json:"idempotency_key"`
+	// Claimed conversation, or the one selected by allocate
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Status         string      `json:"status"`
+	// Resolved by the recovery routine rather than the request
+	Recovered  bool               `json:"recovered"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+}
+
+// Unusual allocation patterns flagged for managers
+type Anomaly struct {
+	ID         pgtype.UUID `json:"id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	Kind       string      `json:"kind"`
+	OperatorID pgtype.UUID `json:"operator_id"`
+	Observed   int32       `json:"observed"`
+	Baseline   float64     `json:"baseline"`
+	// Count at or above which observed was flagged
+	Threshold     float64            `json:"threshold"`
+	WindowSeconds int32              `json:"window_seconds"`
+	DetectedAt    pgtype.Timestamptz `json:"detected_at"`
+}
+
+// Tenant API keys for service-to-service authentication
+type ApiKey struct {
+	ID        pgtype.UUID `json:"id"`
+	TenantID  pgtype.UUID `json:"tenant_id"`
+	Name      string      `json:"name"`
+	KeyPrefix string      `json:"key_prefix"`
+	// Hex SHA-256 of the key; the key itself is never stored
+	KeyHash   string             `json:"key_hash"`
+	Role      OperatorRole       `json:"role"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// Last authenticated request, updated at most once per minute
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+// Audit trail of mutating operations with before/after snapshots
+type AuditLog struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	// Operator who performed the action; NULL for system actions
+	ActorID     pgtype.UUID        `json:"actor_id"`
+	Action      string             `json:"action"`
+	EntityType  string             `json:"entity_type"`
+	EntityID    pgtype.UUID        `json:"entity_id"`
+	BeforeState []byte             `json:"before_state"`
+	AfterState  []byte             `json:"after_state"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// Last operator activity on allocated conversations, for stale allocation detection
+type ConversationActivity struct {
+	ConversationID   pgtype.UUID        `json:"conversation_id"`
+	OperatorID       pgtype.UUID        `json:"operator_id"`
+	LastActivityAt   pgtype.Timestamptz `json:"last_activity_at"`
+	LastActivityKind string             `json:"last_activity_kind"`
+	StaleAt          pgtype.Timestamptz `json:"stale_at"`
+}
+
+// Checklist items of a conversation, copied from its inbox template
+type ConversationChecklistItem struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Position       int32       `json:"position"`
+	Label          string      `json:"label"`
+	CompletedBy    pgtype.UUID `json:"completed_by"`
+	// When the item was ticked; NULL while unticked
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// Conversations escalated by operators, with the stated reason
+type ConversationEscalation struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	// Operator the conversation was assigned to when escalated
+	OperatorID pgtype.UUID `json:"operator_id"`
+	// Operator who escalated: the assignee, or a manager on their behalf
+	EscalatedBy pgtype.UUID        `json:"escalated_by"`
+	FromInboxID pgtype.UUID        `json:"from_inbox_id"`
+	ToInboxID   pgtype.UUID        `json:"to_inbox_id"`
+	Reason      string             `json:"reason"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type ConversationLabel struct {
 	ID             pgtype.UUID        `json:"id"`
 	ConversationID pgtype.UUID        `json:"conversation_id"`
 	LabelID        pgtype.UUID        `json:"label_id"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	// Label version at attach time; NULL for attachments older than versioning (version 1)
+	LabelVersion pgtype.Int4 `json:"label_version"`
+}
+
+// Internal notes on conversations, such as reassignment handovers
+type ConversationNote struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	Kind           string      `json:"kind"`
+	AuthorID       pgtype.UUID `json:"author_id"`
+	// Operator the note is addressed to; the new assignee for HANDOVER notes
+	RecipientID pgtype.UUID        `json:"recipient_id"`
+	Body        string             `json:"body"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+// Last view of each conversation per operator, for unread flags
+type ConversationRead struct {
+	OperatorID     pgtype.UUID        `json:"operator_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	LastReadAt     pgtype.Timestamptz `json:"last_read_at"`
 }
 
 type ConversationRef struct {
@@ -202,6 +497,100 @@ type ConversationRef struct {
 	CreatedAt              pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
 	ResolvedAt             pgtype.Timestamptz `json:"resolved_at"`
+	// Times the conversation returned to the queue after being resolved
+	ReopenedCount int32 `json:"reopened_count"`
+	// Intent category assigned by the tenant classifier
+	Category pgtype.Text `json:"category"`
+	// When the conversation first missed an SLA target of its inbox
+	SlaBreachedAt pgtype.Timestamptz `json:"sla_breached_at"`
+	// Allocation skips the conversation until this time
+	SnoozedUntil pgtype.Timestamptz `json:"snoozed_until"`
+	// Operator the conversation returns to when the snooze ends; NULL returns it to the queue
+	SnoozeOperatorID pgtype.UUID `json:"snooze_operator_id"`
+	// Manual priority set by a manager; allocated before any conversation without one
+	PriorityOverride pgtype.Numeric `json:"priority_override"`
+	// No earlier conversation with the customer's phone number existed when this one was ingested
+	IsFirstContact bool `json:"is_first_contact"`
+	// Incremented by every update; UpdateConversationRef only writes the version it read
+	Version int32 `json:"version"`
+	// Customer language (ISO 639 primary subtag) from the gateway or classifier
+	Language pgtype.Text `json:"language"`
+	// Customer who wrote in, by tenant and customer_phone_number
+	CustomerID pgtype.UUID `json:"customer_id"`
+	// Digits of customer_phone_number, for partial phone search
+	NormalizedPhone pgtype.Text `json:"normalized_phone"`
+	// When the conversation should be resolved by; NULL for no due date
+	DueAt pgtype.Timestamptz `json:"due_at"`
+	// When the overdue worker flagged the conversation past due_at; cleared when due_at changes
+	OverdueAt pgtype.Timestamptz `json:"overdue_at"`
+}
+
+// Signed, expiring read-only links to conversation snapshots
+type ConversationShareLink struct {
+	ID             pgtype.UUID        `json:"id"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	IncludePii     bool               `json:"include_pii"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt      pgtype.Timestamptz `json:"revoked_at"`
+	AccessCount    int32              `json:"access_count"`
+	LastAccessedAt pgtype.Timestamptz `json:"last_accessed_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+// Conversation handoffs proposed by the assignee, accepted or declined by the recipient
+type ConversationTransfer struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	FromOperatorID pgtype.UUID `json:"from_operator_id"`
+	ToOperatorID   pgtype.UUID `json:"to_operator_id"`
+	// Handed to the recipient as a HANDOVER note on acceptance
+	Note pgtype.Text `json:"note"`
+	// PENDING, ACCEPTED, DECLINED, EXPIRED or CANCELLED
+	Status      string             `json:"status"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	RespondedAt pgtype.Timestamptz `json:"responded_at"`
+}
+
+// Customer profiles keyed by tenant and phone number
+type Customer struct {
+	ID          pgtype.UUID `json:"id"`
+	TenantID    pgtype.UUID `json:"tenant_id"`
+	PhoneNumber string      `json:"phone_number"`
+	Name        pgtype.Text `json:"name"`
+	// Free-form attributes set by integrations (CRM ids, tiers)
+	Metadata  []byte             `json:"metadata"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Operator who last resolved a conversation of the customer, preferred on allocation
+type CustomerOperatorAffinity struct {
+	CustomerID pgtype.UUID `json:"customer_id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	OperatorID pgtype.UUID `json:"operator_id"`
+	// When the operator resolved the customer's last conversation
+	HandledAt pgtype.Timestamptz `json:"handled_at"`
+}
+
+// Domain events staged in the transaction of their state change, published at least once
+type EventOutbox struct {
+	// ID of the domain event, so consumers can deduplicate redeliveries
+	ID           pgtype.UUID        `json:"id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	EventType    string             `json:"event_type"`
+	Data         []byte             `json:"data"`
+	OccurredAt   pgtype.Timestamptz `json:"occurred_at"`
+	AttemptCount int32              `json:"attempt_count"`
+	// Earliest time the outbox worker may publish the entry
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	// When every sink accepted the event; NULL while pending
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type GracePeriodAssignment struct {
@@ -225,11 +614,19 @@ type IdempotencyKey struct {
 	RequestHash pgtype.Text `json:"request_hash"`
 	// HTTP status code of the original response
 	ResponseStatus int32 `json:"response_status"`
-	// Full JSON response body
+	// Full JSON response body; NULL when stored encrypted
 	ResponseBody []byte             `json:"response_body"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	// When this record can be cleaned up
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	// Encrypted response body (nonce prepended)
+	ResponseCiphertext []byte `json:"response_ciphertext"`
+	// Master key the response body is encrypted with; NULL for plaintext
+	EncryptionKeyID pgtype.Text `json:"encryption_key_id"`
+	// PENDING while the claiming request runs, COMPLETED once its response is stored
+	Status string `json:"status"`
+	// When a PENDING claim may be taken over; NULL once COMPLETED
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
 }
 
 type Inbox struct {
@@ -239,6 +636,85 @@ type Inbox struct {
 	DisplayName string             `json:"display_name"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	// Whether break-glass access to its conversations requires a stated reason
+	IsRestricted bool `json:"is_restricted"`
+	// Inbox escalated conversations are moved to; NULL disables escalation
+	EscalationInboxID pgtype.UUID `json:"escalation_inbox_id"`
+	// Idle time after the last message before open conversations are resolved; NULL disables auto-resolution
+	AutoResolveAfterSeconds pgtype.Int4 `json:"auto_resolve_after_seconds"`
+	// Weekly opening hours in a time zone; off-hours do not count towards the priority delay. NULL counts all time
+	BusinessHours []byte `json:"business_hours"`
+}
+
+// Operators delegated admin permissions on single inboxes
+type InboxAdmin struct {
+	OperatorID pgtype.UUID        `json:"operator_id"`
+	InboxID    pgtype.UUID        `json:"inbox_id"`
+	TenantID   pgtype.UUID        `json:"tenant_id"`
+	GrantedBy  pgtype.UUID        `json:"granted_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Minimum share of inbox allocations reserved per label category
+type InboxCategoryQuota struct {
+	InboxID  pgtype.UUID `json:"inbox_id"`
+	LabelID  pgtype.UUID `json:"label_id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	// Percentage of allocations; the shares of an inbox add up to at most 100
+	SharePercent int32              `json:"share_percent"`
+	UpdatedBy    pgtype.UUID        `json:"updated_by"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Checklist and pinned note instantiated on the conversations of an inbox
+type InboxChecklistTemplate struct {
+	InboxID  pgtype.UUID `json:"inbox_id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	// Checklist item labels, in display order
+	Items      []string    `json:"items"`
+	PinnedNote pgtype.Text `json:"pinned_note"`
+	// Refuse to resolve conversations with unticked items
+	Strict    bool               `json:"strict"`
+	UpdatedBy pgtype.UUID        `json:"updated_by"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Per-inbox first-assignment and resolution time targets
+type InboxSlaPolicy struct {
+	InboxID  pgtype.UUID `json:"inbox_id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	// Maximum time a conversation may wait in the queue after creation
+	FirstAssignmentSeconds pgtype.Int4 `json:"first_assignment_seconds"`
+	// Maximum time from conversation creation to resolution
+	ResolutionSeconds pgtype.Int4        `json:"resolution_seconds"`
+	UpdatedBy         pgtype.UUID        `json:"updated_by"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Per-inbox limit on operator inactivity for allocated conversations
+type InboxStalenessPolicy struct {
+	InboxID           pgtype.UUID `json:"inbox_id"`
+	TenantID          pgtype.UUID `json:"tenant_id"`
+	StaleAfterSeconds int32       `json:"stale_after_seconds"`
+	// Grace period before a stale conversation returns to the queue; NULL only flags it
+	DeallocateGraceSeconds pgtype.Int4        `json:"deallocate_grace_seconds"`
+	UpdatedBy              pgtype.UUID        `json:"updated_by"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Materialized per-inbox queue order for read paths
+type InboxQueueRank struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	InboxID        pgtype.UUID `json:"inbox_id"`
+	// 1-based position in the inbox queue
+	Rank          int32              `json:"rank"`
+	PriorityScore pgtype.Numeric     `json:"priority_score"`
+	LastMessageAt pgtype.Timestamptz `json:"last_message_at"`
+	// When the inbox was last re-ranked
+	RefreshedAt pgtype.Timestamptz `json:"refreshed_at"`
 }
 
 type Label struct {
@@ -249,6 +725,22 @@ type Label struct {
 	Color     pgtype.Text        `json:"color"`
 	CreatedBy pgtype.UUID        `json:"created_by"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// Current version; incremented by every rename or recolor
+	Version int32 `json:"version"`
+	// Parent label in the same inbox; NULL for a top-level label
+	ParentLabelID pgtype.UUID `json:"parent_label_id"`
+	// INBOX: label of inbox_id; TENANT: label of every inbox of the tenant, inbox_id NULL
+	Scope string `json:"scope"`
+}
+
+// Name and color of each label version, kept for historical reports
+type LabelVersion struct {
+	LabelID   pgtype.UUID        `json:"label_id"`
+	Version   int32              `json:"version"`
+	Name      string             `json:"name"`
+	Color     pgtype.Text        `json:"color"`
+	ChangedBy pgtype.UUID        `json:"changed_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
 type Operator struct {
@@ -257,6 +749,42 @@ type Operator struct {
 	Role      OperatorRole       `json:"role"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	// Display name shown to customers
+	Name pgtype.Text `json:"name"`
+	// Contact email shown to customers
+	Email pgtype.Text `json:"email"`
+	// Languages the operator is allocated conversations in; empty for any
+	Languages []string `json:"languages"`
+}
+
+// Allocation weight of operators, lowered while their return rate is abnormally high
+type OperatorAllocationHealth struct {
+	OperatorID pgtype.UUID `json:"operator_id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	// Weight set by the feedback loop, between 0 (exclusive) and 1
+	Weight float64 `json:"weight"`
+	// Allocations, claims and reassignments to the operator in the last evaluation window
+	Allocations int32 `json:"allocations"`
+	// Deallocations of the operator's conversations in the last evaluation window
+	Returns     int32              `json:"returns"`
+	EvaluatedAt pgtype.Timestamptz `json:"evaluated_at"`
+	// Weight pinned by a manager; replaces weight until cleared
+	OverrideWeight pgtype.Float8      `json:"override_weight"`
+	OverrideBy     pgtype.UUID        `json:"override_by"`
+	OverrideAt     pgtype.Timestamptz `json:"override_at"`
+}
+
+// Operator devices registered for push notifications
+type OperatorDevice struct {
+	ID         pgtype.UUID `json:"id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	OperatorID pgtype.UUID `json:"operator_id"`
+	Platform   string      `json:"platform"`
+	Token      string      `json:"token"`
+	// Push events the device is notified of; empty means all
+	EventTypes []string           `json:"event_types"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
 type OperatorInboxSubscription struct {
@@ -266,11 +794,190 @@ type OperatorInboxSubscription struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+// Presence WebSocket connections, refreshed by heartbeats
+type OperatorPresence struct {
+	OperatorID pgtype.UUID `json:"operator_id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	// Start of the current run of connections without a timeout
+	ConnectedAt pgtype.Timestamptz `json:"connected_at"`
+	LastSeenAt  pgtype.Timestamptz `json:"last_seen_at"`
+}
+
+// Weekly recurring working windows of operators
+type OperatorSchedule struct {
+	ID         pgtype.UUID `json:"id"`
+	TenantID   pgtype.UUID `json:"tenant_id"`
+	OperatorID pgtype.UUID `json:"operator_id"`
+	// Day the window starts on, 0 = Sunday
+	DayOfWeek int16       `json:"day_of_week"`
+	StartTime pgtype.Time `json:"start_time"`
+	// End of the window; not after start_time when it runs past midnight
+	EndTime pgtype.Time `json:"end_time"`
+	// IANA time zone the window is in
+	Timezone  string             `json:"timezone"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Trainee operators shadowing a mentor with read-only visibility
+type OperatorShadow struct {
+	ID        pgtype.UUID        `json:"id"`
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	MentorID  pgtype.UUID        `json:"mentor_id"`
+	TraineeID pgtype.UUID        `json:"trainee_id"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type OperatorStatus struct {
 	ID                 pgtype.UUID        `json:"id"`
 	OperatorID         pgtype.UUID        `json:"operator_id"`
 	Status             OperatorStatusType `json:"status"`
 	LastStatusChangeAt pgtype.Timestamptz `json:"last_status_change_at"`
+	// End of the operator's focus mode; no new allocations before then, NULL when not in focus
+	FocusUntil pgtype.Timestamptz `json:"focus_until"`
+}
+
+// Operators taking no new conversations, with the drain plan for their current ones
+type OperatorVacation struct {
+	OperatorID   pgtype.UUID        `json:"operator_id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	StartedAt    pgtype.Timestamptz `json:"started_at"`
+	Drain        string             `json:"drain"`
+	ColleagueIds []pgtype.UUID      `json:"colleague_ids"`
+	// End of the ramp-down; every conversation is drained by then
+	DrainUntil pgtype.Timestamptz `json:"drain_until"`
+	DrainedAt  pgtype.Timestamptz `json:"drained_at"`
+	// Round-robin position in colleague_ids for the next handed-over conversation
+	NextColleague int32 `json:"next_colleague"`
+}
+
+// A/B tests of alternative priority weights per inbox
+type PriorityExperiment struct {
+	ID          pgtype.UUID    `json:"id"`
+	TenantID    pgtype.UUID    `json:"tenant_id"`
+	InboxID     pgtype.UUID    `json:"inbox_id"`
+	Name        string         `json:"name"`
+	WeightAlpha pgtype.Numeric `json:"weight_alpha"`
+	WeightBeta  pgtype.Numeric `json:"weight_beta"`
+	// Share of new conversations in the TREATMENT arm
+	TrafficPercent int32              `json:"traffic_percent"`
+	Status         string             `json:"status"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	StartedAt      pgtype.Timestamptz `json:"started_at"`
+	StoppedAt      pgtype.Timestamptz `json:"stopped_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Experiment arm of each enrolled conversation
+type PriorityExperimentAssignment struct {
+	ExperimentID   pgtype.UUID        `json:"experiment_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	Arm            string             `json:"arm"`
+	AssignedAt     pgtype.Timestamptz `json:"assigned_at"`
+	// First allocation of the conversation; NULL while never allocated
+	AllocatedAt pgtype.Timestamptz `json:"allocated_at"`
+}
+
+// Inputs and result of each conversation priority calculation
+type PriorityScoreComponent struct {
+	ID             pgtype.UUID        `json:"id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	MessageCount   int32              `json:"message_count"`
+	LastMessageAt  pgtype.Timestamptz `json:"last_message_at"`
+	// min(log10(message_count + 1) / 3, 1)
+	MessageFactor pgtype.Numeric `json:"message_factor"`
+	// min(hours since last_message_at / 24, 1) at computed_at
+	DelayFactor pgtype.Numeric `json:"delay_factor"`
+	WeightAlpha pgtype.Numeric `json:"weight_alpha"`
+	WeightBeta  pgtype.Numeric `json:"weight_beta"`
+	// Priority added by matching routing rules
+	RuleBoost     pgtype.Numeric     `json:"rule_boost"`
+	PriorityScore pgtype.Numeric     `json:"priority_score"`
+	ComputedAt    pgtype.Timestamptz `json:"computed_at"`
+	// Priority added because the conversation is a first contact
+	FirstContactBoost pgtype.Numeric `json:"first_contact_boost"`
+}
+
+// Review queue of sampled resolved conversations
+type QaReviewItem struct {
+	ID             pgtype.UUID        `json:"id"`
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	OperatorID     pgtype.UUID        `json:"operator_id"`
+	Status         QaReviewStatus     `json:"status"`
+	ReviewerID     pgtype.UUID        `json:"reviewer_id"`
+	ClaimedUntil   pgtype.Timestamptz `json:"claimed_until"`
+	// Rubric criterion -> score (1-5)
+	Scores []byte `json:"scores"`
+	// Mean of the rubric scores
+	OverallScore pgtype.Numeric     `json:"overall_score"`
+	Comment      pgtype.Text        `json:"comment"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+}
+
+// Operators holding the QA reviewer permission
+type QaReviewer struct {
+	OperatorID pgtype.UUID        `json:"operator_id"`
+	TenantID   pgtype.UUID        `json:"tenant_id"`
+	GrantedBy  pgtype.UUID        `json:"granted_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Conversations disagreeing with the upstream system of record
+type ReconciliationDivergence struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	Kind           string      `json:"kind"`
+	LocalState     string      `json:"local_state"`
+	// OPEN, CLOSED or MISSING as reported upstream
+	UpstreamStatus  string             `json:"upstream_status"`
+	FirstDetectedAt pgtype.Timestamptz `json:"first_detected_at"`
+	LastCheckedAt   pgtype.Timestamptz `json:"last_checked_at"`
+	CorrectedAt     pgtype.Timestamptz `json:"corrected_at"`
+}
+
+// Tenant routing rules applied to conversations on trigger events
+type RoutingRule struct {
+	ID                pgtype.UUID        `json:"id"`
+	TenantID          pgtype.UUID        `json:"tenant_id"`
+	InboxID           pgtype.UUID        `json:"inbox_id"`
+	Name              string             `json:"name"`
+	Trigger           RoutingRuleTrigger `json:"trigger"`
+	ConditionField    string             `json:"condition_field"`
+	ConditionOperator string             `json:"condition_operator"`
+	ConditionValue    int32              `json:"condition_value"`
+	LabelName         pgtype.Text        `json:"label_name"`
+	// Added to the computed priority score while the rule matches
+	PriorityBoost pgtype.Numeric     `json:"priority_boost"`
+	IsActive      bool               `json:"is_active"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	// Value compared for text condition fields
+	ConditionText pgtype.Text `json:"condition_text"`
+}
+
+// Batched data backfills for online schema changes
+type SchemaBackfill struct {
+	Name      string         `json:"name"`
+	TableName string         `json:"table_name"`
+	Status    BackfillStatus `json:"status"`
+	// Id of the last processed row
+	CursorID      pgtype.UUID `json:"cursor_id"`
+	RowsProcessed int64       `json:"rows_processed"`
+	// Planner row estimate at registration
+	RowsTotal   int64              `json:"rows_total"`
+	BatchSize   int32              `json:"batch_size"`
+	LastError   pgtype.Text        `json:"last_error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
 }
 
 type Tenant struct {
@@ -281,4 +988,95 @@ type Tenant struct {
 	CreatedAt           pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy           pgtype.UUID        `json:"updated_by"`
+	// Priority added to first-contact conversations; 0 disables the boost
+	FirstContactBoost pgtype.Numeric `json:"first_contact_boost"`
+	// Grace period before an offline operator's conversations return to the queue; NULL uses the server default
+	GracePeriodSeconds pgtype.Int4 `json:"grace_period_seconds"`
+	// Most conversations a customer phone number may start per inbox per hour; NULL disables the limit
+	IntakeLimitPerHour pgtype.Int4 `json:"intake_limit_per_hour"`
+	// Allocation logic version the tenant is pinned to (v1 or v2)
+	AllocationEngine string `json:"allocation_engine"`
+	// Trial tenant holding demo data only; external webhook deliveries are blocked
+	Sandbox bool `json:"sandbox"`
+}
+
+// Tenant-configured anomaly detection sensitivity
+type TenantAnomalySetting struct {
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	Sensitivity string             `json:"sensitivity"`
+	UpdatedBy   pgtype.UUID        `json:"updated_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Tenant-configured conversation classification endpoints
+type TenantClassifier struct {
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	EndpointUrl string             `json:"endpoint_url"`
+	IsEnabled   bool               `json:"is_enabled"`
+	UpdatedBy   pgtype.UUID        `json:"updated_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Tenant-configured maintenance window and manual override for heavy jobs
+type TenantMaintenanceSetting struct {
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	WindowStart    pgtype.Time        `json:"window_start"`
+	WindowEnd      pgtype.Time        `json:"window_end"`
+	Timezone       pgtype.Text        `json:"timezone"`
+	OverrideMode   pgtype.Text        `json:"override_mode"`
+	OverrideUntil  pgtype.Timestamptz `json:"override_until"`
+	OverrideReason pgtype.Text        `json:"override_reason"`
+	UpdatedBy      pgtype.UUID        `json:"updated_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Tenant webhook endpoints for lifecycle event callbacks
+type Webhook struct {
+	ID       pgtype.UUID `json:"id"`
+	TenantID pgtype.UUID `json:"tenant_id"`
+	Url      string      `json:"url"`
+	// Shared secret used to sign payloads (HMAC-SHA256)
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	CreatedBy  pgtype.UUID        `json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	// Operator profile fields included in assignment event payloads
+	OperatorFields []string `json:"operator_fields"`
+}
+
+// Webhook delivery attempts with retry and dead-letter tracking
+type WebhookDelivery struct {
+	ID           pgtype.UUID           `json:"id"`
+	WebhookID    pgtype.UUID           `json:"webhook_id"`
+	TenantID     pgtype.UUID           `json:"tenant_id"`
+	EventID      pgtype.UUID           `json:"event_id"`
+	EventType    string                `json:"event_type"`
+	Payload      []byte                `json:"payload"`
+	Status       WebhookDeliveryStatus `json:"status"`
+	AttemptCount int32                 `json:"attempt_count"`
+	// Earliest time the worker may attempt delivery
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastAttemptAt  pgtype.Timestamptz `json:"last_attempt_at"`
+	LastStatusCode pgtype.Int4        `json:"last_status_code"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+// Running replicas with their supported schema range and worker protocol
+type WorkerInstance struct {
+	InstanceID       pgtype.UUID `json:"instance_id"`
+	BinaryVersion    string      `json:"binary_version"`
+	WorkerProtocol   int32       `json:"worker_protocol"`
+	MinSchemaVersion int64       `json:"min_schema_version"`
+	MaxSchemaVersion int64       `json:"max_schema_version"`
+	// False when the compatibility gate kept the replica from starting workers
+	WorkersEnabled bool               `json:"workers_enabled"`
+	StartedAt      pgtype.Timestamptz `json:"started_at"`
+	HeartbeatAt    pgtype.Timestamptz `json:"heartbeat_at"`
 }
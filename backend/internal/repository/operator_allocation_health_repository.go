@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OperatorAllocationHealthRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorAllocationHealthRepository(q *Queries) *OperatorAllocationHealthRepositoryImpl {
+	return &OperatorAllocationHealthRepositoryImpl{q: q}
+}
+
+func (r *OperatorAllocationHealthRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorAllocationHealth, error) {
+	row, err := r.q.GetOperatorAllocationHealth(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// ListByTenant returns the tenant's evaluated operators, lowest weight first
+func (r *OperatorAllocationHealthRepositoryImpl) ListByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorAllocationHealth, error) {
+	rows, err := r.q.ListOperatorAllocationHealth(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	health := make([]*domain.OperatorAllocationHealth, len(rows))
+	for i, row := range rows {
+		health[i] = r.toDomain(row)
+	}
+	return health, nil
+}
+
+// SaveWeight stores the feedback-loop weight and the outcomes it came from
+func (r *OperatorAllocationHealthRepositoryImpl) SaveWeight(ctx context.Context, h *domain.OperatorAllocationHealth) error {
+	return mapError(r.q.UpsertOperatorAllocationWeight(ctx, UpsertOperatorAllocationWeightParams{
+		OperatorID:  uuidToPgtype(h.OperatorID),
+		TenantID:    uuidToPgtype(h.TenantID),
+		Weight:      h.Weight,
+		Allocations: int32(h.Allocations),
+		Returns:     int32(h.Returns),
+		EvaluatedAt: timeToPgtype(h.EvaluatedAt),
+	}))
+}
+
+// SaveOverride stores the manager override, cleared when OverrideWeight is nil
+func (r *OperatorAllocationHealthRepositoryImpl) SaveOverride(ctx context.Context, h *domain.OperatorAllocationHealth) error {
+	return mapError(r.q.SetOperatorAllocationOverride(ctx, SetOperatorAllocationOverrideParams{
+		OperatorID:     uuidToPgtype(h.OperatorID),
+		TenantID:       uuidToPgtype(h.TenantID),
+		OverrideWeight: floatPtrToPgtype(h.OverrideWeight),
+		OverrideBy:     uuidPtrToPgtype(h.OverrideBy),
+		OverrideAt:     timePtrToPgtype(h.OverrideAt),
+	}))
+}
+
+func (r *OperatorAllocationHealthRepositoryImpl) toDomain(row OperatorAllocationHealth) *domain.OperatorAllocationHealth {
+	return &domain.OperatorAllocationHealth{
+		OperatorID:     pgtypeToUUID(row.OperatorID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		Weight:         row.Weight,
+		Allocations:    int(row.Allocations),
+		Returns:        int(row.Returns),
+		EvaluatedAt:    pgtypeToTime(row.EvaluatedAt),
+		OverrideWeight: pgtypeToFloatPtr(row.OverrideWeight),
+		OverrideBy:     pgtypeToUUIDPtr(row.OverrideBy),
+		OverrideAt:     pgtypeToTimePtr(row.OverrideAt),
+	}
+}
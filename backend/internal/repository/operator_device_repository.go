@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OperatorDeviceRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorDeviceRepository(q *Queries) *OperatorDeviceRepositoryImpl {
+	return &OperatorDeviceRepositoryImpl{q: q}
+}
+
+// Upsert registers the device, or moves an already registered token to the
+// device's operator. The stored device is returned.
+func (r *OperatorDeviceRepositoryImpl) Upsert(ctx context.Context, device *domain.OperatorDevice) (*domain.OperatorDevice, error) {
+	row, err := r.q.UpsertOperatorDevice(ctx, UpsertOperatorDeviceParams{
+		ID:         uuidToPgtype(device.ID),
+		TenantID:   uuidToPgtype(device.TenantID),
+		OperatorID: uuidToPgtype(device.OperatorID),
+		Platform:   string(device.Platform),
+		Token:      device.Token,
+		EventTypes: eventTypesToStrings(device.EventTypes),
+		CreatedAt:  timeToPgtype(device.CreatedAt),
+		UpdatedAt:  timeToPgtype(device.UpdatedAt),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *OperatorDeviceRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.OperatorDevice, error) {
+	row, err := r.q.GetOperatorDeviceByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *OperatorDeviceRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*domain.OperatorDevice, error) {
+	rows, err := r.q.GetOperatorDevicesByOperatorID(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	devices := make([]*domain.OperatorDevice, len(rows))
+	for i, row := range rows {
+		devices[i] = r.toDomain(row)
+	}
+	return devices, nil
+}
+
+func (r *OperatorDeviceRepositoryImpl) CountByOperatorID(ctx context.Context, operatorID uuid.UUID) (int, error) {
+	count, err := r.q.CountOperatorDevices(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+func (r *OperatorDeviceRepositoryImpl) UpdateEventTypes(ctx context.Context, device *domain.OperatorDevice) error {
+	return mapError(r.q.UpdateOperatorDeviceEventTypes(ctx, UpdateOperatorDeviceEventTypesParams{
+		ID:         uuidToPgtype(device.ID),
+		EventTypes: eventTypesToStrings(device.EventTypes),
+		UpdatedAt:  timeToPgtype(device.UpdatedAt),
+	}))
+}
+
+func (r *OperatorDeviceRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return mapError(r.q.DeleteOperatorDevice(ctx, uuidToPgtype(id)))
+}
+
+func (r *OperatorDeviceRepositoryImpl) DeleteByToken(ctx context.Context, token string) error {
+	return mapError(r.q.DeleteOperatorDeviceByToken(ctx, token))
+}
+
+func (r *OperatorDeviceRepositoryImpl) toDomain(row OperatorDevice) *domain.OperatorDevice {
+	return &domain.OperatorDevice{
+		ID:         pgtypeToUUID(row.ID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		OperatorID: pgtypeToUUID(row.OperatorID),
+		Platform:   domain.DevicePlatform(row.Platform),
+		Token:      row.Token,
+		EventTypes: stringsToEventTypes(row.EventTypes),
+		CreatedAt:  pgtypeToTime(row.CreatedAt),
+		UpdatedAt:  pgtypeToTime(row.UpdatedAt),
+	}
+}
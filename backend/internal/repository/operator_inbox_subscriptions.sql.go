@@ -52,6 +52,33 @@ func (q *Queries) CreateSubscription(ctx context.Context, arg CreateSubscription
 	return err
 }
 
+const createSubscriptionIfNotExists = `-- name: CreateSubscriptionIfNotExists :execrows
+INSERT INTO operator_inbox_subscriptions (id, operator_id, inbox_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (operator_id, inbox_id) DO NOTHING
+`
+
+type CreateSubscriptionIfNotExistsParams struct {
+	ID         pgtype.UUID        `json:"id"`
+	OperatorID pgtype.UUID        `json:"operator_id"`
+	InboxID    pgtype.UUID        `json:"inbox_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+// Insert unless the operator is already subscribed to the inbox
+func (q *Queries) CreateSubscriptionIfNotExists(ctx context.Context, arg CreateSubscriptionIfNotExistsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createSubscriptionIfNotExists,
+		arg.ID,
+		arg.OperatorID,
+		arg.InboxID,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteSubscription = `-- name: DeleteSubscription :exec
 DELETE FROM operator_inbox_subscriptions WHERE id = $1
 `
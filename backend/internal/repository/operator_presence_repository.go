@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OperatorPresenceRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorPresenceRepository(q *Queries) *OperatorPresenceRepositoryImpl {
+	return &OperatorPresenceRepositoryImpl{q: q}
+}
+
+func (r *OperatorPresenceRepositoryImpl) Touch(ctx context.Context, presence *domain.OperatorPresence) (bool, error) {
+	inserted, err := r.q.TouchOperatorPresence(ctx, TouchOperatorPresenceParams{
+		OperatorID:  uuidToPgtype(presence.OperatorID),
+		TenantID:    uuidToPgtype(presence.TenantID),
+		ConnectedAt: timeToPgtype(presence.ConnectedAt),
+		LastSeenAt:  timeToPgtype(presence.LastSeenAt),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return inserted, nil
+}
+
+func (r *OperatorPresenceRepositoryImpl) ClaimStale(ctx context.Context, cutoff time.Time) ([]*domain.OperatorPresence, error) {
+	rows, err := r.q.ClaimStaleOperatorPresence(ctx, timeToPgtype(cutoff))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainList(rows), nil
+}
+
+func (r *OperatorPresenceRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorPresence, error) {
+	rows, err := r.q.GetOperatorPresenceByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainList(rows), nil
+}
+
+func (r *OperatorPresenceRepositoryImpl) toDomainList(rows []OperatorPresence) []*domain.OperatorPresence {
+	presence := make([]*domain.OperatorPresence, len(rows))
+	for i, row := range rows {
+		presence[i] = &domain.OperatorPresence{
+			OperatorID:  pgtypeToUUID(row.OperatorID),
+			TenantID:    pgtypeToUUID(row.TenantID),
+			ConnectedAt: pgtypeToTime(row.ConnectedAt),
+			LastSeenAt:  pgtypeToTime(row.LastSeenAt),
+		}
+	}
+	return presence
+}
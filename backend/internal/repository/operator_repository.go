@@ -20,8 +20,11 @@ func (r *OperatorRepositoryImpl) Create(ctx context.Context, operator *domain.Op
 		ID:        uuidToPgtype(operator.ID),
 		TenantID:  uuidToPgtype(operator.TenantID),
 		Role:      operatorRoleToPgtype(operator.Role),
+		Name:      stringPtrToPgtype(operator.Name),
+		Email:     stringPtrToPgtype(operator.Email),
 		CreatedAt: timeToPgtype(operator.CreatedAt),
 		UpdatedAt: timeToPgtype(operator.UpdatedAt),
+		Languages: languagesToPgtype(operator.Languages),
 	})
 }
 
@@ -66,7 +69,10 @@ func (r *OperatorRepositoryImpl) Update(ctx context.Context, operator *domain.Op
 	return r.q.UpdateOperator(ctx, UpdateOperatorParams{
 		ID:        uuidToPgtype(operator.ID),
 		Role:      operatorRoleToPgtype(operator.Role),
+		Name:      stringPtrToPgtype(operator.Name),
+		Email:     stringPtrToPgtype(operator.Email),
 		UpdatedAt: timeToPgtype(operator.UpdatedAt),
+		Languages: languagesToPgtype(operator.Languages),
 	})
 }
 
@@ -79,6 +85,9 @@ func (r *OperatorRepositoryImpl) toDomain(row Operator) *domain.Operator {
 		ID:        pgtypeToUUID(row.ID),
 		TenantID:  pgtypeToUUID(row.TenantID),
 		Role:      pgtypeToOperatorRole(row.Role),
+		Name:      pgtypeToStringPtr(row.Name),
+		Email:     pgtypeToStringPtr(row.Email),
+		Languages: languagesToPgtype(row.Languages),
 		CreatedAt: pgtypeToTime(row.CreatedAt),
 		UpdatedAt: pgtypeToTime(row.UpdatedAt),
 	}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OperatorScheduleRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorScheduleRepository(q *Queries) *OperatorScheduleRepositoryImpl {
+	return &OperatorScheduleRepositoryImpl{q: q}
+}
+
+func (r *OperatorScheduleRepositoryImpl) Create(ctx context.Context, schedule *domain.OperatorSchedule) error {
+	return r.q.CreateOperatorSchedule(ctx, CreateOperatorScheduleParams{
+		ID:         uuidToPgtype(schedule.ID),
+		TenantID:   uuidToPgtype(schedule.TenantID),
+		OperatorID: uuidToPgtype(schedule.OperatorID),
+		DayOfWeek:  int16(schedule.DayOfWeek),
+		StartTime:  timeOfDayToPgtype(schedule.Start),
+		EndTime:    timeOfDayToPgtype(schedule.End),
+		Timezone:   schedule.Timezone,
+		CreatedBy:  uuidPtrToPgtype(schedule.CreatedBy),
+		CreatedAt:  timeToPgtype(schedule.CreatedAt),
+		UpdatedAt:  timeToPgtype(schedule.UpdatedAt),
+	})
+}
+
+func (r *OperatorScheduleRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.OperatorSchedule, error) {
+	row, err := r.q.GetOperatorScheduleByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *OperatorScheduleRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*domain.OperatorSchedule, error) {
+	rows, err := r.q.GetOperatorSchedulesByOperatorID(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *OperatorScheduleRepositoryImpl) GetOfAvailableOperators(ctx context.Context) ([]*domain.OperatorSchedule, error) {
+	rows, err := r.q.GetSchedulesOfAvailableOperators(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *OperatorScheduleRepositoryImpl) Update(ctx context.Context, schedule *domain.OperatorSchedule) error {
+	return r.q.UpdateOperatorSchedule(ctx, UpdateOperatorScheduleParams{
+		ID:        uuidToPgtype(schedule.ID),
+		DayOfWeek: int16(schedule.DayOfWeek),
+		StartTime: timeOfDayToPgtype(schedule.Start),
+		EndTime:   timeOfDayToPgtype(schedule.End),
+		Timezone:  schedule.Timezone,
+		UpdatedAt: timeToPgtype(schedule.UpdatedAt),
+	})
+}
+
+func (r *OperatorScheduleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteOperatorSchedule(ctx, uuidToPgtype(id))
+}
+
+func (r *OperatorScheduleRepositoryImpl) toDomain(row OperatorSchedule) *domain.OperatorSchedule {
+	return &domain.OperatorSchedule{
+		ID:         pgtypeToUUID(row.ID),
+		TenantID:   pgtypeToUUID(row.TenantID),
+		OperatorID: pgtypeToUUID(row.OperatorID),
+		DayOfWeek:  time.Weekday(row.DayOfWeek),
+		Start:      pgtypeToTimeOfDay(row.StartTime),
+		End:        pgtypeToTimeOfDay(row.EndTime),
+		Timezone:   row.Timezone,
+		CreatedBy:  pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt:  pgtypeToTime(row.CreatedAt),
+		UpdatedAt:  pgtypeToTime(row.UpdatedAt),
+	}
+}
+
+func (r *OperatorScheduleRepositoryImpl) toDomainSlice(rows []OperatorSchedule) []*domain.OperatorSchedule {
+	schedules := make([]*domain.OperatorSchedule, len(rows))
+	for i, row := range rows {
+		schedules[i] = r.toDomain(row)
+	}
+	return schedules
+}
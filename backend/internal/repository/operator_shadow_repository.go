@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type OperatorShadowRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorShadowRepository(q *Queries) *OperatorShadowRepositoryImpl {
+	return &OperatorShadowRepositoryImpl{q: q}
+}
+
+func (r *OperatorShadowRepositoryImpl) Create(ctx context.Context, shadow *domain.OperatorShadow) error {
+	err := r.q.CreateOperatorShadow(ctx, CreateOperatorShadowParams{
+		ID:        uuidToPgtype(shadow.ID),
+		TenantID:  uuidToPgtype(shadow.TenantID),
+		MentorID:  uuidToPgtype(shadow.MentorID),
+		TraineeID: uuidToPgtype(shadow.TraineeID),
+		CreatedBy: uuidPtrToPgtype(shadow.CreatedBy),
+		CreatedAt: timeToPgtype(shadow.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *OperatorShadowRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.OperatorShadow, error) {
+	row, err := r.q.GetOperatorShadowByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *OperatorShadowRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorShadow, error) {
+	rows, err := r.q.GetOperatorShadowsByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	shadows := make([]*domain.OperatorShadow, len(rows))
+	for i, row := range rows {
+		shadows[i] = r.toDomain(row)
+	}
+	return shadows, nil
+}
+
+func (r *OperatorShadowRepositoryImpl) GetMentorIDs(ctx context.Context, traineeID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.q.GetMentorIDsForTrainee(ctx, uuidToPgtype(traineeID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = pgtypeToUUID(row)
+	}
+	return ids, nil
+}
+
+func (r *OperatorShadowRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteOperatorShadow(ctx, uuidToPgtype(id))
+}
+
+func (r *OperatorShadowRepositoryImpl) toDomain(row OperatorShadow) *domain.OperatorShadow {
+	return &domain.OperatorShadow{
+		ID:        pgtypeToUUID(row.ID),
+		TenantID:  pgtypeToUUID(row.TenantID),
+		MentorID:  pgtypeToUUID(row.MentorID),
+		TraineeID: pgtypeToUUID(row.TraineeID),
+		CreatedBy: pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt: pgtypeToTime(row.CreatedAt),
+	}
+}
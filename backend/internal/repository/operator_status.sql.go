@@ -11,6 +11,39 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countOperatorsByStatus = `-- name: CountOperatorsByStatus :many
+SELECT os.status, COUNT(*) AS operators
+FROM operator_status os
+JOIN operators o ON o.id = os.operator_id
+WHERE o.tenant_id = $1
+GROUP BY os.status
+`
+
+type CountOperatorsByStatusRow struct {
+	Status    OperatorStatusType `json:"status"`
+	Operators int64              `json:"operators"`
+}
+
+func (q *Queries) CountOperatorsByStatus(ctx context.Context, tenantID pgtype.UUID) ([]CountOperatorsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countOperatorsByStatus, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountOperatorsByStatusRow{}
+	for rows.Next() {
+		var i CountOperatorsByStatusRow
+		if err := rows.Scan(&i.Status, &i.Operators); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createOperatorStatus = `-- name: CreateOperatorStatus :exec
 INSERT INTO operator_status (id, operator_id, status, last_status_change_at)
 VALUES ($1, $2, $3, $4)
@@ -34,7 +67,7 @@ func (q *Queries) CreateOperatorStatus(ctx context.Context, arg CreateOperatorSt
 }
 
 const getAvailableOperators = `-- name: GetAvailableOperators :many
-SELECT os.id, os.operator_id, os.status, os.last_status_change_at
+SELECT os.id, os.operator_id, os.status, os.last_status_change_at, os.focus_until
 FROM operator_status os
 JOIN operators o ON o.id = os.operator_id
 WHERE o.tenant_id = $1 AND os.status = 'AVAILABLE'
@@ -54,6 +87,7 @@ func (q *Queries) GetAvailableOperators(ctx context.Context, tenantID pgtype.UUI
 			&i.OperatorID,
 			&i.Status,
 			&i.LastStatusChangeAt,
+			&i.FocusUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -66,7 +100,7 @@ func (q *Queries) GetAvailableOperators(ctx context.Context, tenantID pgtype.UUI
 }
 
 const getOperatorStatusByOperatorID = `-- name: GetOperatorStatusByOperatorID :one
-SELECT id, operator_id, status, last_status_change_at FROM operator_status WHERE operator_id = $1
+SELECT id, operator_id, status, last_status_change_at, focus_until FROM operator_status WHERE operator_id = $1
 `
 
 func (q *Queries) GetOperatorStatusByOperatorID(ctx context.Context, operatorID pgtype.UUID) (OperatorStatus, error) {
@@ -77,14 +111,49 @@ func (q *Queries) GetOperatorStatusByOperatorID(ctx context.Context, operatorID
 		&i.OperatorID,
 		&i.Status,
 		&i.LastStatusChangeAt,
+		&i.FocusUntil,
 	)
 	return i, err
 }
 
+const getOperatorStatusesByTenantID = `-- name: GetOperatorStatusesByTenantID :many
+SELECT os.id, os.operator_id, os.status, os.last_status_change_at, os.focus_until
+FROM operator_status os
+JOIN operators o ON o.id = os.operator_id
+WHERE o.tenant_id = $1
+`
+
+func (q *Queries) GetOperatorStatusesByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]OperatorStatus, error) {
+	rows, err := q.db.Query(ctx, getOperatorStatusesByTenantID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OperatorStatus{}
+	for rows.Next() {
+		var i OperatorStatus
+		if err := rows.Scan(
+			&i.ID,
+			&i.OperatorID,
+			&i.Status,
+			&i.LastStatusChangeAt,
+			&i.FocusUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateOperatorStatus = `-- name: UpdateOperatorStatus :exec
 UPDATE operator_status
 SET status = $2,
-    last_status_change_at = $3
+    last_status_change_at = $3,
+    focus_until = $4
 WHERE operator_id = $1
 `
 
@@ -92,9 +161,15 @@ type UpdateOperatorStatusParams struct {
 	OperatorID         pgtype.UUID        `json:"operator_id"`
 	Status             OperatorStatusType `json:"status"`
 	LastStatusChangeAt pgtype.Timestamptz `json:"last_status_change_at"`
+	FocusUntil         pgtype.Timestamptz `json:"focus_until"`
 }
 
 func (q *Queries) UpdateOperatorStatus(ctx context.Context, arg UpdateOperatorStatusParams) error {
-	_, err := q.db.Exec(ctx, updateOperatorStatus, arg.OperatorID, arg.Status, arg.LastStatusChangeAt)
+	_, err := q.db.Exec(ctx, updateOperatorStatus,
+		arg.OperatorID,
+		arg.Status,
+		arg.LastStatusChangeAt,
+		arg.FocusUntil,
+	)
 	return err
 }
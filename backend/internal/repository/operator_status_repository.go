@@ -8,7 +8,8 @@ import (
 )
 
 type OperatorStatusRepositoryImpl struct {
-	q *Queries
+	q     *Queries
+	cache *readCache
 }
 
 func NewOperatorStatusRepository(q *Queries) *OperatorStatusRepositoryImpl {
@@ -16,28 +17,41 @@ func NewOperatorStatusRepository(q *Queries) *OperatorStatusRepositoryImpl {
 }
 
 func (r *OperatorStatusRepositoryImpl) Create(ctx context.Context, status *domain.OperatorStatus) error {
-	return r.q.CreateOperatorStatus(ctx, CreateOperatorStatusParams{
+	err := r.q.CreateOperatorStatus(ctx, CreateOperatorStatusParams{
 		ID:                 uuidToPgtype(status.ID),
 		OperatorID:         uuidToPgtype(status.OperatorID),
 		Status:             operatorStatusTypeToPgtype(status.Status),
 		LastStatusChangeAt: timeToPgtype(status.LastStatusChangeAt),
 	})
+	r.cache.invalidate(ctx, operatorStatusCacheKey(status.OperatorID))
+	return err
 }
 
 func (r *OperatorStatusRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorStatus, error) {
+	key := operatorStatusCacheKey(operatorID)
+	var cached domain.OperatorStatus
+	if r.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
 	row, err := r.q.GetOperatorStatusByOperatorID(ctx, uuidToPgtype(operatorID))
 	if err != nil {
 		return nil, mapError(err)
 	}
-	return r.toDomain(row), nil
+	status := r.toDomain(row)
+	r.cache.set(ctx, key, status)
+	return status, nil
 }
 
 func (r *OperatorStatusRepositoryImpl) Update(ctx context.Context, status *domain.OperatorStatus) error {
-	return r.q.UpdateOperatorStatus(ctx, UpdateOperatorStatusParams{
+	err := r.q.UpdateOperatorStatus(ctx, UpdateOperatorStatusParams{
 		OperatorID:         uuidToPgtype(status.OperatorID),
 		Status:             operatorStatusTypeToPgtype(status.Status),
 		LastStatusChangeAt: timeToPgtype(status.LastStatusChangeAt),
+		FocusUntil:         timePtrToPgtype(status.FocusUntil),
 	})
+	r.cache.invalidate(ctx, operatorStatusCacheKey(status.OperatorID))
+	return err
 }
 
 func (r *OperatorStatusRepositoryImpl) GetAvailableOperators(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorStatus, error) {
@@ -53,11 +67,38 @@ func (r *OperatorStatusRepositoryImpl) GetAvailableOperators(ctx context.Context
 	return statuses, nil
 }
 
+func (r *OperatorStatusRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorStatus, error) {
+	rows, err := r.q.GetOperatorStatusesByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	statuses := make([]*domain.OperatorStatus, len(rows))
+	for i, row := range rows {
+		statuses[i] = r.toDomain(row)
+	}
+	return statuses, nil
+}
+
+func (r *OperatorStatusRepositoryImpl) CountByStatus(ctx context.Context, tenantID uuid.UUID) (map[domain.OperatorStatusType]int, error) {
+	rows, err := r.q.CountOperatorsByStatus(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make(map[domain.OperatorStatusType]int, len(rows))
+	for _, row := range rows {
+		counts[pgtypeToOperatorStatusType(row.Status)] = int(row.Operators)
+	}
+	return counts, nil
+}
+
 func (r *OperatorStatusRepositoryImpl) toDomain(row OperatorStatus) *domain.OperatorStatus {
 	return &domain.OperatorStatus{
 		ID:                 pgtypeToUUID(row.ID),
 		OperatorID:         pgtypeToUUID(row.OperatorID),
 		Status:             pgtypeToOperatorStatusType(row.Status),
 		LastStatusChangeAt: pgtypeToTime(row.LastStatusChangeAt),
+		FocusUntil:         pgtypeToTimePtr(row.FocusUntil),
 	}
 }
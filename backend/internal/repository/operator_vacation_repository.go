@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OperatorVacationRepositoryImpl struct {
+	q *Queries
+}
+
+func NewOperatorVacationRepository(q *Queries) *OperatorVacationRepositoryImpl {
+	return &OperatorVacationRepositoryImpl{q: q}
+}
+
+func (r *OperatorVacationRepositoryImpl) Upsert(ctx context.Context, vacation *domain.OperatorVacation) (*domain.OperatorVacation, error) {
+	colleagueIDs := make([]pgtype.UUID, len(vacation.ColleagueIDs))
+	for i, id := range vacation.ColleagueIDs {
+		colleagueIDs[i] = uuidToPgtype(id)
+	}
+	row, err := r.q.UpsertOperatorVacation(ctx, UpsertOperatorVacationParams{
+		OperatorID:   uuidToPgtype(vacation.OperatorID),
+		TenantID:     uuidToPgtype(vacation.TenantID),
+		StartedAt:    timeToPgtype(vacation.StartedAt),
+		Drain:        string(vacation.Drain),
+		ColleagueIds: colleagueIDs,
+		DrainUntil:   timeToPgtype(vacation.DrainUntil),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *OperatorVacationRepositoryImpl) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorVacation, error) {
+	row, err := r.q.GetOperatorVacationByOperatorID(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+// GetAndLockDraining - Uses FOR UPDATE SKIP LOCKED
+func (r *OperatorVacationRepositoryImpl) GetAndLockDraining(ctx context.Context, limit int) ([]*domain.OperatorVacation, error) {
+	rows, err := r.q.GetAndLockDrainingOperatorVacations(ctx, int32(limit))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	vacations := make([]*domain.OperatorVacation, len(rows))
+	for i, row := range rows {
+		vacations[i] = r.toDomain(row)
+	}
+	return vacations, nil
+}
+
+func (r *OperatorVacationRepositoryImpl) UpdateDrain(ctx context.Context, vacation *domain.OperatorVacation) error {
+	err := r.q.UpdateOperatorVacationDrain(ctx, UpdateOperatorVacationDrainParams{
+		OperatorID:    uuidToPgtype(vacation.OperatorID),
+		DrainedAt:     timePtrToPgtype(vacation.DrainedAt),
+		NextColleague: int32(vacation.NextColleague),
+	})
+	return mapError(err)
+}
+
+func (r *OperatorVacationRepositoryImpl) Delete(ctx context.Context, operatorID uuid.UUID) error {
+	deleted, err := r.q.DeleteOperatorVacation(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return mapError(err)
+	}
+	if deleted == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OperatorVacationRepositoryImpl) toDomain(row OperatorVacation) *domain.OperatorVacation {
+	colleagueIDs := make([]uuid.UUID, len(row.ColleagueIds))
+	for i, id := range row.ColleagueIds {
+		colleagueIDs[i] = pgtypeToUUID(id)
+	}
+	return &domain.OperatorVacation{
+		OperatorID:    pgtypeToUUID(row.OperatorID),
+		TenantID:      pgtypeToUUID(row.TenantID),
+		StartedAt:     pgtypeToTime(row.StartedAt),
+		Drain:         domain.VacationDrain(row.Drain),
+		ColleagueIDs:  colleagueIDs,
+		DrainUntil:    pgtypeToTime(row.DrainUntil),
+		DrainedAt:     pgtypeToTimePtr(row.DrainedAt),
+		NextColleague: int(row.NextColleague),
+	}
+}
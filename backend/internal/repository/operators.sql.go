@@ -12,16 +12,19 @@ import (
 )
 
 const createOperator = `-- name: CreateOperator :exec
-INSERT INTO operators (id, tenant_id, role, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO operators (id, tenant_id, role, name, email, created_at, updated_at, languages)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateOperatorParams struct {
 	ID        pgtype.UUID        `json:"id"`
 	TenantID  pgtype.UUID        `json:"tenant_id"`
 	Role      OperatorRole       `json:"role"`
+	Name      pgtype.Text        `json:"name"`
+	Email     pgtype.Text        `json:"email"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Languages []string           `json:"languages"`
 }
 
 func (q *Queries) CreateOperator(ctx context.Context, arg CreateOperatorParams) error {
@@ -29,8 +32,11 @@ func (q *Queries) CreateOperator(ctx context.Context, arg CreateOperatorParams)
 		arg.ID,
 		arg.TenantID,
 		arg.Role,
+		arg.Name,
+		arg.Email,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Languages,
 	)
 	return err
 }
@@ -45,7 +51,7 @@ func (q *Queries) DeleteOperator(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getOperatorByID = `-- name: GetOperatorByID :one
-SELECT id, tenant_id, role, created_at, updated_at FROM operators WHERE id = $1
+SELECT id, tenant_id, role, created_at, updated_at, name, email, languages FROM operators WHERE id = $1
 `
 
 func (q *Queries) GetOperatorByID(ctx context.Context, id pgtype.UUID) (Operator, error) {
@@ -57,12 +63,15 @@ func (q *Queries) GetOperatorByID(ctx context.Context, id pgtype.UUID) (Operator
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.Languages,
 	)
 	return i, err
 }
 
 const getOperatorsByTenantAndRole = `-- name: GetOperatorsByTenantAndRole :many
-SELECT id, tenant_id, role, created_at, updated_at FROM operators WHERE tenant_id = $1 AND role = $2 ORDER BY created_at DESC
+SELECT id, tenant_id, role, created_at, updated_at, name, email, languages FROM operators WHERE tenant_id = $1 AND role = $2 ORDER BY created_at DESC
 `
 
 type GetOperatorsByTenantAndRoleParams struct {
@@ -85,6 +94,9 @@ func (q *Queries) GetOperatorsByTenantAndRole(ctx context.Context, arg GetOperat
 			&i.Role,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Name,
+			&i.Email,
+			&i.Languages,
 		); err != nil {
 			return nil, err
 		}
@@ -97,7 +109,7 @@ func (q *Queries) GetOperatorsByTenantAndRole(ctx context.Context, arg GetOperat
 }
 
 const getOperatorsByTenantID = `-- name: GetOperatorsByTenantID :many
-SELECT id, tenant_id, role, created_at, updated_at FROM operators WHERE tenant_id = $1 ORDER BY created_at DESC
+SELECT id, tenant_id, role, created_at, updated_at, name, email, languages FROM operators WHERE tenant_id = $1 ORDER BY created_at DESC
 `
 
 func (q *Queries) GetOperatorsByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]Operator, error) {
@@ -115,6 +127,9 @@ func (q *Queries) GetOperatorsByTenantID(ctx context.Context, tenantID pgtype.UU
 			&i.Role,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Name,
+			&i.Email,
+			&i.Languages,
 		); err != nil {
 			return nil, err
 		}
@@ -129,17 +144,30 @@ func (q *Queries) GetOperatorsByTenantID(ctx context.Context, tenantID pgtype.UU
 const updateOperator = `-- name: UpdateOperator :exec
 UPDATE operators
 SET role = $2,
-    updated_at = $3
+    name = $3,
+    email = $4,
+    updated_at = $5,
+    languages = $6
 WHERE id = $1
 `
 
 type UpdateOperatorParams struct {
 	ID        pgtype.UUID        `json:"id"`
 	Role      OperatorRole       `json:"role"`
+	Name      pgtype.Text        `json:"name"`
+	Email     pgtype.Text        `json:"email"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Languages []string           `json:"languages"`
 }
 
 func (q *Queries) UpdateOperator(ctx context.Context, arg UpdateOperatorParams) error {
-	_, err := q.db.Exec(ctx, updateOperator, arg.ID, arg.Role, arg.UpdatedAt)
+	_, err := q.db.Exec(ctx, updateOperator,
+		arg.ID,
+		arg.Role,
+		arg.Name,
+		arg.Email,
+		arg.UpdatedAt,
+		arg.Languages,
+	)
 	return err
 }
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PriorityExperimentRepositoryImpl struct {
+	q *Queries
+}
+
+func NewPriorityExperimentRepository(q *Queries) *PriorityExperimentRepositoryImpl {
+	return &PriorityExperimentRepositoryImpl{q: q}
+}
+
+func (r *PriorityExperimentRepositoryImpl) Create(ctx context.Context, experiment *domain.PriorityExperiment) error {
+	err := r.q.CreatePriorityExperiment(ctx, CreatePriorityExperimentParams{
+		ID:             uuidToPgtype(experiment.ID),
+		TenantID:       uuidToPgtype(experiment.TenantID),
+		InboxID:        uuidToPgtype(experiment.InboxID),
+		Name:           experiment.Name,
+		WeightAlpha:    decimalToPgtype(experiment.WeightAlpha),
+		WeightBeta:     decimalToPgtype(experiment.WeightBeta),
+		TrafficPercent: int32(experiment.TrafficPercent),
+		Status:         string(experiment.Status),
+		CreatedBy:      uuidPtrToPgtype(experiment.CreatedBy),
+		StartedAt:      timeToPgtype(experiment.StartedAt),
+		StoppedAt:      timePtrToPgtype(experiment.StoppedAt),
+		CreatedAt:      timeToPgtype(experiment.CreatedAt),
+		UpdatedAt:      timeToPgtype(experiment.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *PriorityExperimentRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.PriorityExperiment, error) {
+	row, err := r.q.GetPriorityExperimentByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *PriorityExperimentRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.PriorityExperiment, error) {
+	rows, err := r.q.GetPriorityExperimentsByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *PriorityExperimentRepositoryImpl) GetRunningByInbox(ctx context.Context, inboxID uuid.UUID) (*domain.PriorityExperiment, error) {
+	row, err := r.q.GetRunningPriorityExperimentByInbox(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *PriorityExperimentRepositoryImpl) GetRunningByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.PriorityExperiment, error) {
+	rows, err := r.q.GetRunningPriorityExperimentsByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *PriorityExperimentRepositoryImpl) Update(ctx context.Context, experiment *domain.PriorityExperiment) error {
+	err := r.q.UpdatePriorityExperiment(ctx, UpdatePriorityExperimentParams{
+		ID:        uuidToPgtype(experiment.ID),
+		Name:      experiment.Name,
+		Status:    string(experiment.Status),
+		StoppedAt: timePtrToPgtype(experiment.StoppedAt),
+		UpdatedAt: timeToPgtype(experiment.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *PriorityExperimentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return mapError(r.q.DeletePriorityExperiment(ctx, uuidToPgtype(id)))
+}
+
+func (r *PriorityExperimentRepositoryImpl) Assign(ctx context.Context, experimentID, conversationID uuid.UUID, arm domain.ExperimentArm) error {
+	err := r.q.CreatePriorityExperimentAssignment(ctx, CreatePriorityExperimentAssignmentParams{
+		ExperimentID:   uuidToPgtype(experimentID),
+		ConversationID: uuidToPgtype(conversationID),
+		Arm:            string(arm),
+		AssignedAt:     timeToPgtype(time.Now().UTC()),
+	})
+	return mapError(err)
+}
+
+func (r *PriorityExperimentRepositoryImpl) MarkAllocated(ctx context.Context, conversationIDs []uuid.UUID, at time.Time) error {
+	ids := make([]pgtype.UUID, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	err := r.q.MarkPriorityExperimentAssignmentsAllocated(ctx, MarkPriorityExperimentAssignmentsAllocatedParams{
+		Column1:     ids,
+		AllocatedAt: timeToPgtype(at),
+	})
+	return mapError(err)
+}
+
+func (r *PriorityExperimentRepositoryImpl) GetOutcomes(ctx context.Context, experimentID uuid.UUID) ([]*domain.ExperimentArmOutcome, error) {
+	rows, err := r.q.GetPriorityExperimentOutcomes(ctx, uuidToPgtype(experimentID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	outcomes := make([]*domain.ExperimentArmOutcome, len(rows))
+	for i, row := range rows {
+		outcomes[i] = &domain.ExperimentArmOutcome{
+			Arm:           domain.ExperimentArm(row.Arm),
+			Conversations: int(row.Conversations),
+			Allocated:     int(row.Allocated),
+			Resolved:      int(row.Resolved),
+			AvgWait:       secondsToDuration(row.AvgWaitSeconds),
+			P50Wait:       secondsToDuration(row.P50WaitSeconds),
+			P90Wait:       secondsToDuration(row.P90WaitSeconds),
+			AvgResolution: secondsToDuration(row.AvgResolutionSeconds),
+			P50Resolution: secondsToDuration(row.P50ResolutionSeconds),
+			P90Resolution: secondsToDuration(row.P90ResolutionSeconds),
+		}
+	}
+	return outcomes, nil
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func (r *PriorityExperimentRepositoryImpl) toDomain(row PriorityExperiment) *domain.PriorityExperiment {
+	return &domain.PriorityExperiment{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		InboxID:        pgtypeToUUID(row.InboxID),
+		Name:           row.Name,
+		WeightAlpha:    pgtypeToDecimal(row.WeightAlpha),
+		WeightBeta:     pgtypeToDecimal(row.WeightBeta),
+		TrafficPercent: int(row.TrafficPercent),
+		Status:         domain.ExperimentStatus(row.Status),
+		CreatedBy:      pgtypeToUUIDPtr(row.CreatedBy),
+		StartedAt:      pgtypeToTime(row.StartedAt),
+		StoppedAt:      pgtypeToTimePtr(row.StoppedAt),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+		UpdatedAt:      pgtypeToTime(row.UpdatedAt),
+	}
+}
+
+func (r *PriorityExperimentRepositoryImpl) toDomainSlice(rows []PriorityExperiment) []*domain.PriorityExperiment {
+	experiments := make([]*domain.PriorityExperiment, len(rows))
+	for i, row := range rows {
+		experiments[i] = r.toDomain(row)
+	}
+	return experiments
+}
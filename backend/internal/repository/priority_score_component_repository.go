@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type PriorityScoreComponentRepositoryImpl struct {
+	q *Queries
+}
+
+func NewPriorityScoreComponentRepository(q *Queries) *PriorityScoreComponentRepositoryImpl {
+	return &PriorityScoreComponentRepositoryImpl{q: q}
+}
+
+func (r *PriorityScoreComponentRepositoryImpl) Create(ctx context.Context, c *domain.PriorityScoreComponents) error {
+	err := r.q.CreatePriorityScoreComponents(ctx, CreatePriorityScoreComponentsParams{
+		ID:                uuidToPgtype(c.ID),
+		ConversationID:    uuidToPgtype(c.ConversationID),
+		TenantID:          uuidToPgtype(c.TenantID),
+		MessageCount:      c.MessageCount,
+		LastMessageAt:     timeToPgtype(c.LastMessageAt),
+		MessageFactor:     decimalToPgtype(c.MessageFactor),
+		DelayFactor:       decimalToPgtype(c.DelayFactor),
+		WeightAlpha:       decimalToPgtype(c.WeightAlpha),
+		WeightBeta:        decimalToPgtype(c.WeightBeta),
+		RuleBoost:         decimalToPgtype(c.RuleBoost),
+		PriorityScore:     decimalToPgtype(c.PriorityScore),
+		ComputedAt:        timeToPgtype(c.ComputedAt),
+		FirstContactBoost: decimalToPgtype(c.FirstContactBoost),
+	})
+	return mapError(err)
+}
+
+func (r *PriorityScoreComponentRepositoryImpl) ListByConversation(ctx context.Context, conversationID uuid.UUID, limit int) ([]*domain.PriorityScoreComponents, error) {
+	rows, err := r.q.ListPriorityScoreComponents(ctx, ListPriorityScoreComponentsParams{
+		ConversationID: uuidToPgtype(conversationID),
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make([]*domain.PriorityScoreComponents, len(rows))
+	for i, row := range rows {
+		result[i] = r.toDomain(row)
+	}
+	return result, nil
+}
+
+func (r *PriorityScoreComponentRepositoryImpl) toDomain(row PriorityScoreComponent) *domain.PriorityScoreComponents {
+	return &domain.PriorityScoreComponents{
+		ID:                pgtypeToUUID(row.ID),
+		ConversationID:    pgtypeToUUID(row.ConversationID),
+		TenantID:          pgtypeToUUID(row.TenantID),
+		MessageCount:      row.MessageCount,
+		LastMessageAt:     pgtypeToTime(row.LastMessageAt),
+		MessageFactor:     pgtypeToDecimal(row.MessageFactor),
+		DelayFactor:       pgtypeToDecimal(row.DelayFactor),
+		WeightAlpha:       pgtypeToDecimal(row.WeightAlpha),
+		WeightBeta:        pgtypeToDecimal(row.WeightBeta),
+		RuleBoost:         pgtypeToDecimal(row.RuleBoost),
+		PriorityScore:     pgtypeToDecimal(row.PriorityScore),
+		ComputedAt:        pgtypeToTime(row.ComputedAt),
+		FirstContactBoost: pgtypeToDecimal(row.FirstContactBoost),
+	}
+}
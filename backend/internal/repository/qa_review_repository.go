@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ==================== Reviewers ====================
+
+type QAReviewerRepositoryImpl struct {
+	q *Queries
+}
+
+func NewQAReviewerRepository(q *Queries) *QAReviewerRepositoryImpl {
+	return &QAReviewerRepositoryImpl{q: q}
+}
+
+func (r *QAReviewerRepositoryImpl) Create(ctx context.Context, reviewer *domain.QAReviewer) error {
+	err := r.q.CreateQAReviewer(ctx, CreateQAReviewerParams{
+		OperatorID: uuidToPgtype(reviewer.OperatorID),
+		TenantID:   uuidToPgtype(reviewer.TenantID),
+		GrantedBy:  uuidPtrToPgtype(reviewer.GrantedBy),
+		CreatedAt:  timeToPgtype(reviewer.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *QAReviewerRepositoryImpl) Exists(ctx context.Context, operatorID uuid.UUID) (bool, error) {
+	exists, err := r.q.IsQAReviewer(ctx, uuidToPgtype(operatorID))
+	if err != nil {
+		return false, mapError(err)
+	}
+	return exists, nil
+}
+
+func (r *QAReviewerRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.QAReviewer, error) {
+	rows, err := r.q.GetQAReviewersByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	reviewers := make([]*domain.QAReviewer, len(rows))
+	for i, row := range rows {
+		reviewers[i] = &domain.QAReviewer{
+			OperatorID: pgtypeToUUID(row.OperatorID),
+			TenantID:   pgtypeToUUID(row.TenantID),
+			GrantedBy:  pgtypeToUUIDPtr(row.GrantedBy),
+			CreatedAt:  pgtypeToTime(row.CreatedAt),
+		}
+	}
+	return reviewers, nil
+}
+
+func (r *QAReviewerRepositoryImpl) Delete(ctx context.Context, operatorID uuid.UUID) error {
+	return r.q.DeleteQAReviewer(ctx, uuidToPgtype(operatorID))
+}
+
+// ==================== Review Items ====================
+
+type QAReviewItemRepositoryImpl struct {
+	q *Queries
+}
+
+func NewQAReviewItemRepository(q *Queries) *QAReviewItemRepositoryImpl {
+	return &QAReviewItemRepositoryImpl{q: q}
+}
+
+func (r *QAReviewItemRepositoryImpl) Create(ctx context.Context, item *domain.QAReviewItem) error {
+	return r.q.CreateQAReviewItem(ctx, CreateQAReviewItemParams{
+		ID:             uuidToPgtype(item.ID),
+		TenantID:       uuidToPgtype(item.TenantID),
+		ConversationID: uuidToPgtype(item.ConversationID),
+		OperatorID:     uuidPtrToPgtype(item.OperatorID),
+		Status:         qaReviewStatusToPgtype(item.Status),
+		CreatedAt:      timeToPgtype(item.CreatedAt),
+	})
+}
+
+func (r *QAReviewItemRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.QAReviewItem, error) {
+	row, err := r.q.GetQAReviewItemByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *QAReviewItemRepositoryImpl) GetActiveForReviewer(ctx context.Context, reviewerID uuid.UUID) (*domain.QAReviewItem, error) {
+	row, err := r.q.GetActiveQAReviewItemForReviewer(ctx, uuidToPgtype(reviewerID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *QAReviewItemRepositoryImpl) ClaimNext(ctx context.Context, tenantID, reviewerID uuid.UUID, claimedUntil time.Time) (*domain.QAReviewItem, error) {
+	row, err := r.q.ClaimNextQAReviewItem(ctx, ClaimNextQAReviewItemParams{
+		TenantID:     uuidToPgtype(tenantID),
+		ReviewerID:   uuidToPgtype(reviewerID),
+		ClaimedUntil: timeToPgtype(claimedUntil),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row)
+}
+
+func (r *QAReviewItemRepositoryImpl) Complete(ctx context.Context, item *domain.QAReviewItem) (bool, error) {
+	scores, err := json.Marshal(item.Scores)
+	if err != nil {
+		return false, err
+	}
+
+	var overall pgtype.Numeric
+	if item.OverallScore != nil {
+		overall = decimalToPgtype(*item.OverallScore)
+	}
+
+	affected, err := r.q.CompleteQAReviewItem(ctx, CompleteQAReviewItemParams{
+		ID:           uuidToPgtype(item.ID),
+		Scores:       scores,
+		OverallScore: overall,
+		Comment:      stringPtrToPgtype(item.Comment),
+		CompletedAt:  timePtrToPgtype(item.CompletedAt),
+		ReviewerID:   uuidPtrToPgtype(item.ReviewerID),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	return affected > 0, nil
+}
+
+func (r *QAReviewItemRepositoryImpl) GetCompleted(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*domain.QAReviewItem, error) {
+	rows, err := r.q.GetCompletedQAReviewItems(ctx, GetCompletedQAReviewItemsParams{
+		TenantID:      uuidToPgtype(tenantID),
+		CompletedAt:   timeToPgtype(from),
+		CompletedAt_2: timeToPgtype(to),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	items := make([]*domain.QAReviewItem, len(rows))
+	for i, row := range rows {
+		item, err := r.toDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func (r *QAReviewItemRepositoryImpl) toDomain(row QaReviewItem) (*domain.QAReviewItem, error) {
+	item := &domain.QAReviewItem{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		OperatorID:     pgtypeToUUIDPtr(row.OperatorID),
+		Status:         pgtypeToQAReviewStatus(row.Status),
+		ReviewerID:     pgtypeToUUIDPtr(row.ReviewerID),
+		ClaimedUntil:   pgtypeToTimePtr(row.ClaimedUntil),
+		Comment:        pgtypeToStringPtr(row.Comment),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+		CompletedAt:    pgtypeToTimePtr(row.CompletedAt),
+	}
+	if row.Scores != nil {
+		if err := json.Unmarshal(row.Scores, &item.Scores); err != nil {
+			return nil, err
+		}
+	}
+	if row.OverallScore.Valid {
+		overall := pgtypeToDecimal(row.OverallScore)
+		item.OverallScore = &overall
+	}
+	return item, nil
+}
@@ -11,82 +11,489 @@ import (
 )
 
 type Querier interface {
+	// Raise queued conversations that used up the $3 fraction of an SLA target
+	// to priority $2; returns their inboxes
+	BoostNearSLABreach(ctx context.Context, arg BoostNearSLABreachParams) ([]pgtype.UUID, error)
 	CheckConversationLabelExists(ctx context.Context, arg CheckConversationLabelExistsParams) (bool, error)
+	// Whether the tenant already has a conversation with the phone number;
+	// served by idx_conversations_phone
+	CheckCustomerPhoneSeen(ctx context.Context, arg CheckCustomerPhoneSeenParams) (bool, error)
+	CheckInboxAdminExists(ctx context.Context, arg CheckInboxAdminExistsParams) (bool, error)
 	CheckSubscriptionExists(ctx context.Context, arg CheckSubscriptionExistsParams) (bool, error)
+	// CRITICAL: Claim due entries for the worker. The lease pushes next_attempt_at
+	// forward so that concurrent workers skip rows while the sinks are called.
+	ClaimDueOutboxEntries(ctx context.Context, arg ClaimDueOutboxEntriesParams) ([]EventOutbox, error)
+	// CRITICAL: Claim due deliveries for the worker. The lease pushes next_attempt_at
+	// forward so that concurrent workers skip rows while the HTTP call is in flight.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Inserts the PENDING claim of a key, or takes over an expired key or a
+	// claim past its locked_until; no row is affected while the key is held
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	// Takes the oldest open item, including items whose reviewer lease expired.
+	// Reviewers never receive conversations they handled themselves.
+	ClaimNextQAReviewItem(ctx context.Context, arg ClaimNextQAReviewItemParams) (QaReviewItem, error)
+	ClaimStaleOperatorPresence(ctx context.Context, lastSeenAt pgtype.Timestamptz) ([]OperatorPresence, error)
+	// Stores the response of a PENDING claim; no row is affected once the claim
+	// was taken over
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) (int64, error)
+	CompleteQAReviewItem(ctx context.Context, arg CompleteQAReviewItemParams) (int64, error)
+	// Attempts that assigned nothing, for the anomaly detector
+	CountAbortedAllocationIntents(ctx context.Context, arg CountAbortedAllocationIntentsParams) (int64, error)
+	// Allocations and claims per inbox since $2
+	CountAllocationsByInbox(ctx context.Context, arg CountAllocationsByInboxParams) ([]CountAllocationsByInboxRow, error)
+	CountAuditLogByAction(ctx context.Context, arg CountAuditLogByActionParams) (int64, error)
+	// Conversations created per hour (as Unix time of the hour start)
+	CountConversationsCreatedByHour(ctx context.Context, arg CountConversationsCreatedByHourParams) ([]CountConversationsCreatedByHourRow, error)
+	// Conversations the phone number started in the inbox since the given time,
+	// and when the oldest of them started; served by idx_conversations_phone
+	CountCustomerIntakeSince(ctx context.Context, arg CountCustomerIntakeSinceParams) (CountCustomerIntakeSinceRow, error)
+	// Escalations per inbox they left in [$2, $3)
+	CountEscalationsByInbox(ctx context.Context, arg CountEscalationsByInboxParams) ([]CountEscalationsByInboxRow, error)
+	// Escalations per operator the conversations were taken from in [$2, $3)
+	CountEscalationsByOperator(ctx context.Context, arg CountEscalationsByOperatorParams) ([]CountEscalationsByOperatorRow, error)
+	// Resolutions by the assigned operator within $3 seconds of the conversation's
+	// latest assignment, per operator
+	CountFastResolvesByActor(ctx context.Context, arg CountFastResolvesByActorParams) ([]CountFastResolvesByActorRow, error)
 	CountIdempotencyKeys(ctx context.Context, tenantID pgtype.UUID) (int64, error)
+	// Allocations and claims out of the inbox since $3
+	CountInboxAllocations(ctx context.Context, arg CountInboxAllocationsParams) (int64, error)
+	// Allocations and claims out of the inbox since $3 of conversations carrying
+	// each of the labels $4
+	CountInboxAllocationsByLabel(ctx context.Context, arg CountInboxAllocationsByLabelParams) ([]CountInboxAllocationsByLabelRow, error)
+	// Conversations of each inbox of the tenant by state, those open past their
+	// due date at $3, and the average time from creation to resolution of those
+	// resolved since $2
+	CountInboxConversationsByState(ctx context.Context, arg CountInboxConversationsByStateParams) ([]CountInboxConversationsByStateRow, error)
+	CountInboxConversationsCreatedByHour(ctx context.Context, arg CountInboxConversationsCreatedByHourParams) ([]CountInboxConversationsCreatedByHourRow, error)
+	CountInboxQueueRanks(ctx context.Context, inboxID pgtype.UUID) (int64, error)
+	// Attachments made in [$3, $4) that are still in place, grouped by the label
+	// version they were attached under so renamed labels keep their old names
+	// Tenant-scoped labels count for the inbox of the conversation
+	CountLabelAttachmentsByVersion(ctx context.Context, arg CountLabelAttachmentsByVersionParams) ([]CountLabelAttachmentsByVersionRow, error)
+	// Allocations, claims and reassignments to each operator since $2, and
+	// deallocations of conversations they held
+	CountOperatorAllocationOutcomes(ctx context.Context, arg CountOperatorAllocationOutcomesParams) ([]CountOperatorAllocationOutcomesRow, error)
+	CountOperatorDevices(ctx context.Context, operatorID pgtype.UUID) (int64, error)
+	CountOperatorsByStatus(ctx context.Context, tenantID pgtype.UUID) ([]CountOperatorsByStatusRow, error)
+	CountPendingOutboxEntries(ctx context.Context) (int64, error)
+	// Conversations waiting for allocation in the inbox, excluding snoozed ones
+	CountQueuedConversationsByInbox(ctx context.Context, inboxID pgtype.UUID) (int64, error)
+	CountReconciliationDivergences(ctx context.Context, tenantID pgtype.UUID) ([]CountReconciliationDivergencesRow, error)
+	CountStaleConversationActivity(ctx context.Context) (int64, error)
+	CreateAllocationIntent(ctx context.Context, arg CreateAllocationIntentParams) error
+	CreateAnomaly(ctx context.Context, arg CreateAnomalyParams) error
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) error
+	CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) error
+	CreateConversationEscalation(ctx context.Context, arg CreateConversationEscalationParams) error
 	CreateConversationLabel(ctx context.Context, arg CreateConversationLabelParams) error
+	CreateConversationNote(ctx context.Context, arg CreateConversationNoteParams) error
 	CreateConversationRef(ctx context.Context, arg CreateConversationRefParams) error
+	// Insert unless the external conversation is already tracked (ingestion upsert)
+	CreateConversationRefIfNotExists(ctx context.Context, arg CreateConversationRefIfNotExistsParams) (int64, error)
+	CreateConversationShareLink(ctx context.Context, arg CreateConversationShareLinkParams) error
+	CreateConversationTransfer(ctx context.Context, arg CreateConversationTransferParams) error
 	CreateGracePeriodAssignment(ctx context.Context, arg CreateGracePeriodAssignmentParams) error
 	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error
 	CreateInbox(ctx context.Context, arg CreateInboxParams) error
+	CreateInboxAdmin(ctx context.Context, arg CreateInboxAdminParams) error
+	CreateInboxCategoryQuota(ctx context.Context, arg CreateInboxCategoryQuotaParams) error
+	// Creates the label together with its first version
 	CreateLabel(ctx context.Context, arg CreateLabelParams) error
+	// Used by routing rules: concurrent creators of the same label must not fail
+	CreateLabelIfNotExists(ctx context.Context, arg CreateLabelIfNotExistsParams) error
+	CreateLabelVersion(ctx context.Context, arg CreateLabelVersionParams) error
 	CreateOperator(ctx context.Context, arg CreateOperatorParams) error
+	CreateOperatorSchedule(ctx context.Context, arg CreateOperatorScheduleParams) error
+	CreateOperatorShadow(ctx context.Context, arg CreateOperatorShadowParams) error
 	CreateOperatorStatus(ctx context.Context, arg CreateOperatorStatusParams) error
+	CreateOutboxEntry(ctx context.Context, arg CreateOutboxEntryParams) error
+	CreatePriorityExperiment(ctx context.Context, arg CreatePriorityExperimentParams) error
+	// Records the arm of a conversation once; later calculations keep it
+	CreatePriorityExperimentAssignment(ctx context.Context, arg CreatePriorityExperimentAssignmentParams) error
+	CreatePriorityScoreComponents(ctx context.Context, arg CreatePriorityScoreComponentsParams) error
+	CreateQAReviewItem(ctx context.Context, arg CreateQAReviewItemParams) error
+	CreateQAReviewer(ctx context.Context, arg CreateQAReviewerParams) error
+	CreateRoutingRule(ctx context.Context, arg CreateRoutingRuleParams) error
+	CreateSchemaBackfill(ctx context.Context, arg CreateSchemaBackfillParams) error
 	CreateSubscription(ctx context.Context, arg CreateSubscriptionParams) error
+	// Insert unless the operator is already subscribed to the inbox
+	CreateSubscriptionIfNotExists(ctx context.Context, arg CreateSubscriptionIfNotExistsParams) (int64, error)
 	CreateTenant(ctx context.Context, arg CreateTenantParams) error
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
 	DeleteAllConversationLabels(ctx context.Context, conversationID pgtype.UUID) error
 	DeleteConversationLabel(ctx context.Context, arg DeleteConversationLabelParams) error
 	DeleteConversationRef(ctx context.Context, id pgtype.UUID) error
+	DeleteCustomersByTenantID(ctx context.Context, tenantID pgtype.UUID) error
 	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
 	DeleteGracePeriodAssignment(ctx context.Context, id pgtype.UUID) error
 	DeleteGracePeriodByConversationID(ctx context.Context, conversationID pgtype.UUID) error
 	DeleteGracePeriodsByOperatorID(ctx context.Context, operatorID pgtype.UUID) error
 	DeleteIdempotencyKey(ctx context.Context, id pgtype.UUID) error
 	DeleteInbox(ctx context.Context, id pgtype.UUID) error
+	DeleteInboxAdmin(ctx context.Context, arg DeleteInboxAdminParams) error
+	DeleteInboxCategoryQuotas(ctx context.Context, inboxID pgtype.UUID) error
+	DeleteInboxChecklistTemplate(ctx context.Context, inboxID pgtype.UUID) error
+	DeleteInboxQueueRanks(ctx context.Context, inboxID pgtype.UUID) error
+	DeleteInboxSLAPolicy(ctx context.Context, inboxID pgtype.UUID) error
+	DeleteInboxStalenessPolicy(ctx context.Context, inboxID pgtype.UUID) error
+	// Deletes every inbox of the tenant with its conversations, labels and
+	// subscriptions
+	DeleteInboxesByTenantID(ctx context.Context, tenantID pgtype.UUID) error
 	DeleteLabel(ctx context.Context, id pgtype.UUID) error
 	DeleteOperator(ctx context.Context, id pgtype.UUID) error
+	DeleteOperatorDevice(ctx context.Context, id pgtype.UUID) error
+	DeleteOperatorDeviceByToken(ctx context.Context, token string) error
+	DeleteOperatorSchedule(ctx context.Context, id pgtype.UUID) error
+	DeleteOperatorShadow(ctx context.Context, id pgtype.UUID) error
+	DeleteOperatorVacation(ctx context.Context, operatorID pgtype.UUID) (int64, error)
+	DeletePriorityExperiment(ctx context.Context, id pgtype.UUID) error
+	DeletePublishedOutboxEntries(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
+	DeleteQAReviewer(ctx context.Context, operatorID pgtype.UUID) error
+	// Forgets the divergences of conversations found consistent again
+	DeleteReconciliationDivergences(ctx context.Context, dollar_1 []pgtype.UUID) error
+	DeleteResolvedAllocationIntents(ctx context.Context, resolvedAt pgtype.Timestamptz) (int64, error)
+	DeleteRoutingRule(ctx context.Context, id pgtype.UUID) error
+	DeleteStaleWorkerInstances(ctx context.Context, heartbeatAt pgtype.Timestamptz) (int64, error)
 	DeleteSubscription(ctx context.Context, id pgtype.UUID) error
 	DeleteSubscriptionByOperatorAndInbox(ctx context.Context, arg DeleteSubscriptionByOperatorAndInboxParams) error
 	DeleteTenant(ctx context.Context, id pgtype.UUID) error
+	DeleteWebhook(ctx context.Context, id pgtype.UUID) error
+	DeleteWorkerInstance(ctx context.Context, instanceID pgtype.UUID) error
+	// Whether an anomaly of the kind was flagged for the operator (or tenant-wide
+	// when NULL) after the given time
+	ExistsRecentAnomaly(ctx context.Context, arg ExistsRecentAnomalyParams) (bool, error)
+	// Ends up to $2 pending transfers past their expiry at $1, soonest first;
+	// transfers being answered are skipped
+	ExpireConversationTransfers(ctx context.Context, arg ExpireConversationTransfersParams) ([]ConversationTransfer, error)
+	FindAllocatedWithoutOperator(ctx context.Context, arg FindAllocatedWithoutOperatorParams) ([]FindAllocatedWithoutOperatorRow, error)
+	// Unresolved conversations sharing their external ID with another, grouped
+	// by external ID. idx_conversations_external_id rules these out unless the
+	// index is invalid or was dropped.
+	FindDuplicateExternalIDs(ctx context.Context, arg FindDuplicateExternalIDsParams) ([]FindDuplicateExternalIDsRow, error)
+	FindOperatorNotSubscribed(ctx context.Context, arg FindOperatorNotSubscribedParams) ([]FindOperatorNotSubscribedRow, error)
+	FindResolvedWithGracePeriod(ctx context.Context, arg FindResolvedWithGracePeriodParams) ([]FindResolvedWithGracePeriodRow, error)
+	GetActiveQAReviewItemForReviewer(ctx context.Context, reviewerID pgtype.UUID) (QaReviewItem, error)
+	// Rules evaluated for a conversation: tenant-wide rules plus rules of its inbox
+	GetActiveRoutingRulesForTrigger(ctx context.Context, arg GetActiveRoutingRulesForTriggerParams) ([]RoutingRule, error)
+	GetActiveWebhooksForEvent(ctx context.Context, arg GetActiveWebhooksForEventParams) ([]Webhook, error)
+	GetAllocationIntentByIdempotencyKey(ctx context.Context, arg GetAllocationIntentByIdempotencyKeyParams) (AllocationIntent, error)
+	// The operator's allocated conversations, most urgent first, locked for the
+	// vacation drain worker
+	GetAndLockAllocatedByOperator(ctx context.Context, assignedOperatorID pgtype.UUID) ([]ConversationRef, error)
+	// Vacations whose conversations are still being drained, locked for the
+	// vacation drain worker
+	GetAndLockDrainingOperatorVacations(ctx context.Context, limit int32) ([]OperatorVacation, error)
+	// Snoozes that ended, locked for the snooze worker
+	GetAndLockEndedSnoozes(ctx context.Context, arg GetAndLockEndedSnoozesParams) ([]ConversationRef, error)
 	// CRITICAL: Get and lock expired for worker
 	GetAndLockExpiredGracePeriods(ctx context.Context, limit int32) ([]GracePeriodAssignment, error)
+	// Open conversations past their due date not flagged yet, locked for the
+	// overdue worker
+	GetAndLockOverdueConversations(ctx context.Context, arg GetAndLockOverdueConversationsParams) ([]ConversationRef, error)
+	// Allocations idle past their inbox's limit not flagged yet, locked for the
+	// stale allocation worker
+	GetAndLockStaleConversationActivity(ctx context.Context, arg GetAndLockStaleConversationActivityParams) ([]ConversationActivity, error)
+	// Open conversations idle past their inbox's auto_resolve_after_seconds,
+	// stalest first, locked for the auto-resolve worker; snoozed conversations
+	// wait for their follow-up
+	GetAndLockStaleConversations(ctx context.Context, arg GetAndLockStaleConversationsParams) ([]ConversationRef, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetApiKeyByID(ctx context.Context, id pgtype.UUID) (ApiKey, error)
+	GetApiKeysByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]ApiKey, error)
 	GetAvailableOperators(ctx context.Context, tenantID pgtype.UUID) ([]OperatorStatus, error)
+	GetCompletedQAReviewItems(ctx context.Context, arg GetCompletedQAReviewItemsParams) ([]QaReviewItem, error)
+	GetConversationActivity(ctx context.Context, conversationID pgtype.UUID) (ConversationActivity, error)
+	GetConversationChecklistItem(ctx context.Context, id pgtype.UUID) (ConversationChecklistItem, error)
+	GetConversationChecklistStatus(ctx context.Context, conversationID pgtype.UUID) (GetConversationChecklistStatusRow, error)
 	GetConversationLabelsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]ConversationLabel, error)
 	GetConversationLabelsByLabelID(ctx context.Context, labelID pgtype.UUID) ([]ConversationLabel, error)
+	GetConversationReadsByOperator(ctx context.Context, arg GetConversationReadsByOperatorParams) ([]ConversationRead, error)
 	GetConversationRefByExternalID(ctx context.Context, arg GetConversationRefByExternalIDParams) (ConversationRef, error)
 	GetConversationRefByID(ctx context.Context, id pgtype.UUID) (ConversationRef, error)
+	GetConversationShareLinkByID(ctx context.Context, id pgtype.UUID) (ConversationShareLink, error)
+	GetConversationShareLinksByConversation(ctx context.Context, conversationID pgtype.UUID) ([]ConversationShareLink, error)
+	GetConversationTransferByID(ctx context.Context, id pgtype.UUID) (ConversationTransfer, error)
 	GetConversationsByInbox(ctx context.Context, arg GetConversationsByInboxParams) ([]ConversationRef, error)
 	GetConversationsByOperatorAndState(ctx context.Context, arg GetConversationsByOperatorAndStateParams) ([]ConversationRef, error)
 	GetConversationsByOperatorID(ctx context.Context, arg GetConversationsByOperatorIDParams) ([]ConversationRef, error)
 	GetConversationsByTenantAndState(ctx context.Context, arg GetConversationsByTenantAndStateParams) ([]ConversationRef, error)
+	GetCustomerByID(ctx context.Context, id pgtype.UUID) (Customer, error)
+	GetCustomerByPhone(ctx context.Context, arg GetCustomerByPhoneParams) (Customer, error)
 	GetExpiredGracePeriods(ctx context.Context, limit int32) ([]GracePeriodAssignment, error)
 	GetExpiredIdempotencyKeysForCleanup(ctx context.Context, limit int32) ([]IdempotencyKey, error)
 	GetGracePeriodByConversationID(ctx context.Context, conversationID pgtype.UUID) (GracePeriodAssignment, error)
+	GetGracePeriodByID(ctx context.Context, arg GetGracePeriodByIDParams) (GracePeriodAssignment, error)
 	GetGracePeriodsByOperatorID(ctx context.Context, operatorID pgtype.UUID) ([]GracePeriodAssignment, error)
 	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	// Unexpired completed keys whose response is not stored under the given key
+	// (NULL: stored in plaintext), after the cursor in id order
+	GetIdempotencyKeysToReencrypt(ctx context.Context, arg GetIdempotencyKeysToReencryptParams) ([]IdempotencyKey, error)
+	GetInboxAdminsByInboxID(ctx context.Context, inboxID pgtype.UUID) ([]InboxAdmin, error)
+	GetInboxAdminsByOperatorID(ctx context.Context, operatorID pgtype.UUID) ([]InboxAdmin, error)
 	GetInboxByID(ctx context.Context, id pgtype.UUID) (Inbox, error)
 	GetInboxByPhoneNumber(ctx context.Context, arg GetInboxByPhoneNumberParams) (Inbox, error)
+	GetInboxChecklistTemplate(ctx context.Context, inboxID pgtype.UUID) (InboxChecklistTemplate, error)
+	GetInboxDeletionImpact(ctx context.Context, inboxID pgtype.UUID) (GetInboxDeletionImpactRow, error)
+	// Inboxes with a queue to rank or ranks to clear
+	GetInboxIDsToRank(ctx context.Context) ([]pgtype.UUID, error)
+	GetInboxQueueRankByConversationID(ctx context.Context, conversationID pgtype.UUID) (InboxQueueRank, error)
+	GetInboxSLAPolicy(ctx context.Context, inboxID pgtype.UUID) (InboxSlaPolicy, error)
+	GetInboxStalenessPolicy(ctx context.Context, inboxID pgtype.UUID) (InboxStalenessPolicy, error)
 	GetInboxesByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]Inbox, error)
 	GetLabelByID(ctx context.Context, id pgtype.UUID) (Label, error)
+	// Serializes concurrent renames of the same label
+	GetLabelByIDForUpdate(ctx context.Context, id pgtype.UUID) (Label, error)
 	GetLabelByName(ctx context.Context, arg GetLabelByNameParams) (Label, error)
+	// Routing rules attach labels by name: the rules of the label's inbox and
+	// the tenant-wide ones naming it; every rule naming a tenant label
+	GetLabelDeletionImpact(ctx context.Context, id pgtype.UUID) (GetLabelDeletionImpactRow, error)
+	// Returns the labels and every label below them; UNION stops at cycles
+	GetLabelDescendantIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]pgtype.UUID, error)
+	// Labels attached to the conversation, by name
+	GetLabelsByConversationID(ctx context.Context, conversationID pgtype.UUID) ([]Label, error)
+	// The inbox's labels and the tenant-scoped labels, which apply to every inbox
 	GetLabelsByInboxID(ctx context.Context, arg GetLabelsByInboxIDParams) ([]Label, error)
+	// Labels attached to each of the conversations, by name; one query for a
+	// whole list page
+	GetLabelsForConversations(ctx context.Context, dollar_1 []pgtype.UUID) ([]GetLabelsForConversationsRow, error)
+	// Time of the operator's latest automatic allocation
+	GetLastAllocationByActor(ctx context.Context, arg GetLastAllocationByActorParams) (pgtype.Timestamptz, error)
+	// Most recent note of a kind
+	GetLatestConversationNote(ctx context.Context, arg GetLatestConversationNoteParams) (ConversationNote, error)
+	// Most recent note of a kind addressed to the operator
+	GetLatestConversationNoteForRecipient(ctx context.Context, arg GetLatestConversationNoteForRecipientParams) (ConversationNote, error)
+	GetMentorIDsForTrainee(ctx context.Context, traineeID pgtype.UUID) ([]pgtype.UUID, error)
+	// Newest worker protocol among replicas running workers; 0 for none
+	GetNewestLiveWorkerProtocol(ctx context.Context, arg GetNewestLiveWorkerProtocolParams) (int32, error)
+	// Queued conversations of the customers the operator $3 resolved last, since
+	// $4, in allocation order; offered to the operator before the rest of the queue
+	GetNextAffineConversationsForAllocation(ctx context.Context, arg GetNextAffineConversationsForAllocationParams) ([]ConversationRef, error)
 	// CRITICAL: Allocation query with FOR UPDATE SKIP LOCKED
+	// Candidates are the top $4 of each inbox in idx_conversations_queue order
+	// plus the pinned ones (idx_conversations_pinned), so the scan stops after a
+	// few index entries per inbox instead of sorting every queued conversation.
+	// Candidates locked by concurrent allocators are skipped; when fewer than $3
+	// are left the caller falls back to GetNextConversationsForAllocationFullScan.
 	GetNextConversationsForAllocation(ctx context.Context, arg GetNextConversationsForAllocationParams) ([]ConversationRef, error)
+	// Allocation order of the v2 engine: conversations that breached an SLA
+	// target or their due date come first, earliest breach first, then the usual
+	// order. Category quotas apply as in GetNextConversationsForAllocationWithQuotas;
+	// an empty $3 and a NULL $4 leave them out.
+	GetNextConversationsForAllocationBreachFirst(ctx context.Context, arg GetNextConversationsForAllocationBreachFirstParams) ([]ConversationRef, error)
+	// Allocation order restricted to conversations carrying one of the labels
+	// $3, for operators pulling e.g. only billing conversations; $4 takes
+	// breached conversations first as the v2 engine does
+	GetNextConversationsForAllocationByLabels(ctx context.Context, arg GetNextConversationsForAllocationByLabelsParams) ([]ConversationRef, error)
+	// Allocation order over every queued conversation of the inboxes; the
+	// fallback of GetNextConversationsForAllocation under contention
+	GetNextConversationsForAllocationFullScan(ctx context.Context, arg GetNextConversationsForAllocationFullScanParams) ([]ConversationRef, error)
+	// Allocation order for inboxes with category quotas: conversations created
+	// before $4 come first so nothing starves, then those carrying one of the
+	// preferred category labels $3, then the usual order
+	GetNextConversationsForAllocationWithQuotas(ctx context.Context, arg GetNextConversationsForAllocationWithQuotasParams) ([]ConversationRef, error)
+	// Oldest first, for moving an inbox's backlog in batches
+	GetOpenConversationIDsByInbox(ctx context.Context, arg GetOpenConversationIDsByInboxParams) ([]pgtype.UUID, error)
+	GetOperatorAllocationHealth(ctx context.Context, operatorID pgtype.UUID) (OperatorAllocationHealth, error)
 	GetOperatorByID(ctx context.Context, id pgtype.UUID) (Operator, error)
+	GetOperatorDeletionImpact(ctx context.Context, assignedOperatorID pgtype.UUID) (GetOperatorDeletionImpactRow, error)
+	GetOperatorDeviceByID(ctx context.Context, id pgtype.UUID) (OperatorDevice, error)
+	GetOperatorDevicesByOperatorID(ctx context.Context, operatorID pgtype.UUID) ([]OperatorDevice, error)
+	GetOperatorPresenceByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]OperatorPresence, error)
+	GetOperatorScheduleByID(ctx context.Context, id pgtype.UUID) (OperatorSchedule, error)
+	GetOperatorSchedulesByOperatorID(ctx context.Context, operatorID pgtype.UUID) ([]OperatorSchedule, error)
+	GetOperatorShadowByID(ctx context.Context, id pgtype.UUID) (OperatorShadow, error)
+	GetOperatorShadowsByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]OperatorShadow, error)
 	GetOperatorStatusByOperatorID(ctx context.Context, operatorID pgtype.UUID) (OperatorStatus, error)
+	GetOperatorStatusesByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]OperatorStatus, error)
+	GetOperatorVacationByOperatorID(ctx context.Context, operatorID pgtype.UUID) (OperatorVacation, error)
 	GetOperatorsByTenantAndRole(ctx context.Context, arg GetOperatorsByTenantAndRoleParams) ([]Operator, error)
 	GetOperatorsByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]Operator, error)
+	// Backlog of the grace period worker, for pipeline health
+	GetOverdueGracePeriodStats(ctx context.Context) (GetOverdueGracePeriodStatsRow, error)
+	GetPendingAllocationIntents(ctx context.Context, arg GetPendingAllocationIntentsParams) ([]AllocationIntent, error)
+	GetPriorityExperimentByID(ctx context.Context, id pgtype.UUID) (PriorityExperiment, error)
+	// Outcomes per arm: wait from creation to first allocation and resolution
+	// time, over the conversations that got there
+	GetPriorityExperimentOutcomes(ctx context.Context, experimentID pgtype.UUID) ([]GetPriorityExperimentOutcomesRow, error)
+	GetPriorityExperimentsByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]PriorityExperiment, error)
+	GetQAReviewItemByID(ctx context.Context, id pgtype.UUID) (QaReviewItem, error)
+	GetQAReviewersByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]QaReviewer, error)
 	GetQueuedConversationsByTenant(ctx context.Context, arg GetQueuedConversationsByTenantParams) ([]ConversationRef, error)
+	GetRoutingRuleByID(ctx context.Context, id pgtype.UUID) (RoutingRule, error)
+	GetRoutingRulesByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]RoutingRule, error)
+	GetRunningPriorityExperimentByInbox(ctx context.Context, inboxID pgtype.UUID) (PriorityExperiment, error)
+	GetRunningPriorityExperimentsByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]PriorityExperiment, error)
+	// Windows of every AVAILABLE operator that has a schedule, ordered by operator
+	GetSchedulesOfAvailableOperators(ctx context.Context) ([]OperatorSchedule, error)
+	GetSchemaBackfills(ctx context.Context) ([]SchemaBackfill, error)
+	// Version recorded by golang-migrate
+	GetSchemaMigration(ctx context.Context) (GetSchemaMigrationRow, error)
 	GetSubscribedInboxIDs(ctx context.Context, operatorID pgtype.UUID) ([]pgtype.UUID, error)
 	GetSubscriptionByID(ctx context.Context, id pgtype.UUID) (OperatorInboxSubscription, error)
 	GetSubscriptionByOperatorAndInbox(ctx context.Context, arg GetSubscriptionByOperatorAndInboxParams) (OperatorInboxSubscription, error)
 	GetSubscriptionsByInboxID(ctx context.Context, inboxID pgtype.UUID) ([]OperatorInboxSubscription, error)
 	GetSubscriptionsByOperatorID(ctx context.Context, operatorID pgtype.UUID) ([]OperatorInboxSubscription, error)
+	GetTenantAnomalySettings(ctx context.Context, tenantID pgtype.UUID) (TenantAnomalySetting, error)
 	GetTenantByID(ctx context.Context, id pgtype.UUID) (Tenant, error)
 	GetTenantByName(ctx context.Context, name string) (Tenant, error)
+	GetTenantClassifier(ctx context.Context, tenantID pgtype.UUID) (TenantClassifier, error)
+	GetTenantLabelByName(ctx context.Context, arg GetTenantLabelByNameParams) (Label, error)
+	GetTenantLabels(ctx context.Context, tenantID pgtype.UUID) ([]Label, error)
+	GetTenantMaintenanceSettings(ctx context.Context, tenantID pgtype.UUID) (TenantMaintenanceSetting, error)
+	GetWebhookByID(ctx context.Context, id pgtype.UUID) (Webhook, error)
+	GetWebhookDeliveriesByWebhookID(ctx context.Context, arg GetWebhookDeliveriesByWebhookIDParams) ([]WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx context.Context, id pgtype.UUID) (WebhookDelivery, error)
+	GetWebhooksByTenantID(ctx context.Context, tenantID pgtype.UUID) ([]Webhook, error)
 	HealthCheck(ctx context.Context) (int32, error)
+	// Ranks the inbox's QUEUED conversations in allocation order; snoozed ones
+	// are not in the queue. A conversation still ranked under the inbox it was
+	// moved from is taken over.
+	InsertInboxQueueRanks(ctx context.Context, arg InsertInboxQueueRanksParams) (int64, error)
+	// Copies the inbox template's items onto the conversations without a checklist
+	InstantiateConversationChecklists(ctx context.Context, dollar_1 []pgtype.UUID) (int64, error)
+	// Copies the inbox template's pinned note onto the conversations without one
+	InstantiatePinnedNotes(ctx context.Context, dollar_1 []pgtype.UUID) (int64, error)
+	IsQAReviewer(ctx context.Context, operatorID pgtype.UUID) (bool, error)
+	ListAnomalies(ctx context.Context, arg ListAnomaliesParams) ([]Anomaly, error)
+	ListAuditLogByAction(ctx context.Context, arg ListAuditLogByActionParams) ([]AuditLog, error)
+	ListCategoryQuotasByInboxIDs(ctx context.Context, dollar_1 []pgtype.UUID) ([]InboxCategoryQuota, error)
+	ListConversationChecklistItems(ctx context.Context, conversationID pgtype.UUID) ([]ConversationChecklistItem, error)
+	// Grace periods of the tenant, optionally of one operator ($2) or
+	// conversation ($3), soonest to expire first
+	ListGracePeriods(ctx context.Context, arg ListGracePeriodsParams) ([]GracePeriodAssignment, error)
+	ListInboxCategoryQuotas(ctx context.Context, inboxID pgtype.UUID) ([]InboxCategoryQuota, error)
+	ListInboxQueueRanks(ctx context.Context, arg ListInboxQueueRanksParams) ([]InboxQueueRank, error)
+	ListInboxSLABreaches(ctx context.Context, arg ListInboxSLABreachesParams) ([]ConversationRef, error)
+	ListLabelVersions(ctx context.Context, labelID pgtype.UUID) ([]LabelVersion, error)
+	ListOperatorAllocationHealth(ctx context.Context, tenantID pgtype.UUID) ([]OperatorAllocationHealth, error)
+	// Pending transfers the operator proposed or received, oldest first
+	ListPendingConversationTransfersByOperator(ctx context.Context, arg ListPendingConversationTransfersByOperatorParams) ([]ConversationTransfer, error)
+	// Newest calculation first
+	ListPriorityScoreComponents(ctx context.Context, arg ListPriorityScoreComponentsParams) ([]PriorityScoreComponent, error)
+	// The tenant's conversations to compare with upstream after id $2: those
+	// updated since $3 and those already diverging
+	ListReconciliationCandidates(ctx context.Context, arg ListReconciliationCandidatesParams) ([]ListReconciliationCandidatesRow, error)
+	// The tenant's divergences of kind $2 (empty for all), oldest first
+	ListReconciliationDivergences(ctx context.Context, arg ListReconciliationDivergencesParams) ([]ListReconciliationDivergencesRow, error)
+	ListSLABreaches(ctx context.Context, arg ListSLABreachesParams) ([]ConversationRef, error)
+	// Every tenant with its configured sensitivity, NULL when not configured
+	ListTenantAnomalySensitivities(ctx context.Context) ([]ListTenantAnomalySensitivitiesRow, error)
 	ListTenants(ctx context.Context) ([]Tenant, error)
 	// CRITICAL: Lock specific conversation for claim
 	LockConversationForClaim(ctx context.Context, id pgtype.UUID) (ConversationRef, error)
+	// Lock conversation row by external ID for in-place updates (ingestion)
+	LockConversationRefByExternalID(ctx context.Context, arg LockConversationRefByExternalIDParams) (ConversationRef, error)
+	// Lock conversation row for in-place updates (message received)
+	LockConversationRefForUpdate(ctx context.Context, id pgtype.UUID) (ConversationRef, error)
+	LockConversationTransfer(ctx context.Context, id pgtype.UUID) (ConversationTransfer, error)
+	// Claim a backfill job; replicas skip jobs another instance is processing
+	LockPendingSchemaBackfill(ctx context.Context, name string) (SchemaBackfill, error)
+	MarkConversationActivityStale(ctx context.Context, arg MarkConversationActivityStaleParams) error
+	MarkOutboxEntryPublished(ctx context.Context, arg MarkOutboxEntryPublishedParams) error
+	// Stamps the first allocation of the enrolled conversations among $1
+	MarkPriorityExperimentAssignmentsAllocated(ctx context.Context, arg MarkPriorityExperimentAssignmentsAllocatedParams) error
+	MarkReconciliationDivergenceCorrected(ctx context.Context, arg MarkReconciliationDivergenceCorrectedParams) error
+	// Flag open conversations past a target of their inbox's SLA policy. Snoozed
+	// conversations were already assigned once and only count for resolution.
+	MarkSLABreaches(ctx context.Context, slaBreachedAt pgtype.Timestamptz) ([]MarkSLABreachesRow, error)
+	// Head of the allocation order of GetNextConversationsForAllocation, without
+	// locking, for previews; may return a conversation being allocated
+	// concurrently
+	PeekNextConversationForAllocation(ctx context.Context, arg PeekNextConversationForAllocationParams) (ConversationRef, error)
+	// Head of the allocation order of GetNextConversationsForAllocationBreachFirst,
+	// without locking, for previews
+	PeekNextConversationForAllocationBreachFirst(ctx context.Context, arg PeekNextConversationForAllocationBreachFirstParams) (ConversationRef, error)
+	// Head of the allocation order of GetNextConversationsForAllocationWithQuotas,
+	// without locking, for previews
+	PeekNextConversationForAllocationWithQuotas(ctx context.Context, arg PeekNextConversationForAllocationWithQuotasParams) (ConversationRef, error)
+	// Drops the activity of conversations no longer allocated or whose inbox
+	// has no staleness policy anymore
+	PruneConversationActivity(ctx context.Context) (int64, error)
+	RecordConversationActivity(ctx context.Context, arg RecordConversationActivityParams) error
+	RecordConversationShareLinkAccess(ctx context.Context, arg RecordConversationShareLinkAccessParams) error
+	ReleaseIdempotencyKey(ctx context.Context, id pgtype.UUID) error
+	// Only the first resolution of a PENDING intent wins
+	ResolveAllocationIntent(ctx context.Context, arg ResolveAllocationIntentParams) (int64, error)
+	// Reuses the intent of an aborted attempt for a retry with the same key
+	RestartAllocationIntent(ctx context.Context, arg RestartAllocationIntentParams) (pgtype.UUID, error)
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) error
+	RevokeConversationShareLink(ctx context.Context, arg RevokeConversationShareLinkParams) error
 	SearchConversationsByPhone(ctx context.Context, arg SearchConversationsByPhoneParams) ([]ConversationRef, error)
-	UpdateConversationRef(ctx context.Context, arg UpdateConversationRefParams) error
+	// Customers whose phone number starts with the pattern or whose name
+	// contains it (an empty pattern matches all), newest first; keyset-paginated
+	// on (created_at, id)
+	SearchCustomers(ctx context.Context, arg SearchCustomersParams) ([]Customer, error)
+	SetAllocationIntentConversation(ctx context.Context, arg SetAllocationIntentConversationParams) error
+	// Set or clear the due date, which re-arms the overdue flag;
+	// UpdateConversationRef leaves both alone
+	SetConversationRefDueAt(ctx context.Context, arg SetConversationRefDueAtParams) error
+	// Flag the conversation overdue; UpdateConversationRef leaves it alone
+	SetConversationRefOverdue(ctx context.Context, arg SetConversationRefOverdueParams) error
+	// Set or clear the manual priority; UpdateConversationRef leaves it alone
+	SetConversationRefPriorityOverride(ctx context.Context, arg SetConversationRefPriorityOverrideParams) error
+	// Set or clear the snooze; state and assignment are changed through
+	// UpdateConversationRef, which leaves these columns alone
+	SetConversationRefSnooze(ctx context.Context, arg SetConversationRefSnoozeParams) error
+	// Moves the expiry of a grace period the worker has not picked up yet
+	SetGracePeriodExpiry(ctx context.Context, arg SetGracePeriodExpiryParams) (int64, error)
+	// Written by managers; leaves the feedback-loop weight untouched
+	SetOperatorAllocationOverride(ctx context.Context, arg SetOperatorAllocationOverrideParams) error
+	// Allocations in inboxes with a staleness policy that have no activity yet,
+	// or activity of a previous operator, start idling at $1
+	StartConversationActivityClocks(ctx context.Context, dollar_1 pgtype.Timestamptz) (int64, error)
+	TouchApiKey(ctx context.Context, arg TouchApiKeyParams) error
+	// Refreshes the operator's presence; inserted is true when the row did not
+	// exist, i.e. the operator was not connected or had timed out
+	TouchOperatorPresence(ctx context.Context, arg TouchOperatorPresenceParams) (bool, error)
+	UpdateConversationChecklistItemCompletion(ctx context.Context, arg UpdateConversationChecklistItemCompletionParams) error
+	// Compare-and-swap on version: no row is updated when another writer
+	// changed the conversation since it was read
+	UpdateConversationRef(ctx context.Context, arg UpdateConversationRefParams) (int64, error)
 	// Update state only (for allocation/deallocate/resolve)
 	UpdateConversationState(ctx context.Context, arg UpdateConversationStateParams) error
+	UpdateConversationTransferStatus(ctx context.Context, arg UpdateConversationTransferStatusParams) error
+	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) error
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) error
 	UpdateInbox(ctx context.Context, arg UpdateInboxParams) error
 	UpdateLabel(ctx context.Context, arg UpdateLabelParams) error
 	UpdateOperator(ctx context.Context, arg UpdateOperatorParams) error
+	UpdateOperatorDeviceEventTypes(ctx context.Context, arg UpdateOperatorDeviceEventTypesParams) error
+	UpdateOperatorSchedule(ctx context.Context, arg UpdateOperatorScheduleParams) error
 	UpdateOperatorStatus(ctx context.Context, arg UpdateOperatorStatusParams) error
+	UpdateOperatorVacationDrain(ctx context.Context, arg UpdateOperatorVacationDrainParams) error
+	UpdateOutboxEntryAttempt(ctx context.Context, arg UpdateOutboxEntryAttemptParams) error
+	UpdatePriorityExperiment(ctx context.Context, arg UpdatePriorityExperimentParams) error
+	UpdateRoutingRule(ctx context.Context, arg UpdateRoutingRuleParams) error
+	UpdateSchemaBackfillProgress(ctx context.Context, arg UpdateSchemaBackfillProgressParams) error
 	UpdateTenant(ctx context.Context, arg UpdateTenantParams) error
+	UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) error
+	UpdateWebhookDeliveryAttempt(ctx context.Context, arg UpdateWebhookDeliveryAttemptParams) error
+	// Records a read; a stale read never moves last_read_at back
+	UpsertConversationRead(ctx context.Context, arg UpsertConversationReadParams) error
+	// Returns the tenant's customer with the phone number, creating it on first
+	// contact
+	UpsertCustomer(ctx context.Context, arg UpsertCustomerParams) (Customer, error)
+	// Records that the operator resolved a conversation of the customer; an
+	// older resolution processed late never replaces a newer one
+	UpsertCustomerOperatorAffinity(ctx context.Context, arg UpsertCustomerOperatorAffinityParams) error
+	UpsertInboxChecklistTemplate(ctx context.Context, arg UpsertInboxChecklistTemplateParams) error
+	UpsertInboxSLAPolicy(ctx context.Context, arg UpsertInboxSLAPolicyParams) error
+	UpsertInboxStalenessPolicy(ctx context.Context, arg UpsertInboxStalenessPolicyParams) error
+	// Written by the health worker; leaves a manager override untouched
+	UpsertOperatorAllocationWeight(ctx context.Context, arg UpsertOperatorAllocationWeightParams) error
+	// A token registered again moves to the registering operator
+	UpsertOperatorDevice(ctx context.Context, arg UpsertOperatorDeviceParams) (OperatorDevice, error)
+	// Starts the vacation, or replaces the drain plan of the running one while
+	// keeping when it started
+	UpsertOperatorVacation(ctx context.Context, arg UpsertOperatorVacationParams) (OperatorVacation, error)
+	// Records a divergence. first_detected_at is kept while the kind is
+	// unchanged; corrected_at is cleared once the local state moves.
+	UpsertReconciliationDivergence(ctx context.Context, arg UpsertReconciliationDivergenceParams) (ReconciliationDivergence, error)
+	UpsertTenantAnomalySettings(ctx context.Context, arg UpsertTenantAnomalySettingsParams) error
+	UpsertTenantClassifier(ctx context.Context, arg UpsertTenantClassifierParams) error
+	UpsertTenantMaintenanceSettings(ctx context.Context, arg UpsertTenantMaintenanceSettingsParams) error
+	UpsertWorkerInstance(ctx context.Context, arg UpsertWorkerInstanceParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,50 @@
+-- name: CreateAllocationIntent :exec
+INSERT INTO allocation_intents (
+    id, tenant_id, operator_id, kind, idempotency_key, conversation_id,
+    status, recovered, created_at, resolved_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetAllocationIntentByIdempotencyKey :one
+SELECT * FROM allocation_intents
+WHERE tenant_id = $1 AND idempotency_key = $2;
+
+-- name: SetAllocationIntentConversation :exec
+UPDATE allocation_intents
+SET conversation_id = $2
+WHERE id = $1;
+
+-- Only the first resolution of a PENDING intent wins
+-- name: ResolveAllocationIntent :execrows
+UPDATE allocation_intents
+SET status = $2,
+    recovered = $3,
+    resolved_at = $4
+WHERE id = $1 AND status = 'PENDING';
+
+-- Reuses the intent of an aborted attempt for a retry with the same key
+-- name: RestartAllocationIntent :one
+UPDATE allocation_intents
+SET operator_id = $3,
+    kind = $4,
+    conversation_id = $5,
+    status = 'PENDING',
+    recovered = FALSE,
+    created_at = $6,
+    resolved_at = NULL
+WHERE tenant_id = $1 AND idempotency_key = $2 AND status = 'ABORTED'
+RETURNING id;
+
+-- name: GetPendingAllocationIntents :many
+SELECT * FROM allocation_intents
+WHERE status = 'PENDING' AND created_at < $1
+ORDER BY created_at ASC
+LIMIT $2;
+
+-- name: DeleteResolvedAllocationIntents :execrows
+DELETE FROM allocation_intents
+WHERE resolved_at < $1;
+
+-- Attempts that assigned nothing, for the anomaly detector
+-- name: CountAbortedAllocationIntents :one
+SELECT COUNT(*) FROM allocation_intents
+WHERE tenant_id = $1 AND status = 'ABORTED' AND created_at >= $2 AND created_at < $3;
@@ -0,0 +1,22 @@
+-- name: CreateAnomaly :exec
+INSERT INTO anomalies (
+    id, tenant_id, kind, operator_id, observed, baseline, threshold,
+    window_seconds, detected_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- Whether an anomaly of the kind was flagged for the operator (or tenant-wide
+-- when NULL) after the given time
+-- name: ExistsRecentAnomaly :one
+SELECT EXISTS (
+    SELECT 1 FROM anomalies
+    WHERE tenant_id = $1
+      AND kind = $2
+      AND operator_id IS NOT DISTINCT FROM $3
+      AND detected_at > $4
+);
+
+-- name: ListAnomalies :many
+SELECT * FROM anomalies
+WHERE tenant_id = $1 AND detected_at >= $2
+ORDER BY detected_at DESC, id DESC
+LIMIT $3;
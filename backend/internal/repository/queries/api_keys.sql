@@ -0,0 +1,20 @@
+-- name: CreateApiKey :exec
+INSERT INTO api_keys (id, tenant_id, name, key_prefix, key_hash, role, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetApiKeyByID :one
+SELECT * FROM api_keys WHERE id = $1;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys WHERE key_hash = $1;
+
+-- name: GetApiKeysByTenantID :many
+SELECT * FROM api_keys
+WHERE tenant_id = $1
+ORDER BY created_at ASC;
+
+-- name: RevokeApiKey :exec
+UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys SET last_used_at = $2 WHERE id = $1;
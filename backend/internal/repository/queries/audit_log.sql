@@ -0,0 +1,93 @@
+-- name: CreateAuditLogEntry :exec
+INSERT INTO audit_log (
+    id, tenant_id, actor_id, action, entity_type, entity_id,
+    before_state, after_state, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ListAuditLogByAction :many
+SELECT * FROM audit_log
+WHERE tenant_id = $1 AND action = $2 AND created_at >= $3
+ORDER BY created_at ASC, id ASC;
+
+-- name: CountAuditLogByAction :one
+SELECT COUNT(*) FROM audit_log
+WHERE tenant_id = $1 AND action = $2 AND created_at >= $3 AND created_at < $4;
+
+-- Resolutions by the assigned operator within $3 seconds of the conversation's
+-- latest assignment, per operator
+-- name: CountFastResolvesByActor :many
+SELECT r.actor_id, COUNT(*) AS resolves
+FROM audit_log r
+WHERE r.tenant_id = $1
+  AND r.action = 'conversation.resolve'
+  AND r.created_at >= $2
+  AND r.actor_id IS NOT NULL
+  AND r.after_state->>'assigned_operator_id' = r.actor_id::text
+  AND EXISTS (
+      SELECT 1 FROM audit_log a
+      WHERE a.tenant_id = r.tenant_id
+        AND a.entity_type = 'conversation'
+        AND a.entity_id = r.entity_id
+        AND a.action IN ('conversation.allocate', 'conversation.claim', 'conversation.reassign')
+        AND a.created_at <= r.created_at
+        AND a.created_at > r.created_at - make_interval(secs => $3::float8)
+  )
+GROUP BY r.actor_id;
+
+-- Allocations and claims out of the inbox since $3
+-- name: CountInboxAllocations :one
+SELECT COUNT(*) FROM audit_log
+WHERE tenant_id = $1
+  AND action IN ('conversation.allocate', 'conversation.claim')
+  AND after_state->>'inbox_id' = $2::text
+  AND created_at >= $3;
+
+-- Allocations and claims out of the inbox since $3 of conversations carrying
+-- each of the labels $4
+-- name: CountInboxAllocationsByLabel :many
+SELECT cl.label_id, COUNT(*) AS allocations
+FROM audit_log a
+JOIN conversation_labels cl ON cl.conversation_id = a.entity_id
+WHERE a.tenant_id = $1
+  AND a.action IN ('conversation.allocate', 'conversation.claim')
+  AND a.after_state->>'inbox_id' = $2::text
+  AND a.created_at >= $3
+  AND cl.label_id = ANY($4::uuid[])
+GROUP BY cl.label_id;
+
+-- Allocations, claims and reassignments to each operator since $2, and
+-- deallocations of conversations they held
+-- name: CountOperatorAllocationOutcomes :many
+SELECT o.operator_id::uuid AS operator_id,
+       COUNT(*) FILTER (WHERE o.action <> 'conversation.deallocate') AS allocations,
+       COUNT(*) FILTER (WHERE o.action = 'conversation.deallocate') AS returns
+FROM (
+    SELECT action,
+           CASE WHEN action = 'conversation.deallocate'
+                THEN before_state->>'assigned_operator_id'
+                ELSE after_state->>'assigned_operator_id'
+           END AS operator_id
+    FROM audit_log
+    WHERE tenant_id = $1
+      AND action IN ('conversation.allocate', 'conversation.claim', 'conversation.reassign', 'conversation.deallocate')
+      AND created_at >= $2
+) o
+WHERE o.operator_id IS NOT NULL
+GROUP BY o.operator_id;
+
+-- Time of the operator's latest automatic allocation
+-- name: GetLastAllocationByActor :one
+SELECT created_at FROM audit_log
+WHERE tenant_id = $1 AND actor_id = $2 AND action = 'conversation.allocate'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- Allocations and claims per inbox since $2
+-- name: CountAllocationsByInbox :many
+SELECT (after_state->>'inbox_id')::uuid AS inbox_id, COUNT(*) AS allocations
+FROM audit_log
+WHERE tenant_id = $1
+  AND action IN ('conversation.allocate', 'conversation.claim')
+  AND after_state->>'inbox_id' IS NOT NULL
+  AND created_at >= $2
+GROUP BY 1;
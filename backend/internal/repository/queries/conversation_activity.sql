@@ -0,0 +1,61 @@
+-- name: GetConversationActivity :one
+SELECT * FROM conversation_activity WHERE conversation_id = $1;
+
+-- name: RecordConversationActivity :exec
+INSERT INTO conversation_activity (
+    conversation_id, operator_id, last_activity_at, last_activity_kind
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (conversation_id) DO UPDATE
+SET operator_id = EXCLUDED.operator_id,
+    last_activity_at = EXCLUDED.last_activity_at,
+    last_activity_kind = EXCLUDED.last_activity_kind,
+    stale_at = NULL;
+
+-- Allocations in inboxes with a staleness policy that have no activity yet,
+-- or activity of a previous operator, start idling at $1
+-- name: StartConversationActivityClocks :execrows
+INSERT INTO conversation_activity (
+    conversation_id, operator_id, last_activity_at, last_activity_kind
+)
+SELECT c.id, c.assigned_operator_id, $1::timestamptz, 'ALLOCATED'
+FROM conversation_refs c
+JOIN inbox_staleness_policies p ON p.inbox_id = c.inbox_id
+LEFT JOIN conversation_activity a ON a.conversation_id = c.id
+WHERE c.state = 'ALLOCATED' AND c.assigned_operator_id IS NOT NULL
+  AND (a.conversation_id IS NULL OR a.operator_id <> c.assigned_operator_id)
+ON CONFLICT (conversation_id) DO UPDATE
+SET operator_id = EXCLUDED.operator_id,
+    last_activity_at = EXCLUDED.last_activity_at,
+    last_activity_kind = EXCLUDED.last_activity_kind,
+    stale_at = NULL;
+
+-- Drops the activity of conversations no longer allocated or whose inbox
+-- has no staleness policy anymore
+-- name: PruneConversationActivity :execrows
+DELETE FROM conversation_activity a
+USING conversation_refs c
+WHERE c.id = a.conversation_id
+  AND (c.state <> 'ALLOCATED'
+    OR NOT EXISTS (SELECT 1 FROM inbox_staleness_policies p WHERE p.inbox_id = c.inbox_id));
+
+-- Allocations idle past their inbox's limit not flagged yet, locked for the
+-- stale allocation worker
+-- name: GetAndLockStaleConversationActivity :many
+SELECT a.conversation_id, a.operator_id, a.last_activity_at, a.last_activity_kind, a.stale_at
+FROM conversation_activity a
+JOIN conversation_refs c ON c.id = a.conversation_id
+JOIN inbox_staleness_policies p ON p.inbox_id = c.inbox_id
+WHERE a.stale_at IS NULL
+  AND c.state = 'ALLOCATED' AND c.assigned_operator_id = a.operator_id
+  AND a.last_activity_at + make_interval(secs => p.stale_after_seconds) <= $1
+ORDER BY a.last_activity_at ASC
+LIMIT $2
+FOR UPDATE OF a SKIP LOCKED;
+
+-- name: MarkConversationActivityStale :exec
+UPDATE conversation_activity SET stale_at = $2 WHERE conversation_id = $1;
+
+-- name: CountStaleConversationActivity :one
+SELECT COUNT(*) FROM conversation_activity a
+JOIN conversation_refs c ON c.id = a.conversation_id
+WHERE a.stale_at IS NOT NULL AND c.state = 'ALLOCATED';
@@ -0,0 +1,55 @@
+-- name: GetInboxChecklistTemplate :one
+SELECT * FROM inbox_checklist_templates WHERE inbox_id = $1;
+
+-- name: UpsertInboxChecklistTemplate :exec
+INSERT INTO inbox_checklist_templates (inbox_id, tenant_id, items, pinned_note, strict, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (inbox_id) DO UPDATE SET
+    items = EXCLUDED.items,
+    pinned_note = EXCLUDED.pinned_note,
+    strict = EXCLUDED.strict,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteInboxChecklistTemplate :exec
+DELETE FROM inbox_checklist_templates WHERE inbox_id = $1;
+
+-- Copies the inbox template's items onto the conversations without a checklist
+-- name: InstantiateConversationChecklists :execrows
+INSERT INTO conversation_checklist_items (id, tenant_id, conversation_id, position, label, created_at)
+SELECT gen_random_uuid(), c.tenant_id, c.id, item.position, item.label, NOW()
+FROM conversation_refs c
+JOIN inbox_checklist_templates t ON t.inbox_id = c.inbox_id
+CROSS JOIN LATERAL unnest(t.items) WITH ORDINALITY AS item(label, position)
+WHERE c.id = ANY($1::uuid[])
+  AND NOT EXISTS (SELECT 1 FROM conversation_checklist_items i WHERE i.conversation_id = c.id);
+
+-- Copies the inbox template's pinned note onto the conversations without one
+-- name: InstantiatePinnedNotes :execrows
+INSERT INTO conversation_notes (id, tenant_id, conversation_id, kind, body, created_at)
+SELECT gen_random_uuid(), c.tenant_id, c.id, 'PINNED', t.pinned_note, NOW()
+FROM conversation_refs c
+JOIN inbox_checklist_templates t ON t.inbox_id = c.inbox_id
+WHERE c.id = ANY($1::uuid[])
+  AND t.pinned_note IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM conversation_notes n WHERE n.conversation_id = c.id AND n.kind = 'PINNED');
+
+-- name: ListConversationChecklistItems :many
+SELECT * FROM conversation_checklist_items
+WHERE conversation_id = $1
+ORDER BY position;
+
+-- name: GetConversationChecklistItem :one
+SELECT * FROM conversation_checklist_items WHERE id = $1;
+
+-- name: UpdateConversationChecklistItemCompletion :exec
+UPDATE conversation_checklist_items
+SET completed_by = $2, completed_at = $3
+WHERE id = $1;
+
+-- name: GetConversationChecklistStatus :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE completed_at IS NULL))::int AS incomplete
+FROM conversation_checklist_items
+WHERE conversation_id = $1;
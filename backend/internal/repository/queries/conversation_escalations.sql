@@ -0,0 +1,22 @@
+-- name: CreateConversationEscalation :exec
+INSERT INTO conversation_escalations (
+    id, tenant_id, conversation_id, operator_id, escalated_by,
+    from_inbox_id, to_inbox_id, reason, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- Escalations per operator the conversations were taken from in [$2, $3)
+-- name: CountEscalationsByOperator :many
+SELECT operator_id, COUNT(*) AS escalations
+FROM conversation_escalations
+WHERE tenant_id = $1
+  AND operator_id IS NOT NULL
+  AND created_at >= $2 AND created_at < $3
+GROUP BY operator_id;
+
+-- Escalations per inbox they left in [$2, $3)
+-- name: CountEscalationsByInbox :many
+SELECT from_inbox_id, COUNT(*) AS escalations
+FROM conversation_escalations
+WHERE tenant_id = $1
+  AND created_at >= $2 AND created_at < $3
+GROUP BY from_inbox_id;
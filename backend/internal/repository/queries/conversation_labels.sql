@@ -1,10 +1,26 @@
 -- name: CreateConversationLabel :exec
-INSERT INTO conversation_labels (id, conversation_id, label_id, created_at)
-VALUES ($1, $2, $3, $4);
+INSERT INTO conversation_labels (id, conversation_id, label_id, created_at, label_version)
+VALUES ($1, $2, $3, $4, $5);
 
 -- name: GetConversationLabelsByConversationID :many
 SELECT * FROM conversation_labels WHERE conversation_id = $1;
 
+-- Labels attached to the conversation, by name
+-- name: GetLabelsByConversationID :many
+SELECT l.* FROM labels l
+JOIN conversation_labels cl ON cl.label_id = l.id
+WHERE cl.conversation_id = $1
+ORDER BY l.name;
+
+-- Labels attached to each of the conversations, by name; one query for a
+-- whole list page
+-- name: GetLabelsForConversations :many
+SELECT cl.conversation_id, l.id, l.tenant_id, l.inbox_id, l.name, l.color, l.created_by, l.created_at, l.version, l.parent_label_id, l.scope
+FROM conversation_labels cl
+JOIN labels l ON l.id = cl.label_id
+WHERE cl.conversation_id = ANY($1::uuid[])
+ORDER BY cl.conversation_id, l.name;
+
 -- name: GetConversationLabelsByLabelID :many
 SELECT * FROM conversation_labels WHERE label_id = $1;
 
@@ -19,3 +35,18 @@ SELECT EXISTS(
     SELECT 1 FROM conversation_labels 
     WHERE conversation_id = $1 AND label_id = $2
 ) AS exists;
+
+-- Attachments made in [$3, $4) that are still in place, grouped by the label
+-- version they were attached under so renamed labels keep their old names
+-- Tenant-scoped labels count for the inbox of the conversation
+-- name: CountLabelAttachmentsByVersion :many
+SELECT cl.label_id, lv.version, lv.name, lv.color, COUNT(*) AS attachments
+FROM conversation_labels cl
+JOIN labels l ON l.id = cl.label_id
+JOIN label_versions lv ON lv.label_id = cl.label_id AND lv.version = COALESCE(cl.label_version, 1)
+JOIN conversation_refs c ON c.id = cl.conversation_id
+WHERE l.tenant_id = $1
+  AND (l.inbox_id = $2 OR (l.scope = 'TENANT' AND c.inbox_id = $2))
+  AND cl.created_at >= $3 AND cl.created_at < $4
+GROUP BY cl.label_id, lv.version, lv.name, lv.color
+ORDER BY lv.name, lv.version;
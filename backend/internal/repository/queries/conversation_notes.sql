@@ -0,0 +1,18 @@
+-- name: CreateConversationNote :exec
+INSERT INTO conversation_notes (
+    id, tenant_id, conversation_id, kind, author_id, recipient_id, body, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- Most recent note of a kind addressed to the operator
+-- name: GetLatestConversationNoteForRecipient :one
+SELECT * FROM conversation_notes
+WHERE conversation_id = $1 AND kind = $2 AND recipient_id = $3
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- Most recent note of a kind
+-- name: GetLatestConversationNote :one
+SELECT * FROM conversation_notes
+WHERE conversation_id = $1 AND kind = $2
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,10 @@
+-- Records a read; a stale read never moves last_read_at back
+-- name: UpsertConversationRead :exec
+INSERT INTO conversation_reads (operator_id, conversation_id, tenant_id, last_read_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (operator_id, conversation_id)
+DO UPDATE SET last_read_at = GREATEST(conversation_reads.last_read_at, EXCLUDED.last_read_at);
+
+-- name: GetConversationReadsByOperator :many
+SELECT operator_id, conversation_id, tenant_id, last_read_at FROM conversation_reads
+WHERE operator_id = $1 AND conversation_id = ANY($2::uuid[]);
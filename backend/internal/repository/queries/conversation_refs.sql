@@ -2,8 +2,17 @@
 INSERT INTO conversation_refs (
     id, tenant_id, inbox_id, external_conversation_id, customer_phone_number,
     state, assigned_operator_id, last_message_at, message_count, priority_score,
-    created_at, updated_at, resolved_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+    created_at, updated_at, resolved_at, is_first_contact, customer_id, normalized_phone, due_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, regexp_replace($5, '[^0-9]', '', 'g'), $16);
+
+-- Insert unless the external conversation is already tracked (ingestion upsert)
+-- name: CreateConversationRefIfNotExists :execrows
+INSERT INTO conversation_refs (
+    id, tenant_id, inbox_id, external_conversation_id, customer_phone_number,
+    state, assigned_operator_id, last_message_at, message_count, priority_score,
+    created_at, updated_at, resolved_at, is_first_contact, customer_id, normalized_phone, due_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, regexp_replace($5, '[^0-9]', '', 'g'), $16)
+ON CONFLICT (tenant_id, external_conversation_id) DO NOTHING;
 
 -- name: GetConversationRefByID :one
 SELECT * FROM conversation_refs WHERE id = $1;
@@ -12,7 +21,9 @@ SELECT * FROM conversation_refs WHERE id = $1;
 SELECT * FROM conversation_refs 
 WHERE tenant_id = $1 AND external_conversation_id = $2;
 
--- name: UpdateConversationRef :exec
+-- Compare-and-swap on version: no row is updated when another writer
+-- changed the conversation since it was read
+-- name: UpdateConversationRef :execrows
 UPDATE conversation_refs
 SET inbox_id = $2,
     state = $3,
@@ -21,8 +32,13 @@ SET inbox_id = $2,
     message_count = $6,
     priority_score = $7,
     updated_at = $8,
-    resolved_at = $9
-WHERE id = $1;
+    resolved_at = $9,
+    reopened_count = $10,
+    category = $11,
+    language = $12,
+    customer_id = COALESCE($13::uuid, customer_id),
+    version = version + 1
+WHERE id = $1 AND version = $14;
 
 -- name: DeleteConversationRef :exec
 DELETE FROM conversation_refs WHERE id = $1;
@@ -32,6 +48,21 @@ SELECT * FROM conversation_refs
 WHERE tenant_id = $1 AND customer_phone_number = $2
 ORDER BY created_at DESC;
 
+-- Whether the tenant already has a conversation with the phone number;
+-- served by idx_conversations_phone
+-- name: CheckCustomerPhoneSeen :one
+SELECT EXISTS(
+    SELECT 1 FROM conversation_refs
+    WHERE tenant_id = $1 AND customer_phone_number = $2
+) AS exists;
+
+-- Conversations the phone number started in the inbox since the given time,
+-- and when the oldest of them started; served by idx_conversations_phone
+-- name: CountCustomerIntakeSince :one
+SELECT COUNT(*) AS conversations, MIN(created_at)::timestamptz AS oldest_created_at
+FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND customer_phone_number = $3 AND created_at >= $4;
+
 -- name: GetConversationsByOperatorID :many
 SELECT * FROM conversation_refs
 WHERE tenant_id = $1 AND assigned_operator_id = $2
@@ -46,8 +77,8 @@ ORDER BY created_at DESC;
 
 -- name: GetQueuedConversationsByTenant :many
 SELECT * FROM conversation_refs
-WHERE tenant_id = $1 AND state = 'QUEUED'
-ORDER BY priority_score DESC, last_message_at ASC
+WHERE tenant_id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
+ORDER BY priority_override DESC NULLS LAST, priority_score DESC, last_message_at ASC
 LIMIT $2;
 
 -- name: GetConversationsByTenantAndState :many
@@ -62,27 +93,387 @@ WHERE tenant_id = $1 AND inbox_id = $2
 ORDER BY created_at DESC
 LIMIT $3;
 
+-- Oldest first, for moving an inbox's backlog in batches
+-- name: GetOpenConversationIDsByInbox :many
+SELECT id FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND state <> 'RESOLVED'
+ORDER BY created_at ASC
+LIMIT $3;
+
+-- Queued conversations of the customers the operator $3 resolved last, since
+-- $4, in allocation order; offered to the operator before the rest of the queue
+-- name: GetNextAffineConversationsForAllocation :many
+SELECT c.* FROM conversation_refs c
+JOIN customer_operator_affinity a ON a.customer_id = c.customer_id
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND a.operator_id = $3
+  AND a.handled_at >= $4
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED;
+
 -- CRITICAL: Allocation query with FOR UPDATE SKIP LOCKED
+-- Candidates are the top $4 of each inbox in idx_conversations_queue order
+-- plus the pinned ones (idx_conversations_pinned), so the scan stops after a
+-- few index entries per inbox instead of sorting every queued conversation.
+-- Candidates locked by concurrent allocators are skipped; when fewer than $3
+-- are left the caller falls back to GetNextConversationsForAllocationFullScan.
 -- name: GetNextConversationsForAllocation :many
+WITH candidates AS (
+    SELECT top.id
+    FROM unnest($2::uuid[]) AS inbox(id)
+    CROSS JOIN LATERAL (
+        SELECT q.id FROM conversation_refs q
+        WHERE q.tenant_id = $1
+          AND q.inbox_id = inbox.id
+          AND q.state = 'QUEUED'
+          AND q.snoozed_until IS NULL
+          AND (q.language IS NULL OR cardinality($5::text[]) = 0 OR q.language = ANY($5::text[]))
+        ORDER BY q.priority_score DESC, q.last_message_at ASC
+        LIMIT $4
+    ) top
+    UNION
+    SELECT p.id FROM conversation_refs p
+    WHERE p.tenant_id = $1
+      AND p.inbox_id = ANY($2::uuid[])
+      AND p.state = 'QUEUED'
+      AND p.priority_override IS NOT NULL
+      AND p.snoozed_until IS NULL
+      AND (p.language IS NULL OR cardinality($5::text[]) = 0 OR p.language = ANY($5::text[]))
+)
+SELECT c.* FROM conversation_refs c
+JOIN candidates ON candidates.id = c.id
+WHERE c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $3
+FOR UPDATE OF c SKIP LOCKED;
+
+-- Allocation order over every queued conversation of the inboxes; the
+-- fallback of GetNextConversationsForAllocation under contention
+-- name: GetNextConversationsForAllocationFullScan :many
 SELECT * FROM conversation_refs
-WHERE tenant_id = $1 
+WHERE tenant_id = $1
   AND inbox_id = ANY($2::uuid[])
   AND state = 'QUEUED'
-ORDER BY priority_score DESC, last_message_at ASC
+  AND snoozed_until IS NULL
+  AND (language IS NULL OR cardinality($4::text[]) = 0 OR language = ANY($4::text[]))
+ORDER BY priority_override DESC NULLS LAST, priority_score DESC, last_message_at ASC
 LIMIT $3
 FOR UPDATE SKIP LOCKED;
 
+-- Allocation order for inboxes with category quotas: conversations created
+-- before $4 come first so nothing starves, then those carrying one of the
+-- preferred category labels $3, then the usual order
+-- name: GetNextConversationsForAllocationWithQuotas :many
+SELECT c.* FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED;
+
+-- Allocation order of the v2 engine: conversations that breached an SLA
+-- target or their due date come first, earliest breach first, then the usual
+-- order. Category quotas apply as in GetNextConversationsForAllocationWithQuotas;
+-- an empty $3 and a NULL $4 leave them out.
+-- name: GetNextConversationsForAllocationBreachFirst :many
+SELECT c.* FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST,
+         LEAST(c.sla_breached_at, c.overdue_at) ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED;
+
+-- Allocation order restricted to conversations carrying one of the labels
+-- $3, for operators pulling e.g. only billing conversations; $4 takes
+-- breached conversations first as the v2 engine does
+-- name: GetNextConversationsForAllocationByLabels :many
+SELECT c.* FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($6::text[]) = 0 OR c.language = ANY($6::text[]))
+  AND EXISTS (
+      SELECT 1 FROM conversation_labels cl
+      WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+  )
+ORDER BY c.priority_override DESC NULLS LAST,
+         CASE WHEN $4::boolean THEN LEAST(c.sla_breached_at, c.overdue_at) END ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT $5
+FOR UPDATE OF c SKIP LOCKED;
+
+-- Head of the allocation order of GetNextConversationsForAllocation, without
+-- locking, for previews; may return a conversation being allocated
+-- concurrently
+-- name: PeekNextConversationForAllocation :one
+WITH candidates AS (
+    SELECT top.id
+    FROM unnest($2::uuid[]) AS inbox(id)
+    CROSS JOIN LATERAL (
+        SELECT q.id FROM conversation_refs q
+        WHERE q.tenant_id = $1
+          AND q.inbox_id = inbox.id
+          AND q.state = 'QUEUED'
+          AND q.snoozed_until IS NULL
+          AND (q.language IS NULL OR cardinality($3::text[]) = 0 OR q.language = ANY($3::text[]))
+        ORDER BY q.priority_score DESC, q.last_message_at ASC
+        LIMIT 1
+    ) top
+    UNION
+    SELECT p.id FROM conversation_refs p
+    WHERE p.tenant_id = $1
+      AND p.inbox_id = ANY($2::uuid[])
+      AND p.state = 'QUEUED'
+      AND p.priority_override IS NOT NULL
+      AND p.snoozed_until IS NULL
+      AND (p.language IS NULL OR cardinality($3::text[]) = 0 OR p.language = ANY($3::text[]))
+)
+SELECT c.* FROM conversation_refs c
+JOIN candidates ON candidates.id = c.id
+ORDER BY c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT 1;
+
+-- Head of the allocation order of GetNextConversationsForAllocationWithQuotas,
+-- without locking, for previews
+-- name: PeekNextConversationForAllocationWithQuotas :one
+SELECT c.* FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($5::text[]) = 0 OR c.language = ANY($5::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST, c.priority_score DESC, c.last_message_at ASC
+LIMIT 1;
+
+-- Head of the allocation order of GetNextConversationsForAllocationBreachFirst,
+-- without locking, for previews
+-- name: PeekNextConversationForAllocationBreachFirst :one
+SELECT c.* FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.inbox_id = ANY($2::uuid[])
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND (c.language IS NULL OR cardinality($5::text[]) = 0 OR c.language = ANY($5::text[]))
+ORDER BY (c.created_at < $4) DESC,
+         EXISTS (
+             SELECT 1 FROM conversation_labels cl
+             WHERE cl.conversation_id = c.id AND cl.label_id = ANY($3::uuid[])
+         ) DESC,
+         c.priority_override DESC NULLS LAST,
+         LEAST(c.sla_breached_at, c.overdue_at) ASC NULLS LAST,
+         c.priority_score DESC, c.last_message_at ASC
+LIMIT 1;
+
 -- CRITICAL: Lock specific conversation for claim
 -- name: LockConversationForClaim :one
 SELECT * FROM conversation_refs
-WHERE id = $1 AND state = 'QUEUED'
+WHERE id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
 FOR UPDATE NOWAIT;
 
+-- Lock conversation row for in-place updates (message received)
+-- name: LockConversationRefForUpdate :one
+SELECT * FROM conversation_refs
+WHERE id = $1
+FOR UPDATE;
+
+-- Lock conversation row by external ID for in-place updates (ingestion)
+-- name: LockConversationRefByExternalID :one
+SELECT * FROM conversation_refs
+WHERE tenant_id = $1 AND external_conversation_id = $2
+FOR UPDATE;
+
 -- Update state only (for allocation/deallocate/resolve)
 -- name: UpdateConversationState :exec
 UPDATE conversation_refs
 SET state = $2,
     assigned_operator_id = $3,
     updated_at = $4,
-    resolved_at = $5
+    resolved_at = $5,
+    version = version + 1
+WHERE id = $1;
+
+-- Conversations created per hour (as Unix time of the hour start)
+-- name: CountConversationsCreatedByHour :many
+SELECT (floor(extract(epoch FROM created_at) / 3600) * 3600)::bigint AS hour_start,
+       COUNT(*) AS conversations
+FROM conversation_refs
+WHERE tenant_id = $1 AND created_at >= $2
+GROUP BY hour_start
+ORDER BY hour_start;
+
+-- name: CountInboxConversationsCreatedByHour :many
+SELECT (floor(extract(epoch FROM created_at) / 3600) * 3600)::bigint AS hour_start,
+       COUNT(*) AS conversations
+FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND created_at >= $3
+GROUP BY hour_start
+ORDER BY hour_start;
+
+-- Flag open conversations past a target of their inbox's SLA policy. Snoozed
+-- conversations were already assigned once and only count for resolution.
+-- name: MarkSLABreaches :many
+UPDATE conversation_refs c
+SET sla_breached_at = $1
+FROM inbox_sla_policies p
+WHERE p.inbox_id = c.inbox_id
+  AND c.sla_breached_at IS NULL
+  AND c.state <> 'RESOLVED'
+  AND (
+      (c.state = 'QUEUED' AND c.snoozed_until IS NULL
+       AND c.created_at + make_interval(secs => p.first_assignment_seconds) <= $1)
+      OR c.created_at + make_interval(secs => p.resolution_seconds) <= $1
+  )
+RETURNING c.id, c.tenant_id, c.inbox_id;
+
+-- Raise queued conversations that used up the $3 fraction of an SLA target
+-- to priority $2; returns their inboxes
+-- name: BoostNearSLABreach :many
+UPDATE conversation_refs c
+SET priority_score = $2,
+    updated_at = $1,
+    version = c.version + 1
+FROM inbox_sla_policies p
+WHERE p.inbox_id = c.inbox_id
+  AND c.state = 'QUEUED'
+  AND c.snoozed_until IS NULL
+  AND c.priority_score < $2
+  AND (
+      c.created_at + make_interval(secs => p.first_assignment_seconds * $3::float8) <= $1
+      OR c.created_at + make_interval(secs => p.resolution_seconds * $3::float8) <= $1
+  )
+RETURNING c.inbox_id;
+
+-- name: ListSLABreaches :many
+SELECT * FROM conversation_refs
+WHERE tenant_id = $1 AND sla_breached_at >= $2
+ORDER BY sla_breached_at DESC, id DESC
+LIMIT $3;
+
+-- name: ListInboxSLABreaches :many
+SELECT * FROM conversation_refs
+WHERE tenant_id = $1 AND inbox_id = $2 AND sla_breached_at >= $3
+ORDER BY sla_breached_at DESC, id DESC
+LIMIT $4;
+
+-- Set or clear the due date, which re-arms the overdue flag;
+-- UpdateConversationRef leaves both alone
+-- name: SetConversationRefDueAt :exec
+UPDATE conversation_refs
+SET due_at = $2,
+    overdue_at = NULL,
+    updated_at = $3
+WHERE id = $1;
+
+-- Flag the conversation overdue; UpdateConversationRef leaves it alone
+-- name: SetConversationRefOverdue :exec
+UPDATE conversation_refs
+SET overdue_at = $2
+WHERE id = $1;
+
+-- Set or clear the manual priority; UpdateConversationRef leaves it alone
+-- name: SetConversationRefPriorityOverride :exec
+UPDATE conversation_refs
+SET priority_override = $2,
+    updated_at = $3
+WHERE id = $1;
+
+-- Set or clear the snooze; state and assignment are changed through
+-- UpdateConversationRef, which leaves these columns alone
+-- name: SetConversationRefSnooze :exec
+UPDATE conversation_refs
+SET snoozed_until = $2,
+    snooze_operator_id = $3,
+    updated_at = $4
 WHERE id = $1;
+
+-- Snoozes that ended, locked for the snooze worker
+-- name: GetAndLockEndedSnoozes :many
+SELECT * FROM conversation_refs
+WHERE snoozed_until <= $1 AND state = 'QUEUED'
+ORDER BY snoozed_until ASC
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- Open conversations past their due date not flagged yet, locked for the
+-- overdue worker
+-- name: GetAndLockOverdueConversations :many
+SELECT * FROM conversation_refs
+WHERE due_at <= $1 AND overdue_at IS NULL AND state <> 'RESOLVED'
+ORDER BY due_at ASC
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- Open conversations idle past their inbox's auto_resolve_after_seconds,
+-- stalest first, locked for the auto-resolve worker; snoozed conversations
+-- wait for their follow-up
+-- name: GetAndLockStaleConversations :many
+SELECT c.* FROM conversation_refs c
+JOIN inboxes i ON i.id = c.inbox_id
+WHERE i.auto_resolve_after_seconds IS NOT NULL
+  AND c.state <> 'RESOLVED'
+  AND c.snoozed_until IS NULL
+  AND c.last_message_at + make_interval(secs => i.auto_resolve_after_seconds) <= $1::timestamptz
+ORDER BY c.last_message_at ASC
+LIMIT $2
+FOR UPDATE OF c SKIP LOCKED;
+
+-- The operator's allocated conversations, most urgent first, locked for the
+-- vacation drain worker
+-- name: GetAndLockAllocatedByOperator :many
+SELECT * FROM conversation_refs
+WHERE assigned_operator_id = $1 AND state = 'ALLOCATED'
+ORDER BY priority_score DESC, created_at ASC
+FOR UPDATE SKIP LOCKED;
+
+-- Conversations waiting for allocation in the inbox, excluding snoozed ones
+-- name: CountQueuedConversationsByInbox :one
+SELECT COUNT(*) FROM conversation_refs
+WHERE inbox_id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL;
+
+-- Conversations of each inbox of the tenant by state, those open past their
+-- due date at $3, and the average time from creation to resolution of those
+-- resolved since $2
+-- name: CountInboxConversationsByState :many
+SELECT i.id AS inbox_id, i.display_name,
+       COUNT(c.id) FILTER (WHERE c.state = 'QUEUED') AS queued,
+       COUNT(c.id) FILTER (WHERE c.state = 'ALLOCATED') AS allocated,
+       COUNT(c.id) FILTER (WHERE c.state = 'RESOLVED') AS resolved,
+       COUNT(c.id) FILTER (WHERE c.state = 'RESOLVED' AND c.resolved_at >= $2) AS recently_resolved,
+       COUNT(c.id) FILTER (WHERE c.state <> 'RESOLVED' AND c.due_at <= $3) AS overdue,
+       COALESCE(AVG(EXTRACT(EPOCH FROM c.resolved_at - c.created_at))
+           FILTER (WHERE c.state = 'RESOLVED' AND c.resolved_at >= $2), 0)::float8 AS avg_resolution_seconds
+FROM inboxes i
+LEFT JOIN conversation_refs c ON c.inbox_id = i.id
+WHERE i.tenant_id = $1
+GROUP BY i.id, i.display_name
+ORDER BY i.display_name, i.id;
@@ -0,0 +1,20 @@
+-- name: CreateConversationShareLink :exec
+INSERT INTO conversation_share_links (
+    id, tenant_id, conversation_id, include_pii, created_by, expires_at, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetConversationShareLinkByID :one
+SELECT * FROM conversation_share_links WHERE id = $1;
+
+-- name: GetConversationShareLinksByConversation :many
+SELECT * FROM conversation_share_links
+WHERE conversation_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeConversationShareLink :exec
+UPDATE conversation_share_links SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RecordConversationShareLinkAccess :exec
+UPDATE conversation_share_links
+SET access_count = access_count + 1, last_accessed_at = $2
+WHERE id = $1;
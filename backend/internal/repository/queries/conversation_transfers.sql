@@ -0,0 +1,34 @@
+-- name: CreateConversationTransfer :exec
+INSERT INTO conversation_transfers (
+    id, tenant_id, conversation_id, from_operator_id, to_operator_id, note, status, expires_at, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetConversationTransferByID :one
+SELECT * FROM conversation_transfers WHERE id = $1;
+
+-- Pending transfers the operator proposed or received, oldest first
+-- name: ListPendingConversationTransfersByOperator :many
+SELECT * FROM conversation_transfers
+WHERE tenant_id = $1
+  AND status = 'PENDING'
+  AND (to_operator_id = $2 OR from_operator_id = $2)
+ORDER BY created_at, id;
+
+-- name: LockConversationTransfer :one
+SELECT * FROM conversation_transfers WHERE id = $1 FOR UPDATE;
+
+-- name: UpdateConversationTransferStatus :exec
+UPDATE conversation_transfers SET status = $2, responded_at = $3 WHERE id = $1;
+
+-- Ends up to $2 pending transfers past their expiry at $1, soonest first;
+-- transfers being answered are skipped
+-- name: ExpireConversationTransfers :many
+UPDATE conversation_transfers SET status = 'EXPIRED', responded_at = $1
+WHERE id IN (
+    SELECT id FROM conversation_transfers
+    WHERE status = 'PENDING' AND expires_at <= $1
+    ORDER BY expires_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
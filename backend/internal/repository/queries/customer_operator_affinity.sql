@@ -0,0 +1,9 @@
+-- Records that the operator resolved a conversation of the customer; an
+-- older resolution processed late never replaces a newer one
+-- name: UpsertCustomerOperatorAffinity :exec
+INSERT INTO customer_operator_affinity (customer_id, tenant_id, operator_id, handled_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (customer_id) DO UPDATE SET
+    operator_id = EXCLUDED.operator_id,
+    handled_at = EXCLUDED.handled_at
+WHERE customer_operator_affinity.handled_at <= EXCLUDED.handled_at;
@@ -0,0 +1,30 @@
+-- Returns the tenant's customer with the phone number, creating it on first
+-- contact
+-- name: UpsertCustomer :one
+INSERT INTO customers (id, tenant_id, phone_number, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (tenant_id, phone_number) DO UPDATE SET phone_number = EXCLUDED.phone_number
+RETURNING *;
+
+-- name: GetCustomerByID :one
+SELECT * FROM customers WHERE id = $1;
+
+-- name: UpdateCustomer :exec
+UPDATE customers SET name = $2, metadata = $3, updated_at = $4 WHERE id = $1;
+
+-- Customers whose phone number starts with the pattern or whose name
+-- contains it (an empty pattern matches all), newest first; keyset-paginated
+-- on (created_at, id)
+-- name: SearchCustomers :many
+SELECT * FROM customers
+WHERE tenant_id = $1
+  AND ($2::text = '' OR phone_number LIKE $2::text || '%' OR name ILIKE '%' || $2::text || '%')
+  AND ($3::timestamptz IS NULL OR (created_at, id) < ($3::timestamptz, $4::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $5;
+
+-- name: GetCustomerByPhone :one
+SELECT * FROM customers WHERE tenant_id = $1 AND phone_number = $2;
+
+-- name: DeleteCustomersByTenantID :exec
+DELETE FROM customers WHERE tenant_id = $1;
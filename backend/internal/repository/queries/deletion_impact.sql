@@ -0,0 +1,35 @@
+-- Dependents of a resource about to be deleted, see DeletionImpact
+
+-- name: GetInboxDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM conversation_refs c WHERE c.inbox_id = $1) AS conversations,
+    (SELECT COUNT(*) FROM conversation_refs c
+     WHERE c.inbox_id = $1 AND c.state IN ('QUEUED', 'ALLOCATED')) AS open_conversations,
+    (SELECT COUNT(*) FROM operator_inbox_subscriptions s WHERE s.inbox_id = $1) AS subscriptions,
+    (SELECT COUNT(*) FROM routing_rules r WHERE r.inbox_id = $1) AS routing_rules,
+    (SELECT COUNT(*) FROM grace_period_assignments g
+     JOIN conversation_refs c ON c.id = g.conversation_id
+     WHERE c.inbox_id = $1) AS grace_periods;
+
+-- Routing rules attach labels by name: the rules of the label's inbox and
+-- the tenant-wide ones naming it; every rule naming a tenant label
+-- name: GetLabelDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM conversation_labels cl WHERE cl.label_id = l.id) AS conversations,
+    (SELECT COUNT(*) FROM conversation_labels cl
+     JOIN conversation_refs c ON c.id = cl.conversation_id
+     WHERE cl.label_id = l.id AND c.state IN ('QUEUED', 'ALLOCATED')) AS open_conversations,
+    (SELECT COUNT(*) FROM routing_rules r
+     WHERE r.tenant_id = l.tenant_id
+       AND r.label_name = l.name
+       AND (r.inbox_id IS NULL OR r.inbox_id = l.inbox_id OR l.scope = 'TENANT')) AS routing_rules
+FROM labels l
+WHERE l.id = $1;
+
+-- name: GetOperatorDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM conversation_refs c WHERE c.assigned_operator_id = $1) AS conversations,
+    (SELECT COUNT(*) FROM conversation_refs c
+     WHERE c.assigned_operator_id = $1 AND c.state = 'ALLOCATED') AS open_conversations,
+    (SELECT COUNT(*) FROM operator_inbox_subscriptions s WHERE s.operator_id = $1) AS subscriptions,
+    (SELECT COUNT(*) FROM grace_period_assignments g WHERE g.operator_id = $1) AS grace_periods;
@@ -0,0 +1,41 @@
+-- name: CreateOutboxEntry :exec
+INSERT INTO event_outbox (
+    id, tenant_id, event_type, data, occurred_at, next_attempt_at, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO NOTHING;
+
+-- CRITICAL: Claim due entries for the worker. The lease pushes next_attempt_at
+-- forward so that concurrent workers skip rows while the sinks are called.
+-- name: ClaimDueOutboxEntries :many
+UPDATE event_outbox
+SET next_attempt_at = $2
+WHERE id IN (
+    SELECT id FROM event_outbox
+    WHERE published_at IS NULL AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at ASC
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEntryPublished :exec
+UPDATE event_outbox
+SET published_at = $2,
+    attempt_count = attempt_count + 1,
+    last_error = NULL
+WHERE id = $1 AND published_at IS NULL;
+
+-- name: UpdateOutboxEntryAttempt :exec
+UPDATE event_outbox
+SET attempt_count = $2,
+    next_attempt_at = $3,
+    last_error = $4,
+    published_at = $5
+WHERE id = $1;
+
+-- name: CountPendingOutboxEntries :one
+SELECT COUNT(*) FROM event_outbox WHERE published_at IS NULL;
+
+-- name: DeletePublishedOutboxEntries :execrows
+DELETE FROM event_outbox
+WHERE published_at < $1;
@@ -5,6 +5,27 @@ VALUES ($1, $2, $3, $4, $5, $6);
 -- name: GetGracePeriodByConversationID :one
 SELECT * FROM grace_period_assignments WHERE conversation_id = $1;
 
+-- name: GetGracePeriodByID :one
+SELECT g.* FROM grace_period_assignments g
+JOIN conversation_refs c ON c.id = g.conversation_id
+WHERE g.id = $1 AND c.tenant_id = $2;
+
+-- Grace periods of the tenant, optionally of one operator ($2) or
+-- conversation ($3), soonest to expire first
+-- name: ListGracePeriods :many
+SELECT g.* FROM grace_period_assignments g
+JOIN conversation_refs c ON c.id = g.conversation_id
+WHERE c.tenant_id = $1
+  AND ($2::uuid IS NULL OR g.operator_id = $2::uuid)
+  AND ($3::uuid IS NULL OR g.conversation_id = $3::uuid)
+ORDER BY g.expires_at, g.id
+LIMIT $4;
+
+-- Moves the expiry of a grace period the worker has not picked up yet
+-- name: SetGracePeriodExpiry :execrows
+UPDATE grace_period_assignments SET expires_at = $2
+WHERE id = $1 AND expires_at > NOW();
+
 -- name: GetGracePeriodsByOperatorID :many
 SELECT * FROM grace_period_assignments WHERE operator_id = $1;
 
@@ -22,6 +43,13 @@ ORDER BY expires_at ASC
 LIMIT $1
 FOR UPDATE SKIP LOCKED;
 
+-- Backlog of the grace period worker, for pipeline health
+-- name: GetOverdueGracePeriodStats :one
+SELECT COUNT(*)::bigint AS overdue_count,
+       MIN(expires_at)::timestamptz AS oldest_expires_at
+FROM grace_period_assignments
+WHERE expires_at <= NOW();
+
 -- name: DeleteGracePeriodAssignment :exec
 DELETE FROM grace_period_assignments WHERE id = $1;
 
@@ -1,8 +1,45 @@
 -- name: CreateIdempotencyKey :exec
 INSERT INTO idempotency_keys (
     id, key, tenant_id, endpoint, method, request_hash,
-    response_status, response_body, created_at, expires_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+    response_status, response_body, response_ciphertext, encryption_key_id,
+    created_at, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- Inserts the PENDING claim of a key, or takes over an expired key or a
+-- claim past its locked_until; no row is affected while the key is held
+-- name: ClaimIdempotencyKey :execrows
+INSERT INTO idempotency_keys (
+    id, key, tenant_id, endpoint, method, request_hash,
+    response_status, status, locked_until, created_at, expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, 0, 'PENDING', $7, $8, $9)
+ON CONFLICT (tenant_id, key) DO UPDATE
+SET id = EXCLUDED.id,
+    endpoint = EXCLUDED.endpoint,
+    method = EXCLUDED.method,
+    request_hash = EXCLUDED.request_hash,
+    response_status = 0,
+    response_body = NULL,
+    response_ciphertext = NULL,
+    encryption_key_id = NULL,
+    status = 'PENDING',
+    locked_until = EXCLUDED.locked_until,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < NOW()
+   OR (idempotency_keys.status = 'PENDING' AND idempotency_keys.locked_until < NOW());
+
+-- Stores the response of a PENDING claim; no row is affected once the claim
+-- was taken over
+-- name: CompleteIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET status = 'COMPLETED',
+    locked_until = NULL,
+    response_status = $2,
+    response_body = $3,
+    response_ciphertext = $4,
+    encryption_key_id = $5,
+    expires_at = $6
+WHERE id = $1 AND status = 'PENDING';
 
 -- name: GetIdempotencyKey :one
 SELECT * FROM idempotency_keys
@@ -11,6 +48,9 @@ WHERE tenant_id = $1 AND key = $2;
 -- name: DeleteIdempotencyKey :exec
 DELETE FROM idempotency_keys WHERE id = $1;
 
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE id = $1 AND status = 'PENDING';
+
 -- name: DeleteExpiredIdempotencyKeys :execrows
 DELETE FROM idempotency_keys
 WHERE expires_at < NOW();
@@ -24,3 +64,19 @@ FOR UPDATE SKIP LOCKED;
 
 -- name: CountIdempotencyKeys :one
 SELECT COUNT(*) FROM idempotency_keys WHERE tenant_id = $1;
+
+-- Unexpired completed keys whose response is not stored under the given key
+-- (NULL: stored in plaintext), after the cursor in id order
+-- name: GetIdempotencyKeysToReencrypt :many
+SELECT * FROM idempotency_keys
+WHERE expires_at >= NOW()
+  AND status = 'COMPLETED'
+  AND encryption_key_id IS DISTINCT FROM $1
+  AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- name: UpdateIdempotencyKeyResponse :exec
+UPDATE idempotency_keys
+SET response_body = $2, response_ciphertext = $3, encryption_key_id = $4
+WHERE id = $1;
@@ -0,0 +1,23 @@
+-- name: CreateInboxAdmin :exec
+INSERT INTO inbox_admins (operator_id, inbox_id, tenant_id, granted_by, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: CheckInboxAdminExists :one
+SELECT EXISTS(
+    SELECT 1 FROM inbox_admins
+    WHERE operator_id = $1 AND inbox_id = $2
+) AS exists;
+
+-- name: GetInboxAdminsByInboxID :many
+SELECT * FROM inbox_admins
+WHERE inbox_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetInboxAdminsByOperatorID :many
+SELECT * FROM inbox_admins
+WHERE operator_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteInboxAdmin :exec
+DELETE FROM inbox_admins
+WHERE operator_id = $1 AND inbox_id = $2;
@@ -0,0 +1,16 @@
+-- name: ListInboxCategoryQuotas :many
+SELECT * FROM inbox_category_quotas
+WHERE inbox_id = $1
+ORDER BY share_percent DESC, label_id;
+
+-- name: ListCategoryQuotasByInboxIDs :many
+SELECT * FROM inbox_category_quotas
+WHERE inbox_id = ANY($1::uuid[])
+ORDER BY inbox_id, share_percent DESC, label_id;
+
+-- name: CreateInboxCategoryQuota :exec
+INSERT INTO inbox_category_quotas (inbox_id, label_id, tenant_id, share_percent, updated_by, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteInboxCategoryQuotas :exec
+DELETE FROM inbox_category_quotas WHERE inbox_id = $1;
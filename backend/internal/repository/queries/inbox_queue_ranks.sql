@@ -0,0 +1,43 @@
+-- name: DeleteInboxQueueRanks :exec
+DELETE FROM inbox_queue_ranks
+WHERE inbox_id = $1;
+
+-- Ranks the inbox's QUEUED conversations in allocation order; snoozed ones
+-- are not in the queue. A conversation still ranked under the inbox it was
+-- moved from is taken over.
+-- name: InsertInboxQueueRanks :execrows
+INSERT INTO inbox_queue_ranks (
+    conversation_id, tenant_id, inbox_id, rank, priority_score, last_message_at, refreshed_at
+)
+SELECT id, tenant_id, inbox_id,
+       ROW_NUMBER() OVER (ORDER BY priority_override DESC NULLS LAST, priority_score DESC, last_message_at ASC, id ASC)::int,
+       priority_score, last_message_at, $2::timestamptz
+FROM conversation_refs
+WHERE inbox_id = $1 AND state = 'QUEUED' AND snoozed_until IS NULL
+ON CONFLICT (conversation_id) DO UPDATE
+SET tenant_id = EXCLUDED.tenant_id,
+    inbox_id = EXCLUDED.inbox_id,
+    rank = EXCLUDED.rank,
+    priority_score = EXCLUDED.priority_score,
+    last_message_at = EXCLUDED.last_message_at,
+    refreshed_at = EXCLUDED.refreshed_at;
+
+-- name: GetInboxQueueRankByConversationID :one
+SELECT * FROM inbox_queue_ranks
+WHERE conversation_id = $1;
+
+-- name: CountInboxQueueRanks :one
+SELECT COUNT(*) FROM inbox_queue_ranks
+WHERE inbox_id = $1;
+
+-- name: ListInboxQueueRanks :many
+SELECT * FROM inbox_queue_ranks
+WHERE inbox_id = $1
+ORDER BY rank ASC
+LIMIT $2;
+
+-- Inboxes with a queue to rank or ranks to clear
+-- name: GetInboxIDsToRank :many
+SELECT DISTINCT inbox_id FROM conversation_refs WHERE state = 'QUEUED'
+UNION
+SELECT DISTINCT inbox_id FROM inbox_queue_ranks;
@@ -0,0 +1,16 @@
+-- name: GetInboxSLAPolicy :one
+SELECT * FROM inbox_sla_policies WHERE inbox_id = $1;
+
+-- name: UpsertInboxSLAPolicy :exec
+INSERT INTO inbox_sla_policies (
+    inbox_id, tenant_id, first_assignment_seconds, resolution_seconds,
+    updated_by, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (inbox_id) DO UPDATE
+SET first_assignment_seconds = EXCLUDED.first_assignment_seconds,
+    resolution_seconds = EXCLUDED.resolution_seconds,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteInboxSLAPolicy :exec
+DELETE FROM inbox_sla_policies WHERE inbox_id = $1;
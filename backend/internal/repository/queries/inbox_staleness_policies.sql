@@ -0,0 +1,16 @@
+-- name: GetInboxStalenessPolicy :one
+SELECT * FROM inbox_staleness_policies WHERE inbox_id = $1;
+
+-- name: UpsertInboxStalenessPolicy :exec
+INSERT INTO inbox_staleness_policies (
+    inbox_id, tenant_id, stale_after_seconds, deallocate_grace_seconds,
+    updated_by, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (inbox_id) DO UPDATE
+SET stale_after_seconds = EXCLUDED.stale_after_seconds,
+    deallocate_grace_seconds = EXCLUDED.deallocate_grace_seconds,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteInboxStalenessPolicy :exec
+DELETE FROM inbox_staleness_policies WHERE inbox_id = $1;
@@ -1,6 +1,6 @@
 -- name: CreateInbox :exec
-INSERT INTO inboxes (id, tenant_id, phone_number, display_name, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO inboxes (id, tenant_id, phone_number, display_name, is_restricted, created_at, updated_at, business_hours)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetInboxByID :one
 SELECT * FROM inboxes WHERE id = $1;
@@ -15,8 +15,17 @@ SELECT * FROM inboxes WHERE tenant_id = $1 AND phone_number = $2;
 UPDATE inboxes
 SET phone_number = $2,
     display_name = $3,
-    updated_at = $4
+    is_restricted = $4,
+    updated_at = $5,
+    escalation_inbox_id = $6,
+    auto_resolve_after_seconds = $7,
+    business_hours = $8
 WHERE id = $1;
 
 -- name: DeleteInbox :exec
 DELETE FROM inboxes WHERE id = $1;
+
+-- Deletes every inbox of the tenant with its conversations, labels and
+-- subscriptions
+-- name: DeleteInboxesByTenantID :exec
+DELETE FROM inboxes WHERE tenant_id = $1;
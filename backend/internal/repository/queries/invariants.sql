@@ -0,0 +1,51 @@
+-- Invariant checks, see InvariantService. Each finds at most $2 of the
+-- tenant's conversations breaking one invariant.
+
+-- name: FindAllocatedWithoutOperator :many
+SELECT id, external_conversation_id, inbox_id, state, assigned_operator_id
+FROM conversation_refs
+WHERE tenant_id = $1
+  AND state = 'ALLOCATED'
+  AND assigned_operator_id IS NULL
+ORDER BY id
+LIMIT $2;
+
+-- name: FindOperatorNotSubscribed :many
+SELECT c.id, c.external_conversation_id, c.inbox_id, c.state, c.assigned_operator_id
+FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.state = 'ALLOCATED'
+  AND c.assigned_operator_id IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM operator_inbox_subscriptions s
+      WHERE s.operator_id = c.assigned_operator_id AND s.inbox_id = c.inbox_id
+  )
+ORDER BY c.id
+LIMIT $2;
+
+-- name: FindResolvedWithGracePeriod :many
+SELECT c.id, c.external_conversation_id, c.inbox_id, c.state, g.operator_id
+FROM grace_period_assignments g
+JOIN conversation_refs c ON c.id = g.conversation_id
+WHERE c.tenant_id = $1
+  AND c.state = 'RESOLVED'
+ORDER BY c.id
+LIMIT $2;
+
+-- Unresolved conversations sharing their external ID with another, grouped
+-- by external ID. idx_conversations_external_id rules these out unless the
+-- index is invalid or was dropped.
+-- name: FindDuplicateExternalIDs :many
+SELECT c.id, c.external_conversation_id, c.inbox_id, c.state, c.assigned_operator_id
+FROM conversation_refs c
+JOIN (
+    SELECT external_conversation_id
+    FROM conversation_refs
+    WHERE tenant_id = $1 AND state <> 'RESOLVED'
+    GROUP BY external_conversation_id
+    HAVING COUNT(*) > 1
+) d ON d.external_conversation_id = c.external_conversation_id
+WHERE c.tenant_id = $1
+  AND c.state <> 'RESOLVED'
+ORDER BY c.external_conversation_id, c.id
+LIMIT $2;
@@ -1,21 +1,67 @@
+-- Creates the label together with its first version
 -- name: CreateLabel :exec
-INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+WITH created AS (
+    INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at, parent_label_id, scope)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    RETURNING id, name, color, created_by, created_at
+)
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+SELECT id, 1, name, color, created_by, created_at FROM created;
+
+-- Used by routing rules: concurrent creators of the same label must not fail
+-- name: CreateLabelIfNotExists :exec
+WITH created AS (
+    INSERT INTO labels (id, tenant_id, inbox_id, name, color, created_by, created_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (inbox_id, name) WHERE scope = 'INBOX' DO NOTHING
+    RETURNING id, name, color, created_by, created_at
+)
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+SELECT id, 1, name, color, created_by, created_at FROM created;
 
 -- name: GetLabelByID :one
 SELECT * FROM labels WHERE id = $1;
 
+-- Serializes concurrent renames of the same label
+-- name: GetLabelByIDForUpdate :one
+SELECT * FROM labels WHERE id = $1 FOR UPDATE;
+
+-- The inbox's labels and the tenant-scoped labels, which apply to every inbox
 -- name: GetLabelsByInboxID :many
-SELECT * FROM labels WHERE tenant_id = $1 AND inbox_id = $2 ORDER BY name;
+SELECT * FROM labels WHERE tenant_id = $1 AND (inbox_id = $2 OR scope = 'TENANT') ORDER BY name;
+
+-- name: GetTenantLabels :many
+SELECT * FROM labels WHERE tenant_id = $1 AND scope = 'TENANT' ORDER BY name;
 
 -- name: GetLabelByName :one
 SELECT * FROM labels WHERE inbox_id = $1 AND name = $2;
 
+-- name: GetTenantLabelByName :one
+SELECT * FROM labels WHERE tenant_id = $1 AND scope = 'TENANT' AND name = $2;
+
 -- name: UpdateLabel :exec
 UPDATE labels
 SET name = $2,
-    color = $3
+    color = $3,
+    version = $4,
+    parent_label_id = $5
 WHERE id = $1;
 
+-- Returns the labels and every label below them; UNION stops at cycles
+-- name: GetLabelDescendantIDs :many
+WITH RECURSIVE tree AS (
+    SELECT l.id FROM labels l WHERE l.id = ANY($1::uuid[])
+    UNION
+    SELECT child.id FROM labels child JOIN tree ON child.parent_label_id = tree.id
+)
+SELECT id FROM tree;
+
 -- name: DeleteLabel :exec
 DELETE FROM labels WHERE id = $1;
+
+-- name: CreateLabelVersion :exec
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListLabelVersions :many
+SELECT * FROM label_versions WHERE label_id = $1 ORDER BY version;
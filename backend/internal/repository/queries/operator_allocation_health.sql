@@ -0,0 +1,26 @@
+-- name: GetOperatorAllocationHealth :one
+SELECT * FROM operator_allocation_health WHERE operator_id = $1;
+
+-- name: ListOperatorAllocationHealth :many
+SELECT * FROM operator_allocation_health
+WHERE tenant_id = $1
+ORDER BY COALESCE(override_weight, weight), operator_id;
+
+-- Written by the health worker; leaves a manager override untouched
+-- name: UpsertOperatorAllocationWeight :exec
+INSERT INTO operator_allocation_health (operator_id, tenant_id, weight, allocations, returns, evaluated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (operator_id) DO UPDATE
+SET weight = EXCLUDED.weight,
+    allocations = EXCLUDED.allocations,
+    returns = EXCLUDED.returns,
+    evaluated_at = EXCLUDED.evaluated_at;
+
+-- Written by managers; leaves the feedback-loop weight untouched
+-- name: SetOperatorAllocationOverride :exec
+INSERT INTO operator_allocation_health (operator_id, tenant_id, override_weight, override_by, override_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (operator_id) DO UPDATE
+SET override_weight = EXCLUDED.override_weight,
+    override_by = EXCLUDED.override_by,
+    override_at = EXCLUDED.override_at;
@@ -0,0 +1,31 @@
+-- A token registered again moves to the registering operator
+-- name: UpsertOperatorDevice :one
+INSERT INTO operator_devices (id, tenant_id, operator_id, platform, token, event_types, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (token) DO UPDATE
+SET tenant_id = EXCLUDED.tenant_id,
+    operator_id = EXCLUDED.operator_id,
+    platform = EXCLUDED.platform,
+    event_types = EXCLUDED.event_types,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetOperatorDeviceByID :one
+SELECT * FROM operator_devices WHERE id = $1;
+
+-- name: GetOperatorDevicesByOperatorID :many
+SELECT * FROM operator_devices
+WHERE operator_id = $1
+ORDER BY created_at ASC;
+
+-- name: CountOperatorDevices :one
+SELECT COUNT(*) FROM operator_devices WHERE operator_id = $1;
+
+-- name: UpdateOperatorDeviceEventTypes :exec
+UPDATE operator_devices SET event_types = $2, updated_at = $3 WHERE id = $1;
+
+-- name: DeleteOperatorDevice :exec
+DELETE FROM operator_devices WHERE id = $1;
+
+-- name: DeleteOperatorDeviceByToken :exec
+DELETE FROM operator_devices WHERE token = $1;
@@ -2,6 +2,12 @@
 INSERT INTO operator_inbox_subscriptions (id, operator_id, inbox_id, created_at)
 VALUES ($1, $2, $3, $4);
 
+-- Insert unless the operator is already subscribed to the inbox
+-- name: CreateSubscriptionIfNotExists :execrows
+INSERT INTO operator_inbox_subscriptions (id, operator_id, inbox_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (operator_id, inbox_id) DO NOTHING;
+
 -- name: GetSubscriptionByID :one
 SELECT * FROM operator_inbox_subscriptions WHERE id = $1;
 
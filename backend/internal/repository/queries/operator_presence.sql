@@ -0,0 +1,19 @@
+-- Refreshes the operator's presence; inserted is true when the row did not
+-- exist, i.e. the operator was not connected or had timed out
+-- name: TouchOperatorPresence :one
+INSERT INTO operator_presence (operator_id, tenant_id, connected_at, last_seen_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (operator_id) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at
+RETURNING (xmax = 0)::boolean AS inserted;
+
+-- CRITICAL: Claims timed-out presence rows. Deleting them hands each row to
+-- exactly one replica's presence worker.
+-- name: ClaimStaleOperatorPresence :many
+DELETE FROM operator_presence
+WHERE last_seen_at < $1
+RETURNING *;
+
+-- name: GetOperatorPresenceByTenantID :many
+SELECT * FROM operator_presence
+WHERE tenant_id = $1
+ORDER BY connected_at ASC;
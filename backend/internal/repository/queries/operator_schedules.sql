@@ -0,0 +1,32 @@
+-- name: CreateOperatorSchedule :exec
+INSERT INTO operator_schedules (
+    id, tenant_id, operator_id, day_of_week, start_time, end_time, timezone,
+    created_by, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetOperatorScheduleByID :one
+SELECT * FROM operator_schedules WHERE id = $1;
+
+-- name: GetOperatorSchedulesByOperatorID :many
+SELECT * FROM operator_schedules
+WHERE operator_id = $1
+ORDER BY day_of_week, start_time;
+
+-- Windows of every AVAILABLE operator that has a schedule, ordered by operator
+-- name: GetSchedulesOfAvailableOperators :many
+SELECT s.* FROM operator_schedules s
+JOIN operator_status os ON os.operator_id = s.operator_id
+WHERE os.status = 'AVAILABLE'
+ORDER BY s.operator_id, s.day_of_week, s.start_time;
+
+-- name: UpdateOperatorSchedule :exec
+UPDATE operator_schedules
+SET day_of_week = $2,
+    start_time = $3,
+    end_time = $4,
+    timezone = $5,
+    updated_at = $6
+WHERE id = $1;
+
+-- name: DeleteOperatorSchedule :exec
+DELETE FROM operator_schedules WHERE id = $1;
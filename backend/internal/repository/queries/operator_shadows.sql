@@ -0,0 +1,17 @@
+-- name: CreateOperatorShadow :exec
+INSERT INTO operator_shadows (id, tenant_id, mentor_id, trainee_id, created_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetOperatorShadowByID :one
+SELECT * FROM operator_shadows WHERE id = $1;
+
+-- name: GetOperatorShadowsByTenantID :many
+SELECT * FROM operator_shadows
+WHERE tenant_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetMentorIDsForTrainee :many
+SELECT mentor_id FROM operator_shadows WHERE trainee_id = $1;
+
+-- name: DeleteOperatorShadow :exec
+DELETE FROM operator_shadows WHERE id = $1;
@@ -8,7 +8,8 @@ SELECT * FROM operator_status WHERE operator_id = $1;
 -- name: UpdateOperatorStatus :exec
 UPDATE operator_status
 SET status = $2,
-    last_status_change_at = $3
+    last_status_change_at = $3,
+    focus_until = $4
 WHERE operator_id = $1;
 
 -- name: GetAvailableOperators :many
@@ -16,3 +17,16 @@ SELECT os.*
 FROM operator_status os
 JOIN operators o ON o.id = os.operator_id
 WHERE o.tenant_id = $1 AND os.status = 'AVAILABLE';
+
+-- name: GetOperatorStatusesByTenantID :many
+SELECT os.*
+FROM operator_status os
+JOIN operators o ON o.id = os.operator_id
+WHERE o.tenant_id = $1;
+
+-- name: CountOperatorsByStatus :many
+SELECT os.status, COUNT(*) AS operators
+FROM operator_status os
+JOIN operators o ON o.id = os.operator_id
+WHERE o.tenant_id = $1
+GROUP BY os.status;
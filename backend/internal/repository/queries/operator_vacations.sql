@@ -0,0 +1,33 @@
+-- Starts the vacation, or replaces the drain plan of the running one while
+-- keeping when it started
+-- name: UpsertOperatorVacation :one
+INSERT INTO operator_vacations (operator_id, tenant_id, started_at, drain, colleague_ids, drain_until)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (operator_id) DO UPDATE SET
+    drain = EXCLUDED.drain,
+    colleague_ids = EXCLUDED.colleague_ids,
+    drain_until = EXCLUDED.drain_until,
+    drained_at = NULL,
+    next_colleague = 0
+RETURNING *;
+
+-- name: GetOperatorVacationByOperatorID :one
+SELECT * FROM operator_vacations WHERE operator_id = $1;
+
+-- Vacations whose conversations are still being drained, locked for the
+-- vacation drain worker
+-- name: GetAndLockDrainingOperatorVacations :many
+SELECT * FROM operator_vacations
+WHERE drain <> 'NONE' AND drained_at IS NULL
+ORDER BY drain_until ASC
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateOperatorVacationDrain :exec
+UPDATE operator_vacations
+SET drained_at = $2,
+    next_colleague = $3
+WHERE operator_id = $1;
+
+-- name: DeleteOperatorVacation :execrows
+DELETE FROM operator_vacations WHERE operator_id = $1;
@@ -1,6 +1,6 @@
 -- name: CreateOperator :exec
-INSERT INTO operators (id, tenant_id, role, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5);
+INSERT INTO operators (id, tenant_id, role, name, email, created_at, updated_at, languages)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetOperatorByID :one
 SELECT * FROM operators WHERE id = $1;
@@ -14,7 +14,10 @@ SELECT * FROM operators WHERE tenant_id = $1 AND role = $2 ORDER BY created_at D
 -- name: UpdateOperator :exec
 UPDATE operators
 SET role = $2,
-    updated_at = $3
+    name = $3,
+    email = $4,
+    updated_at = $5,
+    languages = $6
 WHERE id = $1;
 
 -- name: DeleteOperator :exec
@@ -0,0 +1,59 @@
+-- name: CreatePriorityExperiment :exec
+INSERT INTO priority_experiments (
+    id, tenant_id, inbox_id, name, weight_alpha, weight_beta, traffic_percent,
+    status, created_by, started_at, stopped_at, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetPriorityExperimentByID :one
+SELECT * FROM priority_experiments WHERE id = $1;
+
+-- name: GetPriorityExperimentsByTenantID :many
+SELECT * FROM priority_experiments WHERE tenant_id = $1 ORDER BY created_at DESC;
+
+-- name: GetRunningPriorityExperimentByInbox :one
+SELECT * FROM priority_experiments WHERE inbox_id = $1 AND status = 'RUNNING';
+
+-- name: GetRunningPriorityExperimentsByTenantID :many
+SELECT * FROM priority_experiments WHERE tenant_id = $1 AND status = 'RUNNING';
+
+-- name: UpdatePriorityExperiment :exec
+UPDATE priority_experiments
+SET name = $2,
+    status = $3,
+    stopped_at = $4,
+    updated_at = $5
+WHERE id = $1;
+
+-- name: DeletePriorityExperiment :exec
+DELETE FROM priority_experiments WHERE id = $1;
+
+-- Records the arm of a conversation once; later calculations keep it
+-- name: CreatePriorityExperimentAssignment :exec
+INSERT INTO priority_experiment_assignments (experiment_id, conversation_id, arm, assigned_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (experiment_id, conversation_id) DO NOTHING;
+
+-- Stamps the first allocation of the enrolled conversations among $1
+-- name: MarkPriorityExperimentAssignmentsAllocated :exec
+UPDATE priority_experiment_assignments
+SET allocated_at = $2
+WHERE conversation_id = ANY($1::uuid[]) AND allocated_at IS NULL;
+
+-- Outcomes per arm: wait from creation to first allocation and resolution
+-- time, over the conversations that got there
+-- name: GetPriorityExperimentOutcomes :many
+SELECT a.arm,
+       COUNT(*) AS conversations,
+       COUNT(a.allocated_at) AS allocated,
+       COUNT(c.resolved_at) AS resolved,
+       COALESCE(AVG(EXTRACT(EPOCH FROM a.allocated_at - c.created_at)), 0)::float8 AS avg_wait_seconds,
+       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.allocated_at - c.created_at)), 0)::float8 AS p50_wait_seconds,
+       COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.allocated_at - c.created_at)), 0)::float8 AS p90_wait_seconds,
+       COALESCE(AVG(EXTRACT(EPOCH FROM c.resolved_at - c.created_at)), 0)::float8 AS avg_resolution_seconds,
+       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM c.resolved_at - c.created_at)), 0)::float8 AS p50_resolution_seconds,
+       COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM c.resolved_at - c.created_at)), 0)::float8 AS p90_resolution_seconds
+FROM priority_experiment_assignments a
+JOIN conversation_refs c ON c.id = a.conversation_id
+WHERE a.experiment_id = $1
+GROUP BY a.arm
+ORDER BY a.arm;
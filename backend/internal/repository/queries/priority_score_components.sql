@@ -0,0 +1,13 @@
+-- name: CreatePriorityScoreComponents :exec
+INSERT INTO priority_score_components (
+    id, conversation_id, tenant_id, message_count, last_message_at,
+    message_factor, delay_factor, weight_alpha, weight_beta, rule_boost,
+    priority_score, computed_at, first_contact_boost
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- Newest calculation first
+-- name: ListPriorityScoreComponents :many
+SELECT * FROM priority_score_components
+WHERE conversation_id = $1
+ORDER BY computed_at DESC, id DESC
+LIMIT $2;
@@ -0,0 +1,67 @@
+-- name: CreateQAReviewer :exec
+INSERT INTO qa_reviewers (operator_id, tenant_id, granted_by, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: IsQAReviewer :one
+SELECT EXISTS(
+    SELECT 1 FROM qa_reviewers WHERE operator_id = $1
+) AS exists;
+
+-- name: GetQAReviewersByTenantID :many
+SELECT * FROM qa_reviewers
+WHERE tenant_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteQAReviewer :exec
+DELETE FROM qa_reviewers WHERE operator_id = $1;
+
+-- name: CreateQAReviewItem :exec
+INSERT INTO qa_review_items (id, tenant_id, conversation_id, operator_id, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetQAReviewItemByID :one
+SELECT * FROM qa_review_items WHERE id = $1;
+
+-- name: GetActiveQAReviewItemForReviewer :one
+SELECT * FROM qa_review_items
+WHERE reviewer_id = $1
+  AND status = 'IN_REVIEW'
+  AND claimed_until > NOW()
+ORDER BY claimed_until DESC
+LIMIT 1;
+
+-- name: ClaimNextQAReviewItem :one
+-- Takes the oldest open item, including items whose reviewer lease expired.
+-- Reviewers never receive conversations they handled themselves.
+UPDATE qa_review_items
+SET status = 'IN_REVIEW', reviewer_id = $2, claimed_until = $3
+WHERE id = (
+    SELECT id FROM qa_review_items
+    WHERE tenant_id = $1
+      AND (status = 'PENDING' OR (status = 'IN_REVIEW' AND claimed_until <= NOW()))
+      AND operator_id IS DISTINCT FROM $2
+    ORDER BY created_at ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteQAReviewItem :execrows
+UPDATE qa_review_items
+SET status = 'COMPLETED',
+    scores = $2,
+    overall_score = $3,
+    comment = $4,
+    completed_at = $5,
+    claimed_until = NULL
+WHERE id = $1
+  AND reviewer_id = $6
+  AND status = 'IN_REVIEW';
+
+-- name: GetCompletedQAReviewItems :many
+SELECT * FROM qa_review_items
+WHERE tenant_id = $1
+  AND status = 'COMPLETED'
+  AND completed_at >= $2
+  AND completed_at < $3
+ORDER BY completed_at ASC;
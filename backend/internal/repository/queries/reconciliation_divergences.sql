@@ -0,0 +1,56 @@
+-- Reconciliation against the upstream system of record, see
+-- ReconciliationService.
+
+-- The tenant's conversations to compare with upstream after id $2: those
+-- updated since $3 and those already diverging
+-- name: ListReconciliationCandidates :many
+SELECT c.id, c.external_conversation_id, c.state
+FROM conversation_refs c
+WHERE c.tenant_id = $1
+  AND c.id > $2
+  AND (c.updated_at >= $3
+       OR EXISTS (SELECT 1 FROM reconciliation_divergences d WHERE d.conversation_id = c.id))
+ORDER BY c.id
+LIMIT $4;
+
+-- Records a divergence. first_detected_at is kept while the kind is
+-- unchanged; corrected_at is cleared once the local state moves.
+-- name: UpsertReconciliationDivergence :one
+INSERT INTO reconciliation_divergences (conversation_id, tenant_id, kind, local_state, upstream_status, first_detected_at, last_checked_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6)
+ON CONFLICT (conversation_id) DO UPDATE SET
+    first_detected_at = CASE WHEN reconciliation_divergences.kind = EXCLUDED.kind
+        THEN reconciliation_divergences.first_detected_at ELSE EXCLUDED.first_detected_at END,
+    corrected_at = CASE WHEN reconciliation_divergences.kind = EXCLUDED.kind
+        AND reconciliation_divergences.local_state = EXCLUDED.local_state
+        THEN reconciliation_divergences.corrected_at ELSE NULL END,
+    kind = EXCLUDED.kind,
+    local_state = EXCLUDED.local_state,
+    upstream_status = EXCLUDED.upstream_status,
+    last_checked_at = EXCLUDED.last_checked_at
+RETURNING *;
+
+-- Forgets the divergences of conversations found consistent again
+-- name: DeleteReconciliationDivergences :exec
+DELETE FROM reconciliation_divergences WHERE conversation_id = ANY($1::uuid[]);
+
+-- name: MarkReconciliationDivergenceCorrected :exec
+UPDATE reconciliation_divergences SET corrected_at = $2 WHERE conversation_id = $1;
+
+-- The tenant's divergences of kind $2 (empty for all), oldest first
+-- name: ListReconciliationDivergences :many
+SELECT d.conversation_id, d.tenant_id, d.kind, d.local_state, d.upstream_status,
+       d.first_detected_at, d.last_checked_at, d.corrected_at,
+       c.external_conversation_id, c.inbox_id
+FROM reconciliation_divergences d
+JOIN conversation_refs c ON c.id = d.conversation_id
+WHERE d.tenant_id = $1
+  AND ($2::text = '' OR d.kind = $2::text)
+ORDER BY d.first_detected_at, d.conversation_id
+LIMIT $3;
+
+-- name: CountReconciliationDivergences :many
+SELECT kind, COUNT(*) AS divergences
+FROM reconciliation_divergences
+WHERE tenant_id = $1
+GROUP BY kind;
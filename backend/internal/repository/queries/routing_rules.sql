@@ -0,0 +1,29 @@
+-- name: CreateRoutingRule :exec
+INSERT INTO routing_rules (
+    id, tenant_id, inbox_id, name, trigger, condition_field, condition_operator,
+    condition_value, condition_text, label_name, priority_boost, is_active, created_by, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);
+
+-- name: GetRoutingRuleByID :one
+SELECT * FROM routing_rules WHERE id = $1;
+
+-- name: GetRoutingRulesByTenantID :many
+SELECT * FROM routing_rules WHERE tenant_id = $1 ORDER BY created_at;
+
+-- Rules evaluated for a conversation: tenant-wide rules plus rules of its inbox
+-- name: GetActiveRoutingRulesForTrigger :many
+SELECT * FROM routing_rules
+WHERE tenant_id = $1
+  AND trigger = $2
+  AND is_active = TRUE
+  AND (inbox_id IS NULL OR inbox_id = $3)
+ORDER BY created_at;
+
+-- name: UpdateRoutingRule :exec
+UPDATE routing_rules
+SET is_active = $2,
+    updated_at = $3
+WHERE id = $1;
+
+-- name: DeleteRoutingRule :exec
+DELETE FROM routing_rules WHERE id = $1;
@@ -0,0 +1,25 @@
+-- name: CreateSchemaBackfill :exec
+INSERT INTO schema_backfills (name, table_name, status, rows_total, batch_size, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (name) DO NOTHING;
+
+-- name: GetSchemaBackfills :many
+SELECT * FROM schema_backfills
+ORDER BY created_at ASC, name ASC;
+
+-- Claim a backfill job; replicas skip jobs another instance is processing
+-- name: LockPendingSchemaBackfill :one
+SELECT * FROM schema_backfills
+WHERE name = $1 AND status <> 'COMPLETED'
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateSchemaBackfillProgress :exec
+UPDATE schema_backfills
+SET status = $2,
+    cursor_id = $3,
+    rows_processed = $4,
+    last_error = $5,
+    started_at = $6,
+    updated_at = $7,
+    completed_at = $8
+WHERE name = $1;
@@ -0,0 +1,17 @@
+-- name: GetTenantAnomalySettings :one
+SELECT * FROM tenant_anomaly_settings WHERE tenant_id = $1;
+
+-- Every tenant with its configured sensitivity, NULL when not configured
+-- name: ListTenantAnomalySensitivities :many
+SELECT t.id AS tenant_id, s.sensitivity
+FROM tenants t
+LEFT JOIN tenant_anomaly_settings s ON s.tenant_id = t.id
+ORDER BY t.id;
+
+-- name: UpsertTenantAnomalySettings :exec
+INSERT INTO tenant_anomaly_settings (tenant_id, sensitivity, updated_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (tenant_id) DO UPDATE
+SET sensitivity = EXCLUDED.sensitivity,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
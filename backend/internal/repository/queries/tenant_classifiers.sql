@@ -0,0 +1,11 @@
+-- name: GetTenantClassifier :one
+SELECT * FROM tenant_classifiers WHERE tenant_id = $1;
+
+-- name: UpsertTenantClassifier :exec
+INSERT INTO tenant_classifiers (tenant_id, endpoint_url, is_enabled, updated_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tenant_id) DO UPDATE
+SET endpoint_url = EXCLUDED.endpoint_url,
+    is_enabled = EXCLUDED.is_enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
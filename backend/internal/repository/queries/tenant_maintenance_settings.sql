@@ -0,0 +1,18 @@
+-- name: GetTenantMaintenanceSettings :one
+SELECT * FROM tenant_maintenance_settings WHERE tenant_id = $1;
+
+-- name: UpsertTenantMaintenanceSettings :exec
+INSERT INTO tenant_maintenance_settings (
+    tenant_id, window_start, window_end, timezone,
+    override_mode, override_until, override_reason,
+    updated_by, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (tenant_id) DO UPDATE
+SET window_start = EXCLUDED.window_start,
+    window_end = EXCLUDED.window_end,
+    timezone = EXCLUDED.timezone,
+    override_mode = EXCLUDED.override_mode,
+    override_until = EXCLUDED.override_until,
+    override_reason = EXCLUDED.override_reason,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
@@ -14,7 +14,12 @@ SET name = $2,
     priority_weight_alpha = $3,
     priority_weight_beta = $4,
     updated_at = $5,
-    updated_by = $6
+    updated_by = $6,
+    first_contact_boost = $7,
+    grace_period_seconds = $8,
+    intake_limit_per_hour = $9,
+    allocation_engine = $10,
+    sandbox = $11
 WHERE id = $1;
 
 -- name: DeleteTenant :exec
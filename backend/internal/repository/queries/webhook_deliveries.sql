@@ -0,0 +1,39 @@
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (
+    id, webhook_id, tenant_id, event_id, event_type, payload,
+    status, attempt_count, next_attempt_at, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetWebhookDeliveryByID :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: GetWebhookDeliveriesByWebhookID :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- CRITICAL: Claim due deliveries for the worker. The lease pushes next_attempt_at
+-- forward so that concurrent workers skip rows while the HTTP call is in flight.
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = $2
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at ASC
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = $2,
+    attempt_count = $3,
+    next_attempt_at = $4,
+    last_attempt_at = $5,
+    last_status_code = $6,
+    last_error = $7,
+    delivered_at = $8
+WHERE id = $1;
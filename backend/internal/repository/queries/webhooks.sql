@@ -0,0 +1,25 @@
+-- name: CreateWebhook :exec
+INSERT INTO webhooks (id, tenant_id, url, secret, event_types, operator_fields, is_active, created_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetWebhookByID :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: GetWebhooksByTenantID :many
+SELECT * FROM webhooks
+WHERE tenant_id = $1
+ORDER BY created_at ASC;
+
+-- name: GetActiveWebhooksForEvent :many
+SELECT * FROM webhooks
+WHERE tenant_id = $1
+  AND is_active = TRUE
+  AND $2::text = ANY(event_types);
+
+-- name: UpdateWebhook :exec
+UPDATE webhooks
+SET url = $2, secret = $3, event_types = $4, operator_fields = $5, is_active = $6, updated_at = $7
+WHERE id = $1;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE id = $1;
@@ -0,0 +1,27 @@
+-- Version recorded by golang-migrate
+-- name: GetSchemaMigration :one
+SELECT version, dirty FROM schema_migrations LIMIT 1;
+
+-- name: UpsertWorkerInstance :exec
+INSERT INTO worker_instances (
+    instance_id, binary_version, worker_protocol, min_schema_version,
+    max_schema_version, workers_enabled, started_at, heartbeat_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+ON CONFLICT (instance_id) DO UPDATE SET
+    binary_version = EXCLUDED.binary_version,
+    worker_protocol = EXCLUDED.worker_protocol,
+    min_schema_version = EXCLUDED.min_schema_version,
+    max_schema_version = EXCLUDED.max_schema_version,
+    workers_enabled = EXCLUDED.workers_enabled,
+    heartbeat_at = EXCLUDED.heartbeat_at;
+
+-- name: DeleteWorkerInstance :exec
+DELETE FROM worker_instances WHERE instance_id = $1;
+
+-- name: DeleteStaleWorkerInstances :execrows
+DELETE FROM worker_instances WHERE heartbeat_at < $1;
+
+-- Newest worker protocol among replicas running workers; 0 for none
+-- name: GetNewestLiveWorkerProtocol :one
+SELECT COALESCE(MAX(worker_protocol), 0)::INTEGER FROM worker_instances
+WHERE workers_enabled AND heartbeat_at >= $1 AND instance_id <> $2;
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type QueueRankRepositoryImpl struct {
+	q    *Queries
+	pool *pgxpool.Pool
+}
+
+func NewQueueRankRepository(q *Queries, pool *pgxpool.Pool) *QueueRankRepositoryImpl {
+	return &QueueRankRepositoryImpl{q: q, pool: pool}
+}
+
+// RefreshInbox replaces the inbox's ranks in one transaction, so readers see
+// either the previous or the new ranking
+func (r *QueueRankRepositoryImpl) RefreshInbox(ctx context.Context, inboxID uuid.UUID) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	q := r.q.WithTx(tx)
+	if err := q.DeleteInboxQueueRanks(ctx, uuidToPgtype(inboxID)); err != nil {
+		return 0, mapError(err)
+	}
+	ranked, err := q.InsertInboxQueueRanks(ctx, InsertInboxQueueRanksParams{
+		InboxID: uuidToPgtype(inboxID),
+		Column2: timeToPgtype(time.Now().UTC()),
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return ranked, nil
+}
+
+func (r *QueueRankRepositoryImpl) GetByConversationID(ctx context.Context, conversationID uuid.UUID) (*domain.QueueRank, error) {
+	row, err := r.q.GetInboxQueueRankByConversationID(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *QueueRankRepositoryImpl) CountByInbox(ctx context.Context, inboxID uuid.UUID) (int, error) {
+	count, err := r.q.CountInboxQueueRanks(ctx, uuidToPgtype(inboxID))
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return int(count), nil
+}
+
+func (r *QueueRankRepositoryImpl) ListByInbox(ctx context.Context, inboxID uuid.UUID, limit int) ([]*domain.QueueRank, error) {
+	rows, err := r.q.ListInboxQueueRanks(ctx, ListInboxQueueRanksParams{
+		InboxID: uuidToPgtype(inboxID),
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make([]*domain.QueueRank, len(rows))
+	for i, row := range rows {
+		result[i] = r.toDomain(row)
+	}
+	return result, nil
+}
+
+func (r *QueueRankRepositoryImpl) GetInboxIDsToRank(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.q.GetInboxIDsToRank(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	result := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		result[i] = pgtypeToUUID(row)
+	}
+	return result, nil
+}
+
+func (r *QueueRankRepositoryImpl) toDomain(row InboxQueueRank) *domain.QueueRank {
+	return &domain.QueueRank{
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		InboxID:        pgtypeToUUID(row.InboxID),
+		Rank:           int(row.Rank),
+		PriorityScore:  pgtypeToDecimal(row.PriorityScore),
+		LastMessageAt:  pgtypeToTime(row.LastMessageAt),
+		RefreshedAt:    pgtypeToTime(row.RefreshedAt),
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/pkg/cache"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+)
+
+var (
+	readCacheHits   = metrics.NewCounter("read_cache_hits_total")
+	readCacheMisses = metrics.NewCounter("read_cache_misses_total")
+	readCacheErrors = metrics.NewCounter("read_cache_errors_total")
+)
+
+// readCache caches hot rows as JSON in front of the database. A nil
+// readCache, the default, always misses. Cache errors are counted and treated
+// as misses, so an unavailable cache only costs the database round trip.
+//
+// Writers invalidate after their write; a concurrent reader can still cache
+// the value it read before the write, so entries may be stale for up to ttl.
+type readCache struct {
+	cache cache.Cache
+	ttl   time.Duration
+	// pending is set for repositories bound to a transaction. Their reads
+	// skip the cache, since they must see the transaction and must not cache
+	// what it has not committed, and their invalidations wait in pending
+	// until it commits: dropped earlier, an entry could be cached again from
+	// the row the transaction is still replacing.
+	pending *txInvalidations
+}
+
+// txInvalidations collects the keys a transaction's writes invalidate
+type txInvalidations struct {
+	cache cache.Cache
+	mu    sync.Mutex
+	keys  []string
+}
+
+// forTx returns the cache of repositories bound to a transaction, see pending
+func (c *readCache) forTx(pending *txInvalidations) *readCache {
+	if c == nil {
+		return nil
+	}
+	return &readCache{cache: c.cache, ttl: c.ttl, pending: pending}
+}
+
+func (p *txInvalidations) add(keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = append(p.keys, keys...)
+}
+
+// flush drops the collected keys from the cache
+func (p *txInvalidations) flush(ctx context.Context) {
+	p.mu.Lock()
+	keys := p.keys
+	p.keys = nil
+	p.mu.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+	if err := p.cache.Delete(ctx, keys...); err != nil {
+		readCacheErrors.Inc()
+	}
+}
+
+func (c *readCache) get(ctx context.Context, key string, v interface{}) bool {
+	if c == nil || c.pending != nil {
+		return false
+	}
+	data, found, err := c.cache.Get(ctx, key)
+	if err != nil {
+		readCacheErrors.Inc()
+		return false
+	}
+	if !found || json.Unmarshal(data, v) != nil {
+		readCacheMisses.Inc()
+		return false
+	}
+	readCacheHits.Inc()
+	return true
+}
+
+func (c *readCache) set(ctx context.Context, key string, v interface{}) {
+	if c == nil || c.pending != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if err := c.cache.Set(ctx, key, data, c.ttl); err != nil {
+		readCacheErrors.Inc()
+	}
+}
+
+func (c *readCache) invalidate(ctx context.Context, keys ...string) {
+	if c == nil {
+		return
+	}
+	if c.pending != nil {
+		c.pending.add(keys...)
+		return
+	}
+	if err := c.cache.Delete(ctx, keys...); err != nil {
+		readCacheErrors.Inc()
+	}
+}
+
+func operatorStatusCacheKey(operatorID uuid.UUID) string {
+	return "operator_status:" + operatorID.String()
+}
+
+func subscribedInboxesCacheKey(operatorID uuid.UUID) string {
+	return "subscribed_inboxes:" + operatorID.String()
+}
+
+func tenantCacheKey(tenantID uuid.UUID) string {
+	return "tenant:" + tenantID.String()
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// deletesCache records the keys deleted from it
+type deletesCache struct {
+	deleted []string
+}
+
+func (c *deletesCache) Get(context.Context, string) ([]byte, bool, error) { return nil, false, nil }
+
+func (c *deletesCache) Set(context.Context, string, []byte, time.Duration) error { return nil }
+
+func (c *deletesCache) Delete(_ context.Context, keys ...string) error {
+	c.deleted = append(c.deleted, keys...)
+	return nil
+}
+
+func TestReadCache_TxInvalidationWaitsForFlush(t *testing.T) {
+	ctx := context.Background()
+	c := &deletesCache{}
+	reads := &readCache{cache: c, ttl: time.Minute}
+	pending := &txInvalidations{cache: c}
+	txReads := reads.forTx(pending)
+
+	txReads.invalidate(ctx, "tenant:a")
+	txReads.invalidate(ctx, "subscribed_inboxes:b")
+	assert.Empty(t, c.deleted)
+
+	pending.flush(ctx)
+	assert.Equal(t, []string{"tenant:a", "subscribed_inboxes:b"}, c.deleted)
+
+	pending.flush(ctx)
+	assert.Len(t, c.deleted, 2)
+
+	reads.invalidate(ctx, "tenant:c")
+	assert.Equal(t, "tenant:c", c.deleted[2])
+}
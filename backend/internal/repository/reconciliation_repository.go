@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ReconciliationRepositoryImpl struct {
+	q *Queries
+}
+
+func NewReconciliationRepository(q *Queries) *ReconciliationRepositoryImpl {
+	return &ReconciliationRepositoryImpl{q: q}
+}
+
+func (r *ReconciliationRepositoryImpl) ListCandidates(ctx context.Context, tenantID, afterID uuid.UUID, updatedSince time.Time, limit int) ([]*domain.ReconciliationCandidate, error) {
+	rows, err := r.q.ListReconciliationCandidates(ctx, ListReconciliationCandidatesParams{
+		TenantID:  uuidToPgtype(tenantID),
+		ID:        uuidToPgtype(afterID),
+		UpdatedAt: timeToPgtype(updatedSince),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	candidates := make([]*domain.ReconciliationCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = &domain.ReconciliationCandidate{
+			ConversationID:         pgtypeToUUID(row.ID),
+			ExternalConversationID: row.ExternalConversationID,
+			State:                  pgtypeToConversationState(row.State),
+		}
+	}
+	return candidates, nil
+}
+
+func (r *ReconciliationRepositoryImpl) Upsert(ctx context.Context, divergence *domain.ReconciliationDivergence) (*domain.ReconciliationDivergence, error) {
+	row, err := r.q.UpsertReconciliationDivergence(ctx, UpsertReconciliationDivergenceParams{
+		ConversationID:  uuidToPgtype(divergence.ConversationID),
+		TenantID:        uuidToPgtype(divergence.TenantID),
+		Kind:            string(divergence.Kind),
+		LocalState:      string(divergence.LocalState),
+		UpstreamStatus:  string(divergence.UpstreamStatus),
+		FirstDetectedAt: timeToPgtype(divergence.FirstDetectedAt),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	stored := *divergence
+	stored.FirstDetectedAt = pgtypeToTime(row.FirstDetectedAt)
+	stored.LastCheckedAt = pgtypeToTime(row.LastCheckedAt)
+	stored.CorrectedAt = pgtypeToTimePtr(row.CorrectedAt)
+	return &stored, nil
+}
+
+func (r *ReconciliationRepositoryImpl) DeleteByConversationIDs(ctx context.Context, conversationIDs []uuid.UUID) error {
+	if len(conversationIDs) == 0 {
+		return nil
+	}
+	ids := make([]pgtype.UUID, len(conversationIDs))
+	for i, id := range conversationIDs {
+		ids[i] = uuidToPgtype(id)
+	}
+	return mapError(r.q.DeleteReconciliationDivergences(ctx, ids))
+}
+
+func (r *ReconciliationRepositoryImpl) MarkCorrected(ctx context.Context, conversationID uuid.UUID, correctedAt time.Time) error {
+	return mapError(r.q.MarkReconciliationDivergenceCorrected(ctx, MarkReconciliationDivergenceCorrectedParams{
+		ConversationID: uuidToPgtype(conversationID),
+		CorrectedAt:    timeToPgtype(correctedAt),
+	}))
+}
+
+func (r *ReconciliationRepositoryImpl) List(ctx context.Context, tenantID uuid.UUID, kind domain.DivergenceKind, limit int) ([]*domain.ReconciliationDivergence, error) {
+	rows, err := r.q.ListReconciliationDivergences(ctx, ListReconciliationDivergencesParams{
+		TenantID: uuidToPgtype(tenantID),
+		Column2:  string(kind),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	divergences := make([]*domain.ReconciliationDivergence, len(rows))
+	for i, row := range rows {
+		divergences[i] = &domain.ReconciliationDivergence{
+			ConversationID:         pgtypeToUUID(row.ConversationID),
+			TenantID:               pgtypeToUUID(row.TenantID),
+			ExternalConversationID: row.ExternalConversationID,
+			InboxID:                pgtypeToUUID(row.InboxID),
+			Kind:                   domain.DivergenceKind(row.Kind),
+			LocalState:             domain.ConversationState(row.LocalState),
+			UpstreamStatus:         domain.UpstreamStatus(row.UpstreamStatus),
+			FirstDetectedAt:        pgtypeToTime(row.FirstDetectedAt),
+			LastCheckedAt:          pgtypeToTime(row.LastCheckedAt),
+			CorrectedAt:            pgtypeToTimePtr(row.CorrectedAt),
+		}
+	}
+	return divergences, nil
+}
+
+func (r *ReconciliationRepositoryImpl) Count(ctx context.Context, tenantID uuid.UUID) (map[domain.DivergenceKind]int, error) {
+	rows, err := r.q.CountReconciliationDivergences(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	counts := make(map[domain.DivergenceKind]int, len(rows))
+	for _, row := range rows {
+		counts[domain.DivergenceKind(row.Kind)] = int(row.Divergences)
+	}
+	return counts, nil
+}
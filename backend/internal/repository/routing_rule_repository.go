@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type RoutingRuleRepositoryImpl struct {
+	q *Queries
+}
+
+func NewRoutingRuleRepository(q *Queries) *RoutingRuleRepositoryImpl {
+	return &RoutingRuleRepositoryImpl{q: q}
+}
+
+func (r *RoutingRuleRepositoryImpl) Create(ctx context.Context, rule *domain.RoutingRule) error {
+	return r.q.CreateRoutingRule(ctx, CreateRoutingRuleParams{
+		ID:                uuidToPgtype(rule.ID),
+		TenantID:          uuidToPgtype(rule.TenantID),
+		InboxID:           uuidPtrToPgtype(rule.InboxID),
+		Name:              rule.Name,
+		Trigger:           routingRuleTriggerToPgtype(rule.Trigger),
+		ConditionField:    string(rule.ConditionField),
+		ConditionOperator: string(rule.ConditionOperator),
+		ConditionValue:    rule.ConditionValue,
+		ConditionText:     stringPtrToPgtype(rule.ConditionText),
+		LabelName:         stringPtrToPgtype(rule.LabelName),
+		PriorityBoost:     decimalToPgtype(rule.PriorityBoost),
+		IsActive:          rule.IsActive,
+		CreatedBy:         uuidPtrToPgtype(rule.CreatedBy),
+		CreatedAt:         timeToPgtype(rule.CreatedAt),
+		UpdatedAt:         timeToPgtype(rule.UpdatedAt),
+	})
+}
+
+func (r *RoutingRuleRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.RoutingRule, error) {
+	row, err := r.q.GetRoutingRuleByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *RoutingRuleRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.RoutingRule, error) {
+	rows, err := r.q.GetRoutingRulesByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *RoutingRuleRepositoryImpl) GetActiveForTrigger(ctx context.Context, tenantID, inboxID uuid.UUID, trigger domain.RoutingRuleTrigger) ([]*domain.RoutingRule, error) {
+	rows, err := r.q.GetActiveRoutingRulesForTrigger(ctx, GetActiveRoutingRulesForTriggerParams{
+		TenantID: uuidToPgtype(tenantID),
+		Trigger:  routingRuleTriggerToPgtype(trigger),
+		InboxID:  uuidToPgtype(inboxID),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomainSlice(rows), nil
+}
+
+func (r *RoutingRuleRepositoryImpl) Update(ctx context.Context, rule *domain.RoutingRule) error {
+	return r.q.UpdateRoutingRule(ctx, UpdateRoutingRuleParams{
+		ID:        uuidToPgtype(rule.ID),
+		IsActive:  rule.IsActive,
+		UpdatedAt: timeToPgtype(rule.UpdatedAt),
+	})
+}
+
+func (r *RoutingRuleRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteRoutingRule(ctx, uuidToPgtype(id))
+}
+
+func (r *RoutingRuleRepositoryImpl) toDomain(row RoutingRule) *domain.RoutingRule {
+	return &domain.RoutingRule{
+		ID:                pgtypeToUUID(row.ID),
+		TenantID:          pgtypeToUUID(row.TenantID),
+		InboxID:           pgtypeToUUIDPtr(row.InboxID),
+		Name:              row.Name,
+		Trigger:           pgtypeToRoutingRuleTrigger(row.Trigger),
+		ConditionField:    domain.RuleConditionField(row.ConditionField),
+		ConditionOperator: domain.RuleOperator(row.ConditionOperator),
+		ConditionValue:    row.ConditionValue,
+		ConditionText:     pgtypeToStringPtr(row.ConditionText),
+		LabelName:         pgtypeToStringPtr(row.LabelName),
+		PriorityBoost:     pgtypeToDecimal(row.PriorityBoost),
+		IsActive:          row.IsActive,
+		CreatedBy:         pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt:         pgtypeToTime(row.CreatedAt),
+		UpdatedAt:         pgtypeToTime(row.UpdatedAt),
+	}
+}
+
+func (r *RoutingRuleRepositoryImpl) toDomainSlice(rows []RoutingRule) []*domain.RoutingRule {
+	rules := make([]*domain.RoutingRule, len(rows))
+	for i, row := range rows {
+		rules[i] = r.toDomain(row)
+	}
+	return rules
+}
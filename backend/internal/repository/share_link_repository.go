@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type ShareLinkRepositoryImpl struct {
+	q *Queries
+}
+
+func NewShareLinkRepository(q *Queries) *ShareLinkRepositoryImpl {
+	return &ShareLinkRepositoryImpl{q: q}
+}
+
+func (r *ShareLinkRepositoryImpl) Create(ctx context.Context, link *domain.ShareLink) error {
+	err := r.q.CreateConversationShareLink(ctx, CreateConversationShareLinkParams{
+		ID:             uuidToPgtype(link.ID),
+		TenantID:       uuidToPgtype(link.TenantID),
+		ConversationID: uuidToPgtype(link.ConversationID),
+		IncludePii:     link.IncludePII,
+		CreatedBy:      uuidPtrToPgtype(link.CreatedBy),
+		ExpiresAt:      timeToPgtype(link.ExpiresAt),
+		CreatedAt:      timeToPgtype(link.CreatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *ShareLinkRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.ShareLink, error) {
+	row, err := r.q.GetConversationShareLinkByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *ShareLinkRepositoryImpl) GetByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*domain.ShareLink, error) {
+	rows, err := r.q.GetConversationShareLinksByConversation(ctx, uuidToPgtype(conversationID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	links := make([]*domain.ShareLink, len(rows))
+	for i, row := range rows {
+		links[i] = r.toDomain(row)
+	}
+	return links, nil
+}
+
+func (r *ShareLinkRepositoryImpl) Revoke(ctx context.Context, link *domain.ShareLink) error {
+	return mapError(r.q.RevokeConversationShareLink(ctx, RevokeConversationShareLinkParams{
+		ID:        uuidToPgtype(link.ID),
+		RevokedAt: timePtrToPgtype(link.RevokedAt),
+	}))
+}
+
+func (r *ShareLinkRepositoryImpl) RecordAccess(ctx context.Context, id uuid.UUID, accessedAt time.Time) error {
+	return mapError(r.q.RecordConversationShareLinkAccess(ctx, RecordConversationShareLinkAccessParams{
+		ID:             uuidToPgtype(id),
+		LastAccessedAt: timeToPgtype(accessedAt),
+	}))
+}
+
+func (r *ShareLinkRepositoryImpl) toDomain(row ConversationShareLink) *domain.ShareLink {
+	return &domain.ShareLink{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		ConversationID: pgtypeToUUID(row.ConversationID),
+		IncludePII:     row.IncludePii,
+		CreatedBy:      pgtypeToUUIDPtr(row.CreatedBy),
+		ExpiresAt:      pgtypeToTime(row.ExpiresAt),
+		RevokedAt:      pgtypeToTimePtr(row.RevokedAt),
+		AccessCount:    row.AccessCount,
+		LastAccessedAt: pgtypeToTimePtr(row.LastAccessedAt),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+	}
+}
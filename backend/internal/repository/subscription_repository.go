@@ -8,7 +8,8 @@ import (
 )
 
 type SubscriptionRepositoryImpl struct {
-	q *Queries
+	q     *Queries
+	cache *readCache
 }
 
 func NewSubscriptionRepository(q *Queries) *SubscriptionRepositoryImpl {
@@ -16,12 +17,32 @@ func NewSubscriptionRepository(q *Queries) *SubscriptionRepositoryImpl {
 }
 
 func (r *SubscriptionRepositoryImpl) Create(ctx context.Context, sub *domain.OperatorInboxSubscription) error {
-	return r.q.CreateSubscription(ctx, CreateSubscriptionParams{
+	err := r.q.CreateSubscription(ctx, CreateSubscriptionParams{
 		ID:         uuidToPgtype(sub.ID),
 		OperatorID: uuidToPgtype(sub.OperatorID),
 		InboxID:    uuidToPgtype(sub.InboxID),
 		CreatedAt:  timeToPgtype(sub.CreatedAt),
 	})
+	r.cache.invalidate(ctx, subscribedInboxesCacheKey(sub.OperatorID))
+	return mapError(err)
+}
+
+// CreateIfNotExists inserts the subscription unless the operator is already
+// subscribed to the inbox; false if they were
+func (r *SubscriptionRepositoryImpl) CreateIfNotExists(ctx context.Context, sub *domain.OperatorInboxSubscription) (bool, error) {
+	rows, err := r.q.CreateSubscriptionIfNotExists(ctx, CreateSubscriptionIfNotExistsParams{
+		ID:         uuidToPgtype(sub.ID),
+		OperatorID: uuidToPgtype(sub.OperatorID),
+		InboxID:    uuidToPgtype(sub.InboxID),
+		CreatedAt:  timeToPgtype(sub.CreatedAt),
+	})
+	if err != nil {
+		return false, mapError(err)
+	}
+	if rows > 0 {
+		r.cache.invalidate(ctx, subscribedInboxesCacheKey(sub.OperatorID))
+	}
+	return rows > 0, nil
 }
 
 func (r *SubscriptionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.OperatorInboxSubscription, error) {
@@ -70,17 +91,31 @@ func (r *SubscriptionRepositoryImpl) GetByOperatorAndInbox(ctx context.Context,
 }
 
 func (r *SubscriptionRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// The cached inbox list is keyed by operator
+	if r.cache != nil {
+		if sub, err := r.GetByID(ctx, id); err == nil {
+			defer r.cache.invalidate(ctx, subscribedInboxesCacheKey(sub.OperatorID))
+		}
+	}
 	return r.q.DeleteSubscription(ctx, uuidToPgtype(id))
 }
 
 func (r *SubscriptionRepositoryImpl) DeleteByOperatorAndInbox(ctx context.Context, operatorID, inboxID uuid.UUID) error {
-	return r.q.DeleteSubscriptionByOperatorAndInbox(ctx, DeleteSubscriptionByOperatorAndInboxParams{
+	err := r.q.DeleteSubscriptionByOperatorAndInbox(ctx, DeleteSubscriptionByOperatorAndInboxParams{
 		OperatorID: uuidToPgtype(operatorID),
 		InboxID:    uuidToPgtype(inboxID),
 	})
+	r.cache.invalidate(ctx, subscribedInboxesCacheKey(operatorID))
+	return err
 }
 
 func (r *SubscriptionRepositoryImpl) GetSubscribedInboxIDs(ctx context.Context, operatorID uuid.UUID) ([]uuid.UUID, error) {
+	key := subscribedInboxesCacheKey(operatorID)
+	var cached []uuid.UUID
+	if r.cache.get(ctx, key, &cached) {
+		return cached, nil
+	}
+
 	rows, err := r.q.GetSubscribedInboxIDs(ctx, uuidToPgtype(operatorID))
 	if err != nil {
 		return nil, mapError(err)
@@ -90,6 +125,7 @@ func (r *SubscriptionRepositoryImpl) GetSubscribedInboxIDs(ctx context.Context,
 	for i, row := range rows {
 		ids[i] = pgtypeToUUID(row)
 	}
+	r.cache.set(ctx, key, ids)
 	return ids, nil
 }
 
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type TenantAnomalySettingsRepositoryImpl struct {
+	q *Queries
+}
+
+func NewTenantAnomalySettingsRepository(q *Queries) *TenantAnomalySettingsRepositoryImpl {
+	return &TenantAnomalySettingsRepositoryImpl{q: q}
+}
+
+func (r *TenantAnomalySettingsRepositoryImpl) Get(ctx context.Context, tenantID uuid.UUID) (*domain.TenantAnomalySettings, error) {
+	row, err := r.q.GetTenantAnomalySettings(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *TenantAnomalySettingsRepositoryImpl) Upsert(ctx context.Context, settings *domain.TenantAnomalySettings) error {
+	err := r.q.UpsertTenantAnomalySettings(ctx, UpsertTenantAnomalySettingsParams{
+		TenantID:    uuidToPgtype(settings.TenantID),
+		Sensitivity: string(settings.Sensitivity),
+		UpdatedBy:   uuidPtrToPgtype(settings.UpdatedBy),
+		CreatedAt:   timeToPgtype(settings.CreatedAt),
+		UpdatedAt:   timeToPgtype(settings.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *TenantAnomalySettingsRepositoryImpl) ListSensitivities(ctx context.Context) (map[uuid.UUID]domain.AnomalySensitivity, error) {
+	rows, err := r.q.ListTenantAnomalySensitivities(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	sensitivities := make(map[uuid.UUID]domain.AnomalySensitivity, len(rows))
+	for _, row := range rows {
+		sensitivity := domain.DefaultAnomalySensitivity
+		if row.Sensitivity.Valid {
+			sensitivity = domain.AnomalySensitivity(row.Sensitivity.String)
+		}
+		sensitivities[pgtypeToUUID(row.TenantID)] = sensitivity
+	}
+	return sensitivities, nil
+}
+
+func (r *TenantAnomalySettingsRepositoryImpl) toDomain(row TenantAnomalySetting) *domain.TenantAnomalySettings {
+	return &domain.TenantAnomalySettings{
+		TenantID:    pgtypeToUUID(row.TenantID),
+		Sensitivity: domain.AnomalySensitivity(row.Sensitivity),
+		UpdatedBy:   pgtypeToUUIDPtr(row.UpdatedBy),
+		CreatedAt:   pgtypeToTime(row.CreatedAt),
+		UpdatedAt:   pgtypeToTime(row.UpdatedAt),
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type TenantClassifierRepositoryImpl struct {
+	q *Queries
+}
+
+func NewTenantClassifierRepository(q *Queries) *TenantClassifierRepositoryImpl {
+	return &TenantClassifierRepositoryImpl{q: q}
+}
+
+func (r *TenantClassifierRepositoryImpl) Get(ctx context.Context, tenantID uuid.UUID) (*domain.TenantClassifier, error) {
+	row, err := r.q.GetTenantClassifier(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *TenantClassifierRepositoryImpl) Upsert(ctx context.Context, c *domain.TenantClassifier) error {
+	err := r.q.UpsertTenantClassifier(ctx, UpsertTenantClassifierParams{
+		TenantID:    uuidToPgtype(c.TenantID),
+		EndpointUrl: c.EndpointURL,
+		IsEnabled:   c.IsEnabled,
+		UpdatedBy:   uuidPtrToPgtype(c.UpdatedBy),
+		CreatedAt:   timeToPgtype(c.CreatedAt),
+		UpdatedAt:   timeToPgtype(c.UpdatedAt),
+	})
+	return mapError(err)
+}
+
+func (r *TenantClassifierRepositoryImpl) toDomain(row TenantClassifier) *domain.TenantClassifier {
+	return &domain.TenantClassifier{
+		TenantID:    pgtypeToUUID(row.TenantID),
+		EndpointURL: row.EndpointUrl,
+		IsEnabled:   row.IsEnabled,
+		UpdatedBy:   pgtypeToUUIDPtr(row.UpdatedBy),
+		CreatedAt:   pgtypeToTime(row.CreatedAt),
+		UpdatedAt:   pgtypeToTime(row.UpdatedAt),
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type TenantMaintenanceSettingsRepositoryImpl struct {
+	q *Queries
+}
+
+func NewTenantMaintenanceSettingsRepository(q *Queries) *TenantMaintenanceSettingsRepositoryImpl {
+	return &TenantMaintenanceSettingsRepositoryImpl{q: q}
+}
+
+func (r *TenantMaintenanceSettingsRepositoryImpl) Get(ctx context.Context, tenantID uuid.UUID) (*domain.TenantMaintenanceSettings, error) {
+	row, err := r.q.GetTenantMaintenanceSettings(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *TenantMaintenanceSettingsRepositoryImpl) Upsert(ctx context.Context, settings *domain.TenantMaintenanceSettings) error {
+	params := UpsertTenantMaintenanceSettingsParams{
+		TenantID:  uuidToPgtype(settings.TenantID),
+		UpdatedBy: uuidPtrToPgtype(settings.UpdatedBy),
+		CreatedAt: timeToPgtype(settings.CreatedAt),
+		UpdatedAt: timeToPgtype(settings.UpdatedAt),
+	}
+	if w := settings.Window; w != nil {
+		params.WindowStart = timeOfDayToPgtype(w.Start)
+		params.WindowEnd = timeOfDayToPgtype(w.End)
+		params.Timezone = pgtype.Text{String: w.Timezone, Valid: true}
+	}
+	if o := settings.Override; o != nil {
+		params.OverrideMode = pgtype.Text{String: string(o.Mode), Valid: true}
+		params.OverrideUntil = timeToPgtype(o.Until)
+		params.OverrideReason = pgtype.Text{String: o.Reason, Valid: o.Reason != ""}
+	}
+	return mapError(r.q.UpsertTenantMaintenanceSettings(ctx, params))
+}
+
+func (r *TenantMaintenanceSettingsRepositoryImpl) toDomain(row TenantMaintenanceSetting) *domain.TenantMaintenanceSettings {
+	settings := &domain.TenantMaintenanceSettings{
+		TenantID:  pgtypeToUUID(row.TenantID),
+		UpdatedBy: pgtypeToUUIDPtr(row.UpdatedBy),
+		CreatedAt: pgtypeToTime(row.CreatedAt),
+		UpdatedAt: pgtypeToTime(row.UpdatedAt),
+	}
+	if row.WindowStart.Valid && row.WindowEnd.Valid {
+		settings.Window = &domain.MaintenanceWindow{
+			Start:    pgtypeToTimeOfDay(row.WindowStart),
+			End:      pgtypeToTimeOfDay(row.WindowEnd),
+			Timezone: row.Timezone.String,
+		}
+	}
+	if row.OverrideMode.Valid {
+		settings.Override = &domain.MaintenanceOverride{
+			Mode:   domain.MaintenanceOverrideMode(row.OverrideMode.String),
+			Until:  pgtypeToTime(row.OverrideUntil),
+			Reason: row.OverrideReason.String,
+		}
+	}
+	return settings
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type TenantRepositoryImpl struct {
-	q *Queries
+	q     *Queries
+	cache *readCache
 }
 
 func NewTenantRepository(q *Queries) *TenantRepositoryImpl {
@@ -28,11 +29,19 @@ func (r *TenantRepositoryImpl) Create(ctx context.Context, t *domain.Tenant) err
 }
 
 func (r *TenantRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tenant, error) {
+	key := tenantCacheKey(id)
+	var cached domain.Tenant
+	if r.cache.get(ctx, key, &cached) {
+		return &cached, nil
+	}
+
 	row, err := r.q.GetTenantByID(ctx, uuidToPgtype(id))
 	if err != nil {
 		return nil, mapError(err)
 	}
-	return r.toDomain(row), nil
+	tenant := r.toDomain(row)
+	r.cache.set(ctx, key, tenant)
+	return tenant, nil
 }
 
 func (r *TenantRepositoryImpl) GetByName(ctx context.Context, name string) (*domain.Tenant, error) {
@@ -44,18 +53,40 @@ func (r *TenantRepositoryImpl) GetByName(ctx context.Context, name string) (*dom
 }
 
 func (r *TenantRepositoryImpl) Update(ctx context.Context, t *domain.Tenant) error {
-	return r.q.UpdateTenant(ctx, UpdateTenantParams{
+	err := r.q.UpdateTenant(ctx, UpdateTenantParams{
 		ID:                  uuidToPgtype(t.ID),
 		Name:                t.Name,
 		PriorityWeightAlpha: decimalToPgtype(t.PriorityWeightAlpha),
 		PriorityWeightBeta:  decimalToPgtype(t.PriorityWeightBeta),
 		UpdatedAt:           timeToPgtype(t.UpdatedAt),
 		UpdatedBy:           uuidPtrToPgtype(t.UpdatedBy),
+		FirstContactBoost:   decimalToPgtype(t.FirstContactBoost),
+		GracePeriodSeconds:  durationPtrToSeconds(t.GracePeriod),
+		IntakeLimitPerHour:  intPtrToPgtype(t.IntakeLimitPerHour),
+		AllocationEngine:    string(t.AllocationEngine),
+		Sandbox:             t.Sandbox,
 	})
+	r.cache.invalidate(ctx, tenantCacheKey(t.ID))
+	return err
 }
 
 func (r *TenantRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.q.DeleteTenant(ctx, uuidToPgtype(id))
+	err := r.q.DeleteTenant(ctx, uuidToPgtype(id))
+	r.cache.invalidate(ctx, tenantCacheKey(id))
+	return err
+}
+
+// List returns every tenant, newest first
+func (r *TenantRepositoryImpl) List(ctx context.Context) ([]*domain.Tenant, error) {
+	rows, err := r.q.ListTenants(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	tenants := make([]*domain.Tenant, len(rows))
+	for i, row := range rows {
+		tenants[i] = r.toDomain(row)
+	}
+	return tenants, nil
 }
 
 func (r *TenantRepositoryImpl) toDomain(row Tenant) *domain.Tenant {
@@ -67,5 +98,10 @@ func (r *TenantRepositoryImpl) toDomain(row Tenant) *domain.Tenant {
 		CreatedAt:           pgtypeToTime(row.CreatedAt),
 		UpdatedAt:           pgtypeToTime(row.UpdatedAt),
 		UpdatedBy:           pgtypeToUUIDPtr(row.UpdatedBy),
+		FirstContactBoost:   pgtypeToDecimal(row.FirstContactBoost),
+		GracePeriod:         secondsToDurationPtr(row.GracePeriodSeconds),
+		IntakeLimitPerHour:  pgtypeToIntPtr(row.IntakeLimitPerHour),
+		AllocationEngine:    domain.AllocationEngine(row.AllocationEngine),
+		Sandbox:             row.Sandbox,
 	}
 }
@@ -49,7 +49,7 @@ func (q *Queries) DeleteTenant(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getTenantByID = `-- name: GetTenantByID :one
-SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by FROM tenants WHERE id = $1
+SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by, first_contact_boost, grace_period_seconds, intake_limit_per_hour, allocation_engine, sandbox FROM tenants WHERE id = $1
 `
 
 func (q *Queries) GetTenantByID(ctx context.Context, id pgtype.UUID) (Tenant, error) {
@@ -63,12 +63,17 @@ func (q *Queries) GetTenantByID(ctx context.Context, id pgtype.UUID) (Tenant, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.FirstContactBoost,
+		&i.GracePeriodSeconds,
+		&i.IntakeLimitPerHour,
+		&i.AllocationEngine,
+		&i.Sandbox,
 	)
 	return i, err
 }
 
 const getTenantByName = `-- name: GetTenantByName :one
-SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by FROM tenants WHERE name = $1
+SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by, first_contact_boost, grace_period_seconds, intake_limit_per_hour, allocation_engine, sandbox FROM tenants WHERE name = $1
 `
 
 func (q *Queries) GetTenantByName(ctx context.Context, name string) (Tenant, error) {
@@ -82,12 +87,17 @@ func (q *Queries) GetTenantByName(ctx context.Context, name string) (Tenant, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.UpdatedBy,
+		&i.FirstContactBoost,
+		&i.GracePeriodSeconds,
+		&i.IntakeLimitPerHour,
+		&i.AllocationEngine,
+		&i.Sandbox,
 	)
 	return i, err
 }
 
 const listTenants = `-- name: ListTenants :many
-SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by FROM tenants ORDER BY created_at DESC
+SELECT id, name, priority_weight_alpha, priority_weight_beta, created_at, updated_at, updated_by, first_contact_boost, grace_period_seconds, intake_limit_per_hour, allocation_engine, sandbox FROM tenants ORDER BY created_at DESC
 `
 
 func (q *Queries) ListTenants(ctx context.Context) ([]Tenant, error) {
@@ -107,6 +117,11 @@ func (q *Queries) ListTenants(ctx context.Context) ([]Tenant, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.UpdatedBy,
+			&i.FirstContactBoost,
+			&i.GracePeriodSeconds,
+			&i.IntakeLimitPerHour,
+			&i.AllocationEngine,
+			&i.Sandbox,
 		); err != nil {
 			return nil, err
 		}
@@ -124,7 +139,12 @@ SET name = $2,
     priority_weight_alpha = $3,
     priority_weight_beta = $4,
     updated_at = $5,
-    updated_by = $6
+    updated_by = $6,
+    first_contact_boost = $7,
+    grace_period_seconds = $8,
+    intake_limit_per_hour = $9,
+    allocation_engine = $10,
+    sandbox = $11
 WHERE id = $1
 `
 
@@ -135,6 +155,11 @@ type UpdateTenantParams struct {
 	PriorityWeightBeta  pgtype.Numeric     `json:"priority_weight_beta"`
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 	UpdatedBy           pgtype.UUID        `json:"updated_by"`
+	FirstContactBoost   pgtype.Numeric     `json:"first_contact_boost"`
+	GracePeriodSeconds  pgtype.Int4        `json:"grace_period_seconds"`
+	IntakeLimitPerHour  pgtype.Int4        `json:"intake_limit_per_hour"`
+	AllocationEngine    string             `json:"allocation_engine"`
+	Sandbox             bool               `json:"sandbox"`
 }
 
 func (q *Queries) UpdateTenant(ctx context.Context, arg UpdateTenantParams) error {
@@ -145,6 +170,11 @@ func (q *Queries) UpdateTenant(ctx context.Context, arg UpdateTenantParams) erro
 		arg.PriorityWeightBeta,
 		arg.UpdatedAt,
 		arg.UpdatedBy,
+		arg.FirstContactBoost,
+		arg.GracePeriodSeconds,
+		arg.IntakeLimitPerHour,
+		arg.AllocationEngine,
+		arg.Sandbox,
 	)
 	return err
 }
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type WebhookRepositoryImpl struct {
+	q *Queries
+}
+
+func NewWebhookRepository(q *Queries) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{q: q}
+}
+
+func (r *WebhookRepositoryImpl) Create(ctx context.Context, webhook *domain.Webhook) error {
+	return r.q.CreateWebhook(ctx, CreateWebhookParams{
+		ID:             uuidToPgtype(webhook.ID),
+		TenantID:       uuidToPgtype(webhook.TenantID),
+		Url:            webhook.URL,
+		Secret:         webhook.Secret,
+		EventTypes:     eventTypesToStrings(webhook.EventTypes),
+		OperatorFields: webhookOperatorFieldsToStrings(webhook.OperatorFields),
+		IsActive:       webhook.IsActive,
+		CreatedBy:      uuidPtrToPgtype(webhook.CreatedBy),
+		CreatedAt:      timeToPgtype(webhook.CreatedAt),
+		UpdatedAt:      timeToPgtype(webhook.UpdatedAt),
+	})
+}
+
+func (r *WebhookRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	row, err := r.q.GetWebhookByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *WebhookRepositoryImpl) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.Webhook, error) {
+	rows, err := r.q.GetWebhooksByTenantID(ctx, uuidToPgtype(tenantID))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	webhooks := make([]*domain.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = r.toDomain(row)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) GetActiveForEvent(ctx context.Context, tenantID uuid.UUID, eventType domain.EventType) ([]*domain.Webhook, error) {
+	rows, err := r.q.GetActiveWebhooksForEvent(ctx, GetActiveWebhooksForEventParams{
+		TenantID: uuidToPgtype(tenantID),
+		Column2:  string(eventType),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	webhooks := make([]*domain.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = r.toDomain(row)
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepositoryImpl) Update(ctx context.Context, webhook *domain.Webhook) error {
+	return r.q.UpdateWebhook(ctx, UpdateWebhookParams{
+		ID:             uuidToPgtype(webhook.ID),
+		Url:            webhook.URL,
+		Secret:         webhook.Secret,
+		EventTypes:     eventTypesToStrings(webhook.EventTypes),
+		OperatorFields: webhookOperatorFieldsToStrings(webhook.OperatorFields),
+		IsActive:       webhook.IsActive,
+		UpdatedAt:      timeToPgtype(webhook.UpdatedAt),
+	})
+}
+
+func (r *WebhookRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteWebhook(ctx, uuidToPgtype(id))
+}
+
+func (r *WebhookRepositoryImpl) toDomain(row Webhook) *domain.Webhook {
+	return &domain.Webhook{
+		ID:             pgtypeToUUID(row.ID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		URL:            row.Url,
+		Secret:         row.Secret,
+		EventTypes:     stringsToEventTypes(row.EventTypes),
+		OperatorFields: stringsToWebhookOperatorFields(row.OperatorFields),
+		IsActive:       row.IsActive,
+		CreatedBy:      pgtypeToUUIDPtr(row.CreatedBy),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+		UpdatedAt:      pgtypeToTime(row.UpdatedAt),
+	}
+}
+
+// ==================== Deliveries ====================
+
+type WebhookDeliveryRepositoryImpl struct {
+	q *Queries
+}
+
+func NewWebhookDeliveryRepository(q *Queries) *WebhookDeliveryRepositoryImpl {
+	return &WebhookDeliveryRepositoryImpl{q: q}
+}
+
+func (r *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	return r.q.CreateWebhookDelivery(ctx, CreateWebhookDeliveryParams{
+		ID:            uuidToPgtype(d.ID),
+		WebhookID:     uuidToPgtype(d.WebhookID),
+		TenantID:      uuidToPgtype(d.TenantID),
+		EventID:       uuidToPgtype(d.EventID),
+		EventType:     string(d.EventType),
+		Payload:       d.Payload,
+		Status:        webhookDeliveryStatusToPgtype(d.Status),
+		AttemptCount:  int32(d.AttemptCount),
+		NextAttemptAt: timeToPgtype(d.NextAttemptAt),
+		CreatedAt:     timeToPgtype(d.CreatedAt),
+	})
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	row, err := r.q.GetWebhookDeliveryByID(ctx, uuidToPgtype(id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return r.toDomain(row), nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) GetByWebhookID(ctx context.Context, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.q.GetWebhookDeliveriesByWebhookID(ctx, GetWebhookDeliveriesByWebhookIDParams{
+		WebhookID: uuidToPgtype(webhookID),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = r.toDomain(row)
+	}
+	return deliveries, nil
+}
+
+// ClaimDue leases up to limit due deliveries until leaseUntil (FOR UPDATE SKIP LOCKED)
+func (r *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.q.ClaimDueWebhookDeliveries(ctx, ClaimDueWebhookDeliveriesParams{
+		Limit:         int32(limit),
+		NextAttemptAt: timeToPgtype(leaseUntil),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = r.toDomain(row)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepositoryImpl) UpdateAttempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	return r.q.UpdateWebhookDeliveryAttempt(ctx, UpdateWebhookDeliveryAttemptParams{
+		ID:             uuidToPgtype(d.ID),
+		Status:         webhookDeliveryStatusToPgtype(d.Status),
+		AttemptCount:   int32(d.AttemptCount),
+		NextAttemptAt:  timeToPgtype(d.NextAttemptAt),
+		LastAttemptAt:  timePtrToPgtype(d.LastAttemptAt),
+		LastStatusCode: intPtrToPgtype(d.LastStatusCode),
+		LastError:      stringPtrToPgtype(d.LastError),
+		DeliveredAt:    timePtrToPgtype(d.DeliveredAt),
+	})
+}
+
+func (r *WebhookDeliveryRepositoryImpl) toDomain(row WebhookDelivery) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             pgtypeToUUID(row.ID),
+		WebhookID:      pgtypeToUUID(row.WebhookID),
+		TenantID:       pgtypeToUUID(row.TenantID),
+		EventID:        pgtypeToUUID(row.EventID),
+		EventType:      domain.EventType(row.EventType),
+		Payload:        row.Payload,
+		Status:         pgtypeToWebhookDeliveryStatus(row.Status),
+		AttemptCount:   int(row.AttemptCount),
+		NextAttemptAt:  pgtypeToTime(row.NextAttemptAt),
+		LastAttemptAt:  pgtypeToTimePtr(row.LastAttemptAt),
+		LastStatusCode: pgtypeToIntPtr(row.LastStatusCode),
+		LastError:      pgtypeToStringPtr(row.LastError),
+		DeliveredAt:    pgtypeToTimePtr(row.DeliveredAt),
+		CreatedAt:      pgtypeToTime(row.CreatedAt),
+	}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+type WorkerInstanceRepositoryImpl struct {
+	q *Queries
+}
+
+func NewWorkerInstanceRepository(q *Queries) *WorkerInstanceRepositoryImpl {
+	return &WorkerInstanceRepositoryImpl{q: q}
+}
+
+func (r *WorkerInstanceRepositoryImpl) GetSchemaVersion(ctx context.Context) (*domain.SchemaVersion, error) {
+	row, err := r.q.GetSchemaMigration(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return &domain.SchemaVersion{Version: row.Version, Dirty: row.Dirty}, nil
+}
+
+func (r *WorkerInstanceRepositoryImpl) Upsert(ctx context.Context, instance *domain.WorkerInstance) error {
+	err := r.q.UpsertWorkerInstance(ctx, UpsertWorkerInstanceParams{
+		InstanceID:       uuidToPgtype(instance.InstanceID),
+		BinaryVersion:    instance.BinaryVersion,
+		WorkerProtocol:   instance.WorkerProtocol,
+		MinSchemaVersion: instance.MinSchemaVersion,
+		MaxSchemaVersion: instance.MaxSchemaVersion,
+		WorkersEnabled:   instance.WorkersEnabled,
+		StartedAt:        timeToPgtype(instance.StartedAt),
+	})
+	return mapError(err)
+}
+
+func (r *WorkerInstanceRepositoryImpl) Delete(ctx context.Context, instanceID uuid.UUID) error {
+	return mapError(r.q.DeleteWorkerInstance(ctx, uuidToPgtype(instanceID)))
+}
+
+func (r *WorkerInstanceRepositoryImpl) DeleteStale(ctx context.Context, cutoff time.Time) (int64, error) {
+	n, err := r.q.DeleteStaleWorkerInstances(ctx, timeToPgtype(cutoff))
+	return n, mapError(err)
+}
+
+func (r *WorkerInstanceRepositoryImpl) NewestLiveProtocol(ctx context.Context, instanceID uuid.UUID, cutoff time.Time) (int32, error) {
+	protocol, err := r.q.GetNewestLiveWorkerProtocol(ctx, GetNewestLiveWorkerProtocolParams{
+		HeartbeatAt: timeToPgtype(cutoff),
+		InstanceID:  uuidToPgtype(instanceID),
+	})
+	return protocol, mapError(err)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"sync"
@@ -22,6 +23,8 @@ type Config struct {
 	WriteTimeout    time.Duration
 	IdleTimeout     time.Duration
 	ShutdownTimeout time.Duration
+	// TLS, when set, serves HTTPS with the certificates it holds
+	TLS *tls.Config
 }
 
 // DefaultConfig returns sensible server defaults
@@ -56,6 +59,7 @@ func New(handler http.Handler, log *logger.Logger, cfg Config) *Server {
 			ReadTimeout:  cfg.ReadTimeout,
 			WriteTimeout: cfg.WriteTimeout,
 			IdleTimeout:  cfg.IdleTimeout,
+			TLSConfig:    cfg.TLS,
 		},
 		log:    log.Named("server"),
 		config: cfg,
@@ -82,9 +86,16 @@ func (s *Server) Start() error {
 		zap.String("addr", s.httpServer.Addr),
 		zap.Duration("read_timeout", s.config.ReadTimeout),
 		zap.Duration("write_timeout", s.config.WriteTimeout),
+		zap.Bool("tls", s.config.TLS != nil),
 	)
 
-	if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	var err error
+	if s.config.TLS != nil {
+		err = s.httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("server error: %w", err)
 	}
 
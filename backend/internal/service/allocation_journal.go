@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAllocationInProgress = errors.New("an allocation with this idempotency key is in progress")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different allocation")
+)
+
+var (
+	allocationIntentsRecoveredCommitted = metrics.NewCounter("allocation_intents_recovered_committed_total")
+	allocationIntentsRecoveredAborted   = metrics.NewCounter("allocation_intents_recovered_aborted_total")
+	allocationIntentsReplayed           = metrics.NewCounter("allocation_intents_replayed_total")
+	allocationIntentsJournalErrors      = metrics.NewCounter("allocation_intents_journal_errors_total")
+)
+
+// AllocationJournalConfig holds configuration for the allocation journal
+type AllocationJournalConfig struct {
+	// StaleAfter is how long an intent may stay PENDING before recovery
+	// treats its request as dead
+	StaleAfter time.Duration
+	// Retention is how long resolved intents are kept for replays
+	Retention time.Duration
+	// RecoveryBatch bounds the intents reconciled per recovery pass
+	RecoveryBatch int
+}
+
+// DefaultAllocationJournalConfig returns sensible defaults
+func DefaultAllocationJournalConfig() AllocationJournalConfig {
+	return AllocationJournalConfig{
+		StaleAfter:    1 * time.Minute,
+		Retention:     24 * time.Hour,
+		RecoveryBatch: 100,
+	}
+}
+
+// RecoveryResult summarizes a recovery pass
+type RecoveryResult struct {
+	Committed int
+	Aborted   int
+}
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey attaches the request's idempotency key to ctx so the
+// allocation journal can record it
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the request's idempotency key, if any
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key, ok && key != ""
+}
+
+// AllocationJournal records an intent before each allocate or claim enters its
+// critical section and resolves it afterwards. Intents left PENDING by a crash
+// are reconciled against the conversation they targeted: if the assignment
+// landed, the missing audit entry and event are emitted; otherwise the intent
+// is aborted so a retry with the same idempotency key can run again.
+type AllocationJournal struct {
+	repos  *repository.RepositoryContainer
+	events domain.EventPublisher
+	audit  *AuditService
+	config AllocationJournalConfig
+	logger *logger.Logger
+}
+
+func NewAllocationJournal(repos *repository.RepositoryContainer, events domain.EventPublisher, audit *AuditService, config AllocationJournalConfig, log *logger.Logger) *AllocationJournal {
+	return &AllocationJournal{
+		repos:  repos,
+		events: events,
+		audit:  audit,
+		config: config,
+		logger: log,
+	}
+}
+
+// Replay returns the conversation assigned by an earlier attempt with the
+// request's idempotency key, or nil if the request should run. A stale
+// PENDING attempt is reconciled first.
+func (j *AllocationJournal) Replay(ctx context.Context, tenantID, operatorID uuid.UUID, kind domain.AllocationIntentKind, conversationID *uuid.UUID) (*domain.ConversationRef, error) {
+	key, ok := IdempotencyKeyFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return j.replay(ctx, tenantID, key, operatorID, kind, conversationID)
+}
+
+// ReplayBatch is Replay for an allocate of up to count conversations: the
+// conversations an earlier attempt with the request's key assigned, or nil
+// if the request should run
+func (j *AllocationJournal) ReplayBatch(ctx context.Context, tenantID, operatorID uuid.UUID, count int) ([]*domain.ConversationRef, error) {
+	key, ok := IdempotencyKeyFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	first, err := j.replay(ctx, tenantID, key, operatorID, domain.AllocationIntentAllocate, nil)
+	if first == nil || err != nil {
+		return nil, err
+	}
+	convs := []*domain.ConversationRef{first}
+	for slot := 2; slot <= count; slot++ {
+		conv, err := j.replay(ctx, tenantID, batchSlotKey(key, slot), operatorID, domain.AllocationIntentAllocate, nil)
+		if err != nil {
+			return nil, err
+		}
+		if conv != nil {
+			convs = append(convs, conv)
+		}
+	}
+	return convs, nil
+}
+
+func (j *AllocationJournal) replay(ctx context.Context, tenantID uuid.UUID, key string, operatorID uuid.UUID, kind domain.AllocationIntentKind, conversationID *uuid.UUID) (*domain.ConversationRef, error) {
+	intent, err := j.repos.AllocationIntents.GetByIdempotencyKey(ctx, tenantID, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !intent.Matches(operatorID, kind, conversationID) {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	if intent.IsPending() {
+		if !intent.IsStale(time.Now().UTC(), j.config.StaleAfter) {
+			return nil, ErrAllocationInProgress
+		}
+		if _, err := j.reconcile(ctx, intent); err != nil {
+			return nil, err
+		}
+	}
+
+	if intent.Status != domain.AllocationIntentCommitted || intent.ConversationID == nil {
+		return nil, nil
+	}
+
+	conv, err := j.repos.ConversationRefs.GetByID(ctx, *intent.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	allocationIntentsReplayed.Inc()
+	j.logger.Info("Replayed journaled allocation",
+		zap.String("intent_id", intent.ID.String()),
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("operator_id", operatorID.String()))
+	return conv, nil
+}
+
+// Begin writes a PENDING intent. A retry of an aborted attempt takes over its
+// intent; if another request holds the key, ErrAllocationInProgress.
+func (j *AllocationJournal) Begin(ctx context.Context, tenantID, operatorID uuid.UUID, kind domain.AllocationIntentKind, conversationID *uuid.UUID) (*domain.AllocationIntent, error) {
+	var keyPtr *string
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		keyPtr = &key
+	}
+	return j.begin(ctx, domain.NewAllocationIntent(tenantID, operatorID, kind, keyPtr, conversationID))
+}
+
+// BeginBatchSlot writes the PENDING intent of the slot-th conversation (from
+// 2) selected by a batch allocate whose first intent is first. It is written
+// once the conversation is selected, before the assignments commit.
+func (j *AllocationJournal) BeginBatchSlot(ctx context.Context, first *domain.AllocationIntent, slot int, conversationID uuid.UUID) (*domain.AllocationIntent, error) {
+	var keyPtr *string
+	if first.IdempotencyKey != nil {
+		key := batchSlotKey(*first.IdempotencyKey, slot)
+		keyPtr = &key
+	}
+	return j.begin(ctx, domain.NewAllocationIntent(first.TenantID, first.OperatorID, domain.AllocationIntentAllocate, keyPtr, &conversationID))
+}
+
+func (j *AllocationJournal) begin(ctx context.Context, intent *domain.AllocationIntent) (*domain.AllocationIntent, error) {
+	err := j.repos.AllocationIntents.Create(ctx, intent)
+	if errors.Is(err, domain.ErrAlreadyExists) && intent.IdempotencyKey != nil {
+		err = j.repos.AllocationIntents.Restart(ctx, intent)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrAllocationInProgress
+		}
+	}
+	if err != nil {
+		allocationIntentsJournalErrors.Inc()
+		return nil, err
+	}
+	return intent, nil
+}
+
+// batchSlotKey derives the idempotency key of the slot-th intent of a batch
+// allocate from the request's key
+func batchSlotKey(key string, slot int) string {
+	return fmt.Sprintf("%s#%d", key, slot)
+}
+
+// SetConversation records the conversation an allocate selected, before it
+// is assigned
+func (j *AllocationJournal) SetConversation(ctx context.Context, intent *domain.AllocationIntent, conversationID uuid.UUID) error {
+	if err := j.repos.AllocationIntents.SetConversation(ctx, intent.ID, conversationID); err != nil {
+		allocationIntentsJournalErrors.Inc()
+		return err
+	}
+	intent.ConversationID = &conversationID
+	return nil
+}
+
+// Commit marks the intent as applied. A failure is only logged: recovery
+// later finds the assignment and commits the intent.
+func (j *AllocationJournal) Commit(ctx context.Context, intent *domain.AllocationIntent) {
+	j.resolve(ctx, intent, domain.AllocationIntentCommitted)
+}
+
+// Abort marks a still PENDING intent as not applied. Meant to be deferred
+// right after Begin, like tx.Rollback.
+func (j *AllocationJournal) Abort(ctx context.Context, intent *domain.AllocationIntent) {
+	if intent == nil || !intent.IsPending() {
+		return
+	}
+	j.resolve(ctx, intent, domain.AllocationIntentAborted)
+}
+
+func (j *AllocationJournal) resolve(ctx context.Context, intent *domain.AllocationIntent, status domain.AllocationIntentStatus) {
+	intent.Resolve(status, false)
+	// The request may already be cancelled; the outcome must still be recorded
+	if _, err := j.repos.AllocationIntents.Resolve(context.WithoutCancel(ctx), intent); err != nil {
+		allocationIntentsJournalErrors.Inc()
+		j.logger.Warn("Failed to resolve allocation intent",
+			zap.String("intent_id", intent.ID.String()),
+			zap.String("status", string(status)),
+			zap.Error(err))
+	}
+}
+
+// Recover reconciles intents left PENDING longer than StaleAfter
+func (j *AllocationJournal) Recover(ctx context.Context) (RecoveryResult, error) {
+	var result RecoveryResult
+
+	before := time.Now().UTC().Add(-j.config.StaleAfter)
+	intents, err := j.repos.AllocationIntents.GetPendingBefore(ctx, before, j.config.RecoveryBatch)
+	if err != nil {
+		return result, err
+	}
+
+	for _, intent := range intents {
+		won, err := j.reconcile(ctx, intent)
+		if err != nil {
+			j.logger.Error("Failed to reconcile allocation intent",
+				zap.String("intent_id", intent.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		if !won {
+			continue
+		}
+		if intent.Status == domain.AllocationIntentCommitted {
+			result.Committed++
+		} else {
+			result.Aborted++
+		}
+	}
+	return result, nil
+}
+
+// PurgeResolved deletes resolved intents older than Retention
+func (j *AllocationJournal) PurgeResolved(ctx context.Context) (int64, error) {
+	return j.repos.AllocationIntents.DeleteResolvedBefore(ctx, time.Now().UTC().Add(-j.config.Retention))
+}
+
+// reconcile resolves a dangling intent from the state of its conversation.
+// Returns false if another recovery resolved it first; intent then holds
+// the outcome this call computed, not necessarily the stored one.
+func (j *AllocationJournal) reconcile(ctx context.Context, intent *domain.AllocationIntent) (bool, error) {
+	var conv *domain.ConversationRef
+	if intent.ConversationID != nil {
+		c, err := j.repos.ConversationRefs.GetByID(ctx, *intent.ConversationID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return false, err
+		}
+		conv = c
+	}
+
+	status := domain.AllocationIntentAborted
+	if conv != nil && intent.WasApplied(conv) {
+		status = domain.AllocationIntentCommitted
+	}
+	intent.Resolve(status, true)
+
+	won, err := j.repos.AllocationIntents.Resolve(ctx, intent)
+	if err != nil || !won {
+		return false, err
+	}
+
+	fields := []zap.Field{
+		zap.String("intent_id", intent.ID.String()),
+		zap.String("tenant_id", intent.TenantID.String()),
+		zap.String("operator_id", intent.OperatorID.String()),
+		zap.String("kind", string(intent.Kind)),
+	}
+	if status == domain.AllocationIntentAborted {
+		allocationIntentsRecoveredAborted.Inc()
+		j.logger.Info("Recovered allocation intent as aborted", fields...)
+		return true, nil
+	}
+
+	allocationIntentsRecoveredCommitted.Inc()
+	j.logger.Info("Recovered allocation intent as committed",
+		append(fields, zap.String("conversation_id", conv.ID.String()))...)
+
+	// The crashed request never emitted these
+	action := domain.AuditActionConversationAllocate
+	method := "auto"
+	if intent.Kind == domain.AllocationIntentClaim {
+		action = domain.AuditActionConversationClaim
+		method = "claim"
+	}
+	after := conversationAuditSnapshot(conv)
+	after["recovered"] = true
+	recordAudit(ctx, j.audit, j.logger, domain.NewAuditEntry(intent.TenantID, &intent.OperatorID,
+		action, domain.AuditEntityConversation, conv.ID, nil, after))
+
+	data := conversationEventData(conv)
+	data["method"] = method
+	data["recovered"] = true
+	publishEvent(ctx, j.events, j.logger, domain.NewEvent(intent.TenantID, domain.EventConversationAllocated, data))
+
+	return true, nil
+}
@@ -15,6 +15,9 @@ import (
 
 var (
 	ErrOperatorNotAvailable       = errors.New("operator is not available")
+	ErrOperatorInFocus            = errors.New("operator is in focus mode")
+	ErrOperatorOnVacation         = errors.New("operator is on vacation")
+	ErrOutsideSchedule            = errors.New("operator is outside their scheduled working hours")
 	ErrNoSubscriptions            = errors.New("operator has no inbox subscriptions")
 	ErrNoConversationsAvailable   = errors.New("no conversations available for allocation")
 	ErrConversationNotQueued      = errors.New("conversation is not in QUEUED state")
@@ -25,16 +28,30 @@ var (
 const MaxAllocationCandidates = 100
 
 type AllocationService struct {
-	repos  *repository.RepositoryContainer
-	pool   *pgxpool.Pool
-	logger *logger.Logger
+	repos   *repository.RepositoryContainer
+	pool    *pgxpool.Pool
+	events  domain.EventPublisher
+	audit   *AuditService
+	journal *AllocationJournal
+	quotas  *CategoryQuotaService
+	health  *OperatorHealthService
+	// affinityWindow is how long after resolving a customer's conversation
+	// an operator is offered the customer's next ones first; zero disables it
+	affinityWindow time.Duration
+	logger         *logger.Logger
 }
 
-func NewAllocationService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, log *logger.Logger) *AllocationService {
+func NewAllocationService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, journal *AllocationJournal, quotas *CategoryQuotaService, health *OperatorHealthService, affinityWindow time.Duration, log *logger.Logger) *AllocationService {
 	return &AllocationService{
-		repos:  repos,
-		pool:   pool,
-		logger: log,
+		repos:          repos,
+		pool:           pool,
+		events:         events,
+		audit:          audit,
+		journal:        journal,
+		quotas:         quotas,
+		health:         health,
+		affinityWindow: affinityWindow,
+		logger:         log,
 	}
 }
 
@@ -42,7 +59,45 @@ func NewAllocationService(repos *repository.RepositoryContainer, pool *pgxpool.P
 
 // Allocate automatically assigns the next highest-priority conversation to the operator
 // CRITICAL: Uses FOR UPDATE SKIP LOCKED to prevent race conditions
+// The attempt is journaled (see AllocationJournal); a retry with the same
+// idempotency key returns the conversation the first attempt assigned.
+// Conversations in a language the operator does not speak are skipped (see
+// domain.SpeaksLanguage).
 func (s *AllocationService) Allocate(ctx context.Context, tenantID, operatorID uuid.UUID) (*domain.ConversationRef, error) {
+	convs, err := s.AllocateBatch(ctx, tenantID, operatorID, 1)
+	if err != nil {
+		return nil, err
+	}
+	return convs[0], nil
+}
+
+// AllocateBatch assigns up to count of the highest-priority conversations to
+// the operator in a single transaction, in allocation order. Fewer are
+// returned if fewer are queued; none is ErrNoConversationsAvailable. The
+// operator checks are those of Allocate, made once for the batch, and each
+// conversation is journaled, audited and published as if allocated alone.
+// The queue is taken in the order of the tenant's allocation engine (see
+// AllocationStrategy), after the conversations of customers the operator
+// resolved within the affinity window.
+func (s *AllocationService) AllocateBatch(ctx context.Context, tenantID, operatorID uuid.UUID, count int) ([]*domain.ConversationRef, error) {
+	return s.AllocateFiltered(ctx, tenantID, operatorID, count, AllocationFilter{})
+}
+
+// AllocationFilter restricts which queued conversations an allocate may take
+type AllocationFilter struct {
+	// LabelIDs, when set, limits the allocate to conversations carrying at
+	// least one of the labels
+	LabelIDs []uuid.UUID
+}
+
+// AllocateFiltered is AllocateBatch taking only conversations matching the
+// filter, so operators can pull e.g. just the billing conversations. A label
+// also matches the labels nested below it. Every label must belong to the
+// tenant (ErrLabelNotFound otherwise). A filtered
+// allocate keeps the order of the tenant's engine but skips category quotas
+// and customer affinity, which would otherwise favor conversations the
+// filter excludes.
+func (s *AllocationService) AllocateFiltered(ctx context.Context, tenantID, operatorID uuid.UUID, count int, filter AllocationFilter) ([]*domain.ConversationRef, error) {
 	// Create method-scoped logger with context
 	log := logger.FromContext(ctx).
 		WithService("allocation").
@@ -50,11 +105,17 @@ func (s *AllocationService) Allocate(ctx context.Context, tenantID, operatorID u
 		WithFields(
 			zap.String("tenant_id", tenantID.String()),
 			zap.String("operator_id", operatorID.String()),
+			zap.Int("count", count),
+			zap.Int("label_filter", len(filter.LabelIDs)),
 		)
 
 	log.Debug("starting allocation")
 	start := time.Now()
 
+	if convs, err := s.journal.ReplayBatch(ctx, tenantID, operatorID, count); convs != nil || err != nil {
+		return convs, err
+	}
+
 	// 1. Validate operator status
 	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
 	if err != nil {
@@ -64,11 +125,43 @@ func (s *AllocationService) Allocate(ctx context.Context, tenantID, operatorID u
 
 	log.Debug("operator status validated", zap.String("status", string(status.Status)))
 
-	if status.Status != domain.OperatorStatusAvailable {
+	if !status.Status.AcceptsAllocations() {
 		log.Info("operator not available for allocation",
 			zap.String("status", string(status.Status)))
 		return nil, ErrOperatorNotAvailable
 	}
+	if status.InFocus(time.Now()) {
+		log.Info("operator in focus mode", zap.Timep("focus_until", status.FocusUntil))
+		return nil, ErrOperatorInFocus
+	}
+	onVacation, err := isOnVacation(ctx, s.repos, operatorID)
+	if err != nil {
+		log.Error("failed to get operator vacation", zap.Error(err))
+		return nil, err
+	}
+	if onVacation {
+		log.Info("operator on vacation")
+		return nil, ErrOperatorOnVacation
+	}
+	inSchedule, err := s.inSchedule(ctx, operatorID)
+	if err != nil {
+		log.Error("failed to get operator schedule", zap.Error(err))
+		return nil, err
+	}
+	if !inSchedule {
+		log.Info("operator outside scheduled working hours")
+		return nil, ErrOutsideSchedule
+	}
+	// Operators with a lowered allocation weight are paced; failing to read
+	// their health must not stop allocation
+	wait, err := s.health.Pace(ctx, tenantID, operatorID)
+	if err != nil {
+		log.Warn("failed to check operator allocation health", zap.Error(err))
+	} else if wait > 0 {
+		allocationsThrottled.Inc()
+		log.Info("allocation paced by operator allocation weight", zap.Duration("retry_after", wait))
+		return nil, &AllocationThrottledError{RetryAfter: wait}
+	}
 
 	// 2. Get operator's subscribed inboxes
 	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
@@ -84,17 +177,61 @@ func (s *AllocationService) Allocate(ctx context.Context, tenantID, operatorID u
 
 	log.Debug("found subscriptions", zap.Int("inbox_count", len(inboxIDs)))
 
-	// 3. Begin transaction
+	languages, err := s.operatorLanguages(ctx, operatorID)
+	if err != nil {
+		log.Error("failed to get operator languages", zap.Error(err))
+		return nil, err
+	}
+
+	labelIDs, err := s.resolveLabels(ctx, tenantID, filter.LabelIDs)
+	if err != nil {
+		log.Info("invalid allocation label filter", zap.Error(err))
+		return nil, err
+	}
+
+	// Category quotas only reorder the queue; failing to read them must not
+	// stop allocation
+	var pref *domain.AllocationPreference
+	since := affinitySince(start, s.affinityWindow)
+	if len(filter.LabelIDs) == 0 {
+		pref, err = s.quotas.AllocationPreference(ctx, tenantID, inboxIDs)
+		if err != nil {
+			log.Warn("failed to evaluate category quotas, allocating in priority order", zap.Error(err))
+			pref = nil
+		}
+	} else {
+		since = nil
+	}
+
+	strategy := strategyFor(s.tenantEngine(ctx, tenantID))
+	log = log.WithFields(zap.String("engine", string(strategy.Engine())))
+
+	// 3. Journal the attempt, then begin transaction
+	intent, err := s.journal.Begin(ctx, tenantID, operatorID, domain.AllocationIntentAllocate, nil)
+	if err != nil {
+		log.Error("failed to journal allocation intent", zap.Error(err))
+		return nil, err
+	}
+	defer s.journal.Abort(ctx, intent)
+
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
-	// 4. Get next conversation with lock (FOR UPDATE SKIP LOCKED)
+	// 4. Get next conversations with lock (FOR UPDATE SKIP LOCKED)
 	// This query is CRITICAL for preventing race conditions
 	log.Debug("fetching queued conversations with FOR UPDATE SKIP LOCKED")
-	conversations, err := s.repos.ConversationRefs.GetNextForAllocation(ctx, tenantID, inboxIDs, 1)
+	conversations, err := nextWithAffinity(ctx, strategy, repos.ConversationRefs, AllocationQuery{
+		TenantID:   tenantID,
+		InboxIDs:   inboxIDs,
+		Languages:  languages,
+		Preference: pref,
+		LabelIDs:   labelIDs,
+		Limit:      count,
+	}, operatorID, since)
 	if err != nil {
 		log.Error("failed to fetch conversations for allocation", zap.Error(err))
 		return nil, err
@@ -103,82 +240,263 @@ func (s *AllocationService) Allocate(ctx context.Context, tenantID, operatorID u
 	if len(conversations) == 0 {
 		log.Debug("no conversations available for allocation",
 			zap.Strings("inbox_ids", uuidSliceToStringSlice(inboxIDs)))
+		recordAllocation(strategy.Engine(), 0, time.Since(start))
 		return nil, ErrNoConversationsAvailable
 	}
 
-	conv := conversations[0]
-	log.Debug("conversation selected for allocation",
-		zap.String("conversation_id", conv.ID.String()),
-		zap.String("inbox_id", conv.InboxID.String()))
-
-	// 5. Verify conversation is still QUEUED (should always be true with lock)
-	if conv.State != domain.ConversationStateQueued {
-		log.Error("conversation not in QUEUED state after lock",
+	intents := make([]*domain.AllocationIntent, len(conversations))
+	befores := make([]map[string]interface{}, len(conversations))
+	for i, conv := range conversations {
+		log.Debug("conversation selected for allocation",
 			zap.String("conversation_id", conv.ID.String()),
-			zap.String("state", string(conv.State)))
-		return nil, ErrConversationNotQueued
+			zap.String("inbox_id", conv.InboxID.String()))
+
+		// 5. Verify conversation is still QUEUED (should always be true with lock)
+		if conv.State != domain.ConversationStateQueued {
+			log.Error("conversation not in QUEUED state after lock",
+				zap.String("conversation_id", conv.ID.String()),
+				zap.String("state", string(conv.State)))
+			return nil, ErrConversationNotQueued
+		}
+
+		// Recovery needs to know which conversation to check
+		if i == 0 {
+			if err := s.journal.SetConversation(ctx, intent, conv.ID); err != nil {
+				log.Error("failed to journal selected conversation", zap.Error(err))
+				return nil, err
+			}
+			intents[i] = intent
+		} else {
+			slotIntent, err := s.journal.BeginBatchSlot(ctx, intent, i+1, conv.ID)
+			if err != nil {
+				log.Error("failed to journal selected conversation", zap.Error(err))
+				return nil, err
+			}
+			defer s.journal.Abort(ctx, slotIntent)
+			intents[i] = slotIntent
+		}
+
+		befores[i] = conversationAuditSnapshot(conv)
+
+		// 6. Update conversation state to ALLOCATED
+		conv.State = domain.ConversationStateAllocated
+		conv.AssignedOperatorID = &operatorID
+		conv.UpdatedAt = time.Now().UTC()
+
+		if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+			log.Error("failed to update conversation for allocation",
+				zap.String("conversation_id", conv.ID.String()),
+				zap.Error(err))
+			return nil, err
+		}
 	}
 
-	// 6. Update conversation state to ALLOCATED
-	conv.State = domain.ConversationStateAllocated
-	conv.AssignedOperatorID = &operatorID
-	conv.UpdatedAt = time.Now().UTC()
+	if err := instantiateChecklists(ctx, repos.Checklists, conversations); err != nil {
+		log.Error("failed to instantiate conversation checklists", zap.Error(err))
+		return nil, err
+	}
+	if err := recordExperimentAllocations(ctx, repos.Experiments, conversations); err != nil {
+		log.Error("failed to record experiment allocations", zap.Error(err))
+		return nil, err
+	}
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
-		log.Error("failed to update conversation for allocation",
-			zap.String("conversation_id", conv.ID.String()),
-			zap.Error(err))
+	// 7. Stage events, then commit transaction
+	events := make([]*domain.Event, len(conversations))
+	for i, conv := range conversations {
+		data := conversationEventData(conv)
+		data["method"] = "auto"
+		events[i] = domain.NewEvent(tenantID, domain.EventConversationAllocated, data)
+	}
+	if err := stageEvents(ctx, s.events, tx, events...); err != nil {
+		log.Error("failed to stage allocation events", zap.Error(err))
 		return nil, err
 	}
 
-	// 7. Commit transaction
 	if err := tx.Commit(ctx); err != nil {
 		log.Error("failed to commit allocation transaction",
-			zap.String("conversation_id", conv.ID.String()),
+			zap.Strings("conversation_ids", conversationIDStrings(conversations)),
 			zap.Error(err))
 		return nil, err
 	}
+	// The first intent last, so a replay never finds it committed before the rest
+	for i := len(intents) - 1; i >= 0; i-- {
+		s.journal.Commit(ctx, intents[i])
+	}
+	s.quotas.RecordAllocation(pref, conversations)
+	recordAllocation(strategy.Engine(), len(conversations), time.Since(start))
 
 	// 8. Log success
-	priorityScore, _ := conv.PriorityScore.Float64()
-	log.Info("allocation successful",
-		zap.String("conversation_id", conv.ID.String()),
-		zap.String("inbox_id", conv.InboxID.String()),
-		zap.Float64("priority_score", priorityScore),
-		zap.Duration("duration", time.Since(start)))
+	for i, conv := range conversations {
+		priorityScore, _ := conv.PriorityScore.Float64()
+		log.Info("allocation successful",
+			zap.String("conversation_id", conv.ID.String()),
+			zap.String("inbox_id", conv.InboxID.String()),
+			zap.Float64("priority_score", priorityScore),
+			zap.Duration("duration", time.Since(start)))
 
-	return conv, nil
+		recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+			domain.AuditActionConversationAllocate, domain.AuditEntityConversation, conv.ID,
+			befores[i], conversationAuditSnapshot(conv)))
+
+		publishEvent(ctx, s.events, s.logger, events[i])
+	}
+
+	return conversations, nil
+}
+
+// resolveLabels returns the labels with every label nested below them, or
+// ErrLabelNotFound unless each label belongs to the tenant
+func (s *AllocationService) resolveLabels(ctx context.Context, tenantID uuid.UUID, labelIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(labelIDs) == 0 {
+		return nil, nil
+	}
+	for _, labelID := range labelIDs {
+		label, err := s.repos.Labels.GetByID(ctx, labelID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, ErrLabelNotFound
+			}
+			return nil, err
+		}
+		if label.TenantID != tenantID {
+			return nil, ErrLabelNotFound
+		}
+	}
+	return s.repos.Labels.GetDescendantIDs(ctx, labelIDs)
+}
+
+// ==================== Preview ====================
+
+// Preview returns the conversation Allocate would assign the operator next,
+// in the same order (tenant engine and category quotas included, customer
+// affinity left out), without locking or assigning it. It is a snapshot: a
+// concurrent allocation may take the conversation first. The operator's
+// availability and pacing are not checked, so operators can look before
+// going AVAILABLE.
+func (s *AllocationService) Preview(ctx context.Context, tenantID, operatorID uuid.UUID) (*domain.ConversationRef, error) {
+	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(inboxIDs) == 0 {
+		return nil, ErrNoSubscriptions
+	}
+	languages, err := s.operatorLanguages(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := s.quotas.AllocationPreference(ctx, tenantID, inboxIDs)
+	if err != nil {
+		s.logger.Warn("Failed to evaluate category quotas, previewing in priority order",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		pref = nil
+	}
+
+	conv, err := strategyFor(s.tenantEngine(ctx, tenantID)).Peek(ctx, s.repos.ConversationRefs, AllocationQuery{
+		TenantID:   tenantID,
+		InboxIDs:   inboxIDs,
+		Languages:  languages,
+		Preference: pref,
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrNoConversationsAvailable
+	}
+	return conv, err
+}
+
+// tenantEngine returns the allocation engine the tenant is pinned to; the
+// default engine when the tenant cannot be read, so that allocation goes on
+func (s *AllocationService) tenantEngine(ctx context.Context, tenantID uuid.UUID) domain.AllocationEngine {
+	tenant, err := s.repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("Failed to get tenant allocation engine, using the default",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		return domain.DefaultAllocationEngine
+	}
+	return tenant.AllocationEngine
+}
+
+// operatorLanguages returns the languages automatic allocation gives the
+// operator conversations in; empty for any. Manual claims are not limited.
+func (s *AllocationService) operatorLanguages(ctx context.Context, operatorID uuid.UUID) ([]string, error) {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	return operator.Languages, nil
 }
 
 // ==================== Claim ====================
 
 // Claim allows an operator to manually claim a specific QUEUED conversation
 // CRITICAL: Uses FOR UPDATE NOWAIT to fail fast if conversation is locked
+// Journaled like Allocate.
 func (s *AllocationService) Claim(ctx context.Context, tenantID, operatorID, conversationID uuid.UUID) (*domain.ConversationRef, error) {
 	start := time.Now()
 
+	if conv, err := s.journal.Replay(ctx, tenantID, operatorID, domain.AllocationIntentClaim, &conversationID); conv != nil || err != nil {
+		return conv, err
+	}
+
 	// 1. Validate operator status
 	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
 	if err != nil {
 		return nil, err
 	}
-	if status.Status != domain.OperatorStatusAvailable {
+	if !status.Status.AcceptsAllocations() {
 		s.logger.Warn("Claim attempt by non-available operator",
 			zap.String("operator_id", operatorID.String()),
 			zap.String("status", string(status.Status)))
 		return nil, ErrOperatorNotAvailable
 	}
+	if status.InFocus(time.Now()) {
+		s.logger.Warn("Claim attempt by operator in focus mode",
+			zap.String("operator_id", operatorID.String()),
+			zap.Timep("focus_until", status.FocusUntil))
+		return nil, ErrOperatorInFocus
+	}
+	onVacation, err := isOnVacation(ctx, s.repos, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if onVacation {
+		s.logger.Warn("Claim attempt by operator on vacation",
+			zap.String("operator_id", operatorID.String()))
+		return nil, ErrOperatorOnVacation
+	}
+	inSchedule, err := s.inSchedule(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !inSchedule {
+		s.logger.Warn("Claim attempt outside scheduled working hours",
+			zap.String("operator_id", operatorID.String()))
+		return nil, ErrOutsideSchedule
+	}
+
+	// 2. Journal the attempt, then begin transaction
+	intent, err := s.journal.Begin(ctx, tenantID, operatorID, domain.AllocationIntentClaim, &conversationID)
+	if err != nil {
+		s.logger.Error("Failed to journal claim intent",
+			zap.String("conversation_id", conversationID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	defer s.journal.Abort(ctx, intent)
 
-	// 2. Begin transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
 	// 3. Lock conversation (FOR UPDATE NOWAIT)
 	// This will fail immediately if another transaction has locked the row
-	conv, err := s.repos.ConversationRefs.LockForClaim(ctx, conversationID)
+	conv, err := repos.ConversationRefs.LockForClaim(ctx, conversationID)
 	if err != nil {
 		// Check if it's a lock acquisition error
 		if errors.Is(err, domain.ErrLockTimeout) || errors.Is(err, domain.ErrConversationLocked) {
@@ -224,7 +542,7 @@ func (s *AllocationService) Claim(ctx context.Context, tenantID, operatorID, con
 	}
 
 	// 6. Verify operator is subscribed to the inbox
-	isSubscribed, err := s.repos.Subscriptions.IsSubscribed(ctx, operatorID, conv.InboxID)
+	isSubscribed, err := repos.Subscriptions.IsSubscribed(ctx, operatorID, conv.InboxID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,25 +554,51 @@ func (s *AllocationService) Claim(ctx context.Context, tenantID, operatorID, con
 		return nil, ErrNotSubscribedToInbox
 	}
 
+	before := conversationAuditSnapshot(conv)
+
 	// 7. Update conversation state to ALLOCATED
 	conv.State = domain.ConversationStateAllocated
 	conv.AssignedOperatorID = &operatorID
 	conv.UpdatedAt = time.Now().UTC()
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
 		s.logger.Error("Failed to update conversation for claim",
 			zap.String("conversation_id", conversationID.String()),
 			zap.Error(err))
 		return nil, err
 	}
 
-	// 8. Commit transaction
+	if err := instantiateChecklists(ctx, repos.Checklists, []*domain.ConversationRef{conv}); err != nil {
+		s.logger.Error("Failed to instantiate conversation checklist",
+			zap.String("conversation_id", conversationID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	if err := recordExperimentAllocations(ctx, repos.Experiments, []*domain.ConversationRef{conv}); err != nil {
+		s.logger.Error("Failed to record experiment allocation",
+			zap.String("conversation_id", conversationID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	// 8. Stage the event, then commit transaction
+	data := conversationEventData(conv)
+	data["method"] = "claim"
+	event := domain.NewEvent(tenantID, domain.EventConversationAllocated, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		s.logger.Error("Failed to stage claim event",
+			zap.String("conversation_id", conversationID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		s.logger.Error("Failed to commit claim transaction",
 			zap.String("conversation_id", conversationID.String()),
 			zap.Error(err))
 		return nil, err
 	}
+	s.journal.Commit(ctx, intent)
 
 	// 9. Log success
 	priorityScore, _ := conv.PriorityScore.Float64()
@@ -266,11 +610,27 @@ func (s *AllocationService) Claim(ctx context.Context, tenantID, operatorID, con
 		zap.Float64("priority_score", priorityScore),
 		zap.Duration("claim_time", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionConversationClaim, domain.AuditEntityConversation, conv.ID,
+		before, conversationAuditSnapshot(conv)))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
 	return conv, nil
 }
 
 // ==================== Helpers ====================
 
+// inSchedule reports whether the operator is within their scheduled working
+// hours; operators without a schedule always are
+func (s *AllocationService) inSchedule(ctx context.Context, operatorID uuid.UUID) (bool, error) {
+	windows, err := s.repos.OperatorSchedules.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		return false, err
+	}
+	return domain.InSchedule(windows, time.Now()), nil
+}
+
 func uuidSliceToStringSlice(ids []uuid.UUID) []string {
 	result := make([]string, len(ids))
 	for i, id := range ids {
@@ -278,3 +638,11 @@ func uuidSliceToStringSlice(ids []uuid.UUID) []string {
 	}
 	return result
 }
+
+func conversationIDStrings(convs []*domain.ConversationRef) []string {
+	result := make([]string, len(convs))
+	for i, conv := range convs {
+		result[i] = conv.ID.String()
+	}
+	return result
+}
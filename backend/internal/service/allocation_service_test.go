@@ -122,6 +122,39 @@ func TestAllocationService_ConversationAvailability(t *testing.T) {
 	})
 }
 
+func TestAllocationService_LanguageRouting(t *testing.T) {
+	ctx := testutil.TestContext(t)
+
+	t.Run("operators only get conversations in their languages", func(t *testing.T) {
+		convRepo := testutil.NewMockConversationRepository()
+
+		tenant := testutil.NewTestTenant()
+		inbox := testutil.NewTestInbox(tenant.ID)
+
+		es, en := "es", "en"
+		spanish := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		spanish.Language = &es
+		convRepo.AddConversation(spanish)
+		english := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		english.Language = &en
+		convRepo.AddConversation(english)
+		unknown := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		convRepo.AddConversation(unknown)
+
+		convs, err := convRepo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, []string{"es"}, 10)
+		require.NoError(t, err)
+		ids := make([]uuid.UUID, len(convs))
+		for i, c := range convs {
+			ids[i] = c.ID
+		}
+		assert.ElementsMatch(t, []uuid.UUID{spanish.ID, unknown.ID}, ids)
+
+		convs, err = convRepo.GetNextForAllocation(ctx, tenant.ID, []uuid.UUID{inbox.ID}, nil, 10)
+		require.NoError(t, err)
+		assert.Len(t, convs, 3, "operators without languages take every conversation")
+	})
+}
+
 func TestAllocationService_ClaimValidation(t *testing.T) {
 	ctx := testutil.TestContext(t)
 
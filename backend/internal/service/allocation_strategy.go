@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+)
+
+// Allocation metrics are labeled by engine so that canary tenants can be
+// compared with the rest
+var (
+	allocationsByEngine       = metrics.NewCounterMap("allocations_by_engine_total")
+	allocationMissesByEngine  = metrics.NewCounterMap("allocation_misses_by_engine_total")
+	allocationLatencyByEngine = map[domain.AllocationEngine]*metrics.Histogram{}
+)
+
+var allocationLatencyBounds = []int64{5, 10, 25, 50, 100, 250, 500, 1000}
+
+func init() {
+	for _, engine := range []domain.AllocationEngine{domain.AllocationEngineV1, domain.AllocationEngineV2} {
+		allocationLatencyByEngine[engine] = metrics.NewHistogram("allocation_latency_ms_"+string(engine), allocationLatencyBounds)
+	}
+}
+
+// AllocationQuery is what every engine selects from: the QUEUED, unsnoozed
+// conversations of the inboxes, in the operator's languages
+type AllocationQuery struct {
+	TenantID  uuid.UUID
+	InboxIDs  []uuid.UUID
+	Languages []string
+	// Preference carries the category quotas; nil without any
+	Preference *domain.AllocationPreference
+	// LabelIDs, when set, restricts the queue to conversations carrying one
+	// of the labels; category quotas then do not apply
+	LabelIDs []uuid.UUID
+	Limit    int
+}
+
+// AllocationStrategy orders the queue for automatic allocation. Tenants are
+// pinned to an engine (Tenant.AllocationEngine), so the strategies of every
+// engine coexist and a new one is rolled out tenant by tenant.
+type AllocationStrategy interface {
+	Engine() domain.AllocationEngine
+	// Next locks up to q.Limit conversations with FOR UPDATE SKIP LOCKED,
+	// in allocation order
+	Next(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) ([]*domain.ConversationRef, error)
+	// Peek returns the head of the allocation order without locking it;
+	// ErrNotFound when none is queued
+	Peek(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) (*domain.ConversationRef, error)
+}
+
+// allocationStrategies are the strategies of every engine
+var allocationStrategies = map[domain.AllocationEngine]AllocationStrategy{
+	domain.AllocationEngineV1: priorityStrategy{},
+	domain.AllocationEngineV2: breachFirstStrategy{},
+}
+
+// strategyFor returns the strategy of the engine, the default engine's for
+// an unknown one
+func strategyFor(engine domain.AllocationEngine) AllocationStrategy {
+	if strategy, ok := allocationStrategies[engine]; ok {
+		return strategy
+	}
+	return allocationStrategies[domain.DefaultAllocationEngine]
+}
+
+// recordAllocation counts an allocate of the engine that assigned n
+// conversations, none being a miss
+func recordAllocation(engine domain.AllocationEngine, n int, elapsed time.Duration) {
+	if n == 0 {
+		allocationMissesByEngine.Inc(string(engine))
+		return
+	}
+	allocationsByEngine.Add(string(engine), int64(n))
+	if h, ok := allocationLatencyByEngine[engine]; ok {
+		h.Observe(elapsed.Milliseconds())
+	}
+}
+
+// ==================== v1 ====================
+
+// priorityStrategy allocates by priority override, then priority score,
+// then the longest-waiting last message
+type priorityStrategy struct{}
+
+func (priorityStrategy) Engine() domain.AllocationEngine {
+	return domain.AllocationEngineV1
+}
+
+func (priorityStrategy) Next(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) ([]*domain.ConversationRef, error) {
+	if len(q.LabelIDs) > 0 {
+		return conversations.GetNextForAllocationByLabels(ctx, q.TenantID, q.InboxIDs, q.Languages, q.LabelIDs, false, q.Limit)
+	}
+	if q.Preference != nil {
+		return conversations.GetNextForAllocationWithQuotas(ctx, q.TenantID, q.InboxIDs, q.Languages, q.Preference.LabelIDs, q.Preference.StarvedBefore, q.Limit)
+	}
+	return conversations.GetNextForAllocation(ctx, q.TenantID, q.InboxIDs, q.Languages, q.Limit)
+}
+
+func (priorityStrategy) Peek(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) (*domain.ConversationRef, error) {
+	if q.Preference != nil {
+		return conversations.PeekNextForAllocationWithQuotas(ctx, q.TenantID, q.InboxIDs, q.Languages, q.Preference.LabelIDs, q.Preference.StarvedBefore)
+	}
+	return conversations.PeekNextForAllocation(ctx, q.TenantID, q.InboxIDs, q.Languages)
+}
+
+// ==================== v2 ====================
+
+// breachFirstStrategy allocates conversations that breached an SLA target
+// or their due date first, earliest breach first, then as v1
+type breachFirstStrategy struct{}
+
+func (breachFirstStrategy) Engine() domain.AllocationEngine {
+	return domain.AllocationEngineV2
+}
+
+func (breachFirstStrategy) Next(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) ([]*domain.ConversationRef, error) {
+	if len(q.LabelIDs) > 0 {
+		return conversations.GetNextForAllocationByLabels(ctx, q.TenantID, q.InboxIDs, q.Languages, q.LabelIDs, true, q.Limit)
+	}
+	labelIDs, starvedBefore := q.quotas()
+	return conversations.GetNextForAllocationBreachFirst(ctx, q.TenantID, q.InboxIDs, q.Languages, labelIDs, starvedBefore, q.Limit)
+}
+
+func (breachFirstStrategy) Peek(ctx context.Context, conversations domain.ConversationRefRepository, q AllocationQuery) (*domain.ConversationRef, error) {
+	labelIDs, starvedBefore := q.quotas()
+	return conversations.PeekNextForAllocationBreachFirst(ctx, q.TenantID, q.InboxIDs, q.Languages, labelIDs, starvedBefore)
+}
+
+func (q AllocationQuery) quotas() ([]uuid.UUID, *time.Time) {
+	if q.Preference == nil {
+		return nil, nil
+	}
+	starvedBefore := q.Preference.StarvedBefore
+	return q.Preference.LabelIDs, &starvedBefore
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategyFor(t *testing.T) {
+	assert.Equal(t, domain.AllocationEngineV1, strategyFor(domain.AllocationEngineV1).Engine())
+	assert.Equal(t, domain.AllocationEngineV2, strategyFor(domain.AllocationEngineV2).Engine())
+	assert.Equal(t, domain.DefaultAllocationEngine, strategyFor("").Engine())
+}
+
+func TestAllocationStrategies_Order(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	convRepo := testutil.NewMockConversationRepository()
+
+	tenant := testutil.NewTestTenant()
+	inbox := testutil.NewTestInbox(tenant.ID)
+
+	urgent := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	urgent.PriorityScore = decimal.NewFromInt(90)
+	convRepo.AddConversation(urgent)
+
+	breached := testutil.NewTestConversation(tenant.ID, inbox.ID)
+	breached.PriorityScore = decimal.NewFromInt(10)
+	breachedAt := time.Now().UTC().Add(-10 * time.Minute)
+	breached.SLABreachedAt = &breachedAt
+	convRepo.AddConversation(breached)
+
+	query := AllocationQuery{TenantID: tenant.ID, InboxIDs: []uuid.UUID{inbox.ID}, Limit: 2}
+
+	t.Run("v1 allocates by priority", func(t *testing.T) {
+		convs, err := strategyFor(domain.AllocationEngineV1).Next(ctx, convRepo, query)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, urgent.ID, convs[0].ID)
+
+		head, err := strategyFor(domain.AllocationEngineV1).Peek(ctx, convRepo, query)
+		require.NoError(t, err)
+		assert.Equal(t, urgent.ID, head.ID)
+	})
+
+	t.Run("v2 allocates breached conversations first", func(t *testing.T) {
+		convs, err := strategyFor(domain.AllocationEngineV2).Next(ctx, convRepo, query)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, breached.ID, convs[0].ID)
+		assert.Equal(t, urgent.ID, convs[1].ID)
+
+		head, err := strategyFor(domain.AllocationEngineV2).Peek(ctx, convRepo, query)
+		require.NoError(t, err)
+		assert.Equal(t, breached.ID, head.ID)
+	})
+
+	t.Run("label filter keeps the engine order", func(t *testing.T) {
+		labelID := uuid.New()
+		plain := testutil.NewTestConversation(tenant.ID, inbox.ID)
+		convRepo.AddConversation(plain)
+		convRepo.AddLabel(urgent.ID, labelID)
+		convRepo.AddLabel(breached.ID, labelID)
+
+		filtered := query
+		filtered.LabelIDs = []uuid.UUID{labelID}
+		filtered.Limit = 3
+
+		convs, err := strategyFor(domain.AllocationEngineV1).Next(ctx, convRepo, filtered)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, urgent.ID, convs[0].ID)
+
+		convs, err = strategyFor(domain.AllocationEngineV2).Next(ctx, convRepo, filtered)
+		require.NoError(t, err)
+		require.Len(t, convs, 2)
+		assert.Equal(t, breached.ID, convs[0].ID)
+	})
+}
+
+func TestRecordAllocation(t *testing.T) {
+	allocated := allocationsByEngine.Value("v2")
+	misses := allocationMissesByEngine.Value("v2")
+	observed := allocationLatencyByEngine[domain.AllocationEngineV2].Count()
+
+	recordAllocation(domain.AllocationEngineV2, 3, 20*time.Millisecond)
+	recordAllocation(domain.AllocationEngineV2, 0, time.Millisecond)
+
+	assert.Equal(t, allocated+3, allocationsByEngine.Value("v2"))
+	assert.Equal(t, misses+1, allocationMissesByEngine.Value("v2"))
+	assert.Equal(t, observed+1, allocationLatencyByEngine[domain.AllocationEngineV2].Count())
+}
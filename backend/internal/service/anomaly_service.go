@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	anomaliesDetected      = metrics.NewCounter("anomalies_detected_total")
+	anomalyDetectionErrors = metrics.NewCounter("anomaly_detection_errors_total")
+)
+
+// AnomalyDetectionConfig holds configuration for anomaly detection
+type AnomalyDetectionConfig struct {
+	// Window is the period whose counts are checked on each pass
+	Window time.Duration
+	// Baseline is the period before Window that usual counts are averaged over
+	Baseline time.Duration
+	// Cooldown is how long an anomaly is not raised again for the same
+	// tenant, kind and operator
+	Cooldown time.Duration
+}
+
+// DefaultAnomalyDetectionConfig returns sensible defaults
+func DefaultAnomalyDetectionConfig() AnomalyDetectionConfig {
+	return AnomalyDetectionConfig{
+		Window:   15 * time.Minute,
+		Baseline: 24 * time.Hour,
+		Cooldown: 1 * time.Hour,
+	}
+}
+
+// AnomalyService flags unusual allocation patterns for managers. Counts come
+// from the audit log (deallocations, resolutions) and the allocation journal
+// (attempts that assigned nothing), so every instance sees the whole tenant.
+// A flagged anomaly is stored, audited and published as anomaly.detected
+// (deliverable through webhooks).
+type AnomalyService struct {
+	repos  *repository.RepositoryContainer
+	events domain.EventPublisher
+	audit  *AuditService
+	config AnomalyDetectionConfig
+	logger *logger.Logger
+}
+
+func NewAnomalyService(repos *repository.RepositoryContainer, events domain.EventPublisher, audit *AuditService, config AnomalyDetectionConfig, log *logger.Logger) *AnomalyService {
+	return &AnomalyService{
+		repos:  repos,
+		events: events,
+		audit:  audit,
+		config: config,
+		logger: log,
+	}
+}
+
+// ==================== Settings ====================
+
+// GetSettings returns the tenant's anomaly settings, the defaults if the
+// tenant never configured them
+func (s *AnomalyService) GetSettings(ctx context.Context, tenantID uuid.UUID) (*domain.TenantAnomalySettings, error) {
+	settings, err := s.repos.AnomalySettings.Get(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultTenantAnomalySettings(tenantID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings sets the tenant's detection sensitivity
+// Permission: Admin (enforced by router)
+func (s *AnomalyService) UpdateSettings(ctx context.Context, tenantID uuid.UUID, sensitivity domain.AnomalySensitivity, updatedBy *uuid.UUID) (*domain.TenantAnomalySettings, error) {
+	settings := domain.NewTenantAnomalySettings(tenantID, sensitivity, updatedBy)
+
+	var before map[string]interface{}
+	existing, err := s.repos.AnomalySettings.Get(ctx, tenantID)
+	switch {
+	case err == nil:
+		before = anomalySettingsAuditSnapshot(existing)
+		settings.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, domain.ErrNotFound):
+		return nil, err
+	}
+
+	if err := s.repos.AnomalySettings.Upsert(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant anomaly settings updated",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("sensitivity", string(sensitivity)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionTenantAnomalySettings, domain.AuditEntityTenant, tenantID,
+		before, anomalySettingsAuditSnapshot(settings)))
+
+	return settings, nil
+}
+
+func anomalySettingsAuditSnapshot(settings *domain.TenantAnomalySettings) map[string]interface{} {
+	return map[string]interface{}{
+		"anomaly_sensitivity": string(settings.Sensitivity),
+	}
+}
+
+// ==================== Anomalies ====================
+
+// List returns the tenant's anomalies detected since the given time, newest first
+// Permission: Manager+ (enforced by router)
+func (s *AnomalyService) List(ctx context.Context, tenantID uuid.UUID, since time.Time, limit int) ([]*domain.Anomaly, error) {
+	return s.repos.Anomalies.List(ctx, tenantID, since, limit)
+}
+
+// ==================== Detection ====================
+
+// DetectAll checks every tenant whose sensitivity is not OFF and returns how
+// many anomalies were raised. A tenant that fails is skipped.
+func (s *AnomalyService) DetectAll(ctx context.Context) (int, error) {
+	sensitivities, err := s.repos.AnomalySettings.ListSensitivities(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		raised int
+		errs   []error
+	)
+	for tenantID, sensitivity := range sensitivities {
+		thresholds, ok := sensitivity.Thresholds()
+		if !ok {
+			continue
+		}
+		n, err := s.detect(ctx, tenantID, thresholds)
+		raised += n
+		if err != nil {
+			anomalyDetectionErrors.Inc()
+			s.logger.Warn("Failed to check tenant for anomalies",
+				zap.String("tenant_id", tenantID.String()),
+				zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return raised, errors.Join(errs...)
+}
+
+// detect runs every check for one tenant
+func (s *AnomalyService) detect(ctx context.Context, tenantID uuid.UUID, thresholds domain.AnomalyThresholds) (int, error) {
+	now := time.Now().UTC()
+	windowStart := now.Add(-s.config.Window)
+	baselineStart := windowStart.Add(-s.config.Baseline)
+
+	var raised int
+
+	deallocations := func(from, to time.Time) (int, error) {
+		return s.repos.AuditLogs.CountByAction(ctx, tenantID, domain.AuditActionConversationDeallocate, from, to)
+	}
+	failures := func(from, to time.Time) (int, error) {
+		return s.repos.AllocationIntents.CountAborted(ctx, tenantID, from, to)
+	}
+	spikes := []struct {
+		kind  domain.AnomalyKind
+		count func(from, to time.Time) (int, error)
+	}{
+		{domain.AnomalyDeallocationSpike, deallocations},
+		{domain.AnomalyAllocationFailureSurge, failures},
+	}
+
+	for _, spike := range spikes {
+		current, err := spike.count(windowStart, now)
+		if err != nil {
+			return raised, fmt.Errorf("count %s: %w", spike.kind, err)
+		}
+		usual, err := spike.count(baselineStart, windowStart)
+		if err != nil {
+			return raised, fmt.Errorf("count %s baseline: %w", spike.kind, err)
+		}
+
+		baseline := float64(usual) * float64(s.config.Window) / float64(s.config.Baseline)
+		threshold := thresholds.SpikeThreshold(baseline)
+		if float64(current) < threshold {
+			continue
+		}
+		ok, err := s.raise(ctx, domain.NewAnomaly(tenantID, spike.kind, nil, current, baseline, threshold, s.config.Window))
+		if err != nil {
+			return raised, err
+		}
+		if ok {
+			raised++
+		}
+	}
+
+	fast, err := s.repos.AuditLogs.CountFastResolves(ctx, tenantID, windowStart, domain.FastResolveWithin)
+	if err != nil {
+		return raised, fmt.Errorf("count fast resolves: %w", err)
+	}
+	for operatorID, count := range fast {
+		if count < thresholds.FastResolves {
+			continue
+		}
+		operatorID := operatorID
+		ok, err := s.raise(ctx, domain.NewAnomaly(tenantID, domain.AnomalyFastResolves, &operatorID,
+			count, 0, float64(thresholds.FastResolves), s.config.Window))
+		if err != nil {
+			return raised, err
+		}
+		if ok {
+			raised++
+		}
+	}
+
+	return raised, nil
+}
+
+// raise stores, audits and publishes the anomaly unless the same one was
+// raised within the cooldown. Replicas checking at the same moment may both
+// raise it; the cooldown check is not locked.
+func (s *AnomalyService) raise(ctx context.Context, anomaly *domain.Anomaly) (bool, error) {
+	recent, err := s.repos.Anomalies.ExistsSince(ctx, anomaly.TenantID, anomaly.Kind, anomaly.OperatorID,
+		anomaly.DetectedAt.Add(-s.config.Cooldown))
+	if err != nil || recent {
+		return false, err
+	}
+
+	if err := s.repos.Anomalies.Create(ctx, anomaly); err != nil {
+		return false, err
+	}
+
+	anomaliesDetected.Inc()
+	s.logger.Warn("Anomaly detected",
+		zap.String("anomaly_id", anomaly.ID.String()),
+		zap.String("tenant_id", anomaly.TenantID.String()),
+		zap.String("kind", string(anomaly.Kind)),
+		zap.Any("operator_id", uuidPtrToString(anomaly.OperatorID)),
+		zap.Int("observed", anomaly.Observed),
+		zap.Float64("threshold", anomaly.Threshold))
+
+	data := anomalyEventData(anomaly)
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(anomaly.TenantID, nil,
+		domain.AuditActionAnomalyDetected, domain.AuditEntityAnomaly, anomaly.ID, nil, data))
+	publishEvent(ctx, s.events, s.logger, domain.NewEvent(anomaly.TenantID, domain.EventAnomalyDetected, data))
+
+	return true, nil
+}
+
+func anomalyEventData(anomaly *domain.Anomaly) map[string]interface{} {
+	return map[string]interface{}{
+		"anomaly_id":     anomaly.ID.String(),
+		"kind":           string(anomaly.Kind),
+		"operator_id":    uuidPtrToString(anomaly.OperatorID),
+		"observed":       anomaly.Observed,
+		"baseline":       anomaly.Baseline,
+		"threshold":      anomaly.Threshold,
+		"window_seconds": int(anomaly.Window.Seconds()),
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("invalid or revoked api key")
+)
+
+// APIKeyService manages tenant API keys and authenticates requests made with
+// them. Keys act for the tenant with a fixed role and no operator identity.
+type APIKeyService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewAPIKeyService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *APIKeyService {
+	return &APIKeyService{repos: repos, audit: audit, logger: log}
+}
+
+// CreateKey issues a key and returns it with its plaintext value, which is
+// not retrievable afterwards
+// Permission: Admin (enforced by router)
+func (s *APIKeyService) CreateKey(ctx context.Context, tenantID uuid.UUID, name string, role domain.OperatorRole, createdBy *uuid.UUID) (*domain.APIKey, string, error) {
+	key, raw, err := domain.NewAPIKey(tenantID, name, role, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repos.APIKeys.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("API key created",
+		zap.String("api_key_id", key.ID.String()),
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("role", string(role)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, createdBy,
+		domain.AuditActionAPIKeyCreate, domain.AuditEntityAPIKey, key.ID,
+		nil, apiKeyAuditSnapshot(key)))
+
+	return key, raw, nil
+}
+
+// ListKeys returns the tenant's keys, including revoked ones
+// Permission: Admin (enforced by router)
+func (s *APIKeyService) ListKeys(ctx context.Context, tenantID uuid.UUID) ([]*domain.APIKey, error) {
+	return s.repos.APIKeys.GetByTenantID(ctx, tenantID)
+}
+
+// RevokeKey disables a key; revoking a revoked key is a no-op
+// Permission: Admin (enforced by router)
+func (s *APIKeyService) RevokeKey(ctx context.Context, tenantID, id uuid.UUID, revokedBy *uuid.UUID) (*domain.APIKey, error) {
+	key, err := s.repos.APIKeys.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	if key.TenantID != tenantID {
+		return nil, ErrAPIKeyNotFound
+	}
+	if key.IsRevoked() {
+		return key, nil
+	}
+
+	before := apiKeyAuditSnapshot(key)
+	key.Revoke()
+	if err := s.repos.APIKeys.Revoke(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API key revoked",
+		zap.String("api_key_id", key.ID.String()),
+		zap.String("tenant_id", tenantID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, revokedBy,
+		domain.AuditActionAPIKeyRevoke, domain.AuditEntityAPIKey, key.ID,
+		before, apiKeyAuditSnapshot(key)))
+
+	return key, nil
+}
+
+// Authenticate resolves a plaintext key to an active key and records its use
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(raw, domain.APIKeyPrefix) {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	key, err := s.repos.APIKeys.GetByHash(ctx, domain.HashAPIKey(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	now := time.Now().UTC()
+	if key.NeedsTouch(now) {
+		// Last-used tracking must not fail the request
+		if err := s.repos.APIKeys.Touch(ctx, key.ID, now); err != nil {
+			s.logger.Warn("Failed to record API key use",
+				zap.String("api_key_id", key.ID.String()),
+				zap.Error(err))
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+
+	return key, nil
+}
+
+func apiKeyAuditSnapshot(key *domain.APIKey) map[string]interface{} {
+	snapshot := map[string]interface{}{
+		"name":       key.Name,
+		"key_prefix": key.KeyPrefix,
+		"role":       key.Role,
+	}
+	if key.RevokedAt != nil {
+		snapshot["revoked_at"] = key.RevokedAt
+	}
+	return snapshot
+}
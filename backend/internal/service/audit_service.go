@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type AuditService struct {
+	repos  *repository.RepositoryContainer
+	logger *logger.Logger
+}
+
+func NewAuditService(repos *repository.RepositoryContainer, log *logger.Logger) *AuditService {
+	return &AuditService{repos: repos, logger: log}
+}
+
+// ==================== Record ====================
+
+// Record persists an audit entry
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	return s.repos.AuditLogs.Create(ctx, entry)
+}
+
+// recordAudit writes an audit entry once the change it describes is committed.
+// A nil service is a no-op and failures are only logged, so the audit trail
+// never fails the operation it records.
+func recordAudit(ctx context.Context, audit *AuditService, log *logger.Logger, entry *domain.AuditEntry) {
+	if audit == nil {
+		return
+	}
+	if err := audit.Record(ctx, entry); err != nil {
+		log.Error("Failed to record audit entry",
+			zap.String("action", string(entry.Action)),
+			zap.String("entity_type", string(entry.EntityType)),
+			zap.String("entity_id", entry.EntityID.String()),
+			zap.String("tenant_id", entry.TenantID.String()),
+			zap.Error(err))
+	}
+}
+
+// ==================== List ====================
+
+type ListAuditLogParams struct {
+	TenantID uuid.UUID
+
+	// Filters
+	ActorID    *uuid.UUID
+	EntityType *domain.AuditEntityType
+	EntityID   *uuid.UUID
+	Action     *domain.AuditAction
+	Actions    []domain.AuditAction
+	From       *time.Time
+	To         *time.Time
+
+	// Pagination
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+// List returns the tenant's audit entries, newest first
+// Permission: Admin (enforced by router)
+func (s *AuditService) List(ctx context.Context, params ListAuditLogParams) ([]*domain.AuditEntry, error) {
+	filter := domain.AuditLogFilter{
+		TenantID:   params.TenantID,
+		ActorID:    params.ActorID,
+		EntityType: params.EntityType,
+		EntityID:   params.EntityID,
+		Action:     params.Action,
+		Actions:    params.Actions,
+		From:       params.From,
+		To:         params.To,
+		Limit:      params.PerPage,
+	}
+
+	if params.Cursor != nil {
+		filter.CursorTimestamp = &params.Cursor.Timestamp
+		filter.CursorID = &params.Cursor.ID
+	}
+
+	entries, err := s.repos.AuditLogs.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("Failed to list audit log",
+			zap.String("tenant_id", params.TenantID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ==================== Admin Activity ====================
+
+// MaxAdminActivityExport caps the entries of one admin activity export
+const MaxAdminActivityExport = 5000
+
+// adminActivityExportPage is the page size used to gather an export
+const adminActivityExportPage = 100
+
+type ListAdminActivityParams struct {
+	TenantID uuid.UUID
+	ActorID  *uuid.UUID
+	// Actions narrows the feed to some admin actions; all of them when empty
+	Actions []domain.AuditAction
+	From    *time.Time
+	To      *time.Time
+
+	// Pagination; ignored by ExportAdminActivity
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+func (p ListAdminActivityParams) auditParams() ListAuditLogParams {
+	actions := p.Actions
+	if len(actions) == 0 {
+		actions = domain.AdminAuditActions
+	}
+	return ListAuditLogParams{
+		TenantID: p.TenantID,
+		ActorID:  p.ActorID,
+		Actions:  actions,
+		From:     p.From,
+		To:       p.To,
+		Cursor:   p.Cursor,
+		PerPage:  p.PerPage,
+	}
+}
+
+// ListAdminActivity returns the tenant's configuration changes (settings,
+// roles, routing rules, weights, ...), newest first
+// Permission: Admin (enforced by router)
+func (s *AuditService) ListAdminActivity(ctx context.Context, params ListAdminActivityParams) ([]*domain.AuditEntry, error) {
+	return s.List(ctx, params.auditParams())
+}
+
+// ExportAdminActivity returns every matching admin activity entry, newest
+// first, up to MaxAdminActivityExport. truncated reports older entries left out.
+// Permission: Admin (enforced by router)
+func (s *AuditService) ExportAdminActivity(ctx context.Context, params ListAdminActivityParams) (entries []*domain.AuditEntry, truncated bool, err error) {
+	list := params.auditParams()
+	list.Cursor = nil
+	list.PerPage = adminActivityExportPage
+
+	for {
+		page, err := s.List(ctx, list)
+		if err != nil {
+			return nil, false, err
+		}
+		entries = append(entries, page...)
+		if len(entries) > MaxAdminActivityExport {
+			return entries[:MaxAdminActivityExport], true, nil
+		}
+		if len(page) < list.PerPage {
+			return entries, false, nil
+		}
+		last := page[len(page)-1]
+		list.Cursor = &dto.Cursor{Timestamp: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// ==================== Snapshots ====================
+
+// conversationAuditSnapshot captures the conversation fields changed by lifecycle operations
+func conversationAuditSnapshot(conv *domain.ConversationRef) map[string]interface{} {
+	return map[string]interface{}{
+		"state":                string(conv.State),
+		"inbox_id":             conv.InboxID.String(),
+		"assigned_operator_id": uuidPtrToString(conv.AssignedOperatorID),
+	}
+}
+
+func labelAuditSnapshot(label *domain.Label) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            label.Name,
+		"scope":           string(label.Scope),
+		"inbox_id":        labelInboxToString(label),
+		"color":           label.Color,
+		"version":         label.Version,
+		"parent_label_id": uuidPtrToString(label.ParentLabelID),
+	}
+}
+
+// labelInboxToString is nil for a tenant label, which has no inbox
+func labelInboxToString(label *domain.Label) interface{} {
+	if label.Scope == domain.LabelScopeTenant {
+		return nil
+	}
+	return label.InboxID.String()
+}
+
+// routingRuleAuditSnapshot captures what a routing rule matches and does
+func routingRuleAuditSnapshot(rule *domain.RoutingRule) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           rule.Name,
+		"inbox_id":       uuidPtrToString(rule.InboxID),
+		"trigger":        string(rule.Trigger),
+		"field":          string(rule.ConditionField),
+		"operator":       string(rule.ConditionOperator),
+		"priority_boost": rule.PriorityBoost.String(),
+		"is_active":      rule.IsActive,
+	}
+}
+
+// conversationLabelAuditSnapshot describes a label attached to a conversation
+func conversationLabelAuditSnapshot(label *domain.Label) map[string]interface{} {
+	return map[string]interface{}{
+		"label_id":   label.ID.String(),
+		"label_name": label.Name,
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var ErrAutoResolveInboxNotFound = errors.New("auto-resolve settings inbox not found")
+
+var conversationsAutoResolved = metrics.NewCounter("conversations_auto_resolved_total")
+
+// AutoResolveReason is the resolution reason recorded for conversations
+// resolved by the auto-resolve worker
+const AutoResolveReason = "auto_resolved_idle"
+
+// AutoResolveService resolves the open conversations of an inbox once they
+// have gone without a customer message for the inbox's AutoResolveAfter,
+// so abandoned conversations leave the queue without bulk operations.
+// Snoozed conversations are left to their follow-up.
+type AutoResolveService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewAutoResolveService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *AutoResolveService {
+	return &AutoResolveService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// SetAutoResolveAfter sets how long the inbox's open conversations may be
+// idle before they are resolved; nil disables auto-resolution
+// Permission: Manager+ (enforced by router)
+func (s *AutoResolveService) SetAutoResolveAfter(ctx context.Context, tenantID, inboxID uuid.UUID, after *time.Duration, updatedBy *uuid.UUID) (*domain.Inbox, error) {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrAutoResolveInboxNotFound
+		}
+		return nil, err
+	}
+	if inbox.TenantID != tenantID {
+		return nil, ErrAutoResolveInboxNotFound
+	}
+
+	before := autoResolveAuditSnapshot(inbox)
+
+	inbox.AutoResolveAfter = after
+	inbox.UpdatedAt = time.Now().UTC()
+	if err := s.repos.Inboxes.Update(ctx, inbox); err != nil {
+		return nil, err
+	}
+
+	snapshot := autoResolveAuditSnapshot(inbox)
+	s.logger.Info("Inbox auto-resolution updated",
+		zap.String("inbox_id", inboxID.String()),
+		zap.Any("auto_resolve_after_seconds", snapshot["auto_resolve_after_seconds"]))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionInboxAutoResolveChange, domain.AuditEntityInbox, inboxID,
+		before, snapshot))
+
+	return inbox, nil
+}
+
+// ResolveStale resolves up to batchSize open conversations idle past their
+// inbox's AutoResolveAfter, stalest first. Each resolution is audited as a
+// system action and emits conversation.resolved, both with the reason. Uses
+// FOR UPDATE SKIP LOCKED so instances share the work.
+func (s *AutoResolveService) ResolveStale(ctx context.Context, batchSize int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+	conversations := repos.ConversationRefs
+
+	now := time.Now().UTC()
+	stale, err := conversations.GetAndLockStale(ctx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	pending := make([]*domain.Event, 0, len(stale))
+	audits := make([]*domain.AuditEntry, 0, len(stale))
+	for _, conv := range stale {
+		before := conversationAuditSnapshot(conv)
+
+		// Queued conversations are resolved too: nobody took them
+		conv.State = domain.ConversationStateResolved
+		conv.ResolvedAt = &now
+		conv.UpdatedAt = now
+		if err := conversations.Update(ctx, conv); err != nil {
+			return 0, err
+		}
+		if err := recordAffinity(ctx, repos.CustomerAffinities, conv); err != nil {
+			return 0, err
+		}
+
+		idleSeconds := int(now.Sub(conv.LastMessageAt).Seconds())
+		data := conversationEventData(conv)
+		data["reason"] = AutoResolveReason
+		data["idle_seconds"] = idleSeconds
+		pending = append(pending, domain.NewEvent(conv.TenantID, domain.EventConversationResolved, data))
+
+		after := conversationAuditSnapshot(conv)
+		after["reason"] = AutoResolveReason
+		after["idle_seconds"] = idleSeconds
+		audits = append(audits, domain.NewAuditEntry(conv.TenantID, nil,
+			domain.AuditActionConversationResolve, domain.AuditEntityConversation, conv.ID,
+			before, after))
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	conversationsAutoResolved.Add(int64(len(stale)))
+
+	for _, entry := range audits {
+		recordAudit(ctx, s.audit, s.logger, entry)
+	}
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return len(stale), nil
+}
+
+func autoResolveAuditSnapshot(inbox *domain.Inbox) map[string]interface{} {
+	var seconds interface{}
+	if inbox.AutoResolveAfter != nil {
+		seconds = int(inbox.AutoResolveAfter.Seconds())
+	}
+	return map[string]interface{}{
+		"auto_resolve_after_seconds": seconds,
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/database"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// BackfillDefinition describes the data backfill of an online schema change
+type BackfillDefinition struct {
+	// Name identifies the backfill job; never reuse a name once deployed
+	Name  string
+	Table string
+	// BatchSize overrides BackfillConfig.BatchSize when set
+	BatchSize int
+	// Statement processes one batch, see database.RunBackfillBatch:
+	//   WITH batch AS (SELECT id FROM conversation_refs WHERE id > $1 ORDER BY id LIMIT $2)
+	//   UPDATE conversation_refs c SET ... FROM batch WHERE c.id = batch.id RETURNING c.id
+	Statement string
+}
+
+// Backfills run by the backfill worker. Add an entry together with the
+// expand migration that needs it and remove it with the contract migration
+// once every environment reports it COMPLETED (see migrations/README.md).
+var Backfills = []BackfillDefinition{
+	{
+		// 000046: link existing conversations to a customer per phone number,
+		// creating the customers that never wrote in since
+		Name:  "conversation_refs_customer_id",
+		Table: "conversation_refs",
+		Statement: `
+WITH batch AS (
+    SELECT id, tenant_id, customer_phone_number FROM conversation_refs
+    WHERE id > $1
+    ORDER BY id
+    LIMIT $2
+),
+created AS (
+    INSERT INTO customers (id, tenant_id, phone_number)
+    SELECT gen_random_uuid(), tenant_id, customer_phone_number
+    FROM (SELECT DISTINCT tenant_id, customer_phone_number FROM batch) phones
+    ON CONFLICT (tenant_id, phone_number) DO NOTHING
+    RETURNING id, tenant_id, phone_number
+),
+known AS (
+    SELECT id, tenant_id, phone_number FROM created
+    UNION
+    SELECT cu.id, cu.tenant_id, cu.phone_number FROM customers cu
+    JOIN batch b ON cu.tenant_id = b.tenant_id AND cu.phone_number = b.customer_phone_number
+)
+UPDATE conversation_refs c
+SET customer_id = COALESCE(c.customer_id, k.id)
+FROM batch
+LEFT JOIN known k ON k.tenant_id = batch.tenant_id AND k.phone_number = batch.customer_phone_number
+WHERE c.id = batch.id
+RETURNING c.id`,
+	},
+	{
+		// 000050: digits of the phone numbers of existing conversations
+		Name:  "conversation_refs_normalized_phone",
+		Table: "conversation_refs",
+		Statement: `
+WITH batch AS (
+    SELECT id FROM conversation_refs
+    WHERE id > $1
+    ORDER BY id
+    LIMIT $2
+)
+UPDATE conversation_refs c
+SET normalized_phone = regexp_replace(c.customer_phone_number, '[^0-9]', '', 'g')
+FROM batch
+WHERE c.id = batch.id
+RETURNING c.id`,
+	},
+}
+
+// BackfillConfig holds configuration for batched backfills
+type BackfillConfig struct {
+	BatchSize int
+	// LockTimeout bounds the row locks a batch waits for before it is retried
+	LockTimeout time.Duration
+}
+
+// DefaultBackfillConfig returns sensible defaults
+func DefaultBackfillConfig() BackfillConfig {
+	return BackfillConfig{
+		BatchSize:   1000,
+		LockTimeout: 5 * time.Second,
+	}
+}
+
+// BackfillService runs registered backfills in small keyset-paginated
+// batches. Each batch commits on its own, so a backfill never holds locks on
+// more than one batch of rows and resumes from its cursor after a restart.
+// The schema_backfills rows act as the job queue: replicas claim a job with
+// SKIP LOCKED, so each batch runs once.
+type BackfillService struct {
+	repos       *repository.RepositoryContainer
+	pool        *pgxpool.Pool
+	definitions []BackfillDefinition
+	config      BackfillConfig
+	logger      *logger.Logger
+
+	registered bool
+}
+
+func NewBackfillService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, definitions []BackfillDefinition, config BackfillConfig, log *logger.Logger) *BackfillService {
+	defaults := DefaultBackfillConfig()
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = defaults.LockTimeout
+	}
+	return &BackfillService{
+		repos:       repos,
+		pool:        pool,
+		definitions: definitions,
+		config:      config,
+		logger:      log,
+	}
+}
+
+// List returns the progress of every registered backfill
+// Permission: Admin (enforced by router)
+func (s *BackfillService) List(ctx context.Context) ([]*domain.Backfill, error) {
+	return s.repos.Backfills.List(ctx)
+}
+
+// RunPending processes one batch of every unfinished backfill and returns the
+// number of rows processed. Jobs are registered on the first call.
+func (s *BackfillService) RunPending(ctx context.Context) (int, error) {
+	if !s.registered {
+		if err := s.register(ctx); err != nil {
+			return 0, err
+		}
+		s.registered = true
+	}
+
+	total := 0
+	for _, def := range s.definitions {
+		processed, err := s.runBatch(ctx, def)
+		if err != nil {
+			return total, fmt.Errorf("backfill %s: %w", def.Name, err)
+		}
+		total += processed
+	}
+	return total, nil
+}
+
+func (s *BackfillService) register(ctx context.Context) error {
+	if err := validateBackfillDefinitions(s.definitions); err != nil {
+		return err
+	}
+
+	for _, def := range s.definitions {
+		batchSize := def.BatchSize
+		if batchSize <= 0 {
+			batchSize = s.config.BatchSize
+		}
+
+		estimate, err := database.EstimateRowCount(ctx, s.pool, def.Table)
+		if err != nil {
+			return fmt.Errorf("estimate rows of %s: %w", def.Table, err)
+		}
+
+		if err := s.repos.Backfills.Register(ctx, domain.NewBackfill(def.Name, def.Table, batchSize, estimate)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *BackfillService) runBatch(ctx context.Context, def BackfillDefinition) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := database.SetLockTimeout(ctx, tx, s.config.LockTimeout); err != nil {
+		return 0, err
+	}
+
+	backfills := s.repos.WithTx(tx).Backfills
+
+	job, err := backfills.LockPending(ctx, def.Name)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Completed, or another instance holds the job
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	after := uuid.Nil
+	if job.CursorID != nil {
+		after = *job.CursorID
+	}
+
+	// The savepoint keeps the job row locked and updatable when the batch fails
+	batch, err := tx.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cursor, processed, batchErr := database.RunBackfillBatch(ctx, batch, def.Statement, after, job.BatchSize)
+	if batchErr != nil {
+		_ = batch.Rollback(ctx)
+		job.RecordFailure(batchErr)
+	} else {
+		if err := batch.Commit(ctx); err != nil {
+			return 0, err
+		}
+		job.RecordBatch(cursor, processed)
+	}
+
+	if err := backfills.UpdateProgress(ctx, job); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	if batchErr != nil {
+		return 0, batchErr
+	}
+
+	if job.Status == domain.BackfillStatusCompleted {
+		s.logger.Info("Backfill completed",
+			zap.String("backfill", job.Name),
+			zap.Int64("rows_processed", job.RowsProcessed))
+	}
+
+	return processed, nil
+}
+
+func validateBackfillDefinitions(definitions []BackfillDefinition) error {
+	seen := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		if def.Name == "" || def.Table == "" || def.Statement == "" {
+			return fmt.Errorf("backfill %q: name, table and statement are required", def.Name)
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("backfill %q is registered twice", def.Name)
+		}
+		seen[def.Name] = true
+	}
+	return nil
+}
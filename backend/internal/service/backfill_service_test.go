@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBackfillDefinitions(t *testing.T) {
+	valid := BackfillDefinition{
+		Name:      "conversation_refs_example",
+		Table:     "conversation_refs",
+		Statement: "SELECT id FROM conversation_refs WHERE id > $1 ORDER BY id LIMIT $2",
+	}
+
+	tests := []struct {
+		name        string
+		definitions []BackfillDefinition
+		wantErr     bool
+	}{
+		{"empty registry", nil, false},
+		{"valid", []BackfillDefinition{valid}, false},
+		{"missing statement", []BackfillDefinition{{Name: "x", Table: "conversation_refs"}}, true},
+		{"duplicate name", []BackfillDefinition{valid, valid}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackfillDefinitions(tt.definitions)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegisteredBackfillsAreValid(t *testing.T) {
+	assert.NoError(t, validateBackfillDefinitions(Backfills))
+}
+
+func TestNewBackfillService_Defaults(t *testing.T) {
+	svc := NewBackfillService(nil, nil, nil, BackfillConfig{}, logger.NewNop())
+	assert.Equal(t, DefaultBackfillConfig(), svc.config)
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCategoryQuotaInboxNotFound = errors.New("category quota inbox not found")
+	ErrCategoryQuotaLabelInvalid  = errors.New("category quota label does not belong to the inbox")
+)
+
+var (
+	// Keyed by "<inbox_id>/<label_id>"
+	categoryQuotaAchieved            = metrics.NewGaugeMap("category_quota_achieved_percent")
+	categoryQuotaStarvationOverrides = metrics.NewCounter("category_quota_starvation_overrides_total")
+)
+
+// CategoryQuotaConfig holds configuration for category quotas
+type CategoryQuotaConfig struct {
+	// Window is how far back the achieved share of each category is measured
+	Window time.Duration
+	// MaxWait is how long a conversation may stay queued before it is
+	// allocated ahead of the preferred categories
+	MaxWait time.Duration
+}
+
+// DefaultCategoryQuotaConfig returns sensible defaults
+func DefaultCategoryQuotaConfig() CategoryQuotaConfig {
+	return CategoryQuotaConfig{
+		Window:  time.Hour,
+		MaxWait: 10 * time.Minute,
+	}
+}
+
+// CategoryShare is the requested share of allocations for one label
+type CategoryShare struct {
+	LabelID      uuid.UUID
+	SharePercent int
+}
+
+// CategoryQuotaService manages per-inbox category quotas and tells
+// allocation which categories are behind their share. Shares are measured
+// over the allocations and claims of the last Window, from the audit log.
+type CategoryQuotaService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	audit  *AuditService
+	config CategoryQuotaConfig
+	logger *logger.Logger
+}
+
+func NewCategoryQuotaService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, audit *AuditService, config CategoryQuotaConfig, log *logger.Logger) *CategoryQuotaService {
+	return &CategoryQuotaService{
+		repos:  repos,
+		pool:   pool,
+		audit:  audit,
+		config: config,
+		logger: log,
+	}
+}
+
+// ==================== Quotas ====================
+
+// GetQuotas returns the inbox's quotas with the share each category received
+// over the measurement window
+// Permission: Manager+ (enforced by router)
+func (s *CategoryQuotaService) GetQuotas(ctx context.Context, tenantID, inboxID uuid.UUID) ([]*domain.CategoryQuotaStatus, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	quotas, err := s.repos.CategoryQuotas.ListByInbox(ctx, inboxID)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, tenantID, inboxID, quotas)
+}
+
+// SetQuotas replaces the inbox's quotas; an empty list removes them
+// Permission: Manager+ (enforced by router)
+func (s *CategoryQuotaService) SetQuotas(ctx context.Context, tenantID, inboxID uuid.UUID, shares []CategoryShare, updatedBy *uuid.UUID) ([]*domain.CategoryQuotaStatus, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+
+	quotas := make([]*domain.CategoryQuota, len(shares))
+	for i, share := range shares {
+		label, err := s.repos.Labels.GetByID(ctx, share.LabelID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, ErrCategoryQuotaLabelInvalid
+			}
+			return nil, err
+		}
+		if label.TenantID != tenantID || !label.AppliesTo(inboxID) {
+			return nil, ErrCategoryQuotaLabelInvalid
+		}
+		quotas[i] = domain.NewCategoryQuota(tenantID, inboxID, share.LabelID, share.SharePercent, updatedBy)
+	}
+	if err := domain.ValidateCategoryQuotas(quotas); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repos.CategoryQuotas.ListByInbox(ctx, inboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	repo := s.repos.WithTx(tx).CategoryQuotas
+	if err := repo.DeleteByInbox(ctx, inboxID); err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		if err := repo.Create(ctx, quota); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inbox category quotas updated",
+		zap.String("inbox_id", inboxID.String()),
+		zap.Int("quotas", len(quotas)),
+		zap.Any("updated_by", uuidPtrToString(updatedBy)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionInboxCategoryQuotas, domain.AuditEntityInbox, inboxID,
+		categoryQuotaAuditSnapshot(previous), categoryQuotaAuditSnapshot(quotas)))
+
+	return s.status(ctx, tenantID, inboxID, quotas)
+}
+
+// ==================== Allocation ====================
+
+// AllocationPreference returns how the next allocation out of the inboxes
+// honors their quotas, or nil when none of them has any. Each inbox
+// contributes the category furthest behind its share, if one is.
+func (s *CategoryQuotaService) AllocationPreference(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID) (*domain.AllocationPreference, error) {
+	quotas, err := s.repos.CategoryQuotas.ListByInboxIDs(ctx, inboxIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	byInbox := make(map[uuid.UUID][]*domain.CategoryQuota)
+	for _, quota := range quotas {
+		byInbox[quota.InboxID] = append(byInbox[quota.InboxID], quota)
+	}
+
+	pref := &domain.AllocationPreference{StarvedBefore: time.Now().UTC().Add(-s.config.MaxWait)}
+	for inboxID, inboxQuotas := range byInbox {
+		statuses, err := s.status(ctx, tenantID, inboxID, inboxQuotas)
+		if err != nil {
+			return nil, err
+		}
+		allocated := make(map[uuid.UUID]int, len(statuses))
+		total := 0
+		for _, status := range statuses {
+			allocated[status.Quota.LabelID] = status.Allocations
+		}
+		if len(statuses) > 0 {
+			total = statuses[0].Total
+		}
+		if label := domain.PreferredCategory(inboxQuotas, allocated, total); label != nil {
+			pref.LabelIDs = append(pref.LabelIDs, *label)
+		}
+	}
+	return pref, nil
+}
+
+// RecordAllocation counts the conversations allocated under a preference
+// only because they had waited past MaxWait
+func (s *CategoryQuotaService) RecordAllocation(pref *domain.AllocationPreference, convs []*domain.ConversationRef) {
+	if pref == nil {
+		return
+	}
+	for _, conv := range convs {
+		if conv.CreatedAt.Before(pref.StarvedBefore) {
+			categoryQuotaStarvationOverrides.Inc()
+		}
+	}
+}
+
+// ==================== Helpers ====================
+
+// status measures the share each quota's category received over the window
+// and publishes it as category_quota_achieved_percent
+func (s *CategoryQuotaService) status(ctx context.Context, tenantID, inboxID uuid.UUID, quotas []*domain.CategoryQuota) ([]*domain.CategoryQuotaStatus, error) {
+	statuses := make([]*domain.CategoryQuotaStatus, len(quotas))
+	if len(quotas) == 0 {
+		return statuses, nil
+	}
+
+	since := time.Now().UTC().Add(-s.config.Window)
+	total, err := s.repos.AuditLogs.CountInboxAllocations(ctx, tenantID, inboxID, since)
+	if err != nil {
+		return nil, err
+	}
+	labelIDs := make([]uuid.UUID, len(quotas))
+	for i, quota := range quotas {
+		labelIDs[i] = quota.LabelID
+	}
+	allocated, err := s.repos.AuditLogs.CountInboxAllocationsByLabel(ctx, tenantID, inboxID, labelIDs, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, quota := range quotas {
+		achieved := domain.AchievedSharePercent(allocated[quota.LabelID], total)
+		statuses[i] = &domain.CategoryQuotaStatus{
+			Quota:                quota,
+			Allocations:          allocated[quota.LabelID],
+			Total:                total,
+			AchievedSharePercent: achieved,
+		}
+		categoryQuotaAchieved.Set(inboxID.String()+"/"+quota.LabelID.String(), int64(math.Round(achieved)))
+	}
+	return statuses, nil
+}
+
+func (s *CategoryQuotaService) verifyInbox(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrCategoryQuotaInboxNotFound
+		}
+		return err
+	}
+	if inbox.TenantID != tenantID {
+		return ErrCategoryQuotaInboxNotFound
+	}
+	return nil
+}
+
+// categoryQuotaAuditSnapshot maps label IDs to their share
+func categoryQuotaAuditSnapshot(quotas []*domain.CategoryQuota) map[string]interface{} {
+	shares := make(map[string]interface{}, len(quotas))
+	for _, quota := range quotas {
+		shares[quota.LabelID.String()] = quota.SharePercent
+	}
+	return map[string]interface{}{"share_percent": shares}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChecklistInboxNotFound = errors.New("checklist inbox not found")
+	ErrChecklistItemNotFound  = errors.New("checklist item not found")
+	ErrChecklistIncomplete    = errors.New("conversation checklist has unticked items")
+)
+
+var (
+	checklistItemsInstantiated = metrics.NewCounter("checklist_items_instantiated_total")
+	checklistResolvesBlocked   = metrics.NewCounter("checklist_resolves_blocked_total")
+)
+
+// ChecklistService manages per-inbox checklist templates and the checklists
+// instantiated from them. A template's items and pinned note are copied onto
+// a conversation when it is allocated without a checklist yet; operators
+// tick the items, and a strict template keeps the conversation from being
+// resolved until all are ticked.
+type ChecklistService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewChecklistService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *ChecklistService {
+	return &ChecklistService{
+		repos:  repos,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// ==================== Templates ====================
+
+// GetTemplate returns the inbox's template; an empty one if it has none
+// Permission: Manager+ (enforced by router)
+func (s *ChecklistService) GetTemplate(ctx context.Context, tenantID, inboxID uuid.UUID) (*domain.ChecklistTemplate, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	template, err := s.repos.Checklists.GetTemplate(ctx, inboxID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.NewChecklistTemplate(tenantID, inboxID, nil, nil, false, nil), nil
+	}
+	return template, err
+}
+
+// SetTemplate replaces the inbox's template; an empty template removes it.
+// Conversations that already have a checklist keep theirs.
+// Permission: Manager+ (enforced by router)
+func (s *ChecklistService) SetTemplate(ctx context.Context, tenantID, inboxID uuid.UUID, items []string, pinnedNote *string, strict bool, updatedBy *uuid.UUID) (*domain.ChecklistTemplate, error) {
+	previous, err := s.GetTemplate(ctx, tenantID, inboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := domain.NewChecklistTemplate(tenantID, inboxID, items, pinnedNote, strict, updatedBy)
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if template.IsEmpty() {
+		err = s.repos.Checklists.DeleteTemplate(ctx, inboxID)
+	} else {
+		err = s.repos.Checklists.SaveTemplate(ctx, template)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inbox checklist template updated",
+		zap.String("inbox_id", inboxID.String()),
+		zap.Int("items", len(template.Items)),
+		zap.Bool("strict", template.Strict),
+		zap.Any("updated_by", uuidPtrToString(updatedBy)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionInboxChecklistTemplate, domain.AuditEntityInbox, inboxID,
+		checklistTemplateAuditSnapshot(previous), checklistTemplateAuditSnapshot(template)))
+
+	return template, nil
+}
+
+// ==================== Conversation Checklists ====================
+
+// GetChecklist returns the conversation's checklist and pinned note
+func (s *ChecklistService) GetChecklist(ctx context.Context, tenantID, conversationID uuid.UUID) (*domain.ConversationChecklist, error) {
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	return s.checklist(ctx, conv)
+}
+
+// SetItemCompleted ticks or unticks an item of the conversation's checklist
+// Permission: Owner (assigned operator), Manager, or Admin
+func (s *ChecklistService) SetItemCompleted(ctx context.Context, tenantID, callerID, conversationID, itemID uuid.UUID, completed bool, callerRole domain.OperatorRole) (*domain.ConversationChecklist, error) {
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State == domain.ConversationStateResolved {
+		return nil, ErrConversationAlreadyResolved
+	}
+
+	owner := conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == callerID
+	if !owner && callerRole != domain.OperatorRoleAdmin && callerRole != domain.OperatorRoleManager {
+		s.logger.Warn("Checklist update attempt without permission",
+			zap.String("conversation_id", conversationID.String()),
+			zap.String("caller_id", callerID.String()),
+			zap.String("caller_role", string(callerRole)))
+		return nil, ErrInsufficientPermissions
+	}
+
+	item, err := s.repos.Checklists.GetItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrChecklistItemNotFound
+		}
+		return nil, err
+	}
+	if item.ConversationID != conversationID {
+		return nil, ErrChecklistItemNotFound
+	}
+
+	before := checklistItemAuditSnapshot(item)
+	item.SetCompleted(completed, callerID)
+	if err := s.repos.Checklists.UpdateItemCompletion(ctx, item); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Checklist item updated",
+		zap.String("conversation_id", conversationID.String()),
+		zap.String("item_id", itemID.String()),
+		zap.Bool("completed", completed),
+		zap.String("updated_by", callerID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationChecklist, domain.AuditEntityConversation, conversationID,
+		before, checklistItemAuditSnapshot(item)))
+
+	return s.checklist(ctx, conv)
+}
+
+// ==================== Helpers ====================
+
+func (s *ChecklistService) checklist(ctx context.Context, conv *domain.ConversationRef) (*domain.ConversationChecklist, error) {
+	items, err := s.repos.Checklists.ListItems(ctx, conv.ID)
+	if err != nil {
+		return nil, err
+	}
+	note, err := s.repos.ConversationNotes.GetLatest(ctx, conv.ID, domain.ConversationNotePinned)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	strict, err := checklistStrict(ctx, s.repos.Checklists, conv.InboxID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ConversationChecklist{
+		ConversationID: conv.ID,
+		Items:          items,
+		PinnedNote:     note,
+		Strict:         strict,
+	}, nil
+}
+
+func (s *ChecklistService) verifyInbox(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrChecklistInboxNotFound
+		}
+		return err
+	}
+	if inbox.TenantID != tenantID {
+		return ErrChecklistInboxNotFound
+	}
+	return nil
+}
+
+// instantiateChecklists copies their inbox templates onto newly allocated
+// conversations; run it in the allocation transaction
+func instantiateChecklists(ctx context.Context, checklists domain.ChecklistRepository, convs []*domain.ConversationRef) error {
+	ids := make([]uuid.UUID, len(convs))
+	for i, conv := range convs {
+		ids[i] = conv.ID
+	}
+	n, err := checklists.Instantiate(ctx, ids)
+	if err != nil {
+		return err
+	}
+	checklistItemsInstantiated.Add(n)
+	return nil
+}
+
+// resolveChecklist returns the conversation's checklist status for
+// resolution, or ErrChecklistIncomplete when its inbox template is strict
+// and items are unticked
+func resolveChecklist(ctx context.Context, checklists domain.ChecklistRepository, conv *domain.ConversationRef) (domain.ChecklistStatus, error) {
+	status, err := checklists.GetStatus(ctx, conv.ID)
+	if err != nil || status.Complete() {
+		return status, err
+	}
+	strict, err := checklistStrict(ctx, checklists, conv.InboxID)
+	if err != nil {
+		return status, err
+	}
+	if strict {
+		checklistResolvesBlocked.Inc()
+		return status, ErrChecklistIncomplete
+	}
+	return status, nil
+}
+
+func checklistStrict(ctx context.Context, checklists domain.ChecklistRepository, inboxID uuid.UUID) (bool, error) {
+	template, err := checklists.GetTemplate(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return template.Strict, nil
+}
+
+func checklistTemplateAuditSnapshot(t *domain.ChecklistTemplate) map[string]interface{} {
+	var note interface{}
+	if t.PinnedNote != nil {
+		note = *t.PinnedNote
+	}
+	items := t.Items
+	if items == nil {
+		items = []string{}
+	}
+	return map[string]interface{}{
+		"items":       items,
+		"pinned_note": note,
+		"strict":      t.Strict,
+	}
+}
+
+func checklistItemAuditSnapshot(item *domain.ChecklistItem) map[string]interface{} {
+	return map[string]interface{}{
+		"item_id":   item.ID.String(),
+		"label":     item.Label,
+		"completed": item.CompletedAt != nil,
+	}
+}
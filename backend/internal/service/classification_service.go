@@ -0,0 +1,296 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrClassifierNotConfigured = errors.New("classifier not configured")
+
+	errEmptyClassification = errors.New("classifier returned neither category nor language")
+)
+
+var (
+	classifierRequests = metrics.NewCounter("classifier_requests_total")
+	classifierFailures = metrics.NewCounter("classifier_failures_total")
+	classifierTimeouts = metrics.NewCounter("classifier_timeouts_total")
+	classifierLatency  = metrics.NewHistogram("classifier_latency_ms",
+		[]int64{25, 50, 100, 250, 500, 1000, 2500, 5000})
+)
+
+// ClassificationInput is what the classifier sees of an inbound message.
+// ConversationID is unset when the message starts a new conversation.
+type ClassificationInput struct {
+	TenantID               uuid.UUID  `json:"tenant_id"`
+	ConversationID         *uuid.UUID `json:"conversation_id,omitempty"`
+	ExternalConversationID string     `json:"external_conversation_id"`
+	CustomerPhoneNumber    string     `json:"customer_phone_number"`
+	Text                   string     `json:"text,omitempty"`
+	// Language is the one the messaging gateway reported, if any
+	Language   string    `json:"language,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// ClassifierResult is a classifier's answer before normalization; either
+// field may be empty, not both
+type ClassifierResult struct {
+	Category string `json:"category"`
+	Language string `json:"language"`
+}
+
+// Classifier assigns an intent category and detects the language of a
+// message. HTTPClassifier calls the tenant's endpoint; other backends plug in
+// through this interface.
+type Classifier interface {
+	Classify(ctx context.Context, endpoint *domain.TenantClassifier, input ClassificationInput) (ClassifierResult, error)
+}
+
+// Classification is the normalized outcome of classifying a message; a nil
+// field leaves the conversation's current value
+type Classification struct {
+	Category *string
+	Language *string
+}
+
+// withLanguage returns c with the language reported by the messaging
+// gateway, which takes precedence over the classifier's; c may be nil
+func (c *Classification) withLanguage(language *string) *Classification {
+	if language == nil {
+		return c
+	}
+	result := Classification{Language: language}
+	if c != nil {
+		result.Category = c.Category
+	}
+	return &result
+}
+
+// normalizeClassification checks every attribute the classifier returned
+func normalizeClassification(result ClassifierResult) (*Classification, error) {
+	var c Classification
+	if result.Category != "" {
+		category, err := domain.NormalizeCategory(result.Category)
+		if err != nil {
+			return nil, err
+		}
+		c.Category = &category
+	}
+	if result.Language != "" {
+		language, err := domain.NormalizeLanguage(result.Language)
+		if err != nil {
+			return nil, err
+		}
+		c.Language = &language
+	}
+	if c.Category == nil && c.Language == nil {
+		return nil, errEmptyClassification
+	}
+	return &c, nil
+}
+
+// ClassifierConfig holds configuration for conversation classification
+type ClassifierConfig struct {
+	// Timeout bounds a single classification; ingestion never waits longer
+	Timeout time.Duration
+}
+
+// DefaultClassifierConfig returns sensible defaults
+func DefaultClassifierConfig() ClassifierConfig {
+	return ClassifierConfig{
+		Timeout: 2 * time.Second,
+	}
+}
+
+// ClassificationService manages tenant classifier endpoints and runs the
+// classifier for ingested messages. Classification is best effort: on error,
+// timeout or an invalid answer the conversation keeps its current category
+// and language.
+type ClassificationService struct {
+	repos      *repository.RepositoryContainer
+	classifier Classifier
+	config     ClassifierConfig
+	audit      *AuditService
+	logger     *logger.Logger
+}
+
+func NewClassificationService(repos *repository.RepositoryContainer, classifier Classifier, config ClassifierConfig, audit *AuditService, log *logger.Logger) *ClassificationService {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultClassifierConfig().Timeout
+	}
+	return &ClassificationService{
+		repos:      repos,
+		classifier: classifier,
+		config:     config,
+		audit:      audit,
+		logger:     log,
+	}
+}
+
+// ==================== Configuration ====================
+
+// GetConfig returns the tenant's classifier configuration
+// Permission: Admin (enforced by router)
+func (s *ClassificationService) GetConfig(ctx context.Context, tenantID uuid.UUID) (*domain.TenantClassifier, error) {
+	cfg, err := s.repos.TenantClassifiers.Get(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrClassifierNotConfigured
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Configure sets the tenant's classifier endpoint and enable flag
+// Permission: Admin (enforced by router)
+func (s *ClassificationService) Configure(ctx context.Context, tenantID uuid.UUID, endpointURL string, enabled bool, updatedBy *uuid.UUID) (*domain.TenantClassifier, error) {
+	cfg := domain.NewTenantClassifier(tenantID, endpointURL, enabled, updatedBy)
+
+	var before map[string]interface{}
+	existing, err := s.repos.TenantClassifiers.Get(ctx, tenantID)
+	switch {
+	case err == nil:
+		before = classifierAuditSnapshot(existing)
+		cfg.CreatedAt = existing.CreatedAt
+	case !errors.Is(err, domain.ErrNotFound):
+		return nil, err
+	}
+
+	if err := s.repos.TenantClassifiers.Upsert(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant classifier configured",
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("endpoint_url", endpointURL),
+		zap.Bool("enabled", enabled))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionTenantClassifierChange, domain.AuditEntityTenant, tenantID,
+		before, classifierAuditSnapshot(cfg)))
+
+	return cfg, nil
+}
+
+func classifierAuditSnapshot(cfg *domain.TenantClassifier) map[string]interface{} {
+	return map[string]interface{}{
+		"endpoint_url": cfg.EndpointURL,
+		"is_enabled":   cfg.IsEnabled,
+	}
+}
+
+// ==================== Classification ====================
+
+// Endpoint returns the tenant's classifier when it is enabled. Lookup errors
+// disable classification for the call rather than failing ingestion.
+func (s *ClassificationService) Endpoint(ctx context.Context, tenantID uuid.UUID) *domain.TenantClassifier {
+	cfg, err := s.repos.TenantClassifiers.Get(ctx, tenantID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.Warn("Failed to load tenant classifier",
+				zap.String("tenant_id", tenantID.String()),
+				zap.Error(err))
+		}
+		return nil
+	}
+	if !cfg.IsEnabled {
+		return nil
+	}
+	return cfg
+}
+
+// Classify runs the classifier within the configured timeout and returns the
+// normalized category and language, or nil when classification failed
+// (fallback: the caller keeps the current values). An answer with an invalid
+// category or language is a failure as a whole. Must not be called while
+// holding row locks: the call can take up to ClassifierConfig.Timeout.
+func (s *ClassificationService) Classify(ctx context.Context, endpoint *domain.TenantClassifier, input ClassificationInput) *Classification {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	raw, err := s.classifier.Classify(ctx, endpoint, input)
+	classifierRequests.Inc()
+	classifierLatency.Observe(time.Since(start).Milliseconds())
+
+	if err == nil {
+		var classification *Classification
+		if classification, err = normalizeClassification(raw); err == nil {
+			return classification
+		}
+	}
+
+	classifierFailures.Inc()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		classifierTimeouts.Inc()
+	}
+	s.logger.Warn("Classification failed, keeping current category and language",
+		zap.String("tenant_id", input.TenantID.String()),
+		zap.String("external_conversation_id", input.ExternalConversationID),
+		zap.Duration("duration", time.Since(start)),
+		zap.Error(err))
+	return nil
+}
+
+// ==================== HTTP Classifier ====================
+
+// maxClassifierResponseBytes bounds the response body read from an endpoint
+const maxClassifierResponseBytes = 64 << 10
+
+// HTTPClassifier POSTs the ClassificationInput as JSON to the tenant endpoint,
+// which answers 2xx with {"category": "<category>", "language": "<language>"};
+// either may be omitted
+type HTTPClassifier struct {
+	client *http.Client
+}
+
+// NewHTTPClassifier creates a classifier; timeouts come from the caller's
+// context. client must refuse the service's own network (see
+// outbound.NewClient), since tenants choose the endpoints it calls.
+func NewHTTPClassifier(client *http.Client) *HTTPClassifier {
+	return &HTTPClassifier{client: client}
+}
+
+func (c *HTTPClassifier) Classify(ctx context.Context, endpoint *domain.TenantClassifier, input ClassificationInput) (ClassifierResult, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return ClassifierResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.EndpointURL, bytes.NewReader(body))
+	if err != nil {
+		return ClassifierResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return ClassifierResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return ClassifierResult{}, fmt.Errorf("classifier responded with status %d", resp.StatusCode)
+	}
+
+	var out ClassifierResult
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxClassifierResponseBytes)).Decode(&out); err != nil {
+		return ClassifierResult{}, fmt.Errorf("decode classifier response: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/outbound"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassificationService_Classify(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	svc := NewClassificationService(nil, NewHTTPClassifier(http.DefaultClient), ClassifierConfig{Timeout: 200 * time.Millisecond}, nil, logger.NewNop())
+	input := ClassificationInput{
+		TenantID:               uuid.New(),
+		ExternalConversationID: "ext-1",
+		CustomerPhoneNumber:    "+15550100",
+		Text:                   "I was charged twice",
+		ReceivedAt:             time.Now().UTC(),
+	}
+
+	endpointFor := func(server *httptest.Server) *domain.TenantClassifier {
+		return domain.NewTenantClassifier(input.TenantID, server.URL, true, nil)
+	}
+
+	t.Run("normalizes returned category", func(t *testing.T) {
+		var got ClassificationInput
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"category":" Billing "}`))
+		}))
+		defer server.Close()
+
+		result := svc.Classify(ctx, endpointFor(server), input)
+
+		require.NotNil(t, result)
+		require.NotNil(t, result.Category)
+		assert.Equal(t, "billing", *result.Category)
+		assert.Nil(t, result.Language)
+		assert.Equal(t, input.Text, got.Text)
+		assert.Equal(t, input.ExternalConversationID, got.ExternalConversationID)
+	})
+
+	t.Run("normalizes returned language", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"language":"es-MX"}`))
+		}))
+		defer server.Close()
+
+		result := svc.Classify(ctx, endpointFor(server), input)
+
+		require.NotNil(t, result)
+		assert.Nil(t, result.Category)
+		require.NotNil(t, result.Language)
+		assert.Equal(t, "es", *result.Language)
+	})
+
+	t.Run("invalid language falls back", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"category":"billing","language":"spanish"}`))
+		}))
+		defer server.Close()
+
+		assert.Nil(t, svc.Classify(ctx, endpointFor(server), input))
+	})
+
+	t.Run("non-2xx falls back", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		assert.Nil(t, svc.Classify(ctx, endpointFor(server), input))
+	})
+
+	t.Run("invalid category falls back", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"category":"billing & payments"}`))
+		}))
+		defer server.Close()
+
+		assert.Nil(t, svc.Classify(ctx, endpointFor(server), input))
+	})
+
+	t.Run("timeout falls back", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer server.Close()
+
+		timeouts := classifierTimeouts.Value()
+		start := time.Now()
+
+		assert.Nil(t, svc.Classify(ctx, endpointFor(server), input))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, timeouts+1, classifierTimeouts.Value())
+	})
+
+	t.Run("internal address falls back without a request", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			_, _ = w.Write([]byte(`{"category":"billing"}`))
+		}))
+		defer server.Close()
+
+		guarded := NewClassificationService(nil, NewHTTPClassifier(outbound.NewClient()), ClassifierConfig{Timeout: 200 * time.Millisecond}, nil, logger.NewNop())
+
+		assert.Nil(t, guarded.Classify(ctx, endpointFor(server), input))
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	compatSchemaVersion  = metrics.NewGauge("schema_version")
+	compatWorkersEnabled = metrics.NewGauge("workers_enabled")
+)
+
+// CompatibilityConfig holds configuration for the startup compatibility gate
+type CompatibilityConfig struct {
+	InstanceID    uuid.UUID
+	BinaryVersion string
+	// InstanceTimeout is how long after its last heartbeat a replica stops
+	// counting as live
+	InstanceTimeout time.Duration
+}
+
+// DefaultCompatibilityConfig returns sensible defaults
+func DefaultCompatibilityConfig() CompatibilityConfig {
+	return CompatibilityConfig{
+		InstanceID:      uuid.New(),
+		BinaryVersion:   "dev",
+		InstanceTimeout: time.Minute,
+	}
+}
+
+// CompatibilityReport is the outcome of a compatibility check
+type CompatibilityReport struct {
+	SchemaVersion  int64
+	SchemaDirty    bool
+	WorkersEnabled bool
+	// Reason explains why workers are disabled
+	Reason string
+}
+
+// CompatibilityService keeps replicas of different versions from running
+// workers side by side during a rolling upgrade. Each replica records the
+// schema range and worker protocol it was built for; it only runs workers
+// while the schema is in that range and no live replica runs a newer worker
+// protocol. A replica without workers still serves the API.
+type CompatibilityService struct {
+	repos  *repository.RepositoryContainer
+	config CompatibilityConfig
+	logger *logger.Logger
+
+	startedAt time.Time
+}
+
+func NewCompatibilityService(repos *repository.RepositoryContainer, config CompatibilityConfig, log *logger.Logger) *CompatibilityService {
+	return &CompatibilityService{
+		repos:     repos,
+		config:    config,
+		logger:    log,
+		startedAt: time.Now().UTC(),
+	}
+}
+
+// Check decides whether this replica may run workers and records the
+// decision, with a fresh heartbeat, for its peers. Replicas whose heartbeat
+// expired are removed first.
+func (s *CompatibilityService) Check(ctx context.Context) (*CompatibilityReport, error) {
+	now := time.Now().UTC()
+	cutoff := now.Add(-s.config.InstanceTimeout)
+
+	schema, err := s.repos.WorkerInstances.GetSchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CompatibilityReport{SchemaVersion: schema.Version, SchemaDirty: schema.Dirty}
+
+	// The table itself may not exist yet on a schema older than this binary
+	if reason := domain.CheckWorkerCompatibility(*schema, 0); reason != "" {
+		report.Reason = reason
+		s.setGauges(report)
+		return report, nil
+	}
+
+	if removed, err := s.repos.WorkerInstances.DeleteStale(ctx, cutoff); err != nil {
+		return nil, err
+	} else if removed > 0 {
+		s.logger.Info("Removed stale worker instances", zap.Int64("removed", removed))
+	}
+
+	peer, err := s.repos.WorkerInstances.NewestLiveProtocol(ctx, s.config.InstanceID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	report.Reason = domain.CheckWorkerCompatibility(*schema, peer)
+	report.WorkersEnabled = report.Reason == ""
+
+	instance := domain.NewWorkerInstance(s.config.InstanceID, s.config.BinaryVersion, report.WorkersEnabled, now)
+	instance.StartedAt = s.startedAt
+	if err := s.repos.WorkerInstances.Upsert(ctx, instance); err != nil {
+		return nil, err
+	}
+
+	s.setGauges(report)
+	return report, nil
+}
+
+// Deregister removes this replica on shutdown so it no longer counts as live
+func (s *CompatibilityService) Deregister(ctx context.Context) error {
+	return s.repos.WorkerInstances.Delete(ctx, s.config.InstanceID)
+}
+
+func (s *CompatibilityService) setGauges(report *CompatibilityReport) {
+	compatSchemaVersion.Set(report.SchemaVersion)
+	if report.WorkersEnabled {
+		compatWorkersEnabled.Set(1)
+	} else {
+		compatWorkersEnabled.Set(0)
+	}
+}
@@ -2,25 +2,51 @@ package service
 
 import (
 	"context"
-	"math"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/api/dto"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/database"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/retry"
 	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrMessageOnResolvedConversation = errors.New("cannot record message on resolved conversation")
+	ErrIngestInboxNotFound           = errors.New("inbox not found for ingested message")
+	ErrBreakGlassReasonRequired      = errors.New("a reason is required to open conversations in a restricted inbox")
+	ErrPriorityOverrideOnResolved    = errors.New("cannot override the priority of a resolved conversation")
+)
+
+// conflictRetry re-runs a read-modify-write of a conversation that lost its
+// version check to a concurrent update; each attempt must re-read the row
+var conflictRetry = retry.Config{
+	MaxAttempts:     3,
+	RetryableErrors: []error{domain.ErrConcurrentModification},
+}
+
 type ConversationService struct {
-	repos  *repository.RepositoryContainer
-	logger *logger.Logger
+	repos          *repository.RepositoryContainer
+	txMgr          *database.TxManager
+	classification *ClassificationService
+	ranking        *QueueRankingService
+	events         domain.EventPublisher
+	audit          *AuditService
+	logger         *logger.Logger
 }
 
-func NewConversationService(repos *repository.RepositoryContainer, log *logger.Logger) *ConversationService {
-	return &ConversationService{repos: repos, logger: log}
+// NewConversationService creates the service; classification may be nil to
+// disable message classification, ranking nil to skip marking queue ranks
+// stale on message and priority changes, events nil to skip publishing
+// conversation.created, audit nil to skip recording break-glass access
+func NewConversationService(repos *repository.RepositoryContainer, txMgr *database.TxManager, classification *ClassificationService, ranking *QueueRankingService, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *ConversationService {
+	return &ConversationService{repos: repos, txMgr: txMgr, classification: classification, ranking: ranking, events: events, audit: audit, logger: log}
 }
 
 // ==================== List Conversations ====================
@@ -35,6 +61,12 @@ type ListConversationsParams struct {
 	InboxID          *uuid.UUID
 	OperatorFilterID *uuid.UUID
 	LabelID          *uuid.UUID
+	Category         *string
+	Language         *string
+	CustomerID       *uuid.UUID
+	PhoneDigits      string
+	// Overdue lists only open conversations past their due date
+	Overdue bool
 
 	// Sorting
 	Sort string
@@ -46,29 +78,51 @@ type ListConversationsParams struct {
 
 func (s *ConversationService) List(ctx context.Context, params ListConversationsParams) ([]*domain.ConversationRef, error) {
 	// Get allowed inbox IDs based on role
-	var allowedInboxIDs []uuid.UUID
+	var allowedInboxIDs, shadowedOperatorIDs []uuid.UUID
 
 	if params.Role == domain.OperatorRoleOperator {
-		// Operators can only see conversations in their subscribed inboxes
+		// Operators can only see conversations in their subscribed inboxes,
+		// plus (read-only) the allocated conversations of mentors they shadow
 		ids, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, params.OperatorID)
 		if err != nil {
 			return nil, err
 		}
-		if len(ids) == 0 {
+		mentorIDs, err := s.repos.OperatorShadows.GetMentorIDs(ctx, params.OperatorID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 && len(mentorIDs) == 0 {
 			return []*domain.ConversationRef{}, nil
 		}
 		allowedInboxIDs = ids
+		shadowedOperatorIDs = mentorIDs
 	}
 
 	// Build query filters
 	filters := repository.ConversationFilters{
-		TenantID:        params.TenantID,
-		State:           params.State,
-		InboxID:         params.InboxID,
-		OperatorID:      params.OperatorFilterID,
-		LabelID:         params.LabelID,
-		AllowedInboxIDs: allowedInboxIDs,
-		Limit:           params.PerPage,
+		TenantID:            params.TenantID,
+		State:               params.State,
+		InboxID:             params.InboxID,
+		OperatorID:          params.OperatorFilterID,
+		Category:            params.Category,
+		Language:            params.Language,
+		CustomerID:          params.CustomerID,
+		PhoneDigits:         params.PhoneDigits,
+		AllowedInboxIDs:     allowedInboxIDs,
+		ShadowedOperatorIDs: shadowedOperatorIDs,
+		Limit:               params.PerPage,
+	}
+	if params.Overdue {
+		now := time.Now().UTC()
+		filters.OverdueAsOf = &now
+	}
+	// A label also matches the labels nested below it
+	if params.LabelID != nil {
+		labelIDs, err := s.repos.Labels.GetDescendantIDs(ctx, []uuid.UUID{*params.LabelID})
+		if err != nil {
+			return nil, err
+		}
+		filters.LabelIDs = labelIDs
 	}
 
 	// Apply cursor for pagination
@@ -92,6 +146,49 @@ func (s *ConversationService) List(ctx context.Context, params ListConversations
 	return conversations, nil
 }
 
+// ==================== Workload ====================
+
+type ListWorkloadParams struct {
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+	// Sort is dto.SortUrgency or dto.SortNewest
+	Sort string
+	// AsOf is the time conversations are scored at, that of the first page
+	AsOf    time.Time
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+// ListWorkload returns the operator's allocated conversations with their
+// urgency as of params.AsOf
+func (s *ConversationService) ListWorkload(ctx context.Context, params ListWorkloadParams) ([]*domain.ConversationUrgency, error) {
+	allocated := domain.ConversationStateAllocated
+	operatorID := params.OperatorID
+	filters := repository.ConversationFilters{
+		TenantID:        params.TenantID,
+		State:           &allocated,
+		OperatorID:      &operatorID,
+		UrgencyAsOf:     &params.AsOf,
+		UrgencyReaderID: params.OperatorID,
+		SortOrder:       params.Sort,
+		Limit:           params.PerPage,
+	}
+	if params.Cursor != nil {
+		filters.CursorTimestamp = &params.Cursor.Timestamp
+		filters.CursorID = &params.Cursor.ID
+		filters.CursorUrgency = params.Cursor.Urgency
+	}
+
+	items, err := s.repos.ConversationRefs.ListWithUrgency(ctx, filters)
+	if err != nil {
+		s.logger.Error("Failed to list workload",
+			zap.String("operator_id", params.OperatorID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	return items, nil
+}
+
 // ==================== Get Single Conversation ====================
 
 func (s *ConversationService) GetByID(ctx context.Context, tenantID, conversationID uuid.UUID) (*domain.ConversationRef, error) {
@@ -108,6 +205,19 @@ func (s *ConversationService) GetByID(ctx context.Context, tenantID, conversatio
 	return conv, nil
 }
 
+// GetHandoverNote returns the latest handover note addressed to the
+// conversation's current assignee, or nil if there is none
+func (s *ConversationService) GetHandoverNote(ctx context.Context, conv *domain.ConversationRef) (*domain.ConversationNote, error) {
+	if conv.State != domain.ConversationStateAllocated || conv.AssignedOperatorID == nil {
+		return nil, nil
+	}
+	note, err := s.repos.ConversationNotes.GetLatestForRecipient(ctx, conv.ID, domain.ConversationNoteHandover, *conv.AssignedOperatorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return note, err
+}
+
 // CanAccess checks if operator can access the conversation
 func (s *ConversationService) CanAccess(ctx context.Context, operatorID uuid.UUID, role domain.OperatorRole, conv *domain.ConversationRef) bool {
 	// Managers and Admins can access all conversations in tenant
@@ -120,46 +230,589 @@ func (s *ConversationService) CanAccess(ctx context.Context, operatorID uuid.UUI
 	if err != nil {
 		return false
 	}
+	if isSubscribed {
+		return true
+	}
+
+	// Trainees may also read the conversations allocated to their mentors
+	mentorIDs, err := s.repos.OperatorShadows.GetMentorIDs(ctx, operatorID)
+	if err != nil {
+		return false
+	}
+	return isShadowVisible(conv, mentorIDs)
+}
+
+// isShadowVisible reports whether conv is allocated to one of the mentors
+func isShadowVisible(conv *domain.ConversationRef, mentorIDs []uuid.UUID) bool {
+	if conv.State != domain.ConversationStateAllocated || conv.AssignedOperatorID == nil {
+		return false
+	}
+	for _, id := range mentorIDs {
+		if *conv.AssignedOperatorID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== Break-Glass Access ====================
+
+// isBreakGlass reports whether opening conv is break-glass access: a manager
+// or admin reading a conversation that is not assigned to them
+func isBreakGlass(actorID *uuid.UUID, role domain.OperatorRole, conv *domain.ConversationRef) bool {
+	if role != domain.OperatorRoleManager && role != domain.OperatorRoleAdmin {
+		return false
+	}
+	return actorID == nil || conv.AssignedOperatorID == nil || *conv.AssignedOperatorID != *actorID
+}
+
+// RecordBreakGlassAccess records a manager or admin opening a conversation not
+// assigned to them; other access is not recorded. A reason is required for
+// conversations in restricted inboxes, and ErrBreakGlassReasonRequired is
+// returned without recording when it is missing. actorID is nil for API key
+// callers.
+func (s *ConversationService) RecordBreakGlassAccess(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, conv *domain.ConversationRef, reason string) error {
+	if !isBreakGlass(actorID, role, conv) {
+		return nil
+	}
+
+	inbox, err := s.repos.Inboxes.GetByID(ctx, conv.InboxID)
+	if err != nil {
+		return err
+	}
+	if inbox.IsRestricted && reason == "" {
+		return ErrBreakGlassReasonRequired
+	}
+
+	s.logger.Info("Break-glass conversation access",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("tenant_id", conv.TenantID.String()),
+		zap.Any("actor_id", uuidPtrToString(actorID)),
+		zap.Bool("restricted", inbox.IsRestricted))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(conv.TenantID, actorID,
+		domain.AuditActionConversationBreakGlass, domain.AuditEntityConversation, conv.ID,
+		nil, breakGlassAuditSnapshot(conv, inbox, reason)))
 
-	return isSubscribed
+	return nil
+}
+
+func breakGlassAuditSnapshot(conv *domain.ConversationRef, inbox *domain.Inbox, reason string) map[string]interface{} {
+	var statedReason interface{}
+	if reason != "" {
+		statedReason = reason
+	}
+	return map[string]interface{}{
+		"reason":               statedReason,
+		"inbox_id":             inbox.ID.String(),
+		"restricted":           inbox.IsRestricted,
+		"state":                string(conv.State),
+		"assigned_operator_id": uuidPtrToString(conv.AssignedOperatorID),
+	}
+}
+
+// ==================== Read State ====================
+
+// MarkRead records that the operator viewed conv now; its unread flag clears
+// until the next customer message. For the assigned operator it also counts
+// as activity on the allocation, see StalenessService.
+func (s *ConversationService) MarkRead(ctx context.Context, operatorID uuid.UUID, conv *domain.ConversationRef) (*domain.ConversationRead, error) {
+	read := domain.NewConversationRead(conv, operatorID)
+	if err := s.repos.ConversationReads.MarkRead(ctx, read); err != nil {
+		return nil, err
+	}
+	if conv.IsAllocatedTo(operatorID) {
+		if err := recordOperatorActivity(ctx, s.repos, s.logger, conv, operatorID, domain.ActivityKindRead); err != nil {
+			s.logger.Warn("Failed to record read activity",
+				zap.String("conversation_id", conv.ID.String()),
+				zap.Error(err))
+		}
+	}
+	return read, nil
+}
+
+// UnreadFlags reports, per conversation ID, whether convs have customer
+// messages the operator has not viewed
+func (s *ConversationService) UnreadFlags(ctx context.Context, operatorID uuid.UUID, convs []*domain.ConversationRef) (map[uuid.UUID]bool, error) {
+	ids := make([]uuid.UUID, len(convs))
+	for i, conv := range convs {
+		ids[i] = conv.ID
+	}
+	reads, err := s.repos.ConversationReads.GetLastReadAt(ctx, operatorID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[uuid.UUID]bool, len(convs))
+	for _, conv := range convs {
+		var lastReadAt *time.Time
+		if at, ok := reads[conv.ID]; ok {
+			lastReadAt = &at
+		}
+		flags[conv.ID] = domain.IsUnread(conv, lastReadAt)
+	}
+	return flags, nil
+}
+
+// ==================== Customer Timeline ====================
+
+type CustomerTimelineParams struct {
+	TenantID    uuid.UUID
+	PhoneNumber string
+	OperatorID  uuid.UUID
+	Role        domain.OperatorRole
+
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+// CustomerTimeline returns the customer with the phone number and their
+// conversations across inboxes, most recent message first. Operators see
+// only the conversations List shows them.
+func (s *ConversationService) CustomerTimeline(ctx context.Context, params CustomerTimelineParams) (*domain.Customer, []*domain.ConversationRef, error) {
+	customer, err := s.repos.Customers.GetByPhone(ctx, params.TenantID, params.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil, ErrCustomerNotFound
+		}
+		return nil, nil, err
+	}
+
+	conversations, err := s.List(ctx, ListConversationsParams{
+		TenantID:   params.TenantID,
+		OperatorID: params.OperatorID,
+		Role:       params.Role,
+		CustomerID: &customer.ID,
+		Sort:       dto.SortNewest,
+		Cursor:     params.Cursor,
+		PerPage:    params.PerPage,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return customer, conversations, nil
 }
 
 // ==================== Search by Phone ====================
 
-func (s *ConversationService) SearchByPhone(ctx context.Context, tenantID uuid.UUID, phone string, operatorID uuid.UUID, role domain.OperatorRole) ([]*domain.ConversationRef, error) {
-	// Get conversations by phone
-	conversations, err := s.repos.ConversationRefs.GetByPhone(ctx, tenantID, phone)
+type SearchByPhoneParams struct {
+	TenantID uuid.UUID
+	// PhoneDigits start or end the phone numbers searched for
+	PhoneDigits string
+	OperatorID  uuid.UUID
+	Role        domain.OperatorRole
+
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+// SearchByPhone returns the conversations whose customer phone number starts
+// or ends with the digits, most recent message first. Operators see only the
+// conversations List shows them.
+func (s *ConversationService) SearchByPhone(ctx context.Context, params SearchByPhoneParams) ([]*domain.ConversationRef, error) {
+	return s.List(ctx, ListConversationsParams{
+		TenantID:    params.TenantID,
+		OperatorID:  params.OperatorID,
+		Role:        params.Role,
+		PhoneDigits: params.PhoneDigits,
+		Sort:        dto.SortNewest,
+		Cursor:      params.Cursor,
+		PerPage:     params.PerPage,
+	})
+}
+
+// ==================== Full-Text Search ====================
+
+type SearchTextParams struct {
+	TenantID uuid.UUID
+	// Query is matched against external conversation IDs, customer names
+	// and note bodies
+	Query string
+	// PhoneDigits, when set, narrow the matches as in SearchByPhone
+	PhoneDigits string
+	OperatorID  uuid.UUID
+	Role        domain.OperatorRole
+
+	Cursor  *dto.Cursor
+	PerPage int
+}
+
+// SearchText returns the conversations matching the query, best match
+// first. Operators see only the conversations List shows them, and match
+// only pinned notes and the handover notes they wrote or received.
+func (s *ConversationService) SearchText(ctx context.Context, params SearchTextParams) ([]*domain.ConversationSearchHit, error) {
+	filters := repository.ConversationFilters{
+		TenantID:    params.TenantID,
+		TextQuery:   params.Query,
+		PhoneDigits: params.PhoneDigits,
+		Limit:       params.PerPage,
+	}
+
+	if params.Role == domain.OperatorRoleOperator {
+		ids, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, params.OperatorID)
+		if err != nil {
+			return nil, err
+		}
+		mentorIDs, err := s.repos.OperatorShadows.GetMentorIDs(ctx, params.OperatorID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 && len(mentorIDs) == 0 {
+			return []*domain.ConversationSearchHit{}, nil
+		}
+		filters.AllowedInboxIDs = ids
+		filters.ShadowedOperatorIDs = mentorIDs
+		operatorID := params.OperatorID
+		filters.NoteVisibleTo = &operatorID
+	}
+
+	if params.Cursor != nil && params.Cursor.Rank != nil {
+		filters.CursorRank = params.Cursor.Rank
+		filters.CursorID = &params.Cursor.ID
+	}
+
+	hits, err := s.repos.ConversationRefs.SearchText(ctx, filters)
 	if err != nil {
+		s.logger.Error("Failed to search conversations",
+			zap.String("tenant_id", params.TenantID.String()),
+			zap.Error(err))
 		return nil, err
 	}
 
-	// If not admin/manager, filter by subscribed inboxes
-	if role == domain.OperatorRoleOperator {
-		inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
+	return hits, nil
+}
+
+// ==================== Message Received ====================
+
+// MessageReceivedResult is the conversation state after a message was recorded
+type MessageReceivedResult struct {
+	Conversation *domain.ConversationRef
+	Rules        *RuleOutcome
+}
+
+// RecordMessageReceived bumps message_count/last_message_at, recalculates the
+// priority and applies MESSAGE_RECEIVED routing rules. Everything runs in one
+// transaction holding the conversation row lock, so the next allocation sees
+// the labels and boosted score together with the new message count. When the
+// tenant classifier is enabled the message text is classified first, outside
+// the transaction.
+func (s *ConversationService) RecordMessageReceived(ctx context.Context, tenantID, conversationID uuid.UUID, receivedAt time.Time, text string) (*MessageReceivedResult, error) {
+	var classification *Classification
+	if endpoint := s.classifierEndpoint(ctx, tenantID); endpoint != nil {
+		if conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID); err == nil && conv.TenantID == tenantID {
+			classification = s.classification.Classify(ctx, endpoint, ClassificationInput{
+				TenantID:               tenantID,
+				ConversationID:         &conv.ID,
+				ExternalConversationID: conv.ExternalConversationID,
+				CustomerPhoneNumber:    conv.CustomerPhoneNumber,
+				Text:                   text,
+				ReceivedAt:             receivedAt,
+			})
+		}
+	}
+
+	var result *MessageReceivedResult
+
+	err := s.txMgr.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		repos := s.repos.WithTx(tx)
+		conversations := repos.ConversationRefs
+
+		conv, err := conversations.LockForUpdate(ctx, conversationID)
+		if err != nil {
+			return err
+		}
+		if conv.TenantID != tenantID {
+			return domain.ErrNotFound
+		}
+		if conv.State == domain.ConversationStateResolved {
+			return ErrMessageOnResolvedConversation
+		}
+
+		outcome, err := s.applyMessageReceived(ctx, repos, conv, receivedAt, classification, nil, false)
 		if err != nil {
+			return err
+		}
+
+		if err := conversations.Update(ctx, conv); err != nil {
+			return err
+		}
+
+		result = &MessageReceivedResult{Conversation: conv, Rules: outcome}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.markQueueStale(result.Conversation.InboxID)
+	s.logRuleOutcome(conversationID, result.Rules)
+
+	return result, nil
+}
+
+// applyMessageReceived counts the message, bumps last_message_at, records the
+// classified category and language (nil keeps the current ones), applies
+// MESSAGE_RECEIVED routing rules, then CONVERSATION_CREATED ones when the
+// message started the conversation, and recomputes the priority. metadata is
+// the message's external metadata, nil when none. The caller holds the row
+// lock and persists conv.
+func (s *ConversationService) applyMessageReceived(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, receivedAt time.Time, classification *Classification, metadata map[string]string, created bool) (*RuleOutcome, error) {
+	conv.MessageCount++
+	if receivedAt.After(conv.LastMessageAt) {
+		conv.LastMessageAt = receivedAt
+	}
+	if classification != nil {
+		if classification.Category != nil {
+			conv.Category = classification.Category
+		}
+		if classification.Language != nil {
+			conv.Language = classification.Language
+		}
+	}
+
+	weights := s.priorityWeights(ctx, conv.TenantID, conv)
+	if weights.experiment != nil {
+		if err := repos.Experiments.Assign(ctx, weights.experiment.ID, conv.ID, weights.arm); err != nil {
 			return nil, err
 		}
+	}
 
-		inboxSet := make(map[uuid.UUID]bool)
-		for _, id := range inboxIDs {
-			inboxSet[id] = true
+	triggers := []domain.RoutingRuleTrigger{domain.RoutingRuleTriggerMessageReceived}
+	if created {
+		triggers = append(triggers, domain.RoutingRuleTriggerConversationCreated)
+	}
+	outcome, err := applyRoutingRules(ctx, repos, conv, metadata, triggers...)
+	if err != nil {
+		return nil, err
+	}
+
+	components := domain.NewPriorityScoreComponents(conv, weights.alpha, weights.beta, outcome.PriorityBoost, weights.firstContact, weights.businessHours, time.Now().UTC())
+	if err := repos.PriorityComponents.Create(ctx, components); err != nil {
+		return nil, err
+	}
+	conv.PriorityScore = components.PriorityScore
+	conv.UpdatedAt = components.ComputedAt
+
+	return outcome, nil
+}
+
+func (s *ConversationService) logRuleOutcome(conversationID uuid.UUID, outcome *RuleOutcome) {
+	if len(outcome.MatchedRuleIDs) > 0 {
+		s.logger.Info("Routing rules applied on message received",
+			zap.String("conversation_id", conversationID.String()),
+			zap.Int("matched_rules", len(outcome.MatchedRuleIDs)),
+			zap.Int("attached_labels", len(outcome.AttachedLabels)),
+			zap.String("priority_boost", outcome.PriorityBoost.String()))
+	}
+}
+
+// ==================== Message Ingestion ====================
+
+// IngestMessageParams describes an inbound message reported by the external
+// messaging platform. The inbox is identified by ID or by the phone number
+// the customer wrote to; it is only used when the conversation is new.
+type IngestMessageParams struct {
+	TenantID               uuid.UUID
+	InboxID                *uuid.UUID
+	InboxPhoneNumber       string
+	ExternalConversationID string
+	CustomerPhoneNumber    string
+	ReceivedAt             time.Time
+	// Text is the message body, only sent to the tenant classifier
+	Text string
+	// Language is the normalized customer language reported by the gateway;
+	// it takes precedence over the classifier's, nil leaves it to the classifier
+	Language *string
+	// Metadata is the platform's external metadata for the message, only
+	// matched by metadata routing rules and not stored
+	Metadata map[string]string
+}
+
+// IngestMessageResult reports how the ingested message affected the conversation
+type IngestMessageResult struct {
+	Conversation *domain.ConversationRef
+	Rules        *RuleOutcome
+	// Created is set when the message started a new conversation
+	Created bool
+	// Reopened is set when a resolved conversation was returned to the queue
+	Reopened bool
+}
+
+// IngestMessage upserts the ConversationRef for an external conversation and
+// records the message on it. A message on a resolved conversation re-queues
+// it. Concurrent deliveries for the same external conversation serialize on
+// the row lock, so every message is counted exactly once. The message is
+// classified before the transaction so a slow classifier holds no lock. A new
+// conversation from a phone number the tenant has never seen is flagged as a
+// first contact and gets the tenant's first-contact boost. It is due at its
+// inbox's SLA resolution target, when the inbox has one. The conversation
+// is linked to the customer profile of its phone number, created on the
+// customer's first contact. A phone number past the tenant's intake limit
+// cannot start another conversation in the inbox: IngestMessage returns an
+// *IntakeThrottledError and records nothing.
+func (s *ConversationService) IngestMessage(ctx context.Context, params IngestMessageParams) (*IngestMessageResult, error) {
+	var classification *Classification
+	if endpoint := s.classifierEndpoint(ctx, params.TenantID); endpoint != nil {
+		input := ClassificationInput{
+			TenantID:               params.TenantID,
+			ExternalConversationID: params.ExternalConversationID,
+			CustomerPhoneNumber:    params.CustomerPhoneNumber,
+			Text:                   params.Text,
+			ReceivedAt:             params.ReceivedAt,
 		}
+		if params.Language != nil {
+			input.Language = *params.Language
+		}
+		classification = s.classification.Classify(ctx, endpoint, input)
+	}
+	classification = classification.withLanguage(params.Language)
 
-		filtered := make([]*domain.ConversationRef, 0)
-		for _, conv := range conversations {
-			if inboxSet[conv.InboxID] {
-				filtered = append(filtered, conv)
+	var (
+		result       *IngestMessageResult
+		createdEvent *domain.Event
+	)
+
+	err := s.txMgr.WithTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		repos := s.repos.WithTx(tx)
+		conversations := repos.ConversationRefs
+
+		created := false
+		conv, err := conversations.LockByExternalID(ctx, params.TenantID, params.ExternalConversationID)
+		if errors.Is(err, domain.ErrNotFound) {
+			inbox, err := s.resolveIngestInbox(ctx, repos.Inboxes, params)
+			if err != nil {
+				return err
+			}
+			if err := checkIntake(ctx, repos, params.TenantID, inbox.ID, params.CustomerPhoneNumber, time.Now().UTC()); err != nil {
+				return err
+			}
+
+			conv = domain.NewConversationRef(params.TenantID, inbox.ID, params.ExternalConversationID, params.CustomerPhoneNumber)
+			conv.LastMessageAt = params.ReceivedAt
+			seen, err := conversations.HasConversationWithPhone(ctx, params.TenantID, params.CustomerPhoneNumber)
+			if err != nil {
+				return err
+			}
+			conv.IsFirstContact = !seen
+			policy, err := repos.InboxSLAPolicies.Get(ctx, inbox.ID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+			if policy != nil {
+				conv.DueAt = policy.DueAt(conv.CreatedAt)
+			}
+			if created, err = conversations.CreateIfNotExists(ctx, conv); err != nil {
+				return err
+			}
+
+			// Re-read under lock: a concurrent delivery may have inserted first
+			conv, err = conversations.LockByExternalID(ctx, params.TenantID, params.ExternalConversationID)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Conversations from before customer profiles are linked on their next message
+		if conv.CustomerID == nil {
+			customer, err := repos.Customers.GetOrCreate(ctx, params.TenantID, conv.CustomerPhoneNumber)
+			if err != nil {
+				return err
+			}
+			conv.CustomerID = &customer.ID
+		}
+
+		reopened := false
+		if conv.State == domain.ConversationStateResolved {
+			if err := conv.Reopen(); err != nil {
+				return err
+			}
+			reopened = true
+		}
+
+		outcome, err := s.applyMessageReceived(ctx, repos, conv, params.ReceivedAt, classification, params.Metadata, created)
+		if err != nil {
+			return err
+		}
+
+		if err := conversations.Update(ctx, conv); err != nil {
+			return err
+		}
+
+		if created {
+			createdEvent = domain.NewEvent(conv.TenantID, domain.EventConversationCreated, conversationEventData(conv))
+			if err := stageEvents(ctx, s.events, tx, createdEvent); err != nil {
+				return err
 			}
 		}
-		conversations = filtered
+
+		result = &IngestMessageResult{Conversation: conv, Rules: outcome, Created: created, Reopened: reopened}
+		return nil
+	})
+	if err != nil {
+		var throttled *IntakeThrottledError
+		if errors.As(err, &throttled) {
+			s.logger.Warn("Conversation intake throttled",
+				zap.String("tenant_id", params.TenantID.String()),
+				zap.String("inbox_id", throttled.InboxID.String()),
+				zap.String("external_conversation_id", params.ExternalConversationID),
+				zap.Duration("retry_after", throttled.RetryAfter))
+		}
+		return nil, err
 	}
 
-	// Limit results
-	if len(conversations) > dto.MaxConversationsPerQuery {
-		conversations = conversations[:dto.MaxConversationsPerQuery]
+	if result.Created || result.Reopened {
+		s.logger.Info("Conversation queued by ingested message",
+			zap.String("conversation_id", result.Conversation.ID.String()),
+			zap.String("external_conversation_id", params.ExternalConversationID),
+			zap.Bool("created", result.Created),
+			zap.Bool("reopened", result.Reopened))
 	}
+	s.markQueueStale(result.Conversation.InboxID)
+	s.logRuleOutcome(result.Conversation.ID, result.Rules)
 
-	return conversations, nil
+	if createdEvent != nil {
+		publishEvent(ctx, s.events, s.logger, createdEvent)
+	}
+
+	return result, nil
+}
+
+// markQueueStale schedules the inbox's queue ranks for a refresh
+func (s *ConversationService) markQueueStale(inboxID uuid.UUID) {
+	if s.ranking != nil {
+		s.ranking.MarkStale(inboxID)
+	}
+}
+
+// classifierEndpoint returns the tenant's enabled classifier, nil when
+// classification is off
+func (s *ConversationService) classifierEndpoint(ctx context.Context, tenantID uuid.UUID) *domain.TenantClassifier {
+	if s.classification == nil {
+		return nil
+	}
+	return s.classification.Endpoint(ctx, tenantID)
+}
+
+func (s *ConversationService) resolveIngestInbox(ctx context.Context, inboxes *repository.InboxRepositoryImpl, params IngestMessageParams) (*domain.Inbox, error) {
+	var (
+		inbox *domain.Inbox
+		err   error
+	)
+	if params.InboxID != nil {
+		inbox, err = inboxes.GetByID(ctx, *params.InboxID)
+	} else {
+		inbox, err = inboxes.GetByPhoneNumber(ctx, params.TenantID, params.InboxPhoneNumber)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrIngestInboxNotFound
+		}
+		return nil, err
+	}
+	if inbox.TenantID != params.TenantID {
+		return nil, ErrIngestInboxNotFound
+	}
+	return inbox, nil
 }
 
 // ==================== Priority Calculation ====================
@@ -167,50 +820,154 @@ func (s *ConversationService) SearchByPhone(ctx context.Context, tenantID uuid.U
 // CalculatePriority computes the priority score for a conversation
 // Formula: priority_score = (alpha × normalized_message_count) + (beta × normalized_delay)
 func (s *ConversationService) CalculatePriority(ctx context.Context, tenantID uuid.UUID, conv *domain.ConversationRef) (decimal.Decimal, error) {
-	// Get tenant weights
-	tenant, err := s.repos.Tenants.GetByID(ctx, tenantID)
-	if err != nil {
-		// Use default weights if tenant not found
-		return s.calculatePriorityWithWeights(conv, decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.5)), nil
-	}
+	return s.priorityComponents(ctx, tenantID, conv).PriorityScore, nil
+}
 
-	return s.calculatePriorityWithWeights(conv, tenant.PriorityWeightAlpha, tenant.PriorityWeightBeta), nil
+// priorityComponents calculates the priority with the tenant's weights, or
+// the default weights if the tenant is not found, unless an experiment of
+// the inbox puts the conversation in its treatment arm
+func (s *ConversationService) priorityComponents(ctx context.Context, tenantID uuid.UUID, conv *domain.ConversationRef) *domain.PriorityScoreComponents {
+	weights := s.priorityWeights(ctx, tenantID, conv)
+	return domain.NewPriorityScoreComponents(conv, weights.alpha, weights.beta, decimal.Zero, weights.firstContact, weights.businessHours, time.Now().UTC())
 }
 
-func (s *ConversationService) calculatePriorityWithWeights(conv *domain.ConversationRef, alpha, beta decimal.Decimal) decimal.Decimal {
-	// Normalize message count: min(log10(message_count + 1) / 3, 1.0)
-	normalizedMessageCount := math.Min(math.Log10(float64(conv.MessageCount+1))/3.0, 1.0)
+// tenantPriorityWeights are the tenant settings the priority calculation uses
+type tenantPriorityWeights struct {
+	alpha, beta  decimal.Decimal
+	firstContact decimal.Decimal
+	// businessHours are the conversation's inbox's, nil when it has none
+	businessHours *domain.BusinessHours
+	// experiment is the running experiment enrolling the conversation, if
+	// any, and arm its arm
+	experiment *domain.PriorityExperiment
+	arm        domain.ExperimentArm
+}
 
-	// Normalize delay: min(hours_since_last_message / 24, 1.0)
-	hoursSinceLastMessage := time.Since(conv.LastMessageAt).Hours()
-	normalizedDelay := math.Min(hoursSinceLastMessage/24.0, 1.0)
+// priorityWeights returns the priority settings for conv: the tenant's, or
+// the defaults (even weights, no first-contact boost) if the tenant is not
+// found, with the inbox's business hours and the weights of its running
+// experiment applied
+func (s *ConversationService) priorityWeights(ctx context.Context, tenantID uuid.UUID, conv *domain.ConversationRef) tenantPriorityWeights {
+	weights := tenantPriorityWeights{alpha: decimal.NewFromFloat(0.5), beta: decimal.NewFromFloat(0.5)}
+	if tenant, err := s.repos.Tenants.GetByID(ctx, tenantID); err == nil {
+		weights.alpha, weights.beta = tenant.PriorityWeightAlpha, tenant.PriorityWeightBeta
+		weights.firstContact = tenant.FirstContactBoost
+	}
+	if inbox, err := s.repos.Inboxes.GetByID(ctx, conv.InboxID); err == nil {
+		weights.businessHours = inbox.BusinessHours
+	}
 
-	// Calculate priority: (alpha × normalized_message_count) + (beta × normalized_delay)
-	msgComponent := alpha.Mul(decimal.NewFromFloat(normalizedMessageCount))
-	delayComponent := beta.Mul(decimal.NewFromFloat(normalizedDelay))
+	experiment, err := s.repos.Experiments.GetRunningByInbox(ctx, conv.InboxID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.Warn("Failed to load running priority experiment",
+				zap.String("inbox_id", conv.InboxID.String()),
+				zap.Error(err))
+		}
+		return weights
+	}
+	return weights.withExperiment(experiment, conv)
+}
 
-	return msgComponent.Add(delayComponent)
+// withExperiment enrolls conv in the experiment if it qualifies; the
+// treatment arm takes the experiment's weights and keeps the first-contact
+// boost
+func (w tenantPriorityWeights) withExperiment(experiment *domain.PriorityExperiment, conv *domain.ConversationRef) tenantPriorityWeights {
+	if experiment == nil || !experiment.Enrolls(conv) {
+		return w
+	}
+	w.experiment, w.arm = experiment, experiment.Arm(conv.ID)
+	if w.arm == domain.ExperimentArmTreatment {
+		w.alpha, w.beta = experiment.WeightAlpha, experiment.WeightBeta
+	}
+	return w
 }
 
-// UpdatePriority recalculates and updates the priority score
+// UpdatePriority recalculates and updates the priority score, and records its
+// components
 func (s *ConversationService) UpdatePriority(ctx context.Context, conv *domain.ConversationRef) error {
-	priority, err := s.CalculatePriority(ctx, conv.TenantID, conv)
-	if err != nil {
+	components := s.priorityComponents(ctx, conv.TenantID, conv)
+	conv.PriorityScore = components.PriorityScore
+	conv.UpdatedAt = components.ComputedAt
+
+	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
 		return err
 	}
+	s.markQueueStale(conv.InboxID)
+	return s.repos.PriorityComponents.Create(ctx, components)
+}
 
-	conv.PriorityScore = priority
+// ListPriorityComponents returns the recorded priority calculations of the
+// conversation, newest first
+func (s *ConversationService) ListPriorityComponents(ctx context.Context, tenantID, conversationID uuid.UUID, limit int) ([]*domain.PriorityScoreComponents, error) {
+	if _, err := s.GetByID(ctx, tenantID, conversationID); err != nil {
+		return nil, err
+	}
+	return s.repos.PriorityComponents.ListByConversation(ctx, conversationID, limit)
+}
+
+// ==================== Priority Override ====================
+
+// SetPriorityOverride pins the conversation at the given priority, ahead of
+// every conversation without one, or unpins it when override is nil.
+// Resolved conversations cannot be pinned. actorID is nil for API key callers.
+// Permission: Manager or Admin (enforced by router)
+func (s *ConversationService) SetPriorityOverride(ctx context.Context, tenantID, conversationID uuid.UUID, override *decimal.Decimal, actorID *uuid.UUID) (*domain.ConversationRef, error) {
+	conv, err := s.GetByID(ctx, tenantID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.State == domain.ConversationStateResolved {
+		return nil, ErrPriorityOverrideOnResolved
+	}
+
+	before := priorityOverrideAuditSnapshot(conv)
+
+	conv.PriorityOverride = override
 	conv.UpdatedAt = time.Now().UTC()
+	if err := s.repos.ConversationRefs.SetPriorityOverride(ctx, conv); err != nil {
+		return nil, err
+	}
+	s.markQueueStale(conv.InboxID)
+
+	after := priorityOverrideAuditSnapshot(conv)
+	s.logger.Info("Conversation priority override changed",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.Any("priority_override", after["priority_override"]),
+		zap.Any("actor_id", uuidPtrToString(actorID)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionConversationPriority, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	return conv, nil
+}
 
-	return s.repos.ConversationRefs.Update(ctx, conv)
+func priorityOverrideAuditSnapshot(conv *domain.ConversationRef) map[string]interface{} {
+	var override interface{}
+	if conv.PriorityOverride != nil {
+		override = conv.PriorityOverride.String()
+	}
+	return map[string]interface{}{
+		"priority_override": override,
+		"priority_score":    conv.PriorityScore.String(),
+	}
 }
 
 // ==================== Get Labels for Conversation ====================
 
+// GetLabels returns the labels attached to the conversation
 func (s *ConversationService) GetLabels(ctx context.Context, conversationID uuid.UUID) ([]*domain.Label, error) {
-	// TODO: Implement GetByConversationID in label repository
-	// For now, return empty slice - labels will be added in Stage 8
-	return []*domain.Label{}, nil
+	return s.repos.ConversationLabels.GetLabels(ctx, conversationID)
+}
+
+// LabelsFor returns the labels attached to each of convs, loaded in one query
+func (s *ConversationService) LabelsFor(ctx context.Context, convs []*domain.ConversationRef) (map[uuid.UUID][]*domain.Label, error) {
+	ids := make([]uuid.UUID, len(convs))
+	for i, conv := range convs {
+		ids[i] = conv.ID
+	}
+	return s.repos.ConversationLabels.GetLabelsForConversations(ctx, ids)
 }
 
 // ==================== Batch Priority Update ====================
@@ -232,16 +989,63 @@ func (s *ConversationService) UpdatePrioritiesForTenant(ctx context.Context, ten
 	if err != nil {
 		return err
 	}
+	tenantWeights := tenantPriorityWeights{
+		alpha:        tenant.PriorityWeightAlpha,
+		beta:         tenant.PriorityWeightBeta,
+		firstContact: tenant.FirstContactBoost,
+	}
 
-	for _, conv := range conversations {
-		priority := s.calculatePriorityWithWeights(conv, tenant.PriorityWeightAlpha, tenant.PriorityWeightBeta)
-		conv.PriorityScore = priority
-		conv.UpdatedAt = time.Now().UTC()
+	running, err := s.repos.Experiments.GetRunningByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	experiments := make(map[uuid.UUID]*domain.PriorityExperiment, len(running))
+	for _, experiment := range running {
+		experiments[experiment.InboxID] = experiment
+	}
+
+	inboxes, err := s.repos.Inboxes.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	businessHours := make(map[uuid.UUID]*domain.BusinessHours, len(inboxes))
+	for _, inbox := range inboxes {
+		businessHours[inbox.ID] = inbox.BusinessHours
+	}
 
-		if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	for _, conv := range conversations {
+		current := conv
+		var components *domain.PriorityScoreComponents
+		err := retry.Do(ctx, conflictRetry, func() error {
+			var err error
+			if current == nil {
+				// Lost the version check; start over from the stored row
+				if current, err = s.repos.ConversationRefs.GetByID(ctx, conv.ID); err != nil {
+					return err
+				}
+			}
+			components, err = s.recalculatePriority(ctx, current, tenantWeights, experiments, businessHours)
+			if err != nil {
+				current = nil
+			}
+			return err
+		})
+		if err != nil {
 			s.logger.Warn("Failed to update priority for conversation",
 				zap.String("conversation_id", conv.ID.String()),
 				zap.Error(err))
+			continue
+		}
+		if components == nil {
+			// Left the queue since listed
+			continue
+		}
+		s.markQueueStale(conv.InboxID)
+
+		if err := s.repos.PriorityComponents.Create(ctx, components); err != nil {
+			s.logger.Warn("Failed to record priority components for conversation",
+				zap.String("conversation_id", conv.ID.String()),
+				zap.Error(err))
 		}
 	}
 
@@ -251,3 +1055,24 @@ func (s *ConversationService) UpdatePrioritiesForTenant(ctx context.Context, ten
 
 	return nil
 }
+
+// recalculatePriority writes the priority of conv under the given weights
+// and its inbox's business hours; it returns nil components when conv is no
+// longer QUEUED, and ErrConcurrentModification when the stored row changed
+// since conv was read
+func (s *ConversationService) recalculatePriority(ctx context.Context, conv *domain.ConversationRef, tenantWeights tenantPriorityWeights, experiments map[uuid.UUID]*domain.PriorityExperiment, businessHours map[uuid.UUID]*domain.BusinessHours) (*domain.PriorityScoreComponents, error) {
+	if conv.State != domain.ConversationStateQueued {
+		return nil, nil
+	}
+
+	weights := tenantWeights.withExperiment(experiments[conv.InboxID], conv)
+	weights.businessHours = businessHours[conv.InboxID]
+	components := domain.NewPriorityScoreComponents(conv, weights.alpha, weights.beta, decimal.Zero, weights.firstContact, weights.businessHours, time.Now().UTC())
+	conv.PriorityScore = components.PriorityScore
+	conv.UpdatedAt = components.ComputedAt
+
+	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// recordAffinity makes the operator who resolved conv the affinity of its
+// customer, so that allocation offers them the customer's next conversation
+// first. Conversations without a customer or an operator record nothing.
+func recordAffinity(ctx context.Context, affinities domain.CustomerOperatorAffinityRepository, conv *domain.ConversationRef) error {
+	affinity := domain.AffinityOnResolve(conv)
+	if affinity == nil {
+		return nil
+	}
+	return affinities.Record(ctx, affinity)
+}
+
+// affinitySince returns how far back an affinity counts at now; nil when
+// the window is zero, which disables sticky routing
+func affinitySince(now time.Time, window time.Duration) *time.Time {
+	if window <= 0 {
+		return nil
+	}
+	since := now.Add(-window)
+	return &since
+}
+
+// nextWithAffinity locks the conversations to allocate to the operator: the
+// queued conversations of customers they resolved since the given time come
+// first, then the strategy's order fills the rest. A nil since leaves the
+// strategy's order alone.
+func nextWithAffinity(ctx context.Context, strategy AllocationStrategy, conversations domain.ConversationRefRepository, q AllocationQuery, operatorID uuid.UUID, since *time.Time) ([]*domain.ConversationRef, error) {
+	if since == nil {
+		return strategy.Next(ctx, conversations, q)
+	}
+	affine, err := conversations.GetNextAffineForAllocation(ctx, q.TenantID, operatorID, q.InboxIDs, q.Languages, *since, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(affine) >= q.Limit {
+		return affine, nil
+	}
+	rest, err := strategy.Next(ctx, conversations, q)
+	if err != nil {
+		return nil, err
+	}
+	return mergeAllocations(affine, rest, q.Limit), nil
+}
+
+// mergeAllocations appends rest to first up to limit. Rows locked by this
+// transaction are not skipped by SKIP LOCKED, so rest may repeat some of
+// first; those are dropped.
+func mergeAllocations(first, rest []*domain.ConversationRef, limit int) []*domain.ConversationRef {
+	merged := make([]*domain.ConversationRef, 0, limit)
+	seen := make(map[uuid.UUID]bool, len(first))
+	for _, conv := range first {
+		if len(merged) == limit {
+			break
+		}
+		merged = append(merged, conv)
+		seen[conv.ID] = true
+	}
+	for _, conv := range rest {
+		if len(merged) == limit {
+			break
+		}
+		if !seen[conv.ID] {
+			merged = append(merged, conv)
+			seen[conv.ID] = true
+		}
+	}
+	return merged
+}
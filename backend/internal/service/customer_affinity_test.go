@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAffinitySince(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, affinitySince(now, 0), "zero window disables affinity")
+
+	since := affinitySince(now, 72*time.Hour)
+	require.NotNil(t, since)
+	assert.Equal(t, now.Add(-72*time.Hour), *since)
+}
+
+func TestMergeAllocations(t *testing.T) {
+	a := &domain.ConversationRef{ID: uuid.New()}
+	b := &domain.ConversationRef{ID: uuid.New()}
+	c := &domain.ConversationRef{ID: uuid.New()}
+	d := &domain.ConversationRef{ID: uuid.New()}
+
+	t.Run("affine conversations come first", func(t *testing.T) {
+		assert.Equal(t, []*domain.ConversationRef{c, a, b}, mergeAllocations([]*domain.ConversationRef{c}, []*domain.ConversationRef{a, b}, 3))
+	})
+
+	t.Run("conversations locked twice are kept once", func(t *testing.T) {
+		assert.Equal(t, []*domain.ConversationRef{c, a, b}, mergeAllocations([]*domain.ConversationRef{c}, []*domain.ConversationRef{a, c, b, d}, 3))
+	})
+
+	t.Run("trimmed to the limit", func(t *testing.T) {
+		assert.Equal(t, []*domain.ConversationRef{c, d}, mergeAllocations([]*domain.ConversationRef{c, d}, []*domain.ConversationRef{a}, 2))
+	})
+
+	t.Run("no affinity", func(t *testing.T) {
+		assert.Equal(t, []*domain.ConversationRef{a, b}, mergeAllocations(nil, []*domain.ConversationRef{a, b}, 5))
+	})
+}
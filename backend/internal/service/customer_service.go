@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+)
+
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// CustomerService manages customer profiles. Profiles are created by
+// message ingestion on a phone number's first contact; managers and
+// integrations maintain their name and metadata.
+type CustomerService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewCustomerService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *CustomerService {
+	return &CustomerService{repos: repos, audit: audit, logger: log}
+}
+
+// Search returns the tenant's customers matching the filter, newest first
+// Permission: Manager+ (enforced by router)
+func (s *CustomerService) Search(ctx context.Context, filter domain.CustomerFilter) ([]*domain.Customer, error) {
+	return s.repos.Customers.Search(ctx, filter)
+}
+
+// Get returns a customer of the tenant
+// Permission: Manager+ (enforced by router)
+func (s *CustomerService) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.Customer, error) {
+	customer, err := s.repos.Customers.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	if customer.TenantID != tenantID {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+type UpdateCustomerParams struct {
+	TenantID   uuid.UUID
+	CustomerID uuid.UUID
+	// Name replaces the customer's name; nil leaves it, empty clears it
+	Name *string
+	// Metadata replaces the customer's metadata; nil leaves it
+	Metadata map[string]interface{}
+	ActorID  *uuid.UUID
+}
+
+// Update changes the customer's name and metadata
+// Permission: Manager+ (enforced by router)
+func (s *CustomerService) Update(ctx context.Context, params UpdateCustomerParams) (*domain.Customer, error) {
+	customer, err := s.Get(ctx, params.TenantID, params.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := customerAuditSnapshot(customer)
+	if !customer.SetProfile(params.Name, params.Metadata) {
+		return customer, nil
+	}
+	if err := s.repos.Customers.Update(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(params.TenantID, params.ActorID,
+		domain.AuditActionCustomerUpdate, domain.AuditEntityCustomer, customer.ID,
+		before, customerAuditSnapshot(customer)))
+
+	return customer, nil
+}
+
+// customerAuditSnapshot leaves out the phone number, which the audit log
+// does not need to identify the customer
+func customerAuditSnapshot(c *domain.Customer) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     c.Name,
+		"metadata": c.Metadata,
+	}
+}
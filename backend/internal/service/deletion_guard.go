@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ErrDeletionBlocked is returned by the deletes of inboxes, labels and
+// operators whose deletion would lose active work, unless forced
+var ErrDeletionBlocked = errors.New("deletion blocked by dependents")
+
+// DeletionBlockedError carries the impact that blocked the deletion
+type DeletionBlockedError struct {
+	Impact *domain.DeletionImpact
+}
+
+func (e *DeletionBlockedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrDeletionBlocked, strings.Join(e.Impact.Blockers(), ", "))
+}
+
+func (e *DeletionBlockedError) Unwrap() error {
+	return ErrDeletionBlocked
+}
+
+// guardDeletion returns a *DeletionBlockedError when the impact has
+// blockers and the deletion is not forced
+func guardDeletion(impact *domain.DeletionImpact, force bool) error {
+	if force || len(impact.Blockers()) == 0 {
+		return nil
+	}
+	return &DeletionBlockedError{Impact: impact}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+func TestGuardDeletion(t *testing.T) {
+	blocked := &domain.DeletionImpact{Resource: domain.DeletionResourceInbox, OpenConversations: 2}
+	clear := &domain.DeletionImpact{Resource: domain.DeletionResourceInbox, Conversations: 5}
+
+	err := guardDeletion(blocked, false)
+	var blockedErr *DeletionBlockedError
+	if !errors.As(err, &blockedErr) || blockedErr.Impact != blocked {
+		t.Fatalf("guardDeletion() = %v, want *DeletionBlockedError", err)
+	}
+	if !errors.Is(err, ErrDeletionBlocked) {
+		t.Errorf("error does not wrap ErrDeletionBlocked")
+	}
+	if got, want := err.Error(), "deletion blocked by dependents: 2 open conversations"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if err := guardDeletion(blocked, true); err != nil {
+		t.Errorf("forced guardDeletion() = %v, want nil", err)
+	}
+	if err := guardDeletion(clear, false); err != nil {
+		t.Errorf("guardDeletion() without blockers = %v, want nil", err)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var ErrDueDateOnResolved = errors.New("cannot set the due date of a resolved conversation")
+
+var conversationsOverdue = metrics.NewCounter("conversations_overdue_total")
+
+// DueDateService tracks conversation due dates. A conversation is due at its
+// inbox's SLA resolution target when created, and managers may move or clear
+// the due date. The overdue worker flags open conversations past their due
+// date once and emits conversation.overdue, which reaches the assigned
+// operator's event stream.
+type DueDateService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewDueDateService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *DueDateService {
+	return &DueDateService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// SetDueDate moves the conversation's due date, or clears it when dueAt is
+// nil. The overdue flag is cleared so a new date past due is flagged again.
+// Resolved conversations cannot be changed. actorID is nil for API key callers.
+// Permission: Manager or Admin (enforced by router)
+func (s *DueDateService) SetDueDate(ctx context.Context, tenantID, conversationID uuid.UUID, dueAt *time.Time, actorID *uuid.UUID) (*domain.ConversationRef, error) {
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State == domain.ConversationStateResolved {
+		return nil, ErrDueDateOnResolved
+	}
+
+	before := dueDateAuditSnapshot(conv)
+
+	if dueAt != nil {
+		utc := dueAt.UTC()
+		dueAt = &utc
+	}
+	conv.SetDueAt(dueAt)
+	if err := s.repos.ConversationRefs.SetDueAt(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	after := dueDateAuditSnapshot(conv)
+	s.logger.Info("Conversation due date changed",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.Any("due_at", after["due_at"]),
+		zap.Any("actor_id", uuidPtrToString(actorID)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionConversationDueDate, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	return conv, nil
+}
+
+// FlagOverdue flags up to batchSize open conversations past their due date,
+// earliest due first, and emits conversation.overdue for each. Uses FOR
+// UPDATE SKIP LOCKED so instances share the work.
+func (s *DueDateService) FlagOverdue(ctx context.Context, batchSize int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	conversations := s.repos.WithTx(tx).ConversationRefs
+
+	now := time.Now().UTC()
+	overdue, err := conversations.GetAndLockOverdue(ctx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(overdue) == 0 {
+		return 0, nil
+	}
+
+	pending := make([]*domain.Event, 0, len(overdue))
+	for _, conv := range overdue {
+		conv.OverdueAt = &now
+		if err := conversations.SetOverdue(ctx, conv); err != nil {
+			return 0, err
+		}
+
+		data := conversationEventData(conv)
+		data["due_at"] = conv.DueAt.Format(time.RFC3339)
+		pending = append(pending, domain.NewEvent(conv.TenantID, domain.EventConversationOverdue, data))
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	conversationsOverdue.Add(int64(len(overdue)))
+
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return len(overdue), nil
+}
+
+func dueDateAuditSnapshot(conv *domain.ConversationRef) map[string]interface{} {
+	var dueAt interface{}
+	if conv.DueAt != nil {
+		dueAt = conv.DueAt.Format(time.RFC3339)
+	}
+	return map[string]interface{}{
+		"due_at": dueAt,
+	}
+}
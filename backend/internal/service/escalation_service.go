@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrEscalationInboxNotFound = errors.New("escalation settings inbox not found")
+	ErrEscalationNotConfigured = errors.New("inbox has no escalation inbox")
+	ErrEscalationInboxInvalid  = errors.New("escalation inbox must differ from the inbox")
+)
+
+var escalationsTotal = metrics.NewCounter("conversation_escalations_total")
+
+// EscalationService lets operators hand a conversation they can't handle to
+// their inbox's escalation inbox, typically a manager queue. The escalated
+// conversation is queued there for whoever is subscribed to it, and the
+// conversation.escalated event notifies those subscribers and webhooks.
+type EscalationService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewEscalationService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *EscalationService {
+	return &EscalationService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// Escalate takes an allocated conversation from its operator and queues it
+// in the escalation inbox of its inbox, recording the reason.
+// Permission: Owner (assigned operator), Manager, or Admin
+func (s *EscalationService) Escalate(ctx context.Context, tenantID, callerID, conversationID uuid.UUID, reason string, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+	conversations := repos.ConversationRefs
+
+	conv, err := conversations.LockForUpdate(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State != domain.ConversationStateAllocated {
+		return nil, ErrConversationNotAllocated
+	}
+
+	owner := conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == callerID
+	if !owner && callerRole != domain.OperatorRoleAdmin && callerRole != domain.OperatorRoleManager {
+		s.logger.Warn("Escalation attempt without permission",
+			zap.String("conversation_id", conversationID.String()),
+			zap.String("caller_id", callerID.String()),
+			zap.String("caller_role", string(callerRole)))
+		return nil, ErrInsufficientPermissions
+	}
+
+	inbox, err := s.repos.Inboxes.GetByID(ctx, conv.InboxID)
+	if err != nil {
+		return nil, err
+	}
+	if inbox.EscalationInboxID == nil {
+		return nil, ErrEscalationNotConfigured
+	}
+
+	previousOperator := conv.AssignedOperatorID
+	before := conversationAuditSnapshot(conv)
+
+	if err := conv.Escalate(*inbox.EscalationInboxID); err != nil {
+		return nil, err
+	}
+	if err := conversations.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	escalation := domain.NewConversationEscalation(conv, previousOperator, callerID, inbox.ID, reason)
+	if err := repos.Escalations.Create(ctx, escalation); err != nil {
+		return nil, err
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["escalated_by"] = callerID.String()
+	data["from_inbox_id"] = inbox.ID.String()
+	data["reason"] = reason
+	event := domain.NewEvent(tenantID, domain.EventConversationEscalated, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	escalationsTotal.Inc()
+
+	s.logger.Info("Conversation escalated",
+		zap.String("conversation_id", conversationID.String()),
+		zap.String("escalated_by", callerID.String()),
+		zap.String("from_inbox", inbox.ID.String()),
+		zap.String("to_inbox", conv.InboxID.String()))
+
+	after := conversationAuditSnapshot(conv)
+	after["reason"] = reason
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationEscalate, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return conv, nil
+}
+
+// SetEscalationInbox sets the inbox that the inbox's conversations are
+// escalated to; nil disables escalation
+// Permission: Manager+ (enforced by router)
+func (s *EscalationService) SetEscalationInbox(ctx context.Context, tenantID, inboxID uuid.UUID, escalationInboxID *uuid.UUID, updatedBy *uuid.UUID) (*domain.Inbox, error) {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrEscalationInboxNotFound
+		}
+		return nil, err
+	}
+	if inbox.TenantID != tenantID {
+		return nil, ErrEscalationInboxNotFound
+	}
+
+	if escalationInboxID != nil {
+		if *escalationInboxID == inboxID {
+			return nil, ErrEscalationInboxInvalid
+		}
+		target, err := s.repos.Inboxes.GetByID(ctx, *escalationInboxID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, ErrTargetInboxNotFound
+			}
+			return nil, err
+		}
+		if target.TenantID != tenantID {
+			return nil, ErrTargetInboxDifferentTenant
+		}
+	}
+
+	before := map[string]interface{}{"escalation_inbox_id": uuidPtrToString(inbox.EscalationInboxID)}
+
+	inbox.EscalationInboxID = escalationInboxID
+	inbox.UpdatedAt = time.Now().UTC()
+	if err := s.repos.Inboxes.Update(ctx, inbox); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inbox escalation inbox updated",
+		zap.String("inbox_id", inboxID.String()),
+		zap.Any("escalation_inbox_id", uuidPtrToString(escalationInboxID)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionInboxEscalationChange, domain.AuditEntityInbox, inboxID,
+		before, map[string]interface{}{"escalation_inbox_id": uuidPtrToString(escalationInboxID)}))
+
+	return inbox, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/events"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+)
+
+var (
+	eventBusPublished       = metrics.NewCounter("event_bus_published_total")
+	eventBusPublishFailures = metrics.NewCounter("event_bus_publish_failures_total")
+)
+
+// EventBusConfig selects the events published to the bus
+type EventBusConfig struct {
+	Topic string
+	// EventTypes are the event types published; empty publishes every type
+	EventTypes []domain.EventType
+}
+
+// DefaultEventBusConfig returns sensible defaults
+func DefaultEventBusConfig() EventBusConfig {
+	return EventBusConfig{
+		Topic: "inbox-allocation.events",
+		EventTypes: []domain.EventType{
+			domain.EventConversationCreated,
+			domain.EventConversationAllocated,
+			domain.EventConversationResolved,
+			domain.EventOperatorStatusChanged,
+		},
+	}
+}
+
+// EventBusPublisher publishes domain events to an external message bus
+// (Kafka or NATS) for downstream consumers such as analytics. The message is
+// the event envelope as JSON, keyed by conversation, else operator, else
+// tenant, so each conversation's events stay in order on a Kafka partition.
+// Behind the outbox a failure is retried, so consumers should deduplicate
+// on the envelope id.
+type EventBusPublisher struct {
+	bus    events.Bus
+	topic  string
+	filter map[domain.EventType]bool
+}
+
+func NewEventBusPublisher(bus events.Bus, config EventBusConfig) *EventBusPublisher {
+	var filter map[domain.EventType]bool
+	if len(config.EventTypes) > 0 {
+		filter = make(map[domain.EventType]bool, len(config.EventTypes))
+		for _, t := range config.EventTypes {
+			filter[t] = true
+		}
+	}
+	return &EventBusPublisher{
+		bus:    bus,
+		topic:  config.Topic,
+		filter: filter,
+	}
+}
+
+func (p *EventBusPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	if p.filter != nil && !p.filter[event.Type] {
+		return nil
+	}
+
+	value, err := json.Marshal(NewEventEnvelope(event))
+	if err != nil {
+		return err
+	}
+
+	err = p.bus.Publish(ctx, events.Message{
+		Topic: p.topic,
+		Type:  string(event.Type),
+		Key:   eventBusKey(event),
+		Value: value,
+	})
+	if err != nil {
+		eventBusPublishFailures.Inc()
+		return err
+	}
+	eventBusPublished.Inc()
+	return nil
+}
+
+// eventBusKey returns the entity whose events must stay in order
+func eventBusKey(event *domain.Event) string {
+	for _, field := range []string{"conversation_id", "operator_id"} {
+		if id, ok := event.Data[field].(string); ok && id != "" {
+			return id
+		}
+	}
+	return event.TenantID.String()
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingBus struct {
+	messages []events.Message
+	err      error
+}
+
+func (b *recordingBus) Publish(ctx context.Context, msg events.Message) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.messages = append(b.messages, msg)
+	return nil
+}
+
+func (b *recordingBus) Close() error {
+	return nil
+}
+
+func TestEventBusPublisher(t *testing.T) {
+	ctx := context.Background()
+	tenantID := uuid.New()
+	conversationID := uuid.New().String()
+	operatorID := uuid.New().String()
+
+	bus := &recordingBus{}
+	publisher := NewEventBusPublisher(bus, DefaultEventBusConfig())
+
+	allocated := domain.NewEvent(tenantID, domain.EventConversationAllocated, map[string]interface{}{
+		"conversation_id":      conversationID,
+		"assigned_operator_id": operatorID,
+	})
+	require.NoError(t, publisher.Publish(ctx, allocated))
+	require.NoError(t, publisher.Publish(ctx, domain.NewEvent(tenantID, domain.EventOperatorStatusChanged, map[string]interface{}{
+		"operator_id": operatorID,
+	})))
+	require.NoError(t, publisher.Publish(ctx, domain.NewEvent(tenantID, domain.EventConversationSnoozed, map[string]interface{}{
+		"conversation_id": conversationID,
+	})))
+
+	require.Len(t, bus.messages, 2, "types outside the filter are skipped")
+	msg := bus.messages[0]
+	assert.Equal(t, "inbox-allocation.events", msg.Topic)
+	assert.Equal(t, "conversation.allocated", msg.Type)
+	assert.Equal(t, conversationID, msg.Key)
+	assert.Equal(t, operatorID, bus.messages[1].Key)
+
+	var envelope EventEnvelope
+	require.NoError(t, json.Unmarshal(msg.Value, &envelope))
+	assert.Equal(t, allocated.ID, envelope.ID)
+	assert.Equal(t, tenantID, envelope.TenantID)
+
+	t.Run("every type without a filter", func(t *testing.T) {
+		bus := &recordingBus{}
+		publisher := NewEventBusPublisher(bus, EventBusConfig{Topic: "all"})
+		require.NoError(t, publisher.Publish(ctx, domain.NewEvent(tenantID, domain.EventAnomalyDetected, nil)))
+		require.Len(t, bus.messages, 1)
+		assert.Equal(t, tenantID.String(), bus.messages[0].Key)
+	})
+
+	t.Run("bus failures are returned for retry", func(t *testing.T) {
+		bus := &recordingBus{err: errors.New("broker down")}
+		publisher := NewEventBusPublisher(bus, DefaultEventBusConfig())
+		assert.Error(t, publisher.Publish(ctx, allocated))
+	})
+}
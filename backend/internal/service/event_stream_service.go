@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/database"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// ConversationEventsChannel is the PostgreSQL NOTIFY channel carrying conversation events
+	ConversationEventsChannel = "conversation_events"
+	// RealtimeGrantsChannel carries refreshed realtime tokens to the replica
+	// serving the stream
+	RealtimeGrantsChannel = "realtime_grants"
+)
+
+// ErrRealtimeInboxNotVisible is returned when a realtime token is requested
+// for an inbox the operator is not subscribed to
+var ErrRealtimeInboxNotVisible = errors.New("inbox not visible to operator")
+
+// EventStreamConfig holds configuration for the event stream
+type EventStreamConfig struct {
+	// BufferSize is the number of events queued per subscriber before events are dropped
+	BufferSize int
+}
+
+// DefaultEventStreamConfig returns sensible defaults
+func DefaultEventStreamConfig() EventStreamConfig {
+	return EventStreamConfig{BufferSize: 64}
+}
+
+// EventStreamService fans conversation events out to connected stream clients.
+// Events are published through PostgreSQL NOTIFY so that every instance of the
+// service receives them, and each instance delivers to its own subscribers.
+type EventStreamService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	signer *domain.RealtimeTokenSigner
+	config EventStreamConfig
+	logger *logger.Logger
+
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]*EventSubscription
+}
+
+func NewEventStreamService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, signer *domain.RealtimeTokenSigner, config EventStreamConfig, log *logger.Logger) *EventStreamService {
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultEventStreamConfig().BufferSize
+	}
+	return &EventStreamService{
+		repos:       repos,
+		pool:        pool,
+		signer:      signer,
+		config:      config,
+		logger:      log,
+		subscribers: make(map[uuid.UUID]*EventSubscription),
+	}
+}
+
+// ==================== Publish ====================
+
+// Publish implements domain.EventPublisher. Only conversation.* events are streamed.
+func (s *EventStreamService) Publish(ctx context.Context, event *domain.Event) error {
+	if !isConversationEvent(event.Type) {
+		return nil
+	}
+
+	payload, err := json.Marshal(NewEventEnvelope(event))
+	if err != nil {
+		return err
+	}
+	return database.Notify(ctx, s.pool, ConversationEventsChannel, string(payload))
+}
+
+// ==================== Listen ====================
+
+// Run listens for notifications and dispatches them to subscribers until ctx is cancelled
+func (s *EventStreamService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		database.Listen(ctx, s.pool, RealtimeGrantsChannel, s.dispatchGrant, s.logger)
+	}()
+
+	database.Listen(ctx, s.pool, ConversationEventsChannel, s.dispatch, s.logger)
+	wg.Wait()
+}
+
+func (s *EventStreamService) dispatch(payload string) {
+	var envelope EventEnvelope
+	if err := json.Unmarshal([]byte(payload), &envelope); err != nil {
+		s.logger.Warn("Discarding malformed event notification", zap.Error(err))
+		return
+	}
+	event := envelope.ToEvent()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sub := range s.subscribers {
+		if !sub.wants(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			s.logger.Warn("Event stream subscriber is too slow, dropping event",
+				zap.String("subscription_id", sub.ID.String()),
+				zap.String("event_id", event.ID.String()))
+		}
+	}
+}
+
+// ==================== Subscribe ====================
+
+// EventSubscription receives events for the inboxes its operator is subscribed to,
+// plus events about conversations of the mentors the operator shadows, as
+// scoped by its grant
+type EventSubscription struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+
+	// Guarded by service.mu; replaced when the grant is refreshed
+	inboxIDs  map[uuid.UUID]struct{}
+	mentorIDs map[uuid.UUID]struct{}
+	grant     *domain.RealtimeGrant
+	events    chan *domain.Event
+	refreshed chan time.Time
+	service   *EventStreamService
+	once      sync.Once
+}
+
+// Events returns the channel of matching events. It is closed by Close.
+func (sub *EventSubscription) Events() <-chan *domain.Event {
+	return sub.events
+}
+
+// Refreshed receives the new expiry whenever the grant is refreshed
+func (sub *EventSubscription) Refreshed() <-chan time.Time {
+	return sub.refreshed
+}
+
+// ExpiresAt returns when the grant ends; zero for streams that do not expire
+func (sub *EventSubscription) ExpiresAt() time.Time {
+	sub.service.mu.RLock()
+	defer sub.service.mu.RUnlock()
+	return sub.grant.ExpiresAt
+}
+
+// Close unregisters the subscription. Safe to call more than once.
+func (sub *EventSubscription) Close() {
+	sub.once.Do(func() {
+		sub.service.mu.Lock()
+		delete(sub.service.subscribers, sub.ID)
+		sub.service.mu.Unlock()
+		close(sub.events)
+	})
+}
+
+// setGrant replaces the subscription's scope; the caller holds service.mu
+func (sub *EventSubscription) setGrant(grant *domain.RealtimeGrant) {
+	sub.inboxIDs = make(map[uuid.UUID]struct{}, len(grant.InboxIDs))
+	for _, id := range grant.InboxIDs {
+		sub.inboxIDs[id] = struct{}{}
+	}
+	sub.mentorIDs = make(map[uuid.UUID]struct{}, len(grant.MentorIDs))
+	for _, id := range grant.MentorIDs {
+		sub.mentorIDs[id] = struct{}{}
+	}
+	sub.grant = grant
+}
+
+func (sub *EventSubscription) wants(event *domain.Event) bool {
+	if sub.TenantID != event.TenantID || !sub.grant.AllowsEventType(event.Type) {
+		return false
+	}
+	if _, ok := sub.inboxIDs[eventUUID(event, "inbox_id")]; ok {
+		return true
+	}
+	// Shadowing: follow the mentor's conversations, including the event
+	// that takes a conversation away from them
+	for _, key := range []string{"assigned_operator_id", "previous_operator_id"} {
+		if _, ok := sub.mentorIDs[eventUUID(event, key)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// eventUUID returns the UUID stored under key in the event data, or uuid.Nil
+func eventUUID(event *domain.Event, key string) uuid.UUID {
+	raw, _ := event.Data[key].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+// Subscribe registers a stream for the operator's currently subscribed inboxes
+// and shadowed mentors. Changes made afterwards apply on the next connection.
+func (s *EventStreamService) Subscribe(ctx context.Context, tenantID, operatorID uuid.UUID) (*EventSubscription, error) {
+	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	mentorIDs, err := s.repos.OperatorShadows.GetMentorIDs(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscribeGrant(&domain.RealtimeGrant{
+		TenantID:   tenantID,
+		OperatorID: operatorID,
+		InboxIDs:   inboxIDs,
+		MentorIDs:  mentorIDs,
+	}), nil
+}
+
+// SubscribeWithToken registers a stream scoped by a realtime token. The
+// stream ends when the token expires unless it is refreshed first.
+func (s *EventStreamService) SubscribeWithToken(token string) (*EventSubscription, error) {
+	grant, err := s.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return s.subscribeGrant(grant), nil
+}
+
+func (s *EventStreamService) subscribe(tenantID uuid.UUID, inboxIDs, mentorIDs []uuid.UUID) *EventSubscription {
+	return s.subscribeGrant(&domain.RealtimeGrant{
+		TenantID:  tenantID,
+		InboxIDs:  inboxIDs,
+		MentorIDs: mentorIDs,
+	})
+}
+
+func (s *EventStreamService) subscribeGrant(grant *domain.RealtimeGrant) *EventSubscription {
+	sub := &EventSubscription{
+		ID:         uuid.New(),
+		TenantID:   grant.TenantID,
+		OperatorID: grant.OperatorID,
+		events:     make(chan *domain.Event, s.config.BufferSize),
+		refreshed:  make(chan time.Time, 1),
+		service:    s,
+	}
+	sub.setGrant(grant)
+
+	s.mu.Lock()
+	s.subscribers[sub.ID] = sub
+	s.mu.Unlock()
+
+	return sub
+}
+
+// ==================== Realtime Tokens ====================
+
+type IssueRealtimeTokenParams struct {
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+	// InboxIDs narrows the token to some of the operator's subscribed
+	// inboxes; empty for all of them
+	InboxIDs   []uuid.UUID
+	EventTypes []domain.EventType
+	TTL        time.Duration
+	// SubscriptionID, when set, also applies the new token to that open
+	// stream of the operator, so it continues without reconnecting
+	SubscriptionID *uuid.UUID
+}
+
+// realtimeGrantNotification carries a refreshed token to the stream's replica
+type realtimeGrantNotification struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	Token          string    `json:"token"`
+}
+
+// IssueToken signs a realtime token for the operator's current inbox
+// visibility: their subscribed inboxes and the mentors they shadow
+func (s *EventStreamService) IssueToken(ctx context.Context, params IssueRealtimeTokenParams) (*domain.RealtimeGrant, string, error) {
+	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, params.OperatorID)
+	if err != nil {
+		return nil, "", err
+	}
+	mentorIDs, err := s.repos.OperatorShadows.GetMentorIDs(ctx, params.OperatorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(params.InboxIDs) > 0 {
+		subscribed := make(map[uuid.UUID]bool, len(inboxIDs))
+		for _, id := range inboxIDs {
+			subscribed[id] = true
+		}
+		for _, id := range params.InboxIDs {
+			if !subscribed[id] {
+				return nil, "", ErrRealtimeInboxNotVisible
+			}
+		}
+		inboxIDs = params.InboxIDs
+	}
+
+	grant := &domain.RealtimeGrant{
+		TenantID:   params.TenantID,
+		OperatorID: params.OperatorID,
+		InboxIDs:   inboxIDs,
+		MentorIDs:  mentorIDs,
+		EventTypes: params.EventTypes,
+		// Tokens carry the expiry in whole seconds
+		ExpiresAt: time.Now().UTC().Add(params.TTL).Truncate(time.Second),
+	}
+	token, err := s.signer.Sign(grant)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if params.SubscriptionID != nil {
+		payload, err := json.Marshal(realtimeGrantNotification{SubscriptionID: *params.SubscriptionID, Token: token})
+		if err != nil {
+			return nil, "", err
+		}
+		if err := database.Notify(ctx, s.pool, RealtimeGrantsChannel, string(payload)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return grant, token, nil
+}
+
+// dispatchGrant applies a refreshed token to the stream it names, if this
+// replica serves it and it belongs to the token's operator
+func (s *EventStreamService) dispatchGrant(payload string) {
+	var notification realtimeGrantNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		s.logger.Warn("Discarding malformed realtime grant notification", zap.Error(err))
+		return
+	}
+	grant, err := s.signer.Verify(notification.Token, time.Now())
+	if err != nil {
+		return
+	}
+	s.refresh(notification.SubscriptionID, grant)
+}
+
+func (s *EventStreamService) refresh(subscriptionID uuid.UUID, grant *domain.RealtimeGrant) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subscribers[subscriptionID]
+	if !ok || sub.TenantID != grant.TenantID || sub.OperatorID != grant.OperatorID {
+		return false
+	}
+	sub.setGrant(grant)
+
+	// Only the latest expiry matters to the stream
+	select {
+	case <-sub.refreshed:
+	default:
+	}
+	sub.refreshed <- grant.ExpiresAt
+	return true
+}
+
+func isConversationEvent(t domain.EventType) bool {
+	return strings.HasPrefix(string(t), "conversation.")
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notificationPayload(t *testing.T, tenantID, inboxID uuid.UUID, eventType domain.EventType) string {
+	t.Helper()
+	event := domain.NewEvent(tenantID, eventType, map[string]interface{}{
+		"conversation_id": uuid.New().String(),
+		"inbox_id":        inboxID.String(),
+	})
+	payload, err := json.Marshal(NewEventEnvelope(event))
+	require.NoError(t, err)
+	return string(payload)
+}
+
+func TestEventStreamService_Dispatch(t *testing.T) {
+	svc := NewEventStreamService(nil, nil, nil, EventStreamConfig{BufferSize: 1}, logger.NewNop())
+	tenantID := uuid.New()
+	inboxID := uuid.New()
+
+	sub := svc.subscribe(tenantID, []uuid.UUID{inboxID}, nil)
+	defer sub.Close()
+
+	t.Run("delivers events for subscribed inbox", func(t *testing.T) {
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationAllocated))
+
+		select {
+		case event := <-sub.Events():
+			assert.Equal(t, domain.EventConversationAllocated, event.Type)
+			assert.Equal(t, tenantID, event.TenantID)
+		default:
+			t.Fatal("expected event")
+		}
+	})
+
+	t.Run("skips other inboxes and tenants", func(t *testing.T) {
+		svc.dispatch(notificationPayload(t, tenantID, uuid.New(), domain.EventConversationResolved))
+		svc.dispatch(notificationPayload(t, uuid.New(), inboxID, domain.EventConversationResolved))
+		svc.dispatch("not json")
+
+		assert.Len(t, sub.Events(), 0)
+	})
+
+	t.Run("drops events when buffer is full", func(t *testing.T) {
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationResolved))
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationDeallocated))
+
+		assert.Len(t, sub.Events(), 1)
+		<-sub.Events()
+	})
+}
+
+func TestEventStreamService_DispatchToShadow(t *testing.T) {
+	svc := NewEventStreamService(nil, nil, nil, EventStreamConfig{BufferSize: 4}, logger.NewNop())
+	tenantID := uuid.New()
+	mentorID := uuid.New()
+
+	sub := svc.subscribe(tenantID, nil, []uuid.UUID{mentorID})
+	defer sub.Close()
+
+	shadowPayload := func(key string, operatorID uuid.UUID) string {
+		event := domain.NewEvent(tenantID, domain.EventConversationAllocated, map[string]interface{}{
+			"conversation_id": uuid.New().String(),
+			"inbox_id":        uuid.New().String(),
+			key:               operatorID.String(),
+		})
+		payload, err := json.Marshal(NewEventEnvelope(event))
+		require.NoError(t, err)
+		return string(payload)
+	}
+
+	svc.dispatch(shadowPayload("assigned_operator_id", mentorID))
+	svc.dispatch(shadowPayload("previous_operator_id", mentorID))
+	svc.dispatch(shadowPayload("assigned_operator_id", uuid.New()))
+
+	assert.Len(t, sub.Events(), 2)
+}
+
+func TestEventSubscription_Close(t *testing.T) {
+	svc := NewEventStreamService(nil, nil, nil, DefaultEventStreamConfig(), logger.NewNop())
+	sub := svc.subscribe(uuid.New(), nil, nil)
+
+	sub.Close()
+	sub.Close()
+
+	_, open := <-sub.Events()
+	assert.False(t, open)
+	assert.Empty(t, svc.subscribers)
+}
+
+func TestEventStreamService_PublishIgnoresNonConversationEvents(t *testing.T) {
+	svc := NewEventStreamService(nil, nil, nil, DefaultEventStreamConfig(), logger.NewNop())
+	event := domain.NewEvent(uuid.New(), domain.EventOperatorStatusChanged, nil)
+
+	// No pool is configured; a conversation event would fail here
+	assert.NoError(t, svc.Publish(testutil.TestContext(t), event))
+}
+
+func TestEventStreamService_RefreshGrant(t *testing.T) {
+	signer := domain.NewRealtimeTokenSigner([]byte("0123456789abcdef0123456789abcdef"))
+	svc := NewEventStreamService(nil, nil, signer, EventStreamConfig{BufferSize: 4}, logger.NewNop())
+	tenantID := uuid.New()
+	operatorID := uuid.New()
+	inboxID := uuid.New()
+	otherInboxID := uuid.New()
+
+	grant := func(inboxID uuid.UUID, operatorID uuid.UUID, types ...domain.EventType) string {
+		token, err := signer.Sign(&domain.RealtimeGrant{
+			TenantID:   tenantID,
+			OperatorID: operatorID,
+			InboxIDs:   []uuid.UUID{inboxID},
+			EventTypes: types,
+			ExpiresAt:  time.Now().Add(time.Minute).Truncate(time.Second),
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	sub, err := svc.SubscribeWithToken(grant(inboxID, operatorID, domain.EventConversationAllocated))
+	require.NoError(t, err)
+	defer sub.Close()
+	assert.False(t, sub.ExpiresAt().IsZero())
+
+	t.Run("delivers only the granted event types", func(t *testing.T) {
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationResolved))
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationAllocated))
+
+		require.Len(t, sub.Events(), 1)
+		assert.Equal(t, domain.EventConversationAllocated, (<-sub.Events()).Type)
+	})
+
+	t.Run("ignores refreshes of another operator", func(t *testing.T) {
+		payload, err := json.Marshal(realtimeGrantNotification{SubscriptionID: sub.ID, Token: grant(otherInboxID, uuid.New())})
+		require.NoError(t, err)
+		svc.dispatchGrant(string(payload))
+
+		assert.Len(t, sub.Refreshed(), 0)
+	})
+
+	t.Run("applies the refreshed scope to the open stream", func(t *testing.T) {
+		payload, err := json.Marshal(realtimeGrantNotification{SubscriptionID: sub.ID, Token: grant(otherInboxID, operatorID)})
+		require.NoError(t, err)
+		svc.dispatchGrant(string(payload))
+
+		select {
+		case expiresAt := <-sub.Refreshed():
+			assert.Equal(t, sub.ExpiresAt(), expiresAt)
+		default:
+			t.Fatal("expected refresh")
+		}
+
+		svc.dispatch(notificationPayload(t, tenantID, inboxID, domain.EventConversationAllocated))
+		svc.dispatch(notificationPayload(t, tenantID, otherInboxID, domain.EventConversationResolved))
+		require.Len(t, sub.Events(), 1)
+		assert.Equal(t, domain.EventConversationResolved, (<-sub.Events()).Type)
+	})
+
+	t.Run("rejects invalid tokens on connect", func(t *testing.T) {
+		_, err := svc.SubscribeWithToken("abc.def")
+		assert.ErrorIs(t, err, domain.ErrRealtimeTokenInvalid)
+	})
+}
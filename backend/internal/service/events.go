@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// EventEnvelope is the JSON form of a domain event, used as the webhook body
+// and as the payload of streamed events
+type EventEnvelope struct {
+	ID         uuid.UUID              `json:"id"`
+	Type       domain.EventType       `json:"type"`
+	TenantID   uuid.UUID              `json:"tenant_id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+func NewEventEnvelope(event *domain.Event) EventEnvelope {
+	return EventEnvelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		TenantID:   event.TenantID,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	}
+}
+
+// ToEvent converts the envelope back into a domain event
+func (e EventEnvelope) ToEvent() *domain.Event {
+	return &domain.Event{
+		ID:         e.ID,
+		Type:       e.Type,
+		TenantID:   e.TenantID,
+		OccurredAt: e.OccurredAt,
+		Data:       e.Data,
+	}
+}
+
+// MultiPublisher fans an event out to several publishers. Every publisher is
+// called even if an earlier one fails; the failures are joined.
+type MultiPublisher []domain.EventPublisher
+
+func NewMultiPublisher(publishers ...domain.EventPublisher) MultiPublisher {
+	return MultiPublisher(publishers)
+}
+
+func (m MultiPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	var errs []error
+	for _, publisher := range m {
+		if publisher == nil {
+			continue
+		}
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// eventStager is a publisher that can write events in the transaction of
+// the state change producing them; the EventOutbox is one
+type eventStager interface {
+	Stage(ctx context.Context, tx pgx.Tx, events ...*domain.Event) error
+}
+
+// stageEvents writes events to the outbox inside tx, before commit, so a
+// crash between commit and publishEvent cannot lose them. Unlike publishing,
+// a failure fails the operation. Publishers without an outbox stage nothing.
+func stageEvents(ctx context.Context, publisher domain.EventPublisher, tx pgx.Tx, events ...*domain.Event) error {
+	stager, ok := publisher.(eventStager)
+	if !ok || len(events) == 0 {
+		return nil
+	}
+	return stager.Stage(ctx, tx, events...)
+}
+
+// publishEvent hands an event to the publisher once the state change is committed.
+// Publishing is best-effort: a nil publisher is a no-op and failures are only logged,
+// so a downstream outage never fails the operation that produced the event.
+func publishEvent(ctx context.Context, publisher domain.EventPublisher, log *logger.Logger, event *domain.Event) {
+	if publisher == nil {
+		return
+	}
+	if err := publisher.Publish(ctx, event); err != nil {
+		log.Warn("Failed to publish event",
+			zap.String("event_type", string(event.Type)),
+			zap.String("event_id", event.ID.String()),
+			zap.String("tenant_id", event.TenantID.String()),
+			zap.Error(err))
+	}
+}
+
+// conversationEventData builds the common payload for conversation.* events
+func conversationEventData(conv *domain.ConversationRef) map[string]interface{} {
+	return map[string]interface{}{
+		"conversation_id":          conv.ID.String(),
+		"external_conversation_id": conv.ExternalConversationID,
+		"inbox_id":                 conv.InboxID.String(),
+		"state":                    string(conv.State),
+		"assigned_operator_id":     uuidPtrToString(conv.AssignedOperatorID),
+	}
+}
+
+func uuidPtrToString(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
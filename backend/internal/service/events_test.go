@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stagingPublisher struct {
+	staged []*domain.Event
+}
+
+func (p *stagingPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	return nil
+}
+
+func (p *stagingPublisher) Stage(ctx context.Context, tx pgx.Tx, events ...*domain.Event) error {
+	p.staged = append(p.staged, events...)
+	return nil
+}
+
+func TestStageEvents(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	event := domain.NewEvent(uuid.New(), domain.EventConversationResolved, nil)
+
+	t.Run("stages with an outbox", func(t *testing.T) {
+		publisher := &stagingPublisher{}
+		require.NoError(t, stageEvents(ctx, publisher, nil, event))
+		assert.Equal(t, []*domain.Event{event}, publisher.staged)
+	})
+
+	t.Run("no-op without an outbox", func(t *testing.T) {
+		assert.NoError(t, stageEvents(ctx, NewMultiPublisher(), nil, event))
+		assert.NoError(t, stageEvents(ctx, nil, nil, event))
+	})
+}
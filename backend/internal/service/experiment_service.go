@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrExperimentNotFound       = errors.New("experiment not found")
+	ErrExperimentInboxNotFound  = errors.New("experiment inbox not found")
+	ErrExperimentAlreadyRunning = errors.New("an experiment is already running in the inbox")
+)
+
+// ExperimentService manages priority weight experiments. The conversation
+// service applies the running experiment of an inbox when it calculates a
+// priority and records the arm of enrolled conversations; the allocation
+// service stamps their first allocation for the results.
+type ExperimentService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewExperimentService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *ExperimentService {
+	return &ExperimentService{repos: repos, audit: audit, logger: log}
+}
+
+// ==================== Experiment Management ====================
+
+type CreateExperimentParams struct {
+	TenantID       uuid.UUID
+	InboxID        uuid.UUID
+	Name           string
+	WeightAlpha    decimal.Decimal
+	WeightBeta     decimal.Decimal
+	TrafficPercent int
+	CreatedBy      *uuid.UUID
+}
+
+// Create starts an experiment in the inbox; only one runs per inbox
+// Permission: Admin (enforced by router)
+func (s *ExperimentService) Create(ctx context.Context, params CreateExperimentParams) (*domain.PriorityExperiment, error) {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, params.InboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrExperimentInboxNotFound
+		}
+		return nil, err
+	}
+	if inbox.TenantID != params.TenantID {
+		return nil, ErrExperimentInboxNotFound
+	}
+
+	experiment := domain.NewPriorityExperiment(params.TenantID, params.InboxID, params.Name,
+		params.WeightAlpha, params.WeightBeta, params.TrafficPercent, params.CreatedBy)
+	if err := s.repos.Experiments.Create(ctx, experiment); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrExperimentAlreadyRunning
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Priority experiment started",
+		zap.String("experiment_id", experiment.ID.String()),
+		zap.String("inbox_id", experiment.InboxID.String()),
+		zap.String("alpha", experiment.WeightAlpha.String()),
+		zap.String("beta", experiment.WeightBeta.String()),
+		zap.Int("traffic_percent", experiment.TrafficPercent))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(params.TenantID, params.CreatedBy,
+		domain.AuditActionExperimentCreate, domain.AuditEntityExperiment, experiment.ID,
+		nil, experimentAuditSnapshot(experiment)))
+
+	return experiment, nil
+}
+
+// List returns the tenant's experiments, newest first
+func (s *ExperimentService) List(ctx context.Context, tenantID uuid.UUID) ([]*domain.PriorityExperiment, error) {
+	return s.repos.Experiments.GetByTenantID(ctx, tenantID)
+}
+
+// Get returns an experiment of the tenant
+func (s *ExperimentService) Get(ctx context.Context, tenantID, id uuid.UUID) (*domain.PriorityExperiment, error) {
+	experiment, err := s.repos.Experiments.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, err
+	}
+	if experiment.TenantID != tenantID {
+		return nil, ErrExperimentNotFound
+	}
+	return experiment, nil
+}
+
+// Rename changes the experiment's name. Weights and traffic cannot change
+// once started, or the arms would mix formulas; stop it and start another.
+// Permission: Admin (enforced by router)
+func (s *ExperimentService) Rename(ctx context.Context, tenantID, id uuid.UUID, name string, actorID *uuid.UUID) (*domain.PriorityExperiment, error) {
+	experiment, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := experimentAuditSnapshot(experiment)
+	experiment.Name = name
+	experiment.UpdatedAt = time.Now().UTC()
+	if err := s.repos.Experiments.Update(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionExperimentUpdate, domain.AuditEntityExperiment, experiment.ID,
+		before, experimentAuditSnapshot(experiment)))
+
+	return experiment, nil
+}
+
+// Stop ends a running experiment. Its conversations return to the tenant
+// weights at their next priority calculation; its results stay available.
+// Permission: Admin (enforced by router)
+func (s *ExperimentService) Stop(ctx context.Context, tenantID, id uuid.UUID, actorID *uuid.UUID) (*domain.PriorityExperiment, error) {
+	experiment, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := experimentAuditSnapshot(experiment)
+	if err := experiment.Stop(); err != nil {
+		return nil, err
+	}
+	if err := s.repos.Experiments.Update(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Priority experiment stopped",
+		zap.String("experiment_id", experiment.ID.String()),
+		zap.String("inbox_id", experiment.InboxID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionExperimentStop, domain.AuditEntityExperiment, experiment.ID,
+		before, experimentAuditSnapshot(experiment)))
+
+	return experiment, nil
+}
+
+// Delete removes an experiment with its recorded arms; a running one no
+// longer applies from the next priority calculation
+// Permission: Admin (enforced by router)
+func (s *ExperimentService) Delete(ctx context.Context, tenantID, id uuid.UUID, actorID *uuid.UUID) error {
+	experiment, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repos.Experiments.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionExperimentDelete, domain.AuditEntityExperiment, experiment.ID,
+		experimentAuditSnapshot(experiment), nil))
+
+	return nil
+}
+
+// Results returns the wait and resolution outcomes of each arm
+func (s *ExperimentService) Results(ctx context.Context, tenantID, id uuid.UUID) (*domain.ExperimentResults, error) {
+	experiment, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	outcomes, err := s.repos.Experiments.GetOutcomes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewExperimentResults(experiment, outcomes), nil
+}
+
+func experimentAuditSnapshot(experiment *domain.PriorityExperiment) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            experiment.Name,
+		"inbox_id":        experiment.InboxID.String(),
+		"weight_alpha":    experiment.WeightAlpha.String(),
+		"weight_beta":     experiment.WeightBeta.String(),
+		"traffic_percent": experiment.TrafficPercent,
+		"status":          string(experiment.Status),
+	}
+}
+
+// ==================== Outcome Tracking ====================
+
+// recordExperimentAllocations stamps the first allocation of the enrolled
+// conversations among convs, in the allocating transaction
+func recordExperimentAllocations(ctx context.Context, experiments domain.PriorityExperimentRepository, convs []*domain.ConversationRef) error {
+	ids := make([]uuid.UUID, len(convs))
+	for i, conv := range convs {
+		ids[i] = conv.ID
+	}
+	return experiments.MarkAllocated(ctx, ids, time.Now().UTC())
+}
@@ -8,11 +8,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/pkg/retry"
 	"github.com/inbox-allocation-service/internal/repository"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+var (
+	gracePeriodsOverdue         = metrics.NewGauge("grace_periods_overdue")
+	gracePeriodOldestOverdue    = metrics.NewGauge("grace_period_oldest_overdue_seconds")
+	gracePeriodProcessingErrors = metrics.NewCounter("grace_period_processing_errors_total")
+	// gracePeriodProcessingLag is how long after expiring a grace period was processed
+	gracePeriodProcessingLag = metrics.NewHistogram("grace_period_processing_lag_seconds",
+		[]int64{1, 5, 15, 30, 60, 120, 300, 900, 3600})
+)
+
 // GracePeriodResult holds the result of processing grace periods
 type GracePeriodResult struct {
 	Processed      int
@@ -21,20 +32,51 @@ type GracePeriodResult struct {
 	Errors         int
 }
 
+// GracePipelineHealth is the state of the grace period pipeline's backlog
+type GracePipelineHealth struct {
+	Overdue int64
+	// Lag is how long the oldest overdue grace period has been waiting
+	Lag time.Duration
+	// Degradations says why the pipeline is degraded, empty when healthy
+	Degradations []string
+}
+
+// Degraded reports whether the backlog exceeds the pipeline policy
+func (h *GracePipelineHealth) Degraded() bool {
+	return len(h.Degradations) > 0
+}
+
+// ErrGracePeriodExpired is returned when extending a grace period that has
+// already expired; the worker returns its conversation to the queue
+var ErrGracePeriodExpired = errors.New("grace period has already expired")
+
+// ErrGracePeriodNotFound is returned when the grace period does not exist in
+// the tenant
+var ErrGracePeriodNotFound = errors.New("grace period not found")
+
 type GracePeriodService struct {
 	repos  *repository.RepositoryContainer
 	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	policy domain.GracePipelinePolicy
 	logger *logger.Logger
 }
 
 func NewGracePeriodService(
 	repos *repository.RepositoryContainer,
 	pool *pgxpool.Pool,
+	events domain.EventPublisher,
+	audit *AuditService,
+	policy domain.GracePipelinePolicy,
 	log *logger.Logger,
 ) *GracePeriodService {
 	return &GracePeriodService{
 		repos:  repos,
 		pool:   pool,
+		events: events,
+		audit:  audit,
+		policy: policy,
 		logger: log,
 	}
 }
@@ -64,18 +106,39 @@ func (s *GracePeriodService) ProcessExpiredGracePeriods(ctx context.Context, bat
 	}
 
 	result.Processed = len(expired)
+	var pending []*domain.Event
 
 	// Process each expired grace period
 	for _, gpa := range expired {
-		err := s.processGracePeriod(ctx, gpa, result)
+		gracePeriodProcessingLag.Observe(int64(start.Sub(gpa.ExpiresAt).Seconds()))
+
+		// A conversation changed between its read and the deallocation is
+		// processed again from its new state
+		var conv *domain.ConversationRef
+		err := retry.Do(ctx, conflictRetry, func() error {
+			var err error
+			conv, err = s.processGracePeriod(ctx, gpa, result)
+			return err
+		})
 		if err != nil {
 			s.logger.Error("Failed to process grace period",
 				zap.String("grace_period_id", gpa.ID.String()),
 				zap.String("conversation_id", gpa.ConversationID.String()),
 				zap.Error(err))
+			gracePeriodProcessingErrors.Inc()
 			result.Errors++
 			continue
 		}
+		if conv != nil {
+			data := conversationEventData(conv)
+			data["previous_operator_id"] = gpa.OperatorID.String()
+			data["reason"] = "grace_period_expired"
+			pending = append(pending, domain.NewEvent(conv.TenantID, domain.EventConversationDeallocated, data))
+		}
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return nil, err
 	}
 
 	// Commit transaction
@@ -83,6 +146,11 @@ func (s *GracePeriodService) ProcessExpiredGracePeriods(ctx context.Context, bat
 		return nil, err
 	}
 
+	// Events are emitted only once the deallocations are durable
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
 	s.logger.Info("Grace period processing completed",
 		zap.Int("processed", result.Processed),
 		zap.Int("transitioned", result.Transitioned),
@@ -94,11 +162,12 @@ func (s *GracePeriodService) ProcessExpiredGracePeriods(ctx context.Context, bat
 }
 
 // processGracePeriod handles a single grace period expiration
+// Returns the conversation when it was returned to the queue, nil otherwise
 func (s *GracePeriodService) processGracePeriod(
 	ctx context.Context,
 	gpa *domain.GracePeriodAssignment,
 	result *GracePeriodResult,
-) error {
+) (*domain.ConversationRef, error) {
 	// Get the conversation
 	conv, err := s.repos.ConversationRefs.GetByID(ctx, gpa.ConversationID)
 	if err != nil {
@@ -106,9 +175,9 @@ func (s *GracePeriodService) processGracePeriod(
 			// Conversation was deleted, just remove the grace period
 			s.logger.Debug("Conversation not found, removing grace period",
 				zap.String("conversation_id", gpa.ConversationID.String()))
-			return s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
+			return nil, s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
 		}
-		return err
+		return nil, err
 	}
 
 	// Check if conversation is still ALLOCATED
@@ -118,7 +187,7 @@ func (s *GracePeriodService) processGracePeriod(
 			zap.String("conversation_id", conv.ID.String()),
 			zap.String("current_state", string(conv.State)))
 		result.AlreadyHandled++
-		return s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
+		return nil, s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
 	}
 
 	// Verify the assigned operator matches (extra safety check)
@@ -128,21 +197,21 @@ func (s *GracePeriodService) processGracePeriod(
 			zap.String("conversation_id", conv.ID.String()),
 			zap.String("grace_operator_id", gpa.OperatorID.String()))
 		result.AlreadyHandled++
-		return s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
+		return nil, s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID)
 	}
 
 	// Transition conversation to QUEUED
 	if err := conv.Deallocate(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Delete grace period entry
 	if err := s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID); err != nil {
-		return err
+		return nil, err
 	}
 
 	s.logger.Info("Conversation returned to queue due to grace period expiration",
@@ -151,7 +220,7 @@ func (s *GracePeriodService) processGracePeriod(
 		zap.String("reason", string(gpa.Reason)))
 
 	result.Transitioned++
-	return nil
+	return conv, nil
 }
 
 // CreateGracePeriod creates a grace period for a conversation
@@ -211,3 +280,97 @@ func (s *GracePeriodService) CancelByConversation(ctx context.Context, conversat
 
 	return nil
 }
+
+// List returns the tenant's grace periods matching the filter, soonest to
+// expire first
+func (s *GracePeriodService) List(ctx context.Context, filter domain.GracePeriodFilter) ([]*domain.GracePeriodAssignment, error) {
+	return s.repos.GracePeriodAssignments.List(ctx, filter)
+}
+
+// Cancel deletes the tenant's grace period: its conversation stays assigned
+// to the operator instead of returning to the queue
+func (s *GracePeriodService) Cancel(ctx context.Context, tenantID, id uuid.UUID, actorID *uuid.UUID) error {
+	gpa, err := s.repos.GracePeriodAssignments.GetByID(ctx, tenantID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrGracePeriodNotFound
+		}
+		return err
+	}
+	if err := s.repos.GracePeriodAssignments.Delete(ctx, gpa.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Grace period cancelled",
+		zap.String("grace_period_id", gpa.ID.String()),
+		zap.String("conversation_id", gpa.ConversationID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionGracePeriodCancel, domain.AuditEntityConversation, gpa.ConversationID,
+		gracePeriodAuditSnapshot(gpa), nil))
+
+	return nil
+}
+
+// Extend moves the expiry of the tenant's grace period by the given
+// duration. Once a grace period has expired the worker may already be
+// returning its conversation to the queue, so it can no longer be extended.
+func (s *GracePeriodService) Extend(ctx context.Context, tenantID, id uuid.UUID, by time.Duration, actorID *uuid.UUID) (*domain.GracePeriodAssignment, error) {
+	gpa, err := s.repos.GracePeriodAssignments.GetByID(ctx, tenantID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrGracePeriodNotFound
+		}
+		return nil, err
+	}
+	before := gracePeriodAuditSnapshot(gpa)
+
+	gpa.ExpiresAt = gpa.ExpiresAt.Add(by)
+	if err := s.repos.GracePeriodAssignments.SetExpiry(ctx, gpa.ID, gpa.ExpiresAt); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrGracePeriodExpired
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Grace period extended",
+		zap.String("grace_period_id", gpa.ID.String()),
+		zap.String("conversation_id", gpa.ConversationID.String()),
+		zap.Time("expires_at", gpa.ExpiresAt))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionGracePeriodExtend, domain.AuditEntityConversation, gpa.ConversationID,
+		before, gracePeriodAuditSnapshot(gpa)))
+
+	return gpa, nil
+}
+
+func gracePeriodAuditSnapshot(gpa *domain.GracePeriodAssignment) map[string]interface{} {
+	return map[string]interface{}{
+		"grace_period_id": gpa.ID.String(),
+		"operator_id":     gpa.OperatorID.String(),
+		"reason":          string(gpa.Reason),
+		"expires_at":      gpa.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// PipelineHealth reads the backlog of expired grace periods, publishes it
+// as metrics and evaluates it against the pipeline policy. A growing backlog
+// means conversations stay assigned to operators who went offline.
+func (s *GracePeriodService) PipelineHealth(ctx context.Context) (*GracePipelineHealth, error) {
+	backlog, err := s.repos.GracePeriodAssignments.GetBacklog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	health := &GracePipelineHealth{
+		Overdue:      backlog.Overdue,
+		Lag:          backlog.Lag(now),
+		Degradations: s.policy.Degradations(*backlog, now),
+	}
+	gracePeriodsOverdue.Set(health.Overdue)
+	gracePeriodOldestOverdue.Set(int64(health.Lag.Seconds()))
+
+	return health, nil
+}
@@ -5,27 +5,83 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/breaker"
+	"github.com/inbox-allocation-service/internal/pkg/encryption"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
 	"github.com/inbox-allocation-service/internal/repository"
 	"go.uber.org/zap"
 )
 
 var (
-	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
-	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
-	ErrIdempotencyKeyExpired  = errors.New("idempotency key has expired")
-	ErrRequestHashMismatch    = errors.New("request body does not match stored hash")
+	ErrIdempotencyKeyExists     = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyNotFound   = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExpired    = errors.New("idempotency key has expired")
+	ErrRequestHashMismatch      = errors.New("request body does not match stored hash")
+	ErrIdempotencyUnavailable   = errors.New("idempotency storage is unavailable")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+	ErrIdempotencyClaimLost     = errors.New("idempotency claim was taken over")
 )
 
+// ==================== Degradation Policy ====================
+
+// DegradationPolicy decides what happens to a keyed request when idempotency
+// storage cannot be reached
+type DegradationPolicy string
+
+const (
+	// DegradationFailOpen processes the request without idempotency protection
+	DegradationFailOpen DegradationPolicy = "fail_open"
+	// DegradationFailClosed rejects the request so the client retries later
+	DegradationFailClosed DegradationPolicy = "fail_closed"
+)
+
+func (p DegradationPolicy) IsValid() bool {
+	switch p {
+	case DegradationFailOpen, DegradationFailClosed:
+		return true
+	}
+	return false
+}
+
+// EndpointClass groups endpoints that share a degradation policy
+type EndpointClass string
+
+const (
+	EndpointClassAllocation EndpointClass = "allocation"
+	EndpointClassLifecycle  EndpointClass = "lifecycle"
+)
+
+// DegradationConfig configures behavior while idempotency storage is failing
+type DegradationConfig struct {
+	// DefaultPolicy applies to endpoint classes without an override
+	DefaultPolicy DegradationPolicy
+	// Policies overrides the default per endpoint class
+	Policies map[EndpointClass]DegradationPolicy
+	// Breaker stops storage calls after repeated failures and probes for recovery
+	Breaker breaker.Config
+}
+
 // IdempotencyConfig holds configuration for idempotency
 type IdempotencyConfig struct {
 	TTL             time.Duration
 	CleanupInterval time.Duration
 	CleanupBatch    int
+	// LockTimeout is how long a request holds its claim of a key; a claim
+	// still PENDING after it (its request crashed) is taken over
+	LockTimeout time.Duration
+	// InFlightWait is how long a duplicate of an in-flight request waits for
+	// its response before ErrIdempotencyKeyInProgress
+	InFlightWait time.Duration
+	Degradation  DegradationConfig
+	// Encryption seals cached response bodies at rest; nil stores them in
+	// plaintext
+	Encryption *encryption.Keyring
 }
 
 // DefaultIdempotencyConfig returns sensible defaults
@@ -34,13 +90,28 @@ func DefaultIdempotencyConfig() IdempotencyConfig {
 		TTL:             24 * time.Hour,
 		CleanupInterval: 1 * time.Hour,
 		CleanupBatch:    100,
+		LockTimeout:     1 * time.Minute,
+		InFlightWait:    5 * time.Second,
+		Degradation: DegradationConfig{
+			DefaultPolicy: DegradationFailOpen,
+			Breaker:       breaker.DefaultConfig(),
+		},
 	}
 }
 
+var (
+	idempotencyDegradedFailOpen   = metrics.NewCounter("idempotency_degraded_fail_open_total")
+	idempotencyDegradedFailClosed = metrics.NewCounter("idempotency_degraded_fail_closed_total")
+	idempotencyStorageErrors      = metrics.NewCounter("idempotency_storage_errors_total")
+	idempotencyDecryptErrors      = metrics.NewCounter("idempotency_decrypt_errors_total")
+	idempotencyReencrypted        = metrics.NewCounter("idempotency_reencrypted_total")
+)
+
 type IdempotencyService struct {
-	repos  *repository.RepositoryContainer
-	config IdempotencyConfig
-	logger *logger.Logger
+	repos   *repository.RepositoryContainer
+	config  IdempotencyConfig
+	breaker *breaker.Breaker
+	logger  *logger.Logger
 }
 
 func NewIdempotencyService(
@@ -48,11 +119,95 @@ func NewIdempotencyService(
 	config IdempotencyConfig,
 	log *logger.Logger,
 ) *IdempotencyService {
+	if !config.Degradation.DefaultPolicy.IsValid() {
+		log.Warn("Invalid idempotency degradation policy, using fail_open",
+			zap.String("policy", string(config.Degradation.DefaultPolicy)))
+		config.Degradation.DefaultPolicy = DegradationFailOpen
+	}
+	defaults := DefaultIdempotencyConfig()
+	if config.CleanupBatch <= 0 {
+		config.CleanupBatch = defaults.CleanupBatch
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = defaults.LockTimeout
+	}
+	if config.InFlightWait < 0 {
+		config.InFlightWait = 0
+	}
+	for class, policy := range config.Degradation.Policies {
+		if !policy.IsValid() {
+			log.Warn("Ignoring invalid idempotency degradation override",
+				zap.String("endpoint_class", string(class)),
+				zap.String("policy", string(policy)))
+			delete(config.Degradation.Policies, class)
+		}
+	}
 	return &IdempotencyService{
-		repos:  repos,
-		config: config,
-		logger: log,
+		repos:   repos,
+		config:  config,
+		breaker: breaker.New(config.Degradation.Breaker),
+		logger:  log,
+	}
+}
+
+// PolicyFor returns the degradation policy of an endpoint class
+func (s *IdempotencyService) PolicyFor(class EndpointClass) DegradationPolicy {
+	if policy, ok := s.config.Degradation.Policies[class]; ok {
+		return policy
+	}
+	return s.config.Degradation.DefaultPolicy
+}
+
+// Degrade applies the class policy to a request whose idempotency check failed
+// with ErrIdempotencyUnavailable. It returns true when the request may proceed
+// without idempotency protection.
+func (s *IdempotencyService) Degrade(class EndpointClass, endpoint string, cause error) bool {
+	policy := s.PolicyFor(class)
+	if policy == DegradationFailClosed {
+		idempotencyDegradedFailClosed.Inc()
+		s.logger.Warn("Idempotency storage unavailable, rejecting request (fail-closed)",
+			zap.String("endpoint_class", string(class)),
+			zap.String("endpoint", endpoint),
+			zap.String("breaker_state", string(s.breaker.State())),
+			zap.Error(cause))
+		return false
 	}
+
+	idempotencyDegradedFailOpen.Inc()
+	s.logger.Error("Idempotency storage unavailable, processing request WITHOUT idempotency protection (fail-open)",
+		zap.String("endpoint_class", string(class)),
+		zap.String("endpoint", endpoint),
+		zap.String("breaker_state", string(s.breaker.State())),
+		zap.Error(cause))
+	return true
+}
+
+// guard wraps a storage call with the circuit breaker. Storage errors are
+// reported as ErrIdempotencyUnavailable; lookups that find nothing, duplicate
+// keys and cancelled requests are not storage failures.
+func (s *IdempotencyService) guard(fn func() error) error {
+	if !s.breaker.Allow() {
+		return fmt.Errorf("%w: circuit open", ErrIdempotencyUnavailable)
+	}
+
+	err := fn()
+	if isIdempotencyStorageFailure(err) {
+		idempotencyStorageErrors.Inc()
+		s.breaker.RecordFailure()
+		return fmt.Errorf("%w: %v", ErrIdempotencyUnavailable, err)
+	}
+
+	s.breaker.RecordSuccess()
+	return err
+}
+
+func isIdempotencyStorageFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	return !errors.Is(err, domain.ErrNotFound) &&
+		!errors.Is(err, domain.ErrAlreadyExists) &&
+		!errors.Is(err, context.Canceled)
 }
 
 // CachedResponse holds a cached response from an idempotency key
@@ -61,98 +216,174 @@ type CachedResponse struct {
 	Body   []byte
 }
 
-// CheckKey checks if an idempotency key exists and returns the cached response
-// Returns nil if key doesn't exist (proceed with request)
-// Returns CachedResponse if key exists (return cached response)
-// Returns error if key exists but request hash doesn't match
-func (s *IdempotencyService) CheckKey(
+// idempotencyPollInterval is how often a request waiting on the claim of
+// another request looks for its response
+const idempotencyPollInterval = 100 * time.Millisecond
+
+// Claim reserves the key for the request before it runs, so that of
+// concurrent requests with the same key only one executes.
+// Returns the claim if the request should proceed; settle it with Complete or Release
+// Returns CachedResponse if the key completed (return cached response)
+// Returns ErrRequestHashMismatch if the key was used with another request body
+// Returns ErrIdempotencyKeyInProgress if another request still holds the key
+// after waiting InFlightWait for its response
+// Returns ErrIdempotencyUnavailable if storage is failing or the circuit is open
+func (s *IdempotencyService) Claim(
 	ctx context.Context,
 	tenantID uuid.UUID,
 	key string,
+	endpoint, method string,
 	requestBody []byte,
-) (*CachedResponse, error) {
-	ik, err := s.repos.Idempotency.GetByKey(ctx, tenantID, key)
-	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			// Key doesn't exist, proceed with request
-			return nil, nil
-		}
-		return nil, err
+) (*domain.IdempotencyKey, *CachedResponse, error) {
+	var requestHash *string
+	if len(requestBody) > 0 {
+		h := hashRequestBody(requestBody)
+		requestHash = &h
 	}
+	deadline := time.Now().Add(s.config.InFlightWait)
 
-	// Key exists - check if expired
-	if ik.IsExpired() {
-		// Delete expired key and proceed
-		_ = s.repos.Idempotency.Delete(ctx, ik.ID)
-		return nil, nil
-	}
+	for {
+		claim := domain.NewIdempotencyClaim(key, tenantID, endpoint, method, requestHash, s.config.LockTimeout, s.config.TTL)
+		var claimed bool
+		err := s.guard(func() error {
+			var err error
+			claimed, err = s.repos.Idempotency.Claim(ctx, claim)
+			return err
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if claimed {
+			return claim, nil, nil
+		}
+
+		var ik *domain.IdempotencyKey
+		err = s.guard(func() error {
+			var err error
+			ik, err = s.repos.Idempotency.GetByKey(ctx, tenantID, key)
+			return err
+		})
+		if errors.Is(err, domain.ErrNotFound) {
+			// Released in between: claim again
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if ik.IsExpired() || ik.IsStale() {
+			// Taken over by the next claim
+			continue
+		}
 
-	// Key exists and not expired
-	// Optionally validate request hash if provided
-	if ik.RequestHash != nil && len(requestBody) > 0 {
-		hash := hashRequestBody(requestBody)
-		if hash != *ik.RequestHash {
+		if requestHash != nil && ik.RequestHash != nil && *requestHash != *ik.RequestHash {
 			s.logger.Warn("Idempotency key reused with different request body",
 				zap.String("key", key),
 				zap.String("tenant_id", tenantID.String()))
-			return nil, ErrRequestHashMismatch
+			return nil, nil, ErrRequestHashMismatch
+		}
+
+		if !ik.IsPending() {
+			return s.replay(ik)
+		}
+
+		if !time.Now().Before(deadline) {
+			s.logger.Info("Idempotency key still held by an in-flight request",
+				zap.String("key", key),
+				zap.String("tenant_id", tenantID.String()))
+			return nil, nil, ErrIdempotencyKeyInProgress
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(idempotencyPollInterval):
 		}
 	}
+}
+
+// replay returns the stored response of a completed key
+func (s *IdempotencyService) replay(ik *domain.IdempotencyKey) (*domain.IdempotencyKey, *CachedResponse, error) {
+	// A response that cannot be decrypted cannot be replayed; processing the
+	// request again is left to the degradation policy
+	body, err := s.openResponse(ik)
+	if err != nil {
+		idempotencyDecryptErrors.Inc()
+		s.logger.Error("Failed to decrypt cached idempotency response",
+			zap.String("key", ik.Key),
+			zap.String("tenant_id", ik.TenantID.String()),
+			zap.Error(err))
+		return nil, nil, fmt.Errorf("%w: %v", ErrIdempotencyUnavailable, err)
+	}
 
 	s.logger.Info("Returning cached response for idempotency key",
-		zap.String("key", key),
-		zap.String("tenant_id", tenantID.String()),
+		zap.String("key", ik.Key),
+		zap.String("tenant_id", ik.TenantID.String()),
 		zap.Int("status", ik.ResponseStatus))
 
-	return &CachedResponse{
+	return nil, &CachedResponse{
 		Status: ik.ResponseStatus,
-		Body:   ik.ResponseBody,
+		Body:   body,
 	}, nil
 }
 
-// StoreResult stores the result of a request with an idempotency key
-func (s *IdempotencyService) StoreResult(
+// Complete stores the response of the request holding claim, for replay to
+// later requests with the key. ErrIdempotencyClaimLost when the claim was
+// taken over after its lock timeout.
+func (s *IdempotencyService) Complete(
 	ctx context.Context,
-	tenantID uuid.UUID,
-	key string,
-	endpoint, method string,
-	requestBody []byte,
+	claim *domain.IdempotencyKey,
 	responseStatus int,
 	responseBody []byte,
 ) error {
-	var requestHash *string
-	if len(requestBody) > 0 {
-		h := hashRequestBody(requestBody)
-		requestHash = &h
+	claim.ResponseStatus = responseStatus
+	claim.ExpiresAt = time.Now().UTC().Add(s.config.TTL)
+	if err := s.sealResponse(claim, responseBody); err != nil {
+		s.logger.Error("Failed to encrypt idempotency response",
+			zap.String("key", claim.Key),
+			zap.Error(err))
+		return err
 	}
 
-	ik := domain.NewIdempotencyKey(
-		key,
-		tenantID,
-		endpoint,
-		method,
-		requestHash,
-		responseStatus,
-		responseBody,
-		s.config.TTL,
-	)
-
-	if err := s.repos.Idempotency.Create(ctx, ik); err != nil {
+	err := s.guard(func() error {
+		return s.repos.Idempotency.Complete(ctx, claim)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Warn("Idempotency claim was taken over before the request completed",
+			zap.String("key", claim.Key),
+			zap.String("tenant_id", claim.TenantID.String()),
+			zap.Duration("lock_timeout", s.config.LockTimeout))
+		return ErrIdempotencyClaimLost
+	}
+	if err != nil {
 		s.logger.Error("Failed to store idempotency key",
-			zap.String("key", key),
+			zap.String("key", claim.Key),
 			zap.Error(err))
 		return err
 	}
 
 	s.logger.Debug("Stored idempotency key",
-		zap.String("key", key),
-		zap.String("tenant_id", tenantID.String()),
+		zap.String("key", claim.Key),
+		zap.String("tenant_id", claim.TenantID.String()),
 		zap.Int("status", responseStatus),
-		zap.Time("expires_at", ik.ExpiresAt))
+		zap.Time("expires_at", claim.ExpiresAt))
 
 	return nil
 }
 
+// Release drops the claim without a response, so that a retry with the key
+// executes again
+func (s *IdempotencyService) Release(ctx context.Context, claim *domain.IdempotencyKey) error {
+	err := s.guard(func() error {
+		return s.repos.Idempotency.Release(ctx, claim)
+	})
+	if err != nil {
+		s.logger.Warn("Failed to release idempotency claim; it is taken over after the lock timeout",
+			zap.String("key", claim.Key),
+			zap.Duration("lock_timeout", s.config.LockTimeout),
+			zap.Error(err))
+	}
+	return err
+}
+
 // CleanupExpired removes expired idempotency keys
 func (s *IdempotencyService) CleanupExpired(ctx context.Context) (int64, error) {
 	count, err := s.repos.Idempotency.DeleteExpired(ctx)
@@ -168,6 +399,85 @@ func (s *IdempotencyService) CleanupExpired(ctx context.Context) (int64, error)
 	return count, nil
 }
 
+// Reencrypt stores every unexpired response under the active encryption key:
+// plaintext rows written before encryption was enabled, and rows sealed with
+// a rotated-out key. With a decrypt-only keyring responses are decrypted back
+// to plaintext. Rows that cannot be decrypted are skipped. Returns the number
+// of rows rewritten.
+func (s *IdempotencyService) Reencrypt(ctx context.Context) (int, error) {
+	if s.config.Encryption == nil {
+		return 0, nil
+	}
+	var target *string
+	if active := s.config.Encryption.ActiveKeyID(); active != "" {
+		target = &active
+	}
+
+	var (
+		rewritten int
+		after     uuid.UUID
+	)
+	for {
+		keys, err := s.repos.Idempotency.GetToReencrypt(ctx, target, after, s.config.CleanupBatch)
+		if err != nil {
+			return rewritten, err
+		}
+		for _, ik := range keys {
+			after = ik.ID
+			body, err := s.openResponse(ik)
+			if err != nil {
+				idempotencyDecryptErrors.Inc()
+				s.logger.Warn("Skipping idempotency key that cannot be decrypted",
+					zap.String("id", ik.ID.String()),
+					zap.Any("encryption_key_id", ik.EncryptionKeyID),
+					zap.Error(err))
+				continue
+			}
+			if err := s.sealResponse(ik, body); err != nil {
+				return rewritten, err
+			}
+			if err := s.repos.Idempotency.UpdateResponse(ctx, ik); err != nil {
+				return rewritten, err
+			}
+			rewritten++
+			idempotencyReencrypted.Inc()
+		}
+		if len(keys) < s.config.CleanupBatch {
+			break
+		}
+	}
+
+	if rewritten > 0 {
+		s.logger.Info("Re-encrypted idempotency responses",
+			zap.Int("count", rewritten),
+			zap.Any("encryption_key_id", target))
+	}
+	return rewritten, nil
+}
+
+// sealResponse stores body on the key, encrypted with the active key when
+// one is configured
+func (s *IdempotencyService) sealResponse(ik *domain.IdempotencyKey, body []byte) error {
+	if s.config.Encryption.ActiveKeyID() == "" {
+		ik.ResponseBody, ik.ResponseCiphertext, ik.EncryptionKeyID = body, nil, nil
+		return nil
+	}
+	keyID, ciphertext, err := s.config.Encryption.Seal(ik.TenantID, body)
+	if err != nil {
+		return err
+	}
+	ik.ResponseBody, ik.ResponseCiphertext, ik.EncryptionKeyID = nil, ciphertext, &keyID
+	return nil
+}
+
+// openResponse returns the plaintext response body of the key
+func (s *IdempotencyService) openResponse(ik *domain.IdempotencyKey) ([]byte, error) {
+	if ik.EncryptionKeyID == nil {
+		return ik.ResponseBody, nil
+	}
+	return s.config.Encryption.Open(*ik.EncryptionKeyID, ik.TenantID, ik.ResponseCiphertext)
+}
+
 // hashRequestBody creates a SHA256 hash of the request body
 func hashRequestBody(body []byte) string {
 	h := sha256.Sum256(body)
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/breaker"
+	"github.com/inbox-allocation-service/internal/pkg/encryption"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDegradationTestService(cfg DegradationConfig) *IdempotencyService {
+	config := DefaultIdempotencyConfig()
+	config.Degradation = cfg
+	return NewIdempotencyService(nil, config, logger.NewNop())
+}
+
+func TestIdempotencyService_PolicyFor(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{
+		DefaultPolicy: DegradationFailOpen,
+		Policies: map[EndpointClass]DegradationPolicy{
+			EndpointClassAllocation: DegradationFailClosed,
+			EndpointClassLifecycle:  "sometimes",
+		},
+		Breaker: breaker.DefaultConfig(),
+	})
+
+	assert.Equal(t, DegradationFailClosed, svc.PolicyFor(EndpointClassAllocation))
+	assert.Equal(t, DegradationFailOpen, svc.PolicyFor(EndpointClassLifecycle), "invalid override falls back to default")
+	assert.Equal(t, DegradationFailOpen, svc.PolicyFor("other"))
+}
+
+func TestIdempotencyService_InvalidDefaultPolicy(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{DefaultPolicy: "", Breaker: breaker.DefaultConfig()})
+
+	assert.Equal(t, DegradationFailOpen, svc.PolicyFor(EndpointClassAllocation))
+}
+
+func TestIdempotencyService_Degrade(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{
+		DefaultPolicy: DegradationFailOpen,
+		Policies:      map[EndpointClass]DegradationPolicy{EndpointClassAllocation: DegradationFailClosed},
+		Breaker:       breaker.DefaultConfig(),
+	})
+	cause := ErrIdempotencyUnavailable
+
+	assert.False(t, svc.Degrade(EndpointClassAllocation, "/api/v1/allocate", cause))
+	assert.True(t, svc.Degrade(EndpointClassLifecycle, "/api/v1/resolve", cause))
+}
+
+func TestIdempotencyService_GuardOpensCircuit(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{
+		DefaultPolicy: DegradationFailOpen,
+		Breaker:       breaker.Config{FailureThreshold: 2, OpenTimeout: time.Hour},
+	})
+	storageErr := errors.New("connection refused")
+
+	calls := 0
+	failing := func() error {
+		calls++
+		return storageErr
+	}
+
+	for i := 0; i < 2; i++ {
+		err := svc.guard(failing)
+		assert.ErrorIs(t, err, ErrIdempotencyUnavailable)
+	}
+
+	// Circuit is open: storage is no longer called
+	err := svc.guard(failing)
+	assert.ErrorIs(t, err, ErrIdempotencyUnavailable)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyService_GuardIgnoresExpectedErrors(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{
+		DefaultPolicy: DegradationFailOpen,
+		Breaker:       breaker.Config{FailureThreshold: 1, OpenTimeout: time.Hour},
+	})
+
+	err := svc.guard(func() error { return domain.ErrNotFound })
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NotErrorIs(t, err, ErrIdempotencyUnavailable)
+
+	err = svc.guard(func() error { return domain.ErrAlreadyExists })
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+	assert.Equal(t, breaker.StateClosed, svc.breaker.State())
+}
+
+func TestIdempotencyService_ClaimDefaults(t *testing.T) {
+	config := DefaultIdempotencyConfig()
+	config.LockTimeout = 0
+	config.InFlightWait = -time.Second
+	svc := NewIdempotencyService(nil, config, logger.NewNop())
+
+	assert.Equal(t, DefaultIdempotencyConfig().LockTimeout, svc.config.LockTimeout)
+	assert.Zero(t, svc.config.InFlightWait)
+}
+
+func TestIdempotencyService_ClaimCircuitOpen(t *testing.T) {
+	svc := newDegradationTestService(DegradationConfig{
+		DefaultPolicy: DegradationFailOpen,
+		Breaker:       breaker.Config{FailureThreshold: 1, OpenTimeout: time.Hour},
+	})
+	_ = svc.guard(func() error { return errors.New("connection refused") })
+
+	// Storage is not called while the circuit is open
+	claim, cached, err := svc.Claim(context.Background(), uuid.New(), "key", "/api/v1/allocate", "POST", []byte(`{}`))
+	assert.ErrorIs(t, err, ErrIdempotencyUnavailable)
+	assert.Nil(t, claim)
+	assert.Nil(t, cached)
+}
+
+func TestIdempotencyService_SealResponse(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	keyring, err := encryption.NewKeyring(map[string]string{"k1": key}, "k1")
+	require.NoError(t, err)
+
+	config := DefaultIdempotencyConfig()
+	config.Encryption = keyring
+	svc := NewIdempotencyService(nil, config, logger.NewNop())
+
+	body := []byte(`{"customer_phone_number":"+15550100"}`)
+	ik := domain.NewIdempotencyKey("key", uuid.New(), "/api/v1/allocate", "POST", nil, 200, body, time.Hour)
+	require.NoError(t, svc.sealResponse(ik, body))
+	assert.Nil(t, ik.ResponseBody)
+	require.NotNil(t, ik.EncryptionKeyID)
+	assert.Equal(t, "k1", *ik.EncryptionKeyID)
+	assert.NotContains(t, string(ik.ResponseCiphertext), "+15550100")
+
+	opened, err := svc.openResponse(ik)
+	require.NoError(t, err)
+	assert.Equal(t, body, opened)
+
+	// Without encryption, plaintext rows are still read and sealed rows are not
+	plain := NewIdempotencyService(nil, DefaultIdempotencyConfig(), logger.NewNop())
+	_, err = plain.openResponse(ik)
+	assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+
+	require.NoError(t, plain.sealResponse(ik, body))
+	assert.Nil(t, ik.EncryptionKeyID)
+	opened, err = svc.openResponse(ik)
+	require.NoError(t, err)
+	assert.Equal(t, body, opened)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInboxAdminInboxNotFound    = errors.New("inbox admin inbox not found")
+	ErrInboxAdminOperatorNotFound = errors.New("inbox admin operator not found")
+)
+
+// InboxAdminService manages inbox-scoped admin grants. LabelService,
+// SubscriptionService and RoutingRuleService consult the grants through
+// isInboxAdmin.
+type InboxAdminService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewInboxAdminService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *InboxAdminService {
+	return &InboxAdminService{repos: repos, audit: audit, logger: log}
+}
+
+// ==================== Grant Management ====================
+
+// ListInboxAdmins returns the admins of the inbox
+// Permission: Admin (enforced by router)
+func (s *InboxAdminService) ListInboxAdmins(ctx context.Context, tenantID, inboxID uuid.UUID) ([]*domain.InboxAdmin, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	return s.repos.InboxAdmins.GetByInboxID(ctx, inboxID)
+}
+
+// GrantInboxAdmin makes the operator an admin of the inbox. Granting twice is a no-op.
+// Permission: Admin (enforced by router)
+func (s *InboxAdminService) GrantInboxAdmin(ctx context.Context, tenantID, inboxID, operatorID uuid.UUID, grantedBy *uuid.UUID) (*domain.InboxAdmin, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	if err := s.verifyOperator(ctx, tenantID, operatorID); err != nil {
+		return nil, err
+	}
+
+	grant := domain.NewInboxAdmin(tenantID, operatorID, inboxID, grantedBy)
+	if err := s.repos.InboxAdmins.Create(ctx, grant); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return grant, nil
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Inbox admin granted",
+		zap.String("operator_id", operatorID.String()),
+		zap.String("inbox_id", inboxID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, grantedBy,
+		domain.AuditActionOperatorInboxAdminGrant, domain.AuditEntityOperator, operatorID,
+		nil, map[string]interface{}{"inbox_id": inboxID.String()}))
+
+	return grant, nil
+}
+
+// RevokeInboxAdmin removes the operator's admin grant on the inbox
+// Permission: Admin (enforced by router)
+func (s *InboxAdminService) RevokeInboxAdmin(ctx context.Context, tenantID, inboxID, operatorID uuid.UUID, revokedBy *uuid.UUID) error {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return err
+	}
+	if err := s.verifyOperator(ctx, tenantID, operatorID); err != nil {
+		return err
+	}
+
+	if err := s.repos.InboxAdmins.Delete(ctx, operatorID, inboxID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Inbox admin revoked",
+		zap.String("operator_id", operatorID.String()),
+		zap.String("inbox_id", inboxID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, revokedBy,
+		domain.AuditActionOperatorInboxAdminRevoke, domain.AuditEntityOperator, operatorID,
+		map[string]interface{}{"inbox_id": inboxID.String()}, nil))
+
+	return nil
+}
+
+func (s *InboxAdminService) verifyInbox(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInboxAdminInboxNotFound
+		}
+		return err
+	}
+	if inbox.TenantID != tenantID {
+		return ErrInboxAdminInboxNotFound
+	}
+	return nil
+}
+
+func (s *InboxAdminService) verifyOperator(ctx context.Context, tenantID, operatorID uuid.UUID) error {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInboxAdminOperatorNotFound
+		}
+		return err
+	}
+	if operator.TenantID != tenantID {
+		return ErrInboxAdminOperatorNotFound
+	}
+	return nil
+}
+
+// ==================== Capabilities ====================
+
+// GetCapabilities returns the caller's role capabilities and inbox admin
+// grants. Callers without an operator (API keys) only have their role.
+func (s *InboxAdminService) GetCapabilities(ctx context.Context, operatorID *uuid.UUID, role domain.OperatorRole) (*domain.Capabilities, error) {
+	caps := &domain.Capabilities{
+		Role:    role,
+		Tenant:  role.Capabilities(),
+		Inboxes: []domain.InboxCapabilities{},
+	}
+	if operatorID == nil {
+		return caps, nil
+	}
+
+	grants, err := s.repos.InboxAdmins.GetByOperatorID(ctx, *operatorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		caps.Inboxes = append(caps.Inboxes, domain.InboxCapabilities{
+			InboxID:      grant.InboxID,
+			Capabilities: domain.InboxAdminCapabilities,
+		})
+	}
+	return caps, nil
+}
+
+// ==================== Permission Helpers ====================
+
+// isInboxAdmin reports whether the operator holds an admin grant on the
+// inbox. Callers without an operator (API keys) never do.
+func isInboxAdmin(ctx context.Context, repos *repository.RepositoryContainer, operatorID *uuid.UUID, inboxID uuid.UUID) (bool, error) {
+	if operatorID == nil {
+		return false, nil
+	}
+	return repos.InboxAdmins.Exists(ctx, *operatorID, inboxID)
+}
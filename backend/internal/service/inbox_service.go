@@ -19,13 +19,17 @@ func NewInboxService(repos *repository.RepositoryContainer, log *logger.Logger)
 	return &InboxService{repos: repos, logger: log}
 }
 
-func (s *InboxService) Create(ctx context.Context, tenantID uuid.UUID, phoneNumber, displayName string) (*domain.Inbox, error) {
+// Create creates an inbox; businessHours may be nil to count all time
+// towards priority
+func (s *InboxService) Create(ctx context.Context, tenantID uuid.UUID, phoneNumber, displayName string, isRestricted bool, businessHours *domain.BusinessHours) (*domain.Inbox, error) {
 	existing, err := s.repos.Inboxes.GetByPhoneNumber(ctx, tenantID, phoneNumber)
 	if err == nil && existing != nil {
 		return nil, domain.ErrAlreadyExists
 	}
 
 	inbox := domain.NewInbox(tenantID, phoneNumber, displayName)
+	inbox.IsRestricted = isRestricted
+	inbox.BusinessHours = businessHours
 	if err := s.repos.Inboxes.Create(ctx, inbox); err != nil {
 		return nil, err
 	}
@@ -66,7 +70,9 @@ func (s *InboxService) ListForOperator(ctx context.Context, tenantID, operatorID
 	return inboxes, nil
 }
 
-func (s *InboxService) Update(ctx context.Context, id uuid.UUID, phoneNumber, displayName *string) (*domain.Inbox, error) {
+// Update changes the non-nil fields of the inbox. With setBusinessHours the
+// business hours are replaced by businessHours, or cleared when it is nil.
+func (s *InboxService) Update(ctx context.Context, id uuid.UUID, phoneNumber, displayName *string, isRestricted *bool, businessHours *domain.BusinessHours, setBusinessHours bool) (*domain.Inbox, error) {
 	inbox, err := s.repos.Inboxes.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -84,6 +90,14 @@ func (s *InboxService) Update(ctx context.Context, id uuid.UUID, phoneNumber, di
 		inbox.DisplayName = *displayName
 	}
 
+	if isRestricted != nil {
+		inbox.IsRestricted = *isRestricted
+	}
+
+	if setBusinessHours {
+		inbox.BusinessHours = businessHours
+	}
+
 	inbox.UpdatedAt = time.Now().UTC()
 	if err := s.repos.Inboxes.Update(ctx, inbox); err != nil {
 		return nil, err
@@ -91,6 +105,21 @@ func (s *InboxService) Update(ctx context.Context, id uuid.UUID, phoneNumber, di
 	return inbox, nil
 }
 
-func (s *InboxService) Delete(ctx context.Context, id uuid.UUID) error {
+// DeletionImpact counts what deleting the inbox would affect
+func (s *InboxService) DeletionImpact(ctx context.Context, id uuid.UUID) (*domain.DeletionImpact, error) {
+	return s.repos.DeletionImpacts.ForInbox(ctx, id)
+}
+
+// Delete deletes the inbox with its conversations. Unless forced, an inbox
+// with open conversations or grace periods is kept and a
+// *DeletionBlockedError returned.
+func (s *InboxService) Delete(ctx context.Context, id uuid.UUID, force bool) error {
+	impact, err := s.repos.DeletionImpacts.ForInbox(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := guardDeletion(impact, force); err != nil {
+		return err
+	}
 	return s.repos.Inboxes.Delete(ctx, id)
 }
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+)
+
+// IntakeWindow is the period the tenant's intake limit counts over
+const IntakeWindow = time.Hour
+
+// ErrIntakeThrottled is returned by IngestMessage for a message that would
+// start a conversation past the tenant's per-phone intake limit
+var ErrIntakeThrottled = errors.New("customer phone number started too many conversations")
+
+var intakeThrottled = metrics.NewCounter("conversation_intake_throttled_total")
+
+// IntakeThrottledError carries when the phone number may start a
+// conversation in the inbox again
+type IntakeThrottledError struct {
+	InboxID    uuid.UUID
+	RetryAfter time.Duration
+}
+
+func (e *IntakeThrottledError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrIntakeThrottled, e.RetryAfter.Round(time.Second))
+}
+
+func (e *IntakeThrottledError) Unwrap() error {
+	return ErrIntakeThrottled
+}
+
+// checkIntake returns an *IntakeThrottledError when the phone number already
+// started the tenant's limit of conversations in the inbox within the last
+// IntakeWindow. Concurrent first messages from one phone number can each
+// pass the check, so the limit is approximate under bursts; it is meant to
+// stop floods, not to count exactly.
+func checkIntake(ctx context.Context, repos *repository.RepositoryContainer, tenantID, inboxID uuid.UUID, phoneNumber string, now time.Time) error {
+	tenant, err := repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if tenant.IntakeLimitPerHour == nil {
+		return nil
+	}
+
+	started, oldest, err := repos.ConversationRefs.CustomerIntakeSince(ctx, tenantID, inboxID, phoneNumber, now.Add(-IntakeWindow))
+	if err != nil {
+		return err
+	}
+	if started < int64(*tenant.IntakeLimitPerHour) {
+		return nil
+	}
+
+	intakeThrottled.Inc()
+	retryAfter := time.Second
+	if oldest != nil {
+		if wait := oldest.Add(IntakeWindow).Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return &IntakeThrottledError{InboxID: inboxID, RetryAfter: retryAfter}
+}
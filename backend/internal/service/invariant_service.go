@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	invariantViolationsTotal = metrics.NewCounter("invariant_violations_total")
+	invariantRepairsTotal    = metrics.NewCounter("invariant_repairs_total")
+)
+
+// InvariantService scans conversation data for states that concurrent
+// allocations and lifecycle changes must never produce, and optionally
+// repairs them. It backs the admin endpoints, cmd/invariants and the nightly
+// invariant worker.
+//
+// Repairs return allocations without a valid operator to the queue and
+// delete grace periods left on resolved conversations. Each repair locks the
+// conversation and re-checks the violation in its own transaction, so a
+// repair racing a lifecycle change or another check is a no-op. Duplicate
+// external IDs are only reported.
+type InvariantService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewInvariantService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *InvariantService {
+	return &InvariantService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// Check checks the tenant's invariants and, with repair, repairs the
+// repairable violations. actorID is the operator who asked for the repair,
+// nil for the worker and the CLI.
+// Permission: Admin (enforced by router)
+func (s *InvariantService) Check(ctx context.Context, tenantID uuid.UUID, repair bool, actorID *uuid.UUID) (*domain.InvariantReport, error) {
+	report := domain.NewInvariantReport(tenantID)
+
+	finders := []func(context.Context, uuid.UUID, int) ([]*domain.InvariantViolation, error){
+		s.repos.Invariants.FindAllocatedWithoutOperator,
+		s.repos.Invariants.FindOperatorNotSubscribed,
+		s.repos.Invariants.FindResolvedWithGracePeriod,
+		s.repos.Invariants.FindDuplicateExternalIDs,
+	}
+	for _, find := range finders {
+		violations, err := find(ctx, tenantID, domain.MaxInvariantViolations)
+		if err != nil {
+			return nil, err
+		}
+		report.Violations = append(report.Violations, violations...)
+	}
+	invariantViolationsTotal.Add(int64(len(report.Violations)))
+
+	if repair {
+		for _, v := range report.Violations {
+			if !v.Kind.Repairable() {
+				continue
+			}
+			repaired, err := s.repair(ctx, tenantID, v, actorID)
+			if err != nil {
+				s.logger.Error("Failed to repair invariant violation",
+					zap.String("tenant_id", tenantID.String()),
+					zap.String("conversation_id", v.ConversationID.String()),
+					zap.String("invariant", string(v.Kind)),
+					zap.Error(err))
+				continue
+			}
+			v.Repaired = repaired
+		}
+		invariantRepairsTotal.Add(int64(report.Repaired()))
+	}
+
+	if len(report.Violations) > 0 {
+		counts := report.Counts()
+		fields := []zap.Field{
+			zap.String("tenant_id", tenantID.String()),
+			zap.Int("violations", len(report.Violations)),
+			zap.Int("repaired", report.Repaired()),
+		}
+		for _, kind := range domain.InvariantKinds {
+			fields = append(fields, zap.Int(string(kind), counts[kind]))
+		}
+		s.logger.Warn("Invariant violations found", fields...)
+	}
+
+	return report, nil
+}
+
+// CheckAll checks every tenant, see Check. A tenant whose check fails does
+// not stop the others; the first error is returned with the other reports.
+func (s *InvariantService) CheckAll(ctx context.Context, repair bool) ([]*domain.InvariantReport, error) {
+	tenants, err := s.repos.Tenants.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	reports := make([]*domain.InvariantReport, 0, len(tenants))
+	for _, tenant := range tenants {
+		report, err := s.Check(ctx, tenant.ID, repair, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("tenant %s: %w", tenant.ID, err)
+			}
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, firstErr
+}
+
+// repair repairs one violation and reports whether it still held
+func (s *InvariantService) repair(ctx context.Context, tenantID uuid.UUID, v *domain.InvariantViolation, actorID *uuid.UUID) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+	conversations := repos.ConversationRefs
+
+	conv, err := conversations.LockForUpdate(ctx, v.ConversationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if conv.TenantID != tenantID {
+		return false, nil
+	}
+
+	holds, err := s.stillHolds(ctx, repos, conv, v.Kind)
+	if err != nil || !holds {
+		return false, err
+	}
+
+	before := conversationAuditSnapshot(conv)
+	previousOperator := conv.AssignedOperatorID
+
+	var event *domain.Event
+	if v.Kind != domain.InvariantResolvedWithGracePeriod {
+		if err := conv.Deallocate(); err != nil {
+			return false, err
+		}
+		if err := conversations.Update(ctx, conv); err != nil {
+			return false, err
+		}
+
+		data := conversationEventData(conv)
+		data["previous_operator_id"] = uuidPtrToString(previousOperator)
+		data["reason"] = "invariant_repair"
+		event = domain.NewEvent(tenantID, domain.EventConversationDeallocated, data)
+		if err := stageEvents(ctx, s.events, tx, event); err != nil {
+			return false, err
+		}
+	}
+
+	// A requeued conversation must not be released again by its grace period
+	if err := repos.GracePeriodAssignments.DeleteByConversationID(ctx, conv.ID); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Invariant violation repaired",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("invariant", string(v.Kind)))
+
+	after := conversationAuditSnapshot(conv)
+	after["invariant"] = string(v.Kind)
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionConversationRepair, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	if event != nil {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+	return true, nil
+}
+
+// stillHolds re-checks a violation on the locked conversation
+func (s *InvariantService) stillHolds(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, kind domain.InvariantKind) (bool, error) {
+	switch kind {
+	case domain.InvariantAllocatedWithoutOperator:
+		return conv.State == domain.ConversationStateAllocated && conv.AssignedOperatorID == nil, nil
+	case domain.InvariantOperatorNotSubscribed:
+		if conv.State != domain.ConversationStateAllocated || conv.AssignedOperatorID == nil {
+			return false, nil
+		}
+		subscribed, err := repos.Subscriptions.IsSubscribed(ctx, *conv.AssignedOperatorID, conv.InboxID)
+		return !subscribed, err
+	case domain.InvariantResolvedWithGracePeriod:
+		if conv.State != domain.ConversationStateResolved {
+			return false, nil
+		}
+		_, err := repos.GracePeriodAssignments.GetByConversationID(ctx, conv.ID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	default:
+		return false, nil
+	}
+}
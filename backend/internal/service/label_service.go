@@ -19,38 +19,53 @@ var (
 	ErrLabelNameConflict     = errors.New("label name already exists in this inbox")
 	ErrLabelInboxMismatch    = errors.New("label inbox does not match conversation inbox")
 	ErrLabelPermissionDenied = errors.New("insufficient permissions for label operation")
+	ErrLabelParentInvalid    = errors.New("parent label must be a label of the same inbox, or a tenant label for a tenant label")
+	ErrLabelCycle            = errors.New("label cannot be nested below itself or its descendants")
 )
 
 type LabelService struct {
 	repos  *repository.RepositoryContainer
 	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
 	logger *logger.Logger
 }
 
-func NewLabelService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, log *logger.Logger) *LabelService {
+func NewLabelService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *LabelService {
 	return &LabelService{
 		repos:  repos,
 		pool:   pool,
+		events: events,
+		audit:  audit,
 		logger: log,
 	}
 }
 
 // ==================== Create Label ====================
 
-// CreateLabel creates a new label for an inbox
-// Permission: Manager or Admin only
+// CreateLabel creates a new label for an inbox, or with the TENANT scope a
+// label of every inbox of the tenant (inboxID is then ignored). It is nested
+// below parentID when set (ErrLabelParentInvalid unless it is a label of the
+// same scope and inbox).
+// Permission: Manager, Admin, or Inbox Admin; tenant labels Manager or Admin
 func (s *LabelService) CreateLabel(
 	ctx context.Context,
 	tenantID, operatorID, inboxID uuid.UUID,
 	role domain.OperatorRole,
+	scope domain.LabelScope,
 	name string,
 	color *string,
+	parentID *uuid.UUID,
 ) (*domain.Label, error) {
+	if scope == domain.LabelScopeTenant {
+		return s.createTenantLabel(ctx, tenantID, operatorID, role, name, color, parentID)
+	}
+
 	start := time.Now()
 
 	// Check permissions
-	if !s.canManageLabels(role) {
-		return nil, ErrLabelPermissionDenied
+	if err := s.checkManageLabels(ctx, operatorID, role, inboxID); err != nil {
+		return nil, err
 	}
 
 	// Verify inbox exists and belongs to tenant
@@ -77,8 +92,7 @@ func (s *LabelService) CreateLabel(
 
 	// Create label
 	label := domain.NewLabel(tenantID, inboxID, name, color, &operatorID)
-
-	if err := s.repos.Labels.Create(ctx, label); err != nil {
+	if err := s.create(ctx, label, parentID); err != nil {
 		return nil, err
 	}
 
@@ -89,29 +103,97 @@ func (s *LabelService) CreateLabel(
 		zap.String("created_by", operatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelCreate, domain.AuditEntityLabel, label.ID,
+		nil, labelAuditSnapshot(label)))
+
 	return label, nil
 }
 
+// createTenantLabel creates a label of every inbox of the tenant; names are
+// unique among the tenant labels
+func (s *LabelService) createTenantLabel(
+	ctx context.Context,
+	tenantID, operatorID uuid.UUID,
+	role domain.OperatorRole,
+	name string,
+	color *string,
+	parentID *uuid.UUID,
+) (*domain.Label, error) {
+	start := time.Now()
+
+	// Inbox admins manage their inboxes' labels only
+	if !role.CanManageLabels() {
+		return nil, ErrLabelPermissionDenied
+	}
+
+	name = strings.TrimSpace(name)
+	existing, err := s.repos.Labels.GetTenantScopedByName(ctx, tenantID, name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrLabelNameConflict
+	}
+
+	label := domain.NewTenantLabel(tenantID, name, color, &operatorID)
+	if err := s.create(ctx, label, parentID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant label created",
+		zap.String("label_id", label.ID.String()),
+		zap.String("name", name),
+		zap.String("created_by", operatorID.String()),
+		zap.Duration("duration", time.Since(start)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelCreate, domain.AuditEntityLabel, label.ID,
+		nil, labelAuditSnapshot(label)))
+
+	return label, nil
+}
+
+// create nests the label below parentID when set and stores it
+func (s *LabelService) create(ctx context.Context, label *domain.Label, parentID *uuid.UUID) error {
+	if parentID != nil {
+		if _, err := s.parentOf(ctx, s.repos.Labels, label, *parentID); err != nil {
+			return err
+		}
+		label.ParentLabelID = parentID
+	}
+	return s.repos.Labels.Create(ctx, label)
+}
+
 // ==================== Update Label ====================
 
-// UpdateLabel updates an existing label
-// Permission: Manager or Admin only
+// UpdateLabel updates an existing label. A rename or recolor starts a new
+// label version; earlier versions keep their name and color for reports. The
+// label row is locked so concurrent renames apply one after the other.
+// With setParent the label is moved below parentID, or to the top level when
+// nil; moving it below itself or one of its descendants is ErrLabelCycle.
+// Permission: Manager, Admin, or Inbox Admin
 func (s *LabelService) UpdateLabel(
 	ctx context.Context,
 	tenantID, operatorID, labelID uuid.UUID,
 	role domain.OperatorRole,
 	name *string,
 	color *string,
+	parentID *uuid.UUID,
+	setParent bool,
 ) (*domain.Label, error) {
 	start := time.Now()
 
-	// Check permissions
-	if !s.canManageLabels(role) {
-		return nil, ErrLabelPermissionDenied
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	// Get existing label
-	label, err := s.repos.Labels.GetByID(ctx, labelID)
+	labels := s.repos.WithTx(tx).Labels
+
+	// Get and lock existing label
+	label, err := labels.LockForUpdate(ctx, labelID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, ErrLabelNotFound
@@ -124,54 +206,190 @@ func (s *LabelService) UpdateLabel(
 		return nil, ErrLabelNotFound
 	}
 
-	// Update fields
+	// Check permissions
+	if err := s.checkManageLabel(ctx, operatorID, role, label); err != nil {
+		return nil, err
+	}
+
+	before := labelAuditSnapshot(label)
+
+	newName, newColor := label.Name, label.Color
 	if name != nil {
-		newName := strings.TrimSpace(*name)
+		newName = strings.TrimSpace(*name)
 		// Check for duplicate if name changed
 		if newName != label.Name {
-			existing, err := s.repos.Labels.GetByName(ctx, label.InboxID, newName)
+			existing, err := labelByName(ctx, labels, label, newName)
 			if err != nil && !errors.Is(err, domain.ErrNotFound) {
 				return nil, err
 			}
 			if existing != nil && existing.ID != labelID {
 				return nil, ErrLabelNameConflict
 			}
-			label.Name = newName
 		}
 	}
-
 	if color != nil {
-		label.Color = color
+		newColor = color
 	}
 
-	if err := s.repos.Labels.Update(ctx, label); err != nil {
+	reparented := setParent && !label.SameParent(parentID)
+	if reparented && parentID != nil {
+		if err := s.checkNoCycle(ctx, labels, label, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	changed := label.Change(newName, newColor)
+	if !changed && !reparented {
+		return label, nil
+	}
+	if reparented {
+		label.ParentLabelID = parentID
+	}
+
+	if err := labels.Update(ctx, label); err != nil {
+		// A concurrent create or rename took the name after our check
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrLabelNameConflict
+		}
+		return nil, err
+	}
+	// Versions record names and colors; moving a label starts none
+	if changed {
+		if err := labels.CreateVersion(ctx, domain.NewLabelVersion(label, &operatorID)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
 	s.logger.Info("Label updated",
 		zap.String("label_id", labelID.String()),
+		zap.Int("version", label.Version),
 		zap.String("updated_by", operatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelUpdate, domain.AuditEntityLabel, label.ID,
+		before, labelAuditSnapshot(label)))
+
 	return label, nil
 }
 
+// parentOf returns the label to nest label below: ErrLabelParentInvalid
+// unless it is another label of the label's inbox, or another tenant label
+// for a tenant label
+func (s *LabelService) parentOf(ctx context.Context, labels domain.LabelRepository, label *domain.Label, parentID uuid.UUID) (*domain.Label, error) {
+	if parentID == label.ID {
+		return nil, ErrLabelCycle
+	}
+	parent, err := labels.GetByID(ctx, parentID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrLabelParentInvalid
+		}
+		return nil, err
+	}
+	if parent.TenantID != label.TenantID || parent.Scope != label.Scope || parent.InboxID != label.InboxID {
+		return nil, ErrLabelParentInvalid
+	}
+	return parent, nil
+}
+
+// checkNoCycle walks up from parentID and returns ErrLabelCycle when it
+// reaches label. The ancestors are locked, so two labels concurrently moved
+// below each other cannot both pass.
+func (s *LabelService) checkNoCycle(ctx context.Context, labels domain.LabelRepository, label *domain.Label, parentID uuid.UUID) error {
+	if _, err := s.parentOf(ctx, labels, label, parentID); err != nil {
+		return err
+	}
+	seen := map[uuid.UUID]bool{label.ID: true}
+	for id := &parentID; id != nil; {
+		if seen[*id] {
+			return ErrLabelCycle
+		}
+		seen[*id] = true
+		ancestor, err := labels.LockForUpdate(ctx, *id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		id = ancestor.ParentLabelID
+	}
+	return nil
+}
+
+// ==================== Label Versions ====================
+
+// ListLabelVersions returns every version of a label, oldest first
+// Permission: Manager, Admin, or Inbox Admin
+func (s *LabelService) ListLabelVersions(
+	ctx context.Context,
+	tenantID, operatorID, labelID uuid.UUID,
+	role domain.OperatorRole,
+) ([]*domain.LabelVersion, error) {
+	label, err := s.repos.Labels.GetByID(ctx, labelID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	if label.TenantID != tenantID {
+		return nil, ErrLabelNotFound
+	}
+	if err := s.checkManageLabel(ctx, operatorID, role, label); err != nil {
+		return nil, err
+	}
+
+	return s.repos.Labels.ListVersions(ctx, labelID)
+}
+
 // ==================== Delete Label ====================
 
-// DeleteLabel deletes a label
-// Permission: Manager or Admin only
+// LabelDeletionImpact counts what deleting a label would affect
+// Permission: Manager, Admin, or Inbox Admin
+func (s *LabelService) LabelDeletionImpact(
+	ctx context.Context,
+	tenantID, operatorID, labelID uuid.UUID,
+	role domain.OperatorRole,
+) (*domain.DeletionImpact, error) {
+	label, err := s.repos.Labels.GetByID(ctx, labelID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	if label.TenantID != tenantID {
+		return nil, ErrLabelNotFound
+	}
+	if err := s.checkManageLabel(ctx, operatorID, role, label); err != nil {
+		return nil, err
+	}
+
+	impact, err := s.repos.DeletionImpacts.ForLabel(ctx, labelID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrLabelNotFound
+	}
+	return impact, err
+}
+
+// DeleteLabel deletes a label. Unless forced, a label attached by routing
+// rules is kept and a *DeletionBlockedError returned, as the rules would
+// re-create it.
+// Permission: Manager, Admin, or Inbox Admin
 func (s *LabelService) DeleteLabel(
 	ctx context.Context,
 	tenantID, operatorID, labelID uuid.UUID,
 	role domain.OperatorRole,
+	force bool,
 ) error {
 	start := time.Now()
 
-	// Check permissions
-	if !s.canManageLabels(role) {
-		return ErrLabelPermissionDenied
-	}
-
 	// Get existing label
 	label, err := s.repos.Labels.GetByID(ctx, labelID)
 	if err != nil {
@@ -186,6 +404,22 @@ func (s *LabelService) DeleteLabel(
 		return ErrLabelNotFound
 	}
 
+	// Check permissions
+	if err := s.checkManageLabel(ctx, operatorID, role, label); err != nil {
+		return err
+	}
+
+	impact, err := s.repos.DeletionImpacts.ForLabel(ctx, labelID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrLabelNotFound
+		}
+		return err
+	}
+	if err := guardDeletion(impact, force); err != nil {
+		return err
+	}
+
 	// Delete label (cascade deletes conversation_labels via DB constraint)
 	if err := s.repos.Labels.Delete(ctx, labelID); err != nil {
 		return err
@@ -197,13 +431,18 @@ func (s *LabelService) DeleteLabel(
 		zap.String("deleted_by", operatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelDelete, domain.AuditEntityLabel, label.ID,
+		labelAuditSnapshot(label), nil))
+
 	return nil
 }
 
 // ==================== List Labels ====================
 
-// ListLabelsByInbox lists all labels for an inbox
-// Permission: Subscribed Operator, Manager, or Admin
+// ListLabelsByInbox lists all labels for an inbox, including the tenant
+// labels
+// Permission: Subscribed Operator, Manager, Admin, or Inbox Admin
 func (s *LabelService) ListLabelsByInbox(
 	ctx context.Context,
 	tenantID, operatorID, inboxID uuid.UUID,
@@ -228,13 +467,21 @@ func (s *LabelService) ListLabelsByInbox(
 			return nil, err
 		}
 		if !isSubscribed {
-			return nil, ErrLabelPermissionDenied
+			if err := s.checkManageLabels(ctx, operatorID, role, inboxID); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return s.repos.Labels.GetByInboxID(ctx, tenantID, inboxID)
 }
 
+// ListTenantLabels lists the tenant's tenant-scoped labels
+// Permission: any operator
+func (s *LabelService) ListTenantLabels(ctx context.Context, tenantID uuid.UUID) ([]*domain.Label, error) {
+	return s.repos.Labels.GetTenantScoped(ctx, tenantID)
+}
+
 // ==================== Attach Label ====================
 
 // AttachLabelToConversation attaches a label to a conversation
@@ -282,8 +529,8 @@ func (s *LabelService) AttachLabelToConversation(
 		return ErrLabelNotFound
 	}
 
-	// Verify label inbox matches conversation inbox
-	if label.InboxID != conv.InboxID {
+	// Verify the label applies to the conversation's inbox
+	if !label.AppliesTo(conv.InboxID) {
 		return ErrLabelInboxMismatch
 	}
 
@@ -311,11 +558,16 @@ func (s *LabelService) AttachLabelToConversation(
 	}
 
 	// Create association
-	cl := domain.NewConversationLabel(conversationID, labelID)
+	cl := domain.NewConversationLabel(conversationID, label)
 	if err := s.repos.ConversationLabels.Create(ctx, cl); err != nil {
 		return err
 	}
 
+	event := domain.NewEvent(tenantID, domain.EventConversationLabeled, labelEventData(conv, label, operatorID))
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return err
+	}
+
 	// Commit
 	if err := tx.Commit(ctx); err != nil {
 		return err
@@ -327,6 +579,12 @@ func (s *LabelService) AttachLabelToConversation(
 		zap.String("attached_by", operatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelAttach, domain.AuditEntityConversation, conversationID,
+		nil, conversationLabelAuditSnapshot(label)))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
 	return nil
 }
 
@@ -404,12 +662,65 @@ func (s *LabelService) DetachLabelFromConversation(
 		zap.String("detached_by", operatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionLabelDetach, domain.AuditEntityConversation, conversationID,
+		conversationLabelAuditSnapshot(label), nil))
+
+	publishEvent(ctx, s.events, s.logger, domain.NewEvent(tenantID, domain.EventConversationUnlabeled,
+		labelEventData(conv, label, operatorID)))
+
 	return nil
 }
 
+// labelEventData identifies the conversation and the label so clients can
+// update label badges without refetching
+func labelEventData(conv *domain.ConversationRef, label *domain.Label, changedBy uuid.UUID) map[string]interface{} {
+	data := conversationEventData(conv)
+	data["label_id"] = label.ID.String()
+	data["label_name"] = label.Name
+	data["label_color"] = nil
+	if label.Color != nil {
+		data["label_color"] = *label.Color
+	}
+	data["changed_by"] = changedBy.String()
+	return data
+}
+
+// labelByName returns the label named name in label's scope: among the
+// labels of its inbox, or the tenant labels
+func labelByName(ctx context.Context, labels domain.LabelRepository, label *domain.Label, name string) (*domain.Label, error) {
+	if label.Scope == domain.LabelScopeTenant {
+		return labels.GetTenantScopedByName(ctx, label.TenantID, name)
+	}
+	return labels.GetByName(ctx, label.InboxID, name)
+}
+
 // ==================== Permission Helpers ====================
 
-// canManageLabels checks if caller can create/update/delete labels
-func (s *LabelService) canManageLabels(role domain.OperatorRole) bool {
-	return role == domain.OperatorRoleAdmin || role == domain.OperatorRoleManager
+// checkManageLabel checks if caller can update or delete the label: tenant
+// labels are managed by managers and admins only, see checkManageLabels
+func (s *LabelService) checkManageLabel(ctx context.Context, operatorID uuid.UUID, role domain.OperatorRole, label *domain.Label) error {
+	if label.Scope == domain.LabelScopeTenant {
+		if !role.CanManageLabels() {
+			return ErrLabelPermissionDenied
+		}
+		return nil
+	}
+	return s.checkManageLabels(ctx, operatorID, role, label.InboxID)
+}
+
+// checkManageLabels checks if caller can create/update/delete labels of the
+// inbox: managers and admins can for every inbox, inbox admins for theirs
+func (s *LabelService) checkManageLabels(ctx context.Context, operatorID uuid.UUID, role domain.OperatorRole, inboxID uuid.UUID) error {
+	if role.CanManageLabels() {
+		return nil
+	}
+	isAdmin, err := isInboxAdmin(ctx, s.repos, &operatorID, inboxID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrLabelPermissionDenied
+	}
+	return nil
 }
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,7 @@ import (
 var (
 	ErrConversationNotAllocated    = errors.New("conversation is not in ALLOCATED state")
 	ErrConversationAlreadyResolved = errors.New("conversation is already resolved")
+	ErrConversationNotResolved     = errors.New("conversation is not in RESOLVED state")
 	ErrInsufficientPermissions     = errors.New("insufficient permissions for this operation")
 	ErrTargetOperatorNotFound      = errors.New("target operator not found")
 	ErrTargetOperatorNotSubscribed = errors.New("target operator is not subscribed to inbox")
@@ -24,22 +27,29 @@ var (
 )
 
 type LifecycleService struct {
-	repos  *repository.RepositoryContainer
-	pool   *pgxpool.Pool
-	logger *logger.Logger
+	repos        *repository.RepositoryContainer
+	pool         *pgxpool.Pool
+	events       domain.EventPublisher
+	audit        *AuditService
+	gracePeriods *GracePeriodService
+	logger       *logger.Logger
 }
 
-func NewLifecycleService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, log *logger.Logger) *LifecycleService {
+func NewLifecycleService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, gracePeriods *GracePeriodService, log *logger.Logger) *LifecycleService {
 	return &LifecycleService{
-		repos:  repos,
-		pool:   pool,
-		logger: log,
+		repos:        repos,
+		pool:         pool,
+		events:       events,
+		audit:        audit,
+		gracePeriods: gracePeriods,
+		logger:       log,
 	}
 }
 
 // ==================== Resolve ====================
 
-// Resolve marks a conversation as resolved
+// Resolve marks a conversation as resolved. A conversation whose inbox has a
+// strict checklist template needs every checklist item ticked first.
 // Permission: Owner (assigned operator), Manager, or Admin
 func (s *LifecycleService) Resolve(ctx context.Context, tenantID, callerID, conversationID uuid.UUID, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
 	start := time.Now()
@@ -50,9 +60,10 @@ func (s *LifecycleService) Resolve(ctx context.Context, tenantID, callerID, conv
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
-	// Get conversation
-	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	// Lock the conversation until commit
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, conversationID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrNotFound
@@ -86,13 +97,31 @@ func (s *LifecycleService) Resolve(ctx context.Context, tenantID, callerID, conv
 		return nil, ErrInsufficientPermissions
 	}
 
+	checklist, err := resolveChecklist(ctx, repos.Checklists, conv)
+	if err != nil {
+		return nil, err
+	}
+
+	before := conversationAuditSnapshot(conv)
+
 	// Update state
 	now := time.Now().UTC()
 	conv.State = domain.ConversationStateResolved
 	conv.ResolvedAt = &now
 	conv.UpdatedAt = now
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+	if err := recordAffinity(ctx, repos.CustomerAffinities, conv); err != nil {
+		return nil, err
+	}
+
+	data := conversationEventData(conv)
+	data["resolved_by"] = callerID.String()
+	data["checklist_complete"] = checklist.Complete()
+	event := domain.NewEvent(tenantID, domain.EventConversationResolved, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
 		return nil, err
 	}
 
@@ -107,6 +136,463 @@ func (s *LifecycleService) Resolve(ctx context.Context, tenantID, callerID, conv
 		zap.String("role", string(callerRole)),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationResolve, domain.AuditEntityConversation, conv.ID,
+		before, conversationAuditSnapshot(conv)))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return conv, nil
+}
+
+// ==================== Bulk Resolve ====================
+
+// MaxBulkConversations bounds the conversations of one bulk operation
+const MaxBulkConversations = 500
+
+// BulkChunkSize bounds the conversations changed per transaction of a bulk
+// operation, so one request does not hold hundreds of row locks at once
+const BulkChunkSize = 100
+
+// BulkResult is the outcome of a bulk operation for one conversation: the
+// conversation on success, or the error that skipped it
+type BulkResult struct {
+	ConversationID uuid.UUID
+	Conversation   *domain.ConversationRef
+	Err            error
+}
+
+// BulkResolve resolves the conversations in chunked transactions. A
+// conversation that cannot be resolved is reported in its result without
+// affecting the others, as is one with an incomplete strict checklist; an
+// already resolved one succeeds unchanged. Results
+// are in the order of conversationIDs.
+// Permission: Manager or Admin only
+func (s *LifecycleService) BulkResolve(ctx context.Context, tenantID, callerID uuid.UUID, conversationIDs []uuid.UUID, callerRole domain.OperatorRole) ([]BulkResult, error) {
+	if !s.canManage(callerRole) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	return s.runBulk(ctx, "resolve", tenantID, callerID, conversationIDs, func(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, events *bulkEvents) (func(), error, error) {
+		// Idempotency: already resolved counts as success
+		if conv.State == domain.ConversationStateResolved {
+			return nil, nil, nil
+		}
+		if conv.State != domain.ConversationStateAllocated {
+			return nil, ErrConversationNotAllocated, nil
+		}
+		checklist, err := resolveChecklist(ctx, repos.Checklists, conv)
+		if errors.Is(err, ErrChecklistIncomplete) {
+			return nil, err, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		before := conversationAuditSnapshot(conv)
+
+		now := time.Now().UTC()
+		conv.State = domain.ConversationStateResolved
+		conv.ResolvedAt = &now
+		conv.UpdatedAt = now
+
+		if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+			return nil, nil, err
+		}
+		if err := recordAffinity(ctx, repos.CustomerAffinities, conv); err != nil {
+			return nil, nil, err
+		}
+
+		data := conversationEventData(conv)
+		data["resolved_by"] = callerID.String()
+		data["checklist_complete"] = checklist.Complete()
+		data["bulk"] = true
+		events.add(domain.NewEvent(tenantID, domain.EventConversationResolved, data))
+
+		return func() {
+			recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+				domain.AuditActionConversationResolve, domain.AuditEntityConversation, conv.ID,
+				before, conversationAuditSnapshot(conv)))
+		}, nil, nil
+	}), nil
+}
+
+// BulkReassign assigns the conversations to newOperatorID in chunked
+// transactions, with the same per-conversation reporting as BulkResolve. A
+// conversation already assigned to the target succeeds unchanged; one in an
+// inbox the target is not subscribed to is skipped.
+// Permission: Manager or Admin only
+func (s *LifecycleService) BulkReassign(ctx context.Context, tenantID, callerID uuid.UUID, conversationIDs []uuid.UUID, newOperatorID uuid.UUID, callerRole domain.OperatorRole) ([]BulkResult, error) {
+	if !s.canManage(callerRole) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	newOperator, err := s.repos.Operators.GetByID(ctx, newOperatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrTargetOperatorNotFound
+		}
+		return nil, err
+	}
+	if newOperator.TenantID != tenantID {
+		return nil, ErrTargetOperatorNotFound // Don't reveal cross-tenant info
+	}
+
+	// Subscription of the target per inbox, looked up once
+	subscribed := make(map[uuid.UUID]bool)
+
+	return s.runBulk(ctx, "reassign", tenantID, callerID, conversationIDs, func(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, events *bulkEvents) (func(), error, error) {
+		if conv.State != domain.ConversationStateAllocated {
+			return nil, ErrConversationNotAllocated, nil
+		}
+		// Idempotency: already assigned to the target counts as success
+		if conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == newOperatorID {
+			return nil, nil, nil
+		}
+
+		ok, known := subscribed[conv.InboxID]
+		if !known {
+			var err error
+			if ok, err = repos.Subscriptions.IsSubscribed(ctx, newOperatorID, conv.InboxID); err != nil {
+				return nil, nil, err
+			}
+			subscribed[conv.InboxID] = ok
+		}
+		if !ok {
+			return nil, ErrTargetOperatorNotSubscribed, nil
+		}
+
+		previousOperator := conv.AssignedOperatorID
+		before := conversationAuditSnapshot(conv)
+
+		operatorID := newOperatorID
+		conv.AssignedOperatorID = &operatorID
+		conv.UpdatedAt = time.Now().UTC()
+
+		if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+			return nil, nil, err
+		}
+
+		data := conversationEventData(conv)
+		data["previous_operator_id"] = uuidPtrToString(previousOperator)
+		data["reassigned_by"] = callerID.String()
+		data["bulk"] = true
+		events.add(domain.NewEvent(tenantID, domain.EventConversationReassigned, data))
+
+		return func() {
+			recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+				domain.AuditActionConversationReassign, domain.AuditEntityConversation, conv.ID,
+				before, conversationAuditSnapshot(conv)))
+		}, nil, nil
+	}), nil
+}
+
+// BulkMoveInbox moves the conversations to newInboxID in chunked
+// transactions, with the same per-conversation reporting as BulkResolve.
+// As with MoveInbox, a conversation whose operator is not subscribed to the
+// new inbox is deallocated.
+// Permission: Manager or Admin only
+func (s *LifecycleService) BulkMoveInbox(ctx context.Context, tenantID, callerID uuid.UUID, conversationIDs []uuid.UUID, newInboxID uuid.UUID, callerRole domain.OperatorRole) ([]BulkResult, error) {
+	if !s.canManage(callerRole) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	newInbox, err := s.repos.Inboxes.GetByID(ctx, newInboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrTargetInboxNotFound
+		}
+		return nil, err
+	}
+	if newInbox.TenantID != tenantID {
+		return nil, ErrTargetInboxDifferentTenant
+	}
+
+	// Subscription to the new inbox per operator, looked up once
+	subscribed := make(map[uuid.UUID]bool)
+
+	return s.runBulk(ctx, "move_inbox", tenantID, callerID, conversationIDs, func(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, events *bulkEvents) (func(), error, error) {
+		// Idempotency: already in the target inbox counts as success
+		if conv.InboxID == newInboxID {
+			return nil, nil, nil
+		}
+
+		previousOperator := conv.AssignedOperatorID
+		autoDeallocated := false
+		before := conversationAuditSnapshot(conv)
+
+		if conv.State == domain.ConversationStateAllocated && conv.AssignedOperatorID != nil {
+			ok, known := subscribed[*conv.AssignedOperatorID]
+			if !known {
+				var err error
+				if ok, err = repos.Subscriptions.IsSubscribed(ctx, *conv.AssignedOperatorID, newInboxID); err != nil {
+					return nil, nil, err
+				}
+				subscribed[*conv.AssignedOperatorID] = ok
+			}
+			if !ok {
+				conv.State = domain.ConversationStateQueued
+				conv.AssignedOperatorID = nil
+				autoDeallocated = true
+			}
+		}
+
+		conv.InboxID = newInboxID
+		conv.UpdatedAt = time.Now().UTC()
+
+		if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+			return nil, nil, err
+		}
+
+		if autoDeallocated {
+			data := conversationEventData(conv)
+			data["previous_operator_id"] = uuidPtrToString(previousOperator)
+			data["deallocated_by"] = callerID.String()
+			data["reason"] = "inbox_moved"
+			data["bulk"] = true
+			events.add(domain.NewEvent(tenantID, domain.EventConversationDeallocated, data))
+		}
+
+		return func() {
+			recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+				domain.AuditActionConversationMoveInbox, domain.AuditEntityConversation, conv.ID,
+				before, conversationAuditSnapshot(conv)))
+		}, nil, nil
+	}), nil
+}
+
+// OperatorBacklog returns the IDs of up to MaxBulkConversations conversations
+// allocated to the operator, to reassign their backlog in bulk
+func (s *LifecycleService) OperatorBacklog(ctx context.Context, tenantID, operatorID uuid.UUID) ([]uuid.UUID, error) {
+	state := domain.ConversationStateAllocated
+	convs, err := s.repos.ConversationRefs.GetByOperatorID(ctx, tenantID, operatorID, &state)
+	if err != nil {
+		return nil, err
+	}
+	if len(convs) > MaxBulkConversations {
+		convs = convs[:MaxBulkConversations]
+	}
+	ids := make([]uuid.UUID, len(convs))
+	for i, conv := range convs {
+		ids[i] = conv.ID
+	}
+	return ids, nil
+}
+
+// InboxBacklog returns the IDs of up to MaxBulkConversations QUEUED or
+// ALLOCATED conversations of the inbox, oldest first, to move its backlog in
+// bulk
+func (s *LifecycleService) InboxBacklog(ctx context.Context, tenantID, inboxID uuid.UUID) ([]uuid.UUID, error) {
+	return s.repos.ConversationRefs.GetOpenIDsByInbox(ctx, tenantID, inboxID, MaxBulkConversations)
+}
+
+// bulkStep applies a bulk operation to one conversation, locked in the
+// chunk's transaction and verified to belong to the tenant, through repos
+// bound to that transaction. It adds the change's events to events and
+// returns the function recording its audit entry once the chunk commits (nil
+// if the conversation is left unchanged), the error that skips only this
+// conversation, or an error that rolls back the whole chunk.
+type bulkStep func(ctx context.Context, repos *repository.RepositoryContainer, conv *domain.ConversationRef, events *bulkEvents) (emit func(), skip error, err error)
+
+// bulkEvents collects the events of a chunk, staged in the outbox before the
+// chunk commits and published after
+type bulkEvents []*domain.Event
+
+func (e *bulkEvents) add(event *domain.Event) {
+	*e = append(*e, event)
+}
+
+// runBulk applies step to the conversations in chunks of BulkChunkSize and
+// returns their results in the order of conversationIDs
+func (s *LifecycleService) runBulk(ctx context.Context, operation string, tenantID, callerID uuid.UUID, conversationIDs []uuid.UUID, step bulkStep) []BulkResult {
+	start := time.Now()
+
+	results := make([]BulkResult, len(conversationIDs))
+	for i, id := range conversationIDs {
+		results[i].ConversationID = id
+	}
+
+	var changed int
+	for offset := 0; offset < len(results); offset += BulkChunkSize {
+		chunk := results[offset:min(offset+BulkChunkSize, len(results))]
+		n, err := s.bulkChunk(ctx, tenantID, chunk, step)
+		if err != nil {
+			s.logger.Error("Bulk chunk failed",
+				zap.String("operation", operation),
+				zap.Int("offset", offset),
+				zap.Int("size", len(chunk)),
+				zap.Error(err))
+			// The chunk was rolled back: nothing in it changed
+			for i := range chunk {
+				chunk[i].Conversation = nil
+				if chunk[i].Err == nil {
+					chunk[i].Err = err
+				}
+			}
+			continue
+		}
+		changed += n
+	}
+
+	s.logger.Info("Bulk operation completed",
+		zap.String("operation", operation),
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("caller_id", callerID.String()),
+		zap.Int("requested", len(conversationIDs)),
+		zap.Int("changed", changed),
+		zap.Duration("duration", time.Since(start)))
+
+	return results
+}
+
+// bulkChunk applies step to one chunk in a single transaction and returns how
+// many conversations it changed. Events are staged with the changes; audit
+// entries are recorded and events published only once the transaction
+// commits.
+func (s *LifecycleService) bulkChunk(ctx context.Context, tenantID uuid.UUID, chunk []BulkResult, step bulkStep) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+
+	// Lock in ID order so concurrent bulk operations cannot deadlock
+	order := make([]int, len(chunk))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return bytes.Compare(chunk[order[a]].ConversationID[:], chunk[order[b]].ConversationID[:]) < 0
+	})
+
+	var (
+		emits  []func()
+		events bulkEvents
+	)
+	for _, i := range order {
+		item := &chunk[i]
+
+		conv, err := repos.ConversationRefs.LockForUpdate(ctx, item.ConversationID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				item.Err = domain.ErrNotFound
+				continue
+			}
+			return 0, err
+		}
+		if conv.TenantID != tenantID {
+			item.Err = domain.ErrNotFound
+			continue
+		}
+
+		emit, skip, err := step(ctx, repos, conv, &events)
+		if err != nil {
+			return 0, err
+		}
+		if skip != nil {
+			item.Err = skip
+			continue
+		}
+		item.Conversation = conv
+		if emit != nil {
+			emits = append(emits, emit)
+		}
+	}
+
+	if err := stageEvents(ctx, s.events, tx, events...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	for _, emit := range emits {
+		emit()
+	}
+	for _, event := range events {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return len(emits), nil
+}
+
+// ==================== Reopen ====================
+
+// Reopen returns a resolved conversation to the queue
+// Permission: Last assigned operator, Manager, or Admin
+func (s *LifecycleService) Reopen(ctx context.Context, tenantID, callerID, conversationID uuid.UUID, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
+	start := time.Now()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conversations := s.repos.WithTx(tx).ConversationRefs
+
+	// Lock the row so a concurrent resolve or ingestion cannot interleave
+	conv, err := conversations.LockForUpdate(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify tenant
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+
+	if conv.State != domain.ConversationStateResolved {
+		return nil, ErrConversationNotResolved
+	}
+
+	// Resolved conversations keep their last operator, who may reopen them
+	if !s.canResolve(callerID, callerRole, conv) {
+		s.logger.Warn("Reopen attempt without permission",
+			zap.String("conversation_id", conversationID.String()),
+			zap.String("caller_id", callerID.String()),
+			zap.String("caller_role", string(callerRole)))
+		return nil, ErrInsufficientPermissions
+	}
+
+	before := conversationAuditSnapshot(conv)
+	previousOperator := conv.AssignedOperatorID
+
+	if err := conv.Reopen(); err != nil {
+		return nil, err
+	}
+
+	if err := conversations.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["reopened_by"] = callerID.String()
+	data["reopened_count"] = conv.ReopenedCount
+	event := domain.NewEvent(tenantID, domain.EventConversationReopened, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Conversation reopened",
+		zap.String("conversation_id", conversationID.String()),
+		zap.String("reopened_by", callerID.String()),
+		zap.Int32("reopened_count", conv.ReopenedCount),
+		zap.Duration("duration", time.Since(start)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationReopen, domain.AuditEntityConversation, conv.ID,
+		before, conversationAuditSnapshot(conv)))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
 	return conv, nil
 }
 
@@ -128,9 +614,10 @@ func (s *LifecycleService) Deallocate(ctx context.Context, tenantID, callerID, c
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
-	// Get conversation
-	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	// Lock the conversation until commit
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, conversationID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrNotFound
@@ -156,13 +643,23 @@ func (s *LifecycleService) Deallocate(ctx context.Context, tenantID, callerID, c
 	}
 
 	previousOperator := conv.AssignedOperatorID
+	before := conversationAuditSnapshot(conv)
 
 	// Update state
 	conv.State = domain.ConversationStateQueued
 	conv.AssignedOperatorID = nil
 	conv.UpdatedAt = time.Now().UTC()
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["deallocated_by"] = callerID.String()
+	data["reason"] = "manual"
+	event := domain.NewEvent(tenantID, domain.EventConversationDeallocated, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
 		return nil, err
 	}
 
@@ -182,14 +679,63 @@ func (s *LifecycleService) Deallocate(ctx context.Context, tenantID, callerID, c
 		zap.String("previous_operator", prevOpStr),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationDeallocate, domain.AuditEntityConversation, conv.ID,
+		before, conversationAuditSnapshot(conv)))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
 	return conv, nil
 }
 
+// DeallocateWithGrace returns an ALLOCATED conversation to the queue once a
+// MANUAL grace period expires, so its operator keeps it while handing over.
+// An existing grace period of the conversation is returned unchanged.
+// Permission: Manager or Admin
+func (s *LifecycleService) DeallocateWithGrace(ctx context.Context, tenantID, callerID, conversationID uuid.UUID, grace time.Duration, callerRole domain.OperatorRole) (*domain.GracePeriodAssignment, error) {
+	if !s.canManage(callerRole) {
+		return nil, ErrInsufficientPermissions
+	}
+
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State != domain.ConversationStateAllocated || conv.AssignedOperatorID == nil {
+		return nil, ErrConversationNotAllocated
+	}
+
+	existing, err := s.repos.GracePeriodAssignments.GetByConversationID(ctx, conv.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	gpa, err := s.gracePeriods.CreateGracePeriod(ctx, conv.ID, *conv.AssignedOperatorID, grace, domain.GracePeriodReasonManual)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationDeallocate, domain.AuditEntityConversation, conv.ID,
+		conversationAuditSnapshot(conv), gracePeriodAuditSnapshot(gpa)))
+
+	return gpa, nil
+}
+
 // ==================== Reassign ====================
 
-// Reassign assigns a conversation to a different operator
+// Reassign assigns a conversation to a different operator. A non-empty
+// handoverNote is stored as a HANDOVER note addressed to the new operator and
+// included in the reassigned event; it is dropped if the conversation is
+// already assigned to them.
 // Permission: Manager or Admin only
-func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, conversationID, newOperatorID uuid.UUID, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
+func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, conversationID, newOperatorID uuid.UUID, handoverNote string, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
 	start := time.Now()
 
 	// Check permissions first
@@ -203,9 +749,10 @@ func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, con
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
-	// Get conversation
-	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	// Lock the conversation until commit
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, conversationID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrNotFound
@@ -232,7 +779,7 @@ func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, con
 	}
 
 	// Verify new operator exists and is in same tenant
-	newOperator, err := s.repos.Operators.GetByID(ctx, newOperatorID)
+	newOperator, err := repos.Operators.GetByID(ctx, newOperatorID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, ErrTargetOperatorNotFound
@@ -244,7 +791,7 @@ func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, con
 	}
 
 	// Verify new operator is subscribed to the inbox
-	isSubscribed, err := s.repos.Subscriptions.IsSubscribed(ctx, newOperatorID, conv.InboxID)
+	isSubscribed, err := repos.Subscriptions.IsSubscribed(ctx, newOperatorID, conv.InboxID)
 	if err != nil {
 		return nil, err
 	}
@@ -253,12 +800,33 @@ func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, con
 	}
 
 	previousOperator := conv.AssignedOperatorID
+	before := conversationAuditSnapshot(conv)
 
 	// Update assignment
 	conv.AssignedOperatorID = &newOperatorID
 	conv.UpdatedAt = time.Now().UTC()
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	var note *domain.ConversationNote
+	if handoverNote != "" {
+		note = domain.NewHandoverNote(conv, callerID, newOperatorID, handoverNote)
+		if err := repos.ConversationNotes.Create(ctx, note); err != nil {
+			return nil, err
+		}
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["reassigned_by"] = callerID.String()
+	data["handover_note"] = nil
+	if note != nil {
+		data["handover_note"] = note.Body
+	}
+	event := domain.NewEvent(tenantID, domain.EventConversationReassigned, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
 		return nil, err
 	}
 
@@ -279,6 +847,16 @@ func (s *LifecycleService) Reassign(ctx context.Context, tenantID, callerID, con
 		zap.String("to_operator", newOperatorID.String()),
 		zap.Duration("duration", time.Since(start)))
 
+	after := conversationAuditSnapshot(conv)
+	if note != nil {
+		after["handover_note_id"] = note.ID.String()
+	}
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationReassign, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
 	return conv, nil
 }
 
@@ -301,9 +879,10 @@ func (s *LifecycleService) MoveInbox(ctx context.Context, tenantID, callerID, co
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
 
-	// Get conversation
-	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	// Lock the conversation until commit
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, conversationID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, domain.ErrNotFound
@@ -325,7 +904,7 @@ func (s *LifecycleService) MoveInbox(ctx context.Context, tenantID, callerID, co
 	}
 
 	// Verify new inbox exists and is in same tenant
-	newInbox, err := s.repos.Inboxes.GetByID(ctx, newInboxID)
+	newInbox, err := repos.Inboxes.GetByID(ctx, newInboxID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, ErrTargetInboxNotFound
@@ -337,11 +916,13 @@ func (s *LifecycleService) MoveInbox(ctx context.Context, tenantID, callerID, co
 	}
 
 	previousInbox := conv.InboxID
+	previousOperator := conv.AssignedOperatorID
 	autoDeallocated := false
+	before := conversationAuditSnapshot(conv)
 
 	// If conversation is ALLOCATED, check if operator is subscribed to new inbox
 	if conv.State == domain.ConversationStateAllocated && conv.AssignedOperatorID != nil {
-		isSubscribed, err := s.repos.Subscriptions.IsSubscribed(ctx, *conv.AssignedOperatorID, newInboxID)
+		isSubscribed, err := repos.Subscriptions.IsSubscribed(ctx, *conv.AssignedOperatorID, newInboxID)
 		if err != nil {
 			return nil, err
 		}
@@ -357,10 +938,22 @@ func (s *LifecycleService) MoveInbox(ctx context.Context, tenantID, callerID, co
 	conv.InboxID = newInboxID
 	conv.UpdatedAt = time.Now().UTC()
 
-	if err := s.repos.ConversationRefs.Update(ctx, conv); err != nil {
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
 		return nil, err
 	}
 
+	var event *domain.Event
+	if autoDeallocated {
+		data := conversationEventData(conv)
+		data["previous_operator_id"] = uuidPtrToString(previousOperator)
+		data["deallocated_by"] = callerID.String()
+		data["reason"] = "inbox_moved"
+		event = domain.NewEvent(tenantID, domain.EventConversationDeallocated, data)
+		if err := stageEvents(ctx, s.events, tx, event); err != nil {
+			return nil, err
+		}
+	}
+
 	// Commit
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
@@ -374,6 +967,14 @@ func (s *LifecycleService) MoveInbox(ctx context.Context, tenantID, callerID, co
 		zap.Bool("auto_deallocated", autoDeallocated),
 		zap.Duration("duration", time.Since(start)))
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationMoveInbox, domain.AuditEntityConversation, conv.ID,
+		before, conversationAuditSnapshot(conv)))
+
+	if event != nil {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
 	return conv, nil
 }
 
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var maintenanceJobsDeferred = metrics.NewCounter("maintenance_jobs_deferred_total")
+
+// MaintenanceConfig holds the deployment's maintenance window
+type MaintenanceConfig struct {
+	// Window gates deployment-wide jobs, and tenant jobs of tenants without
+	// their own window; nil runs them at any time
+	Window *domain.MaintenanceWindow
+	// Force overrides every window, tenant overrides included, until the
+	// configuration changes; empty follows the windows
+	Force domain.MaintenanceOverrideMode
+}
+
+// MaintenanceService decides when heavy background jobs run. Workers ask it
+// before each run of a job in domain.MaintenanceJobs and skip the run while
+// the window is closed; the job catches up on its next run inside the
+// window. A nil *MaintenanceService runs every job.
+type MaintenanceService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	config MaintenanceConfig
+	logger *logger.Logger
+}
+
+func NewMaintenanceService(repos *repository.RepositoryContainer, audit *AuditService, config MaintenanceConfig, log *logger.Logger) *MaintenanceService {
+	return &MaintenanceService{
+		repos:  repos,
+		audit:  audit,
+		config: config,
+		logger: log,
+	}
+}
+
+// ==================== Gating ====================
+
+// AllowDeployment reports whether a deployment-wide job may run now
+func (s *MaintenanceService) AllowDeployment(job domain.MaintenanceJob) bool {
+	if s == nil {
+		return true
+	}
+	status := s.DeploymentStatus(time.Now().UTC())
+	if !status.Open {
+		s.deferred(job, status, zap.Skip())
+	}
+	return status.Open
+}
+
+// AllowTenant reports whether a per-tenant job may run now for the tenant.
+// When the tenant's settings cannot be read the job is held, so a database
+// problem does not run heavy work at peak time.
+func (s *MaintenanceService) AllowTenant(ctx context.Context, job domain.MaintenanceJob, tenantID uuid.UUID) bool {
+	if s == nil {
+		return true
+	}
+	status, err := s.TenantStatus(ctx, tenantID, time.Now().UTC())
+	if err != nil {
+		s.logger.Warn("Failed to read maintenance settings, holding job",
+			zap.String("job", string(job)),
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+		maintenanceJobsDeferred.Inc()
+		return false
+	}
+	if !status.Open {
+		s.deferred(job, status, zap.String("tenant_id", tenantID.String()))
+	}
+	return status.Open
+}
+
+func (s *MaintenanceService) deferred(job domain.MaintenanceJob, status domain.MaintenanceStatus, scope zap.Field) {
+	maintenanceJobsDeferred.Inc()
+	fields := []zap.Field{zap.String("job", string(job)), scope}
+	if status.NextOpenAt != nil {
+		fields = append(fields, zap.Time("next_open_at", *status.NextOpenAt))
+	}
+	s.logger.Debug("Heavy job deferred to the maintenance window", fields...)
+}
+
+// ==================== Status ====================
+
+// DeploymentStatus returns whether deployment-wide jobs may run at now
+func (s *MaintenanceService) DeploymentStatus(now time.Time) domain.MaintenanceStatus {
+	return domain.EvaluateMaintenance(s.config.Window, s.forced(), now)
+}
+
+// TenantStatus returns whether the tenant's jobs may run at now
+func (s *MaintenanceService) TenantStatus(ctx context.Context, tenantID uuid.UUID, now time.Time) (domain.MaintenanceStatus, error) {
+	settings, err := s.GetSettings(ctx, tenantID)
+	if err != nil {
+		return domain.MaintenanceStatus{}, err
+	}
+	return s.tenantStatus(settings, now), nil
+}
+
+// Report returns the deployment's and the tenant's maintenance status now
+// Permission: Admin (enforced by router)
+func (s *MaintenanceService) Report(ctx context.Context, tenantID uuid.UUID) (*domain.MaintenanceReport, error) {
+	settings, err := s.GetSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &domain.MaintenanceReport{
+		At:         now,
+		Deployment: s.DeploymentStatus(now),
+		Tenant:     s.tenantStatus(settings, now),
+		Settings:   settings,
+	}, nil
+}
+
+func (s *MaintenanceService) tenantStatus(settings *domain.TenantMaintenanceSettings, now time.Time) domain.MaintenanceStatus {
+	window := settings.Window
+	if window == nil {
+		window = s.config.Window
+	}
+	override := settings.Override
+	if forced := s.forced(); forced != nil {
+		override = forced
+	}
+	return domain.EvaluateMaintenance(window, override, now)
+}
+
+// forced returns the MAINTENANCE_FORCE override, nil when not set
+func (s *MaintenanceService) forced() *domain.MaintenanceOverride {
+	if !s.config.Force.IsValid() {
+		return nil
+	}
+	return &domain.MaintenanceOverride{Mode: s.config.Force, Reason: "MAINTENANCE_FORCE"}
+}
+
+// ==================== Settings ====================
+
+// GetSettings returns the tenant's maintenance settings, empty settings if
+// the tenant never configured them
+func (s *MaintenanceService) GetSettings(ctx context.Context, tenantID uuid.UUID) (*domain.TenantMaintenanceSettings, error) {
+	settings, err := s.repos.MaintenanceSettings.Get(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultTenantMaintenanceSettings(tenantID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetWindow sets the tenant's maintenance window; nil goes back to the
+// deployment's
+// Permission: Admin (enforced by router)
+func (s *MaintenanceService) SetWindow(ctx context.Context, tenantID uuid.UUID, window *domain.MaintenanceWindow, updatedBy *uuid.UUID) (*domain.TenantMaintenanceSettings, error) {
+	return s.update(ctx, tenantID, updatedBy, func(settings *domain.TenantMaintenanceSettings) {
+		settings.Window = window
+	})
+}
+
+// SetOverride holds the tenant's window open or closed until the override
+// expires; nil removes the override
+// Permission: Admin (enforced by router)
+func (s *MaintenanceService) SetOverride(ctx context.Context, tenantID uuid.UUID, override *domain.MaintenanceOverride, updatedBy *uuid.UUID) (*domain.TenantMaintenanceSettings, error) {
+	return s.update(ctx, tenantID, updatedBy, func(settings *domain.TenantMaintenanceSettings) {
+		settings.Override = override
+	})
+}
+
+func (s *MaintenanceService) update(ctx context.Context, tenantID uuid.UUID, updatedBy *uuid.UUID, change func(*domain.TenantMaintenanceSettings)) (*domain.TenantMaintenanceSettings, error) {
+	settings, err := s.GetSettings(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	before := maintenanceAuditSnapshot(settings)
+
+	change(settings)
+	settings.UpdatedBy = updatedBy
+	settings.UpdatedAt = time.Now().UTC()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = settings.UpdatedAt
+	}
+	if err := s.repos.MaintenanceSettings.Upsert(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant maintenance settings updated",
+		zap.String("tenant_id", tenantID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionTenantMaintenanceChange, domain.AuditEntityTenant, tenantID,
+		before, maintenanceAuditSnapshot(settings)))
+
+	return settings, nil
+}
+
+func maintenanceAuditSnapshot(settings *domain.TenantMaintenanceSettings) map[string]interface{} {
+	snapshot := map[string]interface{}{}
+	if w := settings.Window; w != nil {
+		snapshot["window"] = w.String()
+		snapshot["timezone"] = w.Timezone
+	}
+	if o := settings.Override; o != nil {
+		snapshot["override_mode"] = string(o.Mode)
+		snapshot["override_until"] = o.Until
+		snapshot["override_reason"] = o.Reason
+	}
+	return snapshot
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceService_AllowDeployment(t *testing.T) {
+	now := time.Now().UTC()
+	// A two-hour window starting an hour from now never contains now
+	later := sinceMidnightOf(now) + time.Hour
+	closedWindow := &domain.MaintenanceWindow{Start: later % (24 * time.Hour), End: (later + 2*time.Hour) % (24 * time.Hour), Timezone: "UTC"}
+
+	tests := []struct {
+		name   string
+		config MaintenanceConfig
+		want   bool
+	}{
+		{"no window", MaintenanceConfig{}, true},
+		{"outside the window", MaintenanceConfig{Window: closedWindow}, false},
+		{"forced open", MaintenanceConfig{Window: closedWindow, Force: domain.MaintenanceOverrideOpen}, true},
+		{"forced closed", MaintenanceConfig{Force: domain.MaintenanceOverrideClosed}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewMaintenanceService(nil, nil, tt.config, logger.NewNop())
+			assert.Equal(t, tt.want, svc.AllowDeployment(domain.MaintenanceJobBackfill))
+		})
+	}
+
+	t.Run("nil service runs every job", func(t *testing.T) {
+		var svc *MaintenanceService
+		assert.True(t, svc.AllowDeployment(domain.MaintenanceJobQueueRanking))
+		assert.True(t, svc.AllowTenant(context.Background(), domain.MaintenanceJobReconciliation, uuid.New()))
+	})
+}
+
+func TestMaintenanceService_TenantStatus(t *testing.T) {
+	deployment := &domain.MaintenanceWindow{Start: time.Hour, End: 5 * time.Hour, Timezone: "UTC"}
+	own := &domain.MaintenanceWindow{Start: 12 * time.Hour, End: 14 * time.Hour, Timezone: "UTC"}
+	noon := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	svc := NewMaintenanceService(nil, nil, MaintenanceConfig{Window: deployment}, logger.NewNop())
+	inherited := domain.DefaultTenantMaintenanceSettings(uuid.New())
+	assert.False(t, svc.tenantStatus(inherited, noon).Open, "deployment window applies")
+
+	configured := &domain.TenantMaintenanceSettings{Window: own}
+	assert.True(t, svc.tenantStatus(configured, noon).Open, "tenant window replaces the deployment's")
+
+	configured.Override = &domain.MaintenanceOverride{Mode: domain.MaintenanceOverrideClosed, Until: noon.Add(time.Hour)}
+	assert.False(t, svc.tenantStatus(configured, noon).Open, "tenant override")
+
+	forced := NewMaintenanceService(nil, nil, MaintenanceConfig{Window: deployment, Force: domain.MaintenanceOverrideOpen}, logger.NewNop())
+	assert.True(t, forced.tenantStatus(configured, noon).Open, "MAINTENANCE_FORCE wins over tenant overrides")
+}
+
+func sinceMidnightOf(t time.Time) time.Duration {
+	return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAllocationThrottled    = errors.New("allocation is paced by the operator's allocation weight")
+	ErrHealthOperatorNotFound = errors.New("operator not found")
+)
+
+var (
+	operatorHealthWeightsLowered  = metrics.NewCounter("operator_health_weights_lowered_total")
+	operatorHealthWeightsRestored = metrics.NewCounter("operator_health_weights_restored_total")
+	operatorHealthErrors          = metrics.NewCounter("operator_health_evaluation_errors_total")
+	allocationsThrottled          = metrics.NewCounter("allocations_throttled_total")
+)
+
+// AllocationThrottledError is returned by Allocate while an operator's
+// reduced allocation weight holds back their next allocation
+type AllocationThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AllocationThrottledError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrAllocationThrottled, e.RetryAfter.Round(time.Second))
+}
+
+func (e *AllocationThrottledError) Unwrap() error {
+	return ErrAllocationThrottled
+}
+
+// OperatorHealthConfig holds configuration for health-weighted routing
+type OperatorHealthConfig struct {
+	// Window is how far back each evaluation counts allocations and returns
+	Window time.Duration
+	// PaceInterval is the wait between automatic allocations at weight 0.5;
+	// see domain.AllocationPace
+	PaceInterval time.Duration
+	Policy       domain.AllocationHealthPolicy
+}
+
+// DefaultOperatorHealthConfig returns sensible defaults
+func DefaultOperatorHealthConfig() OperatorHealthConfig {
+	return OperatorHealthConfig{
+		Window:       time.Hour,
+		PaceInterval: time.Minute,
+		Policy:       domain.DefaultAllocationHealthPolicy(),
+	}
+}
+
+// OperatorHealthService steers automatic allocation away from operators who
+// keep handing conversations back, which usually means connectivity trouble.
+// The health worker lowers an operator's allocation weight after each window
+// with an abnormal return rate and restores it after normal ones; below 1 the
+// weight paces their automatic allocations. Claims are never paced. Managers
+// can pin the weight with an override.
+type OperatorHealthService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	config OperatorHealthConfig
+	logger *logger.Logger
+}
+
+func NewOperatorHealthService(repos *repository.RepositoryContainer, audit *AuditService, config OperatorHealthConfig, log *logger.Logger) *OperatorHealthService {
+	return &OperatorHealthService{
+		repos:  repos,
+		audit:  audit,
+		config: config,
+		logger: log,
+	}
+}
+
+// ==================== Managers ====================
+
+// List returns the tenant's operators that have been evaluated or
+// overridden, lowest weight first; every other operator has weight 1
+// Permission: Manager+ (enforced by router)
+func (s *OperatorHealthService) List(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorAllocationHealth, error) {
+	return s.repos.OperatorHealth.ListByTenant(ctx, tenantID)
+}
+
+// SetOverride pins the operator's allocation weight; nil clears the override
+// and hands the weight back to the feedback loop
+// Permission: Manager+ (enforced by router)
+func (s *OperatorHealthService) SetOverride(ctx context.Context, tenantID, operatorID uuid.UUID, weight *float64, updatedBy *uuid.UUID) (*domain.OperatorAllocationHealth, error) {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrHealthOperatorNotFound
+		}
+		return nil, err
+	}
+	if operator.TenantID != tenantID {
+		return nil, ErrHealthOperatorNotFound
+	}
+
+	health, err := s.repos.OperatorHealth.GetByOperatorID(ctx, operatorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		health, err = domain.NewOperatorAllocationHealth(tenantID, operatorID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	before := operatorHealthAuditSnapshot(health)
+	if err := health.Override(weight, updatedBy); err != nil {
+		return nil, err
+	}
+	if err := s.repos.OperatorHealth.SaveOverride(ctx, health); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Operator allocation weight override changed",
+		zap.String("operator_id", operatorID.String()),
+		zap.Float64("effective_weight", health.EffectiveWeight()),
+		zap.Bool("override", health.OverrideWeight != nil),
+		zap.Any("updated_by", uuidPtrToString(updatedBy)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionOperatorAllocationWeight, domain.AuditEntityOperator, operatorID,
+		before, operatorHealthAuditSnapshot(health)))
+
+	return health, nil
+}
+
+// ==================== Allocation ====================
+
+// Pace returns how much longer the operator must wait for their next
+// automatic allocation; zero at full weight
+func (s *OperatorHealthService) Pace(ctx context.Context, tenantID, operatorID uuid.UUID) (time.Duration, error) {
+	health, err := s.repos.OperatorHealth.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	pace := domain.AllocationPace(health.EffectiveWeight(), s.config.PaceInterval)
+	if pace == 0 {
+		return 0, nil
+	}
+
+	last, err := s.repos.AuditLogs.LastAllocationAt(ctx, tenantID, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if wait := time.Until(last.Add(pace)); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+// ==================== Feedback Loop ====================
+
+// EvaluateAll adjusts the allocation weights of every tenant from the last
+// Window and returns how many weights changed. A tenant that fails is skipped.
+func (s *OperatorHealthService) EvaluateAll(ctx context.Context) (int, error) {
+	tenants, err := s.repos.Tenants.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		changed int
+		errs    []error
+	)
+	for _, tenant := range tenants {
+		n, err := s.evaluate(ctx, tenant.ID)
+		changed += n
+		if err != nil {
+			operatorHealthErrors.Inc()
+			s.logger.Warn("Failed to evaluate operator allocation health",
+				zap.String("tenant_id", tenant.ID.String()),
+				zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// evaluate adjusts the weights of one tenant. Operators at full weight get a
+// row only once their return rate turns abnormal.
+func (s *OperatorHealthService) evaluate(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	outcomes, err := s.repos.AuditLogs.CountOperatorOutcomes(ctx, tenantID, time.Now().UTC().Add(-s.config.Window))
+	if err != nil {
+		return 0, fmt.Errorf("count outcomes: %w", err)
+	}
+	existing, err := s.repos.OperatorHealth.ListByTenant(ctx, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("list health: %w", err)
+	}
+
+	tracked := make(map[uuid.UUID]*domain.OperatorAllocationHealth, len(existing))
+	for _, health := range existing {
+		tracked[health.OperatorID] = health
+	}
+	for operatorID, outcome := range outcomes {
+		if _, ok := tracked[operatorID]; !ok && s.config.Policy.Abnormal(outcome.Allocations, outcome.Returns) {
+			tracked[operatorID] = domain.NewOperatorAllocationHealth(tenantID, operatorID)
+		}
+	}
+
+	var (
+		changed int
+		errs    []error
+	)
+	for operatorID, health := range tracked {
+		outcome := outcomes[operatorID]
+		previous := health.Weight
+		health.Evaluate(s.config.Policy, outcome.Allocations, outcome.Returns)
+		// One operator failing (e.g. deleted since the window began) must not
+		// hold back the others
+		if err := s.repos.OperatorHealth.SaveWeight(ctx, health); err != nil {
+			errs = append(errs, fmt.Errorf("save operator %s: %w", operatorID, err))
+			continue
+		}
+
+		switch {
+		case health.Weight < previous:
+			changed++
+			operatorHealthWeightsLowered.Inc()
+			s.logger.Warn("Operator allocation weight lowered",
+				zap.String("tenant_id", tenantID.String()),
+				zap.String("operator_id", operatorID.String()),
+				zap.Int("allocations", outcome.Allocations),
+				zap.Int("returns", outcome.Returns),
+				zap.Float64("weight", health.Weight))
+		case health.Weight > previous:
+			changed++
+			operatorHealthWeightsRestored.Inc()
+			s.logger.Info("Operator allocation weight restored",
+				zap.String("tenant_id", tenantID.String()),
+				zap.String("operator_id", operatorID.String()),
+				zap.Float64("weight", health.Weight))
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+func operatorHealthAuditSnapshot(h *domain.OperatorAllocationHealth) map[string]interface{} {
+	var override interface{}
+	if h.OverrideWeight != nil {
+		override = *h.OverrideWeight
+	}
+	return map[string]interface{}{
+		"weight":          h.Weight,
+		"override_weight": override,
+	}
+}
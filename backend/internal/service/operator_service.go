@@ -2,30 +2,62 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/inbox-allocation-service/internal/pkg/database"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
 	"github.com/inbox-allocation-service/internal/repository"
 	"go.uber.org/zap"
 )
 
-const GracePeriodDuration = 5 * time.Minute
+const (
+	// GracePeriodDuration is the default grace period of operators who go
+	// OFFLINE, for tenants without their own
+	GracePeriodDuration = 5 * time.Minute
+	// AwayGracePeriodDuration is the shorter grace period of operators who
+	// step AWAY, capped by the OFFLINE one
+	AwayGracePeriodDuration = 2 * time.Minute
+)
+
+// OperatorConfig holds configuration for the operator service
+type OperatorConfig struct {
+	// GracePeriod applies to tenants without grace_period_seconds
+	GracePeriod time.Duration
+}
+
+// DefaultOperatorConfig returns sensible defaults
+func DefaultOperatorConfig() OperatorConfig {
+	return OperatorConfig{GracePeriod: GracePeriodDuration}
+}
+
+// ErrFocusRequiresAvailable is returned when an operator who is not
+// AVAILABLE asks for focus mode
+var ErrFocusRequiresAvailable = errors.New("operator must be AVAILABLE to start focus mode")
+
+var presenceExpired = metrics.NewCounter("operator_presence_expired_total")
 
 type OperatorService struct {
 	repos  *repository.RepositoryContainer
 	txMgr  *database.TxManager
+	events domain.EventPublisher
+	audit  *AuditService
+	config OperatorConfig
 	logger *logger.Logger
 }
 
 func NewOperatorService(
 	repos *repository.RepositoryContainer,
 	txMgr *database.TxManager,
+	events domain.EventPublisher,
+	audit *AuditService,
+	config OperatorConfig,
 	log *logger.Logger,
 ) *OperatorService {
-	return &OperatorService{repos: repos, txMgr: txMgr, logger: log}
+	return &OperatorService{repos: repos, txMgr: txMgr, events: events, audit: audit, config: config, logger: log}
 }
 
 // ==================== Status Management ====================
@@ -34,7 +66,131 @@ func (s *OperatorService) GetStatus(ctx context.Context, operatorID uuid.UUID) (
 	return s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
 }
 
+// UpdateStatus changes the operator's own status
 func (s *OperatorService) UpdateStatus(ctx context.Context, operatorID uuid.UUID, newStatus domain.OperatorStatusType) (*domain.OperatorStatus, error) {
+	return s.setStatus(ctx, operatorID, newStatus, &operatorID)
+}
+
+// StartFocus keeps new conversations from being allocated to the AVAILABLE
+// operator for the given duration. They stay AVAILABLE, so their
+// conversations get no grace periods. Starting focus again replaces the
+// previous end.
+func (s *OperatorService) StartFocus(ctx context.Context, operatorID uuid.UUID, duration time.Duration) (*domain.OperatorStatus, error) {
+	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, ErrFocusRequiresAvailable
+		}
+		return nil, err
+	}
+	if status.Status != domain.OperatorStatusAvailable {
+		return nil, ErrFocusRequiresAvailable
+	}
+
+	before := focusAuditSnapshot(status)
+	status.StartFocus(time.Now().Add(duration))
+	if err := s.repos.OperatorStatus.Update(ctx, status); err != nil {
+		return nil, err
+	}
+	s.focusChanged(ctx, operatorID, before, status)
+	return status, nil
+}
+
+// EndFocus ends the operator's focus early. Ending focus that is not on is
+// a no-op.
+func (s *OperatorService) EndFocus(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorStatus, error) {
+	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if !status.InFocus(time.Now()) {
+		return status, nil
+	}
+
+	before := focusAuditSnapshot(status)
+	status.EndFocus()
+	if err := s.repos.OperatorStatus.Update(ctx, status); err != nil {
+		return nil, err
+	}
+	s.focusChanged(ctx, operatorID, before, status)
+	return status, nil
+}
+
+func (s *OperatorService) focusChanged(ctx context.Context, operatorID uuid.UUID, before map[string]interface{}, status *domain.OperatorStatus) {
+	if s.audit == nil {
+		return
+	}
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		s.logger.Warn("Failed to get operator for focus audit",
+			zap.String("operator_id", operatorID.String()),
+			zap.Error(err))
+		return
+	}
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(operator.TenantID, &operatorID,
+		domain.AuditActionOperatorFocusChange, domain.AuditEntityOperator, operatorID,
+		before, focusAuditSnapshot(status)))
+}
+
+func focusAuditSnapshot(status *domain.OperatorStatus) map[string]interface{} {
+	if status.FocusUntil == nil {
+		return map[string]interface{}{"focus_until": nil}
+	}
+	return map[string]interface{}{"focus_until": status.FocusUntil.Format(time.RFC3339)}
+}
+
+// ResumePresence sets an OFFLINE operator AVAILABLE when their presence
+// connection opens or comes back after timing out. BUSY and AWAY are the
+// operator's choice and are kept.
+func (s *OperatorService) ResumePresence(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorStatus, error) {
+	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
+	if err != nil && err != domain.ErrNotFound {
+		return nil, err
+	}
+	if err == nil && status.Status != domain.OperatorStatusOffline {
+		return status, nil
+	}
+	return s.setStatus(ctx, operatorID, domain.OperatorStatusAvailable, &operatorID)
+}
+
+// EndShift sets the operator OFFLINE at the end of their scheduled working
+// hours, starting grace periods for their conversations like a manual change
+func (s *OperatorService) EndShift(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorStatus, error) {
+	return s.setStatus(ctx, operatorID, domain.OperatorStatusOffline, nil)
+}
+
+// ExpirePresence sets operators whose presence connection was last seen
+// before the cutoff OFFLINE, starting grace periods for their conversations,
+// and returns how many were. Operators that fail are skipped.
+func (s *OperatorService) ExpirePresence(ctx context.Context, cutoff time.Time) (int, error) {
+	stale, err := s.repos.OperatorPresence.ClaimStale(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		expired int
+		errs    []error
+	)
+	for _, presence := range stale {
+		if _, err := s.setStatus(ctx, presence.OperatorID, domain.OperatorStatusOffline, nil); err != nil {
+			s.logger.Warn("Failed to set disconnected operator offline",
+				zap.String("operator_id", presence.OperatorID.String()),
+				zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		expired++
+		presenceExpired.Inc()
+		s.logger.Info("Operator presence timed out",
+			zap.String("operator_id", presence.OperatorID.String()),
+			zap.Time("last_seen_at", presence.LastSeenAt))
+	}
+	return expired, errors.Join(errs...)
+}
+
+// setStatus changes the operator's status; actorID is nil for system changes
+func (s *OperatorService) setStatus(ctx context.Context, operatorID uuid.UUID, newStatus domain.OperatorStatusType, actorID *uuid.UUID) (*domain.OperatorStatus, error) {
 	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
 	if err != nil {
 		if err == domain.ErrNotFound {
@@ -44,6 +200,7 @@ func (s *OperatorService) UpdateStatus(ctx context.Context, operatorID uuid.UUID
 			if err := s.repos.OperatorStatus.Create(ctx, status); err != nil {
 				return nil, err
 			}
+			s.statusChanged(ctx, operatorID, actorID, nil, newStatus)
 			return status, nil
 		}
 		return nil, err
@@ -59,17 +216,59 @@ func (s *OperatorService) UpdateStatus(ctx context.Context, operatorID uuid.UUID
 		return nil, err
 	}
 
-	// Grace period logic
-	if previousStatus == domain.OperatorStatusAvailable && newStatus == domain.OperatorStatusOffline {
-		s.createGracePeriods(ctx, operatorID)
-	} else if previousStatus == domain.OperatorStatusOffline && newStatus == domain.OperatorStatusAvailable {
+	// Grace period logic: BUSY keeps conversations like AVAILABLE; AWAY
+	// releases them sooner than OFFLINE. Moving between AWAY and OFFLINE
+	// keeps the grace periods already running.
+	if previousStatus.HoldsConversations() && !newStatus.HoldsConversations() {
+		if newStatus == domain.OperatorStatusAway {
+			s.createGracePeriods(ctx, operatorID, domain.GracePeriodReasonAway)
+		} else {
+			s.createGracePeriods(ctx, operatorID, domain.GracePeriodReasonOffline)
+		}
+	} else if !previousStatus.HoldsConversations() && newStatus.HoldsConversations() {
 		s.repos.GracePeriodAssignments.DeleteByOperatorID(ctx, operatorID)
 	}
 
+	s.statusChanged(ctx, operatorID, actorID, &previousStatus, newStatus)
+
 	return status, nil
 }
 
-func (s *OperatorService) createGracePeriods(ctx context.Context, operatorID uuid.UUID) {
+// statusChanged records the status change in the audit log and publishes it.
+// Status changes are made by the operator themselves, or by the system
+// (nil actorID) at shift end or when their presence connection times out.
+func (s *OperatorService) statusChanged(ctx context.Context, operatorID uuid.UUID, actorID *uuid.UUID, previous *domain.OperatorStatusType, current domain.OperatorStatusType) {
+	if s.events == nil && s.audit == nil {
+		return
+	}
+
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		s.logger.Warn("Failed to get operator for status event",
+			zap.String("operator_id", operatorID.String()),
+			zap.Error(err))
+		return
+	}
+
+	data := map[string]interface{}{
+		"operator_id":     operatorID.String(),
+		"status":          string(current),
+		"previous_status": nil,
+	}
+	var before map[string]interface{}
+	if previous != nil {
+		data["previous_status"] = string(*previous)
+		before = map[string]interface{}{"status": string(*previous)}
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(operator.TenantID, actorID,
+		domain.AuditActionOperatorStatusChange, domain.AuditEntityOperator, operatorID,
+		before, map[string]interface{}{"status": string(current)}))
+
+	publishEvent(ctx, s.events, s.logger, domain.NewEvent(operator.TenantID, domain.EventOperatorStatusChanged, data))
+}
+
+func (s *OperatorService) createGracePeriods(ctx context.Context, operatorID uuid.UUID, reason domain.GracePeriodReason) {
 	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
 	if err != nil {
 		s.logger.Warn("Failed to get operator for grace period creation",
@@ -77,6 +276,7 @@ func (s *OperatorService) createGracePeriods(ctx context.Context, operatorID uui
 			zap.Error(err))
 		return
 	}
+	duration := s.gracePeriod(ctx, operator.TenantID, reason)
 
 	state := domain.ConversationStateAllocated
 	conversations, err := s.repos.ConversationRefs.GetByOperatorID(ctx, operator.TenantID, operatorID, &state)
@@ -87,9 +287,9 @@ func (s *OperatorService) createGracePeriods(ctx context.Context, operatorID uui
 		return
 	}
 
-	expiresAt := time.Now().UTC().Add(GracePeriodDuration)
+	expiresAt := time.Now().UTC().Add(duration)
 	for _, conv := range conversations {
-		gpa := domain.NewGracePeriodAssignment(conv.ID, operatorID, expiresAt, domain.GracePeriodReasonOffline)
+		gpa := domain.NewGracePeriodAssignment(conv.ID, operatorID, expiresAt, reason)
 		if err := s.repos.GracePeriodAssignments.Create(ctx, gpa); err != nil {
 			s.logger.Warn("Failed to create grace period for conversation",
 				zap.String("conversation_id", conv.ID.String()),
@@ -99,13 +299,38 @@ func (s *OperatorService) createGracePeriods(ctx context.Context, operatorID uui
 
 	s.logger.Info("Grace periods created",
 		zap.String("operator_id", operatorID.String()),
+		zap.String("reason", string(reason)),
 		zap.Int("count", len(conversations)))
 }
 
+// gracePeriod returns how long the conversations of an operator leaving for
+// reason stay assigned: the tenant's grace period, else the configured
+// default, and for AWAY at most AwayGracePeriodDuration
+func (s *OperatorService) gracePeriod(ctx context.Context, tenantID uuid.UUID, reason domain.GracePeriodReason) time.Duration {
+	duration := s.config.GracePeriod
+	tenant, err := s.repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		s.logger.Warn("Failed to get tenant grace period, using the default",
+			zap.String("tenant_id", tenantID.String()),
+			zap.Error(err))
+	} else if tenant.GracePeriod != nil {
+		duration = *tenant.GracePeriod
+	}
+	if reason == domain.GracePeriodReasonAway && duration > AwayGracePeriodDuration {
+		duration = AwayGracePeriodDuration
+	}
+	return duration
+}
+
 // ==================== CRUD ====================
 
-func (s *OperatorService) Create(ctx context.Context, tenantID uuid.UUID, role domain.OperatorRole) (*domain.Operator, error) {
+// Create adds an operator; name and email are the optional profile
+func (s *OperatorService) Create(ctx context.Context, tenantID uuid.UUID, role domain.OperatorRole, name, email *string, languages []string, createdBy *uuid.UUID) (*domain.Operator, error) {
 	operator := domain.NewOperator(tenantID, role)
+	operator.SetProfile(name, email)
+	if languages != nil {
+		operator.SetLanguages(languages)
+	}
 	if err := s.repos.Operators.Create(ctx, operator); err != nil {
 		return nil, err
 	}
@@ -118,6 +343,10 @@ func (s *OperatorService) Create(ctx context.Context, tenantID uuid.UUID, role d
 			zap.Error(err))
 	}
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, createdBy,
+		domain.AuditActionOperatorCreate, domain.AuditEntityOperator, operator.ID,
+		nil, map[string]interface{}{"role": string(operator.Role), "name": operator.Name, "email": operator.Email, "languages": operator.Languages}))
+
 	return operator, nil
 }
 
@@ -129,21 +358,80 @@ func (s *OperatorService) ListByTenant(ctx context.Context, tenantID uuid.UUID)
 	return s.repos.Operators.GetByTenantID(ctx, tenantID)
 }
 
-func (s *OperatorService) Update(ctx context.Context, id uuid.UUID, role domain.OperatorRole) (*domain.Operator, error) {
+// Update sets the operator's role and changes its profile: a nil name or
+// email is left unchanged and an empty one is cleared. Nil languages are left
+// unchanged; the languages must be normalized.
+func (s *OperatorService) Update(ctx context.Context, id uuid.UUID, role domain.OperatorRole, name, email *string, languages []string, updatedBy *uuid.UUID) (*domain.Operator, error) {
 	operator, err := s.repos.Operators.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	previousRole := operator.Role
+	previousProfile := operatorProfileAuditSnapshot(operator)
 	operator.Role = role
+	profileChanged := operator.SetProfile(name, email)
+	if languages != nil && operator.SetLanguages(languages) {
+		profileChanged = true
+	}
 	operator.UpdatedAt = time.Now().UTC()
 
 	if err := s.repos.Operators.Update(ctx, operator); err != nil {
 		return nil, err
 	}
+
+	if previousRole != role {
+		recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(operator.TenantID, updatedBy,
+			domain.AuditActionOperatorRoleChange, domain.AuditEntityOperator, operator.ID,
+			map[string]interface{}{"role": string(previousRole)},
+			map[string]interface{}{"role": string(role)}))
+	}
+	if profileChanged {
+		recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(operator.TenantID, updatedBy,
+			domain.AuditActionOperatorProfileChange, domain.AuditEntityOperator, operator.ID,
+			previousProfile, operatorProfileAuditSnapshot(operator)))
+	}
+
 	return operator, nil
 }
 
-func (s *OperatorService) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repos.Operators.Delete(ctx, id)
+func operatorProfileAuditSnapshot(operator *domain.Operator) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      operator.Name,
+		"email":     operator.Email,
+		"languages": operator.Languages,
+	}
+}
+
+// DeletionImpact counts what deleting the operator would affect
+func (s *OperatorService) DeletionImpact(ctx context.Context, id uuid.UUID) (*domain.DeletionImpact, error) {
+	return s.repos.DeletionImpacts.ForOperator(ctx, id)
+}
+
+// Delete deletes the operator. Unless forced, an operator holding allocated
+// conversations or grace periods is kept and a *DeletionBlockedError
+// returned.
+func (s *OperatorService) Delete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID, force bool) error {
+	operator, err := s.repos.Operators.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	impact, err := s.repos.DeletionImpacts.ForOperator(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := guardDeletion(impact, force); err != nil {
+		return err
+	}
+
+	if err := s.repos.Operators.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(operator.TenantID, deletedBy,
+		domain.AuditActionOperatorDelete, domain.AuditEntityOperator, operator.ID,
+		map[string]interface{}{"role": string(operator.Role)}, nil))
+
+	return nil
 }
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/pkg/retry"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+var (
+	outboxPublished       = metrics.NewCounter("event_outbox_published_total")
+	outboxRedelivered     = metrics.NewCounter("event_outbox_redelivered_total")
+	outboxPublishFailures = metrics.NewCounter("event_outbox_publish_failures_total")
+	outboxPending         = metrics.NewGauge("event_outbox_pending")
+)
+
+// OutboxConfig holds configuration for the event outbox
+type OutboxConfig struct {
+	// Retry controls the backoff between attempts; MaxAttempts is unused
+	// because entries are retried until they are published
+	Retry retry.Config
+	// FlushGrace is how long after staging the worker leaves an entry to the
+	// producer, which publishes it right after commit
+	FlushGrace time.Duration
+	// LeaseDuration is how long a claimed entry is hidden from other workers
+	LeaseDuration time.Duration
+	// Retention is how long published entries are kept
+	Retention time.Duration
+}
+
+// DefaultOutboxConfig returns sensible defaults
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		Retry: retry.Config{
+			InitialBackoff: 5 * time.Second,
+			MaxBackoff:     10 * time.Minute,
+			BackoffFactor:  2.0,
+			Jitter:         0.1,
+		},
+		FlushGrace:    30 * time.Second,
+		LeaseDuration: 1 * time.Minute,
+		Retention:     24 * time.Hour,
+	}
+}
+
+// OutboxResult holds the result of an outbox cycle
+type OutboxResult struct {
+	Processed int
+	Published int
+	Retrying  int
+	Purged    int64
+}
+
+// EventOutbox makes event publishing survive a crash after commit. Services
+// stage their events in the transaction of the state change (see
+// stageEvents) and publish them after commit as before; Publish hands the
+// event to the sinks and marks its entry published. Entries left pending,
+// because the process died or a sink failed, are published by the outbox
+// worker. Delivery is at least once: a sink may see an event again after a
+// partial failure, and consumers deduplicate on the event ID.
+type EventOutbox struct {
+	repos  *repository.RepositoryContainer
+	sinks  domain.EventPublisher
+	config OutboxConfig
+	logger *logger.Logger
+}
+
+func NewEventOutbox(repos *repository.RepositoryContainer, sinks domain.EventPublisher, config OutboxConfig, log *logger.Logger) *EventOutbox {
+	return &EventOutbox{
+		repos:  repos,
+		sinks:  sinks,
+		config: config,
+		logger: log,
+	}
+}
+
+// Stage writes events to the outbox inside tx, so they commit or roll back
+// with the state change that produced them
+func (o *EventOutbox) Stage(ctx context.Context, tx pgx.Tx, events ...*domain.Event) error {
+	outbox := o.repos.WithTx(tx).Outbox
+	availableAt := time.Now().UTC().Add(o.config.FlushGrace)
+	for _, event := range events {
+		if err := outbox.Create(ctx, domain.NewOutboxEntry(event, availableAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StagedOnly returns a publisher that stages events like the outbox but
+// leaves publishing them to the outbox worker of a running replica. Tools
+// without the event sinks, such as cmd/invariants, use it.
+func (o *EventOutbox) StagedOnly() domain.EventPublisher {
+	return stagedOnlyOutbox{outbox: o}
+}
+
+type stagedOnlyOutbox struct {
+	outbox *EventOutbox
+}
+
+func (s stagedOnlyOutbox) Stage(ctx context.Context, tx pgx.Tx, events ...*domain.Event) error {
+	return s.outbox.Stage(ctx, tx, events...)
+}
+
+func (stagedOnlyOutbox) Publish(context.Context, *domain.Event) error {
+	return nil
+}
+
+// Publish implements domain.EventPublisher. A staged event whose sinks all
+// accept it is marked published; when a sink fails the event stays in (or,
+// if it was never staged, is added to) the outbox for the worker to retry.
+func (o *EventOutbox) Publish(ctx context.Context, event *domain.Event) error {
+	if err := o.sinks.Publish(ctx, event); err != nil {
+		outboxPublishFailures.Inc()
+		entry := domain.NewOutboxEntry(event, time.Now().UTC().Add(o.config.Retry.Backoff(1)))
+		if stageErr := o.repos.Outbox.Create(ctx, entry); stageErr != nil {
+			o.logger.Error("Failed to keep unpublished event in the outbox",
+				zap.String("event_id", event.ID.String()),
+				zap.Error(stageErr))
+		}
+		return err
+	}
+
+	outboxPublished.Inc()
+	if err := o.repos.Outbox.MarkPublished(ctx, event.ID, time.Now().UTC()); err != nil {
+		// The worker publishes the event again once the grace period is over
+		o.logger.Warn("Failed to mark outbox entry published",
+			zap.String("event_id", event.ID.String()),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// PublishDue claims pending entries whose grace period or backoff is over and
+// publishes each once, then purges published entries past the retention.
+// Claiming uses FOR UPDATE SKIP LOCKED plus a lease, so several replicas can
+// run the worker.
+func (o *EventOutbox) PublishDue(ctx context.Context, batchSize int) (*OutboxResult, error) {
+	result := &OutboxResult{}
+
+	leaseUntil := time.Now().UTC().Add(o.config.LeaseDuration)
+	entries, err := o.repos.Outbox.ClaimDue(ctx, batchSize, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	result.Processed = len(entries)
+
+	for _, entry := range entries {
+		if err := o.sinks.Publish(ctx, entry.Event); err != nil {
+			outboxPublishFailures.Inc()
+			entry.MarkFailed(err.Error(), time.Now().UTC().Add(o.config.Retry.Backoff(entry.AttemptCount+1)))
+			result.Retrying++
+			o.logger.Warn("Failed to publish outbox entry",
+				zap.String("event_id", entry.Event.ID.String()),
+				zap.String("event_type", string(entry.Event.Type)),
+				zap.Int("attempts", entry.AttemptCount),
+				zap.Error(err))
+		} else {
+			entry.MarkPublished()
+			outboxRedelivered.Inc()
+			result.Published++
+		}
+
+		if err := o.repos.Outbox.UpdateAttempt(ctx, entry); err != nil {
+			o.logger.Error("Failed to record outbox attempt",
+				zap.String("event_id", entry.Event.ID.String()),
+				zap.Error(err))
+		}
+	}
+
+	purged, err := o.repos.Outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(-o.config.Retention))
+	if err != nil {
+		o.logger.Warn("Failed to purge published outbox entries", zap.Error(err))
+	}
+	result.Purged = purged
+
+	if pending, err := o.repos.Outbox.CountPending(ctx); err == nil {
+		outboxPending.Set(pending)
+	}
+
+	return result, nil
+}
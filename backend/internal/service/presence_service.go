@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/database"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// OperatorPresenceChannel is the PostgreSQL NOTIFY channel carrying operator
+// status changes to the replicas holding presence connections
+const OperatorPresenceChannel = "operator_presence"
+
+// PresenceConfig holds configuration for the presence channel
+type PresenceConfig struct {
+	Policy domain.PresencePolicy
+	// BufferSize is the number of changes queued per session before changes are dropped
+	BufferSize int
+}
+
+// DefaultPresenceConfig returns sensible defaults
+func DefaultPresenceConfig() PresenceConfig {
+	return PresenceConfig{Policy: domain.DefaultPresencePolicy(), BufferSize: 64}
+}
+
+// PresenceService tracks operators connected to the presence WebSocket and
+// fans teammates' status changes out to them. Teammates are operators
+// subscribed to a common inbox. Like the event stream, changes travel
+// through PostgreSQL NOTIFY so that every replica delivers to its own
+// sessions; presence itself is kept in operator_presence so that any
+// replica can time it out.
+type PresenceService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	config PresenceConfig
+	logger *logger.Logger
+
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]*PresenceSession
+}
+
+func NewPresenceService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, config PresenceConfig, log *logger.Logger) *PresenceService {
+	defaults := DefaultPresenceConfig()
+	if config.Policy.HeartbeatInterval <= 0 {
+		config.Policy.HeartbeatInterval = defaults.Policy.HeartbeatInterval
+	}
+	if config.Policy.Timeout <= 0 {
+		config.Policy.Timeout = defaults.Policy.Timeout
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaults.BufferSize
+	}
+	return &PresenceService{
+		repos:    repos,
+		pool:     pool,
+		config:   config,
+		logger:   log,
+		sessions: make(map[uuid.UUID]*PresenceSession),
+	}
+}
+
+// Policy returns the heartbeat and timeout policy sessions follow
+func (s *PresenceService) Policy() domain.PresencePolicy {
+	return s.config.Policy
+}
+
+// ==================== Publish ====================
+
+// Publish implements domain.EventPublisher. Only operator status changes are
+// broadcast, addressed to the inboxes the operator is subscribed to.
+func (s *PresenceService) Publish(ctx context.Context, event *domain.Event) error {
+	if event.Type != domain.EventOperatorStatusChanged {
+		return nil
+	}
+
+	operatorID := eventUUID(event, "operator_id")
+	status, _ := event.Data["status"].(string)
+	if operatorID == uuid.Nil || status == "" {
+		return nil
+	}
+	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	if len(inboxIDs) == 0 {
+		return nil // No teammates to tell
+	}
+
+	change := domain.PresenceChange{
+		TenantID:   event.TenantID,
+		OperatorID: operatorID,
+		InboxIDs:   inboxIDs,
+		Status:     domain.OperatorStatusType(status),
+		OccurredAt: event.OccurredAt,
+	}
+	if previous, ok := event.Data["previous_status"].(string); ok {
+		previousStatus := domain.OperatorStatusType(previous)
+		change.PreviousStatus = &previousStatus
+	}
+
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return database.Notify(ctx, s.pool, OperatorPresenceChannel, string(payload))
+}
+
+// ==================== Listen ====================
+
+// Run listens for status changes and dispatches them to sessions until ctx is cancelled
+func (s *PresenceService) Run(ctx context.Context) {
+	database.Listen(ctx, s.pool, OperatorPresenceChannel, s.dispatch, s.logger)
+}
+
+func (s *PresenceService) dispatch(payload string) {
+	var change domain.PresenceChange
+	if err := json.Unmarshal([]byte(payload), &change); err != nil {
+		s.logger.Warn("Discarding malformed presence notification", zap.Error(err))
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, session := range s.sessions {
+		if !session.wants(&change) {
+			continue
+		}
+		select {
+		case session.changes <- &change:
+		default:
+			s.logger.Warn("Presence session is too slow, dropping status change",
+				zap.String("session_id", session.ID.String()),
+				zap.String("operator_id", change.OperatorID.String()))
+		}
+	}
+}
+
+// ==================== Sessions ====================
+
+// PresenceSession is one presence connection of an operator. It receives
+// status changes of the operator's teammates in the inboxes they were
+// subscribed to when connecting.
+type PresenceSession struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	OperatorID uuid.UUID
+
+	inboxIDs map[uuid.UUID]struct{}
+	changes  chan *domain.PresenceChange
+	service  *PresenceService
+	once     sync.Once
+}
+
+// Changes returns the channel of teammates' status changes. It is closed by Close.
+func (session *PresenceSession) Changes() <-chan *domain.PresenceChange {
+	return session.changes
+}
+
+// Close unregisters the session. The operator's presence is left to time
+// out, so a quick reconnect does not change their status. Safe to call more
+// than once.
+func (session *PresenceSession) Close() {
+	session.once.Do(func() {
+		session.service.mu.Lock()
+		delete(session.service.sessions, session.ID)
+		session.service.mu.Unlock()
+		close(session.changes)
+	})
+}
+
+func (session *PresenceSession) wants(change *domain.PresenceChange) bool {
+	if session.TenantID != change.TenantID || session.OperatorID == change.OperatorID {
+		return false
+	}
+	for _, id := range change.InboxIDs {
+		if _, ok := session.inboxIDs[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Connect records the operator's presence and registers a session for
+// their teammates' status changes. Inbox subscriptions changed afterwards
+// apply on the next connection.
+func (s *PresenceService) Connect(ctx context.Context, tenantID, operatorID uuid.UUID) (*PresenceSession, error) {
+	if _, err := s.Touch(ctx, tenantID, operatorID); err != nil {
+		return nil, err
+	}
+	inboxIDs, err := s.repos.Subscriptions.GetSubscribedInboxIDs(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.register(tenantID, operatorID, inboxIDs), nil
+}
+
+func (s *PresenceService) register(tenantID, operatorID uuid.UUID, inboxIDs []uuid.UUID) *PresenceSession {
+	session := &PresenceSession{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		OperatorID: operatorID,
+		inboxIDs:   make(map[uuid.UUID]struct{}, len(inboxIDs)),
+		changes:    make(chan *domain.PresenceChange, s.config.BufferSize),
+		service:    s,
+	}
+	for _, id := range inboxIDs {
+		session.inboxIDs[id] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.sessions[session.ID] = session
+	s.mu.Unlock()
+
+	return session
+}
+
+// Touch refreshes the operator's presence on a heartbeat. It reports true
+// when the presence had timed out meanwhile, in which case the operator was
+// set OFFLINE and the caller should restore their status.
+func (s *PresenceService) Touch(ctx context.Context, tenantID, operatorID uuid.UUID) (bool, error) {
+	now := time.Now().UTC()
+	return s.repos.OperatorPresence.Touch(ctx, &domain.OperatorPresence{
+		OperatorID:  operatorID,
+		TenantID:    tenantID,
+		ConnectedAt: now,
+		LastSeenAt:  now,
+	})
+}
+
+// Teammates returns the status of every operator sharing an inbox with the
+// session's operator
+func (s *PresenceService) Teammates(ctx context.Context, session *PresenceSession) ([]domain.TeammatePresence, error) {
+	teammates := make(map[uuid.UUID]struct{})
+	for inboxID := range session.inboxIDs {
+		subscriptions, err := s.repos.Subscriptions.GetByInboxID(ctx, inboxID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subscriptions {
+			if sub.OperatorID != session.OperatorID {
+				teammates[sub.OperatorID] = struct{}{}
+			}
+		}
+	}
+	if len(teammates) == 0 {
+		return []domain.TeammatePresence{}, nil
+	}
+
+	statuses, err := s.repos.OperatorStatus.GetByTenantID(ctx, session.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	present, err := s.repos.OperatorPresence.GetByTenantID(ctx, session.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	connected := make(map[uuid.UUID]bool, len(present))
+	for _, p := range present {
+		connected[p.OperatorID] = true
+	}
+
+	result := make([]domain.TeammatePresence, 0, len(teammates))
+	for _, status := range statuses {
+		if _, ok := teammates[status.OperatorID]; !ok {
+			continue
+		}
+		delete(teammates, status.OperatorID)
+		result = append(result, domain.TeammatePresence{
+			OperatorID: status.OperatorID,
+			Status:     status.Status,
+			Connected:  connected[status.OperatorID],
+		})
+	}
+	// Operators who never set a status are OFFLINE
+	for operatorID := range teammates {
+		result = append(result, domain.TeammatePresence{
+			OperatorID: operatorID,
+			Status:     domain.OperatorStatusOffline,
+			Connected:  connected[operatorID],
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func presencePayload(t *testing.T, tenantID, operatorID uuid.UUID, inboxIDs ...uuid.UUID) string {
+	t.Helper()
+	previous := domain.OperatorStatusAvailable
+	payload, err := json.Marshal(domain.PresenceChange{
+		TenantID:       tenantID,
+		OperatorID:     operatorID,
+		InboxIDs:       inboxIDs,
+		Status:         domain.OperatorStatusOffline,
+		PreviousStatus: &previous,
+		OccurredAt:     time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	return string(payload)
+}
+
+func TestPresenceService_Dispatch(t *testing.T) {
+	svc := NewPresenceService(nil, nil, PresenceConfig{BufferSize: 1}, logger.NewNop())
+	tenantID := uuid.New()
+	inboxID := uuid.New()
+	operatorID := uuid.New()
+	teammateID := uuid.New()
+
+	session := svc.register(tenantID, operatorID, []uuid.UUID{inboxID})
+	defer session.Close()
+
+	t.Run("delivers teammate changes in a shared inbox", func(t *testing.T) {
+		svc.dispatch(presencePayload(t, tenantID, teammateID, uuid.New(), inboxID))
+
+		select {
+		case change := <-session.Changes():
+			assert.Equal(t, teammateID, change.OperatorID)
+			assert.Equal(t, domain.OperatorStatusOffline, change.Status)
+			require.NotNil(t, change.PreviousStatus)
+			assert.Equal(t, domain.OperatorStatusAvailable, *change.PreviousStatus)
+		default:
+			t.Fatal("expected change")
+		}
+	})
+
+	t.Run("skips own changes, other inboxes and tenants", func(t *testing.T) {
+		svc.dispatch(presencePayload(t, tenantID, operatorID, inboxID))
+		svc.dispatch(presencePayload(t, tenantID, teammateID, uuid.New()))
+		svc.dispatch(presencePayload(t, uuid.New(), teammateID, inboxID))
+		svc.dispatch("not json")
+
+		assert.Len(t, session.Changes(), 0)
+	})
+
+	t.Run("drops changes when buffer is full", func(t *testing.T) {
+		svc.dispatch(presencePayload(t, tenantID, teammateID, inboxID))
+		svc.dispatch(presencePayload(t, tenantID, uuid.New(), inboxID))
+
+		assert.Len(t, session.Changes(), 1)
+		<-session.Changes()
+	})
+
+	t.Run("closed sessions are unregistered", func(t *testing.T) {
+		closed := svc.register(tenantID, uuid.New(), []uuid.UUID{inboxID})
+		closed.Close()
+		closed.Close()
+
+		svc.mu.RLock()
+		defer svc.mu.RUnlock()
+		assert.NotContains(t, svc.sessions, closed.ID)
+	})
+}
+
+func TestPresenceService_PublishIgnoresOtherEvents(t *testing.T) {
+	svc := NewPresenceService(nil, nil, PresenceConfig{}, logger.NewNop())
+
+	event := domain.NewEvent(uuid.New(), domain.EventConversationAllocated, map[string]interface{}{
+		"operator_id": uuid.New().String(),
+	})
+	assert.NoError(t, svc.Publish(context.Background(), event))
+}
+
+func TestNewPresenceService_Defaults(t *testing.T) {
+	svc := NewPresenceService(nil, nil, PresenceConfig{}, logger.NewNop())
+
+	assert.Equal(t, domain.DefaultPresencePolicy(), svc.Policy())
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/push"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDeviceNotFound = errors.New("device not found")
+	ErrTooManyDevices = errors.New("operator has too many devices")
+)
+
+var (
+	pushSent     = metrics.NewCounter("push_notifications_sent_total")
+	pushFailures = metrics.NewCounter("push_notification_failures_total")
+	pushDropped  = metrics.NewCounter("push_notifications_dropped_total")
+)
+
+// PushConfig holds configuration for push notification delivery
+type PushConfig struct {
+	// QueueSize bounds the notifications waiting to be sent; more are dropped
+	QueueSize int
+	// Workers is how many notifications are sent concurrently
+	Workers int
+}
+
+// DefaultPushConfig returns sensible defaults
+func DefaultPushConfig() PushConfig {
+	return PushConfig{
+		QueueSize: 1024,
+		Workers:   4,
+	}
+}
+
+type pushJob struct {
+	device       *domain.OperatorDevice
+	notification push.Notification
+}
+
+// PushNotificationService registers operators' mobile devices and notifies
+// them of their assignments. As an event sink it looks up the assigned
+// operator's devices and queues one notification per device that wants the
+// event; Run sends them, so a slow push service never delays the operation
+// producing the event. Delivery is best-effort: a full queue drops
+// notifications and failed sends are not retried. Devices whose token the
+// push service rejects are forgotten.
+type PushNotificationService struct {
+	repos  *repository.RepositoryContainer
+	sender push.Sender
+	config PushConfig
+	queue  chan pushJob
+	logger *logger.Logger
+}
+
+// NewPushNotificationService creates the service; a nil sender disables
+// delivery while devices can still be registered
+func NewPushNotificationService(repos *repository.RepositoryContainer, sender push.Sender, config PushConfig, log *logger.Logger) *PushNotificationService {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultPushConfig().QueueSize
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultPushConfig().Workers
+	}
+	return &PushNotificationService{
+		repos:  repos,
+		sender: sender,
+		config: config,
+		queue:  make(chan pushJob, config.QueueSize),
+		logger: log,
+	}
+}
+
+// Enabled reports whether notifications are delivered
+func (s *PushNotificationService) Enabled() bool {
+	return s.sender != nil
+}
+
+// ==================== Devices ====================
+
+// RegisterDevice registers the operator's device, or updates it when the
+// token is already registered. A token registered by another operator moves
+// to this one.
+func (s *PushNotificationService) RegisterDevice(ctx context.Context, tenantID, operatorID uuid.UUID, platform domain.DevicePlatform, token string, eventTypes []domain.EventType) (*domain.OperatorDevice, error) {
+	devices, err := s.repos.OperatorDevices.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	registered := false
+	for _, device := range devices {
+		if device.Token == token {
+			registered = true
+			break
+		}
+	}
+	if !registered && len(devices) >= domain.MaxDevicesPerOperator {
+		return nil, ErrTooManyDevices
+	}
+
+	device, err := s.repos.OperatorDevices.Upsert(ctx,
+		domain.NewOperatorDevice(tenantID, operatorID, platform, token, eventTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Operator device registered",
+		zap.String("device_id", device.ID.String()),
+		zap.String("operator_id", operatorID.String()),
+		zap.String("platform", string(platform)))
+
+	return device, nil
+}
+
+func (s *PushNotificationService) ListDevices(ctx context.Context, operatorID uuid.UUID) ([]*domain.OperatorDevice, error) {
+	return s.repos.OperatorDevices.GetByOperatorID(ctx, operatorID)
+}
+
+// UpdateDevicePreferences replaces the push events the device is notified of
+func (s *PushNotificationService) UpdateDevicePreferences(ctx context.Context, operatorID, deviceID uuid.UUID, eventTypes []domain.EventType) (*domain.OperatorDevice, error) {
+	device, err := s.getDevice(ctx, operatorID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	device.EventTypes = eventTypes
+	device.UpdatedAt = time.Now().UTC()
+	if err := s.repos.OperatorDevices.UpdateEventTypes(ctx, device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func (s *PushNotificationService) DeleteDevice(ctx context.Context, operatorID, deviceID uuid.UUID) error {
+	if _, err := s.getDevice(ctx, operatorID, deviceID); err != nil {
+		return err
+	}
+	if err := s.repos.OperatorDevices.Delete(ctx, deviceID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Operator device removed",
+		zap.String("device_id", deviceID.String()),
+		zap.String("operator_id", operatorID.String()))
+	return nil
+}
+
+// getDevice returns the operator's device; other operators' devices are not found
+func (s *PushNotificationService) getDevice(ctx context.Context, operatorID, deviceID uuid.UUID) (*domain.OperatorDevice, error) {
+	device, err := s.repos.OperatorDevices.GetByID(ctx, deviceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrDeviceNotFound
+		}
+		return nil, err
+	}
+	if device.OperatorID != operatorID {
+		return nil, ErrDeviceNotFound
+	}
+	return device, nil
+}
+
+// ==================== Publishing ====================
+
+// Publish implements domain.EventPublisher by queueing a notification for
+// each device of the assigned operator that wants the event
+func (s *PushNotificationService) Publish(ctx context.Context, event *domain.Event) error {
+	if s.sender == nil || !domain.IsPushEvent(event.Type) {
+		return nil
+	}
+
+	raw, _ := event.Data["assigned_operator_id"].(string)
+	operatorID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	devices, err := s.repos.OperatorDevices.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+
+	notification := pushNotification(event)
+	for _, device := range devices {
+		if !device.Wants(event.Type) {
+			continue
+		}
+		select {
+		case s.queue <- pushJob{device: device, notification: notification}:
+		default:
+			pushDropped.Inc()
+			s.logger.Warn("Push notification queue is full, dropping notification",
+				zap.String("event_id", event.ID.String()),
+				zap.String("device_id", device.ID.String()))
+		}
+	}
+	return nil
+}
+
+// pushNotification builds the notification of an assignment event. It names
+// the conversation by its external ID only; customer details stay off the
+// push services.
+func pushNotification(event *domain.Event) push.Notification {
+	conversationID, _ := event.Data["conversation_id"].(string)
+	externalID, _ := event.Data["external_conversation_id"].(string)
+	inboxID, _ := event.Data["inbox_id"].(string)
+
+	title := "New conversation"
+	if event.Type == domain.EventConversationReassigned {
+		title = "Conversation reassigned to you"
+	}
+
+	return push.Notification{
+		Title: title,
+		Body:  "Conversation " + externalID + " is assigned to you",
+		Data: map[string]string{
+			"event_id":        event.ID.String(),
+			"event_type":      string(event.Type),
+			"conversation_id": conversationID,
+			"inbox_id":        inboxID,
+		},
+		CollapseKey: conversationID,
+	}
+}
+
+// ==================== Delivery ====================
+
+// Run sends queued notifications until the context is cancelled
+func (s *PushNotificationService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < s.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.queue:
+					s.send(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *PushNotificationService) send(ctx context.Context, job pushJob) {
+	err := s.sender.Send(ctx, string(job.device.Platform), job.device.Token, job.notification)
+	switch {
+	case err == nil:
+		pushSent.Inc()
+	case errors.Is(err, push.ErrInvalidToken):
+		pushFailures.Inc()
+		s.logger.Info("Push token rejected, removing device",
+			zap.String("device_id", job.device.ID.String()),
+			zap.String("operator_id", job.device.OperatorID.String()))
+		if err := s.repos.OperatorDevices.DeleteByToken(ctx, job.device.Token); err != nil {
+			s.logger.Warn("Failed to remove device with rejected push token",
+				zap.String("device_id", job.device.ID.String()),
+				zap.Error(err))
+		}
+	default:
+		pushFailures.Inc()
+		s.logger.Warn("Failed to send push notification",
+			zap.String("device_id", job.device.ID.String()),
+			zap.String("platform", string(job.device.Platform)),
+			zap.Error(err))
+	}
+}
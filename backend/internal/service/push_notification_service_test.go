@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushNotification(t *testing.T) {
+	conv := &domain.ConversationRef{
+		ID:                     uuid.New(),
+		InboxID:                uuid.New(),
+		ExternalConversationID: "ext-42",
+		CustomerPhoneNumber:    "+15551234567",
+		State:                  domain.ConversationStateAllocated,
+	}
+	operatorID := uuid.New()
+	conv.AssignedOperatorID = &operatorID
+
+	event := domain.NewEvent(uuid.New(), domain.EventConversationReassigned, conversationEventData(conv))
+	n := pushNotification(event)
+
+	assert.Equal(t, "Conversation reassigned to you", n.Title)
+	assert.Contains(t, n.Body, "ext-42")
+	assert.Equal(t, conv.ID.String(), n.Data["conversation_id"])
+	assert.Equal(t, string(domain.EventConversationReassigned), n.Data["event_type"])
+	assert.Equal(t, conv.ID.String(), n.CollapseKey)
+	for _, v := range n.Data {
+		assert.NotContains(t, v, conv.CustomerPhoneNumber)
+	}
+	assert.NotContains(t, n.Body, conv.CustomerPhoneNumber)
+}
+
+func TestPushNotificationService_PublishWithoutSender(t *testing.T) {
+	svc := NewPushNotificationService(nil, nil, DefaultPushConfig(), logger.NewNop())
+	require.False(t, svc.Enabled())
+
+	event := domain.NewEvent(uuid.New(), domain.EventConversationAllocated, map[string]interface{}{
+		"assigned_operator_id": uuid.NewString(),
+	})
+	assert.NoError(t, svc.Publish(context.Background(), event))
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrQANotReviewer              = errors.New("operator is not a QA reviewer")
+	ErrQAQueueEmpty               = errors.New("no conversations awaiting review")
+	ErrQAItemNotFound             = errors.New("review item not found")
+	ErrQAItemNotClaimed           = errors.New("review item is not claimed by this reviewer")
+	ErrQAReviewerOperatorNotFound = errors.New("reviewer operator not found")
+)
+
+// QAConfig holds configuration for conversation quality sampling
+type QAConfig struct {
+	// SampleRate is the fraction (0..1) of resolved conversations queued for review
+	SampleRate float64
+	// ClaimTimeout is how long a reviewer holds an item before it returns to the queue
+	ClaimTimeout time.Duration
+}
+
+// DefaultQAConfig returns sensible defaults
+func DefaultQAConfig() QAConfig {
+	return QAConfig{
+		SampleRate:   0.05,
+		ClaimTimeout: 30 * time.Minute,
+	}
+}
+
+// QAService samples resolved conversations into a review queue and records
+// reviewer scores against domain.QARubric. Completed reviews feed the
+// per-operator performance reports.
+type QAService struct {
+	repos  *repository.RepositoryContainer
+	config QAConfig
+	logger *logger.Logger
+
+	// sample returns a number in [0, 1); replaced in tests
+	sample func() float64
+}
+
+func NewQAService(repos *repository.RepositoryContainer, config QAConfig, log *logger.Logger) *QAService {
+	if config.ClaimTimeout <= 0 {
+		config.ClaimTimeout = DefaultQAConfig().ClaimTimeout
+	}
+	return &QAService{
+		repos:  repos,
+		config: config,
+		logger: log,
+		sample: rand.Float64,
+	}
+}
+
+// ==================== Sampling ====================
+
+// Publish implements domain.EventPublisher. Resolved conversations are
+// queued for review with probability SampleRate.
+func (s *QAService) Publish(ctx context.Context, event *domain.Event) error {
+	if event.Type != domain.EventConversationResolved {
+		return nil
+	}
+	if s.config.SampleRate <= 0 || s.sample() >= s.config.SampleRate {
+		return nil
+	}
+
+	conversationID := eventUUID(event, "conversation_id")
+	if conversationID == uuid.Nil {
+		return nil
+	}
+	var operatorID *uuid.UUID
+	if id := eventUUID(event, "assigned_operator_id"); id != uuid.Nil {
+		operatorID = &id
+	}
+
+	item := domain.NewQAReviewItem(event.TenantID, conversationID, operatorID)
+	if err := s.repos.QAReviewItems.Create(ctx, item); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Conversation sampled for QA review",
+		zap.String("item_id", item.ID.String()),
+		zap.String("conversation_id", conversationID.String()))
+
+	return nil
+}
+
+// ==================== Review ====================
+
+// NextItem returns the item the reviewer is working on, or leases the oldest
+// open item to them. Reviewers never receive their own conversations.
+// Permission: QA reviewer
+func (s *QAService) NextItem(ctx context.Context, tenantID, reviewerID uuid.UUID) (*domain.QAReviewItem, error) {
+	if err := s.requireReviewer(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
+	active, err := s.repos.QAReviewItems.GetActiveForReviewer(ctx, reviewerID)
+	if err == nil {
+		return active, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	claimedUntil := time.Now().UTC().Add(s.config.ClaimTimeout)
+	item, err := s.repos.QAReviewItems.ClaimNext(ctx, tenantID, reviewerID, claimedUntil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrQAQueueEmpty
+		}
+		return nil, err
+	}
+
+	s.logger.Info("QA review item claimed",
+		zap.String("item_id", item.ID.String()),
+		zap.String("reviewer_id", reviewerID.String()))
+
+	return item, nil
+}
+
+// SubmitReview scores an item the reviewer has claimed
+// Permission: QA reviewer holding the item
+func (s *QAService) SubmitReview(ctx context.Context, tenantID, reviewerID, itemID uuid.UUID, scores map[string]int, comment *string) (*domain.QAReviewItem, error) {
+	if err := s.requireReviewer(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.repos.QAReviewItems.GetByID(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrQAItemNotFound
+		}
+		return nil, err
+	}
+	if item.TenantID != tenantID {
+		return nil, ErrQAItemNotFound
+	}
+	if item.ReviewerID == nil || *item.ReviewerID != reviewerID {
+		return nil, ErrQAItemNotClaimed
+	}
+
+	if err := item.Complete(scores, comment); err != nil {
+		if errors.Is(err, domain.ErrInvalidStateTransition) {
+			return nil, ErrQAItemNotClaimed
+		}
+		return nil, err
+	}
+
+	// The update is conditional on the reviewer still holding the item
+	ok, err := s.repos.QAReviewItems.Complete(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrQAItemNotClaimed
+	}
+
+	s.logger.Info("QA review submitted",
+		zap.String("item_id", item.ID.String()),
+		zap.String("reviewer_id", reviewerID.String()),
+		zap.String("overall_score", item.OverallScore.String()))
+
+	return item, nil
+}
+
+// OperatorReports aggregates reviews completed in [from, to) per operator
+// Permission: Manager, Admin (enforced by router)
+func (s *QAService) OperatorReports(ctx context.Context, tenantID uuid.UUID, from, to time.Time) ([]*domain.QAOperatorReport, error) {
+	items, err := s.repos.QAReviewItems.GetCompleted(ctx, tenantID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildQAOperatorReports(items), nil
+}
+
+func (s *QAService) requireReviewer(ctx context.Context, operatorID uuid.UUID) error {
+	isReviewer, err := s.repos.QAReviewers.Exists(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	if !isReviewer {
+		return ErrQANotReviewer
+	}
+	return nil
+}
+
+// ==================== Reviewer Management ====================
+
+// GrantReviewer gives the operator the QA reviewer permission. Granting twice is a no-op.
+// Permission: Admin (enforced by router)
+func (s *QAService) GrantReviewer(ctx context.Context, tenantID, operatorID uuid.UUID, grantedBy *uuid.UUID) (*domain.QAReviewer, error) {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrQAReviewerOperatorNotFound
+		}
+		return nil, err
+	}
+	if operator.TenantID != tenantID {
+		return nil, ErrQAReviewerOperatorNotFound
+	}
+
+	reviewer := domain.NewQAReviewer(tenantID, operatorID, grantedBy)
+	if err := s.repos.QAReviewers.Create(ctx, reviewer); err != nil && !errors.Is(err, domain.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	s.logger.Info("QA reviewer granted",
+		zap.String("operator_id", operatorID.String()),
+		zap.String("tenant_id", tenantID.String()))
+
+	return reviewer, nil
+}
+
+// ListReviewers returns the tenant's QA reviewers
+// Permission: Admin (enforced by router)
+func (s *QAService) ListReviewers(ctx context.Context, tenantID uuid.UUID) ([]*domain.QAReviewer, error) {
+	return s.repos.QAReviewers.GetByTenantID(ctx, tenantID)
+}
+
+// RevokeReviewer removes the QA reviewer permission. Items the operator has
+// claimed return to the queue when their lease expires.
+// Permission: Admin (enforced by router)
+func (s *QAService) RevokeReviewer(ctx context.Context, tenantID, operatorID uuid.UUID) error {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrQAReviewerOperatorNotFound
+		}
+		return err
+	}
+	if operator.TenantID != tenantID {
+		return ErrQAReviewerOperatorNotFound
+	}
+
+	if err := s.repos.QAReviewers.Delete(ctx, operatorID); err != nil {
+		return err
+	}
+
+	s.logger.Info("QA reviewer revoked", zap.String("operator_id", operatorID.String()))
+	return nil
+}
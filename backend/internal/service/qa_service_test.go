@@ -0,0 +1,49 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// The service has no repositories: any attempt to enqueue would panic
+func TestQAService_PublishSkipsUnsampledEvents(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	resolved := domain.NewEvent(uuid.New(), domain.EventConversationResolved, map[string]interface{}{
+		"conversation_id": uuid.New().String(),
+	})
+
+	t.Run("ignores non-resolved events", func(t *testing.T) {
+		svc := NewQAService(nil, QAConfig{SampleRate: 1}, logger.NewNop())
+		event := domain.NewEvent(uuid.New(), domain.EventConversationAllocated, resolved.Data)
+		assert.NoError(t, svc.Publish(ctx, event))
+	})
+
+	t.Run("zero rate disables sampling", func(t *testing.T) {
+		svc := NewQAService(nil, QAConfig{SampleRate: 0}, logger.NewNop())
+		svc.sample = func() float64 { return 0 }
+		assert.NoError(t, svc.Publish(ctx, resolved))
+	})
+
+	t.Run("draw above rate is skipped", func(t *testing.T) {
+		svc := NewQAService(nil, QAConfig{SampleRate: 0.1}, logger.NewNop())
+		svc.sample = func() float64 { return 0.1 }
+		assert.NoError(t, svc.Publish(ctx, resolved))
+	})
+
+	t.Run("event without conversation is skipped", func(t *testing.T) {
+		svc := NewQAService(nil, QAConfig{SampleRate: 1}, logger.NewNop())
+		svc.sample = func() float64 { return 0 }
+		event := domain.NewEvent(uuid.New(), domain.EventConversationResolved, map[string]interface{}{})
+		assert.NoError(t, svc.Publish(ctx, event))
+	})
+}
+
+func TestNewQAService_DefaultsClaimTimeout(t *testing.T) {
+	svc := NewQAService(nil, QAConfig{SampleRate: 0.2}, logger.NewNop())
+	assert.Equal(t, DefaultQAConfig().ClaimTimeout, svc.config.ClaimTimeout)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	queueRankRefreshes     = metrics.NewCounter("queue_rank_refreshes_total")
+	queueRankRefreshErrors = metrics.NewCounter("queue_rank_refresh_errors_total")
+	queueRankStaleInboxes  = metrics.NewGauge("queue_rank_stale_inboxes")
+	queueRankReadThroughs  = metrics.NewCounter("queue_rank_read_through_refreshes_total")
+)
+
+// QueueRankingService maintains the materialized per-inbox queue order
+// (inbox_queue_ranks) used by read paths. Changes to a conversation mark its
+// inbox stale; stale inboxes are re-ranked by the queue ranking worker, which
+// also re-ranks every inbox periodically to pick up changes made by other
+// instances or without an event. Allocation never reads the ranks.
+type QueueRankingService struct {
+	repos  *repository.RepositoryContainer
+	logger *logger.Logger
+
+	mu    sync.Mutex
+	stale map[uuid.UUID]struct{}
+}
+
+func NewQueueRankingService(repos *repository.RepositoryContainer, log *logger.Logger) *QueueRankingService {
+	return &QueueRankingService{
+		repos:  repos,
+		logger: log,
+		stale:  make(map[uuid.UUID]struct{}),
+	}
+}
+
+// Publish implements domain.EventPublisher: every conversation event but
+// label changes marks the conversation's inbox stale
+func (s *QueueRankingService) Publish(ctx context.Context, event *domain.Event) error {
+	if !strings.HasPrefix(string(event.Type), "conversation.") || event.Type.IsLabelChange() {
+		return nil
+	}
+	raw, _ := event.Data["inbox_id"].(string)
+	if inboxID, err := uuid.Parse(raw); err == nil {
+		s.MarkStale(inboxID)
+	}
+	return nil
+}
+
+// MarkStale schedules the inbox for re-ranking
+func (s *QueueRankingService) MarkStale(inboxID uuid.UUID) {
+	s.mu.Lock()
+	s.stale[inboxID] = struct{}{}
+	queueRankStaleInboxes.Set(int64(len(s.stale)))
+	s.mu.Unlock()
+}
+
+// RefreshStale re-ranks the inboxes marked stale since the last call. An
+// inbox that fails stays marked for the next call.
+func (s *QueueRankingService) RefreshStale(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	inboxIDs := make([]uuid.UUID, 0, len(s.stale))
+	for id := range s.stale {
+		inboxIDs = append(inboxIDs, id)
+	}
+	s.stale = make(map[uuid.UUID]struct{})
+	queueRankStaleInboxes.Set(0)
+	s.mu.Unlock()
+
+	return s.refreshInboxes(ctx, inboxIDs)
+}
+
+// RefreshAll re-ranks every inbox that has a queue or stale ranks
+func (s *QueueRankingService) RefreshAll(ctx context.Context) (int, error) {
+	inboxIDs, err := s.repos.QueueRanks.GetInboxIDsToRank(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.refreshInboxes(ctx, inboxIDs)
+}
+
+func (s *QueueRankingService) refreshInboxes(ctx context.Context, inboxIDs []uuid.UUID) (int, error) {
+	var (
+		refreshed int
+		errs      []error
+	)
+	for _, inboxID := range inboxIDs {
+		if err := s.refresh(ctx, inboxID); err != nil {
+			s.MarkStale(inboxID)
+			errs = append(errs, err)
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, errors.Join(errs...)
+}
+
+func (s *QueueRankingService) refresh(ctx context.Context, inboxID uuid.UUID) error {
+	ranked, err := s.repos.QueueRanks.RefreshInbox(ctx, inboxID)
+	if err != nil {
+		queueRankRefreshErrors.Inc()
+		s.logger.Warn("Failed to refresh inbox queue ranks",
+			zap.String("inbox_id", inboxID.String()),
+			zap.Error(err))
+		return err
+	}
+	queueRankRefreshes.Inc()
+	s.logger.Debug("Inbox queue ranks refreshed",
+		zap.String("inbox_id", inboxID.String()),
+		zap.Int64("ranked", ranked))
+	return nil
+}
+
+// QueuePosition returns the position of a QUEUED conversation in its inbox.
+// A conversation not ranked yet (or ranked under another inbox) has its inbox
+// re-ranked first.
+func (s *QueueRankingService) QueuePosition(ctx context.Context, conv *domain.ConversationRef) (*domain.QueuePosition, error) {
+	if conv.State != domain.ConversationStateQueued {
+		return nil, ErrConversationNotQueued
+	}
+
+	rank, err := s.repos.QueueRanks.GetByConversationID(ctx, conv.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if rank == nil || rank.InboxID != conv.InboxID {
+		queueRankReadThroughs.Inc()
+		if err := s.refresh(ctx, conv.InboxID); err != nil {
+			return nil, err
+		}
+		if rank, err = s.repos.QueueRanks.GetByConversationID(ctx, conv.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				// Left the queue since it was read
+				return nil, ErrConversationNotQueued
+			}
+			return nil, err
+		}
+	}
+
+	length, err := s.repos.QueueRanks.CountByInbox(ctx, conv.InboxID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QueuePosition{QueueRank: *rank, QueueLength: length}, nil
+}
+
+// InboxQueue returns the first limit conversations of an inbox's queue and
+// the queue's length
+// Permission: Manager+ (enforced by router)
+func (s *QueueRankingService) InboxQueue(ctx context.Context, tenantID, inboxID uuid.UUID, limit int) ([]*domain.QueueRank, int, error) {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if inbox.TenantID != tenantID {
+		return nil, 0, domain.ErrNotFound
+	}
+
+	ranks, err := s.repos.QueueRanks.ListByInbox(ctx, inboxID, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	length, err := s.repos.QueueRanks.CountByInbox(ctx, inboxID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ranks, length, nil
+}
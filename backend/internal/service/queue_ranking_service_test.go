@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueRankingService_PublishMarksInboxStale(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	svc := NewQueueRankingService(nil, logger.NewNop())
+	inboxID := uuid.New()
+
+	event := domain.NewEvent(uuid.New(), domain.EventConversationResolved, map[string]interface{}{
+		"conversation_id": uuid.New().String(),
+		"inbox_id":        inboxID.String(),
+	})
+	require.NoError(t, svc.Publish(ctx, event))
+
+	status := domain.NewEvent(uuid.New(), domain.EventOperatorStatusChanged, map[string]interface{}{
+		"inbox_id": uuid.New().String(),
+	})
+	require.NoError(t, svc.Publish(ctx, status))
+
+	label := domain.NewEvent(uuid.New(), domain.EventConversationLabeled, map[string]interface{}{
+		"conversation_id": uuid.New().String(),
+		"inbox_id":        uuid.New().String(),
+	})
+	require.NoError(t, svc.Publish(ctx, label))
+
+	assert.Equal(t, map[uuid.UUID]struct{}{inboxID: {}}, svc.stale)
+}
+
+func TestQueueRankingService_QueuePositionRequiresQueued(t *testing.T) {
+	svc := NewQueueRankingService(nil, logger.NewNop())
+	conv := &domain.ConversationRef{ID: uuid.New(), State: domain.ConversationStateAllocated}
+
+	// No repositories are configured; a QUEUED conversation would read ranks here
+	_, err := svc.QueuePosition(testutil.TestContext(t), conv)
+	assert.ErrorIs(t, err, ErrConversationNotQueued)
+}
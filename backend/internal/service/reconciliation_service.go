@@ -0,0 +1,420 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	reconciliationDivergencesTotal      = metrics.NewCounter("reconciliation_divergences_total")
+	reconciliationCorrectionsTotal      = metrics.NewCounter("reconciliation_corrections_total")
+	reconciliationUpstreamFailuresTotal = metrics.NewCounter("reconciliation_upstream_failures_total")
+)
+
+// maxUpstreamResponseBytes bounds the status response read for one batch
+const maxUpstreamResponseBytes = 1 << 20
+
+// ConversationSource is the upstream system of record for conversation
+// status. HTTPConversationSource calls a status endpoint; other backends plug
+// in through this interface.
+type ConversationSource interface {
+	// Statuses returns the upstream status of the tenant's conversations by
+	// external conversation ID; IDs upstream does not know are left out
+	Statuses(ctx context.Context, tenantID uuid.UUID, externalIDs []string) (map[string]domain.UpstreamStatus, error)
+}
+
+// ReconciliationConfig holds configuration for reconciliation
+type ReconciliationConfig struct {
+	// Lookback bounds the conversations compared to those updated within it;
+	// conversations already diverging are always compared
+	Lookback  time.Duration
+	BatchSize int
+	// Timeout bounds one upstream request
+	Timeout time.Duration
+	// AutoCorrect lists the divergence kinds corrected without a person
+	AutoCorrect []domain.DivergenceKind
+	// CorrectAfter is how long a divergence must persist before it is
+	// corrected, so a change still on its way from upstream is not undone
+	CorrectAfter time.Duration
+}
+
+// DefaultReconciliationConfig returns sensible defaults
+func DefaultReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		Lookback:     24 * time.Hour,
+		BatchSize:    100,
+		Timeout:      10 * time.Second,
+		CorrectAfter: 15 * time.Minute,
+	}
+}
+
+// ReconcileResult is the outcome of reconciling one tenant
+type ReconcileResult struct {
+	TenantID  uuid.UUID
+	Checked   int
+	Divergent int
+	Corrected int
+}
+
+// ReconciliationService compares conversation states with the upstream
+// system of record. Divergences are kept in reconciliation_divergences until
+// a later run finds the conversation consistent again; the admin report
+// lists them.
+//
+// Kinds listed in AutoCorrect are corrected once they have persisted for
+// CorrectAfter: a resolved conversation open upstream is reopened, an
+// allocated one closed upstream is resolved. Each correction locks the
+// conversation and re-checks its state in its own transaction, so one racing
+// an operator is a no-op.
+type ReconciliationService struct {
+	repos       *repository.RepositoryContainer
+	pool        *pgxpool.Pool
+	source      ConversationSource
+	maintenance *MaintenanceService
+	config      ReconciliationConfig
+	events      domain.EventPublisher
+	audit       *AuditService
+	logger      *logger.Logger
+}
+
+func NewReconciliationService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, source ConversationSource, maintenance *MaintenanceService, config ReconciliationConfig, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *ReconciliationService {
+	defaults := DefaultReconciliationConfig()
+	if config.Lookback <= 0 {
+		config.Lookback = defaults.Lookback
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.CorrectAfter < 0 {
+		config.CorrectAfter = defaults.CorrectAfter
+	}
+	return &ReconciliationService{
+		repos:       repos,
+		pool:        pool,
+		source:      source,
+		maintenance: maintenance,
+		config:      config,
+		events:      events,
+		audit:       audit,
+		logger:      log,
+	}
+}
+
+// Report returns the tenant's divergences of the kind (empty for all)
+// Permission: Admin (enforced by router)
+func (s *ReconciliationService) Report(ctx context.Context, tenantID uuid.UUID, kind domain.DivergenceKind) (*domain.ReconciliationReport, error) {
+	report := domain.NewReconciliationReport(tenantID)
+
+	counts, err := s.repos.Reconciliation.Count(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for k, n := range counts {
+		report.Counts[k] = n
+	}
+
+	divergences, err := s.repos.Reconciliation.List(ctx, tenantID, kind, domain.MaxReconciliationDivergences)
+	if err != nil {
+		return nil, err
+	}
+	report.Divergences = divergences
+	return report, nil
+}
+
+// Reconcile compares the tenant's recently updated and already diverging
+// conversations with upstream, batch by batch, and applies the
+// auto-correction rules. An upstream failure stops the tenant's run; the
+// divergences recorded so far are kept.
+func (s *ReconciliationService) Reconcile(ctx context.Context, tenantID uuid.UUID) (*ReconcileResult, error) {
+	result := &ReconcileResult{TenantID: tenantID}
+	since := time.Now().UTC().Add(-s.config.Lookback)
+
+	afterID := uuid.Nil
+	for {
+		candidates, err := s.repos.Reconciliation.ListCandidates(ctx, tenantID, afterID, since, s.config.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(candidates) == 0 {
+			return result, nil
+		}
+
+		if err := s.reconcileBatch(ctx, tenantID, candidates, result); err != nil {
+			return result, err
+		}
+
+		if len(candidates) < s.config.BatchSize {
+			return result, nil
+		}
+		afterID = candidates[len(candidates)-1].ConversationID
+	}
+}
+
+// ReconcileAll reconciles every tenant whose maintenance window is open, see
+// Reconcile. A tenant whose run fails does not stop the others; the first
+// error is returned with the other results.
+func (s *ReconciliationService) ReconcileAll(ctx context.Context) ([]*ReconcileResult, error) {
+	tenants, err := s.repos.Tenants.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	results := make([]*ReconcileResult, 0, len(tenants))
+	for _, tenant := range tenants {
+		if !s.maintenance.AllowTenant(ctx, domain.MaintenanceJobReconciliation, tenant.ID) {
+			continue
+		}
+		result, err := s.Reconcile(ctx, tenant.ID)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("tenant %s: %w", tenant.ID, err)
+		}
+		results = append(results, result)
+	}
+	return results, firstErr
+}
+
+// reconcileBatch compares one batch of candidates with upstream
+func (s *ReconciliationService) reconcileBatch(ctx context.Context, tenantID uuid.UUID, candidates []*domain.ReconciliationCandidate, result *ReconcileResult) error {
+	externalIDs := make([]string, len(candidates))
+	for i, c := range candidates {
+		externalIDs[i] = c.ExternalConversationID
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	statuses, err := s.source.Statuses(fetchCtx, tenantID, externalIDs)
+	cancel()
+	if err != nil {
+		reconciliationUpstreamFailuresTotal.Inc()
+		return fmt.Errorf("fetch upstream statuses: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var consistent []uuid.UUID
+	for _, c := range candidates {
+		result.Checked++
+
+		upstream, ok := statuses[c.ExternalConversationID]
+		if !ok {
+			upstream = domain.UpstreamStatusMissing
+		}
+		kind, diverges := domain.DetectDivergence(c.State, upstream)
+		if !diverges {
+			consistent = append(consistent, c.ConversationID)
+			continue
+		}
+
+		divergence, err := s.repos.Reconciliation.Upsert(ctx, &domain.ReconciliationDivergence{
+			ConversationID:         c.ConversationID,
+			TenantID:               tenantID,
+			ExternalConversationID: c.ExternalConversationID,
+			Kind:                   kind,
+			LocalState:             c.State,
+			UpstreamStatus:         upstream,
+			FirstDetectedAt:        now,
+		})
+		if err != nil {
+			return err
+		}
+		result.Divergent++
+		reconciliationDivergencesTotal.Inc()
+
+		if !s.autoCorrects(kind) || !divergence.DueForCorrection(now, s.config.CorrectAfter) {
+			continue
+		}
+		corrected, err := s.correct(ctx, divergence)
+		if err != nil {
+			s.logger.Error("Failed to correct reconciliation divergence",
+				zap.String("tenant_id", tenantID.String()),
+				zap.String("conversation_id", c.ConversationID.String()),
+				zap.String("divergence", string(kind)),
+				zap.Error(err))
+			continue
+		}
+		if corrected {
+			result.Corrected++
+			reconciliationCorrectionsTotal.Inc()
+		}
+	}
+
+	return s.repos.Reconciliation.DeleteByConversationIDs(ctx, consistent)
+}
+
+func (s *ReconciliationService) autoCorrects(kind domain.DivergenceKind) bool {
+	for _, k := range s.config.AutoCorrect {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// correct brings one conversation in line with upstream and reports whether
+// it was still in the state the divergence was detected in
+func (s *ReconciliationService) correct(ctx context.Context, d *domain.ReconciliationDivergence) (bool, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+	conversations := repos.ConversationRefs
+
+	conv, err := conversations.LockForUpdate(ctx, d.ConversationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if conv.TenantID != d.TenantID || conv.State != d.LocalState || !d.Kind.Correctable(conv.State) {
+		return false, nil
+	}
+
+	before := conversationAuditSnapshot(conv)
+	previousOperator := conv.AssignedOperatorID
+
+	var eventType domain.EventType
+	switch d.Kind {
+	case domain.DivergenceResolvedUpstreamOpen:
+		if err := conv.Reopen(); err != nil {
+			return false, err
+		}
+		eventType = domain.EventConversationReopened
+	case domain.DivergenceOpenUpstreamClosed:
+		if err := conv.Resolve(); err != nil {
+			return false, err
+		}
+		eventType = domain.EventConversationResolved
+		// A resolved conversation must not be released by its grace period
+		if err := repos.GracePeriodAssignments.DeleteByConversationID(ctx, conv.ID); err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+
+	if err := conversations.Update(ctx, conv); err != nil {
+		return false, err
+	}
+	if err := repos.Reconciliation.MarkCorrected(ctx, conv.ID, time.Now().UTC()); err != nil {
+		return false, err
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["reason"] = "reconciliation"
+	event := domain.NewEvent(d.TenantID, eventType, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Reconciliation divergence corrected",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("divergence", string(d.Kind)))
+
+	after := conversationAuditSnapshot(conv)
+	after["divergence"] = string(d.Kind)
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(d.TenantID, nil,
+		domain.AuditActionConversationReconcile, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	publishEvent(ctx, s.events, s.logger, event)
+	return true, nil
+}
+
+// ==================== HTTP Conversation Source ====================
+
+// HTTPConversationSource POSTs
+// {"tenant_id": "<uuid>", "external_conversation_ids": ["<id>", ...]} to the
+// status endpoint, which answers 2xx with
+// {"conversations": [{"external_conversation_id": "<id>", "status": "OPEN|CLOSED"}]}
+// and leaves out the IDs it does not know
+type HTTPConversationSource struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPConversationSource creates a source; token, if set, is sent as a
+// Bearer token. Timeouts come from the caller's context.
+func NewHTTPConversationSource(url, token string) *HTTPConversationSource {
+	return &HTTPConversationSource{url: url, token: token, client: &http.Client{}}
+}
+
+type upstreamStatusRequest struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	ExternalIDs []string  `json:"external_conversation_ids"`
+}
+
+type upstreamStatusResponse struct {
+	Conversations []struct {
+		ExternalConversationID string `json:"external_conversation_id"`
+		Status                 string `json:"status"`
+	} `json:"conversations"`
+}
+
+func (c *HTTPConversationSource) Statuses(ctx context.Context, tenantID uuid.UUID, externalIDs []string) (map[string]domain.UpstreamStatus, error) {
+	body, err := json.Marshal(upstreamStatusRequest{TenantID: tenantID, ExternalIDs: externalIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("upstream responded with status %d", resp.StatusCode)
+	}
+
+	var out upstreamStatusResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxUpstreamResponseBytes)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode upstream response: %w", err)
+	}
+
+	statuses := make(map[string]domain.UpstreamStatus, len(out.Conversations))
+	for _, conv := range out.Conversations {
+		switch status := domain.UpstreamStatus(conv.Status); status {
+		case domain.UpstreamStatusOpen, domain.UpstreamStatusClosed:
+			statuses[conv.ExternalConversationID] = status
+		default:
+			return nil, fmt.Errorf("upstream returned unknown status %q for %s", conv.Status, conv.ExternalConversationID)
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPConversationSource_Statuses(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	tenantID := uuid.New()
+
+	t.Run("returns known statuses with the token", func(t *testing.T) {
+		var got upstreamStatusRequest
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"conversations":[
+				{"external_conversation_id":"ext-1","status":"OPEN"},
+				{"external_conversation_id":"ext-2","status":"CLOSED"}]}`))
+		}))
+		defer server.Close()
+
+		statuses, err := NewHTTPConversationSource(server.URL, "secret").
+			Statuses(ctx, tenantID, []string{"ext-1", "ext-2", "ext-3"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]domain.UpstreamStatus{
+			"ext-1": domain.UpstreamStatusOpen,
+			"ext-2": domain.UpstreamStatusClosed,
+		}, statuses, "unknown IDs are left out")
+		assert.Equal(t, "Bearer secret", auth)
+		assert.Equal(t, tenantID, got.TenantID)
+		assert.Equal(t, []string{"ext-1", "ext-2", "ext-3"}, got.ExternalIDs)
+	})
+
+	t.Run("rejects unknown statuses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"conversations":[{"external_conversation_id":"ext-1","status":"PENDING"}]}`))
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPConversationSource(server.URL, "").Statuses(ctx, tenantID, []string{"ext-1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("fails on non-2xx", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPConversationSource(server.URL, "").Statuses(ctx, tenantID, []string{"ext-1"})
+		assert.Error(t, err)
+	})
+}
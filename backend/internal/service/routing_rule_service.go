@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRoutingRuleNotFound         = errors.New("routing rule not found")
+	ErrRoutingRuleInboxNotFound    = errors.New("routing rule inbox not found")
+	ErrRoutingRulePermissionDenied = errors.New("insufficient permissions for routing rule operation")
+)
+
+type RoutingRuleService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewRoutingRuleService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *RoutingRuleService {
+	return &RoutingRuleService{repos: repos, audit: audit, logger: log}
+}
+
+// ==================== Rule Management ====================
+
+type CreateRoutingRuleParams struct {
+	TenantID uuid.UUID
+	InboxID  *uuid.UUID
+	Name     string
+	Trigger  domain.RoutingRuleTrigger
+	Field    domain.RuleConditionField
+	Operator domain.RuleOperator
+	Value    int32
+	// Text is the normalized comparison value for text fields (category)
+	Text          *string
+	LabelName     *string
+	PriorityBoost decimal.Decimal
+	CreatedBy     *uuid.UUID
+	// Role of the caller; CreatedBy is checked for inbox admin grants
+	Role domain.OperatorRole
+}
+
+// CreateRule registers a routing rule for the tenant
+// Permission: Admin, or Inbox Admin for rules of their inbox
+func (s *RoutingRuleService) CreateRule(ctx context.Context, params CreateRoutingRuleParams) (*domain.RoutingRule, error) {
+	if err := s.checkManageRule(ctx, params.CreatedBy, params.Role, params.InboxID); err != nil {
+		return nil, err
+	}
+
+	if params.InboxID != nil {
+		inbox, err := s.repos.Inboxes.GetByID(ctx, *params.InboxID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, ErrRoutingRuleInboxNotFound
+			}
+			return nil, err
+		}
+		if inbox.TenantID != params.TenantID {
+			return nil, ErrRoutingRuleInboxNotFound
+		}
+	}
+
+	rule := domain.NewRoutingRule(
+		params.TenantID,
+		params.InboxID,
+		params.Name,
+		params.Trigger,
+		params.Field,
+		params.Operator,
+		params.Value,
+		params.LabelName,
+		params.PriorityBoost,
+		params.CreatedBy,
+	)
+	rule.ConditionText = params.Text
+
+	if err := s.repos.RoutingRules.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Routing rule created",
+		zap.String("rule_id", rule.ID.String()),
+		zap.String("tenant_id", rule.TenantID.String()),
+		zap.String("trigger", string(rule.Trigger)))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(rule.TenantID, params.CreatedBy,
+		domain.AuditActionRoutingRuleCreate, domain.AuditEntityRoutingRule, rule.ID,
+		nil, routingRuleAuditSnapshot(rule)))
+
+	return rule, nil
+}
+
+// ListRules returns the routing rules of the tenant the caller can manage:
+// all of them for admins, those of their inboxes for inbox admins
+func (s *RoutingRuleService) ListRules(ctx context.Context, tenantID uuid.UUID, actorID *uuid.UUID, role domain.OperatorRole) ([]*domain.RoutingRule, error) {
+	rules, err := s.repos.RoutingRules.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if role.CanManageRoutingRules() {
+		return rules, nil
+	}
+	if actorID == nil {
+		return nil, ErrRoutingRulePermissionDenied
+	}
+
+	grants, err := s.repos.InboxAdmins.GetByOperatorID(ctx, *actorID)
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return nil, ErrRoutingRulePermissionDenied
+	}
+	inboxes := make(map[uuid.UUID]bool, len(grants))
+	for _, grant := range grants {
+		inboxes[grant.InboxID] = true
+	}
+
+	visible := make([]*domain.RoutingRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.InboxID != nil && inboxes[*rule.InboxID] {
+			visible = append(visible, rule)
+		}
+	}
+	return visible, nil
+}
+
+// SetRuleActive enables or disables a routing rule
+// Permission: Admin, or Inbox Admin for rules of their inbox
+func (s *RoutingRuleService) SetRuleActive(ctx context.Context, tenantID, id uuid.UUID, active bool, actorID *uuid.UUID, role domain.OperatorRole) (*domain.RoutingRule, error) {
+	rule, err := s.getRule(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkManageRule(ctx, actorID, role, rule.InboxID); err != nil {
+		return nil, err
+	}
+
+	before := routingRuleAuditSnapshot(rule)
+	rule.IsActive = active
+	rule.UpdatedAt = time.Now().UTC()
+
+	if err := s.repos.RoutingRules.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionRoutingRuleUpdate, domain.AuditEntityRoutingRule, rule.ID,
+		before, routingRuleAuditSnapshot(rule)))
+
+	return rule, nil
+}
+
+// DeleteRule removes a routing rule
+// Permission: Admin, or Inbox Admin for rules of their inbox
+func (s *RoutingRuleService) DeleteRule(ctx context.Context, tenantID, id uuid.UUID, actorID *uuid.UUID, role domain.OperatorRole) error {
+	rule, err := s.getRule(ctx, tenantID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkManageRule(ctx, actorID, role, rule.InboxID); err != nil {
+		return err
+	}
+	if err := s.repos.RoutingRules.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionRoutingRuleDelete, domain.AuditEntityRoutingRule, rule.ID,
+		routingRuleAuditSnapshot(rule), nil))
+
+	return nil
+}
+
+func (s *RoutingRuleService) getRule(ctx context.Context, tenantID, id uuid.UUID) (*domain.RoutingRule, error) {
+	rule, err := s.repos.RoutingRules.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrRoutingRuleNotFound
+		}
+		return nil, err
+	}
+	if rule.TenantID != tenantID {
+		return nil, ErrRoutingRuleNotFound
+	}
+	return rule, nil
+}
+
+// checkManageRule checks if the caller can manage a rule of the inbox (nil
+// for tenant-wide rules): admins can manage every rule, inbox admins the
+// rules of their inboxes
+func (s *RoutingRuleService) checkManageRule(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, inboxID *uuid.UUID) error {
+	if role.CanManageRoutingRules() {
+		return nil
+	}
+	if inboxID == nil {
+		return ErrRoutingRulePermissionDenied
+	}
+	isAdmin, err := isInboxAdmin(ctx, s.repos, actorID, *inboxID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrRoutingRulePermissionDenied
+	}
+	return nil
+}
+
+// ==================== Rule Evaluation ====================
+
+// RuleOutcome summarizes the actions applied by matching rules
+type RuleOutcome struct {
+	MatchedRuleIDs []uuid.UUID
+	AttachedLabels []*domain.Label
+	PriorityBoost  decimal.Decimal
+}
+
+// applyRoutingRules evaluates the active rules for each trigger, in order,
+// against conv and the external metadata of the triggering message (nil when
+// none) and attaches their labels. repos must be bound to the caller's
+// transaction so the label changes commit (or roll back) together with the
+// triggering update. The returned boost is not applied to conv; callers add it
+// to the score they persist.
+func applyRoutingRules(
+	ctx context.Context,
+	repos *repository.RepositoryContainer,
+	conv *domain.ConversationRef,
+	metadata map[string]string,
+	triggers ...domain.RoutingRuleTrigger,
+) (*RuleOutcome, error) {
+	outcome := &RuleOutcome{PriorityBoost: decimal.Zero}
+	for _, trigger := range triggers {
+		if err := applyTriggerRules(ctx, repos, conv, metadata, trigger, outcome); err != nil {
+			return nil, err
+		}
+	}
+	return outcome, nil
+}
+
+// applyTriggerRules applies the active rules for one trigger, adding to outcome
+func applyTriggerRules(
+	ctx context.Context,
+	repos *repository.RepositoryContainer,
+	conv *domain.ConversationRef,
+	metadata map[string]string,
+	trigger domain.RoutingRuleTrigger,
+	outcome *RuleOutcome,
+) error {
+	rules, err := repos.RoutingRules.GetActiveForTrigger(ctx, conv.TenantID, conv.InboxID, trigger)
+	if err != nil {
+		return err
+	}
+
+	labels := repos.Labels
+	conversationLabels := repos.ConversationLabels
+
+	for _, rule := range rules {
+		if !rule.MatchesMessage(conv, metadata) {
+			continue
+		}
+		outcome.MatchedRuleIDs = append(outcome.MatchedRuleIDs, rule.ID)
+		outcome.PriorityBoost = outcome.PriorityBoost.Add(rule.PriorityBoost)
+
+		if rule.LabelName == nil {
+			continue
+		}
+
+		label, err := ruleLabel(ctx, labels, conv, *rule.LabelName)
+		if err != nil {
+			return err
+		}
+
+		exists, err := conversationLabels.Exists(ctx, conv.ID, label.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		if err := conversationLabels.Create(ctx, domain.NewConversationLabel(conv.ID, label)); err != nil {
+			return err
+		}
+		outcome.AttachedLabels = append(outcome.AttachedLabels, label)
+	}
+
+	return nil
+}
+
+// ruleLabel resolves a rule's label name: the tenant label of that name when
+// there is one, otherwise the conversation inbox's label, created on first use
+func ruleLabel(ctx context.Context, labels domain.LabelRepository, conv *domain.ConversationRef, name string) (*domain.Label, error) {
+	label, err := labels.GetTenantScopedByName(ctx, conv.TenantID, name)
+	if err == nil {
+		return label, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	return labels.GetOrCreateByName(ctx, conv.TenantID, conv.InboxID, name)
+}
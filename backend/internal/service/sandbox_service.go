@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSandboxAlreadyEnabled = errors.New("tenant is already a sandbox")
+	ErrTenantNotEmpty        = errors.New("tenant already has inboxes")
+	ErrTenantNotSandbox      = errors.New("tenant is not a sandbox")
+)
+
+// SandboxSeed reports the demo data seeded into a sandbox tenant
+type SandboxSeed struct {
+	Inboxes       []*domain.Inbox
+	Conversations int
+}
+
+// SandboxService turns trial tenants into sandboxes holding demo data only.
+// Demo conversations are ingested like real messages, so they are
+// prioritized, classified and announced like any other; webhook deliveries
+// of sandbox tenants to external URLs are blocked by the webhook service.
+type SandboxService struct {
+	repos         *repository.RepositoryContainer
+	pool          *pgxpool.Pool
+	conversations *ConversationService
+	audit         *AuditService
+	logger        *logger.Logger
+}
+
+func NewSandboxService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, conversations *ConversationService, audit *AuditService, log *logger.Logger) *SandboxService {
+	return &SandboxService{
+		repos:         repos,
+		pool:          pool,
+		conversations: conversations,
+		audit:         audit,
+		logger:        log,
+	}
+}
+
+// Enable makes the tenant a sandbox and seeds the demo data. Only a tenant
+// without inboxes can become a sandbox, so real data is never mixed with
+// demo data or removed by a reset. Sandbox mode cannot be turned off.
+// Permission: Admin (enforced by router)
+func (s *SandboxService) Enable(ctx context.Context, tenantID uuid.UUID, actorID *uuid.UUID) (*SandboxSeed, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	tenant, err := repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Sandbox {
+		return nil, ErrSandboxAlreadyEnabled
+	}
+	inboxes, err := repos.Inboxes.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(inboxes) > 0 {
+		return nil, ErrTenantNotEmpty
+	}
+
+	tenant.Sandbox = true
+	tenant.UpdatedAt = time.Now().UTC()
+	tenant.UpdatedBy = actorID
+	if err := repos.Tenants.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	seeded, err := s.createInboxes(ctx, repos, tenantID)
+	if err != nil {
+		// A concurrent Enable created the demo inboxes first
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrSandboxAlreadyEnabled
+		}
+		return nil, err
+	}
+	if err := repos.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	seed, err := s.ingestConversations(ctx, tenantID, seeded)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant sandbox enabled",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("conversations", seed.Conversations))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionTenantSandboxEnable, domain.AuditEntityTenant, tenantID,
+		map[string]interface{}{"sandbox": false}, sandboxAuditSnapshot(seed)))
+
+	return seed, nil
+}
+
+// Reset deletes the sandbox tenant's inboxes, with their conversations and
+// labels, and its customers, then seeds fresh demo data. Operators, API keys,
+// webhooks and settings are kept.
+// Permission: Admin (enforced by router)
+func (s *SandboxService) Reset(ctx context.Context, tenantID uuid.UUID, actorID *uuid.UUID) (*SandboxSeed, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	tenant, err := repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.Sandbox {
+		return nil, ErrTenantNotSandbox
+	}
+
+	if err := repos.Inboxes.DeleteByTenantID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	if err := repos.Customers.DeleteByTenantID(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	seeded, err := s.createInboxes(ctx, repos, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	seed, err := s.ingestConversations(ctx, tenantID, seeded)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tenant sandbox reset",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Int("conversations", seed.Conversations))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionTenantSandboxReset, domain.AuditEntityTenant, tenantID,
+		nil, sandboxAuditSnapshot(seed)))
+
+	return seed, nil
+}
+
+// createInboxes creates the demo inboxes and subscribes every operator of
+// the tenant to them
+func (s *SandboxService) createInboxes(ctx context.Context, repos *repository.RepositoryContainer, tenantID uuid.UUID) ([]*domain.Inbox, error) {
+	operators, err := repos.Operators.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	inboxes := make([]*domain.Inbox, len(domain.SandboxDemoInboxes))
+	for i, demo := range domain.SandboxDemoInboxes {
+		inbox := domain.NewInbox(tenantID, demo.PhoneNumber, demo.DisplayName)
+		if err := repos.Inboxes.Create(ctx, inbox); err != nil {
+			return nil, err
+		}
+		for _, operator := range operators {
+			if err := repos.Subscriptions.Create(ctx, domain.NewOperatorInboxSubscription(operator.ID, inbox.ID)); err != nil {
+				return nil, err
+			}
+		}
+		inboxes[i] = inbox
+	}
+	return inboxes, nil
+}
+
+// ingestConversations ingests the demo conversations into the demo inboxes.
+// A failure leaves the conversations ingested so far; a reset starts over.
+func (s *SandboxService) ingestConversations(ctx context.Context, tenantID uuid.UUID, inboxes []*domain.Inbox) (*SandboxSeed, error) {
+	seed := &SandboxSeed{Inboxes: inboxes}
+	now := time.Now().UTC()
+	for i, demo := range domain.SandboxDemoConversations {
+		inboxID := inboxes[demo.Inbox].ID
+		_, err := s.conversations.IngestMessage(ctx, IngestMessageParams{
+			TenantID:               tenantID,
+			InboxID:                &inboxID,
+			ExternalConversationID: demo.ExternalID(i),
+			CustomerPhoneNumber:    demo.CustomerPhoneNumber,
+			ReceivedAt:             now.Add(-demo.Age),
+			Text:                   demo.Text,
+		})
+		if err != nil {
+			return nil, err
+		}
+		seed.Conversations++
+	}
+	return seed, nil
+}
+
+func sandboxAuditSnapshot(seed *SandboxSeed) map[string]interface{} {
+	return map[string]interface{}{
+		"sandbox":       true,
+		"inboxes":       len(seed.Inboxes),
+		"conversations": seed.Conversations,
+	}
+}
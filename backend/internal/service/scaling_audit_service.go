@@ -0,0 +1,196 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// ScalingAuditConfig records how the replica was configured, for the
+// components whose state depends on it
+type ScalingAuditConfig struct {
+	// CacheBackend is the read cache backend, empty without a cache
+	CacheBackend string
+	// IdempotencyBackend stores idempotency keys; empty means postgres
+	IdempotencyBackend string
+	// RealtimeKeyConfigured and ShareLinkKeyConfigured are false when the
+	// signing key was generated at startup instead of configured
+	RealtimeKeyConfigured  bool
+	ShareLinkKeyConfigured bool
+	PublicRateLimit        float64
+	WaitEstimateCacheTTL   time.Duration
+	// EventBusDriver is the external event bus, empty or "none" without one
+	EventBusDriver string
+	// PushPlatforms are the push notification platforms with credentials
+	PushPlatforms []string
+}
+
+// ScalingAuditService reports the state each replica keeps to itself, so
+// that operators of self-hosted installs can check that a multi-replica
+// setup without sticky sessions is safe. A component is unsafe when requests
+// landing on different replicas can get different answers; node-local state
+// that is only an optimization, or that every replica rebuilds from the
+// database, is reported but safe.
+type ScalingAuditService struct {
+	config ScalingAuditConfig
+}
+
+func NewScalingAuditService(config ScalingAuditConfig) *ScalingAuditService {
+	return &ScalingAuditService{config: config}
+}
+
+// Report lists the components of this replica, in a fixed order
+func (s *ScalingAuditService) Report() *domain.ScalingReport {
+	return &domain.ScalingReport{
+		At: time.Now().UTC(),
+		Components: []domain.ScalingComponent{
+			s.readCache(),
+			signingKey("realtime_token_signing_key", "REALTIME_TOKEN_SIGNING_KEY", s.config.RealtimeKeyConfigured),
+			signingKey("share_link_signing_key", "SHARE_LINK_SIGNING_KEY", s.config.ShareLinkKeyConfigured),
+			{
+				Name:            "public_rate_limiter",
+				Backend:         "memory",
+				Scope:           domain.ScalingScopeNodeLocal,
+				SafeForReplicas: true,
+				Note: fmt.Sprintf("each replica allows %g requests per second per API key, "+
+					"so the deployment allows that times the number of replicas", s.config.PublicRateLimit),
+			},
+			{
+				Name:            "wait_estimate_cache",
+				Backend:         "memory",
+				Scope:           domain.ScalingScopeNodeLocal,
+				SafeForReplicas: true,
+				Note: fmt.Sprintf("not invalidated across replicas; estimates may differ between replicas "+
+					"for up to %s", s.config.WaitEstimateCacheTTL),
+			},
+			{
+				Name:            "queue_rank_refresh",
+				Backend:         "memory",
+				Scope:           domain.ScalingScopeNodeLocal,
+				SafeForReplicas: true,
+				Note:            "inboxes marked stale by this replica's writes; the periodic full re-rank covers the other replicas",
+			},
+			{
+				Name:            "presence_sessions",
+				Backend:         "postgres",
+				Scope:           domain.ScalingScopeShared,
+				SafeForReplicas: true,
+				Note:            "WebSockets stay on the replica that accepted them; presence is stored in the database and fanned out with LISTEN/NOTIFY",
+			},
+			{
+				Name:            "event_stream",
+				Backend:         "postgres",
+				Scope:           domain.ScalingScopeShared,
+				SafeForReplicas: true,
+				Note:            "SSE clients of every replica receive events through LISTEN/NOTIFY",
+			},
+			s.eventBus(),
+			s.pushNotifications(),
+			s.idempotencyKeys(),
+			{
+				Name:            "allocation_holds",
+				Backend:         "postgres",
+				Scope:           domain.ScalingScopeShared,
+				SafeForReplicas: true,
+				Note:            "allocations and grace periods are row locks and rows, no replica holds them in memory",
+			},
+			{
+				Name:            "jwks_cache",
+				Backend:         "memory",
+				Scope:           domain.ScalingScopeNodeLocal,
+				SafeForReplicas: true,
+				Note:            "each replica refreshes the signing keys on its own schedule",
+			},
+		},
+	}
+}
+
+func (s *ScalingAuditService) readCache() domain.ScalingComponent {
+	if s.config.CacheBackend == "" {
+		return domain.ScalingComponent{
+			Name:            "read_cache",
+			Backend:         "none",
+			Scope:           domain.ScalingScopeShared,
+			SafeForReplicas: true,
+			Note:            "reads go to the database",
+		}
+	}
+	return domain.ScalingComponent{
+		Name:            "read_cache",
+		Backend:         s.config.CacheBackend,
+		Scope:           domain.ScalingScopeShared,
+		SafeForReplicas: true,
+		Note:            "writers of every replica invalidate the shared entries",
+	}
+}
+
+func (s *ScalingAuditService) idempotencyKeys() domain.ScalingComponent {
+	backend := s.config.IdempotencyBackend
+	if backend == "" {
+		backend = "postgres"
+	}
+	return domain.ScalingComponent{
+		Name:            "idempotency_keys",
+		Backend:         backend,
+		Scope:           domain.ScalingScopeShared,
+		SafeForReplicas: true,
+		Note:            "the circuit breaker guarding the store is per replica",
+	}
+}
+
+func (s *ScalingAuditService) eventBus() domain.ScalingComponent {
+	driver := s.config.EventBusDriver
+	if driver == "" {
+		driver = "none"
+	}
+	return domain.ScalingComponent{
+		Name:            "event_bus",
+		Backend:         driver,
+		Scope:           domain.ScalingScopeShared,
+		SafeForReplicas: true,
+		Note:            "events are published from the transactional outbox, whichever replica wrote them",
+	}
+}
+
+func (s *ScalingAuditService) pushNotifications() domain.ScalingComponent {
+	if len(s.config.PushPlatforms) == 0 {
+		return domain.ScalingComponent{
+			Name:            "push_notifications",
+			Backend:         "none",
+			Scope:           domain.ScalingScopeShared,
+			SafeForReplicas: true,
+			Note:            "no push platform is configured",
+		}
+	}
+	return domain.ScalingComponent{
+		Name:            "push_notifications",
+		Backend:         "memory",
+		Scope:           domain.ScalingScopeNodeLocal,
+		SafeForReplicas: true,
+		Note: "queued by the replica that published the event (" + strings.Join(s.config.PushPlatforms, ", ") +
+			"); queued notifications are lost when it stops",
+	}
+}
+
+// signingKey reports a key that is either configured, and shared by every
+// replica, or generated at startup
+func signingKey(name, env string, configured bool) domain.ScalingComponent {
+	if configured {
+		return domain.ScalingComponent{
+			Name:            name,
+			Backend:         "config",
+			Scope:           domain.ScalingScopeShared,
+			SafeForReplicas: true,
+			Note:            env + " is configured",
+		}
+	}
+	return domain.ScalingComponent{
+		Name:            name,
+		Backend:         "generated",
+		Scope:           domain.ScalingScopeNodeLocal,
+		SafeForReplicas: false,
+		Note:            env + " is not set: tokens only verify on the replica that issued them; set the same key on every replica",
+	}
+}
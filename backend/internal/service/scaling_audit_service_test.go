@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scalingComponent(t *testing.T, report *domain.ScalingReport, name string) domain.ScalingComponent {
+	t.Helper()
+	for _, c := range report.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	require.Failf(t, "component not reported", "%s", name)
+	return domain.ScalingComponent{}
+}
+
+func TestScalingAuditService_Report(t *testing.T) {
+	t.Run("configured keys are safe", func(t *testing.T) {
+		report := NewScalingAuditService(ScalingAuditConfig{
+			CacheBackend:           "redis",
+			IdempotencyBackend:     "redis",
+			RealtimeKeyConfigured:  true,
+			ShareLinkKeyConfigured: true,
+			PublicRateLimit:        10,
+			WaitEstimateCacheTTL:   30 * time.Second,
+			EventBusDriver:         "kafka",
+			PushPlatforms:          []string{"FCM"},
+		}).Report()
+
+		assert.True(t, report.Safe())
+		assert.Equal(t, "redis", scalingComponent(t, report, "read_cache").Backend)
+		assert.Equal(t, "redis", scalingComponent(t, report, "idempotency_keys").Backend)
+		assert.Equal(t, "kafka", scalingComponent(t, report, "event_bus").Backend)
+		assert.Equal(t, domain.ScalingScopeNodeLocal, scalingComponent(t, report, "push_notifications").Scope)
+		assert.Equal(t, domain.ScalingScopeShared, scalingComponent(t, report, "realtime_token_signing_key").Scope)
+		assert.Equal(t, domain.ScalingScopeNodeLocal, scalingComponent(t, report, "public_rate_limiter").Scope)
+	})
+
+	t.Run("generated keys are unsafe", func(t *testing.T) {
+		report := NewScalingAuditService(ScalingAuditConfig{ShareLinkKeyConfigured: true}).Report()
+
+		assert.False(t, report.Safe())
+		realtime := scalingComponent(t, report, "realtime_token_signing_key")
+		assert.False(t, realtime.SafeForReplicas)
+		assert.Equal(t, "generated", realtime.Backend)
+		assert.Equal(t, domain.ScalingScopeNodeLocal, realtime.Scope)
+		assert.True(t, scalingComponent(t, report, "share_link_signing_key").SafeForReplicas)
+		assert.Equal(t, "none", scalingComponent(t, report, "read_cache").Backend)
+		assert.Equal(t, "postgres", scalingComponent(t, report, "idempotency_keys").Backend)
+		assert.Equal(t, "none", scalingComponent(t, report, "event_bus").Backend)
+		assert.Equal(t, "none", scalingComponent(t, report, "push_notifications").Backend)
+	})
+}
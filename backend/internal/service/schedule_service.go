@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrScheduleNotFound         = errors.New("operator schedule not found")
+	ErrScheduleOperatorNotFound = errors.New("schedule operator not found")
+)
+
+var shiftsEnded = metrics.NewCounter("operator_shifts_ended_total")
+
+// ScheduleWindow is a weekly recurring working window, see domain.OperatorSchedule
+type ScheduleWindow struct {
+	DayOfWeek time.Weekday
+	Start     time.Duration
+	End       time.Duration
+	Timezone  string
+}
+
+// ScheduleService manages operator working-hours schedules. Operators with a
+// schedule cannot be allocated conversations outside it (see
+// AllocationService) and are set OFFLINE by the shift-end worker once it
+// ends, which starts grace periods for their conversations.
+type ScheduleService struct {
+	repos     *repository.RepositoryContainer
+	operators *OperatorService
+	audit     *AuditService
+	logger    *logger.Logger
+}
+
+func NewScheduleService(repos *repository.RepositoryContainer, operators *OperatorService, audit *AuditService, log *logger.Logger) *ScheduleService {
+	return &ScheduleService{repos: repos, operators: operators, audit: audit, logger: log}
+}
+
+// ==================== Schedule Management ====================
+
+// ListSchedules returns the operator's windows ordered by day and start time
+func (s *ScheduleService) ListSchedules(ctx context.Context, tenantID, operatorID uuid.UUID) ([]*domain.OperatorSchedule, error) {
+	if err := s.verifyOperator(ctx, tenantID, operatorID); err != nil {
+		return nil, err
+	}
+	return s.repos.OperatorSchedules.GetByOperatorID(ctx, operatorID)
+}
+
+// CreateSchedule adds a working window to the operator's schedule
+// Permission: Admin (enforced by router)
+func (s *ScheduleService) CreateSchedule(ctx context.Context, tenantID, operatorID uuid.UUID, window ScheduleWindow, createdBy *uuid.UUID) (*domain.OperatorSchedule, error) {
+	if err := s.verifyOperator(ctx, tenantID, operatorID); err != nil {
+		return nil, err
+	}
+
+	schedule := domain.NewOperatorSchedule(tenantID, operatorID, window.DayOfWeek, window.Start, window.End,
+		window.Timezone, createdBy)
+	if err := s.repos.OperatorSchedules.Create(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Operator schedule created",
+		zap.String("schedule_id", schedule.ID.String()),
+		zap.String("operator_id", operatorID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, createdBy,
+		domain.AuditActionOperatorScheduleChange, domain.AuditEntityOperator, operatorID,
+		nil, scheduleAuditSnapshot(schedule)))
+
+	return schedule, nil
+}
+
+// UpdateSchedule replaces one of the operator's working windows
+// Permission: Admin (enforced by router)
+func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenantID, operatorID, id uuid.UUID, window ScheduleWindow, updatedBy *uuid.UUID) (*domain.OperatorSchedule, error) {
+	schedule, err := s.getSchedule(ctx, tenantID, operatorID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := scheduleAuditSnapshot(schedule)
+	schedule.DayOfWeek = window.DayOfWeek
+	schedule.Start = window.Start
+	schedule.End = window.End
+	schedule.Timezone = window.Timezone
+	schedule.UpdatedAt = time.Now().UTC()
+
+	if err := s.repos.OperatorSchedules.Update(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionOperatorScheduleChange, domain.AuditEntityOperator, operatorID,
+		before, scheduleAuditSnapshot(schedule)))
+
+	return schedule, nil
+}
+
+// DeleteSchedule removes one of the operator's working windows
+// Permission: Admin (enforced by router)
+func (s *ScheduleService) DeleteSchedule(ctx context.Context, tenantID, operatorID, id uuid.UUID, deletedBy *uuid.UUID) error {
+	schedule, err := s.getSchedule(ctx, tenantID, operatorID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repos.OperatorSchedules.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Operator schedule deleted",
+		zap.String("schedule_id", id.String()),
+		zap.String("operator_id", operatorID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, deletedBy,
+		domain.AuditActionOperatorScheduleChange, domain.AuditEntityOperator, operatorID,
+		scheduleAuditSnapshot(schedule), nil))
+
+	return nil
+}
+
+func (s *ScheduleService) getSchedule(ctx context.Context, tenantID, operatorID, id uuid.UUID) (*domain.OperatorSchedule, error) {
+	schedule, err := s.repos.OperatorSchedules.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	if schedule.TenantID != tenantID || schedule.OperatorID != operatorID {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) verifyOperator(ctx context.Context, tenantID, operatorID uuid.UUID) error {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrScheduleOperatorNotFound
+		}
+		return err
+	}
+	if operator.TenantID != tenantID {
+		return ErrScheduleOperatorNotFound
+	}
+	return nil
+}
+
+func scheduleAuditSnapshot(schedule *domain.OperatorSchedule) map[string]interface{} {
+	return map[string]interface{}{
+		"schedule_id": schedule.ID.String(),
+		"day_of_week": int(schedule.DayOfWeek),
+		"start_time":  formatTimeOfDay(schedule.Start),
+		"end_time":    formatTimeOfDay(schedule.End),
+		"timezone":    schedule.Timezone,
+	}
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return time.Time{}.Add(d).Format("15:04")
+}
+
+// ==================== Shift End ====================
+
+// EndShifts sets every AVAILABLE operator outside their schedule OFFLINE and
+// returns how many were. Operators that fail are skipped.
+func (s *ScheduleService) EndShifts(ctx context.Context) (int, error) {
+	windows, err := s.repos.OperatorSchedules.GetOfAvailableOperators(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	byOperator := make(map[uuid.UUID][]*domain.OperatorSchedule)
+	for _, w := range windows {
+		byOperator[w.OperatorID] = append(byOperator[w.OperatorID], w)
+	}
+
+	now := time.Now()
+	var (
+		ended int
+		errs  []error
+	)
+	for operatorID, operatorWindows := range byOperator {
+		if domain.InSchedule(operatorWindows, now) {
+			continue
+		}
+		if _, err := s.operators.EndShift(ctx, operatorID); err != nil {
+			s.logger.Warn("Failed to end operator shift",
+				zap.String("operator_id", operatorID.String()),
+				zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		ended++
+		shiftsEnded.Inc()
+		s.logger.Info("Operator shift ended",
+			zap.String("operator_id", operatorID.String()))
+	}
+	return ended, errors.Join(errs...)
+}
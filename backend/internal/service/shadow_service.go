@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrShadowNotFound         = errors.New("shadow relationship not found")
+	ErrShadowAlreadyExists    = errors.New("trainee is already shadowing this mentor")
+	ErrShadowSelf             = errors.New("operator cannot shadow themselves")
+	ErrShadowOperatorNotFound = errors.New("mentor or trainee operator not found")
+)
+
+// ShadowService manages mentor/trainee shadow relationships.
+// A trainee gets read-only visibility of the conversations allocated to
+// their mentors (see ConversationService.CanAccess) and receives the same
+// realtime events; mutation paths keep their own checks and are unaffected.
+type ShadowService struct {
+	repos  *repository.RepositoryContainer
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewShadowService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *ShadowService {
+	return &ShadowService{repos: repos, audit: audit, logger: log}
+}
+
+// CreateShadow attaches the trainee to the mentor
+// Permission: Admin (enforced by router)
+func (s *ShadowService) CreateShadow(ctx context.Context, tenantID, mentorID, traineeID uuid.UUID, createdBy *uuid.UUID) (*domain.OperatorShadow, error) {
+	if mentorID == traineeID {
+		return nil, ErrShadowSelf
+	}
+	for _, id := range []uuid.UUID{mentorID, traineeID} {
+		if err := s.verifyOperator(ctx, tenantID, id); err != nil {
+			return nil, err
+		}
+	}
+
+	shadow := domain.NewOperatorShadow(tenantID, mentorID, traineeID, createdBy)
+	if err := s.repos.OperatorShadows.Create(ctx, shadow); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrShadowAlreadyExists
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Shadow relationship created",
+		zap.String("shadow_id", shadow.ID.String()),
+		zap.String("mentor_id", mentorID.String()),
+		zap.String("trainee_id", traineeID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, createdBy,
+		domain.AuditActionOperatorShadowStart, domain.AuditEntityOperator, traineeID,
+		nil, shadowAuditSnapshot(shadow)))
+
+	return shadow, nil
+}
+
+// ListShadows returns all shadow relationships of the tenant
+// Permission: Admin (enforced by router)
+func (s *ShadowService) ListShadows(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorShadow, error) {
+	return s.repos.OperatorShadows.GetByTenantID(ctx, tenantID)
+}
+
+// DeleteShadow detaches the trainee from the mentor
+// Permission: Admin (enforced by router)
+func (s *ShadowService) DeleteShadow(ctx context.Context, tenantID, id uuid.UUID, deletedBy *uuid.UUID) error {
+	shadow, err := s.repos.OperatorShadows.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrShadowNotFound
+		}
+		return err
+	}
+	if shadow.TenantID != tenantID {
+		return ErrShadowNotFound
+	}
+
+	if err := s.repos.OperatorShadows.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Shadow relationship deleted", zap.String("shadow_id", id.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, deletedBy,
+		domain.AuditActionOperatorShadowEnd, domain.AuditEntityOperator, shadow.TraineeID,
+		shadowAuditSnapshot(shadow), nil))
+
+	return nil
+}
+
+func (s *ShadowService) verifyOperator(ctx context.Context, tenantID, operatorID uuid.UUID) error {
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrShadowOperatorNotFound
+		}
+		return err
+	}
+	if operator.TenantID != tenantID {
+		return ErrShadowOperatorNotFound
+	}
+	return nil
+}
+
+func shadowAuditSnapshot(shadow *domain.OperatorShadow) map[string]interface{} {
+	return map[string]interface{}{
+		"shadow_id":  shadow.ID.String(),
+		"mentor_id":  shadow.MentorID.String(),
+		"trainee_id": shadow.TraineeID.String(),
+	}
+}
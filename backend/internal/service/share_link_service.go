@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrShareLinkNotFound         = errors.New("share link not found")
+	ErrShareLinkExpired          = errors.New("share link expired")
+	ErrShareLinkRevoked          = errors.New("share link revoked")
+	ErrShareConversationNotFound = errors.New("conversation not found")
+	ErrShareRestrictedInbox      = errors.New("conversations in restricted inboxes cannot be shared")
+	ErrSharePIIForbidden         = errors.New("only admins can share customer identifiers")
+)
+
+// ShareLinkConfig holds configuration for conversation share links
+type ShareLinkConfig struct {
+	// BaseURL is the public address share link URLs start with; empty
+	// leaves them relative to the service
+	BaseURL string
+}
+
+// ShareLinkService issues read-only links to conversation snapshots for
+// people outside the system. Every access through a link is counted on the
+// link and recorded in the audit log.
+type ShareLinkService struct {
+	repos  *repository.RepositoryContainer
+	signer *domain.ShareTokenSigner
+	config ShareLinkConfig
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewShareLinkService(
+	repos *repository.RepositoryContainer,
+	signer *domain.ShareTokenSigner,
+	config ShareLinkConfig,
+	audit *AuditService,
+	log *logger.Logger,
+) *ShareLinkService {
+	return &ShareLinkService{
+		repos:  repos,
+		signer: signer,
+		config: config,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// ==================== Link Management ====================
+
+type CreateShareLinkParams struct {
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	TTL            time.Duration
+	IncludePII     bool
+	CreatedBy      *uuid.UUID
+	Role           domain.OperatorRole
+}
+
+// Create issues a link to the conversation and returns it with its URL,
+// which is not retrievable afterwards. Sharing the customer's identifiers
+// needs an admin, and conversations in restricted inboxes are not shared.
+// Permission: Manager+ (enforced by router)
+func (s *ShareLinkService) Create(ctx context.Context, params CreateShareLinkParams) (*domain.ShareLink, string, error) {
+	if params.IncludePII && params.Role != domain.OperatorRoleAdmin {
+		return nil, "", ErrSharePIIForbidden
+	}
+
+	conv, err := s.getConversation(ctx, params.TenantID, params.ConversationID)
+	if err != nil {
+		return nil, "", err
+	}
+	inbox, err := s.repos.Inboxes.GetByID(ctx, conv.InboxID)
+	if err != nil {
+		return nil, "", err
+	}
+	if inbox.IsRestricted {
+		return nil, "", ErrShareRestrictedInbox
+	}
+
+	link := domain.NewShareLink(conv, params.TTL, params.IncludePII, params.CreatedBy)
+	if err := s.repos.ShareLinks.Create(ctx, link); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("Conversation share link created",
+		zap.String("share_link_id", link.ID.String()),
+		zap.String("conversation_id", conv.ID.String()),
+		zap.Bool("include_pii", link.IncludePII),
+		zap.Time("expires_at", link.ExpiresAt))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(params.TenantID, params.CreatedBy,
+		domain.AuditActionConversationShare, domain.AuditEntityConversation, conv.ID,
+		nil, shareLinkAuditSnapshot(link)))
+
+	return link, s.url(link), nil
+}
+
+// List returns the conversation's links, newest first, including expired
+// and revoked ones
+// Permission: Manager+ (enforced by router)
+func (s *ShareLinkService) List(ctx context.Context, tenantID, conversationID uuid.UUID) ([]*domain.ShareLink, error) {
+	if _, err := s.getConversation(ctx, tenantID, conversationID); err != nil {
+		return nil, err
+	}
+	return s.repos.ShareLinks.GetByConversationID(ctx, conversationID)
+}
+
+// Revoke disables a link; revoking a revoked link is a no-op
+// Permission: Manager+ (enforced by router)
+func (s *ShareLinkService) Revoke(ctx context.Context, tenantID, conversationID, id uuid.UUID, actorID *uuid.UUID) (*domain.ShareLink, error) {
+	link, err := s.repos.ShareLinks.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	if link.TenantID != tenantID || link.ConversationID != conversationID {
+		return nil, ErrShareLinkNotFound
+	}
+	if link.IsRevoked() {
+		return link, nil
+	}
+
+	before := shareLinkAuditSnapshot(link)
+	link.Revoke()
+	if err := s.repos.ShareLinks.Revoke(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Conversation share link revoked",
+		zap.String("share_link_id", link.ID.String()),
+		zap.String("conversation_id", link.ConversationID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, actorID,
+		domain.AuditActionConversationShareRevoke, domain.AuditEntityConversation, link.ConversationID,
+		before, shareLinkAuditSnapshot(link)))
+
+	return link, nil
+}
+
+// ==================== Link Access ====================
+
+// ShareAccess describes who opened a share link, for the audit log
+type ShareAccess struct {
+	RemoteAddr string
+	UserAgent  string
+}
+
+// Open returns the snapshot a token grants access to. Forged and expired
+// tokens are refused before any database read.
+func (s *ShareLinkService) Open(ctx context.Context, token string, access ShareAccess) (*domain.ConversationSnapshot, error) {
+	id, expiresAt, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, ErrShareLinkNotFound
+	}
+	now := time.Now().UTC()
+	if !now.Before(expiresAt) {
+		return nil, ErrShareLinkExpired
+	}
+
+	link, err := s.repos.ShareLinks.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	if link.IsRevoked() {
+		return nil, ErrShareLinkRevoked
+	}
+	if link.IsExpired(now) {
+		return nil, ErrShareLinkExpired
+	}
+
+	conv, err := s.getConversation(ctx, link.TenantID, link.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	inbox, err := s.repos.Inboxes.GetByID(ctx, conv.InboxID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.labelNames(ctx, conv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repos.ShareLinks.RecordAccess(ctx, link.ID, now); err != nil {
+		return nil, err
+	}
+
+	snapshot := shareLinkAuditSnapshot(link)
+	snapshot["remote_addr"] = access.RemoteAddr
+	snapshot["user_agent"] = access.UserAgent
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(link.TenantID, nil,
+		domain.AuditActionConversationShareAccess, domain.AuditEntityConversation, conv.ID,
+		nil, snapshot))
+
+	return domain.NewConversationSnapshot(conv, inbox.DisplayName, labels, link), nil
+}
+
+// getConversation returns a conversation of the tenant
+func (s *ShareLinkService) getConversation(ctx context.Context, tenantID, conversationID uuid.UUID) (*domain.ConversationRef, error) {
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrShareConversationNotFound
+		}
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, ErrShareConversationNotFound
+	}
+	return conv, nil
+}
+
+// labelNames returns the names of the labels attached to conv
+func (s *ShareLinkService) labelNames(ctx context.Context, conv *domain.ConversationRef) ([]string, error) {
+	labels, err := s.repos.ConversationLabels.GetLabels(ctx, conv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return names, nil
+}
+
+func (s *ShareLinkService) url(link *domain.ShareLink) string {
+	return strings.TrimSuffix(s.config.BaseURL, "/") + "/share/" + s.signer.Sign(link)
+}
+
+func shareLinkAuditSnapshot(link *domain.ShareLink) map[string]interface{} {
+	snapshot := map[string]interface{}{
+		"share_link_id": link.ID.String(),
+		"include_pii":   link.IncludePII,
+		"expires_at":    link.ExpiresAt,
+	}
+	if link.RevokedAt != nil {
+		snapshot["revoked_at"] = link.RevokedAt
+	}
+	return snapshot
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSLAPolicyNotFound = errors.New("sla policy not found")
+	ErrSLAInboxNotFound  = errors.New("sla inbox not found")
+)
+
+var slaBreaches = metrics.NewCounter("sla_breaches_total")
+
+// SLAConfig holds configuration for SLA tracking
+type SLAConfig struct {
+	// NearBreachRatio is the fraction of a target after which a queued
+	// conversation is boosted
+	NearBreachRatio float64
+	// BoostPriority is the priority score boosted conversations are raised to
+	BoostPriority decimal.Decimal
+}
+
+// DefaultSLAConfig returns sensible defaults. BoostPriority is the top of
+// the weighted score range, so boosted conversations are allocated before
+// all others but those raised further by routing rules.
+func DefaultSLAConfig() SLAConfig {
+	return SLAConfig{
+		NearBreachRatio: 0.8,
+		BoostPriority:   decimal.NewFromInt(1),
+	}
+}
+
+// SLAService manages per-inbox SLA policies and flags conversations that
+// miss them. Both targets are measured from conversation creation: the
+// first-assignment target while the conversation is QUEUED, the resolution
+// target until it is RESOLVED.
+type SLAService struct {
+	repos   *repository.RepositoryContainer
+	ranking *QueueRankingService
+	config  SLAConfig
+	logger  *logger.Logger
+}
+
+func NewSLAService(repos *repository.RepositoryContainer, ranking *QueueRankingService, config SLAConfig, log *logger.Logger) *SLAService {
+	return &SLAService{repos: repos, ranking: ranking, config: config, logger: log}
+}
+
+// ==================== Policies ====================
+
+// GetPolicy returns the inbox's SLA policy
+// Permission: Manager+ (enforced by router)
+func (s *SLAService) GetPolicy(ctx context.Context, tenantID, inboxID uuid.UUID) (*domain.InboxSLAPolicy, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	policy, err := s.repos.InboxSLAPolicies.Get(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrSLAPolicyNotFound
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetPolicy creates or replaces the inbox's SLA policy. A nil target is not
+// tracked; conversations already flagged stay flagged.
+// Permission: Manager+ (enforced by router)
+func (s *SLAService) SetPolicy(ctx context.Context, tenantID, inboxID uuid.UUID, firstAssignment, resolution *time.Duration, updatedBy *uuid.UUID) (*domain.InboxSLAPolicy, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+
+	policy := domain.NewInboxSLAPolicy(tenantID, inboxID, firstAssignment, resolution, updatedBy)
+	if existing, err := s.repos.InboxSLAPolicies.Get(ctx, inboxID); err == nil {
+		policy.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repos.InboxSLAPolicies.Upsert(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inbox SLA policy updated",
+		zap.String("inbox_id", inboxID.String()))
+
+	return policy, nil
+}
+
+// DeletePolicy stops tracking SLAs for the inbox
+// Permission: Manager+ (enforced by router)
+func (s *SLAService) DeletePolicy(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	if _, err := s.GetPolicy(ctx, tenantID, inboxID); err != nil {
+		return err
+	}
+	return s.repos.InboxSLAPolicies.Delete(ctx, inboxID)
+}
+
+func (s *SLAService) verifyInbox(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrSLAInboxNotFound
+		}
+		return err
+	}
+	if inbox.TenantID != tenantID {
+		return ErrSLAInboxNotFound
+	}
+	return nil
+}
+
+// ==================== Breaches ====================
+
+// ListBreaches returns conversations that breached an SLA since the given
+// time, newest breach first; inboxID nil lists the whole tenant
+// Permission: Manager+ (enforced by router)
+func (s *SLAService) ListBreaches(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time, limit int) ([]*domain.ConversationRef, error) {
+	if inboxID != nil {
+		if err := s.verifyInbox(ctx, tenantID, *inboxID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repos.ConversationRefs.ListSLABreaches(ctx, tenantID, inboxID, since, limit)
+}
+
+// CheckSLAs flags open conversations that missed a target of their inbox's
+// policy and raises the priority of queued ones close to missing one. Returns
+// how many conversations were flagged.
+func (s *SLAService) CheckSLAs(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	breaches, err := s.repos.ConversationRefs.MarkSLABreaches(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range breaches {
+		slaBreaches.Inc()
+		s.logger.Info("Conversation breached SLA",
+			zap.String("conversation_id", b.ConversationID.String()),
+			zap.String("inbox_id", b.InboxID.String()))
+	}
+
+	inboxIDs, err := s.repos.ConversationRefs.BoostNearSLABreach(ctx, now, s.config.NearBreachRatio, s.config.BoostPriority)
+	if err != nil {
+		return len(breaches), err
+	}
+	if s.ranking != nil {
+		for _, inboxID := range inboxIDs {
+			s.ranking.MarkStale(inboxID)
+		}
+	}
+
+	return len(breaches), nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	snoozesEnded    = metrics.NewCounter("conversation_snoozes_ended_total")
+	snoozesReturned = metrics.NewCounter("conversation_snoozes_returned_to_operator_total")
+)
+
+// SnoozeResult holds the result of ending snoozes
+type SnoozeResult struct {
+	Ended              int
+	ReturnedToOperator int
+}
+
+// SnoozeService parks allocated conversations until a follow-up time. A
+// snoozed conversation is QUEUED but skipped by allocation, claims, queue
+// ranks and first-assignment SLAs until the snooze worker ends the snooze.
+// New customer messages do not end a snooze.
+type SnoozeService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewSnoozeService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *SnoozeService {
+	return &SnoozeService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// Snooze takes an allocated conversation from its operator until the given
+// time. With returnToOperator it is allocated back to the same operator when
+// the snooze ends, if they can take it; otherwise it rejoins the queue.
+// Permission: Owner (assigned operator), Manager, or Admin
+func (s *SnoozeService) Snooze(ctx context.Context, tenantID, callerID, conversationID uuid.UUID, until time.Time, returnToOperator bool, callerRole domain.OperatorRole) (*domain.ConversationRef, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conversations := s.repos.WithTx(tx).ConversationRefs
+
+	conv, err := conversations.LockForUpdate(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State != domain.ConversationStateAllocated {
+		return nil, ErrConversationNotAllocated
+	}
+
+	owner := conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == callerID
+	if !owner && callerRole != domain.OperatorRoleAdmin && callerRole != domain.OperatorRoleManager {
+		s.logger.Warn("Snooze attempt without permission",
+			zap.String("conversation_id", conversationID.String()),
+			zap.String("caller_id", callerID.String()),
+			zap.String("caller_role", string(callerRole)))
+		return nil, ErrInsufficientPermissions
+	}
+
+	previousOperator := conv.AssignedOperatorID
+	before := conversationAuditSnapshot(conv)
+
+	if err := conv.Snooze(until.UTC(), returnToOperator); err != nil {
+		return nil, err
+	}
+	if err := conversations.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+	if err := conversations.SetSnooze(ctx, conv); err != nil {
+		return nil, err
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = uuidPtrToString(previousOperator)
+	data["snoozed_by"] = callerID.String()
+	data["snoozed_until"] = conv.SnoozedUntil.Format(time.RFC3339)
+	data["return_to_operator"] = returnToOperator
+	event := domain.NewEvent(tenantID, domain.EventConversationSnoozed, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Conversation snoozed",
+		zap.String("conversation_id", conversationID.String()),
+		zap.String("snoozed_by", callerID.String()),
+		zap.Time("snoozed_until", *conv.SnoozedUntil),
+		zap.Bool("return_to_operator", returnToOperator))
+
+	after := conversationAuditSnapshot(conv)
+	after["snoozed_until"] = conv.SnoozedUntil.Format(time.RFC3339)
+	after["snooze_operator_id"] = uuidPtrToString(conv.SnoozeOperatorID)
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationSnooze, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return conv, nil
+}
+
+// EndSnoozes ends up to batchSize snoozes that are due. A conversation
+// snoozed with return to operator is allocated back to that operator when
+// they are AVAILABLE and still subscribed to its inbox; every other one
+// rejoins the queue. Uses FOR UPDATE SKIP LOCKED so instances share the work.
+func (s *SnoozeService) EndSnoozes(ctx context.Context, batchSize int) (*SnoozeResult, error) {
+	result := &SnoozeResult{}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conversations := s.repos.WithTx(tx).ConversationRefs
+
+	due, err := conversations.GetAndLockEndedSnoozes(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return result, nil
+	}
+
+	pending := make([]*domain.Event, 0, len(due))
+	for _, conv := range due {
+		returnTo := conv.SnoozeOperatorID
+		conv.EndSnooze()
+
+		returned := false
+		if returnTo != nil {
+			returned, err = s.canReturnTo(ctx, *returnTo, conv.InboxID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		eventType := domain.EventConversationUnsnoozed
+		if returned {
+			if err := conv.Allocate(*returnTo); err != nil {
+				return nil, err
+			}
+			if err := conversations.Update(ctx, conv); err != nil {
+				return nil, err
+			}
+			eventType = domain.EventConversationAllocated
+			result.ReturnedToOperator++
+		}
+		if err := conversations.SetSnooze(ctx, conv); err != nil {
+			return nil, err
+		}
+		result.Ended++
+
+		data := conversationEventData(conv)
+		data["reason"] = "snooze_ended"
+		pending = append(pending, domain.NewEvent(conv.TenantID, eventType, data))
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	snoozesEnded.Add(int64(result.Ended))
+	snoozesReturned.Add(int64(result.ReturnedToOperator))
+
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return result, nil
+}
+
+// canReturnTo reports whether a snoozed conversation can go back to the
+// operator who snoozed it
+func (s *SnoozeService) canReturnTo(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error) {
+	status, err := s.repos.OperatorStatus.GetByOperatorID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !status.AcceptsAllocations(time.Now()) {
+		return false, nil
+	}
+	if onVacation, err := isOnVacation(ctx, s.repos, operatorID); err != nil || onVacation {
+		return false, err
+	}
+	return s.repos.Subscriptions.IsSubscribed(ctx, operatorID, inboxID)
+}
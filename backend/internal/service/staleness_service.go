@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrStalenessPolicyNotFound = errors.New("staleness policy not found")
+	ErrStalenessInboxNotFound  = errors.New("staleness inbox not found")
+	// ErrActivityNotAssignee is returned when activity is reported by an
+	// operator the conversation is not allocated to
+	ErrActivityNotAssignee = errors.New("conversation is not allocated to the operator")
+)
+
+var (
+	conversationsStaleTotal = metrics.NewCounter("conversations_stale_total")
+	conversationsStale      = metrics.NewGauge("conversations_stale")
+)
+
+// StalenessService detects allocations their operator stopped working on.
+// Inboxes with a staleness policy track the last activity of the assigned
+// operator on each ALLOCATED conversation: a reply or note reported through
+// RecordActivity, an acknowledgement, or marking the conversation read. The
+// stale allocation worker flags conversations idle past the policy's limit
+// once and emits conversation.stale, which reaches the operator's event
+// stream; with a deallocate grace it also starts a STALE grace period, after
+// which the grace period worker returns the conversation to the queue. Any
+// activity of the operator clears the flag and cancels that grace period.
+type StalenessService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	logger *logger.Logger
+}
+
+func NewStalenessService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, log *logger.Logger) *StalenessService {
+	return &StalenessService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		logger: log,
+	}
+}
+
+// ==================== Policies ====================
+
+// GetPolicy returns the inbox's staleness policy
+// Permission: Manager+ (enforced by router)
+func (s *StalenessService) GetPolicy(ctx context.Context, tenantID, inboxID uuid.UUID) (*domain.InboxStalenessPolicy, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+	policy, err := s.repos.InboxStalenessPolicies.Get(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrStalenessPolicyNotFound
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// SetPolicy creates or replaces the inbox's staleness policy. Conversations
+// already flagged stay flagged, and their grace periods keep running.
+// Permission: Manager+ (enforced by router)
+func (s *StalenessService) SetPolicy(ctx context.Context, tenantID, inboxID uuid.UUID, staleAfter time.Duration, deallocateGrace *time.Duration, updatedBy *uuid.UUID) (*domain.InboxStalenessPolicy, error) {
+	if err := s.verifyInbox(ctx, tenantID, inboxID); err != nil {
+		return nil, err
+	}
+
+	policy := domain.NewInboxStalenessPolicy(tenantID, inboxID, staleAfter, deallocateGrace, updatedBy)
+	if existing, err := s.repos.InboxStalenessPolicies.Get(ctx, inboxID); err == nil {
+		policy.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repos.InboxStalenessPolicies.Upsert(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inbox staleness policy updated",
+		zap.String("inbox_id", inboxID.String()))
+
+	return policy, nil
+}
+
+// DeletePolicy stops detecting stale allocations in the inbox
+// Permission: Manager+ (enforced by router)
+func (s *StalenessService) DeletePolicy(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	if _, err := s.GetPolicy(ctx, tenantID, inboxID); err != nil {
+		return err
+	}
+	return s.repos.InboxStalenessPolicies.Delete(ctx, inboxID)
+}
+
+func (s *StalenessService) verifyInbox(ctx context.Context, tenantID, inboxID uuid.UUID) error {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrStalenessInboxNotFound
+		}
+		return err
+	}
+	if inbox.TenantID != tenantID {
+		return ErrStalenessInboxNotFound
+	}
+	return nil
+}
+
+// ==================== Activity ====================
+
+// RecordActivity records activity of the operator on a conversation
+// allocated to them
+// Permission: the assigned operator
+func (s *StalenessService) RecordActivity(ctx context.Context, tenantID, conversationID, operatorID uuid.UUID, kind domain.ActivityKind) error {
+	conv, err := s.repos.ConversationRefs.GetByID(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if conv.TenantID != tenantID {
+		return domain.ErrNotFound
+	}
+	if !conv.IsAllocatedTo(operatorID) {
+		return ErrActivityNotAssignee
+	}
+	return recordOperatorActivity(ctx, s.repos, s.logger, conv, operatorID, kind)
+}
+
+// recordOperatorActivity restarts the clock of an allocation the operator is
+// working on. Conversations without a running clock are skipped: their inbox
+// has no staleness policy, or the worker starts the clock on its next sweep.
+// A stale conversation is no longer, and its STALE grace period is cancelled.
+func recordOperatorActivity(ctx context.Context, repos *repository.RepositoryContainer, log *logger.Logger, conv *domain.ConversationRef, operatorID uuid.UUID, kind domain.ActivityKind) error {
+	existing, err := repos.ConversationActivity.Get(ctx, conv.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	activity := domain.NewConversationActivity(conv.ID, operatorID, kind, time.Now().UTC())
+	if err := repos.ConversationActivity.Record(ctx, activity); err != nil {
+		return err
+	}
+	if existing.StaleAt == nil {
+		return nil
+	}
+
+	gpa, err := repos.GracePeriodAssignments.GetByConversationID(ctx, conv.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if gpa != nil && gpa.Reason == domain.GracePeriodReasonStale {
+		if err := repos.GracePeriodAssignments.DeleteByConversationID(ctx, conv.ID); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Stale conversation active again",
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("operator_id", operatorID.String()),
+		zap.String("kind", string(kind)))
+
+	return nil
+}
+
+// ==================== Detection ====================
+
+// FlagStale starts the clock of allocations not tracked yet, then flags up
+// to batchSize allocations idle past their inbox's limit, idlest first, and
+// emits conversation.stale for each. Uses FOR UPDATE SKIP LOCKED so
+// instances share the work.
+func (s *StalenessService) FlagStale(ctx context.Context, batchSize int) (int, error) {
+	now := time.Now().UTC()
+
+	if _, err := s.repos.ConversationActivity.Prune(ctx); err != nil {
+		return 0, err
+	}
+	if _, err := s.repos.ConversationActivity.StartClocks(ctx, now); err != nil {
+		return 0, err
+	}
+
+	flagged, err := s.flagStale(ctx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if count, err := s.repos.ConversationActivity.CountStale(ctx); err == nil {
+		conversationsStale.Set(count)
+	} else {
+		s.logger.Warn("Failed to count stale conversations", zap.Error(err))
+	}
+
+	return flagged, nil
+}
+
+func (s *StalenessService) flagStale(ctx context.Context, now time.Time, batchSize int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	repos := s.repos.WithTx(tx)
+
+	stale, err := repos.ConversationActivity.GetAndLockStale(ctx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	policies := make(map[uuid.UUID]*domain.InboxStalenessPolicy)
+	pending := make([]*domain.Event, 0, len(stale))
+	for _, activity := range stale {
+		conv, err := repos.ConversationRefs.GetByID(ctx, activity.ConversationID)
+		if err != nil {
+			return 0, err
+		}
+		policy, ok := policies[conv.InboxID]
+		if !ok {
+			if policy, err = repos.InboxStalenessPolicies.Get(ctx, conv.InboxID); err != nil {
+				return 0, err
+			}
+			policies[conv.InboxID] = policy
+		}
+
+		if err := repos.ConversationActivity.MarkStale(ctx, conv.ID, now); err != nil {
+			return 0, err
+		}
+
+		data := conversationEventData(conv)
+		data["last_activity_at"] = activity.LastActivityAt.Format(time.RFC3339)
+		data["idle_seconds"] = int64(activity.IdleFor(now) / time.Second)
+		data["deallocate_at"] = nil
+
+		if policy.DeallocateGrace != nil {
+			gpa, err := startStaleGracePeriod(ctx, repos, activity, now.Add(*policy.DeallocateGrace))
+			if err != nil {
+				return 0, err
+			}
+			if gpa != nil {
+				data["deallocate_at"] = gpa.ExpiresAt.Format(time.RFC3339)
+			}
+		}
+
+		pending = append(pending, domain.NewEvent(conv.TenantID, domain.EventConversationStale, data))
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	conversationsStaleTotal.Add(int64(len(stale)))
+	for _, activity := range stale {
+		s.logger.Info("Conversation allocation stale",
+			zap.String("conversation_id", activity.ConversationID.String()),
+			zap.String("operator_id", activity.OperatorID.String()),
+			zap.Time("last_activity_at", activity.LastActivityAt))
+	}
+
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return len(stale), nil
+}
+
+// startStaleGracePeriod starts a STALE grace period ending at expiresAt.
+// A conversation keeps a grace period it already has (its operator went
+// offline or away), and nil is returned.
+func startStaleGracePeriod(ctx context.Context, repos *repository.RepositoryContainer, activity *domain.ConversationActivity, expiresAt time.Time) (*domain.GracePeriodAssignment, error) {
+	if _, err := repos.GracePeriodAssignments.GetByConversationID(ctx, activity.ConversationID); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	gpa := domain.NewGracePeriodAssignment(activity.ConversationID, activity.OperatorID, expiresAt, domain.GracePeriodReasonStale)
+	if err := repos.GracePeriodAssignments.Create(ctx, gpa); err != nil {
+		return nil, err
+	}
+	return gpa, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/repository"
+)
+
+// ForecastLookbackDays is how many previous days each forecast hour averages over
+const ForecastLookbackDays = 7
+
+const (
+	// OverviewResolutionWindow is how far back the overview averages resolution times
+	OverviewResolutionWindow = 24 * time.Hour
+	// OverviewAllocationWindow is how far back the overview counts allocations
+	OverviewAllocationWindow = time.Hour
+)
+
+// StatsService computes reporting and planning statistics for managers
+type StatsService struct {
+	repos  *repository.RepositoryContainer
+	logger *logger.Logger
+}
+
+func NewStatsService(repos *repository.RepositoryContainer, log *logger.Logger) *StatsService {
+	return &StatsService{repos: repos, logger: log}
+}
+
+// AvailabilityForecast estimates, for each of the next hours starting with the
+// current one, how many operators will be available and how many conversations
+// will arrive. Operator availability comes from the status history
+// (operator.status_change audit entries), inflow from recently created
+// conversations. With an inbox, only operators subscribed to it are counted.
+// Permission: Manager+ (enforced by router)
+func (s *StatsService) AvailabilityForecast(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, hours int) (*domain.AvailabilityForecast, error) {
+	current, err := s.forecastOperators(ctx, tenantID, inboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	start := now.Truncate(time.Hour)
+	since := start.Add(-ForecastLookbackDays * 24 * time.Hour)
+
+	entries, err := s.repos.AuditLogs.ListByAction(ctx, tenantID, domain.AuditActionOperatorStatusChange, since)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]domain.OperatorStatusChange, 0, len(entries))
+	for _, entry := range entries {
+		if change, ok := domain.OperatorStatusChangeFromAudit(entry); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	inflow, err := s.repos.ConversationRefs.CountCreatedByHour(ctx, tenantID, inboxID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AvailabilityForecast{
+		InboxID:      inboxID,
+		LookbackDays: ForecastLookbackDays,
+		GeneratedAt:  now,
+		Hours: domain.ForecastAvailability(domain.AvailabilityForecastInput{
+			Start:         start,
+			Hours:         hours,
+			LookbackDays:  ForecastLookbackDays,
+			CurrentStatus: current,
+			Changes:       changes,
+			InflowByHour:  inflow,
+		}),
+	}, nil
+}
+
+// LabelUsage counts the labels attached to the inbox's conversations in
+// [since, until) that are still attached. Attachments are grouped by the
+// label version they were made under, so a renamed label reports its old
+// name for earlier attachments.
+// Permission: Manager+ (enforced by router)
+func (s *StatsService) LabelUsage(ctx context.Context, tenantID, inboxID uuid.UUID, since, until time.Time) (*domain.LabelUsageReport, error) {
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		return nil, err
+	}
+	if inbox.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+
+	counts, err := s.repos.ConversationLabels.CountByVersion(ctx, tenantID, inboxID, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LabelUsageReport{
+		InboxID: inboxID,
+		Since:   since,
+		Until:   until,
+		Labels:  counts,
+	}, nil
+}
+
+// Overview summarizes the tenant for a dashboard: conversations by state per
+// inbox, operators per status, allocations and claims in the last hour and
+// the average resolution time over the last day. Each figure is one grouped
+// query, however many inboxes and operators the tenant has.
+// Permission: Manager+ (enforced by router)
+func (s *StatsService) Overview(ctx context.Context, tenantID uuid.UUID) (*domain.TenantOverview, error) {
+	now := time.Now().UTC()
+
+	stats, err := s.repos.ConversationRefs.CountByInboxAndState(ctx, tenantID, now.Add(-OverviewResolutionWindow), now)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.repos.AuditLogs.CountAllocationsByInbox(ctx, tenantID, now.Add(-OverviewAllocationWindow))
+	if err != nil {
+		return nil, err
+	}
+	operators, err := s.repos.OperatorStatus.CountByStatus(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTenantOverview(now, OverviewResolutionWindow, stats, allocations, operators), nil
+}
+
+// Escalations counts the tenant's escalations in [since, until) per operator
+// the conversations were taken from and per inbox they left
+// Permission: Manager+ (enforced by router)
+func (s *StatsService) Escalations(ctx context.Context, tenantID uuid.UUID, since, until time.Time) (*domain.EscalationReport, error) {
+	byOperator, err := s.repos.Escalations.CountByOperator(ctx, tenantID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	byInbox, err := s.repos.Escalations.CountByInbox(ctx, tenantID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewEscalationReport(since, until, byOperator, byInbox), nil
+}
+
+// forecastOperators returns the current status of the operators counted in a
+// forecast: the inbox's subscribers, or every operator of the tenant
+func (s *StatsService) forecastOperators(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID) (map[uuid.UUID]domain.OperatorStatusType, error) {
+	current := make(map[uuid.UUID]domain.OperatorStatusType)
+
+	if inboxID != nil {
+		inbox, err := s.repos.Inboxes.GetByID(ctx, *inboxID)
+		if err != nil {
+			return nil, err
+		}
+		if inbox.TenantID != tenantID {
+			return nil, domain.ErrNotFound
+		}
+
+		subscriptions, err := s.repos.Subscriptions.GetByInboxID(ctx, *inboxID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subscriptions {
+			current[sub.OperatorID] = domain.OperatorStatusOffline
+		}
+	} else {
+		operators, err := s.repos.Operators.GetByTenantID(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range operators {
+			current[op.ID] = domain.OperatorStatusOffline
+		}
+	}
+
+	statuses, err := s.repos.OperatorStatus.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if _, ok := current[status.OperatorID]; ok {
+			current[status.OperatorID] = status.Status
+		}
+	}
+
+	return current, nil
+}
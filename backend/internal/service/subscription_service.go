@@ -2,11 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
 	"github.com/inbox-allocation-service/internal/pkg/logger"
 	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSubscriptionPermissionDenied = errors.New("insufficient permissions for subscription operation")
+	// ErrSubscriptionConflict is returned when the subscription was removed
+	// while it was being created; retrying subscribes the operator again
+	ErrSubscriptionConflict = errors.New("subscription changed concurrently")
 )
 
 type SubscriptionService struct {
@@ -18,35 +27,69 @@ func NewSubscriptionService(repos *repository.RepositoryContainer, log *logger.L
 	return &SubscriptionService{repos: repos, logger: log}
 }
 
-func (s *SubscriptionService) Subscribe(ctx context.Context, operatorID, inboxID uuid.UUID) (*domain.OperatorInboxSubscription, error) {
-	isSubscribed, err := s.repos.Subscriptions.IsSubscribed(ctx, operatorID, inboxID)
-	if err != nil {
-		return nil, err
+// Subscribe subscribes the operator to the inbox; actorID is the caller, nil
+// for API keys. Subscribing again is not an error: the existing subscription
+// is returned with created false, also when concurrent requests race on the
+// unique (operator_id, inbox_id) constraint.
+// Permission: Manager, Admin, or Inbox Admin
+func (s *SubscriptionService) Subscribe(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, operatorID, inboxID uuid.UUID) (sub *domain.OperatorInboxSubscription, created bool, err error) {
+	if err := s.checkManageSubscriptions(ctx, actorID, role, inboxID); err != nil {
+		return nil, false, err
+	}
+
+	existing, err := s.repos.Subscriptions.GetByOperatorAndInbox(ctx, operatorID, inboxID)
+	if err == nil {
+		return existing, false, nil
 	}
-	if isSubscribed {
-		// Idempotent: return existing subscription
-		return s.repos.Subscriptions.GetByOperatorAndInbox(ctx, operatorID, inboxID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, false, err
 	}
 
 	if _, err := s.repos.Operators.GetByID(ctx, operatorID); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if _, err := s.repos.Inboxes.GetByID(ctx, inboxID); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	sub := domain.NewOperatorInboxSubscription(operatorID, inboxID)
-	if err := s.repos.Subscriptions.Create(ctx, sub); err != nil {
-		return nil, err
+	sub = domain.NewOperatorInboxSubscription(operatorID, inboxID)
+	created, err = s.repos.Subscriptions.CreateIfNotExists(ctx, sub)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		return sub, true, nil
+	}
+
+	// A concurrent request subscribed the operator first
+	existing, err = s.repos.Subscriptions.GetByOperatorAndInbox(ctx, operatorID, inboxID)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Warn("Subscription removed while subscribing",
+			zap.String("operator_id", operatorID.String()),
+			zap.String("inbox_id", inboxID.String()))
+		return nil, false, ErrSubscriptionConflict
+	}
+	if err != nil {
+		return nil, false, err
 	}
-	return sub, nil
+	return existing, false, nil
 }
 
-func (s *SubscriptionService) Unsubscribe(ctx context.Context, operatorID, inboxID uuid.UUID) error {
+// Unsubscribe removes the operator's subscription to the inbox
+// Permission: Manager, Admin, or Inbox Admin
+func (s *SubscriptionService) Unsubscribe(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, operatorID, inboxID uuid.UUID) error {
+	if err := s.checkManageSubscriptions(ctx, actorID, role, inboxID); err != nil {
+		return err
+	}
 	return s.repos.Subscriptions.DeleteByOperatorAndInbox(ctx, operatorID, inboxID)
 }
 
-func (s *SubscriptionService) GetOperatorsByInbox(ctx context.Context, inboxID uuid.UUID) ([]*domain.OperatorInboxSubscription, error) {
+// GetOperatorsByInbox lists the inbox's subscriptions
+// Permission: Manager, Admin, or Inbox Admin
+func (s *SubscriptionService) GetOperatorsByInbox(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, inboxID uuid.UUID) ([]*domain.OperatorInboxSubscription, error) {
+	if err := s.checkManageSubscriptions(ctx, actorID, role, inboxID); err != nil {
+		return nil, err
+	}
 	return s.repos.Subscriptions.GetByInboxID(ctx, inboxID)
 }
 
@@ -57,3 +100,19 @@ func (s *SubscriptionService) GetInboxesByOperator(ctx context.Context, operator
 func (s *SubscriptionService) IsSubscribed(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error) {
 	return s.repos.Subscriptions.IsSubscribed(ctx, operatorID, inboxID)
 }
+
+// checkManageSubscriptions checks if the caller can manage subscriptions of
+// the inbox: managers and admins can for every inbox, inbox admins for theirs
+func (s *SubscriptionService) checkManageSubscriptions(ctx context.Context, actorID *uuid.UUID, role domain.OperatorRole, inboxID uuid.UUID) error {
+	if role.CanManageSubscriptions() {
+		return nil
+	}
+	isAdmin, err := isInboxAdmin(ctx, s.repos, actorID, inboxID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrSubscriptionPermissionDenied
+	}
+	return nil
+}
@@ -14,25 +14,34 @@ import (
 
 type TenantService struct {
 	repos  *repository.RepositoryContainer
+	audit  *AuditService
 	logger *logger.Logger
 }
 
-func NewTenantService(repos *repository.RepositoryContainer, log *logger.Logger) *TenantService {
-	return &TenantService{repos: repos, logger: log}
+func NewTenantService(repos *repository.RepositoryContainer, audit *AuditService, log *logger.Logger) *TenantService {
+	return &TenantService{repos: repos, audit: audit, logger: log}
 }
 
 func (s *TenantService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Tenant, error) {
 	return s.repos.Tenants.GetByID(ctx, id)
 }
 
-func (s *TenantService) UpdateWeights(ctx context.Context, tenantID uuid.UUID, alpha, beta decimal.Decimal, updatedBy *uuid.UUID) (*domain.Tenant, error) {
+// UpdateWeights sets the tenant's priority weights and, unless nil, the
+// first-contact boost. Queued conversations keep their score until it is next
+// recalculated.
+func (s *TenantService) UpdateWeights(ctx context.Context, tenantID uuid.UUID, alpha, beta decimal.Decimal, firstContactBoost *decimal.Decimal, updatedBy *uuid.UUID) (*domain.Tenant, error) {
 	tenant, err := s.repos.Tenants.GetByID(ctx, tenantID)
 	if err != nil {
 		return nil, err
 	}
 
+	before := tenantWeightsAuditSnapshot(tenant)
+
 	tenant.PriorityWeightAlpha = alpha
 	tenant.PriorityWeightBeta = beta
+	if firstContactBoost != nil {
+		tenant.FirstContactBoost = *firstContactBoost
+	}
 	tenant.UpdatedAt = time.Now().UTC()
 	tenant.UpdatedBy = updatedBy
 
@@ -44,7 +53,70 @@ func (s *TenantService) UpdateWeights(ctx context.Context, tenantID uuid.UUID, a
 		zap.String("tenant_id", tenantID.String()),
 		zap.String("alpha", alpha.String()),
 		zap.String("beta", beta.String()),
+		zap.String("first_contact_boost", tenant.FirstContactBoost.String()),
 	)
 
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionTenantWeightsChange, domain.AuditEntityTenant, tenantID,
+		before, tenantWeightsAuditSnapshot(tenant)))
+
 	return tenant, nil
 }
+
+func tenantWeightsAuditSnapshot(tenant *domain.Tenant) map[string]interface{} {
+	return map[string]interface{}{
+		"priority_weight_alpha": tenant.PriorityWeightAlpha.String(),
+		"priority_weight_beta":  tenant.PriorityWeightBeta.String(),
+		"first_contact_boost":   tenant.FirstContactBoost.String(),
+	}
+}
+
+// UpdateSettings replaces the tenant's settings. A nil grace period uses the
+// server default; grace periods already running keep their expiry. A nil
+// intake limit disables intake throttling. A new allocation engine applies
+// from the tenant's next allocation.
+func (s *TenantService) UpdateSettings(ctx context.Context, tenantID uuid.UUID, settings domain.TenantSettings, updatedBy *uuid.UUID) (*domain.Tenant, error) {
+	tenant, err := s.repos.Tenants.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := tenantSettingsAuditSnapshot(tenant)
+
+	tenant.ApplySettings(settings)
+	tenant.UpdatedAt = time.Now().UTC()
+	tenant.UpdatedBy = updatedBy
+
+	if err := s.repos.Tenants.Update(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	after := tenantSettingsAuditSnapshot(tenant)
+	s.logger.Info("Tenant settings updated",
+		zap.String("tenant_id", tenantID.String()),
+		zap.Any("grace_period_seconds", after["grace_period_seconds"]),
+		zap.Any("intake_limit_per_hour", after["intake_limit_per_hour"]),
+		zap.Any("allocation_engine", after["allocation_engine"]),
+	)
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, updatedBy,
+		domain.AuditActionTenantSettingsChange, domain.AuditEntityTenant, tenantID,
+		before, after))
+
+	return tenant, nil
+}
+
+func tenantSettingsAuditSnapshot(tenant *domain.Tenant) map[string]interface{} {
+	var gracePeriod, intakeLimit interface{}
+	if tenant.GracePeriod != nil {
+		gracePeriod = int(tenant.GracePeriod.Seconds())
+	}
+	if tenant.IntakeLimitPerHour != nil {
+		intakeLimit = *tenant.IntakeLimitPerHour
+	}
+	return map[string]interface{}{
+		"grace_period_seconds":  gracePeriod,
+		"intake_limit_per_hour": intakeLimit,
+		"allocation_engine":     string(tenant.AllocationEngine),
+	}
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTransferNotFound     = errors.New("transfer not found")
+	ErrTransferNotAssignee  = errors.New("only the assigned operator can transfer the conversation")
+	ErrTransferToSelf       = errors.New("cannot transfer a conversation to its assignee")
+	ErrTransferPending      = errors.New("conversation already has a pending transfer")
+	ErrTransferNotRecipient = errors.New("only the recipient can answer the transfer")
+	ErrTransferNotPending   = errors.New("transfer has already been answered")
+	ErrTransferExpired      = errors.New("transfer expired")
+	ErrTransferCancelled    = errors.New("conversation is no longer assigned to the proposing operator")
+)
+
+// transfersByStatus counts transfers by how they ended, and those requested
+// under PENDING
+var transfersByStatus = metrics.NewCounterMap("conversation_transfers_total")
+
+// TransferService lets the assigned operator propose a conversation to a
+// colleague instead of a forced reassignment. The recipient accepts, which
+// reassigns the conversation to them, or declines before the transfer
+// expires; the transfer expiry worker ends the unanswered ones. The
+// conversation stays with the proposer until the recipient accepts.
+type TransferService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewTransferService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *TransferService {
+	return &TransferService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// ==================== Request ====================
+
+type RequestTransferParams struct {
+	TenantID       uuid.UUID
+	ConversationID uuid.UUID
+	// FromOperatorID is the caller, who must be the conversation's assignee
+	FromOperatorID uuid.UUID
+	ToOperatorID   uuid.UUID
+	Note           *string
+	TTL            time.Duration
+}
+
+// Request proposes the caller's conversation to another operator subscribed
+// to its inbox and emits conversation.transfer_requested. A conversation has
+// at most one pending transfer.
+// Permission: Owner (assigned operator)
+func (s *TransferService) Request(ctx context.Context, params RequestTransferParams) (*domain.ConversationTransfer, error) {
+	if params.ToOperatorID == params.FromOperatorID {
+		return nil, ErrTransferToSelf
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, params.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conv.TenantID != params.TenantID {
+		return nil, domain.ErrNotFound
+	}
+	if conv.State != domain.ConversationStateAllocated {
+		return nil, ErrConversationNotAllocated
+	}
+	if conv.AssignedOperatorID == nil || *conv.AssignedOperatorID != params.FromOperatorID {
+		return nil, ErrTransferNotAssignee
+	}
+
+	if err := s.checkRecipient(ctx, repos, params.TenantID, params.ToOperatorID, conv.InboxID); err != nil {
+		return nil, err
+	}
+
+	transfer := domain.NewConversationTransfer(conv, params.FromOperatorID, params.ToOperatorID, params.Note, params.TTL)
+	if err := repos.Transfers.Create(ctx, transfer); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrTransferPending
+		}
+		return nil, err
+	}
+
+	event := domain.NewEvent(params.TenantID, domain.EventConversationTransferRequested, transferEventData(transfer, conv))
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	transfersByStatus.Inc(string(domain.TransferStatusPending))
+
+	s.logger.Info("Conversation transfer requested",
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("from_operator", transfer.FromOperatorID.String()),
+		zap.String("to_operator", transfer.ToOperatorID.String()),
+		zap.Time("expires_at", transfer.ExpiresAt))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return transfer, nil
+}
+
+// checkRecipient verifies the operator belongs to the tenant and is
+// subscribed to the inbox
+func (s *TransferService) checkRecipient(ctx context.Context, repos *repository.RepositoryContainer, tenantID, operatorID, inboxID uuid.UUID) error {
+	operator, err := repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrTargetOperatorNotFound
+		}
+		return err
+	}
+	if operator.TenantID != tenantID {
+		return ErrTargetOperatorNotFound // Don't reveal cross-tenant info
+	}
+
+	isSubscribed, err := repos.Subscriptions.IsSubscribed(ctx, operatorID, inboxID)
+	if err != nil {
+		return err
+	}
+	if !isSubscribed {
+		return ErrTargetOperatorNotSubscribed
+	}
+	return nil
+}
+
+// ==================== Answer ====================
+
+// Accept reassigns the conversation to the caller, the transfer's recipient,
+// and emits conversation.reassigned with the transfer's ID. The note is
+// stored as a HANDOVER note. A conversation that left the proposer in the
+// meantime cancels the transfer (ErrTransferCancelled).
+// Permission: the recipient
+func (s *TransferService) Accept(ctx context.Context, tenantID, callerID, transferID uuid.UUID) (*domain.ConversationTransfer, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	transfer, err := s.lockForAnswer(ctx, repos, tenantID, callerID, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	conv, err := repos.ConversationRefs.LockForUpdate(ctx, transfer.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	if conv.State != domain.ConversationStateAllocated || conv.AssignedOperatorID == nil || *conv.AssignedOperatorID != transfer.FromOperatorID {
+		// The transfer can never be accepted; end it rather than leave it
+		// pending until it expires
+		if err := s.end(ctx, repos, transfer, domain.TransferStatusCancelled, now); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		transfersByStatus.Inc(string(domain.TransferStatusCancelled))
+		return nil, ErrTransferCancelled
+	}
+
+	if err := s.checkRecipient(ctx, repos, tenantID, callerID, conv.InboxID); err != nil {
+		return nil, err
+	}
+
+	before := conversationAuditSnapshot(conv)
+
+	conv.AssignedOperatorID = &callerID
+	conv.UpdatedAt = now
+	if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+		return nil, err
+	}
+	if err := s.end(ctx, repos, transfer, domain.TransferStatusAccepted, now); err != nil {
+		return nil, err
+	}
+
+	var note *domain.ConversationNote
+	if transfer.Note != nil {
+		note = domain.NewHandoverNote(conv, transfer.FromOperatorID, callerID, *transfer.Note)
+		if err := repos.ConversationNotes.Create(ctx, note); err != nil {
+			return nil, err
+		}
+	}
+
+	data := conversationEventData(conv)
+	data["previous_operator_id"] = transfer.FromOperatorID.String()
+	data["reassigned_by"] = callerID.String()
+	data["transfer_id"] = transfer.ID.String()
+	data["handover_note"] = nil
+	if note != nil {
+		data["handover_note"] = note.Body
+	}
+	event := domain.NewEvent(tenantID, domain.EventConversationReassigned, data)
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	transfersByStatus.Inc(string(domain.TransferStatusAccepted))
+
+	s.logger.Info("Conversation transfer accepted",
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("conversation_id", conv.ID.String()),
+		zap.String("from_operator", transfer.FromOperatorID.String()),
+		zap.String("to_operator", callerID.String()))
+
+	after := conversationAuditSnapshot(conv)
+	after["transfer_id"] = transfer.ID.String()
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &callerID,
+		domain.AuditActionConversationTransfer, domain.AuditEntityConversation, conv.ID,
+		before, after))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return transfer, nil
+}
+
+// Decline ends the transfer addressed to the caller and emits
+// conversation.transfer_declined; the conversation stays with the proposer.
+// Permission: the recipient
+func (s *TransferService) Decline(ctx context.Context, tenantID, callerID, transferID uuid.UUID) (*domain.ConversationTransfer, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	transfer, err := s.lockForAnswer(ctx, repos, tenantID, callerID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	conv, err := repos.ConversationRefs.GetByID(ctx, transfer.ConversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.end(ctx, repos, transfer, domain.TransferStatusDeclined, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	event := domain.NewEvent(tenantID, domain.EventConversationTransferDeclined, transferEventData(transfer, conv))
+	if err := stageEvents(ctx, s.events, tx, event); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	transfersByStatus.Inc(string(domain.TransferStatusDeclined))
+
+	s.logger.Info("Conversation transfer declined",
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("conversation_id", transfer.ConversationID.String()),
+		zap.String("to_operator", callerID.String()))
+
+	publishEvent(ctx, s.events, s.logger, event)
+
+	return transfer, nil
+}
+
+// lockForAnswer locks a transfer the caller received that can still be
+// answered. Transfers past their expiry are refused even before the worker
+// ends them.
+func (s *TransferService) lockForAnswer(ctx context.Context, repos *repository.RepositoryContainer, tenantID, callerID, transferID uuid.UUID) (*domain.ConversationTransfer, error) {
+	transfer, err := repos.Transfers.LockForUpdate(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	if transfer.TenantID != tenantID {
+		return nil, ErrTransferNotFound
+	}
+	if transfer.ToOperatorID != callerID {
+		return nil, ErrTransferNotRecipient
+	}
+	if !transfer.IsPending() {
+		return nil, ErrTransferNotPending
+	}
+	if transfer.IsExpired(time.Now()) {
+		return nil, ErrTransferExpired
+	}
+	return transfer, nil
+}
+
+func (s *TransferService) end(ctx context.Context, repos *repository.RepositoryContainer, transfer *domain.ConversationTransfer, status domain.TransferStatus, now time.Time) error {
+	if err := transfer.End(status, now); err != nil {
+		return ErrTransferNotPending
+	}
+	return repos.Transfers.UpdateStatus(ctx, transfer)
+}
+
+// ==================== List ====================
+
+// ListPending returns the pending transfers the operator proposed or
+// received, oldest first
+func (s *TransferService) ListPending(ctx context.Context, tenantID, operatorID uuid.UUID) ([]*domain.ConversationTransfer, error) {
+	return s.repos.Transfers.ListPending(ctx, tenantID, operatorID)
+}
+
+// ==================== Expiry ====================
+
+// ExpireDue ends up to batchSize pending transfers past their expiry,
+// soonest first, and emits conversation.transfer_expired for each. Uses FOR
+// UPDATE SKIP LOCKED so instances share the work.
+func (s *TransferService) ExpireDue(ctx context.Context, batchSize int) (int, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	expired, err := repos.Transfers.ExpireDue(ctx, time.Now().UTC(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	pending, err := s.expiryEvents(ctx, repos, expired)
+	if err != nil {
+		return 0, err
+	}
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	transfersByStatus.Add(string(domain.TransferStatusExpired), int64(len(expired)))
+
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return len(expired), nil
+}
+
+func (s *TransferService) expiryEvents(ctx context.Context, repos *repository.RepositoryContainer, expired []*domain.ConversationTransfer) ([]*domain.Event, error) {
+	events := make([]*domain.Event, 0, len(expired))
+	for _, transfer := range expired {
+		conv, err := repos.ConversationRefs.GetByID(ctx, transfer.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, domain.NewEvent(transfer.TenantID, domain.EventConversationTransferExpired, transferEventData(transfer, conv)))
+	}
+	return events, nil
+}
+
+func transferEventData(transfer *domain.ConversationTransfer, conv *domain.ConversationRef) map[string]interface{} {
+	data := conversationEventData(conv)
+	data["transfer_id"] = transfer.ID.String()
+	data["from_operator_id"] = transfer.FromOperatorID.String()
+	data["to_operator_id"] = transfer.ToOperatorID.String()
+	data["expires_at"] = transfer.ExpiresAt.Format(time.RFC3339)
+	return data
+}
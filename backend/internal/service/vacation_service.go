@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrVacationNotFound          = errors.New("operator is not on vacation")
+	ErrVacationColleagueNotFound = errors.New("vacation colleague not found")
+)
+
+var (
+	vacationDrainedToQueue      = metrics.NewCounter("vacation_conversations_drained_to_queue_total")
+	vacationDrainedToColleagues = metrics.NewCounter("vacation_conversations_drained_to_colleagues_total")
+)
+
+// VacationDrainResult holds the result of a drain run
+type VacationDrainResult struct {
+	Vacations    int
+	ToQueue      int
+	ToColleagues int
+	// Completed counts the vacations left with nothing to drain
+	Completed int
+}
+
+// VacationService keeps operators on vacation out of allocation and drains
+// their ALLOCATED conversations over the ramp-down they chose. Vacation is
+// independent of the operator's status: it ends only when they end it.
+type VacationService struct {
+	repos  *repository.RepositoryContainer
+	pool   *pgxpool.Pool
+	events domain.EventPublisher
+	audit  *AuditService
+	logger *logger.Logger
+}
+
+func NewVacationService(repos *repository.RepositoryContainer, pool *pgxpool.Pool, events domain.EventPublisher, audit *AuditService, log *logger.Logger) *VacationService {
+	return &VacationService{
+		repos:  repos,
+		pool:   pool,
+		events: events,
+		audit:  audit,
+		logger: log,
+	}
+}
+
+// Start puts the operator on vacation, or replaces the drain plan of the
+// vacation they are on. New allocations stop at once; the drain worker
+// moves their conversations until rampDown has passed.
+func (s *VacationService) Start(ctx context.Context, tenantID, operatorID uuid.UUID, drain domain.VacationDrain, colleagueIDs []uuid.UUID, rampDown time.Duration) (*domain.OperatorVacation, error) {
+	for _, id := range colleagueIDs {
+		colleague, err := s.repos.Operators.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, ErrVacationColleagueNotFound
+			}
+			return nil, err
+		}
+		if colleague.TenantID != tenantID {
+			return nil, ErrVacationColleagueNotFound
+		}
+	}
+
+	vacation, err := domain.NewOperatorVacation(tenantID, operatorID, drain, colleagueIDs, rampDown)
+	if err != nil {
+		return nil, err
+	}
+
+	var before map[string]interface{}
+	if previous, err := s.repos.OperatorVacations.GetByOperatorID(ctx, operatorID); err == nil {
+		before = vacationAuditSnapshot(previous)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	vacation, err = s.repos.OperatorVacations.Upsert(ctx, vacation)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Operator vacation started",
+		zap.String("operator_id", operatorID.String()),
+		zap.String("drain", string(vacation.Drain)),
+		zap.Time("drain_until", vacation.DrainUntil))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionOperatorVacationStart, domain.AuditEntityOperator, operatorID,
+		before, vacationAuditSnapshot(vacation)))
+
+	return vacation, nil
+}
+
+// Get returns the operator's vacation
+func (s *VacationService) Get(ctx context.Context, operatorID uuid.UUID) (*domain.OperatorVacation, error) {
+	vacation, err := s.repos.OperatorVacations.GetByOperatorID(ctx, operatorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrVacationNotFound
+	}
+	return vacation, err
+}
+
+// End takes the operator off vacation. Conversations not drained yet stay
+// with them.
+func (s *VacationService) End(ctx context.Context, tenantID, operatorID uuid.UUID) error {
+	vacation, err := s.Get(ctx, operatorID)
+	if err != nil {
+		return err
+	}
+	if err := s.repos.OperatorVacations.Delete(ctx, operatorID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrVacationNotFound
+		}
+		return err
+	}
+
+	s.logger.Info("Operator vacation ended", zap.String("operator_id", operatorID.String()))
+
+	recordAudit(ctx, s.audit, s.logger, domain.NewAuditEntry(tenantID, &operatorID,
+		domain.AuditActionOperatorVacationEnd, domain.AuditEntityOperator, operatorID,
+		vacationAuditSnapshot(vacation), nil))
+
+	return nil
+}
+
+// Drain moves the conversations due for up to batchSize draining vacations,
+// as the drain running every interval spreads them over the ramp-down.
+// Uses FOR UPDATE SKIP LOCKED so instances share the work.
+func (s *VacationService) Drain(ctx context.Context, batchSize int, interval time.Duration) (*VacationDrainResult, error) {
+	result := &VacationDrainResult{}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	repos := s.repos.WithTx(tx)
+
+	vacations, err := repos.OperatorVacations.GetAndLockDraining(ctx, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(vacations) == 0 {
+		return result, nil
+	}
+	result.Vacations = len(vacations)
+
+	now := time.Now().UTC()
+	var pending []*domain.Event
+	for _, vacation := range vacations {
+		events, err := s.drainVacation(ctx, repos, vacation, now, interval, result)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, events...)
+	}
+
+	if err := stageEvents(ctx, s.events, tx, pending...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	vacationDrainedToQueue.Add(int64(result.ToQueue))
+	vacationDrainedToColleagues.Add(int64(result.ToColleagues))
+
+	for _, event := range pending {
+		publishEvent(ctx, s.events, s.logger, event)
+	}
+
+	return result, nil
+}
+
+func (s *VacationService) drainVacation(ctx context.Context, repos *repository.RepositoryContainer, vacation *domain.OperatorVacation, now time.Time, interval time.Duration, result *VacationDrainResult) ([]*domain.Event, error) {
+	conversations, err := repos.ConversationRefs.GetAndLockAllocatedByOperator(ctx, vacation.OperatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Conversations locked elsewhere are left for the next run, so the drain
+	// is complete only once none are found
+	if len(conversations) == 0 {
+		vacation.DrainedAt = &now
+		result.Completed++
+		s.logger.Info("Operator vacation drained",
+			zap.String("operator_id", vacation.OperatorID.String()))
+		return nil, repos.OperatorVacations.UpdateDrain(ctx, vacation)
+	}
+
+	quota := vacation.DrainQuota(len(conversations), now, interval)
+	events := make([]*domain.Event, 0, quota)
+	for _, conv := range conversations[:quota] {
+		var colleagueID uuid.UUID
+		found := false
+		if vacation.Drain == domain.VacationDrainColleagues {
+			colleagueID, found, err = s.nextColleague(ctx, repos, vacation, conv.InboxID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		eventType := domain.EventConversationDeallocated
+		if found {
+			conv.AssignedOperatorID = &colleagueID
+			conv.UpdatedAt = now
+			eventType = domain.EventConversationReassigned
+			result.ToColleagues++
+		} else {
+			if err := conv.Deallocate(); err != nil {
+				return nil, err
+			}
+			result.ToQueue++
+		}
+		if err := repos.ConversationRefs.Update(ctx, conv); err != nil {
+			return nil, err
+		}
+
+		data := conversationEventData(conv)
+		data["previous_operator_id"] = vacation.OperatorID.String()
+		data["reason"] = "vacation_drain"
+		events = append(events, domain.NewEvent(conv.TenantID, eventType, data))
+	}
+
+	if err := repos.OperatorVacations.UpdateDrain(ctx, vacation); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// nextColleague picks the next colleague in round-robin order who is
+// subscribed to the inbox and not on vacation themselves
+func (s *VacationService) nextColleague(ctx context.Context, repos *repository.RepositoryContainer, vacation *domain.OperatorVacation, inboxID uuid.UUID) (uuid.UUID, bool, error) {
+	var checkErr error
+	id, found := vacation.NextColleagueFor(func(colleagueID uuid.UUID) bool {
+		if checkErr != nil {
+			return false
+		}
+		subscribed, err := repos.Subscriptions.IsSubscribed(ctx, colleagueID, inboxID)
+		if err != nil || !subscribed {
+			checkErr = err
+			return false
+		}
+		onVacation, err := isOnVacation(ctx, repos, colleagueID)
+		checkErr = err
+		return err == nil && !onVacation
+	})
+	return id, found, checkErr
+}
+
+// isOnVacation reports whether the operator is on vacation
+func isOnVacation(ctx context.Context, repos *repository.RepositoryContainer, operatorID uuid.UUID) (bool, error) {
+	_, err := repos.OperatorVacations.GetByOperatorID(ctx, operatorID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func vacationAuditSnapshot(vacation *domain.OperatorVacation) map[string]interface{} {
+	return map[string]interface{}{
+		"drain":         string(vacation.Drain),
+		"colleague_ids": uuidSliceToStringSlice(vacation.ColleagueIDs),
+		"drain_until":   vacation.DrainUntil.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/repository"
+)
+
+var (
+	waitEstimateCacheHits   = metrics.NewCounter("wait_estimate_cache_hits_total")
+	waitEstimateCacheMisses = metrics.NewCounter("wait_estimate_cache_misses_total")
+)
+
+// WaitEstimateConfig holds configuration for customer-facing wait estimates
+type WaitEstimateConfig struct {
+	// Window is how far back allocation throughput is measured
+	Window time.Duration
+	// CacheTTL is how long an estimate is served before it is recomputed
+	CacheTTL time.Duration
+}
+
+// DefaultWaitEstimateConfig returns sensible defaults
+func DefaultWaitEstimateConfig() WaitEstimateConfig {
+	return WaitEstimateConfig{
+		Window:   time.Hour,
+		CacheTTL: 30 * time.Second,
+	}
+}
+
+type cachedWaitEstimate struct {
+	tenantID  uuid.UUID
+	estimate  *domain.WaitEstimate
+	expiresAt time.Time
+}
+
+// WaitEstimateService estimates how long a new conversation waits for an
+// operator, for display in customer chat widgets. Estimates are cached per
+// inbox for CacheTTL since widgets poll them on every page view.
+type WaitEstimateService struct {
+	repos  *repository.RepositoryContainer
+	config WaitEstimateConfig
+	logger *logger.Logger
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedWaitEstimate
+}
+
+func NewWaitEstimateService(repos *repository.RepositoryContainer, config WaitEstimateConfig, log *logger.Logger) *WaitEstimateService {
+	return &WaitEstimateService{
+		repos:  repos,
+		config: config,
+		logger: log,
+		cache:  make(map[uuid.UUID]cachedWaitEstimate),
+	}
+}
+
+// Estimate returns the wait estimate for a new conversation in the inbox,
+// from its current queue depth and the allocations of the last Window
+func (s *WaitEstimateService) Estimate(ctx context.Context, tenantID, inboxID uuid.UUID) (*domain.WaitEstimate, error) {
+	now := time.Now().UTC()
+
+	s.mu.Lock()
+	cached, ok := s.cache[inboxID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		if cached.tenantID != tenantID {
+			return nil, domain.ErrNotFound
+		}
+		waitEstimateCacheHits.Inc()
+		return cached.estimate, nil
+	}
+	waitEstimateCacheMisses.Inc()
+
+	inbox, err := s.repos.Inboxes.GetByID(ctx, inboxID)
+	if err != nil {
+		return nil, err
+	}
+	if inbox.TenantID != tenantID {
+		return nil, domain.ErrNotFound
+	}
+
+	depth, err := s.repos.ConversationRefs.CountQueuedByInbox(ctx, inboxID)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.repos.AuditLogs.CountInboxAllocations(ctx, tenantID, inboxID, now.Add(-s.config.Window))
+	if err != nil {
+		return nil, err
+	}
+	estimate := domain.NewWaitEstimate(inboxID, depth, allocations, s.config.Window, now)
+
+	s.mu.Lock()
+	s.pruneLocked(now)
+	s.cache[inboxID] = cachedWaitEstimate{
+		tenantID:  tenantID,
+		estimate:  estimate,
+		expiresAt: now.Add(s.config.CacheTTL),
+	}
+	s.mu.Unlock()
+
+	return estimate, nil
+}
+
+// pruneLocked drops expired estimates; callers hold s.mu
+func (s *WaitEstimateService) pruneLocked(now time.Time) {
+	for inboxID, cached := range s.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(s.cache, inboxID)
+		}
+	}
+}
+
+// CacheTTL is how long clients may reuse an estimate
+func (s *WaitEstimateService) CacheTTL() time.Duration {
+	return s.config.CacheTTL
+}
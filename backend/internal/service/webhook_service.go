@@ -0,0 +1,436 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/pkg/metrics"
+	"github.com/inbox-allocation-service/internal/pkg/retry"
+	"github.com/inbox-allocation-service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// webhookSandboxBlocked counts deliveries of sandbox tenants, which are
+// never sent
+var webhookSandboxBlocked = metrics.NewCounter("webhook_deliveries_sandbox_blocked_total")
+
+// Headers sent with every webhook delivery
+const (
+	WebhookHeaderDeliveryID = "X-Webhook-ID"
+	WebhookHeaderEvent      = "X-Webhook-Event"
+	WebhookHeaderTimestamp  = "X-Webhook-Timestamp"
+	WebhookHeaderSignature  = "X-Webhook-Signature"
+)
+
+// WebhookConfig holds configuration for webhook delivery
+type WebhookConfig struct {
+	// Retry controls the persisted retry schedule; MaxAttempts is the dead-letter threshold
+	Retry retry.Config
+	// RequestTimeout bounds a single HTTP delivery attempt
+	RequestTimeout time.Duration
+	// LeaseDuration is how long a claimed delivery is hidden from other workers
+	LeaseDuration time.Duration
+}
+
+// DefaultWebhookConfig returns sensible defaults
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		Retry: retry.Config{
+			MaxAttempts:    8,
+			InitialBackoff: 30 * time.Second,
+			MaxBackoff:     1 * time.Hour,
+			BackoffFactor:  2.0,
+			Jitter:         0.1,
+		},
+		RequestTimeout: 10 * time.Second,
+		LeaseDuration:  1 * time.Minute,
+	}
+}
+
+// WebhookDeliveryResult holds the result of a delivery cycle
+type WebhookDeliveryResult struct {
+	Processed  int
+	Delivered  int
+	Retrying   int
+	DeadLetter int
+}
+
+type WebhookService struct {
+	repos  *repository.RepositoryContainer
+	client *http.Client
+	config WebhookConfig
+	logger *logger.Logger
+}
+
+// NewWebhookService creates the service. client must refuse the service's own
+// network (see outbound.NewClient), since tenants choose the URLs it calls.
+func NewWebhookService(repos *repository.RepositoryContainer, client *http.Client, config WebhookConfig, log *logger.Logger) *WebhookService {
+	return &WebhookService{
+		repos:  repos,
+		client: client,
+		config: config,
+		logger: log,
+	}
+}
+
+// ==================== CRUD ====================
+
+// CreateWebhook registers a new endpoint. A signing secret is generated when none is given.
+// Permission: Admin (enforced by router)
+func (s *WebhookService) CreateWebhook(ctx context.Context, tenantID uuid.UUID, createdBy *uuid.UUID, url string, secret *string, eventTypes []domain.EventType, operatorFields []domain.WebhookOperatorField) (*domain.Webhook, error) {
+	signingSecret := ""
+	if secret != nil && *secret != "" {
+		signingSecret = *secret
+	} else {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		signingSecret = generated
+	}
+
+	webhook := domain.NewWebhook(tenantID, url, signingSecret, eventTypes, createdBy)
+	webhook.OperatorFields = operatorFields
+	if err := s.repos.Webhooks.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook created",
+		zap.String("webhook_id", webhook.ID.String()),
+		zap.String("tenant_id", tenantID.String()),
+		zap.String("url", url))
+
+	return webhook, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, tenantID uuid.UUID) ([]*domain.Webhook, error) {
+	return s.repos.Webhooks.GetByTenantID(ctx, tenantID)
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, tenantID, webhookID uuid.UUID) (*domain.Webhook, error) {
+	webhook, err := s.repos.Webhooks.GetByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	if webhook.TenantID != tenantID {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook applies a partial update; nil arguments are left unchanged
+func (s *WebhookService) UpdateWebhook(ctx context.Context, tenantID, webhookID uuid.UUID, url, secret *string, eventTypes []domain.EventType, operatorFields []domain.WebhookOperatorField, isActive *bool) (*domain.Webhook, error) {
+	webhook, err := s.GetWebhook(ctx, tenantID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if url != nil {
+		webhook.URL = *url
+	}
+	if secret != nil {
+		webhook.Secret = *secret
+	}
+	if eventTypes != nil {
+		webhook.EventTypes = eventTypes
+	}
+	if operatorFields != nil {
+		webhook.OperatorFields = operatorFields
+	}
+	if isActive != nil {
+		webhook.IsActive = *isActive
+	}
+	webhook.UpdatedAt = time.Now().UTC()
+
+	if err := s.repos.Webhooks.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook updated",
+		zap.String("webhook_id", webhook.ID.String()),
+		zap.Bool("is_active", webhook.IsActive))
+
+	return webhook, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, tenantID, webhookID uuid.UUID) error {
+	if _, err := s.GetWebhook(ctx, tenantID, webhookID); err != nil {
+		return err
+	}
+	if err := s.repos.Webhooks.Delete(ctx, webhookID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Webhook deleted", zap.String("webhook_id", webhookID.String()))
+	return nil
+}
+
+// ListDeliveries returns the most recent deliveries of a webhook, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, tenantID, webhookID uuid.UUID, limit int) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, tenantID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repos.WebhookDeliveries.GetByWebhookID(ctx, webhookID, limit)
+}
+
+// ==================== Publishing ====================
+
+// Publish implements domain.EventPublisher by enqueuing one delivery per
+// subscribed webhook. Actual HTTP delivery happens in the webhook worker.
+// Assignment events sent to a webhook with operator fields carry an
+// "operator" object with the assigned operator's profile.
+func (s *WebhookService) Publish(ctx context.Context, event *domain.Event) error {
+	webhooks, err := s.repos.Webhooks.GetActiveForEvent(ctx, event.TenantID, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(NewEventEnvelope(event))
+	if err != nil {
+		return err
+	}
+
+	var operator *domain.Operator
+	if event.Type.IsAssignment() && anyWebhookEnriches(webhooks) {
+		operator = s.assignedOperator(ctx, event)
+	}
+
+	for _, webhook := range webhooks {
+		payload := payload
+		if operator != nil && len(webhook.OperatorFields) > 0 {
+			if payload, err = enrichedPayload(event, webhook.OperatorPayload(operator)); err != nil {
+				return err
+			}
+		}
+
+		delivery := domain.NewWebhookDelivery(webhook.ID, event.TenantID, event.ID, event.Type, payload)
+		if err := s.repos.WebhookDeliveries.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Debug("Webhook deliveries enqueued",
+		zap.String("event_type", string(event.Type)),
+		zap.String("event_id", event.ID.String()),
+		zap.Int("count", len(webhooks)))
+
+	return nil
+}
+
+// assignedOperator loads the operator an assignment event names. A failed
+// lookup is logged and the event is delivered without the operator object.
+func (s *WebhookService) assignedOperator(ctx context.Context, event *domain.Event) *domain.Operator {
+	raw, _ := event.Data["assigned_operator_id"].(string)
+	operatorID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	operator, err := s.repos.Operators.GetByID(ctx, operatorID)
+	if err != nil {
+		s.logger.Warn("Failed to load operator for webhook payload",
+			zap.String("event_id", event.ID.String()),
+			zap.String("operator_id", raw),
+			zap.Error(err))
+		return nil
+	}
+	return operator
+}
+
+func anyWebhookEnriches(webhooks []*domain.Webhook) bool {
+	for _, webhook := range webhooks {
+		if len(webhook.OperatorFields) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// enrichedPayload marshals the event with the operator object added to a
+// copy of its data
+func enrichedPayload(event *domain.Event, operator map[string]interface{}) ([]byte, error) {
+	envelope := NewEventEnvelope(event)
+	envelope.Data = make(map[string]interface{}, len(event.Data)+1)
+	for k, v := range event.Data {
+		envelope.Data[k] = v
+	}
+	envelope.Data["operator"] = operator
+	return json.Marshal(envelope)
+}
+
+// ==================== Delivery ====================
+
+// DeliverDue claims due deliveries and attempts each one once.
+// Claiming uses FOR UPDATE SKIP LOCKED plus a lease, so several replicas can run the worker.
+func (s *WebhookService) DeliverDue(ctx context.Context, batchSize int) (*WebhookDeliveryResult, error) {
+	result := &WebhookDeliveryResult{}
+
+	leaseUntil := time.Now().UTC().Add(s.config.LeaseDuration)
+	deliveries, err := s.repos.WebhookDeliveries.ClaimDue(ctx, batchSize, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Processed = len(deliveries)
+	webhooks := make(map[uuid.UUID]*domain.Webhook)
+	sandboxes := make(map[uuid.UUID]bool)
+
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repos.Webhooks.GetByID(ctx, delivery.WebhookID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				s.logger.Error("Failed to load webhook for delivery",
+					zap.String("delivery_id", delivery.ID.String()),
+					zap.Error(err))
+				continue // Lease expires and the delivery is retried later
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		sandbox, ok := sandboxes[delivery.TenantID]
+		if !ok {
+			tenant, err := s.repos.Tenants.GetByID(ctx, delivery.TenantID)
+			if err != nil {
+				s.logger.Error("Failed to load tenant for delivery",
+					zap.String("delivery_id", delivery.ID.String()),
+					zap.Error(err))
+				continue // Lease expires and the delivery is retried later
+			}
+			sandbox = tenant.Sandbox
+			sandboxes[delivery.TenantID] = sandbox
+		}
+
+		if sandbox {
+			s.holdSandboxDelivery(delivery)
+		} else {
+			s.attempt(ctx, webhook, delivery)
+		}
+
+		if err := s.repos.WebhookDeliveries.UpdateAttempt(ctx, delivery); err != nil {
+			s.logger.Error("Failed to record webhook delivery attempt",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err))
+			continue
+		}
+
+		switch delivery.Status {
+		case domain.WebhookDeliveryDelivered:
+			result.Delivered++
+		case domain.WebhookDeliveryDeadLetter:
+			result.DeadLetter++
+			s.logger.Warn("Webhook delivery moved to dead letter",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.String("webhook_id", delivery.WebhookID.String()),
+				zap.Int("attempts", delivery.AttemptCount))
+		default:
+			result.Retrying++
+		}
+	}
+
+	return result, nil
+}
+
+// attempt performs a single HTTP delivery and records the outcome on the delivery
+func (s *WebhookService) attempt(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	maxAttempts := s.config.Retry.MaxAttempts
+	nextAttemptAt := time.Now().UTC().Add(s.config.Retry.Backoff(delivery.AttemptCount + 1))
+
+	if webhook == nil || !webhook.IsActive {
+		// Endpoint removed or disabled: nothing to retry against
+		delivery.MarkFailed(nil, "webhook is inactive", delivery.AttemptCount+1, nextAttemptAt)
+		return
+	}
+
+	statusCode, err := s.send(ctx, webhook, delivery)
+	if err != nil {
+		delivery.MarkFailed(statusCode, err.Error(), maxAttempts, nextAttemptAt)
+		return
+	}
+	delivery.MarkDelivered(*statusCode)
+}
+
+// holdSandboxDelivery moves a sandbox tenant's delivery straight to dead
+// letter. Sandbox tenants are anonymous trials, so their webhooks never leave
+// the process; the prospect sees each payload in the webhook's deliveries.
+func (s *WebhookService) holdSandboxDelivery(delivery *domain.WebhookDelivery) {
+	delivery.MarkFailed(nil, "sandbox tenants do not send webhooks", delivery.AttemptCount+1, time.Now().UTC())
+	webhookSandboxBlocked.Inc()
+}
+
+// send POSTs the payload; any non-2xx response is treated as a failure
+func (s *WebhookService) send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (*int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	if s.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.RequestTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderDeliveryID, delivery.ID.String())
+	req.Header.Set(WebhookHeaderEvent, string(delivery.EventType))
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(WebhookHeaderSignature, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return &statusCode, fmt.Errorf("endpoint responded with status %d", statusCode)
+	}
+	return &statusCode, nil
+}
+
+// ==================== Signing ====================
+
+// SignWebhookPayload returns the signature header value for a payload.
+// Receivers recompute HMAC-SHA256 over "<timestamp>.<body>" with the shared secret.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
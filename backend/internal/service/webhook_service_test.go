@@ -0,0 +1,119 @@
+package service
+
+import (
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/outbound"
+	"github.com/inbox-allocation-service/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"type":"conversation.resolved"}`)
+
+	sig := SignWebhookPayload("secret", "1700000000", body)
+
+	assert.Contains(t, sig, "sha256=")
+	assert.Equal(t, sig, SignWebhookPayload("secret", "1700000000", body))
+	assert.NotEqual(t, sig, SignWebhookPayload("other-secret", "1700000000", body))
+	assert.NotEqual(t, sig, SignWebhookPayload("secret", "1700000001", body))
+}
+
+func TestWebhookService_Attempt(t *testing.T) {
+	ctx := testutil.TestContext(t)
+	svc := &WebhookService{client: http.DefaultClient, config: DefaultWebhookConfig()}
+	payload := []byte(`{"id":"evt"}`)
+
+	t.Run("2xx marks delivered and signs request", func(t *testing.T) {
+		var gotSig, gotTimestamp string
+		var gotBody []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotSig = r.Header.Get(WebhookHeaderSignature)
+			gotTimestamp = r.Header.Get(WebhookHeaderTimestamp)
+			gotBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		webhook := domain.NewWebhook(uuid.New(), server.URL, "test-secret-123456", []domain.EventType{domain.EventConversationResolved}, nil)
+		delivery := domain.NewWebhookDelivery(webhook.ID, webhook.TenantID, uuid.New(), domain.EventConversationResolved, payload)
+
+		svc.attempt(ctx, webhook, delivery)
+
+		assert.Equal(t, domain.WebhookDeliveryDelivered, delivery.Status)
+		assert.Equal(t, payload, gotBody)
+		assert.True(t, hmac.Equal([]byte(gotSig), []byte(SignWebhookPayload(webhook.Secret, gotTimestamp, payload))))
+	})
+
+	t.Run("5xx schedules retry", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		webhook := domain.NewWebhook(uuid.New(), server.URL, "test-secret-123456", []domain.EventType{domain.EventConversationResolved}, nil)
+		delivery := domain.NewWebhookDelivery(webhook.ID, webhook.TenantID, uuid.New(), domain.EventConversationResolved, payload)
+		before := delivery.NextAttemptAt
+
+		svc.attempt(ctx, webhook, delivery)
+
+		assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, 1, delivery.AttemptCount)
+		require.NotNil(t, delivery.LastStatusCode)
+		assert.Equal(t, http.StatusServiceUnavailable, *delivery.LastStatusCode)
+		assert.True(t, delivery.NextAttemptAt.After(before))
+	})
+
+	t.Run("internal address is refused without a status code", func(t *testing.T) {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		guarded := &WebhookService{client: outbound.NewClient(), config: DefaultWebhookConfig()}
+		webhook := domain.NewWebhook(uuid.New(), server.URL, "test-secret-123456", []domain.EventType{domain.EventConversationResolved}, nil)
+		delivery := domain.NewWebhookDelivery(webhook.ID, webhook.TenantID, uuid.New(), domain.EventConversationResolved, payload)
+
+		guarded.attempt(ctx, webhook, delivery)
+
+		assert.False(t, called)
+		assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+		assert.Nil(t, delivery.LastStatusCode)
+	})
+
+	t.Run("inactive webhook goes straight to dead letter", func(t *testing.T) {
+		webhook := domain.NewWebhook(uuid.New(), "http://127.0.0.1:1", "test-secret-123456", nil, nil)
+		webhook.IsActive = false
+		delivery := domain.NewWebhookDelivery(webhook.ID, webhook.TenantID, uuid.New(), domain.EventConversationResolved, payload)
+
+		svc.attempt(ctx, webhook, delivery)
+
+		assert.Equal(t, domain.WebhookDeliveryDeadLetter, delivery.Status)
+	})
+}
+
+func TestWebhookService_HoldSandboxDelivery(t *testing.T) {
+	svc := &WebhookService{client: http.DefaultClient, config: DefaultWebhookConfig()}
+	payload := []byte(`{"id":"evt"}`)
+
+	for _, url := range []string{"https://hooks.example.com/inbox", "http://localhost:9000/hook"} {
+		t.Run(url, func(t *testing.T) {
+			webhook := domain.NewWebhook(uuid.New(), url, "test-secret-123456", nil, nil)
+			delivery := domain.NewWebhookDelivery(webhook.ID, webhook.TenantID, uuid.New(), domain.EventConversationResolved, payload)
+
+			svc.holdSandboxDelivery(delivery)
+
+			assert.Equal(t, domain.WebhookDeliveryDeadLetter, delivery.Status)
+			assert.Equal(t, payload, delivery.Payload)
+			require.NotNil(t, delivery.LastError)
+		})
+	}
+}
@@ -20,7 +20,7 @@ type PostgresContainer struct {
 }
 
 // NewPostgresContainer creates a new PostgreSQL container for testing
-func NewPostgresContainer(t *testing.T) *PostgresContainer {
+func NewPostgresContainer(t testing.TB) *PostgresContainer {
 	ctx := context.Background()
 
 	container, err := postgres.RunContainer(ctx,
@@ -86,7 +86,12 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			priority_weight_beta DECIMAL(5,4) NOT NULL DEFAULT 0.4,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			updated_by UUID
+			updated_by UUID,
+			first_contact_boost DECIMAL(5,4) NOT NULL DEFAULT 0,
+			grace_period_seconds INTEGER CHECK (grace_period_seconds >= 0),
+			intake_limit_per_hour INTEGER CHECK (intake_limit_per_hour > 0),
+			allocation_engine TEXT NOT NULL DEFAULT 'v1' CHECK (allocation_engine IN ('v1', 'v2')),
+			sandbox BOOLEAN NOT NULL DEFAULT FALSE
 		)`,
 
 		// Inboxes
@@ -97,6 +102,10 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			display_name VARCHAR(255) NOT NULL,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			is_restricted BOOLEAN NOT NULL DEFAULT FALSE,
+			escalation_inbox_id UUID REFERENCES inboxes(id) ON DELETE SET NULL,
+			auto_resolve_after_seconds INTEGER CHECK (auto_resolve_after_seconds > 0),
+			business_hours JSONB,
 			UNIQUE(tenant_id, phone_number)
 		)`,
 
@@ -106,7 +115,10 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
 			role VARCHAR(20) NOT NULL DEFAULT 'OPERATOR',
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			name VARCHAR(255),
+			email VARCHAR(255),
+			languages TEXT[] NOT NULL DEFAULT '{}'
 		)`,
 
 		// Operator status
@@ -114,7 +126,32 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			operator_id UUID NOT NULL UNIQUE REFERENCES operators(id) ON DELETE CASCADE,
 			status VARCHAR(20) NOT NULL DEFAULT 'OFFLINE',
-			last_status_change_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			last_status_change_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			focus_until TIMESTAMPTZ
+		)`,
+
+		// Inbox admins
+		`CREATE TABLE IF NOT EXISTS inbox_admins (
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			granted_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (operator_id, inbox_id)
+		)`,
+
+		// Operator schedules
+		`CREATE TABLE IF NOT EXISTS operator_schedules (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			day_of_week SMALLINT NOT NULL,
+			start_time TIME NOT NULL,
+			end_time TIME NOT NULL,
+			timezone VARCHAR(64) NOT NULL,
+			created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 		)`,
 
 		// Subscriptions
@@ -126,6 +163,18 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			UNIQUE(operator_id, inbox_id)
 		)`,
 
+		// Customers
+		`CREATE TABLE IF NOT EXISTS customers (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			phone_number VARCHAR(20) NOT NULL,
+			name VARCHAR(255),
+			metadata JSONB NOT NULL DEFAULT '{}',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE(tenant_id, phone_number)
+		)`,
+
 		// Conversations
 		`CREATE TABLE IF NOT EXISTS conversation_refs (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -141,19 +190,97 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			resolved_at TIMESTAMPTZ,
+			reopened_count INT NOT NULL DEFAULT 0,
+			category VARCHAR(50),
+			sla_breached_at TIMESTAMPTZ,
+			snoozed_until TIMESTAMPTZ,
+			snooze_operator_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+			priority_override DECIMAL(10,6),
+			is_first_contact BOOLEAN NOT NULL DEFAULT FALSE,
+			version INTEGER NOT NULL DEFAULT 1,
+			language VARCHAR(3),
+			customer_id UUID REFERENCES customers(id) ON DELETE SET NULL,
+			normalized_phone VARCHAR(20),
+			due_at TIMESTAMPTZ,
+			overdue_at TIMESTAMPTZ,
 			UNIQUE(tenant_id, external_conversation_id)
 		)`,
 
+		// Inbox SLA policies
+		`CREATE TABLE IF NOT EXISTS inbox_sla_policies (
+			inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			first_assignment_seconds INTEGER,
+			resolution_seconds INTEGER,
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
 		// Labels
 		`CREATE TABLE IF NOT EXISTS labels (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
-			inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			inbox_id UUID REFERENCES inboxes(id) ON DELETE CASCADE,
 			name VARCHAR(100) NOT NULL,
 			color VARCHAR(7),
 			created_by UUID REFERENCES operators(id),
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-			UNIQUE(inbox_id, name)
+			version INTEGER NOT NULL DEFAULT 1,
+			parent_label_id UUID REFERENCES labels(id) ON DELETE SET NULL,
+			scope VARCHAR(10) NOT NULL DEFAULT 'INBOX',
+			CHECK ((scope = 'INBOX' AND inbox_id IS NOT NULL) OR (scope = 'TENANT' AND inbox_id IS NULL))
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_inbox_name ON labels(inbox_id, name) WHERE scope = 'INBOX'`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_tenant_name ON labels(tenant_id, name) WHERE scope = 'TENANT'`,
+
+		// Label versions
+		`CREATE TABLE IF NOT EXISTS label_versions (
+			label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+			version INTEGER NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			color VARCHAR(7),
+			changed_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (label_id, version)
+		)`,
+
+		// Inbox category quotas
+		`CREATE TABLE IF NOT EXISTS inbox_category_quotas (
+			inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			share_percent INTEGER NOT NULL CHECK (share_percent BETWEEN 1 AND 100),
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (inbox_id, label_id)
+		)`,
+
+		// Event outbox
+		`CREATE TABLE IF NOT EXISTS event_outbox (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			event_type VARCHAR(100) NOT NULL,
+			data JSONB NOT NULL DEFAULT '{}',
+			occurred_at TIMESTAMPTZ NOT NULL,
+			attempt_count INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			last_error TEXT,
+			published_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Operator allocation health
+		`CREATE TABLE IF NOT EXISTS operator_allocation_health (
+			operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (weight > 0 AND weight <= 1),
+			allocations INTEGER NOT NULL DEFAULT 0,
+			returns INTEGER NOT NULL DEFAULT 0,
+			evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			override_weight DOUBLE PRECISION CHECK (override_weight > 0 AND override_weight <= 1),
+			override_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			override_at TIMESTAMPTZ
 		)`,
 
 		// Conversation labels
@@ -162,6 +289,7 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
 			label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			label_version INTEGER,
 			UNIQUE(conversation_id, label_id)
 		)`,
 
@@ -184,18 +312,303 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 			method VARCHAR(10) NOT NULL,
 			request_hash VARCHAR(64),
 			response_status INT NOT NULL,
-			response_body JSONB NOT NULL,
+			response_body JSONB,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			expires_at TIMESTAMPTZ NOT NULL,
+			response_ciphertext BYTEA,
+			encryption_key_id VARCHAR(64),
+			status VARCHAR(16) NOT NULL DEFAULT 'COMPLETED',
+			locked_until TIMESTAMPTZ,
 			UNIQUE(tenant_id, key)
 		)`,
 
+		// Allocation intents
+		`CREATE TABLE IF NOT EXISTS allocation_intents (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			kind VARCHAR(16) NOT NULL,
+			idempotency_key VARCHAR(255),
+			conversation_id UUID REFERENCES conversation_refs(id) ON DELETE SET NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+			recovered BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			resolved_at TIMESTAMPTZ
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS uq_allocation_intents_idempotency_key
+			ON allocation_intents(tenant_id, idempotency_key) WHERE idempotency_key IS NOT NULL`,
+
+		// Inbox queue ranks
+		`CREATE TABLE IF NOT EXISTS inbox_queue_ranks (
+			conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			rank INT NOT NULL,
+			priority_score DECIMAL(10,6) NOT NULL,
+			last_message_at TIMESTAMPTZ NOT NULL,
+			refreshed_at TIMESTAMPTZ NOT NULL
+		)`,
+
+		// Conversation notes
+		`CREATE TABLE IF NOT EXISTS conversation_notes (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			kind VARCHAR(16) NOT NULL,
+			author_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+			recipient_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+			body TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Conversation escalations
+		`CREATE TABLE IF NOT EXISTS conversation_escalations (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			operator_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+			escalated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			from_inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			to_inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			reason TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Inbox checklist templates
+		`CREATE TABLE IF NOT EXISTS inbox_checklist_templates (
+			inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			items TEXT[] NOT NULL DEFAULT '{}',
+			pinned_note TEXT,
+			strict BOOLEAN NOT NULL DEFAULT FALSE,
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Conversation checklist items
+		`CREATE TABLE IF NOT EXISTS conversation_checklist_items (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			label TEXT NOT NULL,
+			completed_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			completed_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			UNIQUE (conversation_id, position)
+		)`,
+
+		// Priority score components
+		`CREATE TABLE IF NOT EXISTS priority_score_components (
+			id UUID PRIMARY KEY,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			message_count INTEGER NOT NULL,
+			last_message_at TIMESTAMPTZ NOT NULL,
+			message_factor DECIMAL(10,6) NOT NULL,
+			delay_factor DECIMAL(10,6) NOT NULL,
+			weight_alpha DECIMAL(5,4) NOT NULL,
+			weight_beta DECIMAL(5,4) NOT NULL,
+			rule_boost DECIMAL(10,6) NOT NULL DEFAULT 0,
+			priority_score DECIMAL(10,6) NOT NULL,
+			computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			first_contact_boost DECIMAL(10,6) NOT NULL DEFAULT 0
+		)`,
+
+		// Audit log
+		`CREATE TABLE IF NOT EXISTS audit_log (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			actor_id UUID,
+			action VARCHAR(64) NOT NULL,
+			entity_type VARCHAR(32) NOT NULL,
+			entity_id UUID NOT NULL,
+			before_state JSONB,
+			after_state JSONB,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Anomaly detection
+		`CREATE TABLE IF NOT EXISTS tenant_anomaly_settings (
+			tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+			sensitivity VARCHAR(10) NOT NULL,
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS tenant_maintenance_settings (
+			tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+			window_start TIME,
+			window_end TIME,
+			timezone VARCHAR(64),
+			override_mode VARCHAR(10),
+			override_until TIMESTAMPTZ,
+			override_reason TEXT,
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS operator_presence (
+			operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			connected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS operator_devices (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			platform VARCHAR(8) NOT NULL CHECK (platform IN ('FCM', 'APNS')),
+			token VARCHAR(4096) NOT NULL UNIQUE,
+			event_types TEXT[] NOT NULL DEFAULT '{}',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS operator_vacations (
+			operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			drain VARCHAR(20) NOT NULL DEFAULT 'NONE',
+			colleague_ids UUID[] NOT NULL DEFAULT '{}',
+			drain_until TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			drained_at TIMESTAMPTZ,
+			next_colleague INT NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE IF NOT EXISTS anomalies (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			kind VARCHAR(32) NOT NULL,
+			operator_id UUID REFERENCES operators(id) ON DELETE CASCADE,
+			observed INT NOT NULL,
+			baseline DOUBLE PRECISION NOT NULL,
+			threshold DOUBLE PRECISION NOT NULL,
+			window_seconds INT NOT NULL,
+			detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
+		// Priority weight experiments
+		`CREATE TABLE IF NOT EXISTS priority_experiments (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+			name VARCHAR(100) NOT NULL,
+			weight_alpha DECIMAL(5,4) NOT NULL,
+			weight_beta DECIMAL(5,4) NOT NULL,
+			traffic_percent INTEGER NOT NULL,
+			status VARCHAR(20) NOT NULL DEFAULT 'RUNNING',
+			created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			stopped_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_priority_experiments_running ON priority_experiments(inbox_id) WHERE status = 'RUNNING'`,
+		`CREATE TABLE IF NOT EXISTS priority_experiment_assignments (
+			experiment_id UUID NOT NULL REFERENCES priority_experiments(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			arm VARCHAR(20) NOT NULL,
+			assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			allocated_at TIMESTAMPTZ,
+			PRIMARY KEY (experiment_id, conversation_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS conversation_reads (
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			last_read_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (operator_id, conversation_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS inbox_staleness_policies (
+			inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			stale_after_seconds INTEGER NOT NULL CHECK (stale_after_seconds > 0),
+			deallocate_grace_seconds INTEGER CHECK (deallocate_grace_seconds > 0),
+			updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS conversation_activity (
+			conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			last_activity_at TIMESTAMPTZ NOT NULL,
+			last_activity_kind VARCHAR(10) NOT NULL,
+			stale_at TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS conversation_share_links (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			include_pii BOOLEAN NOT NULL DEFAULT FALSE,
+			created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+			expires_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ,
+			access_count INTEGER NOT NULL DEFAULT 0,
+			last_accessed_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE IF NOT EXISTS reconciliation_divergences (
+			conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			kind VARCHAR(30) NOT NULL,
+			local_state VARCHAR(20) NOT NULL,
+			upstream_status VARCHAR(20) NOT NULL,
+			first_detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			last_checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			corrected_at TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS conversation_transfers (
+			id UUID PRIMARY KEY,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+			from_operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			to_operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			note TEXT,
+			status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+			expires_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			responded_at TIMESTAMPTZ
+		)`,
+		`CREATE TABLE IF NOT EXISTS customer_operator_affinity (
+			customer_id UUID PRIMARY KEY REFERENCES customers(id) ON DELETE CASCADE,
+			tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+			operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+			handled_at TIMESTAMPTZ NOT NULL
+		)`,
+
+		// Rolling upgrade compatibility (schema_migrations mirrors golang-migrate)
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS worker_instances (
+			instance_id UUID PRIMARY KEY,
+			binary_version VARCHAR(64) NOT NULL,
+			worker_protocol INTEGER NOT NULL,
+			min_schema_version BIGINT NOT NULL,
+			max_schema_version BIGINT NOT NULL,
+			workers_enabled BOOLEAN NOT NULL,
+			started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+
 		// Indexes
 		`CREATE INDEX IF NOT EXISTS idx_conversation_refs_state ON conversation_refs(state)`,
 		`CREATE INDEX IF NOT EXISTS idx_conversation_refs_inbox_state ON conversation_refs(inbox_id, state)`,
 		`CREATE INDEX IF NOT EXISTS idx_conversation_refs_priority ON conversation_refs(priority_score DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_queue ON conversation_refs(tenant_id, inbox_id, priority_score DESC, last_message_at) WHERE state = 'QUEUED'`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_pinned ON conversation_refs(tenant_id, inbox_id) WHERE state = 'QUEUED' AND priority_override IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_phone_prefix ON conversation_refs(tenant_id, normalized_phone text_pattern_ops) WHERE normalized_phone IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_phone_suffix ON conversation_refs(tenant_id, reverse(normalized_phone) text_pattern_ops) WHERE normalized_phone IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_external_id_fts ON conversation_refs USING GIN (to_tsvector('simple', external_conversation_id))`,
+		`CREATE INDEX IF NOT EXISTS idx_customers_name_fts ON customers USING GIN (to_tsvector('simple', coalesce(name, '')))`,
+		`CREATE INDEX IF NOT EXISTS idx_conversation_notes_fts ON conversation_notes USING GIN (to_tsvector('simple', body))`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_due_pending ON conversation_refs(due_at) WHERE due_at IS NOT NULL AND overdue_at IS NULL AND state <> 'RESOLVED'`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_inbox_open_activity ON conversation_refs(inbox_id, last_message_at) WHERE state <> 'RESOLVED'`,
+		`CREATE INDEX IF NOT EXISTS idx_conversations_inbox_due ON conversation_refs(inbox_id, due_at) WHERE due_at IS NOT NULL AND state <> 'RESOLVED'`,
 		`CREATE INDEX IF NOT EXISTS idx_grace_period_expires ON grace_period_assignments(expires_at)`,
 		`CREATE INDEX IF NOT EXISTS idx_idempotency_expires ON idempotency_keys(expires_at)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS uq_conversation_transfers_pending ON conversation_transfers(conversation_id) WHERE status = 'PENDING'`,
+		`CREATE INDEX IF NOT EXISTS idx_customer_operator_affinity_operator ON customer_operator_affinity(operator_id, handled_at DESC)`,
 	}
 
 	for _, sql := range migrations {
@@ -210,13 +623,46 @@ func (pc *PostgresContainer) RunMigrations(ctx context.Context) error {
 // CleanTables truncates all tables for test isolation
 func (pc *PostgresContainer) CleanTables(ctx context.Context) error {
 	tables := []string{
+		"worker_instances",
+		"schema_migrations",
+		"customer_operator_affinity",
+		"conversation_transfers",
+		"anomalies",
+		"tenant_anomaly_settings",
+		"tenant_maintenance_settings",
+		"operator_presence",
+		"operator_devices",
+		"operator_vacations",
+		"audit_log",
+		"event_outbox",
+		"reconciliation_divergences",
+		"conversation_share_links",
+		"conversation_activity",
+		"inbox_staleness_policies",
+		"conversation_reads",
+		"priority_experiment_assignments",
+		"priority_experiments",
+		"priority_score_components",
+		"conversation_notes",
+		"conversation_escalations",
+		"conversation_checklist_items",
+		"inbox_queue_ranks",
+		"allocation_intents",
 		"idempotency_keys",
 		"grace_period_assignments",
 		"conversation_labels",
+		"label_versions",
+		"inbox_category_quotas",
+		"inbox_checklist_templates",
 		"labels",
 		"conversation_refs",
+		"customers",
+		"inbox_sla_policies",
 		"operator_inbox_subscriptions",
+		"inbox_admins",
+		"operator_schedules",
 		"operator_status",
+		"operator_allocation_health",
 		"operators",
 		"inboxes",
 		"tenants",
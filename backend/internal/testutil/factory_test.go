@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_Deterministic(t *testing.T) {
+	a, b := NewFactory(42), NewFactory(42)
+	for i := 0; i < 20; i++ {
+		tenant := a.Tenant()
+		assert.Equal(t, tenant, b.Tenant())
+		inbox := a.Inbox(tenant.ID)
+		assert.Equal(t, inbox, b.Inbox(tenant.ID))
+		assert.Equal(t, a.Conversation(tenant.ID, inbox.ID), b.Conversation(tenant.ID, inbox.ID))
+		assert.Equal(t, a.Label(tenant.ID, inbox.ID), b.Label(tenant.ID, inbox.ID))
+	}
+
+	assert.NotEqual(t, NewFactory(1).UUID(), NewFactory(2).UUID())
+}
+
+func TestFactory_Valid(t *testing.T) {
+	f := SeededFactory(t)
+	for i := 0; i < 200; i++ {
+		tenant := f.Tenant()
+		assert.True(t, tenant.PriorityWeightAlpha.Add(tenant.PriorityWeightBeta).Equal(decimal.NewFromInt(1)), "weights sum to 1")
+
+		operator := f.Operator(tenant.ID)
+		assert.True(t, operator.Role.IsValid())
+		assert.True(t, f.OperatorStatus(operator.ID).Status.IsValid())
+
+		conv := f.Conversation(tenant.ID, f.UUID())
+		require.True(t, conv.State.IsValid())
+		assert.Equal(t, conv.State != domain.ConversationStateQueued, conv.AssignedOperatorID != nil)
+		assert.Equal(t, conv.State == domain.ConversationStateResolved, conv.ResolvedAt != nil)
+		assert.False(t, conv.LastMessageAt.Before(conv.CreatedAt))
+		assert.Equal(t, 4, int(conv.ID.Version()))
+	}
+
+	conv := f.ConversationInState(f.UUID(), f.UUID(), domain.ConversationStateAllocated)
+	assert.Equal(t, domain.ConversationStateAllocated, conv.State)
+}
@@ -1,11 +1,24 @@
 package testutil
 
 import (
+	"bytes"
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// The mocks implement the repository interfaces the services depend on, so
+// a changed interface breaks the build here instead of drifting silently
+var (
+	_ domain.ConversationRefRepository           = (*MockConversationRepository)(nil)
+	_ domain.OperatorStatusRepository            = (*MockOperatorStatusRepository)(nil)
+	_ domain.OperatorInboxSubscriptionRepository = (*MockSubscriptionRepository)(nil)
+	_ domain.IdempotencyRepository               = (*MockIdempotencyRepository)(nil)
 )
 
 // ==================== MockConversationRepository ====================
@@ -13,6 +26,8 @@ import (
 type MockConversationRepository struct {
 	mu            sync.RWMutex
 	conversations map[uuid.UUID]*domain.ConversationRef
+	// labels are the label IDs of each conversation, for allocation by label
+	labels map[uuid.UUID][]uuid.UUID
 
 	// For controlling behavior in tests
 	GetByIDError      error
@@ -24,6 +39,7 @@ type MockConversationRepository struct {
 func NewMockConversationRepository() *MockConversationRepository {
 	return &MockConversationRepository{
 		conversations: make(map[uuid.UUID]*domain.ConversationRef),
+		labels:        make(map[uuid.UUID][]uuid.UUID),
 	}
 }
 
@@ -50,12 +66,17 @@ func (m *MockConversationRepository) GetByID(ctx context.Context, id uuid.UUID)
 	return conv, nil
 }
 
+// Update applies the repository's version check against the stored copy
 func (m *MockConversationRepository) Update(ctx context.Context, conv *domain.ConversationRef) error {
 	if m.UpdateError != nil {
 		return m.UpdateError
 	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	if stored, ok := m.conversations[conv.ID]; ok && stored.Version != conv.Version {
+		return domain.ErrConcurrentModification
+	}
+	conv.Version++
 	m.conversations[conv.ID] = conv
 	return nil
 }
@@ -86,18 +107,6 @@ func (m *MockConversationRepository) GetByInboxID(ctx context.Context, inboxID u
 	return result, nil
 }
 
-func (m *MockConversationRepository) GetByOperatorID(ctx context.Context, operatorID uuid.UUID) ([]*domain.ConversationRef, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	var result []*domain.ConversationRef
-	for _, conv := range m.conversations {
-		if conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == operatorID {
-			result = append(result, conv)
-		}
-	}
-	return result, nil
-}
-
 func (m *MockConversationRepository) GetQueuedForOperator(ctx context.Context, operatorID uuid.UUID, inboxIDs []uuid.UUID, limit int) ([]*domain.ConversationRef, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -137,6 +146,403 @@ func (m *MockConversationRepository) AllocateNext(ctx context.Context, tenantID,
 	return nil, nil // No conversation available
 }
 
+func (m *MockConversationRepository) CreateIfNotExists(ctx context.Context, conv *domain.ConversationRef) (bool, error) {
+	if m.CreateError != nil {
+		return false, m.CreateError
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.conversations {
+		if existing.TenantID == conv.TenantID && existing.ExternalConversationID == conv.ExternalConversationID {
+			return false, nil
+		}
+	}
+	m.conversations[conv.ID] = conv
+	return true, nil
+}
+
+func (m *MockConversationRepository) GetByExternalID(ctx context.Context, tenantID uuid.UUID, externalID string) (*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.ExternalConversationID == externalID {
+			return conv, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// GetByFilter ignores LabelID, like the repository
+func (m *MockConversationRepository) GetByFilter(ctx context.Context, filter domain.ConversationFilter) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.sortedByID() {
+		if conv.TenantID != filter.TenantID ||
+			(filter.State != nil && conv.State != *filter.State) ||
+			(filter.InboxID != nil && conv.InboxID != *filter.InboxID) ||
+			(filter.AssignedOperatorID != nil && (conv.AssignedOperatorID == nil || *conv.AssignedOperatorID != *filter.AssignedOperatorID)) ||
+			(filter.Cursor != nil && bytes.Compare(conv.ID[:], filter.Cursor[:]) <= 0) {
+			continue
+		}
+		result = append(result, conv)
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MockConversationRepository) SearchByPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.CustomerPhoneNumber == phoneNumber {
+			result = append(result, conv)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockConversationRepository) HasConversationWithPhone(ctx context.Context, tenantID uuid.UUID, phoneNumber string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.CustomerPhoneNumber == phoneNumber {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockConversationRepository) CustomerIntakeSince(ctx context.Context, tenantID, inboxID uuid.UUID, phoneNumber string, since time.Time) (int64, *time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var (
+		started int64
+		oldest  *time.Time
+	)
+	for _, conv := range m.conversations {
+		if conv.TenantID != tenantID || conv.InboxID != inboxID || conv.CustomerPhoneNumber != phoneNumber || conv.CreatedAt.Before(since) {
+			continue
+		}
+		started++
+		if oldest == nil || conv.CreatedAt.Before(*oldest) {
+			createdAt := conv.CreatedAt
+			oldest = &createdAt
+		}
+	}
+	return started, oldest, nil
+}
+
+func (m *MockConversationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.conversations, id)
+	return nil
+}
+
+// GetNextForAllocation returns QUEUED, unsnoozed conversations of the inboxes
+// in the languages by descending priority, without locking
+func (m *MockConversationRepository) GetNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, limit int) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.State == domain.ConversationStateQueued &&
+			!conv.IsSnoozed() && containsUUID(inboxIDs, conv.InboxID) && domain.SpeaksLanguage(languages, conv.Language) {
+			result = append(result, conv)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].PriorityScore.Equal(result[j].PriorityScore) {
+			return result[i].PriorityScore.GreaterThan(result[j].PriorityScore)
+		}
+		return result[i].LastMessageAt.Before(result[j].LastMessageAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetNextForAllocationWithQuotas ignores the quotas: the mock tracks no labels
+func (m *MockConversationRepository) GetNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time, limit int) ([]*domain.ConversationRef, error) {
+	return m.GetNextForAllocation(ctx, tenantID, inboxIDs, languages, limit)
+}
+
+// PeekNextForAllocation returns the first of GetNextForAllocation
+func (m *MockConversationRepository) PeekNextForAllocation(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string) (*domain.ConversationRef, error) {
+	convs, _ := m.GetNextForAllocation(ctx, tenantID, inboxIDs, languages, 1)
+	if len(convs) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return convs[0], nil
+}
+
+// PeekNextForAllocationWithQuotas ignores the quotas like GetNextForAllocationWithQuotas
+func (m *MockConversationRepository) PeekNextForAllocationWithQuotas(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore time.Time) (*domain.ConversationRef, error) {
+	return m.PeekNextForAllocation(ctx, tenantID, inboxIDs, languages)
+}
+
+// GetNextForAllocationBreachFirst ignores the quotas like
+// GetNextForAllocationWithQuotas
+func (m *MockConversationRepository) GetNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time, limit int) ([]*domain.ConversationRef, error) {
+	result, _ := m.GetNextForAllocation(ctx, tenantID, inboxIDs, languages, 0)
+	sort.SliceStable(result, func(i, j int) bool {
+		bi, bj := result[i].BreachedAt(), result[j].BreachedAt()
+		if bi == nil || bj == nil {
+			return bi != nil && bj == nil
+		}
+		return bi.Before(*bj)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// PeekNextForAllocationBreachFirst returns the first of GetNextForAllocationBreachFirst
+func (m *MockConversationRepository) PeekNextForAllocationBreachFirst(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, preferredLabelIDs []uuid.UUID, starvedBefore *time.Time) (*domain.ConversationRef, error) {
+	convs, _ := m.GetNextForAllocationBreachFirst(ctx, tenantID, inboxIDs, languages, preferredLabelIDs, starvedBefore, 1)
+	if len(convs) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return convs[0], nil
+}
+
+// GetNextForAllocationByLabels filters GetNextForAllocation, or
+// GetNextForAllocationBreachFirst, by the labels added with AddLabel
+func (m *MockConversationRepository) GetNextForAllocationByLabels(ctx context.Context, tenantID uuid.UUID, inboxIDs []uuid.UUID, languages []string, labelIDs []uuid.UUID, breachFirst bool, limit int) ([]*domain.ConversationRef, error) {
+	var queue []*domain.ConversationRef
+	if breachFirst {
+		queue, _ = m.GetNextForAllocationBreachFirst(ctx, tenantID, inboxIDs, languages, nil, nil, 0)
+	} else {
+		queue, _ = m.GetNextForAllocation(ctx, tenantID, inboxIDs, languages, 0)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range queue {
+		for _, labelID := range m.labels[conv.ID] {
+			if containsUUID(labelIDs, labelID) {
+				result = append(result, conv)
+				break
+			}
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetNextAffineForAllocation returns none: the mock tracks no affinities
+func (m *MockConversationRepository) GetNextAffineForAllocation(ctx context.Context, tenantID, operatorID uuid.UUID, inboxIDs []uuid.UUID, languages []string, since time.Time, limit int) ([]*domain.ConversationRef, error) {
+	return nil, nil
+}
+
+func (m *MockConversationRepository) LockForClaim(ctx context.Context, id uuid.UUID) (*domain.ConversationRef, error) {
+	conv, err := m.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conv.State != domain.ConversationStateQueued {
+		return nil, domain.ErrNotFound
+	}
+	return conv, nil
+}
+
+func (m *MockConversationRepository) LockForUpdate(ctx context.Context, id uuid.UUID) (*domain.ConversationRef, error) {
+	return m.GetByID(ctx, id)
+}
+
+func (m *MockConversationRepository) LockByExternalID(ctx context.Context, tenantID uuid.UUID, externalID string) (*domain.ConversationRef, error) {
+	return m.GetByExternalID(ctx, tenantID, externalID)
+}
+
+func (m *MockConversationRepository) GetByOperatorID(ctx context.Context, tenantID, operatorID uuid.UUID, state *domain.ConversationState) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == operatorID &&
+			(state == nil || conv.State == *state) {
+			result = append(result, conv)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockConversationRepository) GetOpenIDsByInbox(ctx context.Context, tenantID, inboxID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var open []*domain.ConversationRef
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && conv.InboxID == inboxID && conv.State != domain.ConversationStateResolved {
+			open = append(open, conv)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].CreatedAt.Before(open[j].CreatedAt) })
+	ids := []uuid.UUID{}
+	for _, conv := range open {
+		if limit > 0 && len(ids) >= limit {
+			break
+		}
+		ids = append(ids, conv.ID)
+	}
+	return ids, nil
+}
+
+func (m *MockConversationRepository) CountCreatedByHour(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time) (map[time.Time]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[time.Time]int)
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && (inboxID == nil || conv.InboxID == *inboxID) && !conv.CreatedAt.Before(since) {
+			counts[conv.CreatedAt.UTC().Truncate(time.Hour)]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *MockConversationRepository) CountQueuedByInbox(ctx context.Context, inboxID uuid.UUID) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	count := 0
+	for _, conv := range m.conversations {
+		if conv.InboxID == inboxID && conv.State == domain.ConversationStateQueued && !conv.IsSnoozed() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByInboxAndState covers the inboxes holding conversations: the mock
+// tracks no inboxes
+func (m *MockConversationRepository) CountByInboxAndState(ctx context.Context, tenantID uuid.UUID, resolvedSince, now time.Time) ([]domain.InboxConversationStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	byInbox := make(map[uuid.UUID]*domain.InboxConversationStats)
+	resolutionTotals := make(map[uuid.UUID]time.Duration)
+	for _, conv := range m.sortedByID() {
+		if conv.TenantID != tenantID {
+			continue
+		}
+		stats, ok := byInbox[conv.InboxID]
+		if !ok {
+			stats = &domain.InboxConversationStats{InboxID: conv.InboxID}
+			byInbox[conv.InboxID] = stats
+		}
+		if conv.IsOverdue(now) {
+			stats.Overdue++
+		}
+		switch conv.State {
+		case domain.ConversationStateQueued:
+			stats.Queued++
+		case domain.ConversationStateAllocated:
+			stats.Allocated++
+		case domain.ConversationStateResolved:
+			stats.Resolved++
+			if conv.ResolvedAt != nil && !conv.ResolvedAt.Before(resolvedSince) {
+				stats.RecentlyResolved++
+				resolutionTotals[conv.InboxID] += conv.ResolvedAt.Sub(conv.CreatedAt)
+			}
+		}
+	}
+
+	result := []domain.InboxConversationStats{}
+	for inboxID, stats := range byInbox {
+		if stats.RecentlyResolved > 0 {
+			stats.AvgResolution = resolutionTotals[inboxID] / time.Duration(stats.RecentlyResolved)
+		}
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return bytes.Compare(result[i].InboxID[:], result[j].InboxID[:]) < 0 })
+	return result, nil
+}
+
+// MarkSLABreaches finds none: the mock tracks no SLA policies
+func (m *MockConversationRepository) MarkSLABreaches(ctx context.Context, now time.Time) ([]*domain.SLABreach, error) {
+	return nil, nil
+}
+
+// BoostNearSLABreach boosts none: the mock tracks no SLA policies
+func (m *MockConversationRepository) BoostNearSLABreach(ctx context.Context, now time.Time, ratio float64, priority decimal.Decimal) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (m *MockConversationRepository) ListSLABreaches(ctx context.Context, tenantID uuid.UUID, inboxID *uuid.UUID, since time.Time, limit int) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.conversations {
+		if conv.TenantID == tenantID && (inboxID == nil || conv.InboxID == *inboxID) &&
+			conv.SLABreachedAt != nil && !conv.SLABreachedAt.Before(since) {
+			result = append(result, conv)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SLABreachedAt.After(*result[j].SLABreachedAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockConversationRepository) SetPriorityOverride(ctx context.Context, conv *domain.ConversationRef) error {
+	return m.Update(ctx, conv)
+}
+
+func (m *MockConversationRepository) SetSnooze(ctx context.Context, conv *domain.ConversationRef) error {
+	return m.Update(ctx, conv)
+}
+
+func (m *MockConversationRepository) SetDueAt(ctx context.Context, conv *domain.ConversationRef) error {
+	return m.Update(ctx, conv)
+}
+
+func (m *MockConversationRepository) SetOverdue(ctx context.Context, conv *domain.ConversationRef) error {
+	return m.Update(ctx, conv)
+}
+
+func (m *MockConversationRepository) GetAndLockOverdue(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.sortedByID() {
+		if conv.OverdueAt == nil && conv.IsOverdue(now) {
+			result = append(result, conv)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].DueAt.Before(*result[j].DueAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetAndLockStale finds none: the mock tracks no inboxes
+func (m *MockConversationRepository) GetAndLockStale(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	return nil, nil
+}
+
+func (m *MockConversationRepository) GetAndLockEndedSnoozes(ctx context.Context, now time.Time, limit int) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.sortedByID() {
+		if conv.State == domain.ConversationStateQueued && conv.SnoozedUntil != nil && !conv.SnoozedUntil.After(now) {
+			result = append(result, conv)
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
 // AddConversation adds a conversation to the mock (for test setup)
 func (m *MockConversationRepository) AddConversation(conv *domain.ConversationRef) {
 	m.mu.Lock()
@@ -144,16 +550,58 @@ func (m *MockConversationRepository) AddConversation(conv *domain.ConversationRe
 	m.conversations[conv.ID] = conv
 }
 
+// AddLabel tags a conversation for GetNextForAllocationByLabels
+func (m *MockConversationRepository) AddLabel(conversationID, labelID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.labels[conversationID] = append(m.labels[conversationID], labelID)
+}
+
+// sortedByID returns the conversations in id order; callers hold the lock
+func (m *MockConversationRepository) GetAndLockAllocatedByOperator(ctx context.Context, operatorID uuid.UUID) ([]*domain.ConversationRef, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.ConversationRef
+	for _, conv := range m.sortedByID() {
+		if conv.State == domain.ConversationStateAllocated && conv.AssignedOperatorID != nil && *conv.AssignedOperatorID == operatorID {
+			result = append(result, conv)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].PriorityScore.GreaterThan(result[j].PriorityScore) })
+	return result, nil
+}
+
+func (m *MockConversationRepository) sortedByID() []*domain.ConversationRef {
+	result := make([]*domain.ConversationRef, 0, len(m.conversations))
+	for _, conv := range m.conversations {
+		result = append(result, conv)
+	}
+	sort.Slice(result, func(i, j int) bool { return bytes.Compare(result[i].ID[:], result[j].ID[:]) < 0 })
+	return result
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
 // ==================== MockOperatorStatusRepository ====================
 
 type MockOperatorStatusRepository struct {
 	mu       sync.RWMutex
 	statuses map[uuid.UUID]*domain.OperatorStatus
+	// Statuses carry no tenant; the tenant queries resolve it here
+	tenants map[uuid.UUID]uuid.UUID
 }
 
 func NewMockOperatorStatusRepository() *MockOperatorStatusRepository {
 	return &MockOperatorStatusRepository{
 		statuses: make(map[uuid.UUID]*domain.OperatorStatus),
+		tenants:  make(map[uuid.UUID]uuid.UUID),
 	}
 }
 
@@ -195,6 +643,49 @@ func (m *MockOperatorStatusRepository) Delete(ctx context.Context, operatorID uu
 	return nil
 }
 
+func (m *MockOperatorStatusRepository) GetAvailableOperators(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.OperatorStatus
+	for operatorID, status := range m.statuses {
+		if m.tenants[operatorID] == tenantID && status.Status == domain.OperatorStatusAvailable {
+			result = append(result, status)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockOperatorStatusRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.OperatorStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.OperatorStatus
+	for operatorID, status := range m.statuses {
+		if m.tenants[operatorID] == tenantID {
+			result = append(result, status)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockOperatorStatusRepository) CountByStatus(ctx context.Context, tenantID uuid.UUID) (map[domain.OperatorStatusType]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[domain.OperatorStatusType]int)
+	for operatorID, status := range m.statuses {
+		if m.tenants[operatorID] == tenantID {
+			counts[status.Status]++
+		}
+	}
+	return counts, nil
+}
+
+// SetTenant assigns the operator to a tenant for the tenant queries (for test setup)
+func (m *MockOperatorStatusRepository) SetTenant(operatorID, tenantID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenants[operatorID] = tenantID
+}
+
 // AddStatus adds a status to the mock (for test setup)
 func (m *MockOperatorStatusRepository) AddStatus(status *domain.OperatorStatus) {
 	m.mu.Lock()
@@ -222,6 +713,18 @@ func (m *MockSubscriptionRepository) Create(ctx context.Context, sub *domain.Ope
 	return nil
 }
 
+func (m *MockSubscriptionRepository) CreateIfNotExists(ctx context.Context, sub *domain.OperatorInboxSubscription) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.subscriptions {
+		if existing.OperatorID == sub.OperatorID && existing.InboxID == sub.InboxID {
+			return false, nil
+		}
+	}
+	m.subscriptions[sub.ID] = sub
+	return true, nil
+}
+
 func (m *MockSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.OperatorInboxSubscription, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -286,6 +789,26 @@ func (m *MockSubscriptionRepository) DeleteByOperatorAndInbox(ctx context.Contex
 	return nil
 }
 
+func (m *MockSubscriptionRepository) GetSubscribedInboxIDs(ctx context.Context, operatorID uuid.UUID) ([]uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := []uuid.UUID{}
+	for _, sub := range m.subscriptions {
+		if sub.OperatorID == operatorID {
+			ids = append(ids, sub.InboxID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockSubscriptionRepository) IsSubscribed(ctx context.Context, operatorID, inboxID uuid.UUID) (bool, error) {
+	_, err := m.GetByOperatorAndInbox(ctx, operatorID, inboxID)
+	if err == domain.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 // AddSubscription adds a subscription to the mock (for test setup)
 func (m *MockSubscriptionRepository) AddSubscription(sub *domain.OperatorInboxSubscription) {
 	m.mu.Lock()
@@ -314,6 +837,30 @@ func (m *MockIdempotencyRepository) Create(ctx context.Context, ik *domain.Idemp
 	return nil
 }
 
+func (m *MockIdempotencyRepository) Claim(ctx context.Context, ik *domain.IdempotencyKey) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := ik.TenantID.String() + ":" + ik.Key
+	if held, ok := m.keys[key]; ok && !held.IsExpired() && !held.IsStale() {
+		return false, nil
+	}
+	m.keys[key] = ik
+	return true, nil
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, ik *domain.IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := ik.TenantID.String() + ":" + ik.Key
+	held, ok := m.keys[key]
+	if !ok || held.ID != ik.ID || !held.IsPending() {
+		return domain.ErrNotFound
+	}
+	ik.Status, ik.LockedUntil = domain.IdempotencyStatusCompleted, nil
+	m.keys[key] = ik
+	return nil
+}
+
 func (m *MockIdempotencyRepository) GetByKey(ctx context.Context, tenantID uuid.UUID, key string) (*domain.IdempotencyKey, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -337,6 +884,16 @@ func (m *MockIdempotencyRepository) Delete(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
+func (m *MockIdempotencyRepository) Release(ctx context.Context, ik *domain.IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := ik.TenantID.String() + ":" + ik.Key
+	if held, ok := m.keys[key]; ok && held.ID == ik.ID && held.IsPending() {
+		delete(m.keys, key)
+	}
+	return nil
+}
+
 func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -364,3 +921,37 @@ func (m *MockIdempotencyRepository) GetExpiredForCleanup(ctx context.Context, li
 	}
 	return result, nil
 }
+
+func (m *MockIdempotencyRepository) GetToReencrypt(ctx context.Context, encryptionKeyID *string, after uuid.UUID, limit int) ([]*domain.IdempotencyKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.IdempotencyKey
+	for _, ik := range m.keys {
+		stored := ik.EncryptionKeyID
+		same := (stored == nil && encryptionKeyID == nil) ||
+			(stored != nil && encryptionKeyID != nil && *stored == *encryptionKeyID)
+		if ik.IsExpired() || same || bytes.Compare(ik.ID[:], after[:]) <= 0 {
+			continue
+		}
+		result = append(result, ik)
+	}
+	sort.Slice(result, func(i, j int) bool { return bytes.Compare(result[i].ID[:], result[j].ID[:]) < 0 })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockIdempotencyRepository) UpdateResponse(ctx context.Context, ik *domain.IdempotencyKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, stored := range m.keys {
+		if stored.ID == ik.ID {
+			stored.ResponseBody = ik.ResponseBody
+			stored.ResponseCiphertext = ik.ResponseCiphertext
+			stored.EncryptionKeyID = ik.EncryptionKeyID
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
@@ -0,0 +1,167 @@
+package trafficgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/domain"
+)
+
+// APIError is a non-2xx answer from the service
+type APIError struct {
+	Status int
+	Code   response.ErrorCode
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.Status, e.Code)
+}
+
+// Client calls the service API as the configured tenant. Ingest uses the API
+// key or the manager; operator actions use the operator's bearer token or,
+// without one, the dev-mode headers.
+type Client struct {
+	baseURL        string
+	http           *http.Client
+	tenantID       uuid.UUID
+	apiKey         string
+	managerID      *uuid.UUID
+	operatorTokens map[string]string
+}
+
+// ClientConfig holds the target and credentials of a Client
+type ClientConfig struct {
+	BaseURL        string
+	HTTPClient     *http.Client
+	TenantID       uuid.UUID
+	APIKey         string
+	ManagerID      *uuid.UUID
+	OperatorTokens map[string]string
+}
+
+func NewClient(cfg ClientConfig) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:        cfg.BaseURL,
+		http:           httpClient,
+		tenantID:       cfg.TenantID,
+		apiKey:         cfg.APIKey,
+		managerID:      cfg.ManagerID,
+		operatorTokens: cfg.OperatorTokens,
+	}
+}
+
+// Ingest posts an inbound customer message
+func (c *Client) Ingest(ctx context.Context, msg dto.IngestMessageRequest) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/ingest/messages", c.ingestAuth, msg, nil)
+}
+
+// Allocate asks for the next conversation for the operator; nil when the
+// queue is empty
+func (c *Client) Allocate(ctx context.Context, operatorID uuid.UUID) (*dto.AllocationResponse, error) {
+	var conv dto.AllocationResponse
+	err := c.do(ctx, http.MethodPost, "/api/v1/allocate", c.operatorAuth(operatorID), nil, &conv)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == dto.ErrCodeNoConversationsAvailable {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// Resolve resolves one of the operator's conversations
+func (c *Client) Resolve(ctx context.Context, operatorID, conversationID uuid.UUID) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/resolve", c.operatorAuth(operatorID),
+		dto.ResolveRequest{ConversationID: conversationID}, nil)
+}
+
+// Deallocate returns one of the operator's conversations to the queue
+func (c *Client) Deallocate(ctx context.Context, operatorID, conversationID uuid.UUID) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/deallocate", c.operatorAuth(operatorID),
+		dto.DeallocateRequest{ConversationID: conversationID}, nil)
+}
+
+// SetStatus changes the operator's availability
+func (c *Client) SetStatus(ctx context.Context, operatorID uuid.UUID, status domain.OperatorStatusType) error {
+	return c.do(ctx, http.MethodPut, "/api/v1/operator/status", c.operatorAuth(operatorID),
+		dto.UpdateStatusRequest{Status: string(status)}, nil)
+}
+
+func (c *Client) ingestAuth(req *http.Request) {
+	if c.apiKey != "" {
+		req.Header.Set(middleware.APIKeyHeader, c.apiKey)
+		return
+	}
+	req.Header.Set(middleware.TenantIDHeader, c.tenantID.String())
+	req.Header.Set(middleware.OperatorIDHeader, c.managerID.String())
+}
+
+func (c *Client) operatorAuth(operatorID uuid.UUID) func(*http.Request) {
+	return func(req *http.Request) {
+		if token, ok := c.operatorTokens[operatorID.String()]; ok {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return
+		}
+		req.Header.Set(middleware.TenantIDHeader, c.tenantID.String())
+		req.Header.Set(middleware.OperatorIDHeader, operatorID.String())
+	}
+}
+
+// do sends the request and decodes the data of a successful answer into out
+func (c *Client) do(ctx context.Context, method, path string, auth func(*http.Request), body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	auth(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var problem response.Problem
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&problem)
+		return &APIError{Status: resp.StatusCode, Code: problem.Code}
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return err
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
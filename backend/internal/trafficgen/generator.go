@@ -0,0 +1,323 @@
+// Package trafficgen generates synthetic conversations and operator behavior
+// against a running service so staging dashboards, alerts and performance
+// stay representative between releases. It only talks to the public API.
+package trafficgen
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ExternalIDPrefix marks conversations created by the generator
+const ExternalIDPrefix = "synthetic-"
+
+// Config controls the simulated customers and operators
+type Config struct {
+	InboxIDs    []uuid.UUID
+	OperatorIDs []uuid.UUID
+
+	// ConversationsPerMinute is the mean rate of inbound messages; arrivals
+	// are exponentially spaced
+	ConversationsPerMinute float64
+	// FollowUpProbability is the chance an inbound message continues an open
+	// synthetic conversation instead of starting one
+	FollowUpProbability float64
+	// CustomerPool is the number of distinct customer phone numbers
+	CustomerPool int
+
+	// OperatorTick is how often each operator acts
+	OperatorTick time.Duration
+	// OperatorCapacity is how many conversations an operator holds at once
+	OperatorCapacity int
+	// HandleTime is the mean time an operator holds a conversation
+	HandleTime time.Duration
+	// DeallocateProbability is the chance a finished conversation is returned
+	// to the queue instead of resolved
+	DeallocateProbability float64
+	// BreakProbability is the per-tick chance an operator goes OFFLINE for
+	// about BreakDuration
+	BreakProbability float64
+	BreakDuration    time.Duration
+}
+
+// Stats counts the generator's actions
+type Stats struct {
+	Messages    atomic.Int64
+	Allocations atomic.Int64
+	Resolves    atomic.Int64
+	Deallocates atomic.Int64
+	Breaks      atomic.Int64
+	Errors      atomic.Int64
+}
+
+// Generator drives the simulated customers and operators
+type Generator struct {
+	client *Client
+	config Config
+	logger *logger.Logger
+	stats  Stats
+
+	mu sync.Mutex
+	// open holds the synthetic conversations a follow-up can target, by
+	// external ID
+	open map[string]dto.IngestMessageRequest
+}
+
+func NewGenerator(client *Client, config Config, log *logger.Logger) *Generator {
+	return &Generator{
+		client: client,
+		config: config,
+		logger: log,
+		open:   make(map[string]dto.IngestMessageRequest),
+	}
+}
+
+// Stats returns the generator's counters
+func (g *Generator) Stats() *Stats {
+	return &g.stats
+}
+
+// Run generates traffic until ctx is done
+func (g *Generator) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.runCustomers(ctx)
+	}()
+
+	for _, operatorID := range g.config.OperatorIDs {
+		wg.Add(1)
+		go func(operatorID uuid.UUID) {
+			defer wg.Done()
+			g.runOperator(ctx, operatorID)
+		}(operatorID)
+	}
+
+	wg.Wait()
+}
+
+// ==================== Customers ====================
+
+func (g *Generator) runCustomers(ctx context.Context) {
+	mean := time.Duration(float64(time.Minute) / g.config.ConversationsPerMinute)
+	for {
+		if !sleep(ctx, exponential(mean)) {
+			return
+		}
+		g.sendMessage(ctx)
+	}
+}
+
+func (g *Generator) sendMessage(ctx context.Context) {
+	msg, ok := g.followUp()
+	if !ok {
+		msg = dto.IngestMessageRequest{
+			ExternalConversationID: ExternalIDPrefix + uuid.NewString(),
+			CustomerPhoneNumber:    fmt.Sprintf("+1555%07d", rand.Intn(g.config.CustomerPool)),
+			InboxID:                &g.config.InboxIDs[rand.Intn(len(g.config.InboxIDs))],
+		}
+	}
+	now := time.Now().UTC()
+	msg.Timestamp = &now
+	msg.Text = sampleTexts[rand.Intn(len(sampleTexts))]
+
+	if err := g.client.Ingest(ctx, msg); err != nil {
+		g.fail(ctx, "Failed to ingest synthetic message", err)
+		return
+	}
+	g.stats.Messages.Add(1)
+
+	g.mu.Lock()
+	g.open[msg.ExternalConversationID] = msg
+	g.mu.Unlock()
+}
+
+// followUp picks an open synthetic conversation to continue, if the dice say so
+func (g *Generator) followUp() (dto.IngestMessageRequest, bool) {
+	if rand.Float64() >= g.config.FollowUpProbability {
+		return dto.IngestMessageRequest{}, false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// Map iteration order is random enough to pick one
+	for _, msg := range g.open {
+		return msg, true
+	}
+	return dto.IngestMessageRequest{}, false
+}
+
+func (g *Generator) forget(externalID string) {
+	g.mu.Lock()
+	delete(g.open, externalID)
+	g.mu.Unlock()
+}
+
+// ==================== Operators ====================
+
+// held is a conversation an operator is working on
+type held struct {
+	id         uuid.UUID
+	externalID string
+	doneAt     time.Time
+}
+
+func (g *Generator) runOperator(ctx context.Context, operatorID uuid.UUID) {
+	log := g.logger.WithOperator(operatorID.String())
+	if err := g.client.SetStatus(ctx, operatorID, domain.OperatorStatusAvailable); err != nil {
+		g.fail(ctx, "Failed to set synthetic operator AVAILABLE", err, zap.String("operator_id", operatorID.String()))
+	}
+
+	var (
+		holding    []held
+		breakUntil time.Time
+	)
+
+	// Stagger operators so they do not all poll at once
+	if !sleep(ctx, time.Duration(rand.Int63n(int64(g.config.OperatorTick)))) {
+		return
+	}
+	ticker := time.NewTicker(g.config.OperatorTick)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+
+		switch {
+		case !breakUntil.IsZero() && now.Before(breakUntil):
+			// On break
+		case !breakUntil.IsZero():
+			if err := g.client.SetStatus(ctx, operatorID, domain.OperatorStatusAvailable); err != nil {
+				g.fail(ctx, "Failed to end synthetic operator break", err, zap.String("operator_id", operatorID.String()))
+				break
+			}
+			breakUntil = time.Time{}
+		case rand.Float64() < g.config.BreakProbability:
+			// Going OFFLINE starts grace periods for the held conversations,
+			// which then return to the queue
+			if err := g.client.SetStatus(ctx, operatorID, domain.OperatorStatusOffline); err != nil {
+				g.fail(ctx, "Failed to start synthetic operator break", err, zap.String("operator_id", operatorID.String()))
+				break
+			}
+			g.stats.Breaks.Add(1)
+			holding = nil
+			breakUntil = now.Add(exponential(g.config.BreakDuration))
+			log.Debug("Synthetic operator on break", zap.Time("until", breakUntil))
+		default:
+			holding = g.finish(ctx, operatorID, holding, now)
+			holding = g.allocate(ctx, operatorID, holding, now)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish resolves or deallocates the held conversations whose handle time is
+// up and returns the rest
+func (g *Generator) finish(ctx context.Context, operatorID uuid.UUID, holding []held, now time.Time) []held {
+	kept := holding[:0]
+	for _, h := range holding {
+		if now.Before(h.doneAt) {
+			kept = append(kept, h)
+			continue
+		}
+
+		if rand.Float64() < g.config.DeallocateProbability {
+			if err := g.client.Deallocate(ctx, operatorID, h.id); err != nil {
+				g.fail(ctx, "Failed to deallocate synthetic conversation", err, zap.String("conversation_id", h.id.String()))
+				continue
+			}
+			g.stats.Deallocates.Add(1)
+			continue
+		}
+
+		if err := g.client.Resolve(ctx, operatorID, h.id); err != nil {
+			// Usually reassigned or moved away meanwhile; drop it either way
+			g.fail(ctx, "Failed to resolve synthetic conversation", err, zap.String("conversation_id", h.id.String()))
+			continue
+		}
+		g.stats.Resolves.Add(1)
+		g.forget(h.externalID)
+	}
+	return kept
+}
+
+// allocate fills the operator up to capacity
+func (g *Generator) allocate(ctx context.Context, operatorID uuid.UUID, holding []held, now time.Time) []held {
+	for len(holding) < g.config.OperatorCapacity {
+		conv, err := g.client.Allocate(ctx, operatorID)
+		if err != nil {
+			g.fail(ctx, "Failed to allocate synthetic conversation", err, zap.String("operator_id", operatorID.String()))
+			return holding
+		}
+		if conv == nil {
+			return holding
+		}
+		g.stats.Allocations.Add(1)
+		holding = append(holding, held{
+			id:         conv.ID,
+			externalID: conv.ExternalConversationID,
+			doneAt:     now.Add(exponential(g.config.HandleTime)),
+		})
+	}
+	return holding
+}
+
+// ==================== Helpers ====================
+
+// fail counts and logs a failed call; calls cut short by shutdown are not failures
+func (g *Generator) fail(ctx context.Context, msg string, err error, fields ...zap.Field) {
+	if ctx.Err() != nil {
+		return
+	}
+	g.stats.Errors.Add(1)
+	g.logger.Warn(msg, append(fields, zap.Error(err))...)
+}
+
+// exponential draws a duration with the given mean
+func exponential(mean time.Duration) time.Duration {
+	return time.Duration(rand.ExpFloat64() * float64(mean))
+}
+
+// sleep waits for d; false when ctx ended first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// sampleTexts feed the classifier and routing rules with typical intents
+var sampleTexts = []string{
+	"Hi, I was charged twice for my last order",
+	"Can I get a refund for my subscription?",
+	"My package still hasn't arrived, where is it?",
+	"How do I change my delivery address?",
+	"The app keeps crashing when I log in",
+	"I forgot my password and can't reset it",
+	"Do you have this in a larger size?",
+	"I'd like to cancel my account",
+	"Is the store open on Sunday?",
+	"Thanks, that solved it!",
+	"Still waiting for an answer here",
+	"Can someone call me back please?",
+}
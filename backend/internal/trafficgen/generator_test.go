@@ -0,0 +1,143 @@
+package trafficgen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inbox-allocation-service/internal/api/dto"
+	"github.com/inbox-allocation-service/internal/api/middleware"
+	"github.com/inbox-allocation-service/internal/api/response"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI queues ingested conversations and hands them out on allocate
+type fakeAPI struct {
+	mu       sync.Mutex
+	queued   []dto.IngestMessageRequest
+	resolved int
+	statuses []string
+	apiKeys  []string
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/v1/ingest/messages":
+		var msg dto.IngestMessageRequest
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		f.apiKeys = append(f.apiKeys, r.Header.Get(middleware.APIKeyHeader))
+		f.queued = append(f.queued, msg)
+		response.OK(w, nil)
+	case "/api/v1/operator/status":
+		var req dto.UpdateStatusRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.statuses = append(f.statuses, req.Status)
+		response.OK(w, nil)
+	case "/api/v1/allocate":
+		if len(f.queued) == 0 {
+			response.Error(w, http.StatusNotFound, dto.ErrCodeNoConversationsAvailable, "No conversations available")
+			return
+		}
+		msg := f.queued[0]
+		f.queued = f.queued[1:]
+		response.OK(w, dto.AllocationResponse{ID: uuid.New(), ExternalConversationID: msg.ExternalConversationID})
+	case "/api/v1/resolve":
+		f.resolved++
+		response.OK(w, nil)
+	default:
+		response.NotFound(w, "not found")
+	}
+}
+
+func TestGenerator_Run(t *testing.T) {
+	api := &fakeAPI{}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{BaseURL: srv.URL, TenantID: uuid.New(), APIKey: "key"})
+	gen := NewGenerator(client, Config{
+		InboxIDs:               []uuid.UUID{uuid.New()},
+		OperatorIDs:            []uuid.UUID{uuid.New()},
+		ConversationsPerMinute: 6000,
+		CustomerPool:           10,
+		OperatorTick:           5 * time.Millisecond,
+		OperatorCapacity:       2,
+		HandleTime:             time.Millisecond,
+	}, logger.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	gen.Run(ctx)
+
+	stats := gen.Stats()
+	assert.Positive(t, stats.Messages.Load())
+	assert.Positive(t, stats.Allocations.Load())
+	assert.Positive(t, stats.Resolves.Load())
+	assert.Zero(t, stats.Errors.Load())
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	assert.Equal(t, "AVAILABLE", api.statuses[0])
+	assert.Equal(t, int(stats.Resolves.Load()), api.resolved)
+	for _, key := range api.apiKeys {
+		assert.Equal(t, "key", key)
+	}
+}
+
+func TestClient_OperatorAuth(t *testing.T) {
+	tenantID := uuid.New()
+	withToken, withoutToken := uuid.New(), uuid.New()
+
+	var headers []http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		response.Error(w, http.StatusNotFound, dto.ErrCodeNoConversationsAvailable, "No conversations available")
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{
+		BaseURL:        srv.URL,
+		TenantID:       tenantID,
+		OperatorTokens: map[string]string{withToken.String(): "jwt"},
+	})
+
+	conv, err := client.Allocate(context.Background(), withToken)
+	require.NoError(t, err)
+	assert.Nil(t, conv, "an empty queue is not an error")
+
+	_, err = client.Allocate(context.Background(), withoutToken)
+	require.NoError(t, err)
+
+	require.Len(t, headers, 2)
+	assert.Equal(t, "Bearer jwt", headers[0].Get("Authorization"))
+	assert.Empty(t, headers[0].Get(middleware.OperatorIDHeader))
+	assert.Empty(t, headers[1].Get("Authorization"))
+	assert.Equal(t, tenantID.String(), headers[1].Get(middleware.TenantIDHeader))
+	assert.Equal(t, withoutToken.String(), headers[1].Get(middleware.OperatorIDHeader))
+}
+
+func TestClient_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.Forbidden(w, "Manager access required")
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{BaseURL: srv.URL, APIKey: "key"})
+	err := client.Ingest(context.Background(), dto.IngestMessageRequest{})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.Status)
+	assert.True(t, strings.Contains(apiErr.Error(), "403"))
+}
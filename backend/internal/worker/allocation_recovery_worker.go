@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// AllocationRecoveryWorkerConfig holds configuration for the allocation recovery worker
+type AllocationRecoveryWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultAllocationRecoveryWorkerConfig returns sensible defaults
+func DefaultAllocationRecoveryWorkerConfig() AllocationRecoveryWorkerConfig {
+	return AllocationRecoveryWorkerConfig{
+		Interval: 1 * time.Minute,
+	}
+}
+
+// AllocationRecoveryWorker reconciles allocation intents left dangling by a
+// crash. The first pass runs at startup, then one per tick; each pass also
+// purges expired resolved intents.
+type AllocationRecoveryWorker struct {
+	journal  *service.AllocationJournal
+	config   AllocationRecoveryWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAllocationRecoveryWorker creates a new allocation recovery worker
+func NewAllocationRecoveryWorker(
+	journal *service.AllocationJournal,
+	config AllocationRecoveryWorkerConfig,
+	log *logger.Logger,
+) *AllocationRecoveryWorker {
+	return &AllocationRecoveryWorker{
+		journal:  journal,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *AllocationRecoveryWorker) Name() string {
+	return "AllocationRecoveryWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *AllocationRecoveryWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *AllocationRecoveryWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Allocation recovery worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	w.recover(ctx)
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Allocation recovery worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Allocation recovery worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.recover(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *AllocationRecoveryWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Allocation recovery worker stopped")
+}
+
+// recover runs a single recovery cycle
+func (w *AllocationRecoveryWorker) recover(ctx context.Context) {
+	start := time.Now()
+
+	result, err := w.journal.Recover(ctx)
+	if err != nil {
+		w.logger.Error("Failed to recover allocation intents",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if result.Committed > 0 || result.Aborted > 0 {
+		w.logger.Info("Allocation recovery cycle completed",
+			zap.Int("committed", result.Committed),
+			zap.Int("aborted", result.Aborted),
+			zap.Duration("duration", time.Since(start)))
+	}
+
+	purged, err := w.journal.PurgeResolved(ctx)
+	if err != nil {
+		w.logger.Error("Failed to purge resolved allocation intents", zap.Error(err))
+		return
+	}
+	if purged > 0 {
+		w.logger.Debug("Purged resolved allocation intents", zap.Int64("purged", purged))
+	}
+}
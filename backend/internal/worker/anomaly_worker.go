@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// AnomalyWorkerConfig holds configuration for the anomaly worker
+type AnomalyWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultAnomalyWorkerConfig returns sensible defaults
+func DefaultAnomalyWorkerConfig() AnomalyWorkerConfig {
+	return AnomalyWorkerConfig{
+		Interval: 1 * time.Minute,
+	}
+}
+
+// AnomalyWorker periodically checks every tenant for unusual allocation
+// patterns
+type AnomalyWorker struct {
+	service  *service.AnomalyService
+	config   AnomalyWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAnomalyWorker creates a new anomaly worker
+func NewAnomalyWorker(
+	svc *service.AnomalyService,
+	config AnomalyWorkerConfig,
+	log *logger.Logger,
+) *AnomalyWorker {
+	return &AnomalyWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *AnomalyWorker) Name() string {
+	return "AnomalyWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *AnomalyWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *AnomalyWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Anomaly worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Anomaly worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Anomaly worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.detect(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *AnomalyWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Anomaly worker stopped")
+}
+
+// detect runs a single detection cycle
+func (w *AnomalyWorker) detect(ctx context.Context) {
+	start := time.Now()
+
+	raised, err := w.service.DetectAll(ctx)
+	if err != nil {
+		// Tenants that failed were logged; the others were checked
+		w.logger.Error("Anomaly detection cycle completed with errors",
+			zap.Int("raised", raised),
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if raised > 0 {
+		w.logger.Info("Anomaly detection cycle completed",
+			zap.Int("raised", raised),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// AutoResolveWorkerConfig holds configuration for the overdue worker
+type AutoResolveWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultAutoResolveWorkerConfig returns sensible defaults
+func DefaultAutoResolveWorkerConfig() AutoResolveWorkerConfig {
+	return AutoResolveWorkerConfig{
+		Interval:  time.Minute,
+		BatchSize: 100,
+	}
+}
+
+// AutoResolveWorker resolves open conversations idle past their inbox's
+// auto-resolve threshold
+type AutoResolveWorker struct {
+	service  *service.AutoResolveService
+	config   AutoResolveWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAutoResolveWorker creates a new overdue worker
+func NewAutoResolveWorker(
+	svc *service.AutoResolveService,
+	config AutoResolveWorkerConfig,
+	log *logger.Logger,
+) *AutoResolveWorker {
+	return &AutoResolveWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *AutoResolveWorker) Name() string {
+	return "AutoResolveWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *AutoResolveWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *AutoResolveWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Auto-resolve worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Auto-resolve worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Auto-resolve worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *AutoResolveWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Auto-resolve worker stopped")
+}
+
+// process resolves one batch of stale conversations
+func (w *AutoResolveWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	resolved, err := w.service.ResolveStale(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to auto-resolve stale conversations",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if resolved > 0 {
+		w.logger.Info("Auto-resolve worker cycle completed",
+			zap.Int("resolved", resolved),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// BackfillWorkerConfig holds configuration for the backfill worker
+type BackfillWorkerConfig struct {
+	// Interval between batches; throttles the write load of a backfill
+	Interval time.Duration
+}
+
+// DefaultBackfillWorkerConfig returns sensible defaults
+func DefaultBackfillWorkerConfig() BackfillWorkerConfig {
+	return BackfillWorkerConfig{
+		Interval: 1 * time.Second,
+	}
+}
+
+// BackfillWorker runs one batch of every unfinished schema backfill per tick
+// inside the deployment's maintenance window
+type BackfillWorker struct {
+	service     *service.BackfillService
+	maintenance *service.MaintenanceService
+	config      BackfillWorkerConfig
+	logger      *logger.Logger
+	schedule    *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewBackfillWorker creates a new backfill worker
+func NewBackfillWorker(
+	svc *service.BackfillService,
+	maintenance *service.MaintenanceService,
+	config BackfillWorkerConfig,
+	log *logger.Logger,
+) *BackfillWorker {
+	return &BackfillWorker{
+		service:     svc,
+		maintenance: maintenance,
+		config:      config,
+		logger:      log,
+		schedule:    NewIntervalSchedule(config.Interval),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *BackfillWorker) Name() string {
+	return "BackfillWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *BackfillWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *BackfillWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Backfill worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Backfill worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Backfill worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *BackfillWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Backfill worker stopped")
+}
+
+// process runs a single batch cycle
+func (w *BackfillWorker) process(ctx context.Context) {
+	if !w.maintenance.AllowDeployment(domain.MaintenanceJobBackfill) {
+		return
+	}
+	start := time.Now()
+
+	processed, err := w.service.RunPending(ctx)
+	if err != nil {
+		w.logger.Error("Backfill batch failed",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if processed > 0 {
+		w.logger.Debug("Backfill batch completed",
+			zap.Int("processed", processed),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// CompatibilityWorkerConfig holds configuration for the compatibility worker
+type CompatibilityWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultCompatibilityWorkerConfig returns sensible defaults
+func DefaultCompatibilityWorkerConfig() CompatibilityWorkerConfig {
+	return CompatibilityWorkerConfig{
+		Interval: 15 * time.Second,
+	}
+}
+
+// CompatibilityWorker repeats the compatibility check while this replica runs
+// workers, which also serves as its heartbeat. When a migration moves the
+// schema out of range or a replica with a newer worker protocol comes up, it
+// calls stopWorkers once and stops checking; the replica keeps serving the
+// API until it is replaced.
+type CompatibilityWorker struct {
+	service     *service.CompatibilityService
+	config      CompatibilityWorkerConfig
+	stopWorkers func()
+	logger      *logger.Logger
+	schedule    *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewCompatibilityWorker creates a new compatibility worker
+func NewCompatibilityWorker(
+	svc *service.CompatibilityService,
+	config CompatibilityWorkerConfig,
+	stopWorkers func(),
+	log *logger.Logger,
+) *CompatibilityWorker {
+	return &CompatibilityWorker{
+		service:     svc,
+		config:      config,
+		stopWorkers: stopWorkers,
+		logger:      log,
+		schedule:    NewIntervalSchedule(config.Interval),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *CompatibilityWorker) Name() string {
+	return "CompatibilityWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *CompatibilityWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *CompatibilityWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Compatibility worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Compatibility worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Compatibility worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			if !w.process(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// Stop gracefully stops the worker and deregisters the replica
+func (w *CompatibilityWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := w.service.Deregister(ctx); err != nil {
+		w.logger.Warn("Failed to deregister worker instance", zap.Error(err))
+	}
+	w.logger.Info("Compatibility worker stopped")
+}
+
+// process repeats the check and reports whether workers may keep running.
+// A failed check keeps them running; the next one decides.
+func (w *CompatibilityWorker) process(ctx context.Context) bool {
+	report, err := w.service.Check(ctx)
+	if err != nil {
+		w.logger.Error("Compatibility check failed", zap.Error(err))
+		return true
+	}
+	if report.WorkersEnabled {
+		return true
+	}
+
+	w.logger.Error("Replica no longer compatible, stopping workers",
+		zap.Int64("schema_version", report.SchemaVersion),
+		zap.Bool("schema_dirty", report.SchemaDirty),
+		zap.String("reason", report.Reason))
+	w.stopWorkers()
+	return false
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// EventStreamWorker keeps the LISTEN connection feeding the SSE event stream
+type EventStreamWorker struct {
+	service *service.EventStreamService
+	logger  *logger.Logger
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// NewEventStreamWorker creates a new event stream listener worker
+func NewEventStreamWorker(svc *service.EventStreamService, log *logger.Logger) *EventStreamWorker {
+	return &EventStreamWorker{
+		service: svc,
+		logger:  log,
+	}
+}
+
+// Name returns the worker's name
+func (w *EventStreamWorker) Name() string {
+	return "EventStreamWorker"
+}
+
+// Start listens for conversation events until the context is cancelled or Stop is called
+func (w *EventStreamWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.mu.Lock()
+	w.cancel = cancel
+	w.mu.Unlock()
+	defer cancel()
+
+	w.logger.Info("Event stream worker started")
+	w.service.Run(ctx)
+	w.logger.Info("Event stream worker stopping")
+}
+
+// Stop gracefully stops the worker
+func (w *EventStreamWorker) Stop() {
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+	w.logger.Info("Event stream worker stopped")
+}
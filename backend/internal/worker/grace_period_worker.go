@@ -26,9 +26,10 @@ func DefaultGracePeriodWorkerConfig() GracePeriodWorkerConfig {
 
 // GracePeriodWorker processes expired grace periods
 type GracePeriodWorker struct {
-	service *service.GracePeriodService
-	config  GracePeriodWorkerConfig
-	logger  *logger.Logger
+	service  *service.GracePeriodService
+	config   GracePeriodWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
@@ -41,10 +42,11 @@ func NewGracePeriodWorker(
 	log *logger.Logger,
 ) *GracePeriodWorker {
 	return &GracePeriodWorker{
-		service: svc,
-		config:  config,
-		logger:  log,
-		stopCh:  make(chan struct{}),
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
 	}
 }
 
@@ -53,16 +55,21 @@ func (w *GracePeriodWorker) Name() string {
 	return "GracePeriodWorker"
 }
 
+// Schedule returns when the worker runs
+func (w *GracePeriodWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
 // Start begins the worker's processing loop
 func (w *GracePeriodWorker) Start(ctx context.Context) {
 	w.wg.Add(1)
 	defer w.wg.Done()
 
 	w.logger.Info("Grace period worker started",
-		zap.Duration("interval", w.config.Interval),
+		zap.Stringer("schedule", w.schedule),
 		zap.Int("batch_size", w.config.BatchSize))
 
-	ticker := time.NewTicker(w.config.Interval)
+	ticker := w.schedule.Ticker()
 	defer ticker.Stop()
 
 	// Process immediately on start
@@ -92,6 +99,7 @@ func (w *GracePeriodWorker) Stop() {
 // process runs a single processing cycle
 func (w *GracePeriodWorker) process(ctx context.Context) {
 	start := time.Now()
+	defer w.checkHealth(ctx)
 
 	result, err := w.service.ProcessExpiredGracePeriods(ctx, w.config.BatchSize)
 	if err != nil {
@@ -113,3 +121,19 @@ func (w *GracePeriodWorker) process(ctx context.Context) {
 		w.logger.Debug("Grace period worker cycle completed - no expired periods")
 	}
 }
+
+// checkHealth refreshes the backlog metrics after a cycle and warns when the
+// cycles are not keeping up
+func (w *GracePeriodWorker) checkHealth(ctx context.Context) {
+	health, err := w.service.PipelineHealth(ctx)
+	if err != nil {
+		w.logger.Error("Failed to read grace period backlog", zap.Error(err))
+		return
+	}
+	if health.Degraded() {
+		w.logger.Warn("Grace period pipeline degraded",
+			zap.Int64("overdue", health.Overdue),
+			zap.Duration("lag", health.Lag),
+			zap.Strings("reasons", health.Degradations))
+	}
+}
@@ -22,11 +22,13 @@ func DefaultIdempotencyWorkerConfig() IdempotencyWorkerConfig {
 	}
 }
 
-// IdempotencyWorker cleans up expired idempotency keys
+// IdempotencyWorker cleans up expired idempotency keys and re-encrypts
+// responses not stored under the active encryption key
 type IdempotencyWorker struct {
-	service *service.IdempotencyService
-	config  IdempotencyWorkerConfig
-	logger  *logger.Logger
+	service  *service.IdempotencyService
+	config   IdempotencyWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
@@ -39,10 +41,11 @@ func NewIdempotencyWorker(
 	log *logger.Logger,
 ) *IdempotencyWorker {
 	return &IdempotencyWorker{
-		service: svc,
-		config:  config,
-		logger:  log,
-		stopCh:  make(chan struct{}),
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
 	}
 }
 
@@ -51,15 +54,20 @@ func (w *IdempotencyWorker) Name() string {
 	return "IdempotencyCleanupWorker"
 }
 
+// Schedule returns when the worker runs
+func (w *IdempotencyWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
 // Start begins the worker's processing loop
 func (w *IdempotencyWorker) Start(ctx context.Context) {
 	w.wg.Add(1)
 	defer w.wg.Done()
 
 	w.logger.Info("Idempotency cleanup worker started",
-		zap.Duration("interval", w.config.Interval))
+		zap.Stringer("schedule", w.schedule))
 
-	ticker := time.NewTicker(w.config.Interval)
+	ticker := w.schedule.Ticker()
 	defer ticker.Stop()
 
 	for {
@@ -100,4 +108,8 @@ func (w *IdempotencyWorker) cleanup(ctx context.Context) {
 			zap.Int64("cleaned", count),
 			zap.Duration("duration", time.Since(start)))
 	}
+
+	if _, err := w.service.Reencrypt(ctx); err != nil {
+		w.logger.Error("Failed to re-encrypt idempotency responses", zap.Error(err))
+	}
 }
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// InvariantWorkerConfig holds configuration for the invariant worker
+type InvariantWorkerConfig struct {
+	// Hour is the hour of day, in UTC, at which the check runs
+	Hour int
+	// Repair repairs the repairable violations the check finds
+	Repair bool
+}
+
+// DefaultInvariantWorkerConfig returns sensible defaults
+func DefaultInvariantWorkerConfig() InvariantWorkerConfig {
+	return InvariantWorkerConfig{
+		Hour:   3,
+		Repair: false,
+	}
+}
+
+// InvariantWorker checks the invariants of every tenant once a night, or on
+// the cron schedule configured for it in WORKER_SCHEDULES. Every
+// replica running workers runs the check; repairs re-check each violation
+// under a row lock, so the extra runs only cost a scan.
+type InvariantWorker struct {
+	service  *service.InvariantService
+	config   InvariantWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewInvariantWorker creates a new invariant worker
+func NewInvariantWorker(
+	svc *service.InvariantService,
+	config InvariantWorkerConfig,
+	log *logger.Logger,
+) *InvariantWorker {
+	if config.Hour < 0 || config.Hour > 23 {
+		config.Hour = DefaultInvariantWorkerConfig().Hour
+	}
+	return &InvariantWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewCronSchedule(DailyCron(config.Hour)),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *InvariantWorker) Name() string {
+	return "InvariantWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *InvariantWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *InvariantWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Invariant worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Bool("repair", w.config.Repair))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Invariant worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Invariant worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *InvariantWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Invariant worker stopped")
+}
+
+// process checks every tenant
+func (w *InvariantWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	reports, err := w.service.CheckAll(ctx, w.config.Repair)
+	if err != nil {
+		w.logger.Error("Invariant check failed",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+	}
+
+	violations, repaired := 0, 0
+	for _, report := range reports {
+		violations += len(report.Violations)
+		repaired += report.Repaired()
+	}
+	w.logger.Info("Invariant worker cycle completed",
+		zap.Int("tenants", len(reports)),
+		zap.Int("violations", violations),
+		zap.Int("repaired", repaired),
+		zap.Duration("duration", time.Since(start)))
+}
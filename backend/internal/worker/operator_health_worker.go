@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// OperatorHealthWorkerConfig holds configuration for the operator health worker
+type OperatorHealthWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultOperatorHealthWorkerConfig returns sensible defaults
+func DefaultOperatorHealthWorkerConfig() OperatorHealthWorkerConfig {
+	return OperatorHealthWorkerConfig{
+		Interval: 1 * time.Minute,
+	}
+}
+
+// OperatorHealthWorker periodically adjusts operators' allocation weights from
+// their recent return rates
+type OperatorHealthWorker struct {
+	service  *service.OperatorHealthService
+	config   OperatorHealthWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewOperatorHealthWorker creates a new operator health worker
+func NewOperatorHealthWorker(
+	svc *service.OperatorHealthService,
+	config OperatorHealthWorkerConfig,
+	log *logger.Logger,
+) *OperatorHealthWorker {
+	return &OperatorHealthWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *OperatorHealthWorker) Name() string {
+	return "OperatorHealthWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *OperatorHealthWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *OperatorHealthWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Operator health worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Operator health worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Operator health worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.evaluate(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *OperatorHealthWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Operator health worker stopped")
+}
+
+// evaluate runs a single feedback-loop cycle
+func (w *OperatorHealthWorker) evaluate(ctx context.Context) {
+	start := time.Now()
+
+	changed, err := w.service.EvaluateAll(ctx)
+	if err != nil {
+		// Tenants that failed were logged; the others were evaluated
+		w.logger.Error("Operator health cycle completed with errors",
+			zap.Int("changed", changed),
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if changed > 0 {
+		w.logger.Info("Operator health cycle completed",
+			zap.Int("changed", changed),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// OutboxWorkerConfig holds configuration for the event outbox worker
+type OutboxWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultOutboxWorkerConfig returns sensible defaults
+func DefaultOutboxWorkerConfig() OutboxWorkerConfig {
+	return OutboxWorkerConfig{
+		Interval:  5 * time.Second,
+		BatchSize: 100,
+	}
+}
+
+// OutboxWorker publishes events left in the outbox: those of a process that
+// died after commit, and those a sink failed to accept
+type OutboxWorker struct {
+	service  *service.EventOutbox
+	config   OutboxWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewOutboxWorker creates a new event outbox worker
+func NewOutboxWorker(
+	svc *service.EventOutbox,
+	config OutboxWorkerConfig,
+	log *logger.Logger,
+) *OutboxWorker {
+	return &OutboxWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *OutboxWorker) Name() string {
+	return "OutboxWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *OutboxWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *OutboxWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Outbox worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Outbox worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Outbox worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *OutboxWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Outbox worker stopped")
+}
+
+// process runs a single publishing cycle
+func (w *OutboxWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	result, err := w.service.PublishDue(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to publish outbox entries",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if result.Processed > 0 || result.Purged > 0 {
+		w.logger.Info("Outbox worker cycle completed",
+			zap.Int("processed", result.Processed),
+			zap.Int("published", result.Published),
+			zap.Int("retrying", result.Retrying),
+			zap.Int64("purged", result.Purged),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
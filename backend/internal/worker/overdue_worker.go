@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// OverdueWorkerConfig holds configuration for the overdue worker
+type OverdueWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultOverdueWorkerConfig returns sensible defaults
+func DefaultOverdueWorkerConfig() OverdueWorkerConfig {
+	return OverdueWorkerConfig{
+		Interval:  time.Minute,
+		BatchSize: 100,
+	}
+}
+
+// OverdueWorker flags open conversations once they pass their due date
+type OverdueWorker struct {
+	service  *service.DueDateService
+	config   OverdueWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewOverdueWorker creates a new overdue worker
+func NewOverdueWorker(
+	svc *service.DueDateService,
+	config OverdueWorkerConfig,
+	log *logger.Logger,
+) *OverdueWorker {
+	return &OverdueWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *OverdueWorker) Name() string {
+	return "OverdueWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *OverdueWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *OverdueWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Overdue worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Overdue worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Overdue worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *OverdueWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Overdue worker stopped")
+}
+
+// process flags one batch of overdue conversations
+func (w *OverdueWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	flagged, err := w.service.FlagOverdue(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to flag overdue conversations",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if flagged > 0 {
+		w.logger.Info("Overdue worker cycle completed",
+			zap.Int("flagged", flagged),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// PresenceListenerWorker keeps the LISTEN connection delivering teammates'
+// status changes to presence WebSocket sessions
+type PresenceListenerWorker struct {
+	service *service.PresenceService
+	logger  *logger.Logger
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// NewPresenceListenerWorker creates a new presence listener worker
+func NewPresenceListenerWorker(svc *service.PresenceService, log *logger.Logger) *PresenceListenerWorker {
+	return &PresenceListenerWorker{
+		service: svc,
+		logger:  log,
+	}
+}
+
+// Name returns the worker's name
+func (w *PresenceListenerWorker) Name() string {
+	return "PresenceListenerWorker"
+}
+
+// Start listens for status changes until the context is cancelled or Stop is called
+func (w *PresenceListenerWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.mu.Lock()
+	w.cancel = cancel
+	w.mu.Unlock()
+	defer cancel()
+
+	w.logger.Info("Presence listener worker started")
+	w.service.Run(ctx)
+	w.logger.Info("Presence listener worker stopping")
+}
+
+// Stop gracefully stops the worker
+func (w *PresenceListenerWorker) Stop() {
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+	w.logger.Info("Presence listener worker stopped")
+}
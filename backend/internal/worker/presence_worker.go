@@ -0,0 +1,117 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// PresenceWorkerConfig holds configuration for the presence worker
+type PresenceWorkerConfig struct {
+	// Interval is how often timed-out presence is looked for
+	Interval time.Duration
+}
+
+// DefaultPresenceWorkerConfig returns sensible defaults
+func DefaultPresenceWorkerConfig() PresenceWorkerConfig {
+	return PresenceWorkerConfig{
+		Interval: 15 * time.Second,
+	}
+}
+
+// PresenceWorker periodically sets operators whose presence connection timed
+// out OFFLINE, which starts grace periods for their conversations
+type PresenceWorker struct {
+	presence  *service.PresenceService
+	operators *service.OperatorService
+	config    PresenceWorkerConfig
+	logger    *logger.Logger
+	schedule  *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewPresenceWorker creates a new presence worker
+func NewPresenceWorker(
+	presence *service.PresenceService,
+	operators *service.OperatorService,
+	config PresenceWorkerConfig,
+	log *logger.Logger,
+) *PresenceWorker {
+	return &PresenceWorker{
+		presence:  presence,
+		operators: operators,
+		config:    config,
+		logger:    log,
+		schedule:  NewIntervalSchedule(config.Interval),
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *PresenceWorker) Name() string {
+	return "PresenceWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *PresenceWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *PresenceWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Presence worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Duration("timeout", w.presence.Policy().Timeout))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Presence worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Presence worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.expire(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *PresenceWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Presence worker stopped")
+}
+
+// expire runs a single presence timeout cycle
+func (w *PresenceWorker) expire(ctx context.Context) {
+	start := time.Now()
+
+	expired, err := w.operators.ExpirePresence(ctx, w.presence.Policy().StaleBefore(start))
+	if err != nil {
+		// Operators that failed were logged; the others were set OFFLINE
+		w.logger.Error("Presence cycle completed with errors",
+			zap.Int("expired", expired),
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if expired > 0 {
+		w.logger.Info("Presence cycle completed",
+			zap.Int("expired", expired),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+)
+
+// PushWorker sends the queued push notifications of this replica
+type PushWorker struct {
+	service *service.PushNotificationService
+	logger  *logger.Logger
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// NewPushWorker creates a new push notification worker
+func NewPushWorker(svc *service.PushNotificationService, log *logger.Logger) *PushWorker {
+	return &PushWorker{
+		service: svc,
+		logger:  log,
+	}
+}
+
+// Name returns the worker's name
+func (w *PushWorker) Name() string {
+	return "PushWorker"
+}
+
+// Start sends notifications until the context is cancelled or Stop is called
+func (w *PushWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.mu.Lock()
+	w.cancel = cancel
+	w.mu.Unlock()
+	defer cancel()
+
+	w.logger.Info("Push worker started")
+	w.service.Run(ctx)
+	w.logger.Info("Push worker stopping")
+}
+
+// Stop gracefully stops the worker
+func (w *PushWorker) Stop() {
+	w.mu.Lock()
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+	w.logger.Info("Push worker stopped")
+}
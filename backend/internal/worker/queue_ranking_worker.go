@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// QueueRankingWorkerConfig holds configuration for the queue ranking worker
+type QueueRankingWorkerConfig struct {
+	// Interval between re-rankings of inboxes marked stale
+	Interval time.Duration
+	// FullRefreshInterval between re-rankings of every inbox
+	FullRefreshInterval time.Duration
+}
+
+// DefaultQueueRankingWorkerConfig returns sensible defaults
+func DefaultQueueRankingWorkerConfig() QueueRankingWorkerConfig {
+	return QueueRankingWorkerConfig{
+		Interval:            2 * time.Second,
+		FullRefreshInterval: 5 * time.Minute,
+	}
+}
+
+// QueueRankingWorker keeps the materialized inbox queue ranks current. Every
+// inbox is ranked at startup and every FullRefreshInterval inside the
+// deployment's maintenance window; in between, only inboxes marked stale are
+// re-ranked.
+type QueueRankingWorker struct {
+	service     *service.QueueRankingService
+	maintenance *service.MaintenanceService
+	config      QueueRankingWorkerConfig
+	logger      *logger.Logger
+	schedule    *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewQueueRankingWorker creates a new queue ranking worker
+func NewQueueRankingWorker(
+	svc *service.QueueRankingService,
+	maintenance *service.MaintenanceService,
+	config QueueRankingWorkerConfig,
+	log *logger.Logger,
+) *QueueRankingWorker {
+	return &QueueRankingWorker{
+		service:     svc,
+		maintenance: maintenance,
+		config:      config,
+		logger:      log,
+		schedule:    NewIntervalSchedule(config.Interval),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *QueueRankingWorker) Name() string {
+	return "QueueRankingWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *QueueRankingWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *QueueRankingWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Queue ranking worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Duration("full_refresh_interval", w.config.FullRefreshInterval))
+
+	w.refreshAll(ctx)
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+	fullTicker := time.NewTicker(w.config.FullRefreshInterval)
+	defer fullTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Queue ranking worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Queue ranking worker stopping due to stop signal")
+			return
+		case <-fullTicker.C:
+			if w.maintenance.AllowDeployment(domain.MaintenanceJobQueueRanking) {
+				w.refreshAll(ctx)
+			}
+		case <-ticker.C:
+			w.refreshStale(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *QueueRankingWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Queue ranking worker stopped")
+}
+
+// refreshStale re-ranks the inboxes marked stale
+func (w *QueueRankingWorker) refreshStale(ctx context.Context) {
+	start := time.Now()
+
+	count, err := w.service.RefreshStale(ctx)
+	if err != nil {
+		w.logger.Error("Failed to refresh stale queue ranks",
+			zap.Error(err),
+			zap.Int("refreshed", count),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if count > 0 {
+		w.logger.Debug("Stale queue ranks refreshed",
+			zap.Int("inboxes", count),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
+
+// refreshAll re-ranks every inbox
+func (w *QueueRankingWorker) refreshAll(ctx context.Context) {
+	start := time.Now()
+
+	count, err := w.service.RefreshAll(ctx)
+	if err != nil {
+		w.logger.Error("Failed to refresh queue ranks",
+			zap.Error(err),
+			zap.Int("refreshed", count),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	w.logger.Info("Queue ranks refreshed",
+		zap.Int("inboxes", count),
+		zap.Duration("duration", time.Since(start)))
+}
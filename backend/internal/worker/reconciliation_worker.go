@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ReconciliationWorkerConfig holds configuration for the reconciliation worker
+type ReconciliationWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultReconciliationWorkerConfig returns sensible defaults
+func DefaultReconciliationWorkerConfig() ReconciliationWorkerConfig {
+	return ReconciliationWorkerConfig{
+		Interval: 10 * time.Minute,
+	}
+}
+
+// ReconciliationWorker periodically compares conversation states with the
+// upstream system of record. Every replica running workers runs it;
+// corrections re-check each conversation under a row lock, so the extra runs
+// only cost upstream requests.
+type ReconciliationWorker struct {
+	service  *service.ReconciliationService
+	config   ReconciliationWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewReconciliationWorker creates a new reconciliation worker
+func NewReconciliationWorker(
+	svc *service.ReconciliationService,
+	config ReconciliationWorkerConfig,
+	log *logger.Logger,
+) *ReconciliationWorker {
+	if config.Interval <= 0 {
+		config.Interval = DefaultReconciliationWorkerConfig().Interval
+	}
+	return &ReconciliationWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *ReconciliationWorker) Name() string {
+	return "ReconciliationWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *ReconciliationWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *ReconciliationWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Reconciliation worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Reconciliation worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Reconciliation worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *ReconciliationWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Reconciliation worker stopped")
+}
+
+// process reconciles every tenant
+func (w *ReconciliationWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	results, err := w.service.ReconcileAll(ctx)
+	if err != nil {
+		w.logger.Error("Reconciliation failed",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+	}
+
+	checked, divergent, corrected := 0, 0, 0
+	for _, result := range results {
+		checked += result.Checked
+		divergent += result.Divergent
+		corrected += result.Corrected
+	}
+	w.logger.Info("Reconciliation worker cycle completed",
+		zap.Int("tenants", len(results)),
+		zap.Int("checked", checked),
+		zap.Int("divergent", divergent),
+		zap.Int("corrected", corrected),
+		zap.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,370 @@
+package worker
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Schedule is when a worker runs: every Interval, or at the times of a cron
+// expression. Each run is delayed by a random amount up to Jitter, so that
+// replicas started together do not query the database in lockstep.
+type Schedule struct {
+	Interval time.Duration
+	// Cron, when set, replaces Interval
+	Cron   *Cron
+	Jitter time.Duration
+	// Phase delays the first run of an interval schedule; the manager sets
+	// it to spread workers sharing an interval. Zero runs first after one
+	// Interval, like a time.Ticker.
+	Phase time.Duration
+
+	nextRun atomic.Int64
+	lastRun atomic.Int64
+}
+
+// NewIntervalSchedule returns a schedule running every interval
+func NewIntervalSchedule(interval time.Duration) *Schedule {
+	return &Schedule{Interval: interval}
+}
+
+// NewCronSchedule returns a schedule running at the times of cron
+func NewCronSchedule(cron *Cron) *Schedule {
+	return &Schedule{Cron: cron}
+}
+
+// Scheduled is implemented by workers running on a Schedule, which the
+// manager adjusts before starting them
+type Scheduled interface {
+	Schedule() *Schedule
+}
+
+// String describes the schedule, e.g. "every 30s" or "cron 0 3 * * *"
+func (s *Schedule) String() string {
+	if s.Cron != nil {
+		return "cron " + s.Cron.String()
+	}
+	return "every " + s.Interval.String()
+}
+
+// NextRun returns when the running schedule fires next, zero when stopped
+func (s *Schedule) NextRun() time.Time {
+	return unixNanoTime(s.nextRun.Load())
+}
+
+// LastRun returns when the schedule last fired, zero before its first run
+func (s *Schedule) LastRun() time.Time {
+	return unixNanoTime(s.lastRun.Load())
+}
+
+// Ticker starts delivering the schedule's runs on C. Like a time.Ticker it
+// drops runs while the worker is still busy with an earlier one.
+func (s *Schedule) Ticker() *Ticker {
+	c := make(chan time.Time, 1)
+	t := &Ticker{C: c, stopCh: make(chan struct{})}
+	go s.run(c, t.stopCh)
+	return t
+}
+
+func (s *Schedule) run(c chan<- time.Time, stopCh <-chan struct{}) {
+	defer s.nextRun.Store(0)
+
+	base := s.first(time.Now())
+	for {
+		at := base.Add(s.jitter())
+		s.nextRun.Store(at.UnixNano())
+
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-stopCh:
+			timer.Stop()
+			return
+		case fired := <-timer.C:
+			s.lastRun.Store(fired.UnixNano())
+			select {
+			case c <- fired:
+			default:
+			}
+		}
+		base = s.after(base)
+	}
+}
+
+// first returns the unjittered time of the first run
+func (s *Schedule) first(now time.Time) time.Time {
+	if s.Cron != nil {
+		return s.Cron.Next(now)
+	}
+	if s.Phase > 0 {
+		return now.Add(s.Phase)
+	}
+	return now.Add(s.Interval)
+}
+
+// after returns the unjittered time of the run following base. Runs missed
+// while the process was suspended are skipped rather than caught up.
+func (s *Schedule) after(base time.Time) time.Time {
+	if s.Cron != nil {
+		return s.Cron.Next(maxTime(base, time.Now()))
+	}
+	next := base.Add(s.Interval)
+	if behind := time.Since(next); behind > 0 {
+		next = next.Add((behind/s.Interval + 1) * s.Interval)
+	}
+	return next
+}
+
+func (s *Schedule) jitter() time.Duration {
+	if s.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.Jitter)))
+}
+
+// Ticker delivers the runs of a Schedule
+type Ticker struct {
+	C      <-chan time.Time
+	stopCh chan struct{}
+}
+
+// Stop stops the ticker; no more runs are delivered
+func (t *Ticker) Stop() {
+	close(t.stopCh)
+}
+
+func unixNanoTime(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// ==================== Cron ====================
+
+// Cron is a standard five-field cron expression (minute, hour, day of month,
+// month, day of week), evaluated in UTC. Fields accept *, numbers, ranges
+// (1-5), steps (*/15, 0-30/10) and comma-separated lists; day of week runs
+// from 0 (Sunday) to 6, with 7 also meaning Sunday. As in cron, a run
+// matches either day field when both are restricted.
+type Cron struct {
+	expr                         string
+	minute, hour, dom, month     uint64
+	dow                          uint64
+	domRestricted, dowRestricted bool
+}
+
+// ParseCron parses a five-field cron expression
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &Cron{expr: strings.Join(fields, " ")}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("cron %q: minute: %w", expr, err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("cron %q: hour: %w", expr, err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("cron %q: day of month: %w", expr, err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("cron %q: month: %w", expr, err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("cron %q: day of week: %w", expr, err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domRestricted = !strings.HasPrefix(fields[2], "*")
+	c.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	if c.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("cron %q never runs", expr)
+	}
+	return c, nil
+}
+
+// DailyCron returns the cron expression running once a day at hour:00 UTC
+func DailyCron(hour int) *Cron {
+	c, err := ParseCron(fmt.Sprintf("0 %d * * *", hour))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first time after t matching the expression
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	// Every expression matches within a leap cycle of days
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	// Impossible dates such as 31 2 * *, rejected by ParseCron
+	return time.Time{}
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domRestricted && c.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// parseCronField parses one field into a bit set of the values it matches
+func parseCronField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], min, max); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(bounds[1], min, max); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			v, err := parseCronValue(rangePart, min, max)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	if bits.OnesCount64(set) == 0 {
+		return 0, fmt.Errorf("%q matches nothing", field)
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("%q is not a number between %d and %d", s, min, max)
+	}
+	return v, nil
+}
+
+// ==================== Overrides ====================
+
+// ScheduleOverride replaces parts of a worker's schedule. A nil field keeps
+// the worker's own.
+type ScheduleOverride struct {
+	Interval *time.Duration
+	Cron     *Cron
+	Jitter   *time.Duration
+}
+
+// ParseScheduleOverrides parses WORKER_SCHEDULES: semicolon-separated
+// <worker>=<schedule>[~<jitter>] entries, where the schedule is a cron
+// expression or "@every <duration>" and may be empty to only set the jitter,
+// e.g. "InvariantWorker=30 2 * * *~10m;WebhookWorker=@every 5s~1s;SLAWorker=~15s"
+func ParseScheduleOverrides(spec string) (map[string]ScheduleOverride, error) {
+	overrides := make(map[string]ScheduleOverride)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("worker schedule %q: expected <worker>=<schedule>", entry)
+		}
+		if _, dup := overrides[name]; dup {
+			return nil, fmt.Errorf("worker schedule for %s given twice", name)
+		}
+
+		var override ScheduleOverride
+		schedule, jitter, hasJitter := strings.Cut(value, "~")
+		schedule = strings.TrimSpace(schedule)
+		switch {
+		case schedule == "":
+		case strings.HasPrefix(schedule, "@every"):
+			d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(schedule, "@every")))
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("worker schedule for %s: invalid interval %q", name, schedule)
+			}
+			override.Interval = &d
+		default:
+			cron, err := ParseCron(schedule)
+			if err != nil {
+				return nil, fmt.Errorf("worker schedule for %s: %w", name, err)
+			}
+			override.Cron = cron
+		}
+		if hasJitter {
+			d, err := time.ParseDuration(strings.TrimSpace(jitter))
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("worker schedule for %s: invalid jitter %q", name, jitter)
+			}
+			override.Jitter = &d
+		}
+		if override == (ScheduleOverride{}) {
+			return nil, fmt.Errorf("worker schedule for %s is empty", name)
+		}
+		overrides[name] = override
+	}
+	return overrides, nil
+}
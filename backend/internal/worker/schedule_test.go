@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2025, 1, 15, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC)},
+		{"30 2,14 * * *", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 4 * * 0", time.Date(2025, 1, 19, 4, 0, 0, 0, time.UTC)},
+		{"0 4 * * 7", time.Date(2025, 1, 19, 4, 0, 0, 0, time.UTC)},
+		{"0 6 * 3 *", time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 0 20 * 5", time.Date(2025, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, cron.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"0 0 31 2 *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCron(expr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseScheduleOverrides(t *testing.T) {
+	overrides, err := ParseScheduleOverrides("InvariantWorker=30 2 * * *~10m; WebhookWorker=@every 5s~1s;SLAWorker=~15s;")
+	require.NoError(t, err)
+	require.Len(t, overrides, 3)
+
+	require.NotNil(t, overrides["InvariantWorker"].Cron)
+	assert.Equal(t, "30 2 * * *", overrides["InvariantWorker"].Cron.String())
+	assert.Equal(t, 10*time.Minute, *overrides["InvariantWorker"].Jitter)
+
+	assert.Equal(t, 5*time.Second, *overrides["WebhookWorker"].Interval)
+	assert.Equal(t, time.Second, *overrides["WebhookWorker"].Jitter)
+
+	assert.Nil(t, overrides["SLAWorker"].Interval)
+	assert.Nil(t, overrides["SLAWorker"].Cron)
+	assert.Equal(t, 15*time.Second, *overrides["SLAWorker"].Jitter)
+
+	empty, err := ParseScheduleOverrides("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	for _, spec := range []string{
+		"WebhookWorker",
+		"=@every 5s",
+		"WebhookWorker=",
+		"WebhookWorker=@every soon",
+		"WebhookWorker=~-1s",
+		"WebhookWorker=61 * * * *",
+		"WebhookWorker=~1s;WebhookWorker=~2s",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseScheduleOverrides(spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+type scheduledWorker struct {
+	name     string
+	schedule *Schedule
+}
+
+func (w *scheduledWorker) Start(ctx context.Context) {}
+func (w *scheduledWorker) Stop()                     {}
+func (w *scheduledWorker) Name() string              { return w.name }
+func (w *scheduledWorker) Schedule() *Schedule       { return w.schedule }
+
+func TestManager_Scheduling(t *testing.T) {
+	every := 2 * time.Second
+	jitter := 5 * time.Second
+	cron, err := ParseCron("0 4 * * *")
+	require.NoError(t, err)
+
+	config := DefaultManagerConfig()
+	config.Overrides = map[string]ScheduleOverride{
+		"Fast":    {Interval: &every},
+		"Nightly": {Cron: cron, Jitter: &jitter},
+	}
+	m := NewManager(config)
+
+	a := &scheduledWorker{"A", NewIntervalSchedule(time.Minute)}
+	b := &scheduledWorker{"B", NewIntervalSchedule(time.Minute)}
+	fast := &scheduledWorker{"Fast", NewIntervalSchedule(time.Minute)}
+	nightly := &scheduledWorker{"Nightly", NewCronSchedule(DailyCron(3))}
+	daily := &scheduledWorker{"Daily", NewCronSchedule(DailyCron(3))}
+	for _, w := range []*scheduledWorker{a, b, fast, nightly, daily} {
+		m.Register(w)
+	}
+	require.NoError(t, m.Validate())
+
+	assert.Equal(t, 6*time.Second, a.schedule.Jitter, "jitter defaults to a tenth of the interval")
+	assert.Equal(t, every, fast.schedule.Interval)
+	assert.Equal(t, "cron 0 4 * * *", nightly.schedule.String())
+	assert.Equal(t, jitter, nightly.schedule.Jitter)
+	assert.Equal(t, time.Minute, daily.schedule.Jitter, "cron schedules use the cron jitter")
+
+	m.spreadPhases()
+	gap := a.schedule.Phase - b.schedule.Phase
+	if gap < 0 {
+		gap = -gap
+	}
+	assert.Equal(t, 30*time.Second, gap.Round(time.Millisecond), "workers sharing an interval run half an interval apart")
+	assert.Less(t, fast.schedule.Phase, every)
+
+	schedules := m.Schedules()
+	require.Len(t, schedules, 5)
+	assert.Equal(t, "A", schedules[0].Name)
+	assert.Equal(t, time.Minute, schedules[0].Interval)
+	assert.False(t, schedules[0].Running)
+	assert.Nil(t, schedules[0].NextRunAt)
+	assert.Equal(t, "Nightly", schedules[4].Name)
+	assert.Equal(t, "0 4 * * *", schedules[4].Cron)
+
+	invalid := NewManager(ManagerConfig{Overrides: map[string]ScheduleOverride{"Missing": {Jitter: &jitter}}})
+	invalid.Register(a)
+	assert.Error(t, invalid.Validate())
+}
+
+func TestSchedule_Ticker(t *testing.T) {
+	s := NewIntervalSchedule(20 * time.Millisecond)
+	s.Phase = time.Millisecond
+
+	ticker := s.Ticker()
+	defer ticker.Stop()
+
+	select {
+	case <-ticker.C:
+	case <-time.After(time.Second):
+		t.Fatal("schedule did not fire")
+	}
+	assert.False(t, s.LastRun().IsZero())
+	assert.Eventually(t, func() bool { return s.NextRun().After(s.LastRun()) }, time.Second, 5*time.Millisecond)
+}
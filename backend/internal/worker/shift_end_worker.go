@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// ShiftEndWorkerConfig holds configuration for the shift-end worker
+type ShiftEndWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultShiftEndWorkerConfig returns sensible defaults
+func DefaultShiftEndWorkerConfig() ShiftEndWorkerConfig {
+	return ShiftEndWorkerConfig{
+		Interval: 1 * time.Minute,
+	}
+}
+
+// ShiftEndWorker periodically sets AVAILABLE operators whose schedule has
+// ended OFFLINE, which starts grace periods for their conversations
+type ShiftEndWorker struct {
+	service  *service.ScheduleService
+	config   ShiftEndWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewShiftEndWorker creates a new shift-end worker
+func NewShiftEndWorker(
+	svc *service.ScheduleService,
+	config ShiftEndWorkerConfig,
+	log *logger.Logger,
+) *ShiftEndWorker {
+	return &ShiftEndWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *ShiftEndWorker) Name() string {
+	return "ShiftEndWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *ShiftEndWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *ShiftEndWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Shift-end worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Shift-end worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Shift-end worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.endShifts(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *ShiftEndWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Shift-end worker stopped")
+}
+
+// endShifts runs a single shift-end cycle
+func (w *ShiftEndWorker) endShifts(ctx context.Context) {
+	start := time.Now()
+
+	ended, err := w.service.EndShifts(ctx)
+	if err != nil {
+		// Operators that failed were logged; the others were checked
+		w.logger.Error("Shift-end cycle completed with errors",
+			zap.Int("ended", ended),
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if ended > 0 {
+		w.logger.Info("Shift-end cycle completed",
+			zap.Int("ended", ended),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// SLAWorkerConfig holds configuration for the SLA worker
+type SLAWorkerConfig struct {
+	Interval time.Duration
+}
+
+// DefaultSLAWorkerConfig returns sensible defaults
+func DefaultSLAWorkerConfig() SLAWorkerConfig {
+	return SLAWorkerConfig{
+		Interval: 1 * time.Minute,
+	}
+}
+
+// SLAWorker periodically flags conversations that missed their inbox's SLA
+// and boosts queued ones about to
+type SLAWorker struct {
+	service  *service.SLAService
+	config   SLAWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSLAWorker creates a new SLA worker
+func NewSLAWorker(
+	svc *service.SLAService,
+	config SLAWorkerConfig,
+	log *logger.Logger,
+) *SLAWorker {
+	return &SLAWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *SLAWorker) Name() string {
+	return "SLAWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *SLAWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *SLAWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("SLA worker started",
+		zap.Stringer("schedule", w.schedule))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("SLA worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("SLA worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *SLAWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("SLA worker stopped")
+}
+
+// check runs a single SLA check
+func (w *SLAWorker) check(ctx context.Context) {
+	start := time.Now()
+
+	breached, err := w.service.CheckSLAs(ctx)
+	if err != nil {
+		w.logger.Error("SLA check failed",
+			zap.Int("breached", breached),
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+	if breached > 0 {
+		w.logger.Info("SLA check completed",
+			zap.Int("breached", breached),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// SnoozeWorkerConfig holds configuration for the snooze worker
+type SnoozeWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultSnoozeWorkerConfig returns sensible defaults
+func DefaultSnoozeWorkerConfig() SnoozeWorkerConfig {
+	return SnoozeWorkerConfig{
+		Interval:  30 * time.Second,
+		BatchSize: 100,
+	}
+}
+
+// SnoozeWorker wakes snoozed conversations once their snooze is due
+type SnoozeWorker struct {
+	service  *service.SnoozeService
+	config   SnoozeWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSnoozeWorker creates a new snooze worker
+func NewSnoozeWorker(
+	svc *service.SnoozeService,
+	config SnoozeWorkerConfig,
+	log *logger.Logger,
+) *SnoozeWorker {
+	return &SnoozeWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *SnoozeWorker) Name() string {
+	return "SnoozeWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *SnoozeWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *SnoozeWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Snooze worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Snooze worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Snooze worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *SnoozeWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Snooze worker stopped")
+}
+
+// process ends one batch of due snoozes
+func (w *SnoozeWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	result, err := w.service.EndSnoozes(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to end snoozes",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if result.Ended > 0 {
+		w.logger.Info("Snooze worker cycle completed",
+			zap.Int("ended", result.Ended),
+			zap.Int("returned_to_operator", result.ReturnedToOperator),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// StaleAllocationWorkerConfig holds configuration for the stale allocation worker
+type StaleAllocationWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultStaleAllocationWorkerConfig returns sensible defaults
+func DefaultStaleAllocationWorkerConfig() StaleAllocationWorkerConfig {
+	return StaleAllocationWorkerConfig{
+		Interval:  time.Minute,
+		BatchSize: 100,
+	}
+}
+
+// StaleAllocationWorker flags allocations once their operator has been
+// inactive past their inbox's staleness limit
+type StaleAllocationWorker struct {
+	service  *service.StalenessService
+	config   StaleAllocationWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewStaleAllocationWorker creates a new stale allocation worker
+func NewStaleAllocationWorker(
+	svc *service.StalenessService,
+	config StaleAllocationWorkerConfig,
+	log *logger.Logger,
+) *StaleAllocationWorker {
+	return &StaleAllocationWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *StaleAllocationWorker) Name() string {
+	return "StaleAllocationWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *StaleAllocationWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *StaleAllocationWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Stale allocation worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stale allocation worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Stale allocation worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *StaleAllocationWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Stale allocation worker stopped")
+}
+
+// process flags one batch of stale allocations
+func (w *StaleAllocationWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	flagged, err := w.service.FlagStale(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to flag stale allocations",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if flagged > 0 {
+		w.logger.Info("Stale allocation worker cycle completed",
+			zap.Int("flagged", flagged),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
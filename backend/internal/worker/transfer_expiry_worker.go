@@ -0,0 +1,113 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// TransferExpiryWorkerConfig holds configuration for the transfer expiry worker
+type TransferExpiryWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultTransferExpiryWorkerConfig returns sensible defaults
+func DefaultTransferExpiryWorkerConfig() TransferExpiryWorkerConfig {
+	return TransferExpiryWorkerConfig{
+		Interval:  15 * time.Second,
+		BatchSize: 100,
+	}
+}
+
+// TransferExpiryWorker ends transfers nobody answered before they expired
+type TransferExpiryWorker struct {
+	service  *service.TransferService
+	config   TransferExpiryWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewTransferExpiryWorker creates a new transfer expiry worker
+func NewTransferExpiryWorker(
+	svc *service.TransferService,
+	config TransferExpiryWorkerConfig,
+	log *logger.Logger,
+) *TransferExpiryWorker {
+	return &TransferExpiryWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *TransferExpiryWorker) Name() string {
+	return "TransferExpiryWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *TransferExpiryWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *TransferExpiryWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Transfer expiry worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Transfer expiry worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Transfer expiry worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *TransferExpiryWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Transfer expiry worker stopped")
+}
+
+// process expires one batch of unanswered transfers
+func (w *TransferExpiryWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	expired, err := w.service.ExpireDue(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to expire transfers",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if expired > 0 {
+		w.logger.Info("Transfer expiry worker cycle completed",
+			zap.Int("expired", expired),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
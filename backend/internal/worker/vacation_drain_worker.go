@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// VacationDrainWorkerConfig holds configuration for the vacation drain worker
+type VacationDrainWorkerConfig struct {
+	// Interval is how often conversations are drained; a ramp-down is spread
+	// over the runs it spans
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultVacationDrainWorkerConfig returns sensible defaults
+func DefaultVacationDrainWorkerConfig() VacationDrainWorkerConfig {
+	return VacationDrainWorkerConfig{
+		Interval:  1 * time.Minute,
+		BatchSize: 50,
+	}
+}
+
+// VacationDrainWorker moves the conversations of operators on vacation to
+// the queue or their colleagues over the ramp-down they chose
+type VacationDrainWorker struct {
+	service  *service.VacationService
+	config   VacationDrainWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewVacationDrainWorker creates a new vacation drain worker
+func NewVacationDrainWorker(
+	svc *service.VacationService,
+	config VacationDrainWorkerConfig,
+	log *logger.Logger,
+) *VacationDrainWorker {
+	return &VacationDrainWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *VacationDrainWorker) Name() string {
+	return "VacationDrainWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *VacationDrainWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *VacationDrainWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Vacation drain worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Vacation drain worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Vacation drain worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *VacationDrainWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Vacation drain worker stopped")
+}
+
+// process runs one drain over a batch of draining vacations
+func (w *VacationDrainWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	result, err := w.service.Drain(ctx, w.config.BatchSize, w.schedule.Interval)
+	if err != nil {
+		w.logger.Error("Failed to drain vacations",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if result.Vacations > 0 {
+		w.logger.Info("Vacation drain worker cycle completed",
+			zap.Int("vacations", result.Vacations),
+			zap.Int("to_queue", result.ToQueue),
+			zap.Int("to_colleagues", result.ToColleagues),
+			zap.Int("completed", result.Completed),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
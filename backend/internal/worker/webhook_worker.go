@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/pkg/logger"
+	"github.com/inbox-allocation-service/internal/service"
+	"go.uber.org/zap"
+)
+
+// WebhookWorkerConfig holds configuration for the webhook delivery worker
+type WebhookWorkerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// DefaultWebhookWorkerConfig returns sensible defaults
+func DefaultWebhookWorkerConfig() WebhookWorkerConfig {
+	return WebhookWorkerConfig{
+		Interval:  10 * time.Second,
+		BatchSize: 50,
+	}
+}
+
+// WebhookWorker delivers pending webhook callbacks
+type WebhookWorker struct {
+	service  *service.WebhookService
+	config   WebhookWorkerConfig
+	logger   *logger.Logger
+	schedule *Schedule
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewWebhookWorker creates a new webhook delivery worker
+func NewWebhookWorker(
+	svc *service.WebhookService,
+	config WebhookWorkerConfig,
+	log *logger.Logger,
+) *WebhookWorker {
+	return &WebhookWorker{
+		service:  svc,
+		config:   config,
+		logger:   log,
+		schedule: NewIntervalSchedule(config.Interval),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Name returns the worker's name
+func (w *WebhookWorker) Name() string {
+	return "WebhookWorker"
+}
+
+// Schedule returns when the worker runs
+func (w *WebhookWorker) Schedule() *Schedule {
+	return w.schedule
+}
+
+// Start begins the worker's processing loop
+func (w *WebhookWorker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	defer w.wg.Done()
+
+	w.logger.Info("Webhook worker started",
+		zap.Stringer("schedule", w.schedule),
+		zap.Int("batch_size", w.config.BatchSize))
+
+	ticker := w.schedule.Ticker()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Webhook worker stopping due to context cancellation")
+			return
+		case <-w.stopCh:
+			w.logger.Info("Webhook worker stopping due to stop signal")
+			return
+		case <-ticker.C:
+			w.process(ctx)
+		}
+	}
+}
+
+// Stop gracefully stops the worker
+func (w *WebhookWorker) Stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+	w.logger.Info("Webhook worker stopped")
+}
+
+// process runs a single delivery cycle
+func (w *WebhookWorker) process(ctx context.Context) {
+	start := time.Now()
+
+	result, err := w.service.DeliverDue(ctx, w.config.BatchSize)
+	if err != nil {
+		w.logger.Error("Failed to deliver webhooks",
+			zap.Error(err),
+			zap.Duration("duration", time.Since(start)))
+		return
+	}
+
+	if result.Processed > 0 {
+		w.logger.Info("Webhook worker cycle completed",
+			zap.Int("processed", result.Processed),
+			zap.Int("delivered", result.Delivered),
+			zap.Int("retrying", result.Retrying),
+			zap.Int("dead_letter", result.DeadLetter),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -2,6 +2,13 @@ package worker
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/inbox-allocation-service/internal/domain"
 )
 
 // Worker defines the interface for background workers
@@ -16,33 +23,171 @@ type Worker interface {
 	Name() string
 }
 
+// ManagerConfig holds the scheduling applied to Scheduled workers
+type ManagerConfig struct {
+	// JitterRatio is the jitter of interval schedules as a fraction of their
+	// interval, unless overridden
+	JitterRatio float64
+	// CronJitter is the jitter of cron schedules, unless overridden
+	CronJitter time.Duration
+	// AvoidAlignment gives workers sharing an interval evenly spread phases
+	// at a random offset, so that neither the workers of one replica nor
+	// replicas started together run at the same moment
+	AvoidAlignment bool
+	// Overrides replace the schedules of workers by name
+	Overrides map[string]ScheduleOverride
+}
+
+// DefaultManagerConfig returns sensible defaults
+func DefaultManagerConfig() ManagerConfig {
+	return ManagerConfig{
+		JitterRatio:    0.1,
+		CronJitter:     time.Minute,
+		AvoidAlignment: true,
+	}
+}
+
 // Manager handles multiple workers
 type Manager struct {
+	config  ManagerConfig
 	workers []Worker
+
+	mu      sync.RWMutex
+	running bool
 }
 
 // NewManager creates a new worker manager
-func NewManager() *Manager {
+func NewManager(config ManagerConfig) *Manager {
+	if config.JitterRatio < 0 || config.JitterRatio > 1 {
+		config.JitterRatio = DefaultManagerConfig().JitterRatio
+	}
 	return &Manager{
+		config:  config,
 		workers: make([]Worker, 0),
 	}
 }
 
-// Register adds a worker to the manager
+// Register adds a worker to the manager and applies the configured
+// scheduling to it
 func (m *Manager) Register(w Worker) {
+	if s, ok := w.(Scheduled); ok {
+		m.configure(w.Name(), s.Schedule())
+	}
 	m.workers = append(m.workers, w)
 }
 
+func (m *Manager) configure(name string, s *Schedule) {
+	override, overridden := m.config.Overrides[name]
+	switch {
+	case override.Cron != nil:
+		s.Cron = override.Cron
+	case override.Interval != nil:
+		s.Interval = *override.Interval
+		s.Cron = nil
+	}
+
+	switch {
+	case overridden && override.Jitter != nil:
+		s.Jitter = *override.Jitter
+	case s.Cron != nil:
+		s.Jitter = m.config.CronJitter
+	default:
+		s.Jitter = time.Duration(float64(s.Interval) * m.config.JitterRatio)
+	}
+}
+
+// Validate reports overrides naming no registered worker
+func (m *Manager) Validate() error {
+	registered := make(map[string]bool, len(m.workers))
+	for _, w := range m.workers {
+		if _, ok := w.(Scheduled); ok {
+			registered[w.Name()] = true
+		}
+	}
+	for name := range m.config.Overrides {
+		if !registered[name] {
+			return fmt.Errorf("worker schedule for unknown worker %s", name)
+		}
+	}
+	return nil
+}
+
 // StartAll starts all registered workers
 func (m *Manager) StartAll(ctx context.Context) {
+	if m.config.AvoidAlignment {
+		m.spreadPhases()
+	}
+
+	m.mu.Lock()
+	m.running = true
+	m.mu.Unlock()
+
 	for _, w := range m.workers {
 		go w.Start(ctx)
 	}
 }
 
+// spreadPhases spreads the first runs of workers sharing an interval evenly
+// over the interval, from a random offset per interval
+func (m *Manager) spreadPhases() {
+	byInterval := make(map[time.Duration][]*Schedule)
+	for _, w := range m.workers {
+		if s, ok := w.(Scheduled); ok && s.Schedule().Cron == nil {
+			schedule := s.Schedule()
+			byInterval[schedule.Interval] = append(byInterval[schedule.Interval], schedule)
+		}
+	}
+	for interval, schedules := range byInterval {
+		offset := rand.Float64()
+		for i, s := range schedules {
+			slot := offset + float64(i)/float64(len(schedules))
+			if slot >= 1 {
+				slot--
+			}
+			s.Phase = time.Duration(slot * float64(interval))
+		}
+	}
+}
+
 // StopAll stops all registered workers
 func (m *Manager) StopAll() {
 	for _, w := range m.workers {
 		w.Stop()
 	}
+
+	m.mu.Lock()
+	m.running = false
+	m.mu.Unlock()
+}
+
+// Schedules reports when each registered worker runs, sorted by name
+func (m *Manager) Schedules() []domain.WorkerSchedule {
+	m.mu.RLock()
+	running := m.running
+	m.mu.RUnlock()
+
+	schedules := make([]domain.WorkerSchedule, 0, len(m.workers))
+	for _, w := range m.workers {
+		ws := domain.WorkerSchedule{Name: w.Name(), Running: running}
+		if sw, ok := w.(Scheduled); ok {
+			s := sw.Schedule()
+			ws.Schedule = s.String()
+			ws.Jitter = s.Jitter
+			ws.Phase = s.Phase
+			if s.Cron != nil {
+				ws.Cron = s.Cron.String()
+			} else {
+				ws.Interval = s.Interval
+			}
+			if next := s.NextRun(); !next.IsZero() {
+				ws.NextRunAt = &next
+			}
+			if last := s.LastRun(); !last.IsZero() {
+				ws.LastRunAt = &last
+			}
+		}
+		schedules = append(schedules, ws)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Name < schedules[j].Name })
+	return schedules
 }
@@ -0,0 +1,61 @@
+-- ============================================================================
+-- ENUM TYPES
+-- ============================================================================
+
+CREATE TYPE webhook_delivery_status AS ENUM ('PENDING', 'DELIVERED', 'DEAD_LETTER');
+
+-- ============================================================================
+-- TABLE: webhooks
+-- ============================================================================
+-- Tenant-registered endpoints that receive signed callbacks for lifecycle events.
+-- event_types: subset of the events the endpoint subscribes to
+
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing webhooks by tenant
+CREATE INDEX idx_webhooks_tenant_id ON webhooks(tenant_id);
+
+-- ============================================================================
+-- TABLE: webhook_deliveries
+-- ============================================================================
+-- One row per (event, webhook) pair. Rows stay PENDING until delivered or
+-- until max attempts is reached, at which point they move to DEAD_LETTER.
+
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'PENDING',
+    attempt_count INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMPTZ,
+    last_status_code INT,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- CRITICAL INDEX: Used by webhook worker to find due deliveries
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'PENDING';
+
+-- Index for delivery history per webhook
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
+
+COMMENT ON TABLE webhooks IS 'Tenant webhook endpoints for lifecycle event callbacks';
+COMMENT ON COLUMN webhooks.secret IS 'Shared secret used to sign payloads (HMAC-SHA256)';
+COMMENT ON TABLE webhook_deliveries IS 'Webhook delivery attempts with retry and dead-letter tracking';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'Earliest time the worker may attempt delivery';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS routing_rules;
+
+DROP TYPE IF EXISTS routing_rule_trigger;
@@ -0,0 +1,40 @@
+-- ============================================================================
+-- ENUM TYPES
+-- ============================================================================
+
+CREATE TYPE routing_rule_trigger AS ENUM ('MESSAGE_RECEIVED');
+
+-- ============================================================================
+-- TABLE: routing_rules
+-- ============================================================================
+-- Tenant-defined rules evaluated when a conversation is updated by a trigger.
+-- A rule matches when "<condition_field> <condition_operator> <condition_value>"
+-- holds for the conversation. Matching rules attach label_name (created in the
+-- conversation's inbox when missing) and add priority_boost to the score.
+-- inbox_id: NULL applies the rule to every inbox of the tenant
+
+CREATE TABLE routing_rules (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    inbox_id UUID REFERENCES inboxes(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    trigger routing_rule_trigger NOT NULL,
+    condition_field VARCHAR(50) NOT NULL,
+    condition_operator VARCHAR(8) NOT NULL,
+    condition_value INTEGER NOT NULL,
+    label_name VARCHAR(100),
+    priority_boost DECIMAL(10,6) NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_routing_rules_operator CHECK (condition_operator IN ('gt', 'gte', 'lt', 'lte', 'eq')),
+    CONSTRAINT chk_routing_rules_action CHECK (label_name IS NOT NULL OR priority_boost <> 0)
+);
+
+-- Index for evaluating active rules of a tenant on a trigger
+CREATE INDEX idx_routing_rules_trigger ON routing_rules(tenant_id, trigger)
+    WHERE is_active = TRUE;
+
+COMMENT ON TABLE routing_rules IS 'Tenant routing rules applied to conversations on trigger events';
+COMMENT ON COLUMN routing_rules.priority_boost IS 'Added to the computed priority score while the rule matches';
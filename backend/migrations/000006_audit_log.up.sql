@@ -0,0 +1,31 @@
+-- ============================================================================
+-- TABLE: audit_log
+-- ============================================================================
+-- Append-only record of mutating operations: who (actor_id) did what (action)
+-- to which entity, with JSON snapshots of the relevant fields before and after.
+-- actor_id: NULL for system-initiated changes
+-- before_state / after_state: NULL when the entity did not exist before / after
+
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    actor_id UUID,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id UUID NOT NULL,
+    before_state JSONB,
+    after_state JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing the tenant's audit trail, newest first
+CREATE INDEX idx_audit_log_tenant_created ON audit_log(tenant_id, created_at DESC, id DESC);
+
+-- Index for filtering by actor
+CREATE INDEX idx_audit_log_actor ON audit_log(tenant_id, actor_id, created_at DESC);
+
+-- Index for the history of a single entity
+CREATE INDEX idx_audit_log_entity ON audit_log(tenant_id, entity_type, entity_id, created_at DESC);
+
+COMMENT ON TABLE audit_log IS 'Audit trail of mutating operations with before/after snapshots';
+COMMENT ON COLUMN audit_log.actor_id IS 'Operator who performed the action; NULL for system actions';
@@ -0,0 +1,26 @@
+-- ============================================================================
+-- TABLE: operator_shadows
+-- ============================================================================
+-- Attaches a trainee operator to a mentor. The trainee gets read-only access
+-- to the conversations allocated to the mentor and receives the same realtime
+-- events, but gains no permission to mutate them.
+
+CREATE TABLE operator_shadows (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    mentor_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    trainee_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_operator_shadows_pair UNIQUE (trainee_id, mentor_id),
+    CONSTRAINT chk_operator_shadows_distinct CHECK (mentor_id <> trainee_id)
+);
+
+-- Index for listing shadows by tenant
+CREATE INDEX idx_operator_shadows_tenant_id ON operator_shadows(tenant_id);
+
+-- Index for resolving the trainees of a mentor
+CREATE INDEX idx_operator_shadows_mentor_id ON operator_shadows(mentor_id);
+
+COMMENT ON TABLE operator_shadows IS 'Trainee operators shadowing a mentor with read-only visibility';
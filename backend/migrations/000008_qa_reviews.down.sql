@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS qa_review_items;
+DROP TABLE IF EXISTS qa_reviewers;
+
+DROP TYPE IF EXISTS qa_review_status;
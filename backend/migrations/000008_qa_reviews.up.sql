@@ -0,0 +1,57 @@
+-- ============================================================================
+-- ENUM TYPES
+-- ============================================================================
+
+CREATE TYPE qa_review_status AS ENUM ('PENDING', 'IN_REVIEW', 'COMPLETED');
+
+-- ============================================================================
+-- TABLE: qa_reviewers
+-- ============================================================================
+-- Operators granted the QA reviewer permission. Independent of the operator
+-- role: any operator can be made a reviewer.
+
+CREATE TABLE qa_reviewers (
+    operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    granted_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Index for listing reviewers by tenant
+CREATE INDEX idx_qa_reviewers_tenant_id ON qa_reviewers(tenant_id);
+
+-- ============================================================================
+-- TABLE: qa_review_items
+-- ============================================================================
+-- Sampled resolved conversations waiting for (or having received) a review.
+-- operator_id: operator who handled the conversation at resolution
+-- claimed_until: lease of the reviewer working on the item; expired leases
+--                return the item to the queue
+
+CREATE TABLE qa_review_items (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    operator_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+    status qa_review_status NOT NULL DEFAULT 'PENDING',
+    reviewer_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+    claimed_until TIMESTAMPTZ,
+    scores JSONB,
+    overall_score NUMERIC(4,2),
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+-- CRITICAL INDEX: Used by reviewers to fetch the next open item (FIFO)
+CREATE INDEX idx_qa_review_items_queue ON qa_review_items(tenant_id, created_at)
+    WHERE status <> 'COMPLETED';
+
+-- Index for operator performance reports
+CREATE INDEX idx_qa_review_items_completed ON qa_review_items(tenant_id, completed_at)
+    WHERE status = 'COMPLETED';
+
+COMMENT ON TABLE qa_reviewers IS 'Operators holding the QA reviewer permission';
+COMMENT ON TABLE qa_review_items IS 'Review queue of sampled resolved conversations';
+COMMENT ON COLUMN qa_review_items.scores IS 'Rubric criterion -> score (1-5)';
+COMMENT ON COLUMN qa_review_items.overall_score IS 'Mean of the rubric scores';
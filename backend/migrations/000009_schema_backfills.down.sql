@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS schema_backfills;
+
+DROP TYPE IF EXISTS backfill_status;
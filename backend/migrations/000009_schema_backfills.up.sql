@@ -0,0 +1,34 @@
+-- ============================================================================
+-- ENUM TYPES
+-- ============================================================================
+
+CREATE TYPE backfill_status AS ENUM ('PENDING', 'RUNNING', 'COMPLETED');
+
+-- ============================================================================
+-- TABLE: schema_backfills
+-- ============================================================================
+-- Job queue of batched data backfills for online schema changes (see
+-- migrations/README.md). Rows are registered by the application at startup
+-- and processed batch by batch by the backfill worker; replicas share the
+-- work by locking a job row with SKIP LOCKED.
+-- cursor_id: id of the last processed row (keyset pagination)
+-- rows_total: planner estimate at registration, used for progress only
+
+CREATE TABLE schema_backfills (
+    name VARCHAR(100) PRIMARY KEY,
+    table_name VARCHAR(100) NOT NULL,
+    status backfill_status NOT NULL DEFAULT 'PENDING',
+    cursor_id UUID,
+    rows_processed BIGINT NOT NULL DEFAULT 0,
+    rows_total BIGINT NOT NULL DEFAULT 0,
+    batch_size INTEGER NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE schema_backfills IS 'Batched data backfills for online schema changes';
+COMMENT ON COLUMN schema_backfills.cursor_id IS 'Id of the last processed row';
+COMMENT ON COLUMN schema_backfills.rows_total IS 'Planner row estimate at registration';
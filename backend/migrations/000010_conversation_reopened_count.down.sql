@@ -0,0 +1,3 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs DROP COLUMN IF EXISTS reopened_count;
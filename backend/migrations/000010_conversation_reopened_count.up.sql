@@ -0,0 +1,12 @@
+-- ============================================================================
+-- COLUMN: conversation_refs.reopened_count
+-- ============================================================================
+-- Constant default, so the column is added without rewriting the table (see
+-- migrations/README.md). Existing rows start at 0.
+
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs
+    ADD COLUMN reopened_count INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN conversation_refs.reopened_count IS 'Times the conversation returned to the queue after being resolved';
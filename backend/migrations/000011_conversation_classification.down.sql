@@ -0,0 +1,9 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE routing_rules DROP CONSTRAINT IF EXISTS chk_routing_rules_condition_text;
+
+ALTER TABLE routing_rules DROP COLUMN IF EXISTS condition_text;
+
+ALTER TABLE conversation_refs DROP COLUMN IF EXISTS category;
+
+DROP TABLE IF EXISTS tenant_classifiers;
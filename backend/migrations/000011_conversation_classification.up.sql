@@ -0,0 +1,47 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- TABLE: tenant_classifiers
+-- ============================================================================
+-- Per-tenant intent classification endpoint. When enabled, ingested and
+-- received messages are sent to endpoint_url and the returned category is
+-- stored on the conversation, where routing rules and reports can use it.
+-- A failing or slow endpoint never blocks ingestion: the conversation keeps
+-- its previous category.
+
+CREATE TABLE tenant_classifiers (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    endpoint_url TEXT NOT NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE tenant_classifiers IS 'Tenant-configured conversation classification endpoints';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.category
+-- ============================================================================
+-- Nullable, so the column is added without rewriting the table. Conversations
+-- are classified from their next message on; no backfill.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN category VARCHAR(50);
+
+COMMENT ON COLUMN conversation_refs.category IS 'Intent category assigned by the tenant classifier';
+
+-- ============================================================================
+-- COLUMN: routing_rules.condition_text
+-- ============================================================================
+-- Compared instead of condition_value for text fields (category)
+
+ALTER TABLE routing_rules
+    ADD COLUMN condition_text VARCHAR(50);
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_condition_text
+    CHECK (condition_field <> 'category' OR (condition_text IS NOT NULL AND condition_operator = 'eq'));
+
+COMMENT ON COLUMN routing_rules.condition_text IS 'Value compared for text condition fields';
@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_category
+    ON conversation_refs (tenant_id, category) WHERE category IS NOT NULL;
@@ -0,0 +1,30 @@
+-- ============================================================================
+-- TABLE: api_keys
+-- ============================================================================
+-- Tenant-scoped keys for service-to-service calls (X-API-Key header), e.g.
+-- the messaging platform posting to /api/v1/ingest/messages. Only the SHA-256
+-- of a key is stored; the key itself is shown once at creation.
+-- key_prefix: first characters of the key, to recognise it in listings
+-- last_used_at: updated at most once per minute per key
+
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    role operator_role NOT NULL,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+
+    CONSTRAINT uq_api_keys_key_hash UNIQUE (key_hash)
+);
+
+-- Index for listing keys by tenant
+CREATE INDEX idx_api_keys_tenant_id ON api_keys(tenant_id, created_at);
+
+COMMENT ON TABLE api_keys IS 'Tenant API keys for service-to-service authentication';
+COMMENT ON COLUMN api_keys.key_hash IS 'Hex SHA-256 of the key; the key itself is never stored';
+COMMENT ON COLUMN api_keys.last_used_at IS 'Last authenticated request, updated at most once per minute';
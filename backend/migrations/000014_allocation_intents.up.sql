@@ -0,0 +1,45 @@
+-- ============================================================================
+-- TABLE: allocation_intents
+-- ============================================================================
+-- Journal of allocate/claim attempts. An intent is written (PENDING) before the
+-- allocation critical section and resolved once it commits or fails, so an
+-- attempt interrupted by a crash can be reconciled on recovery. Retries that
+-- reuse the request's idempotency key get the journaled conversation back
+-- instead of a second allocation.
+-- conversation_id: claimed conversation, or the one selected by allocate
+-- recovered: resolved by the recovery routine rather than the request
+
+CREATE TABLE allocation_intents (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    idempotency_key VARCHAR(255),
+    conversation_id UUID REFERENCES conversation_refs(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'PENDING',
+    recovered BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_allocation_intents_kind CHECK (kind IN ('allocate', 'claim')),
+    CONSTRAINT chk_allocation_intents_status CHECK (status IN ('PENDING', 'COMMITTED', 'ABORTED'))
+);
+
+-- One intent per idempotency key
+CREATE UNIQUE INDEX uq_allocation_intents_idempotency_key
+    ON allocation_intents(tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- Index for the recovery routine
+CREATE INDEX idx_allocation_intents_pending
+    ON allocation_intents(created_at)
+    WHERE status = 'PENDING';
+
+-- Index for purging resolved intents
+CREATE INDEX idx_allocation_intents_resolved_at
+    ON allocation_intents(resolved_at)
+    WHERE resolved_at IS NOT NULL;
+
+COMMENT ON TABLE allocation_intents IS 'Journal of in-flight allocations for crash recovery and retry deduplication';
+COMMENT ON COLUMN allocation_intents.conversation_id IS 'Claimed conversation, or the one selected by allocate';
+COMMENT ON COLUMN allocation_intents.recovered IS 'Resolved by the recovery routine rather than the request';
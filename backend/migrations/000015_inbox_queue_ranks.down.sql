@@ -0,0 +1 @@
+DROP TABLE IF EXISTS inbox_queue_ranks;
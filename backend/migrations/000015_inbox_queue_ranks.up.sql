@@ -0,0 +1,28 @@
+-- ============================================================================
+-- TABLE: inbox_queue_ranks
+-- ============================================================================
+-- Materialized position of every QUEUED conversation in its inbox, in
+-- allocation order (priority_score DESC, last_message_at ASC). Read paths
+-- (queue position, queue previews) use it instead of ranking the queue on
+-- every request; allocation keeps using the locked query on conversation_refs.
+-- An inbox is re-ranked as a whole when a change to one of its conversations
+-- is seen, so ranks may briefly lag the source table (see refreshed_at).
+
+CREATE TABLE inbox_queue_ranks (
+    conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    priority_score DECIMAL(10,6) NOT NULL,
+    last_message_at TIMESTAMPTZ NOT NULL,
+    refreshed_at TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT chk_inbox_queue_ranks_rank CHECK (rank > 0)
+);
+
+-- Index for reading an inbox's queue in order
+CREATE INDEX idx_inbox_queue_ranks_inbox_rank ON inbox_queue_ranks(inbox_id, rank);
+
+COMMENT ON TABLE inbox_queue_ranks IS 'Materialized per-inbox queue order for read paths';
+COMMENT ON COLUMN inbox_queue_ranks.rank IS '1-based position in the inbox queue';
+COMMENT ON COLUMN inbox_queue_ranks.refreshed_at IS 'When the inbox was last re-ranked';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_allocation_intents_tenant_aborted;
+DROP TABLE IF EXISTS anomalies;
+DROP TABLE IF EXISTS tenant_anomaly_settings;
@@ -0,0 +1,58 @@
+-- ============================================================================
+-- TABLE: tenant_anomaly_settings
+-- ============================================================================
+-- Per-tenant sensitivity of the anomaly detector. Tenants without a row are
+-- checked at MEDIUM sensitivity; OFF disables detection for the tenant.
+
+CREATE TABLE tenant_anomaly_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    sensitivity VARCHAR(10) NOT NULL,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_tenant_anomaly_settings_sensitivity
+        CHECK (sensitivity IN ('OFF', 'LOW', 'MEDIUM', 'HIGH'))
+);
+
+COMMENT ON TABLE tenant_anomaly_settings IS 'Tenant-configured anomaly detection sensitivity';
+
+-- ============================================================================
+-- TABLE: anomalies
+-- ============================================================================
+-- Unusual allocation patterns flagged by the anomaly worker for managers.
+-- observed: count in the detection window that triggered the anomaly
+-- baseline: average count per window over the baseline period (0 for
+--           per-operator anomalies, which use a fixed threshold)
+-- operator_id: the operator concerned, NULL for tenant-wide anomalies
+
+CREATE TABLE anomalies (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    operator_id UUID REFERENCES operators(id) ON DELETE CASCADE,
+    observed INT NOT NULL,
+    baseline DOUBLE PRECISION NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    window_seconds INT NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_anomalies_kind
+        CHECK (kind IN ('DEALLOCATION_SPIKE', 'FAST_RESOLVES', 'ALLOCATION_FAILURE_SURGE'))
+);
+
+-- Index for listing a tenant's anomalies, newest first, and the cooldown check
+CREATE INDEX idx_anomalies_tenant_detected ON anomalies(tenant_id, detected_at DESC);
+
+COMMENT ON TABLE anomalies IS 'Unusual allocation patterns flagged for managers';
+COMMENT ON COLUMN anomalies.threshold IS 'Count at or above which observed was flagged';
+
+-- ============================================================================
+-- INDEX: allocation_intents (failed attempts)
+-- ============================================================================
+-- The detector counts a tenant's ABORTED intents (attempts that assigned
+-- nothing) per window
+
+CREATE INDEX idx_allocation_intents_tenant_aborted
+    ON allocation_intents(tenant_id, created_at)
+    WHERE status = 'ABORTED';
@@ -0,0 +1,5 @@
+ALTER TABLE webhooks DROP COLUMN IF EXISTS operator_fields;
+
+ALTER TABLE operators
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS name;
@@ -0,0 +1,23 @@
+-- ============================================================================
+-- Operator profile
+-- ============================================================================
+-- Contact details an operator can be presented with to customers. Both are
+-- optional and only leave the service in webhook payloads that opt in.
+
+ALTER TABLE operators
+    ADD COLUMN name VARCHAR(255),
+    ADD COLUMN email VARCHAR(255);
+
+COMMENT ON COLUMN operators.name IS 'Display name shown to customers';
+COMMENT ON COLUMN operators.email IS 'Contact email shown to customers';
+
+-- ============================================================================
+-- Webhook payload enrichment
+-- ============================================================================
+-- operator_fields: operator profile fields (name, email) added to the payload
+-- of assignment events; empty sends operator IDs only
+
+ALTER TABLE webhooks
+    ADD COLUMN operator_fields TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN webhooks.operator_fields IS 'Operator profile fields included in assignment event payloads';
@@ -0,0 +1,11 @@
+-- Encrypted responses cannot be decrypted in SQL; drop them (clients retrying
+-- those keys are processed again)
+DELETE FROM idempotency_keys WHERE response_body IS NULL;
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS chk_idempotency_keys_response,
+    DROP COLUMN IF EXISTS encryption_key_id,
+    DROP COLUMN IF EXISTS response_ciphertext,
+    ALTER COLUMN response_body SET NOT NULL;
+
+COMMENT ON COLUMN idempotency_keys.response_body IS 'Full JSON response body';
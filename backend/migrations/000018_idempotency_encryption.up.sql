@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Idempotency response encryption
+-- ============================================================================
+-- Cached response bodies may contain customer PII. When encryption is
+-- configured the body is stored in response_ciphertext (AES-256-GCM under a
+-- per-tenant key derived from the master key named by encryption_key_id) and
+-- response_body is NULL. Existing plaintext rows, and rows sealed with a
+-- rotated-out key, are re-encrypted by the idempotency cleanup worker.
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN response_body DROP NOT NULL,
+    ADD COLUMN response_ciphertext BYTEA,
+    ADD COLUMN encryption_key_id VARCHAR(64),
+    ADD CONSTRAINT chk_idempotency_keys_response CHECK (
+        (response_body IS NULL) = (response_ciphertext IS NOT NULL)
+        AND (response_ciphertext IS NULL) = (encryption_key_id IS NULL)
+    );
+
+COMMENT ON COLUMN idempotency_keys.response_body IS 'Full JSON response body; NULL when stored encrypted';
+COMMENT ON COLUMN idempotency_keys.response_ciphertext IS 'Encrypted response body (nonce prepended)';
+COMMENT ON COLUMN idempotency_keys.encryption_key_id IS 'Master key the response body is encrypted with; NULL for plaintext';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_log_tenant_action;
+
+ALTER TABLE inboxes DROP COLUMN IF EXISTS is_restricted;
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Break-glass access
+-- ============================================================================
+-- Managers and admins can open any conversation in their tenant. Opening one
+-- not assigned to them is recorded as conversation.break_glass_access in the
+-- audit log; for conversations in restricted inboxes a stated reason is
+-- required.
+
+ALTER TABLE inboxes ADD COLUMN is_restricted BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN inboxes.is_restricted IS 'Whether break-glass access to its conversations requires a stated reason';
+
+-- Break-glass report: the tenant's entries for one action, newest first
+CREATE INDEX idx_audit_log_tenant_action ON audit_log(tenant_id, action, created_at DESC, id DESC);
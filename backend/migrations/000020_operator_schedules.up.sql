@@ -0,0 +1,32 @@
+-- ============================================================================
+-- TABLE: operator_schedules
+-- ============================================================================
+-- Weekly recurring working windows of operators. Each row is one window in
+-- the operator's local time: day_of_week (0 = Sunday) and a start/end time of
+-- day in timezone (IANA name). A window whose end_time is not after its
+-- start_time runs past midnight into the next day. Operators without windows
+-- are not restricted; operators with windows cannot be allocated
+-- conversations outside them and are set OFFLINE when they end.
+
+CREATE TABLE operator_schedules (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    day_of_week SMALLINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_operator_schedules_day_of_week CHECK (day_of_week BETWEEN 0 AND 6),
+    CONSTRAINT chk_operator_schedules_window CHECK (start_time <> end_time)
+);
+
+CREATE INDEX idx_operator_schedules_operator ON operator_schedules(operator_id, day_of_week, start_time);
+
+COMMENT ON TABLE operator_schedules IS 'Weekly recurring working windows of operators';
+COMMENT ON COLUMN operator_schedules.day_of_week IS 'Day the window starts on, 0 = Sunday';
+COMMENT ON COLUMN operator_schedules.end_time IS 'End of the window; not after start_time when it runs past midnight';
+COMMENT ON COLUMN operator_schedules.timezone IS 'IANA time zone the window is in';
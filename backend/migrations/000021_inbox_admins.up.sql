@@ -0,0 +1,21 @@
+-- ============================================================================
+-- TABLE: inbox_admins
+-- ============================================================================
+-- Inbox-scoped admin grants. An inbox admin can manage the labels,
+-- subscriptions and routing rules of the inbox regardless of their operator
+-- role, but has no extra permissions on other inboxes.
+
+CREATE TABLE inbox_admins (
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    granted_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (operator_id, inbox_id)
+);
+
+-- Index for listing the admins of an inbox
+CREATE INDEX idx_inbox_admins_inbox_id ON inbox_admins(inbox_id);
+
+COMMENT ON TABLE inbox_admins IS 'Operators delegated admin permissions on single inboxes';
@@ -0,0 +1,5 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs DROP COLUMN IF EXISTS sla_breached_at;
+
+DROP TABLE IF EXISTS inbox_sla_policies;
@@ -0,0 +1,40 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- TABLE: inbox_sla_policies
+-- ============================================================================
+-- Per-inbox service level targets, both measured from conversation creation:
+-- first_assignment_seconds while the conversation is QUEUED, and
+-- resolution_seconds until it is RESOLVED. A NULL target is not tracked.
+-- The SLA worker flags conversations that miss a target and raises the
+-- priority of queued conversations about to.
+
+CREATE TABLE inbox_sla_policies (
+    inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    first_assignment_seconds INTEGER,
+    resolution_seconds INTEGER,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_inbox_sla_policies_first_assignment CHECK (first_assignment_seconds > 0),
+    CONSTRAINT chk_inbox_sla_policies_resolution CHECK (resolution_seconds > 0),
+    CONSTRAINT chk_inbox_sla_policies_target CHECK (first_assignment_seconds IS NOT NULL OR resolution_seconds IS NOT NULL)
+);
+
+COMMENT ON TABLE inbox_sla_policies IS 'Per-inbox first-assignment and resolution time targets';
+COMMENT ON COLUMN inbox_sla_policies.first_assignment_seconds IS 'Maximum time a conversation may wait in the queue after creation';
+COMMENT ON COLUMN inbox_sla_policies.resolution_seconds IS 'Maximum time from conversation creation to resolution';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.sla_breached_at
+-- ============================================================================
+-- Nullable, so the column is added without rewriting the table. Only
+-- conversations open when a policy is configured are checked; no backfill.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN sla_breached_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN conversation_refs.sla_breached_at IS 'When the conversation first missed an SLA target of its inbox';
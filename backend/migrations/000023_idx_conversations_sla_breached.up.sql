@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_sla_breached
+    ON conversation_refs (tenant_id, sla_breached_at DESC) WHERE sla_breached_at IS NOT NULL;
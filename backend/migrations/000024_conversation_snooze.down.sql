@@ -0,0 +1,5 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs
+    DROP COLUMN IF EXISTS snooze_operator_id,
+    DROP COLUMN IF EXISTS snoozed_until;
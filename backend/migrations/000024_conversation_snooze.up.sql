@@ -0,0 +1,21 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMNS: conversation_refs.snoozed_until, conversation_refs.snooze_operator_id
+-- ============================================================================
+-- A snoozed conversation is QUEUED but skipped by allocation until
+-- snoozed_until; the snooze worker then returns it to the queue, or to
+-- snooze_operator_id when set. Nullable, so added without rewriting the
+-- table; the foreign key is validated in 000025.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN snoozed_until TIMESTAMPTZ,
+    ADD COLUMN snooze_operator_id UUID;
+
+ALTER TABLE conversation_refs
+    ADD CONSTRAINT fk_conversation_refs_snooze_operator
+    FOREIGN KEY (snooze_operator_id) REFERENCES operators(id) ON DELETE SET NULL NOT VALID;
+
+COMMENT ON COLUMN conversation_refs.snoozed_until IS 'Allocation skips the conversation until this time';
+COMMENT ON COLUMN conversation_refs.snooze_operator_id IS 'Operator the conversation returns to when the snooze ends; NULL returns it to the queue';
@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_snoozed
+    ON conversation_refs (snoozed_until) WHERE snoozed_until IS NOT NULL;
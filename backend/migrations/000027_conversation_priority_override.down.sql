@@ -0,0 +1,4 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs
+    DROP COLUMN IF EXISTS priority_override;
@@ -0,0 +1,14 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.priority_override
+-- ============================================================================
+-- Set by a manager to pin a conversation: allocation orders by
+-- priority_override first (NULLs last), then by the computed priority_score.
+-- Nullable, so added without rewriting the table.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN priority_override DECIMAL(10,6);
+
+COMMENT ON COLUMN conversation_refs.priority_override IS 'Manual priority set by a manager; allocated before any conversation without one';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS priority_score_components;
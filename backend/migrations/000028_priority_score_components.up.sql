@@ -0,0 +1,31 @@
+-- ============================================================================
+-- TABLE: priority_score_components
+-- ============================================================================
+-- One row per priority calculation of a conversation, so analysts can check
+-- the formula against history without recomputing it:
+--   priority_score = weight_alpha × message_factor
+--                  + weight_beta × delay_factor + rule_boost
+-- Manual overrides and SLA boosts are not calculations and are not recorded.
+
+CREATE TABLE priority_score_components (
+    id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    message_count INTEGER NOT NULL,
+    last_message_at TIMESTAMPTZ NOT NULL,
+    message_factor DECIMAL(10,6) NOT NULL,
+    delay_factor DECIMAL(10,6) NOT NULL,
+    weight_alpha DECIMAL(5,4) NOT NULL,
+    weight_beta DECIMAL(5,4) NOT NULL,
+    rule_boost DECIMAL(10,6) NOT NULL DEFAULT 0,
+    priority_score DECIMAL(10,6) NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_priority_score_components_conversation
+    ON priority_score_components (conversation_id, computed_at DESC);
+
+COMMENT ON TABLE priority_score_components IS 'Inputs and result of each conversation priority calculation';
+COMMENT ON COLUMN priority_score_components.message_factor IS 'min(log10(message_count + 1) / 3, 1)';
+COMMENT ON COLUMN priority_score_components.delay_factor IS 'min(hours since last_message_at / 24, 1) at computed_at';
+COMMENT ON COLUMN priority_score_components.rule_boost IS 'Priority added by matching routing rules';
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- TABLE: conversation_notes
+-- ============================================================================
+-- Internal notes attached to a conversation. HANDOVER notes are written by
+-- the manager reassigning a conversation and addressed to the new assignee.
+
+CREATE TABLE conversation_notes (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('HANDOVER')),
+    author_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+    recipient_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_conversation_notes_conversation
+    ON conversation_notes (conversation_id, created_at DESC);
+
+COMMENT ON TABLE conversation_notes IS 'Internal notes on conversations, such as reassignment handovers';
+COMMENT ON COLUMN conversation_notes.recipient_id IS 'Operator the note is addressed to; the new assignee for HANDOVER notes';
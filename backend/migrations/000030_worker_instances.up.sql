@@ -0,0 +1,23 @@
+-- ============================================================================
+-- TABLE: worker_instances
+-- ============================================================================
+-- Replicas record the schema versions and worker protocol they were built
+-- for at startup. A replica only starts its workers when the schema is in its
+-- supported range and no live replica runs a newer worker protocol, so old
+-- and new binaries never process grace periods or deliveries side by side.
+
+CREATE TABLE worker_instances (
+    instance_id UUID PRIMARY KEY,
+    binary_version VARCHAR(64) NOT NULL,
+    worker_protocol INTEGER NOT NULL,
+    min_schema_version BIGINT NOT NULL,
+    max_schema_version BIGINT NOT NULL,
+    workers_enabled BOOLEAN NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_worker_instances_heartbeat ON worker_instances (heartbeat_at);
+
+COMMENT ON TABLE worker_instances IS 'Running replicas with their supported schema range and worker protocol';
+COMMENT ON COLUMN worker_instances.workers_enabled IS 'False when the compatibility gate kept the replica from starting workers';
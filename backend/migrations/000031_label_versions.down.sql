@@ -0,0 +1,5 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_labels DROP COLUMN IF EXISTS label_version;
+DROP TABLE IF EXISTS label_versions;
+ALTER TABLE labels DROP COLUMN IF EXISTS version;
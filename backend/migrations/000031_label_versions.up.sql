@@ -0,0 +1,32 @@
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- TABLE: label_versions
+-- ============================================================================
+-- Every rename or recolor of a label creates a new version; earlier versions
+-- keep the name and color the label had. Conversation labels record the
+-- version they were attached under, so reports aggregate historical
+-- attachments under the name they had at the time.
+
+ALTER TABLE labels ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE label_versions (
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    color VARCHAR(7),
+    changed_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (label_id, version)
+);
+
+INSERT INTO label_versions (label_id, version, name, color, changed_by, created_at)
+SELECT id, 1, name, color, created_by, created_at FROM labels;
+
+-- Nullable so the column is added without rewriting the table; attachments
+-- made before versioning existed count as version 1
+ALTER TABLE conversation_labels ADD COLUMN label_version INTEGER;
+
+COMMENT ON TABLE label_versions IS 'Name and color of each label version, kept for historical reports';
+COMMENT ON COLUMN labels.version IS 'Current version; incremented by every rename or recolor';
+COMMENT ON COLUMN conversation_labels.label_version IS 'Label version at attach time; NULL for attachments older than versioning (version 1)';
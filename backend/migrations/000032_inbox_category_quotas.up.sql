@@ -0,0 +1,20 @@
+-- ============================================================================
+-- TABLE: inbox_category_quotas
+-- ============================================================================
+-- Minimum share of an inbox's allocations reserved for conversations with a
+-- label (a category). Allocation prefers the category furthest below its
+-- share; conversations queued longer than the starvation limit still go
+-- first, so categories without a quota are never starved.
+
+CREATE TABLE inbox_category_quotas (
+    inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    share_percent INTEGER NOT NULL CHECK (share_percent BETWEEN 1 AND 100),
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (inbox_id, label_id)
+);
+
+COMMENT ON TABLE inbox_category_quotas IS 'Minimum share of inbox allocations reserved per label category';
+COMMENT ON COLUMN inbox_category_quotas.share_percent IS 'Percentage of allocations; the shares of an inbox add up to at most 100';
@@ -0,0 +1,28 @@
+-- ============================================================================
+-- TABLE: operator_allocation_health
+-- ============================================================================
+-- Allocation weight of operators whose recent return rate (deallocations per
+-- allocation) was abnormally high. The health worker lowers the weight while
+-- the rate stays high and restores it once it recovers; a weight below 1
+-- paces the operator's automatic allocations. A manager override pins the
+-- weight until it is cleared. Operators without a row have weight 1.
+
+CREATE TABLE operator_allocation_health (
+    operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1 CHECK (weight > 0 AND weight <= 1),
+    allocations INTEGER NOT NULL DEFAULT 0,
+    returns INTEGER NOT NULL DEFAULT 0,
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    override_weight DOUBLE PRECISION CHECK (override_weight > 0 AND override_weight <= 1),
+    override_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    override_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_operator_allocation_health_tenant ON operator_allocation_health(tenant_id);
+
+COMMENT ON TABLE operator_allocation_health IS 'Allocation weight of operators, lowered while their return rate is abnormally high';
+COMMENT ON COLUMN operator_allocation_health.weight IS 'Weight set by the feedback loop, between 0 (exclusive) and 1';
+COMMENT ON COLUMN operator_allocation_health.allocations IS 'Allocations, claims and reassignments to the operator in the last evaluation window';
+COMMENT ON COLUMN operator_allocation_health.returns IS 'Deallocations of the operator''s conversations in the last evaluation window';
+COMMENT ON COLUMN operator_allocation_health.override_weight IS 'Weight pinned by a manager; replaces weight until cleared';
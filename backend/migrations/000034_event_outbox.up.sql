@@ -0,0 +1,34 @@
+-- ============================================================================
+-- TABLE: event_outbox
+-- ============================================================================
+-- Domain events written in the same transaction as the state change that
+-- produced them. The service publishes an event right after commit and marks
+-- it published; the outbox worker publishes whatever is left (the process
+-- died after commit, or a sink failed), so every committed change is
+-- published at least once. Published entries are purged after a retention.
+
+CREATE TABLE event_outbox (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Worker claim path: unpublished entries in due order
+CREATE INDEX idx_event_outbox_pending ON event_outbox(next_attempt_at)
+    WHERE published_at IS NULL;
+
+-- Retention purge
+CREATE INDEX idx_event_outbox_published ON event_outbox(published_at)
+    WHERE published_at IS NOT NULL;
+
+COMMENT ON TABLE event_outbox IS 'Domain events staged in the transaction of their state change, published at least once';
+COMMENT ON COLUMN event_outbox.id IS 'ID of the domain event, so consumers can deduplicate redeliveries';
+COMMENT ON COLUMN event_outbox.next_attempt_at IS 'Earliest time the outbox worker may publish the entry';
+COMMENT ON COLUMN event_outbox.published_at IS 'When every sink accepted the event; NULL while pending';
@@ -0,0 +1,6 @@
+DELETE FROM conversation_notes WHERE kind = 'PINNED';
+ALTER TABLE conversation_notes DROP CONSTRAINT conversation_notes_kind_check;
+ALTER TABLE conversation_notes ADD CONSTRAINT conversation_notes_kind_check CHECK (kind IN ('HANDOVER'));
+
+DROP TABLE IF EXISTS conversation_checklist_items;
+DROP TABLE IF EXISTS inbox_checklist_templates;
@@ -0,0 +1,45 @@
+-- ============================================================================
+-- TABLE: inbox_checklist_templates
+-- ============================================================================
+-- Standard checklist (e.g. verify identity, confirm order number) and pinned
+-- note of an inbox. Both are copied onto a conversation when it is allocated
+-- without having them yet, so later template changes only affect
+-- conversations allocated afterwards. A strict template refuses to resolve
+-- conversations with unticked items.
+
+CREATE TABLE inbox_checklist_templates (
+    inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    items TEXT[] NOT NULL DEFAULT '{}',
+    pinned_note TEXT,
+    strict BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE inbox_checklist_templates IS 'Checklist and pinned note instantiated on the conversations of an inbox';
+COMMENT ON COLUMN inbox_checklist_templates.items IS 'Checklist item labels, in display order';
+COMMENT ON COLUMN inbox_checklist_templates.strict IS 'Refuse to resolve conversations with unticked items';
+
+-- ============================================================================
+-- TABLE: conversation_checklist_items
+-- ============================================================================
+
+CREATE TABLE conversation_checklist_items (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    label TEXT NOT NULL,
+    completed_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (conversation_id, position)
+);
+
+COMMENT ON TABLE conversation_checklist_items IS 'Checklist items of a conversation, copied from its inbox template';
+COMMENT ON COLUMN conversation_checklist_items.completed_at IS 'When the item was ticked; NULL while unticked';
+
+-- PINNED notes carry the template's pinned note
+ALTER TABLE conversation_notes DROP CONSTRAINT conversation_notes_kind_check;
+ALTER TABLE conversation_notes ADD CONSTRAINT conversation_notes_kind_check CHECK (kind IN ('HANDOVER', 'PINNED'));
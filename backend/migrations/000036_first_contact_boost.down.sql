@@ -0,0 +1,10 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE priority_score_components
+    DROP COLUMN IF EXISTS first_contact_boost;
+
+ALTER TABLE conversation_refs
+    DROP COLUMN IF EXISTS is_first_contact;
+
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS first_contact_boost;
@@ -0,0 +1,37 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMN: tenants.first_contact_boost
+-- ============================================================================
+-- Added to the priority score of conversations started by customers the
+-- tenant has never talked to before. 0 (the default) disables the boost.
+
+ALTER TABLE tenants
+    ADD COLUMN first_contact_boost DECIMAL(5,4) NOT NULL DEFAULT 0
+        CHECK (first_contact_boost >= 0 AND first_contact_boost <= 1);
+
+COMMENT ON COLUMN tenants.first_contact_boost IS 'Priority added to first-contact conversations; 0 disables the boost';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.is_first_contact
+-- ============================================================================
+-- Set at ingestion when no conversation with the customer phone number
+-- existed yet, looked up through idx_conversations_phone. The constant
+-- default is metadata-only; existing conversations stay unflagged, as their
+-- history at ingestion time is not known, so there is nothing to backfill.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN is_first_contact BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN conversation_refs.is_first_contact IS 'No earlier conversation with the customer''s phone number existed when this one was ingested';
+
+-- ============================================================================
+-- COLUMN: priority_score_components.first_contact_boost
+-- ============================================================================
+-- Recorded separately from rule_boost so a calculation still adds up.
+
+ALTER TABLE priority_score_components
+    ADD COLUMN first_contact_boost DECIMAL(10,6) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN priority_score_components.first_contact_boost IS 'Priority added because the conversation is a first contact';
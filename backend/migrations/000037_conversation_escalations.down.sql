@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS conversation_escalations;
+
+ALTER TABLE inboxes
+    DROP CONSTRAINT IF EXISTS chk_inboxes_escalation_not_self,
+    DROP COLUMN IF EXISTS escalation_inbox_id;
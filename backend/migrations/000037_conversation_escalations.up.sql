@@ -0,0 +1,36 @@
+-- ============================================================================
+-- COLUMN: inboxes.escalation_inbox_id
+-- ============================================================================
+-- Inbox that operators escalate this inbox's conversations to, typically a
+-- manager queue only managers subscribe to. NULL disables escalation.
+
+ALTER TABLE inboxes
+    ADD COLUMN escalation_inbox_id UUID REFERENCES inboxes(id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_inboxes_escalation_not_self CHECK (escalation_inbox_id <> id);
+
+COMMENT ON COLUMN inboxes.escalation_inbox_id IS 'Inbox escalated conversations are moved to; NULL disables escalation';
+
+-- ============================================================================
+-- TABLE: conversation_escalations
+-- ============================================================================
+-- One row per escalation, with the operator's reason. Escalation statistics
+-- per operator and per inbox are grouped from it.
+
+CREATE TABLE conversation_escalations (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    operator_id UUID REFERENCES operators(id) ON DELETE SET NULL,
+    escalated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    from_inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    to_inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_conversation_escalations_tenant_created
+    ON conversation_escalations (tenant_id, created_at);
+
+COMMENT ON TABLE conversation_escalations IS 'Conversations escalated by operators, with the stated reason';
+COMMENT ON COLUMN conversation_escalations.operator_id IS 'Operator the conversation was assigned to when escalated';
+COMMENT ON COLUMN conversation_escalations.escalated_by IS 'Operator who escalated: the assignee, or a manager on their behalf';
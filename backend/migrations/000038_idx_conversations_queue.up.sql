@@ -0,0 +1,3 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_queue
+    ON conversation_refs (tenant_id, inbox_id, priority_score DESC, last_message_at)
+    WHERE state = 'QUEUED';
@@ -0,0 +1,3 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_pinned
+    ON conversation_refs (tenant_id, inbox_id)
+    WHERE state = 'QUEUED' AND priority_override IS NOT NULL;
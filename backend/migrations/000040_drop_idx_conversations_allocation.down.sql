@@ -0,0 +1,3 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_allocation
+    ON conversation_refs (tenant_id, inbox_id, state, priority_score DESC, last_message_at ASC)
+    WHERE state = 'QUEUED';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS priority_experiment_assignments;
+DROP TABLE IF EXISTS priority_experiments;
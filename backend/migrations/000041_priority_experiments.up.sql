@@ -0,0 +1,54 @@
+-- ============================================================================
+-- TABLE: priority_experiments
+-- ============================================================================
+-- An A/B test of alternative priority weights in one inbox. While RUNNING,
+-- traffic_percent of the conversations created in the inbox after started_at
+-- (the TREATMENT arm, chosen by a hash of experiment and conversation ID) are
+-- prioritized with weight_alpha/weight_beta instead of the tenant weights;
+-- the rest are the CONTROL arm. At most one experiment runs per inbox.
+
+CREATE TABLE priority_experiments (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    inbox_id UUID NOT NULL REFERENCES inboxes(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    weight_alpha DECIMAL(5,4) NOT NULL CHECK (weight_alpha >= 0 AND weight_alpha <= 1),
+    weight_beta DECIMAL(5,4) NOT NULL CHECK (weight_beta >= 0 AND weight_beta <= 1),
+    traffic_percent INTEGER NOT NULL CHECK (traffic_percent >= 1 AND traffic_percent <= 99),
+    status VARCHAR(20) NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'STOPPED')),
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    stopped_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_priority_experiments_running
+    ON priority_experiments (inbox_id) WHERE status = 'RUNNING';
+CREATE INDEX idx_priority_experiments_tenant
+    ON priority_experiments (tenant_id, created_at DESC);
+
+COMMENT ON TABLE priority_experiments IS 'A/B tests of alternative priority weights per inbox';
+COMMENT ON COLUMN priority_experiments.traffic_percent IS 'Share of new conversations in the TREATMENT arm';
+
+-- ============================================================================
+-- TABLE: priority_experiment_assignments
+-- ============================================================================
+-- The arm of each conversation enrolled in an experiment, recorded at its
+-- first priority calculation. allocated_at is its first allocation; wait and
+-- resolution times are measured from the conversation's created_at.
+
+CREATE TABLE priority_experiment_assignments (
+    experiment_id UUID NOT NULL REFERENCES priority_experiments(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    arm VARCHAR(20) NOT NULL CHECK (arm IN ('CONTROL', 'TREATMENT')),
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    allocated_at TIMESTAMPTZ,
+    PRIMARY KEY (experiment_id, conversation_id)
+);
+
+CREATE INDEX idx_priority_experiment_assignments_conversation
+    ON priority_experiment_assignments (conversation_id) WHERE allocated_at IS NULL;
+
+COMMENT ON TABLE priority_experiment_assignments IS 'Experiment arm of each enrolled conversation';
+COMMENT ON COLUMN priority_experiment_assignments.allocated_at IS 'First allocation of the conversation; NULL while never allocated';
@@ -0,0 +1,18 @@
+-- ============================================================================
+-- TABLE: conversation_reads
+-- ============================================================================
+-- The last time each operator viewed a conversation. A conversation is
+-- unread for an operator while its last_message_at is after last_read_at,
+-- or when it has messages and the operator never marked it read.
+
+CREATE TABLE conversation_reads (
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (operator_id, conversation_id)
+);
+
+CREATE INDEX idx_conversation_reads_conversation ON conversation_reads (conversation_id);
+
+COMMENT ON TABLE conversation_reads IS 'Last view of each conversation per operator, for unread flags';
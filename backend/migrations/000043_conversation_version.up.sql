@@ -0,0 +1,16 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.version
+-- ============================================================================
+-- Optimistic concurrency control. Every update increments the version, and
+-- UpdateConversationRef only writes a row still at the version it was read
+-- at, so a writer holding a stale copy gets a concurrent-modification error
+-- instead of silently overwriting a lifecycle change. The constant default is
+-- metadata-only; existing rows start at 1.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN conversation_refs.version IS 'Incremented by every update; UpdateConversationRef only writes the version it read';
@@ -0,0 +1,24 @@
+-- ============================================================================
+-- TABLE: conversation_share_links
+-- ============================================================================
+-- Read-only links to a conversation snapshot for people outside the system.
+-- The token is signed from the link's id and expiry and is not stored; a link
+-- stops working when it expires or is revoked. Accesses are counted here and
+-- recorded in the audit log.
+
+CREATE TABLE conversation_share_links (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    include_pii BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    access_count INTEGER NOT NULL DEFAULT 0,
+    last_accessed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_share_links_conversation ON conversation_share_links (conversation_id, created_at DESC);
+
+COMMENT ON TABLE conversation_share_links IS 'Signed, expiring read-only links to conversation snapshots';
@@ -0,0 +1,13 @@
+SET lock_timeout = '5s';
+
+DELETE FROM routing_rules WHERE condition_field = 'language';
+
+ALTER TABLE routing_rules DROP CONSTRAINT IF EXISTS chk_routing_rules_condition_text;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_condition_text
+    CHECK (condition_field <> 'category' OR (condition_text IS NOT NULL AND condition_operator = 'eq'));
+
+ALTER TABLE operators DROP COLUMN IF EXISTS languages;
+
+ALTER TABLE conversation_refs DROP COLUMN IF EXISTS language;
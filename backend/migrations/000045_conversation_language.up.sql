@@ -0,0 +1,38 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.language
+-- ============================================================================
+-- Primary language subtag (es, en, pt) set by the messaging gateway on
+-- ingestion or returned by the tenant classifier. Nullable, so the column is
+-- added without rewriting the table; conversations get a language from their
+-- next message on, no backfill.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN language VARCHAR(3);
+
+COMMENT ON COLUMN conversation_refs.language IS 'Customer language (ISO 639 primary subtag) from the gateway or classifier';
+
+-- ============================================================================
+-- COLUMN: operators.languages
+-- ============================================================================
+-- Languages an operator handles. Automatic allocation only gives them
+-- conversations of unknown language or in one of these; an empty list takes
+-- every conversation, so existing operators are unaffected.
+
+ALTER TABLE operators
+    ADD COLUMN languages TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN operators.languages IS 'Languages the operator is allocated conversations in; empty for any';
+
+-- ============================================================================
+-- CONSTRAINT: routing_rules.condition_text
+-- ============================================================================
+-- Language rules compare condition_text like category rules
+
+ALTER TABLE routing_rules DROP CONSTRAINT chk_routing_rules_condition_text;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_condition_text
+    CHECK (condition_field NOT IN ('category', 'language') OR (condition_text IS NOT NULL AND condition_operator = 'eq'));
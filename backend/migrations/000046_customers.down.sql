@@ -0,0 +1,6 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs
+    DROP COLUMN IF EXISTS customer_id;
+
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,42 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- TABLE: customers
+-- ============================================================================
+-- The people behind conversations, one per phone number and tenant. Created
+-- on ingestion the first time a phone number writes in, so repeated contacts
+-- from the same customer can be grouped. Name and metadata are maintained by
+-- managers or integrations.
+
+CREATE TABLE customers (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    name VARCHAR(255),
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_customers_tenant_phone UNIQUE (tenant_id, phone_number)
+);
+
+CREATE INDEX idx_customers_tenant_created ON customers (tenant_id, created_at DESC);
+
+COMMENT ON TABLE customers IS 'Customer profiles keyed by tenant and phone number';
+COMMENT ON COLUMN customers.metadata IS 'Free-form attributes set by integrations (CRM ids, tiers)';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.customer_id
+-- ============================================================================
+-- Nullable, so added without rewriting the table. Existing conversations are
+-- linked by the conversation_refs_customer_id backfill; the foreign key is
+-- validated in 000047.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN customer_id UUID;
+
+ALTER TABLE conversation_refs
+    ADD CONSTRAINT fk_conversation_refs_customer
+    FOREIGN KEY (customer_id) REFERENCES customers(id) ON DELETE SET NULL NOT VALID;
+
+COMMENT ON COLUMN conversation_refs.customer_id IS 'Customer who wrote in, by tenant and customer_phone_number';
@@ -0,0 +1,2 @@
+-- Validation cannot be undone; the constraint is dropped with its column in 000046
+SELECT 1;
@@ -0,0 +1,4 @@
+-- Only takes a SHARE UPDATE EXCLUSIVE lock (see migrations/README.md)
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs VALIDATE CONSTRAINT fk_conversation_refs_customer;
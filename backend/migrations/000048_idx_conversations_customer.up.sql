@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_customer
+    ON conversation_refs (customer_id, created_at DESC) WHERE customer_id IS NOT NULL;
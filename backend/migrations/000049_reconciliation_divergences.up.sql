@@ -0,0 +1,23 @@
+-- ============================================================================
+-- TABLE: reconciliation_divergences
+-- ============================================================================
+-- Conversations whose state disagrees with the upstream system of record, as
+-- last seen by the reconciliation worker. A row is removed once a later run
+-- finds the conversation consistent again; first_detected_at is kept while
+-- the divergence persists, so auto-correction can wait for it to settle.
+
+CREATE TABLE reconciliation_divergences (
+    conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,
+    local_state VARCHAR(20) NOT NULL,
+    upstream_status VARCHAR(20) NOT NULL,
+    first_detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    corrected_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_reconciliation_divergences_tenant ON reconciliation_divergences (tenant_id, first_detected_at DESC);
+
+COMMENT ON TABLE reconciliation_divergences IS 'Conversations disagreeing with the upstream system of record';
+COMMENT ON COLUMN reconciliation_divergences.upstream_status IS 'OPEN, CLOSED or MISSING as reported upstream';
@@ -0,0 +1,17 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- COLUMN: conversation_refs.normalized_phone
+-- ============================================================================
+-- Digits of customer_phone_number, for partial phone search: operators type
+-- the start or the last digits of a number and match however it was
+-- formatted. Nullable, so the column is added without rewriting the table;
+-- inserts fill it and existing conversations are filled by the
+-- conversation_refs_normalized_phone backfill. Searched through the prefix
+-- and suffix indexes in 000051 and 000052.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN normalized_phone VARCHAR(20);
+
+COMMENT ON COLUMN conversation_refs.normalized_phone IS 'Digits of customer_phone_number, for partial phone search';
@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_phone_prefix
+    ON conversation_refs (tenant_id, normalized_phone text_pattern_ops) WHERE normalized_phone IS NOT NULL;
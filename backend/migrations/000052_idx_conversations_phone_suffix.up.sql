@@ -0,0 +1,4 @@
+-- Suffix searches (the last digits of a number) match the reversed digits
+-- by prefix
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_phone_suffix
+    ON conversation_refs (tenant_id, reverse(normalized_phone) text_pattern_ops) WHERE normalized_phone IS NOT NULL;
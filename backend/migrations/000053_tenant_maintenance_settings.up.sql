@@ -0,0 +1,34 @@
+-- ============================================================================
+-- TABLE: tenant_maintenance_settings
+-- ============================================================================
+-- Per-tenant maintenance window for heavy background jobs (reconciliation).
+-- Tenants without a row, or without window columns, use the deployment's
+-- MAINTENANCE_WINDOW. An override holds the window open or closed until
+-- override_until.
+-- window_start, window_end: times of day in timezone (IANA name); a window
+--                           whose end is not after its start runs past
+--                           midnight
+
+CREATE TABLE tenant_maintenance_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    window_start TIME,
+    window_end TIME,
+    timezone VARCHAR(64),
+    override_mode VARCHAR(10),
+    override_until TIMESTAMPTZ,
+    override_reason TEXT,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_tenant_maintenance_settings_window
+        CHECK ((window_start IS NULL) = (window_end IS NULL)
+           AND (window_start IS NULL) = (timezone IS NULL)
+           AND window_start <> window_end),
+    CONSTRAINT chk_tenant_maintenance_settings_override
+        CHECK ((override_mode IS NULL) = (override_until IS NULL)
+           AND override_mode IN ('OPEN', 'CLOSED'))
+);
+
+COMMENT ON TABLE tenant_maintenance_settings IS 'Tenant-configured maintenance window and manual override for heavy jobs';
+COMMENT ON COLUMN tenant_maintenance_settings.override_until IS 'When the override lapses and the window applies again';
@@ -0,0 +1,3 @@
+-- Full-text search over external conversation IDs (GET /api/v1/search?q=)
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_external_id_fts
+    ON conversation_refs USING GIN (to_tsvector('simple', external_conversation_id));
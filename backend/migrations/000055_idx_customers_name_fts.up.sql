@@ -0,0 +1,3 @@
+-- Full-text search over customer names (GET /api/v1/search?q=)
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_customers_name_fts
+    ON customers USING GIN (to_tsvector('simple', coalesce(name, '')));
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS idx_conversation_notes_fts;
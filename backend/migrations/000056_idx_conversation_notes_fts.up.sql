@@ -0,0 +1,3 @@
+-- Full-text search over note bodies (GET /api/v1/search?q=)
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversation_notes_fts
+    ON conversation_notes USING GIN (to_tsvector('simple', body));
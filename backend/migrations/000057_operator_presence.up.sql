@@ -0,0 +1,20 @@
+-- ============================================================================
+-- TABLE: operator_presence
+-- ============================================================================
+-- Operators connected to the presence WebSocket (GET /api/v1/ws). Every
+-- replica holding a connection refreshes last_seen_at on the heartbeat; the
+-- presence worker sets operators whose row went stale OFFLINE and deletes
+-- the row. Operators who never connect have no row and keep the status
+-- they set.
+
+CREATE TABLE operator_presence (
+    operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    connected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_operator_presence_last_seen ON operator_presence (last_seen_at);
+
+COMMENT ON TABLE operator_presence IS 'Presence WebSocket connections, refreshed by heartbeats';
+COMMENT ON COLUMN operator_presence.connected_at IS 'Start of the current run of connections without a timeout';
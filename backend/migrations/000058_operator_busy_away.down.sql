@@ -0,0 +1,21 @@
+-- Enum values cannot be dropped: fold them into existing values and
+-- recreate the types without them
+
+UPDATE operator_status SET status = 'OFFLINE' WHERE status IN ('BUSY', 'AWAY');
+UPDATE grace_period_assignments SET reason = 'OFFLINE' WHERE reason = 'AWAY';
+
+ALTER TYPE operator_status_type RENAME TO operator_status_type_old;
+CREATE TYPE operator_status_type AS ENUM ('AVAILABLE', 'OFFLINE');
+ALTER TABLE operator_status
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE operator_status_type USING status::text::operator_status_type,
+    ALTER COLUMN status SET DEFAULT 'OFFLINE';
+DROP TYPE operator_status_type_old;
+
+ALTER TYPE grace_period_reason RENAME TO grace_period_reason_old;
+CREATE TYPE grace_period_reason AS ENUM ('OFFLINE', 'MANUAL');
+ALTER TABLE grace_period_assignments
+    ALTER COLUMN reason DROP DEFAULT,
+    ALTER COLUMN reason TYPE grace_period_reason USING reason::text::grace_period_reason,
+    ALTER COLUMN reason SET DEFAULT 'OFFLINE';
+DROP TYPE grace_period_reason_old;
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- BUSY and AWAY operator statuses
+-- ============================================================================
+-- BUSY operators keep their conversations but take no new ones. AWAY
+-- operators take no new conversations either, and theirs get a shorter grace
+-- period (reason AWAY) than going OFFLINE. The new values are not used in
+-- this migration, so adding them inside its transaction is safe.
+
+ALTER TYPE operator_status_type ADD VALUE IF NOT EXISTS 'BUSY';
+ALTER TYPE operator_status_type ADD VALUE IF NOT EXISTS 'AWAY';
+ALTER TYPE grace_period_reason ADD VALUE IF NOT EXISTS 'AWAY';
@@ -0,0 +1 @@
+ALTER TABLE operator_status DROP COLUMN IF EXISTS focus_until;
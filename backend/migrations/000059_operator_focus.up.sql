@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Operator focus mode
+-- ============================================================================
+-- An AVAILABLE operator in focus keeps their conversations and stays
+-- AVAILABLE, so no grace periods start, but takes no new conversations until
+-- focus_until. Focus ends on its own once focus_until has passed; changing
+-- the status ends it early.
+
+ALTER TABLE operator_status ADD COLUMN focus_until TIMESTAMPTZ;
+
+COMMENT ON COLUMN operator_status.focus_until IS 'End of the operator''s focus mode; no new allocations before then, NULL when not in focus';
@@ -0,0 +1,27 @@
+-- ============================================================================
+-- TABLE: operator_vacations
+-- ============================================================================
+-- Operators on vacation (PUT /api/v1/operator/vacation) receive no new
+-- conversations, whatever their status. Their ALLOCATED conversations are
+-- optionally drained by the vacation drain worker: returned to the queue or
+-- handed round-robin to named colleagues, spread evenly until drain_until.
+-- drained_at is set once none are left. The row is deleted when the
+-- vacation ends.
+
+CREATE TABLE operator_vacations (
+    operator_id UUID PRIMARY KEY REFERENCES operators(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    drain VARCHAR(20) NOT NULL DEFAULT 'NONE' CHECK (drain IN ('NONE', 'QUEUE', 'COLLEAGUES')),
+    colleague_ids UUID[] NOT NULL DEFAULT '{}',
+    drain_until TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    drained_at TIMESTAMPTZ,
+    next_colleague INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_operator_vacations_draining ON operator_vacations (drain_until)
+    WHERE drain <> 'NONE' AND drained_at IS NULL;
+
+COMMENT ON TABLE operator_vacations IS 'Operators taking no new conversations, with the drain plan for their current ones';
+COMMENT ON COLUMN operator_vacations.drain_until IS 'End of the ramp-down; every conversation is drained by then';
+COMMENT ON COLUMN operator_vacations.next_colleague IS 'Round-robin position in colleague_ids for the next handed-over conversation';
@@ -0,0 +1,5 @@
+SET lock_timeout = '5s';
+
+ALTER TABLE conversation_refs
+    DROP COLUMN IF EXISTS overdue_at,
+    DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,18 @@
+-- Touches conversation_refs (see migrations/README.md)
+SET lock_timeout = '5s';
+
+-- ============================================================================
+-- Conversation due dates
+-- ============================================================================
+-- due_at is when a conversation should be resolved by: set by a manager, or
+-- at creation from the resolution target of the inbox's SLA policy. The
+-- overdue worker stamps overdue_at once due_at passes with the conversation
+-- still open, and notifies the assignee once. Both are nullable, so no
+-- backfill: existing conversations have no due date.
+
+ALTER TABLE conversation_refs
+    ADD COLUMN due_at TIMESTAMPTZ,
+    ADD COLUMN overdue_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN conversation_refs.due_at IS 'When the conversation should be resolved by; NULL for no due date';
+COMMENT ON COLUMN conversation_refs.overdue_at IS 'When the overdue worker flagged the conversation past due_at; cleared when due_at changes';
@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_due_pending
+    ON conversation_refs (due_at) WHERE due_at IS NOT NULL AND overdue_at IS NULL AND state <> 'RESOLVED';
@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_inbox_due
+    ON conversation_refs (inbox_id, due_at) WHERE due_at IS NOT NULL AND state <> 'RESOLVED';
@@ -0,0 +1,3 @@
+ALTER TABLE inboxes
+    DROP CONSTRAINT IF EXISTS chk_inboxes_auto_resolve_after,
+    DROP COLUMN IF EXISTS auto_resolve_after_seconds;
@@ -0,0 +1,12 @@
+-- ============================================================================
+-- COLUMN: inboxes.auto_resolve_after_seconds
+-- ============================================================================
+-- Open conversations of the inbox without a customer message for this long
+-- are resolved by the auto-resolve worker, recording the reason in the
+-- audit log and the conversation.resolved event. NULL disables it.
+
+ALTER TABLE inboxes
+    ADD COLUMN auto_resolve_after_seconds INTEGER,
+    ADD CONSTRAINT chk_inboxes_auto_resolve_after CHECK (auto_resolve_after_seconds > 0);
+
+COMMENT ON COLUMN inboxes.auto_resolve_after_seconds IS 'Idle time after the last message before open conversations are resolved; NULL disables auto-resolution';
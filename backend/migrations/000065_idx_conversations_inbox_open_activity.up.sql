@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_conversations_inbox_open_activity
+    ON conversation_refs (inbox_id, last_message_at) WHERE state <> 'RESOLVED';
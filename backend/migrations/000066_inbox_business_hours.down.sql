@@ -0,0 +1 @@
+ALTER TABLE inboxes DROP COLUMN IF EXISTS business_hours;
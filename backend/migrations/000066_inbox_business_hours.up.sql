@@ -0,0 +1,12 @@
+-- ============================================================================
+-- COLUMN: inboxes.business_hours
+-- ============================================================================
+-- Weekly opening hours of the inbox in its time zone, as
+-- {"timezone": "Europe/Berlin", "days": [{"day_of_week": 1,
+-- "open_seconds": 32400, "close_seconds": 61200}, ...]} with times as
+-- seconds from midnight. Time outside them does not count towards the delay
+-- component of its conversations' priority. NULL counts all time.
+
+ALTER TABLE inboxes ADD COLUMN business_hours JSONB;
+
+COMMENT ON COLUMN inboxes.business_hours IS 'Weekly opening hours in a time zone; off-hours do not count towards the priority delay. NULL counts all time';
@@ -0,0 +1,3 @@
+ALTER TABLE tenants
+    DROP CONSTRAINT IF EXISTS chk_tenants_grace_period,
+    DROP COLUMN IF EXISTS grace_period_seconds;
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- COLUMN: tenants.grace_period_seconds
+-- ============================================================================
+-- How long the conversations of an operator who goes OFFLINE stay assigned
+-- to them before returning to the queue. NULL uses the server default
+-- (GRACE_PERIOD_DURATION). Operators stepping AWAY get the shorter of this
+-- and the away grace period.
+
+ALTER TABLE tenants
+    ADD COLUMN grace_period_seconds INTEGER,
+    ADD CONSTRAINT chk_tenants_grace_period CHECK (grace_period_seconds >= 0);
+
+COMMENT ON COLUMN tenants.grace_period_seconds IS 'Grace period before an offline operator''s conversations return to the queue; NULL uses the server default';
@@ -0,0 +1,3 @@
+ALTER TABLE tenants
+    DROP CONSTRAINT IF EXISTS chk_tenants_intake_limit,
+    DROP COLUMN IF EXISTS intake_limit_per_hour;
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- COLUMN: tenants.intake_limit_per_hour
+-- ============================================================================
+-- Most conversations a customer phone number may start in one inbox within
+-- an hour. Messages that would start another are rejected at ingestion and
+-- counted in conversation_intake_throttled_total; messages on conversations
+-- that already exist are not limited. NULL disables the limit.
+
+ALTER TABLE tenants
+    ADD COLUMN intake_limit_per_hour INTEGER,
+    ADD CONSTRAINT chk_tenants_intake_limit CHECK (intake_limit_per_hour > 0);
+
+COMMENT ON COLUMN tenants.intake_limit_per_hour IS 'Most conversations a customer phone number may start per inbox per hour; NULL disables the limit';
@@ -0,0 +1,29 @@
+-- ============================================================================
+-- TABLE: operator_devices
+-- ============================================================================
+-- Mobile devices registered for push notifications of their operator's
+-- assignments (conversation.allocated and conversation.reassigned).
+-- platform: FCM (Android) or APNS (iOS)
+-- token: the push token; unique, so registering it again moves the device to
+--        the registering operator
+-- event_types: the push events the device is notified of; empty means all
+
+CREATE TABLE operator_devices (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    platform VARCHAR(8) NOT NULL,
+    token VARCHAR(4096) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_operator_devices_token UNIQUE (token),
+    CONSTRAINT chk_operator_devices_platform CHECK (platform IN ('FCM', 'APNS'))
+);
+
+-- Index for finding an operator's devices on every assignment
+CREATE INDEX idx_operator_devices_operator_id ON operator_devices(operator_id);
+
+COMMENT ON TABLE operator_devices IS 'Operator devices registered for push notifications';
+COMMENT ON COLUMN operator_devices.event_types IS 'Push events the device is notified of; empty means all';
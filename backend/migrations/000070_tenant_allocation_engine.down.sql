@@ -0,0 +1,3 @@
+ALTER TABLE tenants
+    DROP CONSTRAINT IF EXISTS chk_tenants_allocation_engine,
+    DROP COLUMN IF EXISTS allocation_engine;
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- COLUMN: tenants.allocation_engine
+-- ============================================================================
+-- Version of the automatic allocation logic the tenant is pinned to, so that
+-- a change of allocation behavior can be rolled out to selected tenants
+-- first. v1 allocates in priority order; v2 allocates conversations that
+-- breached an SLA target or their due date first. Allocation metrics are
+-- labeled by engine so the canary tenants can be compared with the rest.
+
+ALTER TABLE tenants
+    ADD COLUMN allocation_engine TEXT NOT NULL DEFAULT 'v1',
+    ADD CONSTRAINT chk_tenants_allocation_engine CHECK (allocation_engine IN ('v1', 'v2'));
+
+COMMENT ON COLUMN tenants.allocation_engine IS 'Allocation logic version the tenant is pinned to (v1 or v2)';
@@ -0,0 +1,11 @@
+DELETE FROM idempotency_keys WHERE status = 'PENDING';
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS chk_idempotency_keys_response,
+    DROP CONSTRAINT IF EXISTS chk_idempotency_keys_status,
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS status,
+    ADD CONSTRAINT chk_idempotency_keys_response CHECK (
+        (response_body IS NULL) = (response_ciphertext IS NOT NULL)
+        AND (response_ciphertext IS NULL) = (encryption_key_id IS NULL)
+    );
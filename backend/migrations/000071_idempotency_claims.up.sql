@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Idempotency key claims
+-- ============================================================================
+-- A keyed request claims its key before it runs: a PENDING row is inserted
+-- (or takes over an expired key or a claim whose holder stopped before
+-- locked_until) and the response is stored on it when the request completes.
+-- A concurrent duplicate finds the claim, so only one of them executes and
+-- the other replays the stored response. A PENDING row has no response yet.
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'COMPLETED',
+    ADD COLUMN locked_until TIMESTAMPTZ,
+    ADD CONSTRAINT chk_idempotency_keys_status CHECK (status IN ('PENDING', 'COMPLETED')),
+    DROP CONSTRAINT chk_idempotency_keys_response,
+    ADD CONSTRAINT chk_idempotency_keys_response CHECK (
+        (status = 'PENDING' OR (response_body IS NULL) = (response_ciphertext IS NOT NULL))
+        AND (response_ciphertext IS NULL) = (encryption_key_id IS NULL)
+    );
+
+COMMENT ON COLUMN idempotency_keys.status IS 'PENDING while the claiming request runs, COMPLETED once its response is stored';
+COMMENT ON COLUMN idempotency_keys.locked_until IS 'When a PENDING claim may be taken over; NULL once COMPLETED';
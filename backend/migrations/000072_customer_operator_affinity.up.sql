@@ -0,0 +1,21 @@
+-- ============================================================================
+-- TABLE: customer_operator_affinity
+-- ============================================================================
+-- The operator who last resolved a conversation of the customer. Allocation
+-- offers an AVAILABLE operator the queued conversations of the customers
+-- they handled within ALLOCATION_AFFINITY_WINDOW before the rest of the
+-- queue, so returning customers are routed back to a familiar operator.
+-- handled_at: when the operator resolved the customer's last conversation
+
+CREATE TABLE customer_operator_affinity (
+    customer_id UUID PRIMARY KEY REFERENCES customers(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    handled_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for finding the customers an operator handled recently on allocate
+CREATE INDEX idx_customer_operator_affinity_operator ON customer_operator_affinity(operator_id, handled_at DESC);
+
+COMMENT ON TABLE customer_operator_affinity IS 'Operator who last resolved a conversation of the customer, preferred on allocation';
+COMMENT ON COLUMN customer_operator_affinity.handled_at IS 'When the operator resolved the customer''s last conversation';
@@ -0,0 +1,39 @@
+-- ============================================================================
+-- TABLE: conversation_transfers
+-- ============================================================================
+-- Proposals by the assigned operator to hand a conversation to a colleague.
+-- The recipient accepts (the conversation is reassigned to them) or declines
+-- before expires_at; the transfer expiry worker ends the unanswered ones.
+-- status: PENDING until answered, then ACCEPTED, DECLINED, EXPIRED, or
+--         CANCELLED when the conversation left the proposer in the meantime
+-- note: handed to the recipient as a HANDOVER note on acceptance
+
+CREATE TABLE conversation_transfers (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    from_operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    to_operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    note TEXT,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_conversation_transfers_status CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED', 'CANCELLED')),
+    CONSTRAINT chk_conversation_transfers_operators CHECK (from_operator_id <> to_operator_id)
+);
+
+-- At most one pending transfer per conversation
+CREATE UNIQUE INDEX uq_conversation_transfers_pending ON conversation_transfers(conversation_id) WHERE status = 'PENDING';
+
+-- Index for the expiry worker
+CREATE INDEX idx_conversation_transfers_expiry ON conversation_transfers(expires_at) WHERE status = 'PENDING';
+
+-- Indexes for listing an operator's pending transfers
+CREATE INDEX idx_conversation_transfers_to ON conversation_transfers(to_operator_id, created_at) WHERE status = 'PENDING';
+CREATE INDEX idx_conversation_transfers_from ON conversation_transfers(from_operator_id, created_at) WHERE status = 'PENDING';
+
+COMMENT ON TABLE conversation_transfers IS 'Conversation handoffs proposed by the assignee, accepted or declined by the recipient';
+COMMENT ON COLUMN conversation_transfers.status IS 'PENDING, ACCEPTED, DECLINED, EXPIRED or CANCELLED';
+COMMENT ON COLUMN conversation_transfers.note IS 'Handed to the recipient as a HANDOVER note on acceptance';
@@ -0,0 +1,2 @@
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS sandbox;
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- COLUMN: tenants.sandbox
+-- ============================================================================
+-- Sandbox tenants let prospects trial the product without real data. Turning
+-- sandbox mode on seeds demo inboxes and conversations, and an admin can
+-- reset the tenant to a fresh copy of the demo data at any time. Webhook
+-- deliveries of sandbox tenants to external URLs are blocked and API
+-- responses carry an X-Sandbox header.
+
+ALTER TABLE tenants
+    ADD COLUMN sandbox BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN tenants.sandbox IS 'Trial tenant holding demo data only; external webhook deliveries are blocked';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_labels_parent;
+
+ALTER TABLE labels
+    DROP COLUMN IF EXISTS parent_label_id;
@@ -0,0 +1,16 @@
+-- ============================================================================
+-- COLUMN: labels.parent_label_id
+-- ============================================================================
+-- Labels form trees within an inbox for large taxonomies, e.g. "billing" with
+-- "refunds" and "invoices" below it. Filtering conversations or allocations
+-- by a label matches conversations tagged with the label or any label below
+-- it. The service keeps parents in the label's inbox and rejects cycles.
+-- Deleting a label makes its children top-level labels.
+
+ALTER TABLE labels
+    ADD COLUMN parent_label_id UUID REFERENCES labels(id) ON DELETE SET NULL;
+
+-- Index for walking down the tree
+CREATE INDEX idx_labels_parent ON labels(parent_label_id) WHERE parent_label_id IS NOT NULL;
+
+COMMENT ON COLUMN labels.parent_label_id IS 'Parent label in the same inbox; NULL for a top-level label';
@@ -0,0 +1,11 @@
+-- Tenant-scoped labels have no inbox to fall back to
+DELETE FROM labels WHERE scope = 'TENANT';
+
+DROP INDEX IF EXISTS idx_labels_tenant_name;
+DROP INDEX IF EXISTS idx_labels_inbox_name;
+CREATE UNIQUE INDEX idx_labels_inbox_name ON labels(inbox_id, name);
+
+ALTER TABLE labels
+    DROP CONSTRAINT IF EXISTS labels_scope_check,
+    ALTER COLUMN inbox_id SET NOT NULL,
+    DROP COLUMN IF EXISTS scope;
@@ -0,0 +1,22 @@
+-- ============================================================================
+-- COLUMN: labels.scope
+-- ============================================================================
+-- INBOX labels belong to one inbox, as before. TENANT labels have no inbox and
+-- can be attached to conversations of every inbox of the tenant, so a shared
+-- taxonomy is not duplicated per inbox and survives moving a conversation to
+-- another inbox. Names are unique within an inbox, and among the tenant's
+-- TENANT labels.
+
+ALTER TABLE labels
+    ADD COLUMN scope VARCHAR(10) NOT NULL DEFAULT 'INBOX',
+    ALTER COLUMN inbox_id DROP NOT NULL,
+    ADD CONSTRAINT labels_scope_check CHECK (
+        (scope = 'INBOX' AND inbox_id IS NOT NULL) OR
+        (scope = 'TENANT' AND inbox_id IS NULL)
+    );
+
+DROP INDEX IF EXISTS idx_labels_inbox_name;
+CREATE UNIQUE INDEX idx_labels_inbox_name ON labels(inbox_id, name) WHERE scope = 'INBOX';
+CREATE UNIQUE INDEX idx_labels_tenant_name ON labels(tenant_id, name) WHERE scope = 'TENANT';
+
+COMMENT ON COLUMN labels.scope IS 'INBOX: label of inbox_id; TENANT: label of every inbox of the tenant, inbox_id NULL';
@@ -0,0 +1,18 @@
+-- Enum values cannot be dropped: CONVERSATION_CREATED stays in the type, but
+-- no rule uses it anymore
+
+DELETE FROM routing_rules
+WHERE condition_field IN ('customer_phone', 'metadata')
+   OR trigger = 'CONVERSATION_CREATED';
+
+ALTER TABLE routing_rules DROP CONSTRAINT IF EXISTS chk_routing_rules_condition_text;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_condition_text
+    CHECK (condition_field NOT IN ('category', 'language') OR (condition_text IS NOT NULL AND condition_operator = 'eq'));
+
+ALTER TABLE routing_rules DROP CONSTRAINT IF EXISTS chk_routing_rules_operator;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_operator
+    CHECK (condition_operator IN ('gt', 'gte', 'lt', 'lte', 'eq'));
@@ -0,0 +1,28 @@
+-- ============================================================================
+-- Routing rule conditions on the customer phone and message metadata
+-- ============================================================================
+-- customer_phone rules match the customer's phone number (eq) or its start
+-- (prefix, e.g. a country code). metadata rules match when a value of the
+-- external metadata sent with an ingested message contains the keyword
+-- (contains, case-insensitive). CONVERSATION_CREATED rules run once, for the
+-- message that starts a conversation. The new trigger value is not used in
+-- this migration, so adding it inside its transaction is safe.
+
+ALTER TYPE routing_rule_trigger ADD VALUE IF NOT EXISTS 'CONVERSATION_CREATED';
+
+ALTER TABLE routing_rules DROP CONSTRAINT chk_routing_rules_operator;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_operator
+    CHECK (condition_operator IN ('gt', 'gte', 'lt', 'lte', 'eq', 'prefix', 'contains'));
+
+ALTER TABLE routing_rules DROP CONSTRAINT chk_routing_rules_condition_text;
+
+ALTER TABLE routing_rules
+    ADD CONSTRAINT chk_routing_rules_condition_text
+    CHECK (
+        (condition_field = 'message_count' AND condition_operator NOT IN ('prefix', 'contains'))
+        OR (condition_field IN ('category', 'language') AND condition_text IS NOT NULL AND condition_operator = 'eq')
+        OR (condition_field = 'customer_phone' AND condition_text IS NOT NULL AND condition_operator IN ('eq', 'prefix'))
+        OR (condition_field = 'metadata' AND condition_text IS NOT NULL AND condition_operator = 'contains')
+    );
@@ -0,0 +1,7 @@
+-- Enum values cannot be dropped: STALE grace periods become MANUAL ones and
+-- the value stays in the type unused
+
+UPDATE grace_period_assignments SET reason = 'MANUAL' WHERE reason = 'STALE';
+
+DROP TABLE IF EXISTS conversation_activity;
+DROP TABLE IF EXISTS inbox_staleness_policies;
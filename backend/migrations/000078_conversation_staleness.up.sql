@@ -0,0 +1,57 @@
+-- ============================================================================
+-- TABLE: inbox_staleness_policies
+-- ============================================================================
+-- Per-inbox limit on how long an ALLOCATED conversation may go without
+-- activity of its operator (a reply or note reported through the activity
+-- endpoint, an acknowledgement, marking it read). The stale allocation worker
+-- flags such conversations once and notifies the operator; with
+-- deallocate_grace_seconds it also starts a grace period (reason STALE) that
+-- returns the conversation to the queue unless the operator becomes active.
+
+CREATE TABLE inbox_staleness_policies (
+    inbox_id UUID PRIMARY KEY REFERENCES inboxes(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    stale_after_seconds INTEGER NOT NULL,
+    deallocate_grace_seconds INTEGER,
+    updated_by UUID REFERENCES operators(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_inbox_staleness_policies_stale_after CHECK (stale_after_seconds > 0),
+    CONSTRAINT chk_inbox_staleness_policies_grace CHECK (deallocate_grace_seconds > 0)
+);
+
+COMMENT ON TABLE inbox_staleness_policies IS 'Per-inbox limit on operator inactivity for allocated conversations';
+COMMENT ON COLUMN inbox_staleness_policies.deallocate_grace_seconds IS 'Grace period before a stale conversation returns to the queue; NULL only flags it';
+
+-- ============================================================================
+-- TABLE: conversation_activity
+-- ============================================================================
+-- Last activity of the assigned operator on an ALLOCATED conversation. Kept
+-- apart from conversation_refs so heartbeats do not write to the hot table.
+-- The worker starts the clock for allocations it has not seen (or that moved
+-- to another operator) and removes rows of conversations no longer
+-- allocated. stale_at is set when the conversation is flagged and cleared by
+-- the next activity.
+
+CREATE TABLE conversation_activity (
+    conversation_id UUID PRIMARY KEY REFERENCES conversation_refs(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    last_activity_at TIMESTAMPTZ NOT NULL,
+    last_activity_kind VARCHAR(10) NOT NULL,
+    stale_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_conversation_activity_kind CHECK (last_activity_kind IN ('ALLOCATED', 'MESSAGE', 'NOTE', 'ACK', 'READ'))
+);
+
+CREATE INDEX idx_conversation_activity_stale ON conversation_activity(stale_at)
+    WHERE stale_at IS NOT NULL;
+
+COMMENT ON TABLE conversation_activity IS 'Last operator activity on allocated conversations, for stale allocation detection';
+
+-- ============================================================================
+-- ENUM VALUE: grace_period_reason STALE
+-- ============================================================================
+-- Not used in this migration, so adding it inside its transaction is safe.
+
+ALTER TYPE grace_period_reason ADD VALUE IF NOT EXISTS 'STALE';